@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/config"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/cmd/kcp-controller-manager/options"
+	"github.com/kcp-dev/kcp/pkg/controllermanager"
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+)
+
+func NewCommand(ctx context.Context, errout io.Writer) *cobra.Command {
+	opts := options.NewOptions()
+
+	// Default to -v=2
+	opts.Logs.Config.Verbosity = config.VerbosityLevel(2)
+
+	cmd := &cobra.Command{
+		Use:   "kcp-controller-manager",
+		Short: "Run kcp controllers out-of-process",
+		Long: `Run controller groups of a kcp shard in a separate process, such that the latency
+of the apiserver is isolated from CPU spikes of the controllers, and the controllers can be
+scaled and restarted independently. The shard must be started with the same groups in
+--external-controller-groups, and writes controller-manager.kubeconfig with its loopback
+credentials to its root directory.`,
+
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := opts.Logs.ValidateAndApply(kcpfeatures.DefaultFeatureGate); err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return Run(ctx, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Run runs the controllers of the selected groups until the context is done.
+func Run(ctx context.Context, o *options.Options) error {
+	logger := klog.FromContext(ctx).WithValues("component", "kcp-controller-manager")
+	ctx = klog.NewContext(ctx, logger)
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: o.KubeconfigFile},
+		&clientcmd.ConfigOverrides{CurrentContext: o.Context},
+	).ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if o.ProfilerAddress != "" {
+		//nolint:errcheck,gosec
+		go http.ListenAndServe(o.ProfilerAddress, nil)
+	}
+
+	m, err := controllermanager.New(config, o.Groups)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("starting kcp-controller-manager", "groups", o.Groups)
+	return m.Run(ctx)
+}
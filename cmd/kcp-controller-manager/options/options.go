@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/component-base/logs"
+
+	"github.com/kcp-dev/kcp/pkg/controllermanager"
+)
+
+type Options struct {
+	KubeconfigFile  string
+	Context         string
+	Groups          []string
+	ProfilerAddress string
+
+	Logs logs.Options
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Logs: *logs.NewOptions(),
+	}
+}
+
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	o.Logs.AddFlags(flags)
+
+	flags.StringVar(&o.KubeconfigFile, "kubeconfig", o.KubeconfigFile,
+		"The kubeconfig file of the kcp shard, e.g. the controller-manager.kubeconfig in the root directory of the shard.")
+	_ = cobra.MarkFlagRequired(flags, "kubeconfig")
+
+	flags.StringVar(&o.Context, "context", o.Context, "Name of the context in the kubeconfig file to use")
+	flags.StringSliceVar(&o.Groups, "controller-groups", o.Groups,
+		fmt.Sprintf("The controller groups to run. They must be passed to --external-controller-groups of the shard. Supported groups: %s", strings.Join(controllermanager.Groups(), ", ")))
+	flags.StringVar(&o.ProfilerAddress, "profiler-address", "", "[Address]:port to bind the profiler to")
+}
+
+func (o *Options) Validate() error {
+	errs := []error{}
+
+	if len(o.KubeconfigFile) == 0 {
+		errs = append(errs, fmt.Errorf("--kubeconfig is required for this command"))
+	}
+	if len(o.Groups) == 0 {
+		errs = append(errs, fmt.Errorf("--controller-groups is required for this command"))
+	}
+	if err := controllermanager.ValidateGroups(o.Groups); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
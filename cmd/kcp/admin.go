@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/version"
+
+	systemcrds "github.com/kcp-dev/kcp/config/system-crds"
+	"github.com/kcp-dev/kcp/pkg/cmd/help"
+	"github.com/kcp-dev/kcp/pkg/preflight"
+)
+
+// newAdminCommand returns the "kcp admin" command with tools to operate a kcp installation.
+func newAdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Tools to operate a kcp installation",
+	}
+	cmd.AddCommand(newPreflightCommand())
+	return cmd
+}
+
+func newPreflightCommand() *cobra.Command {
+	var (
+		kubeconfig            string
+		kubeContext           string
+		cacheServerKubeconfig string
+		output                string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check whether a kcp installation can be upgraded to the version of this binary",
+		Long: help.Doc(`
+			Check whether a kcp installation can be upgraded to the version of this binary
+
+			Run the command with the binary of the new version before upgrading. It checks
+			the versions of the shards and of the cache server, whether the system CRDs of
+			the shards are stored in versions served by the new version, whether APIExport
+			migrations are in progress, and whether deprecated APIs are in use. It prints
+			a go/no-go report and fails if the upgrade must not proceed.
+
+			The kubeconfig must be an admin kubeconfig of the root shard. Its credentials are
+			used to talk to all shards directly.
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output format %q, must be text or json", output)
+			}
+
+			config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+				&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+				&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+			).ClientConfig()
+			if err != nil {
+				return err
+			}
+			var cacheServerConfig *rest.Config
+			if cacheServerKubeconfig != "" {
+				cacheServerConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+					&clientcmd.ClientConfigLoadingRules{ExplicitPath: cacheServerKubeconfig},
+					&clientcmd.ConfigOverrides{},
+				).ClientConfig()
+				if err != nil {
+					return err
+				}
+			}
+
+			crds, err := systemcrds.CRDs()
+			if err != nil {
+				return err
+			}
+			checker, err := preflight.NewChecker(config, cacheServerConfig, version.Get().GitVersion, crds)
+			if err != nil {
+				return err
+			}
+
+			report := checker.Run(cmd.Context())
+			if output == "json" {
+				err = report.PrintJSON(cmd.OutOrStdout())
+			} else {
+				err = report.Print(cmd.OutOrStdout())
+			}
+			if err != nil {
+				return err
+			}
+			if !report.Go() {
+				return errors.New("preflight checks failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "The admin kubeconfig of the root shard, e.g. .kcp/admin.kubeconfig.")
+	_ = cmd.MarkFlagRequired("kubeconfig")
+	cmd.Flags().StringVar(&kubeContext, "context", "", "Name of the context in the kubeconfig file to use")
+	cmd.Flags().StringVar(&cacheServerKubeconfig, "cache-server-kubeconfig", "", "The kubeconfig of the cache server. If unset, the cache server is not checked.")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format, text or json")
+
+	return cmd
+}
@@ -80,6 +80,7 @@ func main() {
 
 	serverOptions := options.NewOptions(rootDir)
 	serverOptions.GenericControlPlane.Logs.Config.Verbosity = config.VerbosityLevel(2)
+	namedStartFlagSets := serverOptions.Flags()
 
 	startCmd := &cobra.Command{
 		Use:   "start",
@@ -100,6 +101,14 @@ func main() {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// apply the configuration file before anything reads the options
+			if err := serverOptions.ApplyConfigFile(namedStartFlagSets); err != nil {
+				return err
+			}
+
+			// spec.configuration of the Shard takes precedence, and must set feature gates before they are read
+			server.ApplyShardConfiguration(cmd.Context(), serverOptions, namedStartFlagSets)
+
 			// run as early as possible to avoid races later when some components (e.g. grpc) start early using klog
 			if err := serverOptions.GenericControlPlane.Logs.ValidateAndApply(kcpfeatures.DefaultFeatureGate); err != nil {
 				return err
@@ -145,7 +154,6 @@ func main() {
 	}
 
 	// add start named flag sets to start flags
-	namedStartFlagSets := serverOptions.Flags()
 	globalflag.AddGlobalFlags(namedStartFlagSets.FlagSet("global"), cmd.Name(), logs.SkipLoggingConfigurationFlags())
 	startFlags := startCmd.Flags()
 	for _, f := range namedStartFlagSets.FlagSets {
@@ -174,6 +182,7 @@ func main() {
 	}
 	startCmd.AddCommand(startOptionsCmd)
 	cmd.AddCommand(startCmd)
+	cmd.AddCommand(newAdminCommand())
 
 	setPartialUsageAndHelpFunc(startCmd, namedStartFlagSets, cols, []string{
 		"etcd-servers",
@@ -27,9 +27,11 @@ import (
 	"k8s.io/component-base/version"
 	"k8s.io/klog/v2"
 
+	apiexportcmd "github.com/kcp-dev/kcp/pkg/cliplugins/apiexport/cmd"
 	bindcmd "github.com/kcp-dev/kcp/pkg/cliplugins/bind/cmd"
 	claimscmd "github.com/kcp-dev/kcp/pkg/cliplugins/claims/cmd"
 	crdcmd "github.com/kcp-dev/kcp/pkg/cliplugins/crd/cmd"
+	debugcmd "github.com/kcp-dev/kcp/pkg/cliplugins/debug/cmd"
 	workloadcmd "github.com/kcp-dev/kcp/pkg/cliplugins/workload/cmd"
 	workspacecmd "github.com/kcp-dev/kcp/pkg/cliplugins/workspace/cmd"
 	"github.com/kcp-dev/kcp/pkg/cmd/help"
@@ -88,5 +90,11 @@ func KubectlKcpCommand() *cobra.Command {
 	claimsCmd := claimscmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
 	root.AddCommand(claimsCmd)
 
+	apiexportCmd := apiexportcmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(apiexportCmd)
+
+	debugCmd := debugcmd.New(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	root.AddCommand(debugCmd)
+
 	return root
 }
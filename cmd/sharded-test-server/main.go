@@ -172,7 +172,7 @@ func start(proxyFlags, shardFlags []string, logDirPath, workDirPath string, numb
 
 	standaloneVW := sets.NewString(shardFlags...).Has("--run-virtual-workspaces=false")
 	if standaloneVW {
-		shardFlags = append(shardFlags, fmt.Sprintf("--shard-virtual-workspace-url=https://%s:7444", hostIP))
+		shardFlags = append(shardFlags, fmt.Sprintf("--shard-virtual-workspace-url=https://%s", net.JoinHostPort(hostIP.String(), "7444")))
 	}
 
 	cacheServerErrCh := make(chan indexErrTuple)
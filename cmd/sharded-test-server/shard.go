@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"path/filepath"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -80,7 +81,7 @@ func newShard(ctx context.Context, n int, args []string, servingCA *crypto.CA, h
 		fmt.Sprintf("--service-account-key-file=%s", filepath.Join(workDirPath, ".kcp/service-account.crt")),
 		fmt.Sprintf("--service-account-private-key-file=%s", filepath.Join(workDirPath, ".kcp/service-account.key")),
 		"--audit-log-path", auditFilePath,
-		fmt.Sprintf("--shard-external-url=https://%s:%d", hostIP, 6443),
+		fmt.Sprintf("--shard-external-url=https://%s", net.JoinHostPort(hostIP, "6443")),
 		fmt.Sprintf("--tls-cert-file=%s", filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d/apiserver.crt", n))),
 		fmt.Sprintf("--tls-private-key-file=%s", filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d/apiserver.key", n))),
 		fmt.Sprintf("--secure-port=%d", 6444+n),
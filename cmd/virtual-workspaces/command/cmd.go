@@ -35,16 +35,19 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/pkg/version"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/component-base/config"
 	"k8s.io/klog/v2"
 
 	"github.com/kcp-dev/kcp/cmd/virtual-workspaces/options"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
 	"github.com/kcp-dev/kcp/pkg/server/bootstrap"
 	virtualrootapiserver "github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 func NewCommand(ctx context.Context, errout io.Writer) *cobra.Command {
@@ -119,13 +122,29 @@ func Run(ctx context.Context, o *options.Options) error {
 	}
 	wildcardKcpInformers := kcpinformers.NewSharedInformerFactory(kcpClusterClient, 10*time.Minute)
 
+	cacheClientConfig := rest.CopyConfig(nonIdentityConfig)
+	if len(o.CacheKubeconfigFile) > 0 {
+		cacheClientConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&clientcmd.ClientConfigLoadingRules{ExplicitPath: o.CacheKubeconfigFile}, nil).ClientConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load the kubeconfig from: %s, for a cache client, err: %w", o.CacheKubeconfigFile, err)
+		}
+	}
+	rt := cacheclient.WithCacheServiceRoundTripper(cacheClientConfig)
+	rt = cacheclient.WithShardNameFromContextRoundTripper(rt)
+	rt = cacheclient.WithDefaultShardRoundTripper(rt, shard.Wildcard)
+	cacheKcpClusterClient, err := kcpclientset.NewForConfig(rt)
+	if err != nil {
+		return err
+	}
+	cachedKcpInformers := kcpinformers.NewSharedInformerFactory(cacheKcpClusterClient, 10*time.Minute)
+
 	if o.ProfilerAddress != "" {
 		//nolint:errcheck,gosec
 		go http.ListenAndServe(o.ProfilerAddress, nil)
 	}
 
 	// create apiserver
-	virtualWorkspaces, err := o.VirtualWorkspaces.NewVirtualWorkspaces(identityConfig, o.RootPathPrefix, wildcardKubeInformers, wildcardKcpInformers)
+	virtualWorkspaces, err := o.VirtualWorkspaces.NewVirtualWorkspaces(identityConfig, o.RootPathPrefix, wildcardKubeInformers, wildcardKcpInformers, cachedKcpInformers)
 	if err != nil {
 		return err
 	}
@@ -148,6 +167,7 @@ func Run(ctx context.Context, o *options.Options) error {
 	rootAPIServerConfig, err := virtualrootapiserver.NewRootAPIConfig(recommendedConfig, []virtualrootapiserver.InformerStart{
 		wildcardKubeInformers.Start,
 		wildcardKcpInformers.Start,
+		cachedKcpInformers.Start,
 	}, virtualWorkspaces)
 	if err != nil {
 		return err
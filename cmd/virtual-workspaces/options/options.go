@@ -40,9 +40,10 @@ const DefaultRootPathPrefix string = "/services"
 type Options struct {
 	Output io.Writer
 
-	KubeconfigFile string
-	Context        string
-	RootPathPrefix string
+	KubeconfigFile      string
+	CacheKubeconfigFile string
+	Context             string
+	RootPathPrefix      string
 
 	SecureServing  genericapiserveroptions.SecureServingOptions
 	Authentication genericapiserveroptions.DelegatingAuthenticationOptions
@@ -88,6 +89,8 @@ func (o *Options) AddFlags(flags *pflag.FlagSet) {
 		"The kubeconfig file of the KCP instance that hosts workspaces.")
 	_ = cobra.MarkFlagRequired(flags, "kubeconfig")
 
+	flags.StringVar(&o.CacheKubeconfigFile, "cache-kubeconfig", o.CacheKubeconfigFile,
+		"The kubeconfig file of the cache server. If empty, the cache server is reached through the KCP instance.")
 	flags.StringVar(&o.Context, "context", o.Context, "Name of the context in the kubeconfig file to use")
 	flags.StringVar(&o.ProfilerAddress, "profiler-address", "", "[Address]:port to bind the profiler to")
 }
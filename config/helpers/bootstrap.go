@@ -43,11 +43,11 @@ import (
 	"k8s.io/client-go/restmapper"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	tenancyhelper "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1/helper"
-	kcpclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyhelper "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1/helper"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
 )
 
 // TransformFileFunc transforms a resource file before being applied to the cluster.
@@ -249,7 +249,7 @@ func BindRootAPIs(ctx context.Context, kcpClient kcpclient.Interface, exportName
 			Spec: apisv1alpha1.APIBindingSpec{
 				Reference: apisv1alpha1.BindingReference{
 					Export: &apisv1alpha1.ExportBindingReference{
-						Path: core.RootCluster.Path().String(),
+						Path: apisv1alpha1.NewLogicalClusterPath(core.RootCluster.Path()),
 						Name: exportName,
 					},
 				},
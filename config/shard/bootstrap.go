@@ -27,7 +27,7 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	confighelpers "github.com/kcp-dev/kcp/config/helpers"
-	kcpclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
 )
 
 //go:embed *.yaml
@@ -37,7 +37,10 @@ var fs embed.FS
 var SystemShardCluster = logicalcluster.Name("system:shard")
 
 // Bootstrap creates resources required for a shard.
-// As of today creating API bindings for the root APIs and the default ns is enough.
+// As of today creating API bindings for the root APIs, the default ns and the API Priority
+// and Fairness configuration is enough. The latter guarantees kcp's own components a priority
+// level separate from tenants, and can be tuned by editing the FlowSchemas and
+// PriorityLevelConfigurations in the system:shard logical cluster.
 func Bootstrap(ctx context.Context, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, batteriesIncluded sets.String, kcpClient kcpclient.Interface) error {
 	// note: shards are not really needed. But to avoid breaking the kcp shared informer factory, we also add them.
 	if err := confighelpers.BindRootAPIs(ctx, kcpClient, "shards.core.kcp.io", "tenancy.kcp.io", "scheduling.kcp.io", "workload.kcp.io", "apiresource.kcp.io", "topology.kcp.io"); err != nil {
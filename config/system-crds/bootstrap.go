@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -32,33 +33,46 @@ import (
 
 	configcrds "github.com/kcp-dev/kcp/config/crds"
 	confighelpers "github.com/kcp-dev/kcp/config/helpers"
-	"github.com/kcp-dev/kcp/pkg/apis/apis"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 )
 
 //go:embed *.yaml
 var fs embed.FS
 
+// GroupResources is the full list of CRDs that kcp owns and manages in the system:system-crds logical cluster.
+// Our custom CRD lister currently has a hard-coded list of which system CRDs are made available to which
+// workspaces. See pkg/server/apiextensions.go newSystemCRDProvider for the list. These CRDs should never be
+// installed in any other logical cluster.
+// TODO(sttts): get rid of this and enforce/support schema evolution while allowing wildcard informers to work
+var GroupResources = []metav1.GroupResource{
+	{Group: apis.GroupName, Resource: "apiexports"},
+	{Group: apis.GroupName, Resource: "apibindings"},
+	{Group: apis.GroupName, Resource: "apiresourceschemas"},
+	{Group: apis.GroupName, Resource: "apiexportendpointslices"},
+	{Group: core.GroupName, Resource: "logicalclusters"},
+}
+
+// CRDs returns the system CRDs of this version of kcp.
+func CRDs() ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(GroupResources))
+	for _, gr := range GroupResources {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := configcrds.Unmarshal(fmt.Sprintf("%s_%s.yaml", gr.Group, gr.Resource), crd); err != nil {
+			return nil, fmt.Errorf("could not read CRD %s: %w", gr.String(), err)
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
 // Bootstrap creates CRDs and the resources in this package by continuously retrying the list.
 // This is blocking, i.e. it only returns (with error) when the context is closed or with nil when
 // the bootstrapping is successfully completed.
 func Bootstrap(ctx context.Context, crdClient apiextensionsclient.Interface, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, batteriesIncluded sets.String) error {
 	logger := klog.FromContext(ctx)
-	// This is the full list of CRDs that kcp owns and manages in the system:system-crds logical cluster. Our custom CRD
-	// lister currently has a hard-coded list of which system CRDs are made available to which workspaces. See
-	// pkg/server/apiextensions.go newSystemCRDProvider for the list. These CRDs should never be installed in any other
-	// logical cluster.
-	// TODO(sttts): get rid of this and enforce/support schema evolution while allowing wildcard informers to work
-	crds := []metav1.GroupResource{
-		{Group: apis.GroupName, Resource: "apiexports"},
-		{Group: apis.GroupName, Resource: "apibindings"},
-		{Group: apis.GroupName, Resource: "apiresourceschemas"},
-		{Group: apis.GroupName, Resource: "apiexportendpointslices"},
-		{Group: core.GroupName, Resource: "logicalclusters"},
-	}
-
 	if err := wait.PollImmediateInfiniteWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
-		if err := configcrds.Create(ctx, crdClient.ApiextensionsV1().CustomResourceDefinitions(), crds...); err != nil {
+		if err := configcrds.Create(ctx, crdClient.ApiextensionsV1().CustomResourceDefinitions(), GroupResources...); err != nil {
 			logger.Error(err, "failed to bootstrap system CRDs, retrying")
 			return false, nil // keep retrying
 		}
@@ -25,7 +25,7 @@ if [[ -z "${MAKELEVEL:-}" ]]; then
 fi
 
 # Update generated CRD YAML
-cd pkg/apis
+cd sdk/apis
 ../../${CONTROLLER_GEN} \
     crd \
     rbac:roleName=manager-role \
@@ -22,7 +22,7 @@ set -o nounset
 set -o pipefail
 
 "$( dirname "${BASH_SOURCE[0]}")/update-codegen-clients.sh"
-if ! git diff --quiet --exit-code -- pkg/client; then
+if ! git diff --quiet --exit-code -- sdk/client; then
 	cat << EOF
 ERROR: This check enforces that the client code is generated correctly.
 ERROR: The client code is out of date. Run the following command to re-
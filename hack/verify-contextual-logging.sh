@@ -30,8 +30,8 @@ set +o errexit
 ${LOGCHECK} ${LOGCHECK_ARGS} ./... > "${work_file}" 2>&1
 set -o errexit
 
-# pkg/apis is a separate module, so check that in addition to our root packages
-cd "${REPO_ROOT}"/pkg/apis
+# sdk is a separate module, so check that in addition to our root packages
+cd "${REPO_ROOT}"/sdk
 set +o errexit
 ${LOGCHECK} ${LOGCHECK_ARGS} ./... >> "${work_file}" 2>&1
 set -o errexit
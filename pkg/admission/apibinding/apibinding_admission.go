@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"time"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -38,13 +39,17 @@ import (
 	"k8s.io/klog/v2"
 
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1/permissionclaims"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1/permissionclaims"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -61,6 +66,13 @@ func Register(plugins *admission.Plugins) {
 			p.getAPIExport = func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 				return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), p.apiExportIndexer, path, name)
 			}
+			p.getWorkspaceType = func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+				return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), p.workspaceTypeIndexer, path, name)
+			}
+			p.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				return p.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+			}
+			p.transitiveTypeResolver = workspacetypeexists.NewTransitiveTypeResolver(p.getWorkspaceType)
 
 			return p, nil
 		})
@@ -69,10 +81,15 @@ func Register(plugins *admission.Plugins) {
 type apiBindingAdmission struct {
 	*admission.Handler
 
-	getAPIExport func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getAPIExport           func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getWorkspaceType       func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	getLogicalCluster      func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	transitiveTypeResolver workspacetypeexists.TransitiveTypeResolver
 
-	apiExportLister  apisv1alpha1listers.APIExportClusterLister
-	apiExportIndexer cache.Indexer
+	apiExportLister      apisv1alpha1listers.APIExportClusterLister
+	apiExportIndexer     cache.Indexer
+	workspaceTypeIndexer cache.Indexer
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
 
 	deepSARClient    kcpkubernetesclientset.ClusterInterface
 	createAuthorizer delegated.DelegatedAuthorizerFactory
@@ -111,6 +128,9 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 		return nil
 	}
 
+	// normalize the path such that it matches the canonical form of the referenced logical cluster
+	NormalizeExportBindingReference(apiBinding.Spec.Reference.Export)
+
 	var oldAPIBinding *apisv1alpha1.APIBinding
 	if a.GetOperation() == admission.Update {
 		u, ok := a.GetOldObject().(*unstructured.Unstructured)
@@ -135,17 +155,17 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 			action = "update"
 		}
 		forbidden := admission.NewForbidden(a, fmt.Errorf("unable to %s APIBinding: no permission to bind to export %s", action,
-			logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path).Join(apiBinding.Spec.Reference.Export.Name).String()))
+			apiBinding.Spec.Reference.Export.Path.Path().Join(apiBinding.Spec.Reference.Export.Name).String()))
 
 		// get cluster name of export
 		var exportClusterName logicalcluster.Name
 		if apiBinding.Spec.Reference.Export.Path == "" {
 			exportClusterName = clusterName
-		} else if apiBinding.Spec.Reference.Export.Path == core.RootCluster.String() {
+		} else if apiBinding.Spec.Reference.Export.Path.Path() == core.RootCluster.Path() {
 			// special case to allow bootstrapping
 			exportClusterName = core.RootCluster
 		} else {
-			path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+			path := apiBinding.Spec.Reference.Export.Path.Path()
 			export, err := o.getAPIExport(path, apiBinding.Spec.Reference.Export.Name)
 			if err != nil {
 				return forbidden
@@ -163,6 +183,12 @@ func (o *apiBindingAdmission) Admit(ctx context.Context, a admission.Attributes,
 		)
 	}
 
+	if a.GetOperation() == admission.Create {
+		if err := o.acceptPolicyPermissionClaims(clusterName, apiBinding); err != nil {
+			return apierrors.NewInternalError(err)
+		}
+	}
+
 	// write back
 	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(apiBinding)
 	if err != nil {
@@ -214,7 +240,7 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 		errs = ValidateAPIBindingUpdate(oldAPIBinding, apiBinding)
 	}
 	if len(errs) > 0 {
-		return admission.NewForbidden(a, fmt.Errorf("%v", errs))
+		return apierrors.NewInvalid(apisv1alpha1.Kind("APIBinding"), apiBinding.Name, errs)
 	}
 
 	switch {
@@ -228,29 +254,53 @@ func (o *apiBindingAdmission) Validate(ctx context.Context, a admission.Attribut
 			action = "update"
 		}
 		forbidden := admission.NewForbidden(a, fmt.Errorf("unable to %s APIBinding: no permission to bind to export %s", action,
-			logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path).Join(apiBinding.Spec.Reference.Export.Name).String()))
+			apiBinding.Spec.Reference.Export.Path.Path().Join(apiBinding.Spec.Reference.Export.Name).String()))
 
 		// get cluster name of export
 		var exportClusterName logicalcluster.Name
+		var export *apisv1alpha1.APIExport
 		if apiBinding.Spec.Reference.Export.Path == "" {
 			exportClusterName = clusterName
-		} else if apiBinding.Spec.Reference.Export.Path == core.RootCluster.String() {
+		} else if apiBinding.Spec.Reference.Export.Path.Path() == core.RootCluster.Path() {
 			// special case to allow bootstrapping
 			exportClusterName = core.RootCluster
 		} else {
-			path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
-			export, err := o.getAPIExport(path, apiBinding.Spec.Reference.Export.Name)
+			path := apiBinding.Spec.Reference.Export.Path.Path()
+			export, err = o.getAPIExport(path, apiBinding.Spec.Reference.Export.Name)
 			if err != nil {
 				return forbidden
 			}
 			exportClusterName = logicalcluster.From(export)
 		}
+		if export == nil {
+			// local and root APIExports need not exist yet, but are subject to migration and sunset if they do
+			if local, err := o.getAPIExport(exportClusterName.Path(), apiBinding.Spec.Reference.Export.Name); err == nil {
+				export = local
+			}
+		}
 
 		// Access check
 		if err := o.checkAPIExportAccess(ctx, a.GetUserInfo(), exportClusterName, apiBinding.Spec.Reference.Export.Name); err != nil {
 			return forbidden
 		}
 
+		// An APIExport under migration does not accept new consumers
+		if a.GetOperation() == admission.Create && export != nil && export.Spec.Migration != nil {
+			target := export.Spec.Migration.Target
+			targetPath := target.Path.Path()
+			if targetPath.Empty() {
+				targetPath = logicalcluster.From(export).Path()
+			}
+			return admission.NewForbidden(a, fmt.Errorf("APIExport %s is being migrated, bind to %s instead",
+				apiBinding.Spec.Reference.Export.Path.Path().Join(apiBinding.Spec.Reference.Export.Name).String(), targetPath.Join(target.Name).String()))
+		}
+
+		// A sunset APIExport does not accept new consumers
+		if a.GetOperation() == admission.Create && export != nil && export.IsSunset(time.Now()) {
+			return admission.NewForbidden(a, fmt.Errorf("APIExport %s has been sunset on %s",
+				apiBinding.Spec.Reference.Export.Path.Path().Join(apiBinding.Spec.Reference.Export.Name).String(), export.Spec.Deprecation.SunsetTime.UTC().Format(time.RFC3339)))
+		}
+
 		// Verify the labels
 		value := apiBinding.Labels[apisv1alpha1.InternalAPIBindingExportLabelKey]
 		if expected := permissionclaims.ToAPIBindingExportLabelValue(
@@ -301,6 +351,9 @@ func (o *apiBindingAdmission) ValidateInitialization() error {
 	if o.apiExportLister == nil {
 		return fmt.Errorf(PluginName + " plugin needs an APIExport lister")
 	}
+	if o.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
 	return nil
 }
 
@@ -312,11 +365,15 @@ func (o *apiBindingAdmission) SetDeepSARClient(client kcpkubernetesclientset.Clu
 
 func (o *apiBindingAdmission) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
 	apiExportsReady := informers.Apis().V1alpha1().APIExports().Informer().HasSynced
+	typesReady := informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced
+	logicalClustersReady := informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced
 	o.SetReadyFunc(func() bool {
-		return apiExportsReady()
+		return apiExportsReady() && typesReady() && logicalClustersReady()
 	})
 	o.apiExportLister = informers.Apis().V1alpha1().APIExports().Lister()
 	o.apiExportIndexer = informers.Apis().V1alpha1().APIExports().Informer().GetIndexer()
+	o.workspaceTypeIndexer = informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer()
+	o.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
 
 	indexers.AddIfNotPresentOrDie(informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -37,8 +38,9 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
 func createAttr(apiBinding *apisv1alpha1.APIBinding) admission.Attributes {
@@ -98,6 +100,15 @@ func TestAdmit(t *testing.T) {
 			expectedObject: helpers.ToUnstructuredOrDie(newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:aunt"), "someExport").
 				withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-aunt:someExport")).APIBinding),
 		},
+		{
+			name: "Create: normalizes export path",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath(" Root:Aunt: "), "someExport").APIBinding,
+			),
+			authzDecision: authorizer.DecisionAllow,
+			expectedObject: helpers.ToUnstructuredOrDie(newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:aunt"), "someExport").
+				withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-aunt:someExport")).APIBinding),
+		},
 		{
 			name: "Create: with relative export reference",
 			attr: createAttr(
@@ -170,6 +181,19 @@ func TestAdmit(t *testing.T) {
 					switch path.Join(name).String() {
 					case "root:org:ws:someExport", "root-org-ws:someExport":
 						return newExport(logicalcluster.NewPath("root:org:ws"), "someExport").APIExport, nil
+					case "root:org:workspaceName:migratingExport", "root-org-workspaceName:migratingExport":
+						export := newExport(logicalcluster.NewPath("root:org:workspaceName"), name).APIExport
+						export.Spec.Migration = &apisv1alpha1.APIExportMigration{
+							Target: apisv1alpha1.ExportBindingReference{Path: "root:org:newProvider", Name: "someExport"},
+						}
+						return export, nil
+					case "root:org:workspaceName:sunsetExport", "root-org-workspaceName:sunsetExport":
+						export := newExport(logicalcluster.NewPath("root:org:workspaceName"), name).APIExport
+						export.Spec.Deprecation = &apisv1alpha1.APIExportDeprecation{
+							Deprecated: true,
+							SunsetTime: &metav1.Time{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+						}
+						return export, nil
 					case "root:aunt:someExport", "root-aunt:someExport":
 						return newExport(logicalcluster.NewPath("root:aunt"), "someExport").APIExport, nil
 					case "root:org:workspaceName:someExport", "root-org-workspaceName:someExport":
@@ -181,6 +205,9 @@ func TestAdmit(t *testing.T) {
 					}
 					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
 				},
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+				},
 			}
 
 			ctx := request.WithCluster(context.Background(), request.Cluster{Name: logicalcluster.From(tc.attr.GetObject().(metav1.Object))})
@@ -224,6 +251,13 @@ func TestValidate(t *testing.T) {
 			),
 			expectedErrors: []string{"spec.reference.export.name: Required value"},
 		},
+		{
+			name: "Create: invalid export path fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root::org_1"), "someExport").APIBinding,
+			),
+			expectedErrors: []string{`spec.reference.export.path: Invalid value: "root::org_1"`},
+		},
 		{
 			name: "Create: complete workspaceName reference passes when authorized",
 			attr: createAttr(
@@ -283,6 +317,42 @@ func TestValidate(t *testing.T) {
 			authzError:     errors.New("some error here"),
 			expectedErrors: []string{"no permission to bind to export root:org:workspaceName:someExport"},
 		},
+		{
+			name: "Create: reference to an APIExport under migration fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "migratingExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:migratingExport")).APIBinding,
+			),
+			authzDecision:  authorizer.DecisionAllow,
+			expectedErrors: []string{`APIExport root:org:workspaceName:migratingExport is being migrated, bind to root:org:newProvider:someExport instead`},
+		},
+		{
+			name: "Create: local reference to an APIExport under migration fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.Path{}, "migratingExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-ws:migratingExport")).APIBinding,
+			),
+			authzDecision:  authorizer.DecisionAllow,
+			expectedErrors: []string{`APIExport migratingExport is being migrated, bind to root:org:newProvider:someExport instead`},
+		},
+		{
+			name: "Update: reference to an APIExport under migration passes",
+			attr: updateAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "migratingExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:migratingExport")).APIBinding,
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "migratingExport").APIBinding,
+			),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name: "Create: reference to a sunset APIExport fails",
+			attr: createAttr(
+				newAPIBinding().withName("test").withReference(logicalcluster.NewPath("root:org:workspaceName"), "sunsetExport").
+					withLabel(apisv1alpha1.InternalAPIBindingExportLabelKey, toSha224Base62("root-org-workspaceName:sunsetExport")).APIBinding,
+			),
+			authzDecision:  authorizer.DecisionAllow,
+			expectedErrors: []string{`APIExport root:org:workspaceName:sunsetExport has been sunset on 2023-01-01T00:00:00Z`},
+		},
 		{
 			name: "Update: missing workspace reference exportName fails",
 			attr: updateAttr(
@@ -449,6 +519,12 @@ func TestValidate(t *testing.T) {
 						return newExport(logicalcluster.NewPath("root:org:sibling"), name).APIExport, nil
 					case "root:org:someExport", "root-org:someExport":
 						return newExport(logicalcluster.NewPath("root:org"), name).APIExport, nil
+					case "root-org-ws:migratingExport":
+						export := newExport(logicalcluster.NewPath("root:org:ws"), name).APIExport
+						export.Spec.Migration = &apisv1alpha1.APIExportMigration{
+							Target: apisv1alpha1.ExportBindingReference{Path: "root:org:newProvider", Name: "someExport"},
+						}
+						return export, nil
 					case "root:some-other-org:bla:someExport", "root-some-other-org-bla:someExport":
 						return newExport(logicalcluster.NewPath("root:some-other-org:bla"), name).APIExport, nil
 
@@ -507,7 +583,7 @@ func (b *bindingBuilder) withName(name string) *bindingBuilder {
 
 func (b *bindingBuilder) withReference(path logicalcluster.Path, exportName string) *bindingBuilder {
 	b.Spec.Reference.Export = &apisv1alpha1.ExportBindingReference{
-		Path: path.String(),
+		Path: apisv1alpha1.NewLogicalClusterPath(path),
 		Name: exportName,
 	}
 	return b
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// acceptPolicyPermissionClaims accepts the permission claims of the bound APIExport that are listed in
+// the AcceptedPermissionClaimPolicies of the workspace type, or of the types it extends. Claims the
+// APIBinding already accepts or rejects are left untouched.
+func (o *apiBindingAdmission) acceptPolicyPermissionClaims(clusterName logicalcluster.Name, apiBinding *apisv1alpha1.APIBinding) error {
+	logicalCluster, err := o.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	typeAnnotation, found := logicalCluster.Annotations[tenancyv1beta1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return nil
+	}
+	wtPath, wtName := logicalcluster.NewPath(typeAnnotation).Split()
+	if wtPath.Empty() {
+		return nil
+	}
+	wt, err := o.getWorkspaceType(wtPath, wtName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	wts, err := o.transitiveTypeResolver.Resolve(wt)
+	if err != nil {
+		// the type hierarchy is validated on workspace creation, nothing to accept here
+		return nil //nolint:nilerr
+	}
+
+	exportPath := apiBinding.Spec.Reference.Export.Path.Path()
+	if exportPath.Empty() {
+		exportPath = clusterName.Path()
+	}
+	export, err := o.getAPIExport(exportPath, apiBinding.Spec.Reference.Export.Name)
+	if err != nil {
+		// a missing export is rejected in validation
+		return nil //nolint:nilerr
+	}
+
+	for _, wt := range wts {
+		for _, policy := range wt.Spec.AcceptedPermissionClaimPolicies {
+			policyPath := logicalcluster.NewPath(policy.Export.Path)
+			if policyPath.Empty() {
+				policyPath = logicalcluster.From(wt).Path()
+			}
+			policyExport, err := o.getAPIExport(policyPath, policy.Export.Export)
+			if err != nil {
+				continue
+			}
+			if logicalcluster.From(policyExport) != logicalcluster.From(export) || policyExport.Name != export.Name {
+				continue
+			}
+
+			for _, claim := range export.Spec.PermissionClaims {
+				if !policyAcceptsClaim(policy, claim) || bindingHasClaim(apiBinding, claim) {
+					continue
+				}
+				apiBinding.Spec.PermissionClaims = append(apiBinding.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+					PermissionClaim: claim,
+					State:           apisv1alpha1.ClaimAccepted,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+func policyAcceptsClaim(policy tenancyv1alpha1.AcceptedPermissionClaimPolicy, claim apisv1alpha1.PermissionClaim) bool {
+	for _, r := range policy.Claims {
+		if r.Group == claim.Group && r.Resource == claim.Resource && r.IdentityHash == claim.IdentityHash {
+			return true
+		}
+	}
+	return false
+}
+
+func bindingHasClaim(apiBinding *apisv1alpha1.APIBinding, claim apisv1alpha1.PermissionClaim) bool {
+	for _, c := range apiBinding.Spec.PermissionClaims {
+		if c.Group == claim.Group && c.Resource == claim.Resource && c.IdentityHash == claim.IdentityHash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestAcceptPolicyPermissionClaims(t *testing.T) {
+	configMapsClaim := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}
+	secretsClaim := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true}
+
+	tests := []struct {
+		name          string
+		workspaceType string
+		claims        []apisv1alpha1.AcceptablePermissionClaim
+		expected      []apisv1alpha1.AcceptablePermissionClaim
+	}{
+		{
+			name:          "untyped workspace",
+			workspaceType: "",
+		},
+		{
+			name:          "type without policy",
+			workspaceType: "root:universal",
+		},
+		{
+			name:          "type with policy accepts listed claims",
+			workspaceType: "root:org:mandated",
+			expected: []apisv1alpha1.AcceptablePermissionClaim{
+				{PermissionClaim: configMapsClaim, State: apisv1alpha1.ClaimAccepted},
+			},
+		},
+		{
+			name:          "type extending type with policy accepts listed claims",
+			workspaceType: "root:org:extending",
+			expected: []apisv1alpha1.AcceptablePermissionClaim{
+				{PermissionClaim: configMapsClaim, State: apisv1alpha1.ClaimAccepted},
+			},
+		},
+		{
+			name:          "rejected claims are left untouched",
+			workspaceType: "root:org:mandated",
+			claims: []apisv1alpha1.AcceptablePermissionClaim{
+				{PermissionClaim: configMapsClaim, State: apisv1alpha1.ClaimRejected},
+				{PermissionClaim: secretsClaim, State: apisv1alpha1.ClaimAccepted},
+			},
+			expected: []apisv1alpha1.AcceptablePermissionClaim{
+				{PermissionClaim: configMapsClaim, State: apisv1alpha1.ClaimRejected},
+				{PermissionClaim: secretsClaim, State: apisv1alpha1.ClaimAccepted},
+			},
+		},
+		{
+			name:          "policy for another export",
+			workspaceType: "root:org:other",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			export := newExport(logicalcluster.NewPath("root:org:provider"), "someExport").APIExport
+			export.Spec.PermissionClaims = []apisv1alpha1.PermissionClaim{configMapsClaim, secretsClaim}
+			otherExport := newExport(logicalcluster.NewPath("root:org:provider"), "otherExport").APIExport
+
+			types := map[string]*tenancyv1alpha1.WorkspaceType{
+				"root:universal": newWorkspaceType("root", "universal"),
+				"root:org:mandated": withPolicy(newWorkspaceType("root:org", "mandated"), tenancyv1alpha1.AcceptedPermissionClaimPolicy{
+					Export: tenancyv1alpha1.APIExportReference{Path: "root:org:provider", Export: "someExport"},
+					Claims: []tenancyv1alpha1.ClaimedResource{{Resource: "configmaps"}},
+				}),
+				"root:org:other": withPolicy(newWorkspaceType("root:org", "other"), tenancyv1alpha1.AcceptedPermissionClaimPolicy{
+					Export: tenancyv1alpha1.APIExportReference{Path: "root:org:provider", Export: "otherExport"},
+					Claims: []tenancyv1alpha1.ClaimedResource{{Resource: "configmaps"}},
+				}),
+			}
+			extending := newWorkspaceType("root:org", "extending")
+			extending.Spec.Extend.With = []tenancyv1alpha1.WorkspaceTypeReference{{Path: "root:org", Name: "mandated"}}
+			types["root:org:extending"] = extending
+
+			o := &apiBindingAdmission{
+				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+					switch path.Join(name).String() {
+					case "root:org:provider:someExport":
+						return export, nil
+					case "root:org:provider:otherExport":
+						return otherExport, nil
+					}
+					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+				},
+				getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					if wt, found := types[path.Join(name).String()]; found {
+						return wt, nil
+					}
+					return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetypes"), name)
+				},
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					logicalCluster := &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{},
+						},
+					}
+					if tc.workspaceType != "" {
+						logicalCluster.Annotations[tenancyv1beta1.LogicalClusterTypeAnnotationKey] = tc.workspaceType
+					}
+					return logicalCluster, nil
+				},
+			}
+			o.transitiveTypeResolver = workspacetypeexists.NewTransitiveTypeResolver(o.getWorkspaceType)
+
+			apiBinding := newAPIBinding().withReference(logicalcluster.NewPath("root:org:provider"), "someExport").APIBinding
+			apiBinding.Spec.PermissionClaims = tc.claims
+
+			err := o.acceptPolicyPermissionClaims(logicalcluster.Name("root-org-ws"), apiBinding)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, apiBinding.Spec.PermissionClaims)
+		})
+	}
+}
+
+func newWorkspaceType(path, name string) *tenancyv1alpha1.WorkspaceType {
+	return &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey:         strings.ReplaceAll(path, ":", "-"),
+				core.LogicalClusterPathAnnotationKey: path,
+			},
+		},
+	}
+}
+
+func withPolicy(wt *tenancyv1alpha1.WorkspaceType, policy tenancyv1alpha1.AcceptedPermissionClaimPolicy) *tenancyv1alpha1.WorkspaceType {
+	wt.Spec.AcceptedPermissionClaimPolicies = append(wt.Spec.AcceptedPermissionClaimPolicies, policy)
+	return wt
+}
@@ -21,7 +21,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 // ValidateAPIBinding validates an APIBinding.
@@ -58,9 +58,29 @@ func ValidateAPIBindingReference(reference apisv1alpha1.BindingReference, path *
 	// For now, field "export" is required via OpenAPI. But just in case...
 	if reference.Export == nil {
 		allErrs = append(allErrs, field.Required(path.Child("export"), ""))
-	} else if reference.Export.Name == "" {
-		allErrs = append(allErrs, field.Required(path.Child("export").Child("name"), ""))
+	} else {
+		allErrs = append(allErrs, ValidateExportBindingReference(*reference.Export, path.Child("export"))...)
 	}
 
 	return allErrs
 }
+
+// ValidateExportBindingReference validates a reference to an APIExport as used by APIBindings and
+// APIExportEndpointSlices.
+func ValidateExportBindingReference(reference apisv1alpha1.ExportBindingReference, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if reference.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+	}
+	if !reference.Path.Empty() && !reference.Path.IsValid() {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), string(reference.Path), "must be a logical cluster path of the form a:b:c, consisting of lower-case alphanumeric characters or '-'"))
+	}
+
+	return allErrs
+}
+
+// NormalizeExportBindingReference brings the path of a reference to an APIExport into its canonical form.
+func NormalizeExportBindingReference(reference *apisv1alpha1.ExportBindingReference) {
+	reference.Path = reference.Path.Normalize()
+}
@@ -22,8 +22,8 @@ import (
 	"k8s.io/apiserver/pkg/admission"
 
 	"github.com/kcp-dev/kcp/pkg/admission/finalizer"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibindingdeletion"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 const (
@@ -20,15 +20,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
-	"github.com/kcp-dev/kcp/pkg/apis/apis"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/schemacompat"
 	builtinapiexport "github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas/builtin"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 // PluginName is the name used to identify this admission webhook.
@@ -47,6 +56,8 @@ type APIExportAdmission struct {
 	*admission.Handler
 
 	isBuiltIn func(apisv1alpha1.GroupResource) bool
+
+	getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
 }
 
 // NewAPIExportAdmission constructs a new APIExportAdmission admission plugin.
@@ -59,6 +70,8 @@ func NewAPIExportAdmission(isBuiltIn func(apisv1alpha1.GroupResource) bool) *API
 
 // Ensure that the required admission interfaces are implemented.
 var _ = admission.ValidationInterface(&APIExportAdmission{})
+var _ = admission.InitializationValidator(&APIExportAdmission{})
+var _ = kcpinitializers.WantsKcpInformers(&APIExportAdmission{})
 
 // Validate ensures that the APIExport is valid.
 func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
@@ -92,5 +105,130 @@ func (e *APIExportAdmission) Validate(ctx context.Context, a admission.Attribute
 		}
 	}
 
+	if a.GetOperation() == admission.Update {
+		oldU, ok := a.GetOldObject().(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected type %T", a.GetOldObject())
+		}
+		oldAE := &apisv1alpha1.APIExport{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(oldU.Object, oldAE); err != nil {
+			return fmt.Errorf("failed to convert unstructured to APIExport: %w", err)
+		}
+
+		oldReleases := make(map[string][]string, len(oldAE.Spec.Releases))
+		for _, r := range oldAE.Spec.Releases {
+			oldReleases[r.Version] = r.ResourceSchemas
+		}
+		for i, r := range ae.Spec.Releases {
+			if oldSchemas, found := oldReleases[r.Version]; found && !equality.Semantic.DeepEqual(oldSchemas, r.ResourceSchemas) {
+				return admission.NewForbidden(a,
+					field.Invalid(
+						field.NewPath("spec").
+							Child("releases").
+							Index(i).
+							Child("resourceSchemas"),
+						r.ResourceSchemas,
+						fmt.Sprintf("release %q is immutable", r.Version)))
+			}
+		}
+
+		if ae.Annotations[apisv1alpha1.AnnotationAPIExportForceIncompatibleSchemasKey] != "true" &&
+			!equality.Semantic.DeepEqual(oldAE.Spec.LatestResourceSchemas, ae.Spec.LatestResourceSchemas) {
+			clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+			if err != nil {
+				return apierrors.NewInternalError(err)
+			}
+			fieldErr, err := e.validateLatestResourceSchemas(clusterName, oldAE, ae)
+			if err != nil {
+				return apierrors.NewInternalError(err)
+			}
+			if fieldErr != nil {
+				return admission.NewForbidden(a, fieldErr)
+			}
+		}
+	}
+
+	storageVersions := map[apisv1alpha1.GroupResource]string{}
+	for i, rv := range ae.Spec.ResourceVersions {
+		if !rv.Storage {
+			continue
+		}
+		if other, found := storageVersions[rv.GroupResource]; found {
+			return admission.NewForbidden(a,
+				field.Invalid(
+					field.NewPath("spec").
+						Child("resourceVersions").
+						Index(i).
+						Child("storage"),
+					rv.Storage,
+					fmt.Sprintf("version %q is already marked as storage", other)))
+		}
+		storageVersions[rv.GroupResource] = rv.Version
+	}
+
 	return nil
 }
+
+// validateLatestResourceSchemas checks that every APIResourceSchema newly added to spec.latestResourceSchemas
+// is backward compatible with the schema it replaces for the same resource. Schemas that do not exist are
+// skipped, they are reported by the APIExport controller.
+func (e *APIExportAdmission) validateLatestResourceSchemas(clusterName logicalcluster.Name, old, new *apisv1alpha1.APIExport) (*field.Error, error) {
+	oldSchemas := make(map[apisv1alpha1.GroupResource]*apisv1alpha1.APIResourceSchema, len(old.Spec.LatestResourceSchemas))
+	oldNames := make(map[string]bool, len(old.Spec.LatestResourceSchemas))
+	for _, name := range old.Spec.LatestResourceSchemas {
+		oldNames[name] = true
+		schema, err := e.getAPIResourceSchema(clusterName, name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		oldSchemas[apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}] = schema
+	}
+
+	for i, name := range new.Spec.LatestResourceSchemas {
+		if oldNames[name] {
+			continue
+		}
+		schema, err := e.getAPIResourceSchema(clusterName, name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		oldSchema, found := oldSchemas[apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}]
+		if !found {
+			continue
+		}
+
+		incompatibilities, err := schemacompat.APIResourceSchemaIncompatibilities(oldSchema, schema)
+		if err != nil {
+			return nil, err
+		}
+		if len(incompatibilities) > 0 {
+			return field.Invalid(
+				field.NewPath("spec").
+					Child("latestResourceSchemas").
+					Index(i),
+				name,
+				fmt.Sprintf("incompatible with APIResourceSchema %s: %s; set the %s annotation to \"true\" to force the update",
+					oldSchema.Name, strings.Join(incompatibilities, "; "), apisv1alpha1.AnnotationAPIExportForceIncompatibleSchemasKey)), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (e *APIExportAdmission) ValidateInitialization() error {
+	if e.getAPIResourceSchema == nil {
+		return fmt.Errorf(PluginName + " plugin needs an APIResourceSchema lister")
+	}
+	return nil
+}
+
+func (e *APIExportAdmission) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	e.SetReadyFunc(informers.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced)
+	e.getAPIResourceSchema = func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+		return informers.Apis().V1alpha1().APIResourceSchemas().Lister().Cluster(clusterName).Get(name)
+	}
+}
@@ -20,16 +20,20 @@ import (
 	"context"
 	"testing"
 
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func createAttr(name string, obj runtime.Object, kind, resource string) admission.Attributes {
@@ -48,10 +52,10 @@ func createAttr(name string, obj runtime.Object, kind, resource string) admissio
 	)
 }
 
-func updateAttr(name string, obj runtime.Object, kind, resource string) admission.Attributes {
+func updateAttr(name string, obj, old runtime.Object, kind, resource string) admission.Attributes {
 	return admission.NewAttributesRecord(
 		helpers.ToUnstructuredOrDie(obj),
-		helpers.ToUnstructuredOrDie(obj),
+		helpers.ToUnstructuredOrDie(old),
 		apisv1alpha1.Kind(kind).WithVersion("v1alpha1"),
 		"",
 		name,
@@ -73,6 +77,9 @@ func TestAdmission(t *testing.T) {
 		hasIdentity bool
 		isBuiltIn   bool
 		modifyPCs   func([]apisv1alpha1.PermissionClaim) []apisv1alpha1.PermissionClaim
+		versions    []apisv1alpha1.ExportedResourceVersion
+		releases    []apisv1alpha1.APIExportRelease
+		oldReleases []apisv1alpha1.APIExportRelease
 		want        error
 	}{
 		"NotAPIExportKind": {
@@ -124,6 +131,32 @@ func TestAdmission(t *testing.T) {
 				"",
 				"identityHash is required for API types that are not built-in"),
 		},
+		"ValidStorageVersionPerResource": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			versions: []apisv1alpha1.ExportedResourceVersion{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "some", Resource: "widgets"}, Version: "v1alpha1", Served: true},
+				{GroupResource: apisv1alpha1.GroupResource{Group: "some", Resource: "widgets"}, Version: "v1", Served: true, Storage: true},
+				{GroupResource: apisv1alpha1.GroupResource{Group: "some", Resource: "gadgets"}, Version: "v1", Served: true, Storage: true},
+			},
+		},
+		"ForbiddenMultipleStorageVersions": {
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			versions: []apisv1alpha1.ExportedResourceVersion{
+				{GroupResource: apisv1alpha1.GroupResource{Group: "some", Resource: "widgets"}, Version: "v1alpha1", Served: true, Storage: true},
+				{GroupResource: apisv1alpha1.GroupResource{Group: "some", Resource: "widgets"}, Version: "v1", Served: true, Storage: true},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("resourceVersions").
+					Index(1).
+					Child("storage"),
+				true,
+				`version "v1alpha1" is already marked as storage`),
+		},
 		"ValidUpdateBuiltInNoID": {
 			update:    true,
 			kind:      "APIExport",
@@ -179,6 +212,47 @@ func TestAdmission(t *testing.T) {
 			hasIdentity: true,
 			isBuiltIn:   false,
 		},
+		"ValidUpdateAddingRelease": {
+			update:      true,
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			releases: []apisv1alpha1.APIExportRelease{
+				{Version: "v1.0.0", ResourceSchemas: []string{"v1.somethings.some"}},
+				{Version: "v1.1.0", ResourceSchemas: []string{"v2.somethings.some"}},
+			},
+			oldReleases: []apisv1alpha1.APIExportRelease{
+				{Version: "v1.0.0", ResourceSchemas: []string{"v1.somethings.some"}},
+			},
+		},
+		"ValidUpdateRemovingRelease": {
+			update:      true,
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			oldReleases: []apisv1alpha1.APIExportRelease{
+				{Version: "v1.0.0", ResourceSchemas: []string{"v1.somethings.some"}},
+			},
+		},
+		"ForbiddenUpdateChangingRelease": {
+			update:      true,
+			kind:        "APIExport",
+			resource:    "apiexports",
+			hasIdentity: true,
+			releases: []apisv1alpha1.APIExportRelease{
+				{Version: "v1.0.0", ResourceSchemas: []string{"v2.somethings.some"}},
+			},
+			oldReleases: []apisv1alpha1.APIExportRelease{
+				{Version: "v1.0.0", ResourceSchemas: []string{"v1.somethings.some"}},
+			},
+			want: field.Invalid(
+				field.NewPath("spec").
+					Child("releases").
+					Index(0).
+					Child("resourceSchemas"),
+				[]string{"v2.somethings.some"},
+				`release "v1.0.0" is immutable`),
+		},
 		"ValidNoPermissionClaims": {
 			kind:     "APIExport",
 			resource: "apiexports",
@@ -210,9 +284,15 @@ func TestAdmission(t *testing.T) {
 			if tc.modifyPCs != nil {
 				ae.Spec.PermissionClaims = tc.modifyPCs(ae.Spec.PermissionClaims)
 			}
+			ae.Spec.ResourceVersions = tc.versions
+			ae.Spec.Releases = tc.releases
 			var attr admission.Attributes
 			if tc.update {
-				attr = updateAttr("cool-something", ae, tc.kind, tc.resource)
+				old := ae.DeepCopy()
+				if tc.oldReleases != nil {
+					old.Spec.Releases = tc.oldReleases
+				}
+				attr = updateAttr("cool-something", ae, old, tc.kind, tc.resource)
 			} else {
 				attr = createAttr("cool-something", ae, tc.kind, tc.resource)
 			}
@@ -229,3 +309,92 @@ func TestAdmission(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestResourceSchemasCompatibility(t *testing.T) {
+	newSchema := func(name, openAPISchema string) *apisv1alpha1.APIResourceSchema {
+		return &apisv1alpha1.APIResourceSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group: "example.io",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+				Scope: apiextensionsv1.NamespaceScoped,
+				Versions: []apisv1alpha1.APIResourceVersion{
+					{Name: "v1", Served: true, Storage: true, Schema: runtime.RawExtension{Raw: []byte(openAPISchema)}},
+				},
+			},
+		}
+	}
+	schemas := map[string]*apisv1alpha1.APIResourceSchema{
+		"v1.widgets.example.io":           newSchema("v1.widgets.example.io", `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"}}}}}`),
+		"v2.widgets.example.io":           newSchema("v2.widgets.example.io", `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"},"color":{"type":"string"}}}}}`),
+		"v3.widgets.example.io":           newSchema("v3.widgets.example.io", `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"string"}}}}}`),
+		"v1.gadgets.example.io":           newSchema("v1.gadgets.example.io", `{"type":"object"}`),
+		"incompatible.gadgets.example.io": newSchema("incompatible.gadgets.example.io", `{"type":"object"}`),
+	}
+	schemas["v1.gadgets.example.io"].Spec.Names = apiextensionsv1.CustomResourceDefinitionNames{Plural: "gadgets", Kind: "Gadget"}
+	schemas["incompatible.gadgets.example.io"].Spec.Names = apiextensionsv1.CustomResourceDefinitionNames{Plural: "gadgets", Kind: "Gadget"}
+	schemas["incompatible.gadgets.example.io"].Spec.Scope = apiextensionsv1.ClusterScoped
+
+	tests := map[string]struct {
+		old, new []string
+		force    bool
+		wantErr  string
+	}{
+		"compatible": {
+			old: []string{"v1.widgets.example.io", "v1.gadgets.example.io"},
+			new: []string{"v2.widgets.example.io", "v1.gadgets.example.io"},
+		},
+		"incompatible": {
+			old:     []string{"v1.widgets.example.io"},
+			new:     []string{"v3.widgets.example.io"},
+			wantErr: "incompatible with APIResourceSchema v1.widgets.example.io: v1: field spec.size changed type from integer to string",
+		},
+		"incompatible, forced": {
+			old:   []string{"v1.widgets.example.io"},
+			new:   []string{"v3.widgets.example.io"},
+			force: true,
+		},
+		"incompatible other resource": {
+			old:     []string{"v1.widgets.example.io", "v1.gadgets.example.io"},
+			new:     []string{"v1.widgets.example.io", "incompatible.gadgets.example.io"},
+			wantErr: "scope changed from Namespaced to Cluster",
+		},
+		"new resource": {
+			old: []string{"v1.widgets.example.io"},
+			new: []string{"v1.widgets.example.io", "incompatible.gadgets.example.io"},
+		},
+		"missing schema": {
+			old: []string{"v1.widgets.example.io"},
+			new: []string{"v4.widgets.example.io"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+				Spec:       apisv1alpha1.APIExportSpec{LatestResourceSchemas: tc.old},
+			}
+			ae := old.DeepCopy()
+			ae.Spec.LatestResourceSchemas = tc.new
+			if tc.force {
+				ae.Annotations = map[string]string{apisv1alpha1.AnnotationAPIExportForceIncompatibleSchemasKey: "true"}
+			}
+
+			plugin := NewAPIExportAdmission(func(apisv1alpha1.GroupResource) bool { return false })
+			plugin.getAPIResourceSchema = func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+				if s, found := schemas[name]; found {
+					return s, nil
+				}
+				return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name)
+			}
+
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: logicalcluster.Name("root:org")})
+			err := plugin.Validate(ctx, updateAttr("widgets", ae, old, "APIExport", "apiexports"), nil)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
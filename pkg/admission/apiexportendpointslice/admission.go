@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/kcp-dev/kcp/pkg/admission/apibinding"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "apis.kcp.io/APIExportEndpointSlice"
+
+// Register registers the APIExportEndpointSlice admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return NewAPIExportEndpointSliceAdmission(), nil
+		})
+}
+
+// APIExportEndpointSliceAdmission is an admission plugin normalizing and validating the
+// APIExport reference of APIExportEndpointSlices.
+type APIExportEndpointSliceAdmission struct {
+	*admission.Handler
+}
+
+// NewAPIExportEndpointSliceAdmission constructs a new APIExportEndpointSliceAdmission admission plugin.
+func NewAPIExportEndpointSliceAdmission() *APIExportEndpointSliceAdmission {
+	// the export reference is immutable, hence only creation is of interest.
+	return &APIExportEndpointSliceAdmission{
+		Handler: admission.NewHandler(admission.Create),
+	}
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.MutationInterface(&APIExportEndpointSliceAdmission{})
+var _ = admission.ValidationInterface(&APIExportEndpointSliceAdmission{})
+
+// Admit brings the APIExport reference into its canonical form.
+func (e *APIExportEndpointSliceAdmission) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	u, slice, err := toAPIExportEndpointSlice(a)
+	if err != nil || slice == nil {
+		return err
+	}
+
+	apibinding.NormalizeExportBindingReference(&slice.Spec.APIExport)
+
+	// write back
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(slice)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+
+	return nil
+}
+
+// Validate ensures that the APIExport reference is well-formed.
+func (e *APIExportEndpointSliceAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	_, slice, err := toAPIExportEndpointSlice(a)
+	if err != nil || slice == nil {
+		return err
+	}
+
+	if errs := apibinding.ValidateExportBindingReference(slice.Spec.APIExport, field.NewPath("spec", "export")); len(errs) > 0 {
+		return apierrors.NewInvalid(apisv1alpha1.Kind("APIExportEndpointSlice"), slice.Name, errs)
+	}
+
+	return nil
+}
+
+func toAPIExportEndpointSlice(a admission.Attributes) (*unstructured.Unstructured, *apisv1alpha1.APIExportEndpointSlice, error) {
+	if a.GetResource().GroupResource() != apisv1alpha1.Resource("apiexportendpointslices") {
+		return nil, nil, nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	slice := &apisv1alpha1.APIExportEndpointSlice{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, slice); err != nil {
+		return nil, nil, fmt.Errorf("failed to convert unstructured to APIExportEndpointSlice: %w", err)
+	}
+
+	return u, slice, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func createAttr(obj runtime.Object) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		nil,
+		apisv1alpha1.Kind("APIExportEndpointSlice").WithVersion("v1alpha1"),
+		"",
+		"slice",
+		apisv1alpha1.Resource("apiexportendpointslices").WithVersion("v1alpha1"),
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
+func newSlice(path, name string) *apisv1alpha1.APIExportEndpointSlice {
+	return &apisv1alpha1.APIExportEndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "slice"},
+		Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+			APIExport: apisv1alpha1.ExportBindingReference{
+				Path: apisv1alpha1.LogicalClusterPath(path),
+				Name: name,
+			},
+		},
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	tests := map[string]struct {
+		slice *apisv1alpha1.APIExportEndpointSlice
+		want  *apisv1alpha1.APIExportEndpointSlice
+	}{
+		"canonical path is kept": {
+			slice: newSlice("root:org", "export"),
+			want:  newSlice("root:org", "export"),
+		},
+		"empty path is kept": {
+			slice: newSlice("", "export"),
+			want:  newSlice("", "export"),
+		},
+		"path is normalized": {
+			slice: newSlice(" :Root:Org: ", "export"),
+			want:  newSlice("root:org", "export"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attr := createAttr(tt.slice)
+			err := NewAPIExportEndpointSliceAdmission().Admit(context.Background(), attr, nil)
+			require.NoError(t, err)
+			require.Equal(t, helpers.ToUnstructuredOrDie(tt.want), attr.GetObject())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		slice   *apisv1alpha1.APIExportEndpointSlice
+		wantErr string
+	}{
+		"valid": {
+			slice: newSlice("root:org", "export"),
+		},
+		"relative": {
+			slice: newSlice("", "export"),
+		},
+		"missing name": {
+			slice:   newSlice("root:org", ""),
+			wantErr: "spec.export.name: Required value",
+		},
+		"invalid path": {
+			slice:   newSlice("root::org", "export"),
+			wantErr: `spec.export.path: Invalid value: "root::org"`,
+		},
+		"wildcard path": {
+			slice:   newSlice("*", "export"),
+			wantErr: `spec.export.path: Invalid value: "*"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewAPIExportEndpointSliceAdmission().Validate(context.Background(), createAttr(tt.slice), nil)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.True(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
+				require.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -25,7 +25,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/schematemplates"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 const (
@@ -47,6 +48,44 @@ type apiResourceSchemaValidation struct {
 
 // Ensure that the required admission interfaces are implemented.
 var _ = admission.ValidationInterface(&apiResourceSchemaValidation{})
+var _ = admission.MutationInterface(&apiResourceSchemaValidation{})
+
+// Admit injects the printer columns and categories of the templates referenced by the
+// apis.kcp.io/templates annotation into a new APIResourceSchema.
+func (o *apiResourceSchemaValidation) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
+	if a.GetResource().GroupResource() != apisv1alpha1.Resource("apiresourceschemas") {
+		return nil
+	}
+	if a.GetOperation() != admission.Create {
+		return nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	schema := &apisv1alpha1.APIResourceSchema{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, schema); err != nil {
+		return fmt.Errorf("failed to convert unstructured to APIResourceSchema: %w", err)
+	}
+
+	names := schematemplates.TemplateNames(schema)
+	if len(names) == 0 {
+		return nil
+	}
+	if err := schematemplates.Apply(schema, names); err != nil {
+		return admission.NewForbidden(a, err)
+	}
+
+	// write back
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(schema)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+
+	return nil
+}
 
 // Validate does validation of a APIResourceSchema for create and update.
 func (o *apiResourceSchemaValidation) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
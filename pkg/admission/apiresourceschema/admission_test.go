@@ -18,17 +18,25 @@ package apiresourceschema
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	genericfeatures "k8s.io/apiserver/pkg/features"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"sigs.k8s.io/yaml"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func createAttr(s *apisv1alpha1.APIResourceSchema) admission.Attributes {
@@ -161,6 +169,48 @@ spec:
 				"spec.group: Invalid value: \"core\": must be empty string for the core group",
 			},
 		},
+		{
+			name: "conversions must reference versions and compile",
+			attr: createAttr(unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+  - name: v2
+    served: true
+    storage: false
+    schema:
+      type: object
+  conversions:
+  - from: v1
+    to: v3
+  - from: v1
+    to: v2
+    rules:
+    - field: .metadata.name
+    - field: .spec.name
+      transformation: "self +"
+            `)),
+			expectedErrors: []string{
+				"spec.conversions[0].to: Not found: \"v3\"",
+				"spec.conversions[1].rules[0]: Invalid value",
+				"spec.conversions[1].rules[1]: Invalid value",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,6 +235,359 @@ spec:
 	}
 }
 
+func TestValidateCEL(t *testing.T) {
+	schemaWithRule := func(rule string) *apisv1alpha1.APIResourceSchema {
+		return unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+      properties:
+        spec:
+          type: object
+          properties:
+            minHorses:
+              type: integer
+            maxHorses:
+              type: integer
+            names:
+              type: array
+              items:
+                type: string
+          x-kubernetes-validations:
+          - rule: ` + strconv.Quote(rule) + `
+            message: invalid cowboy
+            `)
+	}
+
+	tests := []struct {
+		name            string
+		schema          *apisv1alpha1.APIResourceSchema
+		featureDisabled bool
+		expectedErrors  []string
+	}{
+		{
+			name:   "cross-field rule",
+			schema: schemaWithRule("self.minHorses <= self.maxHorses"),
+		},
+		{
+			name:   "rule not compiling",
+			schema: schemaWithRule("self.minHorses <= self.unknown"),
+			expectedErrors: []string{
+				"spec.versions[0].schema.openAPIV3Schema.properties[spec].x-kubernetes-validations[0].rule: Invalid value",
+			},
+		},
+		{
+			name:   "rule exceeding the cost budget",
+			schema: schemaWithRule("self.names.all(x, self.names.all(y, x == y))"),
+			expectedErrors: []string{
+				"exceeds budget",
+			},
+		},
+		{
+			name:            "rule without feature gate",
+			schema:          schemaWithRule("self.minHorses <= self.maxHorses"),
+			featureDisabled: true,
+			expectedErrors: []string{
+				"spec.versions[0].schema: Forbidden: x-kubernetes-validations require the CustomResourceValidationExpressions feature gate",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, genericfeatures.CustomResourceValidationExpressions, !tt.featureDisabled)()
+
+			errs := ValidateAPIResourceSchema(context.Background(), tt.schema)
+			if len(tt.expectedErrors) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			for _, expected := range tt.expectedErrors {
+				require.Contains(t, errs.ToAggregate().Error(), expected)
+			}
+		})
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	schemaWithSpec := func(spec string) *apisv1alpha1.APIResourceSchema {
+		return unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+      properties:
+        spec:
+` + spec)
+	}
+
+	tests := []struct {
+		name           string
+		schema         *apisv1alpha1.APIResourceSchema
+		expectedErrors []string
+	}{
+		{
+			name: "valid defaults",
+			schema: schemaWithSpec(`
+          type: object
+          default: {}
+          properties:
+            horses:
+              type: integer
+              default: 3
+            hat:
+              type: string
+              default: stetson
+`),
+		},
+		{
+			name: "default of wrong type",
+			schema: schemaWithSpec(`
+          type: object
+          properties:
+            horses:
+              type: integer
+              default: three
+`),
+			expectedErrors: []string{
+				"spec.versions[0].schema.openAPIV3Schema.properties[spec].properties[horses].default: Invalid value",
+			},
+		},
+		{
+			name: "default with unknown fields",
+			schema: schemaWithSpec(`
+          type: object
+          default:
+            horses: 3
+            saddles: 2
+          properties:
+            horses:
+              type: integer
+`),
+			expectedErrors: []string{
+				"spec.versions[0].schema.openAPIV3Schema.properties[spec].default: Invalid value",
+				"must not have unknown fields",
+			},
+		},
+		{
+			name: "default violating the schema",
+			schema: schemaWithSpec(`
+          type: object
+          properties:
+            horses:
+              type: integer
+              maximum: 10
+              default: 12
+`),
+			expectedErrors: []string{
+				"spec.versions[0].schema.openAPIV3Schema.properties[spec].properties[horses].default: Invalid value",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateAPIResourceSchema(context.Background(), tt.schema)
+			if len(tt.expectedErrors) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			for _, expected := range tt.expectedErrors {
+				require.Contains(t, errs.ToAggregate().Error(), expected)
+			}
+		})
+	}
+}
+
+func TestValidateCustomSubresources(t *testing.T) {
+	schemaWithCustomSubresources := func(subresources string) *apisv1alpha1.APIResourceSchema {
+		return unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+    customSubresources:
+` + subresources)
+	}
+
+	tests := []struct {
+		name           string
+		schema         *apisv1alpha1.APIResourceSchema
+		expectedErrors []string
+	}{
+		{
+			name: "valid",
+			schema: schemaWithCustomSubresources(`
+    - name: approve
+    - name: saddle-up
+`),
+		},
+		{
+			name: "invalid name",
+			schema: schemaWithCustomSubresources(`
+    - name: Approve
+`),
+			expectedErrors: []string{
+				"spec.versions[0].customSubresources[0].name: Invalid value: \"Approve\"",
+			},
+		},
+		{
+			name: "builtin subresource",
+			schema: schemaWithCustomSubresources(`
+    - name: status
+`),
+			expectedErrors: []string{
+				"spec.versions[0].customSubresources[0].name: Invalid value: \"status\": must be declared in subresources",
+			},
+		},
+		{
+			name: "duplicate",
+			schema: schemaWithCustomSubresources(`
+    - name: approve
+    - name: approve
+`),
+			expectedErrors: []string{
+				"spec.versions[0].customSubresources[1].name: Duplicate value: \"approve\"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateAPIResourceSchema(context.Background(), tt.schema)
+			if len(tt.expectedErrors) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			for _, expected := range tt.expectedErrors {
+				require.Contains(t, errs.ToAggregate().Error(), expected)
+			}
+		})
+	}
+}
+
+func TestAdmitTemplates(t *testing.T) {
+	schemaWithTemplates := func(templates string) *apisv1alpha1.APIResourceSchema {
+		return unmarshalOrDie(`
+apiVersion: apis.kcp.sh/v1alpha1
+kind: APIResourceSchema
+metadata:
+  name: july.cowboys.wild.west
+  annotations:
+    apis.kcp.io/templates: "` + templates + `"
+spec:
+  group: wild.west
+  names:
+    plural: cowboys
+    singular: cowboy
+    kind: Cowboy
+    listKind: CowboyList
+    categories:
+    - western
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      type: object
+    additionalPrinterColumns:
+    - name: Horse
+      type: string
+      jsonPath: .spec.horse
+`)
+	}
+
+	tests := []struct {
+		name           string
+		schema         *apisv1alpha1.APIResourceSchema
+		wantColumns    []string
+		wantCategories []string
+		wantErr        bool
+	}{
+		{
+			name:           "no templates",
+			schema:         schemaWithTemplates(""),
+			wantColumns:    []string{"Horse"},
+			wantCategories: []string{"western"},
+		},
+		{
+			name:           "standard and all",
+			schema:         schemaWithTemplates("standard,all"),
+			wantColumns:    []string{"Horse", "Ready", "Age"},
+			wantCategories: []string{"western", "all"},
+		},
+		{
+			name:    "unknown template",
+			schema:  schemaWithTemplates("fancy"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &apiResourceSchemaValidation{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}
+			attr := createAttr(tt.schema)
+			err := o.Admit(context.Background(), attr, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := &apisv1alpha1.APIResourceSchema{}
+			require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(attr.GetObject().(*unstructured.Unstructured).Object, got))
+			var columns []string
+			for _, column := range got.Spec.Versions[0].AdditionalPrinterColumns {
+				columns = append(columns, column.Name)
+			}
+			require.Equal(t, tt.wantColumns, columns)
+			require.Equal(t, tt.wantCategories, got.Spec.Names.Categories)
+		})
+	}
+}
+
 func unmarshalOrDie(yml string) *apisv1alpha1.APIResourceSchema {
 	s := apisv1alpha1.APIResourceSchema{}
 	if err := yaml.Unmarshal([]byte(strings.ReplaceAll(yml, "\t", "    ")), &s); err != nil {
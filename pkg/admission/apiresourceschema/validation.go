@@ -32,8 +32,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericfeatures "k8s.io/apiserver/pkg/features"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/schemaconversion"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 var (
@@ -158,7 +161,40 @@ func ValidateAPIResourceSchemaSpec(ctx context.Context, spec *apisv1alpha1.APIRe
 	}
 
 	// TODO(sttts): validate predecessors
-	// TODO(sttts): validate conversions
+
+	allErrs = append(allErrs, ValidateAPIVersionConversions(spec.Conversions, versionsMap, fldPath.Child("conversions"))...)
+
+	return allErrs
+}
+
+// ValidateAPIVersionConversions validates the conversions of an APIResourceSchema against its versions.
+func ValidateAPIVersionConversions(conversions []apisv1alpha1.APIVersionConversion, versions map[string]bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, conversion := range conversions {
+		conversionPath := fldPath.Index(i)
+		if !versions[conversion.From] {
+			allErrs = append(allErrs, field.NotFound(conversionPath.Child("from"), conversion.From))
+		}
+		if !versions[conversion.To] {
+			allErrs = append(allErrs, field.NotFound(conversionPath.Child("to"), conversion.To))
+		}
+		if conversion.From == conversion.To {
+			allErrs = append(allErrs, field.Invalid(conversionPath.Child("to"), conversion.To, "must differ from from"))
+		}
+		key := conversion.From + "/" + conversion.To
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(conversionPath, key))
+		}
+		seen.Insert(key)
+
+		for j, rule := range conversion.Rules {
+			if _, err := schemaconversion.CompileRule(rule); err != nil {
+				allErrs = append(allErrs, field.Invalid(conversionPath.Child("rules").Index(j), rule, err.Error()))
+			}
+		}
+	}
 
 	return allErrs
 }
@@ -198,6 +234,12 @@ func ValidateAPIResourceVersion(ctx context.Context, version *apisv1alpha1.APIRe
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("schema"), string(version.Schema.Raw), fmt.Sprintf("invalid schema: %v", err)))
 		} else {
 			allErrs = append(allErrs, crdvalidation.ValidateCustomResourceDefinitionValidation(ctx, &crdSchemaInternal, statusEnabled, defaultValidationOpts, fldPath.Child("schema"))...)
+
+			// CEL rules are compiled and checked against the cost budget above. Without the feature gate, they
+			// would be silently dropped from the bound CRDs, and the invariants of the provider not enforced.
+			if !utilfeature.DefaultFeatureGate.Enabled(genericfeatures.CustomResourceValidationExpressions) && schemaHasXValidations(crdSchemaInternal.OpenAPIV3Schema) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("schema"), fmt.Sprintf("x-kubernetes-validations require the %s feature gate", genericfeatures.CustomResourceValidationExpressions)))
+			}
 		}
 	}
 
@@ -217,9 +259,30 @@ func ValidateAPIResourceVersion(ctx context.Context, version *apisv1alpha1.APIRe
 		}
 	}
 
+	customSubresourceNames := sets.NewString()
+	for i, sub := range version.CustomSubresources {
+		subPath := fldPath.Child("customSubresources").Index(i).Child("name")
+		for _, msg := range utilvalidation.IsDNS1123Label(sub.Name) {
+			allErrs = append(allErrs, field.Invalid(subPath, sub.Name, msg))
+		}
+		if sub.Name == "status" || sub.Name == "scale" {
+			allErrs = append(allErrs, field.Invalid(subPath, sub.Name, "must be declared in subresources"))
+		}
+		if customSubresourceNames.Has(sub.Name) {
+			allErrs = append(allErrs, field.Duplicate(subPath, sub.Name))
+		}
+		customSubresourceNames.Insert(sub.Name)
+	}
+
 	return allErrs
 }
 
+func schemaHasXValidations(s *apiextensionsinternal.JSONSchemaProps) bool {
+	return s != nil && crdvalidation.SchemaHas(s, func(s *apiextensionsinternal.JSONSchemaProps) bool {
+		return len(s.XValidations) > 0
+	})
+}
+
 // ValidateAPIResourceSchemaUpdate validates an APIResourceSchema on update.
 func ValidateAPIResourceSchemaUpdate(ctx context.Context, s, old *apisv1alpha1.APIResourceSchema) field.ErrorList {
 	allErrs := ValidateAPIResourceSchema(ctx, s)
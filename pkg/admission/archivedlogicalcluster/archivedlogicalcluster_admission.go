@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivedlogicalcluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	PluginName = "core.kcp.io/ArchivedLogicalCluster"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &archivedLogicalClusterPlugin{
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete, admission.Connect),
+			}, nil
+		})
+}
+
+// Validate rejects every write in a logical cluster with the archived annotation, including
+// those of controllers. Only the LogicalCluster itself can still be changed by the system,
+// such that the workspace can be unarchived or deleted. Once the LogicalCluster is deleting,
+// its content can be removed.
+
+type archivedLogicalClusterPlugin struct {
+	*admission.Handler
+
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+
+	// getLogicalCluster is a convenience function for easier unit testing,
+	// it reads the LogicalCluster of the given cluster.
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.ValidationInterface(&archivedLogicalClusterPlugin{})
+var _ = admission.InitializationValidator(&archivedLogicalClusterPlugin{})
+var _ = kcpinitializers.WantsKcpInformers(&archivedLogicalClusterPlugin{})
+
+func (p *archivedLogicalClusterPlugin) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	logicalCluster, err := p.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if _, archived := logicalCluster.Annotations[corev1alpha1.LogicalClusterArchivedAnnotationKey]; !archived {
+		return nil
+	}
+	if !logicalCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	if a.GetResource().GroupResource() == corev1alpha1.Resource("logicalclusters") {
+		groups := sets.NewString(a.GetUserInfo().GetGroups()...)
+		if groups.Has(kuser.SystemPrivilegedGroup) || groups.Has(bootstrap.SystemLogicalClusterAdmin) {
+			return nil
+		}
+	}
+
+	return admission.NewForbidden(a, fmt.Errorf("workspace %s is archived and read-only", clusterName))
+}
+
+func (p *archivedLogicalClusterPlugin) ValidateInitialization() error {
+	if p.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs an LogicalCluster lister")
+	}
+	return nil
+}
+
+func (p *archivedLogicalClusterPlugin) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	logicalClusterReady := informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	p.SetReadyFunc(func() bool {
+		return logicalClusterReady()
+	})
+	p.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
+	p.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return p.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archivedlogicalcluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	archived := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        corev1alpha1.LogicalClusterName,
+			Annotations: map[string]string{corev1alpha1.LogicalClusterArchivedAnnotationKey: "true"},
+		},
+	}
+	deleting := archived.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{}
+	active := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+	}
+	privileged := &kuser.DefaultInfo{Name: "admin", Groups: []string{kuser.SystemPrivilegedGroup}}
+	user := &kuser.DefaultInfo{Name: "user"}
+
+	for _, tt := range []struct {
+		name           string
+		logicalCluster *corev1alpha1.LogicalCluster
+		resource       schema.GroupVersionResource
+		operation      admission.Operation
+		userInfo       kuser.Info
+		wantErr        bool
+	}{
+		{
+			name:      "no logical cluster",
+			resource:  corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation: admission.Create,
+			userInfo:  user,
+		},
+		{
+			name:           "active logical cluster",
+			logicalCluster: active,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Create,
+			userInfo:       user,
+		},
+		{
+			name:           "create in archived logical cluster",
+			logicalCluster: archived,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Create,
+			userInfo:       user,
+			wantErr:        true,
+		},
+		{
+			name:           "delete in archived logical cluster by privileged user",
+			logicalCluster: archived,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Delete,
+			userInfo:       privileged,
+			wantErr:        true,
+		},
+		{
+			name:           "unarchive by privileged user",
+			logicalCluster: archived,
+			resource:       corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:      admission.Update,
+			userInfo:       privileged,
+		},
+		{
+			name:           "unarchive by user",
+			logicalCluster: archived,
+			resource:       corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:      admission.Update,
+			userInfo:       user,
+			wantErr:        true,
+		},
+		{
+			name:           "content deletion of deleting logical cluster",
+			logicalCluster: deleting,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Delete,
+			userInfo:       privileged,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &archivedLogicalClusterPlugin{
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "root:org", clusterName.String())
+					if tt.logicalCluster == nil {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+					}
+					return tt.logicalCluster, nil
+				},
+			}
+			a := admission.NewAttributesRecord(nil, nil, schema.GroupVersionKind{}, "", "foo", tt.resource, "", tt.operation, nil, false, tt.userInfo)
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+
+			err := p.Validate(ctx, a, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got: %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
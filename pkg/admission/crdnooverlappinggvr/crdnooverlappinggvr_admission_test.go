@@ -32,8 +32,9 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/client-go/tools/cache"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/kcperrors"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
 )
 
 func TestValidate(t *testing.T) {
@@ -95,9 +96,13 @@ func TestValidate(t *testing.T) {
 
 			a := &crdNoOverlappingGVRAdmission{Handler: admission.NewHandler(admission.Create, admission.Update), apiBindingClusterLister: apisv1alpha1listers.NewAPIBindingClusterLister(indexer)}
 			ctx := request.WithCluster(context.Background(), request.Cluster{Name: scenario.clusterName})
-			if err := a.Validate(ctx, scenario.attr, nil); (err != nil) != scenario.wantErr {
+			err := a.Validate(ctx, scenario.attr, nil)
+			if (err != nil) != scenario.wantErr {
 				t.Fatalf("Validate() error = %v, wantErr %v", err, scenario.wantErr)
 			}
+			if err != nil && !kcperrors.IsBindingConflict(err) {
+				t.Fatalf("Validate() error = %v, expected a binding conflict", err)
+			}
 		})
 	}
 }
@@ -23,8 +23,10 @@ import (
 	"k8s.io/apiserver/pkg/admission/initializer"
 	quota "k8s.io/apiserver/pkg/quota/v1"
 
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/pkg/pathclaims"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 // NewKcpInformersInitializer returns an admission plugin initializer that injects
@@ -142,3 +144,39 @@ func (i *serverShutdownChannelInitializer) Initialize(plugin admission.Interface
 		wants.SetServerShutdownChannel(i.ch)
 	}
 }
+
+// NewThrottlingExemptionsInitializer returns an admission plugin initializer that injects
+// the throttling exemptions of the shard into admission plugins.
+func NewThrottlingExemptionsInitializer(exemptions *throttling.Exemptions) *throttlingExemptionsInitializer {
+	return &throttlingExemptionsInitializer{
+		exemptions: exemptions,
+	}
+}
+
+type throttlingExemptionsInitializer struct {
+	exemptions *throttling.Exemptions
+}
+
+func (i *throttlingExemptionsInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsThrottlingExemptions); ok {
+		wants.SetThrottlingExemptions(i.exemptions)
+	}
+}
+
+// NewPathClaimsInitializer returns an admission plugin initializer that injects
+// the path claims of the root shard into admission plugins.
+func NewPathClaimsInitializer(claims *pathclaims.Claims) *pathClaimsInitializer {
+	return &pathClaimsInitializer{
+		claims: claims,
+	}
+}
+
+type pathClaimsInitializer struct {
+	claims *pathclaims.Claims
+}
+
+func (i *pathClaimsInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsPathClaims); ok {
+		wants.SetPathClaims(i.claims)
+	}
+}
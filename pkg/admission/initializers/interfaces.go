@@ -19,8 +19,10 @@ package initializers
 import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/pkg/pathclaims"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 // WantsKcpInformers interface should be implemented by admission plugins
@@ -53,3 +55,15 @@ type WantsDeepSARClient interface {
 type WantsServerShutdownChannel interface {
 	SetServerShutdownChannel(<-chan struct{})
 }
+
+// WantsThrottlingExemptions interface should be implemented by admission plugins
+// that want to have the throttling exemptions of the shard injected.
+type WantsThrottlingExemptions interface {
+	SetThrottlingExemptions(*throttling.Exemptions)
+}
+
+// WantsPathClaims interface should be implemented by admission plugins
+// that want to have the path claims of the root shard injected.
+type WantsPathClaims interface {
+	SetPathClaims(*pathclaims.Claims)
+}
@@ -40,10 +40,11 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/kcp-dev/kcp/pkg/admission/initializers"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 // PluginName is the name of this admission plugin.
@@ -143,6 +144,11 @@ func (k *KubeResourceQuota) Validate(ctx context.Context, a admission.Attributes
 		}
 	}
 
+	// requests to workspaces exempt through a ThrottlingExemption are not limited by ResourceQuotas.
+	if throttling.ExemptFrom(ctx) {
+		return nil
+	}
+
 	k.workspaceDeletionMonitorStarter.Do(func() {
 		m := newLogicalClusterDeletionMonitor(k.logicalClusterInformer, k.stopQuotaAdmissionForCluster)
 		go m.Start(k.serverDone)
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitincreaserequest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "tenancy.kcp.io/LimitIncreaseRequest"
+
+// Register registers the LimitIncreaseRequest admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &limitIncreaseRequestAdmission{
+				Handler:          admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer: delegated.NewDelegatedAuthorizer,
+			}, nil
+		})
+}
+
+// limitIncreaseRequestAdmission routes the decision about a LimitIncreaseRequest to the parent
+// workspace: setting spec.decision requires the "approve" verb on limitincreaserequests in the
+// logical cluster of the parent workspace, for the name of the workspace the request lives in.
+type limitIncreaseRequestAdmission struct {
+	*admission.Handler
+
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+	deepSARClient        kcpkubernetesclientset.ClusterInterface
+
+	createAuthorizer delegated.DelegatedAuthorizerFactory
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&limitIncreaseRequestAdmission{})
+	_ = admission.InitializationValidator(&limitIncreaseRequestAdmission{})
+	_ = kcpinitializers.WantsKcpInformers(&limitIncreaseRequestAdmission{})
+	_ = kcpinitializers.WantsDeepSARClient(&limitIncreaseRequestAdmission{})
+)
+
+// Validate ensures that only users allowed to approve in the parent workspace decide about requests.
+func (o *limitIncreaseRequestAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetResource().GroupResource() != tenancyv1alpha1.Resource("limitincreaserequests") {
+		return nil
+	}
+	// status updates of the controller do not decide
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	request, err := toLimitIncreaseRequest(a.GetObject())
+	if err != nil {
+		return err
+	}
+	if request.Spec.Decision == "" {
+		return nil
+	}
+	if a.GetOperation() == admission.Update {
+		old, err := toLimitIncreaseRequest(a.GetOldObject())
+		if err != nil {
+			return err
+		}
+		if old.Spec.Decision == request.Spec.Decision {
+			return nil
+		}
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	logicalCluster, err := o.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("parent workspace cannot be resolved: %w", err))
+	}
+	owner := logicalCluster.Spec.Owner
+	if owner == nil || owner.Resource != "workspaces" {
+		return admission.NewForbidden(a, fmt.Errorf("limit increase requests can only be decided in workspaces with a parent workspace"))
+	}
+
+	parent := logicalcluster.Name(owner.Cluster)
+	authz, err := o.createAuthorizer(parent, o.deepSARClient)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to approve in parent workspace %s: %w", parent, err))
+	}
+	approveAttr := authorizer.AttributesRecord{
+		User:            a.GetUserInfo(),
+		Verb:            "approve",
+		APIGroup:        tenancyv1alpha1.SchemeGroupVersion.Group,
+		APIVersion:      tenancyv1alpha1.SchemeGroupVersion.Version,
+		Resource:        "limitincreaserequests",
+		Name:            owner.Name,
+		ResourceRequest: true,
+	}
+	if decision, _, err := authz.Authorize(ctx, approveAttr); err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to approve in parent workspace %s: %w", parent, err))
+	} else if decision != authorizer.DecisionAllow {
+		return admission.NewForbidden(a, fmt.Errorf("setting spec.decision requires verb='approve' permission on limitincreaserequests named %q in the parent workspace", owner.Name))
+	}
+
+	return nil
+}
+
+func (o *limitIncreaseRequestAdmission) ValidateInitialization() error {
+	if o.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	if o.deepSARClient == nil {
+		return fmt.Errorf(PluginName + " plugin needs a deep SAR client")
+	}
+	return nil
+}
+
+func (o *limitIncreaseRequestAdmission) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	o.SetReadyFunc(informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced)
+	o.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
+}
+
+func (o *limitIncreaseRequestAdmission) SetDeepSARClient(client kcpkubernetesclientset.ClusterInterface) {
+	o.deepSARClient = client
+}
+
+func toLimitIncreaseRequest(obj runtime.Object) (*tenancyv1alpha1.LimitIncreaseRequest, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+	request := &tenancyv1alpha1.LimitIncreaseRequest{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, request); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to LimitIncreaseRequest: %w", err)
+	}
+	return request, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitincreaserequest
+
+import (
+	"context"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+func newRequest(decision tenancyv1alpha1.LimitIncreaseRequestDecision) *tenancyv1alpha1.LimitIncreaseRequest {
+	return &tenancyv1alpha1.LimitIncreaseRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "more-pods"},
+		Spec: tenancyv1alpha1.LimitIncreaseRequestSpec{
+			ResourceQuota: tenancyv1alpha1.LimitIncreaseRequestQuotaReference{Namespace: "default", Name: "quota"},
+			Decision:      decision,
+		},
+	}
+}
+
+func createAttr(obj *tenancyv1alpha1.LimitIncreaseRequest) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		nil,
+		tenancyv1alpha1.Kind("LimitIncreaseRequest").WithVersion("v1alpha1"),
+		"",
+		obj.Name,
+		tenancyv1alpha1.Resource("limitincreaserequests").WithVersion("v1alpha1"),
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
+func updateAttr(obj, old *tenancyv1alpha1.LimitIncreaseRequest, subresource string) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		helpers.ToUnstructuredOrDie(old),
+		tenancyv1alpha1.Kind("LimitIncreaseRequest").WithVersion("v1alpha1"),
+		"",
+		obj.Name,
+		tenancyv1alpha1.Resource("limitincreaserequests").WithVersion("v1alpha1"),
+		subresource,
+		admission.Update,
+		&metav1.UpdateOptions{},
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		clusterName   logicalcluster.Name
+		attr          admission.Attributes
+		authzDecision authorizer.Decision
+
+		wantErr         bool
+		wantAuthzParent logicalcluster.Name
+	}{
+		"create without decision": {
+			clusterName: "child",
+			attr:        createAttr(newRequest("")),
+		},
+		"create with decision, approver in parent": {
+			clusterName:     "child",
+			attr:            createAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved)),
+			authzDecision:   authorizer.DecisionAllow,
+			wantAuthzParent: "parent",
+		},
+		"create with decision, no approver in parent": {
+			clusterName:     "child",
+			attr:            createAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved)),
+			authzDecision:   authorizer.DecisionDeny,
+			wantErr:         true,
+			wantAuthzParent: "parent",
+		},
+		"update changing the decision, no approver in parent": {
+			clusterName:     "child",
+			attr:            updateAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestDenied), newRequest(""), ""),
+			authzDecision:   authorizer.DecisionDeny,
+			wantErr:         true,
+			wantAuthzParent: "parent",
+		},
+		"update keeping the decision": {
+			clusterName: "child",
+			attr:        updateAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved), newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved), ""),
+		},
+		"status update": {
+			clusterName: "child",
+			attr:        updateAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved), newRequest(""), "status"),
+		},
+		"decision without parent workspace": {
+			clusterName:   "root",
+			attr:          createAttr(newRequest(tenancyv1alpha1.LimitIncreaseRequestApproved)),
+			authzDecision: authorizer.DecisionAllow,
+			wantErr:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+			require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}},
+			}))
+			require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "child"}},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					Owner: &corev1alpha1.LogicalClusterOwner{APIVersion: "tenancy.kcp.io/v1beta1", Resource: "workspaces", Cluster: "parent", Name: "team"},
+				},
+			}))
+
+			var authzParent logicalcluster.Name
+			o := &limitIncreaseRequestAdmission{
+				Handler:              admission.NewHandler(admission.Create, admission.Update),
+				logicalClusterLister: corev1alpha1listers.NewLogicalClusterClusterLister(indexer),
+				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface) (authorizer.Authorizer, error) {
+					authzParent = clusterName
+					return &fakeAuthorizer{tt.authzDecision}, nil
+				},
+			}
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: tt.clusterName})
+			err := o.Validate(ctx, tt.attr, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantAuthzParent, authzParent)
+		})
+	}
+}
+
+type fakeAuthorizer struct {
+	decision authorizer.Decision
+}
+
+func (a *fakeAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorized authorizer.Decision, reason string, err error) {
+	if attr.GetVerb() != "approve" || attr.GetName() != "team" {
+		return authorizer.DecisionDeny, "unexpected attributes", nil
+	}
+	return a.decision, "reason", nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathuniqueness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/pathclaims"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+const (
+	PluginName = "core.kcp.io/PathUniqueness"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &pathUniquenessPlugin{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}, nil
+		})
+}
+
+// Validate claims the canonical path of a LogicalCluster in the path claims on the root shard, and
+// rejects the LogicalCluster if another logical cluster on any shard has claimed the path before.
+// Without it, two shards could concurrently create logical clusters with the same path, and path
+// lookups, e.g. of APIExports referenced by path, would resolve nondeterministically.
+
+type pathUniquenessPlugin struct {
+	*admission.Handler
+
+	claim   func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error
+	release func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.ValidationInterface(&pathUniquenessPlugin{})
+var _ = admission.InitializationValidator(&pathUniquenessPlugin{})
+var _ = kcpinitializers.WantsPathClaims(&pathUniquenessPlugin{})
+
+func (p *pathUniquenessPlugin) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetResource().GroupResource() != corev1alpha1.Resource("logicalclusters") {
+		return nil
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	// system logical clusters exist on every shard and are not reachable by path.
+	if strings.HasPrefix(clusterName.String(), "system:") {
+		return nil
+	}
+
+	path := pathAnnotation(a.GetObject())
+	var oldPath string
+	if a.GetOperation() == admission.Update {
+		if oldPath = pathAnnotation(a.GetOldObject()); oldPath == path {
+			return nil
+		}
+	}
+	if path == "" {
+		return nil
+	}
+
+	if err := p.claim(ctx, logicalcluster.NewPath(path), clusterName); err != nil {
+		var conflict *pathclaims.ConflictError
+		if errors.As(err, &conflict) {
+			return admission.NewForbidden(a, fmt.Errorf("path %q is already used by logical cluster %q", path, conflict.Holder))
+		}
+		return apierrors.NewInternalError(err)
+	}
+
+	if oldPath != "" {
+		if err := p.release(ctx, logicalcluster.NewPath(oldPath), clusterName); err != nil {
+			// a claim that is not released blocks the old path until the logical cluster is deleted.
+			klog.FromContext(ctx).Error(err, "failed to release old path", "path", oldPath)
+		}
+	}
+
+	return nil
+}
+
+func pathAnnotation(obj interface{}) string {
+	if obj == nil {
+		return ""
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return m.GetAnnotations()[core.LogicalClusterPathAnnotationKey]
+}
+
+func (p *pathUniquenessPlugin) ValidateInitialization() error {
+	if p.claim == nil {
+		return fmt.Errorf(PluginName + " plugin needs path claims")
+	}
+	return nil
+}
+
+func (p *pathUniquenessPlugin) SetPathClaims(claims *pathclaims.Claims) {
+	p.claim = claims.Claim
+	p.release = claims.Release
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathuniqueness
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/pathclaims"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func newLogicalCluster(path string) *corev1alpha1.LogicalCluster {
+	lc := &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+	}
+	if path != "" {
+		lc.Annotations = map[string]string{core.LogicalClusterPathAnnotationKey: path}
+	}
+	return lc
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		cluster      logicalcluster.Name
+		resource     schema.GroupVersionResource
+		operation    admission.Operation
+		obj, oldObj  runtime.Object
+		claimErr     error
+		wantClaimed  string
+		wantReleased string
+		wantErr      bool
+		wantInternal bool
+	}{
+		{
+			name:      "other resource",
+			cluster:   "cluster1",
+			resource:  corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation: admission.Create,
+			obj:       &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: "root:org"}}},
+		},
+		{
+			name:        "create claims the path",
+			cluster:     "cluster1",
+			resource:    corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:   admission.Create,
+			obj:         newLogicalCluster("root:org"),
+			wantClaimed: "root:org",
+		},
+		{
+			name:      "create without path",
+			cluster:   "cluster1",
+			resource:  corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation: admission.Create,
+			obj:       newLogicalCluster(""),
+		},
+		{
+			name:      "system logical cluster",
+			cluster:   "system:admin",
+			resource:  corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation: admission.Create,
+			obj:       newLogicalCluster("system:admin"),
+		},
+		{
+			name:        "path claimed by another logical cluster",
+			cluster:     "cluster1",
+			resource:    corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:   admission.Create,
+			obj:         newLogicalCluster("root:org"),
+			claimErr:    &pathclaims.ConflictError{Path: logicalcluster.NewPath("root:org"), Holder: "cluster2"},
+			wantClaimed: "root:org",
+			wantErr:     true,
+		},
+		{
+			name:         "claims cannot be checked",
+			cluster:      "cluster1",
+			resource:     corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:    admission.Create,
+			obj:          newLogicalCluster("root:org"),
+			claimErr:     errors.New("root shard unavailable"),
+			wantClaimed:  "root:org",
+			wantErr:      true,
+			wantInternal: true,
+		},
+		{
+			name:      "update without path change",
+			cluster:   "cluster1",
+			resource:  corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation: admission.Update,
+			obj:       newLogicalCluster("root:org"),
+			oldObj:    newLogicalCluster("root:org"),
+		},
+		{
+			name:         "update changing the path claims the new and releases the old path",
+			cluster:      "cluster1",
+			resource:     corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:    admission.Update,
+			obj:          newLogicalCluster("root:new"),
+			oldObj:       newLogicalCluster("root:org"),
+			wantClaimed:  "root:new",
+			wantReleased: "root:org",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var claimed, released string
+			p := &pathUniquenessPlugin{
+				claim: func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error {
+					require.Equal(t, tt.cluster, clusterName)
+					claimed = path.String()
+					return tt.claimErr
+				},
+				release: func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error {
+					require.Equal(t, tt.cluster, clusterName)
+					released = path.String()
+					return nil
+				},
+			}
+			a := admission.NewAttributesRecord(tt.obj, tt.oldObj, schema.GroupVersionKind{}, "", corev1alpha1.LogicalClusterName, tt.resource, "", tt.operation, nil, false, nil)
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: tt.cluster})
+
+			err := p.Validate(ctx, a, nil)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.Equal(t, tt.wantReleased, released)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.wantInternal {
+				require.True(t, apierrors.IsInternalError(err), "expected internal error, got: %v", err)
+			} else {
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got: %v", err)
+			}
+		})
+	}
+}
@@ -23,15 +23,20 @@ import (
 	"io"
 	"strings"
 
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/informerfactoryhack"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	"github.com/kcp-dev/kcp/pkg/permissionclaim"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
 )
 
 const (
@@ -48,6 +53,11 @@ type mutatingPermissionClaims struct {
 	*admission.Handler
 
 	apiBindingsHasSynced cache.InformerSynced
+	namespacesHasSynced  cache.InformerSynced
+
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer
+	apiExportInformer  apisv1alpha1informers.APIExportClusterInformer
+	namespaceInformer  kcpcorev1informers.NamespaceClusterInformer
 
 	permissionClaimLabeler *permissionclaim.Labeler
 }
@@ -67,7 +77,7 @@ func NewMutatingPermissionClaims() admission.MutationInterface {
 
 	p.SetReadyFunc(
 		func() bool {
-			return p.apiBindingsHasSynced()
+			return p.apiBindingsHasSynced() && p.namespacesHasSynced()
 		},
 	)
 
@@ -89,7 +99,7 @@ func (m *mutatingPermissionClaims) Admit(ctx context.Context, a admission.Attrib
 		return err
 	}
 
-	expectedLabels, err := m.permissionClaimLabeler.LabelsFor(ctx, clusterName, a.GetResource().GroupResource(), a.GetName())
+	expectedLabels, err := m.permissionClaimLabeler.LabelsFor(ctx, clusterName, a.GetResource().GroupResource(), a.GetNamespace(), a.GetName())
 	if err != nil {
 		return err
 	}
@@ -129,7 +139,7 @@ func (m *mutatingPermissionClaims) Validate(ctx context.Context, a admission.Att
 		return err
 	}
 
-	expectedLabels, err := m.permissionClaimLabeler.LabelsFor(ctx, clusterName, a.GetResource().GroupResource(), a.GetName())
+	expectedLabels, err := m.permissionClaimLabeler.LabelsFor(ctx, clusterName, a.GetResource().GroupResource(), a.GetNamespace(), a.GetName())
 	if err != nil {
 		return err
 	}
@@ -165,18 +175,30 @@ func (m *mutatingPermissionClaims) Validate(ctx context.Context, a admission.Att
 func (m *mutatingPermissionClaims) SetKcpInformers(f kcpinformers.SharedInformerFactory) {
 	m.apiBindingsHasSynced = f.Apis().V1alpha1().APIBindings().Informer().HasSynced
 
-	m.permissionClaimLabeler = permissionclaim.NewLabeler(
-		f.Apis().V1alpha1().APIBindings(),
-		f.Apis().V1alpha1().APIExports(),
-	)
+	m.apiBindingInformer = f.Apis().V1alpha1().APIBindings()
+	m.apiExportInformer = f.Apis().V1alpha1().APIExports()
+}
+
+// SetExternalKubeInformerFactory implements the WantsExternalKubeInformerFactory interface.
+func (m *mutatingPermissionClaims) SetExternalKubeInformerFactory(f informers.SharedInformerFactory) {
+	m.namespacesHasSynced = informerfactoryhack.Unwrap(f).Core().V1().Namespaces().Informer().HasSynced
+
+	m.namespaceInformer = informerfactoryhack.Unwrap(f).Core().V1().Namespaces()
 }
 
 func (m *mutatingPermissionClaims) ValidateInitialization() error {
 	if m.apiBindingsHasSynced == nil {
 		return errors.New("missing apiBindingsHasSynced")
 	}
-	if m.permissionClaimLabeler == nil {
-		return errors.New("missing permissionClaimLabeler")
+	if m.namespacesHasSynced == nil {
+		return errors.New("missing namespacesHasSynced")
 	}
+	if m.apiBindingInformer == nil || m.apiExportInformer == nil || m.namespaceInformer == nil {
+		return errors.New("missing informers")
+	}
+
+	// the labeler needs informers of both the kcp and kube informer factories, which
+	// are handed in by different initializers.
+	m.permissionClaimLabeler = permissionclaim.NewLabeler(m.apiBindingInformer, m.apiExportInformer, m.namespaceInformer)
 	return nil
 }
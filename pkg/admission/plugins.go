@@ -42,29 +42,41 @@ import (
 	"github.com/kcp-dev/kcp/pkg/admission/apibinding"
 	"github.com/kcp-dev/kcp/pkg/admission/apibindingfinalizer"
 	"github.com/kcp-dev/kcp/pkg/admission/apiexport"
+	"github.com/kcp-dev/kcp/pkg/admission/apiexportendpointslice"
 	"github.com/kcp-dev/kcp/pkg/admission/apiresourceschema"
+	"github.com/kcp-dev/kcp/pkg/admission/archivedlogicalcluster"
 	"github.com/kcp-dev/kcp/pkg/admission/crdnooverlappinggvr"
 	"github.com/kcp-dev/kcp/pkg/admission/kubequota"
+	"github.com/kcp-dev/kcp/pkg/admission/limitincreaserequest"
 	kcplimitranger "github.com/kcp-dev/kcp/pkg/admission/limitranger"
 	"github.com/kcp-dev/kcp/pkg/admission/logicalcluster"
 	"github.com/kcp-dev/kcp/pkg/admission/logicalclusterfinalizer"
 	kcpmutatingwebhook "github.com/kcp-dev/kcp/pkg/admission/mutatingwebhook"
 	workspacenamespacelifecycle "github.com/kcp-dev/kcp/pkg/admission/namespacelifecycle"
 	"github.com/kcp-dev/kcp/pkg/admission/pathannotation"
+	"github.com/kcp-dev/kcp/pkg/admission/pathuniqueness"
 	"github.com/kcp-dev/kcp/pkg/admission/permissionclaims"
+	"github.com/kcp-dev/kcp/pkg/admission/recoverymode"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedcrdannotations"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedcrdgroups"
 	"github.com/kcp-dev/kcp/pkg/admission/reservedmetadata"
 	"github.com/kcp-dev/kcp/pkg/admission/reservednames"
+	"github.com/kcp-dev/kcp/pkg/admission/retentionpolicy"
 	"github.com/kcp-dev/kcp/pkg/admission/shard"
+	"github.com/kcp-dev/kcp/pkg/admission/throttlingexemption"
 	kcpvalidatingwebhook "github.com/kcp-dev/kcp/pkg/admission/validatingwebhook"
 	"github.com/kcp-dev/kcp/pkg/admission/workspace"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacedryrun"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacequota"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetombstone"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetype"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
 )
 
 // AllOrderedPlugins is the list of all the plugins in order.
 var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
+	recoverymode.PluginName,
+	archivedlogicalcluster.PluginName,
 	workspacenamespacelifecycle.PluginName,
 	apiresourceschema.PluginName,
 	workspace.PluginName,
@@ -72,8 +84,11 @@ var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
 	shard.PluginName,
 	workspacetype.PluginName,
 	workspacetypeexists.PluginName,
+	workspacedryrun.PluginName,
+	workspacetombstone.PluginName,
 	logicalcluster.PluginName,
 	apiexport.PluginName,
+	apiexportendpointslice.PluginName,
 	apibinding.PluginName,
 	apibindingfinalizer.PluginName,
 	kcpvalidatingwebhook.PluginName,
@@ -86,7 +101,12 @@ var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
 	reservedmetadata.PluginName,
 	permissionclaims.PluginName,
 	pathannotation.PluginName,
+	pathuniqueness.PluginName,
 	kubequota.PluginName,
+	retentionpolicy.PluginName,
+	limitincreaserequest.PluginName,
+	workspacequota.PluginName,
+	throttlingexemption.PluginName,
 )
 
 func beforeWebhooks(recommended []string, plugins ...string) []string {
@@ -109,9 +129,12 @@ func RegisterAllKcpAdmissionPlugins(plugins *admission.Plugins) {
 	shard.Register(plugins)
 	workspacetype.Register(plugins)
 	workspacetypeexists.Register(plugins)
+	workspacedryrun.Register(plugins)
+	workspacetombstone.Register(plugins)
 	logicalcluster.Register(plugins)
 	apiresourceschema.Register(plugins)
 	apiexport.Register(plugins)
+	apiexportendpointslice.Register(plugins)
 	apibinding.Register(plugins)
 	apibindingfinalizer.Register(plugins)
 	workspacenamespacelifecycle.Register(plugins)
@@ -125,7 +148,14 @@ func RegisterAllKcpAdmissionPlugins(plugins *admission.Plugins) {
 	reservedmetadata.Register(plugins)
 	permissionclaims.Register(plugins)
 	pathannotation.Register(plugins)
+	pathuniqueness.Register(plugins)
 	kubequota.Register(plugins)
+	retentionpolicy.Register(plugins)
+	limitincreaserequest.Register(plugins)
+	archivedlogicalcluster.Register(plugins)
+	recoverymode.Register(plugins)
+	workspacequota.Register(plugins)
+	throttlingexemption.Register(plugins)
 }
 
 var defaultOnPluginsInKcp = sets.NewString(
@@ -141,9 +171,12 @@ var defaultOnPluginsInKcp = sets.NewString(
 	shard.PluginName,
 	workspacetype.PluginName,
 	workspacetypeexists.PluginName,
+	workspacedryrun.PluginName,
+	workspacetombstone.PluginName,
 	logicalcluster.PluginName,
 	apiresourceschema.PluginName,
 	apiexport.PluginName,
+	apiexportendpointslice.PluginName,
 	apibinding.PluginName,
 	apibindingfinalizer.PluginName,
 	kcpvalidatingwebhook.PluginName,
@@ -153,7 +186,13 @@ var defaultOnPluginsInKcp = sets.NewString(
 	reservednames.PluginName,
 	permissionclaims.PluginName,
 	pathannotation.PluginName,
+	pathuniqueness.PluginName,
 	kubequota.PluginName,
+	retentionpolicy.PluginName,
+	limitincreaserequest.PluginName,
+	archivedlogicalcluster.PluginName,
+	workspacequota.PluginName,
+	throttlingexemption.PluginName,
 )
 
 // defaultOnKubePluginsInKube is a copy of kubeapiserveroptions.defaultOnKubePlugins.
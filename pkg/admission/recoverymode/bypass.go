@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recoverymode
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apiserver/pkg/admission"
+	kaudit "k8s.io/apiserver/pkg/audit"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	// bypassAuditAnnotation is added to the audit event of every request that skipped admission
+	// in a logical cluster in recovery mode.
+	bypassAuditAnnotation = "recovery.core.kcp.io/bypassed-admission"
+	// reasonAuditAnnotation carries the value of the recovery annotation, i.e. the reason given
+	// by the operator.
+	reasonAuditAnnotation = "recovery.core.kcp.io/reason"
+)
+
+// WithBypass wraps the admission chain such that requests by members of system:masters in a
+// logical cluster in recovery mode skip all admission plugins, including webhooks. Every skipped
+// request is logged and annotated in the audit log.
+func WithBypass(delegate admission.Interface, logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister) admission.Interface {
+	return &bypass{
+		delegate: delegate,
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+	}
+}
+
+type bypass struct {
+	delegate admission.Interface
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+var _ = admission.MutationInterface(&bypass{})
+var _ = admission.ValidationInterface(&bypass{})
+
+func (b *bypass) Handles(operation admission.Operation) bool {
+	return b.delegate.Handles(operation)
+}
+
+func (b *bypass) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if b.bypassed(ctx, a) {
+		return nil
+	}
+	if mutator, ok := b.delegate.(admission.MutationInterface); ok {
+		return mutator.Admit(ctx, a, o)
+	}
+	return nil
+}
+
+func (b *bypass) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	if b.bypassed(ctx, a) {
+		return nil
+	}
+	if validator, ok := b.delegate.(admission.ValidationInterface); ok {
+		return validator.Validate(ctx, a, o)
+	}
+	return nil
+}
+
+// bypassed returns true if the request skips admission. Admit and Validate are called for the
+// same request, such that it is logged and audited in both stages.
+func (b *bypass) bypassed(ctx context.Context, a admission.Attributes) bool {
+	if a.GetUserInfo() == nil || !isPrivileged(a.GetUserInfo()) {
+		return false
+	}
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return false
+	}
+	logicalCluster, err := b.getLogicalCluster(clusterName)
+	if err != nil {
+		return false
+	}
+	reason, ok := logicalCluster.Annotations[corev1alpha1.LogicalClusterRecoveryAnnotationKey]
+	if !ok {
+		return false
+	}
+
+	kaudit.AddAuditAnnotations(ctx,
+		bypassAuditAnnotation, "true",
+		reasonAuditAnnotation, reason,
+	)
+	klog.FromContext(ctx).Info("admission bypassed in recovery mode",
+		"cluster", clusterName,
+		"user", a.GetUserInfo().GetName(),
+		"operation", a.GetOperation(),
+		"resource", a.GetResource().GroupResource(),
+		"subresource", a.GetSubresource(),
+		"namespace", a.GetNamespace(),
+		"name", a.GetName(),
+		"reason", reason,
+	)
+	return true
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recoverymode
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	PluginName = "core.kcp.io/RecoveryMode"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &recoveryModePlugin{
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete, admission.Connect),
+			}, nil
+		})
+}
+
+// Validate makes a logical cluster in recovery mode read-only, and restricts entering and leaving
+// recovery mode to members of system:masters. The repair requests of system:masters in a logical
+// cluster in recovery mode never reach this plugin, they skip admission through WithBypass.
+
+type recoveryModePlugin struct {
+	*admission.Handler
+
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+
+	// getLogicalCluster is a convenience function for easier unit testing,
+	// it reads the LogicalCluster of the given cluster.
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.ValidationInterface(&recoveryModePlugin{})
+var _ = admission.InitializationValidator(&recoveryModePlugin{})
+var _ = kcpinitializers.WantsKcpInformers(&recoveryModePlugin{})
+
+func (p *recoveryModePlugin) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if a.GetResource().GroupResource() == corev1alpha1.Resource("logicalclusters") && changesRecoveryAnnotation(a) && !isPrivileged(a.GetUserInfo()) {
+		return admission.NewForbidden(a, fmt.Errorf("only members of %s can change the %s annotation", kuser.SystemPrivilegedGroup, corev1alpha1.LogicalClusterRecoveryAnnotationKey))
+	}
+
+	logicalCluster, err := p.getLogicalCluster(clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if reason, ok := logicalCluster.Annotations[corev1alpha1.LogicalClusterRecoveryAnnotationKey]; ok {
+		return admission.NewForbidden(a, fmt.Errorf("workspace %s is in recovery mode and read-only: %s", clusterName, reason))
+	}
+
+	return nil
+}
+
+// changesRecoveryAnnotation returns true if the request sets, changes or removes the recovery
+// annotation of a LogicalCluster.
+func changesRecoveryAnnotation(a admission.Attributes) bool {
+	annotation := func(obj interface{}) (string, bool) {
+		if obj == nil {
+			return "", false
+		}
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return "", false
+		}
+		value, ok := m.GetAnnotations()[corev1alpha1.LogicalClusterRecoveryAnnotationKey]
+		return value, ok
+	}
+
+	switch a.GetOperation() {
+	case admission.Create:
+		_, ok := annotation(a.GetObject())
+		return ok
+	case admission.Update:
+		oldValue, oldOK := annotation(a.GetOldObject())
+		newValue, newOK := annotation(a.GetObject())
+		return oldOK != newOK || oldValue != newValue
+	}
+	return false
+}
+
+func isPrivileged(user kuser.Info) bool {
+	return sets.NewString(user.GetGroups()...).Has(kuser.SystemPrivilegedGroup)
+}
+
+func (p *recoveryModePlugin) ValidateInitialization() error {
+	if p.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs an LogicalCluster lister")
+	}
+	return nil
+}
+
+func (p *recoveryModePlugin) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	logicalClusterReady := informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	p.SetReadyFunc(func() bool {
+		return logicalClusterReady()
+	})
+	p.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
+	p.getLogicalCluster = func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		return p.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recoverymode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+var (
+	recovering = &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        corev1alpha1.LogicalClusterName,
+			Annotations: map[string]string{corev1alpha1.LogicalClusterRecoveryAnnotationKey: "stuck finalizers"},
+		},
+	}
+	healthy = &corev1alpha1.LogicalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+	}
+
+	privileged = &kuser.DefaultInfo{Name: "admin", Groups: []string{kuser.SystemPrivilegedGroup}}
+	user       = &kuser.DefaultInfo{Name: "user"}
+)
+
+func getLogicalCluster(t *testing.T, logicalCluster *corev1alpha1.LogicalCluster) func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+	t.Helper()
+	return func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+		require.Equal(t, "root:org", clusterName.String())
+		if logicalCluster == nil {
+			return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+		}
+		return logicalCluster, nil
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		logicalCluster *corev1alpha1.LogicalCluster
+		resource       schema.GroupVersionResource
+		operation      admission.Operation
+		obj, oldObj    runtime.Object
+		userInfo       kuser.Info
+		wantErr        bool
+	}{
+		{
+			name:      "no logical cluster",
+			resource:  corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation: admission.Create,
+			userInfo:  user,
+		},
+		{
+			name:           "healthy logical cluster",
+			logicalCluster: healthy,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Create,
+			userInfo:       user,
+		},
+		{
+			name:           "update in logical cluster in recovery mode",
+			logicalCluster: recovering,
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			operation:      admission.Update,
+			userInfo:       user,
+			wantErr:        true,
+		},
+		{
+			name:           "entering recovery mode by privileged user",
+			logicalCluster: healthy,
+			resource:       corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:      admission.Update,
+			obj:            recovering,
+			oldObj:         healthy,
+			userInfo:       privileged,
+		},
+		{
+			name:           "entering recovery mode by user",
+			logicalCluster: healthy,
+			resource:       corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:      admission.Update,
+			obj:            recovering,
+			oldObj:         healthy,
+			userInfo:       user,
+			wantErr:        true,
+		},
+		{
+			name:           "other update of the logical cluster by user",
+			logicalCluster: healthy,
+			resource:       corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			operation:      admission.Update,
+			obj:            healthy,
+			oldObj:         healthy,
+			userInfo:       user,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &recoveryModePlugin{getLogicalCluster: getLogicalCluster(t, tt.logicalCluster)}
+			a := admission.NewAttributesRecord(tt.obj, tt.oldObj, schema.GroupVersionKind{}, "", "foo", tt.resource, "", tt.operation, nil, false, tt.userInfo)
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+
+			err := p.Validate(ctx, a, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got: %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+type rejectAll struct{}
+
+func (rejectAll) Handles(admission.Operation) bool { return true }
+func (rejectAll) Admit(context.Context, admission.Attributes, admission.ObjectInterfaces) error {
+	return errors.New("rejected")
+}
+func (rejectAll) Validate(context.Context, admission.Attributes, admission.ObjectInterfaces) error {
+	return errors.New("rejected")
+}
+
+func TestBypass(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		logicalCluster *corev1alpha1.LogicalCluster
+		userInfo       kuser.Info
+		wantBypass     bool
+	}{
+		{name: "no logical cluster", userInfo: privileged},
+		{name: "healthy logical cluster", logicalCluster: healthy, userInfo: privileged},
+		{name: "recovery mode by user", logicalCluster: recovering, userInfo: user},
+		{name: "recovery mode by privileged user", logicalCluster: recovering, userInfo: privileged, wantBypass: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bypass{delegate: rejectAll{}, getLogicalCluster: getLogicalCluster(t, tt.logicalCluster)}
+			a := admission.NewAttributesRecord(nil, nil, schema.GroupVersionKind{}, "", "foo", corev1.SchemeGroupVersion.WithResource("configmaps"), "", admission.Update, nil, false, tt.userInfo)
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+
+			admitErr := b.Admit(ctx, a, nil)
+			validateErr := b.Validate(ctx, a, nil)
+			if tt.wantBypass {
+				require.NoError(t, admitErr)
+				require.NoError(t, validateErr)
+				return
+			}
+			require.Error(t, admitErr)
+			require.Error(t, validateErr)
+		})
+	}
+}
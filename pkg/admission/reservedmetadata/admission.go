@@ -28,12 +28,12 @@ import (
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/utils/strings/slices"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/authorization"
 	"github.com/kcp-dev/kcp/pkg/syncer"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1"
 )
 
 const (
@@ -44,9 +44,13 @@ var (
 	annotationAllowList = []string{
 		workloadv1alpha1.AnnotationSkipDefaultObjectCreation,
 		syncer.AdvancedSchedulingFeatureAnnotation,
-		tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey, // protected by workspace admission from non-system:admins
-		authorization.RequiredGroupsAnnotationKey,               // protected by workspace admission from non-system:admins
-		core.LogicalClusterPathAnnotationKey,                    // protected by pathannoation admission from non-system:admins
+		tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey,           // protected by workspace admission from non-system:admins
+		tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey,           // protected by workspace admission from non-system:admins
+		tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey,            // only set by workspace dry-run admission on dry-run requests
+		authorization.RequiredGroupsAnnotationKey,                         // protected by workspace admission from non-system:admins
+		core.LogicalClusterPathAnnotationKey,                              // protected by pathannoation admission from non-system:admins
+		tenancyv1alpha1.WorkspaceQuotaNotificationThresholdsAnnotationKey, // owned by the tenant
+		tenancyv1alpha1.WorkspaceQuotaNotificationWebhookAnnotationKey,    // owned by the tenant
 	}
 	labelAllowList = []string{
 		apisv1alpha1.APIExportPermissionClaimLabelPrefix + "*", // protected by the permissionclaim admission plugin
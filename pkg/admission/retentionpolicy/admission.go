@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retentionpolicy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/kcp-dev/kcp/pkg/admission/workspace"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "tenancy.kcp.io/RetentionPolicy"
+
+// Register registers the RetentionPolicy admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &retentionPolicyAdmission{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}, nil
+		})
+}
+
+// retentionPolicyAdmission records the user who last changed a RetentionPolicy. The retention
+// janitor only deletes objects this user is allowed to delete.
+type retentionPolicyAdmission struct {
+	*admission.Handler
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.MutationInterface(&retentionPolicyAdmission{})
+var _ = admission.ValidationInterface(&retentionPolicyAdmission{})
+
+// Admit sets the owner annotation to the requesting user.
+func (o *retentionPolicyAdmission) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	u, policy, err := toRetentionPolicy(a)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	owner, err := workspace.WorkspaceOwnerAnnotationValue(a.GetUserInfo())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if policy.Annotations == nil {
+		policy.Annotations = map[string]string{}
+	}
+	policy.Annotations[tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey] = owner
+
+	// write back
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+
+	return nil
+}
+
+// Validate ensures that the owner annotation matches the requesting user.
+func (o *retentionPolicyAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	_, policy, err := toRetentionPolicy(a)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	owner, err := workspace.WorkspaceOwnerAnnotationValue(a.GetUserInfo())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if got := policy.Annotations[tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey]; got != owner {
+		return admission.NewForbidden(a, fmt.Errorf("expected user annotation %s=%s", tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey, owner))
+	}
+
+	return nil
+}
+
+func toRetentionPolicy(a admission.Attributes) (*unstructured.Unstructured, *tenancyv1alpha1.RetentionPolicy, error) {
+	if a.GetResource().GroupResource() != tenancyv1alpha1.Resource("retentionpolicies") {
+		return nil, nil, nil
+	}
+	// status updates of the janitor do not change the owner
+	if a.GetSubresource() != "" {
+		return nil, nil, nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	policy := &tenancyv1alpha1.RetentionPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, policy); err != nil {
+		return nil, nil, fmt.Errorf("failed to convert unstructured to RetentionPolicy: %w", err)
+	}
+
+	return u, policy, nil
+}
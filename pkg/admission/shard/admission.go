@@ -23,12 +23,15 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/network"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
-// Default the external and virtual URLs with the base URL if they are not set.
+// Default the external and virtual URLs with the base URL if they are not set, and validate
+// that the URLs are well-formed, in particular that IPv6 addresses are enclosed in brackets.
 
 const (
 	PluginName = "tenancy.kcp.io/Shard"
@@ -49,6 +52,7 @@ type shard struct {
 
 // Ensure that the required admission interfaces are implemented.
 var _ = admission.MutationInterface(&shard{})
+var _ = admission.ValidationInterface(&shard{})
 
 // Admit sets.
 func (o *shard) Admit(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
@@ -81,3 +85,54 @@ func (o *shard) Admit(_ context.Context, a admission.Attributes, _ admission.Obj
 
 	return nil
 }
+
+// Validate checks the URLs of the shard. URLs which are not changed by an update are not checked,
+// such that shards created before the validation can still be updated.
+func (o *shard) Validate(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
+	if a.GetResource().GroupResource() != corev1alpha1.Resource("shards") {
+		return nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	wShard := &corev1alpha1.Shard{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, wShard); err != nil {
+		return fmt.Errorf("failed to convert unstructured to Shard: %w", err)
+	}
+
+	old := &corev1alpha1.Shard{}
+	if a.GetOperation() == admission.Update {
+		u, ok = a.GetOldObject().(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected type %T", a.GetOldObject())
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, old); err != nil {
+			return fmt.Errorf("failed to convert unstructured to Shard: %w", err)
+		}
+	}
+
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+	for _, u := range []struct {
+		path       *field.Path
+		value, old string
+	}{
+		{specPath.Child("baseURL"), wShard.Spec.BaseURL, old.Spec.BaseURL},
+		{specPath.Child("externalURL"), wShard.Spec.ExternalURL, old.Spec.ExternalURL},
+		{specPath.Child("virtualWorkspaceURL"), wShard.Spec.VirtualWorkspaceURL, old.Spec.VirtualWorkspaceURL},
+	} {
+		if u.value == "" || u.value == u.old {
+			continue
+		}
+		if _, err := network.ParseServerURL(u.value); err != nil {
+			errs = append(errs, field.Invalid(u.path, u.value, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return admission.NewForbidden(a, errs.ToAggregate())
+	}
+
+	return nil
+}
@@ -30,8 +30,8 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 func createAttr(shard *corev1alpha1.Shard) admission.Attributes {
@@ -173,3 +173,76 @@ func TestAdmit(t *testing.T) {
 		}
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		attr    admission.Attributes
+		wantErr string
+	}{
+		{
+			name: "host names",
+			attr: createAttr(newShard().baseURL("https://base:6443").externalURL("https://external").virtualWorkspaceURL("https://virtual:7444").Shard),
+		},
+		{
+			name: "IPv6 addresses in brackets",
+			attr: createAttr(newShard().baseURL("https://[2001:db8::1]:6443").externalURL("https://[2001:db8::2]").virtualWorkspaceURL("https://[2001:db8::1]:7444").Shard),
+		},
+		{
+			name:    "IPv6 address without brackets",
+			attr:    createAttr(newShard().baseURL("https://2001:db8::1:6443").Shard),
+			wantErr: `spec.baseURL: Invalid value: "https://2001:db8::1:6443": IPv6 address in URL "https://2001:db8::1:6443" must be enclosed in brackets`,
+		},
+		{
+			name:    "URL without scheme",
+			attr:    createAttr(newShard().baseURL("https://base").virtualWorkspaceURL("virtual:7444").Shard),
+			wantErr: `spec.virtualWorkspaceURL: Invalid value: "virtual:7444": URL "virtual:7444" must have scheme https or http`,
+		},
+		{
+			name: "unchanged invalid URL on update",
+			attr: updateAttr(
+				newShard().baseURL("https://2001:db8::1:6443").externalURL("https://external").Shard,
+				newShard().baseURL("https://2001:db8::1:6443").Shard,
+			),
+		},
+		{
+			name: "changed invalid URL on update",
+			attr: updateAttr(
+				newShard().baseURL("https://base").externalURL("https://2001:db8::1:6443").Shard,
+				newShard().baseURL("https://base").externalURL("https://external").Shard,
+			),
+			wantErr: `spec.externalURL: Invalid value: "https://2001:db8::1:6443": IPv6 address in URL "https://2001:db8::1:6443" must be enclosed in brackets`,
+		},
+		{
+			name: "other resources",
+			attr: admission.NewAttributesRecord(
+				&unstructured.Unstructured{},
+				nil,
+				tenancyv1alpha1.Kind("ClusterWorkspace").WithVersion("v1alpha1"),
+				"",
+				"test",
+				tenancyv1alpha1.Resource("clusterworkspaces").WithVersion("v1alpha1"),
+				"",
+				admission.Create,
+				&metav1.CreateOptions{},
+				false,
+				&user.DefaultInfo{},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &shard{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}
+
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root"})
+			err := o.Validate(ctx, tt.attr, nil)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
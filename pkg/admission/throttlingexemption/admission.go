@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttlingexemption
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "tenancy.kcp.io/ThrottlingExemption"
+
+// Register registers the ThrottlingExemption admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &throttlingExemptionAdmission{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}, nil
+		})
+}
+
+// throttlingExemptionAdmission restricts ThrottlingExemptions to the root workspace and signs
+// them on creation and update, such that all shards can verify them.
+type throttlingExemptionAdmission struct {
+	*admission.Handler
+
+	exemptions *throttling.Exemptions
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.MutationInterface(&throttlingExemptionAdmission{})
+	_ = admission.ValidationInterface(&throttlingExemptionAdmission{})
+	_ = admission.InitializationValidator(&throttlingExemptionAdmission{})
+	_ = kcpinitializers.WantsThrottlingExemptions(&throttlingExemptionAdmission{})
+)
+
+// Admit signs ThrottlingExemptions in the root workspace, overwriting any user-provided signature.
+func (o *throttlingExemptionAdmission) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	u, exemption, err := toThrottlingExemption(a)
+	if err != nil || exemption == nil {
+		return err
+	}
+	if err := validateCluster(ctx, a); err != nil {
+		return err
+	}
+
+	signature, err := o.exemptions.Sign(exemption)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("cannot sign throttling exemption: %w", err))
+	}
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey] = signature
+	u.SetAnnotations(annotations)
+
+	return nil
+}
+
+// Validate ensures that ThrottlingExemptions live in the root workspace and are signed, i.e. that
+// no other mutating admission plugin changed them after signing.
+func (o *throttlingExemptionAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	_, exemption, err := toThrottlingExemption(a)
+	if err != nil || exemption == nil {
+		return err
+	}
+	if err := validateCluster(ctx, a); err != nil {
+		return err
+	}
+
+	signature, err := o.exemptions.Sign(exemption)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("cannot sign throttling exemption: %w", err))
+	}
+	if exemption.Annotations[tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey] != signature {
+		return admission.NewForbidden(a, fmt.Errorf("annotation %s does not match the throttling exemption", tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey))
+	}
+
+	return nil
+}
+
+func validateCluster(ctx context.Context, a admission.Attributes) error {
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if clusterName != core.RootCluster {
+		return admission.NewForbidden(a, fmt.Errorf("throttling exemptions can only be managed in the %s workspace", core.RootCluster))
+	}
+	return nil
+}
+
+func (o *throttlingExemptionAdmission) ValidateInitialization() error {
+	if o.exemptions == nil {
+		return fmt.Errorf(PluginName + " plugin needs the throttling exemptions")
+	}
+	return nil
+}
+
+func (o *throttlingExemptionAdmission) SetThrottlingExemptions(exemptions *throttling.Exemptions) {
+	o.exemptions = exemptions
+}
+
+func toThrottlingExemption(a admission.Attributes) (*unstructured.Unstructured, *tenancyv1alpha1.ThrottlingExemption, error) {
+	if a.GetResource().GroupResource() != tenancyv1alpha1.Resource("throttlingexemptions") || a.GetSubresource() != "" {
+		return nil, nil, nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	exemption := &tenancyv1alpha1.ThrottlingExemption{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, exemption); err != nil {
+		return nil, nil, fmt.Errorf("failed to convert unstructured to ThrottlingExemption: %w", err)
+	}
+
+	return u, exemption, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttlingexemption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func newExemption(signature string) *tenancyv1alpha1.ThrottlingExemption {
+	exemption := &tenancyv1alpha1.ThrottlingExemption{
+		ObjectMeta: metav1.ObjectMeta{Name: "system"},
+		Spec: tenancyv1alpha1.ThrottlingExemptionSpec{
+			WorkspaceTypes: []tenancyv1alpha1.WorkspaceTypeReference{{Path: "root", Name: "automation"}},
+		},
+	}
+	if signature != "" {
+		exemption.Annotations = map[string]string{tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey: signature}
+	}
+	return exemption
+}
+
+func createAttr(exemption *tenancyv1alpha1.ThrottlingExemption) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(exemption),
+		nil,
+		tenancyv1alpha1.Kind("ThrottlingExemption").WithVersion("v1alpha1"),
+		"",
+		exemption.Name,
+		tenancyv1alpha1.Resource("throttlingexemptions").WithVersion("v1alpha1"),
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
+func TestAdmitAndValidate(t *testing.T) {
+	signer := throttling.NewExemptions([]byte("secret"), nil, nil, nil)
+	want, err := signer.Sign(newExemption(""))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		clusterName logicalcluster.Name
+		key         string
+		exemption   *tenancyv1alpha1.ThrottlingExemption
+		mutate      func(u *unstructured.Unstructured)
+
+		wantAdmitErr    bool
+		wantValidateErr bool
+	}{
+		"creation in root is signed": {
+			clusterName: "root",
+			key:         "secret",
+			exemption:   newExemption(""),
+		},
+		"user-provided signature is overwritten": {
+			clusterName: "root",
+			key:         "secret",
+			exemption:   newExemption("forged"),
+		},
+		"creation outside of root": {
+			clusterName:  "tenant",
+			key:          "secret",
+			exemption:    newExemption(""),
+			wantAdmitErr: true,
+		},
+		"no signing key": {
+			clusterName:  "root",
+			exemption:    newExemption(""),
+			wantAdmitErr: true,
+		},
+		"spec changed after signing": {
+			clusterName: "root",
+			key:         "secret",
+			exemption:   newExemption(""),
+			mutate: func(u *unstructured.Unstructured) {
+				require.NoError(t, unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{"name": "universal"}}, "spec", "workspaceTypes"))
+			},
+			wantValidateErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &throttlingExemptionAdmission{
+				Handler:    admission.NewHandler(admission.Create, admission.Update),
+				exemptions: throttling.NewExemptions([]byte(tt.key), nil, nil, nil),
+			}
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: tt.clusterName})
+			attr := createAttr(tt.exemption)
+
+			err := o.Admit(ctx, attr, nil)
+			if tt.wantAdmitErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			u := attr.GetObject().(*unstructured.Unstructured)
+			require.Equal(t, want, u.GetAnnotations()[tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey])
+			if tt.mutate != nil {
+				tt.mutate(u)
+			}
+
+			err = o.Validate(ctx, attr, nil)
+			if tt.wantValidateErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
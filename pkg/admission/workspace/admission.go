@@ -22,6 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,15 +36,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 	kuser "k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
 	"github.com/kcp-dev/kcp/pkg/authorization"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/routingtarget"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 // Validate and admit Workspace creation and updates.
@@ -53,7 +60,8 @@ func Register(plugins *admission.Plugins) {
 	plugins.Register(PluginName,
 		func(_ io.Reader) (admission.Interface, error) {
 			return &workspace{
-				Handler: admission.NewHandler(admission.Create, admission.Update),
+				Handler:          admission.NewHandler(admission.Create, admission.Update),
+				createAuthorizer: delegated.NewDelegatedAuthorizer,
 			}, nil
 		})
 }
@@ -62,6 +70,19 @@ type workspace struct {
 	*admission.Handler
 
 	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+	deepSARClient        kcpkubernetesclientset.ClusterInterface
+
+	createAuthorizer delegated.DelegatedAuthorizerFactory
+}
+
+// OwnershipTransfer is the value of the WorkspaceOwnershipTransferAnnotationKey annotation.
+type OwnershipTransfer struct {
+	// From is the previous owner. It is empty if the workspace had no owner.
+	From string `json:"from,omitempty"`
+	// To is the new owner.
+	To string `json:"to"`
+	// By is the user who transferred the ownership.
+	By string `json:"by"`
 }
 
 // Ensure that the required admission interfaces are implemented.
@@ -69,9 +90,12 @@ var _ admission.MutationInterface = &workspace{}
 var _ admission.ValidationInterface = &workspace{}
 var _ = admission.InitializationValidator(&workspace{})
 var _ = kcpinitializers.WantsKcpInformers(&workspace{})
+var _ = kcpinitializers.WantsDeepSARClient(&workspace{})
 
 // Admit ensures that
 // - the owner user is recorded in annotations on create
+// - spec.owner defaults to the user creating the workspace
+// - the owner annotation follows spec.owner, and ownership transfers are recorded
 // - the required groups are copied over from the LogicalCluster.
 func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
 	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
@@ -92,7 +116,8 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 		return fmt.Errorf("failed to convert unstructured to Workspace: %w", err)
 	}
 
-	if a.GetOperation() == admission.Create {
+	switch a.GetOperation() {
+	case admission.Create:
 		isSystemPrivileged := sets.NewString(a.GetUserInfo().GetGroups()...).Has(kuser.SystemPrivilegedGroup)
 
 		// create owner anntoation
@@ -105,6 +130,17 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 				ws.Annotations = map[string]string{}
 			}
 			ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey] = userInfo
+
+			if ws.Spec.Owner == nil && a.GetUserInfo().GetName() != "" {
+				ws.Spec.Owner = &tenancyv1beta1.WorkspaceOwner{Username: a.GetUserInfo().GetName()}
+			}
+		}
+
+		// privileged users may create workspaces on behalf of another owner
+		if ws.Spec.Owner != nil && annotatedOwnerOf(ws) != ws.Spec.Owner.Username {
+			if err := setOwnerAnnotation(ws, ws.Spec.Owner.Username); err != nil {
+				return admission.NewForbidden(a, err)
+			}
 		}
 
 		// copy required groups from LogicalCluster to new child-Worksapce
@@ -122,6 +158,28 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 				delete(ws.Annotations, authorization.RequiredGroupsAnnotationKey)
 			}
 		}
+	case admission.Update:
+		old, err := oldWorkspace(a)
+		if err != nil {
+			return err
+		}
+
+		// transfer the ownership: the owner annotation is rewritten together with spec.owner
+		// and the transfer is recorded for the workspace controller.
+		if from := ownerOf(old); ws.Spec.Owner != nil && ws.Spec.Owner.Username != from {
+			if err := setOwnerAnnotation(ws, ws.Spec.Owner.Username); err != nil {
+				return admission.NewForbidden(a, err)
+			}
+			transfer, err := json.Marshal(OwnershipTransfer{
+				From: from,
+				To:   ws.Spec.Owner.Username,
+				By:   a.GetUserInfo().GetName(),
+			})
+			if err != nil {
+				return admission.NewForbidden(a, err)
+			}
+			ws.Annotations[tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey] = string(transfer)
+		}
 	}
 
 	return updateUnstructured(u, ws)
@@ -131,7 +189,9 @@ func (o *workspace) Admit(ctx context.Context, a admission.Attributes, _ admissi
 // - the workspace only does a valid phase transition
 // - has a valid type and it is not mutated
 // - the cluster is not removed
+// - the cluster is not routed to a logical cluster of another workspace
 // - the user is recorded in annotations on create
+// - the owner is only changed through spec.owner, by the current owner or users allowed to transfer the workspace
 // - the required groups match with the LogicalCluster.
 func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
 	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
@@ -154,15 +214,18 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 
 	isSystemPrivileged := sets.NewString(a.GetUserInfo().GetGroups()...).Has(kuser.SystemPrivilegedGroup)
 
+	if value, found := ws.Annotations[tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey]; found {
+		if retention, err := time.ParseDuration(value); err != nil || retention <= 0 {
+			fldPath := field.NewPath("metadata", "annotations").Key(tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey)
+			return admission.NewForbidden(a, field.Invalid(fldPath, value, "must be a positive duration"))
+		}
+	}
+
 	switch a.GetOperation() {
 	case admission.Update:
-		u, ok = a.GetOldObject().(*unstructured.Unstructured)
-		if !ok {
-			return fmt.Errorf("unexpected type %T", a.GetOldObject())
-		}
-		old := &tenancyv1beta1.Workspace{}
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, old); err != nil {
-			return fmt.Errorf("failed to convert unstructured to Workspace: %w", err)
+		old, err := oldWorkspace(a)
+		if err != nil {
+			return err
 		}
 
 		if old.Spec.Cluster != "" && ws.Spec.Cluster == "" {
@@ -174,6 +237,11 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 		if old.Spec.URL != ws.Spec.URL && !isSystemPrivileged {
 			return admission.NewForbidden(a, errors.New("spec.URL can only be changed by system privileged users"))
 		}
+		if old.Spec.Cluster != ws.Spec.Cluster {
+			if err := o.validateTarget(clusterName, ws); err != nil {
+				return admission.NewForbidden(a, field.Invalid(field.NewPath("spec", "cluster"), ws.Spec.Cluster, err.Error()))
+			}
+		}
 
 		if errs := validation.ValidateImmutableField(ws.Spec.Type, old.Spec.Type, field.NewPath("spec", "type")); len(errs) > 0 {
 			return admission.NewForbidden(a, errs.ToAggregate())
@@ -182,6 +250,27 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 			return admission.NewForbidden(a, errors.New("spec.type is immutable"))
 		}
 
+		if old.Spec.Owner != nil && ws.Spec.Owner == nil {
+			return admission.NewForbidden(a, errors.New("spec.owner cannot be unset"))
+		}
+		if from := ownerOf(old); ws.Spec.Owner != nil && ws.Spec.Owner.Username != from {
+			if !isSystemPrivileged && a.GetUserInfo().GetName() != from {
+				if err := o.authorizeTransfer(ctx, a, clusterName); err != nil {
+					return admission.NewForbidden(a, err)
+				}
+			}
+			if annotatedOwnerOf(ws) != ws.Spec.Owner.Username {
+				return admission.NewForbidden(a, fmt.Errorf("annotation %s must match spec.owner", tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey))
+			}
+		} else if !isSystemPrivileged {
+			if annotatedOwnerOf(old) != annotatedOwnerOf(ws) {
+				return admission.NewForbidden(a, fmt.Errorf("annotation %s can only be changed through spec.owner", tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey))
+			}
+			if old.Annotations[tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey] != ws.Annotations[tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey] {
+				return admission.NewForbidden(a, fmt.Errorf("annotation %s can only be changed through spec.owner", tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey))
+			}
+		}
+
 		// If we're transitioning to "Ready", make sure that spec.cluster and spec.URL are set.
 		if old.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && ws.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
 			if ws.Spec.Cluster == "" {
@@ -199,6 +288,11 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 		if ws.Spec.URL != "" && !isSystemPrivileged {
 			return admission.NewForbidden(a, errors.New("spec.URL can only be set by system privileged users"))
 		}
+		if ws.Spec.Cluster != "" {
+			if err := o.validateTarget(clusterName, ws); err != nil {
+				return admission.NewForbidden(a, field.Invalid(field.NewPath("spec", "cluster"), ws.Spec.Cluster, err.Error()))
+			}
+		}
 
 		if !isSystemPrivileged {
 			userInfo, err := WorkspaceOwnerAnnotationValue(a.GetUserInfo())
@@ -208,6 +302,9 @@ func (o *workspace) Validate(ctx context.Context, a admission.Attributes, _ admi
 			if ws.Annotations == nil {
 				ws.Annotations = map[string]string{}
 			}
+			if ws.Spec.Owner != nil && ws.Spec.Owner.Username != a.GetUserInfo().GetName() {
+				return admission.NewForbidden(a, field.Invalid(field.NewPath("spec", "owner", "username"), ws.Spec.Owner.Username, "must be the requesting user, the ownership can be transferred after creation"))
+			}
 			if got := ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey]; got != userInfo {
 				return admission.NewForbidden(a, fmt.Errorf("expected user annotation %s=%s", tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey, userInfo))
 			}
@@ -233,6 +330,9 @@ func (o *workspace) ValidateInitialization() error {
 	if o.logicalClusterLister == nil {
 		return fmt.Errorf(PluginName + " plugin needs an LogicalCluster lister")
 	}
+	if o.deepSARClient == nil {
+		return fmt.Errorf(PluginName + " plugin needs a deep SAR client")
+	}
 	return nil
 }
 
@@ -244,6 +344,99 @@ func (o *workspace) SetKcpInformers(informers kcpinformers.SharedInformerFactory
 	o.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
 }
 
+func (o *workspace) SetDeepSARClient(client kcpkubernetesclientset.ClusterInterface) {
+	o.deepSARClient = client
+}
+
+// authorizeTransfer checks that the requesting user has the "transfer" verb on the workspace.
+func (o *workspace) authorizeTransfer(ctx context.Context, a admission.Attributes, clusterName logicalcluster.Name) error {
+	authz, err := o.createAuthorizer(clusterName, o.deepSARClient)
+	if err != nil {
+		return fmt.Errorf("unable to determine access to workspace %q", a.GetName())
+	}
+
+	transferAttr := authorizer.AttributesRecord{
+		User:            a.GetUserInfo(),
+		Verb:            "transfer",
+		APIGroup:        tenancyv1beta1.SchemeGroupVersion.Group,
+		APIVersion:      tenancyv1beta1.SchemeGroupVersion.Version,
+		Resource:        "workspaces",
+		Name:            a.GetName(),
+		ResourceRequest: true,
+	}
+	if decision, _, err := authz.Authorize(ctx, transferAttr); err != nil {
+		return fmt.Errorf("unable to determine access to workspace %q: %w", a.GetName(), err)
+	} else if decision != authorizer.DecisionAllow {
+		return fmt.Errorf("unable to transfer workspace %q: only the current owner or users with verb='transfer' permission on the workspace can change spec.owner", a.GetName())
+	}
+	return nil
+}
+
+// validateTarget checks that the workspace may be routed to the logical cluster in spec.cluster.
+// If that logical cluster is already known on this shard, it must be owned by the workspace.
+func (o *workspace) validateTarget(clusterName logicalcluster.Name, ws *tenancyv1beta1.Workspace) error {
+	target := logicalcluster.Name(ws.Spec.Cluster)
+	if err := routingtarget.ValidateCluster(clusterName, ws.Name, target); err != nil {
+		return err
+	}
+	logicalCluster, err := o.logicalClusterLister.Cluster(target).Get(corev1alpha1.LogicalClusterName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return routingtarget.ValidateOwner(logicalCluster.Spec.Owner, clusterName, ws.Name)
+}
+
+// oldWorkspace returns the old object of an update.
+func oldWorkspace(a admission.Attributes) (*tenancyv1beta1.Workspace, error) {
+	u, ok := a.GetOldObject().(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", a.GetOldObject())
+	}
+	old := &tenancyv1beta1.Workspace{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, old); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to Workspace: %w", err)
+	}
+	return old, nil
+}
+
+// ownerOf returns the username of the owner of the workspace. Workspaces created before
+// spec.owner existed are owned by the user in the owner annotation.
+func ownerOf(ws *tenancyv1beta1.Workspace) string {
+	if ws.Spec.Owner != nil {
+		return ws.Spec.Owner.Username
+	}
+	return annotatedOwnerOf(ws)
+}
+
+// annotatedOwnerOf returns the username in the owner annotation of the workspace.
+func annotatedOwnerOf(ws *tenancyv1beta1.Workspace) string {
+	value, found := ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey]
+	if !found {
+		return ""
+	}
+	var info authenticationv1.UserInfo
+	if err := json.Unmarshal([]byte(value), &info); err != nil {
+		return ""
+	}
+	return info.Username
+}
+
+// setOwnerAnnotation sets the owner annotation to the given user. Only the username is
+// recorded because the owner is not the requesting user.
+func setOwnerAnnotation(ws *tenancyv1beta1.Workspace, username string) error {
+	value, err := json.Marshal(authenticationv1.UserInfo{Username: username})
+	if err != nil {
+		return fmt.Errorf("failed to marshal user info: %w", err)
+	}
+	if ws.Annotations == nil {
+		ws.Annotations = map[string]string{}
+	}
+	ws.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey] = string(value)
+	return nil
+}
+
 // updateUnstructured updates the given unstructured object to match the given workspace.
 func updateUnstructured(u *unstructured.Unstructured, ws *tenancyv1beta1.Workspace) error {
 	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ws)
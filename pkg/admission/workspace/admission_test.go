@@ -18,10 +18,12 @@ package workspace
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
@@ -32,14 +34,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission"
 	kuser "k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
 	"github.com/kcp-dev/kcp/pkg/authorization"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 func createAttr(ws *tenancyv1beta1.Workspace) admission.Attributes {
@@ -131,6 +134,7 @@ func TestAdmit(t *testing.T) {
 						Name: "foo",
 						Path: "root:org",
 					},
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
 				},
 			},
 		},
@@ -215,6 +219,143 @@ func TestAdmit(t *testing.T) {
 						Name: "Foo",
 						Path: "root:org",
 					},
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
+				},
+			},
+		},
+		{
+			name: "sets owner annotation from spec.owner on create when privileged system user",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org:ws")).LogicalCluster,
+			},
+			clusterName: "root:org:ws",
+			a: createAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			}, &kuser.DefaultInfo{
+				Name:   "admin",
+				Groups: []string{kuser.SystemPrivilegedGroup},
+			}),
+			expectedObj: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someoneelse"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			},
+		},
+		{
+			name:        "rewrites owner annotation and records the transfer on update",
+			clusterName: "root:org:ws",
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
+				},
+			}, &kuser.DefaultInfo{Name: "someone"}),
+			expectedObj: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someoneelse"}`,
+						"tenancy.kcp.io/ownership-transfer": `{"from":"someone","to":"someoneelse","by":"someone"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			},
+		},
+		{
+			name:        "records the transfer of a workspace without spec.owner on update",
+			clusterName: "root:org:ws",
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`,
+					},
+				},
+			}, &kuser.DefaultInfo{Name: "admin"}),
+			expectedObj: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someoneelse"}`,
+						"tenancy.kcp.io/ownership-transfer": `{"from":"someone","to":"someoneelse","by":"admin"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			},
+		},
+		{
+			name:        "keeps owner annotation on update without transfer",
+			clusterName: "root:org:ws",
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone","uid":"id"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone","uid":"id"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
+				},
+			}, &kuser.DefaultInfo{Name: "someone"}),
+			expectedObj: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someone","uid":"id"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someone"},
 				},
 			},
 		},
@@ -322,6 +463,7 @@ func TestValidate(t *testing.T) {
 		name            string
 		logicalClusters []*corev1alpha1.LogicalCluster
 		a               admission.Attributes
+		authzDecision   authorizer.Decision
 		expectedErrors  []string
 	}{
 		{
@@ -667,6 +809,86 @@ func TestValidate(t *testing.T) {
 			}),
 			expectedErrors: []string{"expected user annotation experimental.tenancy.kcp.io/owner={\"username\":\"someone\",\"uid\":\"id\",\"groups\":[\"a\",\"b\"],\"extra\":{\"one\":[\"1\",\"01\"]}}"},
 		},
+		{
+			name: "rejects system logical cluster as target on create",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "system:admin",
+				},
+			}, &kuser.DefaultInfo{Groups: []string{kuser.SystemPrivilegedGroup}}),
+			expectedErrors: []string{"system logical clusters cannot be targeted"},
+		},
+		{
+			name: "rejects root logical cluster as target on update",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "root",
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+			}, &kuser.DefaultInfo{Groups: []string{kuser.SystemPrivilegedGroup}}),
+			expectedErrors: []string{"cannot target the root logical cluster"},
+		},
+		{
+			name: "rejects logical cluster of another workspace as target on update",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+				newLogicalCluster(logicalcluster.NewPath("abc")).WithOwner("root:other", "test").LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "abc",
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+			}, &kuser.DefaultInfo{Groups: []string{kuser.SystemPrivilegedGroup}}),
+			expectedErrors: []string{"logical clusters of other workspaces cannot be targeted"},
+		},
+		{
+			name: "accepts own logical cluster as target on update",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+				newLogicalCluster(logicalcluster.NewPath("abc")).WithOwner("root:org", "test").LogicalCluster,
+			},
+			a: updateAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "abc",
+				},
+			}, &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+				},
+			}, &kuser.DefaultInfo{Groups: []string{kuser.SystemPrivilegedGroup}}),
+		},
 		{
 			name: "rejects with wrong required groups on create as non-system:master",
 			logicalClusters: []*corev1alpha1.LogicalCluster{
@@ -714,12 +936,173 @@ func TestValidate(t *testing.T) {
 				Groups: []string{kuser.SystemPrivilegedGroup},
 			}),
 		},
+		{
+			name: "accepts positive trash retention",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttr(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": "{}",
+						"tenancy.kcp.io/trash-retention":    "72h",
+					},
+				},
+			}),
+		},
+		{
+			name: "rejects invalid trash retention",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttr(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": "{}",
+						"tenancy.kcp.io/trash-retention":    "3 days",
+					},
+				},
+			}),
+			expectedErrors: []string{"must be a positive duration"},
+		},
+		{
+			name: "rejects negative trash retention on update",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttr(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": "{}",
+						"tenancy.kcp.io/trash-retention":    "-1h",
+					},
+				},
+			},
+				&tenancyv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": "{}"},
+					},
+				}),
+			expectedErrors: []string{"must be a positive duration"},
+		},
+		{
+			name: "rejects owner other than the requesting user on create",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: createAttrWithUser(&tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						"experimental.tenancy.kcp.io/owner": `{"username":"someoneelse"}`,
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Owner: &tenancyv1beta1.WorkspaceOwner{Username: "someoneelse"},
+				},
+			}, &kuser.DefaultInfo{Name: "someone"}),
+			expectedErrors: []string{"must be the requesting user"},
+		},
+		{
+			name: "allows the owner to transfer the workspace",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				newOwnedWorkspace("someoneelse", `{"from":"someone","to":"someoneelse","by":"someone"}`),
+				newOwnedWorkspace("someone", ""),
+				&kuser.DefaultInfo{Name: "someone"},
+			),
+		},
+		{
+			name: "allows users with the transfer verb to transfer the workspace",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				newOwnedWorkspace("someoneelse", `{"from":"someone","to":"someoneelse","by":"admin"}`),
+				newOwnedWorkspace("someone", ""),
+				&kuser.DefaultInfo{Name: "admin"},
+			),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name: "rejects transfer by other users",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				newOwnedWorkspace("mallory", `{"from":"someone","to":"mallory","by":"mallory"}`),
+				newOwnedWorkspace("someone", ""),
+				&kuser.DefaultInfo{Name: "mallory"},
+			),
+			authzDecision:  authorizer.DecisionDeny,
+			expectedErrors: []string{`unable to transfer workspace "test"`},
+		},
+		{
+			name: "rejects unsetting the owner",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				&tenancyv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`},
+					},
+				},
+				newOwnedWorkspace("someone", ""),
+				&kuser.DefaultInfo{Name: "someone"},
+			),
+			expectedErrors: []string{"spec.owner cannot be unset"},
+		},
+		{
+			name: "rejects changing the owner annotation without spec.owner",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				&tenancyv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": `{"username":"mallory"}`},
+					},
+				},
+				&tenancyv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: map[string]string{"experimental.tenancy.kcp.io/owner": `{"username":"someone"}`},
+					},
+				},
+				&kuser.DefaultInfo{Name: "mallory"},
+			),
+			expectedErrors: []string{"can only be changed through spec.owner"},
+		},
+		{
+			name: "rejects forging the ownership transfer record",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster(logicalcluster.NewPath("root:org")).LogicalCluster,
+			},
+			a: updateAttrWithUser(
+				newOwnedWorkspace("someone", `{"to":"someone","by":"admin"}`),
+				newOwnedWorkspace("someone", ""),
+				&kuser.DefaultInfo{Name: "someone"},
+			),
+			expectedErrors: []string{"tenancy.kcp.io/ownership-transfer can only be changed through spec.owner"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := &workspace{
 				Handler:              admission.NewHandler(admission.Create, admission.Update),
 				logicalClusterLister: fakeLogicalClusterClusterLister(tt.logicalClusters),
+				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface) (authorizer.Authorizer, error) {
+					return &fakeAuthorizer{tt.authzDecision}, nil
+				},
 			}
 			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
 			err := o.Validate(ctx, tt.a, nil)
@@ -737,6 +1120,32 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func newOwnedWorkspace(owner, transfer string) *tenancyv1beta1.Workspace {
+	ws := &tenancyv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Annotations: map[string]string{
+				"experimental.tenancy.kcp.io/owner": fmt.Sprintf(`{"username":%q}`, owner),
+			},
+		},
+		Spec: tenancyv1beta1.WorkspaceSpec{
+			Owner: &tenancyv1beta1.WorkspaceOwner{Username: owner},
+		},
+	}
+	if transfer != "" {
+		ws.Annotations["tenancy.kcp.io/ownership-transfer"] = transfer
+	}
+	return ws
+}
+
+type fakeAuthorizer struct {
+	decision authorizer.Decision
+}
+
+func (a *fakeAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+	return a.decision, "reason", nil
+}
+
 type builder struct {
 	*tenancyv1alpha1.WorkspaceType
 }
@@ -775,6 +1184,15 @@ func (b thisBuilder) WithRequiredGroups(groups ...string) thisBuilder {
 	return b
 }
 
+func (b thisBuilder) WithOwner(cluster, name string) thisBuilder {
+	b.LogicalCluster.Spec.Owner = &corev1alpha1.LogicalClusterOwner{
+		Resource: "workspaces",
+		Cluster:  cluster,
+		Name:     name,
+	}
+	return b
+}
+
 type fakeLogicalClusterClusterLister []*corev1alpha1.LogicalCluster
 
 func (l fakeLogicalClusterClusterLister) List(selector labels.Selector) (ret []*corev1alpha1.LogicalCluster, err error) {
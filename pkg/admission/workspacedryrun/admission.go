@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/klog/v2"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	workspacereconciler "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// Explain the scheduling of Workspaces on dry-run creation.
+
+const (
+	PluginName = "tenancy.kcp.io/WorkspaceDryRun"
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &workspaceDryRun{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+			}, nil
+		})
+}
+
+type workspaceDryRun struct {
+	*admission.Handler
+
+	schedule func(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (*workspacereconciler.DryRunScheduling, error)
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.MutationInterface(&workspaceDryRun{})
+var _ = admission.InitializationValidator(&workspaceDryRun{})
+var _ = kcpinitializers.WantsKcpInformers(&workspaceDryRun{})
+
+// Admit schedules workspaces on dry-run creation like the workspace controller would, and records
+// the chosen shard and the initializers that would run, or why the workspace is unschedulable, in
+// the tenancy.kcp.io/dry-run-scheduling annotation of the response. The annotation is removed from
+// all other requests. It must run after the workspace type is resolved.
+func (o *workspaceDryRun) Admit(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetResource().GroupResource() != tenancyv1beta1.Resource("workspaces") {
+		return nil
+	}
+
+	u, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", a.GetObject())
+	}
+	ws := &tenancyv1beta1.Workspace{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ws); err != nil {
+		return fmt.Errorf("failed to convert unstructured to Workspace: %w", err)
+	}
+
+	if a.GetOperation() != admission.Create || !a.IsDryRun() {
+		if _, found := ws.Annotations[tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey]; !found {
+			return nil
+		}
+		delete(ws.Annotations, tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey)
+		return updateUnstructured(u, ws)
+	}
+
+	scheduling, err := o.schedule(klog.FromContext(ctx), ws)
+	if err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("failed to schedule workspace: %w", err))
+	}
+	value, err := json.Marshal(scheduling)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if ws.Annotations == nil {
+		ws.Annotations = map[string]string{}
+	}
+	ws.Annotations[tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey] = string(value)
+
+	return updateUnstructured(u, ws)
+}
+
+func (o *workspaceDryRun) ValidateInitialization() error {
+	if o.schedule == nil {
+		return fmt.Errorf(PluginName + " plugin needs a scheduler")
+	}
+	return nil
+}
+
+func (o *workspaceDryRun) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	workspacesReady := informers.Tenancy().V1beta1().Workspaces().Informer().HasSynced
+	shardsReady := informers.Core().V1alpha1().Shards().Informer().HasSynced
+	typesReady := informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return workspacesReady() && shardsReady() && typesReady()
+	})
+
+	o.schedule = workspacereconciler.NewDryRunScheduler(
+		informers.Tenancy().V1beta1().Workspaces(),
+		informers.Core().V1alpha1().Shards(),
+		informers.Tenancy().V1alpha1().WorkspaceTypes(),
+	).Schedule
+}
+
+// updateUnstructured updates the given unstructured object to match the given workspace.
+func updateUnstructured(u *unstructured.Unstructured, ws *tenancyv1beta1.Workspace) error {
+	raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ws)
+	if err != nil {
+		return err
+	}
+	u.Object = raw
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedryrun
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	workspacereconciler "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func attr(ws *tenancyv1beta1.Workspace, op admission.Operation, dryRun bool) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(ws),
+		nil,
+		tenancyv1beta1.Kind("Workspace").WithVersion("v1beta1"),
+		"",
+		ws.Name,
+		tenancyv1beta1.Resource("workspaces").WithVersion("v1beta1"),
+		"",
+		op,
+		&metav1.CreateOptions{},
+		dryRun,
+		&user.DefaultInfo{},
+	)
+}
+
+func TestAdmit(t *testing.T) {
+	scheduled := &workspacereconciler.DryRunScheduling{
+		Shard:        "beta",
+		Candidates:   []string{"alpha", "beta"},
+		Selector:     "region=eu",
+		Initializers: []corev1alpha1.LogicalClusterInitializer{"root:universal"},
+	}
+	unschedulable := &workspacereconciler.DryRunScheduling{
+		Selector: "region=eu",
+		Reason:   tenancyv1alpha1.WorkspaceReasonShardsAtCapacity,
+		Message:  "No shard has capacity for a workspace of priority 0",
+	}
+
+	tests := map[string]struct {
+		op          admission.Operation
+		dryRun      bool
+		annotations map[string]string
+		scheduling  *workspacereconciler.DryRunScheduling
+
+		want *workspacereconciler.DryRunScheduling
+	}{
+		"dry-run create is scheduled": {
+			op:         admission.Create,
+			dryRun:     true,
+			scheduling: scheduled,
+			want:       scheduled,
+		},
+		"dry-run create explains unschedulability": {
+			op:         admission.Create,
+			dryRun:     true,
+			scheduling: unschedulable,
+			want:       unschedulable,
+		},
+		"annotation of the user is replaced on dry-run": {
+			op:          admission.Create,
+			dryRun:      true,
+			annotations: map[string]string{tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey: `{"shard":"fake"}`},
+			scheduling:  scheduled,
+			want:        scheduled,
+		},
+		"create is not scheduled and the annotation removed": {
+			op:          admission.Create,
+			annotations: map[string]string{tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey: `{"shard":"fake"}`},
+		},
+		"dry-run update is not scheduled": {
+			op:          admission.Update,
+			dryRun:      true,
+			annotations: map[string]string{tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey: `{"shard":"fake"}`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &workspaceDryRun{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+				schedule: func(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (*workspacereconciler.DryRunScheduling, error) {
+					require.NotNil(t, tt.scheduling, "unexpected scheduling")
+					return tt.scheduling, nil
+				},
+			}
+			ws := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: tt.annotations},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Type: tenancyv1alpha1.WorkspaceTypeReference{Name: "universal", Path: "root"},
+				},
+			}
+			a := attr(ws, tt.op, tt.dryRun)
+
+			require.NoError(t, o.Admit(context.Background(), a, nil))
+
+			got := &tenancyv1beta1.Workspace{}
+			require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(a.GetObject().(*unstructured.Unstructured).Object, got))
+			value, found := got.Annotations[tenancyv1alpha1.WorkspaceDryRunSchedulingAnnotationKey]
+			if tt.want == nil {
+				require.False(t, found, "expected no scheduling annotation")
+				return
+			}
+			require.True(t, found, "expected a scheduling annotation")
+			var scheduling workspacereconciler.DryRunScheduling
+			require.NoError(t, json.Unmarshal([]byte(value), &scheduling))
+			require.Equal(t, tt.want, &scheduling)
+		})
+	}
+}
+
+func TestAdmitIgnoresOtherResources(t *testing.T) {
+	o := &workspaceDryRun{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+		schedule: func(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (*workspacereconciler.DryRunScheduling, error) {
+			t.Fatal("unexpected scheduling")
+			return nil, nil
+		},
+	}
+	shard := &corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	a := admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(shard),
+		nil,
+		corev1alpha1.Kind("Shard").WithVersion("v1alpha1"),
+		"",
+		shard.Name,
+		corev1alpha1.Resource("shards").WithVersion("v1alpha1"),
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		true,
+		&user.DefaultInfo{},
+	)
+	require.NoError(t, o.Admit(context.Background(), a, nil))
+}
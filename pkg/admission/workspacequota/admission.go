@@ -0,0 +1,355 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/util/retry"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "tenancy.kcp.io/WorkspaceQuota"
+
+// Register registers the WorkspaceQuota admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			p := &workspaceQuotaAdmission{
+				Handler:          admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+				createAuthorizer: delegated.NewDelegatedAuthorizer,
+			}
+			p.getQuota = func(ctx context.Context, clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceQuota, error) {
+				return p.kcpClusterClient.TenancyV1alpha1().WorkspaceQuotas().Cluster(clusterName.Path()).Get(ctx, name, metav1.GetOptions{})
+			}
+			p.updateQuotaStatus = func(ctx context.Context, clusterName logicalcluster.Name, quota *tenancyv1alpha1.WorkspaceQuota) (*tenancyv1alpha1.WorkspaceQuota, error) {
+				return p.kcpClusterClient.TenancyV1alpha1().WorkspaceQuotas().Cluster(clusterName.Path()).UpdateStatus(ctx, quota, metav1.UpdateOptions{})
+			}
+			return p, nil
+		})
+}
+
+// workspaceQuotaAdmission enforces the WorkspaceQuotas of a logical cluster:
+//
+//   - creations exceeding the hard limits of any quota are rejected. Admitted creations are charged
+//     to status.used right away, such that concurrent creations cannot overshoot the limits. The
+//     usage controller recounts the usage periodically, which releases the charges of deleted objects
+//     and of creations that failed after admission. Requests exempt through a ThrottlingExemption
+//     are neither checked nor charged.
+//   - changes to the spec of a WorkspaceQuota require the "manage" verb on workspacequotas in the
+//     logical cluster of the parent workspace, for the name of the workspace the quota lives in.
+type workspaceQuotaAdmission struct {
+	*admission.Handler
+
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+	listQuotas           func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceQuota, error)
+	getQuota             func(ctx context.Context, clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceQuota, error)
+	updateQuotaStatus    func(ctx context.Context, clusterName logicalcluster.Name, quota *tenancyv1alpha1.WorkspaceQuota) (*tenancyv1alpha1.WorkspaceQuota, error)
+
+	kcpClusterClient kcpclientset.ClusterInterface
+	deepSARClient    kcpkubernetesclientset.ClusterInterface
+	createAuthorizer delegated.DelegatedAuthorizerFactory
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&workspaceQuotaAdmission{})
+	_ = admission.InitializationValidator(&workspaceQuotaAdmission{})
+	_ = kcpinitializers.WantsKcpInformers(&workspaceQuotaAdmission{})
+	_ = kcpinitializers.WantsKcpClusterClient(&workspaceQuotaAdmission{})
+	_ = kcpinitializers.WantsDeepSARClient(&workspaceQuotaAdmission{})
+)
+
+// uncountedGroupResources are not charged as objects, because they are either not persisted,
+// too short-lived and too important to be rejected, or needed to manage the quotas themselves.
+var uncountedGroupResources = map[schema.GroupResource]bool{
+	{Group: "", Resource: "events"}:                                        true,
+	{Group: "events.k8s.io", Resource: "events"}:                           true,
+	{Group: "authentication.k8s.io", Resource: "tokenreviews"}:             true,
+	{Group: "authentication.k8s.io", Resource: "selfsubjectreviews"}:       true,
+	{Group: "authorization.k8s.io", Resource: "subjectaccessreviews"}:      true,
+	{Group: "authorization.k8s.io", Resource: "selfsubjectaccessreviews"}:  true,
+	{Group: "authorization.k8s.io", Resource: "selfsubjectrulesreviews"}:   true,
+	{Group: "authorization.k8s.io", Resource: "localsubjectaccessreviews"}: true,
+	tenancyv1alpha1.Resource("workspacequotas"):                            true,
+}
+
+// Counted returns whether objects of the given resource count towards the "objects" limit.
+func Counted(gr schema.GroupResource) bool {
+	return !uncountedGroupResources[gr]
+}
+
+// Usage returns the usage a creation of the given resource is charged with.
+func Usage(gr schema.GroupResource) corev1.ResourceList {
+	if !Counted(gr) {
+		return nil
+	}
+	usage := corev1.ResourceList{tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse("1")}
+	switch gr {
+	case tenancyv1beta1.Resource("workspaces"):
+		usage[tenancyv1alpha1.WorkspaceQuotaWorkspaces] = resource.MustParse("1")
+	case apisv1alpha1.Resource("apibindings"):
+		usage[tenancyv1alpha1.WorkspaceQuotaAPIBindings] = resource.MustParse("1")
+	}
+	return usage
+}
+
+// Validate enforces the quotas of the logical cluster and the authorization of quota changes.
+func (o *workspaceQuotaAdmission) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if a.GetResource().GroupResource() == tenancyv1alpha1.Resource("workspacequotas") {
+		return o.validateQuotaChange(ctx, a, clusterName)
+	}
+	if a.GetOperation() != admission.Create {
+		return nil
+	}
+	if throttling.ExemptFrom(ctx) {
+		return nil
+	}
+	return o.charge(ctx, a, clusterName)
+}
+
+func (o *workspaceQuotaAdmission) validateQuotaChange(ctx context.Context, a admission.Attributes, clusterName logicalcluster.Name) error {
+	logicalCluster, err := o.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("parent workspace cannot be resolved: %w", err))
+	}
+
+	switch a.GetOperation() {
+	case admission.Update:
+		quota, err := toWorkspaceQuota(a.GetObject())
+		if err != nil {
+			return err
+		}
+		old, err := toWorkspaceQuota(a.GetOldObject())
+		if err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(quota.Spec, old.Spec) {
+			return nil
+		}
+	case admission.Delete:
+		// the logical cluster deletion removes all quotas, possibly after the parent is gone
+		if !logicalCluster.DeletionTimestamp.IsZero() {
+			return nil
+		}
+	}
+
+	owner := logicalCluster.Spec.Owner
+	if owner == nil || owner.Resource != "workspaces" {
+		return admission.NewForbidden(a, fmt.Errorf("workspace quotas can only be managed in workspaces with a parent workspace"))
+	}
+
+	parent := logicalcluster.Name(owner.Cluster)
+	authz, err := o.createAuthorizer(parent, o.deepSARClient)
+	if err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to manage quotas in parent workspace %s: %w", parent, err))
+	}
+	manageAttr := authorizer.AttributesRecord{
+		User:            a.GetUserInfo(),
+		Verb:            "manage",
+		APIGroup:        tenancyv1alpha1.SchemeGroupVersion.Group,
+		APIVersion:      tenancyv1alpha1.SchemeGroupVersion.Version,
+		Resource:        "workspacequotas",
+		Name:            owner.Name,
+		ResourceRequest: true,
+	}
+	if decision, _, err := authz.Authorize(ctx, manageAttr); err != nil {
+		return admission.NewForbidden(a, fmt.Errorf("unable to determine access to manage quotas in parent workspace %s: %w", parent, err))
+	} else if decision != authorizer.DecisionAllow {
+		return admission.NewForbidden(a, fmt.Errorf("changing workspace quotas requires verb='manage' permission on workspacequotas named %q in the parent workspace", owner.Name))
+	}
+
+	return nil
+}
+
+func (o *workspaceQuotaAdmission) charge(ctx context.Context, a admission.Attributes, clusterName logicalcluster.Name) error {
+	usage := Usage(a.GetResource().GroupResource())
+	if len(usage) == 0 {
+		return nil
+	}
+
+	quotas, err := o.listQuotas(clusterName)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	for _, quota := range quotas {
+		if !limits(quota, usage) {
+			continue
+		}
+
+		name := quota.Name
+		first := true
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if !first {
+				var err error
+				if quota, err = o.getQuota(ctx, clusterName, name); err != nil {
+					return err
+				}
+			}
+			first = false
+
+			used, exceeded := add(quota, usage)
+			if len(exceeded) > 0 {
+				return admission.NewForbidden(a, fmt.Errorf("exceeded workspace quota %q: %s", quota.Name, describe(quota, exceeded)))
+			}
+			if a.IsDryRun() {
+				return nil
+			}
+
+			quota = quota.DeepCopy()
+			quota.Status.Used = used
+			_, err := o.updateQuotaStatus(ctx, clusterName, quota)
+			return err
+		})
+		if err != nil {
+			if apierrors.IsForbidden(err) {
+				return err
+			}
+			return apierrors.NewInternalError(fmt.Errorf("failed to charge workspace quota %q: %w", name, err))
+		}
+	}
+
+	return nil
+}
+
+// limits returns whether the quota limits any of the resources in usage.
+func limits(quota *tenancyv1alpha1.WorkspaceQuota, usage corev1.ResourceList) bool {
+	for name := range usage {
+		if _, found := quota.Spec.Hard[name]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// add returns the used resources of the quota after charging usage, restricted to the resources limited
+// by the quota, and the resources whose hard limit would be exceeded by the charge.
+func add(quota *tenancyv1alpha1.WorkspaceQuota, usage corev1.ResourceList) (corev1.ResourceList, []corev1.ResourceName) {
+	used := corev1.ResourceList{}
+	var exceeded []corev1.ResourceName
+	for name, hard := range quota.Spec.Hard {
+		current := quota.Status.Used[name].DeepCopy()
+		if delta, found := usage[name]; found {
+			current.Add(delta)
+			if current.Cmp(hard) > 0 {
+				exceeded = append(exceeded, name)
+			}
+		}
+		used[name] = current
+	}
+	return used, exceeded
+}
+
+func describe(quota *tenancyv1alpha1.WorkspaceQuota, names []corev1.ResourceName) string {
+	var s string
+	for i, name := range names {
+		if i > 0 {
+			s += ", "
+		}
+		used := quota.Status.Used[name]
+		hard := quota.Spec.Hard[name]
+		s += fmt.Sprintf("%s used %s, limited to %s", name, used.String(), hard.String())
+	}
+	return s
+}
+
+func (o *workspaceQuotaAdmission) ValidateInitialization() error {
+	if o.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	if o.listQuotas == nil {
+		return fmt.Errorf(PluginName + " plugin needs a WorkspaceQuota lister")
+	}
+	if o.kcpClusterClient == nil {
+		return fmt.Errorf(PluginName + " plugin needs a kcp cluster client")
+	}
+	if o.deepSARClient == nil {
+		return fmt.Errorf(PluginName + " plugin needs a deep SAR client")
+	}
+	return nil
+}
+
+func (o *workspaceQuotaAdmission) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	logicalClustersReady := informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	quotasReady := informers.Tenancy().V1alpha1().WorkspaceQuotas().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return logicalClustersReady() && quotasReady()
+	})
+	o.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
+
+	quotaLister := informers.Tenancy().V1alpha1().WorkspaceQuotas().Lister()
+	o.listQuotas = func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceQuota, error) {
+		return quotaLister.Cluster(clusterName).List(labels.Everything())
+	}
+}
+
+func (o *workspaceQuotaAdmission) SetKcpClusterClient(client kcpclientset.ClusterInterface) {
+	o.kcpClusterClient = client
+}
+
+func (o *workspaceQuotaAdmission) SetDeepSARClient(client kcpkubernetesclientset.ClusterInterface) {
+	o.deepSARClient = client
+}
+
+func toWorkspaceQuota(obj runtime.Object) (*tenancyv1alpha1.WorkspaceQuota, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+	quota := &tenancyv1alpha1.WorkspaceQuota{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, quota); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to WorkspaceQuota: %w", err)
+	}
+	return quota, nil
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	"github.com/kcp-dev/kcp/pkg/throttling"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+func newQuota(hard, used corev1.ResourceList) *tenancyv1alpha1.WorkspaceQuota {
+	return &tenancyv1alpha1.WorkspaceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       tenancyv1alpha1.WorkspaceQuotaSpec{Hard: hard},
+		Status:     tenancyv1alpha1.WorkspaceQuotaStatus{Used: used},
+	}
+}
+
+func list(objects, workspaces string) corev1.ResourceList {
+	l := corev1.ResourceList{}
+	if objects != "" {
+		l[tenancyv1alpha1.WorkspaceQuotaObjects] = resource.MustParse(objects)
+	}
+	if workspaces != "" {
+		l[tenancyv1alpha1.WorkspaceQuotaWorkspaces] = resource.MustParse(workspaces)
+	}
+	return l
+}
+
+func createAttr(gvr schema.GroupVersionResource, dryRun bool) admission.Attributes {
+	return admission.NewAttributesRecord(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "obj"}},
+		nil,
+		schema.GroupVersionKind{},
+		"",
+		"obj",
+		gvr,
+		"",
+		admission.Create,
+		&metav1.CreateOptions{},
+		dryRun,
+		&user.DefaultInfo{},
+	)
+}
+
+func quotaAttr(op admission.Operation, obj, old *tenancyv1alpha1.WorkspaceQuota, subresource string) admission.Attributes {
+	var newObj, oldObj runtime.Object
+	if obj != nil {
+		newObj = helpers.ToUnstructuredOrDie(obj)
+	}
+	if old != nil {
+		oldObj = helpers.ToUnstructuredOrDie(old)
+	}
+	return admission.NewAttributesRecord(
+		newObj,
+		oldObj,
+		tenancyv1alpha1.Kind("WorkspaceQuota").WithVersion("v1alpha1"),
+		"",
+		"quota",
+		tenancyv1alpha1.Resource("workspacequotas").WithVersion("v1alpha1"),
+		subresource,
+		op,
+		nil,
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
+var (
+	configMaps = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	events     = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+	workspaces = schema.GroupVersionResource{Group: "tenancy.kcp.io", Version: "v1beta1", Resource: "workspaces"}
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		clusterName   logicalcluster.Name
+		attr          admission.Attributes
+		quota         *tenancyv1alpha1.WorkspaceQuota
+		liveQuota     *tenancyv1alpha1.WorkspaceQuota
+		conflict      bool
+		exempt        bool
+		authzDecision authorizer.Decision
+
+		wantErr         bool
+		wantUsed        corev1.ResourceList
+		wantAuthzParent logicalcluster.Name
+	}{
+		"create within limits is charged": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, false),
+			quota:       newQuota(list("10", "1"), list("5", "0")),
+			wantUsed:    list("6", "0"),
+		},
+		"create exceeding the object limit": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, false),
+			quota:       newQuota(list("10", ""), list("10", "")),
+			wantErr:     true,
+		},
+		"workspace creation exceeding the workspace limit": {
+			clusterName: "child",
+			attr:        createAttr(workspaces, false),
+			quota:       newQuota(list("10", "1"), list("5", "1")),
+			wantErr:     true,
+		},
+		"workspace creation within limits is charged twice": {
+			clusterName: "child",
+			attr:        createAttr(workspaces, false),
+			quota:       newQuota(list("10", "2"), list("5", "1")),
+			wantUsed:    list("6", "2"),
+		},
+		"create in an exempt workspace is neither checked nor charged": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, false),
+			quota:       newQuota(list("10", ""), list("10", "")),
+			exempt:      true,
+		},
+		"events are not counted": {
+			clusterName: "child",
+			attr:        createAttr(events, false),
+			quota:       newQuota(list("10", ""), list("10", "")),
+		},
+		"quota not limiting the created resource": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, false),
+			quota:       newQuota(list("", "1"), list("", "1")),
+		},
+		"dry run is checked, but not charged": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, true),
+			quota:       newQuota(list("10", ""), list("5", "")),
+		},
+		"conflict is retried against the live quota": {
+			clusterName: "child",
+			attr:        createAttr(configMaps, false),
+			quota:       newQuota(list("10", ""), list("5", "")),
+			liveQuota:   newQuota(list("10", ""), list("10", "")),
+			conflict:    true,
+			wantErr:     true,
+		},
+		"quota spec change, manager in parent": {
+			clusterName:     "child",
+			attr:            quotaAttr(admission.Update, newQuota(list("20", ""), nil), newQuota(list("10", ""), nil), ""),
+			authzDecision:   authorizer.DecisionAllow,
+			wantAuthzParent: "parent",
+		},
+		"quota spec change, no manager in parent": {
+			clusterName:     "child",
+			attr:            quotaAttr(admission.Update, newQuota(list("20", ""), nil), newQuota(list("10", ""), nil), ""),
+			authzDecision:   authorizer.DecisionDeny,
+			wantErr:         true,
+			wantAuthzParent: "parent",
+		},
+		"quota creation, no manager in parent": {
+			clusterName:     "child",
+			attr:            quotaAttr(admission.Create, newQuota(list("20", ""), nil), nil, ""),
+			authzDecision:   authorizer.DecisionDeny,
+			wantErr:         true,
+			wantAuthzParent: "parent",
+		},
+		"quota status update": {
+			clusterName: "child",
+			attr:        quotaAttr(admission.Update, newQuota(list("10", ""), list("5", "")), newQuota(list("10", ""), nil), "status"),
+		},
+		"quota update keeping the spec": {
+			clusterName: "child",
+			attr:        quotaAttr(admission.Update, newQuota(list("10", ""), list("5", "")), newQuota(list("10", ""), nil), ""),
+		},
+		"quota deletion in a deleting logical cluster": {
+			clusterName: "deleting",
+			attr:        quotaAttr(admission.Delete, nil, newQuota(list("10", ""), nil), ""),
+		},
+		"quota without parent workspace": {
+			clusterName:   "root",
+			attr:          quotaAttr(admission.Create, newQuota(list("20", ""), nil), nil, ""),
+			authzDecision: authorizer.DecisionAllow,
+			wantErr:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := metav1.Now()
+			indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+			require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}},
+			}))
+			require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "child"}},
+				Spec: corev1alpha1.LogicalClusterSpec{
+					Owner: &corev1alpha1.LogicalClusterOwner{APIVersion: "tenancy.kcp.io/v1beta1", Resource: "workspaces", Cluster: "parent", Name: "team"},
+				},
+			}))
+			require.NoError(t, indexer.Add(&corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: map[string]string{logicalcluster.AnnotationKey: "deleting"}, DeletionTimestamp: &now},
+			}))
+
+			var authzParent logicalcluster.Name
+			var updated *tenancyv1alpha1.WorkspaceQuota
+			conflict := tt.conflict
+			o := &workspaceQuotaAdmission{
+				Handler:              admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+				logicalClusterLister: corev1alpha1listers.NewLogicalClusterClusterLister(indexer),
+				listQuotas: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceQuota, error) {
+					if tt.quota == nil {
+						return nil, nil
+					}
+					return []*tenancyv1alpha1.WorkspaceQuota{tt.quota}, nil
+				},
+				getQuota: func(ctx context.Context, clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceQuota, error) {
+					return tt.liveQuota, nil
+				},
+				updateQuotaStatus: func(ctx context.Context, clusterName logicalcluster.Name, quota *tenancyv1alpha1.WorkspaceQuota) (*tenancyv1alpha1.WorkspaceQuota, error) {
+					if conflict {
+						conflict = false
+						return nil, apierrors.NewConflict(tenancyv1alpha1.Resource("workspacequotas"), quota.Name, nil)
+					}
+					updated = quota
+					return quota, nil
+				},
+				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface) (authorizer.Authorizer, error) {
+					authzParent = clusterName
+					return &fakeAuthorizer{tt.authzDecision}, nil
+				},
+			}
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: tt.clusterName})
+			if tt.exempt {
+				ctx = throttling.WithExempt(ctx)
+			}
+			err := o.Validate(ctx, tt.attr, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantAuthzParent, authzParent)
+			if tt.wantUsed == nil {
+				require.Nil(t, updated)
+			} else {
+				require.NotNil(t, updated)
+				require.True(t, quotav1.Equals(tt.wantUsed, updated.Status.Used), "expected used %v, got %v", tt.wantUsed, updated.Status.Used)
+			}
+		})
+	}
+}
+
+type fakeAuthorizer struct {
+	decision authorizer.Decision
+}
+
+func (a *fakeAuthorizer) Authorize(ctx context.Context, attr authorizer.Attributes) (authorized authorizer.Decision, reason string, err error) {
+	if attr.GetVerb() != "manage" || attr.GetResource() != "workspacequotas" || attr.GetName() != "team" {
+		return authorizer.DecisionDeny, "unexpected attributes", nil
+	}
+	return a.decision, "reason", nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetombstone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// PluginName is the name used to identify this admission webhook.
+const PluginName = "tenancy.kcp.io/WorkspaceTombstone"
+
+// Register registers the WorkspaceTombstone admission webhook.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &workspaceTombstone{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+				now:     time.Now,
+			}, nil
+		})
+}
+
+// workspaceTombstone keeps the names of deleted workspaces reserved while their WorkspaceTombstone
+// has not expired, and reserves the management of WorkspaceTombstones to kcp. Deletion, i.e. purging
+// a tombstone early, is left to RBAC.
+type workspaceTombstone struct {
+	*admission.Handler
+
+	now          func() time.Time
+	getTombstone func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceTombstone, error)
+}
+
+// Ensure that the required admission interfaces are implemented.
+var (
+	_ = admission.ValidationInterface(&workspaceTombstone{})
+	_ = admission.InitializationValidator(&workspaceTombstone{})
+	_ = kcpinitializers.WantsKcpInformers(&workspaceTombstone{})
+)
+
+// Validate rejects the creation of workspaces whose name is reserved by an unexpired
+// WorkspaceTombstone, and the creation and update of WorkspaceTombstones by anybody but privileged
+// system users.
+func (o *workspaceTombstone) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetSubresource() != "" {
+		return nil
+	}
+
+	switch a.GetResource().GroupResource() {
+	case tenancyv1alpha1.Resource("workspacetombstones"):
+		if sets.NewString(a.GetUserInfo().GetGroups()...).Has(kuser.SystemPrivilegedGroup) {
+			return nil
+		}
+		return admission.NewForbidden(a, fmt.Errorf("workspace tombstones are created by kcp and can only be deleted"))
+
+	case tenancyv1beta1.Resource("workspaces"):
+		if a.GetOperation() != admission.Create {
+			return nil
+		}
+		u, ok := a.GetObject().(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected type %T", a.GetObject())
+		}
+		clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+
+		// generated names are set by now
+		tombstone, err := o.getTombstone(clusterName, u.GetName())
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if !o.now().Before(tombstone.Spec.ExpiresAt.Time) {
+			return nil // the tombstone controller will remove it soon
+		}
+		return admission.NewForbidden(a, fmt.Errorf("the name of a deleted workspace is reserved until %s. An admin can purge the reservation by deleting the WorkspaceTombstone %q",
+			tombstone.Spec.ExpiresAt.UTC().Format(time.RFC3339), tombstone.Name))
+	}
+
+	return nil
+}
+
+func (o *workspaceTombstone) ValidateInitialization() error {
+	if o.getTombstone == nil {
+		return fmt.Errorf(PluginName + " plugin needs a WorkspaceTombstone lister")
+	}
+	return nil
+}
+
+func (o *workspaceTombstone) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
+	tombstonesReady := informers.Tenancy().V1alpha1().WorkspaceTombstones().Informer().HasSynced
+	o.SetReadyFunc(tombstonesReady)
+
+	lister := informers.Tenancy().V1alpha1().WorkspaceTombstones().Lister()
+	o.getTombstone = func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceTombstone, error) {
+		return lister.Cluster(clusterName).Get(name)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetombstone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/admission/helpers"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func createAttr(obj runtime.Object, name string, resource string, kind string, op admission.Operation, groups ...string) admission.Attributes {
+	gvr := tenancyv1beta1.Resource(resource).WithVersion("v1beta1")
+	gvk := tenancyv1beta1.Kind(kind).WithVersion("v1beta1")
+	if resource == "workspacetombstones" {
+		gvr = tenancyv1alpha1.Resource(resource).WithVersion("v1alpha1")
+		gvk = tenancyv1alpha1.Kind(kind).WithVersion("v1alpha1")
+	}
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		nil,
+		gvk,
+		"",
+		name,
+		gvr,
+		"",
+		op,
+		&metav1.CreateOptions{},
+		false,
+		&user.DefaultInfo{Name: "user", Groups: groups},
+	)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)
+	tombstone := &tenancyv1alpha1.WorkspaceTombstone{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec:       tenancyv1alpha1.WorkspaceTombstoneSpec{ExpiresAt: metav1.NewTime(now.Add(time.Hour))},
+	}
+	expired := tombstone.DeepCopy()
+	expired.Spec.ExpiresAt = metav1.NewTime(now.Add(-time.Second))
+	workspace := &tenancyv1beta1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+	tests := map[string]struct {
+		attr      admission.Attributes
+		tombstone *tenancyv1alpha1.WorkspaceTombstone
+		wantErr   bool
+	}{
+		"workspace without tombstone": {
+			attr: createAttr(workspace, "foo", "workspaces", "Workspace", admission.Create),
+		},
+		"workspace with tombstone": {
+			attr:      createAttr(workspace, "foo", "workspaces", "Workspace", admission.Create),
+			tombstone: tombstone,
+			wantErr:   true,
+		},
+		"workspace with tombstone by privileged user": {
+			attr:      createAttr(workspace, "foo", "workspaces", "Workspace", admission.Create, user.SystemPrivilegedGroup),
+			tombstone: tombstone,
+			wantErr:   true,
+		},
+		"workspace with expired tombstone": {
+			attr:      createAttr(workspace, "foo", "workspaces", "Workspace", admission.Create),
+			tombstone: expired,
+		},
+		"workspace update with tombstone": {
+			attr:      createAttr(workspace, "foo", "workspaces", "Workspace", admission.Update),
+			tombstone: tombstone,
+		},
+		"tombstone created by user": {
+			attr:    createAttr(tombstone, "foo", "workspacetombstones", "WorkspaceTombstone", admission.Create),
+			wantErr: true,
+		},
+		"tombstone updated by user": {
+			attr:    createAttr(tombstone, "foo", "workspacetombstones", "WorkspaceTombstone", admission.Update),
+			wantErr: true,
+		},
+		"tombstone created by kcp": {
+			attr: createAttr(tombstone, "foo", "workspacetombstones", "WorkspaceTombstone", admission.Create, user.SystemPrivilegedGroup),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &workspaceTombstone{
+				Handler: admission.NewHandler(admission.Create, admission.Update),
+				now:     func() time.Time { return now },
+				getTombstone: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceTombstone, error) {
+					require.Equal(t, logicalcluster.Name("root:org"), clusterName)
+					if tc.tombstone == nil || tc.tombstone.Name != name {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetombstones"), name)
+					}
+					return tc.tombstone, nil
+				},
+			}
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "root:org"})
+			err := o.Validate(ctx, tc.attr, nil)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
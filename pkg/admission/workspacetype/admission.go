@@ -27,12 +27,14 @@ import (
 	"k8s.io/apiserver/pkg/admission"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 // Validate WorkspaceTypes creation and updates for
-//  - "organization" type is only created in root workspace.
+//  - "organization" type is only created in root workspace
+//  - the naming pattern is a valid regular expression.
 
 const (
 	PluginName = "tenancy.kcp.io/WorkspaceType"
@@ -97,5 +99,11 @@ func (o *workspacetype) Validate(ctx context.Context, a admission.Attributes, _
 		}
 	}
 
+	if wt.Spec.NamingPolicy != nil && wt.Spec.NamingPolicy.Pattern != "" {
+		if _, err := workspacetypeexists.CompileNamingPattern(wt.Spec.NamingPolicy.Pattern); err != nil {
+			return admission.NewForbidden(a, fmt.Errorf(".spec.namingPolicy.pattern is invalid: %w", err))
+		}
+	}
+
 	return nil
 }
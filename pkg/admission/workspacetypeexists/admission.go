@@ -26,6 +26,7 @@ import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,15 +38,15 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
-	tenancyv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -63,6 +64,9 @@ func Register(plugins *admission.Plugins) {
 				return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), plugin.typeIndexer, path, name)
 			}
 			plugin.transitiveTypeResolver = NewTransitiveTypeResolver(plugin.getType)
+			plugin.listWorkspacesWithTypeAndName = func(ref tenancyv1alpha1.WorkspaceTypeReference, name string) ([]*tenancyv1beta1.Workspace, error) {
+				return indexers.ByIndex[*tenancyv1beta1.Workspace](plugin.workspaceIndexer, byTypeAndName, typeAndNameKey(ref, name))
+			}
 
 			return plugin, nil
 		})
@@ -71,13 +75,16 @@ func Register(plugins *admission.Plugins) {
 // workspacetypeExists does the following
 //   - it checks existence of WorkspaceType in the same workspace,
 //   - it applies the WorkspaceType initializers to the Workspace when it
-//     transitions to the Initializing state.
+//     transitions to the Initializing state,
+//   - it enforces the naming policies of the WorkspaceType.
 type workspacetypeExists struct {
 	*admission.Handler
 
-	getType func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	getType                       func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	listWorkspacesWithTypeAndName func(ref tenancyv1alpha1.WorkspaceTypeReference, name string) ([]*tenancyv1beta1.Workspace, error)
 
 	typeIndexer            cache.Indexer
+	workspaceIndexer       cache.Indexer
 	typeLister             tenancyv1alpha1listers.WorkspaceTypeClusterLister
 	logicalClusterLister   corev1alpha1listers.LogicalClusterClusterLister
 	deepSARClient          kcpkubernetesclientset.ClusterInterface
@@ -173,6 +180,12 @@ func (o *workspacetypeExists) Admit(ctx context.Context, a admission.Attributes,
 
 	addAdditionalWorkspaceLabels(wt, ws)
 
+	// default the priority to the one of the type. Workspaces without priority have priority 0.
+	if ws.Spec.Priority == nil && wt.Spec.Priority != 0 {
+		priority := wt.Spec.Priority
+		ws.Spec.Priority = &priority
+	}
+
 	return updateUnstructured(u, ws)
 }
 
@@ -208,7 +221,8 @@ func (o *workspacetypeExists) resolveTypeRef(workspacePath logicalcluster.Path,
 
 // Validate ensures that
 // - has a valid type
-// - has valid initializers when transitioning to initializing.
+// - has valid initializers when transitioning to initializing
+// - has a name satisfying the naming policies of its type.
 func (o *workspacetypeExists) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) (err error) {
 	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
 	if err != nil {
@@ -248,6 +262,9 @@ func (o *workspacetypeExists) Validate(ctx context.Context, a admission.Attribut
 		if old.Spec.Type != ws.Spec.Type {
 			return admission.NewForbidden(a, errors.New("spec.type is immutable"))
 		}
+		if !equality.Semantic.DeepEqual(old.Spec.Priority, ws.Spec.Priority) {
+			return admission.NewForbidden(a, errors.New("spec.priority is immutable"))
+		}
 	case admission.Create:
 		if !o.WaitForReady() {
 			return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
@@ -268,6 +285,15 @@ func (o *workspacetypeExists) Validate(ctx context.Context, a admission.Attribut
 		if ws.Spec.Type.Path == "" {
 			return admission.NewForbidden(a, fmt.Errorf("spec.type.path must be set"))
 		}
+		if ws.Spec.Priority != nil && *ws.Spec.Priority > wt.Spec.Priority {
+			return admission.NewForbidden(a, fmt.Errorf("spec.priority %d exceeds the priority %d of workspace type %s:%s", *ws.Spec.Priority, wt.Spec.Priority, canonicalPathFrom(wt), wt.Name))
+		}
+		if err := validateNamingPolicies(ws.Name, wtAliases); err != nil {
+			return admission.NewForbidden(a, err)
+		}
+		if err := o.validateNameUniqueness(clusterName, ws, wtAliases); err != nil {
+			return admission.NewForbidden(a, err)
+		}
 
 		for _, alias := range wtAliases {
 			authz, err := o.createAuthorizer(logicalcluster.From(alias), o.deepSARClient)
@@ -333,22 +359,30 @@ func (o *workspacetypeExists) ValidateInitialization() error {
 	if o.logicalClusterLister == nil {
 		return fmt.Errorf(PluginName + " plugin needs an LogicalCluster lister")
 	}
+	if o.workspaceIndexer == nil {
+		return fmt.Errorf(PluginName + " plugin needs a Workspace indexer")
+	}
 	return nil
 }
 
 func (o *workspacetypeExists) SetKcpInformers(informers kcpinformers.SharedInformerFactory) {
 	typesReady := informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced
 	logicalClusterReady := informers.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	workspacesReady := informers.Tenancy().V1beta1().Workspaces().Informer().HasSynced
 	o.SetReadyFunc(func() bool {
-		return typesReady() && logicalClusterReady()
+		return typesReady() && logicalClusterReady() && workspacesReady()
 	})
 	o.typeLister = informers.Tenancy().V1alpha1().WorkspaceTypes().Lister()
 	o.typeIndexer = informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer()
+	o.workspaceIndexer = informers.Tenancy().V1beta1().Workspaces().Informer().GetIndexer()
 	o.logicalClusterLister = informers.Core().V1alpha1().LogicalClusters().Lister()
 
 	indexers.AddIfNotPresentOrDie(informers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
 	})
+	indexers.AddIfNotPresentOrDie(informers.Tenancy().V1beta1().Workspaces().Informer().GetIndexer(), cache.Indexers{
+		byTypeAndName: indexByTypeAndName,
+	})
 }
 
 func (o *workspacetypeExists) SetDeepSARClient(client kcpkubernetesclientset.ClusterInterface) {
@@ -39,12 +39,12 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/kcp-dev/kcp/pkg/admission/helpers"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
-	tenancyv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
 )
 
 func createAttr(obj *tenancyv1beta1.Workspace) admission.Attributes {
@@ -63,6 +63,22 @@ func createAttr(obj *tenancyv1beta1.Workspace) admission.Attributes {
 	)
 }
 
+func updateAttr(obj, old *tenancyv1beta1.Workspace) admission.Attributes {
+	return admission.NewAttributesRecord(
+		helpers.ToUnstructuredOrDie(obj),
+		helpers.ToUnstructuredOrDie(old),
+		tenancyv1alpha1.Kind("Workspace").WithVersion("v1beta1"),
+		"",
+		obj.Name,
+		tenancyv1alpha1.Resource("workspaces").WithVersion("v1beta1"),
+		"",
+		admission.Update,
+		&metav1.UpdateOptions{},
+		false,
+		&user.DefaultInfo{},
+	)
+}
+
 func TestAdmit(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -145,6 +161,30 @@ func TestAdmit(t *testing.T) {
 			a:           createAttr(newWorkspace("root:org:ws:test").Workspace),
 			expectedObj: newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace,
 		},
+		{
+			name: "defaults the priority of the type",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:foo").withPriority(100).WorkspaceType,
+			},
+			clusterName: logicalcluster.Name("root:org:ws"),
+			a:           createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace),
+			expectedObj: newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(100).Workspace,
+		},
+		{
+			name: "keeps a lower priority",
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:foo").withPriority(100).WorkspaceType,
+			},
+			clusterName: logicalcluster.Name("root:org:ws"),
+			a:           createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(10).Workspace),
+			expectedObj: newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(10).Workspace,
+		},
 		{
 			name:        "finds a type locally",
 			clusterName: logicalcluster.Name("foo:org:ws"),
@@ -225,6 +265,7 @@ func TestValidate(t *testing.T) {
 		name            string
 		types           []*tenancyv1alpha1.WorkspaceType
 		logicalClusters []*corev1alpha1.LogicalCluster
+		workspaces      []*tenancyv1beta1.Workspace
 		attr            admission.Attributes
 		clusterName     logicalcluster.Name
 
@@ -247,6 +288,50 @@ func TestValidate(t *testing.T) {
 			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace),
 			authzDecision: authorizer.DecisionAllow,
 		},
+		{
+			name:        "passes create if priority does not exceed the priority of the type",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:parent").WorkspaceType,
+				newType("root:org:foo").withPriority(100).WorkspaceType,
+			},
+			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(100).Workspace),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:        "fails create if priority exceeds the priority of the type",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:parent").WorkspaceType,
+				newType("root:org:foo").withPriority(100).WorkspaceType,
+			},
+			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(101).Workspace),
+			authzDecision: authorizer.DecisionAllow,
+			wantErr:       true,
+		},
+		{
+			name:        "fails update if priority changes",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			attr: updateAttr(
+				newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(10).Workspace,
+				newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace,
+			),
+			wantErr: true,
+		},
+		{
+			name:        "passes update if priority is unchanged",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			attr: updateAttr(
+				newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(10).withLabels(map[string]string{"a": "b"}).Workspace,
+				newWorkspace("root:org:ws:test").withType("root:org:foo").withPriority(10).Workspace,
+			),
+		},
 		{
 			name:        "fails create if type reference misses path",
 			clusterName: logicalcluster.Name("root:org:ws"),
@@ -416,6 +501,55 @@ func TestValidate(t *testing.T) {
 			authzError: errors.New("authorizer error"),
 			wantErr:    true,
 		},
+		{
+			name:        "fails if the name violates the naming policy of an extended type",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:parent").WorkspaceType,
+				newType("root:org:base").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Prefixes: []string{"team-"}}).WorkspaceType,
+				newType("root:org:foo").extending("root:org:base").WorkspaceType,
+			},
+			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace),
+			authzDecision: authorizer.DecisionAllow,
+			wantErr:       true,
+		},
+		{
+			name:        "passes create if the name is unique among workspaces of the type",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:parent").WorkspaceType,
+				newType("root:org:foo").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{UniquenessScope: tenancyv1alpha1.WorkspaceNameUniquenessScopeType}).WorkspaceType,
+			},
+			workspaces: []*tenancyv1beta1.Workspace{
+				newWorkspace("root:org:other:test").withType("root:org:bar").Workspace,
+				newWorkspace("root:org:other:another").withType("root:org:foo").Workspace,
+			},
+			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace),
+			authzDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:        "fails if the name is used by another workspace of the type",
+			clusterName: logicalcluster.Name("root:org:ws"),
+			logicalClusters: []*corev1alpha1.LogicalCluster{
+				newLogicalCluster("root:org:ws").withType("root:org", "parent").LogicalCluster,
+			},
+			types: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:org:parent").WorkspaceType,
+				newType("root:org:foo").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{UniquenessScope: tenancyv1alpha1.WorkspaceNameUniquenessScopeType}).WorkspaceType,
+			},
+			workspaces: []*tenancyv1beta1.Workspace{
+				newWorkspace("root:org:other:test").withType("root:org:foo").Workspace,
+			},
+			attr:          createAttr(newWorkspace("root:org:ws:test").withType("root:org:foo").Workspace),
+			authzDecision: authorizer.DecisionAllow,
+			wantErr:       true,
+		},
 		{
 			name:        "ignores different resources",
 			clusterName: logicalcluster.Name("root:org:ws"),
@@ -454,6 +588,15 @@ func TestValidate(t *testing.T) {
 					}, nil
 				},
 				transitiveTypeResolver: NewTransitiveTypeResolver(typeLister.GetByPath),
+				listWorkspacesWithTypeAndName: func(ref tenancyv1alpha1.WorkspaceTypeReference, name string) ([]*tenancyv1beta1.Workspace, error) {
+					var ret []*tenancyv1beta1.Workspace
+					for _, ws := range tt.workspaces {
+						if typeAndNameKey(ws.Spec.Type, ws.Name) == typeAndNameKey(ref, name) {
+							ret = append(ret, ws)
+						}
+					}
+					return ret, nil
+				},
 			}
 			ctx := request.WithCluster(context.Background(), request.Cluster{Name: tt.clusterName})
 			if err := o.Validate(ctx, tt.attr, nil); (err != nil) != tt.wantErr {
@@ -790,6 +933,89 @@ func TestValidateAllowedChildren(t *testing.T) {
 	}
 }
 
+func TestValidateNamingPolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		wsName  string
+		aliases []*tenancyv1alpha1.WorkspaceType
+		wantErr string
+	}{
+		{
+			name:   "no policy",
+			wsName: "anything",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").WorkspaceType,
+			},
+		},
+		{
+			name:   "pattern matches",
+			wsName: "team-42",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Pattern: "[a-z]+-[0-9]+"}).WorkspaceType,
+			},
+		},
+		{
+			name:   "pattern matches only part of the name",
+			wsName: "my-team-42-test",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Pattern: "[a-z]+-[0-9]+"}).WorkspaceType,
+			},
+			wantErr: `workspace type root:a requires names to match "[a-z]+-[0-9]+"`,
+		},
+		{
+			name:   "invalid pattern",
+			wsName: "test",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Pattern: "[a-z"}).WorkspaceType,
+			},
+			wantErr: "workspace type root:a has an invalid naming pattern",
+		},
+		{
+			name:   "one of the prefixes",
+			wsName: "payments-api",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Prefixes: []string{"billing-", "payments-"}}).WorkspaceType,
+			},
+		},
+		{
+			name:   "none of the prefixes",
+			wsName: "api",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Prefixes: []string{"billing-", "payments-"}}).WorkspaceType,
+			},
+			wantErr: "workspace type root:a requires names to start with one of [billing- payments-]",
+		},
+		{
+			name:   "denied word in any case",
+			wsName: "team-test",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:a").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{DeniedWords: []string{"TEST"}}).WorkspaceType,
+			},
+			wantErr: `workspace type root:a denies names containing "TEST"`,
+		},
+		{
+			name:   "policy of an extended type",
+			wsName: "team-42",
+			aliases: []*tenancyv1alpha1.WorkspaceType{
+				newType("root:b").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{Prefixes: []string{"team-"}}).WorkspaceType,
+				newType("root:a").extending("root:b").withNamingPolicy(tenancyv1alpha1.WorkspaceNamingPolicy{DeniedWords: []string{"42"}}).WorkspaceType,
+			},
+			wantErr: `workspace type root:a denies names containing "42"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNamingPolicies(tt.wsName, tt.aliases)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 type builder struct {
 	*tenancyv1alpha1.WorkspaceType
 }
@@ -859,6 +1085,16 @@ func (b builder) withAdditionalLabel(labels map[string]string) builder {
 	return b
 }
 
+func (b builder) withPriority(priority int32) builder {
+	b.WorkspaceType.Spec.Priority = priority
+	return b
+}
+
+func (b builder) withNamingPolicy(policy tenancyv1alpha1.WorkspaceNamingPolicy) builder {
+	b.WorkspaceType.Spec.NamingPolicy = &policy
+	return b
+}
+
 type wsBuilder struct {
 	*tenancyv1beta1.Workspace
 }
@@ -889,6 +1125,11 @@ func (b wsBuilder) withLabels(labels map[string]string) wsBuilder {
 	return b
 }
 
+func (b wsBuilder) withPriority(priority int32) wsBuilder {
+	b.Spec.Priority = &priority
+	return b
+}
+
 type thisWsBuilder struct {
 	*corev1alpha1.LogicalCluster
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetypeexists
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+const byTypeAndName = "workspacetypeexists-byTypeAndName"
+
+func indexByTypeAndName(obj interface{}) ([]string, error) {
+	ws, ok := obj.(*tenancyv1beta1.Workspace)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be a Workspace, but is %T", obj)
+	}
+	return []string{typeAndNameKey(ws.Spec.Type, ws.Name)}, nil
+}
+
+// typeAndNameKey joins the qualified type and the name of a workspace. It is unambiguous
+// because neither type names nor workspace names contain colons.
+func typeAndNameKey(ref tenancyv1alpha1.WorkspaceTypeReference, name string) string {
+	return logicalcluster.NewPath(ref.Path).Join(string(ref.Name)).Join(name).String()
+}
+
+// CompileNamingPattern compiles the pattern of a naming policy such that it has to match
+// the whole name.
+func CompileNamingPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// validateNamingPolicies checks the name of a workspace against the naming policies of
+// its type and of the types it extends.
+func validateNamingPolicies(name string, aliases []*tenancyv1alpha1.WorkspaceType) error {
+	var errs []error
+	for _, alias := range aliases {
+		policy := alias.Spec.NamingPolicy
+		if policy == nil {
+			continue
+		}
+		qualifiedType := canonicalPathFrom(alias).Join(alias.Name)
+
+		if policy.Pattern != "" {
+			re, err := CompileNamingPattern(policy.Pattern)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("workspace type %s has an invalid naming pattern: %w", qualifiedType, err))
+			} else if !re.MatchString(name) {
+				errs = append(errs, fmt.Errorf("workspace type %s requires names to match %q", qualifiedType, policy.Pattern))
+			}
+		}
+
+		if len(policy.Prefixes) > 0 {
+			found := false
+			for _, prefix := range policy.Prefixes {
+				if strings.HasPrefix(name, prefix) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				errs = append(errs, fmt.Errorf("workspace type %s requires names to start with one of %v", qualifiedType, policy.Prefixes))
+			}
+		}
+
+		lowerName := strings.ToLower(name)
+		for _, word := range policy.DeniedWords {
+			if word != "" && strings.Contains(lowerName, strings.ToLower(word)) {
+				errs = append(errs, fmt.Errorf("workspace type %s denies names containing %q", qualifiedType, word))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateNameUniqueness checks that no other workspace of the same type has the name of the
+// given workspace if one of the naming policies requires it. Only the workspaces known to this
+// shard are considered.
+func (o *workspacetypeExists) validateNameUniqueness(clusterName logicalcluster.Name, ws *tenancyv1beta1.Workspace, aliases []*tenancyv1alpha1.WorkspaceType) error {
+	for _, alias := range aliases {
+		if alias.Spec.NamingPolicy == nil || alias.Spec.NamingPolicy.UniquenessScope != tenancyv1alpha1.WorkspaceNameUniquenessScopeType {
+			continue
+		}
+
+		others, err := o.listWorkspacesWithTypeAndName(ws.Spec.Type, ws.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check uniqueness of name %q: %w", ws.Name, err)
+		}
+		for _, other := range others {
+			if logicalcluster.From(other) == clusterName {
+				continue // the sibling conflict is reported by the storage
+			}
+			return fmt.Errorf("workspace type %s requires unique names, but %q is already used by workspace %s",
+				canonicalPathFrom(alias).Join(alias.Name), ws.Name, logicalcluster.From(other).Path().Join(other.Name))
+		}
+		return nil
+	}
+
+	return nil
+}
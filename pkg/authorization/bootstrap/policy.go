@@ -19,12 +19,13 @@ package bootstrap
 import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
 	rbacv1helpers "k8s.io/kubernetes/pkg/apis/rbac/v1"
 	rbacrest "k8s.io/kubernetes/pkg/registry/rbac/rest"
 	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac/bootstrappolicy"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	"github.com/kcp-dev/kcp/pkg/apis/tenancy"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy"
 )
 
 const (
@@ -41,6 +42,17 @@ const (
 	SystemLogicalClusterAdmin = "system:kcp:logical-cluster-admin"
 	// SystemKcpWorkspaceAccessGroup is a group that gives a user system:authenticated access to a workspace.
 	SystemKcpWorkspaceAccessGroup = "system:kcp:workspace:access"
+	// SystemKcpWorkspaceDiscovery is the role granting every user with access to a workspace to
+	// read its workspace discovery endpoint at WorkspaceDiscoveryPath.
+	SystemKcpWorkspaceDiscovery = "system:kcp:workspace:discovery"
+	// WorkspaceDiscoveryPath is the path of the workspace discovery endpoint of every logical cluster.
+	WorkspaceDiscoveryPath = "/.well-known/kcp-workspace"
+	// SystemKcpAPIResourceSchemaDiff is the role granting every user with access to a workspace to
+	// call its APIResourceSchema diff endpoint at APIResourceSchemaDiffPath. The endpoint checks
+	// access to the compared APIResourceSchemas itself.
+	SystemKcpAPIResourceSchemaDiff = "system:kcp:apiresourceschema:diff"
+	// APIResourceSchemaDiffPath is the path of the APIResourceSchema diff endpoint of every logical cluster.
+	APIResourceSchemaDiffPath = "/apiresourceschema-diff"
 )
 
 // ClusterRoleBindings return default rolebindings to the default roles.
@@ -49,6 +61,8 @@ func clusterRoleBindings() []rbacv1.ClusterRoleBinding {
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding("cluster-admin").Groups(SystemKcpAdminGroup).BindingOrDie(), "system:kcp:admin:cluster-admin"),
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpWorkspaceBootstrapper).Groups(SystemKcpWorkspaceBootstrapper, "apis.kcp.io:binding:"+SystemKcpWorkspaceBootstrapper).BindingOrDie(), SystemKcpWorkspaceBootstrapper),
 		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemLogicalClusterAdmin).Groups(SystemLogicalClusterAdmin).BindingOrDie(), SystemLogicalClusterAdmin),
+		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpWorkspaceDiscovery).Groups(user.AllAuthenticated).BindingOrDie(), SystemKcpWorkspaceDiscovery),
+		clusterRoleBindingCustomName(rbacv1helpers.NewClusterBinding(SystemKcpAPIResourceSchemaDiff).Groups(user.AllAuthenticated).BindingOrDie(), SystemKcpAPIResourceSchemaDiff),
 	}
 }
 
@@ -82,6 +96,18 @@ func clusterRoles() []rbacv1.ClusterRole {
 				rbacv1helpers.NewRule("access").URLs("/").RuleOrDie(),
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: SystemKcpWorkspaceDiscovery},
+			Rules: []rbacv1.PolicyRule{
+				rbacv1helpers.NewRule("get").URLs(WorkspaceDiscoveryPath).RuleOrDie(),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: SystemKcpAPIResourceSchemaDiff},
+			Rules: []rbacv1.PolicyRule{
+				rbacv1helpers.NewRule("get").URLs(APIResourceSchemaDiffPath).RuleOrDie(),
+			},
+		},
 	}
 }
 
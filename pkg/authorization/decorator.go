@@ -24,6 +24,8 @@ import (
 	kaudit "k8s.io/apiserver/pkg/audit"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/requesttrace"
 )
 
 const (
@@ -54,7 +56,8 @@ func NewDecorator(key string, target authorizer.Authorizer) *Decorator {
 
 // AddAuditLogging logs every decision of the target authorizer for the given audit prefix key
 // if the decision is not allowed.
-// All authorizer decisions are being logged in the audit log if the context was set using EnableAuditLogging.
+// All authorizer decisions are being logged in the audit log, and recorded in the trace of traced
+// requests, if the context was set using EnableAuditLogging.
 // This prevents double audit log entries by multiple invocations of the authorizer chain.
 func (d *Decorator) AddAuditLogging() *Decorator {
 	target := d.target
@@ -73,6 +76,7 @@ func (d *Decorator) AddAuditLogging() *Decorator {
 				d.key+"/"+auditDecision, decisionString(dec),
 				d.key+"/"+auditReason, auditReasonMsg,
 			)
+			requesttrace.From(ctx).AddAuthorizerDecision(d.key, decisionString(dec), auditReasonMsg)
 		}
 
 		if dec != authorizer.DecisionAllow {
@@ -19,6 +19,7 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"time"
 
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	rbacv1listers "github.com/kcp-dev/client-go/listers/rbac/v1"
@@ -66,12 +67,12 @@ func (a *LocalAuthorizer) Authorize(ctx context.Context, attr authorizer.Attribu
 			a.roleLister.Cluster(cluster.Name),
 			a.roleLister.Cluster(genericcontrolplane.LocalAdminCluster),
 		)},
-		&rbac.RoleBindingLister{Lister: a.roleBindingLister.Cluster(cluster.Name)},
+		&rbac.RoleBindingLister{Lister: newTimeBoundRoleBindingLister(a.roleBindingLister.Cluster(cluster.Name), time.Now)},
 		&rbac.ClusterRoleGetter{Lister: rbacwrapper.NewMergedClusterRoleLister(
 			a.clusterRoleLister.Cluster(cluster.Name),
 			a.clusterRoleLister.Cluster(genericcontrolplane.LocalAdminCluster),
 		)},
-		&rbac.ClusterRoleBindingLister{Lister: a.clusterRoleBindingLister.Cluster(cluster.Name)},
+		&rbac.ClusterRoleBindingLister{Lister: newTimeBoundClusterRoleBindingLister(a.clusterRoleBindingLister.Cluster(cluster.Name), time.Now)},
 	)
 
 	dec, reason, err := scopedAuth.Authorize(ctx, attr)
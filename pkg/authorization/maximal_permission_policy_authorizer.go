@@ -19,6 +19,7 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"time"
 
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -32,10 +33,10 @@ import (
 	"k8s.io/kubernetes/pkg/genericcontrolplane"
 	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	"github.com/kcp-dev/kcp/pkg/indexers"
 	rbacwrapper "github.com/kcp-dev/kcp/pkg/virtual/framework/wrappers/rbac"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -68,15 +69,15 @@ func NewMaximalPermissionPolicyAuthorizer(kubeInformers kcpkubernetesinformers.S
 					kubeInformers.Rbac().V1().Roles().Lister().Cluster(clusterName),
 					kubeInformers.Rbac().V1().Roles().Lister().Cluster(genericcontrolplane.LocalAdminCluster),
 				)},
-				&rbac.RoleBindingLister{Lister: kubeInformers.Rbac().V1().RoleBindings().Lister().Cluster(clusterName)},
+				&rbac.RoleBindingLister{Lister: newTimeBoundRoleBindingLister(kubeInformers.Rbac().V1().RoleBindings().Lister().Cluster(clusterName), time.Now)},
 				&rbac.ClusterRoleGetter{Lister: rbacwrapper.NewMergedClusterRoleLister(
 					kubeInformers.Rbac().V1().ClusterRoles().Lister().Cluster(clusterName),
 					kubeInformers.Rbac().V1().ClusterRoles().Lister().Cluster(genericcontrolplane.LocalAdminCluster),
 				)},
-				&rbac.ClusterRoleBindingLister{Lister: rbacwrapper.NewMergedClusterRoleBindingLister(
+				&rbac.ClusterRoleBindingLister{Lister: newTimeBoundClusterRoleBindingLister(rbacwrapper.NewMergedClusterRoleBindingLister(
 					kubeInformers.Rbac().V1().ClusterRoleBindings().Lister().Cluster(clusterName),
 					kubeInformers.Rbac().V1().ClusterRoleBindings().Lister().Cluster(genericcontrolplane.LocalAdminCluster),
-				)},
+				), time.Now)},
 			)
 		},
 		delegate: delegate,
@@ -123,7 +124,7 @@ func (a *MaximalPermissionPolicyAuthorizer) Authorize(ctx context.Context, attr
 	}
 
 	// get the corresponding APIExport
-	path := logicalcluster.NewPath(relevantBinding.Spec.Reference.Export.Path)
+	path := relevantBinding.Spec.Reference.Export.Path.Path()
 	if path.Empty() {
 		path = lcluster.Path()
 	}
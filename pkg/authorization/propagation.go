@@ -0,0 +1,27 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+const (
+	// PropagateLabelKey on a ClusterRole or ClusterRoleBinding with the value "true" opts the object
+	// into being replicated into the logical clusters of all descendant workspaces.
+	PropagateLabelKey = "authorization.kcp.io/propagate"
+
+	// PropagatedFromAnnotationKey is set on replicated ClusterRoles and ClusterRoleBindings to the
+	// logical cluster of the parent workspace they were copied from.
+	PropagatedFromAnnotationKey = "authorization.kcp.io/propagated-from"
+)
@@ -19,12 +19,16 @@ package authorization
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
+var writeVerbs = sets.NewString("create", "update", "patch", "delete", "deletecollection")
+
 // SystemCRDAuthorizer protects the system CRDs from users who are admins in their workspaces.
 type SystemCRDAuthorizer struct {
 	delegate authorizer.Authorizer
@@ -48,6 +52,14 @@ func (a *SystemCRDAuthorizer) Authorize(ctx context.Context, attr authorizer.Att
 		case attr.GetResource() == "apiexports" && attr.GetSubresource() == "status":
 			return authorizer.DecisionDeny, "apiexport status updates not permitted", nil
 		}
+	case attr.GetAPIGroup() == tenancyv1alpha1.SchemeGroupVersion.Group:
+		switch {
+		case attr.GetResource() == "workspaceusages" && writeVerbs.Has(attr.GetVerb()):
+			return authorizer.DecisionDeny, "workspaceusages are maintained by kcp", nil
+		case attr.GetResource() == "datajobs" && writeVerbs.Has(attr.GetVerb()):
+			// data jobs rewrite objects in all logical clusters of the shard
+			return authorizer.DecisionDeny, "datajobs can only be managed by kcp admins", nil
+		}
 	}
 
 	return DelegateAuthorization("no system CRD violation", a.delegate).Authorize(ctx, attr)
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+)
+
+const (
+	// BindingValidFromAnnotationKey is an RFC3339 timestamp on a RoleBinding or ClusterRoleBinding
+	// before which the binding grants no permissions.
+	BindingValidFromAnnotationKey = "authorization.kcp.io/valid-from"
+
+	// BindingValidUntilAnnotationKey is an RFC3339 timestamp on a RoleBinding or ClusterRoleBinding
+	// after which the binding grants no permissions. Expired bindings are deleted by a controller.
+	BindingValidUntilAnnotationKey = "authorization.kcp.io/valid-until"
+)
+
+// BindingActive returns true if the binding is within its access window at the given time.
+// A binding with an unparsable timestamp is never active.
+func BindingActive(binding metav1.Object, now time.Time) bool {
+	from, until, err := BindingValidity(binding)
+	if err != nil {
+		return false
+	}
+	if !from.IsZero() && now.Before(from) {
+		return false
+	}
+	if !until.IsZero() && !now.Before(until) {
+		return false
+	}
+	return true
+}
+
+// BindingValidity returns the access window of the binding. Zero times mean the window is open
+// on that side.
+func BindingValidity(binding metav1.Object) (from, until time.Time, err error) {
+	annotations := binding.GetAnnotations()
+	if value, found := annotations[BindingValidFromAnnotationKey]; found {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if value, found := annotations[BindingValidUntilAnnotationKey]; found {
+		if until, err = time.Parse(time.RFC3339, value); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return from, until, nil
+}
+
+var _ rbaclisters.RoleBindingLister = (*timeBoundRoleBindingLister)(nil)
+var _ rbaclisters.RoleBindingNamespaceLister = (*timeBoundRoleBindingNamespaceLister)(nil)
+
+// newTimeBoundRoleBindingLister returns a lister hiding the RoleBindings outside of their access window.
+func newTimeBoundRoleBindingLister(lister rbaclisters.RoleBindingLister, now func() time.Time) rbaclisters.RoleBindingLister {
+	return &timeBoundRoleBindingLister{lister: lister, now: now}
+}
+
+type timeBoundRoleBindingLister struct {
+	lister rbaclisters.RoleBindingLister
+	now    func() time.Time
+}
+
+func (l *timeBoundRoleBindingLister) List(selector labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	list, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return activeRoleBindings(list, l.now()), nil
+}
+
+func (l *timeBoundRoleBindingLister) RoleBindings(namespace string) rbaclisters.RoleBindingNamespaceLister {
+	return &timeBoundRoleBindingNamespaceLister{lister: l.lister.RoleBindings(namespace), now: l.now}
+}
+
+type timeBoundRoleBindingNamespaceLister struct {
+	lister rbaclisters.RoleBindingNamespaceLister
+	now    func() time.Time
+}
+
+func (l *timeBoundRoleBindingNamespaceLister) List(selector labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	list, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return activeRoleBindings(list, l.now()), nil
+}
+
+func (l *timeBoundRoleBindingNamespaceLister) Get(name string) (*rbacv1.RoleBinding, error) {
+	return l.lister.Get(name)
+}
+
+func activeRoleBindings(list []*rbacv1.RoleBinding, now time.Time) []*rbacv1.RoleBinding {
+	active := make([]*rbacv1.RoleBinding, 0, len(list))
+	for _, binding := range list {
+		if BindingActive(binding, now) {
+			active = append(active, binding)
+		}
+	}
+	return active
+}
+
+var _ rbaclisters.ClusterRoleBindingLister = (*timeBoundClusterRoleBindingLister)(nil)
+
+// newTimeBoundClusterRoleBindingLister returns a lister hiding the ClusterRoleBindings outside of their access window.
+func newTimeBoundClusterRoleBindingLister(lister rbaclisters.ClusterRoleBindingLister, now func() time.Time) rbaclisters.ClusterRoleBindingLister {
+	return &timeBoundClusterRoleBindingLister{lister: lister, now: now}
+}
+
+type timeBoundClusterRoleBindingLister struct {
+	lister rbaclisters.ClusterRoleBindingLister
+	now    func() time.Time
+}
+
+func (l *timeBoundClusterRoleBindingLister) List(selector labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	list, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]*rbacv1.ClusterRoleBinding, 0, len(list))
+	for _, binding := range list {
+		if BindingActive(binding, l.now()) {
+			active = append(active, binding)
+		}
+	}
+	return active, nil
+}
+
+func (l *timeBoundClusterRoleBindingLister) Get(name string) (*rbacv1.ClusterRoleBinding, error) {
+	return l.lister.Get(name)
+}
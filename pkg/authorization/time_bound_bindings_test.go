@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestBindingActive(t *testing.T) {
+	now := time.Date(2023, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"no window": {
+			want: true,
+		},
+		"not yet valid": {
+			annotations: map[string]string{BindingValidFromAnnotationKey: "2023-01-22T13:00:00Z"},
+		},
+		"valid from the past": {
+			annotations: map[string]string{BindingValidFromAnnotationKey: "2023-01-22T11:00:00Z"},
+			want:        true,
+		},
+		"within window": {
+			annotations: map[string]string{BindingValidFromAnnotationKey: "2023-01-22T11:00:00Z", BindingValidUntilAnnotationKey: "2023-01-22T13:00:00Z"},
+			want:        true,
+		},
+		"expired": {
+			annotations: map[string]string{BindingValidUntilAnnotationKey: "2023-01-22T12:00:00Z"},
+		},
+		"invalid timestamp": {
+			annotations: map[string]string{BindingValidUntilAnnotationKey: "tomorrow"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding", Annotations: tt.annotations}}
+			require.Equal(t, tt.want, BindingActive(binding, now))
+		})
+	}
+}
+
+func TestTimeBoundRoleBindingLister(t *testing.T) {
+	now := time.Date(2023, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "permanent"}}))
+	require.NoError(t, indexer.Add(&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "contractor",
+		Annotations: map[string]string{BindingValidUntilAnnotationKey: "2023-01-22T11:00:00Z"},
+	}}))
+
+	lister := newTimeBoundRoleBindingLister(rbaclisters.NewRoleBindingLister(indexer), func() time.Time { return now })
+	bindings, err := lister.RoleBindings("default").List(labels.Everything())
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	require.Equal(t, "permanent", bindings[0].Name)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
 	rbacv1listers "github.com/kcp-dev/client-go/listers/rbac/v1"
@@ -34,10 +35,10 @@ import (
 	"k8s.io/kubernetes/pkg/genericcontrolplane"
 	"k8s.io/kubernetes/plugin/pkg/auth/authorizer/rbac"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
 	rbacwrapper "github.com/kcp-dev/kcp/pkg/virtual/framework/wrappers/rbac"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 const (
@@ -132,15 +133,15 @@ func (a *workspaceContentAuthorizer) Authorize(ctx context.Context, attr authori
 				a.roleLister.Cluster(cluster.Name),
 				a.roleLister.Cluster(genericcontrolplane.LocalAdminCluster),
 			)},
-			&rbac.RoleBindingLister{Lister: a.roleBindingLister.Cluster(cluster.Name)},
+			&rbac.RoleBindingLister{Lister: newTimeBoundRoleBindingLister(a.roleBindingLister.Cluster(cluster.Name), time.Now)},
 			&rbac.ClusterRoleGetter{Lister: rbacwrapper.NewMergedClusterRoleLister(
 				a.clusterRoleLister.Cluster(cluster.Name),
 				a.clusterRoleLister.Cluster(genericcontrolplane.LocalAdminCluster),
 			)},
-			&rbac.ClusterRoleBindingLister{Lister: rbacwrapper.NewMergedClusterRoleBindingLister(
+			&rbac.ClusterRoleBindingLister{Lister: newTimeBoundClusterRoleBindingLister(rbacwrapper.NewMergedClusterRoleBindingLister(
 				a.clusterRoleBindingLister.Cluster(cluster.Name),
 				a.clusterRoleBindingLister.Cluster(genericcontrolplane.LocalAdminCluster),
-			)},
+			), time.Now)},
 		)
 
 		workspaceAttr := authorizer.AttributesRecord{
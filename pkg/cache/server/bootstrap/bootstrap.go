@@ -46,8 +46,12 @@ func Bootstrap(ctx context.Context, apiExtensionsClusterClient kcpapiextensionsc
 	for _, gr := range []struct{ group, resource string }{
 		{"apis.kcp.io", "apiresourceschemas"},
 		{"apis.kcp.io", "apiexports"},
+		{"apis.kcp.io", "apiexportconsumersummaries"},
 		{"core.kcp.io", "shards"},
 		{"tenancy.kcp.io", "workspacetypes"},
+		{"tenancy.kcp.io", "workspaces"},
+		{"core.kcp.io", "logicalclusters"},
+		{"tenancy.kcp.io", "throttlingexemptions"},
 	} {
 		crd := &apiextensionsv1.CustomResourceDefinition{}
 		if err := configcrds.Unmarshal(fmt.Sprintf("%s_%s.yaml", gr.group, gr.resource), crd); err != nil {
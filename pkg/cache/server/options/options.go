@@ -17,6 +17,8 @@ limitations under the License.
 package options
 
 import (
+	"net"
+
 	"github.com/spf13/pflag"
 
 	genericoptions "k8s.io/apiserver/pkg/server/options"
@@ -95,7 +97,7 @@ func (o *Options) Complete() (*CompletedOptions, error) {
 	o.Authentication = nil
 	o.Authorization = nil
 
-	if err := o.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", nil, nil); err != nil {
+	if err := o.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", nil, []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}); err != nil {
 		return nil, err
 	}
 
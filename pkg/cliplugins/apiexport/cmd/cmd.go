@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/pkg/cliplugins/apiexport/plugin"
+)
+
+var (
+	docsExampleUses = `
+	# Print the schema documentation of the APIExport "my-export" in the "root:my-service" workspace.
+	%[1]s apiexport docs root:my-service:my-export
+
+	# Print the schema documentation of the APIExport "my-export" in the current workspace as YAML.
+	%[1]s apiexport docs my-export -o yaml
+	`
+)
+
+// New returns a cobra.Command for APIExport related actions.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "apiexport",
+		Short:            "Operations related to APIExports",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	docsOpts := plugin.NewDocsOptions(streams)
+	docsCmd := &cobra.Command{
+		Use:          "docs <[workspace_path:]apiexport-name>",
+		Short:        "Print the schema documentation of an APIExport",
+		Example:      fmt.Sprintf(docsExampleUses, "kubectl kcp"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := docsOpts.Complete(args); err != nil {
+				return err
+			}
+
+			if err := docsOpts.Validate(); err != nil {
+				return err
+			}
+
+			return docsOpts.Run(cmd.Context())
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			completions, err := docsOpts.CompleteAPIExportRef(cmd.Context(), toComplete)
+			if err != nil {
+				cobra.CompErrorln(err.Error())
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+		},
+	}
+	docsOpts.BindFlags(docsCmd)
+
+	cmd.AddCommand(docsCmd)
+
+	return cmd
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
+	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	"github.com/kcp-dev/kcp/pkg/virtual/apiexportdocs"
+)
+
+// DocsOptions contains the options for fetching the schema documentation of an APIExport.
+type DocsOptions struct {
+	*base.Options
+
+	// APIExportRef is the argument accepted by the command. It is either the name of an
+	// APIExport in the current workspace, or <absolute_ref_to_workspace>:<apiexport>.
+	APIExportRef string
+
+	// Output is the output format, json or yaml.
+	Output string
+}
+
+// NewDocsOptions returns new DocsOptions.
+func NewDocsOptions(streams genericclioptions.IOStreams) *DocsOptions {
+	return &DocsOptions{
+		Options: base.NewOptions(streams),
+		Output:  "json",
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (d *DocsOptions) BindFlags(cmd *cobra.Command) {
+	d.Options.BindFlags(cmd)
+
+	cmd.Flags().StringVarP(&d.Output, "output", "o", d.Output, "Output format. One of: json, yaml.")
+}
+
+// Complete ensures all fields are initialized.
+func (d *DocsOptions) Complete(args []string) error {
+	if err := d.Options.Complete(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		d.APIExportRef = args[0]
+	}
+	return nil
+}
+
+// Validate validates the DocsOptions are complete and usable.
+func (d *DocsOptions) Validate() error {
+	if d.APIExportRef == "" {
+		return errors.New("the name of the APIExport or a `root:ws:apiexport_object` reference is required as an argument")
+	}
+	if !logicalcluster.NewPath(d.APIExportRef).IsValid() {
+		return fmt.Errorf("invalid APIExport reference %q. The format is `<apiexport>` or `<full>:<path>:<to>:<apiexport>`", d.APIExportRef)
+	}
+	if d.Output != "json" && d.Output != "yaml" {
+		return fmt.Errorf("unsupported output format %q, must be json or yaml", d.Output)
+	}
+
+	return d.Options.Validate()
+}
+
+// CompleteAPIExportRef returns the completions of the APIExport reference argument.
+func (d *DocsOptions) CompleteAPIExportRef(ctx context.Context, toComplete string) ([]string, error) {
+	if err := d.Options.Complete(); err != nil {
+		return nil, err
+	}
+	config, err := d.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := pluginhelpers.CompletePath(ctx, config, "workspaces", toComplete)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		completions = append(completions, ws+":")
+	}
+	exports, err := pluginhelpers.CompletePath(ctx, config, "apiexports", toComplete)
+	if err != nil {
+		return nil, err
+	}
+	return append(completions, exports...), nil
+}
+
+// Run fetches the documentation of the APIExport and prints it.
+func (d *DocsOptions) Run(ctx context.Context) error {
+	config, err := d.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	u, currentClusterName, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to workspace", config.Host)
+	}
+
+	exportPath, exportName := logicalcluster.NewPath(d.APIExportRef).Split()
+	if exportPath.Empty() {
+		exportPath = currentClusterName
+	}
+	u.Path = path.Join(u.Path, "services", apiexportdocs.VirtualWorkspaceName, exportPath.RequestPath(), "apiexports", exportName)
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to get documentation of APIExport %s|%s: %s: %s", exportPath, exportName, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var docs apiexportdocs.APIExportDocumentation
+	if err := json.NewDecoder(resp.Body).Decode(&docs); err != nil {
+		return err
+	}
+
+	var out []byte
+	switch d.Output {
+	case "yaml":
+		out, err = yaml.Marshal(&docs)
+	default:
+		out, err = json.MarshalIndent(&docs, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	_, err = d.Out.Write(out)
+	return err
+}
@@ -30,6 +30,9 @@ var (
 	bindExampleUses = `
 	# Create an APIBinding named "my-binding" that binds to the APIExport "my-export" in the "root:my-service" workspace.
 	%[1]s bind apiexport root:my-service:my-export --name my-binding
+
+	# Create an APIBinding named "my-export" that binds to the release v1.2.0 of the APIExport "my-export" in the "root:my-service" workspace.
+	%[1]s bind apiexport root:my-service:my-export@v1.2.0
 	`
 
 	bindComputeExampleUses = `
@@ -57,7 +60,7 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 
 	bindOpts := plugin.NewBindOptions(streams)
 	bindCmd := &cobra.Command{
-		Use:          "apiexport <workspace_path:apiexport-name>",
+		Use:          "apiexport <workspace_path:apiexport-name>[@release]",
 		Short:        "Bind to an APIExport",
 		Example:      fmt.Sprintf(bindExampleUses, "kubectl kcp"),
 		SilenceUsage: true,
@@ -72,6 +75,17 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 
 			return bindOpts.Run(cmd.Context())
 		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			completions, err := bindOpts.CompleteAPIExportRef(cmd.Context(), toComplete)
+			if err != nil {
+				cobra.CompErrorln(err.Error())
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+		},
 	}
 	bindOpts.BindFlags(bindCmd)
 
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -31,17 +32,18 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/rest"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
 	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 )
 
 // BindOptions contains the options for creating an APIBinding.
 type BindOptions struct {
 	*base.Options
 	// APIExportRef is the argument accepted by the command. It contains the
-	// reference to where APIExport exists. For ex: <absolute_ref_to_workspace>:<apiexport>.
+	// reference to where APIExport exists, optionally followed by a release.
+	// For ex: <absolute_ref_to_workspace>:<apiexport> or <absolute_ref_to_workspace>:<apiexport>@v1.2.0.
 	APIExportRef string
 	// Name of the APIBinding.
 	APIBindingName string
@@ -82,13 +84,40 @@ func (b *BindOptions) Validate() error {
 		return errors.New("`root:ws:apiexport_object` reference to bind is required as an argument")
 	}
 
-	if !logicalcluster.NewPath(b.APIExportRef).IsValid() {
+	exportRef, _ := splitRelease(b.APIExportRef)
+	if !logicalcluster.NewPath(exportRef).IsValid() {
 		return fmt.Errorf("fully qualified reference to workspace where APIExport exists is required. The format is `<logical-cluster-name>:<apiexport>` or `<full>:<path>:<to>:<apiexport>`")
 	}
 
 	return b.Options.Validate()
 }
 
+// CompleteAPIExportRef returns the completions of the APIExport reference argument. While the path
+// of the workspace of the APIExport is typed, the workspaces are completed as well.
+func (b *BindOptions) CompleteAPIExportRef(ctx context.Context, toComplete string) ([]string, error) {
+	if err := b.Options.Complete(); err != nil {
+		return nil, err
+	}
+	config, err := b.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := pluginhelpers.CompletePath(ctx, config, "workspaces", toComplete)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		completions = append(completions, ws+":")
+	}
+	exports, err := pluginhelpers.CompletePath(ctx, config, "apiexports", toComplete)
+	if err != nil {
+		return nil, err
+	}
+	return append(completions, exports...), nil
+}
+
 // Run creates an apibinding for the user.
 func (b *BindOptions) Run(ctx context.Context) error {
 	config, err := b.ClientConfig.ClientConfig()
@@ -96,7 +125,8 @@ func (b *BindOptions) Run(ctx context.Context) error {
 		return err
 	}
 
-	path, apiExportName := logicalcluster.NewPath(b.APIExportRef).Split()
+	exportRef, release := splitRelease(b.APIExportRef)
+	path, apiExportName := logicalcluster.NewPath(exportRef).Split()
 
 	// if a custom name is not provided, default it to <apiExportname>.
 	apiBindingName := b.APIBindingName
@@ -116,10 +146,11 @@ func (b *BindOptions) Run(ctx context.Context) error {
 		Spec: apisv1alpha1.APIBindingSpec{
 			Reference: apisv1alpha1.BindingReference{
 				Export: &apisv1alpha1.ExportBindingReference{
-					Path: path.String(),
+					Path: apisv1alpha1.NewLogicalClusterPath(path),
 					Name: apiExportName,
 				},
 			},
+			Release: release,
 		},
 	}
 
@@ -160,6 +191,13 @@ func (b *BindOptions) Run(ctx context.Context) error {
 	return nil
 }
 
+// splitRelease splits an APIExport reference of the form <path>:<apiexport>@<release> into
+// the APIExport reference and the release, which is empty if not given.
+func splitRelease(ref string) (string, string) {
+	exportRef, release, _ := strings.Cut(ref, "@")
+	return exportRef, release
+}
+
 func newKCPClusterClient(config *rest.Config) (kcpclientset.ClusterInterface, error) {
 	clusterConfig := rest.CopyConfig(config)
 	u, err := url.Parse(config.Host)
@@ -36,11 +36,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
-	kcpclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned"
 	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
 )
 
 type BindComputeOptions struct {
@@ -232,7 +232,7 @@ func bindingsReady(bindings []*apisv1alpha1.APIBinding) (bool, string) {
 		} else if conditions.IsFalse(binding, apisv1alpha1.APIExportValid) {
 			conditionMessage = conditions.GetMessage(binding, apisv1alpha1.APIExportValid)
 		}
-		return false, fmt.Sprintf("APIBinding %q is not bound to APIExport %q yet: %s", binding.Name, logicalcluster.NewPath(binding.Spec.Reference.Export.Path).Join(binding.Spec.Reference.Export.Name), conditionMessage)
+		return false, fmt.Sprintf("APIBinding %q is not bound to APIExport %q yet: %s", binding.Name, binding.Spec.Reference.Export.Path.Path().Join(binding.Spec.Reference.Export.Name), conditionMessage)
 	}
 
 	return true, ""
@@ -271,7 +271,7 @@ func (o *BindComputeOptions) applyAPIBinding(ctx context.Context, client kcpclie
 			continue
 		}
 		// TODO(sttts): binding.Spec.Reference.Export.Path is not unique for one export. This whole method does not work reliably.
-		path := logicalcluster.NewPath(binding.Spec.Reference.Export.Path)
+		path := binding.Spec.Reference.Export.Path.Path()
 		if path.Empty() {
 			path = logicalcluster.From(&binding).Path()
 		}
@@ -308,7 +308,7 @@ func (o *BindComputeOptions) applyAPIBinding(ctx context.Context, client kcpclie
 			Spec: apisv1alpha1.APIBindingSpec{
 				Reference: apisv1alpha1.BindingReference{
 					Export: &apisv1alpha1.ExportBindingReference{
-						Path: path.String(),
+						Path: apisv1alpha1.NewLogicalClusterPath(path),
 						Name: name,
 					},
 				},
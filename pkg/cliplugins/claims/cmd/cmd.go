@@ -80,6 +80,17 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 			}
 			return apibindingGetOpts.Run(cmd.Context())
 		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			completions, err := apibindingGetOpts.CompleteAPIBindingName(cmd.Context(), toComplete)
+			if err != nil {
+				cobra.CompErrorln(err.Error())
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		},
 	}
 	apibindingGetOpts.BindFlags(apibindingGetCmd)
 	getcmd.AddCommand(apibindingGetCmd)
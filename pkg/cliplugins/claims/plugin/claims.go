@@ -32,10 +32,10 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
-	apiv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
 	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	apiv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 )
 
 // GetAPIBindingOptions contains the options for fetching claims
@@ -132,6 +132,38 @@ func (g *GetAPIBindingOptions) Run(ctx context.Context) error {
 	return utilerrors.NewAggregate(allErrors)
 }
 
+// CompleteAPIBindingName returns the names of the APIBindings in the current workspace starting with
+// toComplete. A workspace has few APIBindings, hence they are listed directly.
+func (g *GetAPIBindingOptions) CompleteAPIBindingName(ctx context.Context, toComplete string) ([]string, error) {
+	if err := g.Options.Complete(); err != nil {
+		return nil, err
+	}
+	cfg, err := g.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	_, currentClusterName, err := pluginhelpers.ParseClusterURL(cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("current URL %q does not point to workspace", cfg.Host)
+	}
+	kcpClusterClient, err := newKCPClusterClient(g.ClientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := kcpClusterClient.Cluster(currentClusterName).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, b := range bindings.Items {
+		if strings.HasPrefix(b.Name, toComplete) {
+			names = append(names, b.Name)
+		}
+	}
+	return names, nil
+}
+
 func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
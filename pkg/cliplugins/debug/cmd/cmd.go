@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/kcp-dev/kcp/pkg/cliplugins/debug/plugin"
+)
+
+var (
+	requestExampleUses = `
+	# Trace why the user "alice" cannot list the ConfigMaps in the current workspace.
+	%[1]s debug request /api/v1/namespaces/default/configmaps --user alice --group dev
+
+	# Trace the creation of a Widget in the "root:org:team" workspace as a dry-run.
+	%[1]s debug request -X POST -f widget.yaml '/clusters/root:org:team/apis/example.com/v1/namespaces/default/widgets?dryRun=All'
+	`
+)
+
+// New returns a cobra.Command for diagnostic actions.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "debug",
+		Short:            "Diagnose requests against kcp",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	requestOpts := plugin.NewRequestOptions(streams)
+	requestCmd := &cobra.Command{
+		Use:   "request <path>",
+		Short: "Execute a request with tracing of its filters, authorizers, admission plugins and storage",
+		Long: `Execute a single request with tracing and print the trace instead of the response.
+
+The trace shows the filters the request passed, the decision of every authorizer layer with its
+reason, the result of every admission plugin, the shard and logical cluster the request was
+served from, and the response. Tracing requires a privileged system user, i.e. a member of the
+system:masters group. Use --user and --group to execute the request as the user whose request
+fails, not --as, as impersonation drops the privileges. Requests are executed for real, use
+?dryRun=All for changes. Watches cannot be traced.`,
+		Example:      fmt.Sprintf(requestExampleUses, "kubectl kcp"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requestOpts.Complete(args); err != nil {
+				return err
+			}
+
+			if err := requestOpts.Validate(); err != nil {
+				return err
+			}
+
+			return requestOpts.Run(cmd.Context())
+		},
+	}
+	requestOpts.BindFlags(requestCmd)
+
+	cmd.AddCommand(requestCmd)
+
+	return cmd
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
+	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	"github.com/kcp-dev/kcp/pkg/requesttrace"
+)
+
+// RequestOptions contains the options for tracing a single request.
+type RequestOptions struct {
+	*base.Options
+
+	// Path is the argument accepted by the command, the path of the request relative to the
+	// current workspace, or an absolute /clusters/<path>/... path.
+	Path string
+
+	// Method is the HTTP method of the request.
+	Method string
+	// Filename is the file the request body is read from, - for stdin. YAML is converted to JSON.
+	Filename string
+	// ContentType is the content type of the request body.
+	ContentType string
+	// User is the user the request is executed as. Empty means the user of the kubeconfig.
+	User string
+	// Groups are the groups of User.
+	Groups []string
+	// Output is the output format, json or yaml.
+	Output string
+}
+
+// NewRequestOptions returns new RequestOptions.
+func NewRequestOptions(streams genericclioptions.IOStreams) *RequestOptions {
+	return &RequestOptions{
+		Options:     base.NewOptions(streams),
+		Method:      http.MethodGet,
+		ContentType: "application/json",
+		Output:      "yaml",
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *RequestOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Method, "method", "X", o.Method, "HTTP method of the request.")
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "File to read the request body from, - for stdin. YAML is converted to JSON.")
+	cmd.Flags().StringVar(&o.ContentType, "content-type", o.ContentType, "Content type of the request body, e.g. application/merge-patch+json for patches.")
+	cmd.Flags().StringVar(&o.User, "user", o.User, "User to execute the request as, e.g. the tenant whose request fails. Defaults to the user of the kubeconfig.")
+	cmd.Flags().StringSliceVar(&o.Groups, "group", o.Groups, "Groups of the user given with --user. Can be repeated.")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "Output format. One of: json, yaml.")
+}
+
+// Complete ensures all fields are initialized.
+func (o *RequestOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		o.Path = args[0]
+	}
+	o.Method = strings.ToUpper(o.Method)
+	return nil
+}
+
+// Validate validates the RequestOptions are complete and usable.
+func (o *RequestOptions) Validate() error {
+	if o.Path == "" {
+		return errors.New("the path of the request is required as an argument")
+	}
+	if !strings.HasPrefix(o.Path, "/") {
+		return fmt.Errorf("the path %q must start with a slash", o.Path)
+	}
+	if len(o.Groups) > 0 && o.User == "" {
+		return errors.New("--group requires --user")
+	}
+	if o.Output != "json" && o.Output != "yaml" {
+		return fmt.Errorf("unsupported output format %q, must be json or yaml", o.Output)
+	}
+
+	return o.Options.Validate()
+}
+
+// Run executes the request with tracing and prints the trace.
+func (o *RequestOptions) Run(ctx context.Context) error {
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	u, currentClusterName, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to workspace", config.Host)
+	}
+	target, err := url.Parse(o.Path)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(target.Path, "/clusters/") {
+		u.Path = path.Join(u.Path, target.Path)
+	} else {
+		u.Path = path.Join(u.Path, currentClusterName.RequestPath(), target.Path)
+	}
+	u.RawQuery = target.RawQuery
+
+	var body io.Reader
+	if o.Filename != "" {
+		bs, err := o.readBody()
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(bs)
+	}
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, o.Method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", o.ContentType)
+	}
+	req.Header.Set(requesttrace.TraceHeader, "true")
+	if o.User != "" {
+		req.Header.Set(requesttrace.UserHeader, o.User)
+		for _, g := range o.Groups {
+			req.Header.Add(requesttrace.GroupHeader, g)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	trace, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to trace request: %s: %s", resp.Status, strings.TrimSpace(string(trace)))
+	}
+
+	var out []byte
+	switch o.Output {
+	case "yaml":
+		out, err = yaml.JSONToYAML(trace)
+	default:
+		var buf bytes.Buffer
+		err = json.Indent(&buf, trace, "", "  ")
+		out = append(buf.Bytes(), '\n')
+	}
+	if err != nil {
+		return err
+	}
+	_, err = o.Out.Write(out)
+	return err
+}
+
+func (o *RequestOptions) readBody() ([]byte, error) {
+	var bs []byte
+	var err error
+	if o.Filename == "-" {
+		bs, err = io.ReadAll(o.In)
+	} else {
+		bs, err = os.ReadFile(o.Filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.ContentType != "application/json" && !strings.HasSuffix(o.ContentType, "+json") {
+		return bs, nil
+	}
+	return yaml.YAMLToJSON(bs)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+)
+
+// maxCompletions is the maximum number of completions requested from the server.
+const maxCompletions = 100
+
+// SearchCompletions returns the absolute paths of the objects of the given type ("workspaces" or
+// "apiexports") that complete pathPrefix, an absolute path whose last segment is a name prefix. Only
+// objects directly in the parent path of pathPrefix are returned, e.g. root:org:team-a for root:org:te.
+//
+// The search virtual workspace serves the completions from the cache server, such that
+// workspaces with thousands of children do not have to be listed client-side.
+func SearchCompletions(ctx context.Context, config *rest.Config, typ, pathPrefix string) ([]string, error) {
+	var list search.SearchResultList
+	query := url.Values{
+		"type":       []string{typ},
+		"pathPrefix": []string{pathPrefix},
+		"limit":      []string{fmt.Sprintf("%d", maxCompletions)},
+	}
+	if err := getFromSearch(ctx, config, core.RootCluster.Path(), "", query, &list); err != nil {
+		return nil, fmt.Errorf("search for %s with prefix %q failed: %w", typ, pathPrefix, err)
+	}
+	completions := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		completions = append(completions, item.Path+":"+item.Name)
+	}
+	return completions, nil
+}
+
+// SearchWorkspaceTree returns the workspace tree below the given logical cluster with one request to
+// the search virtual workspace. A depth of 0 returns all levels.
+func SearchWorkspaceTree(ctx context.Context, config *rest.Config, cluster logicalcluster.Name, depth int) (*search.WorkspaceTree, error) {
+	var tree search.WorkspaceTree
+	query := url.Values{}
+	if depth > 0 {
+		query.Set("depth", fmt.Sprintf("%d", depth))
+	}
+	if err := getFromSearch(ctx, config, cluster.Path(), "tree", query, &tree); err != nil {
+		return nil, fmt.Errorf("listing the workspace tree of %s failed: %w", cluster, err)
+	}
+	return &tree, nil
+}
+
+// getFromSearch sends a GET request to the given sub-path of the search virtual workspace of a logical
+// cluster, and decodes the JSON response into into.
+func getFromSearch(ctx context.Context, config *rest.Config, cluster logicalcluster.Path, subPath string, query url.Values, into interface{}) error {
+	u, _, err := ParseClusterURL(config.Host)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "services", search.VirtualWorkspaceName, cluster.RequestPath(), subPath)
+	u.RawQuery = query.Encode()
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// CompletePath returns the absolute paths of the objects of the given type that complete toComplete.
+// Paths without a colon are completed to the root workspace, the only top-level workspace.
+func CompletePath(ctx context.Context, config *rest.Config, typ, toComplete string) ([]string, error) {
+	if !strings.Contains(toComplete, ":") {
+		if typ == "workspaces" && strings.HasPrefix(core.RootCluster.String(), toComplete) {
+			return []string{core.RootCluster.String()}, nil
+		}
+		return nil, nil
+	}
+	return SearchCompletions(ctx, config, typ, toComplete)
+}
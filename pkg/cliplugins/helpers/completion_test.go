@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+)
+
+func TestCompletePath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/services/search/clusters/root", r.URL.Path)
+		require.Equal(t, "workspaces", r.URL.Query().Get("type"))
+		require.Equal(t, "root:org:te", r.URL.Query().Get("pathPrefix"))
+		require.NoError(t, json.NewEncoder(w).Encode(search.SearchResultList{Items: []search.SearchResult{
+			{Kind: search.WorkspaceResultKind, Path: "root:org", Name: "team-a"},
+			{Kind: search.WorkspaceResultKind, Path: "root:org", Name: "team-b"},
+		}}))
+	}))
+	defer server.Close()
+	config := &rest.Config{Host: server.URL + "/clusters/root:org"}
+
+	completions, err := CompletePath(context.Background(), config, "workspaces", "root:org:te")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root:org:team-a", "root:org:team-b"}, completions)
+
+	completions, err = CompletePath(context.Background(), config, "workspaces", "ro")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root"}, completions, "expected root to be completed without a request")
+
+	completions, err = CompletePath(context.Background(), config, "apiexports", "ro")
+	require.NoError(t, err)
+	require.Empty(t, completions)
+}
+
+func TestSearchWorkspaceTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/services/search/clusters/orgcluster/tree", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("depth"))
+		require.NoError(t, json.NewEncoder(w).Encode(search.WorkspaceTree{Path: "root:org", Cluster: "orgcluster", Children: []search.WorkspaceTreeNode{
+			{Name: "team-a", Path: "root:org:team-a"},
+		}}))
+	}))
+	defer server.Close()
+	config := &rest.Config{Host: server.URL + "/clusters/root:org"}
+
+	tree, err := SearchWorkspaceTree(context.Background(), config, "orgcluster", 2)
+	require.NoError(t, err)
+	require.Equal(t, "root:org", tree.Path)
+	require.Len(t, tree.Children, 1)
+
+	oldServer := httptest.NewServer(http.NotFoundHandler())
+	defer oldServer.Close()
+	_, err = SearchWorkspaceTree(context.Background(), &rest.Config{Host: oldServer.URL + "/clusters/root:org"}, "orgcluster", 0)
+	require.Error(t, err, "expected an error if the server does not serve workspace trees")
+}
@@ -61,6 +61,9 @@ var (
 
 	# create a context with the current workspace, named context-name
 	%[1]s workspace create-context context-name
+
+	# create a workspace and import the CRDs and custom resources of namespace my-app of a Kubernetes cluster into it
+	%[1]s workspace import my-workspace --from-kubeconfig=cluster.kubeconfig --namespace=my-app
 `
 )
 
@@ -75,7 +78,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 
 	cmd := &cobra.Command{
 		Aliases:          []string{"ws", "workspaces"},
-		Use:              "workspace [create|create-context|use|current|<workspace>|..|.|-|~|<root:absolute:workspace>]",
+		Use:              "workspace [create|create-context|import|use|current|<workspace>|..|.|-|~|<root:absolute:workspace>]",
 		Short:            "Manages KCP workspaces",
 		Example:          fmt.Sprintf(workspaceExample, cliName),
 		SilenceUsage:     true,
@@ -92,6 +95,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 			}
 			return cmdOpts.Run(cmd.Context())
 		},
+		ValidArgsFunction: completeWorkspace(cmdOpts),
 	}
 	cmdOpts.BindFlags(cmd)
 
@@ -112,6 +116,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 			}
 			return useWorkspaceOpts.Run(c.Context())
 		},
+		ValidArgsFunction: completeWorkspace(useWorkspaceOpts),
 	}
 	useWorkspaceOpts.BindFlags(useCmd)
 
@@ -160,7 +165,7 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 
 	createContextOpts := plugin.NewCreateContextOptions(streams)
 	createContextCmd := &cobra.Command{
-		Use:          "create-context [<context-name>] [--overwrite]",
+		Use:          "create-context [<context-name>] [--overwrite] [--path-url]",
 		Short:        "Create a kubeconfig context for the current workspace",
 		Example:      "kcp workspace create-context",
 		SilenceUsage: true,
@@ -179,6 +184,25 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 	}
 	createContextOpts.BindFlags(createContextCmd)
 
+	importWorkspaceOpts := plugin.NewImportWorkspaceOptions(streams)
+	importCmd := &cobra.Command{
+		Use:          "import",
+		Short:        "Creates a new workspace and imports CRDs and custom resources of a Kubernetes cluster into it",
+		Example:      "kcp workspace import <workspace name> --from-kubeconfig=<file> [--from-context=<context>] --namespace=<namespace> [--group=<group>] [--type=<type>]",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := importWorkspaceOpts.Complete(args); err != nil {
+				return err
+			}
+			if err := importWorkspaceOpts.Validate(); err != nil {
+				return err
+			}
+			return importWorkspaceOpts.Run(cmd.Context())
+		},
+	}
+	importWorkspaceOpts.BindFlags(importCmd)
+
 	treeCmdOpts := plugin.NewTreeOptions(streams)
 	treeCmd := &cobra.Command{
 		Use:          "tree",
@@ -205,5 +229,22 @@ func New(streams genericclioptions.IOStreams) (*cobra.Command, error) {
 	cmd.AddCommand(currentCmd)
 	cmd.AddCommand(createCmd)
 	cmd.AddCommand(createContextCmd)
+	cmd.AddCommand(importCmd)
 	return cmd, nil
 }
+
+// completeWorkspace returns the shell completion of the workspace argument, served by the search
+// virtual workspace.
+func completeWorkspace(opts *plugin.UseWorkspaceOptions) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		completions, err := opts.CompleteWorkspace(cmd.Context(), toComplete)
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
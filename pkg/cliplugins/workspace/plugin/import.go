@@ -0,0 +1,410 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	apiextensionshelpers "k8s.io/apiextensions-apiserver/pkg/apihelpers"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
+	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+var namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// ImportWorkspaceOptions contains options for importing the CRDs and objects of an existing
+// Kubernetes cluster into a new workspace.
+type ImportWorkspaceOptions struct {
+	*base.Options
+
+	// Name is the name of the workspace to create.
+	Name string
+	// Type is the type of the workspace to create.
+	Type string
+	// FromKubeconfig is the kubeconfig of the cluster to import from.
+	FromKubeconfig string
+	// FromContext is the context of FromKubeconfig to use. The current context is used if empty.
+	FromContext string
+	// Namespaces are the namespaces to import objects from.
+	Namespaces []string
+	// Groups limits the imported CRDs to these API groups. All CRDs are imported if empty.
+	Groups []string
+	// ReadyWaitTimeout is how long to wait for the workspace and the imported CRDs to be ready.
+	ReadyWaitTimeout time.Duration
+}
+
+// NewImportWorkspaceOptions returns a new ImportWorkspaceOptions.
+func NewImportWorkspaceOptions(streams genericclioptions.IOStreams) *ImportWorkspaceOptions {
+	return &ImportWorkspaceOptions{
+		Options: base.NewOptions(streams),
+
+		ReadyWaitTimeout: time.Minute,
+	}
+}
+
+// BindFlags binds fields to cmd's flagset.
+func (o *ImportWorkspaceOptions) BindFlags(cmd *cobra.Command) {
+	o.Options.BindFlags(cmd)
+	cmd.Flags().StringVar(&o.Type, "type", o.Type, "A workspace type. The default type depends on where this child workspace is created.")
+	cmd.Flags().StringVar(&o.FromKubeconfig, "from-kubeconfig", o.FromKubeconfig, "Path to the kubeconfig of the Kubernetes cluster to import from")
+	cmd.Flags().StringVar(&o.FromContext, "from-context", o.FromContext, "Context of --from-kubeconfig to use. Defaults to its current context")
+	cmd.Flags().StringSliceVarP(&o.Namespaces, "namespace", "n", o.Namespaces, "Namespaces to import custom resources from. Can be repeated")
+	cmd.Flags().StringSliceVar(&o.Groups, "group", o.Groups, "API groups of the CRDs to import. Defaults to all CRDs. Can be repeated")
+	cmd.Flags().DurationVar(&o.ReadyWaitTimeout, "timeout", o.ReadyWaitTimeout, "Duration to wait for the workspace and the imported CRDs to be ready")
+}
+
+// Complete ensures all dynamically populated fields are initialized.
+func (o *ImportWorkspaceOptions) Complete(args []string) error {
+	if err := o.Options.Complete(); err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+
+	return nil
+}
+
+// Validate validates the ImportWorkspaceOptions are complete and usable.
+func (o *ImportWorkspaceOptions) Validate() error {
+	var errs []error
+
+	if o.Name == "" {
+		errs = append(errs, errors.New("workspace name is required"))
+	}
+	if o.FromKubeconfig == "" {
+		errs = append(errs, errors.New("--from-kubeconfig is required"))
+	}
+	if len(o.Namespaces) == 0 {
+		errs = append(errs, errors.New("at least one --namespace is required"))
+	}
+	if err := o.Options.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// Run creates a workspace and imports the CRDs and objects of the source cluster into it. If the
+// workspace exists already, the import goes into it, skipping objects that exist already.
+func (o *ImportWorkspaceOptions) Run(ctx context.Context) error {
+	sourceConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: o.FromKubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: o.FromContext},
+	).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("error loading --from-kubeconfig: %w", err)
+	}
+	sourceCRDClient, err := apiextensionsclient.NewForConfig(sourceConfig)
+	if err != nil {
+		return err
+	}
+	sourceDynamicClient, err := dynamic.NewForConfig(sourceConfig)
+	if err != nil {
+		return err
+	}
+
+	// collect before creating the workspace, such that errors do not leave an empty workspace behind
+	set, err := collectImport(ctx, sourceCRDClient, sourceDynamicClient, o.Namespaces, sets.NewString(o.Groups...))
+	if err != nil {
+		return err
+	}
+	for _, msg := range set.skipped {
+		if _, err := fmt.Fprintf(o.ErrOut, "Warning: %s\n", msg); err != nil {
+			return err
+		}
+	}
+
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+	u, currentClusterName, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return fmt.Errorf("current URL %q does not point to a workspace", config.Host)
+	}
+
+	createOptions := NewCreateWorkspaceOptions(o.IOStreams)
+	createOptions.Options = o.Options
+	createOptions.Name = o.Name
+	createOptions.Type = o.Type
+	createOptions.ReadyWaitTimeout = o.ReadyWaitTimeout
+	if err := createOptions.Complete([]string{o.Name}); err != nil {
+		return err
+	}
+	if err := createOptions.Validate(); err != nil {
+		return err
+	}
+
+	// an import failing halfway is resumed by running it again, i.e. into the existing workspace
+	ws, err := createOptions.kcpClusterClient.Cluster(currentClusterName).TenancyV1beta1().Workspaces().Get(ctx, o.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := createOptions.Run(ctx); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady:
+		return fmt.Errorf("workspace %q already exists but is not ready to use", o.Name)
+	default:
+		if _, err := fmt.Fprintf(o.Out, "Workspace %q already exists, importing into it.\n", o.Name); err != nil {
+			return err
+		}
+	}
+
+	targetConfig := rest.CopyConfig(config)
+	u.Path = path.Join(u.Path, "clusters", currentClusterName.Join(o.Name).String())
+	targetConfig.Host = u.String()
+
+	targetCRDClient, err := apiextensionsclient.NewForConfig(targetConfig)
+	if err != nil {
+		return err
+	}
+	targetDynamicClient, err := dynamic.NewForConfig(targetConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := applyImport(ctx, o.Out, targetCRDClient, targetDynamicClient, set, o.ReadyWaitTimeout); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(o.Out, promotionHints(set.crds))
+	return err
+}
+
+// importSet is what is imported from the source cluster.
+type importSet struct {
+	crds       []*apiextensionsv1.CustomResourceDefinition
+	namespaces []string
+	objects    []*unstructured.Unstructured
+
+	// skipped explains what cannot be imported, or cannot be imported as is.
+	skipped []string
+}
+
+// collectImport reads the CRDs of the given groups, or all CRDs if groups is empty, and their
+// objects in the given namespaces from the source cluster. Objects of cluster-scoped CRDs are
+// collected as well.
+func collectImport(ctx context.Context, crdClient apiextensionsclient.Interface, dynamicClient dynamic.Interface, namespaces []string, groups sets.String) (*importSet, error) {
+	crds, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing CRDs: %w", err)
+	}
+
+	set := &importSet{namespaces: sets.NewString(namespaces...).List()}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if groups.Len() > 0 && !groups.Has(crd.Spec.Group) {
+			continue
+		}
+		if crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter {
+			set.skipped = append(set.skipped, fmt.Sprintf("CRD %s is skipped because conversion webhooks are not supported in workspaces", crd.Name))
+			continue
+		}
+
+		version, err := apiextensionshelpers.GetCRDStorageVersion(crd)
+		if err != nil {
+			set.skipped = append(set.skipped, fmt.Sprintf("CRD %s is skipped: %v", crd.Name, err))
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural}
+
+		scopes := []string{metav1.NamespaceAll}
+		if crd.Spec.Scope == apiextensionsv1.NamespaceScoped {
+			scopes = set.namespaces
+		}
+		for _, ns := range scopes {
+			list, err := dynamicClient.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("error listing %s in namespace %q: %w", gvr.GroupResource(), ns, err)
+			}
+			for j := range list.Items {
+				obj := &list.Items[j]
+				if len(obj.GetOwnerReferences()) > 0 {
+					set.skipped = append(set.skipped, fmt.Sprintf("owner references of %s %s/%s are dropped, it will not be garbage collected with its owners", gvr.GroupResource(), obj.GetNamespace(), obj.GetName()))
+				}
+				set.objects = append(set.objects, obj)
+			}
+		}
+
+		set.crds = append(set.crds, crd)
+	}
+
+	return set, nil
+}
+
+// applyImport creates the CRDs, namespaces and objects of the import set in the target workspace.
+// Objects existing already are left untouched.
+func applyImport(ctx context.Context, out io.Writer, crdClient apiextensionsclient.Interface, dynamicClient dynamic.Interface, set *importSet, timeout time.Duration) error {
+	for _, crd := range set.crds {
+		if _, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Create(ctx, cleanCRD(crd), metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("error creating CRD %s: %w", crd.Name, err)
+		}
+	}
+	for _, crd := range set.crds {
+		if err := wait.PollImmediate(time.Millisecond*500, timeout, func() (bool, error) {
+			got, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crd.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			return apiextensionshelpers.IsCRDConditionTrue(got, apiextensionsv1.Established), nil
+		}); err != nil {
+			return fmt.Errorf("error waiting for CRD %s to be established: %w", crd.Name, err)
+		}
+	}
+	if _, err := fmt.Fprintf(out, "Imported %d CRD(s).\n", len(set.crds)); err != nil {
+		return err
+	}
+
+	for _, ns := range set.namespaces {
+		namespace := &unstructured.Unstructured{}
+		namespace.SetAPIVersion("v1")
+		namespace.SetKind("Namespace")
+		namespace.SetName(ns)
+		if _, err := dynamicClient.Resource(namespacesGVR).Create(ctx, namespace, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("error creating namespace %s: %w", ns, err)
+		}
+	}
+
+	subresources := map[schema.GroupKind]bool{}
+	resources := map[schema.GroupKind]string{}
+	for _, crd := range set.crds {
+		gk := schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}
+		resources[gk] = crd.Spec.Names.Plural
+		for _, v := range crd.Spec.Versions {
+			if v.Storage && v.Subresources != nil && v.Subresources.Status != nil {
+				subresources[gk] = true
+			}
+		}
+	}
+
+	for _, obj := range set.objects {
+		gvk := obj.GroupVersionKind()
+		gvr := gvk.GroupVersion().WithResource(resources[gvk.GroupKind()])
+
+		created, err := dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Create(ctx, cleanObject(obj), metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error creating %s %s/%s: %w", gvr.GroupResource(), obj.GetNamespace(), obj.GetName(), err)
+		}
+
+		// the status is dropped on create if it is a subresource
+		status, found := obj.Object["status"]
+		if !found || !subresources[gvk.GroupKind()] {
+			continue
+		}
+		created.Object["status"] = status
+		if _, err := dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error updating status of %s %s/%s: %w", gvr.GroupResource(), obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+	_, err := fmt.Fprintf(out, "Imported %d object(s) in %d namespace(s).\n", len(set.objects), len(set.namespaces))
+	return err
+}
+
+// cleanCRD returns a copy of the CRD without the fields set by the source cluster.
+func cleanCRD(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        crd.Name,
+			Labels:      crd.Labels,
+			Annotations: crd.Annotations,
+		},
+		Spec: *crd.Spec.DeepCopy(),
+	}
+}
+
+// cleanObject returns a copy of the object without the fields set by the source cluster. Owner
+// references are dropped as the UIDs of the owners change, collectImport warns about them.
+func cleanObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetSelfLink("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetDeletionTimestamp(nil)
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+	return obj
+}
+
+// promotionHints explains how to promote the imported CRDs to an APIExport, such that other
+// workspaces can bind to them instead of importing them again.
+func promotionHints(crds []*apiextensionsv1.CustomResourceDefinition) string {
+	if len(crds) == 0 {
+		return ""
+	}
+
+	byGroup := map[string][]string{}
+	for _, crd := range crds {
+		byGroup[crd.Spec.Group] = append(byGroup[crd.Spec.Group], crd.Name)
+	}
+	groups := make([]string, 0, len(byGroup))
+	for group := range byGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var b strings.Builder
+	b.WriteString("\nThe imported APIs are local to the workspace. To offer them to other workspaces, promote them to APIExports:\n")
+	for _, group := range groups {
+		names := byGroup[group]
+		sort.Strings(names)
+		fmt.Fprintf(&b, "\n  # %s\n", group)
+		for _, name := range names {
+			fmt.Fprintf(&b, "  kubectl get crd %s -o yaml | kubectl kcp crd snapshot -f - --prefix <prefix> >> %s.yaml\n", name, group)
+		}
+		fmt.Fprintf(&b, "  kubectl create -f %s.yaml\n", group)
+		fmt.Fprintf(&b, "  # then create an APIExport %s with spec.latestResourceSchemas:\n", group)
+		for _, name := range names {
+			fmt.Fprintf(&b, "  #   - <prefix>.%s\n", name)
+		}
+	}
+	b.WriteString("\nThe CRDs are to be deleted from the workspace before binding to the APIExports.\n")
+
+	return b.String()
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newImportCRD(group, plural, kind string, scope apiextensionsv1.ResourceScope) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            plural + "." + group,
+			ResourceVersion: "42",
+			UID:             "crd-uid",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: plural, Kind: kind, ListKind: kind + "List"},
+			Scope: scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensionsv1.CustomResourceDefinitionNames{Plural: plural, Kind: kind},
+		},
+	}
+}
+
+func newImportObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestCollectImport(t *testing.T) {
+	widgets := newImportCRD("example.io", "widgets", "Widget", apiextensionsv1.NamespaceScoped)
+	gadgets := newImportCRD("example.io", "gadgets", "Gadget", apiextensionsv1.ClusterScoped)
+	others := newImportCRD("other.io", "others", "Other", apiextensionsv1.NamespaceScoped)
+	converted := newImportCRD("example.io", "converted", "Converted", apiextensionsv1.NamespaceScoped)
+	converted.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.WebhookConverter}
+
+	owned := newImportObject("example.io/v1", "Widget", "my-app", "e")
+	owned.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid"}})
+
+	crdClient := apiextensionsfake.NewSimpleClientset(widgets, gadgets, others, converted)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "example.io", Version: "v1", Resource: "widgets"}:   "WidgetList",
+			{Group: "example.io", Version: "v1", Resource: "gadgets"}:   "GadgetList",
+			{Group: "example.io", Version: "v1", Resource: "converted"}: "ConvertedList",
+			{Group: "other.io", Version: "v1", Resource: "others"}:      "OtherList",
+		},
+		newImportObject("example.io/v1", "Widget", "my-app", "a"),
+		newImportObject("example.io/v1", "Widget", "other-app", "b"),
+		newImportObject("example.io/v1", "Gadget", "", "c"),
+		newImportObject("other.io/v1", "Other", "my-app", "d"),
+		owned,
+	)
+
+	set, err := collectImport(context.Background(), crdClient, dynamicClient, []string{"my-app"}, sets.NewString("example.io"))
+	require.NoError(t, err)
+
+	var crdNames []string
+	for _, crd := range set.crds {
+		crdNames = append(crdNames, crd.Name)
+	}
+	require.ElementsMatch(t, []string{"widgets.example.io", "gadgets.example.io"}, crdNames)
+	require.Equal(t, []string{"my-app"}, set.namespaces)
+
+	var objectNames []string
+	for _, obj := range set.objects {
+		objectNames = append(objectNames, obj.GetName())
+	}
+	require.ElementsMatch(t, []string{"a", "c", "e"}, objectNames, "objects of other namespaces and groups must not be collected")
+	require.Len(t, set.skipped, 2, "CRDs with conversion webhooks must be skipped, and dropped owner references must be warned about")
+	require.Contains(t, strings.Join(set.skipped, "\n"), "owner references of widgets.example.io my-app/e are dropped")
+}
+
+func TestCleanObject(t *testing.T) {
+	obj := newImportObject("example.io/v1", "Widget", "my-app", "a")
+	obj.SetResourceVersion("42")
+	obj.SetUID("uid")
+	obj.SetGeneration(3)
+	obj.SetFinalizers([]string{"example.io/cleanup"})
+	obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid"}})
+	obj.SetLabels(map[string]string{"app": "my-app"})
+
+	cleaned := cleanObject(obj)
+	require.Empty(t, cleaned.GetResourceVersion())
+	require.Empty(t, cleaned.GetUID())
+	require.Zero(t, cleaned.GetGeneration())
+	require.Empty(t, cleaned.GetFinalizers())
+	require.Empty(t, cleaned.GetOwnerReferences())
+	require.Equal(t, map[string]string{"app": "my-app"}, cleaned.GetLabels())
+	require.Equal(t, "42", obj.GetResourceVersion(), "the original object must not be modified")
+}
+
+func TestCleanCRD(t *testing.T) {
+	crd := newImportCRD("example.io", "widgets", "Widget", apiextensionsv1.NamespaceScoped)
+
+	cleaned := cleanCRD(crd)
+	require.Equal(t, "widgets.example.io", cleaned.Name)
+	require.Empty(t, cleaned.ResourceVersion)
+	require.Empty(t, cleaned.UID)
+	require.Equal(t, crd.Spec, cleaned.Spec)
+	require.Empty(t, cleaned.Status.AcceptedNames.Plural)
+}
+
+func TestPromotionHints(t *testing.T) {
+	require.Empty(t, promotionHints(nil))
+
+	hints := promotionHints([]*apiextensionsv1.CustomResourceDefinition{
+		newImportCRD("example.io", "widgets", "Widget", apiextensionsv1.NamespaceScoped),
+		newImportCRD("example.io", "gadgets", "Gadget", apiextensionsv1.ClusterScoped),
+		newImportCRD("other.io", "others", "Other", apiextensionsv1.NamespaceScoped),
+	})
+	require.Contains(t, hints, "kubectl get crd gadgets.example.io -o yaml | kubectl kcp crd snapshot -f - --prefix <prefix> >> example.io.yaml")
+	require.Contains(t, hints, "kubectl get crd widgets.example.io -o yaml | kubectl kcp crd snapshot -f - --prefix <prefix> >> example.io.yaml")
+	require.Contains(t, hints, "#   - <prefix>.others.other.io")
+	require.Less(t, strings.Index(hints, "# example.io"), strings.Index(hints, "# other.io"), "groups must be sorted")
+}
@@ -38,14 +38,15 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	"github.com/kcp-dev/kcp/pkg/cliplugins/base"
 	pluginhelpers "github.com/kcp-dev/kcp/pkg/cliplugins/helpers"
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 )
 
 const (
@@ -118,6 +119,40 @@ func (o *UseWorkspaceOptions) BindFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&o.ShortWorkspaceOutput, "short", o.ShortWorkspaceOutput, "Print only the name of the workspace, e.g. for integration into the shell prompt")
 }
 
+// CompleteWorkspace returns the completions of the workspace argument. Names without a colon are
+// completed to the child workspaces of the current workspace, all others to absolute paths.
+func (o *UseWorkspaceOptions) CompleteWorkspace(ctx context.Context, toComplete string) ([]string, error) {
+	if err := o.Options.Complete(); err != nil {
+		return nil, err
+	}
+	config, err := o.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(toComplete, ":") {
+		return pluginhelpers.CompletePath(ctx, config, "workspaces", toComplete)
+	}
+
+	completions, err := pluginhelpers.CompletePath(ctx, config, "workspaces", toComplete)
+	if err != nil {
+		return nil, err
+	}
+	_, currentClusterName, err := pluginhelpers.ParseClusterURL(config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("current URL %q does not point to a workspace", config.Host)
+	}
+	children, err := pluginhelpers.SearchCompletions(ctx, config, "workspaces", currentClusterName.String()+":"+toComplete)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		_, name := logicalcluster.NewPath(child).Split()
+		completions = append(completions, name)
+	}
+	return completions, nil
+}
+
 // Run executes the "use workspace" logic based on the supplied options.
 func (o *UseWorkspaceOptions) Run(ctx context.Context) error {
 	rawConfig, err := o.ClientConfig.RawConfig()
@@ -656,8 +691,12 @@ type CreateContextOptions struct {
 	Name string
 	// Overwrite indicates the context should be updated if it already exists. This is required to perform the update.
 	Overwrite bool
+	// PathURL indicates the context should address the workspace by its path instead of by the name of its
+	// logical cluster.
+	PathURL bool
 
-	startingConfig *clientcmdapi.Config
+	kcpClusterClient kcpclientset.ClusterInterface
+	startingConfig   *clientcmdapi.Config
 
 	// for testing
 	modifyConfig func(configAccess clientcmd.ConfigAccess, newConfig *clientcmdapi.Config) error
@@ -678,6 +717,7 @@ func NewCreateContextOptions(streams genericclioptions.IOStreams) *CreateContext
 func (o *CreateContextOptions) BindFlags(cmd *cobra.Command) {
 	o.Options.BindFlags(cmd)
 	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", o.Overwrite, "Overwrite the context if it already exists")
+	cmd.Flags().BoolVar(&o.PathURL, "path-url", o.PathURL, "Address the workspace by its path instead of by its logical cluster. The context breaks when the workspace or one of its ancestors is renamed or moved")
 }
 
 // Complete ensures all dynamically populated fields are initialized.
@@ -696,6 +736,12 @@ func (o *CreateContextOptions) Complete(args []string) error {
 		o.Name = args[0]
 	}
 
+	kcpClusterClient, err := newKCPClusterClient(o.ClientConfig)
+	if err != nil {
+		return err
+	}
+	o.kcpClusterClient = kcpClusterClient
+
 	return nil
 }
 
@@ -734,6 +780,9 @@ func (o *CreateContextOptions) Run(ctx context.Context) error {
 
 	newKubeConfig := o.startingConfig.DeepCopy()
 	newCluster := *currentCluster
+	if !o.PathURL {
+		newCluster.Server = o.stableServerURL(ctx, currentCluster.Server)
+	}
 	newKubeConfig.Clusters[o.Name] = &newCluster
 	newContext := *currentContext
 	newContext.Cluster = o.Name
@@ -757,6 +806,34 @@ func (o *CreateContextOptions) Run(ctx context.Context) error {
 	return err
 }
 
+// stableServerURL returns the given server URL with the workspace addressed by the name of its logical
+// cluster, which does not change when the workspace or one of its ancestors is renamed or moved. If the
+// workspace cannot be looked up, the given server URL is returned.
+func (o *CreateContextOptions) stableServerURL(ctx context.Context, server string) string {
+	u, clusterPath, err := pluginhelpers.ParseClusterURL(server)
+	if err != nil {
+		return server
+	}
+	parent, name := clusterPath.Split()
+	if parent.Empty() {
+		// root, or already addressed by logical cluster
+		return server
+	}
+
+	ws, err := o.kcpClusterClient.Cluster(parent).TenancyV1beta1().Workspaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Warning: cannot look up the logical cluster of workspace %q, addressing it by path: %v\n", clusterPath, err)
+		return server
+	}
+	if ws.Spec.Cluster == "" {
+		fmt.Fprintf(o.ErrOut, "Warning: workspace %q is not scheduled yet, addressing it by path\n", clusterPath)
+		return server
+	}
+
+	u.Path = path.Join(u.Path, logicalcluster.Name(ws.Spec.Cluster).Path().RequestPath())
+	return u.String()
+}
+
 func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.ClusterInterface, error) {
 	config, err := clientConfig.ClientConfig()
 	if err != nil {
@@ -777,15 +854,21 @@ func newKCPClusterClient(clientConfig clientcmd.ClientConfig) (kcpclientset.Clus
 type TreeOptions struct {
 	*base.Options
 
-	Full bool
+	Full  bool
+	Depth int
 
 	kcpClusterClient kcpclientset.ClusterInterface
+
+	// for testing
+	searchWorkspaceTree func(ctx context.Context, config *rest.Config, cluster logicalcluster.Name, depth int) (*search.WorkspaceTree, error)
 }
 
 // NewShowWorkspaceTreeOptions returns a new ShowWorkspaceTreeOptions.
 func NewTreeOptions(streams genericclioptions.IOStreams) *TreeOptions {
 	return &TreeOptions{
 		Options: base.NewOptions(streams),
+
+		searchWorkspaceTree: pluginhelpers.SearchWorkspaceTree,
 	}
 }
 
@@ -793,6 +876,7 @@ func NewTreeOptions(streams genericclioptions.IOStreams) *TreeOptions {
 func (o *TreeOptions) BindFlags(cmd *cobra.Command) {
 	o.Options.BindFlags(cmd)
 	cmd.Flags().BoolVarP(&o.Full, "full", "f", o.Full, "Show full workspaces names")
+	cmd.Flags().IntVar(&o.Depth, "depth", o.Depth, "Number of levels of workspaces to show, 0 shows all levels")
 }
 
 // Complete ensures all dynamically populated fields are initialized.
@@ -810,6 +894,14 @@ func (o *TreeOptions) Complete() error {
 	return nil
 }
 
+// Validate validates the TreeOptions are complete and usable.
+func (o *TreeOptions) Validate() error {
+	if o.Depth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+	return o.Options.Validate()
+}
+
 // Run outputs the current workspace.
 func (o *TreeOptions) Run(ctx context.Context) error {
 	config, err := o.ClientConfig.ClientConfig()
@@ -821,17 +913,51 @@ func (o *TreeOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("current config context URL %q does not point to workspace", config.Host)
 	}
 
+	// prefer fetching the whole tree with one request, and fall back to walking the hierarchy for
+	// servers without the search virtual workspace.
 	tree := treeprint.New()
-	err = o.populateBranch(ctx, tree, currentClusterName)
+	if err := o.populateFromSearch(ctx, config, tree, currentClusterName); err != nil {
+		tree = treeprint.New()
+		if err := o.populateBranch(ctx, tree, currentClusterName, 0); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(o.Out, tree.String())
+	return nil
+}
+
+func (o *TreeOptions) populateFromSearch(ctx context.Context, config *rest.Config, tree treeprint.Tree, name logicalcluster.Path) error {
+	lc, err := o.kcpClusterClient.Cluster(name).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	wsTree, err := o.searchWorkspaceTree(ctx, config, logicalcluster.From(lc), o.Depth)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(tree.String())
+	if o.Full {
+		o.addNodes(tree.AddBranch(wsTree.Path), wsTree.Children)
+	} else {
+		o.addNodes(tree.AddBranch(logicalcluster.NewPath(wsTree.Path).Base()), wsTree.Children)
+	}
 	return nil
 }
 
-func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, name logicalcluster.Path) error {
+func (o *TreeOptions) addNodes(tree treeprint.Tree, nodes []search.WorkspaceTreeNode) {
+	for _, node := range nodes {
+		var b treeprint.Tree
+		if o.Full {
+			b = tree.AddBranch(node.Path)
+		} else {
+			b = tree.AddBranch(node.Name)
+		}
+		o.addNodes(b, node.Children)
+	}
+}
+
+func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, name logicalcluster.Path, depth int) error {
 	var b treeprint.Tree
 	if o.Full {
 		b = tree.AddBranch(name.String())
@@ -839,6 +965,10 @@ func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, n
 		b = tree.AddBranch(name.Base())
 	}
 
+	if o.Depth > 0 && depth >= o.Depth {
+		return nil
+	}
+
 	results, err := o.kcpClusterClient.Cluster(name).TenancyV1beta1().Workspaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -852,7 +982,7 @@ func (o *TreeOptions) populateBranch(ctx context.Context, tree treeprint.Tree, n
 		if err != nil {
 			return fmt.Errorf("current config context URL %q does not point to workspace", workspace.Spec.URL)
 		}
-		err = o.populateBranch(ctx, b, currentClusterName)
+		err = o.populateBranch(ctx, b, currentClusterName, depth+1)
 		if err != nil {
 			return err
 		}
@@ -39,14 +39,14 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	kcpclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
 )
 
 func TestCreate(t *testing.T) {
@@ -1313,8 +1313,10 @@ func TestCreateContext(t *testing.T) {
 		config    clientcmdapi.Config
 		overrides *clientcmd.ConfigOverrides
 
-		param     string
-		overwrite bool
+		param      string
+		overwrite  bool
+		pathURL    bool
+		workspaces []*tenancyv1beta1.Workspace
 
 		expected   *clientcmdapi.Config
 		wantStdout []string
@@ -1445,6 +1447,61 @@ func TestCreateContext(t *testing.T) {
 			},
 			wantStdout: []string{"Updated context \"root:foo:bar\"."},
 		},
+		{
+			name: "current, scheduled workspace, addressed by logical cluster",
+			config: clientcmdapi.Config{CurrentContext: "workspace.kcp.io/current",
+				Contexts:  map[string]*clientcmdapi.Context{"workspace.kcp.io/current": {Cluster: "workspace.kcp.io/current", AuthInfo: "test"}},
+				Clusters:  map[string]*clientcmdapi.Cluster{"workspace.kcp.io/current": {Server: "https://test/clusters/root:foo:bar"}},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+			},
+			workspaces: []*tenancyv1beta1.Workspace{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:foo"}},
+					Spec:       tenancyv1beta1.WorkspaceSpec{Cluster: "2x8l3v1b9rgnh8ve", URL: "https://shard/clusters/2x8l3v1b9rgnh8ve"},
+				},
+			},
+			param: "",
+			expected: &clientcmdapi.Config{CurrentContext: "root:foo:bar",
+				Contexts: map[string]*clientcmdapi.Context{
+					"workspace.kcp.io/current": {Cluster: "workspace.kcp.io/current", AuthInfo: "test"},
+					"root:foo:bar":             {Cluster: "root:foo:bar", AuthInfo: "test"},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"workspace.kcp.io/current": {Server: "https://test/clusters/root:foo:bar"},
+					"root:foo:bar":             {Server: "https://test/clusters/2x8l3v1b9rgnh8ve"},
+				},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+			},
+			wantStdout: []string{"Created context \"root:foo:bar\" and switched to it."},
+		},
+		{
+			name: "current, scheduled workspace, addressed by path",
+			config: clientcmdapi.Config{CurrentContext: "workspace.kcp.io/current",
+				Contexts:  map[string]*clientcmdapi.Context{"workspace.kcp.io/current": {Cluster: "workspace.kcp.io/current", AuthInfo: "test"}},
+				Clusters:  map[string]*clientcmdapi.Cluster{"workspace.kcp.io/current": {Server: "https://test/clusters/root:foo:bar"}},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+			},
+			workspaces: []*tenancyv1beta1.Workspace{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar", Annotations: map[string]string{logicalcluster.AnnotationKey: "root:foo"}},
+					Spec:       tenancyv1beta1.WorkspaceSpec{Cluster: "2x8l3v1b9rgnh8ve", URL: "https://shard/clusters/2x8l3v1b9rgnh8ve"},
+				},
+			},
+			param:   "",
+			pathURL: true,
+			expected: &clientcmdapi.Config{CurrentContext: "root:foo:bar",
+				Contexts: map[string]*clientcmdapi.Context{
+					"workspace.kcp.io/current": {Cluster: "workspace.kcp.io/current", AuthInfo: "test"},
+					"root:foo:bar":             {Cluster: "root:foo:bar", AuthInfo: "test"},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"workspace.kcp.io/current": {Server: "https://test/clusters/root:foo:bar"},
+					"root:foo:bar":             {Server: "https://test/clusters/root:foo:bar"},
+				},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{"test": {Token: "test"}},
+			},
+			wantStdout: []string{"Created context \"root:foo:bar\" and switched to it."},
+		},
 		{
 			name: "current, no arg, overrides don't apply",
 			config: clientcmdapi.Config{CurrentContext: "workspace.kcp.io/current",
@@ -1485,6 +1542,12 @@ func TestCreateContext(t *testing.T) {
 			opts := NewCreateContextOptions(streams)
 			opts.Name = tt.param
 			opts.Overwrite = tt.overwrite
+			opts.PathURL = tt.pathURL
+			objs := []runtime.Object{}
+			for _, ws := range tt.workspaces {
+				objs = append(objs, ws)
+			}
+			opts.kcpClusterClient = kcpfakeclient.NewSimpleClientset(objs...)
 			opts.modifyConfig = func(configAccess clientcmd.ConfigAccess, config *clientcmdapi.Config) error {
 				got = config
 				return nil
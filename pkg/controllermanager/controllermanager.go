@@ -0,0 +1,318 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllermanager runs controller groups of a kcp shard out-of-process, against the
+// API of the shard. This isolates the latency of the apiserver from CPU spikes of the controllers,
+// and allows to scale and restart the controllers independently of the apiserver.
+package controllermanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	kcpmetadata "github.com/kcp-dev/client-go/metadata"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportdefaultendpointslice"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportschemalint"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiresourceschemacompat"
+	"github.com/kcp-dev/kcp/pkg/reconciler/rbac/bindingexpiry"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/limitincreaserequest"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/retention"
+	"github.com/kcp-dev/kcp/pkg/server/bootstrap"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+const resyncPeriod = 10 * time.Hour
+
+// installers are the controller groups that can run out-of-process, by the names used by
+// --unsupported-run-individual-controllers of kcp. The other groups depend on state of the
+// apiserver process and only run in-process.
+var installers = map[string]func(m *ControllerManager) error{
+	"apiexportdefaultendpointslice": (*ControllerManager).installAPIExportDefaultEndpointSliceController,
+	"apiexportschemalint":           (*ControllerManager).installAPIExportSchemaLintController,
+	"apiresourceschemacompat":       (*ControllerManager).installAPIResourceSchemaCompatController,
+	"binding-expiry":                (*ControllerManager).installBindingExpiryController,
+	"limitincreaserequest":          (*ControllerManager).installLimitIncreaseRequestController,
+	"retention":                     (*ControllerManager).installRetentionController,
+}
+
+// Groups returns the sorted names of the controller groups that can run out-of-process.
+func Groups() []string {
+	groups := make([]string, 0, len(installers))
+	for group := range installers {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// ValidateGroups returns an error if one of the given groups cannot run out-of-process.
+func ValidateGroups(groups []string) error {
+	for _, group := range groups {
+		if _, ok := installers[group]; !ok {
+			return fmt.Errorf("controller group %q cannot run out-of-process, supported groups are: %s", group, strings.Join(Groups(), ", "))
+		}
+	}
+	return nil
+}
+
+// ControllerManager runs the controllers of the selected groups against a kcp shard.
+type ControllerManager struct {
+	config            *rest.Config
+	resolveIdentities func(ctx context.Context) error
+
+	kcpSharedInformerFactory  kcpinformers.SharedInformerFactory
+	kubeSharedInformerFactory kcpkubernetesinformers.SharedInformerFactory
+
+	// switchboard holds the switches of the controllers. Overrides in spec.controllers of
+	// the Shard are only applied to the controllers running in-process.
+	switchboard *controllerswitch.Switchboard
+
+	starters []func(ctx context.Context)
+}
+
+// New returns a controller manager running the controllers of the given groups through the
+// given client config of the shard, e.g. the controller-manager.kubeconfig written by kcp.
+func New(config *rest.Config, groups []string) (*ControllerManager, error) {
+	if err := ValidateGroups(groups); err != nil {
+		return nil, err
+	}
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	identityConfig, resolveIdentities := bootstrap.NewConfigWithWildcardIdentities(config, bootstrap.KcpRootGroupExportNames, bootstrap.KcpRootGroupResourceExportNames, kubeClusterClient)
+
+	informerConfig := rest.CopyConfig(identityConfig)
+	informerConfig.UserAgent = "kcp-controller-manager-informers"
+	informerKcpClient, err := kcpclientset.NewForConfig(informerConfig)
+	if err != nil {
+		return nil, err
+	}
+	informerKubeClient, err := kcpkubernetesclientset.NewForConfig(informerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ControllerManager{
+		config:            identityConfig,
+		resolveIdentities: resolveIdentities,
+
+		kcpSharedInformerFactory:  kcpinformers.NewSharedInformerFactoryWithOptions(informerKcpClient, resyncPeriod),
+		kubeSharedInformerFactory: kcpkubernetesinformers.NewSharedInformerFactoryWithOptions(informerKubeClient, resyncPeriod),
+
+		switchboard: controllerswitch.NewSwitchboard(),
+	}
+	for _, group := range groups {
+		if err := installers[group](m); err != nil {
+			return nil, fmt.Errorf("failed to install controller group %q: %w", group, err)
+		}
+	}
+
+	return m, nil
+}
+
+// Run resolves the identities of the kcp APIs, starts the informers and then the controllers,
+// and blocks until the context is done.
+func (m *ControllerManager) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithValues("component", "kcp-controller-manager")
+	ctx = klog.NewContext(ctx, logger)
+
+	logger.Info("getting kcp APIExport identities")
+	if err := wait.PollImmediateInfiniteWithContext(ctx, time.Millisecond*500, func(ctx context.Context) (bool, error) {
+		if err := m.resolveIdentities(ctx); err != nil {
+			logger.V(3).Info("failed to resolve identities, keeping trying", "err", err)
+			return false, nil
+		}
+		return true, nil
+	}); err != nil {
+		return fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	logger.Info("starting informers")
+	m.kcpSharedInformerFactory.Start(ctx.Done())
+	m.kubeSharedInformerFactory.Start(ctx.Done())
+	m.kcpSharedInformerFactory.WaitForCacheSync(ctx.Done())
+	m.kubeSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+	}
+
+	logger.Info("synced all informers, starting controllers")
+	for _, start := range m.starters {
+		go start(ctx)
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (m *ControllerManager) clientConfig(controllerName string) *rest.Config {
+	return rest.AddUserAgent(rest.CopyConfig(m.config), controllerName)
+}
+
+func (m *ControllerManager) installAPIExportDefaultEndpointSliceController() error {
+	kcpClusterClient, err := kcpclientset.NewForConfig(m.clientConfig(apiexportdefaultendpointslice.ControllerName))
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := m.switchboard.Register(apiexportdefaultendpointslice.ControllerName, 2)
+	c, err := apiexportdefaultendpointslice.NewController(
+		kcpClusterClient,
+		m.kcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		m.kcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, controllerSwitch.MaxWorkers()) })
+	return nil
+}
+
+func (m *ControllerManager) installAPIExportSchemaLintController() error {
+	kcpClusterClient, err := kcpclientset.NewForConfig(m.clientConfig(apiexportschemalint.ControllerName))
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := m.switchboard.Register(apiexportschemalint.ControllerName, 2)
+	c, err := apiexportschemalint.NewController(
+		kcpClusterClient,
+		m.kcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		m.kcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, controllerSwitch.MaxWorkers()) })
+	return nil
+}
+
+func (m *ControllerManager) installAPIResourceSchemaCompatController() error {
+	kcpClusterClient, err := kcpclientset.NewForConfig(m.clientConfig(apiresourceschemacompat.ControllerName))
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := m.switchboard.Register(apiresourceschemacompat.ControllerName, 2)
+	c, err := apiresourceschemacompat.NewController(
+		kcpClusterClient,
+		m.kcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, controllerSwitch.MaxWorkers()) })
+	return nil
+}
+
+func (m *ControllerManager) installBindingExpiryController() error {
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(m.clientConfig(bindingexpiry.ControllerName))
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := m.switchboard.Register(bindingexpiry.ControllerName, 2)
+	c, err := bindingexpiry.NewController(
+		kubeClusterClient,
+		m.kubeSharedInformerFactory.Rbac().V1().RoleBindings(),
+		m.kubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, controllerSwitch.MaxWorkers()) })
+	return nil
+}
+
+func (m *ControllerManager) installLimitIncreaseRequestController() error {
+	config := m.clientConfig(limitincreaserequest.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := m.switchboard.Register(limitincreaserequest.ControllerName, 2)
+	c, err := limitincreaserequest.NewController(
+		kcpClusterClient,
+		kubeClusterClient,
+		m.kcpSharedInformerFactory.Tenancy().V1alpha1().LimitIncreaseRequests(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, controllerSwitch.MaxWorkers()) })
+	return nil
+}
+
+func (m *ControllerManager) installRetentionController() error {
+	config := m.clientConfig(retention.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	metadataClient, err := kcpmetadata.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := retention.NewController(
+		kcpClusterClient,
+		kubeClusterClient,
+		metadataClient,
+		m.kcpSharedInformerFactory.Tenancy().V1alpha1().RetentionPolicies(),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.starters = append(m.starters, func(ctx context.Context) { c.Start(ctx, 2) })
+	return nil
+}
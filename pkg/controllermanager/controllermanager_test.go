@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllermanager
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroups(t *testing.T) {
+	groups := Groups()
+	require.True(t, sort.StringsAreSorted(groups), "groups must be sorted: %v", groups)
+	require.Len(t, groups, len(installers))
+}
+
+func TestValidateGroups(t *testing.T) {
+	tests := map[string]struct {
+		groups  []string
+		wantErr bool
+	}{
+		"no groups":          {},
+		"supported groups":   {groups: []string{"retention", "binding-expiry"}},
+		"in-process group":   {groups: []string{"retention", "workspace-scheduler"}, wantErr: true},
+		"unknown group":      {groups: []string{"foo"}, wantErr: true},
+		"empty group string": {groups: []string{""}, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateGroups(tt.groups)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controllerswitch switches embedded controllers of a shard on and off, and changes
+// their number of workers at runtime, as configured in spec.controllers of the Shard. This
+// allows operators to stop a misbehaving controller without restarting the shard.
+package controllerswitch
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// Switchboard holds the switches of the embedded controllers of a shard.
+type Switchboard struct {
+	lock      sync.Mutex
+	switches  map[string]*Switch
+	overrides []corev1alpha1.ShardControllerOverride
+}
+
+// NewSwitchboard returns a switchboard without overrides.
+func NewSwitchboard() *Switchboard {
+	return &Switchboard{
+		switches: map[string]*Switch{},
+	}
+}
+
+// Register returns the switch of the controller with the given name, which runs with
+// defaultWorkers workers unless overridden. Registering a name twice returns the same switch.
+func (b *Switchboard) Register(name string, defaultWorkers int) *Switch {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if s, ok := b.switches[name]; ok {
+		return s
+	}
+	s := &Switch{name: name, defaultWorkers: defaultWorkers, workers: defaultWorkers, changed: make(chan struct{})}
+	for _, o := range b.overrides {
+		if o.Name == name {
+			s.set(o)
+		}
+	}
+	b.switches[name] = s
+	return s
+}
+
+// Apply applies the overrides to the registered controllers. Controllers without an override
+// return to their defaults. Overrides of unknown controllers are kept, in case the controller
+// registers later, and are returned by Unknown.
+func (b *Switchboard) Apply(ctx context.Context, overrides []corev1alpha1.ShardControllerOverride) {
+	logger := klog.FromContext(ctx)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.overrides = overrides
+	overridden := sets.NewString()
+	for _, o := range overrides {
+		overridden.Insert(o.Name)
+		s, ok := b.switches[o.Name]
+		if !ok {
+			logger.Info("ignoring override of unknown controller", "controller", o.Name)
+			continue
+		}
+		if s.set(o) {
+			logger.Info("applied controller override", "controller", o.Name, "disabled", o.Disabled, "workers", s.Workers())
+		}
+	}
+	for name, s := range b.switches {
+		if !overridden.Has(name) && s.set(corev1alpha1.ShardControllerOverride{Name: name}) {
+			logger.Info("removed controller override", "controller", name)
+		}
+	}
+}
+
+// Unknown returns the sorted names of the overridden controllers which are not registered,
+// e.g. because of a typo, or because the controller runs out-of-process.
+func (b *Switchboard) Unknown() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	unknown := sets.NewString()
+	for _, o := range b.overrides {
+		if _, ok := b.switches[o.Name]; !ok {
+			unknown.Insert(o.Name)
+		}
+	}
+	return unknown.List()
+}
+
+// Switch gates the workers of a controller. Controllers start MaxWorkers workers on a queue
+// gated by the switch, see Queue. A nil Switch never stops its controller.
+type Switch struct {
+	name           string
+	defaultWorkers int
+
+	lock     sync.Mutex
+	disabled bool
+	workers  int
+	active   int
+	// changed is closed and replaced when the switch changes, or a worker becomes idle.
+	changed chan struct{}
+}
+
+// MaxWorkers returns the number of workers a controller must start to allow raising the
+// number of workers at runtime. Workers beyond the configured number stay idle.
+func (s *Switch) MaxWorkers() int {
+	if s.defaultWorkers > corev1alpha1.ShardControllerMaxWorkers {
+		return s.defaultWorkers
+	}
+	return corev1alpha1.ShardControllerMaxWorkers
+}
+
+// Workers returns the configured number of workers.
+func (s *Switch) Workers() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.workers
+}
+
+// Disabled returns true if the controller is switched off.
+func (s *Switch) Disabled() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.disabled
+}
+
+// Queue returns the given queue of a controller gated by the switch. Get blocks until the
+// worker may process an item, i.e. while the configured number of workers is busy, and while
+// the controller is switched off. The latter is checked again after an item has been taken
+// from the queue, so that workers already waiting for items when the controller is switched
+// off do not process them. Done ends the processing of the item and lets the next worker in.
+// A nil Switch returns the queue as it is.
+func (s *Switch) Queue(queue workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	if s == nil {
+		return queue
+	}
+	return &gatedQueue{
+		RateLimitingInterface: queue,
+		s:                     s,
+		shutdown:              make(chan struct{}),
+	}
+}
+
+// gatedQueue is a queue whose workers are admitted by a switch.
+type gatedQueue struct {
+	workqueue.RateLimitingInterface
+	s *Switch
+
+	shutdownOnce sync.Once
+	shutdown     chan struct{}
+}
+
+func (q *gatedQueue) Get() (interface{}, bool) {
+	if !q.s.acquire(q.shutdown) {
+		return nil, true
+	}
+	item, quit := q.RateLimitingInterface.Get()
+	if quit {
+		q.s.release()
+		return nil, true
+	}
+	// the controller might have been switched off while waiting for the item. It is kept
+	// until the controller is switched on, and is not handed to other workers meanwhile.
+	if !q.s.waitForEnabled(q.shutdown) {
+		q.RateLimitingInterface.Done(item)
+		q.s.release()
+		return nil, true
+	}
+	return item, false
+}
+
+func (q *gatedQueue) Done(item interface{}) {
+	q.RateLimitingInterface.Done(item)
+	q.s.release()
+}
+
+func (q *gatedQueue) ShutDown() {
+	q.shutdownOnce.Do(func() { close(q.shutdown) })
+	q.RateLimitingInterface.ShutDown()
+}
+
+func (q *gatedQueue) ShutDownWithDrain() {
+	q.shutdownOnce.Do(func() { close(q.shutdown) })
+	q.RateLimitingInterface.ShutDownWithDrain()
+}
+
+// acquire blocks until the controller is switched on and less than the configured number of
+// workers is busy, and counts the caller as busy then. It returns false if stop is closed first.
+func (s *Switch) acquire(stop <-chan struct{}) bool {
+	for {
+		s.lock.Lock()
+		if !s.disabled && s.active < s.workers {
+			s.active++
+			s.lock.Unlock()
+			return true
+		}
+		changed := s.changed
+		s.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// waitForEnabled blocks while the controller is switched off. It returns false if stop is
+// closed first.
+func (s *Switch) waitForEnabled(stop <-chan struct{}) bool {
+	for {
+		s.lock.Lock()
+		if !s.disabled {
+			s.lock.Unlock()
+			return true
+		}
+		changed := s.changed
+		s.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// release ends the processing of a worker started with acquire.
+func (s *Switch) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.active--
+	s.notifyLocked()
+}
+
+// notifyLocked wakes up the workers waiting for a change. The lock must be held.
+func (s *Switch) notifyLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// set applies the override, and returns true if anything changed.
+func (s *Switch) set(o corev1alpha1.ShardControllerOverride) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	workers := s.defaultWorkers
+	if o.Workers != nil {
+		workers = int(*o.Workers)
+	}
+	changed := s.disabled != o.Disabled || s.workers != workers
+	s.disabled = o.Disabled
+	s.workers = workers
+	if changed {
+		s.notifyLocked()
+	}
+	return changed
+}
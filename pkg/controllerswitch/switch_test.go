@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerswitch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// get calls Get of the queue in the background, and returns a channel receiving the item.
+func get(q workqueue.Interface) <-chan interface{} {
+	ch := make(chan interface{}, 1)
+	go func() {
+		item, _ := q.Get()
+		ch <- item
+	}()
+	return ch
+}
+
+func requireBlocked(t *testing.T, ch <-chan interface{}) {
+	t.Helper()
+	select {
+	case item := <-ch:
+		t.Fatalf("expected Get to block, got %v", item)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func requireItem(t *testing.T, ch <-chan interface{}) interface{} {
+	t.Helper()
+	select {
+	case item := <-ch:
+		return item
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("expected Get to return")
+		return nil
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	ctx := context.Background()
+	b := NewSwitchboard()
+	s := b.Register("foo", 2)
+	require.Equal(t, corev1alpha1.ShardControllerMaxWorkers, s.MaxWorkers())
+
+	q := s.Queue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+	t.Cleanup(q.ShutDown)
+	q.Add("a")
+	q.Add("b")
+	q.Add("c")
+
+	// two workers by default
+	a := requireItem(t, get(q))
+	requireItem(t, get(q))
+	third := get(q)
+	requireBlocked(t, third)
+	q.Done(a)
+	c := requireItem(t, third)
+
+	// more workers
+	b.Apply(ctx, []corev1alpha1.ShardControllerOverride{{Name: "foo", Workers: pointer.Int32(3)}})
+	require.Equal(t, 3, s.Workers())
+	q.Add("d")
+	d := requireItem(t, get(q))
+	q.Done(c)
+	q.Done(d)
+
+	// back to defaults
+	b.Apply(ctx, nil)
+	require.Equal(t, 2, s.Workers())
+	require.False(t, s.Disabled())
+}
+
+func TestSwitchOffWhileWaiting(t *testing.T) {
+	ctx := context.Background()
+	b := NewSwitchboard()
+	s := b.Register("foo", 2)
+
+	q := s.Queue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+	t.Cleanup(q.ShutDown)
+
+	// the worker waits for an item when the controller is switched off
+	waiting := get(q)
+	requireBlocked(t, waiting)
+	b.Apply(ctx, []corev1alpha1.ShardControllerOverride{{Name: "foo", Disabled: true}})
+	require.True(t, s.Disabled())
+
+	q.Add("a")
+	requireBlocked(t, waiting)
+
+	b.Apply(ctx, nil)
+	require.Equal(t, "a", requireItem(t, waiting))
+}
+
+func TestSwitchShutDown(t *testing.T) {
+	b := NewSwitchboard()
+	b.Apply(context.Background(), []corev1alpha1.ShardControllerOverride{{Name: "foo", Disabled: true}})
+	q := b.Register("foo", 2).Queue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+
+	waiting := get(q)
+	requireBlocked(t, waiting)
+	q.ShutDown()
+	require.Nil(t, requireItem(t, waiting))
+}
+
+func TestSwitchboardRegisterAfterApply(t *testing.T) {
+	b := NewSwitchboard()
+	b.Apply(context.Background(), []corev1alpha1.ShardControllerOverride{{Name: "foo", Disabled: true}, {Name: "baz"}})
+	require.Equal(t, []string{"baz", "foo"}, b.Unknown())
+
+	require.True(t, b.Register("foo", 2).Disabled())
+	require.False(t, b.Register("bar", 2).Disabled())
+	require.Same(t, b.Register("foo", 2), b.Register("foo", 5))
+	require.Equal(t, []string{"baz"}, b.Unknown())
+}
+
+func TestNilSwitch(t *testing.T) {
+	var s *Switch
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	require.Same(t, q, s.Queue(q))
+}
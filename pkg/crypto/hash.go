@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Hasher computes the hashes kcp derives names, index values and identities from. Values derived
+// with a hasher are stored next to the name of its algorithm, so that they can still be resolved
+// after the configured algorithm changed.
+type Hasher interface {
+	// Algorithm returns the name of the algorithm, e.g. "sha224". It must never change, as it is
+	// persisted.
+	Algorithm() string
+	// Sum returns the hash of data.
+	Sum(data []byte) []byte
+	// FIPSApproved returns whether the algorithm is approved by FIPS 140.
+	FIPSApproved() bool
+}
+
+var (
+	// SHA224 is the algorithm workspace shards were indexed with before the hash became configurable.
+	SHA224 Hasher = &stdHasher{algorithm: "sha224", sum: func(data []byte) []byte { h := sha256.Sum224(data); return h[:] }}
+	// SHA256 is the algorithm identity hashes were computed with before the hash became configurable.
+	SHA256 Hasher = &stdHasher{algorithm: "sha256", sum: func(data []byte) []byte { h := sha256.Sum256(data); return h[:] }}
+	// SHA384 and SHA512 are the stronger FIPS approved algorithms, e.g. for deployments that mandate them.
+	SHA384 Hasher = &stdHasher{algorithm: "sha384", sum: func(data []byte) []byte { h := sha512.Sum384(data); return h[:] }}
+	SHA512 Hasher = &stdHasher{algorithm: "sha512", sum: func(data []byte) []byte { h := sha512.Sum512(data); return h[:] }}
+)
+
+type stdHasher struct {
+	algorithm string
+	sum       func(data []byte) []byte
+}
+
+func (h *stdHasher) Algorithm() string      { return h.algorithm }
+func (h *stdHasher) Sum(data []byte) []byte { return h.sum(data) }
+func (h *stdHasher) FIPSApproved() bool     { return true }
+
+var (
+	hashersLock sync.RWMutex
+	hashers     = map[string]Hasher{}
+	configured  Hasher
+)
+
+func init() {
+	for _, h := range []Hasher{SHA224, SHA256, SHA384, SHA512} {
+		RegisterHasher(h)
+	}
+}
+
+// RegisterHasher makes the hasher available under the name of its algorithm. It panics if another
+// hasher is registered under that name already.
+func RegisterHasher(h Hasher) {
+	hashersLock.Lock()
+	defer hashersLock.Unlock()
+	if _, found := hashers[h.Algorithm()]; found {
+		panic(fmt.Sprintf("hasher %q is registered already", h.Algorithm()))
+	}
+	hashers[h.Algorithm()] = h
+}
+
+// HasherFor returns the hasher registered for the algorithm.
+func HasherFor(algorithm string) (Hasher, error) {
+	hashersLock.RLock()
+	defer hashersLock.RUnlock()
+	h, found := hashers[algorithm]
+	if !found {
+		return nil, fmt.Errorf("unknown hash algorithm %q, must be one of %v", algorithm, hashAlgorithms())
+	}
+	return h, nil
+}
+
+// Hashers returns all registered hashers, sorted by algorithm.
+func Hashers() []Hasher {
+	hashersLock.RLock()
+	defer hashersLock.RUnlock()
+	ret := make([]Hasher, 0, len(hashers))
+	for _, algorithm := range hashAlgorithms() {
+		ret = append(ret, hashers[algorithm])
+	}
+	return ret
+}
+
+func hashAlgorithms() []string {
+	algorithms := make([]string, 0, len(hashers))
+	for algorithm := range hashers {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	return algorithms
+}
+
+// SetHashAlgorithm configures the algorithm used for newly derived values. An empty algorithm
+// restores the algorithm each value was derived with before the hash became configurable.
+func SetHashAlgorithm(algorithm string) error {
+	var h Hasher
+	if algorithm != "" {
+		var err error
+		if h, err = HasherFor(algorithm); err != nil {
+			return err
+		}
+	}
+	hashersLock.Lock()
+	defer hashersLock.Unlock()
+	configured = h
+	return nil
+}
+
+// ConfiguredHasher returns the hasher configured through SetHashAlgorithm, or the given legacy
+// hasher if none is configured.
+func ConfiguredHasher(legacy Hasher) Hasher {
+	hashersLock.RLock()
+	defer hashersLock.RUnlock()
+	if configured == nil {
+		return legacy
+	}
+	return configured
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasherFor(t *testing.T) {
+	for algorithm, want := range map[string]string{
+		"sha224": "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7",
+		"sha256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	} {
+		h, err := HasherFor(algorithm)
+		require.NoError(t, err)
+		require.Equal(t, algorithm, h.Algorithm())
+		require.Equal(t, want, hex.EncodeToString(h.Sum([]byte("abc"))))
+	}
+
+	_, err := HasherFor("md5")
+	require.ErrorContains(t, err, `unknown hash algorithm "md5", must be one of [sha224 sha256 sha384 sha512]`)
+}
+
+func TestConfiguredHasher(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetHashAlgorithm("")) })
+
+	require.Equal(t, SHA224, ConfiguredHasher(SHA224))
+
+	require.NoError(t, SetHashAlgorithm("sha384"))
+	require.Equal(t, SHA384, ConfiguredHasher(SHA224))
+
+	require.Error(t, SetHashAlgorithm("md5"))
+	require.Equal(t, SHA384, ConfiguredHasher(SHA224), "a failed configuration must not change the hasher")
+
+	require.NoError(t, SetHashAlgorithm(""))
+	require.Equal(t, SHA256, ConfiguredHasher(SHA256))
+}
@@ -48,6 +48,18 @@ const (
 	//
 	// Enable reverse tunnels to the downstream clusters through the syncers.
 	SyncerTunnel featuregate.Feature = "KCPSyncerTunnel"
+
+	// alpha: v0.11
+	//
+	// Record the controller, shard and instance of the most recent status change of
+	// APIBindings, APIExports, Workspaces and LogicalClusters in the field manager.
+	StatusProvenance featuregate.Feature = "KCPStatusProvenance"
+
+	// alpha: v0.11
+	//
+	// Persist the per-key backoff of the APIBinding, APIExport, Workspace and LogicalCluster
+	// controllers in the system:admin logical cluster, and restore it on restart.
+	PersistentRateLimiter featuregate.Feature = "KCPPersistentRateLimiter"
 )
 
 // DefaultFeatureGate exposes the upstream feature gate, but with our gate setting applied.
@@ -96,8 +108,10 @@ func (f *kcpFeatureGate) Type() string {
 // in the generic control plane code. To add a new feature, define a key for it above and add it
 // here. The features will be available throughout Kubernetes binaries.
 var defaultGenericControlPlaneFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	LocationAPI:  {Default: true, PreRelease: featuregate.Alpha},
-	SyncerTunnel: {Default: false, PreRelease: featuregate.Alpha},
+	LocationAPI:           {Default: true, PreRelease: featuregate.Alpha},
+	SyncerTunnel:          {Default: false, PreRelease: featuregate.Alpha},
+	StatusProvenance:      {Default: false, PreRelease: featuregate.Alpha},
+	PersistentRateLimiter: {Default: false, PreRelease: featuregate.Alpha},
 
 	// inherited features from generic apiserver, relisted here to get a conflict if it is changed
 	// unintentionally on either side:
@@ -22,8 +22,11 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/pkg/routingtarget"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // Index implements a mapping from logical cluster to (shard) URL.
@@ -41,8 +44,10 @@ func New(rewriters []PathRewriter) *State {
 		rewriters: rewriters,
 
 		clusterShards:             map[logicalcluster.Name]string{},
+		clusterOwners:             map[logicalcluster.Name]corev1alpha1.LogicalClusterOwner{},
 		shardWorkspaceNameCluster: map[string]map[logicalcluster.Name]map[string]logicalcluster.Name{},
 		shardWorkspaceName:        map[string]map[logicalcluster.Name]string{},
+		shardWorkspaceNameMount:   map[string]map[logicalcluster.Name]map[string]string{},
 		shardClusterParentCluster: map[string]map[logicalcluster.Name]logicalcluster.Name{},
 		shardBaseURLs:             map[string]string{},
 	}
@@ -56,8 +61,10 @@ type State struct {
 
 	lock                      sync.RWMutex
 	clusterShards             map[logicalcluster.Name]string                                    // logical cluster -> shard name
+	clusterOwners             map[logicalcluster.Name]corev1alpha1.LogicalClusterOwner          // logical cluster -> owner, if any
 	shardWorkspaceNameCluster map[string]map[logicalcluster.Name]map[string]logicalcluster.Name // (shard name, logical cluster, workspace name) -> logical cluster
 	shardWorkspaceName        map[string]map[logicalcluster.Name]string                         // (shard name, logical cluster) -> workspace name
+	shardWorkspaceNameMount   map[string]map[logicalcluster.Name]map[string]string              // (shard name, logical cluster, workspace name) -> mount URL, if ready
 	shardClusterParentCluster map[string]map[logicalcluster.Name]logicalcluster.Name            // (shard name, logical cluster) -> parent logical cluster
 	shardBaseURLs             map[string]string                                                 // shard name -> base URL
 }
@@ -68,17 +75,40 @@ func (c *State) UpsertWorkspace(shard string, ws *tenancyv1beta1.Workspace) {
 	}
 	clusterName := logicalcluster.From(ws)
 
+	if err := routingtarget.ValidateCluster(clusterName, ws.Name, logicalcluster.Name(ws.Spec.Cluster)); err != nil {
+		// never route to forbidden targets
+		c.DeleteWorkspace(shard, ws)
+		return
+	}
+
+	mountURL := readyMountURL(ws)
+
 	c.lock.RLock()
 	got := c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]
+	gotMountURL := c.shardWorkspaceNameMount[shard][clusterName][ws.Name]
 	c.lock.RUnlock()
 
-	if got.String() == ws.Spec.Cluster {
+	if got.String() == ws.Spec.Cluster && gotMountURL == mountURL {
 		return
 	}
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if gotMountURL := c.shardWorkspaceNameMount[shard][clusterName][ws.Name]; gotMountURL != mountURL {
+		if mountURL == "" {
+			c.deleteMount(shard, clusterName, ws.Name)
+		} else {
+			if c.shardWorkspaceNameMount[shard] == nil {
+				c.shardWorkspaceNameMount[shard] = map[logicalcluster.Name]map[string]string{}
+			}
+			if c.shardWorkspaceNameMount[shard][clusterName] == nil {
+				c.shardWorkspaceNameMount[shard][clusterName] = map[string]string{}
+			}
+			c.shardWorkspaceNameMount[shard][clusterName][ws.Name] = mountURL
+		}
+	}
+
 	if got := c.shardWorkspaceNameCluster[shard][clusterName][ws.Name]; got.String() != ws.Spec.Cluster {
 		if c.shardWorkspaceNameCluster[shard] == nil {
 			c.shardWorkspaceNameCluster[shard] = map[logicalcluster.Name]map[string]logicalcluster.Name{}
@@ -94,6 +124,25 @@ func (c *State) UpsertWorkspace(shard string, ws *tenancyv1beta1.Workspace) {
 	}
 }
 
+// readyMountURL returns the URL of the mount of the workspace, or an empty string if the
+// workspace is not mounted or the mount is not ready.
+func readyMountURL(ws *tenancyv1beta1.Workspace) string {
+	if ws.Spec.Mount == nil || ws.Status.Mount == nil || !conditions.IsTrue(ws, tenancyv1alpha1.WorkspaceMountReady) {
+		return ""
+	}
+	return ws.Status.Mount.URL
+}
+
+func (c *State) deleteMount(shard string, clusterName logicalcluster.Name, name string) {
+	delete(c.shardWorkspaceNameMount[shard][clusterName], name)
+	if len(c.shardWorkspaceNameMount[shard][clusterName]) == 0 {
+		delete(c.shardWorkspaceNameMount[shard], clusterName)
+	}
+	if len(c.shardWorkspaceNameMount[shard]) == 0 {
+		delete(c.shardWorkspaceNameMount, shard)
+	}
+}
+
 func (c *State) DeleteWorkspace(shard string, ws *tenancyv1beta1.Workspace) {
 	clusterName := logicalcluster.From(ws)
 
@@ -112,6 +161,8 @@ func (c *State) DeleteWorkspace(shard string, ws *tenancyv1beta1.Workspace) {
 		return
 	}
 
+	c.deleteMount(shard, clusterName, ws.Name)
+
 	delete(c.shardWorkspaceNameCluster[shard][clusterName], ws.Name)
 	if len(c.shardWorkspaceNameCluster[shard][clusterName]) == 0 {
 		delete(c.shardWorkspaceNameCluster[shard], clusterName)
@@ -134,14 +185,22 @@ func (c *State) DeleteWorkspace(shard string, ws *tenancyv1beta1.Workspace) {
 func (c *State) UpsertLogicalCluster(shard string, logicalCluster *corev1alpha1.LogicalCluster) {
 	clusterName := logicalcluster.From(logicalCluster)
 
+	owner := logicalCluster.Spec.Owner
+
 	c.lock.RLock()
 	got := c.clusterShards[clusterName]
+	gotOwner, hasOwner := c.clusterOwners[clusterName]
 	c.lock.RUnlock()
 
-	if got != shard {
+	if got != shard || hasOwner != (owner != nil) || (owner != nil && gotOwner != *owner) {
 		c.lock.Lock()
 		defer c.lock.Unlock()
 		c.clusterShards[clusterName] = shard
+		if owner != nil {
+			c.clusterOwners[clusterName] = *owner
+		} else {
+			delete(c.clusterOwners, clusterName)
+		}
 	}
 }
 
@@ -157,6 +216,7 @@ func (c *State) DeleteLogicalCluster(shard string, logicalCluster *corev1alpha1.
 		defer c.lock.Unlock()
 		if got := c.clusterShards[clusterName]; got == shard {
 			delete(c.clusterShards, clusterName)
+			delete(c.clusterOwners, clusterName)
 		}
 	}
 }
@@ -180,15 +240,24 @@ func (c *State) DeleteShard(shardName string) {
 	for lc, gotShardName := range c.clusterShards {
 		if shardName == gotShardName {
 			delete(c.clusterShards, lc)
+			delete(c.clusterOwners, lc)
 		}
 	}
 	delete(c.shardWorkspaceNameCluster, shardName)
 	delete(c.shardBaseURLs, shardName)
 	delete(c.shardWorkspaceName, shardName)
+	delete(c.shardWorkspaceNameMount, shardName)
 	delete(c.shardClusterParentCluster, shardName)
 }
 
 func (c *State) Lookup(path logicalcluster.Path) (shard string, cluster logicalcluster.Name, found bool) {
+	shard, cluster, _, found = c.lookup(path)
+	return shard, cluster, found
+}
+
+// lookup walks the index graph along the path and returns the shard and logical cluster of the
+// final workspace, and the URL of its mount if it is mounted.
+func (c *State) lookup(path logicalcluster.Path) (shard string, cluster logicalcluster.Name, mountURL string, found bool) {
 	segments := strings.Split(path.String(), ":")
 
 	for _, rewriter := range c.rewriters {
@@ -204,31 +273,48 @@ func (c *State) Lookup(path logicalcluster.Path) (shard string, cluster logicalc
 			var found bool
 			shard, found = c.clusterShards[logicalcluster.Name(s)]
 			if !found {
-				return "", "", false
+				return "", "", "", false
 			}
 			cluster = logicalcluster.Name(s)
 			continue
 		}
 
+		if mountURL != "" {
+			// the content of mounted workspaces is not known to kcp
+			return "", "", "", false
+		}
+
 		var found bool
-		cluster, found = c.shardWorkspaceNameCluster[shard][cluster][s]
+		parent := cluster
+		cluster, found = c.shardWorkspaceNameCluster[shard][parent][s]
 		if !found {
-			return "", "", false
+			return "", "", "", false
 		}
+		mountURL = c.shardWorkspaceNameMount[shard][parent][s]
 		shard, found = c.clusterShards[cluster]
 		if !found {
-			return "", "", false
+			return "", "", "", false
+		}
+		if owner, found := c.clusterOwners[cluster]; found {
+			if err := routingtarget.ValidateOwner(&owner, parent, s); err != nil {
+				return "", "", "", false
+			}
 		}
 	}
 
-	return shard, cluster, true
+	return shard, cluster, mountURL, true
 }
 
+// LookupURL returns the URL serving the given path. It is the URL of the logical cluster on its
+// shard, or the URL of the mount for mounted workspaces.
 func (c *State) LookupURL(path logicalcluster.Path) (url string, found bool) {
-	shard, cluster, found := c.Lookup(path)
+	shard, cluster, mountURL, found := c.lookup(path)
 	if !found {
 		return "", false
 	}
+	if mountURL != "" {
+		return mountURL, true
+	}
 
 	baseURL, found := c.shardBaseURLs[shard]
 	if !found {
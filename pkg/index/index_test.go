@@ -23,8 +23,11 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type shardStub struct {
@@ -382,6 +385,53 @@ func TestLookupURL(t *testing.T) {
 	}
 }
 
+func TestLookupURLMount(t *testing.T) {
+	target := New(nil)
+
+	target.UpsertShard("root", "https://root.io")
+	target.UpsertLogicalCluster("root", newLogicalCluster("root"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("34"))
+	target.UpsertLogicalCluster("root", newLogicalCluster("44"))
+
+	mounted := newWorkspace("cluster", "root", "34")
+	mounted.Spec.Mount = &tenancyv1beta1.Mount{Reference: tenancyv1beta1.ObjectReference{APIVersion: "proxy.example.com/v1alpha1", Kind: "ClusterProxy", Name: "cluster"}}
+	mounted.Status.Mount = &tenancyv1beta1.WorkspaceMountStatus{URL: "https://proxy.example.com/clusters/cluster"}
+	mounted.Status.Conditions = conditionsv1alpha1.Conditions{*conditions.FalseCondition(tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountNotReadyReason, conditionsv1alpha1.ConditionSeverityWarning, "")}
+	target.UpsertWorkspace("root", mounted)
+	target.UpsertWorkspace("root", newWorkspace("child", "34", "44"))
+
+	url, found := target.LookupURL(logicalcluster.NewPath("root:cluster"))
+	if !found {
+		t.Fatalf("expected to find a URL for %q path", "root:cluster")
+	}
+	if url != "https://root.io/clusters/34" {
+		t.Fatalf("unexpected url = %v returned for a mount which is not ready, expected = %v", url, "https://root.io/clusters/34")
+	}
+
+	mounted = mounted.DeepCopy()
+	mounted.Status.Conditions = conditionsv1alpha1.Conditions{*conditions.TrueCondition(tenancyv1alpha1.WorkspaceMountReady)}
+	target.UpsertWorkspace("root", mounted)
+
+	url, found = target.LookupURL(logicalcluster.NewPath("root:cluster"))
+	if !found {
+		t.Fatalf("expected to find a URL for %q path", "root:cluster")
+	}
+	if url != "https://proxy.example.com/clusters/cluster" {
+		t.Fatalf("unexpected url = %v returned, expected = %v", url, "https://proxy.example.com/clusters/cluster")
+	}
+	if _, found := target.LookupURL(logicalcluster.NewPath("root:cluster:child")); found {
+		t.Fatalf("didn't expect to find a URL below the mounted %q path", "root:cluster")
+	}
+
+	target.DeleteWorkspace("root", mounted)
+	if _, found := target.LookupURL(logicalcluster.NewPath("root:cluster")); found {
+		t.Fatalf("didn't expect to find a URL for deleted %q path", "root:cluster")
+	}
+	if len(target.shardWorkspaceNameMount) != 0 {
+		t.Fatalf("expected mounts to be cleaned up, got %v", target.shardWorkspaceNameMount)
+	}
+}
+
 func TestUpsertShard(t *testing.T) {
 	target := New(nil)
 
@@ -425,6 +475,28 @@ func TestUpsertWorkspace(t *testing.T) {
 	validateLookupOutput(t, logicalcluster.NewPath("root:org"), shard, cluster, found, "root", "44", true)
 }
 
+func TestUpsertWorkspaceRejectsForbiddenTargets(t *testing.T) {
+	target := New(nil)
+
+	target.UpsertShard("root", "https://root.io")
+	target.UpsertLogicalCluster("root", newLogicalCluster("root"))
+	target.UpsertWorkspace("root", newWorkspace("admin", "root", "system:admin"))
+
+	shard, cluster, found := target.Lookup(logicalcluster.NewPath("root:admin"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:admin"), shard, cluster, found, "", "", false)
+
+	owned := newLogicalCluster("34")
+	owned.Spec.Owner = &corev1alpha1.LogicalClusterOwner{Resource: "workspaces", Cluster: "root", Name: "org"}
+	target.UpsertLogicalCluster("root", owned)
+	target.UpsertWorkspace("root", newWorkspace("org", "root", "34"))
+	target.UpsertWorkspace("root", newWorkspace("thief", "root", "34"))
+
+	shard, cluster, found = target.Lookup(logicalcluster.NewPath("root:org"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:org"), shard, cluster, found, "root", "34", true)
+	shard, cluster, found = target.Lookup(logicalcluster.NewPath("root:thief"))
+	validateLookupOutput(t, logicalcluster.NewPath("root:thief"), shard, cluster, found, "", "", false)
+}
+
 func validateLookupOutput(t *testing.T, path logicalcluster.Path, shard string, cluster logicalcluster.Name, found bool, expectedShard string, expectedCluster logicalcluster.Name, expectToFind bool) {
 	t.Helper()
 
@@ -23,7 +23,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 // ClusterAndGroupResourceValue returns the index value for use with
@@ -100,7 +100,7 @@ func IndexAPIBindingByAPIExport(obj interface{}) ([]string, error) {
 		return []string{}, fmt.Errorf("obj %T is not an APIBinding", obj)
 	}
 
-	path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+	path := apiBinding.Spec.Reference.Export.Path.Path()
 	if path.Empty() {
 		path = logicalcluster.From(apiBinding).Path()
 	}
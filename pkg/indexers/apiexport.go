@@ -22,7 +22,9 @@ import (
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 const (
@@ -30,6 +32,9 @@ const (
 	APIExportByIdentity = "APIExportByIdentity"
 	// APIExportBySecret is the indexer name for retrieving APIExports by secret.
 	APIExportBySecret = "APIExportSecret"
+	// APIExportByAPIResourceSchema is the indexer name for retrieving APIExports by the APIResourceSchemas
+	// they export, including those of their releases.
+	APIExportByAPIResourceSchema = "apiExportsByAPIResourceSchema"
 )
 
 // IndexAPIExportByIdentity is an index function that indexes an APIExport by its identity hash.
@@ -65,3 +70,24 @@ func IndexAPIExportBySecret(obj interface{}) ([]string, error) {
 
 	return []string{kcpcache.ToClusterAwareKey(logicalcluster.From(apiExport).String(), ref.Namespace, ref.Name)}, nil
 }
+
+// IndexAPIExportByAPIResourceSchemas is an index function that indexes an APIExport by its spec.latestResourceSchemas
+// and the APIResourceSchemas of its spec.releases. Index values are of the form <cluster name>|<schema name> (cache keys).
+func IndexAPIExportByAPIResourceSchemas(obj interface{}) ([]string, error) {
+	apiExport, ok := obj.(*apisv1alpha1.APIExport)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIExport", obj)
+	}
+
+	schemaNames := sets.NewString(apiExport.Spec.LatestResourceSchemas...)
+	for _, release := range apiExport.Spec.Releases {
+		schemaNames.Insert(release.ResourceSchemas...)
+	}
+
+	ret := make([]string, 0, schemaNames.Len())
+	for _, schemaName := range schemaNames.List() {
+		ret = append(ret, kcpcache.ToClusterAwareKey(logicalcluster.From(apiExport).String(), "", schemaName))
+	}
+
+	return ret, nil
+}
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package apibinding
+package indexers
 
 import (
 	"reflect"
@@ -24,8 +24,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/client"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func TestIndexAPIExportByAPIResourceSchemas(t *testing.T) {
@@ -52,8 +51,30 @@ func TestIndexAPIExportByAPIResourceSchemas(t *testing.T) {
 				},
 			},
 			want: []string{
-				client.ToClusterAwareKey(logicalcluster.NewPath("root:default"), "schema1"),
-				client.ToClusterAwareKey(logicalcluster.NewPath("root:default"), "some-other-schema"),
+				"root:default|schema1",
+				"root:default|some-other-schema",
+			},
+			wantErr: false,
+		},
+		"APIExport with releases": {
+			obj: &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:default",
+					},
+					Name: "foo",
+				},
+				Spec: apisv1alpha1.APIExportSpec{
+					LatestResourceSchemas: []string{"v2.schema1"},
+					Releases: []apisv1alpha1.APIExportRelease{
+						{Version: "v1.0.0", ResourceSchemas: []string{"v1.schema1"}},
+						{Version: "v2.0.0", ResourceSchemas: []string{"v2.schema1"}},
+					},
+				},
+			},
+			want: []string{
+				"root:default|v1.schema1",
+				"root:default|v2.schema1",
 			},
 			wantErr: false,
 		},
@@ -61,13 +82,13 @@ func TestIndexAPIExportByAPIResourceSchemas(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			got, err := indexAPIExportsByAPIResourceSchemasFunc(tt.obj)
+			got, err := IndexAPIExportByAPIResourceSchemas(tt.obj)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("indexAPIExportsByAPIResourceSchemasFunc() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("IndexAPIExportByAPIResourceSchemas() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("indexAPIExportsByAPIResourceSchemasFunc() got = %v, want %v", got, tt.want)
+				t.Errorf("IndexAPIExportByAPIResourceSchemas() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -20,17 +20,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kcp-dev/logicalcluster/v3"
-
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/tools/cache"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
 	syncershared "github.com/kcp-dev/kcp/pkg/syncer/shared"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1"
 )
 
 const (
@@ -41,10 +34,6 @@ const (
 	APIBindingByClusterAndAcceptedClaimedGroupResources = "byClusterAndAcceptedClaimedGroupResources"
 	// ByClusterResourceStateLabelKey indexes resources based on the cluster state label key.
 	ByClusterResourceStateLabelKey = "ByClusterResourceStateLabelKey"
-	// ByLogicalClusterPath indexes by logical cluster path, if the annotation exists.
-	ByLogicalClusterPath = "ByLogicalClusterPath"
-	// ByLogicalClusterPathAndName indexes by logical cluster path and object name, if the annotation exists.
-	ByLogicalClusterPathAndName = "ByLogicalClusterPathAndName"
 )
 
 // IndexBySyncerFinalizerKey indexes by syncer finalizer label keys.
@@ -79,66 +68,3 @@ func IndexByClusterResourceStateLabelKey(obj interface{}) ([]string, error) {
 	}
 	return ClusterResourceStateLabelKeys, nil
 }
-
-// IndexByLogicalClusterPath indexes by logical cluster path, if the annotation exists.
-func IndexByLogicalClusterPath(obj interface{}) ([]string, error) {
-	metaObj, ok := obj.(metav1.Object)
-	if !ok {
-		return []string{}, fmt.Errorf("obj is supposed to be a metav1.Object, but is %T", obj)
-	}
-	if path, found := metaObj.GetAnnotations()[core.LogicalClusterPathAnnotationKey]; found {
-		return []string{
-			logicalcluster.NewPath(path).String(),
-			logicalcluster.From(metaObj).String(),
-		}, nil
-	}
-
-	return []string{logicalcluster.From(metaObj).String()}, nil
-}
-
-// IndexByLogicalClusterPathAndName indexes by logical cluster path and object name, if the annotation exists.
-func IndexByLogicalClusterPathAndName(obj interface{}) ([]string, error) {
-	metaObj, ok := obj.(metav1.Object)
-	if !ok {
-		return []string{}, fmt.Errorf("obj is supposed to be a metav1.Object, but is %T", obj)
-	}
-	if path, found := metaObj.GetAnnotations()[core.LogicalClusterPathAnnotationKey]; found {
-		return []string{
-			logicalcluster.NewPath(path).Join(metaObj.GetName()).String(),
-			logicalcluster.From(metaObj).Path().Join(metaObj.GetName()).String(),
-		}, nil
-	}
-
-	return []string{logicalcluster.From(metaObj).String()}, nil
-}
-
-// ByIndex returns all instances of T that match indexValue in indexName in indexer.
-func ByIndex[T runtime.Object](indexer cache.Indexer, indexName, indexValue string) ([]T, error) {
-	list, err := indexer.ByIndex(indexName, indexValue)
-	if err != nil {
-		return nil, err
-	}
-
-	ret := make([]T, 0, len(list))
-	for _, o := range list {
-		ret = append(ret, o.(T))
-	}
-
-	return ret, nil
-}
-
-// ByPathAndName returns the instance of T from the indexer with the matching path and name. Path may be a canonical path
-// or a cluster name. Note: this depends on the presence of the optional "kcp.io/path" annotation.
-func ByPathAndName[T runtime.Object](groupResource schema.GroupResource, indexer cache.Indexer, path logicalcluster.Path, name string) (ret T, err error) {
-	objs, err := indexer.ByIndex(ByLogicalClusterPathAndName, path.Join(name).String())
-	if err != nil {
-		return ret, err
-	}
-	if len(objs) == 0 {
-		return ret, apierrors.NewNotFound(groupResource, path.Join(name).String())
-	}
-	if len(objs) > 1 {
-		return ret, fmt.Errorf("multiple %s found for %s", groupResource, path.Join(name).String())
-	}
-	return objs[0].(T), nil
-}
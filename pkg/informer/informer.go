@@ -49,8 +49,8 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/projection"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kcperrors defines machine-readable causes of errors returned by kcp, and predicates for
+// API clients to branch on them instead of matching messages. The causes are carried in the
+// details of the returned Status.
+package kcperrors
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CauseTypeBindingConflict is the cause of errors rejecting a resource because it conflicts
+	// with a resource bound by an APIBinding in the same workspace.
+	CauseTypeBindingConflict metav1.CauseType = "BindingConflict"
+
+	// CauseTypeWorkspaceNotReady is the cause of errors returned because a workspace is not ready
+	// yet, e.g. while it is being created. The request can be retried.
+	CauseTypeWorkspaceNotReady metav1.CauseType = "WorkspaceNotReady"
+)
+
+// WithCause adds a cause to the details of a Status error. Other errors are returned unchanged.
+func WithCause(err error, causeType metav1.CauseType, message string) error {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	if statusErr.ErrStatus.Details == nil {
+		statusErr.ErrStatus.Details = &metav1.StatusDetails{}
+	}
+	statusErr.ErrStatus.Details.Causes = append(statusErr.ErrStatus.Details.Causes, metav1.StatusCause{
+		Type:    causeType,
+		Message: message,
+	})
+	return err
+}
+
+// NewWorkspaceNotReady returns a retriable error for requests to a workspace that is not ready yet.
+func NewWorkspaceNotReady(message string, retryAfterSeconds int) error {
+	return WithCause(apierrors.NewTooManyRequests(message, retryAfterSeconds), CauseTypeWorkspaceNotReady, message)
+}
+
+// HasCause returns whether the error is a Status error with a cause of the given type.
+func HasCause(err error, causeType metav1.CauseType) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	details := status.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, cause := range details.Causes {
+		if cause.Type == causeType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBindingConflict returns whether the error rejects a resource because it conflicts with a
+// resource bound by an APIBinding.
+func IsBindingConflict(err error) bool {
+	return HasCause(err, CauseTypeBindingConflict)
+}
+
+// IsWorkspaceNotReady returns whether the error is caused by a workspace that is not ready yet.
+func IsWorkspaceNotReady(err error) bool {
+	return HasCause(err, CauseTypeWorkspaceNotReady)
+}
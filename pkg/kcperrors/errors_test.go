@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kcperrors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestPredicates(t *testing.T) {
+	conflict := WithCause(apierrors.NewForbidden(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, "widgets.example.io", fmt.Errorf("overlaps")), CauseTypeBindingConflict, "overlaps")
+	require.True(t, IsBindingConflict(conflict))
+	require.False(t, IsWorkspaceNotReady(conflict))
+	require.True(t, apierrors.IsForbidden(conflict), "the status must be kept")
+
+	notReady := NewWorkspaceNotReady("Creating the home workspace", 5)
+	require.True(t, IsWorkspaceNotReady(notReady))
+	require.False(t, IsBindingConflict(notReady))
+	require.True(t, apierrors.IsTooManyRequests(notReady))
+
+	require.True(t, IsWorkspaceNotReady(fmt.Errorf("wrapped: %w", notReady)))
+	require.False(t, IsBindingConflict(apierrors.NewForbidden(schema.GroupResource{}, "foo", fmt.Errorf("forbidden"))))
+	require.False(t, IsBindingConflict(fmt.Errorf("overlaps")))
+	require.Equal(t, fmt.Errorf("overlaps"), WithCause(fmt.Errorf("overlaps"), CauseTypeBindingConflict, "overlaps"))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadshedding
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	degraded = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "load_shedding_degraded",
+			Help:           "1 if the shard sheds load because of the given reason, 0 otherwise.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"reason"},
+	)
+	shedRequests = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "load_shedding_rejected_requests_total",
+			Help:           "Number of requests rejected in degraded mode, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	pausedControllers = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "load_shedding_paused_controllers",
+			Help:           "1 if the given low-priority controller is paused because the shard sheds load, 0 otherwise.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the load shedding metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(degraded)
+		legacyregistry.MustRegister(shedRequests)
+		legacyregistry.MustRegister(pausedControllers)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+func recordState(state State) {
+	for _, reason := range []string{MemoryPressureReason, EtcdLatencyReason} {
+		value := 0.0
+		if state.Degraded && state.Reason == reason {
+			value = 1
+		}
+		degraded.WithLabelValues(reason).Set(value)
+	}
+}
+
+// RecordRejectedRequest records a request for the given resource rejected in degraded mode.
+func RecordRejectedRequest(resource string) {
+	shedRequests.WithLabelValues(resource).Inc()
+}
+
+func recordPaused(controller string, paused bool) {
+	value := 0.0
+	if paused {
+		value = 1
+	}
+	pausedControllers.WithLabelValues(controller).Set(value)
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadshedding implements a watchdog putting a shard into a degraded mode when its
+// memory usage or the latency of etcd crosses a threshold. In degraded mode, the shard sheds
+// load gracefully (pausing low-priority controllers and rejecting expensive requests) instead
+// of being OOM-killed as a whole.
+package loadshedding
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+const (
+	// MemoryPressureReason is the reason of degraded mode caused by memory usage.
+	MemoryPressureReason = corev1alpha1.ShardMemoryPressureReason
+	// EtcdLatencyReason is the reason of degraded mode caused by etcd latency.
+	EtcdLatencyReason = corev1alpha1.ShardEtcdLatencyReason
+
+	// recoveryFactor is the fraction of the thresholds the measurements must fall under
+	// to leave degraded mode again, such that the shard does not flap around a threshold.
+	recoveryFactor = 0.9
+)
+
+// State is the load shedding state of a shard.
+type State struct {
+	// Degraded is true if the shard sheds load.
+	Degraded bool
+	// Reason is MemoryPressureReason or EtcdLatencyReason if degraded.
+	Reason string
+	// Message describes the measurement that caused degraded mode.
+	Message string
+}
+
+// Watchdog periodically measures memory usage and etcd latency, and switches into degraded
+// mode when either crosses its threshold. A nil Watchdog is never degraded.
+type Watchdog struct {
+	memoryThreshold      uint64
+	etcdLatencyThreshold time.Duration
+	interval             time.Duration
+
+	memoryUsage func() uint64
+	etcdLatency func(ctx context.Context) (time.Duration, error)
+	onChange    func(ctx context.Context, state State)
+
+	lock        sync.RWMutex
+	state       State
+	lowPriority sets.String
+}
+
+// NewWatchdog returns a watchdog. A zero memoryThreshold or etcdLatencyThreshold disables the
+// respective check. onChange is called with the new state whenever degraded mode is entered or left.
+func NewWatchdog(
+	memoryThreshold uint64,
+	etcdLatencyThreshold time.Duration,
+	interval time.Duration,
+	etcdLatency func(ctx context.Context) (time.Duration, error),
+	onChange func(ctx context.Context, state State),
+) *Watchdog {
+	return &Watchdog{
+		memoryThreshold:      memoryThreshold,
+		etcdLatencyThreshold: etcdLatencyThreshold,
+		interval:             interval,
+
+		memoryUsage: memoryUsage,
+		etcdLatency: etcdLatency,
+		onChange:    onChange,
+
+		lowPriority: sets.NewString(),
+	}
+}
+
+// RegisterLowPriorityController registers the controller with the given name as low-priority,
+// and returns the function the controller checks before doing work. It returns true while the
+// shard is degraded, and the controller must pause then. Controllers registered with a nil
+// Watchdog never pause.
+func (w *Watchdog) RegisterLowPriorityController(name string) (paused func() bool) {
+	if w == nil {
+		return func() bool { return false }
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lowPriority.Insert(name)
+	recordPaused(name, w.state.Degraded)
+	return w.Degraded
+}
+
+// LowPriorityControllers returns the names of the registered low-priority controllers.
+func (w *Watchdog) LowPriorityControllers() []string {
+	if w == nil {
+		return nil
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.lowPriority.List()
+}
+
+// Degraded returns true if the shard sheds load.
+func (w *Watchdog) Degraded() bool {
+	if w == nil {
+		return false
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.state.Degraded
+}
+
+// State returns the current load shedding state.
+func (w *Watchdog) State() State {
+	if w == nil {
+		return State{}
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.state
+}
+
+// SetThresholds replaces the thresholds, e.g. when the configuration file changes. A zero
+// threshold disables the respective check.
+func (w *Watchdog) SetThresholds(memoryThreshold uint64, etcdLatencyThreshold time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.memoryThreshold = memoryThreshold
+	w.etcdLatencyThreshold = etcdLatencyThreshold
+}
+
+// Start runs the checks until ctx is done.
+func (w *Watchdog) Start(ctx context.Context) {
+	logger := klog.FromContext(ctx).WithValues("component", "load-shedding-watchdog")
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting load shedding watchdog")
+	defer logger.Info("Shutting down load shedding watchdog")
+
+	if w.onChange != nil {
+		w.onChange(ctx, w.State())
+	}
+	wait.UntilWithContext(ctx, w.check, w.interval)
+}
+
+// check measures memory usage and etcd latency, and updates the state.
+func (w *Watchdog) check(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	w.lock.RLock()
+	old := w.state
+	memoryThreshold, etcdLatencyThreshold := w.memoryThreshold, w.etcdLatencyThreshold
+	w.lock.RUnlock()
+
+	// leave degraded mode only with some headroom to the thresholds
+	factor := 1.0
+	if old.Degraded {
+		factor = recoveryFactor
+	}
+
+	state := State{}
+	if memoryThreshold > 0 {
+		if usage := w.memoryUsage(); float64(usage) >= factor*float64(memoryThreshold) {
+			state = State{Degraded: true, Reason: MemoryPressureReason, Message: fmt.Sprintf("memory usage of %d bytes exceeds the threshold of %d bytes", usage, memoryThreshold)}
+		}
+	}
+	if !state.Degraded && etcdLatencyThreshold > 0 && w.etcdLatency != nil {
+		latency, err := w.etcdLatency(ctx)
+		if err != nil {
+			state = State{Degraded: true, Reason: EtcdLatencyReason, Message: fmt.Sprintf("etcd check failed: %v", err)}
+		} else if float64(latency) >= factor*float64(etcdLatencyThreshold) {
+			state = State{Degraded: true, Reason: EtcdLatencyReason, Message: fmt.Sprintf("etcd latency of %s exceeds the threshold of %s", latency, etcdLatencyThreshold)}
+		}
+	}
+
+	w.lock.Lock()
+	w.state = state
+	lowPriority := w.lowPriority.List()
+	w.lock.Unlock()
+
+	recordState(state)
+	for _, name := range lowPriority {
+		recordPaused(name, state.Degraded)
+	}
+
+	if state.Degraded != old.Degraded || state.Reason != old.Reason {
+		if state.Degraded {
+			logger.Info("entering degraded mode, shedding load", "reason", state.Reason, "message", state.Message, "pausedControllers", lowPriority)
+		} else {
+			logger.Info("leaving degraded mode", "resumedControllers", lowPriority)
+		}
+		if w.onChange != nil {
+			w.onChange(ctx, state)
+		}
+	}
+}
+
+// memoryUsage returns the memory obtained from the OS and not released to it again.
+func memoryUsage() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys - stats.HeapReleased
+}
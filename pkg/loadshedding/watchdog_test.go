@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadshedding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdogCheck(t *testing.T) {
+	var memory uint64
+	var latency time.Duration
+	var etcdErr error
+	var changes []State
+
+	w := NewWatchdog(1000, time.Second, time.Second,
+		func(ctx context.Context) (time.Duration, error) { return latency, etcdErr },
+		func(ctx context.Context, state State) { changes = append(changes, state) },
+	)
+	w.memoryUsage = func() uint64 { return memory }
+
+	steps := []struct {
+		name         string
+		memory       uint64
+		latency      time.Duration
+		etcdErr      error
+		wantDegraded bool
+		wantReason   string
+		wantChanges  int
+	}{
+		{name: "healthy", memory: 500, latency: 100 * time.Millisecond},
+		{name: "memory pressure", memory: 1000, latency: 100 * time.Millisecond, wantDegraded: true, wantReason: MemoryPressureReason, wantChanges: 1},
+		{name: "memory below threshold, but not below recovery threshold", memory: 950, latency: 100 * time.Millisecond, wantDegraded: true, wantReason: MemoryPressureReason, wantChanges: 1},
+		{name: "memory recovered", memory: 800, latency: 100 * time.Millisecond, wantChanges: 2},
+		{name: "etcd latency", memory: 800, latency: 2 * time.Second, wantDegraded: true, wantReason: EtcdLatencyReason, wantChanges: 3},
+		{name: "memory pressure takes precedence", memory: 2000, latency: 2 * time.Second, wantDegraded: true, wantReason: MemoryPressureReason, wantChanges: 4},
+		{name: "etcd failing", memory: 800, etcdErr: errors.New("connection refused"), wantDegraded: true, wantReason: EtcdLatencyReason, wantChanges: 5},
+		{name: "all recovered", memory: 800, latency: 100 * time.Millisecond, wantChanges: 6},
+	}
+	for _, step := range steps {
+		memory, latency, etcdErr = step.memory, step.latency, step.etcdErr
+		w.check(context.Background())
+
+		state := w.State()
+		require.Equal(t, step.wantDegraded, state.Degraded, step.name)
+		require.Equal(t, step.wantDegraded, w.Degraded(), step.name)
+		require.Equal(t, step.wantReason, state.Reason, step.name)
+		require.Len(t, changes, step.wantChanges, step.name)
+	}
+}
+
+func TestWatchdogSetThresholds(t *testing.T) {
+	w := NewWatchdog(1000, 0, time.Second, nil, nil)
+	w.memoryUsage = func() uint64 { return 1500 }
+
+	w.check(context.Background())
+	require.True(t, w.Degraded())
+
+	w.SetThresholds(2000, 0)
+	w.check(context.Background())
+	require.False(t, w.Degraded())
+}
+
+func TestNilWatchdog(t *testing.T) {
+	var w *Watchdog
+	require.False(t, w.Degraded())
+	require.Equal(t, State{}, w.State())
+	require.False(t, w.RegisterLowPriorityController("foo")())
+	require.Empty(t, w.LowPriorityControllers())
+}
+
+func TestLowPriorityControllers(t *testing.T) {
+	var memory uint64
+	w := NewWatchdog(1000, 0, time.Second, nil, nil)
+	w.memoryUsage = func() uint64 { return memory }
+
+	pausedA := w.RegisterLowPriorityController("a")
+	pausedB := w.RegisterLowPriorityController("b")
+	require.Equal(t, []string{"a", "b"}, w.LowPriorityControllers())
+	require.False(t, pausedA())
+
+	memory = 2000
+	w.check(context.Background())
+	require.True(t, pausedA())
+	require.True(t, pausedB())
+
+	memory = 0
+	w.check(context.Background())
+	require.False(t, pausedA())
+	require.False(t, pausedB())
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ParseServerURL parses the URL of a kcp server, e.g. the base URL of a shard. It must be an
+// absolute http or https URL. IPv6 literal hosts must be enclosed in brackets as in
+// https://[2001:db8::1]:6443, as url.Parse silently splits an unbracketed address at its last
+// colon, taking the last group for the port.
+func ParseServerURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("URL %q must have scheme https or http", rawURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("URL %q has no host", rawURL)
+	}
+
+	host := u.Hostname()
+	if !strings.HasPrefix(u.Host, "[") {
+		if strings.Contains(host, ":") {
+			return nil, fmt.Errorf("IPv6 address in URL %q must be enclosed in brackets", rawURL)
+		}
+		return u, nil
+	}
+	// url.Parse of older Go versions accepts any host in brackets. Strip the zone of link-local
+	// addresses, e.g. fe80::1%eth0, before checking it.
+	if i := strings.Index(host, "%"); i >= 0 {
+		host = host[:i]
+	}
+	if net.ParseIP(host) == nil || !strings.Contains(host, ":") {
+		return nil, fmt.Errorf("URL %q has an invalid IPv6 address %q", rawURL, u.Hostname())
+	}
+	return u, nil
+}
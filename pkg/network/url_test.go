@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServerURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantHost string
+		wantErr  string
+	}{
+		{url: "https://kcp.example.com:6443", wantHost: "kcp.example.com"},
+		{url: "https://10.0.0.1:6443", wantHost: "10.0.0.1"},
+		{url: "https://[2001:db8::1]:6443", wantHost: "2001:db8::1"},
+		{url: "https://[2001:db8::1]", wantHost: "2001:db8::1"},
+		{url: "http://[::1]:8080/clusters/root", wantHost: "::1"},
+		{url: "https://[fe80::1%25eth0]:6443", wantHost: "fe80::1%eth0"},
+		{url: "https://2001:db8::1:6443", wantErr: `IPv6 address in URL "https://2001:db8::1:6443" must be enclosed in brackets`},
+		{url: "https://[10.0.0.1]:6443", wantErr: `"https://[10.0.0.1]:6443"`},
+		{url: "https://[kcp.example.com]:6443", wantErr: `"https://[kcp.example.com]:6443"`},
+		{url: "kcp.example.com:6443", wantErr: `URL "kcp.example.com:6443" must have scheme https or http`},
+		{url: "https:///clusters/root", wantErr: `URL "https:///clusters/root" has no host`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := ParseServerURL(tt.url)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHost, u.Hostname())
+		})
+	}
+}
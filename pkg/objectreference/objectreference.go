@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectreference validates and resolves apisv1alpha1.ObjectReference, the standard
+// reference to an object in the same or in another workspace. Admission plugins use a Resolver
+// to check that the referenced object is visible to the user creating the reference, controllers
+// use Get to read the referenced object.
+package objectreference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+)
+
+// Validate checks that the reference is well-formed.
+func Validate(ref *apisv1alpha1.ObjectReference, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if !ref.Path.Empty() && !ref.Path.IsValid() {
+		errs = append(errs, field.Invalid(fldPath.Child("path"), ref.Path, "must be a valid logical cluster path"))
+	}
+	if ref.Group != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Group) {
+			errs = append(errs, field.Invalid(fldPath.Child("group"), ref.Group, msg))
+		}
+	}
+	if ref.Version == "" {
+		errs = append(errs, field.Required(fldPath.Child("version"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1035Label(ref.Version) {
+			errs = append(errs, field.Invalid(fldPath.Child("version"), ref.Version, msg))
+		}
+	}
+	if ref.Resource == "" {
+		errs = append(errs, field.Required(fldPath.Child("resource"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(ref.Resource) {
+			errs = append(errs, field.Invalid(fldPath.Child("resource"), ref.Resource, msg))
+		}
+	}
+	if ref.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			errs = append(errs, field.Invalid(fldPath.Child("namespace"), ref.Namespace, msg))
+		}
+	}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("name"), ""))
+	}
+
+	return errs
+}
+
+// Resolver resolves references to the logical cluster of the referenced object and checks
+// access to it, as needed by admission.
+type Resolver struct {
+	getLogicalClustersByPath func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error)
+
+	createAuthorizer delegated.DelegatedAuthorizerFactory
+	deepSARClient    kcpkubernetesclientset.ClusterInterface
+}
+
+// NewResolver returns a resolver looking up paths in the given LogicalCluster indexer, which must
+// have the indexers.ByLogicalClusterPath index. Access is checked with deep SubjectAccessReviews.
+func NewResolver(logicalClusterIndexer cache.Indexer, deepSARClient kcpkubernetesclientset.ClusterInterface) *Resolver {
+	return &Resolver{
+		getLogicalClustersByPath: func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error) {
+			return indexers.ByIndex[*corev1alpha1.LogicalCluster](logicalClusterIndexer, indexers.ByLogicalClusterPath, path.String())
+		},
+		createAuthorizer: delegated.NewDelegatedAuthorizer,
+		deepSARClient:    deepSARClient,
+	}
+}
+
+// ClusterName returns the name of the logical cluster of the referenced object. The reference is
+// relative to the logical cluster of the referencing object.
+func (r *Resolver) ClusterName(from logicalcluster.Name, ref apisv1alpha1.ObjectReference) (logicalcluster.Name, error) {
+	if ref.Path.Empty() {
+		return from, nil
+	}
+	if name, ok := ref.Path.Path().Name(); ok {
+		return name, nil
+	}
+
+	logicalClusters, err := r.getLogicalClustersByPath(ref.Path.Path())
+	if err != nil {
+		return "", err
+	}
+	if len(logicalClusters) == 0 {
+		return "", apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), ref.Path.String())
+	}
+	return logicalcluster.From(logicalClusters[0]), nil
+}
+
+// CheckAccess returns nil if the user may perform verb on the referenced object.
+func (r *Resolver) CheckAccess(ctx context.Context, user user.Info, verb string, from logicalcluster.Name, ref apisv1alpha1.ObjectReference) error {
+	clusterName, err := r.ClusterName(from, ref)
+	if err != nil {
+		return err
+	}
+
+	authz, err := r.createAuthorizer(clusterName, r.deepSARClient)
+	if err != nil {
+		// Logging a more specific error for the operator
+		klog.FromContext(ctx).Error(err, "error creating authorizer from delegating authorizer config")
+		// Returning a less specific error to the end user
+		return errors.New("unable to authorize request")
+	}
+
+	attr := authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		APIGroup:        ref.Group,
+		APIVersion:      ref.Version,
+		Resource:        ref.Resource,
+		Namespace:       ref.Namespace,
+		Name:            ref.Name,
+		ResourceRequest: true,
+	}
+	if decision, _, err := authz.Authorize(ctx, attr); err != nil {
+		return fmt.Errorf("unable to determine access to %s: %w", ref.String(), err)
+	} else if decision != authorizer.DecisionAllow {
+		return fmt.Errorf("no permission to %s %s", verb, ref.String())
+	}
+
+	return nil
+}
+
+// Admit validates the reference at fldPath of the object in admission, and checks that the
+// referenced object is visible, i.e. can be read, by the requesting user. The returned error
+// is suitable to be returned from admission.
+func (r *Resolver) Admit(ctx context.Context, a admission.Attributes, from logicalcluster.Name, ref apisv1alpha1.ObjectReference, fldPath *field.Path) error {
+	if errs := Validate(&ref, fldPath); len(errs) > 0 {
+		return admission.NewForbidden(a, errs.ToAggregate())
+	}
+	if err := r.CheckAccess(ctx, a.GetUserInfo(), "get", from, ref); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.NewForbidden(a, field.NotFound(fldPath.Child("path"), ref.Path))
+		}
+		return admission.NewForbidden(a, field.Forbidden(fldPath, err.Error()))
+	}
+	return nil
+}
+
+// Get reads the referenced object. The client must be able to address logical clusters by path,
+// e.g. a client going through the front-proxy, unless the reference uses logical cluster names.
+func Get(ctx context.Context, client kcpdynamic.ClusterInterface, from logicalcluster.Name, ref apisv1alpha1.ObjectReference) (*unstructured.Unstructured, error) {
+	return client.Cluster(ref.ClusterPath(from)).Resource(ref.GroupVersionResource()).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectreference
+
+import (
+	"context"
+	"testing"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        apisv1alpha1.ObjectReference
+		wantFields []string
+	}{
+		{
+			name: "valid local reference",
+			ref:  apisv1alpha1.ObjectReference{Version: "v1", Resource: "configmaps", Namespace: "default", Name: "foo"},
+		},
+		{
+			name: "valid remote reference",
+			ref:  apisv1alpha1.ObjectReference{Path: "root:org:ws", Group: "apis.kcp.io", Version: "v1alpha1", Resource: "apiexports", Name: "foo"},
+		},
+		{
+			name:       "missing fields",
+			ref:        apisv1alpha1.ObjectReference{},
+			wantFields: []string{"spec.target.version", "spec.target.resource", "spec.target.name"},
+		},
+		{
+			name:       "invalid fields",
+			ref:        apisv1alpha1.ObjectReference{Path: "root::ws", Group: "Foo_Bar", Version: "v1", Resource: "ConfigMaps", Namespace: "default", Name: "foo"},
+			wantFields: []string{"spec.target.path", "spec.target.group", "spec.target.resource"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(&tt.ref, field.NewPath("spec", "target"))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestClusterName(t *testing.T) {
+	r := &Resolver{
+		getLogicalClustersByPath: func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error) {
+			if path.String() != "root:org:ws" {
+				return nil, nil
+			}
+			return []*corev1alpha1.LogicalCluster{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "abc123"},
+				},
+			}}, nil
+		},
+	}
+
+	name, err := r.ClusterName("local", apisv1alpha1.ObjectReference{})
+	require.NoError(t, err)
+	require.Equal(t, logicalcluster.Name("local"), name)
+
+	name, err = r.ClusterName("local", apisv1alpha1.ObjectReference{Path: "xyz789"})
+	require.NoError(t, err)
+	require.Equal(t, logicalcluster.Name("xyz789"), name)
+
+	name, err = r.ClusterName("local", apisv1alpha1.ObjectReference{Path: "root:org:ws"})
+	require.NoError(t, err)
+	require.Equal(t, logicalcluster.Name("abc123"), name)
+
+	_, err = r.ClusterName("local", apisv1alpha1.ObjectReference{Path: "root:org:missing"})
+	require.Error(t, err)
+}
+
+func TestAdmit(t *testing.T) {
+	ref := apisv1alpha1.ObjectReference{Path: "root:org:ws", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "foo"}
+
+	tests := []struct {
+		name     string
+		ref      apisv1alpha1.ObjectReference
+		decision authorizer.Decision
+		wantErr  string
+	}{
+		{
+			name:     "visible",
+			ref:      ref,
+			decision: authorizer.DecisionAllow,
+		},
+		{
+			name:     "not visible",
+			ref:      ref,
+			decision: authorizer.DecisionDeny,
+			wantErr:  "no permission to get root:org:ws|configmaps.v1/default/foo",
+		},
+		{
+			name:     "unknown path",
+			ref:      apisv1alpha1.ObjectReference{Path: "root:org:missing", Version: "v1", Resource: "configmaps", Name: "foo"},
+			decision: authorizer.DecisionAllow,
+			wantErr:  "spec.target.path: Not found",
+		},
+		{
+			name:     "invalid",
+			ref:      apisv1alpha1.ObjectReference{Version: "v1", Name: "foo"},
+			decision: authorizer.DecisionAllow,
+			wantErr:  "spec.target.resource: Required value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAttr authorizer.Attributes
+			r := &Resolver{
+				getLogicalClustersByPath: func(path logicalcluster.Path) ([]*corev1alpha1.LogicalCluster, error) {
+					if path.String() != "root:org:ws" {
+						return nil, nil
+					}
+					return []*corev1alpha1.LogicalCluster{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        corev1alpha1.LogicalClusterName,
+							Annotations: map[string]string{logicalcluster.AnnotationKey: "abc123"},
+						},
+					}}, nil
+				},
+				createAuthorizer: func(clusterName logicalcluster.Name, client kcpkubernetesclientset.ClusterInterface) (authorizer.Authorizer, error) {
+					require.Equal(t, logicalcluster.Name("abc123"), clusterName)
+					return authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+						gotAttr = a
+						return tt.decision, "", nil
+					}), nil
+				},
+			}
+			a := admission.NewAttributesRecord(nil, nil, schema.GroupVersionKind{}, "", "binding", schema.GroupVersionResource{}, "", admission.Create, nil, false, &user.DefaultInfo{Name: "alice"})
+
+			err := r.Admit(context.Background(), a, "local", tt.ref, field.NewPath("spec", "target"))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "alice", gotAttr.GetUser().GetName())
+			require.Equal(t, "get", gotAttr.GetVerb())
+			require.Equal(t, "configmaps", gotAttr.GetResource())
+			require.Equal(t, "default", gotAttr.GetNamespace())
+			require.Equal(t, "foo", gotAttr.GetName())
+		})
+	}
+}
@@ -31,97 +31,173 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImport":                    schema_pkg_apis_apiresource_v1alpha1_APIResourceImport(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportCondition":           schema_pkg_apis_apiresource_v1alpha1_APIResourceImportCondition(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportList":                schema_pkg_apis_apiresource_v1alpha1_APIResourceImportList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportSpec":                schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportStatus":              schema_pkg_apis_apiresource_v1alpha1_APIResourceImportStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition":                     schema_pkg_apis_apiresource_v1alpha1_ColumnDefinition(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.CommonAPIResourceSpec":                schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion":                         schema_pkg_apis_apiresource_v1alpha1_GroupVersion(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResource":                schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResource(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition":       schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceCondition(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceList":            schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec":            schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus":          schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource":                          schema_pkg_apis_apiresource_v1alpha1_SubResource(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBinding":                                  schema_pkg_apis_apis_v1alpha1_APIBinding(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingList":                              schema_pkg_apis_apis_v1alpha1_APIBindingList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingSpec":                              schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingStatus":                            schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExport":                                   schema_pkg_apis_apis_v1alpha1_APIExport(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpoint":                           schema_pkg_apis_apis_v1alpha1_APIExportEndpoint(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSlice":                      schema_pkg_apis_apis_v1alpha1_APIExportEndpointSlice(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceList":                  schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceSpec":                  schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceStatus":                schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportList":                               schema_pkg_apis_apis_v1alpha1_APIExportList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportSpec":                               schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportStatus":                             schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchema":                           schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaList":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaSpec":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceVersion":                          schema_pkg_apis_apis_v1alpha1_APIResourceVersion(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AcceptablePermissionClaim":                   schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BindingReference":                            schema_pkg_apis_apis_v1alpha1_BindingReference(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource":                            schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResourceSchema":                      schema_pkg_apis_apis_v1alpha1_BoundAPIResourceSchema(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ExportBindingReference":                      schema_pkg_apis_apis_v1alpha1_ExportBindingReference(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.GroupResource":                               schema_pkg_apis_apis_v1alpha1_GroupResource(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity":                                    schema_pkg_apis_apis_v1alpha1_Identity(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.LocalAPIExportPolicy":                        schema_pkg_apis_apis_v1alpha1_LocalAPIExportPolicy(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.MaximalPermissionPolicy":                     schema_pkg_apis_apis_v1alpha1_MaximalPermissionPolicy(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim":                             schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector":                            schema_pkg_apis_apis_v1alpha1_ResourceSelector(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace":                            schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalCluster":                              schema_pkg_apis_core_v1alpha1_LogicalCluster(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterList":                          schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterOwner":                         schema_pkg_apis_core_v1alpha1_LogicalClusterOwner(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterSpec":                          schema_pkg_apis_core_v1alpha1_LogicalClusterSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterStatus":                        schema_pkg_apis_core_v1alpha1_LogicalClusterStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.Shard":                                       schema_pkg_apis_core_v1alpha1_Shard(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardList":                                   schema_pkg_apis_core_v1alpha1_ShardList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardSpec":                                   schema_pkg_apis_core_v1alpha1_ShardSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardStatus":                                 schema_pkg_apis_core_v1alpha1_ShardStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.AvailableSelectorLabel":                schema_pkg_apis_scheduling_v1alpha1_AvailableSelectorLabel(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.GroupVersionResource":                  schema_pkg_apis_scheduling_v1alpha1_GroupVersionResource(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Location":                              schema_pkg_apis_scheduling_v1alpha1_Location(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationList":                          schema_pkg_apis_scheduling_v1alpha1_LocationList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationReference":                     schema_pkg_apis_scheduling_v1alpha1_LocationReference(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationSpec":                          schema_pkg_apis_scheduling_v1alpha1_LocationSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationStatus":                        schema_pkg_apis_scheduling_v1alpha1_LocationStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Placement":                             schema_pkg_apis_scheduling_v1alpha1_Placement(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementList":                         schema_pkg_apis_scheduling_v1alpha1_PlacementList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementSpec":                         schema_pkg_apis_scheduling_v1alpha1_PlacementSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementStatus":                       schema_pkg_apis_scheduling_v1alpha1_PlacementStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.APIExportReference":                       schema_pkg_apis_tenancy_v1alpha1_APIExportReference(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.VirtualWorkspace":                         schema_pkg_apis_tenancy_v1alpha1_VirtualWorkspace(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceType":                            schema_pkg_apis_tenancy_v1alpha1_WorkspaceType(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeExtension":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeExtension(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeList":                        schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeReference(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSelector":                    schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSelector(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSpec":                        schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeStatus":                      schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.Workspace":                                 schema_pkg_apis_tenancy_v1beta1_Workspace(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceList":                             schema_pkg_apis_tenancy_v1beta1_WorkspaceList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceLocation":                         schema_pkg_apis_tenancy_v1beta1_WorkspaceLocation(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceSpec":                             schema_pkg_apis_tenancy_v1beta1_WorkspaceSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceStatus":                           schema_pkg_apis_tenancy_v1beta1_WorkspaceStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition": schema_conditions_apis_conditions_v1alpha1_Condition(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.Partition":                               schema_pkg_apis_topology_v1alpha1_Partition(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionList":                           schema_pkg_apis_topology_v1alpha1_PartitionList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSet":                            schema_pkg_apis_topology_v1alpha1_PartitionSet(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetList":                        schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetSpec":                        schema_pkg_apis_topology_v1alpha1_PartitionSetSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetStatus":                      schema_pkg_apis_topology_v1alpha1_PartitionSetStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSpec":                           schema_pkg_apis_topology_v1alpha1_PartitionSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.ResourceToSync":                          schema_pkg_apis_workload_v1alpha1_ResourceToSync(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTarget":                              schema_pkg_apis_workload_v1alpha1_SyncTarget(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetList":                          schema_pkg_apis_workload_v1alpha1_SyncTargetList(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetSpec":                          schema_pkg_apis_workload_v1alpha1_SyncTargetSpec(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetStatus":                        schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref),
-		"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.VirtualWorkspace":                        schema_pkg_apis_workload_v1alpha1_VirtualWorkspace(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImport":                    schema_pkg_apis_apiresource_v1alpha1_APIResourceImport(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportCondition":           schema_pkg_apis_apiresource_v1alpha1_APIResourceImportCondition(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportList":                schema_pkg_apis_apiresource_v1alpha1_APIResourceImportList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportSpec":                schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportStatus":              schema_pkg_apis_apiresource_v1alpha1_APIResourceImportStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition":                     schema_pkg_apis_apiresource_v1alpha1_ColumnDefinition(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.CommonAPIResourceSpec":                schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion":                         schema_pkg_apis_apiresource_v1alpha1_GroupVersion(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResource":                schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition":       schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceCondition(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceList":            schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec":            schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus":          schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource":                          schema_pkg_apis_apiresource_v1alpha1_SubResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBinding":                                  schema_pkg_apis_apis_v1alpha1_APIBinding(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingList":                              schema_pkg_apis_apis_v1alpha1_APIBindingList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingResourceStatus":                    schema_pkg_apis_apis_v1alpha1_APIBindingResourceStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingSpec":                              schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingStatus":                            schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIConversionRule":                           schema_pkg_apis_apis_v1alpha1_APIConversionRule(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExport":                                   schema_pkg_apis_apis_v1alpha1_APIExport(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummary":                    schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummary(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummaryList":                schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummaryList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummarySpec":                schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummarySpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumers":                          schema_pkg_apis_apis_v1alpha1_APIExportConsumers(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportDeprecation":                        schema_pkg_apis_apis_v1alpha1_APIExportDeprecation(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpoint":                           schema_pkg_apis_apis_v1alpha1_APIExportEndpoint(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSlice":                      schema_pkg_apis_apis_v1alpha1_APIExportEndpointSlice(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceList":                  schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceSpec":                  schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceStatus":                schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportList":                               schema_pkg_apis_apis_v1alpha1_APIExportList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigration":                          schema_pkg_apis_apis_v1alpha1_APIExportMigration(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigrationStatus":                    schema_pkg_apis_apis_v1alpha1_APIExportMigrationStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportRelease":                            schema_pkg_apis_apis_v1alpha1_APIExportRelease(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportShardConsumers":                     schema_pkg_apis_apis_v1alpha1_APIExportShardConsumers(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportSpec":                               schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportStatus":                             schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchema":                           schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaList":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaSpec":                       schema_pkg_apis_apis_v1alpha1_APIResourceSchemaSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaStatus":                     schema_pkg_apis_apis_v1alpha1_APIResourceSchemaStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceVersion":                          schema_pkg_apis_apis_v1alpha1_APIResourceVersion(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIVersionConversion":                        schema_pkg_apis_apis_v1alpha1_APIVersionConversion(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.AcceptablePermissionClaim":                   schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BindingReference":                            schema_pkg_apis_apis_v1alpha1_BindingReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIExport":                              schema_pkg_apis_apis_v1alpha1_BoundAPIExport(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResource":                            schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResourceSchema":                      schema_pkg_apis_apis_v1alpha1_BoundAPIResourceSchema(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundSchemaCount":                            schema_pkg_apis_apis_v1alpha1_BoundSchemaCount(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresource":                           schema_pkg_apis_apis_v1alpha1_CustomSubresource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresourceHandler":                    schema_pkg_apis_apis_v1alpha1_CustomSubresourceHandler(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference":                      schema_pkg_apis_apis_v1alpha1_ExportBindingReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportedResourceVersion":                     schema_pkg_apis_apis_v1alpha1_ExportedResourceVersion(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.GroupResource":                               schema_pkg_apis_apis_v1alpha1_GroupResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.Identity":                                    schema_pkg_apis_apis_v1alpha1_Identity(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.LocalAPIExportPolicy":                        schema_pkg_apis_apis_v1alpha1_LocalAPIExportPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.MaximalPermissionPolicy":                     schema_pkg_apis_apis_v1alpha1_MaximalPermissionPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ObjectReference":                             schema_pkg_apis_apis_v1alpha1_ObjectReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim":                             schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ResourceSelector":                            schema_pkg_apis_apis_v1alpha1_ResourceSelector(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.VirtualWorkspace":                            schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalCluster":                              schema_pkg_apis_core_v1alpha1_LogicalCluster(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterList":                          schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterOwner":                         schema_pkg_apis_core_v1alpha1_LogicalClusterOwner(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterSpec":                          schema_pkg_apis_core_v1alpha1_LogicalClusterSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterStatus":                        schema_pkg_apis_core_v1alpha1_LogicalClusterStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.Shard":                                       schema_pkg_apis_core_v1alpha1_Shard(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardConfiguration":                          schema_pkg_apis_core_v1alpha1_ShardConfiguration(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardControllerOverride":                     schema_pkg_apis_core_v1alpha1_ShardControllerOverride(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardLease":                                  schema_pkg_apis_core_v1alpha1_ShardLease(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardList":                                   schema_pkg_apis_core_v1alpha1_ShardList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardSpec":                                   schema_pkg_apis_core_v1alpha1_ShardSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardStatus":                                 schema_pkg_apis_core_v1alpha1_ShardStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardUsage":                                  schema_pkg_apis_core_v1alpha1_ShardUsage(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.AvailableSelectorLabel":                schema_pkg_apis_scheduling_v1alpha1_AvailableSelectorLabel(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.GroupVersionResource":                  schema_pkg_apis_scheduling_v1alpha1_GroupVersionResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Location":                              schema_pkg_apis_scheduling_v1alpha1_Location(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationList":                          schema_pkg_apis_scheduling_v1alpha1_LocationList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationReference":                     schema_pkg_apis_scheduling_v1alpha1_LocationReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationSpec":                          schema_pkg_apis_scheduling_v1alpha1_LocationSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationStatus":                        schema_pkg_apis_scheduling_v1alpha1_LocationStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Placement":                             schema_pkg_apis_scheduling_v1alpha1_Placement(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementList":                         schema_pkg_apis_scheduling_v1alpha1_PlacementList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementSpec":                         schema_pkg_apis_scheduling_v1alpha1_PlacementSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementStatus":                       schema_pkg_apis_scheduling_v1alpha1_PlacementStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.APIExportReference":                       schema_pkg_apis_tenancy_v1alpha1_APIExportReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.AcceptedPermissionClaimPolicy":            schema_pkg_apis_tenancy_v1alpha1_AcceptedPermissionClaimPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ClaimedResource":                          schema_pkg_apis_tenancy_v1alpha1_ClaimedResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJob":                                  schema_pkg_apis_tenancy_v1alpha1_DataJob(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobCheckpoint":                        schema_pkg_apis_tenancy_v1alpha1_DataJobCheckpoint(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobList":                              schema_pkg_apis_tenancy_v1alpha1_DataJobList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobRelabel":                           schema_pkg_apis_tenancy_v1alpha1_DataJobRelabel(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobResource":                          schema_pkg_apis_tenancy_v1alpha1_DataJobResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobSpec":                              schema_pkg_apis_tenancy_v1alpha1_DataJobSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobStatus":                            schema_pkg_apis_tenancy_v1alpha1_DataJobStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobThrottle":                          schema_pkg_apis_tenancy_v1alpha1_DataJobThrottle(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DefaultAPIBinding":                        schema_pkg_apis_tenancy_v1alpha1_DefaultAPIBinding(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.InitializerPolicy":                        schema_pkg_apis_tenancy_v1alpha1_InitializerPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequest":                     schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequest(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestList":                 schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestQuotaReference":       schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestQuotaReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestSpec":                 schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestStatus":               schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicy":                          schema_pkg_apis_tenancy_v1alpha1_RetentionPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyList":                      schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyResource":                  schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyResource(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicySpec":                      schema_pkg_apis_tenancy_v1alpha1_RetentionPolicySpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyStatus":                    schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemption":                      schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemption(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemptionList":                  schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemptionList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemptionSpec":                  schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemptionSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.VirtualWorkspace":                         schema_pkg_apis_tenancy_v1alpha1_VirtualWorkspace(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceNamingPolicy":                    schema_pkg_apis_tenancy_v1alpha1_WorkspaceNamingPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuota":                           schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuota(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaList":                       schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaSpec":                       schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaStatus":                     schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaThreshold":                  schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaThreshold(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceResourceUsage":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceResourceUsage(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplate":                        schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplate(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateList":                    schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateReference":               schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateSpec":                    schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstone":                       schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstone(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstoneList":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstoneList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstoneSpec":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstoneSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceType":                            schema_pkg_apis_tenancy_v1alpha1_WorkspaceType(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeExtension":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeExtension(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeList":                        schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference":                   schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutPolicy":               schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutPolicy(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutStatus":               schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutWave":                 schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutWave(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSelector":                    schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSelector(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSpec":                        schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeStatus":                      schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsage":                           schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsage(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsageList":                       schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsageList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsageStatus":                     schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsageStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Mount":                                     schema_pkg_apis_tenancy_v1beta1_Mount(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.ObjectReference":                           schema_pkg_apis_tenancy_v1beta1_ObjectReference(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Workspace":                                 schema_pkg_apis_tenancy_v1beta1_Workspace(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceList":                             schema_pkg_apis_tenancy_v1beta1_WorkspaceList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceLocation":                         schema_pkg_apis_tenancy_v1beta1_WorkspaceLocation(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceMountStatus":                      schema_pkg_apis_tenancy_v1beta1_WorkspaceMountStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceOwner":                            schema_pkg_apis_tenancy_v1beta1_WorkspaceOwner(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceScheduling":                       schema_pkg_apis_tenancy_v1beta1_WorkspaceScheduling(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSpec":                             schema_pkg_apis_tenancy_v1beta1_WorkspaceSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceStatus":                           schema_pkg_apis_tenancy_v1beta1_WorkspaceStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSummary":                          schema_pkg_apis_tenancy_v1beta1_WorkspaceSummary(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceTTL":                              schema_pkg_apis_tenancy_v1beta1_WorkspaceTTL(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceURLs":                             schema_pkg_apis_tenancy_v1beta1_WorkspaceURLs(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition": schema_conditions_apis_conditions_v1alpha1_Condition(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.Partition":                               schema_pkg_apis_topology_v1alpha1_Partition(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionList":                           schema_pkg_apis_topology_v1alpha1_PartitionList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSet":                            schema_pkg_apis_topology_v1alpha1_PartitionSet(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetList":                        schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetSpec":                        schema_pkg_apis_topology_v1alpha1_PartitionSetSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetStatus":                      schema_pkg_apis_topology_v1alpha1_PartitionSetStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSpec":                           schema_pkg_apis_topology_v1alpha1_PartitionSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.ResourceToSync":                          schema_pkg_apis_workload_v1alpha1_ResourceToSync(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTarget":                              schema_pkg_apis_workload_v1alpha1_SyncTarget(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetList":                          schema_pkg_apis_workload_v1alpha1_SyncTargetList(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetSpec":                          schema_pkg_apis_workload_v1alpha1_SyncTargetSpec(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetStatus":                        schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref),
+		"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.VirtualWorkspace":                        schema_pkg_apis_workload_v1alpha1_VirtualWorkspace(ref),
 		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                             schema_pkg_apis_meta_v1_APIGroup(ref),
 		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                         schema_pkg_apis_meta_v1_APIGroupList(ref),
 		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                          schema_pkg_apis_meta_v1_APIResource(ref),
@@ -207,20 +283,20 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImport(ref common.Reference
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportSpec"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportSpec", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportSpec", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -311,7 +387,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportList(ref common.Refer
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImport"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImport"),
 									},
 								},
 							},
@@ -322,7 +398,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportList(ref common.Refer
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImport", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImport", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -336,7 +412,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref common.Refer
 					"groupVersion": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion"),
 						},
 					},
 					"scope": {
@@ -430,7 +506,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref common.Refer
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource"),
 									},
 								},
 							},
@@ -449,7 +525,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref common.Refer
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition"),
 									},
 								},
 							},
@@ -475,7 +551,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportSpec(ref common.Refer
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -493,7 +569,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportStatus(ref common.Ref
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportCondition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportCondition"),
 									},
 								},
 							},
@@ -503,7 +579,7 @@ func schema_pkg_apis_apiresource_v1alpha1_APIResourceImportStatus(ref common.Ref
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.APIResourceImportCondition"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.APIResourceImportCondition"},
 	}
 }
 
@@ -576,7 +652,7 @@ func schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref common.Refer
 					"groupVersion": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion"),
 						},
 					},
 					"scope": {
@@ -670,7 +746,7 @@ func schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref common.Refer
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource"),
 									},
 								},
 							},
@@ -689,7 +765,7 @@ func schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref common.Refer
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition"),
 									},
 								},
 							},
@@ -700,7 +776,7 @@ func schema_pkg_apis_apiresource_v1alpha1_CommonAPIResourceSpec(ref common.Refer
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -760,20 +836,20 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResource(ref common.Refer
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceSpec", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -864,7 +940,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceList(ref common.R
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResource"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResource"),
 									},
 								},
 							},
@@ -875,7 +951,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceList(ref common.R
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResource", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResource", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -889,7 +965,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref common.R
 					"groupVersion": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion"),
 						},
 					},
 					"scope": {
@@ -983,7 +1059,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref common.R
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource"),
 									},
 								},
 							},
@@ -1002,7 +1078,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref common.R
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition"),
 									},
 								},
 							},
@@ -1019,7 +1095,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceSpec(ref common.R
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.ColumnDefinition", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.GroupVersion", "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.SubResource", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1037,7 +1113,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceStatus(ref common
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition"),
 									},
 								},
 							},
@@ -1047,7 +1123,7 @@ func schema_pkg_apis_apiresource_v1alpha1_NegotiatedAPIResourceStatus(ref common
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition"},
+			"github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1.NegotiatedAPIResourceCondition"},
 	}
 }
 
@@ -1102,21 +1178,21 @@ func schema_pkg_apis_apis_v1alpha1_APIBinding(ref common.ReferenceCallback) comm
 						SchemaProps: spec.SchemaProps{
 							Description: "Spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Status communicates the observed state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingStatus"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingSpec", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBindingStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingSpec", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -1154,7 +1230,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingList(ref common.ReferenceCallback)
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBinding"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBinding"),
 									},
 								},
 							},
@@ -1165,7 +1241,73 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingList(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIBinding", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBinding", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIBindingResourceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIBindingResourceStatus is the binding state of a resource of the referenced APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Default:     "",
+							Description: "group is the group of the resource. Empty string for the core API group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default:     "",
+							Description: "resource is the resource name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Default:     "",
+							Description: "state is the binding state of the resource:\n- Bound: the latest APIResourceSchema of the resource is served.\n- Pending: the pending APIResourceSchema will be served without further action.\n- Blocked: the pending APIResourceSchema cannot be served without action, see reason.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"boundSchema": {
+						SchemaProps: spec.SchemaProps{
+							Description: "boundSchema is the name of the APIResourceSchema currently served for the resource, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pendingSchema": {
+						SchemaProps: spec.SchemaProps{
+							Description: "pendingSchema is the name of the APIResourceSchema the resource is being bound or updated to. It is empty if the resource is bound with the latest APIResourceSchema.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "reason is a machine-readable, CamelCase reason why the pending APIResourceSchema is not served yet. The reasons of the BindingUpToDate condition are used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "message is a human-readable message explaining the reason.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"group", "resource", "state"},
+			},
+		},
 	}
 }
 
@@ -1178,9 +1320,16 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref common.ReferenceCallback)
 				Properties: map[string]spec.Schema{
 					"reference": {
 						SchemaProps: spec.SchemaProps{
-							Description: "reference uniquely identifies an API to bind to.",
+							Description: "reference uniquely identifies an API to bind to.\n\nThe reference can be changed to point to a different APIExport. The resources bound so far are only switched over to the new APIExport if it serves all of them under the same identity and with all stored versions. Otherwise, the APIBinding keeps serving the previously bound resources and reports a MigrationRequired reason on the BindingUpToDate condition.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BindingReference"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BindingReference"),
+						},
+					},
+					"release": {
+						SchemaProps: spec.SchemaProps{
+							Description: "release is the version of a release of the referenced APIExport, e.g. v1.2.0. The APIResourceSchemas of the release are bound instead of latestResourceSchemas.\n\nWhen the release is removed from the APIExport, the APIBinding moves on to the next newer release, or to latestResourceSchemas if there is none, and reports the APIExportReleaseRemoved reason on the BindingUpToDate condition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"permissionClaims": {
@@ -1191,7 +1340,30 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref common.ReferenceCallback)
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AcceptablePermissionClaim"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.AcceptablePermissionClaim"),
+									},
+								},
+							},
+						},
+					},
+					"excludedResources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"group",
+									"resource",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "excludedResources are resources of the referenced APIExport that are not bound, e.g. because they conflict with local CRDs. No CRD is created for them, and they are not considered in conflict checking. Excluding a bound resource stops serving it in this workspace, but keeps the stored objects.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.GroupResource"),
 									},
 								},
 							},
@@ -1202,7 +1374,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingSpec(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.AcceptablePermissionClaim", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BindingReference"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.AcceptablePermissionClaim", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BindingReference", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.GroupResource"},
 	}
 }
 
@@ -1230,7 +1402,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResource"),
 									},
 								},
 							},
@@ -1251,7 +1423,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
 									},
 								},
 							},
@@ -1265,7 +1437,7 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim"),
 									},
 								},
 							},
@@ -1279,7 +1451,52 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim"),
+									},
+								},
+							},
+						},
+					},
+					"boundAPIExport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "boundAPIExport records the APIExport the resources in boundResources were bound from. It is used to detect a change of spec.reference to a different APIExport.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIExport"),
+						},
+					},
+					"resources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"group",
+									"resource",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "resources records the binding state of each resource of the referenced APIExport, i.e. which APIResourceSchema is served, which one is pending, and why it is not served yet. Automation can wait on specific resources instead of parsing the BindingUpToDate condition.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingResourceStatus"),
+									},
+								},
+							},
+						},
+					},
+					"extraCRDAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "extraCRDAnnotations are the extra annotations of the APIBinding that the APIExport selected to be set on the CRDs of the bound resources (see apis.kcp.io/extra-crd-keys). They are published here for tooling in the consumer workspace, which cannot read the bound CRDs.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
@@ -1289,7 +1506,43 @@ func schema_pkg_apis_apis_v1alpha1_APIBindingStatus(ref common.ReferenceCallback
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResource", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIBindingResourceStatus", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIExport", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResource", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIConversionRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIConversionRule moves a field of a custom resource and optionally transforms its value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "field is the JSONPath of the source field, e.g. \".spec.firstName\". Only dot-separated field names are supported, and metadata, apiVersion and kind cannot be converted. Rules of fields missing in a custom resource are skipped.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "destination is the JSONPath the value is written to, in the same syntax as field. It defaults to field. If it differs, the source field is removed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"transformation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "transformation is a CEL expression computing the written value from the value of the source field, which is available as \"self\", e.g. \"self.split(' ')[0]\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"field"},
+			},
+		},
 	}
 }
 
@@ -1324,51 +1577,29 @@ func schema_pkg_apis_apis_v1alpha1_APIExport(ref common.ReferenceCallback) commo
 						SchemaProps: spec.SchemaProps{
 							Description: "Spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Status communicates the observed state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportStatus"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportSpec", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_APIExportEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "APIExportEndpoint contains the endpoint information of an APIExport service for a specific shard.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"url": {
-						SchemaProps: spec.SchemaProps{
-							Description: "url is an APIExport virtual workspace URL.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-				},
-				Required: []string{"url"},
-			},
-		},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportSpec", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSlice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportEndpointSlice is a sink for the endpoints of an APIExport. These endpoints can be filtered by a Partition. They get consumed by the managers to start controllers and informers for the respective APIExport services.",
+				Description: "APIExportConsumerSummary summarizes the APIBindings of one shard that reference the APIExports of one logical cluster. It lives in the logical cluster of the APIExports, is named after the shard and is written by that shard.\n\nAPIExportConsumerSummaries only exist in the cache server. They let the shard of an APIExport learn about consumers on other shards without replicating the APIBindings.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1393,31 +1624,24 @@ func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSlice(ref common.ReferenceCa
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "spec holds the desired state: - the targetted APIExport - an optional partition for filtering",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceSpec"),
-						},
-					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Description: "status communicates the observed state: the filtered list of endpoints for the APIExport service.",
+							Description: "Spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceStatus"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummarySpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceSpec", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSliceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummarySpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummaryList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportEndpointSliceList is a list of APIExportEndpointSlice resources",
+				Description: "APIExportConsumerSummaryList is a list of APIExportConsumerSummary resources",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1447,7 +1671,7 @@ func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref common.Referen
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSlice"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummary"),
 									},
 								},
 							},
@@ -1458,257 +1682,223 @@ func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpointSlice", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumerSummary", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportConsumerSummarySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportEndpointSliceSpec defines the desired state of the APIExportEndpointSlice.",
+				Description: "APIExportConsumerSummarySpec holds the consumers of the APIExports of a logical cluster on a shard.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"export": {
-						SchemaProps: spec.SchemaProps{
-							Description: "export points to the API export.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ExportBindingReference"),
-						},
-					},
-					"partition": {
+					"shard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "partition (optional) points to a partition that is used for filtering the endpoints of the APIExport part of the slice.",
+							Description: "shard is the name of the shard the summarized APIBindings live on.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"export"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ExportBindingReference"},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "APIExportEndpointSliceStatus defines the observed state of APIExportEndpointSlice.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"conditions": {
-						SchemaProps: spec.SchemaProps{
-							Description: "conditions is a list of conditions that apply to the APIExportEndpointSlice.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
-									},
+					"apiExports": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
 								},
+								"x-kubernetes-list-type": "map",
 							},
 						},
-					},
-					"endpoints": {
 						SchemaProps: spec.SchemaProps{
-							Description: "endpoints contains all the URLs of the APIExport service.",
+							Description: "apiExports lists the consumers per APIExport. APIExports without APIBindings on the shard are omitted.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpoint"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportShardConsumers"),
 									},
 								},
 							},
 						},
 					},
 				},
+				Required: []string{"shard"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExportEndpoint", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportShardConsumers"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportConsumers(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportList is a list of APIExport resources",
+				Description: "APIExportConsumers summarizes the APIBindings referencing an APIExport.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"apiBindings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "apiBindings is the number of APIBindings referencing this APIExport.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"apiVersion": {
+					"readyAPIBindings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "readyAPIBindings is the number of APIBindings referencing this APIExport that are Ready.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+					"boundSchemas": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
 						},
-					},
-					"items": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "boundSchemas is the distribution of the APIResourceSchemas bound by the APIBindings, i.e. how far a schema rollout has propagated.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExport"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundSchemaCount"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"metadata", "items"},
+				Required: []string{"apiBindings", "readyAPIBindings"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIExport", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundSchemaCount"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportDeprecation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportSpec defines the desired state of APIExport.",
+				Description: "APIExportDeprecation describes the deprecation of an APIExport.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"latestResourceSchemas": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
-							},
-						},
-						SchemaProps: spec.SchemaProps{
-							Description: "latestResourceSchemas records the latest APIResourceSchemas that are exposed with this APIExport.\n\nThe schemas can be changed in the life-cycle of the APIExport. These changes have no effect on existing APIBindings, but only on newly bound ones.\n\nFor updating existing APIBindings, use an APIDeployment keeping bound workspaces up-to-date.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"identity": {
+					"deprecated": {
 						SchemaProps: spec.SchemaProps{
-							Description: "identity points to a secret that contains the API identity in the 'key' file. The API identity determines an unique etcd prefix for objects stored via this APIExport.\n\nDifferent APIExport in a workspace can share a common identity, or have different ones. The identity (the secret) can also be transferred to another workspace when the APIExport is moved.\n\nThe identity is a secret of the API provider. The APIBindings referencing this APIExport will store a derived, non-sensitive value of this identity.\n\nThe identity of an APIExport cannot be changed. A derived, non-sensitive value of the identity key is stored in the APIExport status and this value is immutable.\n\nThe identity is defaulted. A secret with the name of the APIExport is automatically created.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity"),
+							Description: "deprecated marks the APIExport as deprecated.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"maximalPermissionPolicy": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "maximalPermissionPolicy will allow for a service provider to set an upper bound on what is allowed for a consumer of this API. If the policy is not set, no upper bound is applied, i.e the consuming users can do whatever the user workspace allows the user to do.\n\nThe policy consists of RBAC (Cluster)Roles and (Cluster)Bindings. A request of a user in a workspace that binds to this APIExport via an APIBinding is additionally checked against these rules, with the user name and the groups prefixed with `apis.kcp.io:binding:`.\n\nFor example: assume a user `adam` with groups `system:authenticated` and `a-team` binds to this APIExport in another workspace root:org:ws. Then a request in that workspace against a resource of this APIExport is authorized as every other request in that workspace, but in addition the RBAC policy here in the APIExport workspace has to grant access to the user `apis.kcp.io:binding:adam` with the groups `apis.kcp.io:binding:system:authenticated` and `apis.kcp.io:binding:a-team`.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.MaximalPermissionPolicy"),
+							Description: "message is shown to consumers of the APIExport, e.g. to point to a replacement.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"permissionClaims": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"group",
-									"resource",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+					"sunsetTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "permissionClaims make resources available in APIExport's virtual workspace that are not part of the actual APIExport resources.\n\nPermissionClaims are optional and should be the least access necessary to complete the functions that the service provider needs. Access is asked for on a GroupResource + identity basis.\n\nPermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims change, the respecting objects are not visible immediately.\n\nPermissionClaims overlapping with the APIExport resources are ignored.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"),
-									},
-								},
-							},
+							Description: "sunsetTime is the time after which the APIExport is expected to be removed. From then on, no new APIBindings to the APIExport can be created.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"deprecated"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.Identity", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.MaximalPermissionPolicy", "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.PermissionClaim"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIExportStatus defines the observed state of APIExport.",
+				Description: "APIExportEndpoint contains the endpoint information of an APIExport service for a specific shard.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"identityHash": {
+					"url": {
 						SchemaProps: spec.SchemaProps{
-							Description: "identityHash is the hash of the API identity key of this APIExport. This value is immutable as soon as it is set.",
+							Description: "url is an APIExport virtual workspace URL. Clients only knowing about URLs can keep using this field.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"conditions": {
+					"id": {
 						SchemaProps: spec.SchemaProps{
-							Description: "conditions is a list of conditions that apply to the APIExport.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
-									},
-								},
-							},
+							Description: "id is a stable identifier of the endpoint. It is the UID of the shard, i.e. it does not change when the URL of the shard changes, but when the shard is recreated.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"virtualWorkspaces": {
+					"shard": {
 						SchemaProps: spec.SchemaProps{
-							Description: "virtualWorkspaces contains all APIExport virtual workspace URLs.\n\nDeprecated: use APIExportEndpointSlice.status.endpoints instead",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace"),
-									},
-								},
-							},
+							Description: "shard is the name of the shard serving this endpoint.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "region is the value of the topology.kcp.io/region label of the shard, if set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dnsName is a stable DNS name of the endpoint, rendered from the shard name and the base domain configured for kcp. It is only set if configured. External load balancers and service meshes can target the endpoint by this name instead of parsing the url.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"servingSince": {
+						SchemaProps: spec.SchemaProps{
+							Description: "servingSince is the time the endpoint was added to the slice.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "state is the result of the last health probe of the endpoint. It is only set if endpoint probing is enabled. Consumers can skip Unready endpoints.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastProbeTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastProbeTime is the time of the health probe that first observed the current state of the endpoint. Later probes observing the same state do not update it.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"url"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSlice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIResourceSchema describes a resource, identified by (group, version, resource, schema).\n\nA APIResourceSchema is immutable and cannot be deleted if they are referenced by an APIExport in the same workspace.",
+				Description: "APIExportEndpointSlice is a sink for the endpoints of an APIExport. These endpoints can be filtered by a Partition. They get consumed by the managers to start controllers and informers for the respective APIExport services.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1733,24 +1923,31 @@ func schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref common.ReferenceCallbac
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec holds the desired state.",
+							Description: "spec holds the desired state: - the targetted APIExport - an optional partition for filtering",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "status communicates the observed state: the filtered list of endpoints for the APIExport service.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchemaSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceSpec", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSliceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIResourceSchemaList is a list of APIResourceSchema resources",
+				Description: "APIExportEndpointSliceList is a list of APIExportEndpointSlice resources",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -1780,7 +1977,7 @@ func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref common.ReferenceCal
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchema"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSlice"),
 									},
 								},
 							},
@@ -1791,248 +1988,3588 @@ func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref common.ReferenceCal
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceSchema", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpointSlice", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIResourceSchemaSpec defines the desired state of APIResourceSchema.",
+				Description: "APIExportEndpointSliceSpec defines the desired state of the APIExportEndpointSlice.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"group": {
+					"export": {
 						SchemaProps: spec.SchemaProps{
-							Description: "group is the API group of the defined custom resource. Empty string means the core API group. \tThe resources are served under `/apis/<group>/...` or `/api` for the core group.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "export points to the API export.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference"),
 						},
 					},
-					"names": {
+					"partition": {
 						SchemaProps: spec.SchemaProps{
-							Description: "names specify the resource and kind names for the custom resource.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceDefinitionNames"),
+							Description: "partition (optional) points to a partition in the same workspace that is used for filtering the endpoints of the APIExport part of the slice. Only shards matching the selector of the partition get an endpoint.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"scope": {
+					"orphanPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "scope indicates whether the defined custom resource is cluster- or namespace-scoped. Allowed values are `Cluster` and `Namespaced`.",
-							Default:     "",
+							Description: "orphanPolicy decides what happens to the slice once the referenced APIExport has been gone for longer than orphanGracePeriod. With Retain, the slice is kept and marked with the Orphaned condition. With Delete, the slice is deleted.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"versions": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"name",
+					"orphanGracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "orphanGracePeriod is the time the referenced APIExport must be gone before orphanPolicy applies. It defaults to one hour, such that recreating an APIExport does not lose its slices.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"export"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportEndpointSliceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportEndpointSliceStatus defines the observed state of APIExportEndpointSlice.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of conditions that apply to the APIExportEndpointSlice.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
 								},
-								"x-kubernetes-list-type": "map",
 							},
 						},
+					},
+					"endpoints": {
 						SchemaProps: spec.SchemaProps{
-							Description: "versions is the API version of the defined custom resource.\n\nNote: the OpenAPI v3 schemas must be equal for all versions until CEL\n      version migration is supported.",
+							Description: "endpoints contains one endpoint of the APIExport service per shard, sorted by shard name. Shards sharing a virtual workspace URL are represented by the first of them, such that every URL is listed once.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceVersion"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpoint"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"group", "names", "scope", "versions"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.APIResourceVersion", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceDefinitionNames"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportEndpoint", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_APIResourceVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "APIResourceVersion describes one API version of a resource.",
+				Description: "APIExportList is a list of APIExport resources",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "name is the version name, e.g. “v1”, “v2beta1”, etc. The custom resources are served under this version at `/apis/<group>/<version>/...` if `served` is true.",
-							Default:     "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"served": {
-						SchemaProps: spec.SchemaProps{
-							Description: "served is a flag enabling/disabling this version from being served via REST APIs",
-							Default:     false,
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"storage": {
-						SchemaProps: spec.SchemaProps{
-							Description: "storage indicates this version should be used when persisting custom resources to storage. There must be exactly one version with storage=true.",
-							Default:     false,
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"deprecated": {
-						SchemaProps: spec.SchemaProps{
-							Description: "deprecated indicates this version of the custom resource API is deprecated. When set to true, API requests to this version receive a warning header in the server response. Defaults to false.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"deprecationWarning": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "deprecationWarning overrides the default warning returned to API clients. May only be set when `deprecated` is true. The default warning indicates this version is deprecated and recommends use of the newest served version of equal or greater stability, if one exists.",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schema": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-map-type": "atomic",
-							},
-						},
-						SchemaProps: spec.SchemaProps{
-							Description: "schema describes the structural schema used for validation, pruning, and defaulting of this version of the custom resource.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
-						},
-					},
-					"subresources": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "subresources specify what subresources this version of the defined custom resource have.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceSubresources"),
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
 					},
-					"additionalPrinterColumns": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"name",
-								},
-								"x-kubernetes-list-type": "map",
-							},
-						},
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "additionalPrinterColumns specifies additional columns returned in Table output. See https://kubernetes.io/docs/reference/using-api/api-concepts/#receiving-resources-as-tables for details. If no columns are specified, a single column displaying the age of the custom resource is used.",
-							Type:        []string{"array"},
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceColumnDefinition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExport"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"name", "served", "storage", "schema"},
+				Required: []string{"metadata", "items"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceColumnDefinition", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceSubresources", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExport", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_apis_v1alpha1_APIExportMigration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "AcceptablePermissionClaim is a PermissionClaim that records if the user accepts or rejects it.",
+				Description: "APIExportMigration describes the migration of the consumers of an APIExport to another APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "target is the APIExport the consumers are moved to. It must have the same identity as this APIExport. If the path is unset, the logical cluster of this APIExport is used.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference"),
+						},
+					},
+					"batchSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "batchSize is the maximal number of APIBindings repointed to the target at a time.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retireAfter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "retireAfter is the time this APIExport keeps serving its virtual workspace after all APIBindings have been migrated, for the API provider to switch its controllers to the target. Afterwards the virtual workspace of this APIExport stops serving.\n\nAPIBindings are only repointed if the owner of their workspace is allowed to bind the target.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"target"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportMigrationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportMigrationStatus is the progress of the migration of the consumers of an APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"migratedAPIBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "migratedAPIBindings is the number of APIBindings currently referencing the target APIExport that were repointed from this APIExport.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"remainingAPIBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "remainingAPIBindings is the number of APIBindings still referencing this APIExport.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "completionTime is the time all APIBindings were found to be migrated. The APIExport is retired spec.migration.retireAfter later.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"migratedAPIBindings", "remainingAPIBindings"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportRelease(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportRelease is a named snapshot of the APIResourceSchemas of an APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the semantic version of the release, e.g. v1.2.0.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceSchemas": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceSchemas are the APIResourceSchemas of the release, in the same format as latestResourceSchemas.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"version", "resourceSchemas"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportShardConsumers(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportShardConsumers are the consumers of an APIExport on a single shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "apiBindings is the number of APIBindings referencing this APIExport.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyAPIBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "readyAPIBindings is the number of APIBindings referencing this APIExport that are Ready.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"boundSchemas": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "boundSchemas is the distribution of the APIResourceSchemas bound by the APIBindings, i.e. how far a schema rollout has propagated.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundSchemaCount"),
+									},
+								},
+							},
+						},
+					},
+					"migratedAPIBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "migratedAPIBindings is the number of APIBindings on the shard which spec.migration of the APIExport has repointed to its target.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name", "apiBindings", "readyAPIBindings"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundSchemaCount"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportSpec defines the desired state of APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"latestResourceSchemas": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "latestResourceSchemas records the latest APIResourceSchemas that are exposed with this APIExport.\n\nThe schemas can be changed in the life-cycle of the APIExport. These changes have no effect on existing APIBindings, but only on newly bound ones.\n\nFor updating existing APIBindings, use an APIDeployment keeping bound workspaces up-to-date.\n\nMultiple APIResourceSchemas can be listed for the same resource, each contributing different versions of it. They must agree on names and scope. The versions are merged into one multi-version API, with the served and storage flags taken from the schemas unless overridden in resourceVersions.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resourceVersions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"group",
+									"resource",
+									"version",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceVersions overrides the served and storage flags of versions of the exported resources. Versions not listed here keep the flags of their APIResourceSchema. If a version of a resource is marked as storage here, all other versions of that resource are not storage.\n\nAPIResourceSchemas are immutable. With resourceVersions, a new version can be added and served first, and later be promoted to storage without publishing new schemas.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportedResourceVersion"),
+									},
+								},
+							},
+						},
+					},
+					"releases": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"version",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "releases are named snapshots of APIResourceSchemas under a semantic version. APIBindings referencing a release bind its APIResourceSchemas instead of latestResourceSchemas, such that consumers get a reproducible API independent of the evolution of the APIExport.\n\nReleases are immutable, but can be removed. APIBindings of a removed release move on to the next newer release, or to latestResourceSchemas. The served and storage flags of the versions of the resources are taken from the APIResourceSchemas, resourceVersions is not applied.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportRelease"),
+									},
+								},
+							},
+						},
+					},
+					"identity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identity points to a secret that contains the API identity in the 'key' file. The API identity determines an unique etcd prefix for objects stored via this APIExport.\n\nDifferent APIExport in a workspace can share a common identity, or have different ones. The identity (the secret) can also be transferred to another workspace when the APIExport is moved.\n\nThe identity is a secret of the API provider. The APIBindings referencing this APIExport will store a derived, non-sensitive value of this identity.\n\nThe identity of an APIExport cannot be changed. A derived, non-sensitive value of the identity key is stored in the APIExport status and this value is immutable.\n\nThe identity is defaulted. A secret with the name of the APIExport is automatically created.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.Identity"),
+						},
+					},
+					"maximalPermissionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maximalPermissionPolicy will allow for a service provider to set an upper bound on what is allowed for a consumer of this API. If the policy is not set, no upper bound is applied, i.e the consuming users can do whatever the user workspace allows the user to do.\n\nThe policy consists of RBAC (Cluster)Roles and (Cluster)Bindings. A request of a user in a workspace that binds to this APIExport via an APIBinding is additionally checked against these rules, with the user name and the groups prefixed with `apis.kcp.io:binding:`.\n\nFor example: assume a user `adam` with groups `system:authenticated` and `a-team` binds to this APIExport in another workspace root:org:ws. Then a request in that workspace against a resource of this APIExport is authorized as every other request in that workspace, but in addition the RBAC policy here in the APIExport workspace has to grant access to the user `apis.kcp.io:binding:adam` with the groups `apis.kcp.io:binding:system:authenticated` and `apis.kcp.io:binding:a-team`.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.MaximalPermissionPolicy"),
+						},
+					},
+					"permissionClaims": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"group",
+									"resource",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "permissionClaims make resources available in APIExport's virtual workspace that are not part of the actual APIExport resources.\n\nPermissionClaims are optional and should be the least access necessary to complete the functions that the service provider needs. Access is asked for on a GroupResource + identity basis.\n\nPermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims change, the respecting objects are not visible immediately.\n\nPermissionClaims overlapping with the APIExport resources are ignored.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim"),
+									},
+								},
+							},
+						},
+					},
+					"deprecation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "deprecation marks the APIExport as deprecated. Consumers are informed through a condition and an annotation on their APIBindings, and through warnings on requests against the bound resources.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportDeprecation"),
+						},
+					},
+					"migration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "migration moves the consumers of this APIExport to another APIExport with the same identity, e.g. when the API provider moves to a different workspace. While migrating, both APIExports serve the API, the APIBindings on all shards are repointed to the target in batches, and no new APIBindings to this APIExport are admitted. Once all APIBindings are migrated, this APIExport keeps serving for spec.migration.retireAfter and is retired afterwards.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigration"),
+						},
+					},
+					"customSubresourceHandler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "customSubresourceHandler serves the custom subresources declared in the exported APIResourceSchemas. Requests of consumers are forwarded to `<url>/clusters/<consumer logical cluster>/<request path>`, with the user in the X-Remote-User, X-Remote-Group and X-Remote-Extra-* headers.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresourceHandler"),
+						},
+					},
+					"categories": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "categories are kubectl categories added to all resources of this APIExport when they are bound, in addition to the categories of the APIResourceSchemas. API providers sharing a category name let consumers list the resources of all their APIs with `kubectl get <category>`.\n\nThe categories are applied when the APIResourceSchema of a resource is bound for the first time. Later changes do not affect resources already bound.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportDeprecation", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigration", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportRelease", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresourceHandler", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportedResourceVersion", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.Identity", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.MaximalPermissionPolicy", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.PermissionClaim"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIExportStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportStatus defines the observed state of APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityHash is the hash of the API identity key of this APIExport. This value is immutable as soon as it is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"identityHashAlgorithm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityHashAlgorithm is the hash algorithm of identityHash, e.g. sha256. It is recorded together with the first identityHash and is immutable. If it is empty, the identity is hashed with sha256.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of conditions that apply to the APIExport.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"virtualWorkspaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "virtualWorkspaces contains all APIExport virtual workspace URLs.\n\nDeprecated: use APIExportEndpointSlice.status.endpoints instead",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.VirtualWorkspace"),
+									},
+								},
+							},
+						},
+					},
+					"consumers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "consumers summarizes the APIBindings referencing this APIExport on all shards. The shards publish their APIBindings with a delay, hence the numbers lag behind a bit.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumers"),
+						},
+					},
+					"migration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "migration is the progress of spec.migration on all shards.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigrationStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportConsumers", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIExportMigrationStatus", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIResourceSchema(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIResourceSchema describes a resource, identified by (group, version, resource, schema).\n\nA APIResourceSchema is immutable and cannot be deleted if they are referenced by an APIExport in the same workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec holds the desired state.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status communicates the observed state.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaSpec", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchemaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIResourceSchemaList is a list of APIResourceSchema resources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchema"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceSchema", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIResourceSchemaSpec defines the desired state of APIResourceSchema.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the API group of the defined custom resource. Empty string means the core API group. \tThe resources are served under `/apis/<group>/...` or `/api` for the core group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"names": {
+						SchemaProps: spec.SchemaProps{
+							Description: "names specify the resource and kind names for the custom resource.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceDefinitionNames"),
+						},
+					},
+					"scope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "scope indicates whether the defined custom resource is cluster- or namespace-scoped. Allowed values are `Cluster` and `Namespaced`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"versions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "versions is the API version of the defined custom resource.\n\nNote: the OpenAPI v3 schemas must be equal for all versions until CEL\n      version migration is supported.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceVersion"),
+									},
+								},
+							},
+						},
+					},
+					"conversions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "conversions declares how custom resources are converted between versions. Bound resources of a schema with conversions are converted by kcp, without a conversion webhook hosted by the provider. Between versions without a conversion, only the apiVersion is changed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIVersionConversion"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"group", "names", "scope", "versions"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIResourceVersion", "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIVersionConversion", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceDefinitionNames"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIResourceSchemaStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIResourceSchemaStatus defines the observed state of APIResourceSchema.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of conditions that apply to the APIResourceSchema.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIResourceVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIResourceVersion describes one API version of a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the version name, e.g. “v1”, “v2beta1”, etc. The custom resources are served under this version at `/apis/<group>/<version>/...` if `served` is true.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"served": {
+						SchemaProps: spec.SchemaProps{
+							Description: "served is a flag enabling/disabling this version from being served via REST APIs",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "storage indicates this version should be used when persisting custom resources to storage. There must be exactly one version with storage=true.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"deprecated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "deprecated indicates this version of the custom resource API is deprecated. When set to true, API requests to this version receive a warning header in the server response. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"deprecationWarning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "deprecationWarning overrides the default warning returned to API clients. May only be set when `deprecated` is true. The default warning indicates this version is deprecated and recommends use of the newest served version of equal or greater stability, if one exists.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schema": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-map-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "schema describes the structural schema used for validation, pruning, and defaulting of this version of the custom resource. CEL validation rules (x-kubernetes-validations) are compiled and checked against the cost budget on creation, and enforced for all bound resources. Defaults must be pruned and valid against the schema, like in CRDs, and are applied to all bound resources.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"subresources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "subresources specify what subresources this version of the defined custom resource have.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceSubresources"),
+						},
+					},
+					"additionalPrinterColumns": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "additionalPrinterColumns specifies additional columns returned in Table output. See https://kubernetes.io/docs/reference/using-api/api-concepts/#receiving-resources-as-tables for details. If no columns are specified, a single column displaying the age of the custom resource is used.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceColumnDefinition"),
+									},
+								},
+							},
+						},
+					},
+					"customSubresources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "customSubresources declares subresources in addition to status and scale, e.g. approve or bind. Requests of consumers to them are authorized like requests to other subresources, and then forwarded to spec.customSubresourceHandler of the APIExport. Custom subresources are not published in discovery.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "served", "storage", "schema"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.CustomSubresource", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceColumnDefinition", "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.CustomResourceSubresources", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_APIVersionConversion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIVersionConversion declares the conversion of custom resources from one version to another.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "from is the version the custom resources are converted from.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "to is the version the custom resources are converted to.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rules": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "rules are applied to the custom resources converted. Fields not mentioned in any rule are kept unchanged.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIConversionRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"from", "to"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.APIConversionRule"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_AcceptablePermissionClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AcceptablePermissionClaim is a PermissionClaim that records if the user accepts or rejects it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"all": {
+						SchemaProps: spec.SchemaProps{
+							Description: "all claims all resources for the given group/resource. This is mutually exclusive with resourceSelector.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceSelector is a list of claimed resource selectors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ResourceSelector"),
+									},
+								},
+							},
+						},
+					},
+					"verbs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "verbs restricts the claim to the given verbs, e.g. get, list and watch for read-only access. If empty, all verbs are claimed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This is the identity for a given APIExport that the APIResourceSchema belongs to. The hash can be found on APIExport and APIResourceSchema's status. It will be empty for core types. Note that one must look this up for a particular KCP instance.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"state"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ResourceSelector"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_BindingReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindingReference describes a reference to an APIExport. Exactly one of the fields must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"export": {
+						SchemaProps: spec.SchemaProps{
+							Description: "export is a reference to an APIExport by cluster name and export name. The creator of the APIBinding needs to have access to the APIExport with the verb `bind` in order to bind to it.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ExportBindingReference"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_BoundAPIExport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundAPIExport identifies the APIExport an APIBinding is bound to by logical cluster and name.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cluster is the logical cluster name of the bound APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the bound APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundAPIResource describes a bound GroupVersionResource through an APIResourceSchema of an APIExport..",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the group of the bound API. Empty string for the core API group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the resource of the bound API.\n\nkubebuilder:validation:MinLength=1",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schema": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schema references the APIResourceSchema that is bound to this API.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResourceSchema"),
+						},
+					},
+					"storageVersions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "storageVersions lists all versions of a resource that were ever persisted. Tracking these versions allows a migration path for stored versions in etcd. The field is mutable so a migration controller can finish a migration to another version (ensuring no old objects are left in storage), and then remove the rest of the versions from this list.\n\nVersions may not be removed while they exist in this list.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"group", "resource", "schema"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.BoundAPIResourceSchema"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_BoundAPIResourceSchema(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundAPIResourceSchema is a reference to an APIResourceSchema.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the bound APIResourceSchema name.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"UID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UID is the UID of the APIResourceSchema that is bound to this API.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityHash is the hash of the API identity that this schema is bound to. The API identity determines the etcd prefix used to persist the object. Different identity means that the objects are effectively served and stored under a distinct resource. A CRD of the same GroupVersionResource uses a different identity and hence a separate etcd prefix.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "UID", "identityHash"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_BoundSchemaCount(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundSchemaCount is the number of APIBindings binding an APIResourceSchema.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the APIResourceSchema.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "apiBindings is the number of APIBindings binding the APIResourceSchema.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name", "apiBindings"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_CustomSubresource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomSubresource is a subresource served by the provider of an APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the subresource, served under `.../<resource>/<object name>/<name>`. It must be a DNS label, and must not be status or scale.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_CustomSubresourceHandler(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomSubresourceHandler describes the endpoint of the API provider serving custom subresources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "url is the https URL of the handler, without query and fragment.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"caBundle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "caBundle is a PEM encoded CA bundle used to verify the serving certificate of the handler. If unset, the system trust roots are used.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_ExportBindingReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExportBindingReference is a reference to an APIExport by cluster and name.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is a logical cluster path where the APIExport is defined. If the path is unset, the logical cluster of the APIBinding is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the APIExport that describes the API.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_ExportedResourceVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExportedResourceVersion sets the served and storage flags of a version of an exported resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the name of the version, e.g. \"v1\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"served": {
+						SchemaProps: spec.SchemaProps{
+							Description: "served is a flag enabling/disabling this version from being served via REST APIs.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "storage indicates this version should be used when persisting objects to storage. At most one version of a resource can be marked as storage.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version", "served"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_GroupResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GroupResource identifies a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the name of an API group. For core groups this is the empty string '\"\"'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the name of the resource. Note: it is worth noting that you can not ask for permissions for resource provided by a CRD not provided by an api export.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_Identity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Identity defines the identity of an APIExport, i.e. determines the etcd prefix data of this APIExport are stored under.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "secretRef is a reference to a secret that contains the API identity in the 'key' file.",
+							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SecretReference"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_LocalAPIExportPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocalAPIExportPolicy is a maximal permission policy that checks RBAC in the workspace of the API Export.\n\nIn order to avoid conflicts the user and group name will be prefixed with \"apis.kcp.io:binding:\".",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_MaximalPermissionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MaximalPermissionPolicy is a wrapper type around the multiple options that would be allowed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"local": {
+						SchemaProps: spec.SchemaProps{
+							Description: "local is the policy that is defined in same workspace as the API Export.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.LocalAPIExportPolicy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.LocalAPIExportPolicy"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_ObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ObjectReference is a reference to an object in the same or in another workspace. It is meant to be embedded by APIs that point to objects across workspaces, such that they all share the same path semantics. Use pkg/objectreference to validate and resolve it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is a logical cluster path where the object lives. If the path is unset, the logical cluster of the referencing object is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the API group of the object. The empty group is the core group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the API version of the object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the lower-case plural resource name of the object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespace is the namespace of the object. It is empty for cluster-scoped objects.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version", "resource", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PermissionClaim identifies an object by GR and identity hash. Its purpose is to determine the added permissions that a service provider may request and that a consumer may accept and allow the service provider access to.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"all": {
 						SchemaProps: spec.SchemaProps{
-							Description: "all claims all resources for the given group/resource. This is mutually exclusive with resourceSelector.",
-							Type:        []string{"boolean"},
+							Description: "all claims all resources for the given group/resource. This is mutually exclusive with resourceSelector.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceSelector is a list of claimed resource selectors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ResourceSelector"),
+									},
+								},
+							},
+						},
+					},
+					"verbs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "verbs restricts the claim to the given verbs, e.g. get, list and watch for read-only access. If empty, all verbs are claimed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This is the identity for a given APIExport that the APIResourceSchema belongs to. The hash can be found on APIExport and APIResourceSchema's status. It will be empty for core types. Note that one must look this up for a particular KCP instance.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1.ResourceSelector"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_ResourceSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name of an object within a claimed group/resource. It matches the metadata.name field of the underlying object. If namespace is unset, all objects matching that name will be claimed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespace containing the named object. Matches metadata.namespace field. If \"name\" is unset, all objects from the namespace are being claimed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector selects the namespaces of claimed objects by their labels. Objects in namespaces not matching the selector are not claimed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "url is an APIExport virtual workspace URL.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_LogicalCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogicalCluster describes the current logical cluster. It is used to authorize requests to the logical cluster and to track state.\n\nA LogicalCluster is always named \"cluster\".",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterSpec", "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogicalClusterList is a list of LogicalCluster",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalCluster"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalCluster", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_LogicalClusterOwner(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogicalClusterOwner is a reference to a resource controlling the life-cycle of a LogicalCluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "apiVersion is the group and API version of the owner.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is API resource to access the owner.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the owner.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespace is the optional namespace of the owner.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cluster is the logical cluster in which the owner is located.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UID is the UID of the owner.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"apiVersion", "resource", "name", "cluster", "uid"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_LogicalClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogicalClusterSpec is the specification of the LogicalCluster resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"directlyDeletable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DirectlyDeletable indicates that this logical cluster can be directly deleted by the user from within by deleting the LogicalCluster object.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "owner is a reference to a resource controlling the life-cycle of this logical cluster. On deletion of the LogicalCluster, the finalizer core.kcp.io/logicalcluster is removed from the owner.\n\nWhen this object is deleted, but the owner is not deleted, the owner is deleted too.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterOwner"),
+						},
+					},
+					"initializers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "initializers are set on creation by the system and copied to status when initialization starts.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.LogicalClusterOwner"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_LogicalClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogicalClusterStatus communicates the observed state of the Workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"URL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "url is the address under which the Kubernetes-cluster-like endpoint can be found. This URL can be used to access the logical cluster with standard Kubernetes client libraries and command line tools.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the logical cluster (Initializing, Ready).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current processing state of the LogicalCluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"initializers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "initializers are set on creation by the system and must be cleared by a controller before the logical cluster can be used. The LogicalCluster object will stay in the phase \"Initializing\" state until all initializers are cleared.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_Shard(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Shard describes a kcp instance on which a number of logical clusters will live",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardSpec", "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardConfiguration overrides the configuration of a shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"featureGates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "featureGates enables or disables feature gates of the shard by name, e.g. KCPStatusProvenance: true. Changes are applied when the shard restarts.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: false,
+										Type:    []string{"boolean"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"flags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "flags sets flags of the shard by name without leading dashes, e.g. load-shedding-memory-threshold: 6Gi. Only the flags of the sections of the configuration file and the committer flags can be set. The load shedding thresholds and the committer flags are applied at runtime, other changes when the shard restarts. Lists are comma separated.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardControllerOverride(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardControllerOverride overrides an embedded controller of a shard at runtime.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the controller, e.g. kcp-apiexport-extra-annotation-sync.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"disabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "disabled stops the controller from processing its queue. Queued work is processed when the controller is enabled again.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"workers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "workers is the number of concurrent workers of the controller. It defaults to the number of workers the controller is started with.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardLease(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardLease is the liveness heartbeat of a shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"renewTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "renewTime is the time the shard renewed the lease last.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"leaseDurationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "leaseDurationSeconds is the duration after renewTime the lease expires unless it is renewed.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"renewTime", "leaseDurationSeconds"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardList is a list of shard instances",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.Shard"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.Shard", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardSpec holds the desired state of the Shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"baseURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "baseURL is the address of the KCP shard for direct connections, e.g. by some front-proxy doing the fan-out to the shards.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "externalURL is the externally visible address presented to users in Workspace URLs. Changing this will break all existing logical clusters on that shard, i.e. existing kubeconfigs of clients will be invalid. Hence, when changing this value, the old URL used by clients must keep working.\n\nThe external address will not be unique if a front-proxy does a fan-out to shards, but all logical cluster clients will talk to the front-proxy. In that case, put the address of the front-proxy here.\n\nNote that movement of shards is only possible (in the future) between shards that share a common external URL.\n\nThis will be defaulted to the value of the baseURL.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"virtualWorkspaceURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "virtualWorkspaceURL is the address of the virtual workspace apiserver associated with this shard. It can be a direct address, an address of a front-proxy or even an address of an LB. As of today this address is assigned to APIExports.\n\nThis will be defaulted to the value of the baseURL.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cordoned": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cordoned stops the scheduling of new workspaces to the shard. Existing workspaces are not affected.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"draining": {
+						SchemaProps: spec.SchemaProps{
+							Description: "draining stops the scheduling of new workspaces to the shard like cordoned, and marks the workspaces on it with the ShardDraining condition. Workspaces cannot be moved between shards yet, so the condition tells their owners that the shard is about to go down for maintenance.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"controllers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "controllers overrides embedded controllers of the shard at runtime, e.g. to stop a misbehaving controller without restarting the shard. Controllers which are not listed run as configured on the command line. Overrides of controllers which the shard does not run are reported in the ControllersApplied condition.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardControllerOverride"),
+									},
+								},
+							},
+						},
+					},
+					"configuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "configuration overrides feature gates and flags of the shard, e.g. to canary them on a subset of shards. It takes precedence over the command line and the configuration file of the shard. Reloadable flags are applied at runtime, everything else when the shard starts.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardConfiguration"),
+						},
+					},
+				},
+				Required: []string{"baseURL"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardConfiguration", "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardControllerOverride"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardStatus communicates the observed state of the Shard.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set of integer resources that logical clusters can be scheduled into",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current processing state of the Shard.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "usage is the utilization of the shard, reported by the shard itself in a regular heartbeat. The workspace scheduler spreads workspaces across shards by their usage relative to their capacity.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardUsage"),
+						},
+					},
+					"lease": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lease is renewed by the shard itself in a regular heartbeat. The shard is considered down when the lease expires.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardLease"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardLease", "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1.ShardUsage", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ShardUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ShardUsage is the utilization of a shard at its last heartbeat.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"logicalClusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "logicalClusters is the number of logical clusters on the shard.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"etcdDatabaseSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "etcdDatabaseSize is the size of the etcd database of the shard. It is not set if the size could not be determined.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"requestsPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "requestsPerSecond is the average rate of requests served by the shard since the previous heartbeat.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastHeartbeatTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastHeartbeatTime is the time the shard reported this usage.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"logicalClusters", "requestsPerSecond", "lastHeartbeatTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_AvailableSelectorLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AvailableSelectorLabel specifies a label with key name and possible values.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "key is the name of the label.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"values": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "values are the possible values for this labels.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "description is a human readable description of the label.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key", "values"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_GroupVersionResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GroupVersionResource unambiguously identifies a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the name of an API group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the version of the API.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the name of the resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_Location(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Location represents a set of instances of a scheduling resource type acting a target of scheduling.\n\nThe location is chosen by the user (in the future) through a Placement object, while the instance is chosen by the scheduler depending on considerations like load or available resources, or further node selectors specified by the user.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationSpec", "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_LocationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocationList is a list of locations.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Location"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Location", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_LocationReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocationReference describes a location that are provided in the specified Workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is an absolute reference to a workspace, e.g. root:org:ws. The workspace must be some ancestor or a child of some ancestor.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"locationName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the Location.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"path", "locationName"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_LocationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocationSpec holds the desired state of the Location.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the group-version-resource of the instances that are subject to this location.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.GroupVersionResource"),
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "description is a human-readable description of the location.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"availableSelectorLabels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"key",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "availableSelectorLabels is a list of labels that can be used to select an instance at this location in a placement object.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.AvailableSelectorLabel"),
+									},
+								},
+							},
+						},
+					},
+					"instanceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "instanceSelector chooses the instances that will be part of this location.\n\nNote that these labels are not what is shown in the Location objects to the user. Depending on context, both will match or won't match.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.AvailableSelectorLabel", "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.GroupVersionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_LocationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocationStatus defines the observed state of Location.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"instances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "instances is the number of actual instances at this location.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"availableInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "available is the number of actual instances that are available at this location.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_Placement(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Placement defines a selection rule to choose ONE location for MULTIPLE namespaces in a workspace.\n\nplacement is in Pending state initially. When a location is selected by the placement, the placement turns to Unbound state. In Pending or Unbound state, the selection rule can be updated to select another location. When the a namespace is annotated by another controller or user with the key of \"scheduling.kcp.io/placement\", the namespace will pick one placement, and this placement is transferred to Bound state. Any update to spec of the placement is ignored in Bound state and reflected in the conditions. The placement will turn back to Unbound state when no namespace uses this placement any more.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementSpec", "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.PlacementStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_PlacementList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PlacementList is a list of locations.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Placement"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.Placement", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_PlacementSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"locationSelectors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "locationSelectors represents a slice of label selector to select a location, these label selectors are logically ORed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+									},
+								},
+							},
+						},
+					},
+					"locationResource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "locationResource is the group-version-resource of the instances that are subject to the locations to select.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.GroupVersionResource"),
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector is a label selector to select ns. It match all ns by default, but can be specified to a certain set of ns.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"locationWorkspace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "locationWorkspace is an absolute reference to a workspace for the location. If it is not set, the workspace of APIBinding will be used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"locationResource"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.GroupVersionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_scheduling_v1alpha1_PlacementStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "phase is the current phase of the placement",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"selectedLocation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "selectedLocation is the location that a picked by this placement.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationReference"),
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current processing state of the Placement.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1.LocationReference", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_APIExportReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "APIExportReference provides the fields necessary to resolve an APIExport.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is the fully-qualified path to the workspace containing the APIExport. If it is empty, the current workspace is assumed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"export": {
+						SchemaProps: spec.SchemaProps{
+							Description: "export is the name of the APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"export"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_AcceptedPermissionClaimPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AcceptedPermissionClaimPolicy declares the permission claims of an APIExport that are accepted automatically.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"export": {
+						SchemaProps: spec.SchemaProps{
+							Description: "export references the APIExport whose permission claims are accepted.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.APIExportReference"),
+						},
+					},
+					"claims": {
+						SchemaProps: spec.SchemaProps{
+							Description: "claims are the claimed resources whose permission claims are accepted. Claims of the APIExport not listed here have to be accepted by the user.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ClaimedResource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"export", "claims"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.APIExportReference", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ClaimedResource"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_ClaimedResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClaimedResource identifies the resource of a permission claim.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the API group of the claimed resource. It is empty for the core group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the name of the claimed resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"identityHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "identityHash is the identity of the APIExport providing the claimed resource. It is empty for built-in resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJob rewrites all objects of a resource in the logical clusters of the shard it is stored on, e.g. to re-encrypt them with the current encryption key, to migrate them to the current storage version or to relabel them in bulk. It is the common execution engine of such bulk rewrites: the objects are processed in batches, logical cluster by logical cluster in the order of their names, with a checkpoint recorded in the status after every batch, such that the job resumes where it stopped after restarts of the shard. Writes are throttled by spec.throttle.\n\nDataJobs can only be created and changed by kcp admins.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobSpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobCheckpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobCheckpoint is a position within the objects of a DataJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"logicalCluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "logicalCluster is the logical cluster being processed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"continue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "continue is the continue token of the next batch of objects in the logical cluster, empty to start with its first batch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"logicalCluster"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobList is a list of data jobs.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJob"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJob", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobRelabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobRelabel describes the labels changed by the Relabel operation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"set": {
+						SchemaProps: spec.SchemaProps{
+							Description: "set are the labels set on every object, overriding existing values.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"remove": {
+						SchemaProps: spec.SchemaProps{
+							Description: "remove are the keys of the labels removed from every object.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobResource identifies a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the API group of the resource, empty for the core group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the API version the objects are read and written with.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the plural name of the resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobSpec describes the objects to process and how.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"operation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "operation is the operation applied to every object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resource is the resource whose objects are processed. Logical clusters not serving the resource are skipped.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobResource"),
+						},
+					},
+					"relabel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "relabel configures the Relabel operation.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobRelabel"),
+						},
+					},
+					"logicalClusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "logicalClusters restricts the job to the given logical clusters of the shard. By default, all logical clusters of the shard are processed, including those created while the job runs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"throttle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "throttle limits the load the job puts on the shard.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobThrottle"),
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "suspend stops the job at its last checkpoint until it is unset again.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"operation", "resource"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobRelabel", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobResource", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobThrottle"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobStatus communicates the progress of a DataJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "phase is the phase of the job.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"checkpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "checkpoint is the position the job resumes at. All logical clusters with names before the checkpoint's logical cluster are completed.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobCheckpoint"),
+						},
+					},
+					"logicalClusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "logicalClusters is the number of logical clusters covered by the job.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"completedLogicalClusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "completedLogicalClusters is the number of logical clusters whose objects have all been processed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"processedObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "processedObjects is the number of objects processed so far.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"failedObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "failedObjects is the number of objects that could not be written.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "message describes why the job failed, or the last object that could not be written.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "startTime is the time the job started processing objects.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"estimatedCompletionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "estimatedCompletionTime is extrapolated from the share of completed logical clusters.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "completionTime is the time the job succeeded or failed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DataJobCheckpoint", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DataJobThrottle(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DataJobThrottle limits the rate of a DataJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"writesPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "writesPerSecond is the maximum average number of objects written per second.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"batchSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "batchSize is the number of objects listed at once. A checkpoint is recorded after every batch.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_DefaultAPIBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DefaultAPIBinding is an APIExport bound during initialization of workspaces of a type.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is the fully-qualified path to the workspace containing the APIExport. If it is empty, the current workspace is assumed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"export": {
+						SchemaProps: spec.SchemaProps{
+							Description: "export is the name of the APIExport.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"releaseConstraint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "releaseConstraint restricts the releases of the APIExport to bind, as a space-separated list of comparisons with semantic versions that all have to hold, e.g. \">=v1.2.0 <v2.0.0\". The operators =, >, >=, < and <= are supported, a version without operator means =. The newest matching release is bound and recorded in spec.release of the APIBinding.\n\nIf unset, the APIBinding binds the latestResourceSchemas of the APIExport.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"upgradePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "upgradePolicy determines whether existing APIBindings move on to newer releases of the APIExport matching releaseConstraint. With \"Pinned\", the release bound during initialization is kept. With \"Automatic\", the APIBindings of all workspaces of this type are bumped to the newest matching release when the APIExport publishes one or releaseConstraint is changed. APIBindings are never moved to an older release.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"export"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_InitializerPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InitializerPolicy configures the timeout and the failure policy of an initializer.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"initializer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "initializer is the name of the initializer, e.g. \"system:apibindings\" or \"root:org:example\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "timeout is the time the initializer may take, counted from the creation of the logical cluster of the workspace.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "failurePolicy determines what happens when the initializer times out. With \"Fail\", the workspace stays in phase Initializing with the WorkspaceInitialized condition marking the initializer as failed. With \"Ignore\", the initializer is removed and the initialization continues without it. With \"Retry\", the initializer gets maxRetries more attempts, each with twice the timeout of the previous one, before it fails.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxRetries is the number of additional attempts with failurePolicy \"Retry\".",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"initializer", "timeout"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LimitIncreaseRequest is a request of a tenant to increase the hard limits of a ResourceQuota in their workspace. It is decided by the admins of the parent workspace: setting spec.decision requires the \"approve\" verb on limitincreaserequests in the parent workspace, for the name of the workspace. Approved requests are applied to the ResourceQuota by a controller.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestSpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LimitIncreaseRequestList is a list of limit increase requests.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequest"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequest", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestQuotaReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LimitIncreaseRequestQuotaReference identifies a ResourceQuota.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespace is the namespace of the ResourceQuota.",
+							Default:     "",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"resourceSelector": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resourceSelector is a list of claimed resource selectors.",
+							Description: "name is the name of the ResourceQuota.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LimitIncreaseRequestSpec holds the requested limits and the decision about them.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resourceQuota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceQuota references the ResourceQuota in this workspace whose hard limits are to be increased.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestQuotaReference"),
+						},
+					},
+					"hard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "hard is the requested set of hard limits. Limits of the ResourceQuota that are not listed are left unchanged.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"justification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "justification explains to the admins of the parent workspace why the limits are needed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"decision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "decision is set by the admins of the parent workspace. It cannot be changed once set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resourceQuota", "hard", "justification"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.LimitIncreaseRequestQuotaReference", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_LimitIncreaseRequestStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LimitIncreaseRequestStatus communicates the observed state of the LimitIncreaseRequest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "phase is the current phase of the request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"appliedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "appliedTime is the time the requested limits were applied to the ResourceQuota.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of conditions that apply to the LimitIncreaseRequest.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
 									},
 								},
 							},
 						},
 					},
-					"identityHash": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_RetentionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetentionPolicy defines when objects of a resource in this workspace are deleted, either because they exceed a maximum age, or because only a number of most recent objects is kept. Policies are executed periodically by a janitor controller, with the permissions of the user who last changed the policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "This is the identity for a given APIExport that the APIResourceSchema belongs to. The hash can be found on APIExport and APIResourceSchema's status. It will be empty for core types. Note that one must look this up for a particular KCP instance.",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"state": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyStatus"),
 						},
 					},
 				},
-				Required: []string{"state"},
+				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicySpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_BindingReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BindingReference describes a reference to an APIExport. Exactly one of the fields must be set.",
+				Description: "RetentionPolicyList is a list of retention policies.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"export": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "export is a reference to an APIExport by cluster name and export name. The creator of the APIBinding needs to have access to the APIExport with the verb `bind` in order to bind to it.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ExportBindingReference"),
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicy"),
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"metadata", "items"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ExportBindingReference"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BoundAPIResource describes a bound GroupVersionResource through an APIResourceSchema of an APIExport..",
+				Description: "RetentionPolicyResource identifies a resource by group, version and resource name.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"group": {
 						SchemaProps: spec.SchemaProps{
-							Description: "group is the group of the bound API. Empty string for the core API group.",
+							Description: "group is the API group of the resource. Empty string for the core API group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "version is the API version used to list and delete objects.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
@@ -2040,27 +5577,91 @@ func schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref common.ReferenceCallback
 					},
 					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resource is the resource of the bound API.\n\nkubebuilder:validation:MinLength=1",
+							Description: "resource is the lower-case plural name of the resource.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"schema": {
+				},
+				Required: []string{"version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_RetentionPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetentionPolicySpec defines which objects are subject to the policy and when they are deleted. If both maxAge and keepLatest are set, objects are deleted when either of them applies.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Schema references the APIResourceSchema that is bound to this API.",
+							Description: "resource is the resource whose objects are subject to this policy.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResourceSchema"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyResource"),
 						},
 					},
-					"storageVersions": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
-							},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "selector restricts the policy to objects matching the label selector. If unset, all objects of the resource are subject to the policy.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxAge is the age, measured from creation, after which objects are deleted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"keepLatest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "keepLatest is the number of most recently created objects that are kept per namespace. All older objects are deleted.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dryRun prevents objects from being deleted if set. Instead, the objects that would have been deleted are reported in status.dryRunCandidates.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.RetentionPolicyResource", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_RetentionPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetentionPolicyStatus communicates the observed state of the RetentionPolicy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastRunTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastRunTime is the time the policy was last executed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastDeletedCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastDeletedCount is the number of objects deleted during the last run, or in dry-run mode the number of objects that would have been deleted.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
+					},
+					"dryRunCandidates": {
 						SchemaProps: spec.SchemaProps{
-							Description: "storageVersions lists all versions of a resource that were ever persisted. Tracking these versions allows a migration path for stored versions in etcd. The field is mutable so a migration controller can finish a migration to another version (ensuring no old objects are left in storage), and then remove the rest of the versions from this list.\n\nVersions may not be removed while they exist in this list.",
+							Description: "dryRunCandidates lists the objects, in the format namespace/name or name for cluster-scoped objects, that would have been deleted during the last run in dry-run mode. The list is truncated to 100 entries.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -2073,259 +5674,234 @@ func schema_pkg_apis_apis_v1alpha1_BoundAPIResource(ref common.ReferenceCallback
 							},
 						},
 					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of conditions that apply to the RetentionPolicy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
 				},
-				Required: []string{"group", "resource", "schema"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.BoundAPIResourceSchema"},
+			"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_BoundAPIResourceSchema(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemption(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BoundAPIResourceSchema is a reference to an APIResourceSchema.",
+				Description: "ThrottlingExemption exempts the workspaces of the referenced WorkspaceTypes from the per-workspace limits, i.e. from WorkspaceQuotas and ResourceQuotas. It is meant for system automation tenants, e.g. the workspaces of platform-internal controllers, which must not be throttled like tenants.\n\nThrottlingExemptions are only honored in the root workspace, and only if they carry a valid signature in the tenancy.kcp.io/throttling-exemption-signature annotation. The signature is set on creation and update by the shard serving the root workspace, using the key of --throttling-exemption-signing-key-file, which must be the same on all shards. Replicas forged in the cache server are hence ignored.\n\nNote that anybody allowed to use an exempt WorkspaceType can create exempt workspaces.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "name is the bound APIResourceSchema name.",
-							Default:     "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"UID": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "UID is the UID of the APIResourceSchema that is bound to this API.",
-							Default:     "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"identityHash": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "identityHash is the hash of the API identity that this schema is bound to. The API identity determines the etcd prefix used to persist the object. Different identity means that the objects are effectively served and stored under a distinct resource. A CRD of the same GroupVersionResource uses a different identity and hence a separate etcd prefix.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemptionSpec"),
 						},
 					},
 				},
-				Required: []string{"name", "UID", "identityHash"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemptionSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
-
-func schema_pkg_apis_apis_v1alpha1_ExportBindingReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemptionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExportBindingReference is a reference to an APIExport by cluster and name.",
+				Description: "ThrottlingExemptionList is a list of throttling exemptions.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"path": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "path is a logical cluster path where the APIExport is defined. If the path is unset, the logical cluster of the APIBinding is used.",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"name": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "name is the name of the APIExport that describes the API.",
-							Default:     "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"name"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_GroupResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "GroupResource identifies a resource.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"group": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "group is the name of an API group. For core groups this is the empty string '\"\"'.",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
 					},
-					"resource": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resource is the name of the resource. Note: it is worth noting that you can not ask for permissions for resource provided by a CRD not provided by an api export.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemption"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"resource"},
+				Required: []string{"metadata", "items"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.ThrottlingExemption", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
-
-func schema_pkg_apis_apis_v1alpha1_Identity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_ThrottlingExemptionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Identity defines the identity of an APIExport, i.e. determines the etcd prefix data of this APIExport are stored under.",
+				Description: "ThrottlingExemptionSpec holds the exempt WorkspaceTypes.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"secretRef": {
+					"workspaceTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "secretRef is a reference to a secret that contains the API identity in the 'key' file.",
-							Ref:         ref("k8s.io/api/core/v1.SecretReference"),
+							Description: "workspaceTypes are the WorkspaceTypes whose workspaces are exempt. Only workspaces of exactly these types are exempt, not those of types extending them. An empty path refers to the root workspace.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"workspaceTypes"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.SecretReference"},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_LocalAPIExportPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "LocalAPIExportPolicy is a maximal permission policy that checks RBAC in the workspace of the API Export.\n\nIn order to avoid conflicts the user and group name will be prefixed with \"apis.kcp.io:binding:\".",
-				Type:        []string{"object"},
-			},
-		},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_MaximalPermissionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_VirtualWorkspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MaximalPermissionPolicy is a wrapper type around the multiple options that would be allowed.",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"local": {
+					"url": {
 						SchemaProps: spec.SchemaProps{
-							Description: "local is the policy that is defined in same workspace as the API Export.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.LocalAPIExportPolicy"),
+							Description: "url is a WorkspaceType initialization virtual workspace URL.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"url"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.LocalAPIExportPolicy"},
 	}
 }
 
-func schema_pkg_apis_apis_v1alpha1_PermissionClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceNamingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PermissionClaim identifies an object by GR and identity hash. Its purpose is to determine the added permissions that a service provider may request and that a consumer may accept and allow the service provider access to.",
+				Description: "WorkspaceNamingPolicy restricts the names of workspaces. A name must satisfy all rules that are set.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"all": {
+					"pattern": {
 						SchemaProps: spec.SchemaProps{
-							Description: "all claims all resources for the given group/resource. This is mutually exclusive with resourceSelector.",
-							Type:        []string{"boolean"},
+							Description: "pattern is a regular expression in RE2 syntax the whole name must match, e.g. \"^[a-z]+-[0-9]+$\".",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"resourceSelector": {
+					"prefixes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resourceSelector is a list of claimed resource selectors.",
+							Description: "prefixes are the prefixes one of which the name must start with, e.g. the team prefixes of an organization.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"identityHash": {
-						SchemaProps: spec.SchemaProps{
-							Description: "This is the identity for a given APIExport that the APIResourceSchema belongs to. The hash can be found on APIExport and APIResourceSchema's status. It will be empty for core types. Note that one must look this up for a particular KCP instance.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1.ResourceSelector"},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_ResourceSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Description: "name of an object within a claimed group/resource. It matches the metadata.name field of the underlying object. If namespace is unset, all objects matching that name will be claimed.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"namespace": {
+					"deniedWords": {
 						SchemaProps: spec.SchemaProps{
-							Description: "namespace containing the named object. Matches metadata.namespace field. If \"name\" is unset, all objects from the namespace are being claimed.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "deniedWords are words the name must not contain, compared case-insensitively.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_apis_v1alpha1_VirtualWorkspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"url": {
+					"uniquenessScope": {
 						SchemaProps: spec.SchemaProps{
-							Description: "url is an APIExport virtual workspace URL.",
-							Default:     "",
+							Description: "uniquenessScope is the scope in which the name must be unique. With \"Parent\", names are unique among the sibling workspaces only, like the names of all objects. With \"Type\", the name must also be unique among all workspaces of the same type. The latter is checked against the workspaces known to the shard of the parent workspace.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"url"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_LogicalCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuota(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogicalCluster describes the current logical cluster. It is used to authorize requests to the logical cluster and to track state.\n\nA LogicalCluster is always named \"cluster\".",
+				Description: "WorkspaceQuota limits the number of child workspaces, APIBindings and total objects in the logical cluster it lives in. Creations exceeding any of the hard limits are rejected by admission. The spec is owned by the admins of the parent workspace: changing it requires the \"manage\" verb on workspacequotas in the parent workspace, for the name of the workspace.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2351,28 +5927,29 @@ func schema_pkg_apis_core_v1alpha1_LogicalCluster(ref common.ReferenceCallback)
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterSpec"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaStatus"),
 						},
 					},
 				},
+				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterSpec", "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaSpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogicalClusterList is a list of LogicalCluster",
+				Description: "WorkspaceQuotaList is a list of workspace quotas.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2402,7 +5979,7 @@ func schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref common.ReferenceCallba
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalCluster"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuota"),
 									},
 								},
 							},
@@ -2413,101 +5990,94 @@ func schema_pkg_apis_core_v1alpha1_LogicalClusterList(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalCluster", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuota", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_LogicalClusterOwner(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogicalClusterOwner is a reference to a resource controlling the life-cycle of a LogicalCluster.",
+				Description: "WorkspaceQuotaSpec holds the hard limits of a WorkspaceQuota.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"apiVersion": {
-						SchemaProps: spec.SchemaProps{
-							Description: "apiVersion is the group and API version of the owner.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"resource": {
-						SchemaProps: spec.SchemaProps{
-							Description: "resource is API resource to access the owner.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Description: "name is the name of the owner.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"namespace": {
-						SchemaProps: spec.SchemaProps{
-							Description: "namespace is the optional namespace of the owner.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"cluster": {
-						SchemaProps: spec.SchemaProps{
-							Description: "cluster is the logical cluster in which the owner is located.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"uid": {
-						SchemaProps: spec.SchemaProps{
-							Description: "UID is the UID of the owner.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+					"hard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "hard is the set of hard limits, keyed by \"workspaces\", \"apibindings\" or \"objects\". Resources that are not listed are not limited.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"apiVersion", "resource", "name", "cluster", "uid"},
+				Required: []string{"hard"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_LogicalClusterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogicalClusterSpec is the specification of the LogicalCluster resource.",
+				Description: "WorkspaceQuotaStatus communicates the observed usage of the logical cluster.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"directlyDeletable": {
+					"used": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DirectlyDeletable indicates that this logical cluster can be directly deleted by the user from within by deleting the LogicalCluster object.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "used is the current usage of the resources listed in spec.hard.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
 						},
 					},
-					"owner": {
+					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "owner is a reference to a resource controlling the life-cycle of this logical cluster. On deletion of the LogicalCluster, the finalizer core.kcp.io/logicalcluster is removed from the owner.\n\nWhen this object is deleted, but the owner is not deleted, the owner is deleted too.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterOwner"),
+							Description: "conditions is a list of conditions that apply to the WorkspaceQuota.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+									},
+								},
+							},
 						},
 					},
-					"initializers": {
+					"thresholds": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"resource",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "initializers are set on creation by the system and copied to status when initialization starts.",
+							Description: "thresholds are the highest notification thresholds the usage of the resources has reached. The tenant is notified when the usage reaches a higher threshold, and again after it has dropped below a threshold and reaches it another time.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaThreshold"),
 									},
 								},
 							},
@@ -2517,73 +6087,90 @@ func schema_pkg_apis_core_v1alpha1_LogicalClusterSpec(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.LogicalClusterOwner"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceQuotaThreshold", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_LogicalClusterStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceQuotaThreshold(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LogicalClusterStatus communicates the observed state of the Workspace.",
+				Description: "WorkspaceQuotaThreshold is a notification threshold reached by the usage of a resource.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"URL": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "url is the address under which the Kubernetes-cluster-like endpoint can be found. This URL can be used to access the logical cluster with standard Kubernetes client libraries and command line tools.",
+							Description: "resource is the name of the resource in spec.hard.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"phase": {
+					"percent": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase of the logical cluster (Initializing, Ready).",
+							Description: "percent is the threshold in percent of the hard limit.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"resource", "percent"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceResourceUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceResourceUsage is the number of objects of a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "group is the API group of the resource, empty for the core group.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"conditions": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Current processing state of the LogicalCluster.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
-									},
-								},
-							},
+							Description: "version is the API version of the resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"initializers": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "initializers are set on creation by the system and must be cleared by a controller before the logical cluster can be used. The LogicalCluster object will stay in the phase \"Initializing\" state until all initializers are cleared.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "resource is the plural name of the resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "count is the number of objects.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
 				},
+				Required: []string{"version", "resource", "count"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_Shard(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Shard describes a kcp instance on which a number of logical clusters will live",
+				Description: "WorkspaceTemplate declares a set of objects that are created inside of new workspaces during initialization. It is referenced by WorkspaceTypes through spec.template, and applied to every workspace of such a type (or of a type extending it) before the workspace becomes ready.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2609,28 +6196,21 @@ func schema_pkg_apis_core_v1alpha1_Shard(ref common.ReferenceCallback) common.Op
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardSpec"),
-						},
-					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardSpec", "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.ShardStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
-
-func schema_pkg_apis_core_v1alpha1_ShardList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ShardList is a list of shard instances",
+				Description: "WorkspaceTemplateList is a list of workspace templates.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2660,7 +6240,7 @@ func schema_pkg_apis_core_v1alpha1_ShardList(ref common.ReferenceCallback) commo
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.Shard"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplate"),
 									},
 								},
 							},
@@ -2671,77 +6251,58 @@ func schema_pkg_apis_core_v1alpha1_ShardList(ref common.ReferenceCallback) commo
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1.Shard", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplate", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
-
-func schema_pkg_apis_core_v1alpha1_ShardSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ShardSpec holds the desired state of the Shard.",
+				Description: "WorkspaceTemplateReference is a reference to a WorkspaceTemplate.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"baseURL": {
-						SchemaProps: spec.SchemaProps{
-							Description: "baseURL is the address of the KCP shard for direct connections, e.g. by some front-proxy doing the fan-out to the shards.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"externalURL": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "externalURL is the externally visible address presented to users in Workspace URLs. Changing this will break all existing logical clusters on that shard, i.e. existing kubeconfigs of clients will be invalid. Hence, when changing this value, the old URL used by clients must keep working.\n\nThe external address will not be unique if a front-proxy does a fan-out to shards, but all logical cluster clients will talk to the front-proxy. In that case, put the address of the front-proxy here.\n\nNote that movement of shards is only possible (in the future) between shards that share a common external URL.\n\nThis will be defaulted to the value of the baseURL.",
+							Description: "path is an absolute reference to the workspace that owns the WorkspaceTemplate, e.g. root:org. If it is empty, the workspace of the referencing WorkspaceType is used.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"virtualWorkspaceURL": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "virtualWorkspaceURL is the address of the virtual workspace apiserver associated with this shard. It can be a direct address, an address of a front-proxy or even an address of an LB. As of today this address is assigned to APIExports.\n\nThis will be defaulted to the value of the baseURL.",
+							Description: "name is the name of the WorkspaceTemplate.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"baseURL"},
+				Required: []string{"name"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_core_v1alpha1_ShardStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ShardStatus communicates the observed state of the Shard.",
+				Description: "WorkspaceTemplateSpec holds the objects of a WorkspaceTemplate.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"capacity": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Set of integer resources that logical clusters can be scheduled into",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-									},
-								},
+					"objects": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
 							},
 						},
-					},
-					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Current processing state of the Shard.",
+							Description: "objects are the objects to create in new workspaces, e.g. APIBindings, namespaces, RBAC or configmaps. They are created in the given order, hence namespaces must be listed before the objects living in them. Objects that already exist are left untouched.\n\nEvery object must have apiVersion, kind and metadata.name set.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 									},
 								},
 							},
@@ -2751,101 +6312,140 @@ func schema_pkg_apis_core_v1alpha1_ShardStatus(ref common.ReferenceCallback) com
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_AvailableSelectorLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstone(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "AvailableSelectorLabel specifies a label with key name and possible values.",
+				Description: "WorkspaceTombstone reserves the name of a deleted workspace in its parent workspace. While it exists, no workspace of the same name, and hence with the same URL, can be created, such that clients and references to the deleted workspace cannot be taken over by a new one.\n\nWorkspaceTombstones are created by kcp when a workspace is finally deleted, i.e. not while it is in the trash, if --workspace-tombstone-duration is positive. They are named like the deleted workspace and removed when they expire. Deleting a WorkspaceTombstone purges it early and frees the name. Creation and updates are reserved to kcp.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"key": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "key is the name of the label.",
-							Default:     "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"values": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
-							},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
+					},
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "values are the possible values for this labels.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"description": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "description is a human readable description of the label.",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstoneSpec"),
 						},
 					},
 				},
-				Required: []string{"key", "values"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstoneSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
-
-func schema_pkg_apis_scheduling_v1alpha1_GroupVersionResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstoneList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "GroupVersionResource unambiguously identifies a resource.",
+				Description: "WorkspaceTombstoneList is a list of workspace tombstones.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"group": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "group is the name of an API group.",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"version": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "version is the version of the API.",
-							Default:     "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstone"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTombstone", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTombstoneSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTombstoneSpec describes the deleted workspace and the end of the reservation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cluster is the logical cluster the deleted workspace pointed to.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"resource": {
+					"uid": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resource is the name of the resource.",
-							Default:     "",
+							Description: "uid is the UID of the deleted workspace.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "expiresAt is the time after which the name can be reused and the tombstone is removed.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
-				Required: []string{"version", "resource"},
+				Required: []string{"expiresAt"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_Location(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceType(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Location represents a set of instances of a scheduling resource type acting a target of scheduling.\n\nThe location is chosen by the user (in the future) through a Placement object, while the instance is chosen by the scheduler depending on considerations like load or available resources, or further node selectors specified by the user.",
+				Description: "WorkspaceType specifies behaviour of workspaces of this type.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2871,28 +6471,57 @@ func schema_pkg_apis_scheduling_v1alpha1_Location(ref common.ReferenceCallback)
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationSpec"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationSpec", "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_LocationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeExtension(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LocationList is a list of locations.",
+				Description: "WorkspaceTypeExtension defines how other WorkspaceTypes are composed together to add functionality to the owning WorkspaceType.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"with": {
+						SchemaProps: spec.SchemaProps{
+							Description: "with are WorkspaceTypes whose initializers are added to the list for the owning type, and for whom the owning type becomes an alias, as long as all of their required types are not mentioned in without.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTypeList is a list of workspace types",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -2922,7 +6551,7 @@ func schema_pkg_apis_scheduling_v1alpha1_LocationList(ref common.ReferenceCallba
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Location"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceType"),
 									},
 								},
 							},
@@ -2933,366 +6562,407 @@ func schema_pkg_apis_scheduling_v1alpha1_LocationList(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Location", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceType", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_LocationReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LocationReference describes a location that are provided in the specified Workspace.",
+				Description: "WorkspaceTypeReference is a globally unique, fully qualified reference to a workspace type.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"path": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "path is an absolute reference to a workspace, e.g. root:org:ws. The workspace must be some ancestor or a child of some ancestor.",
+							Description: "name is the name of the WorkspaceType",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"locationName": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the Location.",
-							Default:     "",
+							Description: "path is an absolute reference to the workspace that owns this type, e.g. root:org:ws.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"path", "locationName"},
+				Required: []string{"name"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_LocationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LocationSpec holds the desired state of the Location.",
+				Description: "WorkspaceTypeRolloutPolicy configures the waves in which changes of a WorkspaceType reach its workspaces.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"resource": {
+					"waves": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resource is the group-version-resource of the instances that are subject to this location.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.GroupVersionResource"),
+							Description: "waves are started one after the other, every waveInterval. Workspaces in a started wave get the new revision of the WorkspaceType, all others keep the stable revision. Once the last wave has run for waveInterval, the rollout completes and the new revision becomes the stable one for all workspaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutWave"),
+									},
+								},
+							},
 						},
 					},
-					"description": {
+					"waveInterval": {
 						SchemaProps: spec.SchemaProps{
-							Description: "description is a human-readable description of the location.",
+							Description: "waveInterval is the time between the start of two waves.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "paused stops the rollout from starting further waves. Workspaces in started waves keep the new revision.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"waves"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutWave", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTypeRolloutStatus communicates the progress of a staged rollout.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "revision is the revision of spec.defaultAPIBindings being rolled out.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"availableSelectorLabels": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"key",
-								},
-								"x-kubernetes-list-type": "map",
-							},
+					"stableRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "stableRevision is the revision of the workspaces not in a started wave.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
+					},
+					"stableDefaultAPIBindings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "availableSelectorLabels is a list of labels that can be used to select an instance at this location in a placement object.",
+							Description: "stableDefaultAPIBindings are the default APIBindings of the stable revision.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.AvailableSelectorLabel"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DefaultAPIBinding"),
 									},
 								},
 							},
 						},
 					},
-					"instanceSelector": {
+					"startedWaves": {
 						SchemaProps: spec.SchemaProps{
-							Description: "instanceSelector chooses the instances that will be part of this location.\n\nNote that these labels are not what is shown in the Location objects to the user. Depending on context, both will match or won't match.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							Description: "startedWaves is the number of waves of spec.rollout.waves started for revision.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastTransitionTime is the time the last wave was started or the rollout completed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "phase is the phase of the rollout.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"resource"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.AvailableSelectorLabel", "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.GroupVersionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DefaultAPIBinding", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_LocationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeRolloutWave(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LocationStatus defines the observed state of Location.",
+				Description: "WorkspaceTypeRolloutWave selects the workspaces updated in a wave, by percentage or by label. A workspace is in the wave if it matches either.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"instances": {
+					"percentage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "instances is the number of actual instances at this location.",
+							Description: "percentage is the share of the workspaces of this type updated once this wave has started, including the ones of earlier waves. Workspaces are picked by a hash of their logical cluster name, such that the same workspaces come first in every rollout.",
 							Type:        []string{"integer"},
-							Format:      "int64",
+							Format:      "int32",
 						},
 					},
-					"availableInstances": {
+					"selector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "available is the number of actual instances that are available at this location.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "selector selects the workspaces updated in this wave by the labels of their LogicalCluster, e.g. canary workspaces.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_Placement(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Placement defines a selection rule to choose ONE location for MULTIPLE namespaces in a workspace.\n\nplacement is in Pending state initially. When a location is selected by the placement, the placement turns to Unbound state. In Pending or Unbound state, the selection rule can be updated to select another location. When the a namespace is annotated by another controller or user with the key of \"scheduling.kcp.io/placement\", the namespace will pick one placement, and this placement is transferred to Bound state. Any update to spec of the placement is ignored in Bound state and reflected in the conditions. The placement will turn back to Unbound state when no namespace uses this placement any more.",
+				Description: "WorkspaceTypeSelector describes a set of types.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
+					"none": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
+							Description: "none means that no type matches.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
-						},
-					},
-					"spec": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementSpec"),
-						},
-					},
-					"status": {
+					"types": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementStatus"),
+							Description: "types is a list of WorkspaceTypes that match. A workspace type extending another workspace type automatically is considered as that extended type as well (even transitively).\n\nAn empty list matches all types.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementSpec", "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.PlacementStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_PlacementList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PlacementList is a list of locations.",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"initializer": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
+							Description: "initializer determines if this WorkspaceType has an associated initializing controller. These controllers are used to add functionality to a ClusterWorkspace; all controllers must finish their work before the ClusterWorkspace becomes ready for use.\n\nOne initializing controller is supported per WorkspaceType; the identifier for this initializer will be a colon-delimited string using the workspace in which the WorkspaceType is defined, and the type's name. For example, if a WorkspaceType `example` is created in the `root:org` workspace, the implicit initializer name is `root:org:Example`.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"extend": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "extend is a list of other WorkspaceTypes whose initializers and limitAllowedChildren and limitAllowedParents this WorkspaceType is inheriting. By (transitively) extending another WorkspaceType, this WorkspaceType will be considered as that other type in evaluation of limitAllowedChildren and limitAllowedParents constraints.\n\nA dependency cycle stop this WorkspaceType from being admitted as the type of a ClusterWorkspace.\n\nA non-existing dependency stop this WorkspaceType from being admitted as the type of a ClusterWorkspace.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeExtension"),
 						},
 					},
-					"metadata": {
+					"additionalWorkspaceLabels": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Description: "additionalWorkspaceLabels are a set of labels that will be added to a ClusterWorkspace on creation.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"items": {
+					"defaultChildWorkspaceType": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "defaultChildWorkspaceType is the WorkspaceType that will be used by default if another, nested ClusterWorkspace is created in a workspace of this type. When this field is unset, the user must specify a type when creating nested workspaces. Extending another WorkspaceType does not inherit its defaultChildWorkspaceType.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+						},
+					},
+					"limitAllowedChildren": {
+						SchemaProps: spec.SchemaProps{
+							Description: "limitAllowedChildren specifies constraints for sub-workspaces created in workspaces of this type. These are in addition to child constraints of types this one extends.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSelector"),
+						},
+					},
+					"limitAllowedParents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "limitAllowedParents specifies constraints for the parent workspace that workspaces of this type are created in. These are in addition to parent constraints of types this one extends.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSelector"),
+						},
+					},
+					"defaultAPIBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "defaultAPIBindings are the APIs to bind during initialization of workspaces created from this type. The APIBinding names will be generated dynamically.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DefaultAPIBinding"),
+									},
+								},
+							},
+						},
+					},
+					"acceptedPermissionClaimPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "acceptedPermissionClaimPolicies are permission claims of APIExports that are accepted automatically when an APIBinding to the APIExport is created in workspaces of this type. Claims the APIBinding already accepts or rejects are left untouched.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Placement"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.AcceptedPermissionClaimPolicy"),
 									},
 								},
 							},
 						},
-					},
-				},
-				Required: []string{"metadata", "items"},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.Placement", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
-	}
-}
-
-func schema_pkg_apis_scheduling_v1alpha1_PlacementSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"locationSelectors": {
+					},
+					"shardSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "shardSelector restricts the shards workspaces of this type are scheduled to, by the labels of the shards. It is combined with the shard selectors of the types this one extends and with spec.location.selector of the workspace: a shard must match all of them.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "template references a WorkspaceTemplate whose objects are created in workspaces of this type during initialization. Templates of the types this one extends are applied first.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateReference"),
+						},
+					},
+					"initializerPolicies": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"initializer",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "locationSelectors represents a slice of label selector to select a location, these label selectors are logically ORed.",
+							Description: "initializerPolicies bound the time the initializers of workspaces of this type may take, including the initializers inherited from the types this one extends. Initializers without policy block the initialization of the workspace until they are done.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.InitializerPolicy"),
 									},
 								},
 							},
 						},
 					},
-					"locationResource": {
+					"priority": {
 						SchemaProps: spec.SchemaProps{
-							Description: "locationResource is the group-version-resource of the instances that are subject to the locations to select.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.GroupVersionResource"),
+							Description: "priority is the default and maximal priority of workspaces of this type in scheduling when shards are at capacity. Workspaces with higher priority are scheduled first.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"namespaceSelector": {
+					"namingPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "namespaceSelector is a label selector to select ns. It match all ns by default, but can be specified to a certain set of ns.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							Description: "namingPolicy restricts the names of workspaces of this type. The naming policies of the types this one extends apply as well.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceNamingPolicy"),
 						},
 					},
-					"locationWorkspace": {
+					"rollout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "locationWorkspace is an absolute reference to a workspace for the location. If it is not set, the workspace of APIBinding will be used.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "rollout stages changes of defaultAPIBindings, i.e. the APIBindings created by initialization and the releases existing APIBindings are upgraded to, in waves of workspaces. Without rollout, changes apply to all workspaces of this type at once.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutPolicy"),
 						},
 					},
 				},
-				Required: []string{"locationResource"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.GroupVersionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.AcceptedPermissionClaimPolicy", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.DefaultAPIBinding", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.InitializerPolicy", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceNamingPolicy", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTemplateReference", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeExtension", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutPolicy", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
-func schema_pkg_apis_scheduling_v1alpha1_PlacementStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "WorkspaceTypeStatus defines the observed state of WorkspaceType.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"phase": {
-						SchemaProps: spec.SchemaProps{
-							Description: "phase is the current phase of the placement",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"selectedLocation": {
-						SchemaProps: spec.SchemaProps{
-							Description: "selectedLocation is the location that a picked by this placement.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationReference"),
-						},
-					},
 					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Current processing state of the Placement.",
+							Description: "conditions is a list of conditions that apply to the APIExport.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
 									},
 								},
 							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1.LocationReference", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
-	}
-}
-
-func schema_pkg_apis_tenancy_v1alpha1_APIExportReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "APIExportReference provides the fields necessary to resolve an APIExport.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "path is the fully-qualified path to the workspace containing the APIExport. If it is empty, the current workspace is assumed.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"export": {
+					"virtualWorkspaces": {
 						SchemaProps: spec.SchemaProps{
-							Description: "export is the name of the APIExport.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "virtualWorkspaces contains all APIExport virtual workspace URLs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.VirtualWorkspace"),
+									},
+								},
+							},
 						},
 					},
-				},
-				Required: []string{"export"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_tenancy_v1alpha1_VirtualWorkspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"url": {
+					"rollout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "url is a WorkspaceType initialization virtual workspace URL.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "rollout is the state of the staged rollout configured in spec.rollout.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutStatus"),
 						},
 					},
 				},
-				Required: []string{"url"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeRolloutStatus", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
 	}
 }
 
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceType(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceType specifies behaviour of workspaces of this type.",
+				Description: "WorkspaceUsage reports the usage of the logical cluster it lives in, e.g. for chargeback. There is one WorkspaceUsage per logical cluster, named \"cluster\". It is maintained by kcp periodically if --workspace-usage-metrics is enabled, and cannot be changed by anybody else. The same usage is exposed as metrics labeled by the workspace path.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -3315,60 +6985,25 @@ func schema_pkg_apis_tenancy_v1alpha1_WorkspaceType(ref common.ReferenceCallback
 							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"spec": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSpec"),
-						},
-					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeStatus"),
-						},
-					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSpec", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
-	}
-}
-
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeExtension(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceTypeExtension defines how other WorkspaceTypes are composed together to add functionality to the owning WorkspaceType.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"with": {
-						SchemaProps: spec.SchemaProps{
-							Description: "with are WorkspaceTypes whose initializers are added to the list for the owning type, and for whom the owning type becomes an alias, as long as all of their required types are not mentioned in without.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
-									},
-								},
-							},
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsageStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsageStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsageList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceTypeList is a list of workspace types",
+				Description: "WorkspaceUsageList is a list of workspace usages.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -3398,7 +7033,7 @@ func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref common.ReferenceCall
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceType"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsage"),
 									},
 								},
 							},
@@ -3409,191 +7044,131 @@ func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeList(ref common.ReferenceCall
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceType", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceUsage", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1alpha1_WorkspaceUsageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceTypeReference is a globally unique, fully qualified reference to a workspace type.",
+				Description: "WorkspaceUsageStatus communicates the observed usage of the logical cluster.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Description: "name is the name of the WorkspaceType",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "path is an absolute reference to the workspace that owns this type, e.g. root:org:ws.",
+							Description: "path is the canonical path of the workspace, e.g. root:org:team.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"name"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceTypeSelector describes a set of types.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"none": {
-						SchemaProps: spec.SchemaProps{
-							Description: "none means that no type matches.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"types": {
+					"objects": {
 						SchemaProps: spec.SchemaProps{
-							Description: "types is a list of WorkspaceTypes that match. A workspace type extending another workspace type automatically is considered as that extended type as well (even transitively).\n\nAn empty list matches all types.",
+							Description: "objects is the number of objects per resource, sorted by group, version and resource. Every resource is listed once, with one of its served versions.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceResourceUsage"),
 									},
 								},
 							},
 						},
 					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "requests is the number of requests to the logical cluster served by its shard since the WorkspaceUsage was created.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"storageBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "storageBytes is the approximate size of all objects in the logical cluster, as the size of their JSON encoding.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastUpdateTime is the time the usage was last changed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceResourceUsage", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1beta1_Mount(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"initializer": {
-						SchemaProps: spec.SchemaProps{
-							Description: "initializer determines if this WorkspaceType has an associated initializing controller. These controllers are used to add functionality to a ClusterWorkspace; all controllers must finish their work before the ClusterWorkspace becomes ready for use.\n\nOne initializing controller is supported per WorkspaceType; the identifier for this initializer will be a colon-delimited string using the workspace in which the WorkspaceType is defined, and the type's name. For example, if a WorkspaceType `example` is created in the `root:org` workspace, the implicit initializer name is `root:org:Example`.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"extend": {
-						SchemaProps: spec.SchemaProps{
-							Description: "extend is a list of other WorkspaceTypes whose initializers and limitAllowedChildren and limitAllowedParents this WorkspaceType is inheriting. By (transitively) extending another WorkspaceType, this WorkspaceType will be considered as that other type in evaluation of limitAllowedChildren and limitAllowedParents constraints.\n\nA dependency cycle stop this WorkspaceType from being admitted as the type of a ClusterWorkspace.\n\nA non-existing dependency stop this WorkspaceType from being admitted as the type of a ClusterWorkspace.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeExtension"),
-						},
-					},
-					"additionalWorkspaceLabels": {
-						SchemaProps: spec.SchemaProps{
-							Description: "additionalWorkspaceLabels are a set of labels that will be added to a ClusterWorkspace on creation.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"defaultChildWorkspaceType": {
-						SchemaProps: spec.SchemaProps{
-							Description: "defaultChildWorkspaceType is the WorkspaceType that will be used by default if another, nested ClusterWorkspace is created in a workspace of this type. When this field is unset, the user must specify a type when creating nested workspaces. Extending another WorkspaceType does not inherit its defaultChildWorkspaceType.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
-						},
-					},
-					"limitAllowedChildren": {
-						SchemaProps: spec.SchemaProps{
-							Description: "limitAllowedChildren specifies constraints for sub-workspaces created in workspaces of this type. These are in addition to child constraints of types this one extends.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSelector"),
-						},
-					},
-					"limitAllowedParents": {
-						SchemaProps: spec.SchemaProps{
-							Description: "limitAllowedParents specifies constraints for the parent workspace that workspaces of this type are created in. These are in addition to parent constraints of types this one extends.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSelector"),
-						},
-					},
-					"defaultAPIBindings": {
-						SchemaProps: spec.SchemaProps{
-							Description: "defaultAPIBindings are the APIs to bind during initialization of workspaces created from this type. The APIBinding names will be generated dynamically.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.APIExportReference"),
-									},
-								},
-							},
+				Description: "Mount references the object providing the endpoint of a mounted workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reference": {
+						SchemaProps: spec.SchemaProps{
+							Description: "reference is the object in the workspace of the Workspace object, e.g. a ClusterProxy. It must publish the URL of the endpoint in status.URL and its readiness in status.phase, which is Ready once the endpoint can serve requests.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.ObjectReference"),
 						},
 					},
 				},
+				Required: []string{"reference"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.APIExportReference", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeExtension", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeSelector"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.ObjectReference"},
 	}
 }
 
-func schema_pkg_apis_tenancy_v1alpha1_WorkspaceTypeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_tenancy_v1beta1_ObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "WorkspaceTypeStatus defines the observed state of WorkspaceType.",
+				Description: "ObjectReference references an object by API version, kind and name.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"conditions": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "conditions is a list of conditions that apply to the APIExport.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
-									},
-								},
-							},
+							Description: "apiVersion is the API group and version of the object, e.g. \"proxy.example.com/v1alpha1\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"virtualWorkspaces": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "virtualWorkspaces contains all APIExport virtual workspace URLs.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.VirtualWorkspace"),
-									},
-								},
-							},
+							Description: "kind is the kind of the object, e.g. \"ClusterProxy\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "name is the name of the object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespace is the namespace of the object, if it is namespaced.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"apiVersion", "kind", "name"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.VirtualWorkspace", "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
 	}
 }
 
@@ -3627,13 +7202,13 @@ func schema_pkg_apis_tenancy_v1beta1_Workspace(ref common.ReferenceCallback) com
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceSpec"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceStatus"),
+							Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceStatus"),
 						},
 					},
 				},
@@ -3641,7 +7216,7 @@ func schema_pkg_apis_tenancy_v1beta1_Workspace(ref common.ReferenceCallback) com
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceSpec", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSpec", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -3679,7 +7254,7 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceList(ref common.ReferenceCallback)
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.Workspace"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Workspace"),
 									},
 								},
 							},
@@ -3690,7 +7265,7 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceList(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.Workspace", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Workspace", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -3714,6 +7289,89 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceLocation(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceMountStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceMountStatus is the observed state of the mount of a workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"URL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL is the address of the endpoint of the mount, as published by the referenced object.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dnsName is the stable DNS name of the mount, rendered from the logical cluster of the workspace and its name if the workspace controller is configured with a base domain for mounts. It does not change when the URL of the mount changes.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceOwner(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceOwner is the user owning a workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "username is the name of the user owning the workspace.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"username"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceScheduling(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceScheduling records the decision of the workspace scheduler.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"shard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "shard is the name of the shard the workspace is scheduled to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "selector is the shard label selector the decision was made with. It combines spec.location.selector with the shard selectors of the workspace type and the types it extends. It is empty if any shard was eligible.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "message explains the decision, e.g. why no shard could be chosen.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_tenancy_v1beta1_WorkspaceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3725,13 +7383,13 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceSpec(ref common.ReferenceCallback)
 						SchemaProps: spec.SchemaProps{
 							Description: "type defines properties of the workspace both on creation (e.g. initial resources and initially installed APIs) and during runtime (e.g. permissions). If no type is provided, the default type for the workspace in which this workspace is nesting will be used.\n\nThe type is a reference to a WorkspaceType in the listed workspace, but lower-cased. The WorkspaceType existence is validated at admission during creation. The type is immutable after creation. The use of a type is gated via the RBAC workspacetypes/use resource permission.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference"),
 						},
 					},
 					"shard": {
 						SchemaProps: spec.SchemaProps{
 							Description: "location constraints where this workspace can be scheduled to.\n\nIf the no location is specified, an arbitrary location is chosen.",
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceLocation"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceLocation"),
 						},
 					},
 					"cluster": {
@@ -3743,16 +7401,48 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceSpec(ref common.ReferenceCallback)
 					},
 					"URL": {
 						SchemaProps: spec.SchemaProps{
-							Description: "URL is the address under which the Kubernetes-cluster-like endpoint can be found. This URL can be used to access the workspace with standard Kubernetes client libraries and command line tools.\n\nSet by the system.",
+							Description: "URL is the address under which the Kubernetes-cluster-like endpoint can be found. This URL can be used to access the workspace with standard Kubernetes client libraries and command line tools.\n\nThe URL addresses the workspace by the name of its logical cluster. It stays valid for the lifetime of the workspace, independently of renames and moves of the workspace or its ancestors. Prefer it for long-lived references like kubeconfigs.\n\nSet by the system.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "state is the desired state of the workspace. An Archived workspace is read-only: its logical cluster rejects all writes, and with them controllers stop reconciling inside of it, until the workspace is set back to Active.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"ttl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ttl makes the workspace expire after a period of inactivity, e.g. for CI sandboxes or demos. Activity is tracked in status.summary.lastActivityTime, and the time of expiry is published in status.expiryTime.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceTTL"),
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "owner is the user owning the workspace. The owner is bound to the cluster-admin role inside of the workspace through the workspace-admin ClusterRoleBinding.\n\nIt defaults to the user creating the workspace. Changing it transfers the ownership of the workspace, which is allowed for the current owner and for users with the \"transfer\" verb on the workspace.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceOwner"),
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "priority of the workspace in scheduling when shards are at capacity. Workspaces with higher priority are scheduled first, while workspaces with lower priority are kept unschedulable until capacity is free.\n\nIt defaults to the priority of the workspace type and must not exceed it. The priority is immutable.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"mount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "mount makes an external endpoint serve the workspace path instead of kcp, e.g. a cluster proxy in front of a Kubernetes cluster. The front-proxy forwards requests under the workspace path to the URL of the mount while its MountReady condition is true.\n\nThe mount is immutable.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Mount"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.WorkspaceTypeReference", "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1.WorkspaceLocation"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.WorkspaceTypeReference", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.Mount", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceLocation", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceOwner", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceTTL"},
 	}
 }
 
@@ -3778,7 +7468,7 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceStatus(ref common.ReferenceCallbac
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
 									},
 								},
 							},
@@ -3799,11 +7489,172 @@ func schema_pkg_apis_tenancy_v1beta1_WorkspaceStatus(ref common.ReferenceCallbac
 							},
 						},
 					},
+					"summary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "summary is a rollup of the content of the workspace, meant to be displayed by user interfaces. It is updated asynchronously and may lag behind the actual content.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSummary"),
+						},
+					},
+					"urls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "urls are the URLs the workspace is served at, once it is scheduled.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceURLs"),
+						},
+					},
+					"scheduling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "scheduling records the decision of the workspace scheduler.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceScheduling"),
+						},
+					},
+					"expiryTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "expiryTime is the time the workspace expires at if it stays inactive. It is only set for ready workspaces with spec.ttl.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"mount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "mount is the observed state of spec.mount.",
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceMountStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceMountStatus", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceScheduling", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceSummary", "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1.WorkspaceURLs", "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceSummary is a rollup of the content of a workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"childWorkspaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "childWorkspaces is the number of workspaces directly inside of the workspace.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"apiBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "apiBindings is the number of APIBindings in the workspace.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"apis": {
+						SchemaProps: spec.SchemaProps{
+							Description: "apis is the number of resources bound by the APIBindings in the workspace.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastActivityTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "lastActivityTime is the last time the workspace, its child workspaces, APIBindings or resource quotas have been changed by a user. Changes by kcp itself, e.g. of the status, are not considered activity.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"quotaHard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "quotaHard is the sum of the hard limits of all resource quotas in the workspace.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"quotaUsed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "quotaUsed is the sum of the observed usage of all resource quotas in the workspace.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"childWorkspaces", "apiBindings", "apis"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceTTL(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTTL configures the expiry of an inactive workspace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"inactivity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "inactivity is the time without activity after which the workspace expires, e.g. \"72h\".",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "action is what happens to the workspace when it expires. A Deleted workspace goes through the trash if it has a trash retention. An Archived workspace stays read-only until spec.state is set back to Active, which counts as activity.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"warningPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "warningPeriod is the time before the expiry at which the Expiring condition is set and a warning event is emitted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
+				Required: []string{"inactivity"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_tenancy_v1beta1_WorkspaceURLs(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceURLs are the URLs a workspace is served at.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "path is the URL addressing the workspace by its canonical path, e.g. https://kcp.example.com/clusters/root:org:ws. It is human readable, but unlike spec.URL it changes when the workspace or one of its ancestors is renamed or moved.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -3898,14 +7749,14 @@ func schema_pkg_apis_topology_v1alpha1_Partition(ref common.ReferenceCallback) c
 						SchemaProps: spec.SchemaProps{
 							Description: "spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -3943,7 +7794,7 @@ func schema_pkg_apis_topology_v1alpha1_PartitionList(ref common.ReferenceCallbac
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.Partition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.Partition"),
 									},
 								},
 							},
@@ -3954,7 +7805,7 @@ func schema_pkg_apis_topology_v1alpha1_PartitionList(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.Partition", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.Partition", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -3989,21 +7840,21 @@ func schema_pkg_apis_topology_v1alpha1_PartitionSet(ref common.ReferenceCallback
 						SchemaProps: spec.SchemaProps{
 							Description: "spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Description: "status holds information about the current status",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetStatus"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetSpec", "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetSpec", "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -4041,7 +7892,7 @@ func schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref common.ReferenceCall
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSet"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSet"),
 									},
 								},
 							},
@@ -4052,7 +7903,7 @@ func schema_pkg_apis_topology_v1alpha1_PartitionSetList(ref common.ReferenceCall
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1.PartitionSet", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1.PartitionSet", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -4207,21 +8058,21 @@ func schema_pkg_apis_workload_v1alpha1_SyncTarget(ref common.ReferenceCallback)
 						SchemaProps: spec.SchemaProps{
 							Description: "Spec holds the desired state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetSpec"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Status communicates the observed state.",
 							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetStatus"),
+							Ref:         ref("github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetStatus"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetSpec", "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTargetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetSpec", "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTargetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
@@ -4259,7 +8110,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetList(ref common.ReferenceCallba
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTarget"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTarget"),
 									},
 								},
 							},
@@ -4270,7 +8121,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetList(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.SyncTarget", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.SyncTarget", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
@@ -4303,7 +8154,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetSpec(ref common.ReferenceCallba
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.APIExportReference"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.APIExportReference"),
 									},
 								},
 							},
@@ -4329,7 +8180,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetSpec(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1.APIExportReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1.APIExportReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -4378,7 +8229,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref common.ReferenceCall
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition"),
 									},
 								},
 							},
@@ -4392,7 +8243,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref common.ReferenceCall
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.ResourceToSync"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.ResourceToSync"),
 									},
 								},
 							},
@@ -4412,7 +8263,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref common.ReferenceCall
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.VirtualWorkspace"),
+										Ref:     ref("github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.VirtualWorkspace"),
 									},
 								},
 							},
@@ -4422,7 +8273,7 @@ func schema_pkg_apis_workload_v1alpha1_SyncTargetStatus(ref common.ReferenceCall
 			},
 		},
 		Dependencies: []string{
-			"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.ResourceToSync", "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1.VirtualWorkspace", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1.Condition", "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.ResourceToSync", "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1.VirtualWorkspace", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
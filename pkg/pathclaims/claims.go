@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathclaims
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	// Namespace is the namespace in the system:admin logical cluster of the root shard holding
+	// one ConfigMap per claimed path.
+	Namespace = "kcp-system"
+
+	// PathKey and ClusterKey are the data keys of the claimed path and of the logical cluster
+	// holding the claim.
+	PathKey    = "path"
+	ClusterKey = "cluster"
+
+	claimNamePrefix = "path-claim-"
+
+	// staleClaimGracePeriod is how long a claim is kept although its logical cluster cannot be found.
+	// It covers the time until a new logical cluster is replicated to the cache server.
+	staleClaimGracePeriod = 5 * time.Minute
+)
+
+// ClusterName is the logical cluster on the root shard holding the claims.
+var ClusterName = logicalcluster.Name("system:admin")
+
+// ConflictError is returned if a path is claimed by another logical cluster.
+type ConflictError struct {
+	Path   logicalcluster.Path
+	Holder logicalcluster.Name
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("path %q is already claimed by logical cluster %q", e.Path, e.Holder)
+}
+
+// Claims makes sure that every canonical path is claimed by at most one logical cluster across
+// all shards. Claims are ConfigMaps on the root shard, created atomically by the first logical
+// cluster claiming a path, such that two shards cannot both succeed.
+//
+// A claim whose logical cluster exists neither on this shard nor in the cache server for longer
+// than a grace period is considered stale and is taken over. This recovers claims of logical
+// clusters that were never created or whose release failed.
+type Claims struct {
+	rootKubeClusterClient kcpkubernetesclientset.ClusterInterface
+
+	logicalClusterExists func(clusterName logicalcluster.Name) bool
+	now                  func() time.Time
+}
+
+// NewClaims returns Claims stored through the given client of the root shard. The existence of the
+// logical clusters holding claims is checked in the given listers of this shard and of the cache server.
+func NewClaims(
+	rootKubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	localLogicalClusterLister corev1alpha1listers.LogicalClusterClusterLister,
+	cachedLogicalClusterLister corev1alpha1listers.LogicalClusterClusterLister,
+) *Claims {
+	return &Claims{
+		rootKubeClusterClient: rootKubeClusterClient,
+		logicalClusterExists: func(clusterName logicalcluster.Name) bool {
+			for _, lister := range []corev1alpha1listers.LogicalClusterClusterLister{localLogicalClusterLister, cachedLogicalClusterLister} {
+				if _, err := lister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName); err == nil {
+					return true
+				}
+			}
+			return false
+		},
+		now: time.Now,
+	}
+}
+
+// Claim claims the path for the given logical cluster. It succeeds if the path is not claimed yet, or
+// already claimed by the same logical cluster. If the path is claimed by another logical cluster, a
+// ConflictError is returned.
+func (c *Claims) Claim(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error {
+	client := c.rootKubeClusterClient.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace)
+	claim := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName(path),
+			Namespace: Namespace,
+		},
+		Data: map[string]string{
+			PathKey:    path.String(),
+			ClusterKey: clusterName.String(),
+		},
+	}
+
+	_, err := client.Create(ctx, claim, metav1.CreateOptions{})
+	if apierrors.IsNotFound(err) {
+		if err := c.ensureNamespace(ctx); err != nil {
+			return err
+		}
+		_, err = client.Create(ctx, claim, metav1.CreateOptions{})
+	}
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to claim path %q: %w", path, err)
+	}
+
+	existing, err := client.Get(ctx, claim.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get claim of path %q: %w", path, err)
+	}
+	holder := logicalcluster.Name(existing.Data[ClusterKey])
+	if holder == clusterName {
+		return nil
+	}
+	if c.now().Sub(existing.CreationTimestamp.Time) < staleClaimGracePeriod || c.logicalClusterExists(holder) {
+		return &ConflictError{Path: path, Holder: holder}
+	}
+
+	// the update fails on concurrent takeovers due to the resource version, hence only one of them wins.
+	existing = existing.DeepCopy()
+	existing.Data = claim.Data
+	if _, err := client.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			return &ConflictError{Path: path, Holder: holder}
+		}
+		return fmt.Errorf("failed to take over stale claim of path %q from logical cluster %q: %w", path, holder, err)
+	}
+	return nil
+}
+
+// Release releases the claim of the path if it is held by the given logical cluster.
+func (c *Claims) Release(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error {
+	client := c.rootKubeClusterClient.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace)
+	existing, err := client.Get(ctx, claimName(path), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get claim of path %q: %w", path, err)
+	}
+	if logicalcluster.Name(existing.Data[ClusterKey]) != clusterName {
+		return nil
+	}
+
+	// the preconditions protect a claim that has been taken over in the meantime.
+	err = client.Delete(ctx, existing.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{
+		UID:             &existing.UID,
+		ResourceVersion: &existing.ResourceVersion,
+	}})
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		return fmt.Errorf("failed to release claim of path %q: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Claims) ensureNamespace(ctx context.Context) error {
+	_, err := c.rootKubeClusterClient.Cluster(ClusterName.Path()).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: Namespace}}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s|%s: %w", ClusterName, Namespace, err)
+	}
+	return nil
+}
+
+// claimName returns the name of the ConfigMap claiming the path. Paths are hashed because they can be
+// longer than object names.
+func claimName(path logicalcluster.Path) string {
+	hash := sha256.Sum256([]byte(path.String()))
+	return claimNamePrefix + hex.EncodeToString(hash[:])[:32]
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pathclaims
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kcpfakekubeclient "github.com/kcp-dev/client-go/kubernetes/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClaims(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := logicalcluster.NewPath("root:org:team")
+
+	existingClaim := func(holder string, created time.Time) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              claimName(path),
+				Namespace:         Namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       map[string]string{logicalcluster.AnnotationKey: ClusterName.String()},
+			},
+			Data: map[string]string{PathKey: path.String(), ClusterKey: holder},
+		}
+	}
+
+	tests := map[string]struct {
+		existing     *corev1.ConfigMap
+		holderExists bool
+		wantHolder   string
+		wantConflict bool
+	}{
+		"unclaimed path": {
+			wantHolder: "new",
+		},
+		"claimed by the same logical cluster": {
+			existing:   existingClaim("new", now),
+			wantHolder: "new",
+		},
+		"claimed by an existing logical cluster": {
+			existing:     existingClaim("old", now.Add(-time.Hour)),
+			holderExists: true,
+			wantHolder:   "old",
+			wantConflict: true,
+		},
+		"claimed by a logical cluster that might not be replicated yet": {
+			existing:     existingClaim("old", now.Add(-time.Minute)),
+			wantHolder:   "old",
+			wantConflict: true,
+		},
+		"stale claim is taken over": {
+			existing:   existingClaim("old", now.Add(-time.Hour)),
+			wantHolder: "new",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := kcpfakekubeclient.NewSimpleClientset()
+			if tt.existing != nil {
+				client = kcpfakekubeclient.NewSimpleClientset(tt.existing)
+			}
+			c := &Claims{
+				rootKubeClusterClient: client,
+				logicalClusterExists: func(clusterName logicalcluster.Name) bool {
+					return tt.holderExists
+				},
+				now: func() time.Time { return now },
+			}
+
+			err := c.Claim(context.Background(), path, "new")
+			var conflict *ConflictError
+			require.Equal(t, tt.wantConflict, errors.As(err, &conflict), "unexpected error: %v", err)
+			if !tt.wantConflict {
+				require.NoError(t, err)
+			}
+
+			claim, err := client.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace).Get(context.Background(), claimName(path), metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHolder, claim.Data[ClusterKey])
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	path := logicalcluster.NewPath("root:org:team")
+	client := kcpfakekubeclient.NewSimpleClientset()
+	c := &Claims{
+		rootKubeClusterClient: client,
+		logicalClusterExists:  func(clusterName logicalcluster.Name) bool { return true },
+		now:                   time.Now,
+	}
+
+	require.NoError(t, c.Claim(context.Background(), path, "holder"))
+
+	require.NoError(t, c.Release(context.Background(), path, "other"))
+	_, err := client.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace).Get(context.Background(), claimName(path), metav1.GetOptions{})
+	require.NoError(t, err, "expected the claim of another logical cluster to be kept")
+
+	require.NoError(t, c.Release(context.Background(), path, "holder"))
+	_, err = client.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace).Get(context.Background(), claimName(path), metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected the claim to be released")
+
+	require.NoError(t, c.Release(context.Background(), path, "holder"), "expected releasing twice to succeed")
+}
@@ -20,18 +20,23 @@ import (
 	"context"
 	"fmt"
 
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/apis/apis"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1/permissionclaims"
-	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1/permissionclaims"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 // Labeler calculates labels to apply to all instances of a cluster-group-resource based on permission claims.
@@ -39,12 +44,14 @@ type Labeler struct {
 	listAPIBindingsAcceptingClaimedGroupResource func(clusterName logicalcluster.Name, groupResource schema.GroupResource) ([]*apisv1alpha1.APIBinding, error)
 	getAPIBinding                                func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error)
 	getAPIExport                                 func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getNamespace                                 func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error)
 }
 
 // NewLabeler returns a new Labeler.
 func NewLabeler(
 	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
 	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
 ) *Labeler {
 	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
@@ -52,8 +59,8 @@ func NewLabeler(
 
 	return &Labeler{
 		listAPIBindingsAcceptingClaimedGroupResource: func(clusterName logicalcluster.Name, groupResource schema.GroupResource) ([]*apisv1alpha1.APIBinding, error) {
-			indexKey := indexers.ClusterAndGroupResourceValue(clusterName, groupResource)
-			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexers.APIBindingByClusterAndAcceptedClaimedGroupResources, indexKey)
+			indexKey := kcpindexers.ClusterAndGroupResourceValue(clusterName, groupResource)
+			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), kcpindexers.APIBindingByClusterAndAcceptedClaimedGroupResources, indexKey)
 		},
 
 		getAPIBinding: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error) {
@@ -62,14 +69,18 @@ func NewLabeler(
 		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 			return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
 		},
+		getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+			return namespaceInformer.Lister().Cluster(clusterName).Get(name)
+		},
 	}
 }
 
 // LabelsFor returns all the applicable labels for the cluster-group-resource relating to permission claims. This is
 // the intersection of (1) all APIBindings in the cluster that have accepted claims for the group-resource with (2)
-// associated APIExports that are claiming group-resource.
-func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, groupResource schema.GroupResource, resourceName string) (map[string]string, error) {
-	labels := map[string]string{}
+// associated APIExports that are claiming group-resource, restricted to the claims whose resource selectors select
+// the object.
+func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, groupResource schema.GroupResource, namespace, resourceName string) (map[string]string, error) {
+	claimLabels := map[string]string{}
 
 	bindings, err := l.listAPIBindingsAcceptingClaimedGroupResource(cluster, groupResource)
 	if err != nil {
@@ -78,10 +89,26 @@ func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, gr
 
 	logger := klog.FromContext(ctx)
 
+	var namespaceLabels labels.Labels
+	getNamespaceLabels := func() (labels.Labels, error) {
+		if namespaceLabels != nil || namespace == "" {
+			return namespaceLabels, nil
+		}
+		ns, err := l.getNamespace(cluster, namespace)
+		if apierrors.IsNotFound(err) {
+			namespaceLabels = labels.Set{}
+			return namespaceLabels, nil
+		} else if err != nil {
+			return nil, err
+		}
+		namespaceLabels = labels.Set(ns.Labels)
+		return namespaceLabels, nil
+	}
+
 	for _, binding := range bindings {
 		logger := logging.WithObject(logger, binding)
 
-		path := logicalcluster.NewPath(binding.Spec.Reference.Export.Path)
+		path := binding.Spec.Reference.Export.Path.Path()
 		if path.Empty() {
 			path = logicalcluster.From(binding).Path()
 		}
@@ -95,6 +122,17 @@ func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, gr
 				continue
 			}
 
+			nsLabels, err := getNamespaceLabels()
+			if err != nil {
+				return nil, fmt.Errorf("error getting namespace %q in %q: %w", namespace, cluster, err)
+			}
+			if selected, err := Selects(claim.PermissionClaim, namespace, resourceName, nsLabels); err != nil {
+				logger.Error(err, "error evaluating permission claim resource selectors", "claim", claim.String())
+				continue
+			} else if !selected {
+				continue
+			}
+
 			k, v, err := permissionclaims.ToLabelKeyAndValue(logicalcluster.From(export), export.Name, claim.PermissionClaim)
 			if err != nil {
 				// extremely unlikely to get an error here - it means the json marshaling failed
@@ -102,7 +140,7 @@ func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, gr
 					"claim", claim.String())
 				continue
 			}
-			labels[k] = v
+			claimLabels[k] = v
 		}
 	}
 
@@ -113,21 +151,21 @@ func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, gr
 		binding, err := l.getAPIBinding(cluster, resourceName)
 		if err != nil {
 			logger.Error(err, "error getting APIBinding", "bindingName", resourceName)
-			return labels, nil // can only be a NotFound
+			return claimLabels, nil // can only be a NotFound
 		}
 
-		path := logicalcluster.NewPath(binding.Spec.Reference.Export.Path)
+		path := binding.Spec.Reference.Export.Path.Path()
 		if path.Empty() {
 			path = logicalcluster.From(binding).Path()
 		}
 		export, err := l.getAPIExport(path, binding.Spec.Reference.Export.Name)
 		if err == nil {
 			k, v := permissionclaims.ToReflexiveAPIBindingLabelKeyAndValue(logicalcluster.From(export), binding.Spec.Reference.Export.Name)
-			if _, found := labels[k]; !found {
-				labels[k] = v
+			if _, found := claimLabels[k]; !found {
+				claimLabels[k] = v
 			}
 		}
 	}
 
-	return labels, nil
+	return claimLabels, nil
 }
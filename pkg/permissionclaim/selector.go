@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaim
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// Selects returns true if the claim applies to the object with the given namespace and name,
+// where namespaceLabels are the labels of the object's namespace.
+func Selects(claim apisv1alpha1.PermissionClaim, namespace, name string, namespaceLabels labels.Labels) (bool, error) {
+	if claim.All || len(claim.ResourceSelector) == 0 {
+		return true, nil
+	}
+
+	for _, s := range claim.ResourceSelector {
+		if s.Name != "" && s.Name != name {
+			continue
+		}
+		if s.Namespace != "" && s.Namespace != namespace {
+			continue
+		}
+		if s.NamespaceSelector != nil {
+			if namespace == "" {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+			if err != nil {
+				return false, err
+			}
+			if !selector.Matches(namespaceLabels) {
+				continue
+			}
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// MaySelect returns true if the claim might apply to objects addressed by a request for the
+// given namespace and name. An empty namespace or name addresses all namespaces or names.
+// Namespace selectors are not evaluated, as they are enforced by labelling the claimed objects.
+func MaySelect(claim apisv1alpha1.PermissionClaim, namespace, name string) bool {
+	if claim.All || len(claim.ResourceSelector) == 0 {
+		return true
+	}
+
+	for _, s := range claim.ResourceSelector {
+		if s.Name != "" && name != "" && s.Name != name {
+			continue
+		}
+		if s.Namespace != "" && namespace != "" && s.Namespace != namespace {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestSelects(t *testing.T) {
+	serviceNamespaces := &metav1.LabelSelector{MatchLabels: map[string]string{"service": "foo"}}
+
+	testCases := []struct {
+		name            string
+		selectors       []apisv1alpha1.ResourceSelector
+		all             bool
+		namespace, obj  string
+		namespaceLabels labels.Set
+		want            bool
+	}{
+		{name: "all", all: true, namespace: "default", obj: "any", want: true},
+		{name: "matching name", selectors: []apisv1alpha1.ResourceSelector{{Name: "config-foo"}}, namespace: "default", obj: "config-foo", want: true},
+		{name: "other name", selectors: []apisv1alpha1.ResourceSelector{{Name: "config-foo"}}, namespace: "default", obj: "config-bar", want: false},
+		{name: "matching namespace", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default"}}, namespace: "default", obj: "any", want: true},
+		{name: "other namespace", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default"}}, namespace: "kube-system", obj: "any", want: false},
+		{name: "matching namespace labels", selectors: []apisv1alpha1.ResourceSelector{{NamespaceSelector: serviceNamespaces}}, namespace: "svc", obj: "any", namespaceLabels: labels.Set{"service": "foo"}, want: true},
+		{name: "other namespace labels", selectors: []apisv1alpha1.ResourceSelector{{NamespaceSelector: serviceNamespaces}}, namespace: "svc", obj: "any", namespaceLabels: labels.Set{"service": "bar"}, want: false},
+		{name: "namespace selector on cluster-scoped object", selectors: []apisv1alpha1.ResourceSelector{{NamespaceSelector: serviceNamespaces}}, obj: "any", want: false},
+		{name: "second selector matches", selectors: []apisv1alpha1.ResourceSelector{{Name: "config-foo"}, {Namespace: "default"}}, namespace: "default", obj: "any", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claim := apisv1alpha1.PermissionClaim{
+				GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+				All:              tc.all,
+				ResourceSelector: tc.selectors,
+			}
+			got, err := Selects(claim, tc.namespace, tc.obj, tc.namespaceLabels)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMaySelect(t *testing.T) {
+	testCases := []struct {
+		name           string
+		selectors      []apisv1alpha1.ResourceSelector
+		namespace, obj string
+		want           bool
+	}{
+		{name: "list across namespaces", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "config-foo"}}, want: true},
+		{name: "list in matching namespace", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "config-foo"}}, namespace: "default", want: true},
+		{name: "list in other namespace", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "config-foo"}}, namespace: "kube-system", want: false},
+		{name: "get matching name", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "config-foo"}}, namespace: "default", obj: "config-foo", want: true},
+		{name: "get other name", selectors: []apisv1alpha1.ResourceSelector{{Namespace: "default", Name: "config-foo"}}, namespace: "default", obj: "config-bar", want: false},
+		{name: "namespace selector is not evaluated", selectors: []apisv1alpha1.ResourceSelector{{NamespaceSelector: &metav1.LabelSelector{}}}, namespace: "default", obj: "any", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claim := apisv1alpha1.PermissionClaim{
+				GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+				ResourceSelector: tc.selectors,
+			}
+			require.Equal(t, tc.want, MaySelect(claim, tc.namespace, tc.obj))
+		})
+	}
+}
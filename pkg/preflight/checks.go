@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// The names of the checks.
+const (
+	ShardVersionsCheck   = "ShardVersions"
+	StorageVersionsCheck = "StorageVersions"
+	MigrationsCheck      = "Migrations"
+	DeprecationsCheck    = "Deprecations"
+	CacheServerCheck     = "CacheServer"
+)
+
+// deprecatedAPIRequestsMetric is the apiserver metric recording requests to deprecated APIs.
+const deprecatedAPIRequestsMetric = "apiserver_requested_deprecated_apis"
+
+var metricLabelRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// checkShardVersions checks that every shard can be upgraded to the target version directly.
+func checkShardVersions(targetVersion string, versions map[string]string) Result {
+	result := Result{Check: ShardVersionsCheck, Status: StatusPass, Message: "all shards can be upgraded to the target version"}
+
+	target, err := kcpVersion(targetVersion)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("invalid target version %q: %v", targetVersion, err)
+		return result
+	}
+
+	for _, shard := range sets.StringKeySet(versions).List() {
+		if versions[shard] == "" {
+			continue
+		}
+		current, err := kcpVersion(versions[shard])
+		if err != nil {
+			result.Details = append(result.Details, fmt.Sprintf("shard %q: invalid version %q: %v", shard, versions[shard], err))
+			continue
+		}
+		if msg := checkSkew(current, target); msg != "" {
+			result.Details = append(result.Details, fmt.Sprintf("shard %q: %s", shard, msg))
+		}
+	}
+	if len(result.Details) > 0 {
+		result.Status = StatusFail
+		result.Message = "shards cannot be upgraded to the target version directly"
+	}
+
+	return result
+}
+
+// checkStorageVersions checks that the objects of the system CRDs of every shard are stored in
+// versions that are served by the target version.
+func checkStorageVersions(targetCRDs []*apiextensionsv1.CustomResourceDefinition, crds map[string][]apiextensionsv1.CustomResourceDefinition) Result {
+	result := Result{Check: StorageVersionsCheck, Status: StatusPass, Message: "all system CRDs are stored in versions served by the target version"}
+
+	served := map[string]sets.String{}
+	for _, crd := range targetCRDs {
+		served[crd.Name] = sets.NewString()
+		for _, v := range crd.Spec.Versions {
+			if v.Served {
+				served[crd.Name].Insert(v.Name)
+			}
+		}
+	}
+
+	var fail, pending []string
+	for _, shard := range sets.StringKeySet(crds).List() {
+		for _, crd := range crds[shard] {
+			targetServed, found := served[crd.Name]
+			if !found {
+				continue
+			}
+			for _, v := range crd.Status.StoredVersions {
+				if !targetServed.Has(v) {
+					fail = append(fail, fmt.Sprintf("shard %q: %s has objects stored in version %s, which the target version does not serve", shard, crd.Name, v))
+				}
+			}
+			if len(crd.Status.StoredVersions) > 1 {
+				pending = append(pending, fmt.Sprintf("shard %q: %s has objects stored in versions %s; rewrite them in the storage version and remove the old versions from status.storedVersions", shard, crd.Name, strings.Join(crd.Status.StoredVersions, ", ")))
+			}
+		}
+	}
+
+	switch {
+	case len(fail) > 0:
+		result.Status = StatusFail
+		result.Message = "objects are stored in versions the target version does not serve"
+	case len(pending) > 0:
+		result.Status = StatusWarning
+		result.Message = "storage version migrations are pending"
+	}
+	result.Details = append(fail, pending...)
+
+	return result
+}
+
+// checkMigrations checks for APIExport migrations in progress.
+func checkMigrations(exports map[string][]apisv1alpha1.APIExport) Result {
+	result := Result{Check: MigrationsCheck, Status: StatusPass, Message: "no APIExport migrations in progress"}
+
+	for _, shard := range sets.StringKeySet(exports).List() {
+		for _, export := range exports[shard] {
+			if export.Spec.Migration == nil || (export.Status.Migration != nil && export.Status.Migration.CompletionTime != nil) {
+				continue
+			}
+			clusterName := logicalcluster.From(&export)
+			targetPath := logicalcluster.NewPath(string(export.Spec.Migration.Target.Path))
+			if targetPath.Empty() {
+				targetPath = clusterName.Path()
+			}
+			detail := fmt.Sprintf("shard %q: APIExport %s|%s is migrating its consumers to %s|%s", shard, clusterName, export.Name, targetPath, export.Spec.Migration.Target.Name)
+			if export.Status.Migration != nil {
+				detail += fmt.Sprintf(", %d APIBindings remaining", export.Status.Migration.RemainingAPIBindings)
+			}
+			result.Details = append(result.Details, detail)
+		}
+	}
+	if len(result.Details) > 0 {
+		result.Status = StatusWarning
+		result.Message = "APIExport migrations are in progress and continue after the upgrade"
+	}
+
+	return result
+}
+
+// checkDeprecations checks for APIBindings to deprecated APIExports, and for requests to deprecated
+// APIs recorded in the metrics of the shards. Requests to APIs removed in the target version fail.
+func checkDeprecations(targetVersion string, bindings map[string][]apisv1alpha1.APIBinding, metrics map[string][]byte) Result {
+	result := Result{Check: DeprecationsCheck, Status: StatusPass, Message: "no deprecated APIs in use"}
+
+	var fail, warn []string
+	for _, shard := range sets.StringKeySet(bindings).List() {
+		counts := map[string]int{}
+		for i := range bindings[shard] {
+			binding := &bindings[shard][i]
+			if binding.Spec.Reference.Export == nil || !conditions.IsFalse(binding, apisv1alpha1.APIExportNotDeprecated) {
+				continue
+			}
+			path := logicalcluster.NewPath(string(binding.Spec.Reference.Export.Path))
+			if path.Empty() {
+				path = logicalcluster.From(binding).Path()
+			}
+			counts[fmt.Sprintf("%s|%s", path, binding.Spec.Reference.Export.Name)]++
+		}
+		for _, export := range sets.StringKeySet(counts).List() {
+			warn = append(warn, fmt.Sprintf("shard %q: %d APIBindings to the deprecated APIExport %s", shard, counts[export], export))
+		}
+	}
+
+	target, _ := kubeVersion(targetVersion)
+	for _, shard := range sets.StringKeySet(metrics).List() {
+		for _, request := range parseDeprecatedAPIRequests(metrics[shard]) {
+			api := fmt.Sprintf("%s %s", request.gv, request.resource)
+			if request.removedRelease == "" {
+				warn = append(warn, fmt.Sprintf("shard %q: requests to the deprecated API %s", shard, api))
+				continue
+			}
+			removed, err := utilversion.ParseGeneric(request.removedRelease)
+			if err == nil && target != nil && !target.LessThan(removed) {
+				fail = append(fail, fmt.Sprintf("shard %q: requests to the API %s, which is removed in %s", shard, api, request.removedRelease))
+				continue
+			}
+			warn = append(warn, fmt.Sprintf("shard %q: requests to the deprecated API %s, which will be removed in %s", shard, api, request.removedRelease))
+		}
+	}
+
+	switch {
+	case len(fail) > 0:
+		result.Status = StatusFail
+		result.Message = "APIs removed in the target version are in use"
+	case len(warn) > 0:
+		result.Status = StatusWarning
+		result.Message = "deprecated APIs are in use"
+	}
+	result.Details = append(fail, warn...)
+
+	return result
+}
+
+// checkCacheServer checks that the cache server is reachable and compatible with the target version.
+func checkCacheServer(targetVersion, version string, err error) Result {
+	result := Result{Check: CacheServerCheck, Status: StatusPass}
+
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to get the version of the cache server: %v", err)
+		return result
+	}
+	target, err := kcpVersion(targetVersion)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("invalid target version %q: %v", targetVersion, err)
+		return result
+	}
+	current, err := kcpVersion(version)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("invalid cache server version %q: %v", version, err)
+		return result
+	}
+	if msg := checkSkew(current, target); msg != "" {
+		result.Status = StatusFail
+		result.Message = "the cache server is incompatible with the target version: " + msg
+		return result
+	}
+
+	result.Message = fmt.Sprintf("the cache server version %s is compatible with the target version", version)
+	return result
+}
+
+// checkSkew returns why current cannot be upgraded to target directly, or an empty string.
+func checkSkew(current, target *utilversion.Version) string {
+	switch {
+	case current.Major() != target.Major():
+		return fmt.Sprintf("upgrading from %s to %s changes the major version", current, target)
+	case current.Minor() > target.Minor():
+		return fmt.Sprintf("downgrading from %s to %s is not supported", current, target)
+	case target.Minor()-current.Minor() > 1:
+		return fmt.Sprintf("upgrading from %s to %s skips minor versions, upgrade one minor version at a time", current, target)
+	}
+	return ""
+}
+
+// kcpVersion returns the kcp version of a version.Info.GitVersion like v1.26.3+kcp-v0.11.0.
+func kcpVersion(gitVersion string) (*utilversion.Version, error) {
+	if _, kcp, found := strings.Cut(gitVersion, "+kcp-"); found {
+		gitVersion = kcp
+	}
+	return utilversion.ParseGeneric(gitVersion)
+}
+
+// kubeVersion returns the Kubernetes version of a version.Info.GitVersion like v1.26.3+kcp-v0.11.0.
+func kubeVersion(gitVersion string) (*utilversion.Version, error) {
+	kube, _, _ := strings.Cut(gitVersion, "+")
+	return utilversion.ParseGeneric(kube)
+}
+
+type deprecatedAPIRequest struct {
+	gv             schema.GroupVersion
+	resource       string
+	removedRelease string
+}
+
+// parseDeprecatedAPIRequests returns the requested deprecated APIs from the metrics of a shard in the
+// Prometheus text format.
+func parseDeprecatedAPIRequests(metrics []byte) []deprecatedAPIRequest {
+	var requests []deprecatedAPIRequest
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, deprecatedAPIRequestsMetric+"{") {
+			continue
+		}
+		end := strings.LastIndex(line, "}")
+		if end < 0 {
+			continue
+		}
+		if value, err := strconv.ParseFloat(strings.TrimSpace(line[end+1:]), 64); err != nil || value == 0 {
+			continue
+		}
+		labels := map[string]string{}
+		for _, match := range metricLabelRegexp.FindAllStringSubmatch(line[:end], -1) {
+			labels[match[1]] = match[2]
+		}
+		requests = append(requests, deprecatedAPIRequest{
+			gv:             schema.GroupVersion{Group: labels["group"], Version: labels["version"]},
+			resource:       labels["resource"],
+			removedRelease: labels["removed_release"],
+		})
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].gv.String()+"/"+requests[i].resource < requests[j].gv.String()+"/"+requests[j].resource
+	})
+	return requests
+}
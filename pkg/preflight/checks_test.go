@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestCheckShardVersions(t *testing.T) {
+	tests := map[string]struct {
+		target      string
+		versions    map[string]string
+		wantStatus  Status
+		wantDetails []string
+	}{
+		"same minor": {
+			target:     "v1.26.3+kcp-v0.11.2",
+			versions:   map[string]string{"root": "v1.26.3+kcp-v0.11.0"},
+			wantStatus: StatusPass,
+		},
+		"next minor": {
+			target:     "v1.26.3+kcp-v0.12.0",
+			versions:   map[string]string{"root": "v1.26.3+kcp-v0.11.0", "beta": "v1.26.3+kcp-v0.12.0-3-g0123456789abcd"},
+			wantStatus: StatusPass,
+		},
+		"shard unreachable": {
+			target:     "v1.26.3+kcp-v0.12.0",
+			versions:   map[string]string{"root": ""},
+			wantStatus: StatusPass,
+		},
+		"skipping a minor": {
+			target:      "v1.26.3+kcp-v0.12.0",
+			versions:    map[string]string{"root": "v1.26.3+kcp-v0.12.0", "beta": "v1.26.3+kcp-v0.10.1"},
+			wantStatus:  StatusFail,
+			wantDetails: []string{`shard "beta": upgrading from 0.10.1 to 0.12.0 skips minor versions, upgrade one minor version at a time`},
+		},
+		"downgrade": {
+			target:      "v1.26.3+kcp-v0.11.0",
+			versions:    map[string]string{"root": "v1.26.3+kcp-v0.12.0"},
+			wantStatus:  StatusFail,
+			wantDetails: []string{`shard "root": downgrading from 0.12.0 to 0.11.0 is not supported`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := checkShardVersions(tt.target, tt.versions)
+			require.Equal(t, tt.wantStatus, result.Status)
+			require.Equal(t, tt.wantDetails, result.Details)
+		})
+	}
+}
+
+func TestCheckStorageVersions(t *testing.T) {
+	target := []*apiextensionsv1.CustomResourceDefinition{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "apibindings.apis.kcp.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1alpha1", Served: false},
+					{Name: "v1alpha2", Served: true, Storage: true},
+				},
+			},
+		},
+	}
+	crd := func(storedVersions ...string) apiextensionsv1.CustomResourceDefinition {
+		return apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "apibindings.apis.kcp.io"},
+			Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+		}
+	}
+
+	tests := map[string]struct {
+		crds        map[string][]apiextensionsv1.CustomResourceDefinition
+		wantStatus  Status
+		wantDetails []string
+	}{
+		"stored in served version": {
+			crds:       map[string][]apiextensionsv1.CustomResourceDefinition{"root": {crd("v1alpha2")}},
+			wantStatus: StatusPass,
+		},
+		"unknown CRD": {
+			crds: map[string][]apiextensionsv1.CustomResourceDefinition{"root": {{
+				ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+				Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1"}},
+			}}},
+			wantStatus: StatusPass,
+		},
+		"stored in version not served anymore": {
+			crds:       map[string][]apiextensionsv1.CustomResourceDefinition{"root": {crd("v1alpha2")}, "beta": {crd("v1alpha1")}},
+			wantStatus: StatusFail,
+			wantDetails: []string{
+				`shard "beta": apibindings.apis.kcp.io has objects stored in version v1alpha1, which the target version does not serve`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := checkStorageVersions(target, tt.crds)
+			require.Equal(t, tt.wantStatus, result.Status)
+			require.Equal(t, tt.wantDetails, result.Details)
+		})
+	}
+}
+
+func TestCheckDeprecations(t *testing.T) {
+	deprecated := apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: map[string]string{"kcp.io/cluster": "consumer"}},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{Export: &apisv1alpha1.ExportBindingReference{Path: "root:org", Name: "foo"}},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			Conditions: conditionsv1alpha1.Conditions{*conditions.FalseCondition(apisv1alpha1.APIExportNotDeprecated, apisv1alpha1.APIExportDeprecatedReason, conditionsv1alpha1.ConditionSeverityWarning, "")},
+		},
+	}
+	metrics := []byte(`# HELP apiserver_requested_deprecated_apis [STABLE] Gauge of deprecated APIs that have been requested, broken out by API group, version, resource, subresource, and removed_release.
+# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="flowcontrol.apiserver.k8s.io",removed_release="1.26",resource="flowschemas",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="flowcontrol.apiserver.k8s.io",removed_release="1.29",resource="prioritylevelconfigurations",subresource="",version="v1beta2"} 1
+apiserver_request_total{code="200",verb="GET"} 12
+`)
+
+	result := checkDeprecations("v1.26.3+kcp-v0.12.0", map[string][]apisv1alpha1.APIBinding{"root": {deprecated, deprecated}}, map[string][]byte{"root": metrics})
+	require.Equal(t, StatusFail, result.Status)
+	require.Equal(t, []string{
+		`shard "root": requests to the API flowcontrol.apiserver.k8s.io/v1beta1 flowschemas, which is removed in 1.26`,
+		`shard "root": 2 APIBindings to the deprecated APIExport root:org|foo`,
+		`shard "root": requests to the deprecated API flowcontrol.apiserver.k8s.io/v1beta2 prioritylevelconfigurations, which will be removed in 1.29`,
+	}, result.Details)
+
+	result = checkDeprecations("v1.26.3+kcp-v0.12.0", map[string][]apisv1alpha1.APIBinding{"root": {deprecated}}, nil)
+	require.Equal(t, StatusWarning, result.Status)
+
+	result = checkDeprecations("v1.26.3+kcp-v0.12.0", nil, map[string][]byte{"root": []byte("apiserver_request_total 3\n")})
+	require.Equal(t, StatusPass, result.Status)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight checks whether a kcp installation can be upgraded to a target version.
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kcpapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/kcp/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/server"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPass means that the check found nothing blocking the upgrade.
+	StatusPass Status = "Pass"
+	// StatusWarning means that the upgrade can proceed, but something needs attention.
+	StatusWarning Status = "Warning"
+	// StatusFail means that the upgrade must not proceed.
+	StatusFail Status = "Fail"
+)
+
+// Result is the result of a single check.
+type Result struct {
+	Check   string   `json:"check"`
+	Status  Status   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the result of all checks.
+type Report struct {
+	TargetVersion string   `json:"targetVersion"`
+	Results       []Result `json:"results"`
+}
+
+// Go returns whether the upgrade can proceed, i.e. no check failed.
+func (r *Report) Go() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes a human readable version of the report to w.
+func (r *Report) Print(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Preflight checks for an upgrade to %s:\n\n", r.TargetVersion)
+	for _, result := range r.Results {
+		fmt.Fprintf(&b, "[%s] %s: %s\n", result.Status, result.Check, result.Message)
+		for _, detail := range result.Details {
+			fmt.Fprintf(&b, "  - %s\n", detail)
+		}
+	}
+	if r.Go() {
+		b.WriteString("\nGO: the upgrade can proceed.\n")
+	} else {
+		b.WriteString("\nNO-GO: resolve the failed checks before upgrading.\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// PrintJSON writes the report as JSON to w.
+func (r *Report) PrintJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		*Report
+		Go bool `json:"go"`
+	}{r, r.Go()})
+}
+
+// Checker runs the checks against the shards of a kcp installation.
+type Checker struct {
+	// targetVersion is the version to upgrade to, in the format of version.Info.GitVersion.
+	targetVersion string
+	// targetCRDs are the system CRDs of the target version.
+	targetCRDs []*apiextensionsv1.CustomResourceDefinition
+
+	listShards            func(ctx context.Context) ([]corev1alpha1.Shard, error)
+	getShardVersion       func(ctx context.Context, shard *corev1alpha1.Shard) (string, error)
+	listSystemCRDs        func(ctx context.Context, shard *corev1alpha1.Shard) ([]apiextensionsv1.CustomResourceDefinition, error)
+	listAPIExports        func(ctx context.Context, shard *corev1alpha1.Shard) ([]apisv1alpha1.APIExport, error)
+	listAPIBindings       func(ctx context.Context, shard *corev1alpha1.Shard) ([]apisv1alpha1.APIBinding, error)
+	getShardMetrics       func(ctx context.Context, shard *corev1alpha1.Shard) ([]byte, error)
+	getCacheServerVersion func(ctx context.Context) (string, error)
+}
+
+// NewChecker returns a checker for an upgrade to targetVersion with the given system CRDs. The
+// config must be an admin config of the root shard, which is used for all shards by replacing the
+// host with the base URL of the shard. The cacheServerConfig is optional.
+func NewChecker(config, cacheServerConfig *rest.Config, targetVersion string, targetCRDs []*apiextensionsv1.CustomResourceDefinition) (*Checker, error) {
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	shardConfig := func(shard *corev1alpha1.Shard) *rest.Config {
+		shardConfig := rest.CopyConfig(config)
+		shardConfig.Host = shard.Spec.BaseURL
+		return shardConfig
+	}
+
+	c := &Checker{
+		targetVersion: targetVersion,
+		targetCRDs:    targetCRDs,
+
+		listShards: func(ctx context.Context) ([]corev1alpha1.Shard, error) {
+			shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return shards.Items, nil
+		},
+		getShardVersion: func(ctx context.Context, shard *corev1alpha1.Shard) (string, error) {
+			client, err := kubernetes.NewForConfig(shardConfig(shard))
+			if err != nil {
+				return "", err
+			}
+			info, err := client.Discovery().ServerVersion()
+			if err != nil {
+				return "", err
+			}
+			return info.GitVersion, nil
+		},
+		listSystemCRDs: func(ctx context.Context, shard *corev1alpha1.Shard) ([]apiextensionsv1.CustomResourceDefinition, error) {
+			client, err := kcpapiextensionsclientset.NewForConfig(shardConfig(shard))
+			if err != nil {
+				return nil, err
+			}
+			crds, err := client.Cluster(server.SystemCRDClusterName.Path()).ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return crds.Items, nil
+		},
+		listAPIExports: func(ctx context.Context, shard *corev1alpha1.Shard) ([]apisv1alpha1.APIExport, error) {
+			client, err := kcpclientset.NewForConfig(shardConfig(shard))
+			if err != nil {
+				return nil, err
+			}
+			exports, err := client.ApisV1alpha1().APIExports().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return exports.Items, nil
+		},
+		listAPIBindings: func(ctx context.Context, shard *corev1alpha1.Shard) ([]apisv1alpha1.APIBinding, error) {
+			client, err := kcpclientset.NewForConfig(shardConfig(shard))
+			if err != nil {
+				return nil, err
+			}
+			bindings, err := client.ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return bindings.Items, nil
+		},
+		getShardMetrics: func(ctx context.Context, shard *corev1alpha1.Shard) ([]byte, error) {
+			client, err := kubernetes.NewForConfig(shardConfig(shard))
+			if err != nil {
+				return nil, err
+			}
+			return client.Discovery().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+		},
+	}
+
+	if cacheServerConfig != nil {
+		cacheClient, err := kubernetes.NewForConfig(cacheServerConfig)
+		if err != nil {
+			return nil, err
+		}
+		c.getCacheServerVersion = func(ctx context.Context) (string, error) {
+			info, err := cacheClient.Discovery().ServerVersion()
+			if err != nil {
+				return "", err
+			}
+			return info.GitVersion, nil
+		}
+	}
+
+	return c, nil
+}
+
+// Run runs all checks and returns the report. Errors talking to a shard fail the checks
+// depending on it.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{TargetVersion: c.targetVersion}
+
+	shards, err := c.listShards(ctx)
+	if err != nil {
+		report.Results = append(report.Results, Result{
+			Check:   ShardVersionsCheck,
+			Status:  StatusFail,
+			Message: fmt.Sprintf("failed to list shards: %v", err),
+		})
+		return report
+	}
+
+	versions := map[string]string{}
+	crds := map[string][]apiextensionsv1.CustomResourceDefinition{}
+	exports := map[string][]apisv1alpha1.APIExport{}
+	bindings := map[string][]apisv1alpha1.APIBinding{}
+	metrics := map[string][]byte{}
+	errs := map[string][]string{}
+	for i := range shards {
+		shard := &shards[i]
+		var err error
+		if versions[shard.Name], err = c.getShardVersion(ctx, shard); err != nil {
+			errs[ShardVersionsCheck] = append(errs[ShardVersionsCheck], fmt.Sprintf("shard %q: failed to get version: %v", shard.Name, err))
+		}
+		if crds[shard.Name], err = c.listSystemCRDs(ctx, shard); err != nil {
+			errs[StorageVersionsCheck] = append(errs[StorageVersionsCheck], fmt.Sprintf("shard %q: failed to list system CRDs: %v", shard.Name, err))
+		}
+		if exports[shard.Name], err = c.listAPIExports(ctx, shard); err != nil {
+			errs[MigrationsCheck] = append(errs[MigrationsCheck], fmt.Sprintf("shard %q: failed to list APIExports: %v", shard.Name, err))
+		}
+		if bindings[shard.Name], err = c.listAPIBindings(ctx, shard); err != nil {
+			errs[DeprecationsCheck] = append(errs[DeprecationsCheck], fmt.Sprintf("shard %q: failed to list APIBindings: %v", shard.Name, err))
+		}
+		if metrics[shard.Name], err = c.getShardMetrics(ctx, shard); err != nil {
+			errs[DeprecationsCheck] = append(errs[DeprecationsCheck], fmt.Sprintf("shard %q: failed to get metrics: %v", shard.Name, err))
+		}
+	}
+
+	report.Results = append(report.Results,
+		withErrors(checkShardVersions(c.targetVersion, versions), errs[ShardVersionsCheck]),
+		withErrors(checkStorageVersions(c.targetCRDs, crds), errs[StorageVersionsCheck]),
+		withErrors(checkMigrations(exports), errs[MigrationsCheck]),
+		withErrors(checkDeprecations(c.targetVersion, bindings, metrics), errs[DeprecationsCheck]),
+	)
+
+	if c.getCacheServerVersion == nil {
+		report.Results = append(report.Results, Result{
+			Check:   CacheServerCheck,
+			Status:  StatusWarning,
+			Message: "not checked, no cache server kubeconfig given",
+		})
+	} else {
+		version, err := c.getCacheServerVersion(ctx)
+		report.Results = append(report.Results, checkCacheServer(c.targetVersion, version, err))
+	}
+
+	return report
+}
+
+// withErrors fails the result if there were errors collecting its input.
+func withErrors(result Result, errs []string) Result {
+	if len(errs) == 0 {
+		return result
+	}
+	result.Status = StatusFail
+	result.Message = "failed to check all shards"
+	result.Details = append(errs, result.Details...)
+	return result
+}
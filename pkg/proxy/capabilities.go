@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	componentbaseversion "k8s.io/component-base/version"
+
+	proxyoptions "github.com/kcp-dev/kcp/pkg/proxy/options"
+)
+
+// Capabilities is served by the front-proxy for clients to probe which client versions are supported
+// before they run into version skew problems.
+type Capabilities struct {
+	// ServerVersion is the version of the front-proxy.
+	ServerVersion string `json:"serverVersion"`
+	// ClientVersionPolicy is how requests of clients older than their minimum version are treated.
+	ClientVersionPolicy string `json:"clientVersionPolicy"`
+	// MinimumClientVersions are the minimum versions of clients, by the name in their user agent.
+	MinimumClientVersions map[string]string `json:"minimumClientVersions,omitempty"`
+}
+
+func newCapabilitiesHandler(o *proxyoptions.Options) (http.Handler, error) {
+	data, err := json.Marshal(Capabilities{
+		ServerVersion:         componentbaseversion.Get().GitVersion,
+		ClientVersionPolicy:   o.ClientVersion.Policy,
+		MinimumClientVersions: o.ClientVersion.MinimumVersions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data) //nolint:errcheck
+	}), nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+// anonymousReadOnlyVerbs are the only verbs anonymous requests may use.
+var anonymousReadOnlyVerbs = sets.NewString("get", "list", "watch")
+
+// anonymousDiscoveryPrefixes are the non-resource paths anonymous requests may get, such that clients
+// can discover the allowed resources.
+var anonymousDiscoveryPrefixes = []string{"/api", "/apis", "/openapi", "/version"}
+
+// AnonymousAccess declares the workspaces which can be read without credentials, and what can be read.
+type AnonymousAccess struct {
+	// Workspaces are the logical cluster paths of the workspaces that allow anonymous access.
+	Workspaces sets.String
+	// Resources are the group resources that can be read anonymously.
+	Resources map[schema.GroupResource]bool
+	// NewRateLimiter creates the rate limiter of anonymous requests of one workspace.
+	NewRateLimiter func() flowcontrol.RateLimiter
+}
+
+// WithAnonymousAccess serves requests without credentials to the workspaces of the given AnonymousAccess
+// with the anonymous handler as the anonymous user, if they are read-only requests of allowed resources or
+// discovery. Other requests without credentials to these workspaces are passed to the failed handler, and
+// anonymous requests exceeding the rate limit of a workspace are rejected with 429. All other requests are
+// passed to the authenticated handler.
+func WithAnonymousAccess(authenticated, anonymous, failed http.Handler, access *AnonymousAccess, requestInfoResolver request.RequestInfoResolver) http.Handler {
+	if access == nil || access.Workspaces.Len() == 0 {
+		return authenticated
+	}
+
+	limiters := make(map[string]flowcontrol.RateLimiter, access.Workspaces.Len())
+	for _, ws := range access.Workspaces.List() {
+		limiters[ws] = access.NewRateLimiter()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if hasCredentials(req) {
+			authenticated.ServeHTTP(w, req)
+			return
+		}
+
+		clusterPath, rest, ok := splitClusterPath(req.URL.Path)
+		if !ok || !access.Workspaces.Has(clusterPath.String()) {
+			authenticated.ServeHTTP(w, req)
+			return
+		}
+
+		logger := klog.FromContext(req.Context()).WithValues("clusterPath", clusterPath)
+
+		// resolve the request info relative to the workspace, the front-proxy request info does not know about clusters
+		stripped := req.Clone(req.Context())
+		stripped.URL.Path = rest
+		info, err := requestInfoResolver.NewRequestInfo(stripped)
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+		if !access.allows(info) {
+			logger.V(4).Info("rejecting anonymous request", "verb", info.Verb, "path", rest)
+			failed.ServeHTTP(w, req)
+			return
+		}
+
+		if !limiters[clusterPath.String()].TryAccept() {
+			w.Header().Set("Retry-After", "1")
+			responsewriters.ErrorNegotiated(
+				apierrors.NewTooManyRequests(fmt.Sprintf("too many anonymous requests to workspace %s", clusterPath), 1),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		req = req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{
+			Name:   user.Anonymous,
+			Groups: []string{user.AllUnauthenticated},
+		}))
+		anonymous.ServeHTTP(w, req)
+	})
+}
+
+func hasCredentials(req *http.Request) bool {
+	return (req.TLS != nil && len(req.TLS.PeerCertificates) > 0) || req.Header.Get("Authorization") != ""
+}
+
+func (a *AnonymousAccess) allows(info *request.RequestInfo) bool {
+	if !anonymousReadOnlyVerbs.Has(info.Verb) {
+		return false
+	}
+
+	if !info.IsResourceRequest {
+		for _, prefix := range anonymousDiscoveryPrefixes {
+			if info.Path == prefix || strings.HasPrefix(info.Path, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if info.Subresource != "" {
+		return false
+	}
+	return a.Resources[schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}]
+}
+
+// splitClusterPath splits /clusters/<path>/<rest> into the logical cluster path and /<rest>.
+func splitClusterPath(urlPath string) (logicalcluster.Path, string, bool) {
+	cs := strings.SplitN(strings.TrimLeft(urlPath, "/"), "/", 3)
+	if len(cs) < 2 || cs[0] != "clusters" {
+		return logicalcluster.Path{}, "", false
+	}
+	clusterPath := logicalcluster.NewPath(cs[1])
+	if !clusterPath.IsValid() {
+		return logicalcluster.Path{}, "", false
+	}
+	rest := "/"
+	if len(cs) == 3 {
+		rest += cs[2]
+	}
+	return clusterPath, rest, true
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/kcp-dev/kcp/pkg/server/requestinfo"
+)
+
+func TestWithAnonymousAccess(t *testing.T) {
+	tests := map[string]struct {
+		method        string
+		path          string
+		authorization string
+		wantCode      int
+		wantAnonymous bool
+	}{
+		"list allowed resource": {
+			path:          "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports",
+			wantCode:      http.StatusOK,
+			wantAnonymous: true,
+		},
+		"get allowed resource": {
+			path:          "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports/foo",
+			wantCode:      http.StatusOK,
+			wantAnonymous: true,
+		},
+		"watch allowed resource": {
+			path:          "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports?watch=true",
+			wantCode:      http.StatusOK,
+			wantAnonymous: true,
+		},
+		"discovery": {
+			path:          "/clusters/root:catalog/apis",
+			wantCode:      http.StatusOK,
+			wantAnonymous: true,
+		},
+		"create allowed resource": {
+			method:   http.MethodPost,
+			path:     "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports",
+			wantCode: http.StatusUnauthorized,
+		},
+		"subresource of allowed resource": {
+			path:     "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports/foo/status",
+			wantCode: http.StatusUnauthorized,
+		},
+		"other resource": {
+			path:     "/clusters/root:catalog/api/v1/namespaces/default/secrets",
+			wantCode: http.StatusUnauthorized,
+		},
+		"other non-resource path": {
+			path:     "/clusters/root:catalog/metrics",
+			wantCode: http.StatusUnauthorized,
+		},
+		"other workspace": {
+			path:     "/clusters/root:private/apis/apis.kcp.io/v1alpha1/apiexports",
+			wantCode: http.StatusOK,
+		},
+		"with credentials": {
+			path:          "/clusters/root:catalog/api/v1/namespaces/default/secrets",
+			authorization: "Bearer token",
+			wantCode:      http.StatusOK,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var anonymous bool
+			authenticated := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+			anonymousHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				u, ok := request.UserFrom(req.Context())
+				require.True(t, ok)
+				require.Equal(t, user.Anonymous, u.GetName())
+				anonymous = true
+			})
+			failed := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+
+			handler := WithAnonymousAccess(authenticated, anonymousHandler, failed, &AnonymousAccess{
+				Workspaces: sets.NewString("root:catalog"),
+				Resources:  map[schema.GroupResource]bool{{Group: "apis.kcp.io", Resource: "apiexports"}: true},
+				NewRateLimiter: func() flowcontrol.RateLimiter {
+					return flowcontrol.NewFakeAlwaysRateLimiter()
+				},
+			}, requestinfo.NewFactory())
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tc.path, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tc.wantCode, w.Code)
+			require.Equal(t, tc.wantAnonymous, anonymous)
+		})
+	}
+}
+
+func TestWithAnonymousAccessRateLimit(t *testing.T) {
+	noop := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+	handler := WithAnonymousAccess(noop, noop, noop, &AnonymousAccess{
+		Workspaces: sets.NewString("root:catalog"),
+		Resources:  map[schema.GroupResource]bool{{Group: "apis.kcp.io", Resource: "apiexports"}: true},
+		NewRateLimiter: func() flowcontrol.RateLimiter {
+			return flowcontrol.NewFakeNeverRateLimiter()
+		},
+	}, requestinfo.NewFactory())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/root:catalog/apis/apis.kcp.io/v1alpha1/apiexports", nil))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+}
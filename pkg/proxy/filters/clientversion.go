@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/klog/v2"
+)
+
+// ClientVersionPolicy determines how the front-proxy treats clients older than their declared minimum version.
+type ClientVersionPolicy string
+
+const (
+	// ClientVersionPolicyIgnore passes requests of outdated clients through unchanged.
+	ClientVersionPolicyIgnore ClientVersionPolicy = "Ignore"
+	// ClientVersionPolicyWarn passes requests of outdated clients through, but adds a warning header to the response.
+	ClientVersionPolicyWarn ClientVersionPolicy = "Warn"
+	// ClientVersionPolicyReject rejects requests of outdated clients with a bad request error.
+	ClientVersionPolicyReject ClientVersionPolicy = "Reject"
+)
+
+// CapabilitiesPath is the path of the capabilities endpoint. It is always served, independent of the client version.
+const CapabilitiesPath = "/capabilities"
+
+var errorCodecs = func() serializer.CodecFactory {
+	scheme := runtime.NewScheme()
+	metav1.AddToGroupVersion(scheme, schema.GroupVersion{Group: "", Version: "v1"})
+	return serializer.NewCodecFactory(scheme)
+}()
+
+// WithClientVersionCheck checks the version of well-known clients, identified by the name in their user agent
+// (e.g. kubectl/v1.24.3), against the given minimum versions. Depending on the policy, requests of older clients
+// get a warning or are rejected. Clients without a declared minimum version or with an unparsable user agent
+// are passed through.
+func WithClientVersionCheck(handler http.Handler, policy ClientVersionPolicy, minimumVersions map[string]*utilversion.Version) http.Handler {
+	if policy == ClientVersionPolicyIgnore || len(minimumVersions) == 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == CapabilitiesPath {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		msg := checkClientVersion(req.UserAgent(), minimumVersions)
+		if msg == "" {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		if policy == ClientVersionPolicyReject {
+			klog.FromContext(req.Context()).V(4).Info("rejecting outdated client", "userAgent", req.UserAgent())
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest(msg), errorCodecs, schema.GroupVersion{}, w, req)
+			return
+		}
+
+		if header, err := utilnet.NewWarningHeader(299, "-", msg); err == nil {
+			w.Header().Add("Warning", header)
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// checkClientVersion returns an actionable message if the client identified by the user agent is older than its
+// minimum version, or an empty string otherwise.
+func checkClientVersion(userAgent string, minimumVersions map[string]*utilversion.Version) string {
+	name, rest, found := strings.Cut(userAgent, "/")
+	if !found {
+		return ""
+	}
+	minimum, ok := minimumVersions[name]
+	if !ok {
+		return ""
+	}
+
+	v, _, _ := strings.Cut(rest, " ")
+	clientVersion, err := utilversion.ParseGeneric(v)
+	if err != nil {
+		return ""
+	}
+	if clientVersion.AtLeast(minimum) {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s is not supported by this server, please upgrade to at least v%s (see %s for the supported clients)", name, v, minimum, CapabilitiesPath)
+}
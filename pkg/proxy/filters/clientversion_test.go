@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestWithClientVersionCheck(t *testing.T) {
+	minimumVersions := map[string]*utilversion.Version{
+		"kubectl": utilversion.MustParseGeneric("v1.23.0"),
+	}
+
+	tests := map[string]struct {
+		policy      ClientVersionPolicy
+		userAgent   string
+		path        string
+		wantCode    int
+		wantWarning bool
+	}{
+		"current kubectl": {
+			policy:    ClientVersionPolicyReject,
+			userAgent: "kubectl/v1.24.3 (linux/amd64) kubernetes/aef86a9",
+			wantCode:  http.StatusOK,
+		},
+		"unknown client": {
+			policy:    ClientVersionPolicyReject,
+			userAgent: "curl/7.68.0",
+			wantCode:  http.StatusOK,
+		},
+		"unparsable user agent": {
+			policy:    ClientVersionPolicyReject,
+			userAgent: "kubectl",
+			wantCode:  http.StatusOK,
+		},
+		"old kubectl - ignore": {
+			policy:    ClientVersionPolicyIgnore,
+			userAgent: "kubectl/v1.20.1 (linux/amd64) kubernetes/c4d7527",
+			wantCode:  http.StatusOK,
+		},
+		"old kubectl - warn": {
+			policy:      ClientVersionPolicyWarn,
+			userAgent:   "kubectl/v1.20.1 (linux/amd64) kubernetes/c4d7527",
+			wantCode:    http.StatusOK,
+			wantWarning: true,
+		},
+		"old kubectl - reject": {
+			policy:    ClientVersionPolicyReject,
+			userAgent: "kubectl/v1.20.1 (linux/amd64) kubernetes/c4d7527",
+			wantCode:  http.StatusBadRequest,
+		},
+		"old kubectl - reject - capabilities": {
+			policy:    ClientVersionPolicyReject,
+			userAgent: "kubectl/v1.20.1 (linux/amd64) kubernetes/c4d7527",
+			path:      CapabilitiesPath,
+			wantCode:  http.StatusOK,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := WithClientVersionCheck(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}), tc.policy, minimumVersions)
+
+			path := tc.path
+			if path == "" {
+				path = "/clusters/root/api/v1/namespaces"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("User-Agent", tc.userAgent)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantWarning {
+				require.Contains(t, rec.Header().Get("Warning"), "kubectl v1.20.1 is not supported by this server, please upgrade to at least v1.23.0")
+			} else {
+				require.Empty(t, rec.Header().Get("Warning"))
+			}
+		})
+	}
+}
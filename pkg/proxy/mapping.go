@@ -21,13 +21,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httputil"
-	"net/url"
 	"os"
 
 	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 
+	"github.com/kcp-dev/kcp/pkg/network"
+	frontproxyfilters "github.com/kcp-dev/kcp/pkg/proxy/filters"
 	"github.com/kcp-dev/kcp/pkg/proxy/index"
 	proxyoptions "github.com/kcp-dev/kcp/pkg/proxy/options"
 )
@@ -73,11 +74,17 @@ func NewHandler(ctx context.Context, o *proxyoptions.Options, index index.Index)
 
 	mux.Handle("/metrics", legacyregistry.Handler())
 
+	capabilitiesHandler, err := newCapabilitiesHandler(o)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capabilities handler: %w", err)
+	}
+	mux.Handle(frontproxyfilters.CapabilitiesPath, capabilitiesHandler)
+
 	logger := klog.FromContext(ctx)
 	for _, m := range mapping {
 		logger.WithValues("mapping", m).V(2).Info("adding mapping")
 
-		u, err := url.Parse(m.Backend)
+		u, err := network.ParseServerURL(m.Backend)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create path mapping for path %q: failed to parse URL %q: %w", m.Path, m.Backend, err)
 		}
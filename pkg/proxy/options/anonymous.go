@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+
+	frontproxyfilters "github.com/kcp-dev/kcp/pkg/proxy/filters"
+)
+
+// AnonymousAccess configures workspaces, e.g. public API catalogs, that can be read through the front-proxy
+// without credentials.
+type AnonymousAccess struct {
+	Workspaces []string
+	Resources  []string
+	QPS        float32
+	Burst      int
+}
+
+// NewAnonymousAccess creates a default AnonymousAccess, with anonymous access disabled.
+func NewAnonymousAccess() *AnonymousAccess {
+	return &AnonymousAccess{
+		QPS:   5,
+		Burst: 10,
+	}
+}
+
+func (a *AnonymousAccess) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&a.Workspaces, "anonymous-read-only-workspaces", a.Workspaces, "Paths of workspaces that unauthenticated clients can read through the front-proxy, "+
+		"as user system:anonymous in group system:unauthenticated. The workspace must grant that user access via RBAC.")
+	fs.StringSliceVar(&a.Resources, "anonymous-read-only-resources", a.Resources, "Resources, in resource.group form, that unauthenticated clients can get, list and watch "+
+		"in the anonymous read-only workspaces, e.g. apiexports.apis.kcp.io. Other requests but discovery are rejected.")
+	fs.Float32Var(&a.QPS, "anonymous-read-only-qps", a.QPS, "Maximum rate of unauthenticated requests per anonymous read-only workspace.")
+	fs.IntVar(&a.Burst, "anonymous-read-only-burst", a.Burst, "Maximum burst of unauthenticated requests per anonymous read-only workspace.")
+}
+
+func (a *AnonymousAccess) Validate() []error {
+	var errs []error
+
+	for _, ws := range a.Workspaces {
+		if !logicalcluster.NewPath(ws).IsValid() {
+			errs = append(errs, fmt.Errorf("--anonymous-read-only-workspaces: invalid workspace path %q", ws))
+		}
+	}
+	if len(a.Workspaces) > 0 && len(a.Resources) == 0 {
+		errs = append(errs, fmt.Errorf("--anonymous-read-only-resources is required with --anonymous-read-only-workspaces"))
+	}
+	if a.QPS <= 0 {
+		errs = append(errs, fmt.Errorf("--anonymous-read-only-qps must be positive"))
+	}
+	if a.Burst < 1 {
+		errs = append(errs, fmt.Errorf("--anonymous-read-only-burst must be at least 1"))
+	}
+
+	return errs
+}
+
+// ToAnonymousAccess returns the anonymous access policy of the front-proxy, or nil if it is disabled.
+func (a *AnonymousAccess) ToAnonymousAccess() *frontproxyfilters.AnonymousAccess {
+	if len(a.Workspaces) == 0 {
+		return nil
+	}
+
+	resources := make(map[schema.GroupResource]bool, len(a.Resources))
+	for _, r := range a.Resources {
+		resources[schema.ParseGroupResource(r)] = true
+	}
+
+	qps, burst := a.QPS, a.Burst
+	return &frontproxyfilters.AnonymousAccess{
+		Workspaces: sets.NewString(a.Workspaces...),
+		Resources:  resources,
+		NewRateLimiter: func() flowcontrol.RateLimiter {
+			return flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+		},
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	frontproxyfilters "github.com/kcp-dev/kcp/pkg/proxy/filters"
+)
+
+// ClientVersion declares the compatibility matrix of the front-proxy, i.e. the minimum versions of well-known
+// clients, and how requests of older clients are treated.
+type ClientVersion struct {
+	Policy          string
+	MinimumVersions map[string]string
+}
+
+// NewClientVersion creates a default ClientVersion. kubectl is supported within the Kubernetes version skew
+// policy of the kcp apiserver. Outdated clients are ignored by default, i.e. requests are passed through as
+// before. Warnings or rejection have to be opted in via --client-version-policy.
+func NewClientVersion() *ClientVersion {
+	return &ClientVersion{
+		Policy: string(frontproxyfilters.ClientVersionPolicyIgnore),
+		MinimumVersions: map[string]string{
+			"kubectl": "v1.23.0",
+		},
+	}
+}
+
+func (c *ClientVersion) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Policy, "client-version-policy", c.Policy, fmt.Sprintf("How to treat requests of clients older than their minimum version. One of %s, %s or %s.",
+		frontproxyfilters.ClientVersionPolicyIgnore, frontproxyfilters.ClientVersionPolicyWarn, frontproxyfilters.ClientVersionPolicyReject))
+	fs.StringToStringVar(&c.MinimumVersions, "minimum-client-versions", c.MinimumVersions, "Minimum versions of clients, by the name in their user agent, e.g. kubectl=v1.23.0.")
+}
+
+func (c *ClientVersion) Validate() []error {
+	var errs []error
+
+	switch frontproxyfilters.ClientVersionPolicy(c.Policy) {
+	case frontproxyfilters.ClientVersionPolicyIgnore, frontproxyfilters.ClientVersionPolicyWarn, frontproxyfilters.ClientVersionPolicyReject:
+	default:
+		errs = append(errs, fmt.Errorf("--client-version-policy must be one of %s, %s or %s", frontproxyfilters.ClientVersionPolicyIgnore, frontproxyfilters.ClientVersionPolicyWarn, frontproxyfilters.ClientVersionPolicyReject))
+	}
+
+	if _, err := c.ParsedMinimumVersions(); err != nil {
+		errs = append(errs, fmt.Errorf("--minimum-client-versions: %w", err))
+	}
+
+	return errs
+}
+
+// ParsedMinimumVersions returns the minimum client versions by client name.
+func (c *ClientVersion) ParsedMinimumVersions() (map[string]*utilversion.Version, error) {
+	versions := make(map[string]*utilversion.Version, len(c.MinimumVersions))
+	for name, v := range c.MinimumVersions {
+		parsed, err := utilversion.ParseGeneric(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q for client %q: %w", v, name, err)
+		}
+		versions[name] = parsed
+	}
+	return versions, nil
+}
@@ -18,6 +18,7 @@ package options
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 
@@ -29,6 +30,8 @@ import (
 type Options struct {
 	SecureServing    apiserveroptions.SecureServingOptionsWithLoopback
 	Authentication   Authentication
+	ClientVersion    ClientVersion
+	AnonymousAccess  AnonymousAccess
 	MappingFile      string
 	RootDirectory    string
 	RootKubeconfig   string
@@ -38,10 +41,12 @@ type Options struct {
 
 func NewOptions() *Options {
 	o := &Options{
-		SecureServing:  *apiserveroptions.NewSecureServingOptions().WithLoopback(),
-		Authentication: *NewAuthentication(),
-		RootKubeconfig: "",
-		RootDirectory:  ".kcp",
+		SecureServing:   *apiserveroptions.NewSecureServingOptions().WithLoopback(),
+		Authentication:  *NewAuthentication(),
+		ClientVersion:   *NewClientVersion(),
+		AnonymousAccess: *NewAnonymousAccess(),
+		RootKubeconfig:  "",
+		RootDirectory:   ".kcp",
 	}
 
 	// override all the things
@@ -54,6 +59,8 @@ func NewOptions() *Options {
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	o.SecureServing.AddFlags(fs)
 	o.Authentication.AddFlags(fs)
+	o.ClientVersion.AddFlags(fs)
+	o.AnonymousAccess.AddFlags(fs)
 	fs.StringVar(&o.MappingFile, "mapping-file", o.MappingFile, "Config file mapping paths to backends")
 	fs.StringVar(&o.RootDirectory, "root-directory", o.RootDirectory, "Root directory.")
 	fs.StringVar(&o.RootKubeconfig, "root-kubeconfig", o.RootKubeconfig, "The path to the kubeconfig of the root shard.")
@@ -77,7 +84,8 @@ func (o *Options) Complete() error {
 		o.SecureServing.ServerCert.CertDirectory = filepath.Join(o.RootDirectory, o.SecureServing.ServerCert.CertDirectory)
 	}
 
-	return o.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", []string{"kubernetes.default.svc", "kubernetes.default", "kubernetes"}, nil)
+	// include both loopback addresses, such that local clients can connect over IPv4 and IPv6
+	return o.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", []string{"kubernetes.default.svc", "kubernetes.default", "kubernetes"}, []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback})
 }
 
 func (o *Options) Validate() []error {
@@ -92,6 +100,8 @@ func (o *Options) Validate() []error {
 
 	errs = append(errs, o.SecureServing.Validate()...)
 	errs = append(errs, o.Authentication.Validate()...)
+	errs = append(errs, o.ClientVersion.Validate()...)
+	errs = append(errs, o.AnonymousAccess.Validate()...)
 
 	return errs
 }
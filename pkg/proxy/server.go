@@ -29,15 +29,15 @@ import (
 	_ "k8s.io/component-base/metrics/prometheus/workqueue"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	frontproxyfilters "github.com/kcp-dev/kcp/pkg/proxy/filters"
 	"github.com/kcp-dev/kcp/pkg/proxy/index"
 	"github.com/kcp-dev/kcp/pkg/proxy/metrics"
 	"github.com/kcp-dev/kcp/pkg/server"
 	"github.com/kcp-dev/kcp/pkg/server/requestinfo"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 type Server struct {
@@ -109,13 +109,21 @@ func (s preparedServer) Run(ctx context.Context) error {
 
 	// start the server
 	failedHandler := frontproxyfilters.NewUnauthorizedHandler()
+	unauthenticatedHandler := s.Handler
 	s.Handler = frontproxyfilters.WithOptionalAuthentication(
 		s.Handler,
 		failedHandler,
 		s.CompletedConfig.AuthenticationInfo.Authenticator,
 		s.CompletedConfig.AdditionalAuthEnabled)
-
 	requestInfoFactory := requestinfo.NewFactory()
+	s.Handler = frontproxyfilters.WithAnonymousAccess(s.Handler, unauthenticatedHandler, failedHandler, s.CompletedConfig.Options.AnonymousAccess.ToAnonymousAccess(), requestInfoFactory)
+
+	minimumClientVersions, err := s.CompletedConfig.Options.ClientVersion.ParsedMinimumVersions()
+	if err != nil {
+		return err // shouldn't happen due to flag validation
+	}
+	s.Handler = frontproxyfilters.WithClientVersionCheck(s.Handler, frontproxyfilters.ClientVersionPolicy(s.CompletedConfig.Options.ClientVersion.Policy), minimumClientVersions)
+
 	s.Handler = server.WithInClusterServiceAccountRequestRewrite(s.Handler)
 	s.Handler = genericapifilters.WithRequestInfo(s.Handler, requestInfoFactory)
 	s.Handler = genericfilters.WithHTTPLogging(s.Handler)
@@ -42,16 +42,20 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
-	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
-	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	"github.com/kcp-dev/kcp/pkg/reconciler/ratelimiter"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
 )
 
 const (
@@ -59,6 +63,8 @@ const (
 )
 
 var (
+	// SystemBoundCRDsClusterName is the logical cluster holding the bound CRDs, or their first
+	// partition if they are partitioned. See BoundCRDPartitions.
 	SystemBoundCRDsClusterName = logicalcluster.Name("system:bound-crds")
 )
 
@@ -74,11 +80,15 @@ func NewController(
 	globalAPIExportInformer apisv1alpha1informers.APIExportClusterInformer,
 	globalAPIResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer,
 	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
+	boundCRDPartitions BoundCRDPartitions,
+	annotateTimeToReady bool,
+	commitBatcher *committer.Batcher,
+	controllerSwitch *controllerswitch.Switch,
 ) (*controller, error) {
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	queue := ratelimiter.NewControllerQueue(ControllerName)
 
 	c := &controller{
-		queue:                queue,
+		queue:                controllerSwitch.Queue(queue),
 		crdClusterClient:     crdClusterClient,
 		kcpClusterClient:     kcpClusterClient,
 		dynamicClusterClient: dynamicClusterClient,
@@ -139,25 +149,27 @@ func NewController(
 		listCRDs: func(clusterName logicalcluster.Name) ([]*apiextensionsv1.CustomResourceDefinition, error) {
 			return crdInformer.Lister().Cluster(clusterName).List(labels.Everything())
 		},
-		deletedCRDTracker: newLockedStringSet(),
-		commit:            committer.NewCommitter[*APIBinding, Patcher, *APIBindingSpec, *APIBindingStatus](kcpClusterClient.ApisV1alpha1().APIBindings()),
+		boundCRDPartitions:  boundCRDPartitions,
+		deletedCRDTracker:   newDeletedCRDTracker(deletedCRDTrackerTTL),
+		annotateTimeToReady: annotateTimeToReady,
+		commit:              committer.NewCommitterWithProvenance[*APIBinding, Patcher, *APIBindingSpec, *APIBindingStatus](kcpClusterClient.ApisV1alpha1().APIBindings(), ControllerName, committer.WithBatcher(commitBatcher)),
 	}
 
 	logger := logging.WithReconciler(klog.Background(), ControllerName)
 
 	if err := apiBindingInformer.Informer().AddIndexers(cache.Indexers{
-		indexers.APIBindingsByAPIExport: indexers.IndexAPIBindingByAPIExport,
+		kcpindexers.APIBindingsByAPIExport: kcpindexers.IndexAPIBindingByAPIExport,
 	}); err != nil {
 		return nil, err
 	}
 
 	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
-		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
-		indexAPIExportsByAPIResourceSchema:   indexAPIExportsByAPIResourceSchemasFunc,
+		indexers.ByLogicalClusterPathAndName:     indexers.IndexByLogicalClusterPathAndName,
+		kcpindexers.APIExportByAPIResourceSchema: kcpindexers.IndexAPIExportByAPIResourceSchemas,
 	})
 	indexers.AddIfNotPresentOrDie(globalAPIExportInformer.Informer().GetIndexer(), cache.Indexers{
-		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
-		indexAPIExportsByAPIResourceSchema:   indexAPIExportsByAPIResourceSchemasFunc,
+		indexers.ByLogicalClusterPathAndName:     indexers.IndexByLogicalClusterPathAndName,
+		kcpindexers.APIExportByAPIResourceSchema: kcpindexers.IndexAPIExportByAPIResourceSchemas,
 	})
 
 	apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -173,7 +185,7 @@ func NewController(
 				return false
 			}
 
-			return logicalcluster.From(crd) == SystemBoundCRDsClusterName
+			return IsSystemBoundCRDsCluster(logicalcluster.From(crd))
 		},
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc:    func(obj interface{}) { c.enqueueCRD(obj, logger) },
@@ -186,9 +198,9 @@ func NewController(
 				}
 
 				// If something deletes one of our bound CRDs, we need to keep track of it so when we're reconciling,
-				// we know we need to recreate it. This set is there to fight against stale informers still seeing
+				// we know we need to recreate it. This tracker is there to fight against stale informers still seeing
 				// the deleted CRD.
-				c.deletedCRDTracker.Add(meta.GetName())
+				c.deletedCRDTracker.Add(meta.GetUID())
 
 				c.enqueueCRD(obj, logger)
 			},
@@ -252,8 +264,15 @@ type controller struct {
 	getCRD    func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error)
 	listCRDs  func(clusterName logicalcluster.Name) ([]*apiextensionsv1.CustomResourceDefinition, error)
 
-	deletedCRDTracker *lockedStringSet
-	commit            CommitFunc
+	// boundCRDPartitions is the number of logical clusters the bound CRDs are partitioned across.
+	boundCRDPartitions BoundCRDPartitions
+
+	deletedCRDTracker *deletedCRDTracker
+
+	// annotateTimeToReady makes bound APIBindings carry their time to ready in an annotation.
+	annotateTimeToReady bool
+
+	commit CommitFunc
 }
 
 // enqueueAPIBinding enqueues an APIBinding .
@@ -283,7 +302,7 @@ func (c *controller) enqueueAPIExport(obj interface{}, logger logr.Logger, logSu
 	// binding keys by full path
 	keys := sets.NewString()
 	if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
-		pathKeys, err := c.apiBindingsIndexer.IndexKeys(indexers.APIBindingsByAPIExport, path.Join(export.Name).String())
+		pathKeys, err := c.apiBindingsIndexer.IndexKeys(kcpindexers.APIBindingsByAPIExport, path.Join(export.Name).String())
 		if err != nil {
 			runtime.HandleError(err)
 			return
@@ -291,7 +310,7 @@ func (c *controller) enqueueAPIExport(obj interface{}, logger logr.Logger, logSu
 		keys.Insert(pathKeys...)
 	}
 
-	clusterKeys, err := c.apiBindingsIndexer.IndexKeys(indexers.APIBindingsByAPIExport, logicalcluster.From(export).Path().Join(export.Name).String())
+	clusterKeys, err := c.apiBindingsIndexer.IndexKeys(kcpindexers.APIBindingsByAPIExport, logicalcluster.From(export).Path().Join(export.Name).String())
 	if err != nil {
 		runtime.HandleError(err)
 		return
@@ -350,13 +369,13 @@ func (c *controller) enqueueAPIResourceSchema(obj interface{}, logger logr.Logge
 		return
 	}
 
-	apiExports, err := c.apiExportsIndexer.ByIndex(indexAPIExportsByAPIResourceSchema, key)
+	apiExports, err := c.apiExportsIndexer.ByIndex(kcpindexers.APIExportByAPIResourceSchema, key)
 	if err != nil {
 		runtime.HandleError(err)
 		return
 	}
 	if len(apiExports) == 0 {
-		apiExports, err = c.globalAPIExportsIndexer.ByIndex(indexAPIExportsByAPIResourceSchema, key)
+		apiExports, err = c.globalAPIExportsIndexer.ByIndex(kcpindexers.APIExportByAPIResourceSchema, key)
 		if err != nil {
 			runtime.HandleError(err)
 			return
@@ -453,6 +472,8 @@ func (c *controller) process(ctx context.Context, key string) (bool, error) {
 	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
 	if err := c.commit(ctx, oldResource, newResource); err != nil {
 		errs = append(errs, err)
+	} else if !conditions.IsTrue(old, apisv1alpha1.InitialBindingCompleted) && conditions.IsTrue(obj, apisv1alpha1.InitialBindingCompleted) {
+		recordTimeToReady(obj, time.Now())
 	}
 
 	return requeue, utilerrors.NewAggregate(errs)
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	kcpapiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/kcp/clientset/versioned/fake"
+	kcpapiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/kcp/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/test/e2e/framework/fakecache"
+)
+
+func TestLocalAndGlobalLookups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	localExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:local"},
+			Name:        "local-export",
+		},
+	}
+	remoteExport := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:remote"},
+			Name:        "remote-export",
+		},
+	}
+	remoteSchema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:remote"},
+			Name:        "today.widgets.kcp.dev",
+		},
+	}
+
+	// the local shard holds local-export, remote-export and its schema live on another shard
+	cacheServer := fakecache.NewServer()
+	localClient := kcpfakeclient.NewSimpleClientset(localExport)
+	localInformers := kcpinformers.NewSharedInformerFactory(localClient, 0)
+	crdClient := kcpapiextensionsfake.NewSimpleClientset()
+	crdInformers := kcpapiextensionsinformers.NewSharedInformerFactory(crdClient, 0)
+	c, err := NewController(
+		crdClient,
+		localClient,
+		nil,
+		nil,
+		localInformers.Apis().V1alpha1().APIBindings(),
+		localInformers.Apis().V1alpha1().APIExports(),
+		localInformers.Apis().V1alpha1().APIResourceSchemas(),
+		cacheServer.InformerFactory().Apis().V1alpha1().APIExports(),
+		cacheServer.InformerFactory().Apis().V1alpha1().APIResourceSchemas(),
+		crdInformers.Apiextensions().V1().CustomResourceDefinitions(),
+		1,
+		false,
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	localInformers.Start(ctx.Done())
+	localInformers.WaitForCacheSync(ctx.Done())
+	require.NoError(t, cacheServer.Start(ctx))
+
+	t.Log("Local APIExports are found without the cache server")
+	export, err := c.getAPIExport(logicalcluster.NewPath("root:local"), "local-export")
+	require.NoError(t, err)
+	require.Equal(t, "local-export", export.Name)
+
+	t.Log("APIExports of other shards are not found before they are replicated")
+	cacheServer.SetReplicationLag(time.Minute)
+	cacheServer.Replicate("other", remoteExport)
+	cacheServer.Replicate("other", remoteSchema)
+	require.NoError(t, cacheServer.Step(30*time.Second))
+	_, err = c.getAPIExport(logicalcluster.NewPath("root:remote"), "remote-export")
+	require.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+	_, err = c.getAPIResourceSchema("root:remote", "today.widgets.kcp.dev")
+	require.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	t.Log("A failed replication delays the APIExport until it is retried")
+	cacheServer.FailReplications(errors.New("replication failed"))
+	require.Error(t, cacheServer.Step(30*time.Second))
+	require.Equal(t, 1, cacheServer.Pending())
+	_, err = c.getAPIExport(logicalcluster.NewPath("root:remote"), "remote-export")
+	require.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+	require.NoError(t, cacheServer.Step(0))
+
+	t.Log("Replicated APIExports and APIResourceSchemas are found through the global informers")
+	require.Eventually(t, func() bool {
+		_, err := c.getAPIExport(logicalcluster.NewPath("root:remote"), "remote-export")
+		return err == nil
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, err := c.getAPIResourceSchema("root:remote", "today.widgets.kcp.dev")
+		return err == nil
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	t.Log("Lookups are served from the informers while the cache server is unavailable")
+	cacheServer.SetUnavailable(apierrors.NewServiceUnavailable("cache server is down"))
+	_, err = cacheServer.Client().Cluster(logicalcluster.NewPath("root:remote")).ApisV1alpha1().APIExports().Get(ctx, "remote-export", metav1.GetOptions{})
+	require.Error(t, err)
+	export, err = c.getAPIExport(logicalcluster.NewPath("root:remote"), "remote-export")
+	require.NoError(t, err)
+	require.Equal(t, "other", export.Annotations[genericrequest.AnnotationKey])
+}
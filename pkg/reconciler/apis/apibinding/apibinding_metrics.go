@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"sync"
+	"time"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+var (
+	timeToReady = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "apibinding_time_to_ready_seconds",
+			Help:           "Duration from the creation of an APIBinding until its initial binding completed, by APIExport.",
+			Buckets:        []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"apiexport_cluster", "apiexport"},
+	)
+	deletedCRDTrackerEntries = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "apibinding_deleted_crd_tracker_entries",
+			Help:           "Number of deleted bound CRDs remembered to recreate them despite stale listers.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+	deletedCRDTrackerLookups = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "apibinding_deleted_crd_tracker_lookups_total",
+			Help:           "Number of lookups of bound CRDs in the deleted CRD tracker, by result (hit or miss).",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the APIBinding metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(timeToReady)
+		legacyregistry.MustRegister(deletedCRDTrackerEntries)
+		legacyregistry.MustRegister(deletedCRDTrackerLookups)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+// recordTimeToReady observes the time from creation until now of an APIBinding whose initial binding
+// just completed.
+func recordTimeToReady(apiBinding *apisv1alpha1.APIBinding, now time.Time) {
+	var cluster, name string
+	if export := apiBinding.Status.BoundAPIExport; export != nil {
+		cluster, name = export.Cluster.String(), export.Name
+	}
+	timeToReady.WithLabelValues(cluster, name).Observe(now.Sub(apiBinding.CreationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// isRebinding returns true if the APIBinding has resources bound from an APIExport
+// other than the given one, i.e. spec.reference has been changed after binding.
+func isRebinding(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) bool {
+	bound := apiBinding.Status.BoundAPIExport
+	if bound == nil || len(apiBinding.Status.BoundResources) == 0 {
+		return false
+	}
+	return bound.Cluster.Name() != logicalcluster.From(apiExport) || bound.Name != apiExport.Name
+}
+
+// rebindingIncompatibilities returns the reasons why the resources currently bound by the APIBinding
+// cannot be taken over by the given APIExport and its merged schemas, i.e. with one schema per resource
+// holding all exported versions, without losing access to stored objects.
+// An empty result means that the APIBinding can switch over to the APIExport safely.
+func rebindingIncompatibilities(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport, schemas []*apisv1alpha1.APIResourceSchema) []string {
+	byGroupResource := make(map[schema.GroupResource]*apisv1alpha1.APIResourceSchema, len(schemas))
+	for _, s := range schemas {
+		byGroupResource[schema.GroupResource{Group: s.Spec.Group, Resource: s.Spec.Names.Plural}] = s
+	}
+
+	var incompatibilities []string
+	for _, boundResource := range apiBinding.Status.BoundResources {
+		gr := schema.GroupResource{Group: boundResource.Group, Resource: boundResource.Resource}
+
+		s, found := byGroupResource[gr]
+		if !found {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s is not exported anymore", gr))
+			continue
+		}
+
+		if boundResource.Schema.IdentityHash != apiExport.Status.IdentityHash {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s is exported with a different identity", gr))
+			continue
+		}
+
+		versions := sets.NewString()
+		for _, v := range s.Spec.Versions {
+			versions.Insert(v.Name)
+		}
+		if missing := sets.NewString(boundResource.StorageVersions...).Difference(versions); missing.Len() > 0 {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s is missing stored versions %v", gr, missing.List()))
+		}
+	}
+
+	return incompatibilities
+}
+
+// resourceSchemasForAPIBinding returns the APIResourceSchemas and the version overrides of the APIExport that
+// the APIBinding binds, and the release they belong to. If spec.release was removed from the APIExport after
+// binding, the next newer release or the latest schemas are returned instead, such that the bound resources
+// keep being served. It returns false if the release does not exist and nothing is bound yet.
+func resourceSchemasForAPIBinding(apiBinding *apisv1alpha1.APIBinding, apiExport *apisv1alpha1.APIExport) ([]string, []apisv1alpha1.ExportedResourceVersion, string, bool) {
+	release := apiBinding.Spec.Release
+	if schemaNames, resourceVersions, found := apiExport.Spec.ResourceSchemasForRelease(release); found {
+		return schemaNames, resourceVersions, release, true
+	}
+	if len(apiBinding.Status.BoundResources) == 0 {
+		return nil, nil, "", false
+	}
+
+	successor := apiExport.Spec.SuccessorRelease(release)
+	schemaNames, resourceVersions, _ := apiExport.Spec.ResourceSchemasForRelease(successor)
+	return schemaNames, resourceVersions, successor, true
+}
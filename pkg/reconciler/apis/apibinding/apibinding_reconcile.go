@@ -34,10 +34,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/schemaconversion"
+	"github.com/kcp-dev/kcp/pkg/schematemplates"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type reconcileStatus int
@@ -53,6 +55,8 @@ type reconciler interface {
 
 func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (bool, error) {
 	reconcilers := []reconciler{
+		&timeToReadyReconciler{controller: c},
+		&extraCRDAnnotationsReconciler{},
 		&phaseReconciler{
 			newReconciler:     &newReconciler{controller: c},
 			bindingReconciler: &bindingReconciler{controller: c},
@@ -97,6 +101,56 @@ func (r *summaryReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 	return reconcileStatusContinue, nil
 }
 
+// timeToReadyReconciler annotates APIBindings with the duration from their creation until the initial
+// binding completed, if enabled.
+type timeToReadyReconciler struct {
+	*controller
+}
+
+func (r *timeToReadyReconciler) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (reconcileStatus, error) {
+	if !r.annotateTimeToReady {
+		return reconcileStatusContinue, nil
+	}
+	if _, found := apiBinding.Annotations[apisv1alpha1.AnnotationTimeToReadyKey]; found {
+		return reconcileStatusContinue, nil
+	}
+	if !conditions.IsTrue(apiBinding, apisv1alpha1.InitialBindingCompleted) {
+		return reconcileStatusContinue, nil
+	}
+	cond := conditions.Get(apiBinding, apisv1alpha1.InitialBindingCompleted)
+
+	if apiBinding.Annotations == nil {
+		apiBinding.Annotations = map[string]string{}
+	}
+	apiBinding.Annotations[apisv1alpha1.AnnotationTimeToReadyKey] = cond.LastTransitionTime.Sub(apiBinding.CreationTimestamp.Time).String()
+
+	// first update ObjectMeta before status
+	return reconcileStatusStopAndRequeue, nil
+}
+
+// extraCRDAnnotationsReconciler publishes the extra annotations selected for bound CRDs in the
+// status, such that consumers can read them, and the CRD lister does not have to compute them.
+type extraCRDAnnotationsReconciler struct{}
+
+func (r *extraCRDAnnotationsReconciler) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) (reconcileStatus, error) {
+	var extra map[string]string
+	for _, k := range strings.Split(apiBinding.Annotations[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey], ",") {
+		k = strings.TrimSpace(k)
+		if !strings.HasPrefix(k, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix) {
+			continue
+		}
+		if v, found := apiBinding.Annotations[k]; found {
+			if extra == nil {
+				extra = map[string]string{}
+			}
+			extra[k] = v
+		}
+	}
+	apiBinding.Status.ExtraCRDAnnotations = extra
+
+	return reconcileStatusContinue, nil
+}
+
 type phaseReconciler struct {
 	newReconciler     reconciler
 	bindingReconciler reconciler
@@ -154,7 +208,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 	}
 
 	// Get APIExport
-	apiExportPath := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+	apiExportPath := apiBinding.Spec.Reference.Export.Path.Path()
 	if apiExportPath.Empty() {
 		apiExportPath = logicalcluster.From(apiBinding).Path()
 	}
@@ -187,8 +241,19 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 	logger = logging.WithObject(logger, apiExport)
 
-	// Record the export's permission claims
-	apiBinding.Status.ExportPermissionClaims = apiExport.Spec.PermissionClaims
+	// Surface the deprecation of the APIExport. The condition is only added once the APIExport is deprecated.
+	if warning := apiExport.DeprecationWarning(); warning != "" {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.APIExportNotDeprecated,
+			apisv1alpha1.APIExportDeprecatedReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"%s",
+			warning,
+		)
+	} else if conditions.Has(apiBinding, apisv1alpha1.APIExportNotDeprecated) {
+		conditions.MarkTrue(apiBinding, apisv1alpha1.APIExportNotDeprecated)
+	}
 
 	// Make sure the APIExport has an identity
 	if apiExport.Status.IdentityHash == "" {
@@ -204,13 +269,36 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		return reconcileStatusContinue, nil
 	}
 
-	var needToWaitForRequeueWhenEstablished []string
+	// Resolve the referenced release, or the latest schemas
+	schemaNames, resourceVersions, release, found := resourceSchemasForAPIBinding(apiBinding, apiExport)
+	if !found {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.APIExportValid,
+			apisv1alpha1.APIExportReleaseNotFoundReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Release %s of APIExport %s|%s not found",
+			apiBinding.Spec.Release,
+			apiExportPath,
+			workspaceRef.Name,
+		)
+		return reconcileStatusContinue, nil
+	}
 
-	// Process all APIResourceSchemas
-	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
-		bindingClusterName := logicalcluster.From(apiBinding)
+	// Stop serving excluded resources that were bound before
+	var boundResources []apisv1alpha1.BoundAPIResource
+	for _, boundResource := range apiBinding.Status.BoundResources {
+		if apiBinding.Spec.ExcludesResource(apisv1alpha1.GroupResource{Group: boundResource.Group, Resource: boundResource.Resource}) {
+			logger.V(2).Info("unbinding excluded resource", "group", boundResource.Group, "resource", boundResource.Resource)
+			continue
+		}
+		boundResources = append(boundResources, boundResource)
+	}
+	apiBinding.Status.BoundResources = boundResources
 
-		// Get the schema
+	// Get all APIResourceSchemas
+	var exportedSchemas []*apisv1alpha1.APIResourceSchema
+	for _, schemaName := range schemaNames {
 		schema, err := r.getAPIResourceSchema(logicalcluster.From(apiExport), schemaName)
 		if err != nil {
 			logger.Error(err, "error binding")
@@ -229,7 +317,64 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 			return reconcileStatusContinue, err
 		}
+		exportedSchemas = append(exportedSchemas, schema)
+	}
+
+	// Merge the APIResourceSchemas of the same resource into multi-version schemas
+	schemas, err := mergeAPIResourceSchemas(exportedSchemas, resourceVersions)
+	if err != nil {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.APIExportValid,
+			apisv1alpha1.APIResourceSchemaInvalidReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Invalid versions in APIExport %s|%s: %v",
+			apiExportPath,
+			apiExport.Name,
+			err,
+		)
+		return reconcileStatusContinue, nil
+	}
+
+	// Only switch over to a different APIExport if its merged schemas can serve the already bound resources
+	if isRebinding(apiBinding, apiExport) {
+		if incompatibilities := rebindingIncompatibilities(apiBinding, apiExport, schemas); len(incompatibilities) > 0 {
+			conditions.MarkFalse(
+				apiBinding,
+				apisv1alpha1.BindingUpToDate,
+				apisv1alpha1.MigrationRequiredReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Cannot switch from APIExport %s|%s to %s|%s without data loss: %s",
+				apiBinding.Status.BoundAPIExport.Cluster, apiBinding.Status.BoundAPIExport.Name,
+				logicalcluster.From(apiExport), apiExport.Name,
+				strings.Join(incompatibilities, "; "),
+			)
+			return reconcileStatusContinue, nil
+		}
+
+		logger.V(2).Info("switching APIBinding to different APIExport", "previousCluster", apiBinding.Status.BoundAPIExport.Cluster, "previousName", apiBinding.Status.BoundAPIExport.Name)
+	}
+	apiBinding.Status.BoundAPIExport = &apisv1alpha1.BoundAPIExport{
+		Cluster: apisv1alpha1.NewLogicalClusterName(logicalcluster.From(apiExport)),
+		Name:    apiExport.Name,
+	}
 
+	// Record the export's permission claims
+	apiBinding.Status.ExportPermissionClaims = apiExport.Spec.PermissionClaims
+
+	var needToWaitForRequeueWhenEstablished []string
+
+	// Skip excluded resources
+	schemas = withoutExcludedResources(apiBinding, schemas)
+
+	// Publish the binding state of each resource, whatever the outcome
+	states := newResourceStates(apiBinding, schemas)
+	defer states.publish(apiBinding)
+
+	// Process all APIResourceSchemas
+	bindingClusterName := logicalcluster.From(apiBinding)
+	for _, schema := range schemas {
+		schemaName := schema.Name
 		logger := logging.WithObject(logger, schema)
 
 		// Check for conflicts
@@ -239,9 +384,11 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 			getAPIResourceSchema: r.getAPIResourceSchema,
 			getCRD:               r.getCRD,
 			listCRDs:             r.listCRDs,
+			boundCRDPartitions:   r.boundCRDPartitions,
 		}
 
 		if err := checker.checkForConflicts(schema, apiBinding); err != nil {
+			states.blocked(schema, apisv1alpha1.NamingConflictsReason, "Unable to bind APIs: %v", err)
 			conditions.MarkFalse(
 				apiBinding,
 				apisv1alpha1.BindingUpToDate,
@@ -265,9 +412,58 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 			return reconcileStatusContinue, nil
 		}
 
+		// Refuse to move a bound resource to an incompatible schema, unless forced
+		if !forcesIncompatibleSchemaUpdate(apiBinding) {
+			for _, boundResource := range apiBinding.Status.BoundResources {
+				if boundResource.Group != schema.Spec.Group || boundResource.Resource != schema.Spec.Names.Plural || boundResource.Schema.UID == string(schema.UID) {
+					continue
+				}
+
+				boundCRD, err := r.boundCRDPartitions.Get(r.getCRD, boundResource.Schema.UID)
+				if apierrors.IsNotFound(err) {
+					break // nothing to compare with
+				} else if err != nil {
+					return reconcileStatusContinue, err
+				}
+
+				incompatibilities, err := schemaIncompatibilities(boundCRD, boundResource.StorageVersions, schema)
+				if err != nil {
+					logger.Error(err, "error comparing schemas")
+					conditions.MarkFalse(
+						apiBinding,
+						apisv1alpha1.APIExportValid,
+						apisv1alpha1.InternalErrorReason,
+						conditionsv1alpha1.ConditionSeverityError,
+						"Invalid APIExport. Please contact the APIExport owner to resolve",
+					)
+					return reconcileStatusContinue, nil
+				}
+				if len(incompatibilities) > 0 {
+					states.blocked(schema, apisv1alpha1.SchemaIncompatibleReason,
+						"APIResourceSchema %s is incompatible with bound APIResourceSchema %s: %s",
+						schemaName, boundResource.Schema.Name, strings.Join(incompatibilities, "; "),
+					)
+					conditions.MarkFalse(
+						apiBinding,
+						apisv1alpha1.BindingUpToDate,
+						apisv1alpha1.SchemaIncompatibleReason,
+						conditionsv1alpha1.ConditionSeverityError,
+						"APIResourceSchema %s|%s is incompatible with bound APIResourceSchema %s: %s. Set annotation %s=true to update anyway",
+						logicalcluster.From(schema), schemaName,
+						boundResource.Schema.Name,
+						strings.Join(incompatibilities, "; "),
+						apisv1alpha1.AnnotationForceIncompatibleSchemaUpdateKey,
+					)
+					return reconcileStatusContinue, nil
+				}
+				break
+			}
+		}
+
 		// Try to get the bound CRD
-		existingCRD, err := r.getCRD(SystemBoundCRDsClusterName, boundCRDName(schema))
+		existingCRD, err := r.boundCRDPartitions.Get(r.getCRD, boundCRDName(schema))
 		if err != nil && !apierrors.IsNotFound(err) {
+			states.pending(schema, apisv1alpha1.InternalErrorReason, "An internal error prevented the APIBinding process from completing")
 			conditions.MarkFalse(
 				apiBinding,
 				apisv1alpha1.APIExportValid,
@@ -278,7 +474,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 			return reconcileStatusContinue, fmt.Errorf(
 				"error getting CRD %s|%s for APIBinding %s|%s, APIExport %s|%s, APIResourceSchema %s|%s: %w",
-				SystemBoundCRDsClusterName, boundCRDName(schema),
+				r.boundCRDPartitions.ClusterFor(boundCRDName(schema)), boundCRDName(schema),
 				bindingClusterName, apiBinding.Name,
 				apiExportPath, apiExport.Name,
 				apiExportPath, schemaName,
@@ -286,22 +482,31 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 			)
 		}
 
-		if err == nil {
+		// The CRD was deleted and needs to be recreated, but the lister is behind.
+		if err == nil && r.deletedCRDTracker.Has(existingCRD.UID) {
+			logger.V(4).Info("bound CRD was deleted - need to recreate")
+			existingCRD = nil
+		}
+
+		if existingCRD != nil {
 			// Bound CRD already exists
 			if !apihelpers.IsCRDConditionTrue(existingCRD, apiextensionsv1.Established) {
 				logger.V(4).Info("CRD is not established", "conditions", fmt.Sprintf("%#v", existingCRD.Status.Conditions))
+				states.pending(schema, apisv1alpha1.WaitingForEstablishedReason, "Waiting for API to be established")
 				needToWaitForRequeueWhenEstablished = append(needToWaitForRequeueWhenEstablished, schemaName)
 				continue
 			} else if apihelpers.IsCRDConditionTrue(existingCRD, apiextensionsv1.Terminating) {
 				logger.V(4).Info("CRD is terminating")
+				states.pending(schema, apisv1alpha1.WaitingForEstablishedReason, "Waiting for API to be established")
 				needToWaitForRequeueWhenEstablished = append(needToWaitForRequeueWhenEstablished, schemaName)
 				continue
 			}
 		} else {
 			// Need to create bound CRD
-			crd, err := generateCRD(schema)
+			crd, err := generateCRD(schema, apiExport.Spec.Categories)
 			if err != nil {
 				logger.Error(err, "error generating CRD")
+				states.blocked(schema, apisv1alpha1.APIResourceSchemaInvalidReason, "APIResourceSchema %s is invalid: %v", schemaName, err)
 
 				conditions.MarkFalse(
 					apiBinding,
@@ -313,25 +518,28 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 
 				return reconcileStatusContinue, nil
 			}
+			crdClusterName := r.boundCRDPartitions.ClusterFor(crd.Name)
+			crd.Annotations[logicalcluster.AnnotationKey] = crdClusterName.String()
 			logger = logging.WithObject(logger, crd).WithValues(
 				"groupResource", fmt.Sprintf("%s.%s", crd.Spec.Names.Plural, crd.Spec.Group),
 			)
 
-			// The crd was deleted and needs to be recreated. `existingCRD` might be non-nil if
-			// the lister is behind, so explicitly set to nil to ensure recreation.
-			if r.deletedCRDTracker.Has(crd.Name) {
-				logger.V(4).Info("bound CRD was deleted - need to recreate")
-				existingCRD = nil
-			}
-
-			// Create bound CRD
+			// Create bound CRD in its partition
 			logger.V(2).Info("creating CRD")
-			if _, err := r.createCRD(ctx, SystemBoundCRDsClusterName.Path(), crd); err != nil {
+			if _, err := r.createCRD(ctx, crdClusterName.Path(), crd); err != nil {
 				schemaClusterName := logicalcluster.From(schema)
+				if apierrors.IsAlreadyExists(err) {
+					// the lister is behind, e.g. because the CRD has just been recreated
+					logger.V(4).Info("CRD already exists")
+					states.pending(schema, apisv1alpha1.WaitingForEstablishedReason, "Waiting for API to be established")
+					needToWaitForRequeueWhenEstablished = append(needToWaitForRequeueWhenEstablished, schemaName)
+					continue
+				}
 				if apierrors.IsInvalid(err) {
 					status := apierrors.APIStatus(nil)
 					// The error is guaranteed to implement APIStatus here
 					errors.As(err, &status)
+					states.blocked(schema, apisv1alpha1.APIResourceSchemaInvalidReason, "APIResourceSchema %s|%s is invalid: %v", schemaClusterName, schemaName, status.Status().Details.Causes)
 					conditions.MarkFalse(
 						apiBinding,
 						apisv1alpha1.BindingUpToDate,
@@ -355,6 +563,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 					return reconcileStatusContinue, nil
 				}
 
+				states.pending(schema, apisv1alpha1.InternalErrorReason, "An internal error prevented the APIBinding process from completing")
 				conditions.MarkFalse(
 					apiBinding,
 					apisv1alpha1.BindingUpToDate,
@@ -376,8 +585,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 				return reconcileStatusContinue, err
 			}
 
-			r.deletedCRDTracker.Remove(crd.Name)
-
+			states.pending(schema, apisv1alpha1.WaitingForEstablishedReason, "Waiting for API to be established")
 			needToWaitForRequeueWhenEstablished = append(needToWaitForRequeueWhenEstablished, schemaName)
 			continue
 		}
@@ -421,6 +629,7 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		if !found {
 			apiBinding.Status.BoundResources = append(apiBinding.Status.BoundResources, newBoundResource)
 		}
+		states.bound(schema)
 	}
 
 	conditions.MarkTrue(apiBinding, apisv1alpha1.APIExportValid)
@@ -450,16 +659,76 @@ func (r *bindingReconciler) reconcile(ctx context.Context, apiBinding *apisv1alp
 		conditions.MarkTrue(apiBinding, apisv1alpha1.InitialBindingCompleted)
 		conditions.MarkTrue(apiBinding, apisv1alpha1.BindingUpToDate)
 		apiBinding.Status.Phase = apisv1alpha1.APIBindingPhaseBound
+
+		if release != apiBinding.Spec.Release {
+			boundRelease := release
+			if boundRelease == "" {
+				boundRelease = "the latest schemas"
+			}
+			conditions.MarkFalse(
+				apiBinding,
+				apisv1alpha1.BindingUpToDate,
+				apisv1alpha1.APIExportReleaseRemovedReason,
+				conditionsv1alpha1.ConditionSeverityWarning,
+				"Release %s was removed from APIExport %s|%s, bound %s instead",
+				apiBinding.Spec.Release,
+				apiExportPath,
+				workspaceRef.Name,
+				boundRelease,
+			)
+		}
 	}
 
 	return reconcileStatusContinue, nil
 }
 
+// withoutExcludedResources returns the APIResourceSchemas whose resources are not excluded by the APIBinding.
+func withoutExcludedResources(apiBinding *apisv1alpha1.APIBinding, schemas []*apisv1alpha1.APIResourceSchema) []*apisv1alpha1.APIResourceSchema {
+	if len(apiBinding.Spec.ExcludedResources) == 0 {
+		return schemas
+	}
+
+	ret := make([]*apisv1alpha1.APIResourceSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		if apiBinding.Spec.ExcludesResource(apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}) {
+			continue
+		}
+		ret = append(ret, schema)
+	}
+	return ret
+}
+
+// mergeAPIResourceSchemas merges the given APIResourceSchemas by resource, in the order
+// of their first appearance.
+func mergeAPIResourceSchemas(schemas []*apisv1alpha1.APIResourceSchema, overrides []apisv1alpha1.ExportedResourceVersion) ([]*apisv1alpha1.APIResourceSchema, error) {
+	var resources []apisv1alpha1.GroupResource
+	byResource := map[apisv1alpha1.GroupResource][]*apisv1alpha1.APIResourceSchema{}
+	for _, schema := range schemas {
+		gr := apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}
+		if _, found := byResource[gr]; !found {
+			resources = append(resources, gr)
+		}
+		byResource[gr] = append(byResource[gr], schema)
+	}
+
+	merged := make([]*apisv1alpha1.APIResourceSchema, 0, len(resources))
+	for _, gr := range resources {
+		schema, err := apisv1alpha1.MergeAPIResourceSchemas(byResource[gr], overrides)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, schema)
+	}
+
+	return merged, nil
+}
+
 func boundCRDName(schema *apisv1alpha1.APIResourceSchema) string {
 	return string(schema.UID)
 }
 
-func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.CustomResourceDefinition, error) {
+// generateCRD generates the bound CRD of the schema, with the given categories added to those of the schema.
+func generateCRD(schema *apisv1alpha1.APIResourceSchema, categories []string) (*apiextensionsv1.CustomResourceDefinition, error) {
 	crd := &apiextensionsv1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: boundCRDName(schema),
@@ -476,6 +745,7 @@ func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.Custo
 			Scope: schema.Spec.Scope,
 		},
 	}
+	crd.Spec.Names.Categories = schematemplates.MergeCategories(append([]string(nil), schema.Spec.Names.Categories...), categories)
 
 	// Propagate the protected API approval annotation, `api-approved.kubernetes.io`, if any.
 	// API groups that match `*.k8s.io` or `*.kubernetes.io` are owned by the Kubernetes community,
@@ -506,5 +776,25 @@ func generateCRD(schema *apisv1alpha1.APIResourceSchema) (*apiextensionsv1.Custo
 		crd.Spec.Versions = append(crd.Spec.Versions, crdVersion)
 	}
 
+	// Declarative conversions are served by kcp itself through a reserved service, which
+	// resolves to the shard. The path identifies the schema to convert with.
+	if len(schema.Spec.Conversions) > 0 {
+		path := logicalcluster.From(schema).Path().RequestPath() + schemaconversion.Path + "/" + schema.Name
+		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+			Strategy: apiextensionsv1.WebhookConverter,
+			Webhook: &apiextensionsv1.WebhookConversion{
+				ClientConfig: &apiextensionsv1.WebhookClientConfig{
+					Service: &apiextensionsv1.ServiceReference{
+						Namespace: schemaconversion.ServiceNamespace,
+						Name:      schemaconversion.ServiceName,
+						Path:      &path,
+						Port:      pointer.Int32(443),
+					},
+				},
+				ConversionReviewVersions: []string{"v1"},
+			},
+		}
+	}
+
 	return crd, nil
 }
@@ -18,8 +18,10 @@ package apibinding
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
@@ -34,9 +36,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // requireConditionMatches looks for a condition matching c in g. Only fields that are set in c are compared (Type is
@@ -81,6 +83,28 @@ var (
 				BoundAPIResource,
 		)
 
+	switchedExport = binding.DeepCopy().
+			WithBoundAPIExport("org-other-workspace", "other-export").
+			WithBoundResources(
+			new(boundAPIResourceBuilder).
+				WithGroupResource("kcp.io", "widgets").
+				WithSchema("yesterday.widgets.kcp.io", "yesterdaywidgetsuid").
+				WithIdentityHash("hash1").
+				WithStorageVersions("v1").
+				BoundAPIResource,
+		)
+
+	boundToYesterday = binding.DeepCopy().
+				WithBoundAPIExport("org-some-workspace", "some-export").
+				WithBoundResources(
+			new(boundAPIResourceBuilder).
+				WithGroupResource("kcp.io", "widgets").
+				WithSchema("yesterday.widgets.kcp.io", "yesterdaywidgetsuid").
+				WithIdentityHash("hash1").
+				WithStorageVersions("v1").
+				BoundAPIResource,
+		)
+
 	invalidSchema = binding.DeepCopy().WithExportReference(logicalcluster.NewPath("org:some-workspace"), "invalid-schema")
 
 	bound = unbound.DeepCopy().
@@ -137,6 +161,36 @@ var (
 		},
 	}
 
+	tomorrowWidgetsAPIResourceSchema = &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "org-some-workspace",
+			},
+			Name: "tomorrow.widgets.kcp.io",
+			UID:  "tomorrowwidgetsuid",
+		},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "kcp.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "widgets",
+				Singular: "widget",
+				Kind:     "Widget",
+				ListKind: "WidgetList",
+			},
+			Scope: "Namespace",
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{
+					Name:    "v2",
+					Served:  true,
+					Storage: false,
+					Schema: runtime.RawExtension{
+						Raw: []byte(`{"description":"foo","type":"object"}`),
+					},
+				},
+			},
+		},
+	}
+
 	someOtherWidgetsAPIResourceSchema = &apisv1alpha1.APIResourceSchema{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "another.widgets.kcp.io",
@@ -165,6 +219,44 @@ var (
 	}
 )
 
+// crdVersionFlags are the flags of a version of a bound CRD.
+type crdVersionFlags struct {
+	name            string
+	served, storage bool
+}
+
+// yesterdayWidgetsCRD returns the bound CRD of an older widgets APIResourceSchema serving the given versions
+// with the given OpenAPI schema, and having stored objects in v1.
+func yesterdayWidgetsCRD(servedVersions []string, openAPISchema string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "yesterdaywidgetsuid",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "kcp.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "widgets",
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: []string{"v1"},
+		},
+	}
+	for _, v := range servedVersions {
+		var props apiextensionsv1.JSONSchemaProps
+		if err := json.Unmarshal([]byte(openAPISchema), &props); err != nil {
+			panic(err)
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    v,
+			Served:  true,
+			Storage: v == "v1",
+			Schema:  &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &props},
+		})
+	}
+	return crd
+}
+
 func TestReconcileNew(t *testing.T) {
 	apiBinding := unbound.Build()
 
@@ -177,6 +269,31 @@ func TestReconcileNew(t *testing.T) {
 	requireConditionMatches(t, apiBinding, conditions.FalseCondition(conditionsv1alpha1.ReadyCondition, "", "", ""))
 }
 
+func TestReconcileExtraCRDAnnotations(t *testing.T) {
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey: "extra.apis.kcp.io/docs, extra.apis.kcp.io/missing,apis.kcp.io/not-extra",
+				"extra.apis.kcp.io/docs":                        "https://example.com",
+				"extra.apis.kcp.io/owner":                       "team-a",
+				"apis.kcp.io/not-extra":                         "value",
+			},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			ExtraCRDAnnotations: map[string]string{"extra.apis.kcp.io/gone": "value"},
+		},
+	}
+	r := &extraCRDAnnotationsReconciler{}
+	_, err := r.reconcile(context.Background(), apiBinding)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"extra.apis.kcp.io/docs": "https://example.com"}, apiBinding.Status.ExtraCRDAnnotations)
+
+	apiBinding.Annotations = nil
+	_, err = r.reconcile(context.Background(), apiBinding)
+	require.NoError(t, err)
+	require.Nil(t, apiBinding.Status.ExtraCRDAnnotations)
+}
+
 func TestReconcileBinding(t *testing.T) {
 	tests := map[string]struct {
 		apiBinding                              *apisv1alpha1.APIBinding
@@ -184,6 +301,7 @@ func TestReconcileBinding(t *testing.T) {
 		getAPIResourceSchemaError               error
 		existingAPIBindings                     []*apisv1alpha1.APIBinding
 		crdExists                               bool
+		boundCRDs                               map[string]*apiextensionsv1.CustomResourceDefinition
 		getCRDError                             error
 		wantCreateCRD                           bool
 		createCRDError                          error
@@ -194,6 +312,8 @@ func TestReconcileBinding(t *testing.T) {
 		wantRequeue                             bool
 		wantInvalidReference                    bool
 		wantAPIExportNotFound                   bool
+		wantAPIExportReleaseNotFound            bool
+		wantAPIExportReleaseRemoved             bool
 		wantAPIExportInternalError              bool
 		wantWaitingForEstablished               bool
 		wantAPIExportValid                      bool
@@ -205,7 +325,13 @@ func TestReconcileBinding(t *testing.T) {
 		wantInitialBindingCompleteSchemaInvalid bool
 		wantPhaseBound                          bool
 		wantBoundResources                      []apisv1alpha1.BoundAPIResource
+		wantResources                           []apisv1alpha1.APIBindingResourceStatus
 		wantNamingConflict                      bool
+		wantMigrationRequired                   string
+		wantSchemaIncompatible                  string
+		wantInvalidVersions                     string
+		wantCreatedCRDVersions                  []crdVersionFlags
+		wantDeprecated                          string
 		crdEstablished                          bool
 		crdStorageVersions                      []string
 	}{
@@ -266,6 +392,46 @@ func TestReconcileBinding(t *testing.T) {
 			wantInitialBindingCompleteInternalError: true,
 			wantError:                               true,
 		},
+		"create CRD fails - already exists": {
+			apiBinding:                binding.Build(),
+			wantCreateCRD:             true,
+			createCRDError:            apierrors.NewAlreadyExists(apiextensionsv1.Resource("customresourcedefinitions"), "todaywidgetsuid"),
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantBoundAPIExport:        true,
+		},
+		"recreate CRD deleted while the lister is stale": {
+			apiBinding:                binding.Build(),
+			crdExists:                 true,
+			crdEstablished:            true,
+			deletedCRDs:               []string{"todaywidgetsuid"},
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantBoundAPIExport:        true,
+		},
+		"CRD recreated after deletion is not recreated again": {
+			apiBinding:         binding.Build(),
+			crdExists:          true,
+			crdEstablished:     true,
+			deletedCRDs:        []string{"some-old-uid"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "today.widgets.kcp.io",
+						UID:          "todaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+				},
+			},
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
 		"create CRD - no other bindings": {
 			apiBinding:                binding.Build(),
 			wantCreateCRD:             true,
@@ -273,6 +439,9 @@ func TestReconcileBinding(t *testing.T) {
 			wantAPIExportValid:        true,
 			wantBoundAPIExport:        true,
 			wantBoundResources:        nil, // not yet established
+			wantResources: []apisv1alpha1.APIBindingResourceStatus{
+				{Group: "kcp.io", Resource: "widgets", State: apisv1alpha1.ResourceBindingStatePending, PendingSchema: "today.widgets.kcp.io", Reason: apisv1alpha1.WaitingForEstablishedReason},
+			},
 		},
 		"create CRD - other bindings - no conflicts": {
 			apiBinding: binding.Build(),
@@ -291,6 +460,9 @@ func TestReconcileBinding(t *testing.T) {
 				conflicting.Build(),
 			},
 			wantNamingConflict: true,
+			wantResources: []apisv1alpha1.APIBindingResourceStatus{
+				{Group: "kcp.io", Resource: "widgets", State: apisv1alpha1.ResourceBindingStateBlocked, PendingSchema: "today.widgets.kcp.io", Reason: apisv1alpha1.NamingConflictsReason},
+			},
 		},
 		"bind existing CRD - other bindings - conflicts": {
 			apiBinding: binding.Build(),
@@ -333,6 +505,9 @@ func TestReconcileBinding(t *testing.T) {
 					StorageVersions: []string{"v0", "v1"},
 				},
 			},
+			wantResources: []apisv1alpha1.APIBindingResourceStatus{
+				{Group: "kcp.io", Resource: "widgets", State: apisv1alpha1.ResourceBindingStateBound, BoundSchema: "today.widgets.kcp.io"},
+			},
 			wantPhaseBound:             true,
 			wantInitialBindingComplete: true,
 		},
@@ -360,6 +535,262 @@ func TestReconcileBinding(t *testing.T) {
 			wantPhaseBound:             true,
 			wantInitialBindingComplete: true,
 		},
+		"switch to APIExport with same identity and stored versions": {
+			apiBinding:         switchedExport.Build(),
+			crdExists:          true,
+			crdEstablished:     true,
+			crdStorageVersions: []string{"v1"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "today.widgets.kcp.io",
+						UID:          "todaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+					StorageVersions: []string{"v1"},
+				},
+			},
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
+		"update to compatible schema": {
+			apiBinding: boundToYesterday.Build(),
+			boundCRDs: map[string]*apiextensionsv1.CustomResourceDefinition{
+				"yesterdaywidgetsuid": yesterdayWidgetsCRD([]string{"v1"}, `{"type":"object"}`),
+			},
+			crdExists:          true,
+			crdEstablished:     true,
+			crdStorageVersions: []string{"v1"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "today.widgets.kcp.io",
+						UID:          "todaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+					StorageVersions: []string{"v1"},
+				},
+			},
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
+		"update to schema removing a field is refused": {
+			apiBinding: boundToYesterday.Build(),
+			boundCRDs: map[string]*apiextensionsv1.CustomResourceDefinition{
+				"yesterdaywidgetsuid": yesterdayWidgetsCRD([]string{"v1"}, `{"type":"object","properties":{"spec":{"type":"object"}}}`),
+			},
+			wantBoundAPIExport:     true,
+			wantSchemaIncompatible: "v1: field spec is removed",
+			wantResources: []apisv1alpha1.APIBindingResourceStatus{
+				{Group: "kcp.io", Resource: "widgets", State: apisv1alpha1.ResourceBindingStateBlocked, BoundSchema: "yesterday.widgets.kcp.io", PendingSchema: "today.widgets.kcp.io", Reason: apisv1alpha1.SchemaIncompatibleReason},
+			},
+		},
+		"update to schema not serving a version anymore is refused": {
+			apiBinding: boundToYesterday.Build(),
+			boundCRDs: map[string]*apiextensionsv1.CustomResourceDefinition{
+				"yesterdaywidgetsuid": yesterdayWidgetsCRD([]string{"v0", "v1"}, `{"type":"object"}`),
+			},
+			wantBoundAPIExport:     true,
+			wantSchemaIncompatible: "version v0 is not served anymore",
+		},
+		"update to incompatible schema is forced by annotation": {
+			apiBinding: boundToYesterday.DeepCopy().
+				WithAnnotation(apisv1alpha1.AnnotationForceIncompatibleSchemaUpdateKey, "true").
+				Build(),
+			boundCRDs: map[string]*apiextensionsv1.CustomResourceDefinition{
+				"yesterdaywidgetsuid": yesterdayWidgetsCRD([]string{"v1"}, `{"type":"object","properties":{"spec":{"type":"object"}}}`),
+			},
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantBoundAPIExport:        true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "yesterday.widgets.kcp.io",
+						UID:          "yesterdaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+					StorageVersions: []string{"v1"},
+				},
+			},
+		},
+		"multi-version APIExport creates merged CRD": {
+			apiBinding: binding.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "multi-version").
+				Build(),
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantCreatedCRDVersions: []crdVersionFlags{
+				{name: "v1", served: true, storage: false},
+				{name: "v2", served: true, storage: true},
+			},
+		},
+		"release of APIExport is bound without version overrides": {
+			apiBinding: binding.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "multi-version").
+				WithRelease("v1.0.0").
+				Build(),
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantCreatedCRDVersions: []crdVersionFlags{
+				{name: "v1", served: true, storage: true},
+				{name: "v2", served: true, storage: false},
+			},
+		},
+		"release of APIExport not found": {
+			apiBinding: binding.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "multi-version").
+				WithRelease("v9.9.9").
+				Build(),
+			wantAPIExportReleaseNotFound: true,
+		},
+		"removed release of APIExport falls back to the latest schemas": {
+			apiBinding:         rebinding.DeepCopy().WithRelease("v0.9.0").Build(),
+			crdExists:          true,
+			crdEstablished:     true,
+			crdStorageVersions: []string{"v1"},
+			wantAPIExportValid: true,
+			wantReady:          true,
+			wantBoundAPIExport: true,
+			wantBoundResources: []apisv1alpha1.BoundAPIResource{
+				{
+					Group:    "kcp.io",
+					Resource: "widgets",
+					Schema: apisv1alpha1.BoundAPIResourceSchema{
+						Name:         "today.widgets.kcp.io",
+						UID:          "todaywidgetsuid",
+						IdentityHash: "hash1",
+					},
+					StorageVersions: []string{"v0", "v1"},
+				},
+			},
+			wantPhaseBound:              true,
+			wantInitialBindingComplete:  true,
+			wantAPIExportReleaseRemoved: true,
+		},
+		"APIExport with a version in multiple schemas is invalid": {
+			apiBinding: binding.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "duplicate-version").
+				Build(),
+			wantInvalidVersions: `version "v1" of resource widgets.kcp.io is defined by both APIResourceSchemas today.widgets.kcp.io and another.widgets.kcp.io`,
+		},
+		"deprecated APIExport is surfaced": {
+			apiBinding: binding.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "deprecated").
+				Build(),
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantDeprecated:            "APIExport deprecated is deprecated and will be removed after 2023-01-01T00:00:00Z: use some-export instead",
+		},
+		"excluded resource - no CRD created": {
+			apiBinding: binding.DeepCopy().
+				WithExcludedResources(apisv1alpha1.GroupResource{Group: "kcp.io", Resource: "widgets"}).
+				Build(),
+			wantAPIExportValid:         true,
+			wantReady:                  true,
+			wantBoundAPIExport:         true,
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
+		"excluded resource - other bindings - no conflicts": {
+			apiBinding: binding.DeepCopy().
+				WithExcludedResources(apisv1alpha1.GroupResource{Group: "kcp.io", Resource: "widgets"}).
+				Build(),
+			existingAPIBindings: []*apisv1alpha1.APIBinding{
+				conflicting.Build(),
+			},
+			wantAPIExportValid:         true,
+			wantReady:                  true,
+			wantBoundAPIExport:         true,
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+		},
+		"excluding a bound resource unbinds it": {
+			apiBinding: boundToYesterday.DeepCopy().
+				WithExcludedResources(apisv1alpha1.GroupResource{Group: "kcp.io", Resource: "widgets"}).
+				Build(),
+			wantAPIExportValid:         true,
+			wantReady:                  true,
+			wantBoundAPIExport:         true,
+			wantPhaseBound:             true,
+			wantInitialBindingComplete: true,
+			wantBoundResources:         nil,
+		},
+		"switch to APIExport with missing stored versions requires migration": {
+			apiBinding: switchedExport.DeepCopy().
+				WithBoundResources(
+					new(boundAPIResourceBuilder).
+						WithGroupResource("kcp.io", "widgets").
+						WithSchema("yesterday.widgets.kcp.io", "yesterdaywidgetsuid").
+						WithIdentityHash("hash1").
+						WithStorageVersions("v0", "v1").
+						BoundAPIResource,
+				).
+				Build(),
+			wantMigrationRequired: "widgets.kcp.io is missing stored versions [v0]",
+		},
+		"switch to APIExport with different identity requires migration": {
+			apiBinding: switchedExport.DeepCopy().
+				WithBoundResources(
+					new(boundAPIResourceBuilder).
+						WithGroupResource("kcp.io", "widgets").
+						WithSchema("yesterday.widgets.kcp.io", "yesterdaywidgetsuid").
+						WithIdentityHash("oldhash").
+						WithStorageVersions("v1").
+						BoundAPIResource,
+				).
+				Build(),
+			wantMigrationRequired: "widgets.kcp.io is exported with a different identity",
+		},
+		"switch to APIExport not exporting a bound resource requires migration": {
+			apiBinding: switchedExport.DeepCopy().
+				WithBoundResources(
+					new(boundAPIResourceBuilder).
+						WithGroupResource("kcp.io", "gadgets").
+						WithSchema("yesterday.gadgets.kcp.io", "yesterdaygadgetsuid").
+						WithIdentityHash("hash1").
+						WithStorageVersions("v1").
+						BoundAPIResource,
+				).
+				Build(),
+			wantMigrationRequired: "gadgets.kcp.io is not exported anymore",
+		},
+		"switch to multi-version APIExport with stored versions in different schemas": {
+			apiBinding: switchedExport.DeepCopy().
+				WithExportReference(logicalcluster.NewPath("org:some-workspace"), "multi-version").
+				WithBoundResources(
+					new(boundAPIResourceBuilder).
+						WithGroupResource("kcp.io", "widgets").
+						WithSchema("yesterday.widgets.kcp.io", "yesterdaywidgetsuid").
+						WithIdentityHash("hash1").
+						WithStorageVersions("v1", "v2").
+						BoundAPIResource,
+				).
+				Build(),
+			wantCreateCRD:             true,
+			wantWaitingForEstablished: true,
+			wantAPIExportValid:        true,
+			wantCreatedCRDVersions: []crdVersionFlags{
+				{name: "v1", served: true, storage: false},
+				{name: "v2", served: true, storage: true},
+			},
+		},
 	}
 
 	for testName, tc := range tests {
@@ -403,6 +834,53 @@ func TestReconcileBinding(t *testing.T) {
 					},
 					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash3"},
 				},
+				"multi-version": {
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "org-some-workspace",
+						},
+						Name: "multi-version",
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						LatestResourceSchemas: []string{"today.widgets.kcp.io", "tomorrow.widgets.kcp.io"},
+						ResourceVersions: []apisv1alpha1.ExportedResourceVersion{
+							{GroupResource: apisv1alpha1.GroupResource{Group: "kcp.io", Resource: "widgets"}, Version: "v2", Served: true, Storage: true},
+						},
+						Releases: []apisv1alpha1.APIExportRelease{
+							{Version: "v1.0.0", ResourceSchemas: []string{"today.widgets.kcp.io", "tomorrow.widgets.kcp.io"}},
+						},
+					},
+					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash1"},
+				},
+				"duplicate-version": {
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "org-some-workspace",
+						},
+						Name: "duplicate-version",
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						LatestResourceSchemas: []string{"today.widgets.kcp.io", "another.widgets.kcp.io"},
+					},
+					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash1"},
+				},
+				"deprecated": {
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "org-some-workspace",
+						},
+						Name: "deprecated",
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						LatestResourceSchemas: []string{"today.widgets.kcp.io"},
+						Deprecation: &apisv1alpha1.APIExportDeprecation{
+							Deprecated: true,
+							Message:    "use some-export instead",
+							SunsetTime: &metav1.Time{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+						},
+					},
+					Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash1"},
+				},
 				"no-identity-hash": {
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{
@@ -434,10 +912,13 @@ func TestReconcileBinding(t *testing.T) {
 						},
 					},
 				},
-				"today.widgets.kcp.io":   todayWidgetsAPIResourceSchema,
-				"another.widgets.kcp.io": someOtherWidgetsAPIResourceSchema,
+				"today.widgets.kcp.io":    todayWidgetsAPIResourceSchema,
+				"tomorrow.widgets.kcp.io": tomorrowWidgetsAPIResourceSchema,
+				"another.widgets.kcp.io":  someOtherWidgetsAPIResourceSchema,
 			}
 
+			var createdCRD *apiextensionsv1.CustomResourceDefinition
+
 			c := &controller{
 				listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
 					return tc.existingAPIBindings, nil
@@ -467,7 +948,14 @@ func TestReconcileBinding(t *testing.T) {
 						return nil, tc.getCRDError
 					}
 
+					if crd, found := tc.boundCRDs[name]; found {
+						return crd, nil
+					}
+
 					crd := &apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							UID: types.UID(name),
+						},
 						Status: apiextensionsv1.CustomResourceDefinitionStatus{
 							StoredVersions: tc.crdStorageVersions,
 						},
@@ -502,9 +990,13 @@ func TestReconcileBinding(t *testing.T) {
 				},
 				createCRD: func(ctx context.Context, clusterName logicalcluster.Path, crd *apiextensionsv1.CustomResourceDefinition) (*apiextensionsv1.CustomResourceDefinition, error) {
 					createCRDCalled = true
+					createdCRD = crd
 					return crd, tc.createCRDError
 				},
-				deletedCRDTracker: &lockedStringSet{},
+				deletedCRDTracker: newDeletedCRDTracker(deletedCRDTrackerTTL),
+			}
+			for _, uid := range tc.deletedCRDs {
+				c.deletedCRDTracker.Add(types.UID(uid))
 			}
 
 			requeue, err := c.reconcile(context.Background(), tc.apiBinding)
@@ -536,6 +1028,25 @@ func TestReconcileBinding(t *testing.T) {
 				})
 			}
 
+			if tc.wantAPIExportReleaseNotFound {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.APIExportValid,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityError,
+					Reason:   apisv1alpha1.APIExportReleaseNotFoundReason,
+				})
+			}
+
+			if tc.wantAPIExportReleaseRemoved {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.BindingUpToDate,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityWarning,
+					Reason:   apisv1alpha1.APIExportReleaseRemovedReason,
+					Message:  "Release v0.9.0 was removed from APIExport org:some-workspace|some-export, bound the latest schemas instead",
+				})
+			}
+
 			if tc.wantAPIExportInternalError {
 				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
 					Type:     apisv1alpha1.APIExportValid,
@@ -566,6 +1077,10 @@ func TestReconcileBinding(t *testing.T) {
 				requireConditionMatches(t, tc.apiBinding, conditions.FalseCondition(conditionsv1alpha1.ReadyCondition, "", "", ""))
 			}
 
+			if tc.wantBoundAPIExport {
+				require.Equal(t, &apisv1alpha1.BoundAPIExport{Cluster: "org-some-workspace", Name: "some-export"}, tc.apiBinding.Status.BoundAPIExport)
+			}
+
 			if tc.wantInitialBindingComplete {
 				requireConditionMatches(t, tc.apiBinding, conditions.TrueCondition(apisv1alpha1.InitialBindingCompleted))
 			}
@@ -594,6 +1109,16 @@ func TestReconcileBinding(t *testing.T) {
 				require.True(t, found, "expected bound resource group=%s resource=%s", want.Group, want.Resource)
 			}
 
+			if tc.wantResources != nil {
+				got := make([]apisv1alpha1.APIBindingResourceStatus, 0, len(tc.apiBinding.Status.Resources))
+				for _, r := range tc.apiBinding.Status.Resources {
+					require.Equal(t, r.Reason == "", r.Message == "", "resource state %s.%s must have a message iff it has a reason", r.Resource, r.Group)
+					r.Message = ""
+					got = append(got, r)
+				}
+				require.Equal(t, tc.wantResources, got, "unexpected resource states")
+			}
+
 			if tc.wantNamingConflict {
 				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
 					Type:     apisv1alpha1.InitialBindingCompleted,
@@ -604,6 +1129,60 @@ func TestReconcileBinding(t *testing.T) {
 				})
 			}
 
+			if tc.wantMigrationRequired != "" {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.BindingUpToDate,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityError,
+					Reason:   apisv1alpha1.MigrationRequiredReason,
+					Message:  tc.wantMigrationRequired,
+				})
+				require.Equal(t, "other-export", tc.apiBinding.Status.BoundAPIExport.Name, "previously bound APIExport must be kept")
+			}
+
+			if tc.wantInvalidVersions != "" {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.APIExportValid,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityError,
+					Reason:   apisv1alpha1.APIResourceSchemaInvalidReason,
+					Message:  tc.wantInvalidVersions,
+				})
+			}
+
+			if tc.wantCreatedCRDVersions != nil {
+				require.NotNil(t, createdCRD)
+				require.NotEqual(t, "todaywidgetsuid", createdCRD.Name, "merged CRD must not be named after a single schema")
+				var got []crdVersionFlags
+				for _, v := range createdCRD.Spec.Versions {
+					got = append(got, crdVersionFlags{name: v.Name, served: v.Served, storage: v.Storage})
+				}
+				require.Equal(t, tc.wantCreatedCRDVersions, got)
+			}
+
+			if tc.wantDeprecated != "" {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.APIExportNotDeprecated,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityWarning,
+					Reason:   apisv1alpha1.APIExportDeprecatedReason,
+					Message:  tc.wantDeprecated,
+				})
+			} else {
+				require.False(t, conditions.Has(tc.apiBinding, apisv1alpha1.APIExportNotDeprecated), "unexpected deprecation condition")
+			}
+
+			if tc.wantSchemaIncompatible != "" {
+				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.BindingUpToDate,
+					Status:   corev1.ConditionFalse,
+					Severity: conditionsv1alpha1.ConditionSeverityError,
+					Reason:   apisv1alpha1.SchemaIncompatibleReason,
+					Message:  tc.wantSchemaIncompatible,
+				})
+				require.Equal(t, "yesterday.widgets.kcp.io", tc.apiBinding.Status.BoundResources[0].Schema.Name, "previously bound schema must be kept")
+			}
+
 			if tc.wantInitialBindingCompleteInternalError {
 				requireConditionMatches(t, tc.apiBinding, &conditionsv1alpha1.Condition{
 					Type:     apisv1alpha1.InitialBindingCompleted,
@@ -627,11 +1206,13 @@ func TestReconcileBinding(t *testing.T) {
 
 func TestCRDFromAPIResourceSchema(t *testing.T) {
 	tests := map[string]struct {
-		schema  *apisv1alpha1.APIResourceSchema
-		want    *apiextensionsv1.CustomResourceDefinition
-		wantErr bool
+		schema     *apisv1alpha1.APIResourceSchema
+		categories []string
+		want       *apiextensionsv1.CustomResourceDefinition
+		wantErr    bool
 	}{
 		"full schema": {
+			categories: []string{"things", "western"},
 			schema: &apisv1alpha1.APIResourceSchema{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
@@ -738,7 +1319,7 @@ func TestCRDFromAPIResourceSchema(t *testing.T) {
 						ShortNames: []string{"w"},
 						Kind:       "Widget",
 						ListKind:   "WidgetList",
-						Categories: []string{"things"},
+						Categories: []string{"things", "western"},
 					},
 					Scope: apiextensionsv1.NamespaceScoped,
 					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
@@ -810,6 +1391,200 @@ func TestCRDFromAPIResourceSchema(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"CEL validation rules": {
+			schema: &apisv1alpha1.APIResourceSchema{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "my-cluster",
+					},
+					Name: "my-name",
+					UID:  types.UID("my-uuid"),
+				},
+				Spec: apisv1alpha1.APIResourceSchemaSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apisv1alpha1.APIResourceVersion{
+						{
+							Name:    "v1",
+							Served:  true,
+							Storage: true,
+							Schema: runtime.RawExtension{
+								Raw: []byte(`
+{
+	"type": "object",
+	"x-kubernetes-validations": [{"rule": "self.min <= self.max", "message": "min must not exceed max"}]
+}
+								`),
+							},
+						},
+					},
+				},
+			},
+			want: &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-uuid",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:            SystemBoundCRDsClusterName.String(),
+						apisv1alpha1.AnnotationBoundCRDKey:      "",
+						apisv1alpha1.AnnotationSchemaClusterKey: "my-cluster",
+						apisv1alpha1.AnnotationSchemaNameKey:    "my-name",
+					},
+				},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{
+							Name:    "v1",
+							Served:  true,
+							Storage: true,
+							Schema: &apiextensionsv1.CustomResourceValidation{
+								OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+									Type: "object",
+									XValidations: apiextensionsv1.ValidationRules{
+										{Rule: "self.min <= self.max", Message: "min must not exceed max"},
+									},
+								},
+							},
+							Subresources: &apiextensionsv1.CustomResourceSubresources{},
+						},
+					},
+				},
+			},
+		},
+		"defaults": {
+			schema: &apisv1alpha1.APIResourceSchema{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "my-cluster",
+					},
+					Name: "my-name",
+					UID:  types.UID("my-uuid"),
+				},
+				Spec: apisv1alpha1.APIResourceSchemaSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apisv1alpha1.APIResourceVersion{
+						{
+							Name:    "v1",
+							Served:  true,
+							Storage: true,
+							Schema: runtime.RawExtension{
+								Raw: []byte(`
+{
+	"type": "object",
+	"properties": {"replicas": {"type": "integer", "default": 1}}
+}
+								`),
+							},
+						},
+					},
+				},
+			},
+			want: &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-uuid",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:            SystemBoundCRDsClusterName.String(),
+						apisv1alpha1.AnnotationBoundCRDKey:      "",
+						apisv1alpha1.AnnotationSchemaClusterKey: "my-cluster",
+						apisv1alpha1.AnnotationSchemaNameKey:    "my-name",
+					},
+				},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{
+							Name:    "v1",
+							Served:  true,
+							Storage: true,
+							Schema: &apiextensionsv1.CustomResourceValidation{
+								OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"replicas": {Type: "integer", Default: &apiextensionsv1.JSON{Raw: []byte("1")}},
+									},
+								},
+							},
+							Subresources: &apiextensionsv1.CustomResourceSubresources{},
+						},
+					},
+				},
+			},
+		},
+		"conversions are served by kcp": {
+			schema: &apisv1alpha1.APIResourceSchema{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "my-cluster",
+					},
+					Name: "my-name",
+					UID:  types.UID("my-uuid"),
+				},
+				Spec: apisv1alpha1.APIResourceSchemaSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apisv1alpha1.APIResourceVersion{
+						{Name: "v1", Served: true, Storage: true, Schema: runtime.RawExtension{Raw: []byte(`{"type": "object"}`)}},
+						{Name: "v2", Served: true, Schema: runtime.RawExtension{Raw: []byte(`{"type": "object"}`)}},
+					},
+					Conversions: []apisv1alpha1.APIVersionConversion{
+						{From: "v1", To: "v2", Rules: []apisv1alpha1.APIConversionRule{{Field: ".spec.name", Destination: ".spec.fullName"}}},
+					},
+				},
+			},
+			want: &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-uuid",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:            SystemBoundCRDsClusterName.String(),
+						apisv1alpha1.AnnotationBoundCRDKey:      "",
+						apisv1alpha1.AnnotationSchemaClusterKey: "my-cluster",
+						apisv1alpha1.AnnotationSchemaNameKey:    "my-name",
+					},
+				},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group: "my-group",
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+					Scope: apiextensionsv1.ClusterScoped,
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{
+							Name:         "v1",
+							Served:       true,
+							Storage:      true,
+							Schema:       &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}},
+							Subresources: &apiextensionsv1.CustomResourceSubresources{},
+						},
+						{
+							Name:         "v2",
+							Served:       true,
+							Schema:       &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}},
+							Subresources: &apiextensionsv1.CustomResourceSubresources{},
+						},
+					},
+					Conversion: &apiextensionsv1.CustomResourceConversion{
+						Strategy: apiextensionsv1.WebhookConverter,
+						Webhook: &apiextensionsv1.WebhookConversion{
+							ClientConfig: &apiextensionsv1.WebhookClientConfig{
+								Service: &apiextensionsv1.ServiceReference{
+									Namespace: "kcp-system",
+									Name:      "apiresourceschema-conversion",
+									Path:      pointer.String("/clusters/my-cluster/apiresourceschema-conversion/my-name"),
+									Port:      pointer.Int32(443),
+								},
+							},
+							ConversionReviewVersions: []string{"v1"},
+						},
+					},
+				},
+			},
+		},
 		"error when schema is invalid": {
 			schema: &apisv1alpha1.APIResourceSchema{
 				Spec: apisv1alpha1.APIResourceSchemaSpec{
@@ -827,7 +1602,7 @@ func TestCRDFromAPIResourceSchema(t *testing.T) {
 	}
 	for testName, tc := range tests {
 		t.Run(testName, func(t *testing.T) {
-			got, err := generateCRD(tc.schema)
+			got, err := generateCRD(tc.schema, tc.categories)
 
 			if tc.wantErr != (err != nil) {
 				t.Fatalf("wantErr: %v, got %v", tc.wantErr, err)
@@ -870,6 +1645,14 @@ func (b *bindingBuilder) WithClusterName(clusterName logicalcluster.Name) *bindi
 	return b
 }
 
+func (b *bindingBuilder) WithAnnotation(key, value string) *bindingBuilder {
+	if b.Annotations == nil {
+		b.Annotations = make(map[string]string)
+	}
+	b.Annotations[key] = value
+	return b
+}
+
 func (b *bindingBuilder) WithName(name string) *bindingBuilder {
 	b.Name = name
 	return b
@@ -882,17 +1665,35 @@ func (b *bindingBuilder) WithoutWorkspaceReference() *bindingBuilder {
 
 func (b *bindingBuilder) WithExportReference(path logicalcluster.Path, exportName string) *bindingBuilder {
 	b.Spec.Reference.Export = &apisv1alpha1.ExportBindingReference{
-		Path: path.String(),
+		Path: apisv1alpha1.NewLogicalClusterPath(path),
 		Name: exportName,
 	}
 	return b
 }
 
+func (b *bindingBuilder) WithRelease(release string) *bindingBuilder {
+	b.Spec.Release = release
+	return b
+}
+
+func (b *bindingBuilder) WithExcludedResources(excluded ...apisv1alpha1.GroupResource) *bindingBuilder {
+	b.Spec.ExcludedResources = excluded
+	return b
+}
+
 func (b *bindingBuilder) WithPhase(phase apisv1alpha1.APIBindingPhaseType) *bindingBuilder {
 	b.Status.Phase = phase
 	return b
 }
 
+func (b *bindingBuilder) WithBoundAPIExport(clusterName logicalcluster.Name, exportName string) *bindingBuilder {
+	b.Status.BoundAPIExport = &apisv1alpha1.BoundAPIExport{
+		Cluster: apisv1alpha1.NewLogicalClusterName(clusterName),
+		Name:    exportName,
+	}
+	return b
+}
+
 func (b *bindingBuilder) WithBoundResources(boundResources ...apisv1alpha1.BoundAPIResource) *bindingBuilder {
 	b.Status.BoundResources = boundResources
 	return b
@@ -916,6 +1717,11 @@ func (b *boundAPIResourceBuilder) WithSchema(name, uid string) *boundAPIResource
 	return b
 }
 
+func (b *boundAPIResourceBuilder) WithIdentityHash(identityHash string) *boundAPIResourceBuilder {
+	b.Schema.IdentityHash = identityHash
+	return b
+}
+
 func (b *boundAPIResourceBuilder) WithStorageVersions(v ...string) *boundAPIResourceBuilder {
 	b.StorageVersions = v
 	return b
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+	"sort"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// resourceStates collects the binding state of each resource of an APIBinding during a
+// reconciliation, to be published in status.resources.
+type resourceStates map[apisv1alpha1.GroupResource]*apisv1alpha1.APIBindingResourceStatus
+
+// newResourceStates returns the initial states of the resources of the given schemas: resources
+// already bound to the schema are bound, all others are pending.
+func newResourceStates(apiBinding *apisv1alpha1.APIBinding, schemas []*apisv1alpha1.APIResourceSchema) resourceStates {
+	states := resourceStates{}
+	for _, schema := range schemas {
+		gr := apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}
+		state := &apisv1alpha1.APIBindingResourceStatus{
+			Group:         gr.Group,
+			Resource:      gr.Resource,
+			State:         apisv1alpha1.ResourceBindingStatePending,
+			PendingSchema: schema.Name,
+		}
+		for _, boundResource := range apiBinding.Status.BoundResources {
+			if boundResource.Group != gr.Group || boundResource.Resource != gr.Resource {
+				continue
+			}
+			state.BoundSchema = boundResource.Schema.Name
+			if boundResource.Schema.UID == string(schema.UID) {
+				state.State = apisv1alpha1.ResourceBindingStateBound
+				state.PendingSchema = ""
+			}
+			break
+		}
+		states[gr] = state
+	}
+	return states
+}
+
+func (s resourceStates) get(schema *apisv1alpha1.APIResourceSchema) *apisv1alpha1.APIBindingResourceStatus {
+	return s[apisv1alpha1.GroupResource{Group: schema.Spec.Group, Resource: schema.Spec.Names.Plural}]
+}
+
+// bound records that the given schema is served.
+func (s resourceStates) bound(schema *apisv1alpha1.APIResourceSchema) {
+	state := s.get(schema)
+	if state == nil {
+		return
+	}
+	state.State = apisv1alpha1.ResourceBindingStateBound
+	state.BoundSchema = schema.Name
+	state.PendingSchema = ""
+	state.Reason = ""
+	state.Message = ""
+}
+
+// pending records that the given schema will be served without further action.
+func (s resourceStates) pending(schema *apisv1alpha1.APIResourceSchema, reason string, messageFormat string, messageArgs ...interface{}) {
+	s.set(schema, apisv1alpha1.ResourceBindingStatePending, reason, fmt.Sprintf(messageFormat, messageArgs...))
+}
+
+// blocked records that the given schema cannot be served without action.
+func (s resourceStates) blocked(schema *apisv1alpha1.APIResourceSchema, reason string, messageFormat string, messageArgs ...interface{}) {
+	s.set(schema, apisv1alpha1.ResourceBindingStateBlocked, reason, fmt.Sprintf(messageFormat, messageArgs...))
+}
+
+func (s resourceStates) set(schema *apisv1alpha1.APIResourceSchema, state apisv1alpha1.ResourceBindingState, reason, message string) {
+	rs := s.get(schema)
+	if rs == nil {
+		return
+	}
+	rs.State = state
+	rs.PendingSchema = schema.Name
+	rs.Reason = reason
+	rs.Message = message
+}
+
+// publish sets status.resources of the APIBinding, sorted by group and resource.
+func (s resourceStates) publish(apiBinding *apisv1alpha1.APIBinding) {
+	if len(s) == 0 {
+		apiBinding.Status.Resources = nil
+		return
+	}
+
+	resources := make([]apisv1alpha1.APIBindingResourceStatus, 0, len(s))
+	for _, state := range s {
+		resources = append(resources, *state)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Group != resources[j].Group {
+			return resources[i].Group < resources[j].Group
+		}
+		return resources[i].Resource < resources[j].Resource
+	})
+	apiBinding.Status.Resources = resources
+}
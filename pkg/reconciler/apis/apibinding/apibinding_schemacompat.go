@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kcp-dev/kcp/pkg/schemacompat"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// schemaIncompatibilities compares the APIResourceSchema a resource is about to be bound to with the
+// currently bound CRD of the resource. It returns the changes that could break existing clients or make
+// stored objects inaccessible: versions that are not served anymore, stored versions that are dropped,
+// removed fields and fields changing their type. An empty result means that the update is safe.
+func schemaIncompatibilities(existing *apiextensionsv1.CustomResourceDefinition, storageVersions []string, schema *apisv1alpha1.APIResourceSchema) ([]string, error) {
+	newVersions := make(map[string]*apisv1alpha1.APIResourceVersion, len(schema.Spec.Versions))
+	for i := range schema.Spec.Versions {
+		newVersions[schema.Spec.Versions[i].Name] = &schema.Spec.Versions[i]
+	}
+
+	var incompatibilities []string
+
+	stored := sets.NewString(storageVersions...).Insert(existing.Status.StoredVersions...)
+	for _, v := range stored.List() {
+		if _, found := newVersions[v]; !found {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("stored version %s is dropped", v))
+		}
+	}
+
+	for _, oldVersion := range existing.Spec.Versions {
+		newVersion, found := newVersions[oldVersion.Name]
+		if !found {
+			// dropped stored versions are reported above already
+			if oldVersion.Served && !stored.Has(oldVersion.Name) {
+				incompatibilities = append(incompatibilities, fmt.Sprintf("version %s is not served anymore", oldVersion.Name))
+			}
+			continue
+		}
+		if oldVersion.Served && !newVersion.Served {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s is not served anymore", oldVersion.Name))
+		}
+
+		if oldVersion.Schema == nil || oldVersion.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		newSchema, err := newVersion.GetSchema()
+		if err != nil {
+			return nil, err
+		}
+		if newSchema == nil {
+			continue
+		}
+
+		for _, change := range schemacompat.IncompatibleFieldChanges("", oldVersion.Schema.OpenAPIV3Schema, newSchema) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s: %s", oldVersion.Name, change))
+		}
+	}
+
+	return incompatibilities, nil
+}
+
+// forcesIncompatibleSchemaUpdate returns true if the APIBinding opts into switching to incompatible APIResourceSchemas.
+func forcesIncompatibleSchemaUpdate(apiBinding *apisv1alpha1.APIBinding) bool {
+	return apiBinding.Annotations[apisv1alpha1.AnnotationForceIncompatibleSchemaUpdateKey] == "true"
+}
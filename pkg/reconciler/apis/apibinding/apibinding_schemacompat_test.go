@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestSchemaIncompatibilities(t *testing.T) {
+	const widgetSchema = `{
+		"type": "object",
+		"properties": {
+			"spec": {
+				"type": "object",
+				"properties": {
+					"size": {"type": "integer"},
+					"tags": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string"}}}}
+				}
+			}
+		}
+	}`
+
+	crdVersion := func(name string, served bool, openAPISchema string) apiextensionsv1.CustomResourceDefinitionVersion {
+		var props apiextensionsv1.JSONSchemaProps
+		require.NoError(t, json.Unmarshal([]byte(openAPISchema), &props))
+		return apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:   name,
+			Served: served,
+			Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &props},
+		}
+	}
+	schemaVersion := func(name string, served bool, openAPISchema string) apisv1alpha1.APIResourceVersion {
+		return apisv1alpha1.APIResourceVersion{
+			Name:   name,
+			Served: served,
+			Schema: runtime.RawExtension{Raw: []byte(openAPISchema)},
+		}
+	}
+
+	tests := map[string]struct {
+		existing        []apiextensionsv1.CustomResourceDefinitionVersion
+		storedVersions  []string
+		storageVersions []string
+		versions        []apisv1alpha1.APIResourceVersion
+		want            []string
+	}{
+		"identical": {
+			existing:       []apiextensionsv1.CustomResourceDefinitionVersion{crdVersion("v1", true, widgetSchema)},
+			storedVersions: []string{"v1"},
+			versions:       []apisv1alpha1.APIResourceVersion{schemaVersion("v1", true, widgetSchema)},
+		},
+		"added field and version": {
+			existing:       []apiextensionsv1.CustomResourceDefinitionVersion{crdVersion("v1", true, `{"type":"object","properties":{"spec":{"type":"object"}}}`)},
+			storedVersions: []string{"v1"},
+			versions: []apisv1alpha1.APIResourceVersion{
+				schemaVersion("v1", true, widgetSchema),
+				schemaVersion("v2", true, widgetSchema),
+			},
+		},
+		"removed field": {
+			existing:       []apiextensionsv1.CustomResourceDefinitionVersion{crdVersion("v1", true, widgetSchema)},
+			storedVersions: []string{"v1"},
+			versions:       []apisv1alpha1.APIResourceVersion{schemaVersion("v1", true, `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"}}}}}`)},
+			want:           []string{"v1: field spec.tags is removed"},
+		},
+		"removed field with preserved unknown fields": {
+			existing:       []apiextensionsv1.CustomResourceDefinitionVersion{crdVersion("v1", true, widgetSchema)},
+			storedVersions: []string{"v1"},
+			versions:       []apisv1alpha1.APIResourceVersion{schemaVersion("v1", true, `{"type":"object","properties":{"spec":{"type":"object","x-kubernetes-preserve-unknown-fields":true}}}`)},
+		},
+		"changed field types": {
+			existing:       []apiextensionsv1.CustomResourceDefinitionVersion{crdVersion("v1", true, widgetSchema)},
+			storedVersions: []string{"v1"},
+			versions: []apisv1alpha1.APIResourceVersion{schemaVersion("v1", true, `{
+				"type": "object",
+				"properties": {
+					"spec": {
+						"type": "object",
+						"properties": {
+							"size": {"type": "string"},
+							"tags": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "integer"}}}}
+						}
+					}
+				}
+			}`)},
+			want: []string{
+				"v1: field spec.size changed type from integer to string",
+				"v1: field spec.tags[*].name changed type from string to integer",
+			},
+		},
+		"version not served anymore": {
+			existing: []apiextensionsv1.CustomResourceDefinitionVersion{
+				crdVersion("v1", true, widgetSchema),
+				crdVersion("v2", true, widgetSchema),
+			},
+			storedVersions: []string{"v2"},
+			versions: []apisv1alpha1.APIResourceVersion{
+				schemaVersion("v1", false, widgetSchema),
+				schemaVersion("v2", true, widgetSchema),
+			},
+			want: []string{"version v1 is not served anymore"},
+		},
+		"version dropped": {
+			existing: []apiextensionsv1.CustomResourceDefinitionVersion{
+				crdVersion("v1", true, widgetSchema),
+				crdVersion("v2", true, widgetSchema),
+			},
+			storedVersions: []string{"v2"},
+			versions:       []apisv1alpha1.APIResourceVersion{schemaVersion("v2", true, widgetSchema)},
+			want:           []string{"version v1 is not served anymore"},
+		},
+		"stored version dropped": {
+			existing: []apiextensionsv1.CustomResourceDefinitionVersion{
+				crdVersion("v2", true, widgetSchema),
+			},
+			storedVersions:  []string{"v2"},
+			storageVersions: []string{"v1"},
+			versions:        []apisv1alpha1.APIResourceVersion{schemaVersion("v2", true, widgetSchema)},
+			want:            []string{"stored version v1 is dropped"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			existing := &apiextensionsv1.CustomResourceDefinition{
+				Spec:   apiextensionsv1.CustomResourceDefinitionSpec{Versions: tt.existing},
+				Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: tt.storedVersions},
+			}
+			schema := &apisv1alpha1.APIResourceSchema{
+				Spec: apisv1alpha1.APIResourceSchemaSpec{Versions: tt.versions},
+			}
+
+			got, err := schemaIncompatibilities(existing, tt.storageVersions, schema)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// BoundCRDPartitions is the number of system logical clusters the bound CRDs of a shard are
+// partitioned across by the hash of their APIResourceSchema UID, such that a single logical
+// cluster does not become a hotspot on large shards. The first partition is
+// SystemBoundCRDsClusterName, further partitions are named system:bound-crds-<n>.
+//
+// The number of partitions can be increased at any time: bound CRDs are found in every partition,
+// and only new bound CRDs are created in the partition of their hash. It must not be decreased
+// below a partition holding bound CRDs.
+type BoundCRDPartitions int
+
+// ClusterFor returns the logical cluster new bound CRDs of the APIResourceSchema with the given
+// UID are created in.
+func (p BoundCRDPartitions) ClusterFor(schemaUID string) logicalcluster.Name {
+	if p <= 1 {
+		return SystemBoundCRDsClusterName
+	}
+	h := fnv.New32a()
+	h.Write([]byte(schemaUID)) //nolint:errcheck
+	return boundCRDsPartition(int(h.Sum32() % uint32(p)))
+}
+
+// Clusters returns the logical clusters of all partitions.
+func (p BoundCRDPartitions) Clusters() []logicalcluster.Name {
+	clusters := []logicalcluster.Name{SystemBoundCRDsClusterName}
+	for i := 1; i < int(p); i++ {
+		clusters = append(clusters, boundCRDsPartition(i))
+	}
+	return clusters
+}
+
+// Get returns the bound CRD of the APIResourceSchema with the given UID. It looks into the
+// partition of the hash first, and into the other partitions after, in order to find bound CRDs
+// created before the number of partitions was increased.
+func (p BoundCRDPartitions) Get(getCRD func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error), schemaUID string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	clusterName := p.ClusterFor(schemaUID)
+	crd, err := getCRD(clusterName, schemaUID)
+	if !apierrors.IsNotFound(err) {
+		return crd, err
+	}
+	for _, other := range p.Clusters() {
+		if other == clusterName {
+			continue
+		}
+		if crd, otherErr := getCRD(other, schemaUID); !apierrors.IsNotFound(otherErr) {
+			return crd, otherErr
+		}
+	}
+	return nil, err
+}
+
+// IsSystemBoundCRDsCluster returns whether the logical cluster is a partition of the bound CRDs,
+// independently of the configured number of partitions.
+func IsSystemBoundCRDsCluster(clusterName logicalcluster.Name) bool {
+	if clusterName == SystemBoundCRDsClusterName {
+		return true
+	}
+	suffix := strings.TrimPrefix(clusterName.String(), SystemBoundCRDsClusterName.String()+"-")
+	if suffix == clusterName.String() {
+		return false
+	}
+	i, err := strconv.Atoi(suffix)
+	return err == nil && i > 0 && strconv.Itoa(i) == suffix
+}
+
+func boundCRDsPartition(i int) logicalcluster.Name {
+	if i == 0 {
+		return SystemBoundCRDsClusterName
+	}
+	return logicalcluster.Name(fmt.Sprintf("%s-%d", SystemBoundCRDsClusterName, i))
+}
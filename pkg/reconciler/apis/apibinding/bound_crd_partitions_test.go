@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBoundCRDPartitionsClusterFor(t *testing.T) {
+	require.Equal(t, SystemBoundCRDsClusterName, BoundCRDPartitions(0).ClusterFor("uid"))
+	require.Equal(t, SystemBoundCRDsClusterName, BoundCRDPartitions(1).ClusterFor("uid"))
+
+	partitions := BoundCRDPartitions(4)
+	require.Equal(t, []logicalcluster.Name{"system:bound-crds", "system:bound-crds-1", "system:bound-crds-2", "system:bound-crds-3"}, partitions.Clusters())
+
+	used := map[logicalcluster.Name]bool{}
+	for i := 0; i < 100; i++ {
+		uid := fmt.Sprintf("uid-%d", i)
+		clusterName := partitions.ClusterFor(uid)
+		require.Contains(t, partitions.Clusters(), clusterName)
+		require.Equal(t, clusterName, partitions.ClusterFor(uid), "partition must be stable")
+		used[clusterName] = true
+	}
+	require.Len(t, used, 4, "expected bound CRDs to be spread across all partitions")
+}
+
+func TestBoundCRDPartitionsGet(t *testing.T) {
+	crds := map[logicalcluster.Name]string{}
+	getCRD := func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+		if crds[clusterName] != name {
+			return nil, apierrors.NewNotFound(apiextensionsv1.Resource("customresourcedefinitions"), name)
+		}
+		return &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName.String()},
+		}}, nil
+	}
+	partitions := BoundCRDPartitions(3)
+
+	_, err := partitions.Get(getCRD, "uid")
+	require.True(t, apierrors.IsNotFound(err), "unexpected error: %v", err)
+
+	crds[partitions.ClusterFor("uid")] = "uid"
+	crd, err := partitions.Get(getCRD, "uid")
+	require.NoError(t, err)
+	require.Equal(t, partitions.ClusterFor("uid"), logicalcluster.From(crd))
+
+	// created before the number of partitions was increased
+	crds = map[logicalcluster.Name]string{SystemBoundCRDsClusterName: "old"}
+	crd, err = BoundCRDPartitions(8).Get(getCRD, "old")
+	require.NoError(t, err)
+	require.Equal(t, SystemBoundCRDsClusterName, logicalcluster.From(crd))
+}
+
+func TestIsSystemBoundCRDsCluster(t *testing.T) {
+	for clusterName, want := range map[logicalcluster.Name]bool{
+		"system:bound-crds":    true,
+		"system:bound-crds-1":  true,
+		"system:bound-crds-12": true,
+		"system:bound-crds-0":  false,
+		"system:bound-crds-01": false,
+		"system:bound-crds-x":  false,
+		"system:system-crds":   false,
+		"root":                 false,
+	} {
+		require.Equal(t, want, IsSystemBoundCRDsCluster(clusterName), clusterName)
+	}
+}
@@ -25,7 +25,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 // byUID implements sort.Interface based on the UID field of CustomResourceDefinition.
@@ -41,6 +41,7 @@ type conflictChecker struct {
 	getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
 	getCRD               func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error)
 	listCRDs             func(clusterName logicalcluster.Name) ([]*apiextensionsv1.CustomResourceDefinition, error)
+	boundCRDPartitions   BoundCRDPartitions
 
 	boundCRDs    []*apiextensionsv1.CustomResourceDefinition
 	crdToBinding map[string]*apisv1alpha1.APIBinding
@@ -65,7 +66,7 @@ func (ncc *conflictChecker) getBoundCRDs(apiBindingToExclude *apisv1alpha1.APIBi
 			// this should not happen because of OpenAPI
 			return fmt.Errorf("APIBinding %s|%s has no cluster reference", logicalcluster.From(apiBinding), apiBinding.Name)
 		}
-		path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+		path := apiBinding.Spec.Reference.Export.Path.Path()
 		if path.Empty() {
 			path = logicalcluster.From(apiBinding).Path()
 		}
@@ -80,7 +81,8 @@ func (ncc *conflictChecker) getBoundCRDs(apiBindingToExclude *apisv1alpha1.APIBi
 			boundSchemaUIDs.Insert(boundResource.Schema.UID)
 		}
 
-		for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		schemaNames, _, _, _ := resourceSchemasForAPIBinding(apiBinding, apiExport)
+		for _, schemaName := range schemaNames {
 			schema, err := ncc.getAPIResourceSchema(logicalcluster.From(apiExport), schemaName)
 			if err != nil {
 				return err
@@ -90,7 +92,7 @@ func (ncc *conflictChecker) getBoundCRDs(apiBindingToExclude *apisv1alpha1.APIBi
 				continue
 			}
 
-			crd, err := ncc.getCRD(SystemBoundCRDsClusterName, string(schema.UID))
+			crd, err := ncc.boundCRDPartitions.Get(ncc.getCRD, string(schema.UID))
 			if err != nil {
 				return err
 			}
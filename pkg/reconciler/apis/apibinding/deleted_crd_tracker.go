@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deletedCRDTrackerTTL is how long a deleted bound CRD is remembered. It only has to outlive the
+// time a lister might still serve the deleted CRD.
+const deletedCRDTrackerTTL = 10 * time.Minute
+
+// deletedCRDTracker remembers the UIDs of deleted bound CRDs for a limited time. The reconciler
+// treats a CRD returned by a stale lister as missing if its UID is tracked, so that it gets
+// recreated. A recreated CRD has a new UID and is not affected.
+type deletedCRDTracker struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock    sync.Mutex
+	deleted map[types.UID]time.Time
+}
+
+func newDeletedCRDTracker(ttl time.Duration) *deletedCRDTracker {
+	return &deletedCRDTracker{
+		ttl:     ttl,
+		now:     time.Now,
+		deleted: map[types.UID]time.Time{},
+	}
+}
+
+// Add tracks the deletion of the CRD with the given UID.
+func (t *deletedCRDTracker) Add(uid types.UID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.pruneLocked(now)
+	t.deleted[uid] = now
+	deletedCRDTrackerEntries.Set(float64(len(t.deleted)))
+}
+
+// Has returns whether the CRD with the given UID has been deleted within the ttl.
+func (t *deletedCRDTracker) Has(uid types.UID) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pruneLocked(t.now())
+	deletedCRDTrackerEntries.Set(float64(len(t.deleted)))
+
+	_, found := t.deleted[uid]
+	if found {
+		deletedCRDTrackerLookups.WithLabelValues("hit").Inc()
+	} else {
+		deletedCRDTrackerLookups.WithLabelValues("miss").Inc()
+	}
+	return found
+}
+
+// Len returns the number of tracked CRDs, including those whose ttl has passed but have not been
+// pruned yet.
+func (t *deletedCRDTracker) Len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.deleted)
+}
+
+func (t *deletedCRDTracker) pruneLocked(now time.Time) {
+	for uid, deleted := range t.deleted {
+		if now.Sub(deleted) >= t.ttl {
+			delete(t.deleted, uid)
+		}
+	}
+}
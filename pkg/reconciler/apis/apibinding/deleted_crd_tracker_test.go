@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDeletedCRDTracker(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newDeletedCRDTracker(time.Minute)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Add("old")
+	require.True(t, tracker.Has("old"))
+	require.False(t, tracker.Has("new"), "a recreated CRD has a new UID and must not be treated as deleted")
+
+	now = now.Add(30 * time.Second)
+	tracker.Add(types.UID("other"))
+	require.True(t, tracker.Has("old"))
+	require.Equal(t, 2, tracker.Len())
+
+	now = now.Add(30 * time.Second)
+	require.False(t, tracker.Has("old"), "expected entry to expire after the ttl")
+	require.True(t, tracker.Has("other"))
+	require.Equal(t, 1, tracker.Len())
+
+	now = now.Add(time.Hour)
+	tracker.Add("last")
+	require.Equal(t, 1, tracker.Len(), "expected stale entries to be pruned on Add")
+}
@@ -31,11 +31,12 @@ import (
 	"k8s.io/klog/v2"
 
 	virtualworkspacesoptions "github.com/kcp-dev/kcp/cmd/virtual-workspaces/options"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	"github.com/kcp-dev/kcp/pkg/crypto"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	apiexportbuilder "github.com/kcp-dev/kcp/pkg/virtual/apiexport/builder"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 func (c *controller) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIExport) error {
@@ -159,13 +160,21 @@ func (c *controller) updateOrVerifyIdentitySecretHash(ctx context.Context, clust
 		return err
 	}
 
-	hash, err := IdentityHash(secret)
+	// the algorithm is fixed together with the first identity hash, and changing --hash-algorithm
+	// later does not change the identity of the APIExport
+	algorithm := apiExport.Status.IdentityHashAlgorithm
+	if apiExport.Status.IdentityHash == "" && algorithm == "" {
+		algorithm = crypto.ConfiguredHasher(crypto.SHA256).Algorithm()
+	}
+
+	hash, err := IdentityHash(secret, algorithm)
 	if err != nil {
 		return err
 	}
 
 	if apiExport.Status.IdentityHash == "" {
 		apiExport.Status.IdentityHash = hash
+		apiExport.Status.IdentityHashAlgorithm = algorithm
 	}
 
 	if apiExport.Status.IdentityHash != hash {
@@ -18,7 +18,6 @@ package apiexport
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
 	"time"
 
@@ -26,8 +25,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/crypto"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func GenerateIdentitySecret(ctx context.Context, ns string, apiExportName string) (*corev1.Secret, error) {
@@ -52,13 +51,23 @@ func GenerateIdentitySecret(ctx context.Context, ns string, apiExportName string
 	return secret, nil
 }
 
-func IdentityHash(secret *corev1.Secret) (string, error) {
+// IdentityHash returns the hash of the identity key in the secret, computed with the given
+// algorithm. An empty algorithm stands for sha256, which identities were hashed with before
+// the algorithm was recorded in the status of APIExports.
+func IdentityHash(secret *corev1.Secret, algorithm string) (string, error) {
 	key := secret.Data[apisv1alpha1.SecretKeyAPIExportIdentity]
 	if len(key) == 0 {
 		return "", fmt.Errorf("secret is missing data.%s", apisv1alpha1.SecretKeyAPIExportIdentity)
 	}
 
-	hashBytes := sha256.Sum256(key)
-	hash := fmt.Sprintf("%x", hashBytes)
+	hasher := crypto.SHA256
+	if algorithm != "" {
+		var err error
+		if hasher, err = crypto.HasherFor(algorithm); err != nil {
+			return "", fmt.Errorf("invalid identity hash algorithm: %w", err)
+		}
+	}
+
+	hash := fmt.Sprintf("%x", hasher.Sum(key))
 	return hash, nil
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/pkg/crypto"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestIdentityHash(t *testing.T) {
+	tests := map[string]struct {
+		algorithm string
+		want      string
+		wantError string
+	}{
+		"legacy export without algorithm": {
+			want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		"sha256": {
+			algorithm: "sha256",
+			want:      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		"sha384": {
+			algorithm: "sha384",
+			want:      "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7",
+		},
+		"unknown algorithm": {
+			algorithm: "md5",
+			wantError: `invalid identity hash algorithm: unknown hash algorithm "md5"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{apisv1alpha1.SecretKeyAPIExportIdentity: []byte("abc")},
+			}
+			hash, err := IdentityHash(secret, tt.algorithm)
+			if tt.wantError != "" {
+				require.ErrorContains(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, hash)
+		})
+	}
+}
+
+func TestIdentityHashAlgorithmIsRecorded(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, crypto.SetHashAlgorithm("")) })
+
+	c := &controller{
+		getSecret: func(ctx context.Context, clusterName logicalcluster.Name, ns, name string) (*corev1.Secret, error) {
+			return &corev1.Secret{Data: map[string][]byte{apisv1alpha1.SecretKeyAPIExportIdentity: []byte("abc")}}, nil
+		},
+	}
+	newAPIExport := func() *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "export",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"},
+			},
+			Spec: apisv1alpha1.APIExportSpec{
+				Identity: &apisv1alpha1.Identity{SecretRef: &corev1.SecretReference{Namespace: "ns", Name: "export"}},
+			},
+		}
+	}
+	sha384 := "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7"
+
+	t.Log("The configured algorithm is recorded with the first identity hash")
+	require.NoError(t, crypto.SetHashAlgorithm("sha384"))
+	apiExport := newAPIExport()
+	require.NoError(t, c.updateOrVerifyIdentitySecretHash(context.Background(), "root:org", apiExport))
+	require.Equal(t, sha384, apiExport.Status.IdentityHash)
+	require.Equal(t, "sha384", apiExport.Status.IdentityHashAlgorithm)
+
+	t.Log("Changing the configured algorithm keeps the identity")
+	require.NoError(t, crypto.SetHashAlgorithm("sha512"))
+	require.NoError(t, c.updateOrVerifyIdentitySecretHash(context.Background(), "root:org", apiExport))
+	require.Equal(t, sha384, apiExport.Status.IdentityHash)
+	require.Equal(t, "sha384", apiExport.Status.IdentityHashAlgorithm)
+
+	t.Log("Identities hashed before the algorithm was recorded stay sha256")
+	apiExport = newAPIExport()
+	apiExport.Status.IdentityHash = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	require.NoError(t, c.updateOrVerifyIdentitySecretHash(context.Background(), "root:org", apiExport))
+	require.Empty(t, apiExport.Status.IdentityHashAlgorithm)
+	require.True(t, conditions.IsTrue(apiExport, apisv1alpha1.APIExportIdentityValid))
+}
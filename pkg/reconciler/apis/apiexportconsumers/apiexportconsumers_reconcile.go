@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func (c *controller) reconcile(ctx context.Context, export *apisv1alpha1.APIExport) error {
+	if !export.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	summaries, err := c.listAPIExportConsumerSummaries(logicalcluster.From(export))
+	if err != nil {
+		return err
+	}
+
+	export.Status.Consumers = sum(export.Name, summaries)
+	return nil
+}
+
+// sum adds up the consumers of an APIExport reported by the shards.
+func sum(name string, summaries []*apisv1alpha1.APIExportConsumerSummary) *apisv1alpha1.APIExportConsumers {
+	consumers := &apisv1alpha1.APIExportConsumers{}
+
+	schemas := map[string]int32{}
+	for _, summary := range summaries {
+		for _, shardConsumers := range summary.Spec.APIExports {
+			if shardConsumers.Name != name {
+				continue
+			}
+			consumers.APIBindings += shardConsumers.APIBindings
+			consumers.ReadyAPIBindings += shardConsumers.ReadyAPIBindings
+			for _, schema := range shardConsumers.BoundSchemas {
+				schemas[schema.Name] += schema.APIBindings
+			}
+		}
+	}
+
+	for name, count := range schemas {
+		consumers.BoundSchemas = append(consumers.BoundSchemas, apisv1alpha1.BoundSchemaCount{Name: name, APIBindings: count})
+	}
+	sort.Slice(consumers.BoundSchemas, func(i, j int) bool {
+		return consumers.BoundSchemas[i].Name < consumers.BoundSchemas[j].Name
+	})
+
+	return consumers
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func summary(shard string, consumers ...apisv1alpha1.APIExportShardConsumers) *apisv1alpha1.APIExportConsumerSummary {
+	return &apisv1alpha1.APIExportConsumerSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: shard},
+		Spec:       apisv1alpha1.APIExportConsumerSummarySpec{Shard: shard, APIExports: consumers},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		deleting     bool
+		summaries    []*apisv1alpha1.APIExportConsumerSummary
+		summariesErr error
+		want         *apisv1alpha1.APIExportConsumers
+		wantErr      bool
+	}{
+		"no consumers": {
+			want: &apisv1alpha1.APIExportConsumers{},
+		},
+		"consumers of all shards are added up": {
+			summaries: []*apisv1alpha1.APIExportConsumerSummary{
+				summary("alpha",
+					apisv1alpha1.APIExportShardConsumers{Name: "widgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{
+						APIBindings: 2, ReadyAPIBindings: 1,
+						BoundSchemas: []apisv1alpha1.BoundSchemaCount{{Name: "v1.widgets.kcp.io", APIBindings: 2}},
+					}},
+					apisv1alpha1.APIExportShardConsumers{Name: "gadgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 5}},
+				),
+				summary("beta",
+					apisv1alpha1.APIExportShardConsumers{Name: "widgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{
+						APIBindings: 3, ReadyAPIBindings: 3,
+						BoundSchemas: []apisv1alpha1.BoundSchemaCount{{Name: "v1.widgets.kcp.io", APIBindings: 1}, {Name: "v2.widgets.kcp.io", APIBindings: 2}},
+					}},
+				),
+			},
+			want: &apisv1alpha1.APIExportConsumers{
+				APIBindings:      5,
+				ReadyAPIBindings: 4,
+				BoundSchemas: []apisv1alpha1.BoundSchemaCount{
+					{Name: "v1.widgets.kcp.io", APIBindings: 3},
+					{Name: "v2.widgets.kcp.io", APIBindings: 2},
+				},
+			},
+		},
+		"deleting": {
+			deleting: true,
+		},
+		"listing summaries fails": {
+			summariesErr: errors.New("boom"),
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				listAPIExportConsumerSummaries: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+					return tc.summaries, tc.summariesErr
+				},
+			}
+
+			export := &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "widgets"}}
+			if tc.deleting {
+				now := metav1.Now()
+				export.DeletionTimestamp = &now
+			}
+
+			err := c.reconcile(context.Background(), export)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, export.Status.Consumers)
+		})
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumersummary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+)
+
+const (
+	ControllerName = "kcp-apiexportconsumersummary"
+
+	// batchPeriod is the time APIBinding changes are collected before the summary of their
+	// APIExport's logical cluster is rewritten. Binding a popular APIExport in many
+	// workspaces then costs a single cache server write per period.
+	batchPeriod = 10 * time.Second
+)
+
+// NewController returns a new controller writing the APIExportConsumerSummaries of this shard
+// to the cache server, one per logical cluster with APIExports bound on this shard.
+func NewController(
+	shardName string,
+	cacheKcpClusterClient kcpclientset.ClusterInterface,
+	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	globalAPIExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	globalAPIExportConsumerSummaryInformer apisv1alpha1informers.APIExportConsumerSummaryClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue:     controllerSwitch.Queue(queue),
+		shardName: shardName,
+
+		listAPIExports: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+			// APIExports of other shards are only known through the cache server
+			local, err := apiExportInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			global, err := globalAPIExportInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			seen := sets.NewString()
+			exports := make([]*apisv1alpha1.APIExport, 0, len(local)+len(global))
+			for _, export := range append(local, global...) {
+				if seen.Has(export.Name) {
+					continue
+				}
+				seen.Insert(export.Name)
+				exports = append(exports, export)
+			}
+			return exports, nil
+		},
+		getAPIExportByPath: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+			export, err := indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
+			if apierrors.IsNotFound(err) {
+				return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), globalAPIExportInformer.Informer().GetIndexer(), path, name)
+			}
+			return export, err
+		},
+		getAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+			// APIBindings reference the APIExport either by its canonical path or by its cluster name
+			values := sets.NewString(logicalcluster.From(export).Path().Join(export.Name).String())
+			if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+				values.Insert(path.Join(export.Name).String())
+			}
+
+			var bindings []*apisv1alpha1.APIBinding
+			for _, value := range values.List() {
+				objs, err := indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), kcpindexers.APIBindingsByAPIExport, value)
+				if err != nil {
+					return nil, err
+				}
+				bindings = append(bindings, objs...)
+			}
+			return bindings, nil
+		},
+		getAPIBindingsMigratedFromAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexAPIBindingByMigratedFrom, logicalcluster.From(export).Path().Join(export.Name).String())
+		},
+		getAPIExportConsumerSummary: func(clusterName logicalcluster.Name) (*apisv1alpha1.APIExportConsumerSummary, error) {
+			return globalAPIExportConsumerSummaryInformer.Lister().Cluster(clusterName).Get(shardName)
+		},
+		createAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error {
+			ctx = cacheclient.WithShardInContext(ctx, shard.New(shardName))
+			_, err := cacheKcpClusterClient.Cluster(clusterName.Path()).ApisV1alpha1().APIExportConsumerSummaries().Create(ctx, summary, metav1.CreateOptions{})
+			return err
+		},
+		updateAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error {
+			ctx = cacheclient.WithShardInContext(ctx, shard.New(shardName))
+			_, err := cacheKcpClusterClient.Cluster(clusterName.Path()).ApisV1alpha1().APIExportConsumerSummaries().Update(ctx, summary, metav1.UpdateOptions{})
+			return err
+		},
+		deleteAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name) error {
+			ctx = cacheclient.WithShardInContext(ctx, shard.New(shardName))
+			return cacheKcpClusterClient.Cluster(clusterName.Path()).ApisV1alpha1().APIExportConsumerSummaries().Delete(ctx, shardName, metav1.DeleteOptions{})
+		},
+	}
+
+	for _, informer := range []cache.SharedIndexInformer{apiExportInformer.Informer(), globalAPIExportInformer.Informer()} {
+		indexers.AddIfNotPresentOrDie(informer.GetIndexer(), cache.Indexers{
+			indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+		})
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIExport(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueAPIExport(obj) },
+		})
+	}
+
+	indexers.AddIfNotPresentOrDie(apiBindingInformer.Informer().GetIndexer(), cache.Indexers{
+		kcpindexers.APIBindingsByAPIExport: kcpindexers.IndexAPIBindingByAPIExport,
+		indexAPIBindingByMigratedFrom:      indexAPIBindingByMigratedFromFunc,
+	})
+	apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueAPIBinding(obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueAPIBinding(oldObj)
+			c.enqueueAPIBinding(newObj)
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueueAPIBinding(obj) },
+	})
+
+	// repair summaries of this shard that were changed or deleted behind our back, and drop stale
+	// ones after a restart
+	globalAPIExportConsumerSummaryInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = d.Obj
+			}
+			summary, ok := obj.(*apisv1alpha1.APIExportConsumerSummary)
+			return ok && summary.Name == shardName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+		},
+	})
+
+	return c, nil
+}
+
+// controller writes the APIExportConsumerSummaries of a shard. The queue is keyed by the
+// logical cluster of the summarized APIExports.
+type controller struct {
+	queue     workqueue.RateLimitingInterface
+	shardName string
+
+	listAPIExports            func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error)
+	getAPIExportByPath        func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getAPIBindingsByAPIExport func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error)
+
+	getAPIBindingsMigratedFromAPIExport func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error)
+
+	getAPIExportConsumerSummary    func(clusterName logicalcluster.Name) (*apisv1alpha1.APIExportConsumerSummary, error)
+	createAPIExportConsumerSummary func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error
+	updateAPIExportConsumerSummary func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error
+	deleteAPIExportConsumerSummary func(ctx context.Context, clusterName logicalcluster.Name) error
+}
+
+func (c *controller) enqueueCluster(clusterName logicalcluster.Name, after time.Duration, reason string) {
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), clusterName.String())
+	logger.V(4).Info("queueing logical cluster", "reason", reason)
+	c.queue.AddAfter(clusterName.String(), after)
+}
+
+// enqueueAPIExport enqueues the logical cluster of an APIExport. APIBindings can exist before
+// the APIExport they reference.
+func (c *controller) enqueueAPIExport(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	export, ok := obj.(*apisv1alpha1.APIExport)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be an APIExport, but is %T", obj))
+		return
+	}
+	c.enqueueCluster(logicalcluster.From(export), batchPeriod, "APIExport changed")
+}
+
+// enqueueAPIBinding enqueues the logical cluster of the APIExport referenced by an APIBinding,
+// and of the APIExport it was migrated from.
+func (c *controller) enqueueAPIBinding(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	binding, ok := obj.(*apisv1alpha1.APIBinding)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be an APIBinding, but is %T", obj))
+		return
+	}
+	if from := logicalcluster.NewPath(binding.Annotations[apisv1alpha1.AnnotationMigratedFromKey]); !from.Empty() {
+		if parent, ok := from.Parent(); ok {
+			c.enqueueCluster(logicalcluster.Name(parent.String()), batchPeriod, "migrated APIBinding changed")
+		}
+	}
+	if binding.Spec.Reference.Export == nil {
+		return
+	}
+
+	path := binding.Spec.Reference.Export.Path.Path()
+	if path.Empty() {
+		path = logicalcluster.From(binding).Path()
+	}
+	export, err := c.getAPIExportByPath(path, binding.Spec.Reference.Export.Name)
+	if apierrors.IsNotFound(err) {
+		return // enqueued when the APIExport shows up
+	} else if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.enqueueCluster(logicalcluster.From(export), batchPeriod, "APIBinding changed")
+}
+
+// enqueueAPIExportConsumerSummary enqueues the logical cluster of a summary of this shard.
+func (c *controller) enqueueAPIExportConsumerSummary(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.enqueueCluster(clusterName, 0, "APIExportConsumerSummary changed")
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(ctx, logicalcluster.Name(key)); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumersummary
+
+import (
+	"fmt"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+const indexAPIBindingByMigratedFrom = "indexAPIBindingByMigratedFrom"
+
+// indexAPIBindingByMigratedFromFunc indexes APIBindings by the cluster and name of the APIExport
+// they were migrated from.
+func indexAPIBindingByMigratedFromFunc(obj interface{}) ([]string, error) {
+	binding, ok := obj.(*apisv1alpha1.APIBinding)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIBinding", obj)
+	}
+
+	if from := binding.Annotations[apisv1alpha1.AnnotationMigratedFromKey]; from != "" {
+		return []string{from}, nil
+	}
+	return []string{}, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumersummary
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, clusterName logicalcluster.Name) error {
+	logger := klog.FromContext(ctx)
+
+	exports, err := c.listAPIExports(clusterName)
+	if err != nil {
+		return err
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Name < exports[j].Name })
+
+	spec := apisv1alpha1.APIExportConsumerSummarySpec{Shard: c.shardName}
+	for _, export := range exports {
+		bindings, err := c.getAPIBindingsByAPIExport(export)
+		if err != nil {
+			return err
+		}
+		migrated, err := c.getAPIBindingsMigratedFromAPIExport(export)
+		if err != nil {
+			return err
+		}
+		if len(bindings) == 0 && len(migrated) == 0 {
+			continue
+		}
+		spec.APIExports = append(spec.APIExports, apisv1alpha1.APIExportShardConsumers{
+			Name:                export.Name,
+			APIExportConsumers:  *summarize(bindings),
+			MigratedAPIBindings: int32(len(migrated)),
+		})
+	}
+
+	existing, err := c.getAPIExportConsumerSummary(clusterName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	switch {
+	case len(spec.APIExports) == 0 && existing == nil:
+		return nil
+	case len(spec.APIExports) == 0:
+		logger.V(2).Info("deleting APIExportConsumerSummary")
+		if err := c.deleteAPIExportConsumerSummary(ctx, clusterName); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	case existing == nil:
+		logger.V(2).Info("creating APIExportConsumerSummary")
+		return c.createAPIExportConsumerSummary(ctx, clusterName, &apisv1alpha1.APIExportConsumerSummary{
+			ObjectMeta: metav1.ObjectMeta{Name: c.shardName},
+			Spec:       spec,
+		})
+	case equality.Semantic.DeepEqual(existing.Spec, spec):
+		return nil
+	default:
+		logger.V(2).Info("updating APIExportConsumerSummary")
+		summary := existing.DeepCopy()
+		summary.Spec = spec
+		return c.updateAPIExportConsumerSummary(ctx, clusterName, summary)
+	}
+}
+
+// summarize counts the APIBindings of an APIExport, and which APIResourceSchemas they bind.
+func summarize(bindings []*apisv1alpha1.APIBinding) *apisv1alpha1.APIExportConsumers {
+	consumers := &apisv1alpha1.APIExportConsumers{}
+
+	schemas := map[string]int32{}
+	for _, binding := range bindings {
+		consumers.APIBindings++
+		if conditions.IsTrue(binding, conditionsv1alpha1.ReadyCondition) {
+			consumers.ReadyAPIBindings++
+		}
+		for _, br := range binding.Status.BoundResources {
+			schemas[br.Schema.Name]++
+		}
+	}
+
+	for name, count := range schemas {
+		consumers.BoundSchemas = append(consumers.BoundSchemas, apisv1alpha1.BoundSchemaCount{Name: name, APIBindings: count})
+	}
+	sort.Slice(consumers.BoundSchemas, func(i, j int) bool {
+		return consumers.BoundSchemas[i].Name < consumers.BoundSchemas[j].Name
+	})
+
+	return consumers
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportconsumersummary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+func binding(name string, ready bool, schemas ...string) *apisv1alpha1.APIBinding {
+	b := &apisv1alpha1.APIBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	b.Status.Conditions = conditionsv1alpha1.Conditions{{Type: conditionsv1alpha1.ReadyCondition, Status: status}}
+	for _, schema := range schemas {
+		b.Status.BoundResources = append(b.Status.BoundResources, apisv1alpha1.BoundAPIResource{
+			Schema: apisv1alpha1.BoundAPIResourceSchema{Name: schema},
+		})
+	}
+	return b
+}
+
+func TestSummarize(t *testing.T) {
+	tests := map[string]struct {
+		bindings []*apisv1alpha1.APIBinding
+		want     *apisv1alpha1.APIExportConsumers
+	}{
+		"no bindings": {
+			want: &apisv1alpha1.APIExportConsumers{},
+		},
+		"schema rollout in progress": {
+			bindings: []*apisv1alpha1.APIBinding{
+				binding("a", true, "v2.widgets.kcp.io", "v1.gadgets.kcp.io"),
+				binding("b", true, "v1.widgets.kcp.io", "v1.gadgets.kcp.io"),
+				binding("c", false),
+			},
+			want: &apisv1alpha1.APIExportConsumers{
+				APIBindings:      3,
+				ReadyAPIBindings: 2,
+				BoundSchemas: []apisv1alpha1.BoundSchemaCount{
+					{Name: "v1.gadgets.kcp.io", APIBindings: 2},
+					{Name: "v1.widgets.kcp.io", APIBindings: 1},
+					{Name: "v2.widgets.kcp.io", APIBindings: 1},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, summarize(tc.bindings))
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	widgets := apisv1alpha1.APIExportShardConsumers{
+		Name: "widgets",
+		APIExportConsumers: apisv1alpha1.APIExportConsumers{
+			APIBindings:      1,
+			ReadyAPIBindings: 1,
+			BoundSchemas:     []apisv1alpha1.BoundSchemaCount{{Name: "v1.widgets.kcp.io", APIBindings: 1}},
+		},
+	}
+
+	tests := map[string]struct {
+		bindings map[string][]*apisv1alpha1.APIBinding
+		migrated map[string][]*apisv1alpha1.APIBinding
+		existing *apisv1alpha1.APIExportConsumerSummarySpec
+
+		wantCreated *apisv1alpha1.APIExportConsumerSummarySpec
+		wantUpdated *apisv1alpha1.APIExportConsumerSummarySpec
+		wantDeleted bool
+	}{
+		"no bindings, no summary": {},
+		"new bindings create the summary": {
+			bindings:    map[string][]*apisv1alpha1.APIBinding{"widgets": {binding("a", true, "v1.widgets.kcp.io")}},
+			wantCreated: &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{widgets}},
+		},
+		"unchanged summary is not written": {
+			bindings: map[string][]*apisv1alpha1.APIBinding{"widgets": {binding("a", true, "v1.widgets.kcp.io")}},
+			existing: &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{widgets}},
+		},
+		"changed bindings update the summary": {
+			bindings: map[string][]*apisv1alpha1.APIBinding{
+				"widgets": {binding("a", true, "v1.widgets.kcp.io")},
+				"gadgets": {binding("b", false)},
+			},
+			existing: &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{widgets}},
+			wantUpdated: &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{
+				{Name: "gadgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 1}},
+				widgets,
+			}},
+		},
+		"migrated bindings are counted": {
+			migrated:    map[string][]*apisv1alpha1.APIBinding{"gadgets": {binding("b", true)}},
+			wantCreated: &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{{Name: "gadgets", MigratedAPIBindings: 1}}},
+		},
+		"last binding gone deletes the summary": {
+			existing:    &apisv1alpha1.APIExportConsumerSummarySpec{Shard: "alpha", APIExports: []apisv1alpha1.APIExportShardConsumers{widgets}},
+			wantDeleted: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created, updated *apisv1alpha1.APIExportConsumerSummarySpec
+			var deleted bool
+			c := &controller{
+				shardName: "alpha",
+				listAPIExports: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExport, error) {
+					return []*apisv1alpha1.APIExport{
+						{ObjectMeta: metav1.ObjectMeta{Name: "widgets"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "gadgets"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "unbound"}},
+					}, nil
+				},
+				getAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return tc.bindings[export.Name], nil
+				},
+				getAPIBindingsMigratedFromAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return tc.migrated[export.Name], nil
+				},
+				getAPIExportConsumerSummary: func(clusterName logicalcluster.Name) (*apisv1alpha1.APIExportConsumerSummary, error) {
+					if tc.existing == nil {
+						return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexportconsumersummaries"), "alpha")
+					}
+					return &apisv1alpha1.APIExportConsumerSummary{ObjectMeta: metav1.ObjectMeta{Name: "alpha"}, Spec: *tc.existing}, nil
+				},
+				createAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error {
+					created = &summary.Spec
+					return nil
+				},
+				updateAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name, summary *apisv1alpha1.APIExportConsumerSummary) error {
+					updated = &summary.Spec
+					return nil
+				},
+				deleteAPIExportConsumerSummary: func(ctx context.Context, clusterName logicalcluster.Name) error {
+					deleted = true
+					return nil
+				},
+			}
+
+			require.NoError(t, c.reconcile(context.Background(), "root:org"))
+			require.Equal(t, tc.wantCreated, created)
+			require.Equal(t, tc.wantUpdated, updated)
+			require.Equal(t, tc.wantDeleted, deleted)
+		})
+	}
+}
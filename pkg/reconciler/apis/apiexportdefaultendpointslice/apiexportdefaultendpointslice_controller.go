@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdefaultendpointslice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-apiexportdefaultendpointslice"
+)
+
+// NewController returns a new controller maintaining the default APIExportEndpointSlice of
+// APIExports opting in with the apis.kcp.io/default-endpoint-slice annotation.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	apiExportEndpointSliceInformer apisv1alpha1informers.APIExportEndpointSliceClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: controllerSwitch.Queue(queue),
+
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return apiExportInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getAPIExportEndpointSlice: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error) {
+			return apiExportEndpointSliceInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		createAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+			_, err := kcpClusterClient.Cluster(clusterName).ApisV1alpha1().APIExportEndpointSlices().Create(ctx, slice, metav1.CreateOptions{})
+			return err
+		},
+		deleteAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+			return kcpClusterClient.Cluster(clusterName).ApisV1alpha1().APIExportEndpointSlices().Delete(ctx, slice.Name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &slice.UID},
+			})
+		},
+
+		commit: committer.NewCommitter[*APIExport, Patcher, *APIExportSpec, *APIExportStatus](kcpClusterClient.ApisV1alpha1().APIExports()),
+	}
+
+	apiExportInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAPIExport(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueAPIExport(newObj) },
+	})
+
+	apiExportEndpointSliceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAPIExportEndpointSlice(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueAPIExportEndpointSlice(newObj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueAPIExportEndpointSlice(obj) },
+	})
+
+	return c, nil
+}
+
+type APIExport = apisv1alpha1.APIExport
+type APIExportSpec = apisv1alpha1.APIExportSpec
+type APIExportStatus = apisv1alpha1.APIExportStatus
+type Patcher = apisv1alpha1client.APIExportInterface
+type Resource = committer.Resource[*APIExportSpec, *APIExportStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller maintains the default APIExportEndpointSlices of APIExports and their
+// DefaultEndpointSliceReady condition.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getAPIExport                 func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	getAPIExportEndpointSlice    func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error)
+	createAPIExportEndpointSlice func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error
+	deleteAPIExportEndpointSlice func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error
+
+	commit CommitFunc
+}
+
+// enqueueAPIExport enqueues an APIExport.
+func (c *controller) enqueueAPIExport(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing APIExport")
+	c.queue.Add(key)
+}
+
+// enqueueAPIExportEndpointSlice enqueues the APIExport whose default slice has the name of
+// the given slice, if any.
+func (c *controller) enqueueAPIExportEndpointSlice(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	slice, ok := obj.(*apisv1alpha1.APIExportEndpointSlice)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be an APIExportEndpointSlice, but is %T", obj))
+		return
+	}
+
+	key := kcpcache.ToClusterAwareKey(logicalcluster.From(slice).String(), "", slice.Name)
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), slice)
+	logging.WithQueueKey(logger, key).V(4).Info("queueing APIExport because of APIExportEndpointSlice")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getAPIExport(clusterName, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it, the garbage collector deletes the slice
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj); err != nil {
+		errs = append(errs, err)
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
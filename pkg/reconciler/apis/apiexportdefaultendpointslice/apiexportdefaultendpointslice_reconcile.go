@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdefaultendpointslice
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, export *apisv1alpha1.APIExport) error {
+	if !export.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	logger := klog.FromContext(ctx)
+	clusterName := logicalcluster.From(export)
+
+	slice, err := c.getAPIExportEndpointSlice(clusterName, export.Name)
+	if apierrors.IsNotFound(err) {
+		slice = nil
+	} else if err != nil {
+		return err
+	}
+
+	if export.Annotations[apisv1alpha1.AnnotationAPIExportDefaultEndpointSliceKey] != "true" {
+		conditions.Delete(export, apisv1alpha1.APIExportDefaultEndpointSliceReady)
+		if slice != nil && metav1.IsControlledBy(slice, export) && slice.DeletionTimestamp.IsZero() {
+			logger.V(2).Info("deleting default APIExportEndpointSlice after opt-out")
+			if err := c.deleteAPIExportEndpointSlice(ctx, clusterName.Path(), slice); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case slice == nil:
+		logger.V(2).Info("creating default APIExportEndpointSlice")
+		if err := c.createAPIExportEndpointSlice(ctx, clusterName.Path(), defaultAPIExportEndpointSlice(export)); err != nil {
+			conditions.MarkFalse(
+				export,
+				apisv1alpha1.APIExportDefaultEndpointSliceReady,
+				apisv1alpha1.DefaultEndpointSliceCreationFailedReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Error creating APIExportEndpointSlice %s: %v",
+				export.Name,
+				err,
+			)
+			// AlreadyExists means the lister is behind, the next try will see the slice.
+			return err
+		}
+	case metav1.IsControlledBy(slice, export), referencesAPIExport(slice, export):
+	default:
+		// never take over a slice of someone else
+		conditions.MarkFalse(
+			export,
+			apisv1alpha1.APIExportDefaultEndpointSliceReady,
+			apisv1alpha1.DefaultEndpointSliceNameConflictReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"APIExportEndpointSlice %s exists, but references APIExport %s|%s",
+			slice.Name,
+			slice.Spec.APIExport.Path,
+			slice.Spec.APIExport.Name,
+		)
+		return nil
+	}
+
+	conditions.MarkTrue(export, apisv1alpha1.APIExportDefaultEndpointSliceReady)
+	return nil
+}
+
+// defaultAPIExportEndpointSlice returns the default slice of the APIExport, owned by it such that
+// the garbage collector deletes it with the APIExport.
+func defaultAPIExportEndpointSlice(export *apisv1alpha1.APIExport) *apisv1alpha1.APIExportEndpointSlice {
+	return &apisv1alpha1.APIExportEndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: export.Name,
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: logicalcluster.From(export).String(),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(export, apisv1alpha1.SchemeGroupVersion.WithKind("APIExport")),
+			},
+		},
+		Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+			APIExport: apisv1alpha1.ExportBindingReference{
+				Name: export.Name,
+			},
+		},
+	}
+}
+
+// referencesAPIExport returns whether the slice references the APIExport in its own workspace.
+func referencesAPIExport(slice *apisv1alpha1.APIExportEndpointSlice, export *apisv1alpha1.APIExport) bool {
+	if slice.Spec.APIExport.Name != export.Name {
+		return false
+	}
+	switch slice.Spec.APIExport.Path {
+	case "", logicalcluster.From(export).String(), export.Annotations[core.LogicalClusterPathAnnotationKey]:
+		return true
+	}
+	return false
+}
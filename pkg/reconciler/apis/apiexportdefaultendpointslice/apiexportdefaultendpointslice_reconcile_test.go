@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdefaultendpointslice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	newExport := func(optIn bool) *apisv1alpha1.APIExport {
+		export := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "widgets",
+				UID:         "export-uid",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+			},
+		}
+		if optIn {
+			export.Annotations[apisv1alpha1.AnnotationAPIExportDefaultEndpointSliceKey] = "true"
+		}
+		return export
+	}
+	newSlice := func(exportPath, exportName string, owned bool) *apisv1alpha1.APIExportEndpointSlice {
+		slice := &apisv1alpha1.APIExportEndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "widgets",
+				UID:         "slice-uid",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+			},
+			Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+				APIExport: apisv1alpha1.ExportBindingReference{Path: exportPath, Name: exportName},
+			},
+		}
+		if owned {
+			slice.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(newExport(true), apisv1alpha1.SchemeGroupVersion.WithKind("APIExport")),
+			}
+		}
+		return slice
+	}
+
+	tests := map[string]struct {
+		optIn       bool
+		slice       *apisv1alpha1.APIExportEndpointSlice
+		createError error
+
+		wantCreated bool
+		wantDeleted bool
+		wantError   bool
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+	}{
+		"no opt-in": {},
+		"slice created": {
+			optIn:       true,
+			wantCreated: true,
+			wantStatus:  corev1.ConditionTrue,
+		},
+		"error creating slice": {
+			optIn:       true,
+			createError: errors.New("boom"),
+			wantCreated: true,
+			wantError:   true,
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  apisv1alpha1.DefaultEndpointSliceCreationFailedReason,
+		},
+		"owned slice exists": {
+			optIn:      true,
+			slice:      newSlice("", "widgets", true),
+			wantStatus: corev1.ConditionTrue,
+		},
+		"slice of the provider referencing the export is used": {
+			optIn:      true,
+			slice:      newSlice("root:org:ws", "widgets", false),
+			wantStatus: corev1.ConditionTrue,
+		},
+		"name conflict": {
+			optIn:      true,
+			slice:      newSlice("root:other", "widgets", false),
+			wantStatus: corev1.ConditionFalse,
+			wantReason: apisv1alpha1.DefaultEndpointSliceNameConflictReason,
+		},
+		"owned slice deleted after opt-out": {
+			slice:       newSlice("", "widgets", true),
+			wantDeleted: true,
+		},
+		"slice of the provider kept after opt-out": {
+			slice: newSlice("", "widgets", false),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created *apisv1alpha1.APIExportEndpointSlice
+			var deleted bool
+			c := &controller{
+				getAPIExportEndpointSlice: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error) {
+					require.Equal(t, "root:org:ws", clusterName.String())
+					if tc.slice == nil {
+						return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexportendpointslices"), name)
+					}
+					return tc.slice, nil
+				},
+				createAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+					created = slice
+					return tc.createError
+				},
+				deleteAPIExportEndpointSlice: func(ctx context.Context, clusterName logicalcluster.Path, slice *apisv1alpha1.APIExportEndpointSlice) error {
+					deleted = true
+					return nil
+				},
+			}
+
+			export := newExport(tc.optIn)
+			err := c.reconcile(context.Background(), export)
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.wantCreated, created != nil)
+			if created != nil {
+				require.Equal(t, "widgets", created.Name)
+				require.Equal(t, "widgets", created.Spec.APIExport.Name)
+				require.True(t, metav1.IsControlledBy(created, export))
+			}
+			require.Equal(t, tc.wantDeleted, deleted)
+
+			cond := conditions.Get(export, apisv1alpha1.APIExportDefaultEndpointSliceReady)
+			if tc.wantStatus == "" {
+				require.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			require.Equal(t, tc.wantStatus, cond.Status)
+			require.Equal(t, tc.wantReason, cond.Reason)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -35,16 +36,20 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
-	apisinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
 	"github.com/kcp-dev/kcp/pkg/logging"
-	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	topologyinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/topology/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
 )
 
 const (
@@ -52,17 +57,28 @@ const (
 )
 
 // NewController returns a new controller for APIExportEndpointSlices.
-// Shards and APIExports are read from the cache server.
+// Shards, APIExports and APIExportConsumerSummaries are read from the cache server. If endpointProbeInterval
+// is positive, the published endpoints are probed periodically and their state is recorded.
+// If endpointDNSBaseDomain is not empty, the endpoints get a DNS name rendered from
+// endpointDNSNameTemplate. Probing is skipped while probingPaused returns true.
 func NewController(
 	apiExportEndpointSliceClusterInformer apisinformers.APIExportEndpointSliceClusterInformer,
+	partitionClusterInformer topologyinformers.PartitionClusterInformer,
 	shardClusterInformer corev1alpha1informers.ShardClusterInformer,
 	apiExportClusterInformer apisinformers.APIExportClusterInformer,
+	apiExportConsumerSummaryClusterInformer apisinformers.APIExportConsumerSummaryClusterInformer,
 	kcpClusterClient kcpclientset.ClusterInterface,
+	endpointProbeInterval time.Duration,
+	endpointProbeTimeout time.Duration,
+	endpointDNSBaseDomain string,
+	endpointDNSNameTemplate string,
+	probingPaused func() bool,
+	controllerSwitch *controllerswitch.Switch,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &controller{
-		queue: queue,
+		queue: controllerSwitch.Queue(queue),
 		listAPIExportEndpointSlices: func() ([]*apisv1alpha1.APIExportEndpointSlice, error) {
 			return apiExportEndpointSliceClusterInformer.Lister().List(labels.Everything())
 		},
@@ -72,19 +88,52 @@ func NewController(
 		getAPIExportEndpointSlice: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error) {
 			return apiExportEndpointSliceClusterInformer.Lister().Cluster(clusterName).Get(name)
 		},
+		getPartition: func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error) {
+			return partitionClusterInformer.Lister().Cluster(clusterName).Get(name)
+		},
 		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 			return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportClusterInformer.Informer().GetIndexer(), path, name)
 		},
+		listAPIExportConsumerSummaries: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+			return apiExportConsumerSummaryClusterInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		listAPIExportConsumerSummariesByShard: func(shardName string) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+			return indexers.ByIndex[*apisv1alpha1.APIExportConsumerSummary](apiExportConsumerSummaryClusterInformer.Informer().GetIndexer(), indexAPIExportConsumerSummaryByShard, shardName)
+		},
+		deleteAPIExportEndpointSlice: func(ctx context.Context, slice *apisv1alpha1.APIExportEndpointSlice) error {
+			return kcpClusterClient.Cluster(logicalcluster.From(slice).Path()).ApisV1alpha1().APIExportEndpointSlices().Delete(ctx, slice.Name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &slice.UID},
+			})
+		},
 		apiExportEndpointSliceClusterInformer: apiExportEndpointSliceClusterInformer,
 		commit:                                committer.NewCommitter[*APIExportEndpointSlice, Patcher, *APIExportEndpointSliceSpec, *APIExportEndpointSliceStatus](kcpClusterClient.ApisV1alpha1().APIExportEndpointSlices()),
 	}
 
+	if endpointProbeInterval > 0 {
+		c.prober = newEndpointProber(endpointProbeInterval, endpointProbeTimeout)
+		c.probingPaused = probingPaused
+	}
+
+	if endpointDNSBaseDomain != "" {
+		dnsName, err := newDNSNamer(endpointDNSBaseDomain, endpointDNSNameTemplate)
+		if err != nil {
+			return nil, err
+		}
+		c.dnsName = dnsName
+	}
+
 	indexers.AddIfNotPresentOrDie(apiExportClusterInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
 	})
 
+	indexers.AddIfNotPresentOrDie(apiExportConsumerSummaryClusterInformer.Informer().GetIndexer(), cache.Indexers{
+		indexAPIExportConsumerSummaryByShard: indexAPIExportConsumerSummaryByShardFunc,
+	})
+
 	indexers.AddIfNotPresentOrDie(apiExportEndpointSliceClusterInformer.Informer().GetIndexer(), cache.Indexers{
 		indexAPIExportEndpointSliceByAPIExport: indexAPIExportEndpointSliceByAPIExportFunc,
+		indexAPIExportEndpointSliceByPartition: indexAPIExportEndpointSliceByPartitionFunc,
+		indexAPIExportEndpointSliceByShard:     indexAPIExportEndpointSliceByShardFunc,
 	})
 
 	apiExportEndpointSliceClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -108,17 +157,41 @@ func NewController(
 		},
 	})
 
+	partitionClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForPartition(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForPartition(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForPartition(obj)
+		},
+	})
+
+	apiExportConsumerSummaryClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForAPIExportConsumerSummary(nil, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForAPIExportConsumerSummary(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueAPIExportEndpointSlicesForAPIExportConsumerSummary(obj, nil)
+		},
+	})
+
 	shardClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			c.enqueueAllAPIExportEndpointSlices(obj)
+			c.enqueueAPIExportEndpointSlicesForShard(obj)
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			if filterShardEvent(oldObj, newObj) {
-				c.enqueueAllAPIExportEndpointSlices(newObj)
+				c.enqueueAPIExportEndpointSlicesForShard(newObj)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
-			c.enqueueAllAPIExportEndpointSlices(obj)
+			c.enqueueAPIExportEndpointSlicesForShard(obj)
 		},
 	},
 	)
@@ -138,13 +211,23 @@ type CommitFunc = func(context.Context, *Resource, *Resource) error
 type controller struct {
 	queue workqueue.RateLimitingInterface
 
-	listShards                  func() ([]*corev1alpha1.Shard, error)
-	listAPIExportEndpointSlices func() ([]*apisv1alpha1.APIExportEndpointSlice, error)
-	getAPIExportEndpointSlice   func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error)
-	getAPIExport                func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	listShards                            func() ([]*corev1alpha1.Shard, error)
+	listAPIExportEndpointSlices           func() ([]*apisv1alpha1.APIExportEndpointSlice, error)
+	getAPIExportEndpointSlice             func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExportEndpointSlice, error)
+	getPartition                          func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error)
+	getAPIExport                          func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	listAPIExportConsumerSummaries        func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error)
+	listAPIExportConsumerSummariesByShard func(shardName string) ([]*apisv1alpha1.APIExportConsumerSummary, error)
+	deleteAPIExportEndpointSlice          func(ctx context.Context, slice *apisv1alpha1.APIExportEndpointSlice) error
 
 	apiExportEndpointSliceClusterInformer apisinformers.APIExportEndpointSliceClusterInformer
 	commit                                CommitFunc
+
+	// prober is nil if endpoint probing is disabled.
+	prober        *endpointProber
+	probingPaused func() bool
+	// dnsName is nil if DNS names are disabled.
+	dnsName func(shard *corev1alpha1.Shard) (string, error)
 }
 
 // enqueueAPIExportEndpointSlice enqueues an APIExportEndpointSlice.
@@ -160,6 +243,16 @@ func (c *controller) enqueueAPIExportEndpointSlice(obj interface{}) {
 	c.queue.Add(key)
 }
 
+// requeueAfter enqueues an APIExportEndpointSlice after the given duration.
+func (c *controller) requeueAfter(slice *apisv1alpha1.APIExportEndpointSlice, duration time.Duration) {
+	key, err := kcpcache.MetaClusterNamespaceKeyFunc(slice)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.AddAfter(key, duration)
+}
+
 // enqueueAPIExportEndpointSlicesForAPIExport enqueues APIExportEndpointSlices referencing a specific APIExport.
 func (c *controller) enqueueAPIExportEndpointSlicesForAPIExport(obj interface{}) {
 	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
@@ -203,25 +296,119 @@ func (c *controller) enqueueAPIExportEndpointSlicesForAPIExport(obj interface{})
 	}
 }
 
-// enqueueAllAPIExportEndpointSlices enqueues all APIExportEndpointSlices.
-func (c *controller) enqueueAllAPIExportEndpointSlices(shard interface{}) {
-	list, err := c.listAPIExportEndpointSlices()
-	if err != nil {
+// enqueueAPIExportEndpointSlicesForAPIExportConsumerSummary enqueues the APIExportEndpointSlices of the
+// APIExports that gained or lost their last APIBinding on the shard of an APIExportConsumerSummary.
+// Either of oldObj and newObj can be nil.
+func (c *controller) enqueueAPIExportEndpointSlicesForAPIExportConsumerSummary(oldObj, newObj interface{}) {
+	var clusterName logicalcluster.Name
+	exports := make([]sets.String, 0, 2)
+	for _, obj := range []interface{}{oldObj, newObj} {
+		if obj == nil {
+			exports = append(exports, sets.NewString())
+			continue
+		}
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		summary, ok := obj.(*apisv1alpha1.APIExportConsumerSummary)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("obj is supposed to be an APIExportConsumerSummary, but is %T", obj))
+			return
+		}
+		clusterName = logicalcluster.From(summary)
+		exports = append(exports, boundAPIExports(summary))
+	}
+
+	for _, name := range exports[0].Difference(exports[1]).Union(exports[1].Difference(exports[0])).List() {
+		c.enqueueAPIExportEndpointSlicesForAPIExportName(clusterName, name)
+	}
+}
+
+// enqueueAPIExportEndpointSlicesForAPIExportName enqueues the APIExportEndpointSlices referencing the
+// APIExport with the given name in the given logical cluster.
+func (c *controller) enqueueAPIExportEndpointSlicesForAPIExportName(clusterName logicalcluster.Name, name string) {
+	export, err := c.getAPIExport(clusterName.Path(), name)
+	if errors.IsNotFound(err) {
+		return
+	} else if err != nil {
 		runtime.HandleError(err)
 		return
 	}
 
-	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), shard.(*corev1alpha1.Shard))
-	for i := range list {
-		key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(list[i])
-		if err != nil {
-			runtime.HandleError(err)
-			continue
+	c.enqueueAPIExportEndpointSlicesForAPIExport(export)
+}
+
+// boundAPIExports returns the names of the APIExports with APIBindings on the shard of the summary.
+func boundAPIExports(summary *apisv1alpha1.APIExportConsumerSummary) sets.String {
+	names := sets.NewString()
+	for _, export := range summary.Spec.APIExports {
+		if export.APIBindings > 0 {
+			names.Insert(export.Name)
 		}
+	}
+	return names
+}
 
+// enqueueAPIExportEndpointSlicesForPartition enqueues APIExportEndpointSlices referencing a specific Partition.
+func (c *controller) enqueueAPIExportEndpointSlicesForPartition(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	partition, ok := obj.(*topologyv1alpha1.Partition)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be a Partition, but is %T", obj))
+		return
+	}
+
+	keys, err := c.apiExportEndpointSliceClusterInformer.Informer().GetIndexer().IndexKeys(indexAPIExportEndpointSliceByPartition, logicalcluster.From(partition).Path().Join(partition.Name).String())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), partition)
+	for _, key := range keys {
+		logging.WithQueueKey(logger, key).V(2).Info("queuing APIExportEndpointSlice because of referenced Partition")
+		c.queue.Add(key)
+	}
+}
+
+// enqueueAPIExportEndpointSlicesForShard enqueues the APIExportEndpointSlices a Shard contributes an
+// endpoint to, or could contribute one to because it holds APIBindings to their APIExport. Slices
+// not related to the Shard are left alone.
+func (c *controller) enqueueAPIExportEndpointSlicesForShard(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	shard, ok := obj.(*corev1alpha1.Shard)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be a Shard, but is %T", obj))
+		return
+	}
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), shard)
+
+	// slices publishing an endpoint of the shard
+	keys, err := c.apiExportEndpointSliceClusterInformer.Informer().GetIndexer().IndexKeys(indexAPIExportEndpointSliceByShard, shard.Name)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, key := range keys {
 		logging.WithQueueKey(logger, key).V(2).Info("queuing APIExportEndpointSlice because Shard changed")
 		c.queue.Add(key)
 	}
+
+	// slices of APIExports bound on the shard
+	summaries, err := c.listAPIExportConsumerSummariesByShard(shard.Name)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, summary := range summaries {
+		for _, name := range boundAPIExports(summary).List() {
+			c.enqueueAPIExportEndpointSlicesForAPIExportName(logicalcluster.From(summary), name)
+		}
+	}
 }
 
 // Start starts the controller, which stops when ctx.Done() is closed.
@@ -238,9 +425,45 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
 	}
 
+	if c.prober != nil {
+		go wait.UntilWithContext(ctx, c.probeEndpoints, c.prober.interval)
+	}
+
 	<-ctx.Done()
 }
 
+// probeEndpoints probes the endpoints of all APIExportEndpointSlices, and enqueues the
+// slices whose recorded endpoint states are outdated.
+func (c *controller) probeEndpoints(ctx context.Context) {
+	if c.probingPaused != nil && c.probingPaused() {
+		klog.FromContext(ctx).V(2).Info("endpoint probing paused")
+		return
+	}
+
+	list, err := c.listAPIExportEndpointSlices()
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	var urls []string
+	for _, slice := range list {
+		for _, endpoint := range slice.Status.APIExportEndpoints {
+			urls = append(urls, endpoint.URL)
+		}
+	}
+	c.prober.probeAll(ctx, urls)
+
+	for _, slice := range list {
+		for _, endpoint := range slice.Status.APIExportEndpoints {
+			if result, found := c.prober.result(endpoint.URL); found && !result.recordedIn(endpoint) {
+				c.enqueueAPIExportEndpointSlice(slice)
+				break
+			}
+		}
+	}
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -302,7 +525,8 @@ func (c *controller) process(ctx context.Context, key string) error {
 	// If the object being reconciled changed as a result, update it.
 	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
 	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
-	if err := c.commit(ctx, oldResource, newResource); err != nil {
+	if err := c.commit(ctx, oldResource, newResource); err != nil && !errors.IsNotFound(err) {
+		// NotFound means the slice has been deleted as orphan.
 		errs = append(errs, err)
 	}
 
@@ -325,5 +549,8 @@ func filterShardEvent(oldObj, newObj interface{}) bool {
 	if !reflect.DeepEqual(oldShard.Labels, newShard.Labels) {
 		return true
 	}
+	if conditions.IsFalse(oldShard, corev1alpha1.ShardLive) != conditions.IsFalse(newShard, corev1alpha1.ShardLive) {
+		return true
+	}
 	return false
 }
@@ -21,27 +21,47 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/test/e2e/framework/fakecache"
 )
 
 func TestReconcile(t *testing.T) {
+	servingSince := metav1.NewTime(time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))
+
 	tests := map[string]struct {
 		keyMissing             bool
 		apiExportMissing       bool
 		apiExportHasInvalidRef bool
 		listShardsError        error
+		bindingShards          []string
+		notLiveShards          []string
+		otherExportShards      []string
+		partition              *topologyv1alpha1.Partition
+		partitionMissing       bool
+		probeResults           map[string]apisv1alpha1.APIExportEndpointState
+		dnsNameTemplate        string
 		errorReason            string
+		orphanedSince          *metav1.Time
+		orphanPolicy           apisv1alpha1.APIExportEndpointSliceOrphanPolicy
 
 		wantError                           bool
 		wantVerifyFailure                   bool
@@ -49,6 +69,11 @@ func TestReconcile(t *testing.T) {
 		wantAPIExportEndpointSliceURLsReady bool
 		wantAPIExportValid                  bool
 		wantAPIExportNotValid               bool
+		wantPartitionNotValid               bool
+		wantEndpoints                       []apisv1alpha1.APIExportEndpoint
+		wantRequeueAfter                    time.Duration
+		wantOrphaned                        bool
+		wantDeleted                         bool
 	}{
 		"error listing shards": {
 			listShardsError:                     errors.New("foo"),
@@ -60,9 +85,217 @@ func TestReconcile(t *testing.T) {
 			errorReason:           apisv1alpha1.APIExportNotFoundReason,
 			wantAPIExportNotValid: true,
 		},
+		"orphaned slice requeued until the grace period ends": {
+			apiExportMissing:      true,
+			orphanedSince:         &metav1.Time{Time: now.Add(-30 * time.Minute)},
+			errorReason:           apisv1alpha1.APIExportNotFoundReason,
+			wantAPIExportNotValid: true,
+			wantRequeueAfter:      30 * time.Minute,
+		},
+		"orphaned slice marked after the grace period": {
+			apiExportMissing:      true,
+			orphanedSince:         &metav1.Time{Time: now.Add(-2 * time.Hour)},
+			errorReason:           apisv1alpha1.APIExportNotFoundReason,
+			wantAPIExportNotValid: true,
+			wantOrphaned:          true,
+		},
+		"orphaned slice deleted after the grace period with the Delete policy": {
+			apiExportMissing:      true,
+			orphanedSince:         &metav1.Time{Time: now.Add(-2 * time.Hour)},
+			orphanPolicy:          apisv1alpha1.APIExportEndpointSliceOrphanPolicyDelete,
+			errorReason:           apisv1alpha1.APIExportNotFoundReason,
+			wantAPIExportNotValid: true,
+			wantDeleted:           true,
+		},
 		"APIExportEndpointSliceURLs set when no issue": {
+			bindingShards:                       []string{"shard1", "shard2"},
 			wantAPIExportEndpointSliceURLsReady: true,
 			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-1",
+					Shard:        "shard1",
+					Region:       "eu-west",
+					ServingSince: &servingSince,
+				},
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"shards with an expired lease get no endpoints": {
+			bindingShards:                       []string{"shard1", "shard2"},
+			notLiveShards:                       []string{"shard1"},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"only shards with APIBindings get endpoints": {
+			bindingShards:                       []string{"shard2"},
+			otherExportShards:                   []string{"shard1"},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"shards sharing a virtual workspace URL are listed once": {
+			bindingShards:                       []string{"shard2", "shard3"},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"only shards selected by the partition get endpoints": {
+			bindingShards: []string{"shard1", "shard2"},
+			partition: &topologyv1alpha1.Partition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-partition"},
+				Spec: topologyv1alpha1.PartitionSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1alpha1.ShardRegionLabelKey: "eu-west"}},
+				},
+			},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-1",
+					Shard:        "shard1",
+					Region:       "eu-west",
+					ServingSince: &servingSince,
+				},
+			},
+		},
+		"partition without selector selects all shards": {
+			bindingShards: []string{"shard1", "shard2"},
+			partition: &topologyv1alpha1.Partition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-partition"},
+			},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-1",
+					Shard:        "shard1",
+					Region:       "eu-west",
+					ServingSince: &servingSince,
+				},
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"PartitionValid set to false and endpoints removed when partition is missing": {
+			bindingShards: []string{"shard1", "shard2"},
+			partition: &topologyv1alpha1.Partition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-partition"},
+			},
+			partitionMissing:      true,
+			errorReason:           apisv1alpha1.PartitionInvalidReferenceReason,
+			wantAPIExportValid:    true,
+			wantPartitionNotValid: true,
+			wantEndpoints:         []apisv1alpha1.APIExportEndpoint{},
+		},
+		"probe results are recorded": {
+			bindingShards: []string{"shard1", "shard2"},
+			probeResults: map[string]apisv1alpha1.APIExportEndpointState{
+				"https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export": apisv1alpha1.APIExportEndpointStateUnready,
+			},
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:           "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:            "uid-1",
+					Shard:         "shard1",
+					Region:        "eu-west",
+					ServingSince:  &servingSince,
+					State:         apisv1alpha1.APIExportEndpointStateUnready,
+					LastProbeTime: &now,
+				},
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"DNS names are published": {
+			bindingShards:                       []string{"shard1", "shard2"},
+			dnsNameTemplate:                     "{{.Shard}}.{{.BaseDomain}}",
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-1",
+					Shard:        "shard1",
+					Region:       "eu-west",
+					DNSName:      "shard1.apis.kcp.dev",
+					ServingSince: &servingSince,
+				},
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					DNSName:      "shard2.apis.kcp.dev",
+					ServingSince: &now,
+				},
+			},
+		},
+		"endpoints are published without DNS names failing to render": {
+			bindingShards:                       []string{"shard1", "shard2"},
+			dnsNameTemplate:                     "{{.Region}}.{{.BaseDomain}}",
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints: []apisv1alpha1.APIExportEndpoint{
+				{
+					URL:          "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-1",
+					Shard:        "shard1",
+					Region:       "eu-west",
+					DNSName:      "eu-west.apis.kcp.dev",
+					ServingSince: &servingSince,
+				},
+				{
+					URL:          "https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+					ID:           "uid-2",
+					Shard:        "shard2",
+					ServingSince: &now,
+				},
+			},
+		},
+		"no endpoints without APIBindings": {
+			wantAPIExportEndpointSliceURLsReady: true,
+			wantAPIExportValid:                  true,
+			wantEndpoints:                       []apisv1alpha1.APIExportEndpoint{},
 		},
 	}
 
@@ -76,16 +309,21 @@ func TestReconcile(t *testing.T) {
 						return nil, tc.listShardsError
 					}
 
-					return []*corev1alpha1.Shard{
+					shards := []*corev1alpha1.Shard{
 						{
 							ObjectMeta: metav1.ObjectMeta{
 								Annotations: map[string]string{
 									logicalcluster.AnnotationKey: "root:org:ws",
 								},
 								Name: "shard1",
+								UID:  "uid-1",
+								Labels: map[string]string{
+									corev1alpha1.ShardRegionLabelKey: "eu-west",
+								},
 							},
 							Spec: corev1alpha1.ShardSpec{
-								ExternalURL: "https://server-1.kcp.dev/",
+								ExternalURL:         "https://server-1.kcp.dev/",
+								VirtualWorkspaceURL: "https://server-1.kcp.dev/",
 							},
 						},
 						{
@@ -94,12 +332,40 @@ func TestReconcile(t *testing.T) {
 									logicalcluster.AnnotationKey: "root:org:ws",
 								},
 								Name: "shard2",
+								UID:  "uid-2",
+							},
+							Spec: corev1alpha1.ShardSpec{
+								ExternalURL:         "https://server-2.kcp.dev/",
+								VirtualWorkspaceURL: "https://server-2.kcp.dev/",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									logicalcluster.AnnotationKey: "root:org:ws",
+								},
+								Name: "shard3",
+								UID:  "uid-3",
 							},
 							Spec: corev1alpha1.ShardSpec{
-								ExternalURL: "https://server-2.kcp.dev/",
+								ExternalURL:         "https://server-3.kcp.dev/",
+								VirtualWorkspaceURL: "https://server-2.kcp.dev/",
 							},
 						},
-					}, nil
+					}
+					for _, shard := range shards {
+						if sets.NewString(tc.notLiveShards...).Has(shard.Name) {
+							conditions.MarkFalse(shard, corev1alpha1.ShardLive, corev1alpha1.ShardLeaseExpiredReason, conditionsv1alpha1.ConditionSeverityError, "")
+						}
+					}
+					return shards, nil
+				},
+				getPartition: func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error) {
+					require.Equal(t, "root:org:ws", clusterName.String())
+					if tc.partition == nil || tc.partitionMissing || tc.partition.Name != name {
+						return nil, apierrors.NewNotFound(topologyv1alpha1.Resource("partitions"), name)
+					}
+					return tc.partition, nil
 				},
 				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 					if tc.apiExportMissing {
@@ -133,7 +399,78 @@ func TestReconcile(t *testing.T) {
 					},
 				},
 			}
-			err := c.reconcile(context.Background(), apiExportEndpointSlice)
+			if tc.partition != nil {
+				apiExportEndpointSlice.Spec.Partition = tc.partition.Name
+			}
+			apiExportEndpointSlice.Spec.OrphanPolicy = tc.orphanPolicy
+			if tc.orphanedSince != nil {
+				apiExportEndpointSlice.Status.Conditions = conditionsv1alpha1.Conditions{{
+					Type:               apisv1alpha1.APIExportValid,
+					Status:             "False",
+					Severity:           conditionsv1alpha1.ConditionSeverityError,
+					Reason:             apisv1alpha1.APIExportNotFoundReason,
+					Message:            "APIExport root:org:ws|my-export not found",
+					LastTransitionTime: *tc.orphanedSince,
+				}}
+			}
+			// shard1 already served the endpoint under an old URL
+			apiExportEndpointSlice.Status.APIExportEndpoints = []apisv1alpha1.APIExportEndpoint{
+				{URL: "https://old.kcp.dev/services/apiexport/root:org:ws/my-export", ID: "uid-1", Shard: "shard1", ServingSince: &servingSince},
+			}
+			r := &endpointsReconciler{
+				listShards:   c.listShards,
+				getPartition: c.getPartition,
+				getAPIExport: c.getAPIExport,
+				listAPIExportConsumerSummaries: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+					var summaries []*apisv1alpha1.APIExportConsumerSummary
+					for _, shard := range tc.bindingShards {
+						summaries = append(summaries, &apisv1alpha1.APIExportConsumerSummary{
+							ObjectMeta: metav1.ObjectMeta{Name: shard},
+							Spec: apisv1alpha1.APIExportConsumerSummarySpec{
+								Shard: shard,
+								APIExports: []apisv1alpha1.APIExportShardConsumers{
+									{Name: "my-export", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 2}},
+								},
+							},
+						})
+					}
+					for _, shard := range tc.otherExportShards {
+						summaries = append(summaries, &apisv1alpha1.APIExportConsumerSummary{
+							ObjectMeta: metav1.ObjectMeta{Name: shard},
+							Spec: apisv1alpha1.APIExportConsumerSummarySpec{
+								Shard: shard,
+								APIExports: []apisv1alpha1.APIExportShardConsumers{
+									{Name: "other-export", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 1}},
+									{Name: "my-export", MigratedAPIBindings: 1},
+								},
+							},
+						})
+					}
+					return summaries, nil
+				},
+				now: func() time.Time { return now.Time },
+			}
+			var requeuedAfter time.Duration
+			r.requeueAfter = func(_ *apisv1alpha1.APIExportEndpointSlice, duration time.Duration) {
+				requeuedAfter = duration
+			}
+			var deleted bool
+			r.deleteAPIExportEndpointSlice = func(_ context.Context, _ *apisv1alpha1.APIExportEndpointSlice) error {
+				deleted = true
+				return nil
+			}
+			if tc.probeResults != nil {
+				r.probeResult = func(endpointURL string) (probeResult, bool) {
+					state, found := tc.probeResults[endpointURL]
+					return probeResult{state: state, time: now}, found
+				}
+			}
+			if tc.dnsNameTemplate != "" {
+				dnsName, err := newDNSNamer("apis.kcp.dev", tc.dnsNameTemplate)
+				require.NoError(t, err)
+				r.dnsName = dnsName
+			}
+			err := r.reconcile(context.Background(), apiExportEndpointSlice)
 			if tc.wantError {
 				require.Error(t, err, "expected an error")
 			} else {
@@ -166,15 +503,166 @@ func TestReconcile(t *testing.T) {
 				)
 			}
 
+			if tc.wantPartitionNotValid {
+				requireConditionMatches(t, apiExportEndpointSlice,
+					conditions.FalseCondition(
+						apisv1alpha1.PartitionValid,
+						tc.errorReason,
+						conditionsv1alpha1.ConditionSeverityError,
+						"",
+					),
+				)
+			} else if tc.partition != nil {
+				requireConditionMatches(t, apiExportEndpointSlice, conditions.TrueCondition(apisv1alpha1.PartitionValid))
+			}
+
+			if tc.wantEndpoints != nil {
+				require.Empty(t, cmp.Diff(tc.wantEndpoints, apiExportEndpointSlice.Status.APIExportEndpoints, cmpopts.EquateEmpty()))
+			}
+
 			if tc.wantAPIExportValid {
 				requireConditionMatches(t, apiExportEndpointSlice,
 					conditions.TrueCondition(apisv1alpha1.APIExportValid),
 				)
 			}
+
+			if tc.wantRequeueAfter != 0 {
+				require.Equal(t, tc.wantRequeueAfter, requeuedAfter)
+			}
+			if tc.wantOrphaned {
+				requireConditionMatches(t, apiExportEndpointSlice, &conditionsv1alpha1.Condition{
+					Type:     apisv1alpha1.APIExportEndpointSliceOrphaned,
+					Status:   "True",
+					Severity: conditionsv1alpha1.ConditionSeverityWarning,
+					Reason:   apisv1alpha1.APIExportDeletedReason,
+				})
+			} else {
+				require.Nil(t, conditions.Get(apiExportEndpointSlice, apisv1alpha1.APIExportEndpointSliceOrphaned))
+			}
+			require.Equal(t, tc.wantDeleted, deleted)
 		})
 	}
 }
 
+func TestReconcileWithCacheServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	slice := &apisv1alpha1.APIExportEndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:consumer",
+			},
+			Name: "my-slice",
+		},
+		Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+			APIExport: apisv1alpha1.ExportBindingReference{
+				Path: "root:org:ws",
+				Name: "my-export",
+			},
+		},
+	}
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:org:ws",
+			},
+			Name: "my-export",
+		},
+	}
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root",
+			},
+			Name: "shard1",
+			UID:  "uid-1",
+		},
+		Spec: corev1alpha1.ShardSpec{
+			ExternalURL:         "https://server-1.kcp.dev/",
+			VirtualWorkspaceURL: "https://server-1.kcp.dev/",
+		},
+	}
+	summary := &apisv1alpha1.APIExportConsumerSummary{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:org:ws",
+			},
+			Name: "shard1",
+		},
+		Spec: apisv1alpha1.APIExportConsumerSummarySpec{
+			Shard: "shard1",
+			APIExports: []apisv1alpha1.APIExportShardConsumers{
+				{Name: "my-export", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 1}},
+			},
+		},
+	}
+
+	// the slice is local, everything else comes from the cache server
+	cacheServer := fakecache.NewServer(shard)
+	localClient := kcpfakeclient.NewSimpleClientset(slice)
+	localInformers := kcpinformers.NewSharedInformerFactory(localClient, 0)
+	c, err := NewController(
+		localInformers.Apis().V1alpha1().APIExportEndpointSlices(),
+		localInformers.Topology().V1alpha1().Partitions(),
+		cacheServer.InformerFactory().Core().V1alpha1().Shards(),
+		cacheServer.InformerFactory().Apis().V1alpha1().APIExports(),
+		cacheServer.InformerFactory().Apis().V1alpha1().APIExportConsumerSummaries(),
+		localClient,
+		0, 0, "", "", nil, nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, cacheServer.Start(ctx))
+
+	reconcile := func() *apisv1alpha1.APIExportEndpointSlice {
+		obj := slice.DeepCopy()
+		require.NoError(t, c.reconcile(ctx, obj))
+		return obj
+	}
+
+	cacheServer.SetReplicationLag(time.Minute)
+	cacheServer.Replicate("shard1", export)
+	cacheServer.Replicate("shard1", summary)
+
+	t.Log("The slice is invalid while the APIExport has not been replicated yet")
+	require.NoError(t, cacheServer.Step(30*time.Second))
+	obj := reconcile()
+	requireConditionMatches(t, obj, conditions.FalseCondition(apisv1alpha1.APIExportValid, apisv1alpha1.APIExportNotFoundReason, conditionsv1alpha1.ConditionSeverityError, ""))
+	require.Empty(t, obj.Status.APIExportEndpoints)
+
+	t.Log("A failed replication is retried on the next step")
+	cacheServer.FailReplications(errors.New("replication failed"))
+	require.Error(t, cacheServer.Step(30*time.Second))
+	require.Equal(t, 1, cacheServer.Pending())
+	require.NoError(t, cacheServer.Step(0))
+	require.Equal(t, 0, cacheServer.Pending())
+
+	t.Log("The slice gets the endpoint of the shard once the APIExport and the summary are replicated")
+	require.Eventually(t, func() bool {
+		obj = slice.DeepCopy()
+		if err := c.reconcile(ctx, obj); err != nil {
+			return false
+		}
+		return len(obj.Status.APIExportEndpoints) == 1
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	requireConditionMatches(t, obj, conditions.TrueCondition(apisv1alpha1.APIExportValid))
+	require.Equal(t, "https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export", obj.Status.APIExportEndpoints[0].URL)
+	require.Equal(t, "shard1", obj.Status.APIExportEndpoints[0].Shard)
+
+	t.Log("The endpoint is dropped when the APIExport is deleted in the cache server")
+	cacheServer.SetReplicationLag(0)
+	cacheServer.ReplicateDeletion("shard1", export)
+	require.NoError(t, cacheServer.Flush())
+	require.Eventually(t, func() bool {
+		obj = slice.DeepCopy()
+		if err := c.reconcile(ctx, obj); err != nil {
+			return false
+		}
+		return conditions.IsFalse(obj, apisv1alpha1.APIExportValid)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+	require.Empty(t, obj.Status.APIExportEndpoints)
+}
+
 // requireConditionMatches looks for a condition matching c in g. LastTransitionTime and Message
 // are not compared.
 func requireConditionMatches(t *testing.T, g conditions.Getter, c *conditionsv1alpha1.Condition) {
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// DefaultEndpointDNSNameTemplate renders the DNS name of an endpoint as <shard>.<base domain>.
+const DefaultEndpointDNSNameTemplate = "{{.Shard}}.{{.BaseDomain}}"
+
+// dnsNameData is what the DNS name template of endpoints is rendered with.
+type dnsNameData struct {
+	// Shard is the name of the shard of the endpoint.
+	Shard string
+	// Region is the value of the topology.kcp.io/region label of the shard, if set.
+	Region string
+	// BaseDomain is the configured base domain.
+	BaseDomain string
+}
+
+// newDNSNamer returns a function rendering the DNS name of the endpoint of a shard with the
+// given template and base domain.
+func newDNSNamer(baseDomain, nameTemplate string) (func(shard *corev1alpha1.Shard) (string, error), error) {
+	if errs := validation.IsDNS1123Subdomain(baseDomain); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid base domain %q: %s", baseDomain, strings.Join(errs, ", "))
+	}
+	tmpl, err := template.New("dnsName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS name template %q: %w", nameTemplate, err)
+	}
+
+	return func(shard *corev1alpha1.Shard) (string, error) {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, dnsNameData{
+			Shard:      shard.Name,
+			Region:     shard.Labels[corev1alpha1.ShardRegionLabelKey],
+			BaseDomain: baseDomain,
+		}); err != nil {
+			return "", fmt.Errorf("error rendering DNS name of shard %s: %w", shard.Name, err)
+		}
+
+		name := strings.ToLower(b.String())
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return "", fmt.Errorf("invalid DNS name %q of shard %s: %s", name, shard.Name, strings.Join(errs, ", "))
+		}
+		return name, nil
+	}, nil
+}
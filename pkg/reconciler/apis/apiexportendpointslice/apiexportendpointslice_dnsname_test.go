@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestDNSNamer(t *testing.T) {
+	shard := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "Shard-1",
+			Labels: map[string]string{corev1alpha1.ShardRegionLabelKey: "eu-west"},
+		},
+	}
+
+	tests := map[string]struct {
+		baseDomain   string
+		nameTemplate string
+		shard        *corev1alpha1.Shard
+
+		wantInvalid   bool
+		wantRenderErr bool
+		want          string
+	}{
+		"default template": {
+			baseDomain:   "apis.kcp.dev",
+			nameTemplate: DefaultEndpointDNSNameTemplate,
+			shard:        shard,
+			want:         "shard-1.apis.kcp.dev",
+		},
+		"region in template": {
+			baseDomain:   "apis.kcp.dev",
+			nameTemplate: "{{.Shard}}.{{.Region}}.{{.BaseDomain}}",
+			shard:        shard,
+			want:         "shard-1.eu-west.apis.kcp.dev",
+		},
+		"invalid base domain": {
+			baseDomain:   "apis_kcp.dev",
+			nameTemplate: DefaultEndpointDNSNameTemplate,
+			wantInvalid:  true,
+		},
+		"invalid template": {
+			baseDomain:   "apis.kcp.dev",
+			nameTemplate: "{{.Shard",
+			wantInvalid:  true,
+		},
+		"unknown key in template": {
+			baseDomain:    "apis.kcp.dev",
+			nameTemplate:  "{{.Zone}}.{{.BaseDomain}}",
+			shard:         shard,
+			wantRenderErr: true,
+		},
+		"invalid rendered name": {
+			baseDomain:    "apis.kcp.dev",
+			nameTemplate:  "{{.Region}}.{{.BaseDomain}}",
+			shard:         &corev1alpha1.Shard{ObjectMeta: metav1.ObjectMeta{Name: "shard-2"}},
+			wantRenderErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dnsName, err := newDNSNamer(tt.baseDomain, tt.nameTemplate)
+			if tt.wantInvalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := dnsName(tt.shard)
+			if tt.wantRenderErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -21,7 +21,7 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 const indexAPIExportEndpointSliceByAPIExport = "indexAPIExportEndpointSliceByAPIExport"
@@ -33,9 +33,57 @@ func indexAPIExportEndpointSliceByAPIExportFunc(obj interface{}) ([]string, erro
 		return []string{}, fmt.Errorf("obj %T is not an APIExportEndpointSlice", obj)
 	}
 
-	path := logicalcluster.NewPath(apiExportEndpointSlice.Spec.APIExport.Path)
+	path := apiExportEndpointSlice.Spec.APIExport.Path.Path()
 	if path.Empty() {
 		path = logicalcluster.From(apiExportEndpointSlice).Path()
 	}
 	return []string{path.Join(apiExportEndpointSlice.Spec.APIExport.Name).String()}, nil
 }
+
+const indexAPIExportEndpointSliceByPartition = "indexAPIExportEndpointSliceByPartition"
+
+// indexAPIExportEndpointSliceByPartitionFunc indexes the APIExportEndpointSlice by their Partition's cluster and name.
+func indexAPIExportEndpointSliceByPartitionFunc(obj interface{}) ([]string, error) {
+	apiExportEndpointSlice, ok := obj.(*apisv1alpha1.APIExportEndpointSlice)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIExportEndpointSlice", obj)
+	}
+
+	if apiExportEndpointSlice.Spec.Partition == "" {
+		return []string{}, nil
+	}
+	return []string{logicalcluster.From(apiExportEndpointSlice).Path().Join(apiExportEndpointSlice.Spec.Partition).String()}, nil
+}
+
+const indexAPIExportEndpointSliceByShard = "indexAPIExportEndpointSliceByShard"
+
+// indexAPIExportEndpointSliceByShardFunc indexes the APIExportEndpointSlice by the shards of their published endpoints.
+func indexAPIExportEndpointSliceByShardFunc(obj interface{}) ([]string, error) {
+	apiExportEndpointSlice, ok := obj.(*apisv1alpha1.APIExportEndpointSlice)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIExportEndpointSlice", obj)
+	}
+
+	shards := make([]string, 0, len(apiExportEndpointSlice.Status.APIExportEndpoints))
+	for _, endpoint := range apiExportEndpointSlice.Status.APIExportEndpoints {
+		if endpoint.Shard != "" {
+			shards = append(shards, endpoint.Shard)
+		}
+	}
+	return shards, nil
+}
+
+const indexAPIExportConsumerSummaryByShard = "indexAPIExportConsumerSummaryByShard"
+
+// indexAPIExportConsumerSummaryByShardFunc indexes the APIExportConsumerSummaries by the shard of the summarized APIBindings.
+func indexAPIExportConsumerSummaryByShardFunc(obj interface{}) ([]string, error) {
+	summary, ok := obj.(*apisv1alpha1.APIExportConsumerSummary)
+	if !ok {
+		return []string{}, fmt.Errorf("obj %T is not an APIExportConsumerSummary", obj)
+	}
+
+	if summary.Spec.Shard == "" {
+		return []string{}, nil
+	}
+	return []string{summary.Spec.Shard}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"reflect"
+	"testing"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestIndexAPIExportEndpointSliceByShard(t *testing.T) {
+	tests := map[string]struct {
+		obj     interface{}
+		want    []string
+		wantErr bool
+	}{
+		"not an APIExportEndpointSlice": {
+			obj:     "not an APIExportEndpointSlice",
+			want:    []string{},
+			wantErr: true,
+		},
+		"no endpoints": {
+			obj:  &apisv1alpha1.APIExportEndpointSlice{},
+			want: []string{},
+		},
+		"endpoints of shards": {
+			obj: &apisv1alpha1.APIExportEndpointSlice{
+				Status: apisv1alpha1.APIExportEndpointSliceStatus{
+					APIExportEndpoints: []apisv1alpha1.APIExportEndpoint{
+						{URL: "https://server-1.kcp.dev/", Shard: "shard-1"},
+						{URL: "https://server-2.kcp.dev/", Shard: "shard-2"},
+						{URL: "https://server-3.kcp.dev/"},
+					},
+				},
+			},
+			want: []string{"shard-1", "shard-2"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := indexAPIExportEndpointSliceByShardFunc(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("indexAPIExportEndpointSliceByShardFunc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("indexAPIExportEndpointSliceByShardFunc() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexAPIExportConsumerSummaryByShard(t *testing.T) {
+	tests := map[string]struct {
+		obj     interface{}
+		want    []string
+		wantErr bool
+	}{
+		"not an APIExportConsumerSummary": {
+			obj:     "not an APIExportConsumerSummary",
+			want:    []string{},
+			wantErr: true,
+		},
+		"no shard": {
+			obj:  &apisv1alpha1.APIExportConsumerSummary{},
+			want: []string{},
+		},
+		"summary of a shard": {
+			obj: &apisv1alpha1.APIExportConsumerSummary{
+				Spec: apisv1alpha1.APIExportConsumerSummarySpec{Shard: "shard-1"},
+			},
+			want: []string{"shard-1"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := indexAPIExportConsumerSummaryByShardFunc(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("indexAPIExportConsumerSummaryByShardFunc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("indexAPIExportConsumerSummaryByShardFunc() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// probeResult is the outcome of the last probe of a virtual workspace server. The time is the
+// one of the probe that first observed the state, such that repeated probes observing the same
+// state do not cause status updates.
+type probeResult struct {
+	state apisv1alpha1.APIExportEndpointState
+	time  metav1.Time
+}
+
+// recordedIn returns true if the result is recorded in the given endpoint already.
+func (r probeResult) recordedIn(endpoint apisv1alpha1.APIExportEndpoint) bool {
+	return endpoint.State == r.state && endpoint.LastProbeTime != nil && endpoint.LastProbeTime.Equal(&r.time)
+}
+
+// endpointProber probes the virtual workspace servers of published endpoints. Endpoints are
+// probed per server, i.e. per scheme and host of their URL, as all APIExports of a shard are
+// served by the same server.
+type endpointProber struct {
+	interval time.Duration
+	probe    func(ctx context.Context, server *url.URL) error
+	now      func() time.Time
+
+	lock    sync.RWMutex
+	results map[string]probeResult
+}
+
+func newEndpointProber(interval, timeout time.Duration) *endpointProber {
+	return &endpointProber{
+		interval: interval,
+		probe:    dialProbe(timeout),
+		now:      time.Now,
+		results:  map[string]probeResult{},
+	}
+}
+
+// result returns the last probe result of the server of the given endpoint URL.
+func (p *endpointProber) result(endpointURL string) (probeResult, bool) {
+	key, _, err := serverKey(endpointURL)
+	if err != nil {
+		return probeResult{}, false
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	r, found := p.results[key]
+	return r, found
+}
+
+// probeAll probes the servers of the given endpoint URLs concurrently, and forgets the results
+// of servers not referenced anymore.
+func (p *endpointProber) probeAll(ctx context.Context, endpointURLs []string) {
+	logger := klog.FromContext(ctx)
+
+	servers := map[string]*url.URL{}
+	for _, endpointURL := range endpointURLs {
+		key, u, err := serverKey(endpointURL)
+		if err != nil {
+			logger.Error(err, "error parsing endpoint URL", "url", endpointURL)
+			continue
+		}
+		servers[key] = u
+	}
+
+	var wg sync.WaitGroup
+	var resultsLock sync.Mutex
+	results := make(map[string]probeResult, len(servers))
+	for key, u := range servers {
+		wg.Add(1)
+		go func(key string, u *url.URL) {
+			defer wg.Done()
+			state := apisv1alpha1.APIExportEndpointStateReady
+			if err := p.probe(ctx, u); err != nil {
+				logger.V(4).Info("endpoint probe failed", "server", key, "err", err)
+				state = apisv1alpha1.APIExportEndpointStateUnready
+			}
+
+			resultsLock.Lock()
+			defer resultsLock.Unlock()
+			results[key] = probeResult{state: state, time: metav1.NewTime(p.now())}
+		}(key, u)
+	}
+	wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for key, r := range results {
+		old, found := p.results[key]
+		if !found {
+			continue
+		}
+		if old.state == r.state {
+			results[key] = old
+			continue
+		}
+		logger.V(2).Info("endpoint state changed", "server", key, "state", r.state)
+	}
+	p.results = results
+}
+
+// serverKey returns the scheme and host of an endpoint URL, identifying its virtual workspace server.
+func serverKey(endpointURL string) (string, *url.URL, error) {
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return "", nil, err
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("endpoint URL %q has no host", endpointURL)
+	}
+	return u.Scheme + "://" + u.Host, u, nil
+}
+
+// dialProbe returns a probe that succeeds if the server accepts a TCP connection and, for
+// https, completes a TLS handshake within the timeout.
+func dialProbe(timeout time.Duration) func(ctx context.Context, server *url.URL) error {
+	return func(ctx context.Context, server *url.URL) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		port := server.Port()
+		if port == "" {
+			port = "443"
+			if server.Scheme == "http" {
+				port = "80"
+			}
+		}
+		addr := net.JoinHostPort(server.Hostname(), port)
+
+		var dialer interface {
+			DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+		} = &net.Dialer{}
+		if server.Scheme != "http" {
+			dialer = &tls.Dialer{
+				// only the reachability of the server is probed, not its identity
+				Config: &tls.Config{InsecureSkipVerify: true, ServerName: server.Hostname()}, //nolint:gosec
+			}
+		}
+
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
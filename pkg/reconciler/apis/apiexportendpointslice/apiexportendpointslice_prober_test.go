@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestEndpointProber(t *testing.T) {
+	now := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	p := newEndpointProber(time.Minute, time.Second)
+	p.now = func() time.Time { return now }
+	var probed []string
+	p.probe = func(ctx context.Context, server *url.URL) error {
+		probed = append(probed, server.Host)
+		if server.Host == "server-2.kcp.dev" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	p.probeAll(context.Background(), []string{
+		"https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export",
+		"https://server-1.kcp.dev/services/apiexport/root:org:ws/other-export",
+		"https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export",
+	})
+	require.ElementsMatch(t, []string{"server-1.kcp.dev", "server-2.kcp.dev"}, probed, "servers must be probed once")
+
+	r, found := p.result("https://server-1.kcp.dev/services/apiexport/root:org:ws/third-export")
+	require.True(t, found)
+	require.Equal(t, apisv1alpha1.APIExportEndpointStateReady, r.state)
+	require.Equal(t, now, r.time.Time)
+
+	r, found = p.result("https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export")
+	require.True(t, found)
+	require.Equal(t, apisv1alpha1.APIExportEndpointStateUnready, r.state)
+
+	later := now.Add(time.Minute)
+	p.now = func() time.Time { return later }
+	p.probeAll(context.Background(), []string{"https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export"})
+	_, found = p.result("https://server-2.kcp.dev/services/apiexport/root:org:ws/my-export")
+	require.False(t, found, "results of servers not published anymore must be forgotten")
+
+	r, found = p.result("https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export")
+	require.True(t, found)
+	require.Equal(t, now, r.time.Time, "probes observing the same state must not change the result")
+	require.True(t, r.recordedIn(apisv1alpha1.APIExportEndpoint{State: apisv1alpha1.APIExportEndpointStateReady, LastProbeTime: &metav1.Time{Time: now}}))
+
+	p.probe = func(ctx context.Context, server *url.URL) error { return errors.New("connection refused") }
+	p.probeAll(context.Background(), []string{"https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export"})
+	r, found = p.result("https://server-1.kcp.dev/services/apiexport/root:org:ws/my-export")
+	require.True(t, found)
+	require.Equal(t, apisv1alpha1.APIExportEndpointStateUnready, r.state)
+	require.Equal(t, later, r.time.Time)
+}
+
+func TestDialProbe(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/services/apiexport/root:org:ws/my-export")
+	require.NoError(t, err)
+	require.NoError(t, dialProbe(time.Second)(context.Background(), u))
+
+	// a port nobody listens on anymore
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	u, err = url.Parse("https://" + addr + "/services/apiexport/root:org:ws/my-export")
+	require.NoError(t, err)
+	require.Error(t, dialProbe(time.Second)(context.Background(), u))
+}
@@ -19,46 +19,66 @@ package apiexportendpointslice
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"path"
+	"sort"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	virtualworkspacesoptions "github.com/kcp-dev/kcp/cmd/virtual-workspaces/options"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/network"
 	apiexportbuilder "github.com/kcp-dev/kcp/pkg/virtual/apiexport/builder"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
 )
 
 type endpointsReconciler struct {
-	listShards   func() ([]*corev1alpha1.Shard, error)
-	getAPIExport func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	listShards                     func() ([]*corev1alpha1.Shard, error)
+	getPartition                   func(clusterName logicalcluster.Name, name string) (*topologyv1alpha1.Partition, error)
+	getAPIExport                   func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	listAPIExportConsumerSummaries func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error)
+	probeResult                    func(endpointURL string) (probeResult, bool)
+	dnsName                        func(shard *corev1alpha1.Shard) (string, error)
+	deleteAPIExportEndpointSlice   func(ctx context.Context, slice *apisv1alpha1.APIExportEndpointSlice) error
+	requeueAfter                   func(slice *apisv1alpha1.APIExportEndpointSlice, duration time.Duration)
+	now                            func() time.Time
 }
 
+// defaultOrphanGracePeriod is the orphan grace period of slices not specifying one.
+const defaultOrphanGracePeriod = time.Hour
+
 func (c *controller) reconcile(ctx context.Context, apiExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice) error {
 	r := &endpointsReconciler{
-		listShards:   c.listShards,
-		getAPIExport: c.getAPIExport,
+		listShards:                     c.listShards,
+		getPartition:                   c.getPartition,
+		getAPIExport:                   c.getAPIExport,
+		listAPIExportConsumerSummaries: c.listAPIExportConsumerSummaries,
+		dnsName:                        c.dnsName,
+		deleteAPIExportEndpointSlice:   c.deleteAPIExportEndpointSlice,
+		requeueAfter:                   c.requeueAfter,
+		now:                            time.Now,
+	}
+	if c.prober != nil {
+		r.probeResult = c.prober.result
 	}
 
 	return r.reconcile(ctx, apiExportEndpointSlice)
 }
 
 func (r *endpointsReconciler) reconcile(ctx context.Context, apiExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice) error {
-	// TODO (fgiloux): When the information is available in the cache server
-	// check if at least one APIBinding is bound in the shard to the APIExport referenced by the APIExportEndpointSLice.
-	// If so, add the respective endpoint to the status.
-	// For now the unfiltered list is added.
-
 	// Get APIExport
-	apiExportPath := logicalcluster.NewPath(apiExportEndpointSlice.Spec.APIExport.Path)
+	apiExportPath := apiExportEndpointSlice.Spec.APIExport.Path.Path()
 	if apiExportPath.Empty() {
 		apiExportPath = logicalcluster.From(apiExportEndpointSlice).Path()
 	}
@@ -76,7 +96,7 @@ func (r *endpointsReconciler) reconcile(ctx context.Context, apiExportEndpointSl
 				apiExportPath,
 				apiExportEndpointSlice.Spec.APIExport.Name,
 			)
-			return nil
+			return r.reconcileOrphaned(ctx, apiExportEndpointSlice, apiExportPath)
 		} else {
 			conditions.MarkFalse(
 				apiExportEndpointSlice,
@@ -91,8 +111,62 @@ func (r *endpointsReconciler) reconcile(ctx context.Context, apiExportEndpointSl
 		}
 	}
 	conditions.MarkTrue(apiExportEndpointSlice, apisv1alpha1.APIExportValid)
+	conditions.Delete(apiExportEndpointSlice, apisv1alpha1.APIExportEndpointSliceOrphaned)
 
-	if err = r.updateEndpoints(ctx, apiExportEndpointSlice, apiExport); err != nil {
+	// Get the shard selector of the Partition, if any
+	selector := labels.Everything()
+	if partitionName := apiExportEndpointSlice.Spec.Partition; partitionName == "" {
+		conditions.Delete(apiExportEndpointSlice, apisv1alpha1.PartitionValid)
+	} else {
+		clusterName := logicalcluster.From(apiExportEndpointSlice)
+		partition, err := r.getPartition(clusterName, partitionName)
+		if errors.IsNotFound(err) {
+			// Don't keep endpoints of shards possibly outside of the partition
+			apiExportEndpointSlice.Status.APIExportEndpoints = nil
+			conditions.MarkFalse(
+				apiExportEndpointSlice,
+				apisv1alpha1.PartitionValid,
+				apisv1alpha1.PartitionInvalidReferenceReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Partition %s|%s not found",
+				clusterName,
+				partitionName,
+			)
+			return nil
+		} else if err != nil {
+			conditions.MarkFalse(
+				apiExportEndpointSlice,
+				apisv1alpha1.PartitionValid,
+				apisv1alpha1.InternalErrorReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Error getting Partition %s|%s",
+				clusterName,
+				partitionName,
+			)
+			return err
+		}
+
+		if partition.Spec.Selector != nil {
+			selector, err = metav1.LabelSelectorAsSelector(partition.Spec.Selector)
+			if err != nil {
+				apiExportEndpointSlice.Status.APIExportEndpoints = nil
+				conditions.MarkFalse(
+					apiExportEndpointSlice,
+					apisv1alpha1.PartitionValid,
+					apisv1alpha1.PartitionInvalidReferenceReason,
+					conditionsv1alpha1.ConditionSeverityError,
+					"Partition %s|%s has an invalid selector: %v",
+					clusterName,
+					partitionName,
+					err,
+				)
+				return nil
+			}
+		}
+		conditions.MarkTrue(apiExportEndpointSlice, apisv1alpha1.PartitionValid)
+	}
+
+	if err = r.updateEndpoints(ctx, apiExportEndpointSlice, apiExport, selector); err != nil {
 		conditions.MarkFalse(
 			apiExportEndpointSlice,
 			apisv1alpha1.APIExportEndpointSliceURLsReady,
@@ -109,23 +183,50 @@ func (r *endpointsReconciler) reconcile(ctx context.Context, apiExportEndpointSl
 
 func (r *endpointsReconciler) updateEndpoints(ctx context.Context,
 	apiExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice,
-	apiExport *apisv1alpha1.APIExport) error {
+	apiExport *apisv1alpha1.APIExport,
+	selector labels.Selector) error {
 	logger := klog.FromContext(ctx)
 	shards, err := r.listShards()
 	if err != nil {
 		return fmt.Errorf("error listing Shards: %w", err)
 	}
 
-	desiredURLs := sets.NewString()
+	// only shards with at least one APIBinding to the APIExport get an endpoint. Every shard
+	// publishes the number of its APIBindings in an APIExportConsumerSummary.
+	summaries, err := r.listAPIExportConsumerSummaries(logicalcluster.From(apiExport))
+	if err != nil {
+		return fmt.Errorf("error listing APIExportConsumerSummaries: %w", err)
+	}
+	shardsWithBindings := sets.NewString()
+	for _, summary := range summaries {
+		if boundAPIExports(summary).Has(apiExport.Name) {
+			shardsWithBindings.Insert(summary.Spec.Shard)
+		}
+	}
+
+	existing := make(map[string]apisv1alpha1.APIExportEndpoint, len(apiExportEndpointSlice.Status.APIExportEndpoints))
+	for _, ep := range apiExportEndpointSlice.Status.APIExportEndpoints {
+		if ep.ID != "" {
+			existing[ep.ID] = ep
+		}
+	}
+
+	var endpoints []apisv1alpha1.APIExportEndpoint
 	for _, shard := range shards {
 		logger = logging.WithObject(logger, shard)
-		if shard.Spec.VirtualWorkspaceURL == "" {
+		if shard.Spec.VirtualWorkspaceURL == "" || !shardsWithBindings.Has(shard.Name) || !selector.Matches(labels.Set(shard.Labels)) {
+			continue
+		}
+		if conditions.IsFalse(shard, corev1alpha1.ShardLive) {
+			// clients would be sent to a shard that stopped renewing its lease, i.e. is likely down
+			logger.V(4).Info("skipping shard with an expired lease")
 			continue
 		}
 
-		u, err := url.Parse(shard.Spec.VirtualWorkspaceURL)
+		u, err := network.ParseServerURL(shard.Spec.VirtualWorkspaceURL)
 		if err != nil {
-			// Should never happen
+			// Only shards created before their URLs were validated, e.g. with an IPv6 address
+			// without brackets, can get here.
 			logger.Error(
 				err, "error parsing shard.spec.virtualWorkspaceURL",
 				"VirtualWorkspaceURL", shard.Spec.VirtualWorkspaceURL,
@@ -142,15 +243,107 @@ func (r *endpointsReconciler) updateEndpoints(ctx context.Context,
 			apiExport.Name,
 		)
 
-		desiredURLs.Insert(u.String())
+		endpoint := apisv1alpha1.APIExportEndpoint{
+			URL:    u.String(),
+			ID:     string(shard.UID),
+			Shard:  shard.Name,
+			Region: shard.Labels[corev1alpha1.ShardRegionLabelKey],
+		}
+		if r.dnsName != nil {
+			if name, err := r.dnsName(shard); err != nil {
+				// publish the endpoint without a DNS name rather than not at all
+				logger.Error(err, "error rendering the DNS name of the endpoint")
+			} else {
+				endpoint.DNSName = name
+			}
+		}
+		// keep the time the endpoint was added, also when the URL of the shard changes
+		if old, found := existing[endpoint.ID]; found && old.ServingSince != nil {
+			endpoint.ServingSince = old.ServingSince
+		} else {
+			now := metav1.NewTime(r.now())
+			endpoint.ServingSince = &now
+		}
+		// endpoints are probed after being published, i.e. new endpoints have no state yet
+		if r.probeResult != nil {
+			if result, found := r.probeResult(endpoint.URL); found {
+				endpoint.State = result.state
+				endpoint.LastProbeTime = result.time.DeepCopy()
+			}
+		}
+		endpoints = append(endpoints, endpoint)
 	}
 
-	apiExportEndpointSlice.Status.APIExportEndpoints = nil
-	for _, u := range desiredURLs.List() {
-		apiExportEndpointSlice.Status.APIExportEndpoints = append(apiExportEndpointSlice.Status.APIExportEndpoints, apisv1alpha1.APIExportEndpoint{
-			URL: u,
-		})
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Shard < endpoints[j].Shard
+	})
+
+	// shards behind the same virtual workspace URL are listed once, as clients only
+	// knowing about URLs would otherwise see duplicates.
+	urls := sets.NewString()
+	deduped := endpoints[:0]
+	for _, ep := range endpoints {
+		if urls.Has(ep.URL) {
+			continue
+		}
+		urls.Insert(ep.URL)
+		deduped = append(deduped, ep)
+	}
+	endpoints = deduped
+
+	// log the contributions of shards that changed, unchanged endpoints are kept as they are
+	oldShards, newShards := sets.NewString(), sets.NewString()
+	for _, ep := range apiExportEndpointSlice.Status.APIExportEndpoints {
+		oldShards.Insert(ep.Shard)
+	}
+	for _, ep := range endpoints {
+		newShards.Insert(ep.Shard)
+	}
+	if added := newShards.Difference(oldShards); added.Len() > 0 {
+		logger.V(2).Info("adding endpoints of shards", "shards", added.List())
+	}
+	if removed := oldShards.Difference(newShards); removed.Len() > 0 {
+		logger.V(2).Info("removing endpoints of shards", "shards", removed.List())
+	}
+	apiExportEndpointSlice.Status.APIExportEndpoints = endpoints
+
+	return nil
+}
+
+// reconcileOrphaned applies the orphan policy of a slice whose APIExport has been gone for longer than
+// the orphan grace period. Before that, the slice is requeued for when the grace period ends. The time
+// the APIExport is gone since is the last transition of the APIExportValid condition to APIExportNotFound.
+func (r *endpointsReconciler) reconcileOrphaned(ctx context.Context, apiExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice, apiExportPath logicalcluster.Path) error {
+	logger := klog.FromContext(ctx)
+
+	gracePeriod := defaultOrphanGracePeriod
+	if apiExportEndpointSlice.Spec.OrphanGracePeriod != nil {
+		gracePeriod = apiExportEndpointSlice.Spec.OrphanGracePeriod.Duration
+	}
+	var orphanedSince time.Time
+	if c := conditions.Get(apiExportEndpointSlice, apisv1alpha1.APIExportValid); c != nil {
+		orphanedSince = c.LastTransitionTime.Time
+	}
+	if remaining := orphanedSince.Add(gracePeriod).Sub(r.now()); remaining > 0 {
+		conditions.Delete(apiExportEndpointSlice, apisv1alpha1.APIExportEndpointSliceOrphaned)
+		r.requeueAfter(apiExportEndpointSlice, remaining)
+		return nil
+	}
+
+	if apiExportEndpointSlice.Spec.OrphanPolicy == apisv1alpha1.APIExportEndpointSliceOrphanPolicyDelete {
+		logger.V(2).Info("deleting orphaned APIExportEndpointSlice", "orphanedSince", orphanedSince)
+		if err := r.deleteAPIExportEndpointSlice(ctx, apiExportEndpointSlice); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
 	}
 
+	conditions.Set(apiExportEndpointSlice, &conditionsv1alpha1.Condition{
+		Type:     apisv1alpha1.APIExportEndpointSliceOrphaned,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityWarning,
+		Reason:   apisv1alpha1.APIExportDeletedReason,
+		Message:  fmt.Sprintf("APIExport %s|%s has been gone since %s", apiExportPath, apiExportEndpointSlice.Spec.APIExport.Name, orphanedSince.UTC().Format(time.RFC3339)),
+	})
 	return nil
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportendpointslice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func DefaultOptions() *Options {
+	return &Options{
+		EndpointProbeTimeout:    5 * time.Second,
+		EndpointDNSNameTemplate: DefaultEndpointDNSNameTemplate,
+	}
+}
+
+func BindOptions(o *Options, fs *pflag.FlagSet) *Options {
+	fs.DurationVar(&o.EndpointProbeInterval, "apiexport-endpoint-probe-interval", o.EndpointProbeInterval, "Interval to probe the virtual workspace URLs published in APIExportEndpointSlices, recording the state of each endpoint. 0 disables probing")
+	fs.DurationVar(&o.EndpointProbeTimeout, "apiexport-endpoint-probe-timeout", o.EndpointProbeTimeout, "Timeout of a single probe of a virtual workspace URL published in APIExportEndpointSlices")
+	fs.StringVar(&o.EndpointDNSBaseDomain, "apiexport-endpoint-dns-base-domain", o.EndpointDNSBaseDomain, "Base domain of the stable DNS names published for the endpoints of APIExportEndpointSlices. Empty disables DNS names")
+	fs.StringVar(&o.EndpointDNSNameTemplate, "apiexport-endpoint-dns-name-template", o.EndpointDNSNameTemplate, "Go template of the DNS names published for the endpoints of APIExportEndpointSlices, rendered with .Shard, .Region and .BaseDomain")
+	return o
+}
+
+type Options struct {
+	EndpointProbeInterval time.Duration
+	EndpointProbeTimeout  time.Duration
+
+	EndpointDNSBaseDomain   string
+	EndpointDNSNameTemplate string
+}
+
+func (o *Options) Validate() error {
+	if o.EndpointProbeInterval < 0 {
+		return fmt.Errorf("--apiexport-endpoint-probe-interval must be >=0 (%s)", o.EndpointProbeInterval)
+	}
+	if o.EndpointProbeTimeout <= 0 {
+		return fmt.Errorf("--apiexport-endpoint-probe-timeout must be >0 (%s)", o.EndpointProbeTimeout)
+	}
+	if o.EndpointDNSBaseDomain != "" {
+		if _, err := newDNSNamer(o.EndpointDNSBaseDomain, o.EndpointDNSNameTemplate); err != nil {
+			return fmt.Errorf("--apiexport-endpoint-dns-base-domain or --apiexport-endpoint-dns-name-template is invalid: %w", err)
+		}
+	}
+	return nil
+}
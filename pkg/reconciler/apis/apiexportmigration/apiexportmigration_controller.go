@@ -0,0 +1,370 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportmigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-apiexportmigration"
+)
+
+// NewController returns a new controller moving the APIBindings of APIExports with spec.migration
+// to the target APIExport. It runs on every shard and repoints the APIBindings of the shard, while
+// the shard of the APIExport aggregates the progress from the APIExportConsumerSummaries of all
+// shards and retires the APIExport.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	deepSARClient kcpkubernetesclientset.ClusterInterface,
+	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	globalAPIExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	globalAPIExportConsumerSummaryInformer apisv1alpha1informers.APIExportConsumerSummaryClusterInformer,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return apiExportInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getGlobalAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return globalAPIExportInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getTargetAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+			// the target is usually in another workspace, possibly on another shard
+			export, err := indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
+			if apierrors.IsNotFound(err) {
+				return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), globalAPIExportInformer.Informer().GetIndexer(), path, name)
+			}
+			return export, err
+		},
+		getAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+			// APIBindings reference the APIExport either by its canonical path or by its cluster name
+			values := sets.NewString(logicalcluster.From(export).Path().Join(export.Name).String())
+			if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+				values.Insert(path.Join(export.Name).String())
+			}
+
+			var bindings []*apisv1alpha1.APIBinding
+			for _, value := range values.List() {
+				objs, err := indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), kcpindexers.APIBindingsByAPIExport, value)
+				if err != nil {
+					return nil, err
+				}
+				bindings = append(bindings, objs...)
+			}
+			return bindings, nil
+		},
+		listAPIExportConsumerSummaries: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+			return globalAPIExportConsumerSummaryInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		authorizeBind: func(ctx context.Context, binding *apisv1alpha1.APIBinding, target *apisv1alpha1.APIExport) (bool, error) {
+			logger := klog.FromContext(ctx)
+
+			// act on behalf of the owner of the consumer workspace, who was allowed to bind the old APIExport
+			logicalCluster, err := logicalClusterInformer.Lister().Cluster(logicalcluster.From(binding)).Get(corev1alpha1.LogicalClusterName)
+			if err != nil {
+				return false, err
+			}
+			raw, ok := logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey]
+			if !ok {
+				logger.V(2).Info("workspace has no owner recorded", "apibinding", logicalcluster.From(binding).Path().Join(binding.Name))
+				return false, nil
+			}
+			var info authenticationv1.UserInfo
+			if err := json.Unmarshal([]byte(raw), &info); err != nil {
+				logger.Error(err, "failed to unmarshal owner annotation on LogicalCluster", "key", tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey, "value", raw)
+				return false, nil
+			}
+			extra := map[string][]string{}
+			for k, v := range info.Extra {
+				extra[k] = v
+			}
+
+			authz, err := delegated.NewDelegatedAuthorizer(logicalcluster.From(target), deepSARClient)
+			if err != nil {
+				return false, err
+			}
+			decision, _, err := authz.Authorize(ctx, authorizer.AttributesRecord{
+				User:            &user.DefaultInfo{Name: info.Username, UID: info.UID, Groups: info.Groups, Extra: extra},
+				Verb:            "bind",
+				APIGroup:        apisv1alpha1.SchemeGroupVersion.Group,
+				APIVersion:      apisv1alpha1.SchemeGroupVersion.Version,
+				Resource:        "apiexports",
+				Name:            target.Name,
+				ResourceRequest: true,
+			})
+			if err != nil {
+				return false, err
+			}
+			return decision == authorizer.DecisionAllow, nil
+		},
+		repointAPIBinding: func(ctx context.Context, binding *apisv1alpha1.APIBinding, target *apisv1alpha1.ExportBindingReference, from string) error {
+			patch, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{apisv1alpha1.AnnotationMigratedFromKey: from},
+				},
+				"spec": map[string]interface{}{
+					"reference": map[string]interface{}{"export": target},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			_, err = kcpClusterClient.Cluster(logicalcluster.From(binding).Path()).ApisV1alpha1().APIBindings().Patch(ctx, binding.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+			return err
+		},
+
+		now: time.Now,
+
+		commit: committer.NewCommitter[*APIExport, Patcher, *APIExportSpec, *APIExportStatus](kcpClusterClient.ApisV1alpha1().APIExports()),
+	}
+	c.requeueAfter = func(export *apisv1alpha1.APIExport, after time.Duration) {
+		c.enqueueAPIExportAfter(export, after)
+	}
+
+	for _, informer := range []cache.SharedIndexInformer{apiExportInformer.Informer(), globalAPIExportInformer.Informer()} {
+		indexers.AddIfNotPresentOrDie(informer.GetIndexer(), cache.Indexers{
+			indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+		})
+	}
+	indexers.AddIfNotPresentOrDie(apiBindingInformer.Informer().GetIndexer(), cache.Indexers{
+		kcpindexers.APIBindingsByAPIExport: kcpindexers.IndexAPIBindingByAPIExport,
+	})
+
+	apiExportInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			export, ok := obj.(*apisv1alpha1.APIExport)
+			return ok && (export.Spec.Migration != nil || export.Status.Migration != nil || conditions.Get(export, apisv1alpha1.APIExportRetired) != nil)
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIExportAfter(obj, 0) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExportAfter(obj, 0) },
+		},
+	})
+
+	// APIExports of other shards, whose APIBindings on this shard have to be repointed
+	globalAPIExportInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			export, ok := obj.(*apisv1alpha1.APIExport)
+			return ok && export.Spec.Migration != nil
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIExportAfter(obj, 0) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExportAfter(obj, 0) },
+		},
+	})
+
+	globalAPIExportConsumerSummaryInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueAPIExportConsumerSummary(obj) },
+	})
+
+	return c, nil
+}
+
+type APIExport = apisv1alpha1.APIExport
+type APIExportSpec = apisv1alpha1.APIExportSpec
+type APIExportStatus = apisv1alpha1.APIExportStatus
+type Patcher = apisv1alpha1client.APIExportInterface
+type Resource = committer.Resource[*APIExportSpec, *APIExportStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller repoints the APIBindings of APIExports under migration to the target APIExport.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getAPIExport                   func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	getGlobalAPIExport             func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	getTargetAPIExport             func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+	getAPIBindingsByAPIExport      func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error)
+	listAPIExportConsumerSummaries func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error)
+	authorizeBind                  func(ctx context.Context, binding *apisv1alpha1.APIBinding, target *apisv1alpha1.APIExport) (bool, error)
+	repointAPIBinding              func(ctx context.Context, binding *apisv1alpha1.APIBinding, target *apisv1alpha1.ExportBindingReference, from string) error
+	requeueAfter                   func(export *apisv1alpha1.APIExport, after time.Duration)
+	now                            func() time.Time
+
+	commit CommitFunc
+}
+
+// enqueueAPIExportAfter enqueues an APIExport after the given duration.
+func (c *controller) enqueueAPIExportAfter(obj interface{}, after time.Duration) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing APIExport", "after", after)
+	c.queue.AddAfter(key, after)
+}
+
+// enqueueAPIExportConsumerSummary enqueues the APIExports under migration listed in a summary
+// of some shard. APIExports on other shards are not found and hence skipped.
+func (c *controller) enqueueAPIExportConsumerSummary(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	summary, ok := obj.(*apisv1alpha1.APIExportConsumerSummary)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be an APIExportConsumerSummary, but is %T", obj))
+		return
+	}
+
+	clusterName := logicalcluster.From(summary)
+	for _, consumers := range summary.Spec.APIExports {
+		export, err := c.getAPIExport(clusterName, consumers.Name)
+		if err != nil || export.Spec.Migration == nil {
+			continue
+		}
+		c.enqueueAPIExportAfter(export, 0)
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	// APIExports of other shards only have their APIBindings on this shard repointed
+	home := true
+	obj, err := c.getAPIExport(clusterName, name)
+	if apierrors.IsNotFound(err) {
+		home = false
+		obj, err = c.getGlobalAPIExport(clusterName, name)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj, home); err != nil {
+		errs = append(errs, err)
+	}
+	if !home {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportmigration
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+const (
+	// defaultBatchSize is the number of APIBindings repointed at a time if spec.migration.batchSize is unset.
+	defaultBatchSize = 10
+
+	// batchInterval is the time between two batches of repointed APIBindings, giving the
+	// APIBinding controller time to switch over the bound resources.
+	batchInterval = 10 * time.Second
+
+	// targetRetryInterval is the time after which a missing or invalid target APIExport, or
+	// a missing permission to bind it, is checked again.
+	targetRetryInterval = time.Minute
+
+	// defaultRetireAfter is the dual-publish window if spec.migration.retireAfter is unset.
+	defaultRetireAfter = 24 * time.Hour
+)
+
+// reconcile repoints the APIBindings of this shard to the target. On the shard of the APIExport
+// (home), it also aggregates the progress of all shards and retires the APIExport.
+func (c *controller) reconcile(ctx context.Context, export *apisv1alpha1.APIExport, home bool) error {
+	logger := klog.FromContext(ctx)
+
+	if export.Spec.Migration == nil {
+		if home {
+			export.Status.Migration = nil
+			conditions.Delete(export, apisv1alpha1.APIExportMigrated)
+			conditions.Delete(export, apisv1alpha1.APIExportRetired)
+		}
+		return nil
+	}
+	if !export.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	targetPath := export.Spec.Migration.Target.Path.Path()
+	if targetPath.Empty() {
+		targetPath = logicalcluster.From(export).Path()
+	}
+	target, err := c.getTargetAPIExport(targetPath, export.Spec.Migration.Target.Name)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(export, apisv1alpha1.APIExportMigrated, apisv1alpha1.MigrationTargetInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Target APIExport %s not found", targetPath.Join(export.Spec.Migration.Target.Name))
+		c.requeueAfter(export, targetRetryInterval)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if logicalcluster.From(target) == logicalcluster.From(export) && target.Name == export.Name {
+		conditions.MarkFalse(export, apisv1alpha1.APIExportMigrated, apisv1alpha1.MigrationTargetInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Target APIExport must not be the APIExport itself")
+		return nil
+	}
+	if export.Status.IdentityHash == "" || target.Status.IdentityHash != export.Status.IdentityHash {
+		conditions.MarkFalse(export, apisv1alpha1.APIExportMigrated, apisv1alpha1.MigrationTargetInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Target APIExport %s must have the same identity", targetPath.Join(target.Name))
+		c.requeueAfter(export, targetRetryInterval)
+		return nil
+	}
+
+	if err := c.repointAPIBindings(ctx, export, target); err != nil {
+		return err
+	}
+	if !home {
+		return nil
+	}
+
+	// the APIBindings of all shards are counted by the APIExportConsumerSummaries
+	summaries, err := c.listAPIExportConsumerSummaries(logicalcluster.From(export))
+	if err != nil {
+		return err
+	}
+	var remaining, migrated int32
+	for _, summary := range summaries {
+		for _, consumers := range summary.Spec.APIExports {
+			if consumers.Name == export.Name {
+				remaining += consumers.APIBindings
+				migrated += consumers.MigratedAPIBindings
+			}
+		}
+	}
+	if export.Status.Migration == nil {
+		export.Status.Migration = &apisv1alpha1.APIExportMigrationStatus{}
+	}
+	export.Status.Migration.RemainingAPIBindings = remaining
+	export.Status.Migration.MigratedAPIBindings = migrated
+
+	if remaining > 0 {
+		export.Status.Migration.CompletionTime = nil
+		conditions.MarkFalse(export, apisv1alpha1.APIExportMigrated, apisv1alpha1.MigrationInProgressReason, conditionsv1alpha1.ConditionSeverityInfo,
+			"%d APIBindings remaining", remaining)
+		conditions.MarkFalse(export, apisv1alpha1.APIExportRetired, apisv1alpha1.DualPublishingReason, conditionsv1alpha1.ConditionSeverityInfo,
+			"Serving until all APIBindings are migrated")
+		return nil
+	}
+
+	now := c.now()
+	if export.Status.Migration.CompletionTime == nil {
+		export.Status.Migration.CompletionTime = &metav1.Time{Time: now}
+	}
+	conditions.MarkTrue(export, apisv1alpha1.APIExportMigrated)
+
+	retireAfter := defaultRetireAfter
+	if export.Spec.Migration.RetireAfter != nil {
+		retireAfter = export.Spec.Migration.RetireAfter.Duration
+	}
+	if retireAt := export.Status.Migration.CompletionTime.Add(retireAfter); now.Before(retireAt) {
+		conditions.MarkFalse(export, apisv1alpha1.APIExportRetired, apisv1alpha1.DualPublishingReason, conditionsv1alpha1.ConditionSeverityInfo,
+			"Serving until %s", retireAt.UTC().Format(time.RFC3339))
+		c.requeueAfter(export, retireAt.Sub(now))
+		return nil
+	}
+
+	if !conditions.IsTrue(export, apisv1alpha1.APIExportRetired) {
+		logger.V(2).Info("retiring APIExport")
+	}
+	conditions.MarkTrue(export, apisv1alpha1.APIExportRetired)
+	return nil
+}
+
+// repointAPIBindings repoints a batch of the APIBindings of this shard to the target, if the owner
+// of their workspace is allowed to bind the target.
+func (c *controller) repointAPIBindings(ctx context.Context, export, target *apisv1alpha1.APIExport) error {
+	logger := klog.FromContext(ctx)
+
+	// point the APIBindings to the canonical path of the target if known
+	ref := &apisv1alpha1.ExportBindingReference{
+		Path: apisv1alpha1.LogicalClusterPath(logicalcluster.From(target).Path().String()),
+		Name: target.Name,
+	}
+	if path := target.Annotations[core.LogicalClusterPathAnnotationKey]; path != "" {
+		ref.Path = apisv1alpha1.LogicalClusterPath(path)
+	}
+	from := logicalcluster.From(export).Path().Join(export.Name).String()
+
+	bindings, err := c.getAPIBindingsByAPIExport(export)
+	if err != nil {
+		return err
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if ci, cj := logicalcluster.From(bindings[i]), logicalcluster.From(bindings[j]); ci != cj {
+			return ci < cj
+		}
+		return bindings[i].Name < bindings[j].Name
+	})
+
+	batchSize := int(export.Spec.Migration.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	var repointed, unauthorized int
+	for _, binding := range bindings {
+		if repointed == batchSize {
+			// more APIBindings left for the next batch
+			c.requeueAfter(export, batchInterval)
+			return nil
+		}
+
+		allowed, err := c.authorizeBind(ctx, binding, target)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			logger.V(2).Info("not repointing APIBinding, the workspace owner is not allowed to bind the target APIExport", "apibinding", logicalcluster.From(binding).Path().Join(binding.Name), "target", ref.Path.Path().Join(ref.Name))
+			unauthorized++
+			continue
+		}
+
+		logger.V(2).Info("repointing APIBinding to target APIExport", "apibinding", logicalcluster.From(binding).Path().Join(binding.Name), "target", ref.Path.Path().Join(ref.Name))
+		if err := c.repointAPIBinding(ctx, binding, ref, from); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		repointed++
+	}
+
+	if unauthorized > 0 {
+		// permissions might be granted later
+		c.requeueAfter(export, targetRetryInterval)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	newExport := func(migration *apisv1alpha1.APIExportMigration) *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "widgets",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "old-provider"},
+			},
+			Spec:   apisv1alpha1.APIExportSpec{Migration: migration},
+			Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash1"},
+		}
+	}
+	target := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "widgets",
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey:         "new-provider",
+				core.LogicalClusterPathAnnotationKey: "root:org:new-provider",
+			},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "hash1"},
+	}
+	migration := &apisv1alpha1.APIExportMigration{
+		Target:    apisv1alpha1.ExportBindingReference{Path: "root:org:new-provider", Name: "widgets"},
+		BatchSize: 2,
+	}
+
+	bindings := func(n int) []*apisv1alpha1.APIBinding {
+		var ret []*apisv1alpha1.APIBinding
+		for i := 0; i < n; i++ {
+			ret = append(ret, &apisv1alpha1.APIBinding{ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("binding-%d", i),
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "consumer"},
+			}})
+		}
+		return ret
+	}
+	summary := func(shard string, remaining, migrated int32) *apisv1alpha1.APIExportConsumerSummary {
+		return &apisv1alpha1.APIExportConsumerSummary{
+			ObjectMeta: metav1.ObjectMeta{Name: shard},
+			Spec: apisv1alpha1.APIExportConsumerSummarySpec{Shard: shard, APIExports: []apisv1alpha1.APIExportShardConsumers{
+				{Name: "widgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: remaining}, MigratedAPIBindings: migrated},
+				{Name: "gadgets", APIExportConsumers: apisv1alpha1.APIExportConsumers{APIBindings: 42}},
+			}},
+		}
+	}
+	now := time.Date(2023, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		export       *apisv1alpha1.APIExport
+		notHome      bool
+		target       *apisv1alpha1.APIExport
+		bindings     []*apisv1alpha1.APIBinding
+		unauthorized sets.String
+		summaries    []*apisv1alpha1.APIExportConsumerSummary
+		repointErr   error
+		wantErr      bool
+		wantStatus   *apisv1alpha1.APIExportMigrationStatus
+		wantReason   string
+		wantMigrate  bool
+		wantRetired  bool
+		wantRepoint  []string
+		wantRequeue  time.Duration
+	}{
+		"no migration": {
+			export: newExport(nil),
+		},
+		"target not found": {
+			export:      newExport(migration),
+			wantReason:  apisv1alpha1.MigrationTargetInvalidReason,
+			wantRequeue: targetRetryInterval,
+		},
+		"target with different identity": {
+			export: newExport(migration),
+			target: func() *apisv1alpha1.APIExport {
+				t := target.DeepCopy()
+				t.Status.IdentityHash = "hash2"
+				return t
+			}(),
+			wantReason:  apisv1alpha1.MigrationTargetInvalidReason,
+			wantRequeue: targetRetryInterval,
+		},
+		"first batch": {
+			export:      newExport(migration),
+			target:      target,
+			bindings:    bindings(3),
+			summaries:   []*apisv1alpha1.APIExportConsumerSummary{summary("alpha", 3, 0), summary("beta", 2, 0)},
+			wantStatus:  &apisv1alpha1.APIExportMigrationStatus{MigratedAPIBindings: 0, RemainingAPIBindings: 5},
+			wantReason:  apisv1alpha1.MigrationInProgressReason,
+			wantRepoint: []string{"binding-0", "binding-1"},
+			wantRequeue: batchInterval,
+		},
+		"other shard only repoints": {
+			export:      newExport(migration),
+			notHome:     true,
+			target:      target,
+			bindings:    bindings(1),
+			wantRepoint: []string{"binding-0"},
+		},
+		"workspace owner not allowed to bind the target": {
+			export:       newExport(migration),
+			target:       target,
+			bindings:     bindings(2),
+			unauthorized: sets.NewString("binding-0"),
+			summaries:    []*apisv1alpha1.APIExportConsumerSummary{summary("alpha", 1, 1)},
+			wantStatus:   &apisv1alpha1.APIExportMigrationStatus{MigratedAPIBindings: 1, RemainingAPIBindings: 1},
+			wantReason:   apisv1alpha1.MigrationInProgressReason,
+			wantRepoint:  []string{"binding-1"},
+			wantRequeue:  targetRetryInterval,
+		},
+		"all shards migrated": {
+			export:      newExport(migration),
+			target:      target,
+			summaries:   []*apisv1alpha1.APIExportConsumerSummary{summary("alpha", 0, 2), summary("beta", 0, 3)},
+			wantStatus:  &apisv1alpha1.APIExportMigrationStatus{MigratedAPIBindings: 5, RemainingAPIBindings: 0, CompletionTime: &metav1.Time{Time: now}},
+			wantMigrate: true,
+			wantRequeue: defaultRetireAfter,
+		},
+		"retired after the dual-publish window": {
+			export: func() *apisv1alpha1.APIExport {
+				e := newExport(migration)
+				e.Status.Migration = &apisv1alpha1.APIExportMigrationStatus{MigratedAPIBindings: 5, CompletionTime: &metav1.Time{Time: now.Add(-defaultRetireAfter)}}
+				return e
+			}(),
+			target:      target,
+			summaries:   []*apisv1alpha1.APIExportConsumerSummary{summary("alpha", 0, 2), summary("beta", 0, 3)},
+			wantStatus:  &apisv1alpha1.APIExportMigrationStatus{MigratedAPIBindings: 5, RemainingAPIBindings: 0, CompletionTime: &metav1.Time{Time: now.Add(-defaultRetireAfter)}},
+			wantMigrate: true,
+			wantRetired: true,
+		},
+		"repoint fails": {
+			export:      newExport(migration),
+			target:      target,
+			bindings:    bindings(1),
+			repointErr:  errors.New("boom"),
+			wantErr:     true,
+			wantRepoint: []string{"binding-0"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var repointed []string
+			var requeue time.Duration
+			c := &controller{
+				getTargetAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+					if tc.target == nil {
+						return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+					}
+					return tc.target, nil
+				},
+				getAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return tc.bindings, nil
+				},
+				listAPIExportConsumerSummaries: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIExportConsumerSummary, error) {
+					require.False(t, tc.notHome, "summaries are only read on the shard of the APIExport")
+					return tc.summaries, nil
+				},
+				authorizeBind: func(ctx context.Context, binding *apisv1alpha1.APIBinding, target *apisv1alpha1.APIExport) (bool, error) {
+					return !tc.unauthorized.Has(binding.Name), nil
+				},
+				repointAPIBinding: func(ctx context.Context, binding *apisv1alpha1.APIBinding, ref *apisv1alpha1.ExportBindingReference, from string) error {
+					require.Equal(t, apisv1alpha1.ExportBindingReference{Path: "root:org:new-provider", Name: "widgets"}, *ref)
+					require.Equal(t, "old-provider:widgets", from)
+					repointed = append(repointed, binding.Name)
+					return tc.repointErr
+				},
+				requeueAfter: func(export *apisv1alpha1.APIExport, after time.Duration) {
+					requeue = after
+				},
+				now: func() time.Time { return now },
+			}
+
+			err := c.reconcile(context.Background(), tc.export, !tc.notHome)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.wantStatus, tc.export.Status.Migration)
+			require.Equal(t, tc.wantRepoint, repointed)
+			require.Equal(t, tc.wantRequeue, requeue)
+			switch {
+			case tc.wantMigrate:
+				require.True(t, conditions.IsTrue(tc.export, apisv1alpha1.APIExportMigrated))
+			case tc.wantReason != "":
+				require.True(t, conditions.IsFalse(tc.export, apisv1alpha1.APIExportMigrated))
+				require.Equal(t, tc.wantReason, conditions.GetReason(tc.export, apisv1alpha1.APIExportMigrated))
+			default:
+				require.False(t, conditions.IsTrue(tc.export, apisv1alpha1.APIExportMigrated))
+			}
+			require.Equal(t, tc.wantRetired, conditions.IsTrue(tc.export, apisv1alpha1.APIExportRetired))
+		})
+	}
+}
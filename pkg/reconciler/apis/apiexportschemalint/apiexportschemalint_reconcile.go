@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportschemalint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// maxReportedViolations is the number of violations listed in the condition message.
+const maxReportedViolations = 10
+
+func (c *controller) reconcile(ctx context.Context, export *apisv1alpha1.APIExport) error {
+	if !export.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	clusterName := logicalcluster.From(export)
+
+	var missing, violations []string
+	for _, name := range export.Spec.LatestResourceSchemas {
+		schema, err := c.getAPIResourceSchema(clusterName, name)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, name)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		for _, v := range lint(schema) {
+			violations = append(violations, fmt.Sprintf("%s: %s", name, v))
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		conditions.MarkFalse(
+			export,
+			apisv1alpha1.APIExportSchemasLinted,
+			apisv1alpha1.SchemaLintFailedReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"APIResourceSchemas not found: %s",
+			strings.Join(missing, ", "),
+		)
+	case len(violations) > 0:
+		conditions.MarkFalse(
+			export,
+			apisv1alpha1.APIExportSchemasLinted,
+			apisv1alpha1.SchemaLintViolationsReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"%s",
+			summarize(violations),
+		)
+	default:
+		conditions.MarkTrue(export, apisv1alpha1.APIExportSchemasLinted)
+	}
+
+	return nil
+}
+
+// summarize joins the first maxReportedViolations violations.
+func summarize(violations []string) string {
+	if len(violations) <= maxReportedViolations {
+		return strings.Join(violations, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(violations[:maxReportedViolations], "; "), len(violations)-maxReportedViolations)
+}
+
+// lint returns the best-practice violations of the served versions of an APIResourceSchema:
+// a missing status subresource, missing printer columns, floating point fields, and lists
+// without maxItems.
+func lint(schema *apisv1alpha1.APIResourceSchema) []string {
+	var violations []string
+	for i := range schema.Spec.Versions {
+		v := &schema.Spec.Versions[i]
+		if !v.Served {
+			continue
+		}
+
+		if v.Subresources.Status == nil {
+			violations = append(violations, fmt.Sprintf("version %s has no status subresource", v.Name))
+		}
+		if len(v.AdditionalPrinterColumns) == 0 {
+			violations = append(violations, fmt.Sprintf("version %s has no additional printer columns", v.Name))
+		}
+
+		props, err := v.GetSchema()
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("version %s has an invalid schema: %v", v.Name, err))
+			continue
+		}
+		for _, f := range lintSchema(props, "") {
+			violations = append(violations, fmt.Sprintf("version %s %s", v.Name, f))
+		}
+	}
+	return violations
+}
+
+func lintSchema(props *apiextensionsv1.JSONSchemaProps, path string) []string {
+	if props == nil {
+		return nil
+	}
+
+	var violations []string
+	switch props.Type {
+	case "number":
+		violations = append(violations, fmt.Sprintf("field %s is a floating point number", fieldPath(path)))
+	case "array":
+		if props.MaxItems == nil {
+			violations = append(violations, fmt.Sprintf("list %s has no maxItems", fieldPath(path)))
+		}
+		if props.Items != nil {
+			violations = append(violations, lintSchema(props.Items.Schema, path+"[*]")...)
+		}
+	}
+
+	names := make([]string, 0, len(props.Properties))
+	for name := range props.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := props.Properties[name]
+		violations = append(violations, lintSchema(&prop, path+"."+name)...)
+	}
+
+	if props.AdditionalProperties != nil {
+		violations = append(violations, lintSchema(props.AdditionalProperties.Schema, path+"[*]")...)
+	}
+
+	return violations
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportschemalint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func newSchema(t *testing.T, name string, props *apiextensionsv1.JSONSchemaProps, status bool, columns bool) *apisv1alpha1.APIResourceSchema {
+	t.Helper()
+
+	v := apisv1alpha1.APIResourceVersion{Name: "v1", Served: true, Storage: true}
+	if status {
+		v.Subresources.Status = &apiextensionsv1.CustomResourceSubresourceStatus{}
+	}
+	if columns {
+		v.AdditionalPrinterColumns = []apiextensionsv1.CustomResourceColumnDefinition{{Name: "Ready", Type: "string", JSONPath: ".status.ready"}}
+	}
+	require.NoError(t, v.SetSchema(props))
+
+	return &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apisv1alpha1.APIResourceSchemaSpec{Versions: []apisv1alpha1.APIResourceVersion{v}},
+	}
+}
+
+func maxItems(n int64) *int64 {
+	return &n
+}
+
+func TestReconcile(t *testing.T) {
+	good := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+					"hosts":    {Type: "array", MaxItems: maxItems(16), Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}}},
+				},
+			},
+		},
+	}
+	bad := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"ratio": {Type: "number"},
+					"rules": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:       "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{"weight": {Type: "number"}},
+					}}},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		schemas     []*apisv1alpha1.APIResourceSchema
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		"no violations": {
+			schemas:    []*apisv1alpha1.APIResourceSchema{newSchema(t, "v1.widgets.kcp.io", good, true, true)},
+			wantStatus: corev1.ConditionTrue,
+		},
+		"violations": {
+			schemas:     []*apisv1alpha1.APIResourceSchema{newSchema(t, "v1.widgets.kcp.io", bad, false, false)},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  apisv1alpha1.SchemaLintViolationsReason,
+			wantMessage: "v1.widgets.kcp.io: version v1 has no status subresource; v1.widgets.kcp.io: version v1 has no additional printer columns; v1.widgets.kcp.io: version v1 field .spec.ratio is a floating point number; v1.widgets.kcp.io: version v1 list .spec.rules has no maxItems; v1.widgets.kcp.io: version v1 field .spec.rules[*].weight is a floating point number",
+		},
+		"schema not found": {
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  apisv1alpha1.SchemaLintFailedReason,
+			wantMessage: "APIResourceSchemas not found: v1.widgets.kcp.io",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			schemas := map[string]*apisv1alpha1.APIResourceSchema{}
+			for _, s := range tc.schemas {
+				schemas[s.Name] = s
+			}
+			c := &controller{
+				getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+					if s, found := schemas[name]; found {
+						return s, nil
+					}
+					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name)
+				},
+			}
+
+			export := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "export"},
+				Spec:       apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{"v1.widgets.kcp.io"}},
+			}
+			require.NoError(t, c.reconcile(context.Background(), export))
+
+			cond := conditions.Get(export, apisv1alpha1.APIExportSchemasLinted)
+			require.NotNil(t, cond)
+			require.Equal(t, tc.wantStatus, cond.Status)
+			require.Equal(t, tc.wantReason, cond.Reason)
+			require.Equal(t, tc.wantMessage, cond.Message)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportusage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/informer"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-apiexportusage"
+)
+
+// NewController returns a new controller periodically publishing the objects and consumers of the
+// APIExports bound on this shard as metrics. Together with the consumer requests counted by the
+// handler chain, they are the load signals to autoscale provider controllers with.
+func NewController(
+	interval time.Duration,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	ddsif *informer.DiscoveringDynamicSharedInformerFactory,
+) (*controller, error) {
+	c := &controller{
+		interval:  interval,
+		published: map[usageKey]bool{},
+
+		listAPIBindings: func() ([]*apisv1alpha1.APIBinding, error) {
+			return apiBindingInformer.Lister().List(labels.Everything())
+		},
+		countObjects: func(clusterName logicalcluster.Name, group, resource string) (int, error) {
+			inf, err := getInformerForGroupResource(ddsif, group, resource)
+			if err != nil {
+				return 0, err
+			}
+			objs, err := inf.Lister().ByCluster(clusterName).List(labels.Everything())
+			if err != nil {
+				return 0, err
+			}
+			return len(objs), nil
+		},
+	}
+
+	return c, nil
+}
+
+// controller publishes the usage metrics of APIExports.
+type controller struct {
+	interval time.Duration
+
+	listAPIBindings func() ([]*apisv1alpha1.APIBinding, error)
+	countObjects    func(clusterName logicalcluster.Name, group, resource string) (int, error)
+
+	// published are the label values of the object and consumer gauges set in the last round,
+	// to delete the series of APIExports and resources that are not bound anymore.
+	published map[usageKey]bool
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context) {
+	defer runtime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	wait.UntilWithContext(ctx, c.publish, c.interval)
+}
+
+func (c *controller) publish(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	bindings, err := c.listAPIBindings()
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to list APIBindings: %w", ControllerName, err))
+		return
+	}
+
+	usage := c.collect(ctx, bindings)
+	for key, value := range usage {
+		if key.resource == "" {
+			consumers.WithLabelValues(key.exportCluster, key.export).Set(float64(value))
+		} else {
+			objects.WithLabelValues(key.exportCluster, key.export, key.resource).Set(float64(value))
+		}
+	}
+	for key := range c.published {
+		if _, found := usage[key]; found {
+			continue
+		}
+		if key.resource == "" {
+			consumers.Delete(map[string]string{"apiexport_cluster": key.exportCluster, "apiexport": key.export})
+		} else {
+			objects.Delete(map[string]string{"apiexport_cluster": key.exportCluster, "apiexport": key.export, "resource": key.resource})
+		}
+	}
+
+	c.published = make(map[usageKey]bool, len(usage))
+	for key := range usage {
+		c.published[key] = true
+	}
+	logger.V(4).Info("published APIExport usage", "series", len(usage))
+}
+
+// usageKey identifies a series of the object gauge, or of the consumer gauge if resource is empty.
+type usageKey struct {
+	exportCluster string
+	export        string
+	resource      string
+}
+
+// collect counts the consumers of the bound APIExports, and the objects of the bound resources.
+// Resources that cannot be counted are logged and skipped.
+func (c *controller) collect(ctx context.Context, bindings []*apisv1alpha1.APIBinding) map[usageKey]int {
+	logger := klog.FromContext(ctx)
+
+	usage := map[usageKey]int{}
+	for _, binding := range bindings {
+		export := binding.Status.BoundAPIExport
+		if export == nil {
+			continue
+		}
+		usage[usageKey{exportCluster: export.Cluster.String(), export: export.Name}]++
+
+		for _, br := range binding.Status.BoundResources {
+			resource := schema.GroupResource{Group: br.Group, Resource: br.Resource}
+			count, err := c.countObjects(logicalcluster.From(binding), br.Group, br.Resource)
+			if err != nil {
+				logger.V(4).Info("failed to count objects", "resource", resource, "err", err)
+				continue
+			}
+			usage[usageKey{exportCluster: export.Cluster.String(), export: export.Name, resource: resource.String()}] += count
+		}
+	}
+	return usage
+}
+
+func getInformerForGroupResource(ddsif *informer.DiscoveringDynamicSharedInformerFactory, group, resource string) (kcpkubernetesinformers.GenericClusterInformer, error) {
+	informers, _ := ddsif.Informers()
+
+	for gvr := range informers {
+		if gvr.Group == group && gvr.Resource == resource {
+			// once we find one, return.
+			return ddsif.ForResource(gvr)
+		}
+	}
+	return nil, fmt.Errorf("unable to find informer for %s.%s", resource, group)
+}
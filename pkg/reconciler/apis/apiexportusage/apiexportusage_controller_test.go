@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportusage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func newBinding(cluster, exportCluster, export string, resources ...string) *apisv1alpha1.APIBinding {
+	b := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        export,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		},
+	}
+	if export != "" {
+		b.Status.BoundAPIExport = &apisv1alpha1.BoundAPIExport{Cluster: apisv1alpha1.LogicalClusterName(exportCluster), Name: export}
+	}
+	for _, r := range resources {
+		b.Status.BoundResources = append(b.Status.BoundResources, apisv1alpha1.BoundAPIResource{Group: "example.io", Resource: r})
+	}
+	return b
+}
+
+func TestCollect(t *testing.T) {
+	c := &controller{
+		countObjects: func(clusterName logicalcluster.Name, group, resource string) (int, error) {
+			switch {
+			case resource == "gadgets":
+				return 0, errors.New("no informer")
+			case clusterName == "consumer-1":
+				return 3, nil
+			default:
+				return 5, nil
+			}
+		},
+	}
+
+	got := c.collect(context.Background(), []*apisv1alpha1.APIBinding{
+		newBinding("consumer-1", "provider", "widgets", "widgets", "gadgets"),
+		newBinding("consumer-2", "provider", "widgets", "widgets"),
+		newBinding("consumer-3", "provider", "sprockets"),
+		newBinding("consumer-4", "", ""),
+	})
+
+	require.Equal(t, map[usageKey]int{
+		{exportCluster: "provider", export: "widgets"}:                                 2,
+		{exportCluster: "provider", export: "widgets", resource: "widgets.example.io"}: 8,
+		{exportCluster: "provider", export: "sprockets"}:                               1,
+	}, got)
+}
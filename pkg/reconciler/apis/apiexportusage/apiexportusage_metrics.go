@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportusage
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+var (
+	consumerRequests = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "apiexport_consumer_requests_total",
+			Help:           "Number of requests of consumers to the resources bound from an APIExport, served by this shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"apiexport_cluster", "apiexport", "verb"},
+	)
+
+	objects = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "apiexport_objects",
+			Help:           "Number of objects of the resources bound from an APIExport, stored on this shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"apiexport_cluster", "apiexport", "resource"},
+	)
+
+	consumers = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "apiexport_consumers",
+			Help:           "Number of APIBindings bound to an APIExport on this shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"apiexport_cluster", "apiexport"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the APIExport usage metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(consumerRequests)
+		legacyregistry.MustRegister(objects)
+		legacyregistry.MustRegister(consumers)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+// RecordConsumerRequest counts a request of a consumer to a resource bound from the given APIExport.
+func RecordConsumerRequest(export *apisv1alpha1.BoundAPIExport, verb string) {
+	consumerRequests.WithLabelValues(export.Cluster.String(), export.Name, verb).Inc()
+}
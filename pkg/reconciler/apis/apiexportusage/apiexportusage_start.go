@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportusage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func DefaultOptions() *Options {
+	return &Options{
+		Interval: 30 * time.Second,
+	}
+}
+
+func BindOptions(o *Options, fs *pflag.FlagSet) *Options {
+	fs.BoolVar(&o.Enabled, "apiexport-usage-metrics", o.Enabled, "Publish per-APIExport load signals as metrics: consumer requests to bound resources, objects of bound resources and consumers on this shard. These can drive the autoscaling of provider controllers, e.g. through the Prometheus adapter or KEDA. Labels have the cardinality of the bound APIExports")
+	fs.DurationVar(&o.Interval, "apiexport-usage-metrics-interval", o.Interval, "Interval of counting the objects and consumers of APIExports for --apiexport-usage-metrics")
+	return o
+}
+
+type Options struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+func (o *Options) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("--apiexport-usage-metrics-interval must be >0 (%v)", o.Interval)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresourceschemacompat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-apiresourceschema-compat"
+)
+
+// NewController returns a new controller checking APIResourceSchemas for backward compatibility
+// with the predecessor referenced by the apis.kcp.io/predecessor annotation, and reporting the
+// result in the CompatibleWithPredecessor condition.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	apiResourceSchemaInformer apisv1alpha1informers.APIResourceSchemaClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: controllerSwitch.Queue(queue),
+
+		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			return apiResourceSchemaInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listAPIResourceSchemas: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIResourceSchema, error) {
+			return apiResourceSchemaInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+
+		commit: committer.NewCommitter[*APIResourceSchema, Patcher, *APIResourceSchemaSpec, *APIResourceSchemaStatus](kcpClusterClient.ApisV1alpha1().APIResourceSchemas()),
+	}
+
+	apiResourceSchemaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIResourceSchema(obj)
+			c.enqueueSuccessors(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// only the predecessor annotation can change, the spec is immutable
+			oldSchema, ok := oldObj.(*apisv1alpha1.APIResourceSchema)
+			if !ok {
+				return
+			}
+			newSchema, ok := newObj.(*apisv1alpha1.APIResourceSchema)
+			if !ok {
+				return
+			}
+			if oldSchema.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaPredecessorKey] != newSchema.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaPredecessorKey] {
+				c.enqueueAPIResourceSchema(newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueueSuccessors(obj) },
+	})
+
+	return c, nil
+}
+
+type APIResourceSchema = apisv1alpha1.APIResourceSchema
+type APIResourceSchemaSpec = apisv1alpha1.APIResourceSchemaSpec
+type APIResourceSchemaStatus = apisv1alpha1.APIResourceSchemaStatus
+type Patcher = apisv1alpha1client.APIResourceSchemaInterface
+type Resource = committer.Resource[*APIResourceSchemaSpec, *APIResourceSchemaStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller maintains the CompatibleWithPredecessor condition of APIResourceSchemas.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getAPIResourceSchema   func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
+	listAPIResourceSchemas func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIResourceSchema, error)
+
+	commit CommitFunc
+}
+
+// enqueueAPIResourceSchema enqueues an APIResourceSchema.
+func (c *controller) enqueueAPIResourceSchema(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing APIResourceSchema")
+	c.queue.Add(key)
+}
+
+// enqueueSuccessors enqueues the APIResourceSchemas referencing an APIResourceSchema as predecessor,
+// as their condition depends on whether it exists.
+func (c *controller) enqueueSuccessors(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	schema, ok := obj.(*apisv1alpha1.APIResourceSchema)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected object type: %T", obj))
+		return
+	}
+
+	schemas, err := c.listAPIResourceSchemas(logicalcluster.From(schema))
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, s := range schemas {
+		if s.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaPredecessorKey] == schema.Name {
+			c.enqueueAPIResourceSchema(s)
+		}
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getAPIResourceSchema(clusterName, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.reconcile(ctx, obj); err != nil {
+		return err
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	return c.commit(ctx, oldResource, newResource)
+}
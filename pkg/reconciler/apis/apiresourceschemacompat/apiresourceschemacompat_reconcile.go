@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresourceschemacompat
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kcp-dev/kcp/pkg/schemacompat"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, schema *apisv1alpha1.APIResourceSchema) error {
+	predecessorName, found := schema.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaPredecessorKey]
+	if !found {
+		conditions.Delete(schema, apisv1alpha1.CompatibleWithPredecessor)
+		return nil
+	}
+
+	predecessor, err := c.getAPIResourceSchema(logicalcluster.From(schema), predecessorName)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(
+			schema,
+			apisv1alpha1.CompatibleWithPredecessor,
+			apisv1alpha1.PredecessorNotFoundReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"APIResourceSchema %s not found",
+			predecessorName,
+		)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	incompatibilities, err := schemacompat.APIResourceSchemaIncompatibilities(predecessor, schema)
+	if err != nil {
+		return err
+	}
+	if len(incompatibilities) > 0 {
+		conditions.MarkFalse(
+			schema,
+			apisv1alpha1.CompatibleWithPredecessor,
+			apisv1alpha1.IncompatibleChangesReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"incompatible with APIResourceSchema %s: %s",
+			predecessorName,
+			strings.Join(incompatibilities, "; "),
+		)
+		return nil
+	}
+
+	conditions.MarkTrue(schema, apisv1alpha1.CompatibleWithPredecessor)
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresourceschemacompat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func newSchema(name, predecessor, openAPISchema string) *apisv1alpha1.APIResourceSchema {
+	s := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "example.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{Name: "v1", Served: true, Storage: true, Schema: runtime.RawExtension{Raw: []byte(openAPISchema)}},
+			},
+		},
+	}
+	if predecessor != "" {
+		s.Annotations = map[string]string{apisv1alpha1.AnnotationAPIResourceSchemaPredecessorKey: predecessor}
+	}
+	return s
+}
+
+func TestReconcile(t *testing.T) {
+	const (
+		v1Schema = `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"}}}}}`
+		v2Schema = `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"},"color":{"type":"string"}}}}}`
+	)
+
+	tests := map[string]struct {
+		schema      *apisv1alpha1.APIResourceSchema
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		"no predecessor": {
+			schema: newSchema("v2.widgets.example.io", "", v2Schema),
+		},
+		"compatible": {
+			schema:     newSchema("v2.widgets.example.io", "v1.widgets.example.io", v2Schema),
+			wantStatus: corev1.ConditionTrue,
+		},
+		"incompatible": {
+			schema:      newSchema("v0.widgets.example.io", "v1.widgets.example.io", `{"type":"object","properties":{"spec":{"type":"object"}}}`),
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  apisv1alpha1.IncompatibleChangesReason,
+			wantMessage: "incompatible with APIResourceSchema v1.widgets.example.io: v1: field spec.size is removed",
+		},
+		"predecessor not found": {
+			schema:     newSchema("v2.widgets.example.io", "v1.gadgets.example.io", v2Schema),
+			wantStatus: corev1.ConditionFalse,
+			wantReason: apisv1alpha1.PredecessorNotFoundReason,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+					if name == "v1.widgets.example.io" {
+						return newSchema(name, "", v1Schema), nil
+					}
+					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name)
+				},
+			}
+
+			require.NoError(t, c.reconcile(context.Background(), tc.schema))
+
+			cond := conditions.Get(tc.schema, apisv1alpha1.CompatibleWithPredecessor)
+			if tc.wantStatus == "" {
+				require.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			require.Equal(t, tc.wantStatus, cond.Status)
+			require.Equal(t, tc.wantReason, cond.Reason)
+			if tc.wantMessage != "" {
+				require.Equal(t, tc.wantMessage, cond.Message)
+			}
+		})
+	}
+}
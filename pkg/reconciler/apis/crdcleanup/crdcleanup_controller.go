@@ -36,11 +36,13 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -55,33 +57,36 @@ func NewController(
 	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
 	crdClusterClient kcpapiextensionsclientset.ClusterInterface,
 	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	boundCRDPartitions apibinding.BoundCRDPartitions,
+	controllerSwitch *controllerswitch.Switch,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &controller{
-		queue: queue,
+		queue: controllerSwitch.Queue(queue),
 		getCRD: func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
 			return crdInformer.Lister().Cluster(clusterName).Get(name)
 		},
 		getAPIBindingsByBoundResourceUID: func(name string) ([]*apisv1alpha1.APIBinding, error) {
-			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexers.APIBindingByBoundResourceUID, name)
+			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), kcpindexers.APIBindingByBoundResourceUID, name)
 		},
-		deleteCRD: func(ctx context.Context, name string) error {
-			return crdClusterClient.ApiextensionsV1().CustomResourceDefinitions().Cluster(apibinding.SystemBoundCRDsClusterName.Path()).Delete(ctx, name, metav1.DeleteOptions{})
+		deleteCRD: func(ctx context.Context, clusterName logicalcluster.Name, name string) error {
+			return crdClusterClient.ApiextensionsV1().CustomResourceDefinitions().Cluster(clusterName.Path()).Delete(ctx, name, metav1.DeleteOptions{})
 		},
+		boundCRDPartitions: boundCRDPartitions,
 	}
 
 	indexers.AddIfNotPresentOrDie(
 		apiBindingInformer.Informer().GetIndexer(),
 		cache.Indexers{
-			indexers.APIBindingByBoundResourceUID: indexers.IndexAPIBindingByBoundResourceUID,
+			kcpindexers.APIBindingByBoundResourceUID: kcpindexers.IndexAPIBindingByBoundResourceUID,
 		},
 	)
 
 	crdInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
 			crd := obj.(*apiextensionsv1.CustomResourceDefinition)
-			return logicalcluster.From(crd) == apibinding.SystemBoundCRDsClusterName
+			return apibinding.IsSystemBoundCRDsCluster(logicalcluster.From(crd))
 		},
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
@@ -116,7 +121,9 @@ type controller struct {
 
 	getCRD                           func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error)
 	getAPIBindingsByBoundResourceUID func(name string) ([]*apisv1alpha1.APIBinding, error)
-	deleteCRD                        func(ctx context.Context, name string) error
+	deleteCRD                        func(ctx context.Context, clusterName logicalcluster.Name, name string) error
+
+	boundCRDPartitions apibinding.BoundCRDPartitions
 }
 
 // enqueueCRD enqueues a CRD.
@@ -152,7 +159,11 @@ func (c *controller) enqueueFromAPIBinding(oldBinding, newBinding *apisv1alpha1.
 	}
 
 	for uid := range uidSet {
-		key := kcpcache.ToClusterAwareKey(apibinding.SystemBoundCRDsClusterName.String(), "", uid)
+		crd, err := c.boundCRDPartitions.Get(c.getCRD, uid)
+		if err != nil {
+			continue // not found, nothing to clean up
+		}
+		key := kcpcache.ToClusterAwareKey(logicalcluster.From(crd).String(), "", uid)
 		logging.WithQueueKey(logger, key).V(2).Info("queueing CRD via APIBinding")
 		c.queue.Add(key)
 	}
@@ -243,7 +254,7 @@ func (c *controller) process(ctx context.Context, key string) error {
 	}
 
 	logger.V(1).Info("Deleting CRD")
-	if err := c.deleteCRD(ctx, obj.Name); err != nil {
+	if err := c.deleteCRD(ctx, clusterName, obj.Name); err != nil {
 		if errors.IsNotFound(err) {
 			return nil // object deleted before we handled it
 		}
@@ -28,8 +28,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 func TestBoundCRDDeletion(t *testing.T) {
@@ -123,7 +123,7 @@ func TestBoundCRDDeletion(t *testing.T) {
 					}
 					return []*apisv1alpha1.APIBinding{}, nil
 				},
-				deleteCRD: func(ctx context.Context, name string) error {
+				deleteCRD: func(ctx context.Context, clusterName logicalcluster.Name, name string) error {
 					deleteHappened = true
 					return nil
 				},
@@ -20,13 +20,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -35,16 +38,19 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	apisinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	apislisters "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	apislisters "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -56,11 +62,24 @@ func NewController(
 	kcpClusterClient kcpclientset.ClusterInterface,
 	apiExportInformer apisinformers.APIExportClusterInformer,
 	apiBindingInformer apisinformers.APIBindingClusterInformer,
+	patchQPS float32,
+	patchBurst int,
+	annotationPrefixes, labelPrefixes []string,
+	paused func() bool,
+	controllerSwitch *controllerswitch.Switch,
 ) (*controller, error) {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &controller{
-		queue: queue,
+		queue: controllerSwitch.Queue(queue),
+
+		patchLimiter:  flowcontrol.NewTokenBucketRateLimiter(patchQPS, patchBurst),
+		batchSize:     patchBurst,
+		batchInterval: time.Duration(float64(patchBurst) / float64(patchQPS) * float64(time.Second)),
+		backlog:       &backlog{due: map[string]time.Time{}, now: time.Now},
+		paused:        paused,
+
+		prefixes: syncedPrefixes{annotations: annotationPrefixes, labels: labelPrefixes},
 
 		kcpClusterClient: kcpClusterClient,
 
@@ -71,7 +90,7 @@ func NewController(
 		apiBindingIndexer: apiBindingInformer.Informer().GetIndexer(),
 
 		getAPIBindingsByAPIExport: func(path logicalcluster.Path, exportName string) ([]*apisv1alpha1.APIBinding, error) {
-			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), indexers.APIBindingsByAPIExport, path.Join(exportName).String())
+			return indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingInformer.Informer().GetIndexer(), kcpindexers.APIBindingsByAPIExport, path.Join(exportName).String())
 		},
 		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
 			return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
@@ -85,29 +104,47 @@ func NewController(
 	})
 
 	indexers.AddIfNotPresentOrDie(apiBindingInformer.Informer().GetIndexer(), cache.Indexers{
-		indexers.APIBindingsByAPIExport: indexers.IndexAPIBindingByAPIExport,
+		kcpindexers.APIBindingsByAPIExport: kcpindexers.IndexAPIBindingByAPIExport,
 	})
 
 	apiExportInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.enqueueAPIExport(obj, logger) },
-		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExport(obj, logger) },
+		AddFunc: func(obj interface{}) { c.enqueueAPIExport(obj, logger) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if extraMetadataChanged(c.prefixes, oldObj.(*apisv1alpha1.APIExport), newObj.(*apisv1alpha1.APIExport)) {
+				c.enqueueAPIExport(newObj, logger)
+			}
+		},
 	})
 
 	apiBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.enqueueAPIBinding(obj, logger, "") },
-		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIBinding(obj, logger, "") },
+		AddFunc:    func(obj interface{}) { c.enqueueAPIBinding(obj, logger, "", 0) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIBinding(obj, logger, "", 0) },
 	})
 
 	return c, nil
 }
 
-// controller continuously sync annotations with the prefix extra.api.kcp.io from an APIExport to
-// all APIBindings that bind to the APIExport. If the annotation is added to the APIExport, the controller ensures
-// the existence of the annotation on all related APIBindings. If the annotaion is removed from the APIExport, the
-// controller ensures the annotation is removed from all related APIBindings.
+// controller continuously sync annotations with the configured prefixes (extra.apis.kcp.io by default) and labels
+// with the configured prefixes (extra-label.apis.kcp.io by default) from an APIExport to all APIBindings that bind
+// to the APIExport. If the annotation or label is added to the APIExport, the controller ensures its existence on all
+// related APIBindings. If the annotaion or label is removed from the APIExport, the controller ensures it is removed
+// from all related APIBindings.
+//
+// Patches are limited to a QPS budget. When an APIExport changes, its APIBindings are enqueued in batches
+// of the burst size, spread with jitter over the time the budget needs to refill, to avoid a patch storm
+// for APIExports with many bindings.
 type controller struct {
 	queue workqueue.RateLimitingInterface
 
+	patchLimiter  flowcontrol.RateLimiter
+	batchSize     int
+	batchInterval time.Duration
+	backlog       *backlog
+	// paused returns true while the shard sheds load. Workers do not process keys then.
+	paused func() bool
+
+	prefixes syncedPrefixes
+
 	kcpClusterClient kcpclientset.ClusterInterface
 
 	apiExportLister  apislisters.APIExportClusterLister
@@ -120,16 +157,17 @@ type controller struct {
 	getAPIExport              func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
 }
 
-// enqueueAPIBinding enqueues an APIBinding .
-func (c *controller) enqueueAPIBinding(obj interface{}, logger logr.Logger, logSuffix string) {
+// enqueueAPIBinding enqueues an APIBinding after the given delay.
+func (c *controller) enqueueAPIBinding(obj interface{}, logger logr.Logger, logSuffix string, delay time.Duration) {
 	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
 	if err != nil {
 		runtime.HandleError(err)
 		return
 	}
 
-	logging.WithQueueKey(logger, key).V(2).Info(fmt.Sprintf("queueing APIBinding%s", logSuffix))
-	c.queue.Add(key)
+	logging.WithQueueKey(logger, key).V(2).Info(fmt.Sprintf("queueing APIBinding%s", logSuffix), "delay", delay)
+	c.backlog.add(key, delay)
+	c.queue.AddAfter(key, delay)
 }
 
 // enqueueAPIExport enqueues maps an APIExport to APIBindings for enqueuing.
@@ -147,7 +185,7 @@ func (c *controller) enqueueAPIExport(obj interface{}, logger logr.Logger) {
 	// APIBinding keys by full path
 	keys := sets.NewString()
 	if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
-		pathKeys, err := c.apiBindingIndexer.IndexKeys(indexers.APIBindingsByAPIExport, path.Join(export.Name).String())
+		pathKeys, err := c.apiBindingIndexer.IndexKeys(kcpindexers.APIBindingsByAPIExport, path.Join(export.Name).String())
 		if err != nil {
 			runtime.HandleError(err)
 			return
@@ -155,14 +193,14 @@ func (c *controller) enqueueAPIExport(obj interface{}, logger logr.Logger) {
 		keys.Insert(pathKeys...)
 	}
 
-	clusterKeys, err := c.apiBindingIndexer.IndexKeys(indexers.APIBindingsByAPIExport, logicalcluster.From(export).Path().Join(export.Name).String())
+	clusterKeys, err := c.apiBindingIndexer.IndexKeys(kcpindexers.APIBindingsByAPIExport, logicalcluster.From(export).Path().Join(export.Name).String())
 	if err != nil {
 		runtime.HandleError(err)
 		return
 	}
 	keys.Insert(clusterKeys...)
 
-	for _, key := range keys.List() {
+	for i, key := range keys.List() {
 		binding, exists, err := c.apiBindingIndexer.GetByKey(key)
 		if err != nil {
 			runtime.HandleError(err)
@@ -171,8 +209,67 @@ func (c *controller) enqueueAPIExport(obj interface{}, logger logr.Logger) {
 			runtime.HandleError(fmt.Errorf("APIBinding %q does not exist", key))
 			continue
 		}
-		c.enqueueAPIBinding(binding, logging.WithObject(logger, obj.(*apisv1alpha1.APIExport)), " because of APIExport")
+		c.enqueueAPIBinding(binding, logging.WithObject(logger, obj.(*apisv1alpha1.APIExport)), " because of APIExport", c.batchDelay(i, rand.Float64()))
+	}
+}
+
+// syncedPrefixes are the key prefixes of the annotations and labels synced from APIExports to APIBindings.
+type syncedPrefixes struct {
+	annotations []string
+	labels      []string
+}
+
+// extraMetadataChanged returns true if an APIExport update changes what is synced to its APIBindings,
+// or which APIBindings are found for it. Status-only updates return false.
+func extraMetadataChanged(prefixes syncedPrefixes, oldExport, newExport *apisv1alpha1.APIExport) bool {
+	if oldExport.DeprecationWarning() != newExport.DeprecationWarning() {
+		return true
+	}
+	for _, key := range []string{
+		apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey,
+		apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey,
+		core.LogicalClusterPathAnnotationKey,
+	} {
+		oldValue, oldFound := oldExport.Annotations[key]
+		newValue, newFound := newExport.Annotations[key]
+		if oldFound != newFound || oldValue != newValue {
+			return true
+		}
+	}
+	return !equality.Semantic.DeepEqual(extraKeys(oldExport.Annotations, prefixes.annotations), extraKeys(newExport.Annotations, prefixes.annotations)) ||
+		!equality.Semantic.DeepEqual(extraKeys(oldExport.Labels, prefixes.labels), extraKeys(newExport.Labels, prefixes.labels))
+}
+
+// extraKeys returns the entries of m with one of the given key prefixes.
+func extraKeys(m map[string]string, prefixes []string) map[string]string {
+	extra := map[string]string{}
+	for k, v := range m {
+		if hasPrefix(k, prefixes) {
+			extra[k] = v
+		}
 	}
+	return extra
+}
+
+// hasPrefix returns true if the key has one of the given prefixes.
+func hasPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// batchDelay returns the delay of the i-th APIBinding enqueued because of an APIExport. The first
+// batch is not delayed, every further batch is delayed by another batch interval, and jittered
+// within it by the given factor in [0,1).
+func (c *controller) batchDelay(i int, jitter float64) time.Duration {
+	batch := i / c.batchSize
+	if batch == 0 {
+		return 0
+	}
+	return time.Duration(batch)*c.batchInterval + time.Duration(jitter*float64(c.batchInterval))
 }
 
 // Start starts the controller, which stops when ctx.Done() is closed.
@@ -193,7 +290,8 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 }
 
 func (c *controller) startWorker(ctx context.Context) {
-	for c.processNextWorkItem(ctx) {
+	// returning while paused makes wait.UntilWithContext retry a second later
+	for !(c.paused != nil && c.paused()) && c.processNextWorkItem(ctx) {
 	}
 }
 
@@ -213,8 +311,10 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	c.backlog.done(key)
 	if err := c.process(ctx, key); err != nil {
 		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.backlog.add(key, 0)
 		c.queue.AddRateLimited(key)
 		return true
 	}
@@ -243,7 +343,7 @@ func (c *controller) process(ctx context.Context, key string) error {
 		return nil
 	}
 
-	path := logicalcluster.NewPath(apiBinding.Spec.Reference.Export.Path)
+	path := apiBinding.Spec.Reference.Export.Path.Path()
 	if path.Empty() {
 		path = clusterName.Path()
 	}
@@ -255,7 +355,7 @@ func (c *controller) process(ctx context.Context, key string) error {
 		return err
 	}
 
-	patchBytes, err := syncExtraAnnotationPatch(apiExport.Annotations, apiBinding.Annotations)
+	patchBytes, err := syncExtraMetadataPatch(c.prefixes, apiExport.ObjectMeta, apiBinding.ObjectMeta, apiExport.DeprecationWarning())
 	if err != nil {
 		return err
 	}
@@ -263,41 +363,152 @@ func (c *controller) process(ctx context.Context, key string) error {
 		return nil
 	}
 
-	logger.V(1).Info("patching APIBinding extra annotations", "patch", string(patchBytes))
+	if err := c.patchLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	logger.V(1).Info("patching APIBinding extra annotations and labels", "patch", string(patchBytes))
 	_, err = c.kcpClusterClient.Cluster(clusterName.Path()).ApisV1alpha1().APIBindings().Patch(ctx, name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
 	return err
 }
 
-func syncExtraAnnotationPatch(a1, a2 map[string]string) ([]byte, error) {
-	annotationToPatch := map[string]interface{}{} // nil means to remove the key
-	// Override annotations from a1 to a2
-	for k, v := range a1 {
-		if !strings.HasPrefix(k, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix) {
-			continue
+// backlog tracks the keys of APIBindings waiting to be synced, including those whose
+// enqueuing is delayed, and publishes their number as metric. A key can be queued
+// while a delayed add of it is still pending, so every key remembers when its last
+// pending add is due, and stays in the backlog until it is processed after that.
+type backlog struct {
+	lock sync.Mutex
+	due  map[string]time.Time
+	now  func() time.Time
+}
+
+// add records the key to be enqueued after the given delay.
+func (b *backlog) add(key string, delay time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if due := b.now().Add(delay); due.After(b.due[key]) {
+		b.due[key] = due
+	}
+	syncBacklog.Set(float64(len(b.due)))
+}
+
+// done records the key to be processed. It is kept if a delayed add is still pending.
+func (b *backlog) done(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if due, found := b.due[key]; found && !b.now().Before(due) {
+		delete(b.due, key)
+	}
+	syncBacklog.Set(float64(len(b.due)))
+}
+
+// syncExtraMetadataPatch returns a merge patch syncing the extra annotations and labels, and the
+// deprecation warning of an APIExport to an APIBinding, or nil if they are in sync. Extra annotations
+// the APIExport marks as default-only are only seeded once, and owned by the consumer afterwards.
+func syncExtraMetadataPatch(prefixes syncedPrefixes, export, binding metav1.ObjectMeta, deprecationWarning string) ([]byte, error) {
+	defaultOnly := splitKeys(export.Annotations[apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey])
+	owned := splitKeys(binding.Annotations[apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey])
+	annotationToPatch := syncExtraKeys(export.Annotations, binding.Annotations, prefixes.annotations, defaultOnly, owned)
+	labelToPatch := syncExtraKeys(export.Labels, binding.Labels, prefixes.labels, nil, sets.NewString())
+
+	// track the keys owned by the consumer
+	if value := strings.Join(owned.List(), ","); value != binding.Annotations[apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey] {
+		if value == "" {
+			annotationToPatch[apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey] = nil
+		} else {
+			annotationToPatch[apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey] = value
+		}
+	}
+
+	// mirror the extra annotations to be set on bound CRDs
+	if value, ok := export.Annotations[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey]; ok && value != binding.Annotations[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey] {
+		annotationToPatch[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey] = value
+	} else if _, found := binding.Annotations[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey]; !ok && found {
+		annotationToPatch[apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey] = nil
+	}
+
+	// surface the deprecation of the APIExport, or remove it if it is gone
+	if value, ok := binding.Annotations[apisv1alpha1.AnnotationAPIExportDeprecationKey]; deprecationWarning != "" && (!ok || value != deprecationWarning) {
+		annotationToPatch[apisv1alpha1.AnnotationAPIExportDeprecationKey] = deprecationWarning
+	} else if deprecationWarning == "" && ok {
+		annotationToPatch[apisv1alpha1.AnnotationAPIExportDeprecationKey] = nil
+	}
+
+	if len(annotationToPatch) == 0 && len(labelToPatch) == 0 {
+		return nil, nil
+	}
+
+	patch := map[string]interface{}{}
+	if len(annotationToPatch) > 0 {
+		if err := unstructured.SetNestedField(patch, annotationToPatch, "metadata", "annotations"); err != nil {
+			return nil, err
 		}
-		if value, ok := a2[k]; !ok || v != value {
-			annotationToPatch[k] = v
+	}
+	if len(labelToPatch) > 0 {
+		if err := unstructured.SetNestedField(patch, labelToPatch, "metadata", "labels"); err != nil {
+			return nil, err
 		}
 	}
 
-	// remove annotation on a2 if it does not exist on a1
-	for k := range a2 {
-		if !strings.HasPrefix(k, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix) {
+	return json.Marshal(patch)
+}
+
+// syncExtraKeys returns the merge patch values syncing the keys with one of the given prefixes from m1 to m2.
+// Keys in defaultOnly are only set if they are not owned yet, and become owned. Owned keys are not
+// changed or removed. owned is updated in place.
+func syncExtraKeys(m1, m2 map[string]string, prefixes []string, defaultOnly, owned sets.String) map[string]interface{} {
+	toPatch := map[string]interface{}{} // nil means to remove the key
+	// Override keys from m1 to m2
+	for k, v := range m1 {
+		if !hasPrefix(k, prefixes) {
+			continue
+		}
+		if defaultOnly.Has(k) {
+			if !owned.Has(k) {
+				// seed once, then the key is owned by the consumer
+				if _, ok := m2[k]; !ok {
+					toPatch[k] = v
+				}
+				owned.Insert(k)
+			}
 			continue
 		}
-		if _, ok := a1[k]; !ok {
-			annotationToPatch[k] = nil
+		// enforced again
+		owned.Delete(k)
+		if value, ok := m2[k]; !ok || v != value {
+			toPatch[k] = v
 		}
 	}
 
-	if len(annotationToPatch) == 0 {
-		return nil, nil
+	// remove key on m2 if it does not exist on m1, and is not owned
+	for k := range m2 {
+		if !hasPrefix(k, prefixes) {
+			continue
+		}
+		if _, ok := m1[k]; !ok && !owned.Has(k) {
+			toPatch[k] = nil
+		}
 	}
 
-	patch := map[string]interface{}{}
-	if err := unstructured.SetNestedField(patch, annotationToPatch, "metadata", "annotations"); err != nil {
-		return nil, err
+	// forget about owned keys that are gone on both sides
+	for _, k := range owned.List() {
+		_, inM1 := m1[k]
+		_, inM2 := m2[k]
+		if !inM1 && !inM2 {
+			owned.Delete(k)
+		}
 	}
 
-	return json.Marshal(patch)
+	return toPatch
+}
+
+// splitKeys returns the keys of a comma separated annotation value.
+func splitKeys(value string) sets.String {
+	keys := sets.NewString()
+	for _, k := range strings.Split(value, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys.Insert(k)
+		}
+	}
+	return keys
 }
@@ -19,17 +19,29 @@ package extraannotationsync
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
-func TestSyncExtraAnnotationPatch(t *testing.T) {
+var defaultPrefixes = syncedPrefixes{
+	annotations: []string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix},
+	labels:      []string{apisv1alpha1.LabelAPIExportExtraKeyPrefix},
+}
+
+func TestSyncExtraMetadataPatch(t *testing.T) {
 	scenarios := []struct {
 		name                  string
+		prefixes              *syncedPrefixes
 		apiExportAnnotations  map[string]string
 		apiBindingAnnotations map[string]string
+		apiExportLabels       map[string]string
+		apiBindingLabels      map[string]string
+		deprecationWarning    string
 		wantPatch             string
 	}{
 		{
@@ -56,13 +68,254 @@ func TestSyncExtraAnnotationPatch(t *testing.T) {
 			apiBindingAnnotations: map[string]string{"key2": "value2", apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test1"},
 			wantPatch:             fmt.Sprintf(`{"metadata":{"annotations":{%q:"test"}}}`, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix+"test"),
 		},
+		{
+			name:             "sync extra labels",
+			apiExportLabels:  map[string]string{"key1": "value1", apisv1alpha1.LabelAPIExportExtraKeyPrefix + "test1": "test1"},
+			apiBindingLabels: map[string]string{"key2": "value2", apisv1alpha1.LabelAPIExportExtraKeyPrefix + "test2": "test2"},
+			wantPatch: fmt.Sprintf(
+				`{"metadata":{"labels":{%q:"test1",%q:null}}}`,
+				apisv1alpha1.LabelAPIExportExtraKeyPrefix+"test1",
+				apisv1alpha1.LabelAPIExportExtraKeyPrefix+"test2",
+			),
+		},
+		{
+			name:             "extra annotation prefix is not synced for labels",
+			apiExportLabels:  map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test"},
+			apiBindingLabels: map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "other": "other"},
+		},
+		{
+			name:                  "sync extra annotations and labels",
+			apiExportAnnotations:  map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test"},
+			apiExportLabels:       map[string]string{apisv1alpha1.LabelAPIExportExtraKeyPrefix + "test": "test"},
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test"},
+			apiBindingLabels:      map[string]string{apisv1alpha1.LabelAPIExportExtraKeyPrefix + "test": "test1"},
+			wantPatch:             fmt.Sprintf(`{"metadata":{"labels":{%q:"test"}}}`, apisv1alpha1.LabelAPIExportExtraKeyPrefix+"test"),
+		},
+		{
+			name: "seed default-only extra annotation",
+			apiExportAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test",
+				apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			wantPatch: fmt.Sprintf(
+				`{"metadata":{"annotations":{%q:"%stest",%q:"test"}}}`,
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey,
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix,
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix+"test",
+			),
+		},
+		{
+			name: "default-only extra annotation set by consumer before seeding is kept",
+			apiExportAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test",
+				apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "consumer"},
+			wantPatch:             fmt.Sprintf(`{"metadata":{"annotations":{%q:"%stest"}}}`, apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix),
+		},
+		{
+			name: "consumer-owned extra annotation changed by consumer is not reverted",
+			apiExportAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test",
+				apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			apiBindingAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "consumer",
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey:        apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+		},
+		{
+			name: "consumer-owned extra annotation removed by consumer is not restored",
+			apiExportAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test",
+				apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			apiBindingAnnotations: map[string]string{
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+		},
+		{
+			name: "consumer-owned extra annotation removed from APIExport is kept",
+			apiBindingAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "consumer",
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey:        apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+		},
+		{
+			name: "consumer-owned extra annotation gone on both sides is forgotten",
+			apiBindingAnnotations: map[string]string{
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey: apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			wantPatch: fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey),
+		},
+		{
+			name:                 "consumer-owned extra annotation is enforced again when not default-only anymore",
+			apiExportAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "test"},
+			apiBindingAnnotations: map[string]string{
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test": "consumer",
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey:        apisv1alpha1.AnnotationAPIExportExtraKeyPrefix + "test",
+			},
+			wantPatch: fmt.Sprintf(
+				`{"metadata":{"annotations":{%q:null,%q:"test"}}}`,
+				apisv1alpha1.AnnotationConsumerOwnedExtraKeysKey,
+				apisv1alpha1.AnnotationAPIExportExtraKeyPrefix+"test",
+			),
+		},
+		{
+			name:               "add deprecation",
+			deprecationWarning: "APIExport foo is deprecated",
+			wantPatch:          fmt.Sprintf(`{"metadata":{"annotations":{%q:"APIExport foo is deprecated"}}}`, apisv1alpha1.AnnotationAPIExportDeprecationKey),
+		},
+		{
+			name:                  "deprecation up-to-date",
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportDeprecationKey: "APIExport foo is deprecated"},
+			deprecationWarning:    "APIExport foo is deprecated",
+		},
+		{
+			name:                  "update deprecation",
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportDeprecationKey: "APIExport foo is deprecated"},
+			deprecationWarning:    "APIExport foo is deprecated: use bar",
+			wantPatch:             fmt.Sprintf(`{"metadata":{"annotations":{%q:"APIExport foo is deprecated: use bar"}}}`, apisv1alpha1.AnnotationAPIExportDeprecationKey),
+		},
+		{
+			name:                  "remove deprecation",
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportDeprecationKey: "APIExport foo is deprecated"},
+			wantPatch:             fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, apisv1alpha1.AnnotationAPIExportDeprecationKey),
+		},
+		{
+			name:                 "mirror extra CRD keys",
+			apiExportAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey: "extra.apis.kcp.io/docs"},
+			wantPatch:            fmt.Sprintf(`{"metadata":{"annotations":{%q:"extra.apis.kcp.io/docs"}}}`, apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey),
+		},
+		{
+			name:                  "extra CRD keys in sync",
+			apiExportAnnotations:  map[string]string{apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey: "extra.apis.kcp.io/docs"},
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey: "extra.apis.kcp.io/docs"},
+		},
+		{
+			name:                  "remove extra CRD keys",
+			apiBindingAnnotations: map[string]string{apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey: "extra.apis.kcp.io/docs"},
+			wantPatch:             fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, apisv1alpha1.AnnotationAPIExportExtraCRDKeysKey),
+		},
+		{
+			name:                  "configured prefixes",
+			prefixes:              &syncedPrefixes{annotations: []string{"acme.com/", apisv1alpha1.AnnotationAPIExportExtraKeyPrefix}, labels: []string{"acme.com/"}},
+			apiExportAnnotations:  map[string]string{"acme.com/team": "storage", "extra.apis.kcp.io/docs": "url", "other.com/x": "y"},
+			apiBindingAnnotations: map[string]string{"acme.com/stale": "true"},
+			apiExportLabels:       map[string]string{"acme.com/tier": "gold", apisv1alpha1.LabelAPIExportExtraKeyPrefix + "a": "b"},
+			wantPatch:             `{"metadata":{"annotations":{"acme.com/stale":null,"acme.com/team":"storage","extra.apis.kcp.io/docs":"url"},"labels":{"acme.com/tier":"gold"}}}`,
+		},
 	}
 
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
-			patch, err := syncExtraAnnotationPatch(scenario.apiExportAnnotations, scenario.apiBindingAnnotations)
+			prefixes := defaultPrefixes
+			if scenario.prefixes != nil {
+				prefixes = *scenario.prefixes
+			}
+			patch, err := syncExtraMetadataPatch(
+				prefixes,
+				metav1.ObjectMeta{Annotations: scenario.apiExportAnnotations, Labels: scenario.apiExportLabels},
+				metav1.ObjectMeta{Annotations: scenario.apiBindingAnnotations, Labels: scenario.apiBindingLabels},
+				scenario.deprecationWarning,
+			)
 			require.NoError(t, err)
 			require.Equal(t, scenario.wantPatch, string(patch))
 		})
 	}
 }
+
+func TestBatchDelay(t *testing.T) {
+	c := &controller{batchSize: 50, batchInterval: 2 * time.Second}
+
+	for _, scenario := range []struct {
+		name   string
+		i      int
+		jitter float64
+		want   time.Duration
+	}{
+		{name: "first of the first batch", i: 0, jitter: 0.5, want: 0},
+		{name: "last of the first batch", i: 49, jitter: 0.5, want: 0},
+		{name: "first of the second batch", i: 50, jitter: 0, want: 2 * time.Second},
+		{name: "second batch with jitter", i: 99, jitter: 0.5, want: 3 * time.Second},
+		{name: "third batch with jitter", i: 100, jitter: 0.25, want: 4*time.Second + 500*time.Millisecond},
+	} {
+		t.Run(scenario.name, func(t *testing.T) {
+			require.Equal(t, scenario.want, c.batchDelay(scenario.i, scenario.jitter))
+		})
+	}
+}
+
+func TestBacklog(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &backlog{due: map[string]time.Time{}, now: func() time.Time { return now }}
+
+	b.add("a", 10*time.Second)
+	b.add("a", 0)
+	b.add("b", 0)
+	require.Len(t, b.due, 2)
+
+	// processing the immediate add keeps the delayed one in the backlog
+	b.done("a")
+	b.done("b")
+	require.Len(t, b.due, 1)
+
+	now = now.Add(10 * time.Second)
+	b.done("a")
+	require.Empty(t, b.due)
+}
+
+func TestExtraMetadataChanged(t *testing.T) {
+	export := func(annotations, labels map[string]string) *apisv1alpha1.APIExport {
+		return &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "export", Annotations: annotations, Labels: labels}}
+	}
+
+	for _, scenario := range []struct {
+		name     string
+		old, new *apisv1alpha1.APIExport
+		want     bool
+	}{
+		{
+			name: "unrelated annotation and status change",
+			old:  export(map[string]string{"extra.apis.kcp.io/a": "1", "foo": "bar"}, nil),
+			new: func() *apisv1alpha1.APIExport {
+				e := export(map[string]string{"extra.apis.kcp.io/a": "1", "foo": "baz"}, nil)
+				e.Status.IdentityHash = "hash"
+				return e
+			}(),
+			want: false,
+		},
+		{
+			name: "extra annotation changed",
+			old:  export(map[string]string{"extra.apis.kcp.io/a": "1"}, nil),
+			new:  export(map[string]string{"extra.apis.kcp.io/a": "2"}, nil),
+			want: true,
+		},
+		{
+			name: "extra label removed",
+			old:  export(nil, map[string]string{"extra-label.apis.kcp.io/a": "1"}),
+			new:  export(nil, nil),
+			want: true,
+		},
+		{
+			name: "default-only keys changed",
+			old:  export(map[string]string{"extra.apis.kcp.io/a": "1"}, nil),
+			new:  export(map[string]string{"extra.apis.kcp.io/a": "1", apisv1alpha1.AnnotationAPIExportExtraDefaultOnlyKeysKey: "extra.apis.kcp.io/a"}, nil),
+			want: true,
+		},
+		{
+			name: "deprecated",
+			old:  export(nil, nil),
+			new: func() *apisv1alpha1.APIExport {
+				e := export(nil, nil)
+				e.Spec.Deprecation = &apisv1alpha1.APIExportDeprecation{Deprecated: true}
+				return e
+			}(),
+			want: true,
+		},
+	} {
+		t.Run(scenario.name, func(t *testing.T) {
+			require.Equal(t, scenario.want, extraMetadataChanged(defaultPrefixes, scenario.old, scenario.new))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extraannotationsync
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	syncBacklog = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "apiexport_extra_annotation_sync_backlog",
+			Help:           "Number of APIBindings waiting for the extra annotations and labels of their APIExport to be synced.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the extra annotation sync metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(syncBacklog)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
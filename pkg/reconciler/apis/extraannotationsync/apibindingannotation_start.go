@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extraannotationsync
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func DefaultOptions() *Options {
+	return &Options{
+		PatchQPS:           20,
+		PatchBurst:         50,
+		AnnotationPrefixes: []string{apisv1alpha1.AnnotationAPIExportExtraKeyPrefix},
+		LabelPrefixes:      []string{apisv1alpha1.LabelAPIExportExtraKeyPrefix},
+	}
+}
+
+func BindOptions(o *Options, fs *pflag.FlagSet) *Options {
+	fs.Float32Var(&o.PatchQPS, "apiexport-extra-annotation-sync-qps", o.PatchQPS, "Maximum number of APIBindings patched per second when syncing extra annotations and labels of APIExports")
+	fs.IntVar(&o.PatchBurst, "apiexport-extra-annotation-sync-burst", o.PatchBurst, "Maximum burst of APIBinding patches when syncing extra annotations and labels of APIExports. APIBindings of a changed APIExport are synced in batches of this size")
+	fs.StringSliceVar(&o.AnnotationPrefixes, "apiexport-extra-annotation-sync-annotation-prefixes", o.AnnotationPrefixes, "Key prefixes of the annotations synced from APIExports to their APIBindings, e.g. to propagate organization specific metadata. Prefixes must end with a slash, and must not be in the kcp.io domain other than the default")
+	fs.StringSliceVar(&o.LabelPrefixes, "apiexport-extra-annotation-sync-label-prefixes", o.LabelPrefixes, "Key prefixes of the labels synced from APIExports to their APIBindings. Prefixes must end with a slash, and must not be in the kcp.io domain other than the default")
+	return o
+}
+
+type Options struct {
+	PatchQPS   float32
+	PatchBurst int
+
+	AnnotationPrefixes []string
+	LabelPrefixes      []string
+}
+
+func (o *Options) Validate() error {
+	if o.PatchQPS <= 0 {
+		return fmt.Errorf("--apiexport-extra-annotation-sync-qps must be >0 (%v)", o.PatchQPS)
+	}
+	if o.PatchBurst <= 0 {
+		return fmt.Errorf("--apiexport-extra-annotation-sync-burst must be >0 (%d)", o.PatchBurst)
+	}
+	if err := validatePrefixes(o.AnnotationPrefixes, apisv1alpha1.AnnotationAPIExportExtraKeyPrefix); err != nil {
+		return fmt.Errorf("--apiexport-extra-annotation-sync-annotation-prefixes: %w", err)
+	}
+	if err := validatePrefixes(o.LabelPrefixes, apisv1alpha1.LabelAPIExportExtraKeyPrefix); err != nil {
+		return fmt.Errorf("--apiexport-extra-annotation-sync-label-prefixes: %w", err)
+	}
+	return nil
+}
+
+// validatePrefixes checks that prefixes are key prefixes ending with a slash. Keys in the kcp.io domain
+// are reserved to the system, and must not be synced onto APIBindings, apart from the default prefix.
+func validatePrefixes(prefixes []string, defaultPrefix string) error {
+	for _, prefix := range prefixes {
+		if prefix == defaultPrefix {
+			continue
+		}
+		domain := strings.TrimSuffix(prefix, "/")
+		if domain == prefix {
+			return fmt.Errorf("prefix %q must end with a slash", prefix)
+		}
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			return fmt.Errorf("invalid prefix %q: %s", prefix, strings.Join(errs, ", "))
+		}
+		if domain == "kcp.io" || strings.HasSuffix(domain, ".kcp.io") {
+			return fmt.Errorf("prefix %q is reserved to the system", prefix)
+		}
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configshard "github.com/kcp-dev/kcp/config/shard"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 )
 
 func (c *controller) reconcile(ctx context.Context) error {
@@ -26,8 +26,13 @@ import (
 	"github.com/go-logr/logr"
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -35,12 +40,13 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/permissionclaim"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
 )
 
 const (
@@ -54,6 +60,7 @@ func NewResourceController(
 	dynamicDiscoverySharedInformerFactory *informer.DiscoveringDynamicSharedInformerFactory,
 	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
 	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
 ) (*resourceController, error) {
 	if err := apiBindingInformer.Informer().GetIndexer().AddIndexers(
 		cache.Indexers{
@@ -68,7 +75,10 @@ func NewResourceController(
 		kcpClusterClient:       kcpClusterClient,
 		dynamicClusterClient:   dynamicClusterClient,
 		ddsif:                  dynamicDiscoverySharedInformerFactory,
-		permissionClaimLabeler: permissionclaim.NewLabeler(apiBindingInformer, apiExportInformer),
+		permissionClaimLabeler: permissionclaim.NewLabeler(apiBindingInformer, apiExportInformer, namespaceInformer),
+		listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+			return apiBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
 	}
 
 	logger := logging.WithReconciler(klog.Background(), ControllerName)
@@ -78,6 +88,10 @@ func NewResourceController(
 		DeleteFunc: nil, // Nothing to do.
 	})
 
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) { c.enqueueForNamespace(logger, oldObj, obj) },
+	})
+
 	return c, nil
 }
 
@@ -89,6 +103,8 @@ type resourceController struct {
 	dynamicClusterClient   kcpdynamic.ClusterInterface
 	ddsif                  *informer.DiscoveringDynamicSharedInformerFactory
 	permissionClaimLabeler *permissionclaim.Labeler
+
+	listAPIBindings func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error)
 }
 
 // enqueueForResource adds the resource (gvr + obj) to the queue.
@@ -106,6 +122,61 @@ func (c *resourceController) enqueueForResource(logger logr.Logger, gvr schema.G
 	c.queue.Add(queueKey)
 }
 
+// enqueueForNamespace adds the objects in a namespace to the queue whose resources are claimed
+// with a namespace selector, if the labels of the namespace changed. Otherwise, revoking access by
+// removing a namespace label would not remove the claim labels from the objects in it.
+func (c *resourceController) enqueueForNamespace(logger logr.Logger, oldObj, obj interface{}) {
+	oldNamespace, ok := oldObj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if equality.Semantic.DeepEqual(oldNamespace.Labels, namespace.Labels) {
+		return
+	}
+
+	clusterName := logicalcluster.From(namespace)
+	bindings, err := c.listAPIBindings(clusterName)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	claimed := map[schema.GroupResource]bool{}
+	for _, binding := range bindings {
+		for _, claim := range binding.Spec.PermissionClaims {
+			if claim.State != apisv1alpha1.ClaimAccepted {
+				continue
+			}
+			for _, selector := range claim.ResourceSelector {
+				if selector.NamespaceSelector != nil {
+					claimed[schema.GroupResource{Group: claim.Group, Resource: claim.Resource}] = true
+				}
+			}
+		}
+	}
+	if len(claimed) == 0 {
+		return
+	}
+
+	informers, _ := c.ddsif.Informers()
+	for gvr, inf := range informers {
+		if !claimed[gvr.GroupResource()] {
+			continue
+		}
+		objs, err := inf.Lister().ByCluster(clusterName).ByNamespace(namespace.Name).List(labels.Everything())
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		}
+		for _, obj := range objs {
+			c.enqueueForResource(logger, gvr, obj)
+		}
+	}
+}
+
 // Start starts the controller, which stops when ctx.Done() is closed.
 func (c *resourceController) Start(ctx context.Context, numThreads int) {
 	defer utilruntime.HandleCrash()
@@ -31,17 +31,19 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
 	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
 	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
-	tenancyv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+	tenancyv1beta1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -62,18 +64,24 @@ func NewController(
 	globalKcpInformers kcpinformers.SharedInformerFactory,
 ) (*controller, error) {
 	c := &controller{
-		shardName:                      shardName,
-		queue:                          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
-		dynamicCacheClient:             dynamicCacheClient,
-		dynamicLocalClient:             dynamicLocalClient,
-		localAPIExportLister:           localKcpInformers.Apis().V1alpha1().APIExports().Lister(),
-		localAPIResourceSchemaLister:   localKcpInformers.Apis().V1alpha1().APIResourceSchemas().Lister(),
-		localShardLister:               localKcpInformers.Core().V1alpha1().Shards().Lister(),
-		localWorkspaceTypeLister:       localKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Lister(),
-		globalAPIExportIndexer:         globalKcpInformers.Apis().V1alpha1().APIExports().Informer().GetIndexer(),
-		globalAPIResourceSchemaIndexer: globalKcpInformers.Apis().V1alpha1().APIResourceSchemas().Informer().GetIndexer(),
-		globalShardIndexer:             globalKcpInformers.Core().V1alpha1().Shards().Informer().GetIndexer(),
-		globalWorkspaceTypeIndexer:     globalKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer(),
+		shardName:                        shardName,
+		queue:                            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		dynamicCacheClient:               dynamicCacheClient,
+		dynamicLocalClient:               dynamicLocalClient,
+		localAPIExportLister:             localKcpInformers.Apis().V1alpha1().APIExports().Lister(),
+		localAPIResourceSchemaLister:     localKcpInformers.Apis().V1alpha1().APIResourceSchemas().Lister(),
+		localShardLister:                 localKcpInformers.Core().V1alpha1().Shards().Lister(),
+		localWorkspaceTypeLister:         localKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Lister(),
+		localWorkspaceLister:             localKcpInformers.Tenancy().V1beta1().Workspaces().Lister(),
+		localLogicalClusterLister:        localKcpInformers.Core().V1alpha1().LogicalClusters().Lister(),
+		localThrottlingExemptionLister:   localKcpInformers.Tenancy().V1alpha1().ThrottlingExemptions().Lister(),
+		globalAPIExportIndexer:           globalKcpInformers.Apis().V1alpha1().APIExports().Informer().GetIndexer(),
+		globalAPIResourceSchemaIndexer:   globalKcpInformers.Apis().V1alpha1().APIResourceSchemas().Informer().GetIndexer(),
+		globalShardIndexer:               globalKcpInformers.Core().V1alpha1().Shards().Informer().GetIndexer(),
+		globalWorkspaceTypeIndexer:       globalKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer().GetIndexer(),
+		globalWorkspaceIndexer:           globalKcpInformers.Tenancy().V1beta1().Workspaces().Informer().GetIndexer(),
+		globalLogicalClusterIndexer:      globalKcpInformers.Core().V1alpha1().LogicalClusters().Informer().GetIndexer(),
+		globalThrottlingExemptionIndexer: globalKcpInformers.Tenancy().V1alpha1().ThrottlingExemptions().Informer().GetIndexer(),
 	}
 
 	indexers.AddIfNotPresentOrDie(
@@ -104,6 +112,27 @@ func NewController(
 		},
 	)
 
+	indexers.AddIfNotPresentOrDie(
+		globalKcpInformers.Tenancy().V1beta1().Workspaces().Informer().GetIndexer(),
+		cache.Indexers{
+			ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+		},
+	)
+
+	indexers.AddIfNotPresentOrDie(
+		globalKcpInformers.Core().V1alpha1().LogicalClusters().Informer().GetIndexer(),
+		cache.Indexers{
+			ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+		},
+	)
+
+	indexers.AddIfNotPresentOrDie(
+		globalKcpInformers.Tenancy().V1alpha1().ThrottlingExemptions().Informer().GetIndexer(),
+		cache.Indexers{
+			ByShardAndLogicalClusterAndNamespaceAndName: IndexByShardAndLogicalClusterAndNamespace,
+		},
+	)
+
 	localKcpInformers.Apis().V1alpha1().APIExports().Informer().AddEventHandler(c.objectInformerEventHandler(apisv1alpha1.SchemeGroupVersion.WithResource("apiexports")))
 	globalKcpInformers.Apis().V1alpha1().APIExports().Informer().AddEventHandler(c.objectInformerEventHandler(apisv1alpha1.SchemeGroupVersion.WithResource("apiexports")))
 
@@ -116,6 +145,15 @@ func NewController(
 	localKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes")))
 	globalKcpInformers.Tenancy().V1alpha1().WorkspaceTypes().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes")))
 
+	localKcpInformers.Tenancy().V1beta1().Workspaces().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces")))
+	globalKcpInformers.Tenancy().V1beta1().Workspaces().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces")))
+
+	localKcpInformers.Core().V1alpha1().LogicalClusters().Informer().AddEventHandler(c.objectInformerEventHandler(corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters")))
+	globalKcpInformers.Core().V1alpha1().LogicalClusters().Informer().AddEventHandler(c.objectInformerEventHandler(corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters")))
+
+	localKcpInformers.Tenancy().V1alpha1().ThrottlingExemptions().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions")))
+	globalKcpInformers.Tenancy().V1alpha1().ThrottlingExemptions().Informer().AddEventHandler(c.objectInformerEventHandler(tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions")))
+
 	return c, nil
 }
 
@@ -186,13 +224,19 @@ type controller struct {
 	dynamicCacheClient kcpdynamic.ClusterInterface
 	dynamicLocalClient kcpdynamic.ClusterInterface
 
-	localAPIExportLister         apisv1alpha1listers.APIExportClusterLister
-	localAPIResourceSchemaLister apisv1alpha1listers.APIResourceSchemaClusterLister
-	localShardLister             corev1alpha1listers.ShardClusterLister
-	localWorkspaceTypeLister     tenancyv1alpha1listers.WorkspaceTypeClusterLister
-
-	globalAPIExportIndexer         cache.Indexer
-	globalAPIResourceSchemaIndexer cache.Indexer
-	globalShardIndexer             cache.Indexer
-	globalWorkspaceTypeIndexer     cache.Indexer
+	localAPIExportLister           apisv1alpha1listers.APIExportClusterLister
+	localAPIResourceSchemaLister   apisv1alpha1listers.APIResourceSchemaClusterLister
+	localShardLister               corev1alpha1listers.ShardClusterLister
+	localWorkspaceTypeLister       tenancyv1alpha1listers.WorkspaceTypeClusterLister
+	localWorkspaceLister           tenancyv1beta1listers.WorkspaceClusterLister
+	localLogicalClusterLister      corev1alpha1listers.LogicalClusterClusterLister
+	localThrottlingExemptionLister tenancyv1alpha1listers.ThrottlingExemptionClusterLister
+
+	globalAPIExportIndexer           cache.Indexer
+	globalAPIResourceSchemaIndexer   cache.Indexer
+	globalShardIndexer               cache.Indexer
+	globalWorkspaceTypeIndexer       cache.Indexer
+	globalWorkspaceIndexer           cache.Indexer
+	globalLogicalClusterIndexer      cache.Indexer
+	globalThrottlingExemptionIndexer cache.Indexer
 }
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	replicationPatches = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "cache_replication_patches_total",
+			Help:           "Number of server-side applies of replicated objects sent to the cache server, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	replicationConflicts = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "cache_replication_conflicts_total",
+			Help:           "Number of server-side applies of replicated objects rejected by the cache server because of a conflict, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	replicationPatchBytes = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "cache_replication_patch_bytes_total",
+			Help:           "Number of bytes of server-side applies of replicated objects sent to the cache server, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the replication metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(replicationPatches)
+		legacyregistry.MustRegister(replicationConflicts)
+		legacyregistry.MustRegister(replicationPatchBytes)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+// recordPatch records a patch of the given size sent to the cache server.
+func recordPatch(gr schema.GroupResource, patchSize int, err error) {
+	resource := gr.String()
+	replicationPatches.WithLabelValues(resource).Inc()
+	if errors.IsConflict(err) {
+		replicationConflicts.WithLabelValues(resource).Inc()
+	}
+	replicationPatchBytes.WithLabelValues(resource).Add(float64(patchSize))
+}
@@ -32,9 +32,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
 )
 
 func (c *controller) reconcile(ctx context.Context, gvrKey string) error {
@@ -87,6 +88,39 @@ func (c *controller) reconcile(ctx context.Context, gvrKey string) error {
 			func(cluster logicalcluster.Name, _, name string) (interface{}, error) {
 				return c.localWorkspaceTypeLister.Cluster(cluster).Get(name)
 			})
+	case tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces").String():
+		return c.reconcileObject(ctx,
+			keyParts[1],
+			tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces"),
+			tenancyv1beta1.SchemeGroupVersion.WithKind("Workspace"),
+			func(gvr schema.GroupVersionResource, cluster logicalcluster.Name, namespace, name string) (interface{}, error) {
+				return retrieveCacheObject(&gvr, c.globalWorkspaceIndexer, c.shardName, cluster, namespace, name)
+			},
+			func(cluster logicalcluster.Name, _, name string) (interface{}, error) {
+				return c.localWorkspaceLister.Cluster(cluster).Get(name)
+			})
+	case corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters").String():
+		return c.reconcileObject(ctx,
+			keyParts[1],
+			corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			corev1alpha1.SchemeGroupVersion.WithKind("LogicalCluster"),
+			func(gvr schema.GroupVersionResource, cluster logicalcluster.Name, namespace, name string) (interface{}, error) {
+				return retrieveCacheObject(&gvr, c.globalLogicalClusterIndexer, c.shardName, cluster, namespace, name)
+			},
+			func(cluster logicalcluster.Name, _, name string) (interface{}, error) {
+				return c.localLogicalClusterLister.Cluster(cluster).Get(name)
+			})
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions").String():
+		return c.reconcileObject(ctx,
+			keyParts[1],
+			tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions"),
+			tenancyv1alpha1.SchemeGroupVersion.WithKind("ThrottlingExemption"),
+			func(gvr schema.GroupVersionResource, cluster logicalcluster.Name, namespace, name string) (interface{}, error) {
+				return retrieveCacheObject(&gvr, c.globalThrottlingExemptionIndexer, c.shardName, cluster, namespace, name)
+			},
+			func(cluster logicalcluster.Name, _, name string) (interface{}, error) {
+				return c.localThrottlingExemptionLister.Cluster(cluster).Get(name)
+			})
 	default:
 		return fmt.Errorf("unsupported resource %v", keyParts[0])
 	}
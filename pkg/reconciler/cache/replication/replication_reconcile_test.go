@@ -18,6 +18,7 @@ package replication
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -32,10 +33,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
 )
 
 var scheme *runtime.Scheme
@@ -191,31 +193,9 @@ func TestReconcileAPIExports(t *testing.T) {
 				if len(localClientActions) != 0 {
 					t.Fatalf("unexpected REST calls were made to the localDynamicClient: %#v", localClientActions)
 				}
-				wasGlobalAPIExportValidated := false
-				for _, action := range cacheClientActions {
-					if action.Matches("update", "apiexports") {
-						updateAction := action.(kcptesting.UpdateAction)
-						if updateAction.GetCluster().String() != "root" {
-							t.Fatalf("wrong cluster = %s was targeted for cacheDynamicClient", updateAction.GetCluster())
-						}
-						updatedUnstructuredAPIExport := updateAction.GetObject().(*unstructured.Unstructured)
-						globalAPIExportFromUnstructured := &apisv1alpha1.APIExport{}
-						if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updatedUnstructuredAPIExport.Object, globalAPIExportFromUnstructured); err != nil {
-							t.Fatalf("failed to convert unstructured to APIExport: %v", err)
-						}
-
-						expectedAPIExport := newAPIExportWithShardAnnotation("foo")
-						expectedAPIExport.Labels["fooLabel"] = "fooLabelVal"
-						if !equality.Semantic.DeepEqual(globalAPIExportFromUnstructured, expectedAPIExport) {
-							t.Errorf("unexpected update to the APIExport:\n%s", cmp.Diff(globalAPIExportFromUnstructured, expectedAPIExport))
-						}
-						wasGlobalAPIExportValidated = true
-						break
-					}
-				}
-				if !wasGlobalAPIExportValidated {
-					t.Errorf("an APIExport on the cache sever wasn't updated")
-				}
+				expectedAPIExport := newAPIExportWithShardAnnotation("foo")
+				expectedAPIExport.Labels["fooLabel"] = "fooLabelVal"
+				validateAppliedAPIExport(t, cacheClientActions, expectedAPIExport)
 			},
 		},
 		{
@@ -236,31 +216,9 @@ func TestReconcileAPIExports(t *testing.T) {
 				if len(localClientActions) != 0 {
 					t.Fatalf("unexpected REST calls were made to the localDynamicClient: %#v", localClientActions)
 				}
-				wasGlobalAPIExportValidated := false
-				for _, action := range cacheClientActions {
-					if action.Matches("update", "apiexports") {
-						updateAction := action.(kcptesting.UpdateAction)
-						if updateAction.GetCluster().String() != "root" {
-							t.Fatalf("wrong cluster = %s was targeted for cacheDynamicClient", updateAction.GetCluster())
-						}
-						updatedUnstructuredAPIExport := updateAction.GetObject().(*unstructured.Unstructured)
-						globalAPIExportFromUnstructured := &apisv1alpha1.APIExport{}
-						if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updatedUnstructuredAPIExport.Object, globalAPIExportFromUnstructured); err != nil {
-							t.Fatalf("failed to convert unstructured to APIExport: %v", err)
-						}
-
-						expectedAPIExport := newAPIExportWithShardAnnotation("foo")
-						expectedAPIExport.Spec.PermissionClaims = []apisv1alpha1.PermissionClaim{{GroupResource: apisv1alpha1.GroupResource{}, IdentityHash: "abc"}}
-						if !equality.Semantic.DeepEqual(globalAPIExportFromUnstructured, expectedAPIExport) {
-							t.Errorf("unexpected update to the APIExport:\n%s", cmp.Diff(globalAPIExportFromUnstructured, expectedAPIExport))
-						}
-						wasGlobalAPIExportValidated = true
-						break
-					}
-				}
-				if !wasGlobalAPIExportValidated {
-					t.Errorf("an APIExport on the cache sever wasn't updated")
-				}
+				expectedAPIExport := newAPIExportWithShardAnnotation("foo")
+				expectedAPIExport.Spec.PermissionClaims = []apisv1alpha1.PermissionClaim{{GroupResource: apisv1alpha1.GroupResource{}, IdentityHash: "abc"}}
+				validateAppliedAPIExport(t, cacheClientActions, expectedAPIExport)
 			},
 		},
 		{
@@ -282,32 +240,10 @@ func TestReconcileAPIExports(t *testing.T) {
 				if len(localClientActions) != 0 {
 					t.Fatalf("unexpected REST calls were made to the localDynamicClient: %#v", localClientActions)
 				}
-				wasGlobalAPIExportValidated := false
-				for _, action := range cacheClientActions {
-					if action.Matches("update", "apiexports") {
-						updateAction := action.(kcptesting.UpdateAction)
-						if updateAction.GetCluster().String() != "root" {
-							t.Fatalf("wrong cluster = %s was targeted for cacheDynamicClient", updateAction.GetCluster())
-						}
-						updatedUnstructuredAPIExport := updateAction.GetObject().(*unstructured.Unstructured)
-						globalAPIExportFromUnstructured := &apisv1alpha1.APIExport{}
-						if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updatedUnstructuredAPIExport.Object, globalAPIExportFromUnstructured); err != nil {
-							t.Fatalf("failed to convert unstructured to APIExport: %v", err)
-						}
-
-						expectedAPIExport := newAPIExportWithShardAnnotation("foo")
-						//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
-						expectedAPIExport.Status.VirtualWorkspaces = []apisv1alpha1.VirtualWorkspace{{URL: "https://acme.dev"}}
-						if !equality.Semantic.DeepEqual(globalAPIExportFromUnstructured, expectedAPIExport) {
-							t.Errorf("unexpected update to the APIExport:\n%s", cmp.Diff(globalAPIExportFromUnstructured, expectedAPIExport))
-						}
-						wasGlobalAPIExportValidated = true
-						break
-					}
-				}
-				if !wasGlobalAPIExportValidated {
-					t.Errorf("an APIExport on the cache sever wasn't updated")
-				}
+				expectedAPIExport := newAPIExportWithShardAnnotation("foo")
+				//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+				expectedAPIExport.Status.VirtualWorkspaces = []apisv1alpha1.VirtualWorkspace{{URL: "https://acme.dev"}}
+				validateAppliedAPIExport(t, cacheClientActions, expectedAPIExport)
 			},
 		},
 	}
@@ -333,6 +269,10 @@ func TestReconcileAPIExports(t *testing.T) {
 				}
 				return []runtime.Object{}
 			}()...)
+			// the fake object tracker does not implement server-side apply
+			fakeCacheDynamicClient.PrependReactor("patch", "*", func(action kcptesting.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
 			target.dynamicCacheClient = fakeCacheDynamicClient
 			fakeLocalDynamicClient := kcpfakedynamic.NewSimpleDynamicClient(scheme)
 			target.dynamicLocalClient = fakeLocalDynamicClient
@@ -346,6 +286,38 @@ func TestReconcileAPIExports(t *testing.T) {
 	}
 }
 
+// validateAppliedAPIExport checks that the APIExport on the cache server was server-side applied
+// instead of updated, and that the expected APIExport was applied.
+func validateAppliedAPIExport(t *testing.T, cacheClientActions []kcptesting.Action, expectedAPIExport *apisv1alpha1.APIExport) {
+	t.Helper()
+
+	for _, action := range cacheClientActions {
+		if action.Matches("update", "apiexports") {
+			t.Fatalf("the APIExport on the cache server must be applied, not updated")
+		}
+		if !action.Matches("patch", "apiexports") {
+			continue
+		}
+		patchAction := action.(kcptesting.PatchAction)
+		if patchAction.GetCluster().String() != "root" {
+			t.Fatalf("wrong cluster = %s was targeted for cacheDynamicClient", patchAction.GetCluster())
+		}
+		if patchAction.GetPatchType() != types.ApplyPatchType {
+			t.Fatalf("unexpected patch type = %s, expected = %s", patchAction.GetPatchType(), types.ApplyPatchType)
+		}
+
+		appliedAPIExport := &apisv1alpha1.APIExport{}
+		if err := json.Unmarshal(patchAction.GetPatch(), appliedAPIExport); err != nil {
+			t.Fatal(err)
+		}
+		if !equality.Semantic.DeepEqual(appliedAPIExport, expectedAPIExport) {
+			t.Errorf("unexpected APIExport %s applied:\n%s", patchAction.GetPatch(), cmp.Diff(appliedAPIExport, expectedAPIExport))
+		}
+		return
+	}
+	t.Errorf("an APIExport on the cache sever wasn't applied")
+}
+
 func newAPIExport(name string) *apisv1alpha1.APIExport {
 	return &apisv1alpha1.APIExport{
 		TypeMeta: metav1.TypeMeta{
@@ -18,6 +18,7 @@ package replication
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -27,7 +28,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/utils/pointer"
 )
 
 // reconcileUnstructuredObjects makes sure that the given cachedObject of the given GVR under the given key from the local shard is replicated to the cache server.
@@ -44,6 +47,7 @@ import (
 //     - the localObject's metadata doesn't match the cacheObject
 //     - the localObject's spec doesn't match the cacheObject
 //     - the localObject's status doesn't match the cacheObject
+//     the object is server-side applied to the cache server, see applyCacheObject
 func (c *controller) reconcileUnstructuredObjects(ctx context.Context, cluster logicalcluster.Name, gvr *schema.GroupVersionResource, cacheObject *unstructured.Unstructured, localObject *unstructured.Unstructured) error {
 	if localObject == nil {
 		return c.handleObjectDeletion(ctx, cluster, gvr, cacheObject)
@@ -66,6 +70,7 @@ func (c *controller) reconcileUnstructuredObjects(ctx context.Context, cluster l
 		return err
 	}
 
+	originalCacheObject := cacheObject.DeepCopy()
 	metaChanged, err := ensureMeta(cacheObject, localObject)
 	if err != nil {
 		return err
@@ -78,11 +83,32 @@ func (c *controller) reconcileUnstructuredObjects(ctx context.Context, cluster l
 		return nil
 	}
 
-	if metaChanged || remainingChanged {
-		_, err := c.dynamicCacheClient.Cluster(cluster.Path()).Resource(*gvr).Namespace(cacheObject.GetNamespace()).Update(ctx, cacheObject, metav1.UpdateOptions{})
-		return err
+	return c.applyCacheObject(ctx, cluster, gvr, originalCacheObject, cacheObject)
+}
+
+// applyCacheObject server-side applies the new cache object to the cache server with the field
+// manager of the controller. The cache server merges lists such as status.conditions by their keys,
+// instead of replacing them like a merge patch would. The resourceVersion of the old cache object
+// is sent as a precondition, such that concurrent changes are rejected as conflicts and the key is
+// retried.
+func (c *controller) applyCacheObject(ctx context.Context, cluster logicalcluster.Name, gvr *schema.GroupVersionResource, oldCacheObject, newCacheObject *unstructured.Unstructured) error {
+	obj := newCacheObject.DeepCopy()
+	// fields owned by the cache server must not be applied
+	obj.SetManagedFields(nil)
+	obj.SetUID("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetGeneration(0)
+	obj.SetSelfLink("")
+	obj.SetResourceVersion(oldCacheObject.GetResourceVersion())
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s|%s/%s: %w", gvr, cluster, obj.GetNamespace(), obj.GetName(), err)
 	}
-	return nil
+
+	_, err = c.dynamicCacheClient.Cluster(cluster.Path()).Resource(*gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: ControllerName, Force: pointer.Bool(true)})
+	recordPatch(gvr.GroupResource(), len(data), err)
+	return err
 }
 
 func (c *controller) handleObjectDeletion(ctx context.Context, cluster logicalcluster.Name, gvr *schema.GroupVersionResource, cacheObject *unstructured.Unstructured) error {
@@ -22,7 +22,7 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
 type urlReconciler struct {
@@ -44,12 +44,14 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 const (
@@ -69,11 +71,13 @@ func NewController(
 	metadataClusterClient kcpmetadata.ClusterInterface,
 	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
 	discoverResourcesFn func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error),
+	releasePath func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error,
+	controllerSwitch *controllerswitch.Switch,
 ) *Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &Controller{
-		queue:                     queue,
+		queue:                     controllerSwitch.Queue(queue),
 		kubeClusterClient:         kubeClusterClient,
 		kcpClusterClient:          kcpClusterClient,
 		logicalClusterAdminConfig: logicalClusterAdminConfig,
@@ -81,6 +85,7 @@ func NewController(
 		metadataClusterClient:     metadataClusterClient,
 		logicalClusterLister:      logicalClusterInformer.Lister(),
 		deleter:                   deletion.NewWorkspacedResourcesDeleter(metadataClusterClient, discoverResourcesFn),
+		releasePath:               releasePath,
 	}
 
 	logicalClusterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -116,6 +121,10 @@ type Controller struct {
 	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
 
 	deleter deletion.WorkspaceResourcesDeleterInterface
+
+	// releasePath releases the claim of the path of a logical cluster, such that it can be
+	// reused by a new logical cluster on any shard.
+	releasePath func(ctx context.Context, path logicalcluster.Path, clusterName logicalcluster.Name) error
 }
 
 func (c *Controller) enqueue(obj interface{}) {
@@ -346,8 +355,19 @@ func (c *Controller) finalizeWorkspace(ctx context.Context, ws *corev1alpha1.Log
 			}
 
 			logger.V(2).Info("removing finalizer from LogicalCluster")
-			_, err := c.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterName.Path()).Update(ctx, ws, metav1.UpdateOptions{})
-			return err
+			if _, err := c.kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterName.Path()).Update(ctx, ws, metav1.UpdateOptions{}); err != nil {
+				return err
+			}
+
+			// the logical cluster is gone, failing to release its path claim is not retried. The claim
+			// is taken over as stale by the next logical cluster with the same path.
+			if path := ws.Annotations[core.LogicalClusterPathAnnotationKey]; path != "" {
+				logger.V(2).Info("releasing path", "path", path)
+				if err := c.releasePath(ctx, logicalcluster.NewPath(path), clusterName); err != nil {
+					logger.Error(err, "failed to release path", "path", path)
+				}
+			}
+			return nil
 		}
 	}
 
@@ -36,12 +36,12 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 const (
@@ -59,6 +59,7 @@ func NewController(
 		kcpClient:    rootKcpClient,
 		shardIndexer: shardInformer.Informer().GetIndexer(),
 		shardLister:  shardInformer.Lister(),
+		now:          time.Now,
 	}
 
 	shardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -69,8 +70,8 @@ func NewController(
 	return c, nil
 }
 
-// Controller watches WorkspaceShards and Secrets in order to make sure every Shard
-// has its URL exposed when a valid kubeconfig is connected to it.
+// Controller watches Shards in order to maintain their Live condition from the lease the
+// shards renew in their status.
 type Controller struct {
 	queue workqueue.RateLimitingInterface
 
@@ -78,6 +79,8 @@ type Controller struct {
 
 	shardIndexer cache.Indexer
 	shardLister  corev1alpha1listers.ShardClusterLister
+
+	now func() time.Time
 }
 
 func (c *Controller) enqueue(obj interface{}) {
@@ -162,9 +165,13 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	logger = logging.WithObject(logger, obj)
 	ctx = klog.NewContext(ctx, logger)
 
-	if err := c.reconcile(ctx, obj); err != nil {
+	requeueAfter, err := c.reconcile(ctx, obj)
+	if err != nil {
 		return err
 	}
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
 
 	// If the object being reconciled changed as a result, update it.
 	if !equality.Semantic.DeepEqual(previous.Status, obj.Status) {
@@ -198,6 +205,7 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	return nil
 }
 
-func (c *Controller) reconcile(ctx context.Context, workspaceShard *corev1alpha1.Shard) error {
-	return nil
+// reconcile returns the duration after which the shard must be reconciled again, or 0.
+func (c *Controller) reconcile(ctx context.Context, shard *corev1alpha1.Shard) (time.Duration, error) {
+	return reconcileLiveness(shard, c.now()), nil
 }
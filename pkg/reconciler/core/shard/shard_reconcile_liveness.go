@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"time"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// reconcileLiveness sets the ShardLive condition from the lease of the shard. It returns the
+// duration until the lease expires, i.e. after which the shard must be reconciled again, or 0
+// if the lease is expired or missing.
+func reconcileLiveness(shard *corev1alpha1.Shard, now time.Time) time.Duration {
+	lease := shard.Status.Lease
+	if lease == nil {
+		conditions.Delete(shard, corev1alpha1.ShardLive)
+		return 0
+	}
+
+	expiry := lease.RenewTime.Add(time.Duration(lease.LeaseDurationSeconds) * time.Second)
+	if remaining := expiry.Sub(now); remaining > 0 {
+		conditions.MarkTrue(shard, corev1alpha1.ShardLive)
+		return remaining
+	}
+	conditions.MarkFalse(
+		shard,
+		corev1alpha1.ShardLive,
+		corev1alpha1.ShardLeaseExpiredReason,
+		conditionsv1alpha1.ConditionSeverityError,
+		"Shard %q has not renewed its lease since %s",
+		shard.Name,
+		lease.RenewTime.UTC().Format(time.RFC3339),
+	)
+	return 0
+}
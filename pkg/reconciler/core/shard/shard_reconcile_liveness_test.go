@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileLiveness(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		lease            *corev1alpha1.ShardLease
+		existing         *conditionsv1alpha1.Condition
+		wantCondition    *conditionsv1alpha1.Condition
+		wantRequeueAfter time.Duration
+	}{
+		"no lease": {},
+		"lease removed": {
+			existing: conditions.TrueCondition(corev1alpha1.ShardLive),
+		},
+		"lease renewed": {
+			lease:            &corev1alpha1.ShardLease{RenewTime: metav1.NewTime(now.Add(-10 * time.Second)), LeaseDurationSeconds: 40},
+			wantCondition:    conditions.TrueCondition(corev1alpha1.ShardLive),
+			wantRequeueAfter: 30 * time.Second,
+		},
+		"lease expired": {
+			lease:         &corev1alpha1.ShardLease{RenewTime: metav1.NewTime(now.Add(-time.Minute)), LeaseDurationSeconds: 40},
+			existing:      conditions.TrueCondition(corev1alpha1.ShardLive),
+			wantCondition: conditions.FalseCondition(corev1alpha1.ShardLive, corev1alpha1.ShardLeaseExpiredReason, conditionsv1alpha1.ConditionSeverityError, `Shard "beta" has not renewed its lease since 2023-05-01T11:59:00Z`),
+		},
+		"lease renewed after expiry": {
+			lease:            &corev1alpha1.ShardLease{RenewTime: metav1.NewTime(now), LeaseDurationSeconds: 40},
+			existing:         conditions.FalseCondition(corev1alpha1.ShardLive, corev1alpha1.ShardLeaseExpiredReason, conditionsv1alpha1.ConditionSeverityError, ""),
+			wantCondition:    conditions.TrueCondition(corev1alpha1.ShardLive),
+			wantRequeueAfter: 40 * time.Second,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			shard := &corev1alpha1.Shard{
+				ObjectMeta: metav1.ObjectMeta{Name: "beta"},
+				Status:     corev1alpha1.ShardStatus{Lease: tt.lease},
+			}
+			if tt.existing != nil {
+				conditions.Set(shard, tt.existing)
+			}
+
+			requeueAfter := reconcileLiveness(shard, now)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+
+			got := conditions.Get(shard, corev1alpha1.ShardLive)
+			if tt.wantCondition == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.Equal(t, tt.wantCondition.Status, got.Status)
+			require.Equal(t, tt.wantCondition.Reason, got.Reason)
+			require.Equal(t, tt.wantCondition.Message, got.Message)
+		})
+	}
+}
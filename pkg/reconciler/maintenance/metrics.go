@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	taskRuns = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "maintenance_task_runs_total",
+			Help:           "Number of runs of maintenance tasks, by task and result.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"task", "result"},
+	)
+
+	taskDuration = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "maintenance_task_duration_seconds",
+			Help:           "Duration of the runs of maintenance tasks, by task.",
+			Buckets:        []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"task"},
+	)
+
+	taskLastSuccess = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "maintenance_task_last_success_timestamp_seconds",
+			Help:           "Unix time of the end of the last successful run of maintenance tasks on this replica, by task.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"task"},
+	)
+
+	leader = compbasemetrics.NewGauge(
+		&compbasemetrics.GaugeOpts{
+			Name:           "maintenance_leader",
+			Help:           "1 if this replica holds the maintenance lease of its shard and runs the maintenance tasks, 0 otherwise.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the maintenance scheduler metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(taskRuns)
+		legacyregistry.MustRegister(taskDuration)
+		legacyregistry.MustRegister(taskLastSuccess)
+		legacyregistry.MustRegister(leader)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
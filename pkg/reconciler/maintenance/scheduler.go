@@ -0,0 +1,367 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenance runs scheduled maintenance tasks of a shard, like usage rollups or
+// consistency sweeps. Tasks are registered by controllers during startup and run periodically
+// by the replica of the shard holding the maintenance Lease, with jitter. The time of the last
+// run of every task is persisted, such that restarts and leader changes don't reset the schedule.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// SchedulerName is the name of the maintenance scheduler.
+	SchedulerName = "kcp-maintenance-scheduler"
+
+	// Namespace is the namespace in the shard-local system:admin logical cluster holding
+	// the Lease of the scheduler and the ConfigMap with the last runs of the tasks.
+	Namespace             = "kcp-system"
+	LeaseName             = "kcp-maintenance"
+	LastRunsConfigMapName = "maintenance-last-runs"
+
+	// DefaultJitterFactor is the jitter factor of tasks that don't specify one.
+	DefaultJitterFactor = 0.1
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// ClusterName is the logical cluster holding the Lease and the last runs of the tasks.
+var ClusterName = logicalcluster.Name("system:admin")
+
+// Task is a maintenance task run periodically by the leader of a shard.
+type Task struct {
+	// Name identifies the task in the persisted last runs and in metrics. It must be a
+	// valid ConfigMap key.
+	Name string
+	// Interval is the time between the starts of two runs.
+	Interval time.Duration
+	// JitterFactor is the maximal fraction of the interval randomly added to it, such that
+	// the tasks of many shards don't run in lockstep. It defaults to DefaultJitterFactor.
+	JitterFactor float64
+	// Timeout bounds a single run. It defaults to the interval.
+	Timeout time.Duration
+	// Run runs the task once. A failed run is not retried before the next interval.
+	Run func(ctx context.Context) error
+}
+
+// Scheduler runs the registered maintenance tasks while holding the maintenance Lease of the shard.
+type Scheduler struct {
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+	identity          string
+
+	lock    sync.Mutex
+	tasks   map[string]Task
+	started bool
+
+	// storeLock serializes the updates of the last runs ConfigMap.
+	storeLock sync.Mutex
+
+	getLastRuns func(ctx context.Context) (map[string]time.Time, error)
+	setLastRun  func(ctx context.Context, name string, t time.Time) error
+	now         func() time.Time
+	random      func() float64
+}
+
+// NewScheduler returns a scheduler electing its leader among the replicas of the shard with the given name
+// through the given client.
+func NewScheduler(kubeClusterClient kcpkubernetesclientset.ClusterInterface, shardName string) *Scheduler {
+	hostname, _ := os.Hostname()
+	s := &Scheduler{
+		kubeClusterClient: kubeClusterClient,
+		identity:          fmt.Sprintf("%s_%s_%s", shardName, hostname, uuid.NewUUID()),
+		tasks:             map[string]Task{},
+		now:               time.Now,
+		random:            rand.Float64,
+	}
+	s.getLastRuns = s.loadLastRuns
+	s.setLastRun = s.storeLastRun
+	return s
+}
+
+// Register adds a task. Tasks must be registered before the scheduler is started.
+func (s *Scheduler) Register(task Task) error {
+	if errs := validation.IsConfigMapKey(task.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid maintenance task name %q: %s", task.Name, strings.Join(errs, ", "))
+	}
+	if task.Interval <= 0 {
+		return fmt.Errorf("maintenance task %q must have a positive interval", task.Name)
+	}
+	if task.Run == nil {
+		return fmt.Errorf("maintenance task %q has no run function", task.Name)
+	}
+	if task.JitterFactor <= 0 {
+		task.JitterFactor = DefaultJitterFactor
+	}
+	if task.Timeout <= 0 {
+		task.Timeout = task.Interval
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.started {
+		return fmt.Errorf("maintenance task %q registered after the scheduler started", task.Name)
+	}
+	if _, found := s.tasks[task.Name]; found {
+		return fmt.Errorf("maintenance task %q is already registered", task.Name)
+	}
+	s.tasks[task.Name] = task
+	return nil
+}
+
+// registered returns the registered tasks sorted by name, and stops further registrations.
+func (s *Scheduler) registered() []Task {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.started = true
+	tasks := make([]Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks
+}
+
+// Start campaigns for the maintenance Lease of the shard until the context is done, and runs the
+// registered tasks while holding it. It returns immediately if no tasks are registered.
+func (s *Scheduler) Start(ctx context.Context) {
+	defer utilruntime.HandleCrash()
+
+	logger := klog.FromContext(ctx).WithValues("component", SchedulerName, "identity", s.identity)
+	ctx = klog.NewContext(ctx, logger)
+
+	tasks := s.registered()
+	if len(tasks) == 0 {
+		logger.V(2).Info("no maintenance tasks registered")
+		return
+	}
+
+	logger.Info("Starting maintenance scheduler", "tasks", len(tasks))
+	defer logger.Info("Shutting down maintenance scheduler")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.ensureNamespace(ctx); err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta:  metav1.ObjectMeta{Namespace: Namespace, Name: LeaseName},
+				Client:     s.kubeClusterClient.Cluster(ClusterName.Path()).CoordinationV1(),
+				LockConfig: resourcelock.ResourceLockConfig{Identity: s.identity},
+			},
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            SchedulerName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					logger.Info("acquired maintenance lease")
+					leader.Set(1)
+					s.runTasks(ctx, tasks)
+				},
+				OnStoppedLeading: func() {
+					logger.Info("lost maintenance lease")
+					leader.Set(0)
+				},
+			},
+		})
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		elector.Run(ctx)
+	}, retryPeriod)
+}
+
+// runTasks runs the tasks on their schedule until the context is done, i.e. until the lease is lost.
+func (s *Scheduler) runTasks(ctx context.Context, tasks []Task) {
+	logger := klog.FromContext(ctx)
+
+	var lastRuns map[string]time.Time
+	if err := wait.PollImmediateInfiniteWithContext(ctx, retryPeriod, func(ctx context.Context) (bool, error) {
+		var err error
+		if lastRuns, err = s.getLastRuns(ctx); err != nil {
+			logger.Error(err, "failed to load last runs of maintenance tasks, retrying")
+			return false, nil
+		}
+		return true, nil
+	}); err != nil {
+		return // lease lost
+	}
+
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task Task) {
+			defer wg.Done()
+			s.runTask(ctx, task, lastRuns[task.Name])
+		}(task)
+	}
+	wg.Wait()
+}
+
+// runTask runs the task on its schedule until the context is done.
+func (s *Scheduler) runTask(ctx context.Context, task Task, lastRun time.Time) {
+	for {
+		next := nextRun(task, lastRun, s.now(), s.random())
+		klog.FromContext(ctx).V(4).Info("scheduling maintenance task", "task", task.Name, "next", next)
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		lastRun = s.run(ctx, task)
+	}
+}
+
+// nextRun returns the time of the next run of the task, given its last run and a random number in [0,1).
+// Overdue tasks, and tasks that never ran, are run within the jitter window from now, such that replicas
+// and shards starting together don't run them at the same time.
+func nextRun(task Task, lastRun, now time.Time, random float64) time.Time {
+	jitter := time.Duration(random * task.JitterFactor * float64(task.Interval))
+	if next := lastRun.Add(task.Interval + jitter); !lastRun.IsZero() && next.After(now) {
+		return next
+	}
+	return now.Add(jitter)
+}
+
+// run runs the task once and records the run. It returns the start of the run.
+func (s *Scheduler) run(ctx context.Context, task Task) time.Time {
+	logger := klog.FromContext(ctx).WithValues("task", task.Name)
+	logger.V(2).Info("running maintenance task")
+
+	start := s.now()
+	runCtx, cancel := context.WithTimeout(klog.NewContext(ctx, logger), task.Timeout)
+	err := task.Run(runCtx)
+	cancel()
+	end := s.now()
+
+	taskDuration.WithLabelValues(task.Name).Observe(end.Sub(start).Seconds())
+	if err != nil {
+		taskRuns.WithLabelValues(task.Name, "error").Inc()
+		logger.Error(err, "maintenance task failed")
+	} else {
+		taskRuns.WithLabelValues(task.Name, "success").Inc()
+		taskLastSuccess.WithLabelValues(task.Name).Set(float64(end.Unix()))
+		logger.V(2).Info("finished maintenance task", "duration", end.Sub(start))
+	}
+
+	if ctx.Err() != nil {
+		// the lease was lost during the run. Let the next leader run the task again.
+		return start
+	}
+	if err := s.setLastRun(ctx, task.Name, start); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to record last run of maintenance task %s: %w", task.Name, err))
+	}
+	return start
+}
+
+func (s *Scheduler) ensureNamespace(ctx context.Context) error {
+	_, err := s.kubeClusterClient.Cluster(ClusterName.Path()).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: Namespace}}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s|%s: %w", ClusterName, Namespace, err)
+	}
+	return nil
+}
+
+func (s *Scheduler) loadLastRuns(ctx context.Context) (map[string]time.Time, error) {
+	logger := klog.FromContext(ctx)
+
+	cm, err := s.kubeClusterClient.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace).Get(ctx, LastRunsConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[string]time.Time{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get last runs %s|%s/%s: %w", ClusterName, Namespace, LastRunsConfigMapName, err)
+	}
+
+	lastRuns := make(map[string]time.Time, len(cm.Data))
+	for name, value := range cm.Data {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			// a missing last run only runs the task early. Don't block the scheduler on corrupt data.
+			logger.Error(err, "ignoring invalid last run of maintenance task", "task", name, "value", value)
+			continue
+		}
+		lastRuns[name] = t
+	}
+	return lastRuns, nil
+}
+
+func (s *Scheduler) storeLastRun(ctx context.Context, name string, t time.Time) error {
+	s.storeLock.Lock()
+	defer s.storeLock.Unlock()
+
+	client := s.kubeClusterClient.Cluster(ClusterName.Path()).CoreV1().ConfigMaps(Namespace)
+	value := t.UTC().Format(time.RFC3339)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := client.Get(ctx, LastRunsConfigMapName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: LastRunsConfigMapName},
+				Data:       map[string]string{name: value},
+			}
+			_, err = client.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// retry as an update
+				return apierrors.NewConflict(corev1.Resource("configmaps"), LastRunsConfigMapName, err)
+			}
+			return err
+		} else if err != nil {
+			return err
+		}
+
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[name] = value
+		_, err = client.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	s := &Scheduler{tasks: map[string]Task{}}
+
+	require.NoError(t, s.Register(Task{Name: "usage-rollup", Interval: time.Hour, Run: run}))
+	require.Equal(t, DefaultJitterFactor, s.tasks["usage-rollup"].JitterFactor)
+	require.Equal(t, time.Hour, s.tasks["usage-rollup"].Timeout)
+
+	require.Error(t, s.Register(Task{Name: "usage-rollup", Interval: time.Hour, Run: run}), "duplicate name")
+	require.Error(t, s.Register(Task{Name: "usage rollup", Interval: time.Hour, Run: run}), "invalid name")
+	require.Error(t, s.Register(Task{Name: "sweep", Run: run}), "no interval")
+	require.Error(t, s.Register(Task{Name: "sweep", Interval: time.Hour}), "no run function")
+
+	require.Len(t, s.registered(), 1)
+	require.Error(t, s.Register(Task{Name: "sweep", Interval: time.Hour, Run: run}), "registered after start")
+}
+
+func TestNextRun(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	task := Task{Name: "sweep", Interval: time.Hour, JitterFactor: 0.1}
+
+	tests := map[string]struct {
+		lastRun time.Time
+		random  float64
+		want    time.Time
+	}{
+		"never ran":           {random: 0.5, want: now.Add(3 * time.Minute)},
+		"due later":           {lastRun: now.Add(-30 * time.Minute), want: now.Add(30 * time.Minute)},
+		"due later, jittered": {lastRun: now.Add(-30 * time.Minute), random: 0.5, want: now.Add(33 * time.Minute)},
+		"overdue":             {lastRun: now.Add(-2 * time.Hour), random: 0.5, want: now.Add(3 * time.Minute)},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, nextRun(task, tc.lastRun, now, tc.random))
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		err          error
+		cancelDuring bool
+		wantRecorded bool
+	}{
+		"success":             {wantRecorded: true},
+		"failure":             {err: errors.New("boom"), wantRecorded: true},
+		"lease lost":          {cancelDuring: true},
+		"lease lost, failure": {err: errors.New("boom"), cancelDuring: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			recorded := map[string]time.Time{}
+			s := &Scheduler{
+				setLastRun: func(ctx context.Context, name string, t time.Time) error {
+					recorded[name] = t
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+			task := Task{Name: "sweep", Interval: time.Hour, Timeout: time.Minute, Run: func(ctx context.Context) error {
+				_, hasDeadline := ctx.Deadline()
+				require.True(t, hasDeadline)
+				if tc.cancelDuring {
+					cancel()
+				}
+				return tc.err
+			}}
+
+			require.Equal(t, now, s.run(ctx, task))
+			if tc.wantRecorded {
+				require.Equal(t, map[string]time.Time{"sweep": now}, recorded)
+			} else {
+				require.Empty(t, recorded)
+			}
+		})
+	}
+}
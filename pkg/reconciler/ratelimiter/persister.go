@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PersisterName is the name of the rate limiter state persister.
+	PersisterName = "kcp-rate-limiter-state"
+
+	// StateNamespace is the namespace in the shard-local system:admin logical cluster
+	// holding the state of the persistent rate limiters, one ConfigMap per controller
+	// named with StateConfigMapPrefix followed by the controller name.
+	StateNamespace       = "kcp-system"
+	StateConfigMapPrefix = "rate-limiter-state-"
+
+	// stateKey is the data key of the state in the ConfigMaps.
+	stateKey = "state"
+
+	// maxPersistedItems bounds the items saved per controller, such that the ConfigMap
+	// stays well below the object size limit of 1MiB even with long keys.
+	maxPersistedItems = 2000
+
+	saveInterval = 30 * time.Second
+)
+
+// StateClusterName is the logical cluster holding the rate limiter state ConfigMap.
+var StateClusterName = logicalcluster.Name("system:admin")
+
+// Persister restores the state of the registered persistent rate limiters on startup and
+// saves it periodically and on shutdown.
+type Persister struct {
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+}
+
+// NewPersister returns a Persister storing the rate limiter state through the given client.
+func NewPersister(kubeClusterClient kcpkubernetesclientset.ClusterInterface) *Persister {
+	return &Persister{
+		kubeClusterClient: kubeClusterClient,
+	}
+}
+
+// Restore restores the state of all registered rate limiters, retrying until it succeeds or
+// the context is done. The queues hold back added items until then.
+func (p *Persister) Restore(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithValues("component", PersisterName)
+	ctx = klog.NewContext(ctx, logger)
+
+	return wait.PollImmediateInfiniteWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		if err := p.restore(ctx); err != nil {
+			logger.Error(err, "failed to restore rate limiter state, retrying")
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+// Start saves the rate limiter state every 30 seconds until the context is done, followed
+// by a final save. Restore must have been called before.
+func (p *Persister) Start(ctx context.Context) {
+	defer utilruntime.HandleCrash()
+
+	logger := klog.FromContext(ctx).WithValues("component", PersisterName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting rate limiter state persister")
+	defer logger.Info("Shutting down rate limiter state persister")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.save(ctx); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}, saveInterval)
+
+	saveCtx, cancel := context.WithTimeout(klog.NewContext(context.Background(), logger), 5*time.Second)
+	defer cancel()
+	if err := p.save(saveCtx); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+func (p *Persister) restore(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	client := p.kubeClusterClient.Cluster(StateClusterName.Path()).CoreV1().ConfigMaps(StateNamespace)
+
+	names, queues := registered()
+	states := make(map[string]State, len(names))
+	for _, name := range names {
+		cm, err := client.Get(ctx, StateConfigMapPrefix+name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var state State
+		if err := json.Unmarshal([]byte(cm.Data[stateKey]), &state); err != nil {
+			// the state is a cache only. Don't block startup on corrupt data.
+			logger.Error(err, "ignoring invalid rate limiter state", "controller", name)
+			continue
+		}
+		states[name] = state
+	}
+
+	// only release the queues once all state is read, such that a retry doesn't find
+	// queues that already started processing.
+	for _, name := range names {
+		queues[name].restore(states[name])
+		logger.V(2).Info("restored rate limiter state", "controller", name, "items", len(states[name]))
+	}
+
+	return nil
+}
+
+func (p *Persister) save(ctx context.Context) error {
+	names, queues := registered()
+	client := p.kubeClusterClient.Cluster(StateClusterName.Path()).CoreV1()
+
+	var errs []error
+	for _, name := range names {
+		bs, err := json.Marshal(queues[name].limiter.State(maxPersistedItems))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to marshal rate limiter state of %s: %w", name, err))
+			continue
+		}
+		if err := p.saveConfigMap(ctx, client, StateConfigMapPrefix+name, string(bs)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (p *Persister) saveConfigMap(ctx context.Context, client typedcorev1.CoreV1Interface, name, data string) error {
+	cm, err := client.ConfigMaps(StateNamespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := client.Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: StateNamespace}}, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s|%s: %w", StateClusterName, StateNamespace, err)
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: StateNamespace,
+				Name:      name,
+			},
+			Data: map[string]string{stateKey: data},
+		}
+		if _, err := client.ConfigMaps(StateNamespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create rate limiter state %s|%s/%s: %w", StateClusterName, StateNamespace, name, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get rate limiter state %s|%s/%s: %w", StateClusterName, StateNamespace, name, err)
+	}
+
+	if cm.Data[stateKey] == data {
+		return nil
+	}
+	cm = cm.DeepCopy()
+	cm.Data = map[string]string{stateKey: data}
+	if _, err := client.ConfigMaps(StateNamespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update rate limiter state %s|%s/%s: %w", StateClusterName, StateNamespace, name, err)
+	}
+	return nil
+}
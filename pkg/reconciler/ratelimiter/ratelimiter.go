@@ -0,0 +1,281 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+)
+
+const (
+	// baseDelay and maxDelay match workqueue.DefaultControllerRateLimiter.
+	baseDelay = 5 * time.Millisecond
+	maxDelay  = 1000 * time.Second
+)
+
+// ItemState is the persisted per-item state of a PersistentRateLimiter.
+type ItemState struct {
+	// Failures is the number of consecutive failures of the item.
+	Failures int `json:"f"`
+	// LastAttempt is the unix time in seconds of the last failure of the item.
+	LastAttempt int64 `json:"t"`
+}
+
+// State maps queue keys to their rate limiter state.
+type State map[string]ItemState
+
+// PersistentRateLimiter is an exponential per-item failure rate limiter like the one of
+// workqueue.DefaultControllerRateLimiter, whose per-item state can be saved and restored
+// across process restarts. Only string items are persisted.
+type PersistentRateLimiter struct {
+	// defaults provides the overall bucket limit. Its own per-item backoff is lower or
+	// equal to ours, as it starts from zero on process start.
+	defaults workqueue.RateLimiter
+
+	lock     sync.Mutex
+	failures map[interface{}]ItemState
+	// notBefore holds the end of the restored backoff of items that have not
+	// been retried since the restore.
+	notBefore map[interface{}]time.Time
+	now       func() time.Time
+}
+
+var _ workqueue.RateLimiter = &PersistentRateLimiter{}
+
+var (
+	registryLock sync.Mutex
+	registry     = map[string]*persistentQueue{}
+)
+
+// NewControllerQueue returns a named rate limiting queue for the controller. If the
+// KCPPersistentRateLimiter feature gate is enabled, its rate limiter is a PersistentRateLimiter
+// registered under the controller name, whose state is saved and restored by a Persister.
+// Items added before the state is restored are held back until then, and items added
+// with a restored backoff are delayed by the rest of it. Otherwise, the queue uses
+// workqueue.DefaultControllerRateLimiter.
+func NewControllerQueue(controllerName string) workqueue.RateLimitingInterface {
+	if !kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.PersistentRateLimiter) {
+		return workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), controllerName)
+	}
+
+	r := NewPersistentRateLimiter()
+	q := &persistentQueue{
+		RateLimitingInterface: workqueue.NewNamedRateLimitingQueue(r, controllerName),
+		limiter:               r,
+		held:                  map[interface{}]struct{}{},
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[controllerName] = q
+
+	return q
+}
+
+// NewPersistentRateLimiter returns a PersistentRateLimiter with the delays and the overall
+// bucket of workqueue.DefaultControllerRateLimiter.
+func NewPersistentRateLimiter() *PersistentRateLimiter {
+	return &PersistentRateLimiter{
+		defaults:  workqueue.DefaultControllerRateLimiter(),
+		failures:  map[interface{}]ItemState{},
+		notBefore: map[interface{}]time.Time{},
+		now:       time.Now,
+	}
+}
+
+// registered returns the queues of the registered rate limiters sorted by controller name.
+func registered() ([]string, map[string]*persistentQueue) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	names := make([]string, 0, len(registry))
+	queues := make(map[string]*persistentQueue, len(registry))
+	for name, q := range registry {
+		names = append(names, name)
+		queues[name] = q
+	}
+	sort.Strings(names)
+
+	return names, queues
+}
+
+// persistentQueue is a rate limiting queue which holds back added items until the state
+// of its PersistentRateLimiter is restored, and delays items by their restored backoff.
+// Without this, the informers would enqueue all keys right after a restart, and the
+// restored failure counts would only apply after the next failure.
+type persistentQueue struct {
+	workqueue.RateLimitingInterface
+	limiter *PersistentRateLimiter
+
+	lock     sync.Mutex
+	restored bool
+	held     map[interface{}]struct{}
+}
+
+// Add adds the item, unless the state is not restored yet or the item has a
+// restored backoff left.
+func (q *persistentQueue) Add(item interface{}) {
+	q.lock.Lock()
+	if !q.restored {
+		q.held[item] = struct{}{}
+		q.lock.Unlock()
+		return
+	}
+	q.lock.Unlock()
+
+	if delay := q.limiter.restoredDelay(item); delay > 0 {
+		q.RateLimitingInterface.AddAfter(item, delay)
+		return
+	}
+	q.RateLimitingInterface.Add(item)
+}
+
+// restore merges the given state into the rate limiter and adds the held back items.
+func (q *persistentQueue) restore(state State) {
+	q.limiter.Restore(state)
+
+	q.lock.Lock()
+	held := q.held
+	q.held = nil
+	q.restored = true
+	q.lock.Unlock()
+
+	for item := range held {
+		q.Add(item)
+	}
+}
+
+// When returns how long to wait before processing the item again.
+func (r *PersistentRateLimiter) When(item interface{}) time.Duration {
+	r.lock.Lock()
+	s := r.failures[item]
+	s.Failures++
+	s.LastAttempt = r.now().Unix()
+	r.failures[item] = s
+	delete(r.notBefore, item)
+	r.lock.Unlock()
+
+	delay := backoff(s.Failures)
+	if defaultDelay := r.defaults.When(item); defaultDelay > delay {
+		return defaultDelay
+	}
+	return delay
+}
+
+// NumRequeues returns the number of consecutive failures of the item.
+func (r *PersistentRateLimiter) NumRequeues(item interface{}) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.failures[item].Failures
+}
+
+// Forget stops tracking the item.
+func (r *PersistentRateLimiter) Forget(item interface{}) {
+	r.defaults.Forget(item)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.failures, item)
+	delete(r.notBefore, item)
+}
+
+// restoredDelay returns the rest of the restored backoff of the item, or zero if there
+// is none or it has passed.
+func (r *PersistentRateLimiter) restoredDelay(item interface{}) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	notBefore, found := r.notBefore[item]
+	if !found {
+		return 0
+	}
+	delay := notBefore.Sub(r.now())
+	if delay <= 0 {
+		delete(r.notBefore, item)
+		return 0
+	}
+	return delay
+}
+
+// backoff returns the exponential per-item delay after the given number of failures.
+func backoff(failures int) time.Duration {
+	backoff := float64(baseDelay.Nanoseconds()) * math.Pow(2, float64(failures-1))
+	if backoff > math.MaxInt64 || time.Duration(backoff) >= maxDelay {
+		return maxDelay
+	}
+	return time.Duration(backoff)
+}
+
+// State returns the state of at most maxItems string items. If there are more, those with
+// the most failures are returned, as they have the longest backoff to lose.
+func (r *PersistentRateLimiter) State(maxItems int) State {
+	r.lock.Lock()
+	keys := make([]string, 0, len(r.failures))
+	all := make(State, len(r.failures))
+	for item, s := range r.failures {
+		if key, ok := item.(string); ok {
+			keys = append(keys, key)
+			all[key] = s
+		}
+	}
+	r.lock.Unlock()
+
+	if len(keys) <= maxItems {
+		return all
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := all[keys[i]], all[keys[j]]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		return a.LastAttempt > b.LastAttempt
+	})
+	state := make(State, maxItems)
+	for _, key := range keys[:maxItems] {
+		state[key] = all[key]
+	}
+	return state
+}
+
+// Restore merges the given state into the rate limiter. Items that have not failed for
+// longer than twice the maximum delay are skipped, as they either stopped failing or were
+// deleted before the state was saved. Items already tracked keep the higher failure count.
+func (r *PersistentRateLimiter) Restore(state State) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cutoff := r.now().Add(-2 * maxDelay).Unix()
+	for key, s := range state {
+		if s.Failures <= 0 || s.LastAttempt < cutoff {
+			continue
+		}
+		if existing, found := r.failures[key]; found && existing.Failures >= s.Failures {
+			continue
+		}
+		r.failures[key] = s
+		r.notBefore[key] = time.Unix(s.LastAttempt, 0).Add(backoff(s.Failures))
+	}
+}
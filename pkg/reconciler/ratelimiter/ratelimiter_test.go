@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestPersistentRateLimiter(t *testing.T) {
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	r := NewPersistentRateLimiter()
+	r.now = func() time.Time { return now }
+
+	require.Equal(t, baseDelay, r.When("a"))
+	require.Equal(t, 2*baseDelay, r.When("a"))
+	require.Equal(t, 4*baseDelay, r.When("a"))
+	require.Equal(t, 3, r.NumRequeues("a"))
+	for i := 0; i < 100; i++ {
+		r.When("b")
+	}
+	require.Equal(t, maxDelay, r.When("b"))
+	r.When(42)
+
+	state := r.State(10)
+	require.Equal(t, State{
+		"a": {Failures: 3, LastAttempt: now.Unix()},
+		"b": {Failures: 101, LastAttempt: now.Unix()},
+	}, state, "non-string items are not persisted")
+
+	r.Forget("a")
+	require.Equal(t, 0, r.NumRequeues("a"))
+
+	restarted := NewPersistentRateLimiter()
+	restarted.now = func() time.Time { return now.Add(time.Minute) }
+	restarted.Restore(State{
+		"a":     state["a"],
+		"b":     state["b"],
+		"stale": {Failures: 20, LastAttempt: now.Add(-time.Hour).Unix()},
+	})
+	require.Equal(t, 3, restarted.NumRequeues("a"))
+	require.Equal(t, 0, restarted.NumRequeues("stale"))
+	require.Equal(t, 8*baseDelay, restarted.When("a"))
+	require.Equal(t, maxDelay, restarted.When("b"), "restored backoff must not start from zero")
+
+	require.Equal(t, State{"b": state["b"]}, r.State(1), "items with the most failures are kept")
+}
+
+func TestPersistentQueue(t *testing.T) {
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	r := NewPersistentRateLimiter()
+	r.now = func() time.Time { return now }
+	q := &persistentQueue{
+		RateLimitingInterface: workqueue.NewRateLimitingQueue(r),
+		limiter:               r,
+		held:                  map[interface{}]struct{}{},
+	}
+	defer q.ShutDown()
+
+	q.Add("failing")
+	q.Add("healthy")
+	require.Equal(t, 0, q.Len(), "items must be held back until the state is restored")
+
+	q.restore(State{"failing": {Failures: 20, LastAttempt: now.Unix()}})
+	require.Equal(t, 1, q.Len(), "items with a restored backoff must be delayed")
+	item, _ := q.Get()
+	require.Equal(t, "healthy", item)
+	q.Done(item)
+
+	require.Equal(t, maxDelay, r.restoredDelay("failing"))
+	r.now = func() time.Time { return now.Add(2 * maxDelay) }
+	require.Equal(t, time.Duration(0), r.restoredDelay("failing"), "restored backoff must pass")
+}
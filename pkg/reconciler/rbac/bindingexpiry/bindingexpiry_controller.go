@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindingexpiry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/logging"
+)
+
+const (
+	ControllerName = "kcp-rbac-binding-expiry"
+
+	roleBindings        = "rolebindings"
+	clusterRoleBindings = "clusterrolebindings"
+)
+
+// NewController returns a new controller deleting RoleBindings and ClusterRoleBindings whose
+// access window, given by the authorization.kcp.io/valid-until annotation, has passed. The
+// authorizers ignore expired bindings anyway, the deletion only cleans them up.
+func NewController(
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	roleBindingInformer kcprbacinformers.RoleBindingClusterInformer,
+	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: controllerSwitch.Queue(queue),
+		getBinding: func(resource string, clusterName logicalcluster.Name, namespace, name string) (metav1.Object, error) {
+			if resource == roleBindings {
+				return roleBindingInformer.Lister().Cluster(clusterName).RoleBindings(namespace).Get(name)
+			}
+			return clusterRoleBindingInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		deleteBinding: func(ctx context.Context, resource string, clusterName logicalcluster.Name, namespace, name string, uid types.UID) error {
+			opts := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}
+			if resource == roleBindings {
+				return kubeClusterClient.Cluster(clusterName.Path()).RbacV1().RoleBindings(namespace).Delete(ctx, name, opts)
+			}
+			return kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Delete(ctx, name, opts)
+		},
+		now: time.Now,
+	}
+
+	roleBindingInformer.Informer().AddEventHandler(c.eventHandler(roleBindings))
+	clusterRoleBindingInformer.Informer().AddEventHandler(c.eventHandler(clusterRoleBindings))
+
+	return c, nil
+}
+
+// controller deletes RoleBindings and ClusterRoleBindings after their access window.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getBinding    func(resource string, clusterName logicalcluster.Name, namespace, name string) (metav1.Object, error)
+	deleteBinding func(ctx context.Context, resource string, clusterName logicalcluster.Name, namespace, name string, uid types.UID) error
+	now           func() time.Time
+}
+
+func (c *controller) eventHandler(resource string) cache.ResourceEventHandler {
+	return cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			binding, ok := obj.(metav1.Object)
+			if !ok {
+				return false
+			}
+			_, found := binding.GetAnnotations()[authorization.BindingValidUntilAnnotationKey]
+			return found
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueue(resource, obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueue(resource, obj) },
+		},
+	}
+}
+
+func (c *controller) enqueue(resource string, obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	resourceKey := fmt.Sprintf("%s::%s", resource, key)
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), resourceKey)
+	logger.V(4).Info("queueing binding")
+	c.queue.Add(resourceKey)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	resource, objKey, found := strings.Cut(key, "::")
+	if !found {
+		runtime.HandleError(fmt.Errorf("invalid key %q", key))
+		return nil
+	}
+	clusterName, namespace, name, err := kcpcache.SplitMetaClusterNamespaceKey(objKey)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	binding, err := c.getBinding(resource, clusterName, namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	requeueAfter, err := c.reconcile(ctx, resource, clusterName, binding)
+	if err != nil {
+		return err
+	}
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindingexpiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+)
+
+// reconcile deletes the binding if its access window has passed. Otherwise, it returns when to
+// check again.
+func (c *controller) reconcile(ctx context.Context, resource string, clusterName logicalcluster.Name, binding metav1.Object) (time.Duration, error) {
+	logger := klog.FromContext(ctx)
+
+	_, until, err := authorization.BindingValidity(binding)
+	if err != nil {
+		// the authorizers never grant access through such a binding, so there is nothing to clean up in a hurry
+		logger.V(2).Info("ignoring binding with invalid access window", "err", err)
+		return 0, nil
+	}
+	if until.IsZero() {
+		return 0, nil
+	}
+	if now := c.now(); now.Before(until) {
+		return until.Sub(now), nil
+	}
+
+	if binding.GetDeletionTimestamp() != nil {
+		return 0, nil
+	}
+	logger.V(2).Info("deleting expired binding", "validUntil", until)
+	if err := c.deleteBinding(ctx, resource, clusterName, binding.GetNamespace(), binding.GetName(), binding.GetUID()); err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindingexpiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2023, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		annotations map[string]string
+
+		wantDeleted      bool
+		wantRequeueAfter time.Duration
+	}{
+		"no access window": {},
+		"only valid from": {
+			annotations: map[string]string{authorization.BindingValidFromAnnotationKey: "2023-01-22T13:00:00Z"},
+		},
+		"not yet expired": {
+			annotations:      map[string]string{authorization.BindingValidUntilAnnotationKey: "2023-01-22T14:00:00Z"},
+			wantRequeueAfter: 2 * time.Hour,
+		},
+		"expired": {
+			annotations: map[string]string{authorization.BindingValidUntilAnnotationKey: "2023-01-22T12:00:00Z"},
+			wantDeleted: true,
+		},
+		"invalid timestamp": {
+			annotations: map[string]string{authorization.BindingValidUntilAnnotationKey: "soon"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted string
+			c := &controller{
+				deleteBinding: func(ctx context.Context, resource string, clusterName logicalcluster.Name, namespace, name string, uid types.UID) error {
+					deleted = resource + "|" + clusterName.String() + "|" + namespace + "|" + name + "|" + string(uid)
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "contractor",
+				UID:         "uid",
+				Annotations: tt.annotations,
+			}}
+			requeueAfter, err := c.reconcile(context.Background(), roleBindings, "root:org:ws", binding)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+			if tt.wantDeleted {
+				require.Equal(t, "rolebindings|root:org:ws|default|contractor|uid", deleted)
+			} else {
+				require.Empty(t, deleted)
+			}
+		})
+	}
+}
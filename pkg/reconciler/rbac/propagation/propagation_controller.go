@@ -0,0 +1,269 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcprbacinformers "github.com/kcp-dev/client-go/informers/rbac/v1"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	tenancyv1beta1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
+)
+
+const (
+	ControllerName = "kcp-rbac-propagation"
+
+	// resyncPeriod is the interval in which workspaces with propagated RBAC are reconciled
+	// again, in order to repair copies changed or deleted in the descendant logical clusters.
+	resyncPeriod = 10 * time.Minute
+)
+
+// propagateSelector selects the ClusterRoles and ClusterRoleBindings opted into propagation.
+var propagateSelector = labels.SelectorFromSet(labels.Set{authorization.PropagateLabelKey: "true"})
+
+// NewController returns a new controller replicating ClusterRoles and ClusterRoleBindings labeled
+// with authorization.kcp.io/propagate=true into the logical clusters of the child Workspaces.
+// The copies carry the label too, hence the controller of the shard of the child replicates them
+// further down the tree. Children can live on other shards, hence they are written through the
+// front-proxy.
+func NewController(
+	logicalClusterAdminConfig *rest.Config,
+	shardExternalURL func() string,
+	workspaceInformer tenancyv1beta1informers.WorkspaceClusterInformer,
+	clusterRoleInformer kcprbacinformers.ClusterRoleClusterInformer,
+	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+) *controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+
+		logicalClusterAdminConfig: logicalClusterAdminConfig,
+		shardExternalURL:          shardExternalURL,
+
+		getWorkspace: func(clusterName logicalcluster.Name, name string) (*tenancyv1beta1.Workspace, error) {
+			return workspaceInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1beta1.Workspace, error) {
+			return workspaceInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		listClusterRoles: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRole, error) {
+			return clusterRoleInformer.Lister().Cluster(clusterName).List(propagateSelector)
+		},
+		listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+			return clusterRoleBindingInformer.Lister().Cluster(clusterName).List(propagateSelector)
+		},
+	}
+
+	workspaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueWorkspace(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueWorkspace(obj) },
+	})
+
+	for _, informer := range []cache.SharedIndexInformer{
+		clusterRoleInformer.Informer(),
+		clusterRoleBindingInformer.Informer(),
+	} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { c.enqueuePropagated(obj) },
+			UpdateFunc: func(oldObj, obj interface{}) {
+				// enqueue if the label was removed too, such that the copies are deleted
+				if propagated(oldObj) || propagated(obj) {
+					c.enqueueChildren(obj)
+				}
+			},
+			DeleteFunc: func(obj interface{}) { c.enqueuePropagated(obj) },
+		})
+	}
+
+	return c
+}
+
+// controller replicates ClusterRoles and ClusterRoleBindings from parent into child workspaces.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	logicalClusterAdminConfig *rest.Config
+	shardExternalURL          func() string
+
+	getWorkspace            func(clusterName logicalcluster.Name, name string) (*tenancyv1beta1.Workspace, error)
+	listWorkspaces          func(clusterName logicalcluster.Name) ([]*tenancyv1beta1.Workspace, error)
+	listClusterRoles        func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRole, error)
+	listClusterRoleBindings func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error)
+
+	// kubeClusterClient is set up in Start as it needs the external URL of the shard.
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+}
+
+// propagated returns true if the object is opted into propagation.
+func propagated(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	return propagateSelector.Matches(labels.Set(o.GetLabels()))
+}
+
+// enqueueWorkspace enqueues a Workspace.
+func (c *controller) enqueueWorkspace(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing Workspace")
+	c.queue.Add(key)
+}
+
+// enqueuePropagated enqueues the child Workspaces of the logical cluster of a propagated object.
+func (c *controller) enqueuePropagated(obj interface{}) {
+	if propagated(obj) {
+		c.enqueueChildren(obj)
+	}
+}
+
+// enqueueChildren enqueues all Workspaces in the logical cluster an object lives in.
+func (c *controller) enqueueChildren(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	workspaces, err := c.listWorkspaces(clusterName)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, ws := range workspaces {
+		workspaceKey := kcpcache.ToClusterAwareKey(clusterName.String(), "", ws.Name)
+		logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), workspaceKey)
+		logger.V(4).Info("queueing Workspace because of propagated RBAC change", "object", key)
+		c.queue.Add(workspaceKey)
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	// a client needed to write into child logical clusters on a different shard
+	frontProxyConfig := rest.CopyConfig(c.logicalClusterAdminConfig)
+	frontProxyConfig = rest.AddUserAgent(frontProxyConfig, ControllerName)
+	frontProxyConfig.Host = c.shardExternalURL()
+	kubeFrontProxyClient, err := kcpkubernetesclientset.NewForConfig(frontProxyConfig)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.kubeClusterClient = kubeFrontProxyClient
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	requeue, err := c.process(ctx, key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if requeue {
+		c.queue.AddAfter(key, resyncPeriod)
+	}
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) (bool, error) {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return false, nil
+	}
+	ws, err := c.getWorkspace(clusterName, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil // object deleted before we handled it, its logical cluster goes away too
+		}
+		return false, err
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), ws)
+	ctx = klog.NewContext(ctx, logger)
+
+	return c.reconcile(ctx, ws)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// reconcile brings the propagated ClusterRoles and ClusterRoleBindings in the logical cluster of
+// the workspace in line with the opted-in objects of the parent. It returns true if the workspace
+// should be resynced periodically.
+func (c *controller) reconcile(ctx context.Context, ws *tenancyv1beta1.Workspace) (bool, error) {
+	// mounted, unscheduled and uninitialized workspaces have no logical cluster to write into
+	if ws.DeletionTimestamp != nil || ws.Spec.Cluster == "" || ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+		return false, nil
+	}
+	parent := logicalcluster.From(ws)
+	child := logicalcluster.Name(ws.Spec.Cluster).Path()
+
+	roles, err := c.listClusterRoles(parent)
+	if err != nil {
+		return false, err
+	}
+	bindings, err := c.listClusterRoleBindings(parent)
+	if err != nil {
+		return false, err
+	}
+
+	var errs []error
+	if err := c.reconcileClusterRoles(ctx, parent, child, roles); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.reconcileClusterRoleBindings(ctx, parent, child, bindings); err != nil {
+		errs = append(errs, err)
+	}
+
+	return len(roles) > 0 || len(bindings) > 0, utilerrors.NewAggregate(errs)
+}
+
+func (c *controller) reconcileClusterRoles(ctx context.Context, parent logicalcluster.Name, child logicalcluster.Path, roles []*rbacv1.ClusterRole) error {
+	logger := klog.FromContext(ctx)
+	client := c.kubeClusterClient.Cluster(child).RbacV1().ClusterRoles()
+
+	existing, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	copies := map[string]*rbacv1.ClusterRole{}
+	for i := range existing.Items {
+		if role := &existing.Items[i]; role.Annotations[authorization.PropagatedFromAnnotationKey] == parent.String() {
+			copies[role.Name] = role
+		}
+	}
+
+	var errs []error
+	for _, role := range roles {
+		desired := clusterRoleCopy(parent, role)
+		current, found := copies[role.Name]
+		delete(copies, role.Name)
+
+		if !found {
+			logger.V(2).Info("creating propagated ClusterRole", "name", role.Name)
+			if _, err := client.Create(ctx, desired, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) {
+				logger.Info("not propagating ClusterRole, the child workspace has its own of the same name", "name", role.Name)
+			} else if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		updated := current.DeepCopy()
+		updated.Labels = desired.Labels
+		updated.Annotations = desired.Annotations
+		updated.AggregationRule = desired.AggregationRule
+		if desired.AggregationRule == nil {
+			// the rules of aggregated ClusterRoles are maintained by the aggregation controller of the child
+			updated.Rules = desired.Rules
+		}
+		if equality.Semantic.DeepEqual(current, updated) {
+			continue
+		}
+		logger.V(2).Info("updating propagated ClusterRole", "name", role.Name)
+		if _, err := client.Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for name, stale := range copies {
+		logger.V(2).Info("deleting propagated ClusterRole", "name", name)
+		uid := stale.UID
+		if err := client.Delete(ctx, name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *controller) reconcileClusterRoleBindings(ctx context.Context, parent logicalcluster.Name, child logicalcluster.Path, bindings []*rbacv1.ClusterRoleBinding) error {
+	logger := klog.FromContext(ctx)
+	client := c.kubeClusterClient.Cluster(child).RbacV1().ClusterRoleBindings()
+
+	existing, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	copies := map[string]*rbacv1.ClusterRoleBinding{}
+	for i := range existing.Items {
+		if binding := &existing.Items[i]; binding.Annotations[authorization.PropagatedFromAnnotationKey] == parent.String() {
+			copies[binding.Name] = binding
+		}
+	}
+
+	var errs []error
+	for _, binding := range bindings {
+		desired := clusterRoleBindingCopy(parent, binding)
+		current, found := copies[binding.Name]
+		delete(copies, binding.Name)
+
+		if !found {
+			logger.V(2).Info("creating propagated ClusterRoleBinding", "name", binding.Name)
+			if _, err := client.Create(ctx, desired, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) {
+				logger.Info("not propagating ClusterRoleBinding, the child workspace has its own of the same name", "name", binding.Name)
+			} else if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if current.RoleRef != desired.RoleRef {
+			// the roleRef is immutable
+			logger.V(2).Info("recreating propagated ClusterRoleBinding", "name", binding.Name)
+			uid := current.UID
+			if err := client.Delete(ctx, current.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+				continue
+			}
+			if _, err := client.Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		updated := current.DeepCopy()
+		updated.Labels = desired.Labels
+		updated.Annotations = desired.Annotations
+		updated.Subjects = desired.Subjects
+		if equality.Semantic.DeepEqual(current, updated) {
+			continue
+		}
+		logger.V(2).Info("updating propagated ClusterRoleBinding", "name", binding.Name)
+		if _, err := client.Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for name, stale := range copies {
+		logger.V(2).Info("deleting propagated ClusterRoleBinding", "name", name)
+		uid := stale.UID
+		if err := client.Delete(ctx, name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// clusterRoleCopy returns the ClusterRole as it is propagated from the parent logical cluster.
+func clusterRoleCopy(parent logicalcluster.Name, role *rbacv1.ClusterRole) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: copyMeta(parent, role),
+		Rules:      role.DeepCopy().Rules,
+		// aggregation is evaluated against the ClusterRoles of the child
+		AggregationRule: role.DeepCopy().AggregationRule,
+	}
+}
+
+// clusterRoleBindingCopy returns the ClusterRoleBinding as it is propagated from the parent
+// logical cluster. Subjects are resolved in the child, i.e. users and groups keep their meaning,
+// while service accounts refer to those in the child.
+func clusterRoleBindingCopy(parent logicalcluster.Name, binding *rbacv1.ClusterRoleBinding) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: copyMeta(parent, binding),
+		RoleRef:    binding.RoleRef,
+		Subjects:   binding.DeepCopy().Subjects,
+	}
+}
+
+// copyMeta returns the metadata of a propagated object. The labels are kept, including the
+// propagation label, such that the copy is propagated further down the tree.
+func copyMeta(parent logicalcluster.Name, obj metav1.Object) metav1.ObjectMeta {
+	labels := make(map[string]string, len(obj.GetLabels()))
+	for k, v := range obj.GetLabels() {
+		labels[k] = v
+	}
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for k, v := range obj.GetAnnotations() {
+		switch k {
+		case logicalcluster.AnnotationKey, corev1.LastAppliedConfigAnnotation:
+		default:
+			annotations[k] = v
+		}
+	}
+	annotations[authorization.PropagatedFromAnnotationKey] = parent.String()
+
+	return metav1.ObjectMeta{
+		Name:        obj.GetName(),
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	kcpfakekubeclient "github.com/kcp-dev/client-go/kubernetes/fake"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kcp-dev/kcp/pkg/authorization"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestReconcile(t *testing.T) {
+	parent := logicalcluster.Name("parent")
+	child := logicalcluster.Name("child")
+
+	propagatedLabels := map[string]string{authorization.PropagateLabelKey: "true"}
+	viewRules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}}
+	editRules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "update"}}}
+	viewerRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "viewer"}
+	subjects := []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: "Group", Name: "auditors"}}
+
+	role := func(clusterName logicalcluster.Name, name string, from logicalcluster.Name, rules []rbacv1.PolicyRule) *rbacv1.ClusterRole {
+		annotations := map[string]string{logicalcluster.AnnotationKey: clusterName.String()}
+		if from != "" {
+			annotations[authorization.PropagatedFromAnnotationKey] = from.String()
+		}
+		return &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: propagatedLabels, Annotations: annotations},
+			Rules:      rules,
+		}
+	}
+	binding := func(clusterName logicalcluster.Name, name string, from logicalcluster.Name) *rbacv1.ClusterRoleBinding {
+		annotations := map[string]string{logicalcluster.AnnotationKey: clusterName.String()}
+		if from != "" {
+			annotations[authorization.PropagatedFromAnnotationKey] = from.String()
+		}
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: propagatedLabels, Annotations: annotations},
+			RoleRef:    viewerRef,
+			Subjects:   subjects,
+		}
+	}
+
+	tests := map[string]struct {
+		phase    corev1alpha1.LogicalClusterPhaseType
+		roles    []*rbacv1.ClusterRole
+		bindings []*rbacv1.ClusterRoleBinding
+		existing []runtime.Object
+
+		wantRequeue  bool
+		wantRules    map[string][]rbacv1.PolicyRule // by ClusterRole name, nil for absent
+		wantBindings []string
+	}{
+		"not ready workspace is skipped": {
+			phase: corev1alpha1.LogicalClusterPhaseInitializing,
+			roles: []*rbacv1.ClusterRole{role(parent, "viewer", "", viewRules)},
+			wantRules: map[string][]rbacv1.PolicyRule{
+				"viewer": nil,
+			},
+		},
+		"missing copies are created": {
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			roles:       []*rbacv1.ClusterRole{role(parent, "viewer", "", viewRules)},
+			bindings:    []*rbacv1.ClusterRoleBinding{binding(parent, "auditors", "")},
+			wantRequeue: true,
+			wantRules: map[string][]rbacv1.PolicyRule{
+				"viewer": viewRules,
+			},
+			wantBindings: []string{"auditors"},
+		},
+		"drifted copies are updated": {
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			roles:       []*rbacv1.ClusterRole{role(parent, "viewer", "", viewRules)},
+			existing:    []runtime.Object{role(child, "viewer", parent, editRules)},
+			wantRequeue: true,
+			wantRules: map[string][]rbacv1.PolicyRule{
+				"viewer": viewRules,
+			},
+		},
+		"stale copies are deleted": {
+			phase:    corev1alpha1.LogicalClusterPhaseReady,
+			existing: []runtime.Object{role(child, "viewer", parent, viewRules), binding(child, "auditors", parent)},
+			wantRules: map[string][]rbacv1.PolicyRule{
+				"viewer": nil,
+			},
+		},
+		"objects of the child are not touched": {
+			phase: corev1alpha1.LogicalClusterPhaseReady,
+			roles: []*rbacv1.ClusterRole{role(parent, "viewer", "", viewRules)},
+			existing: []runtime.Object{
+				role(child, "viewer", "", editRules),
+				role(child, "editor", "grandparent", editRules),
+			},
+			wantRequeue: true,
+			wantRules: map[string][]rbacv1.PolicyRule{
+				"viewer": editRules,
+				"editor": editRules,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := kcpfakekubeclient.NewSimpleClientset(tt.existing...)
+			c := &controller{
+				listClusterRoles: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRole, error) {
+					require.Equal(t, parent, clusterName)
+					return tt.roles, nil
+				},
+				listClusterRoleBindings: func(clusterName logicalcluster.Name) ([]*rbacv1.ClusterRoleBinding, error) {
+					require.Equal(t, parent, clusterName)
+					return tt.bindings, nil
+				},
+				kubeClusterClient: client,
+			}
+
+			ws := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ws",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: parent.String()},
+				},
+				Spec:   tenancyv1beta1.WorkspaceSpec{Cluster: child.String()},
+				Status: tenancyv1beta1.WorkspaceStatus{Phase: tt.phase},
+			}
+			requeue, err := c.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequeue, requeue)
+
+			for name, rules := range tt.wantRules {
+				got, err := client.Cluster(child.Path()).RbacV1().ClusterRoles().Get(context.Background(), name, metav1.GetOptions{})
+				if rules == nil {
+					require.True(t, apierrors.IsNotFound(err), "expected ClusterRole %q to be absent, got: %v", name, err)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, rules, got.Rules)
+			}
+
+			got, err := client.Cluster(child.Path()).RbacV1().ClusterRoleBindings().List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			var gotBindings []string
+			for _, b := range got.Items {
+				require.Equal(t, parent.String(), b.Annotations[authorization.PropagatedFromAnnotationKey])
+				require.Equal(t, "true", b.Labels[authorization.PropagateLabelKey], "expected the copy to be propagated further")
+				gotBindings = append(gotBindings, b.Name)
+			}
+			require.Equal(t, tt.wantBindings, gotBindings)
+		})
+	}
+}
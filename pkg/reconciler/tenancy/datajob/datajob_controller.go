@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datajob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-datajob"
+)
+
+// NewController returns a new controller executing the DataJobs stored on this shard against the
+// logical clusters of this shard.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	dataJobInformer tenancyv1alpha1informers.DataJobClusterInformer,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: controllerSwitch.Queue(queue),
+		getDataJob: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.DataJob, error) {
+			return dataJobInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		updateDataJobStatus: func(ctx context.Context, clusterName logicalcluster.Name, job *tenancyv1alpha1.DataJob) error {
+			_, err := kcpClusterClient.Cluster(clusterName.Path()).TenancyV1alpha1().DataJobs().UpdateStatus(ctx, job, metav1.UpdateOptions{})
+			return err
+		},
+		listLogicalClusters: func() ([]logicalcluster.Name, error) {
+			lcs, err := logicalClusterInformer.Lister().List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			names := make([]logicalcluster.Name, 0, len(lcs))
+			for _, lc := range lcs {
+				names = append(names, logicalcluster.From(lc))
+			}
+			return names, nil
+		},
+		listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+			return dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).List(ctx, opts)
+		},
+		getObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+			return dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		updateObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+			_, err := dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			return err
+		},
+		now: time.Now,
+	}
+
+	dataJobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueue(obj) },
+		UpdateFunc: func(oldObj, obj interface{}) {
+			// the controller schedules the next batch itself, throttled. Only spec changes, e.g.
+			// of spec.suspend, trigger it early.
+			oldJob, ok := oldObj.(*tenancyv1alpha1.DataJob)
+			if !ok {
+				return
+			}
+			job, ok := obj.(*tenancyv1alpha1.DataJob)
+			if !ok {
+				return
+			}
+			if oldJob.Generation != job.Generation {
+				c.enqueue(obj)
+			}
+		},
+	})
+
+	return c, nil
+}
+
+// controller executes DataJobs batch by batch.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getDataJob          func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.DataJob, error)
+	updateDataJobStatus func(ctx context.Context, clusterName logicalcluster.Name, job *tenancyv1alpha1.DataJob) error
+	listLogicalClusters func() ([]logicalcluster.Name, error)
+
+	listObjects  func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, opts metav1.ListOptions) (*unstructured.UnstructuredList, error)
+	getObject    func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	updateObject func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error
+
+	now func() time.Time
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing DataJob")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	job, err := c.getDataJob(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), job)
+	ctx = klog.NewContext(ctx, logger)
+
+	requeue, requeueAfter, err := c.reconcile(ctx, clusterName, job)
+	if err != nil {
+		return err
+	}
+	if requeue {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datajob
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// operation changes obj in place and returns whether it has to be written. It must be
+// idempotent, because batches are processed again when their checkpoint could not be recorded.
+type operation func(job *tenancyv1alpha1.DataJob, obj *unstructured.Unstructured) (bool, error)
+
+// operations are the bulk rewrites executed by DataJobs. New features rewriting objects in bulk
+// add their operation here and to the enum of spec.operation.
+var operations = map[tenancyv1alpha1.DataJobOperation]operation{
+	tenancyv1alpha1.DataJobRewrite: rewrite,
+	tenancyv1alpha1.DataJobRelabel: relabel,
+}
+
+// rewrite writes every object back unchanged. The storage layer only persists objects again whose
+// stored data is stale, i.e. encrypted with another key than the current write key or encoded in
+// another storage version.
+func rewrite(_ *tenancyv1alpha1.DataJob, _ *unstructured.Unstructured) (bool, error) {
+	return true, nil
+}
+
+// relabel sets and removes the labels of spec.relabel.
+func relabel(job *tenancyv1alpha1.DataJob, obj *unstructured.Unstructured) (bool, error) {
+	lbls := obj.GetLabels()
+	changed := false
+	for _, key := range job.Spec.Relabel.Remove {
+		if _, found := lbls[key]; found {
+			delete(lbls, key)
+			changed = true
+		}
+	}
+	for key, value := range job.Spec.Relabel.Set {
+		if current, found := lbls[key]; found && current == value {
+			continue
+		}
+		if lbls == nil {
+			lbls = map[string]string{}
+		}
+		lbls[key] = value
+		changed = true
+	}
+	if changed {
+		obj.SetLabels(lbls)
+	}
+	return changed, nil
+}
+
+// validate returns why the job cannot be executed, if so.
+func validate(job *tenancyv1alpha1.DataJob) error {
+	if _, found := operations[job.Spec.Operation]; !found {
+		return fmt.Errorf("unknown operation %q", job.Spec.Operation)
+	}
+	if job.Spec.Resource.Version == "" || job.Spec.Resource.Resource == "" {
+		return errors.New("spec.resource.version and spec.resource.resource are required")
+	}
+	if job.Spec.Operation != tenancyv1alpha1.DataJobRelabel {
+		return nil
+	}
+
+	relabel := job.Spec.Relabel
+	if relabel == nil || len(relabel.Set)+len(relabel.Remove) == 0 {
+		return errors.New("spec.relabel must set or remove labels for the Relabel operation")
+	}
+	var errs []string
+	keys := make([]string, 0, len(relabel.Set))
+	for key := range relabel.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := relabel.Set[key]
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Sprintf("spec.relabel.set: invalid key %q: %s", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, fmt.Sprintf("spec.relabel.set[%s]: invalid value %q: %s", key, value, msg))
+		}
+	}
+	for _, key := range relabel.Remove {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Sprintf("spec.relabel.remove: invalid key %q: %s", key, msg))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datajob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+const (
+	defaultWritesPerSecond = 50
+	defaultBatchSize       = 100
+)
+
+// reconcile processes the next batch of the job and records the new checkpoint. It returns
+// whether and when to process the next batch, such that the writes of the batch are spread
+// according to spec.throttle.writesPerSecond.
+//
+// If the checkpoint cannot be recorded, the batch is processed again. This is safe because
+// operations are idempotent.
+func (c *controller) reconcile(ctx context.Context, clusterName logicalcluster.Name, job *tenancyv1alpha1.DataJob) (bool, time.Duration, error) {
+	switch job.Status.Phase {
+	case tenancyv1alpha1.DataJobSucceeded, tenancyv1alpha1.DataJobFailed:
+		return false, 0, nil
+	}
+
+	updated := job.DeepCopy()
+	requeue, requeueAfter, err := c.step(ctx, updated)
+	if err != nil {
+		return false, 0, err
+	}
+	if !equality.Semantic.DeepEqual(job.Status, updated.Status) {
+		if err := c.updateDataJobStatus(ctx, clusterName, updated); err != nil {
+			return false, 0, err
+		}
+	}
+	return requeue, requeueAfter, nil
+}
+
+// step processes the next batch of the job, updating its status.
+func (c *controller) step(ctx context.Context, job *tenancyv1alpha1.DataJob) (bool, time.Duration, error) {
+	logger := klog.FromContext(ctx)
+	start := c.now()
+	status := &job.Status
+
+	if err := validate(job); err != nil {
+		logger.V(2).Info("DataJob is invalid", "err", err)
+		status.Phase = tenancyv1alpha1.DataJobFailed
+		status.Message = err.Error()
+		status.EstimatedCompletionTime = nil
+		status.CompletionTime = &metav1.Time{Time: start}
+		return false, 0, nil
+	}
+	if job.Spec.Suspend {
+		status.Phase = tenancyv1alpha1.DataJobSuspended
+		status.EstimatedCompletionTime = nil
+		return false, 0, nil
+	}
+
+	clusters, err := c.jobLogicalClusters(job)
+	if err != nil {
+		return false, 0, err
+	}
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: start}
+	}
+	status.Phase = tenancyv1alpha1.DataJobRunning
+	status.LogicalClusters = int32(len(clusters))
+
+	// the checkpoint's logical cluster might be gone, then we continue with the next one
+	completed := 0
+	continueToken := ""
+	if status.Checkpoint != nil {
+		completed = sort.SearchStrings(clusters, status.Checkpoint.LogicalCluster)
+		if completed < len(clusters) && clusters[completed] == status.Checkpoint.LogicalCluster {
+			continueToken = status.Checkpoint.Continue
+		}
+	}
+	status.CompletedLogicalClusters = int32(completed)
+	if completed == len(clusters) {
+		complete(status, start)
+		return false, 0, nil
+	}
+	cluster := logicalcluster.Name(clusters[completed])
+	logger = logger.WithValues("cluster", cluster.String())
+
+	gvr := schema.GroupVersionResource{Group: job.Spec.Resource.Group, Version: job.Spec.Resource.Version, Resource: job.Spec.Resource.Resource}
+	batchSize := int64(job.Spec.Throttle.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	list, err := c.listObjects(ctx, cluster, gvr, metav1.ListOptions{Limit: batchSize, Continue: continueToken})
+	switch {
+	case errors.IsNotFound(err):
+		// the logical cluster does not serve the resource
+		list = &unstructured.UnstructuredList{}
+	case errors.IsResourceExpired(err):
+		// the continue token is compacted away. Start over with the logical cluster.
+		logger.V(2).Info("continue token expired, restarting logical cluster")
+		status.Checkpoint = &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: cluster.String()}
+		return true, 0, nil
+	case err != nil:
+		return false, 0, err
+	}
+
+	op := operations[job.Spec.Operation]
+	writes := 0
+	for i := range list.Items {
+		written, err := c.apply(ctx, op, job, cluster, gvr, &list.Items[i])
+		status.ProcessedObjects++
+		if written {
+			writes++
+		}
+		if err != nil {
+			logger.V(2).Info("failed to write object", "namespace", list.Items[i].GetNamespace(), "name", list.Items[i].GetName(), "err", err)
+			status.FailedObjects++
+			status.Message = fmt.Sprintf("failed to write %s %s|%s: %v", gvr.GroupResource(), cluster, namespacedName(&list.Items[i]), err)
+		}
+	}
+
+	if next := list.GetContinue(); next != "" {
+		status.Checkpoint = &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: cluster.String(), Continue: next}
+	} else {
+		logger.V(4).Info("completed logical cluster")
+		completed++
+		status.CompletedLogicalClusters = int32(completed)
+		if completed == len(clusters) {
+			complete(status, start)
+			return false, 0, nil
+		}
+		status.Checkpoint = &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: clusters[completed]}
+	}
+
+	status.EstimatedCompletionTime = nil
+	if completed > 0 {
+		elapsed := start.Sub(status.StartTime.Time)
+		remaining := time.Duration(float64(elapsed) * float64(len(clusters)-completed) / float64(completed))
+		status.EstimatedCompletionTime = &metav1.Time{Time: start.Add(remaining)}
+	}
+
+	writesPerSecond := job.Spec.Throttle.WritesPerSecond
+	if writesPerSecond <= 0 {
+		writesPerSecond = defaultWritesPerSecond
+	}
+	requeueAfter := time.Duration(writes)*time.Second/time.Duration(writesPerSecond) - c.now().Sub(start)
+	if requeueAfter < 0 {
+		requeueAfter = 0
+	}
+	return true, requeueAfter, nil
+}
+
+// jobLogicalClusters returns the sorted names of the logical clusters covered by the job.
+func (c *controller) jobLogicalClusters(job *tenancyv1alpha1.DataJob) ([]string, error) {
+	names, err := c.listLogicalClusters()
+	if err != nil {
+		return nil, err
+	}
+	var only map[string]bool
+	if len(job.Spec.LogicalClusters) > 0 {
+		only = make(map[string]bool, len(job.Spec.LogicalClusters))
+		for _, name := range job.Spec.LogicalClusters {
+			only[name] = true
+		}
+	}
+
+	clusters := make([]string, 0, len(names))
+	for _, name := range names {
+		if only == nil || only[name.String()] {
+			clusters = append(clusters, name.String())
+		}
+	}
+	sort.Strings(clusters)
+	return clusters, nil
+}
+
+// apply applies the operation to obj and writes it if needed. On conflicts, the operation is
+// applied to the current object again.
+func (c *controller) apply(ctx context.Context, op operation, job *tenancyv1alpha1.DataJob, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) (bool, error) {
+	written := false
+	current := obj
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if current == nil {
+			var err error
+			if current, err = c.getObject(ctx, clusterName, gvr, obj.GetNamespace(), obj.GetName()); err != nil {
+				return err
+			}
+		}
+		changed, err := op(job, current)
+		if err != nil || !changed {
+			return err
+		}
+		if err := c.updateObject(ctx, clusterName, gvr, current); err != nil {
+			current = nil
+			return err
+		}
+		written = true
+		return nil
+	})
+	if errors.IsNotFound(err) {
+		return false, nil // deleted meanwhile
+	}
+	return written, err
+}
+
+// complete finishes the job. It fails if some objects could not be written.
+func complete(status *tenancyv1alpha1.DataJobStatus, now time.Time) {
+	status.Phase = tenancyv1alpha1.DataJobSucceeded
+	if status.FailedObjects > 0 {
+		status.Phase = tenancyv1alpha1.DataJobFailed
+	}
+	status.Checkpoint = nil
+	status.CompletedLogicalClusters = status.LogicalClusters
+	status.EstimatedCompletionTime = nil
+	status.CompletionTime = &metav1.Time{Time: now}
+}
+
+func namespacedName(obj *unstructured.Unstructured) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return ns + "/" + obj.GetName()
+	}
+	return obj.GetName()
+}
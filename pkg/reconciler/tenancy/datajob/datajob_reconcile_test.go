@@ -0,0 +1,282 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datajob
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2023, 1, 27, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-time.Hour))
+
+	object := func(name string, lbls map[string]string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetLabels(lbls)
+		return obj
+	}
+	// logical cluster "c" does not serve configmaps
+	objects := map[logicalcluster.Name][]unstructured.Unstructured{
+		"a": {object("a1", nil), object("a2", map[string]string{"team": "billing"}), object("a3", nil)},
+		"b": {object("b1", nil)},
+	}
+
+	rewrite := func(mutators ...func(*tenancyv1alpha1.DataJob)) *tenancyv1alpha1.DataJob {
+		job := &tenancyv1alpha1.DataJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "reencrypt"},
+			Spec: tenancyv1alpha1.DataJobSpec{
+				Operation: tenancyv1alpha1.DataJobRewrite,
+				Resource:  tenancyv1alpha1.DataJobResource{Version: "v1", Resource: "configmaps"},
+				Throttle:  tenancyv1alpha1.DataJobThrottle{WritesPerSecond: 1, BatchSize: 2},
+			},
+		}
+		for _, m := range mutators {
+			m(job)
+		}
+		return job
+	}
+	running := func(checkpoint *tenancyv1alpha1.DataJobCheckpoint) func(*tenancyv1alpha1.DataJob) {
+		return func(job *tenancyv1alpha1.DataJob) {
+			job.Status = tenancyv1alpha1.DataJobStatus{
+				Phase:            tenancyv1alpha1.DataJobRunning,
+				Checkpoint:       checkpoint,
+				LogicalClusters:  3,
+				ProcessedObjects: 10,
+				StartTime:        &started,
+			}
+		}
+	}
+	relabel := func(job *tenancyv1alpha1.DataJob) {
+		job.Spec.Operation = tenancyv1alpha1.DataJobRelabel
+		job.Spec.Relabel = &tenancyv1alpha1.DataJobRelabel{Set: map[string]string{"team": "billing"}}
+	}
+	eta := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: now.Add(d)} }
+
+	tests := map[string]struct {
+		job       *tenancyv1alpha1.DataJob
+		failWrite string
+
+		wantStatus       *tenancyv1alpha1.DataJobStatus
+		wantWritten      []string
+		wantRequeue      bool
+		wantRequeueAfter time.Duration
+	}{
+		"first batch": {
+			job: rewrite(),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:            tenancyv1alpha1.DataJobRunning,
+				Checkpoint:       &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "2"},
+				LogicalClusters:  3,
+				ProcessedObjects: 2,
+				StartTime:        &metav1.Time{Time: now},
+			},
+			wantWritten:      []string{"a|default/a1", "a|default/a2"},
+			wantRequeue:      true,
+			wantRequeueAfter: 2 * time.Second,
+		},
+		"last batch of a logical cluster continues with the next one": {
+			job: rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "2"})),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:                    tenancyv1alpha1.DataJobRunning,
+				Checkpoint:               &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "b"},
+				LogicalClusters:          3,
+				CompletedLogicalClusters: 1,
+				ProcessedObjects:         11,
+				StartTime:                &started,
+				EstimatedCompletionTime:  eta(2 * time.Hour),
+			},
+			wantWritten:      []string{"a|default/a3"},
+			wantRequeue:      true,
+			wantRequeueAfter: time.Second,
+		},
+		"logical cluster not serving the resource is skipped": {
+			job: rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "c"})),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:                    tenancyv1alpha1.DataJobSucceeded,
+				LogicalClusters:          3,
+				CompletedLogicalClusters: 3,
+				ProcessedObjects:         10,
+				StartTime:                &started,
+				CompletionTime:           &metav1.Time{Time: now},
+			},
+		},
+		"deleted checkpoint logical cluster continues with the next one": {
+			job: rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "aa", Continue: "5"})),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:                    tenancyv1alpha1.DataJobRunning,
+				Checkpoint:               &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "c"},
+				LogicalClusters:          3,
+				CompletedLogicalClusters: 2,
+				ProcessedObjects:         11,
+				StartTime:                &started,
+				EstimatedCompletionTime:  eta(30 * time.Minute),
+			},
+			wantWritten:      []string{"b|default/b1"},
+			wantRequeue:      true,
+			wantRequeueAfter: time.Second,
+		},
+		"expired continue token restarts the logical cluster": {
+			job: rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "expired"})),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:            tenancyv1alpha1.DataJobRunning,
+				Checkpoint:       &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a"},
+				LogicalClusters:  3,
+				ProcessedObjects: 10,
+				StartTime:        &started,
+			},
+			wantRequeue: true,
+		},
+		"relabel only writes changed objects": {
+			job: rewrite(relabel),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:            tenancyv1alpha1.DataJobRunning,
+				Checkpoint:       &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "2"},
+				LogicalClusters:  3,
+				ProcessedObjects: 2,
+				StartTime:        &metav1.Time{Time: now},
+			},
+			wantWritten:      []string{"a|default/a1"},
+			wantRequeue:      true,
+			wantRequeueAfter: time.Second,
+		},
+		"restricted to logical clusters": {
+			job: rewrite(func(job *tenancyv1alpha1.DataJob) { job.Spec.LogicalClusters = []string{"b", "unknown"} }),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:                    tenancyv1alpha1.DataJobSucceeded,
+				LogicalClusters:          1,
+				CompletedLogicalClusters: 1,
+				ProcessedObjects:         1,
+				StartTime:                &metav1.Time{Time: now},
+				CompletionTime:           &metav1.Time{Time: now},
+			},
+			wantWritten: []string{"b|default/b1"},
+		},
+		"failed writes fail the job when it completes": {
+			job:       rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "b"}), func(job *tenancyv1alpha1.DataJob) { job.Spec.LogicalClusters = []string{"a", "b"} }),
+			failWrite: "b|default/b1",
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:                    tenancyv1alpha1.DataJobFailed,
+				LogicalClusters:          2,
+				CompletedLogicalClusters: 2,
+				ProcessedObjects:         11,
+				FailedObjects:            1,
+				Message:                  `failed to write configmaps b|default/b1: Internal error occurred: boom`,
+				StartTime:                &started,
+				CompletionTime:           &metav1.Time{Time: now},
+			},
+		},
+		"suspended": {
+			job: rewrite(running(&tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "2"}), func(job *tenancyv1alpha1.DataJob) { job.Spec.Suspend = true }),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:            tenancyv1alpha1.DataJobSuspended,
+				Checkpoint:       &tenancyv1alpha1.DataJobCheckpoint{LogicalCluster: "a", Continue: "2"},
+				LogicalClusters:  3,
+				ProcessedObjects: 10,
+				StartTime:        &started,
+			},
+		},
+		"invalid job fails": {
+			job: rewrite(func(job *tenancyv1alpha1.DataJob) { job.Spec.Operation = "Shred" }),
+			wantStatus: &tenancyv1alpha1.DataJobStatus{
+				Phase:          tenancyv1alpha1.DataJobFailed,
+				Message:        `unknown operation "Shred"`,
+				CompletionTime: &metav1.Time{Time: now},
+			},
+		},
+		"completed job is left alone": {
+			job: rewrite(func(job *tenancyv1alpha1.DataJob) { job.Status.Phase = tenancyv1alpha1.DataJobSucceeded }),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var written []string
+			var updated *tenancyv1alpha1.DataJob
+			c := &controller{
+				updateDataJobStatus: func(ctx context.Context, clusterName logicalcluster.Name, job *tenancyv1alpha1.DataJob) error {
+					updated = job
+					return nil
+				},
+				listLogicalClusters: func() ([]logicalcluster.Name, error) {
+					return []logicalcluster.Name{"c", "a", "b"}, nil
+				},
+				listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+					objs, found := objects[clusterName]
+					if !found {
+						return nil, errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "")
+					}
+					start := 0
+					if opts.Continue != "" {
+						var err error
+						if start, err = strconv.Atoi(opts.Continue); err != nil {
+							return nil, errors.NewResourceExpired("expired")
+						}
+					}
+					end := start + int(opts.Limit)
+					list := &unstructured.UnstructuredList{}
+					if end < len(objs) {
+						list.SetContinue(strconv.Itoa(end))
+					} else {
+						end = len(objs)
+					}
+					for _, obj := range objs[start:end] {
+						list.Items = append(list.Items, *obj.DeepCopy())
+					}
+					return list, nil
+				},
+				updateObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+					key := clusterName.String() + "|" + obj.GetNamespace() + "/" + obj.GetName()
+					if key == tt.failWrite {
+						return errors.NewInternalError(fmt.Errorf("boom"))
+					}
+					written = append(written, key)
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			requeue, requeueAfter, err := c.reconcile(context.Background(), "root", tt.job)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequeue, requeue)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+			require.Equal(t, tt.wantWritten, written)
+			if tt.wantStatus == nil {
+				require.Nil(t, updated)
+			} else {
+				require.NotNil(t, updated)
+				require.Equal(t, *tt.wantStatus, updated.Status)
+			}
+		})
+	}
+}
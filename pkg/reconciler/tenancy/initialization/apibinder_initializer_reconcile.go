@@ -32,14 +32,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/tenancy/initialization"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) error {
@@ -117,13 +117,17 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 
 	requiredExportRefs := map[tenancyv1alpha1.APIExportReference]struct{}{}
 	someExportsMissing := false
+	someReleasesMissing := false
 
 	for _, wt := range wts {
 		logger := logging.WithObject(logger, wt)
 		logger.V(2).Info("attempting to initialize APIBindings")
 
-		for i := range wt.Spec.DefaultAPIBindings {
-			exportRef := wt.Spec.DefaultAPIBindings[i]
+		// while a change of the default APIBindings is rolled out, workspaces outside the started waves get the stable ones
+		defaultBindings := wt.DefaultAPIBindingsFor(clusterName.String(), logicalCluster.Labels)
+		for i := range defaultBindings {
+			defaultBinding := defaultBindings[i]
+			exportRef := defaultBinding.APIExportReference
 			if exportRef.Path == "" {
 				exportRef.Path = logicalcluster.From(wt).String()
 			}
@@ -136,6 +140,21 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 				continue
 			}
 
+			var release string
+			if defaultBinding.ReleaseConstraint != "" {
+				release, err = apiExport.Spec.NewestReleaseMatching(defaultBinding.ReleaseConstraint)
+				if err != nil {
+					errors = append(errors, fmt.Errorf("invalid release constraint for APIExport %s|%s: %w", exportRef.Path, exportRef.Export, err))
+					continue
+				}
+				if release == "" {
+					// the APIExport might publish a matching release later
+					errors = append(errors, fmt.Errorf("no release of APIExport %s|%s matches %q", exportRef.Path, exportRef.Export, defaultBinding.ReleaseConstraint))
+					someReleasesMissing = true
+					continue
+				}
+			}
+
 			// Keep track of unique set of expected exports across all WTs
 			requiredExportRefs[exportRef] = struct{}{}
 
@@ -157,10 +176,11 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 				Spec: apisv1alpha1.APIBindingSpec{
 					Reference: apisv1alpha1.BindingReference{
 						Export: &apisv1alpha1.ExportBindingReference{
-							Path: exportRef.Path,
+							Path: apisv1alpha1.LogicalClusterPath(exportRef.Path),
 							Name: apiExport.Name,
 						},
 					},
+					Release: release,
 				},
 			}
 
@@ -177,7 +197,7 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 
 			logger = logging.WithObject(logger, apiBinding)
 
-			logger.V(2).Info("trying to create APIBinding")
+			logger.V(2).Info("trying to create APIBinding", "release", release)
 			if _, err := b.createAPIBinding(ctx, clusterName.Path(), apiBinding); err != nil {
 				if apierrors.IsAlreadyExists(err) {
 					logger.V(2).Info("APIBinding already exists")
@@ -204,9 +224,9 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 			utilerrors.NewAggregate(errors),
 		)
 
-		if someExportsMissing {
-			// Retry if any APIExports are missing, as it's possible they'll show up (cache server slow to catch up,
-			// arrive via replication)
+		if someExportsMissing || someReleasesMissing {
+			// Retry if any APIExports or matching releases are missing, as it's possible they'll show up (cache
+			// server slow to catch up, arrive via replication)
 			return utilerrors.NewAggregate(errors)
 		}
 
@@ -218,7 +238,7 @@ func (b *APIBinder) reconcile(ctx context.Context, logicalCluster *corev1alpha1.
 
 	for exportRef := range requiredExportRefs {
 		binding, exists := exportToBinding[apisv1alpha1.ExportBindingReference{
-			Path: exportRef.Path,
+			Path: apisv1alpha1.LogicalClusterPath(exportRef.Path),
 			Name: exportRef.Export,
 		}]
 		if !exists {
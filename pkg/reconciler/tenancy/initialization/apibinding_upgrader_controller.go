@@ -0,0 +1,372 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	admission "github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+)
+
+const (
+	UpgraderControllerName = "kcp-apibinding-upgrader"
+)
+
+// NewAPIBindingUpgrader returns a new controller which bumps the release of the APIBindings created for
+// the default APIBindings of WorkspaceTypes with the Automatic upgrade policy, in all workspaces of the shard.
+func NewAPIBindingUpgrader(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	workspaceTypeInformer, globalWorkspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
+	apiBindingsInformer apisv1alpha1informers.APIBindingClusterInformer,
+	apiExportsInformer, globalAPIExportsInformer apisv1alpha1informers.APIExportClusterInformer,
+) (*APIBindingUpgrader, error) {
+	c := &APIBindingUpgrader{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), UpgraderControllerName),
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+			t, err := indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), workspaceTypeInformer.Informer().GetIndexer(), path, name)
+			if apierrors.IsNotFound(err) {
+				return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), globalWorkspaceTypeInformer.Informer().GetIndexer(), path, name)
+			}
+			return t, err
+		},
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+
+		getAPIBinding: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error) {
+			return apiBindingsInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+			// APIBindings reference the APIExport either by its canonical path or by its cluster name
+			values := sets.NewString(logicalcluster.From(export).Path().Join(export.Name).String())
+			if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+				values.Insert(path.Join(export.Name).String())
+			}
+
+			var bindings []*apisv1alpha1.APIBinding
+			for _, value := range values.List() {
+				objs, err := indexers.ByIndex[*apisv1alpha1.APIBinding](apiBindingsInformer.Informer().GetIndexer(), kcpindexers.APIBindingsByAPIExport, value)
+				if err != nil {
+					return nil, err
+				}
+				bindings = append(bindings, objs...)
+			}
+			return bindings, nil
+		},
+		setAPIBindingRelease: func(ctx context.Context, binding *apisv1alpha1.APIBinding, release string) error {
+			patch, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"resourceVersion": binding.ResourceVersion,
+				},
+				"spec": map[string]interface{}{
+					"release": release,
+				},
+			})
+			if err != nil {
+				return err
+			}
+			_, err = kcpClusterClient.Cluster(logicalcluster.From(binding).Path()).ApisV1alpha1().APIBindings().Patch(ctx, binding.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+			return err
+		},
+
+		getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+			export, err := indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportsInformer.Informer().GetIndexer(), path, name)
+			if apierrors.IsNotFound(err) {
+				return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), globalAPIExportsInformer.Informer().GetIndexer(), path, name)
+			}
+			return export, err
+		},
+	}
+
+	c.transitiveTypeResolver = admission.NewTransitiveTypeResolver(c.getWorkspaceType)
+
+	logger := logging.WithReconciler(klog.Background(), UpgraderControllerName)
+
+	indexers.AddIfNotPresentOrDie(workspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	indexers.AddIfNotPresentOrDie(globalWorkspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	indexers.AddIfNotPresentOrDie(apiBindingsInformer.Informer().GetIndexer(), cache.Indexers{
+		kcpindexers.APIBindingsByAPIExport: kcpindexers.IndexAPIBindingByAPIExport,
+	})
+
+	apiBindingsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueAPIBinding(obj, logger)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueueAPIBinding(obj, logger)
+		},
+	})
+
+	logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, obj interface{}) {
+			// the labels decide whether a workspace is part of a started wave of a WorkspaceType rollout
+			oldLC, ok := oldObj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			newLC, ok := obj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			if !equality.Semantic.DeepEqual(oldLC.Labels, newLC.Labels) {
+				c.enqueueLogicalCluster(newLC, logger)
+			}
+		},
+	})
+
+	for _, informer := range []cache.SharedIndexInformer{workspaceTypeInformer.Informer(), globalWorkspaceTypeInformer.Informer()} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueWorkspaceTypes(obj, logger)
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				c.enqueueWorkspaceTypes(obj, logger)
+			},
+		})
+	}
+
+	for _, informer := range []cache.SharedIndexInformer{apiExportsInformer.Informer(), globalAPIExportsInformer.Informer()} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueAPIExport(obj, logger)
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				c.enqueueAPIExport(obj, logger)
+			},
+		})
+	}
+
+	return c, nil
+}
+
+// APIBindingUpgrader is a controller which bumps the release of the APIBindings created for the default
+// APIBindings of WorkspaceTypes with the Automatic upgrade policy.
+type APIBindingUpgrader struct {
+	queue workqueue.RateLimitingInterface
+
+	getLogicalCluster   func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getWorkspaceType    func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	listLogicalClusters func() ([]*corev1alpha1.LogicalCluster, error)
+
+	getAPIBinding             func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error)
+	getAPIBindingsByAPIExport func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error)
+	setAPIBindingRelease      func(ctx context.Context, binding *apisv1alpha1.APIBinding, release string) error
+
+	getAPIExport func(clusterName logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error)
+
+	transitiveTypeResolver transitiveTypeResolver
+}
+
+func (u *APIBindingUpgrader) enqueueLogicalCluster(obj interface{}, logger logr.Logger) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(2).Info("queueing LogicalCluster")
+	u.queue.Add(key)
+}
+
+func (u *APIBindingUpgrader) enqueueAPIBinding(obj interface{}, logger logr.Logger) {
+	apiBinding, ok := obj.(*apisv1alpha1.APIBinding)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("expected APIBinding, got %T", obj))
+		return
+	}
+	if apiBinding.Spec.Release == "" {
+		// bound to latestResourceSchemas, nothing to upgrade
+		return
+	}
+
+	logicalCluster, err := u.getLogicalCluster(logicalcluster.From(apiBinding))
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logging.WithObject(logger, apiBinding).Error(err, "failed to get LogicalCluster from lister")
+		}
+		return
+	}
+
+	u.enqueueLogicalCluster(logicalCluster, logger)
+}
+
+// enqueueWorkspaceTypes enqueues all workspaces of the shard when a WorkspaceType with automatically upgraded
+// default APIBindings changes, e.g. because its release constraint was widened.
+func (u *APIBindingUpgrader) enqueueWorkspaceTypes(obj interface{}, logger logr.Logger) {
+	wt, ok := obj.(*tenancyv1alpha1.WorkspaceType)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be a WorkspaceType, but is %T", obj))
+		return
+	}
+
+	defaultBindings := wt.Spec.DefaultAPIBindings
+	if wt.Status.Rollout != nil {
+		// workspaces outside the started waves of a rollout are upgraded according to the stable default APIBindings
+		defaultBindings = append(append([]tenancyv1alpha1.DefaultAPIBinding{}, defaultBindings...), wt.Status.Rollout.StableDefaultAPIBindings...)
+	}
+	automatic := false
+	for _, b := range defaultBindings {
+		automatic = automatic || b.UpgradePolicy == tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic
+	}
+	if !automatic {
+		return
+	}
+
+	list, err := u.listLogicalClusters()
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("error listing workspaces: %w", err))
+	}
+
+	for _, lc := range list {
+		logger := logging.WithObject(logger, lc)
+		u.enqueueLogicalCluster(lc, logger)
+	}
+}
+
+// enqueueAPIExport enqueues the workspaces binding a release of the APIExport, which might have published a
+// newer release.
+func (u *APIBindingUpgrader) enqueueAPIExport(obj interface{}, logger logr.Logger) {
+	export, ok := obj.(*apisv1alpha1.APIExport)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("obj is supposed to be an APIExport, but is %T", obj))
+		return
+	}
+	if len(export.Spec.Releases) == 0 {
+		return
+	}
+
+	bindings, err := u.getAPIBindingsByAPIExport(export)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	for _, binding := range bindings {
+		u.enqueueAPIBinding(binding, logging.WithObject(logger, export))
+	}
+}
+
+func (u *APIBindingUpgrader) startWorker(ctx context.Context) {
+	for u.processNextWorkItem(ctx) {
+	}
+}
+
+func (u *APIBindingUpgrader) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer u.queue.ShutDown()
+	logger := logging.WithReconciler(klog.FromContext(ctx), UpgraderControllerName)
+	ctx = klog.NewContext(ctx, logger)
+
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, u.startWorker, time.Second)
+	}
+	<-ctx.Done()
+}
+
+func (u *APIBindingUpgrader) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := u.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(1).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer u.queue.Done(key)
+
+	if err := u.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%s: failed to sync %q, err: %w", UpgraderControllerName, key, err))
+		u.queue.AddRateLimited(key)
+		return true
+	}
+
+	u.queue.Forget(key)
+	return true
+}
+
+func (u *APIBindingUpgrader) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "unable to decode key")
+		return nil
+	}
+
+	logicalCluster, err := u.getLogicalCluster(clusterName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get LogicalCluster from lister", "cluster", clusterName)
+		}
+
+		return nil // nothing we can do here
+	}
+
+	logger = logging.WithObject(logger, logicalCluster)
+	ctx = klog.NewContext(ctx, logger)
+
+	return u.reconcile(ctx, logicalCluster)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// reconcile bumps the APIBindings of the workspace, which were created for default APIBindings with the
+// Automatic upgrade policy, to the newest release of the APIExport matching the release constraint.
+// APIBindings are never moved to an older release.
+func (u *APIBindingUpgrader) reconcile(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) error {
+	if logicalCluster.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+		// the release is chosen by the apibinder initializer until the workspace is ready
+		return nil
+	}
+	annotationValue, found := logicalCluster.Annotations[v1beta1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return nil
+	}
+	wtCluster, wtName := logicalcluster.NewPath(annotationValue).Split()
+	if wtCluster.Empty() {
+		return nil
+	}
+	logger := klog.FromContext(ctx).WithValues(
+		"workspacetype.path", wtCluster.String(),
+		"workspacetype.name", wtName,
+	)
+
+	leafWT, err := u.getWorkspaceType(wtCluster, wtName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	wts, err := u.transitiveTypeResolver.Resolve(leafWT)
+	if err != nil {
+		logger.V(2).Info("not upgrading APIBindings, failed to resolve the transitive set of workspace types", "err", err)
+		return nil
+	}
+
+	clusterName := logicalcluster.From(logicalCluster)
+	var errs []error
+	for _, wt := range wts {
+		for _, defaultBinding := range wt.DefaultAPIBindingsFor(clusterName.String(), logicalCluster.Labels) {
+			if defaultBinding.UpgradePolicy != tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic || defaultBinding.ReleaseConstraint == "" {
+				continue
+			}
+			exportRef := defaultBinding.APIExportReference
+			if exportRef.Path == "" {
+				exportRef.Path = logicalcluster.From(wt).String()
+			}
+			logger := logger.WithValues("apiExport.path", exportRef.Path, "apiExport.name", exportRef.Export)
+
+			binding, err := u.getAPIBinding(clusterName, generateAPIBindingName(clusterName, exportRef.Path, exportRef.Export))
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if binding.Spec.Release == "" {
+				// bound to latestResourceSchemas, e.g. because the constraint was added later
+				continue
+			}
+
+			export, err := u.getAPIExport(logicalcluster.NewPath(exportRef.Path), exportRef.Export)
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			release, err := export.Spec.NewestReleaseMatching(defaultBinding.ReleaseConstraint)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid release constraint for APIExport %s|%s: %w", exportRef.Path, exportRef.Export, err))
+				continue
+			}
+			if release == "" || !isNewerRelease(release, binding.Spec.Release) {
+				continue
+			}
+
+			logger.V(2).Info("upgrading APIBinding to newer release", "apibinding", binding.Name, "from", binding.Spec.Release, "to", release)
+			if err := u.setAPIBindingRelease(ctx, binding, release); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// isNewerRelease returns whether release is newer than current. A current release that is not a
+// semantic version is considered older than any release.
+func isNewerRelease(release, current string) bool {
+	r, err := utilversion.ParseSemantic(release)
+	if err != nil {
+		return false
+	}
+	c, err := utilversion.ParseSemantic(current)
+	if err != nil {
+		return true
+	}
+	return c.LessThan(r)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestAPIBindingUpgraderReconcile(t *testing.T) {
+	t.Parallel()
+
+	wt := func(constraint string, policy tenancyv1alpha1.DefaultAPIBindingUpgradePolicy) *tenancyv1alpha1.WorkspaceType {
+		return &tenancyv1alpha1.WorkspaceType{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "team",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root-org"},
+			},
+			Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+				DefaultAPIBindings: []tenancyv1alpha1.DefaultAPIBinding{{
+					APIExportReference: tenancyv1alpha1.APIExportReference{Path: "root:providers", Export: "widgets"},
+					ReleaseConstraint:  constraint,
+					UpgradePolicy:      policy,
+				}},
+			},
+		}
+	}
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+		Spec: apisv1alpha1.APIExportSpec{Releases: []apisv1alpha1.APIExportRelease{
+			{Version: "v1.0.0"},
+			{Version: "v1.1.0"},
+			{Version: "v1.2.0"},
+			{Version: "v2.0.0"},
+		}},
+	}
+	bindingName := generateAPIBindingName("root-org-team", "root:providers", "widgets")
+
+	tests := map[string]struct {
+		wt       *tenancyv1alpha1.WorkspaceType
+		phase    corev1alpha1.LogicalClusterPhaseType
+		release  string
+		noExport bool
+
+		wantRelease string
+		wantError   bool
+	}{
+		"pinned": {
+			wt:      wt(">=v1.0.0 <v2.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradePinned),
+			release: "v1.0.0",
+		},
+		"unset policy is pinned": {
+			wt:      wt(">=v1.0.0 <v2.0.0", ""),
+			release: "v1.0.0",
+		},
+		"automatic upgrade within constraint": {
+			wt:          wt(">=v1.0.0 <v2.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			release:     "v1.0.0",
+			wantRelease: "v1.2.0",
+		},
+		"already newest": {
+			wt:      wt(">=v1.0.0 <v2.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			release: "v1.2.0",
+		},
+		"no downgrade": {
+			wt:      wt("<v1.2.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			release: "v1.2.0",
+		},
+		"bound to latest": {
+			wt: wt(">=v1.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+		},
+		"workspace initializing": {
+			wt:      wt(">=v1.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			phase:   corev1alpha1.LogicalClusterPhaseInitializing,
+			release: "v1.0.0",
+		},
+		"export gone": {
+			wt:       wt(">=v1.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			release:  "v1.0.0",
+			noExport: true,
+		},
+		"invalid constraint": {
+			wt:        wt("~v1.0.0", tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic),
+			release:   "v1.0.0",
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var upgradedTo string
+			u := &APIBindingUpgrader{
+				getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					return tc.wt, nil
+				},
+				getAPIBinding: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error) {
+					require.Equal(t, bindingName, name)
+					return &apisv1alpha1.APIBinding{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       apisv1alpha1.APIBindingSpec{Release: tc.release},
+					}, nil
+				},
+				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+					if tc.noExport {
+						return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+					}
+					return export, nil
+				},
+				setAPIBindingRelease: func(ctx context.Context, binding *apisv1alpha1.APIBinding, release string) error {
+					upgradedTo = release
+					return nil
+				},
+				transitiveTypeResolver: staticTypeResolver{tc.wt},
+			}
+
+			phase := tc.phase
+			if phase == "" {
+				phase = corev1alpha1.LogicalClusterPhaseReady
+			}
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:            "root-org-team",
+						v1beta1.LogicalClusterTypeAnnotationKey: "root:org:team",
+					},
+				},
+				Status: corev1alpha1.LogicalClusterStatus{Phase: phase},
+			}
+
+			err := u.reconcile(context.Background(), logicalCluster)
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantRelease, upgradedTo)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	admission "github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	TemplateControllerName = "kcp-template-initializer"
+)
+
+// NewTemplateInitializer returns a new controller which creates the objects of the WorkspaceTemplates
+// referenced by the WorkspaceType of new Workspaces.
+func NewTemplateInitializer(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	workspaceTypeInformer, globalWorkspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
+	workspaceTemplateInformer tenancyv1alpha1informers.WorkspaceTemplateClusterInformer,
+) (*TemplateInitializer, error) {
+	c := &TemplateInitializer{
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), TemplateControllerName),
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+			t, err := indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), workspaceTypeInformer.Informer().GetIndexer(), path, name)
+			if apierrors.IsNotFound(err) {
+				return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), globalWorkspaceTypeInformer.Informer().GetIndexer(), path, name)
+			}
+			return t, err
+		},
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+
+		// TODO: WorkspaceTemplates are not replicated to the cache server yet, hence only
+		// templates on the same shard as the new workspace are found.
+		getWorkspaceTemplate: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceTemplate, error) {
+			return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceTemplate](tenancyv1alpha1.Resource("workspacetemplates"), workspaceTemplateInformer.Informer().GetIndexer(), path, name)
+		},
+
+		resourceFor: func(ctx context.Context, clusterName logicalcluster.Path, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+			resources, err := kubeClusterClient.Cluster(clusterName).Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+			if err != nil {
+				return schema.GroupVersionResource{}, false, err
+			}
+			for _, r := range resources.APIResources {
+				if r.Kind == gvk.Kind && !strings.Contains(r.Name, "/") {
+					return gvk.GroupVersion().WithResource(r.Name), r.Namespaced, nil
+				}
+			}
+			return schema.GroupVersionResource{}, false, apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, "")
+		},
+		createObject: func(ctx context.Context, clusterName logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+			_, err := dynamicClusterClient.Cluster(clusterName).Resource(gvr).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+			return err
+		},
+
+		commit: committer.NewCommitter[*corev1alpha1.LogicalCluster, corev1alpha1client.LogicalClusterInterface, *corev1alpha1.LogicalClusterSpec, *corev1alpha1.LogicalClusterStatus](kcpClusterClient.CoreV1alpha1().LogicalClusters()),
+	}
+
+	c.transitiveTypeResolver = admission.NewTransitiveTypeResolver(c.getWorkspaceType)
+
+	logger := logging.WithReconciler(klog.Background(), TemplateControllerName)
+
+	indexers.AddIfNotPresentOrDie(workspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	indexers.AddIfNotPresentOrDie(globalWorkspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	indexers.AddIfNotPresentOrDie(workspaceTemplateInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueLogicalCluster(obj, logger)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueLogicalCluster(obj, logger)
+		},
+	})
+
+	for _, informer := range []cache.SharedIndexInformer{
+		workspaceTypeInformer.Informer(),
+		globalWorkspaceTypeInformer.Informer(),
+		workspaceTemplateInformer.Informer(),
+	} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueAllLogicalClusters(obj, logger)
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				c.enqueueAllLogicalClusters(obj, logger)
+			},
+		})
+	}
+
+	return c, nil
+}
+
+// TemplateInitializer is a controller which creates the objects of the WorkspaceTemplates
+// referenced by the WorkspaceType of new Workspaces.
+type TemplateInitializer struct {
+	queue workqueue.RateLimitingInterface
+
+	getLogicalCluster   func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getWorkspaceType    func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+	listLogicalClusters func() ([]*corev1alpha1.LogicalCluster, error)
+
+	getWorkspaceTemplate func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceTemplate, error)
+
+	resourceFor  func(ctx context.Context, clusterName logicalcluster.Path, gvk schema.GroupVersionKind) (gvr schema.GroupVersionResource, namespaced bool, err error)
+	createObject func(ctx context.Context, clusterName logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error
+
+	transitiveTypeResolver transitiveTypeResolver
+
+	// commit creates a patch and submits it, if needed.
+	commit func(ctx context.Context, new, old *logicalClusterResource) error
+}
+
+func (t *TemplateInitializer) enqueueLogicalCluster(obj interface{}, logger logr.Logger) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(2).Info("queueing LogicalCluster")
+	t.queue.Add(key)
+}
+
+// enqueueAllLogicalClusters enqueues all workspaces (which are only those that are initializing, because of
+// how the informer is supposed to be configured) whenever a WorkspaceType or WorkspaceTemplate changes. This
+// picks up templates that show up after the workspace was created, and fixes to broken templates.
+func (t *TemplateInitializer) enqueueAllLogicalClusters(obj interface{}, logger logr.Logger) {
+	if wt, ok := obj.(*tenancyv1alpha1.WorkspaceType); ok && wt.Spec.Template == nil {
+		return
+	}
+
+	list, err := t.listLogicalClusters()
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("error listing workspaces: %w", err))
+	}
+
+	for _, lc := range list {
+		logger := logging.WithObject(logger, lc)
+		t.enqueueLogicalCluster(lc, logger)
+	}
+}
+
+func (t *TemplateInitializer) startWorker(ctx context.Context) {
+	for t.processNextWorkItem(ctx) {
+	}
+}
+
+func (t *TemplateInitializer) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer t.queue.ShutDown()
+	logger := logging.WithReconciler(klog.FromContext(ctx), TemplateControllerName)
+	ctx = klog.NewContext(ctx, logger)
+
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, t.startWorker, time.Second)
+	}
+	<-ctx.Done()
+}
+
+func (t *TemplateInitializer) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := t.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(1).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer t.queue.Done(key)
+
+	if err := t.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%s: failed to sync %q, err: %w", TemplateControllerName, key, err))
+		t.queue.AddRateLimited(key)
+		return true
+	}
+
+	t.queue.Forget(key)
+	return true
+}
+
+func (t *TemplateInitializer) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		logger.Error(err, "unable to decode key")
+		return nil
+	}
+
+	logicalCluster, err := t.getLogicalCluster(clusterName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get LogicalCluster from lister", "cluster", clusterName)
+		}
+
+		return nil // nothing we can do here
+	}
+
+	old := logicalCluster
+	logicalCluster = logicalCluster.DeepCopy()
+
+	logger = logging.WithObject(logger, logicalCluster)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	err = t.reconcile(ctx, logicalCluster)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &logicalClusterResource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &logicalClusterResource{ObjectMeta: logicalCluster.ObjectMeta, Spec: &logicalCluster.Spec, Status: &logicalCluster.Status}
+	if err := t.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
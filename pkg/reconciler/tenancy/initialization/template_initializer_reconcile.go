@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (t *TemplateInitializer) reconcile(ctx context.Context, logicalCluster *corev1alpha1.LogicalCluster) error {
+	annotationValue, found := logicalCluster.Annotations[v1beta1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return nil
+	}
+	wtCluster, wtName := logicalcluster.NewPath(annotationValue).Split()
+	if wtCluster.Empty() {
+		return nil
+	}
+	logger := klog.FromContext(ctx).WithValues(
+		"workspacetype.path", wtCluster.String(),
+		"workspacetype.name", wtName,
+	)
+
+	clusterName := logicalcluster.From(logicalCluster)
+	logger.V(2).Info("applying WorkspaceTemplates to workspace")
+
+	leafWT, err := t.getWorkspaceType(wtCluster, wtName)
+	if err != nil {
+		logger.Error(err, "error getting WorkspaceType")
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceTemplateApplied,
+			tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+			conditionsv1alpha1.ConditionSeverityError,
+			"error getting WorkspaceType %s|%s: %v",
+			wtCluster.String(), wtName,
+			err,
+		)
+
+		return nil
+	}
+
+	wts, err := t.transitiveTypeResolver.Resolve(leafWT)
+	if err != nil {
+		logger.Error(err, "error resolving transitive types")
+
+		conditions.MarkFalse(
+			logicalCluster,
+			tenancyv1alpha1.WorkspaceTemplateApplied,
+			tenancyv1alpha1.WorkspaceInitializedWorkspaceTypeInvalid,
+			conditionsv1alpha1.ConditionSeverityError,
+			"error resolving transitive set of workspace types: %v",
+			err,
+		)
+
+		return nil
+	}
+
+	// Base types come first, hence their templates are applied before the ones of the leaf type.
+	for _, wt := range wts {
+		if wt.Spec.Template == nil {
+			continue
+		}
+		templatePath := logicalcluster.NewPath(wt.Spec.Template.Path)
+		if templatePath.Empty() {
+			templatePath = logicalcluster.From(wt).Path()
+		}
+		logger := logging.WithObject(logger, wt).WithValues("workspacetemplate.path", templatePath.String(), "workspacetemplate.name", wt.Spec.Template.Name)
+
+		template, err := t.getWorkspaceTemplate(templatePath, wt.Spec.Template.Name)
+		if err != nil {
+			logger.Error(err, "error getting WorkspaceTemplate")
+
+			conditions.MarkFalse(
+				logicalCluster,
+				tenancyv1alpha1.WorkspaceTemplateApplied,
+				tenancyv1alpha1.WorkspaceTemplateNotFoundReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"error getting WorkspaceTemplate %s|%s: %v",
+				templatePath.String(), wt.Spec.Template.Name,
+				err,
+			)
+
+			// The template might show up later, e.g. when it is created after the type.
+			return err
+		}
+
+		for i, raw := range template.Spec.Objects {
+			obj := &unstructured.Unstructured{}
+			if err := json.Unmarshal(raw.Raw, &obj.Object); err != nil || obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+				if err == nil {
+					err = fmt.Errorf("apiVersion, kind and metadata.name must be set")
+				}
+				conditions.MarkFalse(
+					logicalCluster,
+					tenancyv1alpha1.WorkspaceTemplateApplied,
+					tenancyv1alpha1.WorkspaceTemplateInvalidObjectReason,
+					conditionsv1alpha1.ConditionSeverityError,
+					"invalid object %d of WorkspaceTemplate %s|%s: %v",
+					i, templatePath.String(), template.Name,
+					err,
+				)
+
+				// Wait for the template to be fixed, which requeues.
+				return nil
+			}
+			gvk := obj.GroupVersionKind()
+			logger := logger.WithValues("gvk", gvk.String(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+			ctx := klog.NewContext(ctx, logger)
+
+			gvr, namespaced, err := t.resourceFor(ctx, clusterName.Path(), gvk)
+			if err != nil {
+				conditions.MarkFalse(
+					logicalCluster,
+					tenancyv1alpha1.WorkspaceTemplateApplied,
+					tenancyv1alpha1.WorkspaceTemplateInvalidObjectReason,
+					conditionsv1alpha1.ConditionSeverityError,
+					"unable to find resource for %s of WorkspaceTemplate %s|%s: %v",
+					gvk.String(), templatePath.String(), template.Name,
+					err,
+				)
+
+				// Resources might be served later, e.g. after an APIBinding created before is bound.
+				return err
+			}
+			switch {
+			case namespaced && obj.GetNamespace() == "":
+				obj.SetNamespace(metav1.NamespaceDefault)
+			case !namespaced:
+				obj.SetNamespace("")
+			}
+
+			logger.V(2).Info("trying to create object")
+			if err := t.createObject(ctx, clusterName.Path(), gvr, obj); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					logger.V(4).Info("object already exists - skipping creation")
+					continue
+				}
+
+				conditions.MarkFalse(
+					logicalCluster,
+					tenancyv1alpha1.WorkspaceTemplateApplied,
+					tenancyv1alpha1.WorkspaceTemplateCreateErrorReason,
+					conditionsv1alpha1.ConditionSeverityError,
+					"error creating %s %s of WorkspaceTemplate %s|%s: %v",
+					gvr.Resource, obj.GetName(), templatePath.String(), template.Name,
+					err,
+				)
+
+				// Later objects might depend on this one, e.g. on its namespace. Hence, stop here.
+				return err
+			}
+			logger.V(2).Info("created object")
+		}
+	}
+
+	conditions.MarkTrue(logicalCluster, tenancyv1alpha1.WorkspaceTemplateApplied)
+	logicalCluster.Status.Initializers = initialization.EnsureInitializerAbsent(tenancyv1alpha1.WorkspaceTemplateInitializer, logicalCluster.Status.Initializers)
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+type staticTypeResolver []*tenancyv1alpha1.WorkspaceType
+
+func (r staticTypeResolver) Resolve(_ *tenancyv1alpha1.WorkspaceType) ([]*tenancyv1alpha1.WorkspaceType, error) {
+	return r, nil
+}
+
+func TestTemplateInitializerReconcile(t *testing.T) {
+	t.Parallel()
+
+	wt := func(name string, template *tenancyv1alpha1.WorkspaceTemplateReference) *tenancyv1alpha1.WorkspaceType {
+		return &tenancyv1alpha1.WorkspaceType{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "root-org"},
+			},
+			Spec: tenancyv1alpha1.WorkspaceTypeSpec{Template: template},
+		}
+	}
+	template := func(name string, objs ...string) *tenancyv1alpha1.WorkspaceTemplate {
+		tmpl := &tenancyv1alpha1.WorkspaceTemplate{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, obj := range objs {
+			tmpl.Spec.Objects = append(tmpl.Spec.Objects, runtime.RawExtension{Raw: []byte(obj)})
+		}
+		return tmpl
+	}
+
+	namespace := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team"}}`
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"team"}}`
+	defaultConfigMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"}}`
+	widget := `{"apiVersion":"example.io/v1","kind":"Widget","metadata":{"name":"w"}}`
+
+	tests := map[string]struct {
+		types     []*tenancyv1alpha1.WorkspaceType
+		templates map[string]*tenancyv1alpha1.WorkspaceTemplate
+		existing  map[string]bool
+
+		wantCreated     []string
+		wantError       bool
+		wantInitializer bool
+		wantReason      string
+	}{
+		"no template": {
+			types:       []*tenancyv1alpha1.WorkspaceType{wt("team", nil)},
+			wantCreated: nil,
+		},
+		"objects are created in order, base type first": {
+			types: []*tenancyv1alpha1.WorkspaceType{
+				wt("base", &tenancyv1alpha1.WorkspaceTemplateReference{Path: "root:shared", Name: "base"}),
+				wt("team", &tenancyv1alpha1.WorkspaceTemplateReference{Name: "team"}),
+			},
+			templates: map[string]*tenancyv1alpha1.WorkspaceTemplate{
+				"root:shared|base": template("base", namespace),
+				"root-org|team":    template("team", configMap, defaultConfigMap),
+			},
+			wantCreated: []string{"namespaces//team", "configmaps/team/settings", "configmaps/default/settings"},
+		},
+		"existing objects are skipped": {
+			types:       []*tenancyv1alpha1.WorkspaceType{wt("team", &tenancyv1alpha1.WorkspaceTemplateReference{Name: "team"})},
+			templates:   map[string]*tenancyv1alpha1.WorkspaceTemplate{"root-org|team": template("team", namespace, configMap)},
+			existing:    map[string]bool{"namespaces//team": true},
+			wantCreated: []string{"configmaps/team/settings"},
+		},
+		"missing template": {
+			types:           []*tenancyv1alpha1.WorkspaceType{wt("team", &tenancyv1alpha1.WorkspaceTemplateReference{Name: "team"})},
+			wantError:       true,
+			wantInitializer: true,
+			wantReason:      tenancyv1alpha1.WorkspaceTemplateNotFoundReason,
+		},
+		"invalid object": {
+			types:           []*tenancyv1alpha1.WorkspaceType{wt("team", &tenancyv1alpha1.WorkspaceTemplateReference{Name: "team"})},
+			templates:       map[string]*tenancyv1alpha1.WorkspaceTemplate{"root-org|team": template("team", `{"kind":"Namespace"}`)},
+			wantInitializer: true,
+			wantReason:      tenancyv1alpha1.WorkspaceTemplateInvalidObjectReason,
+		},
+		"resource not served stops at the object": {
+			types:           []*tenancyv1alpha1.WorkspaceType{wt("team", &tenancyv1alpha1.WorkspaceTemplateReference{Name: "team"})},
+			templates:       map[string]*tenancyv1alpha1.WorkspaceTemplate{"root-org|team": template("team", namespace, widget, configMap)},
+			wantCreated:     []string{"namespaces//team"},
+			wantError:       true,
+			wantInitializer: true,
+			wantReason:      tenancyv1alpha1.WorkspaceTemplateInvalidObjectReason,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var created []string
+			ti := &TemplateInitializer{
+				getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					return tc.types[len(tc.types)-1], nil
+				},
+				getWorkspaceTemplate: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceTemplate, error) {
+					if tmpl, ok := tc.templates[path.String()+"|"+name]; ok {
+						return tmpl, nil
+					}
+					return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetemplates"), name)
+				},
+				resourceFor: func(ctx context.Context, clusterName logicalcluster.Path, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+					switch gvk.Kind {
+					case "Namespace":
+						return gvk.GroupVersion().WithResource("namespaces"), false, nil
+					case "ConfigMap":
+						return gvk.GroupVersion().WithResource("configmaps"), true, nil
+					}
+					return schema.GroupVersionResource{}, false, apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, "")
+				},
+				createObject: func(ctx context.Context, clusterName logicalcluster.Path, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+					require.Equal(t, "root:org:team", clusterName.String())
+					key := gvr.Resource + "/" + obj.GetNamespace() + "/" + obj.GetName()
+					if tc.existing[key] {
+						return apierrors.NewAlreadyExists(gvr.GroupResource(), obj.GetName())
+					}
+					created = append(created, key)
+					return nil
+				},
+				transitiveTypeResolver: staticTypeResolver(tc.types),
+			}
+
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:            "root:org:team",
+						v1beta1.LogicalClusterTypeAnnotationKey: "root:org:team",
+					},
+				},
+				Status: corev1alpha1.LogicalClusterStatus{
+					Initializers: []corev1alpha1.LogicalClusterInitializer{tenancyv1alpha1.WorkspaceTemplateInitializer},
+				},
+			}
+
+			err := ti.reconcile(context.Background(), logicalCluster)
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantCreated, created)
+
+			if tc.wantInitializer {
+				require.Contains(t, logicalCluster.Status.Initializers, tenancyv1alpha1.WorkspaceTemplateInitializer)
+				require.True(t, conditions.IsFalse(logicalCluster, tenancyv1alpha1.WorkspaceTemplateApplied))
+				require.Equal(t, tc.wantReason, conditions.GetReason(logicalCluster, tenancyv1alpha1.WorkspaceTemplateApplied))
+			} else {
+				require.NotContains(t, logicalCluster.Status.Initializers, tenancyv1alpha1.WorkspaceTemplateInitializer)
+				require.True(t, conditions.IsTrue(logicalCluster, tenancyv1alpha1.WorkspaceTemplateApplied))
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitincreaserequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-limitincreaserequest"
+)
+
+// NewController returns a new controller applying approved LimitIncreaseRequests to the
+// referenced ResourceQuota. The decision itself is guarded by admission, which requires the
+// approve verb in the parent workspace.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	limitIncreaseRequestInformer tenancyv1alpha1informers.LimitIncreaseRequestClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: controllerSwitch.Queue(queue),
+		getLimitIncreaseRequest: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.LimitIncreaseRequest, error) {
+			return limitIncreaseRequestInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getResourceQuota: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.ResourceQuota, error) {
+			return kubeClusterClient.Cluster(clusterName.Path()).CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		updateResourceQuota: func(ctx context.Context, clusterName logicalcluster.Name, quota *corev1.ResourceQuota) error {
+			_, err := kubeClusterClient.Cluster(clusterName.Path()).CoreV1().ResourceQuotas(quota.Namespace).Update(ctx, quota, metav1.UpdateOptions{})
+			return err
+		},
+		now:    time.Now,
+		commit: committer.NewCommitter[*LimitIncreaseRequest, Patcher, *LimitIncreaseRequestSpec, *LimitIncreaseRequestStatus](kcpClusterClient.TenancyV1alpha1().LimitIncreaseRequests()),
+	}
+
+	limitIncreaseRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueLimitIncreaseRequest(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueLimitIncreaseRequest(obj) },
+	})
+
+	return c, nil
+}
+
+type LimitIncreaseRequest = tenancyv1alpha1.LimitIncreaseRequest
+type LimitIncreaseRequestSpec = tenancyv1alpha1.LimitIncreaseRequestSpec
+type LimitIncreaseRequestStatus = tenancyv1alpha1.LimitIncreaseRequestStatus
+type Patcher = tenancyv1alpha1client.LimitIncreaseRequestInterface
+type Resource = committer.Resource[*LimitIncreaseRequestSpec, *LimitIncreaseRequestStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller applies the hard limits of approved LimitIncreaseRequests to ResourceQuotas.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getLimitIncreaseRequest func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.LimitIncreaseRequest, error)
+	getResourceQuota        func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.ResourceQuota, error)
+	updateResourceQuota     func(ctx context.Context, clusterName logicalcluster.Name, quota *corev1.ResourceQuota) error
+	now                     func() time.Time
+
+	commit CommitFunc
+}
+
+// enqueueLimitIncreaseRequest enqueues a LimitIncreaseRequest.
+func (c *controller) enqueueLimitIncreaseRequest(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing LimitIncreaseRequest")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getLimitIncreaseRequest(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Regardless of whether reconcile returned an error or not, always try to patch status if needed. Return the
+	// reconciliation error at the end.
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitincreaserequest
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, request *tenancyv1alpha1.LimitIncreaseRequest) error {
+	logger := klog.FromContext(ctx)
+
+	switch request.Spec.Decision {
+	case "":
+		request.Status.Phase = tenancyv1alpha1.LimitIncreaseRequestPhasePending
+		conditions.MarkFalse(
+			request,
+			tenancyv1alpha1.LimitIncreaseRequestApplied,
+			tenancyv1alpha1.LimitIncreaseRequestWaitingForDecisionReason,
+			conditionsv1alpha1.ConditionSeverityInfo,
+			"Waiting for a decision in the parent workspace",
+		)
+		return nil
+	case tenancyv1alpha1.LimitIncreaseRequestDenied:
+		request.Status.Phase = tenancyv1alpha1.LimitIncreaseRequestPhaseDenied
+		conditions.MarkFalse(
+			request,
+			tenancyv1alpha1.LimitIncreaseRequestApplied,
+			tenancyv1alpha1.LimitIncreaseRequestDeniedReason,
+			conditionsv1alpha1.ConditionSeverityInfo,
+			"The request has been denied",
+		)
+		return nil
+	}
+
+	// the requested limits are applied once, later changes of the quota are not overridden
+	if request.Status.Phase == tenancyv1alpha1.LimitIncreaseRequestPhaseApplied {
+		return nil
+	}
+	request.Status.Phase = tenancyv1alpha1.LimitIncreaseRequestPhasePending
+
+	clusterName := logicalcluster.From(request)
+	ref := request.Spec.ResourceQuota
+	quota, err := c.getResourceQuota(ctx, clusterName, ref.Namespace, ref.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			conditions.MarkFalse(
+				request,
+				tenancyv1alpha1.LimitIncreaseRequestApplied,
+				tenancyv1alpha1.LimitIncreaseRequestQuotaNotFoundReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"ResourceQuota %s/%s not found",
+				ref.Namespace, ref.Name,
+			)
+		}
+		return err
+	}
+
+	quota = quota.DeepCopy()
+	if quota.Spec.Hard == nil {
+		quota.Spec.Hard = corev1.ResourceList{}
+	}
+	for name, quantity := range request.Spec.Hard {
+		quota.Spec.Hard[name] = quantity
+	}
+	logger.V(2).Info("applying limit increase to ResourceQuota", "resourcequota", ref.Namespace+"/"+ref.Name)
+	if err := c.updateResourceQuota(ctx, clusterName, quota); err != nil {
+		conditions.MarkFalse(
+			request,
+			tenancyv1alpha1.LimitIncreaseRequestApplied,
+			tenancyv1alpha1.LimitIncreaseRequestUpdateFailedReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Failed to update ResourceQuota %s/%s: %v",
+			ref.Namespace, ref.Name, err,
+		)
+		return err
+	}
+
+	now := metav1.NewTime(c.now())
+	request.Status.Phase = tenancyv1alpha1.LimitIncreaseRequestPhaseApplied
+	request.Status.AppliedTime = &now
+	conditions.MarkTrue(request, tenancyv1alpha1.LimitIncreaseRequestApplied)
+
+	return nil
+}
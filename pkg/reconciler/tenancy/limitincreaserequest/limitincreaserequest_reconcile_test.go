@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitincreaserequest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+var now = time.Date(2023, 1, 21, 12, 0, 0, 0, time.UTC)
+
+func TestReconcile(t *testing.T) {
+	quota := func() *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "quota"},
+			Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+				corev1.ResourcePods:     resource.MustParse("10"),
+				corev1.ResourceServices: resource.MustParse("5"),
+			}},
+		}
+	}
+
+	tests := map[string]struct {
+		decision  tenancyv1alpha1.LimitIncreaseRequestDecision
+		phase     tenancyv1alpha1.LimitIncreaseRequestPhase
+		quota     *corev1.ResourceQuota
+		updateErr error
+
+		wantErr       bool
+		wantPhase     tenancyv1alpha1.LimitIncreaseRequestPhase
+		wantReason    string
+		wantApplied   bool
+		wantQuotaHard corev1.ResourceList
+	}{
+		"pending": {
+			quota:      quota(),
+			wantPhase:  tenancyv1alpha1.LimitIncreaseRequestPhasePending,
+			wantReason: tenancyv1alpha1.LimitIncreaseRequestWaitingForDecisionReason,
+		},
+		"denied": {
+			decision:   tenancyv1alpha1.LimitIncreaseRequestDenied,
+			quota:      quota(),
+			wantPhase:  tenancyv1alpha1.LimitIncreaseRequestPhaseDenied,
+			wantReason: tenancyv1alpha1.LimitIncreaseRequestDeniedReason,
+		},
+		"approved": {
+			decision:    tenancyv1alpha1.LimitIncreaseRequestApproved,
+			quota:       quota(),
+			wantPhase:   tenancyv1alpha1.LimitIncreaseRequestPhaseApplied,
+			wantApplied: true,
+			wantQuotaHard: corev1.ResourceList{
+				corev1.ResourcePods:     resource.MustParse("20"),
+				corev1.ResourceServices: resource.MustParse("5"),
+			},
+		},
+		"approved, already applied": {
+			decision:    tenancyv1alpha1.LimitIncreaseRequestApproved,
+			phase:       tenancyv1alpha1.LimitIncreaseRequestPhaseApplied,
+			quota:       quota(),
+			wantPhase:   tenancyv1alpha1.LimitIncreaseRequestPhaseApplied,
+			wantApplied: true,
+		},
+		"approved, quota not found": {
+			decision:   tenancyv1alpha1.LimitIncreaseRequestApproved,
+			wantErr:    true,
+			wantPhase:  tenancyv1alpha1.LimitIncreaseRequestPhasePending,
+			wantReason: tenancyv1alpha1.LimitIncreaseRequestQuotaNotFoundReason,
+		},
+		"approved, update failed": {
+			decision:   tenancyv1alpha1.LimitIncreaseRequestApproved,
+			quota:      quota(),
+			updateErr:  fmt.Errorf("conflict"),
+			wantErr:    true,
+			wantPhase:  tenancyv1alpha1.LimitIncreaseRequestPhasePending,
+			wantReason: tenancyv1alpha1.LimitIncreaseRequestUpdateFailedReason,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var updated *corev1.ResourceQuota
+			c := &controller{
+				getResourceQuota: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.ResourceQuota, error) {
+					if tt.quota == nil {
+						return nil, errors.NewNotFound(corev1.Resource("resourcequotas"), name)
+					}
+					return tt.quota, nil
+				},
+				updateResourceQuota: func(ctx context.Context, clusterName logicalcluster.Name, quota *corev1.ResourceQuota) error {
+					if tt.updateErr != nil {
+						return tt.updateErr
+					}
+					updated = quota
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			request := &tenancyv1alpha1.LimitIncreaseRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "more-pods",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org:ws"},
+				},
+				Spec: tenancyv1alpha1.LimitIncreaseRequestSpec{
+					ResourceQuota: tenancyv1alpha1.LimitIncreaseRequestQuotaReference{Namespace: "default", Name: "quota"},
+					Hard:          corev1.ResourceList{corev1.ResourcePods: resource.MustParse("20")},
+					Decision:      tt.decision,
+				},
+				Status: tenancyv1alpha1.LimitIncreaseRequestStatus{Phase: tt.phase},
+			}
+			if tt.phase == tenancyv1alpha1.LimitIncreaseRequestPhaseApplied {
+				conditions.MarkTrue(request, tenancyv1alpha1.LimitIncreaseRequestApplied)
+			}
+
+			err := c.reconcile(context.Background(), request)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantPhase, request.Status.Phase)
+			require.Equal(t, tt.wantApplied, conditions.IsTrue(request, tenancyv1alpha1.LimitIncreaseRequestApplied))
+			if tt.wantReason != "" {
+				require.Equal(t, tt.wantReason, conditions.GetReason(request, tenancyv1alpha1.LimitIncreaseRequestApplied))
+			}
+			if tt.wantQuotaHard != nil {
+				require.NotNil(t, updated)
+				require.Equal(t, tt.wantQuotaHard, updated.Spec.Hard)
+				require.Equal(t, now, request.Status.AppliedTime.Time)
+			} else {
+				require.Nil(t, updated)
+			}
+		})
+	}
+}
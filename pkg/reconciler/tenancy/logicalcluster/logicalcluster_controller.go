@@ -38,11 +38,12 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 const (
@@ -57,11 +58,12 @@ func NewController(
 	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
 	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
 	clusterRoleBindingInformer kcprbacinformers.ClusterRoleBindingClusterInformer,
+	controllerSwitch *controllerswitch.Switch,
 ) *Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 
 	c := &Controller{
-		queue:                    queue,
+		queue:                    controllerSwitch.Queue(queue),
 		kubeClusterClient:        kubeClusterClient,
 		logicalClusterLister:     logicalClusterInformer.Lister(),
 		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
@@ -237,12 +239,24 @@ func (c *Controller) process(ctx context.Context, key string) error {
 		return err
 	}
 
-	if equality.Semantic.DeepEqual(old.Subjects, newBinding.Subjects) == equality.Semantic.DeepEqual(old.RoleRef, newBinding.RoleRef) {
+	if equality.Semantic.DeepEqual(old.Subjects, newBinding.Subjects) && equality.Semantic.DeepEqual(old.RoleRef, newBinding.RoleRef) {
 		return nil
 	}
 
-	old.Subjects = newBinding.Subjects
-	old.RoleRef = newBinding.RoleRef
-	_, err = c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Update(ctx, newBinding, metav1.UpdateOptions{})
+	// the roleRef is immutable, hence the binding is recreated if it changed.
+	if !equality.Semantic.DeepEqual(old.RoleRef, newBinding.RoleRef) {
+		logger.Info("recreating ClusterRoleBinding with changed roleRef", "name", workspaceAdminClusterRoleBindingName)
+		if err := c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Delete(ctx, workspaceAdminClusterRoleBindingName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Create(ctx, newBinding, metav1.CreateOptions{})
+		return err
+	}
+
+	// e.g. after an ownership transfer of the workspace
+	logger.Info("updating ClusterRoleBinding subjects to the owner", "name", workspaceAdminClusterRoleBindingName, "owner", userInfo.Username)
+	updated := old.DeepCopy()
+	updated.Subjects = newBinding.Subjects
+	_, err = c.kubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoleBindings().Update(ctx, updated, metav1.UpdateOptions{})
 	return err
 }
@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	kcpmetadata "github.com/kcp-dev/client-go/metadata"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-retention"
+
+	// runInterval is the interval in which every RetentionPolicy is executed.
+	runInterval = 5 * time.Minute
+)
+
+// NewController returns a new controller executing RetentionPolicies. Objects are listed
+// and deleted through the metadata client, such that any resource can be subject to a policy.
+// Before, the permissions of the user who last changed the policy are checked via
+// SubjectAccessReviews.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	metadataClient kcpmetadata.ClusterInterface,
+	retentionPolicyInformer tenancyv1alpha1informers.RetentionPolicyClusterInformer,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+		getRetentionPolicy: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.RetentionPolicy, error) {
+			return retentionPolicyInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*metav1.PartialObjectMetadataList, error) {
+			return metadataClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		},
+		deleteObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string, uid types.UID) error {
+			background := metav1.DeletePropagationBackground
+			opts := metav1.DeleteOptions{
+				PropagationPolicy: &background,
+				Preconditions:     &metav1.Preconditions{UID: &uid},
+			}
+			return metadataClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(namespace).Delete(ctx, name, opts)
+		},
+		authorize: func(ctx context.Context, clusterName logicalcluster.Name, attr authorizer.Attributes) (authorizer.Decision, error) {
+			authz, err := delegated.NewDelegatedAuthorizer(clusterName, kubeClusterClient)
+			if err != nil {
+				return authorizer.DecisionNoOpinion, err
+			}
+			decision, _, err := authz.Authorize(ctx, attr)
+			return decision, err
+		},
+		now:    time.Now,
+		commit: committer.NewCommitter[*RetentionPolicy, Patcher, *RetentionPolicySpec, *RetentionPolicyStatus](kcpClusterClient.TenancyV1alpha1().RetentionPolicies()),
+	}
+
+	retentionPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueRetentionPolicy(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// status updates are caused by executing the policy and must not trigger another run
+			oldPolicy, ok := oldObj.(*tenancyv1alpha1.RetentionPolicy)
+			if !ok {
+				return
+			}
+			newPolicy, ok := newObj.(*tenancyv1alpha1.RetentionPolicy)
+			if !ok {
+				return
+			}
+			if oldPolicy.Generation != newPolicy.Generation {
+				c.enqueueRetentionPolicy(newObj)
+			}
+		},
+	})
+
+	return c, nil
+}
+
+type RetentionPolicy = tenancyv1alpha1.RetentionPolicy
+type RetentionPolicySpec = tenancyv1alpha1.RetentionPolicySpec
+type RetentionPolicyStatus = tenancyv1alpha1.RetentionPolicyStatus
+type Patcher = tenancyv1alpha1client.RetentionPolicyInterface
+type Resource = committer.Resource[*RetentionPolicySpec, *RetentionPolicyStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller executes RetentionPolicies periodically, deleting the objects that are expired
+// according to the policy, or reporting them in status if the policy is in dry-run mode.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getRetentionPolicy func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.RetentionPolicy, error)
+	listObjects        func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*metav1.PartialObjectMetadataList, error)
+	deleteObject       func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string, uid types.UID) error
+	authorize          func(ctx context.Context, clusterName logicalcluster.Name, attr authorizer.Attributes) (authorizer.Decision, error)
+	now                func() time.Time
+
+	commit CommitFunc
+}
+
+// enqueueRetentionPolicy enqueues a RetentionPolicy.
+func (c *controller) enqueueRetentionPolicy(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing RetentionPolicy")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getRetentionPolicy(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	// run the policy again after the interval, independently of the outcome of this run
+	c.queue.AddAfter(key, runInterval)
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Regardless of whether reconcile returned an error or not, always try to patch status if needed. Return the
+	// reconciliation error at the end.
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
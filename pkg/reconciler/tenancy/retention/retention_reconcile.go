@@ -0,0 +1,310 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// maxDryRunCandidates is the maximum number of objects reported in status.dryRunCandidates.
+const maxDryRunCandidates = 100
+
+func (c *controller) reconcile(ctx context.Context, policy *tenancyv1alpha1.RetentionPolicy) error {
+	logger := klog.FromContext(ctx)
+	clusterName := logicalcluster.From(policy)
+
+	selector := labels.Everything()
+	if policy.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+			conditions.MarkFalse(
+				policy,
+				tenancyv1alpha1.RetentionPolicyApplied,
+				tenancyv1alpha1.RetentionPolicyInvalidReason,
+				conditionsv1alpha1.ConditionSeverityError,
+				"Invalid selector: %v",
+				err,
+			)
+			return nil // nothing we can do until the spec is fixed
+		}
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    policy.Spec.Resource.Group,
+		Version:  policy.Spec.Resource.Version,
+		Resource: policy.Spec.Resource.Resource,
+	}
+
+	// the policy is executed with the permissions of the user who last changed it
+	owner, err := policyOwner(policy)
+	if err != nil {
+		conditions.MarkFalse(
+			policy,
+			tenancyv1alpha1.RetentionPolicyApplied,
+			tenancyv1alpha1.RetentionPolicyForbiddenReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"%v",
+			err,
+		)
+		return nil // nothing we can do until the policy is updated
+	}
+	if allowed, err := c.allowed(ctx, clusterName, owner, "list", gvr, metav1.NamespaceAll); err != nil {
+		return err
+	} else if !allowed {
+		conditions.MarkFalse(
+			policy,
+			tenancyv1alpha1.RetentionPolicyApplied,
+			tenancyv1alpha1.RetentionPolicyForbiddenReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"User %q is not allowed to list %s",
+			owner.GetName(),
+			gvr,
+		)
+		return nil
+	}
+
+	list, err := c.listObjects(ctx, clusterName, gvr, selector)
+	if err != nil {
+		conditions.MarkFalse(
+			policy,
+			tenancyv1alpha1.RetentionPolicyApplied,
+			tenancyv1alpha1.RetentionPolicyListFailedReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Failed to list %s: %v",
+			gvr,
+			err,
+		)
+		if errors.IsNotFound(err) {
+			return nil // the resource might show up later, we retry with the next run
+		}
+		return err
+	}
+
+	now := c.now()
+	expired := expiredObjects(list.Items, policy.Spec, now)
+
+	// only objects in namespaces the owner may delete in are subject to the policy
+	forbiddenNamespaces := sets.NewString()
+	allowedNamespaces := sets.NewString()
+	permitted := make([]metav1.PartialObjectMetadata, 0, len(expired))
+	for _, obj := range expired {
+		if forbiddenNamespaces.Has(obj.Namespace) {
+			continue
+		}
+		if !allowedNamespaces.Has(obj.Namespace) {
+			allowed, err := c.allowed(ctx, clusterName, owner, "delete", gvr, obj.Namespace)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				forbiddenNamespaces.Insert(obj.Namespace)
+				continue
+			}
+			allowedNamespaces.Insert(obj.Namespace)
+		}
+		permitted = append(permitted, obj)
+	}
+	expired = permitted
+
+	policy.Status.LastRunTime = &metav1.Time{Time: now}
+
+	if policy.Spec.DryRun {
+		candidates := make([]string, 0, len(expired))
+		for _, obj := range expired {
+			if len(candidates) == maxDryRunCandidates {
+				break
+			}
+			candidates = append(candidates, objectKey(obj))
+		}
+		policy.Status.LastDeletedCount = int32(len(expired))
+		policy.Status.DryRunCandidates = candidates
+		markApplied(policy, owner, gvr, forbiddenNamespaces)
+		return nil
+	}
+	policy.Status.DryRunCandidates = nil
+
+	var deleted int32
+	var errs []error
+	for _, obj := range expired {
+		logger.V(4).Info("deleting expired object", "gvr", gvr, "namespace", obj.Namespace, "name", obj.Name)
+		if err := c.deleteObject(ctx, clusterName, gvr, obj.Namespace, obj.Name, obj.UID); err != nil {
+			if errors.IsNotFound(err) || errors.IsConflict(err) {
+				// deleted or replaced in the meantime
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	policy.Status.LastDeletedCount = deleted
+
+	if len(errs) > 0 {
+		err := utilerrors.NewAggregate(errs)
+		conditions.MarkFalse(
+			policy,
+			tenancyv1alpha1.RetentionPolicyApplied,
+			tenancyv1alpha1.RetentionPolicyDeletionFailedReason,
+			conditionsv1alpha1.ConditionSeverityError,
+			"Failed to delete %d of %d expired objects: %v",
+			len(errs),
+			len(expired),
+			err,
+		)
+		return err
+	}
+
+	markApplied(policy, owner, gvr, forbiddenNamespaces)
+	return nil
+}
+
+// markApplied sets the Applied condition after a successful run, which is only true if the owner
+// was allowed to delete all expired objects.
+func markApplied(policy *tenancyv1alpha1.RetentionPolicy, owner user.Info, gvr schema.GroupVersionResource, forbiddenNamespaces sets.String) {
+	if forbiddenNamespaces.Len() == 0 {
+		conditions.MarkTrue(policy, tenancyv1alpha1.RetentionPolicyApplied)
+		return
+	}
+
+	namespaces := forbiddenNamespaces.List()
+	for i := range namespaces {
+		if namespaces[i] == "" {
+			namespaces[i] = "<cluster-scoped>"
+		}
+	}
+	conditions.MarkFalse(
+		policy,
+		tenancyv1alpha1.RetentionPolicyApplied,
+		tenancyv1alpha1.RetentionPolicyForbiddenReason,
+		conditionsv1alpha1.ConditionSeverityError,
+		"User %q is not allowed to delete %s in namespaces %v",
+		owner.GetName(),
+		gvr,
+		namespaces,
+	)
+}
+
+// policyOwner returns the user recorded by admission as the last one to change the policy.
+func policyOwner(policy *tenancyv1alpha1.RetentionPolicy) (user.Info, error) {
+	value, found := policy.Annotations[tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey]
+	if !found {
+		return nil, fmt.Errorf("missing annotation %s", tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey)
+	}
+
+	var info authenticationv1.UserInfo
+	if err := json.Unmarshal([]byte(value), &info); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey, err)
+	}
+	extra := make(map[string][]string, len(info.Extra))
+	for k, v := range info.Extra {
+		extra[k] = v
+	}
+	return &user.DefaultInfo{
+		Name:   info.Username,
+		UID:    info.UID,
+		Groups: info.Groups,
+		Extra:  extra,
+	}, nil
+}
+
+// allowed returns whether the given user may perform the verb on the resource in the namespace.
+func (c *controller) allowed(ctx context.Context, clusterName logicalcluster.Name, owner user.Info, verb string, gvr schema.GroupVersionResource, namespace string) (bool, error) {
+	decision, err := c.authorize(ctx, clusterName, authorizer.AttributesRecord{
+		User:            owner,
+		Verb:            verb,
+		APIGroup:        gvr.Group,
+		APIVersion:      gvr.Version,
+		Resource:        gvr.Resource,
+		Namespace:       namespace,
+		ResourceRequest: true,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to authorize %s of %s for user %q: %w", verb, gvr, owner.GetName(), err)
+	}
+	return decision == authorizer.DecisionAllow, nil
+}
+
+// expiredObjects returns the objects that are to be deleted according to the given policy spec,
+// ordered by namespace and then from newest to oldest. Objects that are already being deleted are
+// neither returned nor counted towards keepLatest.
+func expiredObjects(objs []metav1.PartialObjectMetadata, spec tenancyv1alpha1.RetentionPolicySpec, now time.Time) []metav1.PartialObjectMetadata {
+	byNamespace := map[string][]metav1.PartialObjectMetadata{}
+	for _, obj := range objs {
+		if obj.DeletionTimestamp != nil {
+			continue
+		}
+		byNamespace[obj.Namespace] = append(byNamespace[obj.Namespace], obj)
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var expired []metav1.PartialObjectMetadata
+	for _, ns := range namespaces {
+		items := byNamespace[ns]
+		sort.SliceStable(items, func(i, j int) bool {
+			ti, tj := items[i].CreationTimestamp, items[j].CreationTimestamp
+			if !ti.Equal(&tj) {
+				return tj.Before(&ti)
+			}
+			return items[i].Name < items[j].Name
+		})
+
+		for i, obj := range items {
+			if spec.KeepLatest != nil && i >= int(*spec.KeepLatest) {
+				expired = append(expired, obj)
+				continue
+			}
+			if spec.MaxAge != nil && obj.CreationTimestamp.Add(spec.MaxAge.Duration).Before(now) {
+				expired = append(expired, obj)
+			}
+		}
+	}
+
+	return expired
+}
+
+func objectKey(obj metav1.PartialObjectMetadata) string {
+	if obj.Namespace == "" {
+		return obj.Name
+	}
+	return obj.Namespace + "/" + obj.Name
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/utils/pointer"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+var now = time.Date(2023, 1, 16, 12, 0, 0, 0, time.UTC)
+
+func object(namespace, name string, age time.Duration) metav1.PartialObjectMetadata {
+	return metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			UID:               types.UID(namespace + "-" + name),
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+	}
+}
+
+func keys(objs []metav1.PartialObjectMetadata) []string {
+	ret := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		ret = append(ret, objectKey(obj))
+	}
+	return ret
+}
+
+func TestExpiredObjects(t *testing.T) {
+	terminating := object("ns1", "terminating", time.Minute)
+	terminating.DeletionTimestamp = &metav1.Time{Time: now}
+
+	objs := []metav1.PartialObjectMetadata{
+		object("ns1", "a", 3*time.Hour),
+		object("ns1", "b", 2*time.Hour),
+		object("ns1", "c", time.Hour),
+		terminating,
+		object("ns2", "d", 5*time.Hour),
+		object("", "e", 10*time.Minute),
+	}
+
+	tests := map[string]struct {
+		spec tenancyv1alpha1.RetentionPolicySpec
+		want []string
+	}{
+		"maxAge": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: 90 * time.Minute}},
+			want: []string{"ns1/b", "ns1/a", "ns2/d"},
+		},
+		"keepLatest per namespace ignoring terminating objects": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{KeepLatest: pointer.Int32(1)},
+			want: []string{"ns1/b", "ns1/a"},
+		},
+		"keepLatest zero": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{KeepLatest: pointer.Int32(0)},
+			want: []string{"e", "ns1/c", "ns1/b", "ns1/a", "ns2/d"},
+		},
+		"maxAge or keepLatest": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{
+				MaxAge:     &metav1.Duration{Duration: 4 * time.Hour},
+				KeepLatest: pointer.Int32(2),
+			},
+			want: []string{"ns1/a", "ns2/d"},
+		},
+		"nothing expired": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			want: []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := expiredObjects(objs, tt.spec, now)
+			require.Equal(t, tt.want, keys(got))
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	objs := []metav1.PartialObjectMetadata{
+		object("ns1", "old", 2*time.Hour),
+		object("ns1", "gone", 3*time.Hour),
+		object("ns1", "new", time.Minute),
+		object("ns2", "other", 2*time.Hour),
+	}
+
+	tests := map[string]struct {
+		spec      tenancyv1alpha1.RetentionPolicySpec
+		noOwner   bool
+		forbidden sets.String
+		listErr   error
+		deleteErr error
+
+		wantErr              bool
+		wantDeleted          []string
+		wantLastDeletedCount int32
+		wantCandidates       []string
+		wantReason           string
+	}{
+		"objects are deleted": {
+			spec:                 tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			wantDeleted:          []string{"ns1/old", "ns1/gone", "ns2/other"},
+			wantLastDeletedCount: 2,
+		},
+		"dry run reports candidates": {
+			spec:                 tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}, DryRun: true},
+			wantLastDeletedCount: 3,
+			wantCandidates:       []string{"ns1/old", "ns1/gone", "ns2/other"},
+		},
+		"objects in namespaces the owner cannot delete in are kept": {
+			spec:                 tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			forbidden:            sets.NewString("delete/ns2"),
+			wantDeleted:          []string{"ns1/old", "ns1/gone"},
+			wantLastDeletedCount: 1,
+			wantReason:           tenancyv1alpha1.RetentionPolicyForbiddenReason,
+		},
+		"dry run only reports objects the owner can delete": {
+			spec:                 tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}, DryRun: true},
+			forbidden:            sets.NewString("delete/ns1"),
+			wantLastDeletedCount: 1,
+			wantCandidates:       []string{"ns2/other"},
+			wantReason:           tenancyv1alpha1.RetentionPolicyForbiddenReason,
+		},
+		"owner cannot list": {
+			spec:       tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			forbidden:  sets.NewString("list/"),
+			wantReason: tenancyv1alpha1.RetentionPolicyForbiddenReason,
+		},
+		"owner unknown": {
+			spec:       tenancyv1alpha1.RetentionPolicySpec{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			noOwner:    true,
+			wantReason: tenancyv1alpha1.RetentionPolicyForbiddenReason,
+		},
+		"invalid selector": {
+			spec: tenancyv1alpha1.RetentionPolicySpec{
+				KeepLatest: pointer.Int32(1),
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "foo", Operator: "Bogus"},
+				}},
+			},
+			wantReason: tenancyv1alpha1.RetentionPolicyInvalidReason,
+		},
+		"unknown resource": {
+			spec:       tenancyv1alpha1.RetentionPolicySpec{KeepLatest: pointer.Int32(1)},
+			listErr:    errors.NewNotFound(schema.GroupResource{Group: "example.io", Resource: "widgets"}, ""),
+			wantReason: tenancyv1alpha1.RetentionPolicyListFailedReason,
+		},
+		"list fails": {
+			spec:       tenancyv1alpha1.RetentionPolicySpec{KeepLatest: pointer.Int32(1)},
+			listErr:    fmt.Errorf("boom"),
+			wantErr:    true,
+			wantReason: tenancyv1alpha1.RetentionPolicyListFailedReason,
+		},
+		"deletion fails": {
+			spec:        tenancyv1alpha1.RetentionPolicySpec{KeepLatest: pointer.Int32(1)},
+			deleteErr:   fmt.Errorf("boom"),
+			wantErr:     true,
+			wantDeleted: []string{"ns1/old", "ns1/gone"},
+			wantReason:  tenancyv1alpha1.RetentionPolicyDeletionFailedReason,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			c := &controller{
+				listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*metav1.PartialObjectMetadataList, error) {
+					require.Equal(t, logicalcluster.Name("root:org:ws"), clusterName)
+					require.Equal(t, schema.GroupVersionResource{Group: "example.io", Version: "v1", Resource: "widgets"}, gvr)
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					return &metav1.PartialObjectMetadataList{Items: objs}, nil
+				},
+				deleteObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string, uid types.UID) error {
+					require.Equal(t, types.UID(namespace+"-"+name), uid)
+					deleted = append(deleted, namespace+"/"+name)
+					if tt.deleteErr != nil {
+						return tt.deleteErr
+					}
+					if name == "gone" {
+						return errors.NewNotFound(schema.GroupResource{Group: "example.io", Resource: "widgets"}, name)
+					}
+					return nil
+				},
+				authorize: func(ctx context.Context, clusterName logicalcluster.Name, attr authorizer.Attributes) (authorizer.Decision, error) {
+					require.Equal(t, "alice", attr.GetUser().GetName())
+					if tt.forbidden.Has(attr.GetVerb() + "/" + attr.GetNamespace()) {
+						return authorizer.DecisionNoOpinion, nil
+					}
+					return authorizer.DecisionAllow, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			spec := tt.spec
+			spec.Resource = tenancyv1alpha1.RetentionPolicyResource{Group: "example.io", Version: "v1", Resource: "widgets"}
+			policy := &tenancyv1alpha1.RetentionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "widgets",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey:                      "root:org:ws",
+						tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey: `{"username":"alice","groups":["team-a"]}`,
+					},
+				},
+				Spec: spec,
+			}
+			if tt.noOwner {
+				delete(policy.Annotations, tenancyv1alpha1.RetentionPolicyOwnerAnnotationKey)
+			}
+
+			err := c.reconcile(context.Background(), policy)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.wantDeleted, deleted)
+			require.Equal(t, tt.wantLastDeletedCount, policy.Status.LastDeletedCount)
+			require.Equal(t, tt.wantCandidates, policy.Status.DryRunCandidates)
+
+			if tt.wantReason != "" {
+				require.True(t, conditions.IsFalse(policy, tenancyv1alpha1.RetentionPolicyApplied))
+				require.Equal(t, tt.wantReason, conditions.GetReason(policy, tenancyv1alpha1.RetentionPolicyApplied))
+				require.Equal(t, conditionsv1alpha1.ConditionSeverityError, *conditions.GetSeverity(policy, tenancyv1alpha1.RetentionPolicyApplied))
+			} else {
+				require.True(t, conditions.IsTrue(policy, tenancyv1alpha1.RetentionPolicyApplied))
+				require.NotNil(t, policy.Status.LastRunTime)
+				require.True(t, policy.Status.LastRunTime.Time.Equal(now))
+			}
+		})
+	}
+}
@@ -19,12 +19,16 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
 	"github.com/kcp-dev/client-go/kubernetes"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -33,18 +37,23 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	"github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1beta1"
-	corev1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/core/v1alpha1"
-	tenancyv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/tenancy/v1alpha1"
-	tenancyv1beta1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/tenancy/v1beta1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/core/v1alpha1"
-	tenancyv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1alpha1"
-	tenancyv1beta1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1beta1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
 	"github.com/kcp-dev/kcp/pkg/logging"
-	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	"github.com/kcp-dev/kcp/pkg/reconciler/ratelimiter"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1beta1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	tenancyv1beta1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+	tenancyv1beta1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
 )
 
 const (
@@ -56,24 +65,32 @@ func NewController(
 	shardExternalURL func() string,
 	kcpClusterClient kcpclientset.ClusterInterface,
 	kubeClusterClient kubernetes.ClusterInterface,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
 	logicalClusterAdminConfig *rest.Config,
 	workspaceInformer tenancyv1beta1informers.WorkspaceClusterInformer,
 	shardInformer corev1alpha1informers.ShardClusterInformer,
 	workspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
 	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	annotateTimeToReady bool,
+	tombstoneDuration time.Duration,
+	mountDNSBaseDomain string,
+	mountDNSNameTemplate string,
+	commitBatcher *committer.Batcher,
+	controllerSwitch *controllerswitch.Switch,
 ) (*Controller, error) {
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	queue := ratelimiter.NewControllerQueue(ControllerName)
 
 	c := &Controller{
-		queue: queue,
+		queue: controllerSwitch.Queue(queue),
 
 		shardName:        shardName,
 		shardExternalURL: shardExternalURL,
 
 		logicalClusterAdminConfig: logicalClusterAdminConfig,
 
-		kcpClusterClient:  kcpClusterClient,
-		kubeClusterClient: kubeClusterClient,
+		kcpClusterClient:     kcpClusterClient,
+		kubeClusterClient:    kubeClusterClient,
+		dynamicClusterClient: dynamicClusterClient,
 
 		workspaceIndexer: workspaceInformer.Informer().GetIndexer(),
 		workspaceLister:  workspaceInformer.Lister(),
@@ -87,14 +104,26 @@ func NewController(
 		logicalClusterIndexer: logicalClusterInformer.Informer().GetIndexer(),
 		logicalClusterLister:  logicalClusterInformer.Lister(),
 
-		commit: committer.NewCommitter[*tenancyv1beta1.Workspace, v1beta1.WorkspaceInterface, *tenancyv1beta1.WorkspaceSpec, *tenancyv1beta1.WorkspaceStatus](kcpClusterClient.TenancyV1beta1().Workspaces()),
+		annotateTimeToReady: annotateTimeToReady,
+		tombstoneDuration:   tombstoneDuration,
+
+		commit: committer.NewCommitterWithProvenance[*tenancyv1beta1.Workspace, v1beta1.WorkspaceInterface, *tenancyv1beta1.WorkspaceSpec, *tenancyv1beta1.WorkspaceStatus](kcpClusterClient.TenancyV1beta1().Workspaces(), ControllerName, committer.WithBatcher(commitBatcher)),
+	}
+
+	if mountDNSBaseDomain != "" {
+		mountDNSName, err := newMountDNSNamer(mountDNSBaseDomain, mountDNSNameTemplate)
+		if err != nil {
+			return nil, err
+		}
+		c.mountDNSName = mountDNSName
 	}
 
 	indexers.AddIfNotPresentOrDie(workspaceInformer.Informer().GetIndexer(), cache.Indexers{
 		unschedulable: indexUnschedulable,
+		byShardHash:   indexByShardHash,
 	})
 	indexers.AddIfNotPresentOrDie(shardInformer.Informer().GetIndexer(), cache.Indexers{
-		byBase36Sha224Name: indexByBase36Sha224Name,
+		byShardNameHash: indexByShardNameHash,
 	})
 	indexers.AddIfNotPresentOrDie(workspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
 		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
@@ -103,11 +132,26 @@ func NewController(
 	workspaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    func(obj interface{}) { c.enqueue(obj) },
 		UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueFreedCapacity(obj) },
 	})
 
 	shardInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.enqueueShard(obj) },
-		UpdateFunc: func(obj, _ interface{}) { c.enqueueShard(obj) },
+		AddFunc: func(obj interface{}) { c.enqueueShard(obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueShard(oldObj)
+			oldShard, ok := oldObj.(*corev1alpha1.Shard)
+			if !ok {
+				return
+			}
+			newShard, ok := newObj.(*corev1alpha1.Shard)
+			if !ok {
+				return
+			}
+			if oldShard.Spec.Draining != newShard.Spec.Draining ||
+				!equality.Semantic.DeepEqual(conditions.Get(oldShard, corev1alpha1.ShardLoadNominal), conditions.Get(newShard, corev1alpha1.ShardLoadNominal)) {
+				c.enqueueShardWorkspaces(newShard)
+			}
+		},
 		DeleteFunc: func(obj interface{}) { c.enqueueShard(obj) },
 	})
 
@@ -125,9 +169,10 @@ type Controller struct {
 	shardExternalURL          func() string
 	logicalClusterAdminConfig *rest.Config
 
-	kcpClusterClient  kcpclientset.ClusterInterface
-	kubeClusterClient kubernetes.ClusterInterface
-	kcpExternalClient kcpclientset.ClusterInterface
+	kcpClusterClient     kcpclientset.ClusterInterface
+	kubeClusterClient    kubernetes.ClusterInterface
+	dynamicClusterClient kcpdynamic.ClusterInterface
+	kcpExternalClient    kcpclientset.ClusterInterface
 
 	workspaceIndexer cache.Indexer
 	workspaceLister  tenancyv1beta1listers.WorkspaceClusterLister
@@ -141,6 +186,15 @@ type Controller struct {
 	logicalClusterIndexer cache.Indexer
 	logicalClusterLister  corev1alpha1listers.LogicalClusterClusterLister
 
+	// annotateTimeToReady makes ready workspaces carry their time to ready in an annotation.
+	annotateTimeToReady bool
+
+	// tombstoneDuration is how long the names of deleted workspaces stay reserved.
+	tombstoneDuration time.Duration
+
+	// mountDNSName renders the DNS names of mounts, or is nil if they are disabled.
+	mountDNSName func(workspace *tenancyv1beta1.Workspace) (string, error)
+
 	// commit creates a patch and submits it, if needed.
 	commit func(ctx context.Context, new, old *workspaceResource) error
 }
@@ -171,7 +225,82 @@ func (c *Controller) enqueueShard(obj interface{}) {
 
 	shard, err := c.shardLister.Cluster(clusterName).Get(name)
 	if err == nil {
-		workspaces, err := c.workspaceIndexer.ByIndex(unschedulable, "true")
+		c.enqueueUnschedulable(logger, shard, "shard update")
+	}
+}
+
+// enqueueFreedCapacity enqueues the unschedulable workspaces when a deleted workspace frees
+// capacity on its shard, or stops reserving capacity for its priority.
+func (c *Controller) enqueueFreedCapacity(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	workspace, ok := obj.(*tenancyv1beta1.Workspace)
+	if !ok {
+		return
+	}
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	if hash, found := workspace.Annotations[workspaceShardAnnotationKey]; found {
+		shards, err := c.shardIndexer.ByIndex(byShardNameHash, hash)
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		for _, shard := range shards {
+			c.enqueueUnschedulable(logger, shard.(*corev1alpha1.Shard), "workspace deletion")
+		}
+		return
+	}
+	if conditions.GetReason(workspace, tenancyv1alpha1.WorkspaceScheduled) == tenancyv1alpha1.WorkspaceReasonShardsAtCapacity {
+		c.enqueueUnschedulable(logger, nil, "workspace deletion")
+	}
+}
+
+// enqueueUnschedulable enqueues the unschedulable workspaces which may be scheduled to the given
+// shard, or all of them if shard is nil. Workspaces of higher priority are enqueued first.
+func (c *Controller) enqueueUnschedulable(logger klog.Logger, shard *corev1alpha1.Shard, cause string) {
+	workspaces, err := indexers.ByIndex[*tenancyv1beta1.Workspace](c.workspaceIndexer, unschedulable, "true")
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		return workspacePriority(workspaces[i]) > workspacePriority(workspaces[j])
+	})
+	for _, workspace := range workspaces {
+		if shard != nil && !shardMayMatch(workspace, shard) {
+			continue
+		}
+		key, err := kcpcache.MetaClusterNamespaceKeyFunc(workspace)
+		if err != nil {
+			runtime.HandleError(err)
+			return
+		}
+		logging.WithQueueKey(logger, key).V(2).Info("queueing unschedulable Workspace because of "+cause, "shard", shard)
+		c.queue.Add(key)
+	}
+}
+
+// shardMayMatch returns whether the labels of the shard match the shard selector an unschedulable
+// workspace was last tried to be scheduled with. Workspaces without recorded selector always match.
+func shardMayMatch(workspace *tenancyv1beta1.Workspace, shard *corev1alpha1.Shard) bool {
+	if workspace.Status.Scheduling == nil || workspace.Status.Scheduling.Selector == "" {
+		return true
+	}
+	selector, err := labels.Parse(workspace.Status.Scheduling.Selector)
+	if err != nil {
+		return true
+	}
+	return selector.Matches(labels.Set(shard.Labels))
+}
+
+// enqueueShardWorkspaces enqueues the workspaces scheduled to the given shard, e.g. to update
+// their ShardDegraded condition.
+func (c *Controller) enqueueShardWorkspaces(shard *corev1alpha1.Shard) {
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	for _, hash := range shardNameHashValues(shard.Name) {
+		workspaces, err := c.workspaceIndexer.ByIndex(byShardHash, hash)
 		if err != nil {
 			runtime.HandleError(err)
 			return
@@ -182,7 +311,7 @@ func (c *Controller) enqueueShard(obj interface{}) {
 				runtime.HandleError(err)
 				return
 			}
-			logging.WithQueueKey(logger, key).V(2).Info("queueing unschedulable Workspace because of shard update", "shard", shard)
+			logging.WithQueueKey(logger, key).V(2).Info("queueing Workspace because of shard health change", "shard", shard.Name)
 			c.queue.Add(key)
 		}
 	}
@@ -279,6 +408,8 @@ func (c *Controller) process(ctx context.Context, key string) (bool, error) {
 	newResource := &workspaceResource{ObjectMeta: workspace.ObjectMeta, Spec: &workspace.Spec, Status: &workspace.Status}
 	if err := c.commit(ctx, oldResource, newResource); err != nil {
 		errs = append(errs, err)
+	} else if old.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && workspace.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
+		recordTimeToReady(workspace, time.Now())
 	}
 
 	return requeue, utilerrors.NewAggregate(errs)
@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	tenancyv1beta1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+)
+
+// DryRunScheduling is the outcome of scheduling a workspace without creating its logical cluster.
+type DryRunScheduling struct {
+	// Shard is the shard the workspace would be scheduled to. It is empty if the workspace is
+	// unschedulable.
+	Shard string `json:"shard,omitempty"`
+	// Candidates are all the shards the workspace can be scheduled to. Shard is chosen randomly
+	// among them, weighted by their reported utilization.
+	Candidates []string `json:"candidates,omitempty"`
+	// Selector is the shard selector of the workspace and its type.
+	Selector string `json:"selector,omitempty"`
+	// Initializers are the initializers of the logical cluster of the workspace.
+	Initializers []corev1alpha1.LogicalClusterInitializer `json:"initializers,omitempty"`
+	// Reason is the reason of the Scheduled condition if the workspace is unschedulable.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation why the workspace is unschedulable.
+	Message string `json:"message,omitempty"`
+}
+
+// DryRunScheduler schedules workspaces like the workspace controller does, but without side effects.
+type DryRunScheduler struct {
+	scheduler *schedulingReconciler
+}
+
+// NewDryRunScheduler returns a scheduler working on the given informers.
+func NewDryRunScheduler(
+	workspaceInformer tenancyv1beta1informers.WorkspaceClusterInformer,
+	shardInformer corev1alpha1informers.ShardClusterInformer,
+	workspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
+) *DryRunScheduler {
+	indexers.AddIfNotPresentOrDie(workspaceInformer.Informer().GetIndexer(), cache.Indexers{
+		unschedulable: indexUnschedulable,
+		byShardHash:   indexByShardHash,
+	})
+	indexers.AddIfNotPresentOrDie(workspaceTypeInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	workspaceIndexer := workspaceInformer.Informer().GetIndexer()
+	workspaceTypeIndexer := workspaceTypeInformer.Informer().GetIndexer()
+	getType := func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+		return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), workspaceTypeIndexer, path, name)
+	}
+
+	return &DryRunScheduler{
+		scheduler: &schedulingReconciler{
+			listShards: shardInformer.Lister().List,
+			listScheduledWorkspaces: func(shardHash string) ([]*tenancyv1beta1.Workspace, error) {
+				return indexers.ByIndex[*tenancyv1beta1.Workspace](workspaceIndexer, byShardHash, shardHash)
+			},
+			listUnschedulableWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) {
+				return indexers.ByIndex[*tenancyv1beta1.Workspace](workspaceIndexer, unschedulable, "true")
+			},
+			getWorkspaceType:       getType,
+			transitiveTypeResolver: workspacetypeexists.NewTransitiveTypeResolver(getType),
+		},
+	}
+}
+
+// Schedule returns where the workspace would be scheduled to and how its logical cluster would be
+// initialized. The type of the workspace must be resolved already.
+func (s *DryRunScheduler) Schedule(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (*DryRunScheduling, error) {
+	result := &DryRunScheduling{}
+	if workspace.Spec.URL != "" && workspace.Spec.Cluster != "" {
+		result.Message = "The workspace is not scheduled because spec.URL and spec.cluster are set"
+	} else {
+		shards, selector, reason, message, err := s.scheduler.schedulableShards(logger, workspace)
+		if err != nil {
+			return nil, err
+		}
+		result.Selector, result.Reason, result.Message = selector, reason, message
+		for _, shard := range shards {
+			result.Candidates = append(result.Candidates, shard.Name)
+		}
+		if len(shards) > 0 {
+			result.Shard = pickShard(shards).Name
+		}
+	}
+
+	var err error
+	result.Initializers, err = LogicalClustersInitializers(s.scheduler.transitiveTypeResolver, s.scheduler.getWorkspaceType, logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -17,38 +17,70 @@ limitations under the License.
 package workspace
 
 import (
-	"crypto/sha256"
 	"strings"
 
 	"github.com/martinlindhe/base36"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kcp-dev/kcp/pkg/crypto"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 const (
-	byBase36Sha224Name = "byBase36Sha224Name"
-	unschedulable      = "unschedulable"
+	byShardNameHash = "byShardNameHash"
+	unschedulable   = "unschedulable"
+	byShardHash     = "byShardHash"
 )
 
 func indexUnschedulable(obj interface{}) ([]string, error) {
 	workspace := obj.(*tenancyv1beta1.Workspace)
-	if conditions.IsFalse(workspace, tenancyv1alpha1.WorkspaceScheduled) && conditions.GetReason(workspace, tenancyv1alpha1.WorkspaceScheduled) == tenancyv1alpha1.WorkspaceReasonUnschedulable {
+	if !conditions.IsFalse(workspace, tenancyv1alpha1.WorkspaceScheduled) {
+		return []string{}, nil
+	}
+	switch conditions.GetReason(workspace, tenancyv1alpha1.WorkspaceScheduled) {
+	case tenancyv1alpha1.WorkspaceReasonUnschedulable, tenancyv1alpha1.WorkspaceReasonShardsAtCapacity:
 		return []string{"true"}, nil
 	}
 	return []string{}, nil
 }
 
-func indexByBase36Sha224Name(obj interface{}) ([]string, error) {
+func indexByShardHash(obj interface{}) ([]string, error) {
+	workspace := obj.(*tenancyv1beta1.Workspace)
+	if hash, ok := workspace.Annotations[workspaceShardAnnotationKey]; ok {
+		return []string{hash}, nil
+	}
+	return []string{}, nil
+}
+
+// indexByShardNameHash indexes shards by the hash of their name with every registered algorithm,
+// such that workspaces scheduled before the hash algorithm was changed still find their shard.
+func indexByShardNameHash(obj interface{}) ([]string, error) {
 	s := obj.(*corev1alpha1.Shard)
-	return []string{ByBase36Sha224NameValue(s.Name)}, nil
+	return shardNameHashValues(s.Name), nil
 }
 
+// ByBase36Sha224NameValue returns the hash of the shard name with the sha224 algorithm, which
+// workspaces without a recorded shard hash algorithm were scheduled with.
 func ByBase36Sha224NameValue(name string) string {
-	hash := sha256.Sum224([]byte(name))
-	base36hash := strings.ToLower(base36.EncodeBytes(hash[:]))
+	return ShardNameHashValue(crypto.SHA224, name)
+}
 
+// ShardNameHashValue returns the hash of the shard name as stored in the shard annotation of the
+// workspaces scheduled to it.
+func ShardNameHashValue(hasher crypto.Hasher, name string) string {
+	base36hash := strings.ToLower(base36.EncodeBytes(hasher.Sum([]byte(name))))
 	return base36hash[:8]
 }
+
+// shardNameHashValues returns the hashes of the shard name with every registered algorithm.
+func shardNameHashValues(name string) []string {
+	values := sets.NewString()
+	for _, hasher := range crypto.Hashers() {
+		values.Insert(ShardNameHashValue(hasher, name))
+	}
+	return values.List()
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"sync"
+	"time"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+var (
+	timeToReady = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "workspace_time_to_ready_seconds",
+			Help:           "Duration from the creation of a workspace until it became ready, by workspace type.",
+			Buckets:        []float64{0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300, 600},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the workspace metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(timeToReady)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+// recordTimeToReady observes the time from creation until now of a workspace that just became ready.
+func recordTimeToReady(workspace *tenancyv1beta1.Workspace, now time.Time) {
+	timeToReady.WithLabelValues(workspace.Spec.Type.String()).Observe(now.Sub(workspace.CreationTimestamp.Time).Seconds())
+}
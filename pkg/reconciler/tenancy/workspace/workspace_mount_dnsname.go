@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// DefaultMountDNSNameTemplate renders the DNS name of a mount as <workspace>.<logical cluster>.<base domain>.
+const DefaultMountDNSNameTemplate = "{{.Workspace}}.{{.Cluster}}.{{.BaseDomain}}"
+
+// mountDNSNameData is what the DNS name template of mounts is rendered with.
+type mountDNSNameData struct {
+	// Workspace is the name of the mounted workspace.
+	Workspace string
+	// Cluster is the name of the logical cluster the mounted workspace lives in, i.e. the
+	// one of its parent. Other than the workspace path, it never changes.
+	Cluster string
+	// BaseDomain is the configured base domain.
+	BaseDomain string
+}
+
+// ValidateMountDNSName returns an error if the given base domain or DNS name template of
+// mounts is invalid.
+func ValidateMountDNSName(baseDomain, nameTemplate string) error {
+	_, err := newMountDNSNamer(baseDomain, nameTemplate)
+	return err
+}
+
+// newMountDNSNamer returns a function rendering the DNS name of the mount of a workspace with
+// the given template and base domain.
+func newMountDNSNamer(baseDomain, nameTemplate string) (func(workspace *tenancyv1beta1.Workspace) (string, error), error) {
+	if errs := validation.IsDNS1123Subdomain(baseDomain); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid base domain %q: %s", baseDomain, strings.Join(errs, ", "))
+	}
+	tmpl, err := template.New("dnsName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS name template %q: %w", nameTemplate, err)
+	}
+
+	return func(workspace *tenancyv1beta1.Workspace) (string, error) {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, mountDNSNameData{
+			Workspace:  workspace.Name,
+			Cluster:    logicalcluster.From(workspace).String(),
+			BaseDomain: baseDomain,
+		}); err != nil {
+			return "", fmt.Errorf("error rendering DNS name of the mount of workspace %s: %w", workspace.Name, err)
+		}
+
+		name := strings.ToLower(b.String())
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return "", fmt.Errorf("invalid DNS name %q of the mount of workspace %s: %s", name, workspace.Name, strings.Join(errs, ", "))
+		}
+		return name, nil
+	}, nil
+}
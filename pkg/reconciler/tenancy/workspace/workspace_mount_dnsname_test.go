@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestMountDNSNamer(t *testing.T) {
+	workspace := &tenancyv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "2x8yhlekd8lp6ykm"},
+		},
+	}
+
+	tests := map[string]struct {
+		baseDomain   string
+		nameTemplate string
+		workspace    *tenancyv1beta1.Workspace
+
+		wantInvalid   bool
+		wantRenderErr bool
+		want          string
+	}{
+		"default template": {
+			baseDomain:   "mounts.kcp.dev",
+			nameTemplate: DefaultMountDNSNameTemplate,
+			workspace:    workspace,
+			want:         "cluster.2x8yhlekd8lp6ykm.mounts.kcp.dev",
+		},
+		"workspace only": {
+			baseDomain:   "mounts.kcp.dev",
+			nameTemplate: "{{.Workspace}}.{{.BaseDomain}}",
+			workspace:    workspace,
+			want:         "cluster.mounts.kcp.dev",
+		},
+		"invalid base domain": {
+			baseDomain:   "mounts_kcp.dev",
+			nameTemplate: DefaultMountDNSNameTemplate,
+			wantInvalid:  true,
+		},
+		"invalid template": {
+			baseDomain:   "mounts.kcp.dev",
+			nameTemplate: "{{.Workspace",
+			wantInvalid:  true,
+		},
+		"unknown key in template": {
+			baseDomain:    "mounts.kcp.dev",
+			nameTemplate:  "{{.Shard}}.{{.BaseDomain}}",
+			workspace:     workspace,
+			wantRenderErr: true,
+		},
+		"invalid rendered name": {
+			baseDomain:   "mounts.kcp.dev",
+			nameTemplate: DefaultMountDNSNameTemplate,
+			workspace: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "system:admin"},
+				},
+			},
+			wantRenderErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dnsName, err := newMountDNSNamer(tt.baseDomain, tt.nameTemplate)
+			if tt.wantInvalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := dnsName(tt.workspace)
+			if tt.wantRenderErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
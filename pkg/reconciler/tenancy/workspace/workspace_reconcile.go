@@ -25,17 +25,21 @@ import (
 	"github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	utilserrors "k8s.io/apimachinery/pkg/util/errors"
 	restclient "k8s.io/client-go/rest"
 
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 type reconcileStatus int
@@ -51,7 +55,7 @@ type reconciler interface {
 
 func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1beta1.Workspace) (bool, error) {
 	getShardByName := func(hash string) (*corev1alpha1.Shard, error) {
-		shards, err := c.shardIndexer.ByIndex(byBase36Sha224Name, hash)
+		shards, err := c.shardIndexer.ByIndex(byShardNameHash, hash)
 		if err != nil {
 			return nil, err
 		}
@@ -98,7 +102,27 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1beta1.Workspace
 	}
 
 	reconcilers := []reconciler{
-		&metaDataReconciler{},
+		&metaDataReconciler{
+			annotateTimeToReady: c.annotateTimeToReady,
+		},
+		&trashReconciler{
+			now: time.Now,
+			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			},
+			updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{})
+			},
+			createWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error) {
+				return c.kcpClusterClient.Cluster(cluster).TenancyV1beta1().Workspaces().Create(ctx, workspace, metav1.CreateOptions{})
+			},
+			updateWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error) {
+				return c.kcpClusterClient.Cluster(cluster).TenancyV1beta1().Workspaces().Update(ctx, workspace, metav1.UpdateOptions{})
+			},
+			requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
+		},
 		&deletionReconciler{
 			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
@@ -106,14 +130,26 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1beta1.Workspace
 			deleteLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) error {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Delete(ctx, corev1alpha1.LogicalClusterName, metav1.DeleteOptions{})
 			},
+			tombstoneDuration: c.tombstoneDuration,
+			now:               time.Now,
+			createTombstone: func(ctx context.Context, cluster logicalcluster.Path, tombstone *tenancyv1alpha1.WorkspaceTombstone) error {
+				_, err := c.kcpClusterClient.Cluster(cluster).TenancyV1alpha1().WorkspaceTombstones().Create(ctx, tombstone, metav1.CreateOptions{})
+				return err
+			},
 		},
 		&schedulingReconciler{
 			generateClusterName: randomClusterName,
 			getShard: func(name string) (*corev1alpha1.Shard, error) {
 				return c.shardLister.Cluster(core.RootCluster).Get(name)
 			},
-			getShardByHash:   getShardByName,
-			listShards:       c.shardLister.List,
+			getShardByHash: getShardByName,
+			listShards:     c.shardLister.List,
+			listScheduledWorkspaces: func(shardHash string) ([]*tenancyv1beta1.Workspace, error) {
+				return indexers.ByIndex[*tenancyv1beta1.Workspace](c.workspaceIndexer, byShardHash, shardHash)
+			},
+			listUnschedulableWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) {
+				return indexers.ByIndex[*tenancyv1beta1.Workspace](c.workspaceIndexer, unschedulable, "true")
+			},
 			getWorkspaceType: getType,
 			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
 				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
@@ -122,13 +158,69 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1beta1.Workspace
 			kcpLogicalClusterAdminClientFor:  kcpDirectClientFor,
 			kubeLogicalClusterAdminClientFor: kubeDirectClientFor,
 		},
+		&shardHealthReconciler{
+			getShardByHash: getShardByName,
+		},
+		&urlsReconciler{
+			getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+				return c.logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+			},
+		},
 		&phaseReconciler{
 			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
 			},
+			updateLogicalClusterStatus: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().UpdateStatus(ctx, logicalCluster, metav1.UpdateOptions{})
+			},
+			getWorkspaceType: getType,
+			requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
+			now: time.Now,
+		},
+		&archiveReconciler{
+			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			},
+			updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{})
+			},
+		},
+		&ownershipReconciler{
+			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			},
+			updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{})
+			},
+		},
+		&expiryReconciler{
+			now: time.Now,
+			deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string) error {
+				return c.kcpClusterClient.Cluster(cluster).TenancyV1beta1().Workspaces().Delete(ctx, name, metav1.DeleteOptions{})
+			},
+			archiveWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string) error {
+				patch := fmt.Sprintf(`{"spec":{"state":%q}}`, tenancyv1beta1.WorkspaceStateArchived)
+				_, err := c.kcpClusterClient.Cluster(cluster).TenancyV1beta1().Workspaces().Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+				return err
+			},
+			createEvent: func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error {
+				_, err := c.kubeClusterClient.Cluster(cluster).CoreV1().Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{})
+				return err
+			},
+			requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
+			},
+		},
+		&mountReconciler{
+			getMountObject: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+				return c.dynamicClusterClient.Cluster(cluster).Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+			},
 			requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
 				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
 			},
+			dnsName: c.mountDNSName,
 		},
 	}
 
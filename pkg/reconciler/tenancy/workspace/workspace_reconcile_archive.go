@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// archiveReconciler propagates spec.state of a ready workspace to the archived annotation of its
+// logical cluster, which makes the logical cluster read-only in admission.
+type archiveReconciler struct {
+	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalCluster func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error)
+}
+
+func (r *archiveReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "archive")
+
+	if !workspace.DeletionTimestamp.IsZero() || workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+		return reconcileStatusContinue, nil
+	}
+	logger = logger.WithValues("cluster", workspace.Spec.Cluster)
+
+	logicalCluster, err := r.getLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster))
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil // the phase reconciler takes care
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	wantArchived := workspace.Spec.State == tenancyv1beta1.WorkspaceStateArchived
+	_, archived := logicalCluster.Annotations[corev1alpha1.LogicalClusterArchivedAnnotationKey]
+	if wantArchived != archived {
+		logicalCluster = logicalCluster.DeepCopy()
+		if wantArchived {
+			logger.Info("Archiving LogicalCluster")
+			if logicalCluster.Annotations == nil {
+				logicalCluster.Annotations = map[string]string{}
+			}
+			logicalCluster.Annotations[corev1alpha1.LogicalClusterArchivedAnnotationKey] = "true"
+		} else {
+			logger.Info("Unarchiving LogicalCluster")
+			delete(logicalCluster.Annotations, corev1alpha1.LogicalClusterArchivedAnnotationKey)
+		}
+		if _, err := r.updateLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster), logicalCluster); err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+	}
+
+	if wantArchived {
+		conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceArchived)
+	} else {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceArchived)
+	}
+
+	return reconcileStatusContinue, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileArchive(t *testing.T) {
+	for _, testCase := range []struct {
+		name          string
+		state         tenancyv1beta1.WorkspaceState
+		phase         corev1alpha1.LogicalClusterPhaseType
+		archived      bool
+		wasArchived   bool
+		wantUpdate    bool
+		wantArchived  bool
+		wantCondition bool
+	}{
+		{
+			name:  "active",
+			state: tenancyv1beta1.WorkspaceStateActive,
+			phase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+		{
+			name:  "not ready yet",
+			state: tenancyv1beta1.WorkspaceStateArchived,
+			phase: corev1alpha1.LogicalClusterPhaseInitializing,
+		},
+		{
+			name:          "archives",
+			state:         tenancyv1beta1.WorkspaceStateArchived,
+			phase:         corev1alpha1.LogicalClusterPhaseReady,
+			wantUpdate:    true,
+			wantArchived:  true,
+			wantCondition: true,
+		},
+		{
+			name:          "stays archived",
+			state:         tenancyv1beta1.WorkspaceStateArchived,
+			phase:         corev1alpha1.LogicalClusterPhaseReady,
+			archived:      true,
+			wasArchived:   true,
+			wantCondition: true,
+		},
+		{
+			name:        "unarchives",
+			state:       tenancyv1beta1.WorkspaceStateActive,
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			archived:    true,
+			wasArchived: true,
+			wantUpdate:  true,
+		},
+		{
+			name:        "unarchives with unset state",
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			archived:    true,
+			wasArchived: true,
+			wantUpdate:  true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws"},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "somewhere",
+					State:   testCase.state,
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{Phase: testCase.phase},
+			}
+			if testCase.wasArchived {
+				conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceArchived)
+			}
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+			}
+			if testCase.archived {
+				logicalCluster.Annotations = map[string]string{corev1alpha1.LogicalClusterArchivedAnnotationKey: "true"}
+			}
+
+			var updated *corev1alpha1.LogicalCluster
+			r := &archiveReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "somewhere", cluster.String())
+					return logicalCluster, nil
+				},
+				updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "somewhere", cluster.String())
+					updated = logicalCluster
+					return logicalCluster, nil
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+
+			require.Equal(t, testCase.wantUpdate, updated != nil)
+			if updated != nil {
+				_, archived := updated.Annotations[corev1alpha1.LogicalClusterArchivedAnnotationKey]
+				require.Equal(t, testCase.wantArchived, archived)
+			}
+			if testCase.phase == corev1alpha1.LogicalClusterPhaseReady {
+				require.Equal(t, testCase.wantCondition, conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceArchived))
+			}
+		})
+	}
+}
@@ -19,20 +19,29 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
 )
 
 type deletionReconciler struct {
 	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
 	deleteLogicalCluster func(ctx context.Context, cluster logicalcluster.Path) error
+
+	// tombstoneDuration is how long the name of a deleted workspace stays reserved. Zero disables
+	// the reservation.
+	tombstoneDuration time.Duration
+	now               func() time.Time
+	createTombstone   func(ctx context.Context, cluster logicalcluster.Path, tombstone *tenancyv1alpha1.WorkspaceTombstone) error
 }
 
 func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
@@ -67,6 +76,9 @@ func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 	} else if apierrors.IsNotFound(err) {
 		finalizers := sets.NewString(workspace.Finalizers...)
 		if finalizers.Has(corev1alpha1.LogicalClusterFinalizer) {
+			if err := r.reserveName(ctx, workspace); err != nil {
+				return reconcileStatusStopAndRequeue, err
+			}
 			logger.Info(fmt.Sprintf("Removing finalizer %s", corev1alpha1.LogicalClusterFinalizer))
 			workspace.Finalizers = finalizers.Delete(corev1alpha1.LogicalClusterFinalizer).List()
 			return reconcileStatusStopAndRequeue, nil // spec change
@@ -83,3 +95,27 @@ func (r *deletionReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 
 	return reconcileStatusContinue, nil
 }
+
+// reserveName creates a WorkspaceTombstone for the workspace before its last finalizer goes away, such
+// that its name cannot be reclaimed by somebody else until the tombstone expires or is purged.
+func (r *deletionReconciler) reserveName(ctx context.Context, workspace *tenancyv1beta1.Workspace) error {
+	if r.tombstoneDuration <= 0 {
+		return nil
+	}
+
+	tombstone := &tenancyv1alpha1.WorkspaceTombstone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: workspace.Name,
+		},
+		Spec: tenancyv1alpha1.WorkspaceTombstoneSpec{
+			Cluster:   workspace.Spec.Cluster,
+			UID:       string(workspace.UID),
+			ExpiresAt: metav1.NewTime(r.now().Add(r.tombstoneDuration)).Rfc3339Copy(),
+		},
+	}
+	if err := r.createTombstone(ctx, logicalcluster.From(workspace).Path(), tombstone); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create WorkspaceTombstone: %w", err)
+	}
+	klog.FromContext(ctx).WithValues("reconciler", "deletion").V(2).Info("reserved workspace name", "expiresAt", tombstone.Spec.ExpiresAt)
+	return nil
+}
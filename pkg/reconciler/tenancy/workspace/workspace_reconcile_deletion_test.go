@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestReconcileDeletionTombstone(t *testing.T) {
+	now := time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		name              string
+		tombstoneDuration time.Duration
+		createErr         error
+
+		wantStatus     reconcileStatus
+		wantErr        bool
+		wantTombstone  *tenancyv1alpha1.WorkspaceTombstone
+		wantFinalizers []string
+	}{
+		{
+			name:           "tombstones disabled",
+			wantStatus:     reconcileStatusStopAndRequeue,
+			wantFinalizers: []string{},
+		},
+		{
+			name:              "creates tombstone",
+			tombstoneDuration: 24 * time.Hour,
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantFinalizers:    []string{},
+			wantTombstone: &tenancyv1alpha1.WorkspaceTombstone{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+				Spec: tenancyv1alpha1.WorkspaceTombstoneSpec{
+					Cluster:   "somecluster",
+					UID:       "uid",
+					ExpiresAt: metav1.NewTime(now.Add(24 * time.Hour)),
+				},
+			},
+		},
+		{
+			name:              "tombstone exists already",
+			tombstoneDuration: 24 * time.Hour,
+			createErr:         apierrors.NewAlreadyExists(tenancyv1alpha1.Resource("workspacetombstones"), "team-a"),
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantFinalizers:    []string{},
+		},
+		{
+			name:              "tombstone creation fails",
+			tombstoneDuration: 24 * time.Hour,
+			createErr:         errors.New("boom"),
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantErr:           true,
+			wantFinalizers:    []string{corev1alpha1.LogicalClusterFinalizer},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var created *tenancyv1alpha1.WorkspaceTombstone
+			r := &deletionReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+				},
+				tombstoneDuration: testCase.tombstoneDuration,
+				now:               func() time.Time { return now },
+				createTombstone: func(ctx context.Context, cluster logicalcluster.Path, tombstone *tenancyv1alpha1.WorkspaceTombstone) error {
+					require.Equal(t, logicalcluster.NewPath("root:org"), cluster)
+					if testCase.createErr != nil {
+						return testCase.createErr
+					}
+					created = tombstone
+					return nil
+				},
+			}
+
+			ws := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "team-a",
+					UID:               "uid",
+					DeletionTimestamp: &metav1.Time{Time: now.Add(-time.Minute)},
+					Finalizers:        []string{corev1alpha1.LogicalClusterFinalizer},
+					Annotations:       map[string]string{logicalcluster.AnnotationKey: "root:org"},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{Cluster: "somecluster"},
+				Status: tenancyv1beta1.WorkspaceStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+				},
+			}
+			status, err := r.reconcile(context.Background(), ws)
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantTombstone, created)
+			require.Equal(t, testCase.wantFinalizers, ws.Finalizers)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+const (
+	workspaceExpiringEventReason = "Expiring"
+	workspaceExpiredEventReason  = "Expired"
+)
+
+// expiryReconciler deletes or archives ready workspaces with spec.ttl which have been inactive for
+// longer than the ttl. The time of expiry is published in the status, and within the warning period
+// the Expiring condition is set and a warning event is emitted in the parent workspace.
+type expiryReconciler struct {
+	now func() time.Time
+
+	deleteWorkspace  func(ctx context.Context, cluster logicalcluster.Path, name string) error
+	archiveWorkspace func(ctx context.Context, cluster logicalcluster.Path, name string) error
+	createEvent      func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error
+
+	requeueAfter func(workspace *tenancyv1beta1.Workspace, after time.Duration)
+}
+
+func (r *expiryReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "expiry")
+
+	ttl := workspace.Spec.TTL
+	if ttl == nil || !workspace.DeletionTimestamp.IsZero() || workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady || workspace.Spec.State == tenancyv1beta1.WorkspaceStateArchived {
+		workspace.Status.ExpiryTime = nil
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceExpiring)
+		return reconcileStatusContinue, nil
+	}
+
+	lastActivity := workspace.CreationTimestamp.Time
+	if summary := workspace.Status.Summary; summary != nil && summary.LastActivityTime != nil && summary.LastActivityTime.After(lastActivity) {
+		lastActivity = summary.LastActivityTime.Time
+	}
+	expiry := metav1.NewTime(lastActivity.Add(ttl.Inactivity.Duration)).Rfc3339Copy()
+	warningPeriod := tenancyv1beta1.DefaultWorkspaceTTLWarningPeriod
+	if ttl.WarningPeriod != nil {
+		warningPeriod = ttl.WarningPeriod.Duration
+	}
+	action := "deleted"
+	if ttl.Action == tenancyv1beta1.WorkspaceTTLActionArchive {
+		action = "archived"
+	}
+
+	remaining := expiry.Sub(r.now())
+	if remaining <= 0 {
+		logger = logger.WithValues("lastActivity", lastActivity, "inactivity", ttl.Inactivity.Duration)
+		message := fmt.Sprintf("Workspace has been inactive since %s and is %s.", lastActivity.UTC().Format(time.RFC3339), action)
+		r.recordEvent(ctx, workspace, corev1.EventTypeNormal, workspaceExpiredEventReason, message)
+
+		var err error
+		if ttl.Action == tenancyv1beta1.WorkspaceTTLActionArchive {
+			logger.Info("Workspace expired, archiving it")
+			err = r.archiveWorkspace(ctx, logicalcluster.From(workspace).Path(), workspace.Name)
+		} else {
+			logger.Info("Workspace expired, deleting it")
+			err = r.deleteWorkspace(ctx, logicalcluster.From(workspace).Path(), workspace.Name)
+		}
+		if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		return reconcileStatusContinue, nil
+	}
+
+	workspace.Status.ExpiryTime = &expiry
+
+	if remaining > warningPeriod {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceExpiring)
+		r.requeueAfter(workspace, remaining-warningPeriod)
+		return reconcileStatusContinue, nil
+	}
+
+	message := fmt.Sprintf("Workspace has been inactive since %s and will be %s at %s unless it is used.", lastActivity.UTC().Format(time.RFC3339), action, expiry.UTC().Format(time.RFC3339))
+	if !conditions.IsTrue(workspace, tenancyv1alpha1.WorkspaceExpiring) {
+		r.recordEvent(ctx, workspace, corev1.EventTypeWarning, workspaceExpiringEventReason, message)
+	}
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceExpiring,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityWarning,
+		Reason:   tenancyv1alpha1.WorkspaceInactiveReason,
+		Message:  message,
+	})
+	r.requeueAfter(workspace, remaining)
+
+	return reconcileStatusContinue, nil
+}
+
+// recordEvent creates an event about the workspace in the default namespace of its parent. Events
+// are informational, hence failures are only logged.
+func (r *expiryReconciler) recordEvent(ctx context.Context, workspace *tenancyv1beta1.Workspace, eventType, reason, message string) {
+	now := metav1.NewTime(r.now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: workspace.Name + ".",
+			Namespace:    metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      tenancyv1beta1.SchemeGroupVersion.String(),
+			Kind:            "Workspace",
+			Name:            workspace.Name,
+			UID:             workspace.UID,
+			ResourceVersion: workspace.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: ControllerName},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if err := r.createEvent(ctx, logicalcluster.From(workspace).Path(), event); err != nil {
+		klog.FromContext(ctx).Error(err, "failed to create event", "reason", reason)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileExpiry(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	created := metav1.NewTime(now.Add(-48 * time.Hour))
+
+	expiring := &conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceExpiring,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityWarning,
+		Reason:   tenancyv1alpha1.WorkspaceInactiveReason,
+		Message:  "Workspace has been inactive since 2022-09-30T11:30:00Z and will be deleted at 2022-10-01T12:30:00Z unless it is used.",
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		ttl           *tenancyv1beta1.WorkspaceTTL
+		phase         corev1alpha1.LogicalClusterPhaseType
+		lastActivity  *metav1.Time
+		wasExpiring   bool
+		expiryTime    *metav1.Time
+		wantExpiry    *metav1.Time
+		wantCondition *conditionsv1alpha1.Condition
+		wantRequeue   time.Duration
+		wantEvents    []string
+		wantDeleted   bool
+		wantArchived  bool
+	}{
+		{
+			name:       "no ttl clears the status",
+			phase:      corev1alpha1.LogicalClusterPhaseReady,
+			expiryTime: &created,
+		},
+		{
+			name:  "not ready yet",
+			ttl:   &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: time.Hour}},
+			phase: corev1alpha1.LogicalClusterPhaseInitializing,
+		},
+		{
+			name:         "active, requeued at the start of the warning period",
+			ttl:          &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: 25 * time.Hour}},
+			phase:        corev1alpha1.LogicalClusterPhaseReady,
+			lastActivity: &metav1.Time{Time: now.Add(-time.Hour)},
+			wantExpiry:   &metav1.Time{Time: now.Add(24 * time.Hour)},
+			wantRequeue:  23 * time.Hour,
+		},
+		{
+			name:          "within the warning period",
+			ttl:           &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: 25 * time.Hour}},
+			phase:         corev1alpha1.LogicalClusterPhaseReady,
+			lastActivity:  &metav1.Time{Time: now.Add(-24*time.Hour - 30*time.Minute)},
+			wantExpiry:    &metav1.Time{Time: now.Add(30 * time.Minute)},
+			wantCondition: expiring,
+			wantRequeue:   30 * time.Minute,
+			wantEvents:    []string{"Warning/Expiring"},
+		},
+		{
+			name:          "within the warning period, event already emitted",
+			ttl:           &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: 25 * time.Hour}},
+			phase:         corev1alpha1.LogicalClusterPhaseReady,
+			lastActivity:  &metav1.Time{Time: now.Add(-24*time.Hour - 30*time.Minute)},
+			wasExpiring:   true,
+			wantExpiry:    &metav1.Time{Time: now.Add(30 * time.Minute)},
+			wantCondition: expiring,
+			wantRequeue:   30 * time.Minute,
+		},
+		{
+			name: "custom warning period",
+			ttl: &tenancyv1beta1.WorkspaceTTL{
+				Inactivity:    metav1.Duration{Duration: 72 * time.Hour},
+				WarningPeriod: &metav1.Duration{Duration: 48 * time.Hour},
+			},
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			wantExpiry:  &metav1.Time{Time: now.Add(24 * time.Hour)},
+			wantRequeue: 24 * time.Hour,
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceExpiring,
+				Status:   corev1.ConditionTrue,
+				Severity: conditionsv1alpha1.ConditionSeverityWarning,
+				Reason:   tenancyv1alpha1.WorkspaceInactiveReason,
+				Message:  "Workspace has been inactive since 2022-09-29T12:00:00Z and will be deleted at 2022-10-02T12:00:00Z unless it is used.",
+			},
+			wantEvents: []string{"Warning/Expiring"},
+		},
+		{
+			name:        "expired without activity since creation",
+			ttl:         &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: 24 * time.Hour}},
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			wantEvents:  []string{"Normal/Expired"},
+			wantDeleted: true,
+		},
+		{
+			name:         "expired and archived",
+			ttl:          &tenancyv1beta1.WorkspaceTTL{Inactivity: metav1.Duration{Duration: 24 * time.Hour}, Action: tenancyv1beta1.WorkspaceTTLActionArchive},
+			phase:        corev1alpha1.LogicalClusterPhaseReady,
+			lastActivity: &metav1.Time{Time: now.Add(-25 * time.Hour)},
+			wantEvents:   []string{"Normal/Expired"},
+			wantArchived: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "ws",
+					CreationTimestamp: created,
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:org",
+					},
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "somewhere",
+					TTL:     testCase.ttl,
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{
+					Phase:      testCase.phase,
+					ExpiryTime: testCase.expiryTime,
+				},
+			}
+			if testCase.lastActivity != nil {
+				workspace.Status.Summary = &tenancyv1beta1.WorkspaceSummary{LastActivityTime: testCase.lastActivity}
+			}
+			if testCase.wasExpiring {
+				conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceExpiring)
+			}
+
+			var requeuedAfter time.Duration
+			var events []string
+			deleted, archived := false, false
+			r := &expiryReconciler{
+				now: func() time.Time { return now },
+				deleteWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string) error {
+					require.Equal(t, "root:org", cluster.String())
+					deleted = true
+					return nil
+				},
+				archiveWorkspace: func(ctx context.Context, cluster logicalcluster.Path, name string) error {
+					require.Equal(t, "root:org", cluster.String())
+					archived = true
+					return nil
+				},
+				createEvent: func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error {
+					require.Equal(t, "root:org", cluster.String())
+					require.Equal(t, "ws", event.InvolvedObject.Name)
+					events = append(events, event.Type+"/"+event.Reason)
+					return nil
+				},
+				requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+					requeuedAfter = after
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantExpiry, workspace.Status.ExpiryTime)
+			require.Equal(t, testCase.wantRequeue, requeuedAfter)
+			require.Equal(t, testCase.wantEvents, events)
+			require.Equal(t, testCase.wantDeleted, deleted)
+			require.Equal(t, testCase.wantArchived, archived)
+
+			got := conditions.Get(workspace, tenancyv1alpha1.WorkspaceExpiring)
+			if testCase.wantCondition == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			got.LastTransitionTime = metav1.Time{}
+			require.Equal(t, testCase.wantCondition, got)
+		})
+	}
+}
@@ -21,14 +21,18 @@ import (
 	"encoding/json"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type metaDataReconciler struct {
+	// annotateTimeToReady makes ready workspaces carry their time to ready in an annotation.
+	annotateTimeToReady bool
 }
 
 func (r *metaDataReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
@@ -38,6 +42,9 @@ func (r *metaDataReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 	expected := string(workspace.Status.Phase)
 	if !workspace.DeletionTimestamp.IsZero() {
 		expected = "Deleting"
+		if sets.NewString(workspace.Finalizers...).Has(tenancyv1alpha1.WorkspaceTrashFinalizer) {
+			expected = "Trashed"
+		}
 	}
 	if got := workspace.Labels[tenancyv1alpha1.WorkspacePhaseLabel]; got != expected {
 		if workspace.Labels == nil {
@@ -65,6 +72,16 @@ func (r *metaDataReconciler) reconcile(ctx context.Context, workspace *tenancyv1
 				changed = true
 			}
 		}
+
+		if _, found := workspace.Annotations[tenancyv1alpha1.WorkspaceTimeToReadyAnnotationKey]; r.annotateTimeToReady && !found {
+			if cond := conditions.Get(workspace, tenancyv1alpha1.WorkspaceInitialized); cond != nil {
+				if workspace.Annotations == nil {
+					workspace.Annotations = map[string]string{}
+				}
+				workspace.Annotations[tenancyv1alpha1.WorkspaceTimeToReadyAnnotationKey] = cond.LastTransitionTime.Sub(workspace.CreationTimestamp.Time).String()
+				changed = true
+			}
+		}
 	}
 
 	if changed {
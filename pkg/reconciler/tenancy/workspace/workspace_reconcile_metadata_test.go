@@ -24,10 +24,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 func TestReconcileMetadata(t *testing.T) {
@@ -35,10 +38,11 @@ func TestReconcileMetadata(t *testing.T) {
 	require.NoError(t, err)
 
 	for _, testCase := range []struct {
-		name       string
-		input      *tenancyv1beta1.Workspace
-		expected   metav1.ObjectMeta
-		wantStatus reconcileStatus
+		name                string
+		annotateTimeToReady bool
+		input               *tenancyv1beta1.Workspace
+		expected            metav1.ObjectMeta
+		wantStatus          reconcileStatus
 	}{
 		{
 			name: "removes everything but owner username when ready",
@@ -85,6 +89,29 @@ func TestReconcileMetadata(t *testing.T) {
 			},
 			wantStatus: reconcileStatusStopAndRequeue,
 		},
+		{
+			name: "shows phase Trashed when deleted workspace is in the trash",
+			input: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					DeletionTimestamp: &metav1.Time{Time: date},
+					Finalizers:        []string{"tenancy.kcp.io/trash"},
+					Labels: map[string]string{
+						"tenancy.kcp.io/phase": "Deleting",
+					},
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+				},
+			},
+			expected: metav1.ObjectMeta{
+				DeletionTimestamp: &metav1.Time{Time: date},
+				Finalizers:        []string{"tenancy.kcp.io/trash"},
+				Labels: map[string]string{
+					"tenancy.kcp.io/phase": "Trashed",
+				},
+			},
+			wantStatus: reconcileStatusStopAndRequeue,
+		},
 		{
 			name: "delete invalid owner annotation when ready",
 			input: &tenancyv1beta1.Workspace{
@@ -111,9 +138,69 @@ func TestReconcileMetadata(t *testing.T) {
 			},
 			wantStatus: reconcileStatusStopAndRequeue,
 		},
+		{
+			name:                "annotates time to ready when ready",
+			annotateTimeToReady: true,
+			input: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Time{Time: date},
+					Labels: map[string]string{
+						"tenancy.kcp.io/phase": "Ready",
+					},
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+					Conditions: conditionsv1alpha1.Conditions{
+						{
+							Type:               tenancyv1alpha1.WorkspaceInitialized,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Time{Time: date.Add(12 * time.Second)},
+						},
+					},
+				},
+			},
+			expected: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Time{Time: date},
+				Labels: map[string]string{
+					"tenancy.kcp.io/phase": "Ready",
+				},
+				Annotations: map[string]string{
+					"tenancy.kcp.io/time-to-ready": "12s",
+				},
+			},
+			wantStatus: reconcileStatusStopAndRequeue,
+		},
+		{
+			name: "does not annotate time to ready if disabled",
+			input: &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Time{Time: date},
+					Labels: map[string]string{
+						"tenancy.kcp.io/phase": "Ready",
+					},
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{
+					Phase: corev1alpha1.LogicalClusterPhaseReady,
+					Conditions: conditionsv1alpha1.Conditions{
+						{
+							Type:               tenancyv1alpha1.WorkspaceInitialized,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Time{Time: date.Add(12 * time.Second)},
+						},
+					},
+				},
+			},
+			expected: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Time{Time: date},
+				Labels: map[string]string{
+					"tenancy.kcp.io/phase": "Ready",
+				},
+			},
+			wantStatus: reconcileStatusContinue,
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
-			reconciler := metaDataReconciler{}
+			reconciler := metaDataReconciler{annotateTimeToReady: testCase.annotateTimeToReady}
 			status, err := reconciler.reconcile(context.Background(), testCase.input)
 
 			require.NoError(t, err)
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+const (
+	// mountReadyResyncPeriod is the period the object referenced by a ready mount is checked in.
+	mountReadyResyncPeriod = time.Minute
+	// mountNotReadyResyncPeriod is the period the object referenced by a mount which is not
+	// ready is checked in.
+	mountNotReadyResyncPeriod = 10 * time.Second
+)
+
+// mountReconciler maintains the MountReady condition and status.mount of mounted workspaces
+// from the status of the object referenced by spec.mount. The referenced objects are of
+// arbitrary types and therefore not watched, but checked periodically.
+type mountReconciler struct {
+	getMountObject func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	requeueAfter   func(workspace *tenancyv1beta1.Workspace, after time.Duration)
+
+	// dnsName is nil if DNS names of mounts are disabled.
+	dnsName func(workspace *tenancyv1beta1.Workspace) (string, error)
+}
+
+func (r *mountReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	if workspace.Spec.Mount == nil {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceMountReady)
+		workspace.Status.Mount = nil
+		return reconcileStatusContinue, nil
+	}
+	if !workspace.DeletionTimestamp.IsZero() {
+		return reconcileStatusContinue, nil
+	}
+
+	ref := workspace.Spec.Mount.Reference
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Invalid apiVersion %q: %v", ref.APIVersion, err)
+		return reconcileStatusContinue, nil
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(ref.Kind))
+
+	obj, err := r.getMountObject(ctx, logicalcluster.From(workspace).Path(), gvr, ref.Namespace, ref.Name)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountNotFoundReason, conditionsv1alpha1.ConditionSeverityError,
+			"%s %s not found", ref.Kind, mountObjectName(ref))
+		r.requeueAfter(workspace, mountNotReadyResyncPeriod)
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	mountURL, _, err := unstructured.NestedString(obj.Object, "status", "URL")
+	if err != nil {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Invalid status.URL of %s %s: %v", ref.Kind, mountObjectName(ref), err)
+		r.requeueAfter(workspace, mountNotReadyResyncPeriod)
+		return reconcileStatusContinue, nil
+	}
+	if err := validateMountURL(mountURL); err != nil {
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountInvalidReason, conditionsv1alpha1.ConditionSeverityError,
+			"Invalid status.URL of %s %s: %v", ref.Kind, mountObjectName(ref), err)
+		r.requeueAfter(workspace, mountNotReadyResyncPeriod)
+		return reconcileStatusContinue, nil
+	}
+	workspace.Status.Mount = &tenancyv1beta1.WorkspaceMountStatus{URL: mountURL}
+	if r.dnsName != nil {
+		if name, err := r.dnsName(workspace); err != nil {
+			// publish the URL without a DNS name rather than not at all
+			klog.FromContext(ctx).Error(err, "error rendering the DNS name of the mount")
+		} else {
+			workspace.Status.Mount.DNSName = name
+		}
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if tenancyv1beta1.MountPhaseType(phase) != tenancyv1beta1.MountPhaseReady {
+		if phase == "" {
+			phase = string(tenancyv1beta1.MountPhaseUnknown)
+		}
+		conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceMountReady, tenancyv1alpha1.WorkspaceMountNotReadyReason, conditionsv1alpha1.ConditionSeverityWarning,
+			"%s %s is in phase %s", ref.Kind, mountObjectName(ref), phase)
+		r.requeueAfter(workspace, mountNotReadyResyncPeriod)
+		return reconcileStatusContinue, nil
+	}
+
+	conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceMountReady)
+	r.requeueAfter(workspace, mountReadyResyncPeriod)
+
+	return reconcileStatusContinue, nil
+}
+
+func mountObjectName(ref tenancyv1beta1.ObjectReference) string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%q", ref.Name)
+	}
+	return fmt.Sprintf("%q", ref.Namespace+"/"+ref.Name)
+}
+
+func validateMountURL(mountURL string) error {
+	if mountURL == "" {
+		return fmt.Errorf("no URL published")
+	}
+	u, err := url.Parse(mountURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("no host")
+	}
+	return nil
+}
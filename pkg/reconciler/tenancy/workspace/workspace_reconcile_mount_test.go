@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileMount(t *testing.T) {
+	proxy := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "proxy.example.com/v1alpha1",
+			"kind":       "ClusterProxy",
+			"metadata":   map[string]interface{}{"name": "cluster"},
+			"status":     status,
+		}}
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		noMount       bool
+		apiVersion    string
+		object        *unstructured.Unstructured
+		wantCondition *conditionsv1alpha1.Condition
+		dnsBaseDomain string
+		wantURL       string
+		wantDNSName   string
+		wantRequeue   time.Duration
+	}{
+		{
+			name:    "not mounted",
+			noMount: true,
+		},
+		{
+			name:       "invalid apiVersion",
+			apiVersion: "proxy.example.com/v1alpha1/foo",
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceMountReady,
+				Status:   corev1.ConditionFalse,
+				Severity: conditionsv1alpha1.ConditionSeverityError,
+				Reason:   tenancyv1alpha1.WorkspaceMountInvalidReason,
+				Message:  `Invalid apiVersion "proxy.example.com/v1alpha1/foo": unexpected GroupVersion string: proxy.example.com/v1alpha1/foo`,
+			},
+		},
+		{
+			name: "object not found",
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceMountReady,
+				Status:   corev1.ConditionFalse,
+				Severity: conditionsv1alpha1.ConditionSeverityError,
+				Reason:   tenancyv1alpha1.WorkspaceMountNotFoundReason,
+				Message:  `ClusterProxy "cluster" not found`,
+			},
+			wantRequeue: mountNotReadyResyncPeriod,
+		},
+		{
+			name:   "no URL",
+			object: proxy(map[string]interface{}{"phase": "Ready"}),
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceMountReady,
+				Status:   corev1.ConditionFalse,
+				Severity: conditionsv1alpha1.ConditionSeverityError,
+				Reason:   tenancyv1alpha1.WorkspaceMountInvalidReason,
+				Message:  `Invalid status.URL of ClusterProxy "cluster": no URL published`,
+			},
+			wantRequeue: mountNotReadyResyncPeriod,
+		},
+		{
+			name:   "connecting",
+			object: proxy(map[string]interface{}{"URL": "https://proxy.example.com/clusters/cluster", "phase": "Connecting"}),
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceMountReady,
+				Status:   corev1.ConditionFalse,
+				Severity: conditionsv1alpha1.ConditionSeverityWarning,
+				Reason:   tenancyv1alpha1.WorkspaceMountNotReadyReason,
+				Message:  `ClusterProxy "cluster" is in phase Connecting`,
+			},
+			wantURL:     "https://proxy.example.com/clusters/cluster",
+			wantRequeue: mountNotReadyResyncPeriod,
+		},
+		{
+			name:   "ready",
+			object: proxy(map[string]interface{}{"URL": "https://proxy.example.com/clusters/cluster", "phase": "Ready"}),
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:   tenancyv1alpha1.WorkspaceMountReady,
+				Status: corev1.ConditionTrue,
+			},
+			wantURL:     "https://proxy.example.com/clusters/cluster",
+			wantRequeue: mountReadyResyncPeriod,
+		},
+		{
+			name:          "ready with DNS name",
+			object:        proxy(map[string]interface{}{"URL": "https://proxy.example.com/clusters/cluster", "phase": "Ready"}),
+			dnsBaseDomain: "mounts.kcp.dev",
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:   tenancyv1alpha1.WorkspaceMountReady,
+				Status: corev1.ConditionTrue,
+			},
+			wantURL:     "https://proxy.example.com/clusters/cluster",
+			wantDNSName: "ws.root.mounts.kcp.dev",
+			wantRequeue: mountReadyResyncPeriod,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ws",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "root"},
+				},
+			}
+			if !testCase.noMount {
+				apiVersion := testCase.apiVersion
+				if apiVersion == "" {
+					apiVersion = "proxy.example.com/v1alpha1"
+				}
+				workspace.Spec.Mount = &tenancyv1beta1.Mount{Reference: tenancyv1beta1.ObjectReference{APIVersion: apiVersion, Kind: "ClusterProxy", Name: "cluster"}}
+			}
+
+			var requeue time.Duration
+			r := &mountReconciler{
+				getMountObject: func(ctx context.Context, cluster logicalcluster.Path, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+					require.Equal(t, logicalcluster.NewPath("root"), cluster)
+					require.Equal(t, schema.GroupVersionResource{Group: "proxy.example.com", Version: "v1alpha1", Resource: "clusterproxies"}, gvr)
+					require.Equal(t, "cluster", name)
+					if testCase.object == nil {
+						return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+					}
+					return testCase.object, nil
+				},
+				requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+					requeue = after
+				},
+			}
+			if testCase.dnsBaseDomain != "" {
+				dnsName, err := newMountDNSNamer(testCase.dnsBaseDomain, DefaultMountDNSNameTemplate)
+				require.NoError(t, err)
+				r.dnsName = dnsName
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantRequeue, requeue)
+
+			if testCase.wantURL == "" {
+				require.Nil(t, workspace.Status.Mount)
+			} else {
+				require.Equal(t, &tenancyv1beta1.WorkspaceMountStatus{URL: testCase.wantURL, DNSName: testCase.wantDNSName}, workspace.Status.Mount)
+			}
+
+			got := conditions.Get(workspace, tenancyv1alpha1.WorkspaceMountReady)
+			if testCase.wantCondition == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			got.LastTransitionTime = metav1.Time{}
+			require.Equal(t, testCase.wantCondition, got)
+		})
+	}
+}
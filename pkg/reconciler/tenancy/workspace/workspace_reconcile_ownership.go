@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	workspaceadmission "github.com/kcp-dev/kcp/pkg/admission/workspace"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// ownershipReconciler propagates spec.owner of a ready workspace to the owner annotation of its
+// logical cluster, from where the workspace-admin ClusterRoleBinding follows the new owner, and
+// records the last ownership transfer in the OwnershipTransferred condition.
+type ownershipReconciler struct {
+	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalCluster func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error)
+}
+
+func (r *ownershipReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "ownership")
+
+	if !workspace.DeletionTimestamp.IsZero() || workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady || workspace.Spec.Owner == nil {
+		return reconcileStatusContinue, nil
+	}
+	logger = logger.WithValues("cluster", workspace.Spec.Cluster)
+
+	logicalCluster, err := r.getLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster))
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil // the phase reconciler takes care
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	owner := workspace.Spec.Owner.Username
+	if logicalClusterOwner(logicalCluster) != owner {
+		value, err := json.Marshal(authenticationv1.UserInfo{Username: owner})
+		if err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+		logger.Info("Transferring ownership of LogicalCluster", "owner", owner)
+		logicalCluster = logicalCluster.DeepCopy()
+		if logicalCluster.Annotations == nil {
+			logicalCluster.Annotations = map[string]string{}
+		}
+		logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey] = string(value)
+		if _, err := r.updateLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster), logicalCluster); err != nil {
+			return reconcileStatusStopAndRequeue, err
+		}
+	}
+
+	value, found := workspace.Annotations[tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey]
+	if !found {
+		return reconcileStatusContinue, nil
+	}
+	var transfer workspaceadmission.OwnershipTransfer
+	if err := json.Unmarshal([]byte(value), &transfer); err != nil || transfer.To != owner {
+		logger.V(4).Info("ignoring ownership transfer record not matching spec.owner", "value", value)
+		return reconcileStatusContinue, nil
+	}
+
+	from := transfer.From
+	if from == "" {
+		from = "<none>"
+	}
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceOwnershipTransferred,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityInfo,
+		Reason:   tenancyv1alpha1.WorkspaceTransferredReason,
+		Message:  fmt.Sprintf("Ownership transferred from %s to %s by %s.", from, transfer.To, transfer.By),
+	})
+
+	return reconcileStatusContinue, nil
+}
+
+// logicalClusterOwner returns the username in the owner annotation of the logical cluster.
+func logicalClusterOwner(logicalCluster *corev1alpha1.LogicalCluster) string {
+	value, found := logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey]
+	if !found {
+		return ""
+	}
+	var info authenticationv1.UserInfo
+	if err := json.Unmarshal([]byte(value), &info); err != nil {
+		return ""
+	}
+	return info.Username
+}
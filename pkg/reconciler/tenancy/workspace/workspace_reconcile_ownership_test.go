@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileOwnership(t *testing.T) {
+	for _, testCase := range []struct {
+		name                string
+		phase               corev1alpha1.LogicalClusterPhaseType
+		owner               string
+		transfer            string
+		logicalClusterOwner string
+		wantUpdate          string
+		wantMessage         string
+	}{
+		{
+			name:                "owner in sync",
+			phase:               corev1alpha1.LogicalClusterPhaseReady,
+			owner:               "alice",
+			logicalClusterOwner: `{"username":"alice","groups":["a"]}`,
+		},
+		{
+			name:  "no owner",
+			phase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+		{
+			name:                "not ready yet",
+			phase:               corev1alpha1.LogicalClusterPhaseInitializing,
+			owner:               "bob",
+			transfer:            `{"from":"alice","to":"bob","by":"alice"}`,
+			logicalClusterOwner: `{"username":"alice"}`,
+		},
+		{
+			name:                "transfers",
+			phase:               corev1alpha1.LogicalClusterPhaseReady,
+			owner:               "bob",
+			transfer:            `{"from":"alice","to":"bob","by":"admin"}`,
+			logicalClusterOwner: `{"username":"alice"}`,
+			wantUpdate:          `{"username":"bob"}`,
+			wantMessage:         "Ownership transferred from alice to bob by admin.",
+		},
+		{
+			name:                "transferred",
+			phase:               corev1alpha1.LogicalClusterPhaseReady,
+			owner:               "bob",
+			transfer:            `{"from":"alice","to":"bob","by":"alice"}`,
+			logicalClusterOwner: `{"username":"bob"}`,
+			wantMessage:         "Ownership transferred from alice to bob by alice.",
+		},
+		{
+			name:        "transfers workspace without owner annotation on the logical cluster",
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			owner:       "bob",
+			transfer:    `{"to":"bob","by":"admin"}`,
+			wantUpdate:  `{"username":"bob"}`,
+			wantMessage: "Ownership transferred from <none> to bob by admin.",
+		},
+		{
+			name:                "ignores transfer record of another owner",
+			phase:               corev1alpha1.LogicalClusterPhaseReady,
+			owner:               "carol",
+			transfer:            `{"from":"alice","to":"bob","by":"alice"}`,
+			logicalClusterOwner: `{"username":"carol"}`,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws"},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "somewhere",
+				},
+				Status: tenancyv1beta1.WorkspaceStatus{Phase: testCase.phase},
+			}
+			if testCase.owner != "" {
+				workspace.Spec.Owner = &tenancyv1beta1.WorkspaceOwner{Username: testCase.owner}
+			}
+			if testCase.transfer != "" {
+				workspace.Annotations = map[string]string{tenancyv1alpha1.WorkspaceOwnershipTransferAnnotationKey: testCase.transfer}
+			}
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+			}
+			if testCase.logicalClusterOwner != "" {
+				logicalCluster.Annotations = map[string]string{tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey: testCase.logicalClusterOwner}
+			}
+
+			var updated *corev1alpha1.LogicalCluster
+			r := &ownershipReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "somewhere", cluster.String())
+					return logicalCluster, nil
+				},
+				updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "somewhere", cluster.String())
+					updated = logicalCluster
+					return logicalCluster, nil
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+
+			if testCase.wantUpdate == "" {
+				require.Nil(t, updated)
+			} else {
+				require.NotNil(t, updated)
+				require.Equal(t, testCase.wantUpdate, updated.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey])
+			}
+
+			cond := conditions.Get(workspace, tenancyv1alpha1.WorkspaceOwnershipTransferred)
+			if testCase.wantMessage == "" {
+				require.Nil(t, cond)
+			} else {
+				require.NotNil(t, cond)
+				require.Equal(t, testCase.wantMessage, cond.Message)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package workspace
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -25,17 +27,21 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/klog/v2"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 type phaseReconciler struct {
-	getLogicalCluster func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	getLogicalCluster          func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalClusterStatus func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error)
+	getWorkspaceType           func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
 
 	requeueAfter func(workspace *tenancyv1beta1.Workspace, after time.Duration)
+	now          func() time.Time
 }
 
 func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
@@ -60,13 +66,43 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1bet
 
 		workspace.Status.Initializers = logicalCluster.Status.Initializers
 
+		var policies initializerPolicyResult
+		if len(workspace.Status.Initializers) > 0 {
+			policies = evaluateInitializerPolicies(r.initializerPolicies(ctx, workspace), workspace.Status.Initializers, logicalCluster.CreationTimestamp.Time, r.now())
+		}
+
+		if len(policies.ignored) > 0 {
+			logger.Info("removing timed out initializers with failure policy Ignore", "initializers", policies.ignored)
+			logicalCluster = logicalCluster.DeepCopy()
+			for _, initializer := range policies.ignored {
+				logicalCluster.Status.Initializers = initialization.EnsureInitializerAbsent(initializer, logicalCluster.Status.Initializers)
+			}
+			if _, err := r.updateLogicalClusterStatus(ctx, logicalcluster.NewPath(workspace.Spec.Cluster), logicalCluster); err != nil {
+				return reconcileStatusStopAndRequeue, err
+			}
+			workspace.Status.Initializers = logicalCluster.Status.Initializers
+		}
+
 		if initializers := workspace.Status.Initializers; len(initializers) > 0 {
 			after := time.Since(logicalCluster.CreationTimestamp.Time) / 5
 			if max := time.Minute * 10; after > max {
 				after = max
 			}
+			if !policies.nextDeadline.IsZero() {
+				if untilDeadline := policies.nextDeadline.Sub(r.now()); untilDeadline < after {
+					after = untilDeadline
+				}
+			}
 			logger.V(3).Info("LogicalCluster still has initializers, requeueing", "initializers", initializers, "after", after)
-			conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerExists, conditionsv1alpha1.ConditionSeverityInfo, "Initializers still exist: %v", workspace.Status.Initializers)
+			switch {
+			case len(policies.failed) > 0:
+				// keep requeueing: a late initializer still finishes the initialization.
+				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerFailed, conditionsv1alpha1.ConditionSeverityError, "Initialization stopped: %s", strings.Join(policies.failed, "; "))
+			case len(policies.timedOut) > 0:
+				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerTimedOut, conditionsv1alpha1.ConditionSeverityWarning, "Waiting for initializers: %s", strings.Join(policies.timedOut, "; "))
+			default:
+				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerExists, conditionsv1alpha1.ConditionSeverityInfo, "Initializers still exist: %v", workspace.Status.Initializers)
+			}
 			r.requeueAfter(workspace, after)
 			return reconcileStatusContinue, nil
 		}
@@ -111,3 +147,94 @@ func (r *phaseReconciler) reconcile(ctx context.Context, workspace *tenancyv1bet
 
 	return reconcileStatusContinue, nil
 }
+
+// initializerPolicies returns the initializer policies of the type of the workspace.
+func (r *phaseReconciler) initializerPolicies(ctx context.Context, workspace *tenancyv1beta1.Workspace) []tenancyv1alpha1.InitializerPolicy {
+	if workspace.Spec.Type.Name == "" {
+		return nil
+	}
+	wt, err := r.getWorkspaceType(logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+	if err != nil {
+		// without type, initializers wait forever as before.
+		klog.FromContext(ctx).V(3).Info("failed to get WorkspaceType for initializer policies", "err", err)
+		return nil
+	}
+	return wt.Spec.InitializerPolicies
+}
+
+// initializerPolicyResult is the state of the initializers of a workspace with respect to the
+// initializer policies of its type.
+type initializerPolicyResult struct {
+	// ignored are the timed out initializers with failure policy Ignore.
+	ignored []corev1alpha1.LogicalClusterInitializer
+	// timedOut describes the timed out initializers that are retried.
+	timedOut []string
+	// failed describes the initializers that timed out for good.
+	failed []string
+	// nextDeadline is the next time an initializer times out, or zero if there is none.
+	nextDeadline time.Time
+}
+
+// evaluateInitializerPolicies applies the policies to the initializers of a logical cluster created at start.
+// With failure policy Retry, attempt k ends at start + timeout*(2^(k+1)-1), i.e. every retry waits twice as
+// long as the previous attempt.
+func evaluateInitializerPolicies(policies []tenancyv1alpha1.InitializerPolicy, initializers []corev1alpha1.LogicalClusterInitializer, start, now time.Time) initializerPolicyResult {
+	var result initializerPolicyResult
+	track := func(deadline time.Time) {
+		if result.nextDeadline.IsZero() || deadline.Before(result.nextDeadline) {
+			result.nextDeadline = deadline
+		}
+	}
+
+	for _, initializer := range initializers {
+		var policy *tenancyv1alpha1.InitializerPolicy
+		for i := range policies {
+			if policies[i].Initializer == initializer {
+				policy = &policies[i]
+				break
+			}
+		}
+		if policy == nil || policy.Timeout.Duration <= 0 {
+			continue
+		}
+		timeout := policy.Timeout.Duration
+
+		switch policy.FailurePolicy {
+		case tenancyv1alpha1.InitializerFailurePolicyIgnore:
+			if deadline := start.Add(timeout); now.Before(deadline) {
+				track(deadline)
+			} else {
+				result.ignored = append(result.ignored, initializer)
+			}
+
+		case tenancyv1alpha1.InitializerFailurePolicyRetry:
+			maxRetries := int(policy.MaxRetries)
+			if maxRetries <= 0 {
+				maxRetries = tenancyv1alpha1.DefaultInitializerMaxRetries
+			}
+			attempt, deadline := 0, start.Add(timeout)
+			for !now.Before(deadline) && attempt <= maxRetries {
+				attempt++
+				deadline = deadline.Add(timeout << attempt)
+			}
+			switch {
+			case attempt == 0:
+				track(deadline)
+			case attempt <= maxRetries:
+				result.timedOut = append(result.timedOut, fmt.Sprintf("initializer %s timed out, retry %d of %d until %s", initializer, attempt, maxRetries, deadline.UTC().Format(time.RFC3339)))
+				track(deadline)
+			default:
+				result.failed = append(result.failed, fmt.Sprintf("initializer %s timed out after %d retries", initializer, maxRetries))
+			}
+
+		default:
+			if deadline := start.Add(timeout); now.Before(deadline) {
+				track(deadline)
+			} else {
+				result.failed = append(result.failed, fmt.Sprintf("initializer %s timed out after %s", initializer, timeout))
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestEvaluateInitializerPolicies(t *testing.T) {
+	start := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	policy := func(failurePolicy tenancyv1alpha1.InitializerFailurePolicy, maxRetries int32) []tenancyv1alpha1.InitializerPolicy {
+		return []tenancyv1alpha1.InitializerPolicy{{
+			Initializer:   "root:org:example",
+			Timeout:       metav1.Duration{Duration: time.Minute},
+			FailurePolicy: failurePolicy,
+			MaxRetries:    maxRetries,
+		}}
+	}
+	initializers := []corev1alpha1.LogicalClusterInitializer{"root:org:example", "system:apibindings"}
+
+	tests := map[string]struct {
+		policies []tenancyv1alpha1.InitializerPolicy
+		elapsed  time.Duration
+
+		wantIgnored      []corev1alpha1.LogicalClusterInitializer
+		wantTimedOut     []string
+		wantFailed       []string
+		wantNextDeadline time.Duration
+	}{
+		"no policies": {
+			elapsed: time.Hour,
+		},
+		"within timeout": {
+			policies:         policy(tenancyv1alpha1.InitializerFailurePolicyFail, 0),
+			elapsed:          30 * time.Second,
+			wantNextDeadline: time.Minute,
+		},
+		"unset failure policy fails": {
+			policies:   policy("", 0),
+			elapsed:    time.Minute,
+			wantFailed: []string{"initializer root:org:example timed out after 1m0s"},
+		},
+		"ignore": {
+			policies:    policy(tenancyv1alpha1.InitializerFailurePolicyIgnore, 0),
+			elapsed:     2 * time.Minute,
+			wantIgnored: []corev1alpha1.LogicalClusterInitializer{"root:org:example"},
+		},
+		"retry, first attempt": {
+			policies:         policy(tenancyv1alpha1.InitializerFailurePolicyRetry, 2),
+			elapsed:          30 * time.Second,
+			wantNextDeadline: time.Minute,
+		},
+		"retry, first retry": {
+			policies:         policy(tenancyv1alpha1.InitializerFailurePolicyRetry, 2),
+			elapsed:          2 * time.Minute,
+			wantTimedOut:     []string{"initializer root:org:example timed out, retry 1 of 2 until 2022-10-01T12:03:00Z"},
+			wantNextDeadline: 3 * time.Minute,
+		},
+		"retry, last retry": {
+			policies:         policy(tenancyv1alpha1.InitializerFailurePolicyRetry, 2),
+			elapsed:          3 * time.Minute,
+			wantTimedOut:     []string{"initializer root:org:example timed out, retry 2 of 2 until 2022-10-01T12:07:00Z"},
+			wantNextDeadline: 7 * time.Minute,
+		},
+		"retry, exhausted": {
+			policies:   policy(tenancyv1alpha1.InitializerFailurePolicyRetry, 2),
+			elapsed:    7 * time.Minute,
+			wantFailed: []string{"initializer root:org:example timed out after 2 retries"},
+		},
+		"retry, default retries": {
+			policies:   policy(tenancyv1alpha1.InitializerFailurePolicyRetry, 0),
+			elapsed:    15 * time.Minute,
+			wantFailed: []string{"initializer root:org:example timed out after 3 retries"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := evaluateInitializerPolicies(tc.policies, initializers, start, start.Add(tc.elapsed))
+			require.Equal(t, tc.wantIgnored, result.ignored)
+			require.Equal(t, tc.wantTimedOut, result.timedOut)
+			require.Equal(t, tc.wantFailed, result.failed)
+			if tc.wantNextDeadline == 0 {
+				require.True(t, result.nextDeadline.IsZero())
+			} else {
+				require.Equal(t, start.Add(tc.wantNextDeadline), result.nextDeadline)
+			}
+		})
+	}
+}
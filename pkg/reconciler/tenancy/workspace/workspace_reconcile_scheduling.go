@@ -18,17 +18,19 @@ package workspace
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
+	"math"
 	"math/rand"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/martinlindhe/base36"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,22 +40,27 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/tenancy/initialization"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
 	"github.com/kcp-dev/kcp/pkg/authorization"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/pkg/crypto"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/routingtarget"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 )
 
 const (
 	// workspaceShardAnnotationKey keeps track on which shard LogicalCluster must be scheduled. The value
-	// is a base36(sha224) hash of the Shard name.
+	// is a base36 hash of the Shard name.
 	workspaceShardAnnotationKey = "internal.tenancy.kcp.io/shard"
+	// workspaceShardHashAlgorithmAnnotationKey records the algorithm of the hash in
+	// workspaceShardAnnotationKey. Workspaces without it were scheduled with sha224.
+	workspaceShardHashAlgorithmAnnotationKey = "internal.tenancy.kcp.io/shard-hash-algorithm"
 	// workspaceClusterAnnotationKey keeps track of the logical cluster on the shard.
 	workspaceClusterAnnotationKey = "internal.tenancy.kcp.io/cluster"
 )
@@ -65,6 +72,9 @@ type schedulingReconciler struct {
 	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
 	listShards     func(selector labels.Selector) ([]*corev1alpha1.Shard, error)
 
+	listScheduledWorkspaces     func(shardHash string) ([]*tenancyv1beta1.Workspace, error)
+	listUnschedulableWorkspaces func() ([]*tenancyv1beta1.Workspace, error)
+
 	getWorkspaceType func(clusterName logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
 
 	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
@@ -83,6 +93,9 @@ func (r *schedulingReconciler) reconcile(ctx context.Context, workspace *tenancy
 		return reconcileStatusContinue, nil
 	case workspace.Spec.URL != "" && workspace.Spec.Cluster != "":
 		conditions.MarkTrue(workspace, tenancyv1alpha1.WorkspaceScheduled)
+		if workspace.Status.Scheduling == nil || workspace.Status.Scheduling.Shard == "" {
+			r.recordScheduledShard(logger, workspace)
+		}
 		return reconcileStatusContinue, nil
 	case workspace.Spec.URL == "" || workspace.Spec.Cluster == "":
 		shardNameHash, hasShard := workspace.Annotations[workspaceShardAnnotationKey]
@@ -98,20 +111,40 @@ func (r *schedulingReconciler) reconcile(ctx context.Context, workspace *tenancy
 		}
 
 		if !hasShard {
-			shardName, reason, err := r.chooseShardAndMarkCondition(logger, workspace) // call first with status side-effect, before any annotation aka spec change
+			shardName, selector, reason, message, err := r.chooseShardAndMarkCondition(logger, workspace) // call first with status side-effect, before any annotation aka spec change
 			if err != nil {
 				return reconcileStatusStopAndRequeue, err
 			}
 			if len(shardName) == 0 {
-				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceScheduled, tenancyv1alpha1.WorkspaceReasonUnschedulable, conditionsv1alpha1.ConditionSeverityError, reason)
-				return reconcileStatusContinue, nil // retry is automatic when new shards show up
+				conditions.MarkFalse(workspace, tenancyv1alpha1.WorkspaceScheduled, reason, conditionsv1alpha1.ConditionSeverityError, message)
+				workspace.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Selector: selector, Message: message}
+				return reconcileStatusContinue, nil // retry is automatic when new shards show up or capacity is freed
 			}
-			logger.V(1).Info("Chose shard", "shard", shardName)
-			shardNameHash = ByBase36Sha224NameValue(shardName)
+			logger.V(1).Info("Chose shard", "shard", shardName, "selector", selector)
+			hasher := crypto.ConfiguredHasher(crypto.SHA224)
+			shardNameHash = ShardNameHashValue(hasher, shardName)
 			if workspace.Annotations == nil {
 				workspace.Annotations = map[string]string{}
 			}
 			workspace.Annotations[workspaceShardAnnotationKey] = shardNameHash
+			workspace.Annotations[workspaceShardHashAlgorithmAnnotationKey] = hasher.Algorithm()
+		}
+		if hasCluster {
+			if err := routingtarget.ValidateCluster(logicalcluster.From(workspace), workspace.Name, clusterName); err != nil {
+				// never create or adopt a forbidden logical cluster. Choose another one.
+				logger.Error(err, "invalid logical cluster for workspace, choosing another one")
+				delete(workspace.Annotations, workspaceClusterAnnotationKey)
+				return reconcileStatusStopAndRequeue, nil
+			}
+			if existing, err := r.getLogicalCluster(clusterName); err != nil && !apierrors.IsNotFound(err) {
+				return reconcileStatusStopAndRequeue, err
+			} else if err == nil {
+				if err := routingtarget.ValidateOwner(existing.Spec.Owner, logicalcluster.From(workspace), workspace.Name); err != nil {
+					logger.Error(err, "logical cluster of another workspace chosen, choosing another one")
+					delete(workspace.Annotations, workspaceClusterAnnotationKey)
+					return reconcileStatusStopAndRequeue, nil
+				}
+			}
 		}
 		if !hasCluster {
 			cluster := r.generateClusterName(logicalcluster.From(workspace).Path().Join(workspace.Name))
@@ -170,29 +203,46 @@ func (r *schedulingReconciler) reconcile(ctx context.Context, workspace *tenancy
 	return reconcileStatusContinue, nil
 }
 
-func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (shard string, reason string, err error) {
+// chooseShardAndMarkCondition chooses a shard for the workspace. It returns the chosen shard, the
+// shard selector it was chosen with, and the condition reason and message if no shard could be chosen.
+func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (shard string, selectorString string, reason string, message string, err error) {
+	shards, selectorString, reason, message, err := r.schedulableShards(logger, workspace)
+	if err != nil || len(shards) == 0 {
+		return "", selectorString, reason, message, err
+	}
+
+	targetShard := pickShard(shards)
+	return targetShard.Name, selectorString, "", "", nil
+}
+
+// schedulableShards returns the shards the workspace can be scheduled to, and the shard selector
+// they were selected with. If there are none, it returns the condition reason and message why.
+func (r *schedulingReconciler) schedulableShards(logger klog.Logger, workspace *tenancyv1beta1.Workspace) (shards []*corev1alpha1.Shard, selectorString string, reason string, message string, err error) {
+	requested, message, err := r.shardSelector(workspace)
+	if err != nil || message != "" {
+		return nil, "", tenancyv1alpha1.WorkspaceReasonUnschedulable, message, err // don't retry on invalid selectors, cannot do anything useful
+	}
 	selector := labels.Everything()
-	if workspace.Spec.Location != nil {
-		if workspace.Spec.Location.Selector != nil {
-			var err error
-			selector, err = metav1.LabelSelectorAsSelector(workspace.Spec.Location.Selector)
-			if err != nil {
-				return "", fmt.Sprintf("spec.location.selector is invalid: %v", err), nil // don't retry, cannot do anything useful
-			}
-		}
+	if requested != nil {
+		selector = requested
+		selectorString = requested.String()
 	}
 
-	shards, err := r.listShards(selector)
+	shards, err = r.listShards(selector)
 	if err != nil {
-		return "", "", err
+		return nil, "", "", "", err
+	}
+	if len(shards) == 0 && requested != nil {
+		return nil, selectorString, tenancyv1alpha1.WorkspaceReasonUnschedulable, fmt.Sprintf("No shards match the selector %q", selectorString), nil // retry is automatic when shards are labelled
 	}
 
 	// schedule onto the root shard. This step is temporary until working with multi-shard env works
 	// until then we need to assign ws to the root shard otherwise all e2e test will break
-	if len(shards) > 0 && (workspace.Spec.Location == nil || workspace.Spec.Location.Selector == nil) {
-		// trim the list to contain only the "root" shard so that we always schedule onto it
+	if len(shards) > 0 && requested == nil {
+		// trim the list to contain only the "root" shard so that we always schedule onto it,
+		// unless it is cordoned or draining, in which case the other shards take over.
 		for _, shard := range shards {
-			if shard.Name == "root" {
+			if valid, _, _ := isSchedulableShard(shard); valid && shard.Name == "root" {
 				shards = []*corev1alpha1.Shard{shard}
 				break
 			}
@@ -202,7 +252,7 @@ func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, w
 			for _, shard := range shards {
 				names = append(names, shard.Name)
 			}
-			return "", "", fmt.Errorf("since no specific shard was requested we default to schedule onto the root shard, but the root shard wasn't found, found shards: %v", names)
+			return nil, "", "", "", fmt.Errorf("since no specific shard was requested we default to schedule onto the root shard, but the root shard wasn't found, found shards: %v", names)
 		}
 	}
 
@@ -211,7 +261,7 @@ func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, w
 		reason, message string
 	}{}
 	for _, shard := range shards {
-		if valid, reason, message := isValidShard(shard); valid {
+		if valid, reason, message := isSchedulableShard(shard); valid {
 			validShards = append(validShards, shard)
 		} else {
 			invalidShards[shard.Name] = struct {
@@ -225,14 +275,218 @@ func (r *schedulingReconciler) chooseShardAndMarkCondition(logger klog.Logger, w
 
 	if len(validShards) == 0 {
 		failures := make([]error, 0, len(invalidShards))
+		messages := make([]string, 0, len(invalidShards))
 		for name, x := range invalidShards {
 			failures = append(failures, fmt.Errorf("  %s: reason %q, message %q", name, x.reason, x.message))
+			messages = append(messages, x.message)
 		}
 		logger.Error(utilerrors.NewAggregate(failures), "no valid shards found for workspace, skipping")
-		return "", "No available shards to schedule the workspace", nil // retry is automatic when new shards show up
+		message = "No available shards to schedule the workspace"
+		if len(messages) > 0 {
+			sort.Strings(messages)
+			message += ": " + strings.Join(messages, ", ")
+		}
+		return nil, selectorString, tenancyv1alpha1.WorkspaceReasonUnschedulable, message, nil // retry is automatic when new shards show up
+	}
+
+	shardsWithCapacity := make([]*corev1alpha1.Shard, 0, len(validShards))
+	for _, shard := range validShards {
+		ok, err := r.hasCapacity(shard, workspace)
+		if err != nil {
+			return nil, "", "", "", err
+		}
+		if ok {
+			shardsWithCapacity = append(shardsWithCapacity, shard)
+		}
+	}
+	if len(shardsWithCapacity) == 0 {
+		return nil, selectorString, tenancyv1alpha1.WorkspaceReasonShardsAtCapacity, fmt.Sprintf("No shard has capacity for a workspace of priority %d", workspacePriority(workspace)), nil // retry is automatic when capacity is freed
+	}
+
+	return shardsWithCapacity, selectorString, "", "", nil
+}
+
+// hasCapacity returns whether the shard has capacity for the workspace. Free capacity is reserved
+// for the workspaces of higher priority waiting for capacity on the shard, such that they are
+// scheduled first and workspaces of lower priority are kept unschedulable.
+func (r *schedulingReconciler) hasCapacity(shard *corev1alpha1.Shard, workspace *tenancyv1beta1.Workspace) (bool, error) {
+	capacity, found := shard.Status.Capacity[corev1alpha1.ShardCapacityWorkspaces]
+	if !found {
+		return true, nil
+	}
+	var scheduled int64
+	for _, hash := range shardNameHashValues(shard.Name) {
+		workspaces, err := r.listScheduledWorkspaces(hash)
+		if err != nil {
+			return false, err
+		}
+		scheduled += int64(len(workspaces))
+	}
+	free := capacity.Value() - scheduled
+	if free <= 0 {
+		return false, nil
+	}
+
+	waiting, err := r.listUnschedulableWorkspaces()
+	if err != nil {
+		return false, err
+	}
+	priority := workspacePriority(workspace)
+	var reserved int64
+	for _, other := range waiting {
+		if logicalcluster.From(other) == logicalcluster.From(workspace) && other.Name == workspace.Name {
+			continue
+		}
+		if !other.DeletionTimestamp.IsZero() || conditions.GetReason(other, tenancyv1alpha1.WorkspaceScheduled) != tenancyv1alpha1.WorkspaceReasonShardsAtCapacity {
+			continue
+		}
+		if workspacePriority(other) > priority && shardMayMatch(other, shard) {
+			reserved++
+		}
+	}
+	return free > reserved, nil
+}
+
+const (
+	// unreportedUtilization is the utilization assumed for shards which have not reported their
+	// usage, such that they are neither avoided nor flooded with workspaces.
+	unreportedUtilization = 0.5
+
+	// minShardWeight is the weight of a fully utilized shard in pickShard, such that it is still
+	// chosen occasionally, e.g. when the usage reported by all shards is stale.
+	minShardWeight = 0.05
+)
+
+// pickShard chooses one of the shards randomly, weighted by their headroom, such that workspaces
+// spread across shards by utilization. A weighted choice rather than the least utilized shard
+// keeps the workspaces scheduled between two heartbeats from all going to the same shard.
+func pickShard(shards []*corev1alpha1.Shard) *corev1alpha1.Shard {
+	var maxLogicalClusters int64
+	for _, shard := range shards {
+		if shard.Status.Usage != nil && shard.Status.Usage.LogicalClusters > maxLogicalClusters {
+			maxLogicalClusters = shard.Status.Usage.LogicalClusters
+		}
+	}
+
+	weights := make([]float64, len(shards))
+	var total float64
+	for i, shard := range shards {
+		weights[i] = math.Max(1-shardUtilization(shard, maxLogicalClusters), minShardWeight)
+		total += weights[i]
+	}
+	x := rand.Float64() * total
+	for i, weight := range weights {
+		if x < weight {
+			return shards[i]
+		}
+		x -= weight
+	}
+	return shards[len(shards)-1]
+}
+
+// shardUtilization returns the utilization of the shard between 0 and 1, as the highest ratio of
+// its reported usage to its capacity. Shards without capacity are compared by their number of
+// logical clusters relative to the given maximum among the candidates.
+func shardUtilization(shard *corev1alpha1.Shard, maxLogicalClusters int64) float64 {
+	usage := shard.Status.Usage
+	if usage == nil {
+		return unreportedUtilization
+	}
+
+	var ratios []float64
+	addRatio := func(used int64, name corev1.ResourceName) {
+		if capacity, found := shard.Status.Capacity[name]; found && capacity.Value() > 0 {
+			ratios = append(ratios, float64(used)/float64(capacity.Value()))
+		}
+	}
+	addRatio(usage.LogicalClusters, corev1alpha1.ShardCapacityWorkspaces)
+	if usage.EtcdDatabaseSize != nil {
+		addRatio(usage.EtcdDatabaseSize.Value(), corev1alpha1.ShardCapacityEtcdDatabaseSize)
+	}
+	addRatio(usage.RequestsPerSecond, corev1alpha1.ShardCapacityRequestsPerSecond)
+
+	if len(ratios) == 0 {
+		if maxLogicalClusters == 0 {
+			return 0
+		}
+		return float64(usage.LogicalClusters) / float64(maxLogicalClusters)
+	}
+	utilization := 0.0
+	for _, ratio := range ratios {
+		utilization = math.Max(utilization, ratio)
+	}
+	return math.Min(utilization, 1)
+}
+
+// workspacePriority returns the scheduling priority of the workspace. Workspaces without priority
+// have priority 0.
+func workspacePriority(workspace *tenancyv1beta1.Workspace) int32 {
+	if workspace.Spec.Priority == nil {
+		return 0
 	}
-	targetShard := validShards[rand.Intn(len(validShards))]
-	return targetShard.Name, "", nil
+	return *workspace.Spec.Priority
+}
+
+// shardSelector returns the selector of the shards the workspace can be scheduled to, combining
+// spec.location.selector with the shard selectors of the workspace type and the types it extends.
+// It returns nil if no shards are requested, and a reason if a selector is invalid.
+func (r *schedulingReconciler) shardSelector(workspace *tenancyv1beta1.Workspace) (labels.Selector, string, error) {
+	type source struct {
+		field    string
+		selector *metav1.LabelSelector
+	}
+	var sources []source
+	if workspace.Spec.Location != nil && workspace.Spec.Location.Selector != nil {
+		sources = append(sources, source{"spec.location.selector", workspace.Spec.Location.Selector})
+	}
+	if workspace.Spec.Type.Name != "" {
+		wt, err := r.getWorkspaceType(logicalcluster.NewPath(workspace.Spec.Type.Path), string(workspace.Spec.Type.Name))
+		if err != nil {
+			return nil, "", err
+		}
+		types, err := r.transitiveTypeResolver.Resolve(wt)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, t := range types {
+			if t.Spec.ShardSelector != nil {
+				sources = append(sources, source{fmt.Sprintf("spec.shardSelector of WorkspaceType %s|%s", logicalcluster.From(t), t.Name), t.Spec.ShardSelector})
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return nil, "", nil
+	}
+
+	selector := labels.NewSelector()
+	for _, src := range sources {
+		s, err := metav1.LabelSelectorAsSelector(src.selector)
+		if err != nil {
+			return nil, fmt.Sprintf("%s is invalid: %v", src.field, err), nil
+		}
+		requirements, _ := s.Requirements()
+		selector = selector.Add(requirements...)
+	}
+	return selector, "", nil
+}
+
+// recordScheduledShard records the shard a workspace is scheduled to in its status. Failures
+// are not fatal, the decision is recorded on the next reconciliation.
+func (r *schedulingReconciler) recordScheduledShard(logger klog.Logger, workspace *tenancyv1beta1.Workspace) {
+	shard, err := r.getShardByHash(workspace.Annotations[workspaceShardAnnotationKey])
+	if err != nil {
+		logger.V(4).Info("cannot record scheduled shard", "err", err)
+		return
+	}
+	scheduling := &tenancyv1beta1.WorkspaceScheduling{
+		Shard:   shard.Name,
+		Message: fmt.Sprintf("Scheduled to shard %q", shard.Name),
+	}
+	if selector, _, err := r.shardSelector(workspace); err == nil && selector != nil {
+		scheduling.Selector = selector.String()
+		scheduling.Message = fmt.Sprintf("Scheduled to shard %q matching the selector %q", shard.Name, scheduling.Selector)
+	}
+	workspace.Status.Scheduling = scheduling
 }
 
 func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *corev1alpha1.Shard, cluster logicalcluster.Path, parent *corev1alpha1.LogicalCluster, workspace *tenancyv1beta1.Workspace) error {
@@ -312,16 +566,20 @@ func LogicalClustersInitializers(
 
 	initializers := make([]corev1alpha1.LogicalClusterInitializer, 0, len(wtAliases))
 
-	bindings := false
+	bindings, template := false, false
 	for _, alias := range wtAliases {
 		if alias.Spec.Initializer {
 			initializers = append(initializers, initialization.InitializerForType(alias))
 		}
 		bindings = bindings || len(alias.Spec.DefaultAPIBindings) > 0
+		template = template || alias.Spec.Template != nil
 	}
 	if bindings {
 		initializers = append(initializers, tenancyv1alpha1.WorkspaceAPIBindingsInitializer)
 	}
+	if template {
+		initializers = append(initializers, tenancyv1alpha1.WorkspaceTemplateInitializer)
+	}
 
 	return initializers, nil
 }
@@ -344,10 +602,28 @@ func isValidShard(_ *corev1alpha1.Shard) (valid bool, reason, message string) {
 	return true, "", ""
 }
 
+// isSchedulableShard checks whether new workspaces can be scheduled to the shard. Unlike
+// isValidShard, it does not affect workspaces which have already chosen the shard.
+func isSchedulableShard(shard *corev1alpha1.Shard) (valid bool, reason, message string) {
+	if valid, reason, message := isValidShard(shard); !valid {
+		return false, reason, message
+	}
+	if conditions.IsFalse(shard, corev1alpha1.ShardLive) {
+		return false, corev1alpha1.ShardLeaseExpiredReason, fmt.Sprintf("shard %q has an expired lease", shard.Name)
+	}
+	if shard.Spec.Draining {
+		return false, "Draining", fmt.Sprintf("shard %q is draining", shard.Name)
+	}
+	if shard.Spec.Cordoned {
+		return false, "Cordoned", fmt.Sprintf("shard %q is cordoned", shard.Name)
+	}
+	return true, "", ""
+}
+
 func randomClusterName(path logicalcluster.Path) logicalcluster.Name {
 	token := make([]byte, 32)
 	rand.Read(token)
-	hash := sha256.Sum224(token)
-	base36hash := strings.ToLower(base36.EncodeBytes(hash[:]))
+	hash := crypto.ConfiguredHasher(crypto.SHA224).Sum(token)
+	base36hash := strings.ToLower(base36.EncodeBytes(hash))
 	return logicalcluster.Name(base36hash[:16]) // 36^16 = 82 bits, P(conflict)<10^-9 for 2^26 clusters
 }
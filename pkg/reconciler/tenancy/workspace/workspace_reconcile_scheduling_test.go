@@ -35,20 +35,23 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	conditionsapi "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpfakeclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/fake"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/crypto"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsapi "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 func TestReconcileScheduling(t *testing.T) {
@@ -60,6 +63,7 @@ func TestReconcileScheduling(t *testing.T) {
 		initialKcpClientObjects  []runtime.Object
 		targetWorkspace          *tenancyv1beta1.Workspace
 		targetLogicalCluster     *corev1alpha1.LogicalCluster
+		existingLogicalClusters  []*corev1alpha1.LogicalCluster
 		validateWorkspace        func(t *testing.T, initialWS, ws *tenancyv1beta1.Workspace)
 		validateKcpClientActions func(t *testing.T, a []kcpclientgotesting.Action)
 		expectedKcpClientActions []string
@@ -75,6 +79,7 @@ func TestReconcileScheduling(t *testing.T) {
 
 				initialWS.Annotations["internal.tenancy.kcp.io/cluster"] = "root-foo"
 				initialWS.Annotations["internal.tenancy.kcp.io/shard"] = "1pfxsevk"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard-hash-algorithm"] = "sha224"
 				initialWS.Finalizers = append(initialWS.Finalizers, "core.kcp.io/logicalcluster")
 				if !equality.Semantic.DeepEqual(ws, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(ws, initialWS)))
@@ -99,6 +104,7 @@ func TestReconcileScheduling(t *testing.T) {
 					Type:   tenancyv1alpha1.WorkspaceScheduled,
 					Status: corev1.ConditionTrue,
 				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Shard: "root", Message: `Scheduled to shard "root"`}
 				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
 				}
@@ -133,6 +139,7 @@ func TestReconcileScheduling(t *testing.T) {
 					Type:   tenancyv1alpha1.WorkspaceScheduled,
 					Status: corev1.ConditionTrue,
 				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Shard: "root", Message: `Scheduled to shard "root"`}
 				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
 				}
@@ -169,6 +176,28 @@ func TestReconcileScheduling(t *testing.T) {
 			expectedStatus:           reconcileStatusStopAndRequeue,
 			expectedKcpClientActions: []string{"create:logicalclusters", "get:logicalclusters"},
 		},
+		{
+			name:                  "two-phase commit, part two failure: LogicalCluster of another workspace is known to the shard",
+			initialShards:         []*corev1alpha1.Shard{shard("root")},
+			initialWorkspaceTypes: wellKnownWorkspaceTypes(),
+			targetWorkspace:       wellKnownFooWSForPhaseTwo(),
+			targetLogicalCluster:  &corev1alpha1.LogicalCluster{},
+			existingLogicalClusters: []*corev1alpha1.LogicalCluster{func() *corev1alpha1.LogicalCluster {
+				logicalCluster := wellKnownLogicalClusterForFooWS()
+				logicalCluster.Annotations["kcp.io/cluster"] = "root-foo"
+				logicalCluster.Spec.Owner.Name = "bar"
+				return logicalCluster
+			}()},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				delete(initialWS.Annotations, "internal.tenancy.kcp.io/cluster")
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusStopAndRequeue,
+		},
 		{
 			name:                  "two-phase commit, part two failure: CRB, LogicalCluster already exists",
 			initialShards:         []*corev1alpha1.Shard{shard("root")},
@@ -196,6 +225,7 @@ func TestReconcileScheduling(t *testing.T) {
 					Type:   tenancyv1alpha1.WorkspaceScheduled,
 					Status: corev1.ConditionTrue,
 				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Shard: "root", Message: `Scheduled to shard "root"`}
 				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
 				}
@@ -222,12 +252,72 @@ func TestReconcileScheduling(t *testing.T) {
 					Reason:   tenancyv1alpha1.WorkspaceReasonUnschedulable,
 					Message:  "No available shards to schedule the workspace",
 				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Message: "No available shards to schedule the workspace"}
 				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
 				}
 			},
 			expectedStatus: reconcileStatusContinue,
 		},
+		{
+			name:                 "the only shard is cordoned, the ws is unscheduled",
+			initialShards:        []*corev1alpha1.Shard{cordoned(shard("root"))},
+			targetWorkspace:      workspace("foo"),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				clearLastTransitionTimeOnWsConditions(wsAfterReconciliation)
+				initialWS.Status.Conditions = append(initialWS.Status.Conditions, conditionsapi.Condition{
+					Type:     tenancyv1alpha1.WorkspaceScheduled,
+					Severity: conditionsapi.ConditionSeverityError,
+					Status:   corev1.ConditionFalse,
+					Reason:   tenancyv1alpha1.WorkspaceReasonUnschedulable,
+					Message:  `No available shards to schedule the workspace: shard "root" is cordoned`,
+				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Message: `No available shards to schedule the workspace: shard "root" is cordoned`}
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusContinue,
+		},
+		{
+			name:                 "the lease of the root shard expired, the ws is scheduled onto another shard",
+			initialShards:        []*corev1alpha1.Shard{leaseExpired(shard("root")), shard("amber")},
+			targetWorkspace:      workspace("foo"),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				initialWS.Annotations["internal.tenancy.kcp.io/cluster"] = "root-foo"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard"] = "29hdqnv7"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard-hash-algorithm"] = "sha224"
+				initialWS.Finalizers = append(initialWS.Finalizers, "core.kcp.io/logicalcluster")
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusStopAndRequeue,
+		},
+		{
+			name:                 "the root shard is cordoned, the ws is scheduled onto another shard",
+			initialShards:        []*corev1alpha1.Shard{cordoned(shard("root")), shard("amber")},
+			targetWorkspace:      workspace("foo"),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				initialWS.Annotations["internal.tenancy.kcp.io/cluster"] = "root-foo"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard"] = "29hdqnv7"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard-hash-algorithm"] = "sha224"
+				initialWS.Finalizers = append(initialWS.Finalizers, "core.kcp.io/logicalcluster")
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusStopAndRequeue,
+		},
 		{
 			name: "the ws is scheduled onto requested shard (shard name in spec)",
 			targetWorkspace: func() *tenancyv1beta1.Workspace {
@@ -247,6 +337,38 @@ func TestReconcileScheduling(t *testing.T) {
 
 				initialWS.Annotations["internal.tenancy.kcp.io/cluster"] = "root-foo"
 				initialWS.Annotations["internal.tenancy.kcp.io/shard"] = "29hdqnv7"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard-hash-algorithm"] = "sha224"
+				initialWS.Finalizers = append(initialWS.Finalizers, "core.kcp.io/logicalcluster")
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusStopAndRequeue,
+		},
+		{
+			name: "the ws is scheduled onto a shard matching the shard selector of its type",
+			targetWorkspace: func() *tenancyv1beta1.Workspace {
+				ws := workspace("foo")
+				ws.Spec.Type = tenancyv1alpha1.WorkspaceTypeReference{Name: "eu", Path: "root"}
+				return ws
+			}(),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			initialWorkspaceTypes: []*tenancyv1alpha1.WorkspaceType{func() *tenancyv1alpha1.WorkspaceType {
+				wt := workspaceType("eu")
+				wt.Spec.ShardSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}
+				return wt
+			}()},
+			initialShards: []*corev1alpha1.Shard{shard("root"), func() *corev1alpha1.Shard {
+				s := shard("eu-1")
+				s.Labels["region"] = "eu"
+				return s
+			}()},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				initialWS.Annotations["internal.tenancy.kcp.io/cluster"] = "root-foo"
+				initialWS.Annotations["internal.tenancy.kcp.io/shard"] = shardNameToBase36Sha224("eu-1")
+				initialWS.Annotations["internal.tenancy.kcp.io/shard-hash-algorithm"] = "sha224"
 				initialWS.Finalizers = append(initialWS.Finalizers, "core.kcp.io/logicalcluster")
 				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
 					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
@@ -254,6 +376,46 @@ func TestReconcileScheduling(t *testing.T) {
 			},
 			expectedStatus: reconcileStatusStopAndRequeue,
 		},
+		{
+			name: "the ws is unschedulable if no shard matches the selectors of the ws and its type",
+			targetWorkspace: func() *tenancyv1beta1.Workspace {
+				ws := workspace("foo")
+				ws.Spec.Type = tenancyv1alpha1.WorkspaceTypeReference{Name: "eu", Path: "root"}
+				ws.Spec.Location.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dedicated"}}
+				return ws
+			}(),
+			targetLogicalCluster: &corev1alpha1.LogicalCluster{},
+			initialWorkspaceTypes: []*tenancyv1alpha1.WorkspaceType{func() *tenancyv1alpha1.WorkspaceType {
+				wt := workspaceType("eu")
+				wt.Spec.ShardSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}
+				return wt
+			}()},
+			initialShards: []*corev1alpha1.Shard{shard("root"), func() *corev1alpha1.Shard {
+				s := shard("eu-1")
+				s.Labels["region"] = "eu"
+				return s
+			}()},
+			validateWorkspace: func(t *testing.T, initialWS, wsAfterReconciliation *tenancyv1beta1.Workspace) {
+				t.Helper()
+
+				clearLastTransitionTimeOnWsConditions(wsAfterReconciliation)
+				initialWS.Status.Conditions = append(initialWS.Status.Conditions, conditionsapi.Condition{
+					Type:     tenancyv1alpha1.WorkspaceScheduled,
+					Severity: conditionsapi.ConditionSeverityError,
+					Status:   corev1.ConditionFalse,
+					Reason:   tenancyv1alpha1.WorkspaceReasonUnschedulable,
+					Message:  `No shards match the selector "region=eu,tier=dedicated"`,
+				})
+				initialWS.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{
+					Selector: "region=eu,tier=dedicated",
+					Message:  `No shards match the selector "region=eu,tier=dedicated"`,
+				}
+				if !equality.Semantic.DeepEqual(wsAfterReconciliation, initialWS) {
+					t.Fatal(fmt.Errorf("unexpected Workspace:\n%s", cmp.Diff(wsAfterReconciliation, initialWS)))
+				}
+			},
+			expectedStatus: reconcileStatusContinue,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -311,7 +473,12 @@ func TestReconcileScheduling(t *testing.T) {
 				getWorkspaceType: getType,
 				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
 					if clusterName != core.RootCluster {
-						return nil, fmt.Errorf("unexpected cluster name = %v, expected = %v", clusterName, "root")
+						for _, logicalCluster := range scenario.existingLogicalClusters {
+							if logicalcluster.From(logicalCluster) == clusterName {
+								return logicalCluster, nil
+							}
+						}
+						return nil, kerrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
 					}
 					if scenario.targetLogicalCluster == nil {
 						return nil, fmt.Errorf("targetLogicalCluster wasn't provided for this scenario")
@@ -351,6 +518,201 @@ func TestReconcileScheduling(t *testing.T) {
 	}
 }
 
+func TestHasCapacity(t *testing.T) {
+	waiting := func(name string, priority int32, selector string) *tenancyv1beta1.Workspace {
+		ws := workspace(name)
+		ws.Spec.Priority = &priority
+		ws.Status.Conditions = conditionsapi.Conditions{{
+			Type:   tenancyv1alpha1.WorkspaceScheduled,
+			Status: corev1.ConditionFalse,
+			Reason: tenancyv1alpha1.WorkspaceReasonShardsAtCapacity,
+		}}
+		if selector != "" {
+			ws.Status.Scheduling = &tenancyv1beta1.WorkspaceScheduling{Selector: selector}
+		}
+		return ws
+	}
+
+	tests := map[string]struct {
+		capacity        string
+		scheduled       int
+		scheduledSHA256 int
+		unschedulable   []*tenancyv1beta1.Workspace
+		priority        int32
+		want            bool
+	}{
+		"unlimited capacity": {
+			scheduled: 100,
+			want:      true,
+		},
+		"free capacity": {
+			capacity:  "2",
+			scheduled: 1,
+			want:      true,
+		},
+		"at capacity": {
+			capacity:  "2",
+			scheduled: 2,
+		},
+		"at capacity with workspaces scheduled by another hash algorithm": {
+			capacity:        "2",
+			scheduled:       1,
+			scheduledSHA256: 1,
+		},
+		"free capacity reserved for higher priority": {
+			capacity:      "2",
+			scheduled:     1,
+			unschedulable: []*tenancyv1beta1.Workspace{waiting("high", 10, "")},
+			priority:      5,
+		},
+		"free capacity beyond the reserved capacity": {
+			capacity:      "3",
+			scheduled:     1,
+			unschedulable: []*tenancyv1beta1.Workspace{waiting("high", 10, "")},
+			priority:      5,
+			want:          true,
+		},
+		"free capacity not reserved for the same or lower priority": {
+			capacity:      "2",
+			scheduled:     1,
+			unschedulable: []*tenancyv1beta1.Workspace{waiting("same", 5, ""), waiting("low", 1, "")},
+			priority:      5,
+			want:          true,
+		},
+		"free capacity not reserved for higher priority on other shards": {
+			capacity:      "2",
+			scheduled:     1,
+			unschedulable: []*tenancyv1beta1.Workspace{waiting("high", 10, "region=eu")},
+			priority:      5,
+			want:          true,
+		},
+		"free capacity not reserved by the workspace itself": {
+			capacity:      "2",
+			scheduled:     1,
+			unschedulable: []*tenancyv1beta1.Workspace{waiting("foo", 10, "")},
+			priority:      5,
+			want:          true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := shard("root")
+			if tt.capacity != "" {
+				s.Status.Capacity = corev1.ResourceList{corev1alpha1.ShardCapacityWorkspaces: resource.MustParse(tt.capacity)}
+			}
+			r := schedulingReconciler{
+				listScheduledWorkspaces: func(shardHash string) ([]*tenancyv1beta1.Workspace, error) {
+					switch shardHash {
+					case shardNameToBase36Sha224("root"):
+						return make([]*tenancyv1beta1.Workspace, tt.scheduled), nil
+					case ShardNameHashValue(crypto.SHA256, "root"):
+						return make([]*tenancyv1beta1.Workspace, tt.scheduledSHA256), nil
+					}
+					return nil, nil
+				},
+				listUnschedulableWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) {
+					return tt.unschedulable, nil
+				},
+			}
+			ws := workspace("foo")
+			ws.Spec.Priority = &tt.priority
+
+			got, err := r.hasCapacity(s, ws)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("hasCapacity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShardUtilization(t *testing.T) {
+	usage := func(logicalClusters int64, etcdDatabaseSize string, requestsPerSecond int64) *corev1alpha1.ShardUsage {
+		u := &corev1alpha1.ShardUsage{LogicalClusters: logicalClusters, RequestsPerSecond: requestsPerSecond}
+		if etcdDatabaseSize != "" {
+			q := resource.MustParse(etcdDatabaseSize)
+			u.EtcdDatabaseSize = &q
+		}
+		return u
+	}
+
+	tests := map[string]struct {
+		usage              *corev1alpha1.ShardUsage
+		capacity           corev1.ResourceList
+		maxLogicalClusters int64
+		want               float64
+	}{
+		"no usage reported": {
+			want: unreportedUtilization,
+		},
+		"no capacity, compared to the fullest candidate": {
+			usage:              usage(5, "", 0),
+			maxLogicalClusters: 20,
+			want:               0.25,
+		},
+		"no capacity, all candidates empty": {
+			usage: usage(0, "", 0),
+			want:  0,
+		},
+		"workspace capacity": {
+			usage:    usage(30, "", 0),
+			capacity: corev1.ResourceList{corev1alpha1.ShardCapacityWorkspaces: resource.MustParse("40")},
+			want:     0.75,
+		},
+		"highest ratio wins": {
+			usage: usage(10, "8Gi", 50),
+			capacity: corev1.ResourceList{
+				corev1alpha1.ShardCapacityWorkspaces:        resource.MustParse("100"),
+				corev1alpha1.ShardCapacityEtcdDatabaseSize:  resource.MustParse("16Gi"),
+				corev1alpha1.ShardCapacityRequestsPerSecond: resource.MustParse("200"),
+			},
+			want: 0.5,
+		},
+		"etcd database size capacity without reported size": {
+			usage:              usage(10, "", 0),
+			capacity:           corev1.ResourceList{corev1alpha1.ShardCapacityEtcdDatabaseSize: resource.MustParse("16Gi")},
+			maxLogicalClusters: 10,
+			want:               1,
+		},
+		"over capacity": {
+			usage:    usage(0, "", 300),
+			capacity: corev1.ResourceList{corev1alpha1.ShardCapacityRequestsPerSecond: resource.MustParse("200")},
+			want:     1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := shard("root")
+			s.Status.Usage = tt.usage
+			s.Status.Capacity = tt.capacity
+			if got := shardUtilization(s, tt.maxLogicalClusters); got != tt.want {
+				t.Errorf("shardUtilization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickShard(t *testing.T) {
+	full := shard("full")
+	full.Status.Usage = &corev1alpha1.ShardUsage{LogicalClusters: 10}
+	full.Status.Capacity = corev1.ResourceList{corev1alpha1.ShardCapacityWorkspaces: resource.MustParse("10")}
+	empty := shard("empty")
+	empty.Status.Usage = &corev1alpha1.ShardUsage{}
+
+	picked := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		picked[pickShard([]*corev1alpha1.Shard{full, empty}).Name]++
+	}
+	if picked["empty"] <= picked["full"] {
+		t.Errorf("expected the empty shard to be picked more often than the full one, got %v", picked)
+	}
+	if picked["full"] == 0 {
+		t.Errorf("expected the full shard to keep a minimal weight, got %v", picked)
+	}
+}
+
 func workspace(name string) *tenancyv1beta1.Workspace {
 	return &tenancyv1beta1.Workspace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -510,11 +872,11 @@ func wellKnownWorkspaceTypes() []*tenancyv1alpha1.WorkspaceType {
 		Name: "universal",
 		Path: "root",
 	}
-	type2.Spec.DefaultAPIBindings = []tenancyv1alpha1.APIExportReference{
-		{
+	type2.Spec.DefaultAPIBindings = []tenancyv1alpha1.DefaultAPIBinding{
+		{APIExportReference: tenancyv1alpha1.APIExportReference{
 			Path:   "tenancy.kcp.io",
 			Export: "root",
-		},
+		}},
 	}
 	type2.Spec.Extend.With = []tenancyv1alpha1.WorkspaceTypeReference{
 		{
@@ -561,6 +923,16 @@ func actionStrings(actions []kcpclientgotesting.Action) []string {
 	return res
 }
 
+func cordoned(shard *corev1alpha1.Shard) *corev1alpha1.Shard {
+	shard.Spec.Cordoned = true
+	return shard
+}
+
+func leaseExpired(shard *corev1alpha1.Shard) *corev1alpha1.Shard {
+	conditions.MarkFalse(shard, corev1alpha1.ShardLive, corev1alpha1.ShardLeaseExpiredReason, conditionsapi.ConditionSeverityError, "")
+	return shard
+}
+
 func shardNameToBase36Sha224(name string) string {
 	hash := sha256.Sum224([]byte(name))
 	base36hash := strings.ToLower(base36.EncodeBytes(hash[:]))
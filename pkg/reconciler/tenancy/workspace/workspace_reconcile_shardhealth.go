@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// shardHealthReconciler propagates a degraded or draining shard onto the workspaces scheduled to
+// it, such that their owners know their control plane is impaired rather than their own
+// configuration being wrong.
+type shardHealthReconciler struct {
+	getShardByHash func(hash string) (*corev1alpha1.Shard, error)
+}
+
+func (r *shardHealthReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	hash, ok := workspace.Annotations[workspaceShardAnnotationKey]
+	if !ok {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDegraded)
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDraining)
+		return reconcileStatusContinue, nil
+	}
+
+	shard, err := r.getShardByHash(hash)
+	if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	if shard == nil {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDegraded)
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDraining)
+		return reconcileStatusContinue, nil
+	}
+
+	if shard.Spec.Draining {
+		conditions.Set(workspace, &conditionsv1alpha1.Condition{
+			Type:     tenancyv1alpha1.WorkspaceShardDraining,
+			Status:   corev1.ConditionTrue,
+			Severity: conditionsv1alpha1.ConditionSeverityWarning,
+			Reason:   "Draining",
+			Message:  fmt.Sprintf("Shard %q is draining for maintenance", shard.Name),
+		})
+	} else {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDraining)
+	}
+
+	if !conditions.IsFalse(shard, corev1alpha1.ShardLoadNominal) {
+		conditions.Delete(workspace, tenancyv1alpha1.WorkspaceShardDegraded)
+		return reconcileStatusContinue, nil
+	}
+
+	message := fmt.Sprintf("Shard %q is degraded", shard.Name)
+	if msg := conditions.GetMessage(shard, corev1alpha1.ShardLoadNominal); msg != "" {
+		message += ": " + msg
+	}
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceShardDegraded,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityWarning,
+		Reason:   conditions.GetReason(shard, corev1alpha1.ShardLoadNominal),
+		Message:  message,
+	})
+
+	return reconcileStatusContinue, nil
+}
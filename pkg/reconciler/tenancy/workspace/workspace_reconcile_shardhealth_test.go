@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileShardHealth(t *testing.T) {
+	degraded := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{Name: "beta"},
+		Status: corev1alpha1.ShardStatus{
+			Conditions: conditionsv1alpha1.Conditions{
+				*conditions.FalseCondition(corev1alpha1.ShardLoadNominal, corev1alpha1.ShardEtcdLatencyReason, conditionsv1alpha1.ConditionSeverityWarning, "etcd latency of 2s exceeds 1s"),
+			},
+		},
+	}
+	nominal := &corev1alpha1.Shard{
+		ObjectMeta: metav1.ObjectMeta{Name: "alpha"},
+		Status: corev1alpha1.ShardStatus{
+			Conditions: conditionsv1alpha1.Conditions{
+				*conditions.TrueCondition(corev1alpha1.ShardLoadNominal),
+			},
+		},
+	}
+
+	draining := nominal.DeepCopy()
+	draining.Spec.Draining = true
+
+	for _, testCase := range []struct {
+		name          string
+		shard         *corev1alpha1.Shard
+		notScheduled  bool
+		wasDegraded   bool
+		wasDraining   bool
+		conditionType conditionsv1alpha1.ConditionType
+		wantCondition *conditionsv1alpha1.Condition
+	}{
+		{
+			name:         "not scheduled",
+			notScheduled: true,
+		},
+		{
+			name:  "nominal shard",
+			shard: nominal,
+		},
+		{
+			name:        "recovered shard",
+			shard:       nominal,
+			wasDegraded: true,
+		},
+		{
+			name:        "shard gone",
+			wasDegraded: true,
+		},
+		{
+			name:  "degraded shard",
+			shard: degraded,
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceShardDegraded,
+				Status:   corev1.ConditionTrue,
+				Severity: conditionsv1alpha1.ConditionSeverityWarning,
+				Reason:   corev1alpha1.ShardEtcdLatencyReason,
+				Message:  `Shard "beta" is degraded: etcd latency of 2s exceeds 1s`,
+			},
+		},
+		{
+			name:          "draining shard",
+			shard:         draining,
+			conditionType: tenancyv1alpha1.WorkspaceShardDraining,
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceShardDraining,
+				Status:   corev1.ConditionTrue,
+				Severity: conditionsv1alpha1.ConditionSeverityWarning,
+				Reason:   "Draining",
+				Message:  `Shard "alpha" is draining for maintenance`,
+			},
+		},
+		{
+			name:          "drained shard back in service",
+			shard:         nominal,
+			wasDraining:   true,
+			conditionType: tenancyv1alpha1.WorkspaceShardDraining,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ws",
+					Annotations: map[string]string{},
+				},
+			}
+			if !testCase.notScheduled {
+				workspace.Annotations[workspaceShardAnnotationKey] = "abcdefgh"
+			}
+			if testCase.wasDegraded {
+				conditions.Set(workspace, &conditionsv1alpha1.Condition{Type: tenancyv1alpha1.WorkspaceShardDegraded, Status: corev1.ConditionTrue})
+			}
+			if testCase.wasDraining {
+				conditions.Set(workspace, &conditionsv1alpha1.Condition{Type: tenancyv1alpha1.WorkspaceShardDraining, Status: corev1.ConditionTrue})
+			}
+			r := &shardHealthReconciler{
+				getShardByHash: func(hash string) (*corev1alpha1.Shard, error) {
+					require.Equal(t, "abcdefgh", hash)
+					return testCase.shard, nil
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+
+			conditionType := testCase.conditionType
+			if conditionType == "" {
+				conditionType = tenancyv1alpha1.WorkspaceShardDegraded
+			}
+			got := conditions.Get(workspace, conditionType)
+			if testCase.wantCondition == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			got.LastTransitionTime = metav1.Time{}
+			require.Equal(t, testCase.wantCondition, got)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// trashReconciler keeps deleted workspaces with a trash retention, and with them their logical
+// cluster, around until the retention has passed. While in the trash, a workspace can be restored by
+// setting the restore annotation. As the deletion of an object cannot be undone, restoring means
+// replacing the terminating workspace with a new one pointing to the same logical cluster.
+type trashReconciler struct {
+	now func() time.Time
+
+	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalCluster func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error)
+	createWorkspace      func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error)
+	updateWorkspace      func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error)
+
+	requeueAfter func(workspace *tenancyv1beta1.Workspace, after time.Duration)
+}
+
+func (r *trashReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "trash")
+
+	finalizers := sets.NewString(workspace.Finalizers...)
+	_, optedIn := workspace.Annotations[tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey]
+	_, restoreRequested := workspace.Annotations[tenancyv1alpha1.WorkspaceRestoreAnnotationKey]
+
+	if workspace.DeletionTimestamp.IsZero() {
+		changed := false
+		if optedIn && !finalizers.Has(tenancyv1alpha1.WorkspaceTrashFinalizer) {
+			workspace.Finalizers = finalizers.Insert(tenancyv1alpha1.WorkspaceTrashFinalizer).List()
+			changed = true
+		} else if !optedIn && finalizers.Has(tenancyv1alpha1.WorkspaceTrashFinalizer) {
+			workspace.Finalizers = finalizers.Delete(tenancyv1alpha1.WorkspaceTrashFinalizer).List()
+			changed = true
+		}
+		if restoreRequested {
+			// nothing to restore
+			delete(workspace.Annotations, tenancyv1alpha1.WorkspaceRestoreAnnotationKey)
+			changed = true
+		}
+		if changed {
+			return reconcileStatusStopAndRequeue, nil // spec change
+		}
+		return reconcileStatusContinue, nil
+	}
+
+	if !finalizers.Has(tenancyv1alpha1.WorkspaceTrashFinalizer) {
+		return reconcileStatusContinue, nil
+	}
+
+	if workspace.Spec.Cluster == "" {
+		// nothing was created yet that is worth to be kept
+		workspace.Finalizers = finalizers.Delete(tenancyv1alpha1.WorkspaceTrashFinalizer).List()
+		return reconcileStatusStopAndRequeue, nil // spec change
+	}
+
+	if restoreRequested {
+		logger.Info("Restoring workspace from trash")
+		return reconcileStatusStopAndRequeue, r.restore(ctx, workspace)
+	}
+
+	// an invalid or removed retention empties the trash right away
+	var retention time.Duration
+	if value, found := workspace.Annotations[tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey]; found {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			retention = d
+		}
+	}
+
+	deadline := workspace.DeletionTimestamp.Add(retention)
+	remaining := deadline.Sub(r.now())
+	if remaining <= 0 {
+		logger.Info("Trash retention has passed, deleting workspace")
+		workspace.Finalizers = finalizers.Delete(tenancyv1alpha1.WorkspaceTrashFinalizer).List()
+		return reconcileStatusStopAndRequeue, nil // spec change
+	}
+
+	conditions.Set(workspace, &conditionsv1alpha1.Condition{
+		Type:     tenancyv1alpha1.WorkspaceTrashed,
+		Status:   corev1.ConditionTrue,
+		Severity: conditionsv1alpha1.ConditionSeverityInfo,
+		Reason:   tenancyv1alpha1.WorkspaceInTrashReason,
+		Message:  fmt.Sprintf("Workspace is in the trash until %s. Set the %s annotation to restore it.", deadline.UTC().Format(time.RFC3339), tenancyv1alpha1.WorkspaceRestoreAnnotationKey),
+	})
+	r.requeueAfter(workspace, remaining)
+
+	return reconcileStatusContinue, nil
+}
+
+// restore replaces the terminating workspace by a new one with the same name, spec and logical
+// cluster, and hands the ownership of the logical cluster over to it. The given workspace is not
+// modified.
+func (r *trashReconciler) restore(ctx context.Context, workspace *tenancyv1beta1.Workspace) error {
+	logger := klog.FromContext(ctx)
+	clusterName := logicalcluster.From(workspace)
+
+	pending := sets.NewString(workspace.Finalizers...).Delete(corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer)
+	if pending.Len() > 0 {
+		return fmt.Errorf("cannot restore workspace %s|%s while finalizers %v are pending", clusterName, workspace.Name, pending.List())
+	}
+
+	// let the old object go. The logical cluster is kept because we never asked for its deletion.
+	old := workspace.DeepCopy()
+	old.Finalizers = nil
+	if _, err := r.updateWorkspace(ctx, clusterName.Path(), old); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	restored := &tenancyv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workspace.Name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+			Finalizers:  []string{corev1alpha1.LogicalClusterFinalizer},
+		},
+		Spec: *workspace.Spec.DeepCopy(),
+	}
+	for k, v := range workspace.Labels {
+		if k != tenancyv1alpha1.WorkspacePhaseLabel {
+			restored.Labels[k] = v
+		}
+	}
+	for k, v := range workspace.Annotations {
+		if k != tenancyv1alpha1.WorkspaceRestoreAnnotationKey {
+			restored.Annotations[k] = v
+		}
+	}
+	if _, found := restored.Annotations[tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey]; found {
+		restored.Finalizers = append(restored.Finalizers, tenancyv1alpha1.WorkspaceTrashFinalizer)
+	}
+
+	var created *tenancyv1beta1.Workspace
+	if err := retry.OnError(retry.DefaultBackoff, apierrors.IsAlreadyExists, func() error {
+		var err error
+		created, err = r.createWorkspace(ctx, clusterName.Path(), restored)
+		return err
+	}); err != nil {
+		logger.Error(err, "failed to recreate restored workspace, its logical cluster is orphaned", "cluster", workspace.Spec.Cluster)
+		return err
+	}
+
+	// the logical cluster deletion only finalizes an owner with a matching UID
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		logicalCluster, err := r.getLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster))
+		if err != nil {
+			return err
+		}
+		if logicalCluster.Spec.Owner == nil || logicalCluster.Spec.Owner.UID == created.UID {
+			return nil
+		}
+		logicalCluster = logicalCluster.DeepCopy()
+		logicalCluster.Spec.Owner.UID = created.UID
+		_, err = r.updateLogicalCluster(ctx, logicalcluster.NewPath(workspace.Spec.Cluster), logicalCluster)
+		return err
+	})
+}
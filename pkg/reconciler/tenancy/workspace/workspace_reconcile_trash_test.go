@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileTrash(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	deleted := &metav1.Time{Time: now.Add(-time.Hour)}
+
+	for _, testCase := range []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		annotations       map[string]string
+		finalizers        []string
+		noCluster         bool
+
+		wantStatus       reconcileStatus
+		wantFinalizers   []string
+		wantAnnotations  map[string]string
+		wantRequeueAfter time.Duration
+		wantCondition    *conditionsv1alpha1.Condition
+	}{
+		{
+			name:            "not opted in",
+			finalizers:      []string{corev1alpha1.LogicalClusterFinalizer},
+			wantStatus:      reconcileStatusContinue,
+			wantFinalizers:  []string{corev1alpha1.LogicalClusterFinalizer},
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name:            "opted in, adds finalizer",
+			annotations:     map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+			finalizers:      []string{corev1alpha1.LogicalClusterFinalizer},
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantFinalizers:  []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantAnnotations: map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+		},
+		{
+			name:            "opted out, removes finalizer",
+			finalizers:      []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantFinalizers:  []string{corev1alpha1.LogicalClusterFinalizer},
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name: "restore of a live workspace is dropped",
+			annotations: map[string]string{
+				tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h",
+				tenancyv1alpha1.WorkspaceRestoreAnnotationKey:        "true",
+			},
+			finalizers:      []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantStatus:      reconcileStatusStopAndRequeue,
+			wantFinalizers:  []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantAnnotations: map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+		},
+		{
+			name:              "deleted, within retention",
+			deletionTimestamp: deleted,
+			annotations:       map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+			finalizers:        []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantStatus:        reconcileStatusContinue,
+			wantFinalizers:    []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantAnnotations:   map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+			wantRequeueAfter:  time.Hour,
+			wantCondition: &conditionsv1alpha1.Condition{
+				Type:     tenancyv1alpha1.WorkspaceTrashed,
+				Status:   corev1.ConditionTrue,
+				Severity: conditionsv1alpha1.ConditionSeverityInfo,
+				Reason:   tenancyv1alpha1.WorkspaceInTrashReason,
+				Message:  "Workspace is in the trash until 2022-10-01T13:00:00Z. Set the tenancy.kcp.io/restore annotation to restore it.",
+			},
+		},
+		{
+			name:              "deleted, retention passed",
+			deletionTimestamp: deleted,
+			annotations:       map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "30m"},
+			finalizers:        []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantFinalizers:    []string{corev1alpha1.LogicalClusterFinalizer},
+			wantAnnotations:   map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "30m"},
+		},
+		{
+			name:              "deleted, retention removed",
+			deletionTimestamp: deleted,
+			finalizers:        []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantFinalizers:    []string{corev1alpha1.LogicalClusterFinalizer},
+			wantAnnotations:   map[string]string{},
+		},
+		{
+			name:              "deleted before being scheduled",
+			deletionTimestamp: deleted,
+			annotations:       map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+			finalizers:        []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer},
+			noCluster:         true,
+			wantStatus:        reconcileStatusStopAndRequeue,
+			wantFinalizers:    []string{corev1alpha1.LogicalClusterFinalizer},
+			wantAnnotations:   map[string]string{tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			for k, v := range testCase.annotations {
+				annotations[k] = v
+			}
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "ws",
+					Annotations:       annotations,
+					Finalizers:        testCase.finalizers,
+					DeletionTimestamp: testCase.deletionTimestamp,
+				},
+				Spec: tenancyv1beta1.WorkspaceSpec{
+					Cluster: "somewhere",
+				},
+			}
+			if testCase.noCluster {
+				workspace.Spec.Cluster = ""
+			}
+
+			var requeuedAfter time.Duration
+			r := &trashReconciler{
+				now: func() time.Time { return now },
+				requeueAfter: func(workspace *tenancyv1beta1.Workspace, after time.Duration) {
+					requeuedAfter = after
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantFinalizers, workspace.Finalizers)
+			require.Equal(t, testCase.wantAnnotations, workspace.Annotations)
+			require.Equal(t, testCase.wantRequeueAfter, requeuedAfter)
+
+			got := conditions.Get(workspace, tenancyv1alpha1.WorkspaceTrashed)
+			if testCase.wantCondition == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			got.LastTransitionTime = metav1.Time{}
+			require.Equal(t, testCase.wantCondition, got)
+		})
+	}
+}
+
+func TestReconcileTrashRestore(t *testing.T) {
+	newWorkspace := func(finalizers ...string) *tenancyv1beta1.Workspace {
+		return &tenancyv1beta1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ws",
+				UID:  "old-uid",
+				Labels: map[string]string{
+					tenancyv1alpha1.WorkspacePhaseLabel: "Trashed",
+					"team":                              "a",
+				},
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey:                         "root:org",
+					tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h",
+					tenancyv1alpha1.WorkspaceRestoreAnnotationKey:        "true",
+				},
+				Finalizers:        finalizers,
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			},
+			Spec: tenancyv1beta1.WorkspaceSpec{
+				Cluster: "somewhere",
+				URL:     "https://kcp.bigcorp.com/clusters/somewhere",
+				Type:    tenancyv1alpha1.WorkspaceTypeReference{Name: "universal", Path: "root"},
+			},
+		}
+	}
+
+	t.Run("restores", func(t *testing.T) {
+		workspace := newWorkspace(corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer)
+		original := workspace.DeepCopy()
+
+		var updated, created *tenancyv1beta1.Workspace
+		var updatedLogicalCluster *corev1alpha1.LogicalCluster
+		r := &trashReconciler{
+			now: time.Now,
+			updateWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error) {
+				require.Equal(t, "root:org", cluster.String())
+				updated = workspace
+				return workspace, nil
+			},
+			createWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error) {
+				require.Equal(t, "root:org", cluster.String())
+				created = workspace.DeepCopy()
+				created.UID = "new-uid"
+				return created, nil
+			},
+			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				require.Equal(t, "somewhere", cluster.String())
+				return &corev1alpha1.LogicalCluster{
+					Spec: corev1alpha1.LogicalClusterSpec{
+						Owner: &corev1alpha1.LogicalClusterOwner{Resource: "workspaces", Cluster: "root:org", Name: "ws", UID: "old-uid"},
+					},
+				}, nil
+			},
+			updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) (*corev1alpha1.LogicalCluster, error) {
+				updatedLogicalCluster = logicalCluster
+				return logicalCluster, nil
+			},
+		}
+
+		status, err := r.reconcile(context.Background(), workspace)
+		require.NoError(t, err)
+		require.Equal(t, reconcileStatusStopAndRequeue, status)
+		require.Equal(t, original, workspace, "the terminating workspace must not be changed by the reconciler")
+
+		require.NotNil(t, updated)
+		require.Empty(t, updated.Finalizers)
+
+		require.NotNil(t, created)
+		require.Equal(t, map[string]string{"team": "a"}, created.Labels)
+		require.Equal(t, map[string]string{
+			logicalcluster.AnnotationKey:                         "root:org",
+			tenancyv1alpha1.WorkspaceTrashRetentionAnnotationKey: "2h",
+		}, created.Annotations)
+		require.Equal(t, []string{corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer}, created.Finalizers)
+		require.Equal(t, original.Spec, created.Spec)
+
+		require.NotNil(t, updatedLogicalCluster)
+		require.Equal(t, types.UID("new-uid"), updatedLogicalCluster.Spec.Owner.UID)
+	})
+
+	t.Run("waits for foreign finalizers", func(t *testing.T) {
+		workspace := newWorkspace(corev1alpha1.LogicalClusterFinalizer, tenancyv1alpha1.WorkspaceTrashFinalizer, "example.com/other")
+		r := &trashReconciler{
+			now: time.Now,
+			updateWorkspace: func(ctx context.Context, cluster logicalcluster.Path, workspace *tenancyv1beta1.Workspace) (*tenancyv1beta1.Workspace, error) {
+				t.Fatal("unexpected update")
+				return nil, nil
+			},
+		}
+
+		status, err := r.reconcile(context.Background(), workspace)
+		require.Error(t, err)
+		require.Equal(t, reconcileStatusStopAndRequeue, status)
+	})
+}
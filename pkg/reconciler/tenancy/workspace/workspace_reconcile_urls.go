@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// urlsReconciler publishes the path based URL of a scheduled workspace in its status. The
+// logical cluster based URL is spec.URL.
+type urlsReconciler struct {
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+func (r *urlsReconciler) reconcile(ctx context.Context, workspace *tenancyv1beta1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "urls")
+
+	if workspace.Spec.URL == "" || workspace.Spec.Cluster == "" {
+		return reconcileStatusContinue, nil
+	}
+
+	// the path is only known through the parent logical cluster
+	parent, err := r.getLogicalCluster(logicalcluster.From(workspace))
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+	parentPath := logicalcluster.NewPath(parent.Annotations[core.LogicalClusterPathAnnotationKey])
+	if parentPath.Empty() {
+		return reconcileStatusContinue, nil
+	}
+	pathURL, err := pathURLOf(workspace.Spec.URL, parentPath.Join(workspace.Name))
+	if err != nil {
+		logger.Error(err, "invalid workspace URL", "url", workspace.Spec.URL)
+		return reconcileStatusContinue, nil
+	}
+
+	workspace.Status.URLs = &tenancyv1beta1.WorkspaceURLs{
+		Path: pathURL,
+	}
+
+	return reconcileStatusContinue, nil
+}
+
+// pathURLOf returns the given logical cluster based URL of a workspace with the logical
+// cluster name replaced by the given path.
+func pathURLOf(clusterURL string, workspacePath logicalcluster.Path) (string, error) {
+	u, err := url.Parse(clusterURL)
+	if err != nil {
+		return "", err
+	}
+	if i := strings.LastIndex(u.Path, "/clusters/"); i >= 0 {
+		u.Path = u.Path[:i]
+	}
+	u.Path = path.Join(u.Path, workspacePath.RequestPath())
+	return u.String(), nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestReconcileURLs(t *testing.T) {
+	for _, testCase := range []struct {
+		name       string
+		spec       tenancyv1beta1.WorkspaceSpec
+		parentPath string
+		noParent   bool
+		wantURLs   *tenancyv1beta1.WorkspaceURLs
+	}{
+		{
+			name: "not scheduled",
+		},
+		{
+			name: "scheduled",
+			spec: tenancyv1beta1.WorkspaceSpec{
+				Cluster: "2x8l3v1b9rgnh8ve",
+				URL:     "https://kcp.example.com/clusters/2x8l3v1b9rgnh8ve",
+			},
+			parentPath: "root:org",
+			wantURLs: &tenancyv1beta1.WorkspaceURLs{
+				Path: "https://kcp.example.com/clusters/root:org:ws",
+			},
+		},
+		{
+			name: "scheduled on a shard with a path prefix",
+			spec: tenancyv1beta1.WorkspaceSpec{
+				Cluster: "2x8l3v1b9rgnh8ve",
+				URL:     "https://kcp.example.com/prefix/clusters/2x8l3v1b9rgnh8ve",
+			},
+			parentPath: "root:org",
+			wantURLs: &tenancyv1beta1.WorkspaceURLs{
+				Path: "https://kcp.example.com/prefix/clusters/root:org:ws",
+			},
+		},
+		{
+			name: "parent without path",
+			spec: tenancyv1beta1.WorkspaceSpec{
+				Cluster: "2x8l3v1b9rgnh8ve",
+				URL:     "https://kcp.example.com/clusters/2x8l3v1b9rgnh8ve",
+			},
+		},
+		{
+			name: "parent not found",
+			spec: tenancyv1beta1.WorkspaceSpec{
+				Cluster: "2x8l3v1b9rgnh8ve",
+				URL:     "https://kcp.example.com/clusters/2x8l3v1b9rgnh8ve",
+			},
+			noParent: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ws",
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "parent"},
+				},
+				Spec: testCase.spec,
+			}
+			r := &urlsReconciler{
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, logicalcluster.Name("parent"), clusterName)
+					if testCase.noParent {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+					}
+					parent := &corev1alpha1.LogicalCluster{}
+					if testCase.parentPath != "" {
+						parent.Annotations = map[string]string{core.LogicalClusterPathAnnotationKey: testCase.parentPath}
+					}
+					return parent, nil
+				},
+			}
+			status, err := r.reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, testCase.wantURLs, workspace.Status.URLs)
+		})
+	}
+}
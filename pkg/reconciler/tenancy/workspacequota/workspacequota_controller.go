@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	workspacequotaadmission "github.com/kcp-dev/kcp/pkg/admission/workspacequota"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/informer"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	tenancyv1beta1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+const (
+	ControllerName = "kcp-workspacequota"
+
+	// resyncPeriod is the period after which the usage of a quota is recounted. Creations are charged
+	// by admission right away, the recount releases the charges of deleted objects.
+	resyncPeriod = time.Minute
+)
+
+// NewController returns a new controller reporting the usage of the logical cluster in the status
+// of its WorkspaceQuotas. The limits are enforced by admission. The notifiers are called when the
+// usage reaches a notification threshold.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	workspaceQuotaInformer tenancyv1alpha1informers.WorkspaceQuotaClusterInformer,
+	workspaceInformer tenancyv1beta1informers.WorkspaceClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	ddsif *informer.DiscoveringDynamicSharedInformerFactory,
+	controllerSwitch *controllerswitch.Switch,
+	notifiers []Notifier,
+) (*controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue:     controllerSwitch.Queue(queue),
+		notifiers: notifiers,
+		getWorkspaceQuota: func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceQuota, error) {
+			return workspaceQuotaInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listWorkspaceQuotas: func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceQuota, error) {
+			return workspaceQuotaInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		countWorkspaces: func(clusterName logicalcluster.Name) (int, error) {
+			workspaces, err := workspaceInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			return len(workspaces), err
+		},
+		countAPIBindings: func(clusterName logicalcluster.Name) (int, error) {
+			bindings, err := apiBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+			return len(bindings), err
+		},
+		countObjects: func(clusterName logicalcluster.Name) (int, error) {
+			informers, _ := ddsif.Informers()
+			return countObjects(clusterName, informers)
+		},
+		commit: committer.NewCommitter[*WorkspaceQuota, Patcher, *WorkspaceQuotaSpec, *WorkspaceQuotaStatus](kcpClusterClient.TenancyV1alpha1().WorkspaceQuotas()),
+	}
+
+	workspaceQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueWorkspaceQuota(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueWorkspaceQuota(obj) },
+	})
+
+	// workspaces and bindings are counted immediately, all other objects on resync
+	for _, inf := range []cache.SharedIndexInformer{workspaceInformer.Informer(), apiBindingInformer.Informer()} {
+		inf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueCluster(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueCluster(obj) },
+		})
+	}
+
+	return c, nil
+}
+
+type WorkspaceQuota = tenancyv1alpha1.WorkspaceQuota
+type WorkspaceQuotaSpec = tenancyv1alpha1.WorkspaceQuotaSpec
+type WorkspaceQuotaStatus = tenancyv1alpha1.WorkspaceQuotaStatus
+type Patcher = tenancyv1alpha1client.WorkspaceQuotaInterface
+type Resource = committer.Resource[*WorkspaceQuotaSpec, *WorkspaceQuotaStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller reports the usage of logical clusters in their WorkspaceQuotas.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	getWorkspaceQuota   func(clusterName logicalcluster.Name, name string) (*tenancyv1alpha1.WorkspaceQuota, error)
+	listWorkspaceQuotas func(clusterName logicalcluster.Name) ([]*tenancyv1alpha1.WorkspaceQuota, error)
+	countWorkspaces     func(clusterName logicalcluster.Name) (int, error)
+	countAPIBindings    func(clusterName logicalcluster.Name) (int, error)
+	countObjects        func(clusterName logicalcluster.Name) (int, error)
+
+	notifiers []Notifier
+
+	commit CommitFunc
+}
+
+// enqueueWorkspaceQuota enqueues a WorkspaceQuota.
+func (c *controller) enqueueWorkspaceQuota(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing WorkspaceQuota")
+	c.queue.Add(key)
+}
+
+// enqueueCluster enqueues all WorkspaceQuotas in the logical cluster of the given object.
+func (c *controller) enqueueCluster(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(logicalcluster.Object)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("unexpected type %T", obj))
+		return
+	}
+
+	quotas, err := c.listWorkspaceQuotas(logicalcluster.From(o))
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, quota := range quotas {
+		c.enqueueWorkspaceQuota(quota)
+	}
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	c.queue.AddAfter(key, resyncPeriod)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	obj, err := c.getWorkspaceQuota(clusterName, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	var errs []error
+	if err := c.reconcile(ctx, obj); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Regardless of whether reconcile returned an error or not, always try to patch status if needed. Return the
+	// reconciliation error at the end.
+
+	// If the object being reconciled changed as a result, update it.
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	if err := c.commit(ctx, oldResource, newResource); err != nil {
+		errs = append(errs, err)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// countObjects counts the objects of all counted resources in the logical cluster. Every resource is
+// counted once, even if informers for multiple versions exist.
+func countObjects(clusterName logicalcluster.Name, informers map[schema.GroupVersionResource]kcpkubernetesinformers.GenericClusterInformer) (int, error) {
+	seen := map[schema.GroupResource]bool{}
+	count := 0
+	for gvr, inf := range informers {
+		gr := gvr.GroupResource()
+		if seen[gr] || !workspacequotaadmission.Counted(gr) {
+			continue
+		}
+		seen[gr] = true
+
+		objs, err := inf.Lister().ByCluster(clusterName).List(labels.Everything())
+		if err != nil {
+			return 0, fmt.Errorf("failed to list %s: %w", gr, err)
+		}
+		count += len(objs)
+	}
+	return count, nil
+}
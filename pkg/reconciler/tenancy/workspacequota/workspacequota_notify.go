@@ -0,0 +1,248 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+const (
+	quotaThresholdReachedEventReason = "QuotaThresholdReached"
+
+	webhookTimeout = 10 * time.Second
+)
+
+// Notification is a notification threshold reached by the usage of a resource of a WorkspaceQuota.
+// It is the JSON body of webhook requests.
+type Notification struct {
+	// Cluster is the logical cluster of the WorkspaceQuota.
+	Cluster string `json:"cluster"`
+	// Quota is the name of the WorkspaceQuota.
+	Quota string `json:"quota"`
+	// Resource is the name of the resource in spec.hard.
+	Resource corev1.ResourceName `json:"resource"`
+	// Threshold is the reached threshold in percent of the hard limit.
+	Threshold int32 `json:"threshold"`
+	// Used is the current usage of the resource.
+	Used resource.Quantity `json:"used"`
+	// Hard is the hard limit of the resource.
+	Hard resource.Quantity `json:"hard"`
+}
+
+func (n *Notification) message() string {
+	return fmt.Sprintf("Usage of %s reached %d%% of the hard limit: %s used, limited to %s.", n.Resource, n.Threshold, n.Used.String(), n.Hard.String())
+}
+
+// Notifier notifies the tenant of a workspace about a notification threshold reached by the usage
+// of a WorkspaceQuota.
+type Notifier interface {
+	Notify(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota, notification *Notification) error
+}
+
+// NewEventNotifier returns a Notifier creating a warning event about the WorkspaceQuota in the
+// default namespace of its logical cluster.
+func NewEventNotifier(kubeClusterClient kcpkubernetesclientset.ClusterInterface) Notifier {
+	return &eventNotifier{
+		now: time.Now,
+		createEvent: func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error {
+			_, err := kubeClusterClient.Cluster(cluster).CoreV1().Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{})
+			return err
+		},
+	}
+}
+
+type eventNotifier struct {
+	now         func() time.Time
+	createEvent func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error
+}
+
+func (n *eventNotifier) Notify(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota, notification *Notification) error {
+	now := metav1.NewTime(n.now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: quota.Name + ".",
+			Namespace:    metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      tenancyv1alpha1.SchemeGroupVersion.String(),
+			Kind:            "WorkspaceQuota",
+			Name:            quota.Name,
+			UID:             quota.UID,
+			ResourceVersion: quota.ResourceVersion,
+		},
+		Reason:         quotaThresholdReachedEventReason,
+		Message:        notification.message(),
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: ControllerName},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	return n.createEvent(ctx, logicalcluster.From(quota).Path(), event)
+}
+
+// NewWebhookNotifier returns a Notifier posting the notification to the https URL in the
+// WorkspaceQuotaNotificationWebhookAnnotationKey annotation of the WorkspaceQuota, if any.
+// Redirects are not followed.
+func NewWebhookNotifier() Notifier {
+	return &webhookNotifier{
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+type webhookNotifier struct {
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota, notification *Notification) error {
+	value, found := quota.Annotations[tenancyv1alpha1.WorkspaceQuotaNotificationWebhookAnnotationKey]
+	if !found {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid quota notification webhook %q: must be an https URL", value)
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call quota notification webhook %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("quota notification webhook %s returned %s", u.Host, resp.Status)
+	}
+	return nil
+}
+
+// notify notifies the tenant about the notification thresholds the usage has reached since the last
+// reconciliation, and records the reached thresholds in the status. Every threshold is notified at most
+// once until the usage drops below it again, hence failing notifiers are not retried.
+func (c *controller) notify(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota) {
+	logger := klog.FromContext(ctx)
+
+	notified := map[corev1.ResourceName]int32{}
+	for _, t := range quota.Status.Thresholds {
+		notified[t.Resource] = t.Percent
+	}
+
+	names := make([]string, 0, len(quota.Status.Used))
+	for name := range quota.Status.Used {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	thresholds := notificationThresholds(quota)
+	var reached []tenancyv1alpha1.WorkspaceQuotaThreshold
+	for _, name := range names {
+		used, hard := quota.Status.Used[corev1.ResourceName(name)], quota.Spec.Hard[corev1.ResourceName(name)]
+		threshold := reachedThreshold(used.Value(), hard.Value(), thresholds)
+		if threshold == 0 {
+			continue
+		}
+		reached = append(reached, tenancyv1alpha1.WorkspaceQuotaThreshold{Resource: corev1.ResourceName(name), Percent: threshold})
+		if threshold <= notified[corev1.ResourceName(name)] {
+			continue
+		}
+
+		notification := &Notification{
+			Cluster:   logicalcluster.From(quota).String(),
+			Quota:     quota.Name,
+			Resource:  corev1.ResourceName(name),
+			Threshold: threshold,
+			Used:      used,
+			Hard:      hard,
+		}
+		logger.V(2).Info("quota notification threshold reached", "resource", name, "threshold", threshold)
+		for _, n := range c.notifiers {
+			if err := n.Notify(ctx, quota, notification); err != nil {
+				logger.Error(err, "failed to notify about reached quota threshold", "resource", name, "threshold", threshold)
+			}
+		}
+	}
+	quota.Status.Thresholds = reached
+}
+
+// notificationThresholds returns the notification thresholds of the quota in ascending order. Invalid
+// entries of the annotation are ignored, an empty annotation disables notifications.
+func notificationThresholds(quota *tenancyv1alpha1.WorkspaceQuota) []int32 {
+	value, found := quota.Annotations[tenancyv1alpha1.WorkspaceQuotaNotificationThresholdsAnnotationKey]
+	if !found {
+		return tenancyv1alpha1.DefaultWorkspaceQuotaNotificationThresholds
+	}
+
+	seen := map[int32]bool{}
+	var thresholds []int32
+	for _, s := range strings.Split(value, ",") {
+		t, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+		if err != nil || t <= 0 || seen[int32(t)] {
+			continue
+		}
+		seen[int32(t)] = true
+		thresholds = append(thresholds, int32(t))
+	}
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+	return thresholds
+}
+
+// reachedThreshold returns the highest of the ascending thresholds the usage has reached, or 0.
+// Resources limited to 0 have no thresholds.
+func reachedThreshold(used, hard int64, thresholds []int32) int32 {
+	if hard <= 0 {
+		return 0
+	}
+	var reached int32
+	for _, t := range thresholds {
+		if used*100 >= int64(t)*hard {
+			reached = t
+		}
+	}
+	return reached
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+type fakeNotifier struct {
+	notifications []string
+	err           error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota, notification *Notification) error {
+	n.notifications = append(n.notifications, fmt.Sprintf("%s/%s=%d", notification.Cluster, notification.Resource, notification.Threshold))
+	return n.err
+}
+
+func TestNotify(t *testing.T) {
+	tests := map[string]struct {
+		hard              string
+		used              string
+		thresholds        *string
+		notified          int32
+		notifierErr       error
+		wantNotifications []string
+		wantReached       int32
+	}{
+		"below all thresholds": {
+			hard: "100",
+			used: "79",
+		},
+		"reaches the first threshold": {
+			hard:              "100",
+			used:              "80",
+			wantNotifications: []string{"team/objects=80"},
+			wantReached:       80,
+		},
+		"jumps to the highest reached threshold": {
+			hard:              "10",
+			used:              "12",
+			wantNotifications: []string{"team/objects=100"},
+			wantReached:       100,
+		},
+		"already notified": {
+			hard:        "100",
+			used:        "95",
+			notified:    90,
+			wantReached: 90,
+		},
+		"reaches a higher threshold": {
+			hard:              "100",
+			used:              "95",
+			notified:          80,
+			wantNotifications: []string{"team/objects=90"},
+			wantReached:       90,
+		},
+		"drops below a threshold": {
+			hard:        "100",
+			used:        "85",
+			notified:    100,
+			wantReached: 80,
+		},
+		"custom thresholds": {
+			hard:              "100",
+			used:              "60",
+			thresholds:        ptr("100, 50,x,-3,50"),
+			wantNotifications: []string{"team/objects=50"},
+			wantReached:       50,
+		},
+		"disabled": {
+			hard:       "100",
+			used:       "100",
+			thresholds: ptr(""),
+		},
+		"limited to zero": {
+			hard: "0",
+			used: "0",
+		},
+		"failing notifier": {
+			hard:              "100",
+			used:              "100",
+			notifierErr:       fmt.Errorf("webhook down"),
+			wantNotifications: []string{"team/objects=100"},
+			wantReached:       100,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			notifier := &fakeNotifier{err: tt.notifierErr}
+			c := &controller{notifiers: []Notifier{notifier}}
+			quota := &tenancyv1alpha1.WorkspaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Annotations: map[string]string{logicalcluster.AnnotationKey: "team"}},
+				Spec: tenancyv1alpha1.WorkspaceQuotaSpec{Hard: corev1.ResourceList{
+					tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse(tt.hard),
+				}},
+				Status: tenancyv1alpha1.WorkspaceQuotaStatus{Used: corev1.ResourceList{
+					tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse(tt.used),
+				}},
+			}
+			if tt.thresholds != nil {
+				quota.Annotations[tenancyv1alpha1.WorkspaceQuotaNotificationThresholdsAnnotationKey] = *tt.thresholds
+			}
+			if tt.notified > 0 {
+				quota.Status.Thresholds = []tenancyv1alpha1.WorkspaceQuotaThreshold{{Resource: tenancyv1alpha1.WorkspaceQuotaObjects, Percent: tt.notified}}
+			}
+
+			c.notify(context.Background(), quota)
+
+			require.Equal(t, tt.wantNotifications, notifier.notifications)
+			if tt.wantReached == 0 {
+				require.Empty(t, quota.Status.Thresholds)
+			} else {
+				require.Equal(t, []tenancyv1alpha1.WorkspaceQuotaThreshold{{Resource: tenancyv1alpha1.WorkspaceQuotaObjects, Percent: tt.wantReached}}, quota.Status.Thresholds)
+			}
+		})
+	}
+}
+
+func TestEventNotifier(t *testing.T) {
+	var created *corev1.Event
+	n := &eventNotifier{
+		now: func() time.Time { return time.Unix(1700000000, 0) },
+		createEvent: func(ctx context.Context, cluster logicalcluster.Path, event *corev1.Event) error {
+			require.Equal(t, "team", cluster.String())
+			created = event
+			return nil
+		},
+	}
+	quota := &tenancyv1alpha1.WorkspaceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Annotations: map[string]string{logicalcluster.AnnotationKey: "team"}},
+	}
+	err := n.Notify(context.Background(), quota, &Notification{
+		Cluster:   "team",
+		Quota:     "quota",
+		Resource:  tenancyv1alpha1.WorkspaceQuotaWorkspaces,
+		Threshold: 90,
+		Used:      resource.MustParse("9"),
+		Hard:      resource.MustParse("10"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	require.Equal(t, metav1.NamespaceDefault, created.Namespace)
+	require.Equal(t, "WorkspaceQuota", created.InvolvedObject.Kind)
+	require.Equal(t, corev1.EventTypeWarning, created.Type)
+	require.Equal(t, "Usage of workspaces reached 90% of the hard limit: 9 used, limited to 10.", created.Message)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received []Notification
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, n)
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		webhook      *string
+		wantErr      bool
+		wantReceived int
+	}{
+		"no webhook": {},
+		"delivered": {
+			webhook:      ptr(server.URL + "/notify"),
+			wantReceived: 1,
+		},
+		"webhook fails": {
+			webhook: ptr(server.URL + "/fail"),
+			wantErr: true,
+		},
+		"http is rejected": {
+			webhook: ptr("http://example.com/notify"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			received = nil
+			n := &webhookNotifier{client: server.Client()}
+			quota := &tenancyv1alpha1.WorkspaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Annotations: map[string]string{logicalcluster.AnnotationKey: "team"}},
+			}
+			if tt.webhook != nil {
+				quota.Annotations[tenancyv1alpha1.WorkspaceQuotaNotificationWebhookAnnotationKey] = *tt.webhook
+			}
+			notification := &Notification{
+				Cluster:   "team",
+				Quota:     "quota",
+				Resource:  tenancyv1alpha1.WorkspaceQuotaObjects,
+				Threshold: 80,
+				Used:      resource.MustParse("80"),
+				Hard:      resource.MustParse("100"),
+			}
+			err := n.Notify(context.Background(), quota, notification)
+			require.Equal(t, tt.wantErr, err != nil, "unexpected error: %v", err)
+			require.Len(t, received, tt.wantReceived)
+			if tt.wantReceived > 0 {
+				require.Equal(t, "team", received[0].Cluster)
+				require.Equal(t, int32(80), received[0].Threshold)
+				require.Equal(t, "100", received[0].Hard.String())
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, quota *tenancyv1alpha1.WorkspaceQuota) error {
+	clusterName := logicalcluster.From(quota)
+
+	counters := map[corev1.ResourceName]func(logicalcluster.Name) (int, error){
+		tenancyv1alpha1.WorkspaceQuotaWorkspaces:  c.countWorkspaces,
+		tenancyv1alpha1.WorkspaceQuotaAPIBindings: c.countAPIBindings,
+		tenancyv1alpha1.WorkspaceQuotaObjects:     c.countObjects,
+	}
+
+	used := corev1.ResourceList{}
+	var exceeded []string
+	for name, hard := range quota.Spec.Hard {
+		count, found := counters[name]
+		if !found {
+			continue
+		}
+		n, err := count(clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to count %s: %w", name, err)
+		}
+		used[name] = *resource.NewQuantity(int64(n), resource.DecimalSI)
+		if int64(n) > hard.Value() {
+			exceeded = append(exceeded, fmt.Sprintf("%s used %d, limited to %s", name, n, hard.String()))
+		}
+	}
+	quota.Status.Used = used
+
+	if len(exceeded) > 0 {
+		sort.Strings(exceeded)
+		conditions.MarkFalse(
+			quota,
+			tenancyv1alpha1.WorkspaceQuotaWithinLimits,
+			tenancyv1alpha1.WorkspaceQuotaExceededReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"Usage exceeds the hard limits: %s",
+			strings.Join(exceeded, "; "),
+		)
+	} else {
+		conditions.MarkTrue(quota, tenancyv1alpha1.WorkspaceQuotaWithinLimits)
+	}
+
+	c.notify(ctx, quota)
+
+	return nil
+}
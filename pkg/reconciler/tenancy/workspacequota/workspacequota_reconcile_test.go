@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacequota
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	counter := func(n int, err error) func(logicalcluster.Name) (int, error) {
+		return func(clusterName logicalcluster.Name) (int, error) {
+			if clusterName != "team" {
+				return 0, fmt.Errorf("unexpected cluster %s", clusterName)
+			}
+			return n, err
+		}
+	}
+
+	tests := map[string]struct {
+		hard       corev1.ResourceList
+		countErr   error
+		wantErr    bool
+		wantUsed   corev1.ResourceList
+		wantWithin bool
+	}{
+		"within limits": {
+			hard: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaWorkspaces:  resource.MustParse("5"),
+				tenancyv1alpha1.WorkspaceQuotaAPIBindings: resource.MustParse("5"),
+				tenancyv1alpha1.WorkspaceQuotaObjects:     resource.MustParse("100"),
+			},
+			wantUsed: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaWorkspaces:  resource.MustParse("2"),
+				tenancyv1alpha1.WorkspaceQuotaAPIBindings: resource.MustParse("3"),
+				tenancyv1alpha1.WorkspaceQuotaObjects:     resource.MustParse("42"),
+			},
+			wantWithin: true,
+		},
+		"only limited resources are reported": {
+			hard: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaWorkspaces: resource.MustParse("5"),
+			},
+			wantUsed: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaWorkspaces: resource.MustParse("2"),
+			},
+			wantWithin: true,
+		},
+		"limit lowered below usage": {
+			hard: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse("10"),
+			},
+			wantUsed: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse("42"),
+			},
+		},
+		"counting fails": {
+			hard: corev1.ResourceList{
+				tenancyv1alpha1.WorkspaceQuotaObjects: resource.MustParse("10"),
+			},
+			countErr: fmt.Errorf("not synced"),
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				countWorkspaces:  counter(2, nil),
+				countAPIBindings: counter(3, nil),
+				countObjects:     counter(42, tt.countErr),
+			}
+			quota := &tenancyv1alpha1.WorkspaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Annotations: map[string]string{logicalcluster.AnnotationKey: "team"}},
+				Spec:       tenancyv1alpha1.WorkspaceQuotaSpec{Hard: tt.hard},
+			}
+			err := c.reconcile(context.Background(), quota)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, quotav1.Equals(tt.wantUsed, quota.Status.Used), "expected used %v, got %v", tt.wantUsed, quota.Status.Used)
+			require.Equal(t, tt.wantWithin, conditions.IsTrue(quota, tenancyv1alpha1.WorkspaceQuotaWithinLimits))
+		})
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesummary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1beta1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
+)
+
+const (
+	ControllerName = "kcp-workspacesummary"
+
+	// batchPeriod is the time changes to the content of a logical cluster are collected
+	// before the summary is recomputed, such that bursts of changes cause a single update.
+	batchPeriod = 10 * time.Second
+)
+
+// NewController returns a new controller rolling up the content of the logical clusters of
+// this shard into the summary of their owning Workspaces. Owners can live on other shards,
+// hence they are patched through the front-proxy.
+func NewController(
+	logicalClusterAdminConfig *rest.Config,
+	shardExternalURL func() string,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	workspaceInformer tenancyv1beta1informers.WorkspaceClusterInformer,
+	apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer,
+	resourceQuotaInformer kcpcorev1informers.ResourceQuotaClusterInformer,
+) *controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+
+	c := &controller{
+		queue: queue,
+
+		logicalClusterAdminConfig: logicalClusterAdminConfig,
+		shardExternalURL:          shardExternalURL,
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1beta1.Workspace, error) {
+			return workspaceInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+			return apiBindingInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+		listResourceQuotas: func(clusterName logicalcluster.Name) ([]*corev1.ResourceQuota, error) {
+			return resourceQuotaInformer.Lister().Cluster(clusterName).List(labels.Everything())
+		},
+
+		summaries: map[string]*tenancyv1beta1.WorkspaceSummary{},
+	}
+
+	logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueLogicalCluster(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueLogicalCluster(obj) },
+		DeleteFunc: func(obj interface{}) { c.forgetLogicalCluster(obj) },
+	})
+
+	for _, informer := range []cache.SharedIndexInformer{
+		workspaceInformer.Informer(),
+		apiBindingInformer.Informer(),
+		resourceQuotaInformer.Informer(),
+	} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueContent(obj) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueContent(obj) },
+			DeleteFunc: func(obj interface{}) { c.enqueueContent(obj) },
+		})
+	}
+
+	return c
+}
+
+// controller maintains the summary of Workspaces from the content of their logical clusters.
+type controller struct {
+	queue workqueue.RateLimitingInterface
+
+	logicalClusterAdminConfig *rest.Config
+	shardExternalURL          func() string
+
+	getLogicalCluster  func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	listWorkspaces     func(clusterName logicalcluster.Name) ([]*tenancyv1beta1.Workspace, error)
+	listAPIBindings    func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error)
+	listResourceQuotas func(clusterName logicalcluster.Name) ([]*corev1.ResourceQuota, error)
+
+	// patchSummary is set up in Start as it needs the external URL of the shard.
+	patchSummary func(ctx context.Context, owner *corev1alpha1.LogicalClusterOwner, summary *tenancyv1beta1.WorkspaceSummary) error
+
+	// summaries are the last summaries written per logical cluster key, in order to skip
+	// patches that would not change anything.
+	lock      sync.Mutex
+	summaries map[string]*tenancyv1beta1.WorkspaceSummary
+}
+
+// enqueueLogicalCluster enqueues a LogicalCluster.
+func (c *controller) enqueueLogicalCluster(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), key)
+	logger.V(4).Info("queueing LogicalCluster")
+	c.queue.Add(key)
+}
+
+// enqueueContent enqueues the LogicalCluster an object lives in, delayed by the batch period.
+func (c *controller) enqueueContent(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	logicalClusterKey := kcpcache.ToClusterAwareKey(clusterName.String(), "", corev1alpha1.LogicalClusterName)
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), logicalClusterKey)
+	logger.V(4).Info("queueing LogicalCluster because of content change", "object", key)
+	c.queue.AddAfter(logicalClusterKey, batchPeriod)
+}
+
+// forgetLogicalCluster drops the last written summary of a deleted LogicalCluster.
+func (c *controller) forgetLogicalCluster(obj interface{}) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.summaries, key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	// a client needed to patch owning workspaces on a different shard
+	frontProxyConfig := rest.CopyConfig(c.logicalClusterAdminConfig)
+	frontProxyConfig = rest.AddUserAgent(frontProxyConfig, ControllerName)
+	frontProxyConfig.Host = c.shardExternalURL()
+	kcpFrontProxyClient, err := kcpclientset.NewForConfig(frontProxyConfig)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.patchSummary = func(ctx context.Context, owner *corev1alpha1.LogicalClusterOwner, summary *tenancyv1beta1.WorkspaceSummary) error {
+		patch, err := json.Marshal(map[string]interface{}{
+			// the UID is a precondition, such that a recreated workspace of the same name is not touched
+			"metadata": map[string]interface{}{"uid": owner.UID},
+			"status":   map[string]interface{}{"summary": summary},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = kcpFrontProxyClient.Cluster(logicalcluster.NewPath(owner.Cluster)).TenancyV1beta1().Workspaces().Patch(ctx, owner.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: ControllerName}, "status")
+		return err
+	}
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k.(string)
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	if err := c.process(ctx, key); err != nil {
+		runtime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(err)
+		return nil
+	}
+	logicalCluster, err := c.getLogicalCluster(clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), logicalCluster)
+	ctx = klog.NewContext(ctx, logger)
+
+	return c.reconcile(ctx, key, logicalCluster)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesummary
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func (c *controller) reconcile(ctx context.Context, key string, logicalCluster *corev1alpha1.LogicalCluster) error {
+	logger := klog.FromContext(ctx)
+
+	owner := logicalCluster.Spec.Owner
+	if !isWorkspaceOwner(owner) {
+		return nil // e.g. the root logical cluster
+	}
+	if !logicalCluster.DeletionTimestamp.IsZero() {
+		return nil // the workspace is going away anyway
+	}
+
+	clusterName := logicalcluster.From(logicalCluster)
+	workspaces, err := c.listWorkspaces(clusterName)
+	if err != nil {
+		return err
+	}
+	bindings, err := c.listAPIBindings(clusterName)
+	if err != nil {
+		return err
+	}
+	quotas, err := c.listResourceQuotas(clusterName)
+	if err != nil {
+		return err
+	}
+	summary := summarize(logicalCluster, workspaces, bindings, quotas)
+
+	c.lock.Lock()
+	last := c.summaries[key]
+	c.lock.Unlock()
+	if equality.Semantic.DeepEqual(last, summary) {
+		return nil
+	}
+
+	logger.V(3).Info("updating workspace summary", "owner.cluster", owner.Cluster, "owner.name", owner.Name)
+	if err := c.patchSummary(ctx, owner, summary); err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			logger.V(3).Info("owning workspace is gone, skipping summary update", "owner.cluster", owner.Cluster, "owner.name", owner.Name)
+			return nil
+		}
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.summaries[key] = summary
+
+	return nil
+}
+
+// isWorkspaceOwner returns true if the owner is a Workspace.
+func isWorkspaceOwner(owner *corev1alpha1.LogicalClusterOwner) bool {
+	if owner == nil || owner.Resource != "workspaces" {
+		return false
+	}
+	group := strings.SplitN(owner.APIVersion, "/", 2)[0]
+	return group == tenancyv1beta1.SchemeGroupVersion.Group
+}
+
+// summarize rolls up the content of a logical cluster.
+func summarize(logicalCluster *corev1alpha1.LogicalCluster, workspaces []*tenancyv1beta1.Workspace, bindings []*apisv1alpha1.APIBinding, quotas []*corev1.ResourceQuota) *tenancyv1beta1.WorkspaceSummary {
+	summary := &tenancyv1beta1.WorkspaceSummary{
+		ChildWorkspaces: int32(len(workspaces)),
+		APIBindings:     int32(len(bindings)),
+	}
+
+	var last metav1.Time
+	observe := func(obj metav1.Object) {
+		if t := obj.GetCreationTimestamp(); last.Before(&t) {
+			last = t
+		}
+		for _, entry := range obj.GetManagedFields() {
+			if !isUserActivity(entry) {
+				continue
+			}
+			if entry.Time != nil && last.Before(entry.Time) {
+				last = *entry.Time
+			}
+		}
+	}
+
+	observe(logicalCluster)
+	for _, ws := range workspaces {
+		observe(ws)
+	}
+	for _, binding := range bindings {
+		observe(binding)
+		summary.APIs += int32(len(binding.Status.BoundResources))
+	}
+	for _, quota := range quotas {
+		observe(quota)
+		summary.QuotaHard = addResources(summary.QuotaHard, quota.Status.Hard)
+		summary.QuotaUsed = addResources(summary.QuotaUsed, quota.Status.Used)
+	}
+
+	if !last.IsZero() {
+		summary.LastActivityTime = &last
+	}
+
+	return summary
+}
+
+// systemFieldManagers are the field managers of kcp's own components. The field manager of a client
+// defaults to the binary name in its user agent.
+var systemFieldManagers = sets.NewString(
+	ControllerName,
+	"kcp",
+	"kcp-front-proxy",
+	"virtual-workspaces",
+	"cache-server",
+)
+
+// isUserActivity returns true if the managed fields entry was not written by kcp itself. Status is
+// only written by controllers, hence it never counts.
+func isUserActivity(entry metav1.ManagedFieldsEntry) bool {
+	if entry.Subresource != "" {
+		return false
+	}
+	return !systemFieldManagers.Has(entry.Manager)
+}
+
+func addResources(sum, add corev1.ResourceList) corev1.ResourceList {
+	for name, quantity := range add {
+		if sum == nil {
+			sum = corev1.ResourceList{}
+		}
+		total := sum[name]
+		total.Add(quantity)
+		sum[name] = total
+	}
+	return sum
+}
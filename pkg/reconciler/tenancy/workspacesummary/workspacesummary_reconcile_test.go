@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesummary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestSummarize(t *testing.T) {
+	created := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	changed := metav1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	reconciled := metav1.NewTime(time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC))
+
+	logicalCluster := &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+	workspaces := []*tenancyv1beta1.Workspace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", CreationTimestamp: created}},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "b",
+				CreationTimestamp: created,
+				ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: ControllerName, Subresource: "status", Time: &reconciled}},
+			},
+		},
+	}
+	bindings := []*apisv1alpha1.APIBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "kubernetes",
+				CreationTimestamp: created,
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "kubectl-edit", Time: &changed},
+					{Manager: "kcp", Time: &reconciled},
+					{Manager: "kubectl-edit", Subresource: "status", Time: &reconciled},
+				},
+			},
+			Status: apisv1alpha1.APIBindingStatus{
+				BoundResources: []apisv1alpha1.BoundAPIResource{{Resource: "deployments"}, {Resource: "services"}},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tenancy", CreationTimestamp: created}},
+	}
+	quotas := []*corev1.ResourceQuota{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "quota", CreationTimestamp: created},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceConfigMaps: resource.MustParse("10")},
+				Used: corev1.ResourceList{corev1.ResourceConfigMaps: resource.MustParse("1")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "quota", CreationTimestamp: created},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceConfigMaps: resource.MustParse("5"), corev1.ResourceSecrets: resource.MustParse("3")},
+				Used: corev1.ResourceList{corev1.ResourceConfigMaps: resource.MustParse("2")},
+			},
+		},
+	}
+
+	got := summarize(logicalCluster, workspaces, bindings, quotas)
+
+	require.Equal(t, int32(2), got.ChildWorkspaces)
+	require.Equal(t, int32(2), got.APIBindings)
+	require.Equal(t, int32(2), got.APIs)
+	require.Equal(t, &changed, got.LastActivityTime)
+	require.True(t, got.QuotaHard.Name(corev1.ResourceConfigMaps, resource.DecimalSI).Equal(resource.MustParse("15")))
+	require.True(t, got.QuotaHard.Name(corev1.ResourceSecrets, resource.DecimalSI).Equal(resource.MustParse("3")))
+	require.True(t, got.QuotaUsed.Name(corev1.ResourceConfigMaps, resource.DecimalSI).Equal(resource.MustParse("3")))
+	require.Len(t, got.QuotaUsed, 1)
+
+	empty := summarize(&corev1alpha1.LogicalCluster{}, nil, nil, nil)
+	require.Equal(t, &tenancyv1beta1.WorkspaceSummary{}, empty)
+}
+
+func TestReconcile(t *testing.T) {
+	owner := &corev1alpha1.LogicalClusterOwner{
+		APIVersion: tenancyv1beta1.SchemeGroupVersion.String(),
+		Resource:   "workspaces",
+		Name:       "ws",
+		Cluster:    "parent",
+		UID:        "uid",
+	}
+
+	tests := map[string]struct {
+		owner       *corev1alpha1.LogicalClusterOwner
+		deleting    bool
+		patchErr    error
+		wantPatches int
+		wantErr     bool
+	}{
+		"patches once for unchanged content": {
+			owner:       owner,
+			wantPatches: 1,
+		},
+		"no owner": {},
+		"foreign owner": {
+			owner: &corev1alpha1.LogicalClusterOwner{APIVersion: "example.com/v1", Resource: "workspaces", Name: "ws", Cluster: "parent"},
+		},
+		"deleting": {
+			owner:    owner,
+			deleting: true,
+		},
+		"owner is gone": {
+			owner:       owner,
+			patchErr:    apierrors.NewNotFound(tenancyv1beta1.Resource("workspaces"), "ws"),
+			wantPatches: 2, // nothing is remembered, hence the second reconcile tries again
+		},
+		"patch fails": {
+			owner:       owner,
+			patchErr:    apierrors.NewInternalError(nil),
+			wantPatches: 2,
+			wantErr:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var patches int
+			c := &controller{
+				listWorkspaces: func(clusterName logicalcluster.Name) ([]*tenancyv1beta1.Workspace, error) {
+					return []*tenancyv1beta1.Workspace{{ObjectMeta: metav1.ObjectMeta{Name: "child"}}}, nil
+				},
+				listAPIBindings: func(clusterName logicalcluster.Name) ([]*apisv1alpha1.APIBinding, error) {
+					return nil, nil
+				},
+				listResourceQuotas: func(clusterName logicalcluster.Name) ([]*corev1.ResourceQuota, error) {
+					return nil, nil
+				},
+				patchSummary: func(ctx context.Context, gotOwner *corev1alpha1.LogicalClusterOwner, summary *tenancyv1beta1.WorkspaceSummary) error {
+					patches++
+					require.Equal(t, owner, gotOwner)
+					require.Equal(t, int32(1), summary.ChildWorkspaces)
+					return tt.patchErr
+				},
+				summaries: map[string]*tenancyv1beta1.WorkspaceSummary{},
+			}
+
+			logicalCluster := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "child"},
+				},
+				Spec: corev1alpha1.LogicalClusterSpec{Owner: tt.owner},
+			}
+			if tt.deleting {
+				now := metav1.Now()
+				logicalCluster.DeletionTimestamp = &now
+			}
+
+			for i := 0; i < 2; i++ {
+				err := c.reconcile(context.Background(), "child|cluster", logicalCluster)
+				require.Equal(t, tt.wantErr, err != nil, "unexpected error: %v", err)
+			}
+			require.Equal(t, tt.wantPatches, patches)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetombstone
+
+import (
+	"context"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// reconcile deletes the tombstone if it has expired. Otherwise, it returns when to check again.
+func (c *controller) reconcile(ctx context.Context, clusterName logicalcluster.Name, tombstone *tenancyv1alpha1.WorkspaceTombstone) (time.Duration, error) {
+	if now := c.now(); now.Before(tombstone.Spec.ExpiresAt.Time) {
+		return tombstone.Spec.ExpiresAt.Sub(now), nil
+	}
+
+	if tombstone.DeletionTimestamp != nil {
+		return 0, nil
+	}
+	klog.FromContext(ctx).V(2).Info("deleting expired WorkspaceTombstone", "expiresAt", tombstone.Spec.ExpiresAt)
+	if err := c.deleteWorkspaceTombstone(ctx, clusterName, tombstone.Name, tombstone.UID); err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetombstone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		expiresAt time.Time
+
+		wantDeleted      bool
+		wantRequeueAfter time.Duration
+	}{
+		"not yet expired": {
+			expiresAt:        now.Add(2 * time.Hour),
+			wantRequeueAfter: 2 * time.Hour,
+		},
+		"expired": {
+			expiresAt:   now,
+			wantDeleted: true,
+		},
+		"long expired": {
+			expiresAt:   now.Add(-24 * time.Hour),
+			wantDeleted: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted string
+			c := &controller{
+				deleteWorkspaceTombstone: func(ctx context.Context, clusterName logicalcluster.Name, name string, uid types.UID) error {
+					deleted = clusterName.String() + "|" + name + "|" + string(uid)
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			tombstone := &tenancyv1alpha1.WorkspaceTombstone{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a", UID: "uid"},
+				Spec:       tenancyv1alpha1.WorkspaceTombstoneSpec{ExpiresAt: metav1.NewTime(tt.expiresAt)},
+			}
+			requeueAfter, err := c.reconcile(context.Background(), "root:org", tombstone)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+			if tt.wantDeleted {
+				require.Equal(t, "root:org|team-a|uid", deleted)
+			} else {
+				require.Empty(t, deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetyperollout
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// reconcile advances the rollout of the WorkspaceType and returns when to check again.
+func (c *controller) reconcile(ctx context.Context, wt *tenancyv1alpha1.WorkspaceType) (time.Duration, error) {
+	status, requeueAfter := nextRolloutStatus(wt, c.now())
+	if equality.Semantic.DeepEqual(status, wt.Status.Rollout) {
+		return requeueAfter, nil
+	}
+
+	if status != nil && (wt.Status.Rollout == nil || status.Phase != wt.Status.Rollout.Phase || status.StartedWaves != wt.Status.Rollout.StartedWaves) {
+		klog.FromContext(ctx).V(2).Info("rollout of WorkspaceType progressed", "revision", status.Revision, "phase", status.Phase, "startedWaves", status.StartedWaves)
+	}
+	wt = wt.DeepCopy()
+	wt.Status.Rollout = status
+	if err := c.updateWorkspaceTypeStatus(ctx, wt); err != nil {
+		return 0, err
+	}
+	return requeueAfter, nil
+}
+
+// nextRolloutStatus returns the rollout status following the current one at the given time, and the
+// time until the next wave is due. Without a rollout status, the current revision is taken as the
+// stable one, such that configuring a rollout does not change any workspace. A new revision
+// starts over with the first wave, while workspaces not in a started wave keep the stable revision.
+func nextRolloutStatus(wt *tenancyv1alpha1.WorkspaceType, now time.Time) (*tenancyv1alpha1.WorkspaceTypeRolloutStatus, time.Duration) {
+	policy := wt.Spec.Rollout
+	if policy == nil {
+		return nil, 0
+	}
+
+	revision := wt.DefaultAPIBindingsRevision()
+	status := wt.Status.Rollout.DeepCopy()
+	switch {
+	case status == nil || revision == status.StableRevision:
+		return &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+			Revision:                 revision,
+			StableRevision:           revision,
+			StableDefaultAPIBindings: wt.Spec.DefaultAPIBindings,
+			StartedWaves:             int32(len(policy.Waves)),
+			LastTransitionTime:       lastTransitionTime(status, revision, now),
+			Phase:                    tenancyv1alpha1.WorkspaceTypeRolloutComplete,
+		}, 0
+	case status.Revision != revision:
+		status.Revision = revision
+		status.StartedWaves = 0
+	}
+
+	if policy.Paused {
+		status.Phase = tenancyv1alpha1.WorkspaceTypeRolloutPaused
+		return status, 0
+	}
+	status.Phase = tenancyv1alpha1.WorkspaceTypeRolloutProgressing
+
+	if status.StartedWaves == 0 {
+		status.StartedWaves = 1
+		status.LastTransitionTime = &metav1.Time{Time: now}
+		return status, policy.WaveInterval.Duration
+	}
+	if status.LastTransitionTime != nil {
+		if due := status.LastTransitionTime.Add(policy.WaveInterval.Duration); now.Before(due) {
+			return status, due.Sub(now)
+		}
+	}
+	status.LastTransitionTime = &metav1.Time{Time: now}
+	if int(status.StartedWaves) < len(policy.Waves) {
+		status.StartedWaves++
+		return status, policy.WaveInterval.Duration
+	}
+
+	status.StableRevision = revision
+	status.StableDefaultAPIBindings = wt.Spec.DefaultAPIBindings
+	status.StartedWaves = int32(len(policy.Waves))
+	status.Phase = tenancyv1alpha1.WorkspaceTypeRolloutComplete
+	return status, 0
+}
+
+// lastTransitionTime keeps the transition time of a rollout that is already complete.
+func lastTransitionTime(status *tenancyv1alpha1.WorkspaceTypeRolloutStatus, revision string, now time.Time) *metav1.Time {
+	if status != nil && status.Phase == tenancyv1alpha1.WorkspaceTypeRolloutComplete && status.Revision == revision && status.LastTransitionTime != nil {
+		return status.LastTransitionTime
+	}
+	return &metav1.Time{Time: now}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacetyperollout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestNextRolloutStatus(t *testing.T) {
+	now := time.Date(2023, 1, 27, 12, 0, 0, 0, time.UTC)
+	earlier := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: now.Add(-d)} }
+
+	v1 := []tenancyv1alpha1.DefaultAPIBinding{{APIExportReference: tenancyv1alpha1.APIExportReference{Path: "root:org", Export: "widgets"}, ReleaseConstraint: ">=v1.0.0 <v2.0.0", UpgradePolicy: tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic}}
+	v2 := []tenancyv1alpha1.DefaultAPIBinding{{APIExportReference: tenancyv1alpha1.APIExportReference{Path: "root:org", Export: "widgets"}, ReleaseConstraint: ">=v1.0.0 <v3.0.0", UpgradePolicy: tenancyv1alpha1.DefaultAPIBindingUpgradeAutomatic}}
+	revision := func(bindings []tenancyv1alpha1.DefaultAPIBinding) string {
+		wt := &tenancyv1alpha1.WorkspaceType{Spec: tenancyv1alpha1.WorkspaceTypeSpec{DefaultAPIBindings: bindings}}
+		return wt.DefaultAPIBindingsRevision()
+	}
+	policy := &tenancyv1alpha1.WorkspaceTypeRolloutPolicy{
+		Waves:        []tenancyv1alpha1.WorkspaceTypeRolloutWave{{Percentage: 10}, {Percentage: 50}},
+		WaveInterval: metav1.Duration{Duration: time.Hour},
+	}
+	paused := policy.DeepCopy()
+	paused.Paused = true
+
+	tests := map[string]struct {
+		policy           *tenancyv1alpha1.WorkspaceTypeRolloutPolicy
+		bindings         []tenancyv1alpha1.DefaultAPIBinding
+		status           *tenancyv1alpha1.WorkspaceTypeRolloutStatus
+		wantStatus       *tenancyv1alpha1.WorkspaceTypeRolloutStatus
+		wantRequeueAfter time.Duration
+	}{
+		"no rollout policy": {
+			bindings: v2,
+			status:   &tenancyv1alpha1.WorkspaceTypeRolloutStatus{Revision: revision(v2)},
+		},
+		"new policy takes the current revision as stable": {
+			policy:   policy,
+			bindings: v1,
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v1), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 2, LastTransitionTime: &metav1.Time{Time: now}, Phase: tenancyv1alpha1.WorkspaceTypeRolloutComplete,
+			},
+		},
+		"new revision starts the first wave": {
+			policy:   policy,
+			bindings: v2,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v1), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 2, LastTransitionTime: earlier(24 * time.Hour), Phase: tenancyv1alpha1.WorkspaceTypeRolloutComplete,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: &metav1.Time{Time: now}, Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantRequeueAfter: time.Hour,
+		},
+		"wave not yet due": {
+			policy:   policy,
+			bindings: v2,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(20 * time.Minute), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(20 * time.Minute), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantRequeueAfter: 40 * time.Minute,
+		},
+		"next wave is started": {
+			policy:   policy,
+			bindings: v2,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(time.Hour), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 2, LastTransitionTime: &metav1.Time{Time: now}, Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantRequeueAfter: time.Hour,
+		},
+		"rollout completes after the last wave": {
+			policy:   policy,
+			bindings: v2,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 2, LastTransitionTime: earlier(2 * time.Hour), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v2), StableDefaultAPIBindings: v2,
+				StartedWaves: 2, LastTransitionTime: &metav1.Time{Time: now}, Phase: tenancyv1alpha1.WorkspaceTypeRolloutComplete,
+			},
+		},
+		"paused rollout starts no wave": {
+			policy:   paused,
+			bindings: v2,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(2 * time.Hour), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(2 * time.Hour), Phase: tenancyv1alpha1.WorkspaceTypeRolloutPaused,
+			},
+		},
+		"reverted revision completes immediately": {
+			policy:   policy,
+			bindings: v1,
+			status: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v2), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 1, LastTransitionTime: earlier(20 * time.Minute), Phase: tenancyv1alpha1.WorkspaceTypeRolloutProgressing,
+			},
+			wantStatus: &tenancyv1alpha1.WorkspaceTypeRolloutStatus{
+				Revision: revision(v1), StableRevision: revision(v1), StableDefaultAPIBindings: v1,
+				StartedWaves: 2, LastTransitionTime: &metav1.Time{Time: now}, Phase: tenancyv1alpha1.WorkspaceTypeRolloutComplete,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wt := &tenancyv1alpha1.WorkspaceType{
+				Spec:   tenancyv1alpha1.WorkspaceTypeSpec{DefaultAPIBindings: tt.bindings, Rollout: tt.policy},
+				Status: tenancyv1alpha1.WorkspaceTypeStatus{Rollout: tt.status},
+			}
+			status, requeueAfter := nextRolloutStatus(wt, now)
+			require.Equal(t, tt.wantStatus, status)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceusage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/informer"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+	tenancyv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-workspaceusage"
+)
+
+// NewController returns a new controller periodically collecting the usage of the logical clusters
+// on this shard, i.e. their objects per resource, their requests counted by the handler chain and
+// the approximate size of their objects. The usage is published as metrics labeled by the
+// workspace path, and in the WorkspaceUsage of every logical cluster.
+func NewController(
+	interval time.Duration,
+	kcpClusterClient kcpclientset.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+	workspaceUsageInformer tenancyv1alpha1informers.WorkspaceUsageClusterInformer,
+	ddsif *informer.DiscoveringDynamicSharedInformerFactory,
+) (*controller, error) {
+	workspaceUsageLister := workspaceUsageInformer.Lister()
+
+	c := &controller{
+		interval:  interval,
+		now:       time.Now,
+		requests:  map[logicalcluster.Name]int64{},
+		published: map[seriesKey]bool{},
+
+		listLogicalClusters: func() ([]*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().List(labels.Everything())
+		},
+		listResources: func() map[schema.GroupVersionResource]objectLister {
+			informers, _ := ddsif.Informers()
+			listers := make(map[schema.GroupVersionResource]objectLister, len(informers))
+			for gvr, inf := range informers {
+				lister := inf.Lister()
+				listers[gvr] = func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+					return lister.ByCluster(clusterName).List(labels.Everything())
+				}
+			}
+			return listers
+		},
+		getWorkspaceUsage: func(clusterName logicalcluster.Name) (*tenancyv1alpha1.WorkspaceUsage, error) {
+			return workspaceUsageLister.Cluster(clusterName).Get(tenancyv1alpha1.WorkspaceUsageName)
+		},
+		createWorkspaceUsage: func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) (*tenancyv1alpha1.WorkspaceUsage, error) {
+			return kcpClusterClient.Cluster(clusterName.Path()).TenancyV1alpha1().WorkspaceUsages().Create(ctx, usage, metav1.CreateOptions{})
+		},
+		updateWorkspaceUsageStatus: func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) error {
+			_, err := kcpClusterClient.Cluster(clusterName.Path()).TenancyV1alpha1().WorkspaceUsages().UpdateStatus(ctx, usage, metav1.UpdateOptions{})
+			return err
+		},
+	}
+
+	return c, nil
+}
+
+// controller publishes the usage of logical clusters.
+type controller struct {
+	interval time.Duration
+	now      func() time.Time
+
+	listLogicalClusters        func() ([]*corev1alpha1.LogicalCluster, error)
+	listResources              func() map[schema.GroupVersionResource]objectLister
+	getWorkspaceUsage          func(clusterName logicalcluster.Name) (*tenancyv1alpha1.WorkspaceUsage, error)
+	createWorkspaceUsage       func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) (*tenancyv1alpha1.WorkspaceUsage, error)
+	updateWorkspaceUsageStatus func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) error
+
+	// requests are the requests per logical cluster not yet added to its WorkspaceUsage.
+	requests map[logicalcluster.Name]int64
+
+	// published are the label values of the series set in the last round, to delete the series of
+	// workspaces and resources that are gone.
+	published map[seriesKey]bool
+}
+
+// objectLister lists the objects of a resource in a logical cluster.
+type objectLister func(clusterName logicalcluster.Name) ([]runtime.Object, error)
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context) {
+	defer utilruntime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	wait.UntilWithContext(ctx, c.publish, c.interval)
+}
+
+// seriesKey identifies a series of the object gauge, or of the request counter and storage gauge
+// of a workspace if resource is empty.
+type seriesKey struct {
+	workspace string
+	group     string
+	version   string
+	resource  string
+}
+
+func (c *controller) publish(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	clusters, err := c.listLogicalClusters()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to list LogicalClusters: %w", ControllerName, err))
+		return
+	}
+
+	newRequests := takeRequests()
+	for clusterName, n := range newRequests {
+		c.requests[clusterName] += n
+	}
+
+	resources := servedResources(c.listResources())
+	series := map[seriesKey]bool{}
+	current := make(map[logicalcluster.Name]bool, len(clusters))
+	for _, cluster := range clusters {
+		clusterName := logicalcluster.From(cluster)
+		path := cluster.Annotations[core.LogicalClusterPathAnnotationKey]
+		if path == "" {
+			path = clusterName.String()
+		}
+
+		status := c.collect(ctx, clusterName, resources)
+		status.Path = path
+		for _, o := range status.Objects {
+			objects.WithLabelValues(path, o.Group, o.Version, o.Resource).Set(float64(o.Count))
+			series[seriesKey{workspace: path, group: o.Group, version: o.Version, resource: o.Resource}] = true
+		}
+		requestsTotal.WithLabelValues(path).Add(float64(newRequests[clusterName]))
+		storageBytes.WithLabelValues(path).Set(float64(status.StorageBytes))
+		series[seriesKey{workspace: path}] = true
+
+		current[clusterName] = true
+		if err := c.updateWorkspaceUsage(ctx, clusterName, status, c.requests[clusterName]); err != nil {
+			// the requests are added in the next round
+			logger.V(2).Info("failed to update WorkspaceUsage", "cluster", clusterName, "err", err)
+			continue
+		}
+		delete(c.requests, clusterName)
+	}
+
+	// forget the requests of logical clusters that are gone
+	for clusterName := range c.requests {
+		if !current[clusterName] {
+			delete(c.requests, clusterName)
+		}
+	}
+
+	for key := range c.published {
+		if series[key] {
+			continue
+		}
+		if key.resource == "" {
+			requestsTotal.Delete(map[string]string{"workspace": key.workspace})
+			storageBytes.Delete(map[string]string{"workspace": key.workspace})
+		} else {
+			objects.Delete(map[string]string{"workspace": key.workspace, "group": key.group, "version": key.version, "resource": key.resource})
+		}
+	}
+	c.published = series
+
+	logger.V(4).Info("published workspace usage", "workspaces", len(clusters), "series", len(series))
+}
+
+// collect counts the objects per resource of the logical cluster, and their approximate size.
+// Resources that cannot be counted are logged and skipped.
+func (c *controller) collect(ctx context.Context, clusterName logicalcluster.Name, resources []servedResource) tenancyv1alpha1.WorkspaceUsageStatus {
+	logger := klog.FromContext(ctx)
+
+	var status tenancyv1alpha1.WorkspaceUsageStatus
+	for _, r := range resources {
+		objs, err := r.list(clusterName)
+		if err != nil {
+			logger.V(4).Info("failed to count objects", "cluster", clusterName, "resource", r.gvr, "err", err)
+			continue
+		}
+		if len(objs) == 0 {
+			continue
+		}
+		status.Objects = append(status.Objects, tenancyv1alpha1.WorkspaceResourceUsage{
+			Group:    r.gvr.Group,
+			Version:  r.gvr.Version,
+			Resource: r.gvr.Resource,
+			Count:    int64(len(objs)),
+		})
+		for _, obj := range objs {
+			bs, err := json.Marshal(obj)
+			if err != nil {
+				continue
+			}
+			status.StorageBytes += int64(len(bs))
+		}
+	}
+	return status
+}
+
+// updateWorkspaceUsage writes the status to the WorkspaceUsage of the logical cluster, creating it
+// if it does not exist, and adds the requests to the ones reported before.
+func (c *controller) updateWorkspaceUsage(ctx context.Context, clusterName logicalcluster.Name, status tenancyv1alpha1.WorkspaceUsageStatus, newRequests int64) error {
+	usage, err := c.getWorkspaceUsage(clusterName)
+	if apierrors.IsNotFound(err) {
+		usage, err = c.createWorkspaceUsage(ctx, clusterName, &tenancyv1alpha1.WorkspaceUsage{
+			ObjectMeta: metav1.ObjectMeta{Name: tenancyv1alpha1.WorkspaceUsageName},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	status.Requests = usage.Status.Requests + newRequests
+	status.LastUpdateTime = usage.Status.LastUpdateTime
+	if equality.Semantic.DeepEqual(status, usage.Status) {
+		return nil
+	}
+	status.LastUpdateTime = &metav1.Time{Time: c.now()}
+
+	usage = usage.DeepCopy()
+	usage.Status = status
+	return c.updateWorkspaceUsageStatus(ctx, clusterName, usage)
+}
+
+// servedResource is a resource counted in the usage, with one of its versions.
+type servedResource struct {
+	gvr  schema.GroupVersionResource
+	list objectLister
+}
+
+// servedResources returns one version of every resource, sorted by group, version and resource.
+// The version is the first one in lexical order, such that it stays the same between rounds.
+func servedResources(listers map[schema.GroupVersionResource]objectLister) []servedResource {
+	gvrs := make([]schema.GroupVersionResource, 0, len(listers))
+	for gvr := range listers {
+		gvrs = append(gvrs, gvr)
+	}
+	sort.Slice(gvrs, func(i, j int) bool {
+		if gvrs[i].Group != gvrs[j].Group {
+			return gvrs[i].Group < gvrs[j].Group
+		}
+		if gvrs[i].Resource != gvrs[j].Resource {
+			return gvrs[i].Resource < gvrs[j].Resource
+		}
+		return gvrs[i].Version < gvrs[j].Version
+	})
+
+	seen := map[schema.GroupResource]bool{}
+	resources := make([]servedResource, 0, len(gvrs))
+	for _, gvr := range gvrs {
+		if seen[gvr.GroupResource()] {
+			continue
+		}
+		seen[gvr.GroupResource()] = true
+		resources = append(resources, servedResource{gvr: gvr, list: listers[gvr]})
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i].gvr, resources[j].gvr
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Resource < b.Resource
+	})
+	return resources
+}
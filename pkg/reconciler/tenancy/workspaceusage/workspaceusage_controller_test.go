@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceusage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func newObjects(n int) []runtime.Object {
+	objs := make([]runtime.Object, 0, n)
+	for i := 0; i < n; i++ {
+		objs = append(objs, &unstructured.Unstructured{Object: map[string]interface{}{"kind": "Widget"}})
+	}
+	return objs
+}
+
+func TestCollect(t *testing.T) {
+	widgetSize := int64(len(`{"kind":"Widget"}`))
+	listers := map[schema.GroupVersionResource]objectLister{
+		{Group: "example.io", Version: "v1", Resource: "widgets"}: func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+			return newObjects(3), nil
+		},
+		{Group: "example.io", Version: "v2", Resource: "widgets"}: func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+			return nil, errors.New("counted once")
+		},
+		{Group: "example.io", Version: "v1", Resource: "gadgets"}: func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+			return nil, errors.New("no informer")
+		},
+		{Group: "", Version: "v1", Resource: "configmaps"}: func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+			return newObjects(2), nil
+		},
+		{Group: "", Version: "v1", Resource: "secrets"}: func(clusterName logicalcluster.Name) ([]runtime.Object, error) {
+			return nil, nil
+		},
+	}
+
+	c := &controller{}
+	got := c.collect(context.Background(), "root", servedResources(listers))
+
+	require.Equal(t, tenancyv1alpha1.WorkspaceUsageStatus{
+		Objects: []tenancyv1alpha1.WorkspaceResourceUsage{
+			{Group: "", Version: "v1", Resource: "configmaps", Count: 2},
+			{Group: "example.io", Version: "v1", Resource: "widgets", Count: 3},
+		},
+		StorageBytes: 5 * widgetSize,
+	}, got)
+}
+
+func TestUpdateWorkspaceUsage(t *testing.T) {
+	now := time.Date(2023, 1, 26, 12, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	objects := []tenancyv1alpha1.WorkspaceResourceUsage{{Version: "v1", Resource: "configmaps", Count: 2}}
+
+	tests := map[string]struct {
+		existing    *tenancyv1alpha1.WorkspaceUsage
+		newRequests int64
+		wantCreate  bool
+		wantStatus  *tenancyv1alpha1.WorkspaceUsageStatus
+	}{
+		"missing usage is created": {
+			newRequests: 4,
+			wantCreate:  true,
+			wantStatus: &tenancyv1alpha1.WorkspaceUsageStatus{
+				Path:           "root:org",
+				Objects:        objects,
+				Requests:       4,
+				StorageBytes:   100,
+				LastUpdateTime: &metav1.Time{Time: now},
+			},
+		},
+		"requests are added": {
+			existing: &tenancyv1alpha1.WorkspaceUsage{
+				ObjectMeta: metav1.ObjectMeta{Name: tenancyv1alpha1.WorkspaceUsageName},
+				Status:     tenancyv1alpha1.WorkspaceUsageStatus{Path: "root:org", Objects: objects, Requests: 10, StorageBytes: 100, LastUpdateTime: &earlier},
+			},
+			newRequests: 5,
+			wantStatus: &tenancyv1alpha1.WorkspaceUsageStatus{
+				Path:           "root:org",
+				Objects:        objects,
+				Requests:       15,
+				StorageBytes:   100,
+				LastUpdateTime: &metav1.Time{Time: now},
+			},
+		},
+		"unchanged usage is not updated": {
+			existing: &tenancyv1alpha1.WorkspaceUsage{
+				ObjectMeta: metav1.ObjectMeta{Name: tenancyv1alpha1.WorkspaceUsageName},
+				Status:     tenancyv1alpha1.WorkspaceUsageStatus{Path: "root:org", Objects: objects, Requests: 10, StorageBytes: 100, LastUpdateTime: &earlier},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created bool
+			var updated *tenancyv1alpha1.WorkspaceUsageStatus
+			c := &controller{
+				now: func() time.Time { return now },
+				getWorkspaceUsage: func(clusterName logicalcluster.Name) (*tenancyv1alpha1.WorkspaceUsage, error) {
+					if tt.existing == nil {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspaceusages"), tenancyv1alpha1.WorkspaceUsageName)
+					}
+					return tt.existing, nil
+				},
+				createWorkspaceUsage: func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) (*tenancyv1alpha1.WorkspaceUsage, error) {
+					created = true
+					return usage, nil
+				},
+				updateWorkspaceUsageStatus: func(ctx context.Context, clusterName logicalcluster.Name, usage *tenancyv1alpha1.WorkspaceUsage) error {
+					updated = &usage.Status
+					return nil
+				},
+			}
+
+			status := tenancyv1alpha1.WorkspaceUsageStatus{Path: "root:org", Objects: objects, StorageBytes: 100}
+			err := c.updateWorkspaceUsage(context.Background(), "root", status, tt.newRequests)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantStatus, updated)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceusage
+
+import (
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	objects = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "workspace_objects",
+			Help:           "Number of objects of a resource in a workspace stored on this shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"workspace", "group", "version", "resource"},
+	)
+
+	requestsTotal = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "workspace_requests_total",
+			Help:           "Number of requests to a workspace served by this shard.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"workspace"},
+	)
+
+	storageBytes = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Name:           "workspace_storage_bytes",
+			Help:           "Approximate size of the objects of a workspace stored on this shard, as the size of their JSON encoding.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"workspace"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the workspace usage metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(objects)
+		legacyregistry.MustRegister(requestsTotal)
+		legacyregistry.MustRegister(storageBytes)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+var (
+	pendingRequestsLock sync.Mutex
+	pendingRequests     = map[logicalcluster.Name]int64{}
+)
+
+// RecordRequest counts a request to the given logical cluster. The requests are published with the
+// path of the workspace by the controller, which knows the paths.
+func RecordRequest(clusterName logicalcluster.Name) {
+	pendingRequestsLock.Lock()
+	defer pendingRequestsLock.Unlock()
+	pendingRequests[clusterName]++
+}
+
+// takeRequests returns the requests recorded since the last call.
+func takeRequests() map[logicalcluster.Name]int64 {
+	pendingRequestsLock.Lock()
+	defer pendingRequestsLock.Unlock()
+	taken := pendingRequests
+	pendingRequests = map[logicalcluster.Name]int64{}
+	return taken
+}
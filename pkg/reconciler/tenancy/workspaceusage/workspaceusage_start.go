@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceusage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func DefaultOptions() *Options {
+	return &Options{
+		Interval: time.Minute,
+	}
+}
+
+func BindOptions(o *Options, fs *pflag.FlagSet) *Options {
+	fs.BoolVar(&o.Enabled, "workspace-usage-metrics", o.Enabled, "Publish the usage of every logical cluster on this shard, i.e. objects per resource, requests and approximate storage, as metrics labeled by the workspace path and in the WorkspaceUsage object named \"cluster\" in the workspace. Labels have the cardinality of the workspaces and their resources")
+	fs.DurationVar(&o.Interval, "workspace-usage-metrics-interval", o.Interval, "Interval of collecting the usage of the logical clusters for --workspace-usage-metrics")
+	return o
+}
+
+type Options struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+func (o *Options) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("--workspace-usage-metrics-interval must be >0 (%v)", o.Interval)
+	}
+	return nil
+}
@@ -43,15 +43,16 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
-	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
-	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
-	schedulingv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/scheduling/v1alpha1"
-	workloadv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/workload/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
 	"github.com/kcp-dev/kcp/pkg/syncer/shared"
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1"
+	schedulingv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/scheduling/v1alpha1"
+	workloadv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/workload/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 const (
@@ -446,12 +447,12 @@ func (c *Controller) enqueueSyncTargetKey(syncTargetKey string) {
 			runtime.HandleError(err)
 			continue
 		}
-		stateLabelObjs, err := inf.Informer().GetIndexer().ByIndex(indexers.ByClusterResourceStateLabelKey, workloadv1alpha1.ClusterResourceStateLabelPrefix+syncTargetKey)
+		stateLabelObjs, err := inf.Informer().GetIndexer().ByIndex(kcpindexers.ByClusterResourceStateLabelKey, workloadv1alpha1.ClusterResourceStateLabelPrefix+syncTargetKey)
 		if err != nil {
 			runtime.HandleError(err)
 			continue
 		}
-		syncerFinalizerObjs, err := inf.Informer().GetIndexer().ByIndex(indexers.BySyncerFinalizerKey, shared.SyncerFinalizerNamePrefix+syncTargetKey)
+		syncerFinalizerObjs, err := inf.Informer().GetIndexer().ByIndex(kcpindexers.BySyncerFinalizerKey, shared.SyncerFinalizerNamePrefix+syncTargetKey)
 		if err != nil {
 			runtime.HandleError(err)
 			continue
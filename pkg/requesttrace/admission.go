@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requesttrace
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apiserver/pkg/admission"
+)
+
+// WithAdmissionTracing is an admission.DecoratorFunc recording the result of the given plugin
+// in the trace of traced requests.
+func WithAdmissionTracing(handler admission.Interface, name string) admission.Interface {
+	return &pluginHandlerWithTracing{Interface: handler, name: name}
+}
+
+type pluginHandlerWithTracing struct {
+	admission.Interface
+	name string
+}
+
+var (
+	_ = admission.MutationInterface(&pluginHandlerWithTracing{})
+	_ = admission.ValidationInterface(&pluginHandlerWithTracing{})
+)
+
+func (p *pluginHandlerWithTracing) Admit(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	mutatingHandler, ok := p.Interface.(admission.MutationInterface)
+	if !ok {
+		return nil
+	}
+
+	start := time.Now()
+	err := mutatingHandler.Admit(ctx, a, o)
+	From(ctx).AddAdmissionStep(p.name, "mutating", time.Since(start), err)
+	return err
+}
+
+func (p *pluginHandlerWithTracing) Validate(ctx context.Context, a admission.Attributes, o admission.ObjectInterfaces) error {
+	validatingHandler, ok := p.Interface.(admission.ValidationInterface)
+	if !ok {
+		return nil
+	}
+
+	start := time.Now()
+	err := validatingHandler.Validate(ctx, a, o)
+	From(ctx).AddAdmissionStep(p.name, "validating", time.Since(start), err)
+	return err
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requesttrace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type validatingPlugin struct {
+	*admission.Handler
+	err error
+}
+
+func (p *validatingPlugin) Validate(_ context.Context, _ admission.Attributes, _ admission.ObjectInterfaces) error {
+	return p.err
+}
+
+func TestWithAdmissionTracing(t *testing.T) {
+	plugin := WithAdmissionTracing(&validatingPlugin{Handler: admission.NewHandler(admission.Create), err: errors.New("denied")}, "tenancy.kcp.io/Example")
+
+	// untraced requests are not recorded
+	err := plugin.(admission.ValidationInterface).Validate(context.Background(), nil, nil)
+	require.EqualError(t, err, "denied")
+
+	trace := New(Request{})
+	ctx := WithTrace(context.Background(), trace)
+	require.NoError(t, plugin.(admission.MutationInterface).Admit(ctx, nil, nil), "non-mutating plugins must not mutate")
+	err = plugin.(admission.ValidationInterface).Validate(ctx, nil, nil)
+	require.EqualError(t, err, "denied")
+
+	require.Len(t, trace.Admission, 1)
+	require.Equal(t, "tenancy.kcp.io/Example", trace.Admission[0].Plugin)
+	require.Equal(t, "validating", trace.Admission[0].Phase)
+	require.Equal(t, "denied", trace.Admission[0].Error)
+	require.True(t, plugin.Handles(admission.Create))
+	require.False(t, plugin.Handles(admission.Delete))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requesttrace records the path of a single request through the filters, authorizers,
+// admission plugins and storage of a shard, for diagnosing why a request of a tenant fails.
+package requesttrace
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	// TraceHeader turns on tracing of the request when set to "true". Only privileged system users
+	// may trace requests.
+	TraceHeader = "X-Kcp-Debug-Trace"
+	// UserHeader is the user the traced request is executed as. If it is not set, the request is
+	// executed as the requesting user.
+	UserHeader = "X-Kcp-Debug-Trace-User"
+	// GroupHeader is a group of the user the traced request is executed as. It can be repeated.
+	GroupHeader = "X-Kcp-Debug-Trace-Group"
+)
+
+// Trace is the structured trace document returned instead of the response of a traced request.
+type Trace struct {
+	Request       Request              `json:"request"`
+	Filters       []FilterStep         `json:"filters,omitempty"`
+	Authorization []AuthorizerDecision `json:"authorization,omitempty"`
+	Admission     []AdmissionStep      `json:"admission,omitempty"`
+	Storage       *StorageTarget       `json:"storage,omitempty"`
+	Response      Response             `json:"response"`
+
+	lock  sync.Mutex
+	start time.Time
+}
+
+// Request is the traced request.
+type Request struct {
+	Method   string   `json:"method"`
+	Path     string   `json:"path"`
+	User     string   `json:"user"`
+	Groups   []string `json:"groups,omitempty"`
+	TracedBy string   `json:"tracedBy"`
+}
+
+// FilterStep records that the request passed a point in the filter chain.
+type FilterStep struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+	At      string `json:"at"`
+}
+
+// AuthorizerDecision is the decision of one layer of the authorizer chain, with the reason as
+// given by the authorizer, i.e. before anonymization.
+type AuthorizerDecision struct {
+	Authorizer string `json:"authorizer"`
+	Decision   string `json:"decision"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// AdmissionStep is the result of an admission plugin handling the request.
+type AdmissionStep struct {
+	Plugin   string `json:"plugin"`
+	Phase    string `json:"phase"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// StorageTarget is where the request was served.
+type StorageTarget struct {
+	Shard       string `json:"shard"`
+	Cluster     string `json:"cluster,omitempty"`
+	Verb        string `json:"verb,omitempty"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// Response is the response the traced request would have got.
+type Response struct {
+	Code int             `json:"code"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// New returns a new trace of the given request.
+func New(request Request) *Trace {
+	return &Trace{Request: request, start: time.Now()}
+}
+
+type traceKeyType int
+
+const traceKey traceKeyType = iota
+
+// WithTrace returns a context the given trace is recorded in.
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey, trace)
+}
+
+// From returns the trace of the request, or nil if the request is not traced. All recording
+// methods are no-ops on a nil trace.
+func From(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey).(*Trace)
+	return trace
+}
+
+// AddFilterStep records that the request passed the named point in the filter chain.
+func (t *Trace) AddFilterStep(name, message string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Filters = append(t.Filters, FilterStep{Name: name, Message: message, At: time.Since(t.start).String()})
+}
+
+// AddAuthorizerDecision records the decision of an authorizer.
+func (t *Trace) AddAuthorizerDecision(authorizer, decision, reason string) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Authorization = append(t.Authorization, AuthorizerDecision{Authorizer: authorizer, Decision: decision, Reason: reason})
+}
+
+// AddAdmissionStep records the result of an admission plugin.
+func (t *Trace) AddAdmissionStep(plugin, phase string, duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	step := AdmissionStep{Plugin: plugin, Phase: phase, Duration: duration.String()}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Admission = append(t.Admission, step)
+}
+
+// SetStorageTarget records where the request is served.
+func (t *Trace) SetStorageTarget(target StorageTarget) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Storage = &target
+}
+
+// SetResponse records the response of the request. Bodies which are not JSON are recorded as a
+// JSON string.
+func (t *Trace) SetResponse(code int, body []byte) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.Response.Code = code
+	if len(body) == 0 {
+		return
+	}
+	if json.Valid(body) {
+		t.Response.Body = body
+		return
+	}
+	t.Response.Body, _ = json.Marshal(string(body))
+}
+
+// MarshalJSON marshals the trace while holding its lock.
+func (t *Trace) MarshalJSON() ([]byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	type trace Trace
+	return json.Marshal((*trace)(t))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routingtarget validates the logical clusters traffic for a workspace is routed to.
+// Everything that decides where requests for a workspace path end up (workspace admission,
+// scheduling and the path index of the proxies) must use it, such that tenants cannot
+// point traffic at system logical clusters or at logical clusters of other workspaces.
+package routingtarget
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+var (
+	// ErrSystemCluster is returned for targets that are system logical clusters.
+	ErrSystemCluster = errors.New("system logical clusters cannot be targeted")
+	// ErrForeignCluster is returned for targets that belong to another workspace.
+	ErrForeignCluster = errors.New("logical clusters of other workspaces cannot be targeted")
+)
+
+var systemPath = logicalcluster.NewPath("system")
+
+// IsSystemCluster returns true if the given logical cluster is a system logical cluster, i.e.
+// one with the system: prefix.
+func IsSystemCluster(cluster logicalcluster.Name) bool {
+	return cluster.Path().HasPrefix(systemPath)
+}
+
+// ValidateCluster returns an error if traffic for the workspace with the given name in the
+// source logical cluster must not be routed to the target logical cluster.
+func ValidateCluster(source logicalcluster.Name, name string, target logicalcluster.Name) error {
+	switch {
+	case target.Empty():
+		return fmt.Errorf("workspace %s|%s cannot target an empty logical cluster", source, name)
+	case !target.Path().IsValid():
+		return fmt.Errorf("workspace %s|%s cannot target invalid logical cluster %q", source, name, target)
+	case IsSystemCluster(target):
+		return fmt.Errorf("workspace %s|%s cannot target %s: %w", source, name, target, ErrSystemCluster)
+	case target == core.RootCluster:
+		return fmt.Errorf("workspace %s|%s cannot target the root logical cluster", source, name)
+	case target == source:
+		return fmt.Errorf("workspace %s|%s cannot target the logical cluster it lives in", source, name)
+	}
+	return nil
+}
+
+// ValidateOwner returns an error if the given owner of a target logical cluster is not the
+// workspace with the given name in the source logical cluster. Logical clusters without owner
+// are created by the system, and are accepted.
+func ValidateOwner(owner *corev1alpha1.LogicalClusterOwner, source logicalcluster.Name, name string) error {
+	if owner == nil {
+		return nil
+	}
+	if owner.Resource != "workspaces" || owner.Cluster != source.String() || owner.Name != name {
+		return fmt.Errorf("workspace %s|%s cannot target a logical cluster owned by %s %s|%s: %w", source, name, owner.Resource, owner.Cluster, owner.Name, ErrForeignCluster)
+	}
+	return nil
+}
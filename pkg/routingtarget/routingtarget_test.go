@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingtarget
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestValidateCluster(t *testing.T) {
+	tests := map[string]struct {
+		target  logicalcluster.Name
+		wantErr error
+		valid   bool
+	}{
+		"logical cluster":          {target: "2x8l3v1b9rgnh8ve", valid: true},
+		"empty":                    {target: ""},
+		"invalid":                  {target: "Not/Valid"},
+		"system logical cluster":   {target: "system:admin", wantErr: ErrSystemCluster},
+		"system shard":             {target: "system:shard", wantErr: ErrSystemCluster},
+		"root":                     {target: "root"},
+		"own logical cluster":      {target: "parent"},
+		"system-like but no colon": {target: "systemic", valid: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCluster("parent", "ws", tt.target)
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateOwner(t *testing.T) {
+	tests := map[string]struct {
+		owner *corev1alpha1.LogicalClusterOwner
+		valid bool
+	}{
+		"no owner": {valid: true},
+		"owning workspace": {
+			owner: &corev1alpha1.LogicalClusterOwner{Resource: "workspaces", Cluster: "parent", Name: "ws"},
+			valid: true,
+		},
+		"workspace with other name": {
+			owner: &corev1alpha1.LogicalClusterOwner{Resource: "workspaces", Cluster: "parent", Name: "other"},
+		},
+		"workspace in other logical cluster": {
+			owner: &corev1alpha1.LogicalClusterOwner{Resource: "workspaces", Cluster: "other", Name: "ws"},
+		},
+		"other resource": {
+			owner: &corev1alpha1.LogicalClusterOwner{Resource: "clusterworkspaces", Cluster: "parent", Name: "ws"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateOwner(tt.owner, "parent", "ws")
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, ErrForeignCluster), "expected %v, got %v", ErrForeignCluster, err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"fmt"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// APIResourceSchemaIncompatibilities compares an APIResourceSchema with the one it evolves from. It returns
+// the changes that could break existing clients or objects: a different group, resource, kind or scope,
+// versions that are not served anymore, removed fields, fields changing their type, and fields that become
+// required without a default. An empty result means that the new schema is backward compatible.
+func APIResourceSchemaIncompatibilities(old, new *apisv1alpha1.APIResourceSchema) ([]string, error) {
+	var incompatibilities []string
+
+	if old.Spec.Group != new.Spec.Group {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("group changed from %q to %q", old.Spec.Group, new.Spec.Group))
+	}
+	if old.Spec.Names.Plural != new.Spec.Names.Plural {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("resource changed from %q to %q", old.Spec.Names.Plural, new.Spec.Names.Plural))
+	}
+	if old.Spec.Names.Kind != new.Spec.Names.Kind {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("kind changed from %q to %q", old.Spec.Names.Kind, new.Spec.Names.Kind))
+	}
+	if old.Spec.Scope != new.Spec.Scope {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("scope changed from %s to %s", old.Spec.Scope, new.Spec.Scope))
+	}
+
+	newVersions := make(map[string]*apisv1alpha1.APIResourceVersion, len(new.Spec.Versions))
+	for i := range new.Spec.Versions {
+		newVersions[new.Spec.Versions[i].Name] = &new.Spec.Versions[i]
+	}
+
+	for i := range old.Spec.Versions {
+		oldVersion := &old.Spec.Versions[i]
+		if !oldVersion.Served {
+			continue
+		}
+		newVersion, found := newVersions[oldVersion.Name]
+		if !found || !newVersion.Served {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s is not served anymore", oldVersion.Name))
+			continue
+		}
+
+		oldSchema, err := oldVersion.GetSchema()
+		if err != nil {
+			return nil, err
+		}
+		newSchema, err := newVersion.GetSchema()
+		if err != nil {
+			return nil, err
+		}
+		if oldSchema == nil || newSchema == nil {
+			continue
+		}
+
+		for _, change := range IncompatibleFieldChanges("", oldSchema, newSchema) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s: %s", oldVersion.Name, change))
+		}
+		for _, change := range NewlyRequiredFields("", oldSchema, newSchema) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s: %s", oldVersion.Name, change))
+		}
+	}
+
+	return incompatibilities, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestAPIResourceSchemaIncompatibilities(t *testing.T) {
+	const widgetSchema = `{
+		"type": "object",
+		"properties": {
+			"spec": {
+				"type": "object",
+				"required": ["size"],
+				"properties": {
+					"size": {"type": "integer"},
+					"color": {"type": "string"}
+				}
+			}
+		}
+	}`
+
+	newSchema := func(scope apiextensionsv1.ResourceScope, versions ...apisv1alpha1.APIResourceVersion) *apisv1alpha1.APIResourceSchema {
+		return &apisv1alpha1.APIResourceSchema{
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group:    "example.io",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+				Scope:    scope,
+				Versions: versions,
+			},
+		}
+	}
+	version := func(name string, served bool, openAPISchema string) apisv1alpha1.APIResourceVersion {
+		return apisv1alpha1.APIResourceVersion{
+			Name:   name,
+			Served: served,
+			Schema: runtime.RawExtension{Raw: []byte(openAPISchema)},
+		}
+	}
+
+	tests := map[string]struct {
+		new  *apisv1alpha1.APIResourceSchema
+		want []string
+	}{
+		"identical": {
+			new: newSchema(apiextensionsv1.NamespaceScoped, version("v1", true, widgetSchema)),
+		},
+		"added optional field, required field with default and version": {
+			new: newSchema(apiextensionsv1.NamespaceScoped,
+				version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","required":["size","shape"],"properties":{"size":{"type":"integer"},"color":{"type":"string"},"shape":{"type":"string","default":"round"},"weight":{"type":"integer"}}}}}`),
+				version("v2", true, widgetSchema),
+			),
+		},
+		"removed required field": {
+			new:  newSchema(apiextensionsv1.NamespaceScoped, version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","properties":{"color":{"type":"string"}}}}}`)),
+			want: []string{"v1: field spec.size is removed"},
+		},
+		"changed field type": {
+			new:  newSchema(apiextensionsv1.NamespaceScoped, version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","required":["size"],"properties":{"size":{"type":"string"},"color":{"type":"string"}}}}}`)),
+			want: []string{"v1: field spec.size changed type from integer to string"},
+		},
+		"newly required field": {
+			new:  newSchema(apiextensionsv1.NamespaceScoped, version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","required":["size","color"],"properties":{"size":{"type":"integer"},"color":{"type":"string"}}}}}`)),
+			want: []string{"v1: field spec.color is newly required"},
+		},
+		"version not served anymore": {
+			new:  newSchema(apiextensionsv1.NamespaceScoped, version("v1", false, widgetSchema)),
+			want: []string{"version v1 is not served anymore"},
+		},
+		"changed scope": {
+			new:  newSchema(apiextensionsv1.ClusterScoped, version("v1", true, widgetSchema)),
+			want: []string{"scope changed from Namespaced to Cluster"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := newSchema(apiextensionsv1.NamespaceScoped, version("v1", true, widgetSchema))
+			got, err := APIResourceSchemaIncompatibilities(old, tc.new)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// ChangeType is the kind of a Change.
+type ChangeType string
+
+const (
+	ChangeTypeAdded    ChangeType = "Added"
+	ChangeTypeRemoved  ChangeType = "Removed"
+	ChangeTypeModified ChangeType = "Modified"
+)
+
+// Diff is the difference between two APIResourceSchemas, or between two versions of one.
+type Diff struct {
+	// From is the APIResourceSchema, or the version, that is compared.
+	From string `json:"from"`
+	// To is the APIResourceSchema, or the version, From is compared to.
+	To string `json:"to"`
+	// Changes are the differences, in the order of the compared schemas.
+	Changes []Change `json:"changes"`
+}
+
+// Change is a single difference between two APIResourceSchemas or two versions.
+type Change struct {
+	// Path is the changed element, e.g. spec.scope, spec.versions[v1].served or
+	// spec.versions[v1].schema.properties[spec].properties[replicas].type.
+	Path string `json:"path"`
+	// Type tells whether the element was added, removed or modified.
+	Type ChangeType `json:"type"`
+	// Old is the value before the change, if any.
+	Old string `json:"old,omitempty"`
+	// New is the value after the change, if any.
+	New string `json:"new,omitempty"`
+	// Breaking is true if the change can break existing clients or objects, in the
+	// sense of APIResourceSchemaIncompatibilities.
+	Breaking bool `json:"breaking"`
+}
+
+// DiffAPIResourceSchemas returns the changes from the old to the new APIResourceSchema.
+func DiffAPIResourceSchemas(old, new *apisv1alpha1.APIResourceSchema) ([]Change, error) {
+	var changes []Change
+
+	changes = append(changes, diffValue("spec.group", old.Spec.Group, new.Spec.Group, true)...)
+	changes = append(changes, diffValue("spec.names.plural", old.Spec.Names.Plural, new.Spec.Names.Plural, true)...)
+	changes = append(changes, diffValue("spec.names.kind", old.Spec.Names.Kind, new.Spec.Names.Kind, true)...)
+	changes = append(changes, diffValue("spec.scope", string(old.Spec.Scope), string(new.Spec.Scope), true)...)
+
+	oldVersions := make(map[string]*apisv1alpha1.APIResourceVersion, len(old.Spec.Versions))
+	for i := range old.Spec.Versions {
+		oldVersions[old.Spec.Versions[i].Name] = &old.Spec.Versions[i]
+	}
+	newVersions := make(map[string]*apisv1alpha1.APIResourceVersion, len(new.Spec.Versions))
+	for i := range new.Spec.Versions {
+		newVersions[new.Spec.Versions[i].Name] = &new.Spec.Versions[i]
+	}
+
+	for _, name := range sets.StringKeySet(oldVersions).Union(sets.StringKeySet(newVersions)).List() {
+		path := fmt.Sprintf("spec.versions[%s]", name)
+		oldVersion, inOld := oldVersions[name]
+		newVersion, inNew := newVersions[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Path: path, Type: ChangeTypeAdded})
+		case !inNew:
+			changes = append(changes, Change{Path: path, Type: ChangeTypeRemoved, Breaking: oldVersion.Served})
+		default:
+			changes = append(changes, diffValue(path+".served", strconv.FormatBool(oldVersion.Served), strconv.FormatBool(newVersion.Served), oldVersion.Served)...)
+			changes = append(changes, diffValue(path+".storage", strconv.FormatBool(oldVersion.Storage), strconv.FormatBool(newVersion.Storage), false)...)
+			changes = append(changes, diffValue(path+".deprecated", strconv.FormatBool(oldVersion.Deprecated), strconv.FormatBool(newVersion.Deprecated), false)...)
+			versionChanges, err := DiffAPIResourceVersions(oldVersion, newVersion)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range versionChanges {
+				change.Path = path + "." + change.Path
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// DiffAPIResourceVersions returns the changes of the schema and the subresources from the old to the
+// new version, e.g. two versions of one APIResourceSchema. Paths are relative to the version.
+func DiffAPIResourceVersions(old, new *apisv1alpha1.APIResourceVersion) ([]Change, error) {
+	oldSchema, err := old.GetSchema()
+	if err != nil {
+		return nil, err
+	}
+	newSchema, err := new.GetSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	if oldSchema != nil && newSchema != nil {
+		changes = append(changes, diffFields("schema", oldSchema, newSchema)...)
+	}
+
+	changes = append(changes, diffPresence("subresources.status", old.Subresources.Status != nil, new.Subresources.Status != nil)...)
+	changes = append(changes, diffPresence("subresources.scale", old.Subresources.Scale != nil, new.Subresources.Scale != nil)...)
+
+	oldCustom := sets.NewString()
+	for _, sub := range old.CustomSubresources {
+		oldCustom.Insert(sub.Name)
+	}
+	newCustom := sets.NewString()
+	for _, sub := range new.CustomSubresources {
+		newCustom.Insert(sub.Name)
+	}
+	for _, name := range oldCustom.Union(newCustom).List() {
+		changes = append(changes, diffPresence(fmt.Sprintf("customSubresources[%s]", name), oldCustom.Has(name), newCustom.Has(name))...)
+	}
+
+	return changes, nil
+}
+
+// diffFields recursively compares two OpenAPI schemas. Removed fields and type changes are breaking like in
+// IncompatibleFieldChanges, newly required fields without default like in NewlyRequiredFields.
+func diffFields(path string, oldSchema, newSchema *apiextensionsv1.JSONSchemaProps) []Change {
+	var changes []Change
+
+	changes = append(changes, diffValue(path+".type", oldSchema.Type, newSchema.Type, oldSchema.Type != "" && newSchema.Type != "")...)
+	changes = append(changes, diffValue(path+".format", oldSchema.Format, newSchema.Format, false)...)
+	changes = append(changes, diffValue(path+".default", jsonString(oldSchema.Default), jsonString(newSchema.Default), false)...)
+
+	oldRequired := sets.NewString(oldSchema.Required...)
+	newRequired := sets.NewString(newSchema.Required...)
+	for _, name := range newRequired.Difference(oldRequired).List() {
+		prop, found := newSchema.Properties[name]
+		changes = append(changes, Change{Path: fmt.Sprintf("%s.required[%s]", path, name), Type: ChangeTypeAdded, Breaking: !found || prop.Default == nil})
+	}
+	for _, name := range oldRequired.Difference(newRequired).List() {
+		changes = append(changes, Change{Path: fmt.Sprintf("%s.required[%s]", path, name), Type: ChangeTypeRemoved})
+	}
+
+	names := make([]string, 0, len(oldSchema.Properties)+len(newSchema.Properties))
+	for name := range oldSchema.Properties {
+		names = append(names, name)
+	}
+	for name := range newSchema.Properties {
+		if _, found := oldSchema.Properties[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		childPath := fmt.Sprintf("%s.properties[%s]", path, name)
+		oldProp, inOld := oldSchema.Properties[name]
+		newProp, inNew := newSchema.Properties[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Path: childPath, Type: ChangeTypeAdded, New: newProp.Type})
+		case !inNew:
+			// unknown fields are kept in storage, hence nothing is lost
+			preserved := newSchema.XPreserveUnknownFields != nil && *newSchema.XPreserveUnknownFields
+			changes = append(changes, Change{Path: childPath, Type: ChangeTypeRemoved, Old: oldProp.Type, Breaking: !preserved})
+		default:
+			changes = append(changes, diffFields(childPath, &oldProp, &newProp)...)
+		}
+	}
+
+	if oldSchema.Items != nil && oldSchema.Items.Schema != nil && newSchema.Items != nil && newSchema.Items.Schema != nil {
+		changes = append(changes, diffFields(path+".items", oldSchema.Items.Schema, newSchema.Items.Schema)...)
+	}
+
+	if oldSchema.AdditionalProperties != nil && oldSchema.AdditionalProperties.Schema != nil && newSchema.AdditionalProperties != nil && newSchema.AdditionalProperties.Schema != nil {
+		changes = append(changes, diffFields(path+".additionalProperties", oldSchema.AdditionalProperties.Schema, newSchema.AdditionalProperties.Schema)...)
+	}
+
+	return changes
+}
+
+// diffValue compares a single value. Empty values are considered absent.
+func diffValue(path, old, new string, breaking bool) []Change {
+	switch {
+	case old == new:
+		return nil
+	case old == "":
+		return []Change{{Path: path, Type: ChangeTypeAdded, New: new}}
+	case new == "":
+		return []Change{{Path: path, Type: ChangeTypeRemoved, Old: old, Breaking: breaking}}
+	default:
+		return []Change{{Path: path, Type: ChangeTypeModified, Old: old, New: new, Breaking: breaking}}
+	}
+}
+
+// diffPresence compares the presence of an element. Removing it is breaking.
+func diffPresence(path string, old, new bool) []Change {
+	switch {
+	case !old && new:
+		return []Change{{Path: path, Type: ChangeTypeAdded}}
+	case old && !new:
+		return []Change{{Path: path, Type: ChangeTypeRemoved, Breaking: true}}
+	default:
+		return nil
+	}
+}
+
+func jsonString(v *apiextensionsv1.JSON) string {
+	if v == nil {
+		return ""
+	}
+	return string(v.Raw)
+}
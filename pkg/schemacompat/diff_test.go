@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestDiffAPIResourceSchemas(t *testing.T) {
+	version := func(name string, served bool, openAPISchema string) apisv1alpha1.APIResourceVersion {
+		return apisv1alpha1.APIResourceVersion{
+			Name:   name,
+			Served: served,
+			Schema: runtime.RawExtension{Raw: []byte(openAPISchema)},
+		}
+	}
+	newSchema := func(scope apiextensionsv1.ResourceScope, versions ...apisv1alpha1.APIResourceVersion) *apisv1alpha1.APIResourceSchema {
+		return &apisv1alpha1.APIResourceSchema{
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group:    "example.io",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+				Scope:    scope,
+				Versions: versions,
+			},
+		}
+	}
+
+	old := newSchema(apiextensionsv1.NamespaceScoped,
+		version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","properties":{"size":{"type":"integer"},"color":{"type":"string"}}}}}`),
+		version("v1beta1", true, `{"type":"object"}`),
+	)
+	new := newSchema(apiextensionsv1.ClusterScoped,
+		version("v1", true, `{"type":"object","properties":{"spec":{"type":"object","required":["shape"],"properties":{"size":{"type":"string"},"shape":{"type":"string","default":"round"}}}}}`),
+		version("v2", true, `{"type":"object"}`),
+	)
+	new.Spec.Versions[0].CustomSubresources = []apisv1alpha1.CustomSubresource{{Name: "approve"}}
+
+	changes, err := DiffAPIResourceSchemas(old, new)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: "spec.scope", Type: ChangeTypeModified, Old: "Namespaced", New: "Cluster", Breaking: true},
+		{Path: "spec.versions[v1].schema.properties[spec].required[shape]", Type: ChangeTypeAdded},
+		{Path: "spec.versions[v1].schema.properties[spec].properties[color]", Type: ChangeTypeRemoved, Old: "string", Breaking: true},
+		{Path: "spec.versions[v1].schema.properties[spec].properties[shape]", Type: ChangeTypeAdded, New: "string"},
+		{Path: "spec.versions[v1].schema.properties[spec].properties[size].type", Type: ChangeTypeModified, Old: "integer", New: "string", Breaking: true},
+		{Path: "spec.versions[v1].customSubresources[approve]", Type: ChangeTypeAdded},
+		{Path: "spec.versions[v1beta1]", Type: ChangeTypeRemoved, Breaking: true},
+		{Path: "spec.versions[v2]", Type: ChangeTypeAdded},
+	}, changes)
+
+	changes, err = DiffAPIResourceSchemas(old, old)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestDiffAPIResourceVersions(t *testing.T) {
+	v1 := &apisv1alpha1.APIResourceVersion{
+		Name:   "v1",
+		Schema: runtime.RawExtension{Raw: []byte(`{"type":"object","required":["spec"],"properties":{"spec":{"type":"object"}}}`)},
+	}
+	v2 := &apisv1alpha1.APIResourceVersion{
+		Name:   "v2",
+		Schema: runtime.RawExtension{Raw: []byte(`{"type":"object","required":["spec"],"properties":{"spec":{"type":"object","default":{}},"status":{"type":"object"}}}`)},
+		Subresources: apiextensionsv1.CustomResourceSubresources{
+			Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+		},
+	}
+
+	changes, err := DiffAPIResourceVersions(v1, v2)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: "schema.properties[spec].default", Type: ChangeTypeAdded, New: "{}"},
+		{Path: "schema.properties[status]", Type: ChangeTypeAdded, New: "object"},
+		{Path: "subresources.status", Type: ChangeTypeAdded},
+	}, changes)
+
+	changes, err = DiffAPIResourceVersions(v2, v1)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Path: "schema.properties[spec].default", Type: ChangeTypeRemoved, Old: "{}"},
+		{Path: "schema.properties[status]", Type: ChangeTypeRemoved, Old: "object", Breaking: true},
+		{Path: "subresources.status", Type: ChangeTypeRemoved, Breaking: true},
+	}, changes)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"fmt"
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// IncompatibleFieldChanges recursively compares two OpenAPI schemas and returns the fields of the old
+// schema that are removed or change their type in the new schema.
+func IncompatibleFieldChanges(path string, oldSchema, newSchema *apiextensionsv1.JSONSchemaProps) []string {
+	if oldSchema.Type != "" && newSchema.Type != "" && oldSchema.Type != newSchema.Type {
+		return []string{fmt.Sprintf("field %s changed type from %s to %s", fieldPath(path), oldSchema.Type, newSchema.Type)}
+	}
+
+	var changes []string
+
+	names := make([]string, 0, len(oldSchema.Properties))
+	for name := range oldSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldProp := oldSchema.Properties[name]
+		childPath := name
+		if path != "" {
+			childPath = path + "." + name
+		}
+
+		newProp, found := newSchema.Properties[name]
+		if !found {
+			// unknown fields are kept in storage, hence nothing is lost
+			if newSchema.XPreserveUnknownFields == nil || !*newSchema.XPreserveUnknownFields {
+				changes = append(changes, fmt.Sprintf("field %s is removed", childPath))
+			}
+			continue
+		}
+
+		changes = append(changes, IncompatibleFieldChanges(childPath, &oldProp, &newProp)...)
+	}
+
+	if oldSchema.Items != nil && oldSchema.Items.Schema != nil && newSchema.Items != nil && newSchema.Items.Schema != nil {
+		changes = append(changes, IncompatibleFieldChanges(path+"[*]", oldSchema.Items.Schema, newSchema.Items.Schema)...)
+	}
+
+	if oldSchema.AdditionalProperties != nil && oldSchema.AdditionalProperties.Schema != nil && newSchema.AdditionalProperties != nil && newSchema.AdditionalProperties.Schema != nil {
+		changes = append(changes, IncompatibleFieldChanges(path+"[*]", oldSchema.AdditionalProperties.Schema, newSchema.AdditionalProperties.Schema)...)
+	}
+
+	return changes
+}
+
+// NewlyRequiredFields recursively compares two OpenAPI schemas and returns the fields that are required
+// in the new schema, but not in the old one, and have no default. Objects valid against the old schema
+// might not be valid against the new one anymore.
+func NewlyRequiredFields(path string, oldSchema, newSchema *apiextensionsv1.JSONSchemaProps) []string {
+	var changes []string
+
+	oldRequired := make(map[string]bool, len(oldSchema.Required))
+	for _, name := range oldSchema.Required {
+		oldRequired[name] = true
+	}
+	for _, name := range newSchema.Required {
+		childPath := name
+		if path != "" {
+			childPath = path + "." + name
+		}
+		if prop, found := newSchema.Properties[name]; !oldRequired[name] && (!found || prop.Default == nil) {
+			changes = append(changes, fmt.Sprintf("field %s is newly required", childPath))
+		}
+	}
+
+	names := make([]string, 0, len(oldSchema.Properties))
+	for name := range oldSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		newProp, found := newSchema.Properties[name]
+		if !found {
+			continue
+		}
+		oldProp := oldSchema.Properties[name]
+		childPath := name
+		if path != "" {
+			childPath = path + "." + name
+		}
+		changes = append(changes, NewlyRequiredFields(childPath, &oldProp, &newProp)...)
+	}
+
+	if oldSchema.Items != nil && oldSchema.Items.Schema != nil && newSchema.Items != nil && newSchema.Items.Schema != nil {
+		changes = append(changes, NewlyRequiredFields(path+"[*]", oldSchema.Items.Schema, newSchema.Items.Schema)...)
+	}
+
+	if oldSchema.AdditionalProperties != nil && oldSchema.AdditionalProperties.Schema != nil && newSchema.AdditionalProperties != nil && newSchema.AdditionalProperties.Schema != nil {
+		changes = append(changes, NewlyRequiredFields(path+"[*]", oldSchema.AdditionalProperties.Schema, newSchema.AdditionalProperties.Schema)...)
+	}
+
+	return changes
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
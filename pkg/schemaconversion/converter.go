@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schemaconversion converts custom resources between the versions of an
+// APIResourceSchema according to its declarative conversions. kcp hosts the conversion
+// webhook of bound CRDs itself, under a reserved service resolved to the shard.
+package schemaconversion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+const (
+	// ServiceNamespace is the namespace of the reserved conversion webhook service.
+	ServiceNamespace = "kcp-system"
+	// ServiceName is the name of the reserved conversion webhook service. It is not a
+	// real service, but resolved by kcp to the shard serving the CRD.
+	ServiceName = "apiresourceschema-conversion"
+	// Path is the path prefix the conversion webhook is served under. It is followed by
+	// the name of the APIResourceSchema.
+	Path = "/apiresourceschema-conversion"
+
+	// selfVariable is the name of the CEL variable holding the value of the source field.
+	selfVariable = "self"
+	// costLimit bounds the evaluation of a single transformation.
+	costLimit = 1000000
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+func celEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(cel.Variable(selfVariable, cel.DynType), ext.Strings())
+	})
+	return env, envErr
+}
+
+// Rule is a compiled APIConversionRule.
+type Rule struct {
+	field       []string
+	destination []string
+	program     cel.Program
+}
+
+// CompileRule parses the field paths of the given rule and compiles its transformation.
+func CompileRule(rule apisv1alpha1.APIConversionRule) (*Rule, error) {
+	field, err := parsePath(rule.Field)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field %q: %w", rule.Field, err)
+	}
+	destination := field
+	if rule.Destination != "" {
+		if destination, err = parsePath(rule.Destination); err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %w", rule.Destination, err)
+		}
+	}
+	compiled := &Rule{field: field, destination: destination}
+
+	if rule.Transformation != "" {
+		e, err := celEnv()
+		if err != nil {
+			return nil, err
+		}
+		ast, issues := e.Compile(rule.Transformation)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid transformation: %w", issues.Err())
+		}
+		if compiled.program, err = e.Program(ast, cel.CostLimit(costLimit)); err != nil {
+			return nil, fmt.Errorf("invalid transformation: %w", err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// parsePath parses a JSONPath of dot-separated field names like ".spec.firstName".
+func parsePath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("must start with a dot")
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "."), ".")
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("must not contain empty field names")
+		}
+	}
+	switch segments[0] {
+	case "metadata", "apiVersion", "kind":
+		return nil, fmt.Errorf("%s cannot be converted", segments[0])
+	}
+	return segments, nil
+}
+
+type versionPair struct {
+	from, to string
+}
+
+// Converter converts custom resources of an APIResourceSchema between its versions.
+type Converter struct {
+	schemaName string
+	group      string
+	rules      map[versionPair][]*Rule
+}
+
+// Compile compiles the conversions of the given APIResourceSchema.
+func Compile(schema *apisv1alpha1.APIResourceSchema) (*Converter, error) {
+	c := &Converter{
+		schemaName: schema.Name,
+		group:      schema.Spec.Group,
+		rules:      map[versionPair][]*Rule{},
+	}
+	for _, conversion := range schema.Spec.Conversions {
+		pair := versionPair{from: conversion.From, to: conversion.To}
+		if _, found := c.rules[pair]; found {
+			return nil, fmt.Errorf("duplicate conversion from %s to %s", conversion.From, conversion.To)
+		}
+		rules := make([]*Rule, 0, len(conversion.Rules))
+		for _, rule := range conversion.Rules {
+			compiled, err := CompileRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("conversion from %s to %s: %w", conversion.From, conversion.To, err)
+			}
+			rules = append(rules, compiled)
+		}
+		c.rules[pair] = rules
+	}
+	return c, nil
+}
+
+// Convert returns a copy of the given object converted to the given version. Without a
+// conversion declared between the two versions, only the apiVersion is changed.
+func (c *Converter) Convert(obj *unstructured.Unstructured, toVersion string) (*unstructured.Unstructured, error) {
+	start := time.Now()
+	fromGV, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return nil, err
+	}
+	if fromGV.Group != c.group {
+		return nil, fmt.Errorf("unexpected group %q, expected %q", fromGV.Group, c.group)
+	}
+
+	converted, err := c.convert(obj, fromGV.Version, toVersion)
+	recordConversion(c.schemaName, fromGV.Version, toVersion, err, time.Since(start))
+	return converted, err
+}
+
+func (c *Converter) convert(obj *unstructured.Unstructured, fromVersion, toVersion string) (*unstructured.Unstructured, error) {
+	out := obj.DeepCopy()
+	out.SetAPIVersion(schema.GroupVersion{Group: c.group, Version: toVersion}.String())
+	if fromVersion == toVersion {
+		return out, nil
+	}
+
+	// values are read from the original object and written after all moved fields are
+	// removed, such that rules can swap fields.
+	type write struct {
+		path  []string
+		value interface{}
+	}
+	var writes []write
+	for _, rule := range c.rules[versionPair{from: fromVersion, to: toVersion}] {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, rule.field...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read .%s: %w", strings.Join(rule.field, "."), err)
+		}
+		if !found {
+			continue
+		}
+		if rule.program != nil {
+			if value, err = rule.transform(value); err != nil {
+				return nil, fmt.Errorf("failed to transform .%s: %w", strings.Join(rule.field, "."), err)
+			}
+		}
+		if !reflect.DeepEqual(rule.field, rule.destination) {
+			unstructured.RemoveNestedField(out.Object, rule.field...)
+		}
+		writes = append(writes, write{path: rule.destination, value: value})
+	}
+	for _, w := range writes {
+		if err := unstructured.SetNestedField(out.Object, w.value, w.path...); err != nil {
+			return nil, fmt.Errorf("failed to write .%s: %w", strings.Join(w.path, "."), err)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *Rule) transform(value interface{}) (interface{}, error) {
+	out, _, err := r.program.Eval(map[string]interface{}{selfVariable: value})
+	if err != nil {
+		return nil, err
+	}
+	return toJSONValue(out)
+}
+
+// toJSONValue converts a CEL value into a value valid in unstructured objects.
+func toJSONValue(v ref.Val) (interface{}, error) {
+	switch v.Type() {
+	case types.IntType:
+		return v.Value().(int64), nil
+	case types.UintType:
+		return int64(v.Value().(uint64)), nil
+	}
+	pb, err := v.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, err
+	}
+	return pb.(*structpb.Value).AsInterface(), nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaconversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestCompileRule(t *testing.T) {
+	tests := map[string]struct {
+		rule    apisv1alpha1.APIConversionRule
+		wantErr bool
+	}{
+		"field only":                {rule: apisv1alpha1.APIConversionRule{Field: ".spec.name"}},
+		"with destination":          {rule: apisv1alpha1.APIConversionRule{Field: ".spec.name", Destination: ".spec.fullName"}},
+		"with transformation":       {rule: apisv1alpha1.APIConversionRule{Field: ".spec.name", Transformation: "self.split(' ')[0]"}},
+		"no leading dot":            {rule: apisv1alpha1.APIConversionRule{Field: "spec.name"}, wantErr: true},
+		"empty segment":             {rule: apisv1alpha1.APIConversionRule{Field: ".spec..name"}, wantErr: true},
+		"metadata":                  {rule: apisv1alpha1.APIConversionRule{Field: ".metadata.labels"}, wantErr: true},
+		"apiVersion as destination": {rule: apisv1alpha1.APIConversionRule{Field: ".spec.version", Destination: ".apiVersion"}, wantErr: true},
+		"invalid transformation":    {rule: apisv1alpha1.APIConversionRule{Field: ".spec.name", Transformation: "self +"}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CompileRule(tc.rule)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	schema := &apisv1alpha1.APIResourceSchema{
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "example.io",
+			Conversions: []apisv1alpha1.APIVersionConversion{
+				{
+					From: "v1",
+					To:   "v2",
+					Rules: []apisv1alpha1.APIConversionRule{
+						{Field: ".spec.name", Destination: ".spec.firstName", Transformation: "self.split(' ')[0]"},
+						{Field: ".spec.size", Transformation: "self * 2"},
+						{Field: ".spec.a", Destination: ".spec.b"},
+						{Field: ".spec.b", Destination: ".spec.a"},
+						{Field: ".spec.missing", Destination: ".spec.other"},
+					},
+				},
+			},
+		},
+	}
+	c, err := Compile(schema)
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.io/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "foo"},
+		"spec": map[string]interface{}{
+			"name":  "Jane Doe",
+			"size":  int64(3),
+			"a":     "a",
+			"b":     "b",
+			"color": "blue",
+		},
+	}}
+
+	t.Run("declared conversion", func(t *testing.T) {
+		converted, err := c.Convert(obj, "v2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"apiVersion": "example.io/v2",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "foo"},
+			"spec": map[string]interface{}{
+				"firstName": "Jane",
+				"size":      int64(6),
+				"a":         "b",
+				"b":         "a",
+				"color":     "blue",
+			},
+		}, converted.Object)
+		require.Equal(t, "example.io/v1", obj.GetAPIVersion(), "original object must not be modified")
+	})
+
+	t.Run("undeclared conversion only changes apiVersion", func(t *testing.T) {
+		converted, err := c.Convert(obj, "v3")
+		require.NoError(t, err)
+		require.Equal(t, "example.io/v3", converted.GetAPIVersion())
+		require.Equal(t, obj.Object["spec"], converted.Object["spec"])
+	})
+
+	t.Run("foreign group", func(t *testing.T) {
+		foreign := obj.DeepCopy()
+		foreign.SetAPIVersion("other.io/v1")
+		_, err := c.Convert(foreign, "v2")
+		require.Error(t, err)
+	})
+}
+
+func TestCompileDuplicateConversion(t *testing.T) {
+	_, err := Compile(&apisv1alpha1.APIResourceSchema{
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Conversions: []apisv1alpha1.APIVersionConversion{{From: "v1", To: "v2"}, {From: "v1", To: "v2"}},
+		},
+	})
+	require.Error(t, err)
+}
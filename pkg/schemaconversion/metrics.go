@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaconversion
+
+import (
+	"sync"
+	"time"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	conversions = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "apiresourceschema_conversions_total",
+			Help:           "Number of custom resources converted by APIResourceSchema conversions, by schema, versions and result.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"schema", "from", "to", "result"},
+	)
+	conversionDuration = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "apiresourceschema_conversion_duration_seconds",
+			Help:           "Time it takes to convert a custom resource by APIResourceSchema conversions, by schema.",
+			Buckets:        []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"schema"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// RegisterMetrics registers the APIResourceSchema conversion metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(conversions)
+		legacyregistry.MustRegister(conversionDuration)
+	})
+}
+
+func init() {
+	RegisterMetrics()
+}
+
+func recordConversion(schema, from, to string, err error, duration time.Duration) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	conversions.WithLabelValues(schema, from, to, result).Inc()
+	conversionDuration.WithLabelValues(schema).Observe(duration.Seconds())
+}
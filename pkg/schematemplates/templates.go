@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schematemplates provides reusable printer columns and kubectl categories for
+// APIResourceSchemas, such that APIs of different providers look and group alike in kubectl.
+package schematemplates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// Template is a set of printer columns and categories injected into an APIResourceSchema.
+type Template struct {
+	// PrinterColumns are added to every version of the schema.
+	PrinterColumns []apiextensionsv1.CustomResourceColumnDefinition
+	// Categories are added to the names of the schema.
+	Categories []string
+}
+
+var (
+	// ReadyColumn shows the status of the Ready condition.
+	ReadyColumn = apiextensionsv1.CustomResourceColumnDefinition{
+		Name:     "Ready",
+		Type:     "string",
+		JSONPath: `.status.conditions[?(@.type=="Ready")].status`,
+	}
+
+	// AgeColumn shows the age of the object. The API server only shows it by default
+	// for versions without printer columns.
+	AgeColumn = apiextensionsv1.CustomResourceColumnDefinition{
+		Name:     "Age",
+		Type:     "date",
+		JSONPath: ".metadata.creationTimestamp",
+	}
+)
+
+// Templates are the templates by name, to be referenced in the
+// apis.kcp.io/templates annotation of APIResourceSchemas.
+var Templates = map[string]Template{
+	// standard adds the Ready and Age columns.
+	"standard": {
+		PrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{ReadyColumn, AgeColumn},
+	},
+	// all adds the resource to "kubectl get all".
+	"all": {
+		Categories: []string{"all"},
+	},
+}
+
+// TemplateNames returns the names of the templates referenced by the
+// apis.kcp.io/templates annotation of the schema, if any.
+func TemplateNames(schema *apisv1alpha1.APIResourceSchema) []string {
+	value, found := schema.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaTemplatesKey]
+	if !found {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Apply injects the printer columns and categories of the named templates into the schema.
+// Printer columns with the name of an existing column of a version, and existing categories
+// are skipped, such that Apply is idempotent.
+func Apply(schema *apisv1alpha1.APIResourceSchema, names []string) error {
+	for _, name := range names {
+		template, found := Templates[name]
+		if !found {
+			return fmt.Errorf("unknown template %q, must be one of %s", name, strings.Join(sortedTemplateNames(), ", "))
+		}
+
+		for i := range schema.Spec.Versions {
+			version := &schema.Spec.Versions[i]
+			for _, column := range template.PrinterColumns {
+				if !hasColumn(version.AdditionalPrinterColumns, column.Name) {
+					version.AdditionalPrinterColumns = append(version.AdditionalPrinterColumns, column)
+				}
+			}
+		}
+		schema.Spec.Names.Categories = MergeCategories(schema.Spec.Names.Categories, template.Categories)
+	}
+	return nil
+}
+
+// MergeCategories returns the categories followed by the additional categories not
+// already contained.
+func MergeCategories(categories, additional []string) []string {
+	existing := sets.NewString(categories...)
+	for _, category := range additional {
+		if !existing.Has(category) {
+			existing.Insert(category)
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func hasColumn(columns []apiextensionsv1.CustomResourceColumnDefinition, name string) bool {
+	for _, column := range columns {
+		if strings.EqualFold(column.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedTemplateNames() []string {
+	names := make([]string, 0, len(Templates))
+	for name := range Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
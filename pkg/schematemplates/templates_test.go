@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schematemplates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestApply(t *testing.T) {
+	sizeColumn := apiextensionsv1.CustomResourceColumnDefinition{Name: "Size", Type: "integer", JSONPath: ".spec.size"}
+	customAgeColumn := apiextensionsv1.CustomResourceColumnDefinition{Name: "age", Type: "date", JSONPath: ".status.startTime"}
+
+	tests := map[string]struct {
+		annotation     string
+		columns        []apiextensionsv1.CustomResourceColumnDefinition
+		categories     []string
+		wantColumns    []apiextensionsv1.CustomResourceColumnDefinition
+		wantCategories []string
+		wantErr        bool
+	}{
+		"no annotation": {
+			columns:     []apiextensionsv1.CustomResourceColumnDefinition{sizeColumn},
+			wantColumns: []apiextensionsv1.CustomResourceColumnDefinition{sizeColumn},
+		},
+		"standard columns appended": {
+			annotation:  "standard",
+			columns:     []apiextensionsv1.CustomResourceColumnDefinition{sizeColumn},
+			wantColumns: []apiextensionsv1.CustomResourceColumnDefinition{sizeColumn, ReadyColumn, AgeColumn},
+		},
+		"existing columns are kept": {
+			annotation:  "standard",
+			columns:     []apiextensionsv1.CustomResourceColumnDefinition{customAgeColumn},
+			wantColumns: []apiextensionsv1.CustomResourceColumnDefinition{customAgeColumn, ReadyColumn},
+		},
+		"categories merged": {
+			annotation:     " standard , all ",
+			categories:     []string{"widgets", "all"},
+			wantColumns:    []apiextensionsv1.CustomResourceColumnDefinition{ReadyColumn, AgeColumn},
+			wantCategories: []string{"widgets", "all"},
+		},
+		"all category added": {
+			annotation:     "all",
+			wantCategories: []string{"all"},
+		},
+		"unknown template": {
+			annotation: "standard,fancy",
+			wantErr:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			schema := &apisv1alpha1.APIResourceSchema{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec: apisv1alpha1.APIResourceSchemaSpec{
+					Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget", Categories: tc.categories},
+					Versions: []apisv1alpha1.APIResourceVersion{
+						{Name: "v1", AdditionalPrinterColumns: tc.columns},
+						{Name: "v2", AdditionalPrinterColumns: tc.columns},
+					},
+				},
+			}
+			if tc.annotation != "" {
+				schema.Annotations[apisv1alpha1.AnnotationAPIResourceSchemaTemplatesKey] = tc.annotation
+			}
+
+			err := Apply(schema, TemplateNames(schema))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, version := range schema.Spec.Versions {
+				require.Equal(t, tc.wantColumns, version.AdditionalPrinterColumns, "version %s", version.Name)
+			}
+			require.Equal(t, tc.wantCategories, schema.Spec.Names.Categories)
+
+			// applying again changes nothing
+			before := schema.DeepCopy()
+			require.NoError(t, Apply(schema, TemplateNames(schema)))
+			require.Equal(t, before, schema)
+		})
+	}
+}
+
+func TestMergeCategories(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, MergeCategories([]string{"a", "b"}, []string{"b", "c"}))
+	require.Equal(t, []string{"a"}, MergeCategories(nil, []string{"a", "a"}))
+	require.Nil(t, MergeCategories(nil, nil))
+}
@@ -37,13 +37,13 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	apisv1alpha1listers "github.com/kcp-dev/kcp/pkg/client/listers/apis/v1alpha1"
-	tenancyv1beta1listers "github.com/kcp-dev/kcp/pkg/client/listers/tenancy/v1beta1"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
 	"github.com/kcp-dev/kcp/pkg/server/filters"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/apis/v1alpha1"
+	tenancyv1beta1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1beta1"
 )
 
 // SystemCRDClusterName is the logical cluster we install system CRDs into for now. These are needed
@@ -56,6 +56,8 @@ type apiBindingAwareCRDClusterLister struct {
 	crdLister  kcpapiextensionsv1listers.CustomResourceDefinitionClusterLister
 	crdIndexer cache.Indexer
 
+	boundCRDPartitions apibinding.BoundCRDPartitions
+
 	workspaceLister tenancyv1beta1listers.WorkspaceClusterLister
 
 	apiBindingIndexer cache.Indexer
@@ -75,6 +77,13 @@ func (a *apiBindingAwareCRDClusterLister) Cluster(name logicalcluster.Name) kcp.
 
 var _ kcp.ClusterAwareCRDClusterLister = &apiBindingAwareCRDClusterLister{}
 
+// getBoundCRD returns the bound CRD of the APIResourceSchema with the given UID from its partition.
+func (a *apiBindingAwareCRDClusterLister) getBoundCRD(schemaUID string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	return a.boundCRDPartitions.Get(func(clusterName logicalcluster.Name, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+		return a.crdLister.Cluster(clusterName).Get(name)
+	}, schemaUID)
+}
+
 // apiBindingAwareCRDLister is a CRD lister combines APIs coming from APIBindings with CRDs in a workspace.
 type apiBindingAwareCRDLister struct {
 	*apiBindingAwareCRDClusterLister
@@ -118,10 +127,10 @@ func (c *apiBindingAwareCRDLister) List(ctx context.Context, selector labels.Sel
 			logger := logging.WithObject(logger, &apiextensionsv1.CustomResourceDefinition{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        boundResource.Schema.UID,
-					Annotations: map[string]string{logicalcluster.AnnotationKey: apibinding.SystemBoundCRDsClusterName.String()},
+					Annotations: map[string]string{logicalcluster.AnnotationKey: c.boundCRDPartitions.ClusterFor(boundResource.Schema.UID).String()},
 				},
 			})
-			crd, err := c.crdLister.Cluster(apibinding.SystemBoundCRDsClusterName).Get(boundResource.Schema.UID)
+			crd, err := c.getBoundCRD(boundResource.Schema.UID)
 			if err != nil {
 				logger.Error(err, "error getting bound CRD")
 				continue
@@ -142,7 +151,7 @@ func (c *apiBindingAwareCRDLister) List(ctx context.Context, selector labels.Sel
 
 			// Add the APIExport identity hash as an annotation to the CRD so the RESTOptionsGetter can assign
 			// the correct etcd resource prefix.
-			crd = decorateCRDWithBinding(crd, boundResource.Schema.IdentityHash, apiBinding.DeletionTimestamp)
+			crd = decorateCRDWithBinding(crd, boundResource.Schema.IdentityHash, apiBinding.Status.ExtraCRDAnnotations, apiBinding.DeletionTimestamp)
 
 			ret = append(ret, crd)
 			seen.Insert(crdName(crd))
@@ -273,11 +282,15 @@ func shallowCopyCRDAndDeepCopyAnnotations(in *apiextensionsv1.CustomResourceDefi
 
 // decorateCRDWithBinding copy and mutate crd by
 // 1. adding identity annotation
-// 2. terminating status when apibinding is deleting.
-func decorateCRDWithBinding(in *apiextensionsv1.CustomResourceDefinition, identity string, deleteTime *metav1.Time) *apiextensionsv1.CustomResourceDefinition {
+// 2. adding the extra annotations of the APIExport selected for CRDs
+// 3. terminating status when apibinding is deleting.
+func decorateCRDWithBinding(in *apiextensionsv1.CustomResourceDefinition, identity string, extraAnnotations map[string]string, deleteTime *metav1.Time) *apiextensionsv1.CustomResourceDefinition {
 	out := shallowCopyCRDAndDeepCopyAnnotations(in)
 
 	out.Annotations[apisv1alpha1.AnnotationAPIIdentityKey] = identity
+	for k, v := range extraAnnotations {
+		out.Annotations[k] = v
+	}
 
 	if deleteTime.IsZero() {
 		return out
@@ -337,14 +350,14 @@ func (c *apiBindingAwareCRDLister) getForIdentityWildcard(name, identity string)
 		return nil, apierrors.NewNotFound(apiextensionsv1.Resource("customresourcedefinitions"), name)
 	}
 
-	crd, err := c.crdLister.Cluster(apibinding.SystemBoundCRDsClusterName).Get(boundCRDName)
+	crd, err := c.getBoundCRD(boundCRDName)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add the APIExport identity hash as an annotation to the CRD so the RESTOptionsGetter can assign
 	// the correct etcd resource prefix. Use a shallow copy because deep copy is expensive (but deep copy the annotations).
-	crd = decorateCRDWithBinding(crd, identity, apiBinding.DeletionTimestamp)
+	crd = decorateCRDWithBinding(crd, identity, apiBinding.Status.ExtraCRDAnnotations, apiBinding.DeletionTimestamp)
 
 	return crd, nil
 }
@@ -385,7 +398,7 @@ func (c *apiBindingAwareCRDLister) get(clusterName logicalcluster.Name, name, id
 			matchingIdentity := identity == "" || boundResource.Schema.IdentityHash == identity
 
 			if boundResource.Group == group && boundResource.Resource == resource && matchingIdentity {
-				crd, err = c.crdLister.Cluster(apibinding.SystemBoundCRDsClusterName).Get(boundResource.Schema.UID)
+				crd, err = c.getBoundCRD(boundResource.Schema.UID)
 				if err != nil && apierrors.IsNotFound(err) {
 					// If we got here, it means there is supposed to be a CRD coming from an APIBinding, but
 					// the CRD doesn't exist for some reason.
@@ -397,7 +410,7 @@ func (c *apiBindingAwareCRDLister) get(clusterName logicalcluster.Name, name, id
 
 				// Add the APIExport identity hash as an annotation to the CRD so the RESTOptionsGetter can assign
 				// the correct etcd resource prefix.
-				crd = decorateCRDWithBinding(crd, boundResource.Schema.IdentityHash, apiBinding.DeletionTimestamp)
+				crd = decorateCRDWithBinding(crd, boundResource.Schema.IdentityHash, apiBinding.Status.ExtraCRDAnnotations, apiBinding.DeletionTimestamp)
 
 				return crd, nil
 			}
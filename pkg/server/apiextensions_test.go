@@ -27,7 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kcp-dev/kcp/pkg/admission/reservedcrdgroups"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func TestSystemCRDsLogicalClusterName(t *testing.T) {
@@ -42,6 +42,7 @@ func TestDecorateCRDWithBinding(t *testing.T) {
 		crd                *apiextensionsv1.CustomResourceDefinition
 		deleteTime         *metav1.Time
 		identity           string
+		extraAnnotations   map[string]string
 		expectedConditions []apiextensionsv1.CustomResourceDefinitionCondition
 		expectedAnnotation map[string]string
 	}{
@@ -69,6 +70,23 @@ func TestDecorateCRDWithBinding(t *testing.T) {
 				"foo":                                 "bar",
 			},
 		},
+		{
+			name: "extra annotations",
+			crd: &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			identity:         "bob",
+			extraAnnotations: map[string]string{"extra.apis.kcp.io/docs": "https://example.com"},
+			expectedAnnotation: map[string]string{
+				apisv1alpha1.AnnotationAPIIdentityKey: "bob",
+				"extra.apis.kcp.io/docs":              "https://example.com",
+				"foo":                                 "bar",
+			},
+		},
 		{
 			name: "apibinding is deleting",
 			crd: &apiextensionsv1.CustomResourceDefinition{
@@ -100,7 +118,7 @@ func TestDecorateCRDWithBinding(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			crdCopy := tt.crd.DeepCopy()
 
-			newCrd := decorateCRDWithBinding(crdCopy, tt.identity, tt.deleteTime)
+			newCrd := decorateCRDWithBinding(crdCopy, tt.identity, tt.extraAnnotations, tt.deleteTime)
 
 			if !equality.Semantic.DeepEqual(tt.crd, crdCopy) {
 				t.Errorf("expect crd not mutated, but got %v", crdCopy)
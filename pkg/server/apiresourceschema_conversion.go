@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/util/webhook"
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/schemaconversion"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// converterCacheTTL is how long compiled converters are cached. APIResourceSchemas are
+// immutable, so this only bounds the memory of schemas that are gone.
+const converterCacheTTL = time.Hour
+
+// newAPIResourceSchemaConversionHandler returns the conversion webhook handler of bound CRDs of
+// APIResourceSchemas with conversions, served under schemaconversion.Path followed by the schema
+// name in the logical cluster of the schema. Only privileged callers, i.e. the shard itself
+// through the loopback client, are served.
+func newAPIResourceSchemaConversionHandler(getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)) http.HandlerFunc {
+	converters := utilcache.NewLRUExpireCache(1000)
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		schemaName := strings.TrimPrefix(req.URL.Path, schemaconversion.Path+"/")
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard || schemaName == "" || strings.Contains(schemaName, "/") {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(schema.GroupResource{}, req.URL.Path),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		if u, ok := request.UserFrom(req.Context()); !ok || !sets.NewString(u.GetGroups()...).Has(user.SystemPrivilegedGroup) {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewForbidden(apisv1alpha1.Resource("apiresourceschemas"), schemaName, errors.New("conversions are only served to the shard itself")),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		if req.Method != http.MethodPost {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewMethodNotSupported(apisv1alpha1.Resource("apiresourceschemas"), req.Method),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		review := &apiextensionsv1.ConversionReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil || review.Request == nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewBadRequest("expected a ConversionReview request"),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		sch, err := getAPIResourceSchema(cluster.Name, schemaName)
+		if apierrors.IsNotFound(err) {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), schemaName),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		} else if err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewInternalError(err),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		var converter *schemaconversion.Converter
+		if cached, found := converters.Get(sch.UID); found {
+			converter = cached.(*schemaconversion.Converter)
+		} else if converter, err = schemaconversion.Compile(sch); err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewInternalError(err),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		} else {
+			converters.Add(sch.UID, converter, converterCacheTTL)
+		}
+
+		review.Response = convertObjects(converter, review.Request)
+		review.Request = nil
+
+		responsewriters.WriteRawJSON(http.StatusOK, review, w)
+	}
+}
+
+func convertObjects(converter *schemaconversion.Converter, req *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	failed := func(err error) *apiextensionsv1.ConversionResponse {
+		return &apiextensionsv1.ConversionResponse{
+			UID:    req.UID,
+			Result: metav1.Status{Status: metav1.StatusFailure, Message: err.Error()},
+		}
+	}
+
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return failed(err)
+	}
+
+	resp := &apiextensionsv1.ConversionResponse{
+		UID:    req.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, raw := range req.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return failed(err)
+		}
+		converted, err := converter.Convert(obj, gv.Version)
+		if err != nil {
+			return failed(fmt.Errorf("failed to convert %s: %w", obj.GetName(), err))
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Object: converted})
+	}
+	return resp
+}
+
+// apiResourceSchemaConversionServiceResolver is a webhook.ServiceResolver resolving the reserved
+// APIResourceSchema conversion service to the shard itself. Other services are not supported, i.e.
+// CRD webhook conversions of user defined services always get an error.
+type apiResourceSchemaConversionServiceResolver struct {
+	loopbackClientConfig *rest.Config
+}
+
+// ResolveEndpoint returns the loopback address of the shard for the reserved conversion service.
+func (r *apiResourceSchemaConversionServiceResolver) ResolveEndpoint(namespace string, name string, port int32) (*url.URL, error) {
+	if namespace != schemaconversion.ServiceNamespace || name != schemaconversion.ServiceName {
+		return nil, errors.New("CRD webhook conversions are not yet supported in kcp")
+	}
+	return url.Parse(r.loopbackClientConfig.Host)
+}
+
+// withAPIResourceSchemaConversionAuthentication wraps the given resolver wrapper such that calls to the
+// reserved conversion service use the loopback credentials of the shard.
+func withAPIResourceSchemaConversionAuthentication(wrapper webhook.AuthenticationInfoResolverWrapper, loopbackClientConfig *rest.Config) webhook.AuthenticationInfoResolverWrapper {
+	return func(resolver webhook.AuthenticationInfoResolver) webhook.AuthenticationInfoResolver {
+		wrapped := wrapper(resolver)
+		return &webhook.AuthenticationInfoResolverDelegator{
+			ClientConfigForFunc: wrapped.ClientConfigFor,
+			ClientConfigForServiceFunc: func(serviceName, serviceNamespace string, servicePort int) (*rest.Config, error) {
+				if serviceNamespace == schemaconversion.ServiceNamespace && serviceName == schemaconversion.ServiceName {
+					return rest.CopyConfig(loopbackClientConfig), nil
+				}
+				return wrapped.ClientConfigForService(serviceName, serviceNamespace, servicePort)
+			},
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+	"github.com/kcp-dev/kcp/pkg/schemacompat"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// APIResourceSchemaDiffPath is the path of the APIResourceSchema diff endpoint of every logical cluster,
+// e.g. /clusters/root:org:ws/apiresourceschema-diff. It returns a schemacompat.Diff as JSON, either
+//
+//   - between two APIResourceSchemas of the logical cluster, with ?from=<name>&to=<name>, or
+//   - between two versions of one APIResourceSchema, with ?schema=<name>&from=<version>&to=<version>.
+//
+// The user must be allowed to get the compared APIResourceSchemas.
+const APIResourceSchemaDiffPath = bootstrappolicy.APIResourceSchemaDiffPath
+
+// newAPIResourceSchemaDiffHandler returns the handler of APIResourceSchemaDiffPath.
+func newAPIResourceSchemaDiffHandler(getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error), authz authorizer.Authorizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(schema.GroupResource{}, APIResourceSchemaDiffPath),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		query := req.URL.Query()
+		from, to, schemaName := query.Get("from"), query.Get("to"), query.Get("schema")
+		if from == "" || to == "" {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewBadRequest("the from and to query parameters are required"),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		getSchema := func(name string) (*apisv1alpha1.APIResourceSchema, bool) {
+			user, _ := request.UserFrom(req.Context())
+			attr := authorizer.AttributesRecord{
+				User:            user,
+				Verb:            "get",
+				APIGroup:        apisv1alpha1.SchemeGroupVersion.Group,
+				APIVersion:      apisv1alpha1.SchemeGroupVersion.Version,
+				Resource:        "apiresourceschemas",
+				Name:            name,
+				ResourceRequest: true,
+			}
+			if decision, reason, err := authz.Authorize(req.Context(), attr); err != nil || decision != authorizer.DecisionAllow {
+				responsewriters.Forbidden(req.Context(), attr, w, req, reason, errorCodecs)
+				return nil, false
+			}
+
+			sch, err := getAPIResourceSchema(cluster.Name, name)
+			if apierrors.IsNotFound(err) {
+				responsewriters.ErrorNegotiated(
+					apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name),
+					errorCodecs, schema.GroupVersion{}, w, req,
+				)
+				return nil, false
+			} else if err != nil {
+				responsewriters.ErrorNegotiated(
+					apierrors.NewInternalError(err),
+					errorCodecs, schema.GroupVersion{}, w, req,
+				)
+				return nil, false
+			}
+			return sch, true
+		}
+
+		diff := &schemacompat.Diff{From: from, To: to}
+		var err error
+		if schemaName == "" {
+			fromSchema, ok := getSchema(from)
+			if !ok {
+				return
+			}
+			toSchema, ok := getSchema(to)
+			if !ok {
+				return
+			}
+			diff.Changes, err = schemacompat.DiffAPIResourceSchemas(fromSchema, toSchema)
+		} else {
+			sch, ok := getSchema(schemaName)
+			if !ok {
+				return
+			}
+			fromVersion, toVersion := apiResourceVersion(sch, from), apiResourceVersion(sch, to)
+			if fromVersion == nil || toVersion == nil {
+				responsewriters.ErrorNegotiated(
+					apierrors.NewBadRequest(fmt.Sprintf("APIResourceSchema %s does not have versions %s and %s", schemaName, from, to)),
+					errorCodecs, schema.GroupVersion{}, w, req,
+				)
+				return
+			}
+			diff.Changes, err = schemacompat.DiffAPIResourceVersions(fromVersion, toVersion)
+		}
+		if err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewInternalError(err),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		if diff.Changes == nil {
+			diff.Changes = []schemacompat.Change{}
+		}
+
+		responsewriters.WriteRawJSON(http.StatusOK, diff, w)
+	}
+}
+
+func apiResourceVersion(sch *apisv1alpha1.APIResourceSchema, name string) *apisv1alpha1.APIResourceVersion {
+	for i := range sch.Spec.Versions {
+		if sch.Spec.Versions[i].Name == name {
+			return &sch.Spec.Versions[i]
+		}
+	}
+	return nil
+}
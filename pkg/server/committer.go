@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
+)
+
+var (
+	committerPatches = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "committer_patches_total",
+			Help:           "Number of patches sent by the committers of kcp controllers, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	committerCoalescedCommits = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "committer_coalesced_commits_total",
+			Help:           "Number of commits of kcp controllers merged into the status patch of another commit, by resource. Divided by the sum with committer_patches_total, it is the coalesce rate.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	committerThrottledPatches = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "committer_throttled_patches_total",
+			Help:           "Number of patches of kcp controllers that waited for the --committer-concurrency-limit, by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+)
+
+var registerCommitterMetrics sync.Once
+
+// committerMetrics records the events of the committers of kcp controllers.
+type committerMetrics struct{}
+
+var _ committer.Metrics = committerMetrics{}
+
+func newCommitterMetrics() committerMetrics {
+	registerCommitterMetrics.Do(func() {
+		legacyregistry.MustRegister(committerPatches)
+		legacyregistry.MustRegister(committerCoalescedCommits)
+		legacyregistry.MustRegister(committerThrottledPatches)
+	})
+	return committerMetrics{}
+}
+
+func (committerMetrics) Patched(resource string) {
+	committerPatches.WithLabelValues(resource).Inc()
+}
+
+func (committerMetrics) Coalesced(resource string) {
+	committerCoalescedCommits.WithLabelValues(resource).Inc()
+}
+
+func (committerMetrics) Throttled(resource string) {
+	committerThrottledPatches.WithLabelValues(resource).Inc()
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
@@ -30,8 +31,10 @@ import (
 	apiextensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
 	kcpapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/kcp/clientset/versioned"
 	kcpapiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/kcp/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/apiserver/pkg/informerfactoryhack"
 	"k8s.io/apiserver/pkg/quota/v1/generic"
@@ -47,22 +50,32 @@ import (
 	quotainstall "k8s.io/kubernetes/pkg/quota/v1/install"
 
 	kcpadmissioninitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/admission/recoverymode"
 	"github.com/kcp-dev/kcp/pkg/authorization"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
 	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/pkg/controllerswitch"
+	"github.com/kcp-dev/kcp/pkg/crypto"
 	"github.com/kcp-dev/kcp/pkg/embeddedetcd"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/informer"
+	"github.com/kcp-dev/kcp/pkg/loadshedding"
+	"github.com/kcp-dev/kcp/pkg/pathclaims"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	"github.com/kcp-dev/kcp/pkg/reconciler/maintenance"
 	"github.com/kcp-dev/kcp/pkg/server/bootstrap"
 	kcpfilters "github.com/kcp-dev/kcp/pkg/server/filters"
 	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
 	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
+	"github.com/kcp-dev/kcp/pkg/shardregistration"
+	"github.com/kcp-dev/kcp/pkg/throttling"
 	"github.com/kcp-dev/kcp/pkg/tunneler"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
 )
 
 type Config struct {
@@ -96,14 +109,39 @@ type ExtraConfig struct {
 	ApiExtensionsClusterClient          kcpapiextensionsclientset.ClusterInterface
 	KcpClusterClient                    kcpclientset.ClusterInterface
 	RootShardKcpClusterClient           kcpclientset.ClusterInterface
+	RootShardKubeClusterClient          kcpkubernetesclientset.ClusterInterface
 	BootstrapDynamicClusterClient       kcpdynamic.ClusterInterface
 	BootstrapApiExtensionsClusterClient kcpapiextensionsclientset.ClusterInterface
 
-	CacheDynamicClient kcpdynamic.ClusterInterface
+	CacheDynamicClient    kcpdynamic.ClusterInterface
+	CacheKcpClusterClient kcpclientset.ClusterInterface
 
 	// config from which client can be configured
 	LogicalClusterAdminConfig *rest.Config
 
+	// LoadSheddingWatchdog is nil if load shedding is disabled.
+	LoadSheddingWatchdog *loadshedding.Watchdog
+
+	// ControllerSwitchboard switches embedded controllers as configured in the Shard.
+	ControllerSwitchboard *controllerswitch.Switchboard
+
+	// CommitterBatcher batches and limits the status patches of the controllers passing it to
+	// their committer, as configured by --committer-batching-window and --committer-concurrency-limit.
+	CommitterBatcher *committer.Batcher
+
+	// MaintenanceScheduler runs the maintenance tasks registered by controllers on the leader of the shard.
+	MaintenanceScheduler *maintenance.Scheduler
+
+	// ThrottlingExemptions decides which workspaces are exempt from the per-workspace limits.
+	ThrottlingExemptions *throttling.Exemptions
+
+	// ShardRegistrationSigner signs the client certificates of shards registering with a token.
+	// It is nil if shard registration is disabled.
+	ShardRegistrationSigner *shardregistration.Signer
+
+	// PathClaims make sure that every path is used by one logical cluster only, across all shards.
+	PathClaims *pathclaims.Claims
+
 	// misc
 	preHandlerChainMux   *handlerChainMuxes
 	quotaAdmissionStopCh chan struct{}
@@ -165,6 +203,16 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		go http.ListenAndServe(opts.Extra.ProfilerAddress, nil)
 	}
 
+	if err := crypto.SetHashAlgorithm(opts.Extra.HashAlgorithm); err != nil {
+		return nil, err
+	}
+
+	if opts.Extra.ShardRegistrationToken != "" {
+		if err := registerShard(context.Background(), opts); err != nil {
+			return nil, err
+		}
+	}
+
 	if opts.EmbeddedEtcd.Enabled {
 		var err error
 		c.EmbeddedEtcd, err = embeddedetcd.NewConfig(opts.EmbeddedEtcd, opts.GenericControlPlane.Etcd.EnableWatchCache)
@@ -193,12 +241,12 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 	rt = cacheclient.WithShardNameFromContextRoundTripper(rt)
 	rt = cacheclient.WithDefaultShardRoundTripper(rt, shard.Wildcard)
 
-	cacheKcpClusterClient, err := kcpclientset.NewForConfig(rt)
+	c.CacheKcpClusterClient, err = kcpclientset.NewForConfig(rt)
 	if err != nil {
 		return nil, err
 	}
 	c.CacheKcpSharedInformerFactory = kcpinformers.NewSharedInformerFactoryWithOptions(
-		cacheKcpClusterClient,
+		c.CacheKcpClusterClient,
 		resyncPeriod,
 	)
 	c.CacheDynamicClient, err = kcpdynamic.NewForConfig(rt)
@@ -225,6 +273,10 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		c.RootShardKubeClusterClient, err = kcpkubernetesclientset.NewForConfig(nonIdentityRootKcpShardSystemAdminConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		c.identityConfig = rest.CopyConfig(c.GenericConfig.LoopbackClientConfig)
 		c.identityConfig.Wrap(kcpShardIdentityRoundTripper)
@@ -241,6 +293,7 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 			return nil, err
 		}
 		c.RootShardKcpClusterClient = c.KcpClusterClient
+		c.RootShardKubeClusterClient = c.KubeClusterClient
 	}
 
 	informerConfig := rest.CopyConfig(c.identityConfig)
@@ -309,6 +362,66 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		return nil, err
 	}
 
+	c.ControllerSwitchboard = controllerswitch.NewSwitchboard()
+	c.CommitterBatcher = committer.NewBatcher(newCommitterMetrics())
+	c.CommitterBatcher.SetWindow(opts.Extra.CommitterBatchingWindow)
+	c.CommitterBatcher.SetConcurrencyLimit(opts.Extra.CommitterConcurrencyLimit)
+	c.MaintenanceScheduler = maintenance.NewScheduler(c.KubeClusterClient, opts.Extra.ShardName)
+
+	var throttlingExemptionSigningKey []byte
+	if opts.Extra.ThrottlingExemptionSigningKeyFile != "" {
+		throttlingExemptionSigningKey, err = throttling.LoadSigningKey(opts.Extra.ThrottlingExemptionSigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.ThrottlingExemptions = throttling.NewExemptions(
+		throttlingExemptionSigningKey,
+		c.KcpSharedInformerFactory.Tenancy().V1alpha1().ThrottlingExemptions().Lister(),
+		c.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().ThrottlingExemptions().Lister(),
+		c.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister(),
+	)
+
+	if opts.Extra.ShardRegistrationSigningCertFile != "" {
+		c.ShardRegistrationSigner, err = shardregistration.LoadSigner(opts.Extra.ShardRegistrationSigningCertFile, opts.Extra.ShardRegistrationSigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.PathClaims = pathclaims.NewClaims(
+		c.RootShardKubeClusterClient,
+		c.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister(),
+		c.CacheKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister(),
+	)
+
+	if opts.LoadShedding.Enabled {
+		memoryThreshold, err := opts.LoadShedding.MemoryThresholdBytes()
+		if err != nil {
+			return nil, err
+		}
+		etcdLatency, err := newEtcdLatencyProbe(c.GenericConfig.LoopbackClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		c.LoadSheddingWatchdog = loadshedding.NewWatchdog(
+			memoryThreshold,
+			opts.LoadShedding.EtcdLatencyThreshold,
+			opts.LoadShedding.CheckInterval,
+			etcdLatency,
+			updateShardLoadCondition(c.RootShardKcpClusterClient, opts.Extra.ShardName),
+		)
+	}
+
+	var customSubresourceClientCert *tls.Certificate
+	if opts.Extra.CustomSubresourceClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.Extra.CustomSubresourceClientCertFile, opts.Extra.CustomSubresourceClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom subresource client certificate: %w", err)
+		}
+		customSubresourceClientCert = &cert
+	}
+
 	if err := opts.GenericControlPlane.Audit.ApplyTo(c.GenericConfig); err != nil {
 		return nil, err
 	}
@@ -318,10 +431,54 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 	// to give handlers below one mux.Handle func to call.
 	c.preHandlerChainMux = &handlerChainMuxes{}
 	c.GenericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, genericConfig *genericapiserver.Config) (secure http.Handler) {
+		apiHandler = kcpfilters.WithRequestTraceStorageTarget(apiHandler, opts.Extra.ShardName)
 		apiHandler = WithWildcardListWatchGuard(apiHandler)
+		localAPIExportLister := c.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Lister()
+		cacheAPIExportLister := c.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Lister()
+		getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			export, err := localAPIExportLister.Cluster(clusterName).Get(name)
+			if apierrors.IsNotFound(err) {
+				return cacheAPIExportLister.Cluster(clusterName).Get(name)
+			}
+			return export, err
+		}
+		localAPIResourceSchemaLister := c.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister()
+		cacheAPIResourceSchemaLister := c.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister()
+		apiHandler = WithCustomSubresources(apiHandler,
+			c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer(),
+			func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+				sch, err := localAPIResourceSchemaLister.Cluster(clusterName).Get(name)
+				if apierrors.IsNotFound(err) {
+					return cacheAPIResourceSchemaLister.Cluster(clusterName).Get(name)
+				}
+				return sch, err
+			},
+			getAPIExport,
+			customSubresourceClientCert,
+		)
+		apiHandler = WithAPIExportDeprecationWarnings(apiHandler,
+			c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer(),
+			getAPIExport,
+		)
+		if opts.Controllers.APIExportUsage.Enabled {
+			apiHandler = WithAPIExportUsageMetrics(apiHandler, c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer())
+		}
+		if opts.Controllers.WorkspaceUsage.Enabled {
+			apiHandler = WithWorkspaceUsageMetrics(apiHandler)
+		}
+		if opts.Extra.ShardUsageHeartbeatInterval > 0 {
+			apiHandler = WithShardUsage(apiHandler)
+		}
 		apiHandler = WithRequestIdentity(apiHandler)
 		apiHandler = authorization.WithDeepSubjectAccessReview(apiHandler)
 
+		apiHandler = kcpfilters.WithThrottlingExemption(apiHandler, c.ThrottlingExemptions.Exempt)
+
+		if opts.LoadShedding.Enabled {
+			apiHandler = kcpfilters.WithLoadShedding(apiHandler, c.LoadSheddingWatchdog.Degraded, sets.NewString(user.APIServerUser))
+		}
+
+		apiHandler = kcpfilters.WithRequestTraceStep(apiHandler, "authorized")
 		apiHandler = genericapiserver.DefaultBuildHandlerChainFromAuthz(apiHandler, genericConfig)
 
 		if opts.HomeWorkspaces.Enabled {
@@ -343,6 +500,9 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 			}
 		}
 
+		// must run after impersonation, before authorization
+		apiHandler = kcpfilters.WithRequestTrace(apiHandler)
+
 		authorizerWithoutAudit := genericConfig.Authorization.Authorizer
 		genericConfig.Authorization.Authorizer = authorization.EnableAuditLogging(genericConfig.Authorization.Authorizer)
 		apiHandler = genericapiserver.DefaultBuildHandlerChainBeforeAuthz(apiHandler, genericConfig)
@@ -387,6 +547,8 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		kcpadmissioninitializers.NewKubeClusterClientInitializer(c.KubeClusterClient),
 		kcpadmissioninitializers.NewKcpClusterClientInitializer(c.KcpClusterClient),
 		kcpadmissioninitializers.NewDeepSARClientInitializer(c.DeepSARClient),
+		kcpadmissioninitializers.NewThrottlingExemptionsInitializer(c.ThrottlingExemptions),
+		kcpadmissioninitializers.NewPathClaimsInitializer(c.PathClaims),
 		// The external address is provided as a function, as its value may be updated
 		// with the default secure port, when the config is later completed.
 		kcpadmissioninitializers.NewKubeQuotaConfigurationInitializer(quotaConfiguration),
@@ -424,34 +586,44 @@ func NewConfig(opts *kcpserveroptions.CompletedOptions) (*Config, error) {
 		admissionPluginInitializers,
 		opts.GenericControlPlane,
 
-		// Wire in a ServiceResolver that always returns an error that ResolveEndpoint is not yet
-		// supported. The effect is that CRD webhook conversions are not supported and will always get an
-		// error.
-		&unimplementedServiceResolver{},
-
-		webhook.NewDefaultAuthenticationInfoResolverWrapper(
-			nil,
-			c.Apis.GenericConfig.EgressSelector,
+		// Wire in a ServiceResolver that only resolves the reserved service of APIResourceSchema
+		// conversions to the shard itself. Other CRD webhook conversions are not supported and will
+		// always get an error.
+		&apiResourceSchemaConversionServiceResolver{loopbackClientConfig: c.Apis.GenericConfig.LoopbackClientConfig},
+
+		withAPIResourceSchemaConversionAuthentication(
+			webhook.NewDefaultAuthenticationInfoResolverWrapper(
+				nil,
+				c.Apis.GenericConfig.EgressSelector,
+				c.Apis.GenericConfig.LoopbackClientConfig,
+				c.Apis.GenericConfig.TracerProvider,
+			),
 			c.Apis.GenericConfig.LoopbackClientConfig,
-			c.Apis.GenericConfig.TracerProvider,
 		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("configure api extensions: %w", err)
 	}
 
+	// privileged repair requests in logical clusters in recovery mode skip admission of all servers
+	logicalClusterLister := c.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister()
+	c.Apis.GenericConfig.AdmissionControl = recoverymode.WithBypass(c.Apis.GenericConfig.AdmissionControl, logicalClusterLister)
+	c.ApiExtensions.GenericConfig.AdmissionControl = recoverymode.WithBypass(c.ApiExtensions.GenericConfig.AdmissionControl, logicalClusterLister)
+
 	c.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().GetIndexer().AddIndexers(cache.Indexers{byGroupResourceName: indexCRDByGroupResourceName})       //nolint:errcheck
 	c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer().AddIndexers(cache.Indexers{byIdentityGroupResource: indexAPIBindingByIdentityGroupResource})                   //nolint:errcheck
+	c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer().AddIndexers(cache.Indexers{byClusterGroupResource: indexAPIBindingByClusterGroupResource})                     //nolint:errcheck
 	c.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets().Informer().GetIndexer().AddIndexers(cache.Indexers{indexers.SyncTargetsBySyncTargetKey: indexers.IndexSyncTargetsBySyncTargetKey}) //nolint:errcheck
 
 	c.ApiExtensions.ExtraConfig.ClusterAwareCRDLister = &apiBindingAwareCRDClusterLister{
-		kcpClusterClient:  c.KcpClusterClient,
-		crdLister:         c.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Lister(),
-		crdIndexer:        c.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().GetIndexer(),
-		workspaceLister:   c.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces().Lister(),
-		apiBindingLister:  c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Lister(),
-		apiBindingIndexer: c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer(),
-		apiExportIndexer:  c.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().GetIndexer(),
+		kcpClusterClient:   c.KcpClusterClient,
+		crdLister:          c.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Lister(),
+		crdIndexer:         c.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().GetIndexer(),
+		boundCRDPartitions: apibinding.BoundCRDPartitions(opts.Extra.BoundCRDPartitions),
+		workspaceLister:    c.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces().Lister(),
+		apiBindingLister:   c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Lister(),
+		apiBindingIndexer:  c.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().GetIndexer(),
+		apiExportIndexer:   c.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().GetIndexer(),
 		getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
 			return c.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister().Cluster(clusterName).Get(name)
 		},
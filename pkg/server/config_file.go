@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+)
+
+// configFileReloadInterval is the interval of checking the configuration file for changes.
+const configFileReloadInterval = 10 * time.Second
+
+// installConfigFileReloader watches the configuration file given with --config, and applies
+// changes of the reloadable flags to the running shard. Other changes are logged as requiring
+// a restart. Invalid files are logged and ignored, keeping the last applied configuration.
+// spec.configuration of the Shard takes precedence over the file.
+func (s *Server) installConfigFileReloader(ctx context.Context) error {
+	hookName := "kcp-config-file-reloader"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", hookName, "file", s.Options.Extra.ConfigFile)
+
+		startup, err := os.ReadFile(s.Options.Extra.ConfigFile)
+		if err != nil {
+			logger.Error(err, "failed to read configuration file, not reloading it")
+			return nil
+		}
+		initial, err := kcpserveroptions.ParseConfiguration(startup)
+		if err != nil {
+			logger.Error(err, "failed to parse configuration file, not reloading it")
+			return nil
+		}
+
+		last := startup
+		ctx := klog.NewContext(goContext(hookContext), logger)
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			bs, err := os.ReadFile(s.Options.Extra.ConfigFile)
+			if err != nil {
+				logger.Error(err, "failed to read configuration file")
+				return
+			}
+			if bytes.Equal(bs, last) {
+				return
+			}
+			last = bs
+
+			c, err := kcpserveroptions.ParseConfiguration(bs)
+			if err != nil {
+				logger.Error(err, "ignoring invalid configuration file")
+				return
+			}
+			if fields := initial.RestartRequired(c); len(fields) > 0 {
+				logger.Info("configuration file changed, a restart is required to apply some of the changes", "fields", fields)
+			}
+
+			s.reloadLock.Lock()
+			defer s.reloadLock.Unlock()
+			previous := s.reloadedConfigFile
+			s.reloadedConfigFile = c
+			if err := s.applyReloadableOptions(ctx); err != nil {
+				logger.Error(err, "ignoring invalid configuration file")
+				s.reloadedConfigFile = previous
+			}
+		}, configFileReloadInterval)
+
+		return nil
+	})
+}
+
+// applyReloadableOptions applies the reloadable options for the last reloaded configuration
+// file and spec.configuration of the Shard to the running shard. The caller must hold
+// reloadLock.
+func (s *Server) applyReloadableOptions(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+
+	r, err := s.Options.ReloadableOptions().Reload(s.Options.Extra, s.reloadedConfigFile, s.reloadedShardConfiguration)
+	if err != nil {
+		return err
+	}
+
+	if s.LoadSheddingWatchdog != nil && r.LoadShedding != s.reloadedOptions.LoadShedding {
+		memoryThreshold, err := r.LoadShedding.MemoryThresholdBytes()
+		if err != nil {
+			return err
+		}
+		logger.Info("applying load shedding thresholds", "memoryThreshold", r.LoadShedding.MemoryThreshold, "etcdLatencyThreshold", r.LoadShedding.EtcdLatencyThreshold)
+		s.LoadSheddingWatchdog.SetThresholds(memoryThreshold, r.LoadShedding.EtcdLatencyThreshold)
+	}
+	if r.CommitterBatchingWindow != s.reloadedOptions.CommitterBatchingWindow || r.CommitterConcurrencyLimit != s.reloadedOptions.CommitterConcurrencyLimit {
+		logger.Info("applying committer options", "batchingWindow", r.CommitterBatchingWindow, "concurrencyLimit", r.CommitterConcurrencyLimit)
+		s.CommitterBatcher.SetWindow(r.CommitterBatchingWindow)
+		s.CommitterBatcher.SetConcurrencyLimit(r.CommitterConcurrencyLimit)
+	}
+
+	s.reloadedOptions = *r
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ControllerManagerKubeConfigFile is the kubeconfig written to the root directory for
+	// kcp-controller-manager, if controller groups are run out-of-process.
+	ControllerManagerKubeConfigFile = "controller-manager.kubeconfig"
+	controllerManagerTokenFile      = "controller-manager.token"
+)
+
+// writeControllerManagerKubeConfig writes the kubeconfig of kcp-controller-manager, which
+// authenticates with the loopback token of this process. The token changes with every start,
+// hence the kubeconfig references a token file, which client-go re-reads periodically. This way
+// a running kcp-controller-manager continues with the new token after kcp is restarted.
+func (s *Server) writeControllerManagerKubeConfig() error {
+	rootDir := s.Options.Extra.RootDirectory
+	caCert, _ := s.GenericConfig.SecureServing.Cert.CurrentCertKeyContent()
+
+	tokenFile := filepath.Join(rootDir, controllerManagerTokenFile)
+	if err := os.WriteFile(tokenFile, []byte(s.GenericConfig.LoopbackClientConfig.BearerToken), 0600); err != nil {
+		return fmt.Errorf("failed to write the controller manager token file %q: %w", tokenFile, err)
+	}
+
+	kubeConfig := clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"shard": {
+				Server:                   "https://" + s.GenericConfig.ExternalAddress,
+				CertificateAuthorityData: caCert,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"loopback": {
+				TokenFile: tokenFile,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"shard": {
+				Cluster:  "shard",
+				AuthInfo: "loopback",
+			},
+		},
+		CurrentContext: "shard",
+	}
+	return clientcmd.WriteToFile(kubeConfig, filepath.Join(rootDir, ControllerManagerKubeConfigFile))
+}
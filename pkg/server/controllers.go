@@ -47,17 +47,20 @@ import (
 	"k8s.io/kubernetes/pkg/serviceaccount"
 
 	configuniversal "github.com/kcp-dev/kcp/config/universal"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibindingdeletion"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportconsumers"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportconsumersummary"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportdefaultendpointslice"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportendpointslice"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportmigration"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportschemalint"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportusage"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiresource"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiresourceschemacompat"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/crdcleanup"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/extraannotationsync"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/identitycache"
@@ -68,13 +71,25 @@ import (
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/shard"
 	"github.com/kcp-dev/kcp/pkg/reconciler/garbagecollector"
 	"github.com/kcp-dev/kcp/pkg/reconciler/kubequota"
+	"github.com/kcp-dev/kcp/pkg/reconciler/maintenance"
+	"github.com/kcp-dev/kcp/pkg/reconciler/ratelimiter"
+	"github.com/kcp-dev/kcp/pkg/reconciler/rbac/bindingexpiry"
+	"github.com/kcp-dev/kcp/pkg/reconciler/rbac/propagation"
 	schedulinglocationstatus "github.com/kcp-dev/kcp/pkg/reconciler/scheduling/location"
 	schedulingplacement "github.com/kcp-dev/kcp/pkg/reconciler/scheduling/placement"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/bootstrap"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/datajob"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/initialization"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/limitincreaserequest"
 	tenancylogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/logicalcluster"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/retention"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacequota"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacesummary"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacetombstone"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacetype"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacetyperollout"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspaceusage"
 	workloadsapiexport "github.com/kcp-dev/kcp/pkg/reconciler/workload/apiexport"
 	workloadsapiexportcreate "github.com/kcp-dev/kcp/pkg/reconciler/workload/apiexportcreate"
 	"github.com/kcp-dev/kcp/pkg/reconciler/workload/heartbeat"
@@ -84,6 +99,10 @@ import (
 	synctargetcontroller "github.com/kcp-dev/kcp/pkg/reconciler/workload/synctarget"
 	"github.com/kcp-dev/kcp/pkg/reconciler/workload/synctargetexports"
 	initializingworkspacesbuilder "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/builder"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 func postStartHookName(controllerName string) string {
@@ -303,10 +322,12 @@ func (s *Server) installTenancyLogicalClusterController(ctx context.Context, con
 		return err
 	}
 
+	controllerSwitch := s.ControllerSwitchboard.Register(tenancylogicalcluster.ControllerName, 10)
 	controller := tenancylogicalcluster.NewController(
 		kubeClusterClient,
 		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		controllerSwitch,
 	)
 
 	return s.AddPostStartHook(postStartHookName(tenancylogicalcluster.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
@@ -316,7 +337,7 @@ func (s *Server) installTenancyLogicalClusterController(ctx context.Context, con
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go controller.Start(ctx, 10)
+		go controller.Start(ctx, controllerSwitch.MaxWorkers())
 		return nil
 	})
 }
@@ -346,6 +367,7 @@ func (s *Server) installLogicalClusterDeletionController(ctx context.Context, co
 		return err
 	}
 
+	controllerSwitch := s.ControllerSwitchboard.Register(logicalclusterdeletion.ControllerName, 10)
 	logicalClusterDeletionController := logicalclusterdeletion.NewController(
 		kubeClusterClient,
 		kcpClusterClient,
@@ -354,6 +376,8 @@ func (s *Server) installLogicalClusterDeletionController(ctx context.Context, co
 		metadataClusterClient,
 		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		discoverResourcesFn,
+		s.PathClaims.Release,
+		controllerSwitch,
 	)
 
 	return s.AddPostStartHook(postStartHookName(logicalclusterdeletion.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
@@ -363,7 +387,7 @@ func (s *Server) installLogicalClusterDeletionController(ctx context.Context, co
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go logicalClusterDeletionController.Start(ctx, 10)
+		go logicalClusterDeletionController.Start(ctx, controllerSwitch.MaxWorkers())
 		return nil
 	})
 }
@@ -411,20 +435,32 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	if err != nil {
 		return err
 	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(workspaceConfig)
+	if err != nil {
+		return err
+	}
 
 	logicalClusterAdminConfig = rest.CopyConfig(logicalClusterAdminConfig)
 	logicalClusterAdminConfig = rest.AddUserAgent(logicalClusterAdminConfig, workspace.ControllerName)
 
+	workspaceSwitch := s.ControllerSwitchboard.Register(workspace.ControllerName, 2)
 	workspaceController, err := workspace.NewController(
 		s.Options.Extra.ShardName,
 		s.CompletedConfig.ShardExternalURL,
 		kcpClusterClient,
 		kubeClusterClient,
+		dynamicClusterClient,
 		logicalClusterAdminConfig,
 		s.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces(),
 		s.KcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.Options.Controllers.AnnotateTimeToReady,
+		s.Options.Controllers.WorkspaceTombstoneDuration,
+		s.Options.Controllers.WorkspaceMountDNSBaseDomain,
+		s.Options.Controllers.WorkspaceMountDNSNameTemplate,
+		s.CommitterBatcher,
+		workspaceSwitch,
 	)
 	if err != nil {
 		return err
@@ -436,7 +472,7 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
-		go workspaceController.Start(ctx, 2)
+		go workspaceController.Start(ctx, workspaceSwitch.MaxWorkers())
 		return nil
 	}); err != nil {
 		return err
@@ -659,6 +695,7 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		return err
 	}
 
+	apiBindingSwitch := s.ControllerSwitchboard.Register(apibinding.ControllerName, 2)
 	c, err := apibinding.NewController(
 		crdClusterClient,
 		kcpClusterClient,
@@ -670,6 +707,10 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
 		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		apibinding.BoundCRDPartitions(s.Options.Extra.BoundCRDPartitions),
+		s.Options.Controllers.AnnotateTimeToReady,
+		s.CommitterBatcher,
+		apiBindingSwitch,
 	)
 	if err != nil {
 		return err
@@ -693,7 +734,7 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), apiBindingSwitch.MaxWorkers())
 
 		return nil
 	}); err != nil {
@@ -718,6 +759,7 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		ddsif,
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
 	)
 	if err != nil {
 		return err
@@ -853,6 +895,100 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 	})
 }
 
+func (s *Server) installTemplateInitializerController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	// Clients used to create the template objects within the initializing workspace
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, initialization.TemplateControllerName)
+	// TODO(ncdc): support standalone vw server when --shard-virtual-workspace-url is set
+	config.Host += initializingworkspacesbuilder.URLFor(tenancyv1alpha1.WorkspaceTemplateInitializer)
+	initializingWorkspacesKcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	initializingWorkspacesKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	initializingWorkspacesDynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	informerClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// This informer factory is created here because it is specifically against the initializing workspaces virtual
+	// workspace.
+	initializingWorkspacesKcpInformers := kcpinformers.NewSharedInformerFactoryWithOptions(
+		informerClient,
+		resyncPeriod,
+	)
+
+	c, err := initialization.NewTemplateInitializer(
+		initializingWorkspacesKcpClusterClient,
+		initializingWorkspacesKubeClusterClient,
+		initializingWorkspacesDynamicClusterClient,
+		initializingWorkspacesKcpInformers.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTemplates(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(initialization.TemplateControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(initialization.TemplateControllerName))
+
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		initializingWorkspacesKcpInformers.Start(hookContext.StopCh)
+		initializingWorkspacesKcpInformers.WaitForCacheSync(hookContext.StopCh)
+
+		go c.Start(goContext(hookContext), 2)
+		return nil
+	})
+}
+
+func (s *Server) installAPIBindingUpgraderController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, initialization.UpgraderControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := initialization.NewAPIBindingUpgrader(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(initialization.UpgraderControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(initialization.UpgraderControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
 func (s *Server) installCRDCleanupController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
 	config = rest.AddUserAgent(config, crdcleanup.ControllerName)
@@ -862,10 +998,13 @@ func (s *Server) installCRDCleanupController(ctx context.Context, config *rest.C
 		return err
 	}
 
+	controllerSwitch := s.ControllerSwitchboard.Register(crdcleanup.ControllerName, 2)
 	c, err := crdcleanup.NewController(
 		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
 		crdClusterClient,
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		apibinding.BoundCRDPartitions(s.Options.Extra.BoundCRDPartitions),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
@@ -878,7 +1017,7 @@ func (s *Server) installCRDCleanupController(ctx context.Context, config *rest.C
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
 	})
@@ -898,6 +1037,7 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 		return err
 	}
 
+	controllerSwitch := s.ControllerSwitchboard.Register(apiexport.ControllerName, 2)
 	c, err := apiexport.NewController(
 		kcpClusterClient,
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
@@ -905,6 +1045,7 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 		kubeClusterClient,
 		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
 		s.KubeSharedInformerFactory.Core().V1().Secrets(),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
@@ -924,34 +1065,32 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
 	})
 }
 
-func (s *Server) installAPIExportEndpointSliceController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installAPIExportConsumersController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, apiexportendpointslice.ControllerName)
+	config = rest.AddUserAgent(config, apiexportconsumers.ControllerName)
 
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	c, err := apiexportendpointslice.NewController(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
-		// Shards and APIExports get retrieved from cache server
-		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
-		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	c, err := apiexportconsumers.NewController(
 		kcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportConsumerSummaries(),
 	)
 	if err != nil {
 		return err
 	}
 
-	return s.AddPostStartHook(postStartHookName(apiexportendpointslice.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportendpointslice.ControllerName))
+	return server.AddPostStartHook(postStartHookName(apiexportconsumers.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportconsumers.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
@@ -963,93 +1102,180 @@ func (s *Server) installAPIExportEndpointSliceController(ctx context.Context, co
 	})
 }
 
-func (s *Server) installSchedulingLocationStatusController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
-	controllerName := "kcp-scheduling-location-status-controller"
+func (s *Server) installAPIExportConsumerSummaryController(ctx context.Context, server *genericapiserver.GenericAPIServer) error {
+	controllerSwitch := s.ControllerSwitchboard.Register(apiexportconsumersummary.ControllerName, 2)
+	c, err := apiexportconsumersummary.NewController(
+		s.Options.Extra.ShardName,
+		s.CacheKcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportConsumerSummaries(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(apiexportconsumersummary.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportconsumersummary.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installAPIExportDefaultEndpointSliceController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, controllerName)
+	config = rest.AddUserAgent(config, apiexportdefaultendpointslice.ControllerName)
 
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	c, err := schedulinglocationstatus.NewController(
+	controllerSwitch := s.ControllerSwitchboard.Register(apiexportdefaultendpointslice.ControllerName, 2)
+	c, err := apiexportdefaultendpointslice.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
-		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
 	}
 
-	return server.AddPostStartHook(postStartHookName(controllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(controllerName))
+	return server.AddPostStartHook(postStartHookName(apiexportdefaultendpointslice.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportdefaultendpointslice.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
 	})
 }
 
-func (s *Server) installWorkloadNamespaceScheduler(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installAPIExportSchemaLintController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, workloadnamespace.ControllerName)
-	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	config = rest.AddUserAgent(config, apiexportschemalint.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	c, err := workloadnamespace.NewController(
-		kubeClusterClient,
-		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+	controllerSwitch := s.ControllerSwitchboard.Register(apiexportschemalint.ControllerName, 2)
+	c, err := apiexportschemalint.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err := server.AddPostStartHook(postStartHookName(workloadnamespace.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadnamespace.ControllerName))
+	return server.AddPostStartHook(postStartHookName(apiexportschemalint.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportschemalint.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
-	}); err != nil {
+	})
+}
+
+func (s *Server) installAPIResourceSchemaCompatController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, apiresourceschemacompat.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	controllerSwitch := s.ControllerSwitchboard.Register(apiresourceschemacompat.ControllerName, 2)
+	c, err := apiresourceschemacompat.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(apiresourceschemacompat.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiresourceschemacompat.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
 }
 
-func (s *Server) installWorkloadPlacementScheduler(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installAPIExportUsageController(ctx context.Context, server *genericapiserver.GenericAPIServer) error {
+	c, err := apiexportusage.NewController(
+		s.Options.Controllers.APIExportUsage.Interval,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.DiscoveringDynamicSharedInformerFactory,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(apiexportusage.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportusage.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext))
+
+		return nil
+	})
+}
+
+func (s *Server) installAPIExportMigrationController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, workloadplacement.ControllerName)
+	config = rest.AddUserAgent(config, apiexportmigration.ControllerName)
+
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	c, err := workloadplacement.NewController(
+	c, err := apiexportmigration.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
-		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+		s.DeepSARClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		// target APIExports and APIExports of other shards get retrieved from cache server
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportConsumerSummaries(),
 	)
 	if err != nil {
 		return err
 	}
 
-	return server.AddPostStartHook(postStartHookName(workloadplacement.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadplacement.ControllerName))
+	return server.AddPostStartHook(postStartHookName(apiexportmigration.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportmigration.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
@@ -1061,58 +1287,77 @@ func (s *Server) installWorkloadPlacementScheduler(ctx context.Context, config *
 	})
 }
 
-func (s *Server) installSchedulingPlacementController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installAPIExportEndpointSliceController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, schedulingplacement.ControllerName)
+	config = rest.AddUserAgent(config, apiexportendpointslice.ControllerName)
+
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	c, err := schedulingplacement.NewController(
+	controllerSwitch := s.ControllerSwitchboard.Register(apiexportendpointslice.ControllerName, 2)
+	c, err := apiexportendpointslice.NewController(
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
+		// Shards, APIExports and APIExportConsumerSummaries get retrieved from cache server
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportConsumerSummaries(),
 		kcpClusterClient,
-		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
-		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+		s.Options.Controllers.APIExportEndpointSlice.EndpointProbeInterval,
+		s.Options.Controllers.APIExportEndpointSlice.EndpointProbeTimeout,
+		s.Options.Controllers.APIExportEndpointSlice.EndpointDNSBaseDomain,
+		s.Options.Controllers.APIExportEndpointSlice.EndpointDNSNameTemplate,
+		s.LoadSheddingWatchdog.RegisterLowPriorityController(apiexportendpointslice.ControllerName),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
 	}
 
-	return server.AddPostStartHook(postStartHookName(schedulingplacement.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(schedulingplacement.ControllerName))
+	return s.AddPostStartHook(postStartHookName(apiexportendpointslice.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(apiexportendpointslice.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
 	})
 }
 
-func (s *Server) installWorkloadsAPIExportController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installRetentionController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, workloadsapiexport.ControllerName)
+	config = rest.AddUserAgent(config, retention.ControllerName)
+
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	metadataClient, err := kcpmetadata.NewForConfig(config)
+	if err != nil {
+		return err
+	}
 
-	c, err := workloadsapiexport.NewController(
+	c, err := retention.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
-		s.KcpSharedInformerFactory.Apiresource().V1alpha1().NegotiatedAPIResources(),
-		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
+		kubeClusterClient,
+		metadataClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().RetentionPolicies(),
 	)
 	if err != nil {
 		return err
 	}
 
-	return server.AddPostStartHook(postStartHookName(workloadsapiexport.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadsapiexport.ControllerName))
+	return server.AddPostStartHook(postStartHookName(retention.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(retention.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
@@ -1124,30 +1369,541 @@ func (s *Server) installWorkloadsAPIExportController(ctx context.Context, config
 	})
 }
 
-func (s *Server) installExtraAnnotationSyncController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+func (s *Server) installLimitIncreaseRequestController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
 	config = rest.CopyConfig(config)
-	config = rest.AddUserAgent(config, extraannotationsync.ControllerName)
+	config = rest.AddUserAgent(config, limitincreaserequest.ControllerName)
+
 	kcpClusterClient, err := kcpclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
 
-	c, err := extraannotationsync.NewController(kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+	controllerSwitch := s.ControllerSwitchboard.Register(limitincreaserequest.ControllerName, 2)
+	c, err := limitincreaserequest.NewController(
+		kcpClusterClient,
+		kubeClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().LimitIncreaseRequests(),
+		controllerSwitch,
 	)
 	if err != nil {
 		return err
 	}
 
-	return server.AddPostStartHook(postStartHookName(extraannotationsync.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
-		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadsapiexport.ControllerName))
+	return server.AddPostStartHook(postStartHookName(limitincreaserequest.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(limitincreaserequest.ControllerName))
 		if err := s.waitForSync(hookContext.StopCh); err != nil {
 			logger.Error(err, "failed to finish post-start-hook")
 			return nil // don't klog.Fatal. This only happens when context is cancelled.
 		}
 
-		go c.Start(goContext(hookContext), 2)
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkspaceQuotaController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacequota.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	notifiers := []workspacequota.Notifier{workspacequota.NewEventNotifier(kubeClusterClient)}
+	if s.Options.Controllers.QuotaNotificationWebhooks {
+		notifiers = append(notifiers, workspacequota.NewWebhookNotifier())
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(workspacequota.ControllerName, 2)
+	c, err := workspacequota.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceQuotas(),
+		s.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.DiscoveringDynamicSharedInformerFactory,
+		controllerSwitch,
+		notifiers,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workspacequota.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workspacequota.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkspaceTombstoneController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacetombstone.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(workspacetombstone.ControllerName, 2)
+	c, err := workspacetombstone.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTombstones(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workspacetombstone.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workspacetombstone.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkspaceTypeRolloutController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacetyperollout.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(workspacetyperollout.ControllerName, 2)
+	c, err := workspacetyperollout.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workspacetyperollout.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workspacetyperollout.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installDataJobController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, datajob.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(datajob.ControllerName, 2)
+	c, err := datajob.NewController(
+		kcpClusterClient,
+		dynamicClusterClient,
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().DataJobs(),
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(datajob.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(datajob.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkspaceUsageController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspaceusage.ControllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workspaceusage.NewController(
+		s.Options.Controllers.WorkspaceUsage.Interval,
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceUsages(),
+		s.DiscoveringDynamicSharedInformerFactory,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workspaceusage.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workspaceusage.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext))
+
+		return nil
+	})
+}
+
+func (s *Server) installBindingExpiryController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, bindingexpiry.ControllerName)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(bindingexpiry.ControllerName, 2)
+	c, err := bindingexpiry.NewController(
+		kubeClusterClient,
+		s.KubeSharedInformerFactory.Rbac().V1().RoleBindings(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(bindingexpiry.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(bindingexpiry.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
+
+		return nil
+	})
+}
+
+func (s *Server) installRateLimiterStatePersister(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, ratelimiter.PersisterName)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	p := ratelimiter.NewPersister(kubeClusterClient)
+
+	return server.AddPostStartHook(postStartHookName(ratelimiter.PersisterName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(ratelimiter.PersisterName))
+		ctx := klog.NewContext(goContext(hookContext), logger)
+
+		// the queues of the persistent rate limiters hold back all items until the state is restored.
+		if err := p.Restore(ctx); err != nil {
+			logger.Error(err, "failed to restore rate limiter state")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go p.Start(ctx)
+
+		return nil
+	})
+}
+
+func (s *Server) installMaintenanceScheduler(ctx context.Context, server *genericapiserver.GenericAPIServer) error {
+	return server.AddPostStartHook(postStartHookName(maintenance.SchedulerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(maintenance.SchedulerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		// all controllers have registered their tasks by now.
+		go s.MaintenanceScheduler.Start(klog.NewContext(goContext(hookContext), logger))
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkspaceSummaryController(ctx context.Context, logicalClusterAdminConfig *rest.Config, shardExternalURL func() string) error {
+	logicalClusterAdminConfig = rest.CopyConfig(logicalClusterAdminConfig)
+	logicalClusterAdminConfig = rest.AddUserAgent(logicalClusterAdminConfig, workspacesummary.ControllerName)
+
+	c := workspacesummary.NewController(
+		logicalClusterAdminConfig,
+		shardExternalURL,
+		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.KubeSharedInformerFactory.Core().V1().ResourceQuotas(),
+	)
+
+	return s.AddPostStartHook(postStartHookName(workspacesummary.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workspacesummary.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installRBACPropagationController(ctx context.Context, logicalClusterAdminConfig *rest.Config, shardExternalURL func() string) error {
+	logicalClusterAdminConfig = rest.CopyConfig(logicalClusterAdminConfig)
+	logicalClusterAdminConfig = rest.AddUserAgent(logicalClusterAdminConfig, propagation.ControllerName)
+
+	c := propagation.NewController(
+		logicalClusterAdminConfig,
+		shardExternalURL,
+		s.KcpSharedInformerFactory.Tenancy().V1beta1().Workspaces(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
+		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
+	)
+
+	return s.AddPostStartHook(postStartHookName(propagation.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(propagation.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installSchedulingLocationStatusController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	controllerName := "kcp-scheduling-location-status-controller"
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, controllerName)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := schedulinglocationstatus.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
+		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(controllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(controllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkloadNamespaceScheduler(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workloadnamespace.ControllerName)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workloadnamespace.NewController(
+		kubeClusterClient,
+		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := server.AddPostStartHook(postStartHookName(workloadnamespace.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadnamespace.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) installWorkloadPlacementScheduler(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workloadplacement.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workloadplacement.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
+		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workloadplacement.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadplacement.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installSchedulingPlacementController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, schedulingplacement.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := schedulingplacement.NewController(
+		kcpClusterClient,
+		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Locations(),
+		s.KcpSharedInformerFactory.Scheduling().V1alpha1().Placements(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(schedulingplacement.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(schedulingplacement.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installWorkloadsAPIExportController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workloadsapiexport.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workloadsapiexport.NewController(
+		kcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		s.KcpSharedInformerFactory.Apiresource().V1alpha1().NegotiatedAPIResources(),
+		s.KcpSharedInformerFactory.Workload().V1alpha1().SyncTargets(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(workloadsapiexport.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadsapiexport.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), 2)
+
+		return nil
+	})
+}
+
+func (s *Server) installExtraAnnotationSyncController(ctx context.Context, config *rest.Config, server *genericapiserver.GenericAPIServer) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, extraannotationsync.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	controllerSwitch := s.ControllerSwitchboard.Register(extraannotationsync.ControllerName, 2)
+	c, err := extraannotationsync.NewController(kcpClusterClient,
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.Options.Controllers.APIExportExtraAnnotationSync.PatchQPS,
+		s.Options.Controllers.APIExportExtraAnnotationSync.PatchBurst,
+		s.Options.Controllers.APIExportExtraAnnotationSync.AnnotationPrefixes,
+		s.Options.Controllers.APIExportExtraAnnotationSync.LabelPrefixes,
+		s.LoadSheddingWatchdog.RegisterLowPriorityController(extraannotationsync.ControllerName),
+		controllerSwitch,
+	)
+	if err != nil {
+		return err
+	}
+
+	return server.AddPostStartHook(postStartHookName(extraannotationsync.ControllerName), func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", postStartHookName(workloadsapiexport.ControllerName))
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go c.Start(goContext(hookContext), controllerSwitch.MaxWorkers())
 
 		return nil
 	})
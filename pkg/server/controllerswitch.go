@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// installControllerSwitchboard applies spec.controllers of the Shard of this shard, as seen
+// through the cache server, to the controller switchboard whenever it changes. Overrides of
+// controllers which are not registered are reported in the ControllersApplied condition.
+func (s *Server) installControllerSwitchboard(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithValues("component", "controller-switchboard", "shard", s.Options.Extra.ShardName)
+	ctx = klog.NewContext(ctx, logger)
+
+	changed := make(chan struct{}, 1)
+	apply := func(overrides []corev1alpha1.ShardControllerOverride) {
+		s.ControllerSwitchboard.Apply(ctx, overrides)
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: s.isOwnShard,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { apply(obj.(*corev1alpha1.Shard).Spec.Controllers) },
+			UpdateFunc: func(_, obj interface{}) { apply(obj.(*corev1alpha1.Shard).Spec.Controllers) },
+			DeleteFunc: func(obj interface{}) { apply(nil) },
+		},
+	})
+
+	hookName := "kcp-controller-switchboard"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := logger.WithValues("postStartHook", hookName)
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		ctx := klog.NewContext(goContext(hookContext), logger)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+					s.recordUnknownControllers(ctx)
+				}
+			}
+		}()
+
+		return nil
+	})
+}
+
+// recordUnknownControllers records the overrides of controllers which are not registered in
+// the ControllersApplied condition of the Shard of this shard.
+func (s *Server) recordUnknownControllers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	shard, err := s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Lister().Cluster(core.RootCluster).Get(s.Options.Extra.ShardName)
+	if errors.IsNotFound(err) {
+		return
+	} else if err != nil {
+		logger.Error(err, "failed to get Shard")
+		return
+	}
+
+	unknown := s.ControllerSwitchboard.Unknown()
+	updated := shard.DeepCopy()
+	setShardControllersCondition(updated, unknown)
+	if equality.Semantic.DeepEqual(shard.Status.Conditions, updated.Status.Conditions) {
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		shard, err := s.RootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Get(ctx, s.Options.Extra.ShardName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		setShardControllersCondition(shard, unknown)
+		_, err = s.RootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().UpdateStatus(ctx, shard, metav1.UpdateOptions{})
+		return err
+	})
+	if errors.IsNotFound(err) {
+		logger.V(2).Info("Shard not found, not recording unknown controllers")
+	} else if err != nil {
+		logger.Error(err, "failed to record unknown controllers in Shard")
+	}
+}
+
+// setShardControllersCondition sets the ControllersApplied condition of the shard, or removes
+// it if the shard has no controller overrides.
+func setShardControllersCondition(shard *corev1alpha1.Shard, unknown []string) {
+	switch {
+	case len(unknown) > 0:
+		conditions.MarkFalse(shard, corev1alpha1.ShardControllersApplied, corev1alpha1.ShardUnknownControllersReason, conditionsv1alpha1.ConditionSeverityWarning, "Controllers not running in the shard process: %s.", strings.Join(unknown, ", "))
+	case len(shard.Spec.Controllers) == 0:
+		conditions.Delete(shard, corev1alpha1.ShardControllersApplied)
+	default:
+		conditions.MarkTrue(shard, corev1alpha1.ShardControllersApplied)
+	}
+}
+
+// isOwnShard returns true if obj is the Shard of this shard, or a tombstone of it.
+func (s *Server) isOwnShard(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	shard, ok := obj.(*corev1alpha1.Shard)
+	return ok && shard.Name == s.Options.Extra.ShardName && logicalcluster.From(shard) == core.RootCluster
+}
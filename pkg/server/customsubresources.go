@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/transport"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+const (
+	customSubresourceUserHeader        = "X-Remote-User"
+	customSubresourceGroupHeader       = "X-Remote-Group"
+	customSubresourceExtraHeaderPrefix = "X-Remote-Extra-"
+)
+
+// customSubresourceProxy forwards requests to custom subresources of bound resources to the
+// handler of the APIExport.
+type customSubresourceProxy struct {
+	apiBindingIndexer    cache.Indexer
+	getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error)
+	getAPIExport         func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	clientCert           *tls.Certificate
+
+	lock       sync.Mutex
+	transports map[string]http.RoundTripper // by CA bundle
+}
+
+// WithCustomSubresources forwards requests to the custom subresources declared in the APIResourceSchemas
+// of bound resources to the customSubresourceHandler of their APIExport. It must run after authorization,
+// which checks the request verb on <resource>/<subresource> like for any other subresource. Requests to
+// subresources not declared by the schema of the requested version are passed on.
+func WithCustomSubresources(
+	handler http.Handler,
+	apiBindingIndexer cache.Indexer,
+	getAPIResourceSchema func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error),
+	getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error),
+	clientCert *tls.Certificate,
+) http.Handler {
+	p := &customSubresourceProxy{
+		apiBindingIndexer:    apiBindingIndexer,
+		getAPIResourceSchema: getAPIResourceSchema,
+		getAPIExport:         getAPIExport,
+		clientCert:           clientCert,
+		transports:           map[string]http.RoundTripper{},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		requestInfo, ok := request.RequestInfoFrom(req.Context())
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard || !ok || !requestInfo.IsResourceRequest ||
+			requestInfo.Name == "" || requestInfo.Subresource == "" || requestInfo.Subresource == "status" || requestInfo.Subresource == "scale" {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		logger := klog.FromContext(req.Context()).WithValues("operation", "WithCustomSubresources", "cluster", cluster.Name, "resource", requestInfo.Resource, "subresource", requestInfo.Subresource)
+
+		export, err := p.apiExportFor(cluster.Name, requestInfo)
+		if err != nil {
+			logger.Error(err, "unable to determine the APIExport")
+			responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), errorCodecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		if export == nil {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		gr := schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}
+		if export.Spec.CustomSubresourceHandler == nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewServiceUnavailable(fmt.Sprintf("no handler for subresource %q of %s configured in APIExport %s|%s", requestInfo.Subresource, gr, logicalcluster.From(export), export.Name)),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		target, err := customSubresourceTarget(export.Spec.CustomSubresourceHandler.URL, cluster.Name, req.URL)
+		if err != nil {
+			logger.Error(err, "invalid custom subresource handler URL", "apiexport", export.Name)
+			responsewriters.ErrorNegotiated(apierrors.NewServiceUnavailable(fmt.Sprintf("invalid handler for subresource %q of %s", requestInfo.Subresource, gr)), errorCodecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		rt, err := p.transport(export.Spec.CustomSubresourceHandler.CABundle)
+		if err != nil {
+			logger.Error(err, "invalid custom subresource handler CA bundle", "apiexport", export.Name)
+			responsewriters.ErrorNegotiated(apierrors.NewServiceUnavailable(fmt.Sprintf("invalid handler for subresource %q of %s", requestInfo.Subresource, gr)), errorCodecs, schema.GroupVersion{}, w, req)
+			return
+		}
+
+		proxy := &httputil.ReverseProxy{
+			Director: func(r *http.Request) {
+				r.URL = target
+				r.Host = target.Host
+				setCustomSubresourceUserHeaders(r)
+			},
+			Transport: rt,
+		}
+		proxy.ServeHTTP(w, req)
+	})
+}
+
+// apiExportFor returns the APIExport whose APIResourceSchema declares the requested subresource in the
+// requested version, or nil if there is none.
+func (p *customSubresourceProxy) apiExportFor(clusterName logicalcluster.Name, requestInfo *request.RequestInfo) (*apisv1alpha1.APIExport, error) {
+	objs, err := p.apiBindingIndexer.ByIndex(byClusterGroupResource, clusterGroupResourceKeyFunc(clusterName, requestInfo.APIGroup, requestInfo.Resource))
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		binding := obj.(*apisv1alpha1.APIBinding)
+		bound := binding.Status.BoundAPIExport
+		if bound == nil {
+			continue
+		}
+		for _, br := range binding.Status.BoundResources {
+			if br.Group != requestInfo.APIGroup || br.Resource != requestInfo.Resource {
+				continue
+			}
+			sch, err := p.getAPIResourceSchema(bound.Cluster.Name(), br.Schema.Name)
+			if apierrors.IsNotFound(err) {
+				continue // the APIBinding controller reports missing schemas
+			} else if err != nil {
+				return nil, err
+			}
+			if !declaresCustomSubresource(sch, requestInfo.APIVersion, requestInfo.Subresource) {
+				continue
+			}
+			return p.getAPIExport(bound.Cluster.Name(), bound.Name)
+		}
+	}
+	return nil, nil
+}
+
+// transport returns a round tripper trusting the given CA bundle, or the system trust roots if empty.
+func (p *customSubresourceProxy) transport(caBundle []byte) (http.RoundTripper, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if rt, ok := p.transports[string(caBundle)]; ok {
+		return rt, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if p.clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*p.clientCert}
+	}
+
+	rt := http.DefaultTransport.(*http.Transport).Clone()
+	rt.TLSClientConfig = tlsConfig
+	p.transports[string(caBundle)] = rt
+	return rt, nil
+}
+
+// declaresCustomSubresource returns whether the given version of the schema declares the custom subresource.
+func declaresCustomSubresource(sch *apisv1alpha1.APIResourceSchema, version, subresource string) bool {
+	for _, v := range sch.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+		for _, sub := range v.CustomSubresources {
+			if sub.Name == subresource {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// customSubresourceTarget returns the URL a request to a custom subresource is forwarded to, i.e. the
+// request path within the consumer cluster, below the handler URL.
+func customSubresourceTarget(handlerURL string, clusterName logicalcluster.Name, reqURL *url.URL) (*url.URL, error) {
+	target, err := url.Parse(handlerURL)
+	if err != nil {
+		return nil, err
+	}
+	if target.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", target.Scheme)
+	}
+	target.Path = strings.TrimSuffix(target.Path, "/") + clusterName.RequestPath() + reqURL.Path
+	target.RawPath = ""
+	target.RawQuery = reqURL.RawQuery
+	return target, nil
+}
+
+// setCustomSubresourceUserHeaders replaces the credentials of the request by the authenticated user.
+func setCustomSubresourceUserHeaders(req *http.Request) {
+	for _, header := range []string{
+		"Authorization",
+		transport.ImpersonateUserHeader,
+		transport.ImpersonateUIDHeader,
+		transport.ImpersonateGroupHeader,
+		customSubresourceUserHeader,
+		customSubresourceGroupHeader,
+	} {
+		req.Header.Del(header)
+	}
+	for key := range req.Header {
+		if strings.HasPrefix(key, transport.ImpersonateUserExtraHeaderPrefix) || strings.HasPrefix(key, customSubresourceExtraHeaderPrefix) {
+			req.Header.Del(key)
+		}
+	}
+
+	u, ok := request.UserFrom(req.Context())
+	if !ok {
+		return
+	}
+	req.Header.Set(customSubresourceUserHeader, u.GetName())
+	for _, group := range u.GetGroups() {
+		req.Header.Add(customSubresourceGroupHeader, group)
+	}
+	for k, values := range u.GetExtra() {
+		for _, v := range values {
+			req.Header.Add(customSubresourceExtraHeaderPrefix+url.PathEscape(k), v)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestDeclaresCustomSubresource(t *testing.T) {
+	sch := &apisv1alpha1.APIResourceSchema{
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{Name: "v1"},
+				{Name: "v2", CustomSubresources: []apisv1alpha1.CustomSubresource{{Name: "approve"}}},
+			},
+		},
+	}
+
+	require.True(t, declaresCustomSubresource(sch, "v2", "approve"))
+	require.False(t, declaresCustomSubresource(sch, "v1", "approve"), "only declared in v2")
+	require.False(t, declaresCustomSubresource(sch, "v2", "bind"))
+	require.False(t, declaresCustomSubresource(sch, "v3", "approve"))
+}
+
+func TestCustomSubresourceTarget(t *testing.T) {
+	tests := map[string]struct {
+		handlerURL  string
+		reqURL      string
+		expected    string
+		expectError bool
+	}{
+		"namespaced": {
+			handlerURL: "https://provider.example.com/subresources/",
+			reqURL:     "/apis/wild.west/v1/namespaces/default/cowboys/lucky-luke/approve?dryRun=All",
+			expected:   "https://provider.example.com/subresources/clusters/2bxu7gdk0wvnsfaz/apis/wild.west/v1/namespaces/default/cowboys/lucky-luke/approve?dryRun=All",
+		},
+		"without path": {
+			handlerURL: "https://provider.example.com",
+			reqURL:     "/apis/wild.west/v1/cowboys/lucky-luke/approve",
+			expected:   "https://provider.example.com/clusters/2bxu7gdk0wvnsfaz/apis/wild.west/v1/cowboys/lucky-luke/approve",
+		},
+		"http": {
+			handlerURL:  "http://provider.example.com",
+			reqURL:      "/apis/wild.west/v1/cowboys/lucky-luke/approve",
+			expectError: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reqURL, err := url.Parse(tt.reqURL)
+			require.NoError(t, err)
+
+			target, err := customSubresourceTarget(tt.handlerURL, logicalcluster.Name("2bxu7gdk0wvnsfaz"), reqURL)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, target.String())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/loadshedding"
+)
+
+// WithLoadShedding rejects expensive requests with 429 while the shard is degraded, i.e.
+// under memory pressure or with slow etcd. Expensive requests are lists and watches in the
+// wildcard logical cluster, which have to read every logical cluster of the shard. Watches
+// are rejected before the initial list, established watches are not affected.
+//
+// Only kcp's own identities, i.e. users with one of the given names, are never rejected,
+// such that kcp's controllers can still reconcile. Everybody else doing wildcard requests,
+// like external controllers and virtual workspace servers, is expected to retry.
+func WithLoadShedding(handler http.Handler, degraded func() bool, kcpUsers sets.String) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !degraded() {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx := req.Context()
+		cluster := request.ClusterFrom(ctx)
+		requestInfo, ok := request.RequestInfoFrom(ctx)
+		if cluster == nil || !cluster.Wildcard || !ok || !requestInfo.IsResourceRequest || !expensiveVerbs.Has(requestInfo.Verb) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+		if u, ok := request.UserFrom(ctx); ok && kcpUsers.Has(u.GetName()) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		gr := schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}
+		loadshedding.RecordRejectedRequest(gr.String())
+		responsewriters.ErrorNegotiated(
+			apierrors.NewTooManyRequests(fmt.Sprintf("the shard is shedding load, wildcard %ss are rejected temporarily", requestInfo.Verb), 10),
+			errorCodecs, schema.GroupVersion{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion}, w, req,
+		)
+	})
+}
+
+var expensiveVerbs = sets.NewString("list", "watch")
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithLoadShedding(t *testing.T) {
+	tenant := &user.DefaultInfo{Name: "alice", Groups: []string{user.AllAuthenticated}}
+	admin := &user.DefaultInfo{Name: "shard-admin", Groups: []string{user.SystemPrivilegedGroup}}
+	kcp := &user.DefaultInfo{Name: user.APIServerUser, Groups: []string{user.SystemPrivilegedGroup}}
+
+	degraded := false
+	handler := WithLoadShedding(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}), func() bool { return degraded }, sets.NewString(user.APIServerUser))
+
+	serve := func(u user.Info, cluster *request.Cluster, verb string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/configmaps", nil)
+		ctx := request.WithUser(req.Context(), u)
+		ctx = request.WithCluster(ctx, *cluster)
+		ctx = request.WithRequestInfo(ctx, &request.RequestInfo{IsResourceRequest: true, Verb: verb, APIVersion: "v1", Resource: "configmaps"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w.Code
+	}
+	wildcard := &request.Cluster{Name: "*", Wildcard: true}
+	single := &request.Cluster{Name: logicalcluster.Name("root")}
+
+	require.Equal(t, http.StatusOK, serve(tenant, wildcard, "list"), "wildcard lists must be served when not degraded")
+
+	degraded = true
+	require.Equal(t, http.StatusTooManyRequests, serve(tenant, wildcard, "list"), "wildcard lists of tenants must be rejected when degraded")
+	require.Equal(t, http.StatusTooManyRequests, serve(tenant, wildcard, "watch"), "wildcard watches of tenants must be rejected when degraded")
+	require.Equal(t, http.StatusTooManyRequests, serve(admin, wildcard, "list"), "wildcard lists of non-kcp admins must be rejected when degraded")
+	require.Equal(t, http.StatusOK, serve(tenant, single, "list"), "lists in a logical cluster must be served when degraded")
+	require.Equal(t, http.StatusOK, serve(tenant, single, "watch"), "watches in a logical cluster must be served when degraded")
+	require.Equal(t, http.StatusOK, serve(kcp, wildcard, "list"), "wildcard lists of kcp must be served when degraded")
+	require.Equal(t, http.StatusOK, serve(kcp, wildcard, "watch"), "wildcard watches of kcp must be served when degraded")
+}
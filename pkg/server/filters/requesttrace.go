@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/requesttrace"
+)
+
+// WithRequestTrace executes requests carrying the requesttrace.TraceHeader with tracing, and
+// returns the trace document instead of the response. The response is part of the trace.
+//
+// Only privileged system users may trace requests. They can execute the request as somebody else,
+// e.g. the tenant whose request fails, through requesttrace.UserHeader and requesttrace.GroupHeader.
+// Impersonation cannot be used for that, as it drops the privileges. Hence, this filter must run
+// after impersonation and before authorization.
+func WithRequestTrace(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(requesttrace.TraceHeader) != "true" {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx := req.Context()
+		requester, ok := request.UserFrom(ctx)
+		if !ok || !sets.NewString(requester.GetGroups()...).Has(user.SystemPrivilegedGroup) {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewForbidden(schema.GroupResource{}, "", fmt.Errorf("only privileged system users may trace requests")),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		if requestInfo, ok := request.RequestInfoFrom(ctx); ok && requestInfo.Verb == "watch" {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewBadRequest("watch requests cannot be traced"),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		traced := requester
+		if name := req.Header.Get(requesttrace.UserHeader); name != "" {
+			groups := req.Header.Values(requesttrace.GroupHeader)
+			if !sets.NewString(groups...).Has(user.AllAuthenticated) {
+				groups = append(groups, user.AllAuthenticated)
+			}
+			traced = &user.DefaultInfo{Name: name, Groups: groups}
+			ctx = request.WithUser(ctx, traced)
+		}
+
+		trace := requesttrace.New(requesttrace.Request{
+			Method:   req.Method,
+			Path:     req.URL.Path,
+			User:     traced.GetName(),
+			Groups:   traced.GetGroups(),
+			TracedBy: requester.GetName(),
+		})
+		trace.AddFilterStep("trace", fmt.Sprintf("tracing the request as %q with groups %s", traced.GetName(), strings.Join(traced.GetGroups(), ",")))
+
+		recorder := &traceResponseWriter{header: http.Header{}, code: http.StatusOK}
+		handler.ServeHTTP(recorder, req.WithContext(requesttrace.WithTrace(ctx, trace)))
+		trace.SetResponse(recorder.code, recorder.body.Bytes())
+
+		bs, err := json.Marshal(trace)
+		if err != nil {
+			responsewriters.InternalError(w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(bs) //nolint:errcheck
+	})
+}
+
+// WithRequestTraceStep records in the trace of traced requests that they passed the point of the
+// filter chain with the given name.
+func WithRequestTraceStep(handler http.Handler, name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requesttrace.From(req.Context()).AddFilterStep(name, "")
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// WithRequestTraceStorageTarget records in the trace of traced requests where they are served.
+// It must be the last filter before the handler.
+func WithRequestTraceStorageTarget(handler http.Handler, shardName string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		trace := requesttrace.From(ctx)
+		if trace == nil {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		target := requesttrace.StorageTarget{Shard: shardName}
+		if cluster := request.ClusterFrom(ctx); cluster != nil {
+			target.Cluster = cluster.Name.String()
+		}
+		if requestInfo, ok := request.RequestInfoFrom(ctx); ok && requestInfo.IsResourceRequest {
+			target.Verb = requestInfo.Verb
+			target.APIGroup = requestInfo.APIGroup
+			target.APIVersion = requestInfo.APIVersion
+			target.Resource = requestInfo.Resource
+			target.Subresource = requestInfo.Subresource
+			target.Namespace = requestInfo.Namespace
+			target.Name = requestInfo.Name
+		}
+		trace.SetStorageTarget(target)
+		trace.AddFilterStep("handler", "")
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// traceResponseWriter records the response of a traced request.
+type traceResponseWriter struct {
+	header      http.Header
+	code        int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *traceResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *traceResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.code = code
+	w.wroteHeader = true
+}
+
+func (w *traceResponseWriter) Write(bs []byte) (int, error) {
+	w.wroteHeader = true
+	return w.body.Write(bs)
+}
+
+// Flush is a no-op, the response is part of the trace written at the end.
+func (w *traceResponseWriter) Flush() {}
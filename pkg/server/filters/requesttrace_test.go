@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/requesttrace"
+)
+
+func TestWithRequestTrace(t *testing.T) {
+	tenant := &user.DefaultInfo{Name: "alice", Groups: []string{user.AllAuthenticated}}
+	admin := &user.DefaultInfo{Name: "shard-admin", Groups: []string{user.SystemPrivilegedGroup}}
+
+	var servedAs user.Info
+	inner := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		servedAs, _ = request.UserFrom(req.Context())
+		requesttrace.From(req.Context()).AddAuthorizerDecision("local.authorization.kcp.io", "Denied", "no rule")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"kind":"Status","code":403}`)) //nolint:errcheck
+	})
+	handler := WithRequestTrace(WithRequestTraceStorageTarget(inner, "alpha"))
+
+	serve := func(u user.Info, verb string, headers map[string][]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/clusters/root:org/api/v1/namespaces/default/configmaps", nil)
+		for k, vs := range headers {
+			for _, v := range vs {
+				req.Header.Add(k, v)
+			}
+		}
+		ctx := request.WithUser(req.Context(), u)
+		ctx = request.WithCluster(ctx, request.Cluster{Name: logicalcluster.Name("root:org")})
+		ctx = request.WithRequestInfo(ctx, &request.RequestInfo{IsResourceRequest: true, Verb: verb, APIVersion: "v1", Namespace: "default", Resource: "configmaps"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	t.Run("untraced requests are passed through", func(t *testing.T) {
+		w := serve(tenant, "list", nil)
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Equal(t, tenant, servedAs)
+	})
+
+	t.Run("tenants cannot trace", func(t *testing.T) {
+		servedAs = nil
+		w := serve(tenant, "list", map[string][]string{requesttrace.TraceHeader: {"true"}})
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Nil(t, servedAs)
+	})
+
+	t.Run("watches cannot be traced", func(t *testing.T) {
+		w := serve(admin, "watch", map[string][]string{requesttrace.TraceHeader: {"true"}})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("admins trace as a tenant", func(t *testing.T) {
+		w := serve(admin, "list", map[string][]string{
+			requesttrace.TraceHeader: {"true"},
+			requesttrace.UserHeader:  {"alice"},
+			requesttrace.GroupHeader: {"dev", "ops"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, "alice", servedAs.GetName())
+		require.Equal(t, []string{"dev", "ops", user.AllAuthenticated}, servedAs.GetGroups())
+
+		var trace requesttrace.Trace
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &trace))
+		require.Equal(t, "alice", trace.Request.User)
+		require.Equal(t, "shard-admin", trace.Request.TracedBy)
+		require.Equal(t, []requesttrace.AuthorizerDecision{{Authorizer: "local.authorization.kcp.io", Decision: "Denied", Reason: "no rule"}}, trace.Authorization)
+		require.Equal(t, &requesttrace.StorageTarget{Shard: "alpha", Cluster: "root:org", Verb: "list", APIVersion: "v1", Resource: "configmaps", Namespace: "default"}, trace.Storage)
+		require.Equal(t, http.StatusForbidden, trace.Response.Code)
+		require.JSONEq(t, `{"kind":"Status","code":403}`, string(trace.Response.Body))
+		require.Len(t, trace.Filters, 2)
+		require.Equal(t, "trace", trace.Filters[0].Name)
+		require.Equal(t, "handler", trace.Filters[1].Name)
+	})
+}
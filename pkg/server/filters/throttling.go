@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/throttling"
+)
+
+const throttlingExemptAnnotation = "tenancy.kcp.io/throttling-exempt"
+
+// WithThrottlingExemption marks requests to logical clusters that are exempt from the
+// per-workspace limits in the request context, such that the limiting filters and admission
+// plugins down the chain take the same decision. Exempt requests are annotated in the audit log.
+func WithThrottlingExemption(handler http.Handler, exempt func(clusterName logicalcluster.Name) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		cluster := request.ClusterFrom(ctx)
+		if cluster == nil || cluster.Wildcard || cluster.Name.Empty() || !exempt(cluster.Name) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		kaudit.AddAuditAnnotation(ctx, throttlingExemptAnnotation, "true")
+		handler.ServeHTTP(w, req.WithContext(throttling.WithExempt(ctx)))
+	})
+}
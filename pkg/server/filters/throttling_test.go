@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/kcp-dev/kcp/pkg/throttling"
+)
+
+func TestWithThrottlingExemption(t *testing.T) {
+	var exempt bool
+	handler := WithThrottlingExemption(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		exempt = throttling.ExemptFrom(req.Context())
+	}), func(clusterName logicalcluster.Name) bool {
+		return clusterName == "automation"
+	})
+
+	serve := func(cluster *request.Cluster) bool {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/configmaps", nil)
+		ctx := req.Context()
+		if cluster != nil {
+			ctx = request.WithCluster(ctx, *cluster)
+		}
+		exempt = false
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+		return exempt
+	}
+
+	require.True(t, serve(&request.Cluster{Name: "automation"}), "requests to exempt logical clusters must be marked exempt")
+	require.False(t, serve(&request.Cluster{Name: "tenant"}), "requests to other logical clusters must not be marked exempt")
+	require.False(t, serve(&request.Cluster{Name: "*", Wildcard: true}), "wildcard requests must not be marked exempt")
+	require.False(t, serve(nil), "requests without logical cluster must not be marked exempt")
+}
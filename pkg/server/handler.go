@@ -18,16 +18,15 @@ package server
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
-	"net/url"
 	"path"
 	"sort"
 	"strings"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kcp-dev/logicalcluster/v3"
 	jwt2 "gopkg.in/square/go-jose.v2/jwt"
 
 	apiextensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
@@ -45,10 +44,16 @@ import (
 	apiserverdiscovery "k8s.io/apiserver/pkg/endpoints/discovery"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/genericcontrolplane/aggregator"
 
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportusage"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspaceusage"
+	"github.com/kcp-dev/kcp/pkg/shardusage"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 var (
@@ -247,6 +252,101 @@ func WithRequestIdentity(handler http.Handler) http.Handler {
 	})
 }
 
+// WithAPIExportDeprecationWarnings adds a warning to requests against resources bound through an APIBinding
+// whose APIExport is deprecated, similar to the warnings for deprecated Kubernetes APIs. The warning is
+// taken from the APIExport, not from the APIBinding, as the latter is under control of the consumer.
+func WithAPIExportDeprecationWarnings(handler http.Handler, apiBindingIndexer cache.Indexer, getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		requestInfo, ok := request.RequestInfoFrom(req.Context())
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard || !ok || !requestInfo.IsResourceRequest {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		objs, err := apiBindingIndexer.ByIndex(byClusterGroupResource, clusterGroupResourceKeyFunc(cluster.Name, requestInfo.APIGroup, requestInfo.Resource))
+		if err != nil {
+			klog.FromContext(req.Context()).WithValues("operation", "WithAPIExportDeprecationWarnings", "cluster", cluster.Name).Error(err, "unable to list APIBindings")
+			handler.ServeHTTP(w, req)
+			return
+		}
+		apiBindings := make([]*apisv1alpha1.APIBinding, 0, len(objs))
+		for _, obj := range objs {
+			apiBindings = append(apiBindings, obj.(*apisv1alpha1.APIBinding))
+		}
+
+		for _, msg := range apiExportDeprecationWarnings(apiBindings, getAPIExport) {
+			warning.AddWarning(req.Context(), "", msg)
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// apiExportDeprecationWarnings returns the deprecation warnings of the APIExports bound by the given APIBindings.
+func apiExportDeprecationWarnings(apiBindings []*apisv1alpha1.APIBinding, getAPIExport func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)) []string {
+	var warnings []string
+	for _, binding := range apiBindings {
+		bound := binding.Status.BoundAPIExport
+		if bound == nil {
+			continue
+		}
+		export, err := getAPIExport(bound.Cluster.Name(), bound.Name)
+		if err != nil {
+			continue // the APIBinding controller reports missing APIExports
+		}
+		if msg := export.DeprecationWarning(); msg != "" {
+			warnings = append(warnings, msg)
+		}
+	}
+	return warnings
+}
+
+// WithAPIExportUsageMetrics counts the requests of consumers to resources bound from APIExports, per APIExport.
+func WithAPIExportUsageMetrics(handler http.Handler, apiBindingIndexer cache.Indexer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		requestInfo, ok := request.RequestInfoFrom(req.Context())
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard || !ok || !requestInfo.IsResourceRequest {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		objs, err := apiBindingIndexer.ByIndex(byClusterGroupResource, clusterGroupResourceKeyFunc(cluster.Name, requestInfo.APIGroup, requestInfo.Resource))
+		if err != nil {
+			klog.FromContext(req.Context()).WithValues("operation", "WithAPIExportUsageMetrics", "cluster", cluster.Name).Error(err, "unable to list APIBindings")
+			handler.ServeHTTP(w, req)
+			return
+		}
+		for _, obj := range objs {
+			if bound := obj.(*apisv1alpha1.APIBinding).Status.BoundAPIExport; bound != nil {
+				apiexportusage.RecordConsumerRequest(bound, requestInfo.Verb)
+			}
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// WithShardUsage counts the requests served by the shard for its usage heartbeat.
+func WithShardUsage(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		shardusage.RecordRequest()
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// WithWorkspaceUsageMetrics counts the requests to logical clusters, per logical cluster.
+func WithWorkspaceUsageMetrics(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if cluster := request.ClusterFrom(req.Context()); cluster != nil && !cluster.Name.Empty() && !cluster.Wildcard {
+			workspaceusage.RecordRequest(cluster.Name)
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
 func processResourceIdentity(req *http.Request, requestInfo *request.RequestInfo) (*http.Request, error) {
 	if !requestInfo.IsResourceRequest {
 		return req, nil
@@ -471,13 +571,3 @@ func (r *inMemoryResponseWriter) String() string {
 	}
 	return s
 }
-
-// unimplementedServiceResolver is a webhook.ServiceResolver that always returns an error, because
-// we have not implemented support for this yet. As a result, CRD webhook conversions are not
-// supported.
-type unimplementedServiceResolver struct{}
-
-// ResolveEndpoint always returns an error that this is not yet supported.
-func (r *unimplementedServiceResolver) ResolveEndpoint(namespace string, name string, port int32) (*url.URL, error) {
-	return nil, errors.New("CRD webhook conversions are not yet supported in kcp")
-}
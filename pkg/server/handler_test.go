@@ -21,10 +21,15 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/stretchr/testify/require"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func TestProcessResourceIdentity(t *testing.T) {
@@ -153,3 +158,63 @@ func TestProcessResourceIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIExportDeprecationWarnings(t *testing.T) {
+	binding := func(name, exportName string) *apisv1alpha1.APIBinding {
+		b := &apisv1alpha1.APIBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if exportName != "" {
+			b.Status.BoundAPIExport = &apisv1alpha1.BoundAPIExport{Cluster: "provider", Name: exportName}
+		}
+		return b
+	}
+	exports := map[string]*apisv1alpha1.APIExport{
+		"widgets": {
+			ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+			Spec:       apisv1alpha1.APIExportSpec{Deprecation: &apisv1alpha1.APIExportDeprecation{Deprecated: true}},
+		},
+		"things": {ObjectMeta: metav1.ObjectMeta{Name: "things"}},
+	}
+	getAPIExport := func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+		require.Equal(t, logicalcluster.Name("provider"), clusterName)
+		export, found := exports[name]
+		if !found {
+			return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), name)
+		}
+		return export, nil
+	}
+
+	tests := map[string]struct {
+		bindings []*apisv1alpha1.APIBinding
+		expected []string
+	}{
+		"deprecated export":     {bindings: []*apisv1alpha1.APIBinding{binding("widgets", "widgets")}, expected: []string{"APIExport widgets is deprecated"}},
+		"not deprecated export": {bindings: []*apisv1alpha1.APIBinding{binding("things", "things")}},
+		"missing export":        {bindings: []*apisv1alpha1.APIBinding{binding("gadgets", "gadgets")}},
+		"not bound yet":         {bindings: []*apisv1alpha1.APIBinding{binding("widgets", "")}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, apiExportDeprecationWarnings(test.bindings, getAPIExport))
+		})
+	}
+}
+
+func TestIndexAPIBindingByClusterGroupResource(t *testing.T) {
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "widgets",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "consumer"},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			BoundResources: []apisv1alpha1.BoundAPIResource{
+				{Group: "kcp.io", Resource: "widgets"},
+				{Group: "kcp.io", Resource: "gadgets"},
+			},
+		},
+	}
+
+	keys, err := indexAPIBindingByClusterGroupResource(binding)
+	require.NoError(t, err)
+	require.Equal(t, []string{"consumer/kcp.io/widgets", "consumer/kcp.io/gadgets"}, keys)
+}
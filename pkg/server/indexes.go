@@ -19,14 +19,17 @@ package server
 import (
 	"fmt"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 const (
 	byGroupResourceName     = "byGroupResourceName" // <plural>.<group>, core group uses "core"
 	byIdentityGroupResource = "byIdentityGroupResource"
+	byClusterGroupResource  = "byClusterGroupResource"
 )
 
 func indexCRDByGroupResourceName(obj interface{}) ([]string, error) {
@@ -60,3 +63,23 @@ func indexAPIBindingByIdentityGroupResource(obj interface{}) ([]string, error) {
 func identityGroupResourceKeyFunc(identity, group, resource string) string {
 	return fmt.Sprintf("%s/%s/%s", identity, group, resource)
 }
+
+// indexAPIBindingByClusterGroupResource indexes APIBindings by their logical cluster and bound resources.
+func indexAPIBindingByClusterGroupResource(obj interface{}) ([]string, error) {
+	apiBinding, ok := obj.(*apisv1alpha1.APIBinding)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be an APIBinding, but is %T", obj)
+	}
+
+	ret := make([]string, 0, len(apiBinding.Status.BoundResources))
+
+	for _, r := range apiBinding.Status.BoundResources {
+		ret = append(ret, clusterGroupResourceKeyFunc(logicalcluster.From(apiBinding), r.Group, r.Resource))
+	}
+
+	return ret, nil
+}
+
+func clusterGroupResourceKeyFunc(cluster logicalcluster.Name, group, resource string) string {
+	return fmt.Sprintf("%s/%s/%s", cluster, group, resource)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/loadshedding"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// newEtcdLatencyProbe returns a function measuring the latency of the etcd health check
+// of the server behind the given loopback config.
+func newEtcdLatencyProbe(loopbackConfig *rest.Config) (func(ctx context.Context) (time.Duration, error), error) {
+	config := rest.CopyConfig(loopbackConfig)
+	config = rest.AddUserAgent(config, "kcp-load-shedding-watchdog")
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (time.Duration, error) {
+		start := time.Now()
+		err := client.RESTClient().Get().AbsPath("/livez/etcd").Do(ctx).Error()
+		return time.Since(start), err
+	}, nil
+}
+
+// updateShardLoadCondition returns a function recording the load shedding state in the
+// LoadNominal condition of the Shard with the given name in the root logical cluster.
+func updateShardLoadCondition(rootShardKcpClusterClient kcpclientset.ClusterInterface, shardName string) func(ctx context.Context, state loadshedding.State) {
+	return func(ctx context.Context, state loadshedding.State) {
+		logger := klog.FromContext(ctx).WithValues("shard", shardName)
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			shard, err := rootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Get(ctx, shardName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if state.Degraded {
+				conditions.MarkFalse(shard, corev1alpha1.ShardLoadNominal, state.Reason, conditionsv1alpha1.ConditionSeverityWarning, state.Message)
+			} else {
+				conditions.MarkTrue(shard, corev1alpha1.ShardLoadNominal)
+			}
+			_, err = rootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().UpdateStatus(ctx, shard, metav1.UpdateOptions{})
+			return err
+		})
+		if errors.IsNotFound(err) {
+			logger.V(2).Info("Shard not found, not recording load shedding state")
+		} else if err != nil {
+			logger.Error(err, "failed to record load shedding state in Shard")
+		}
+	}
+}
+
+func (s *Server) installLoadSheddingWatchdog(ctx context.Context) error {
+	hookName := "kcp-start-load-shedding-watchdog"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", hookName)
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		go s.LoadSheddingWatchdog.Start(goContext(hookContext))
+
+		return nil
+	})
+}
@@ -28,7 +28,7 @@ import (
 	genericapiserver "k8s.io/apiserver/pkg/server"
 
 	authz "github.com/kcp-dev/kcp/pkg/authorization"
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 type Authorization struct {
@@ -117,7 +117,7 @@ func (s *Authorization) ApplyTo(config *genericapiserver.Config, informer kcpkub
 	maxPermissionPolicyAuth := authz.NewMaximalPermissionPolicyAuthorizer(informer, kcpinformer, union.New(bootstrapAuth, localAuth))
 	maxPermissionPolicyAuth = authz.NewDecorator("maxpermissionpolicy.authorization.kcp.io", maxPermissionPolicyAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
-	// protect status updates to apiexport and apibinding
+	// protect status updates to apiexport and apibinding, the kcp-maintained workspaceusages and the admin-only datajobs
 	systemCRDAuth := authz.NewSystemCRDAuthorizer(maxPermissionPolicyAuth)
 	systemCRDAuth = authz.NewDecorator("systemcrd.authorization.kcp.io", systemCRDAuth).AddAuditLogging().AddAnonymization().AddReasonAnnotation()
 
@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigurationAPIVersion is the apiVersion of the kcp configuration file.
+	ConfigurationAPIVersion = "config.kcp.io/v1alpha1"
+	// ConfigurationKind is the kind of the kcp configuration file.
+	ConfigurationKind = "KcpConfiguration"
+)
+
+// Configuration is the versioned configuration file of a shard, passed with --config. Every
+// section holds the flags of the respective flag section by name, without the leading dashes:
+//
+//	apiVersion: config.kcp.io/v1alpha1
+//	kind: KcpConfiguration
+//	controllers:
+//	  apiexport-schema-lint: true
+//	  unsupported-run-individual-controllers: [apibinding, apiexport]
+//	loadShedding:
+//	  enable-load-shedding: true
+//	  load-shedding-memory-threshold: 6Gi
+//
+// Unknown fields and flags are rejected when the file is loaded. Flags given on the command
+// line take precedence over the file.
+type Configuration struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Controllers holds the flags of the "KCP Controllers" section.
+	Controllers map[string]interface{} `json:"controllers,omitempty"`
+	// Replication holds the flags of the "KCP Cache Server" section.
+	Replication map[string]interface{} `json:"replication,omitempty"`
+	// Authorization holds the flags of the "KCP Authorization" section.
+	Authorization map[string]interface{} `json:"authorization,omitempty"`
+	// VirtualWorkspaces holds the flags of the "KCP Virtual Workspaces" section.
+	VirtualWorkspaces map[string]interface{} `json:"virtualWorkspaces,omitempty"`
+	// LoadShedding holds the flags of the "KCP Load Shedding" section.
+	LoadShedding map[string]interface{} `json:"loadShedding,omitempty"`
+}
+
+// ReloadableFlags are the flags which are applied to a running shard when the configuration
+// file changes. Changes of all other flags require a restart.
+var ReloadableFlags = sets.NewString(
+	"load-shedding-memory-threshold",
+	"load-shedding-etcd-latency-threshold",
+)
+
+// LoadConfiguration reads and validates the configuration file at the given path.
+func LoadConfiguration(path string) (*Configuration, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfiguration(bs)
+}
+
+// ParseConfiguration parses and validates a configuration file.
+func ParseConfiguration(bs []byte) (*Configuration, error) {
+	var c Configuration
+	if err := yaml.UnmarshalStrict(bs, &c); err != nil {
+		return nil, fmt.Errorf("invalid configuration file: %w", err)
+	}
+	if c.APIVersion != ConfigurationAPIVersion || c.Kind != ConfigurationKind {
+		return nil, fmt.Errorf("invalid configuration file: expected apiVersion %q and kind %q, got %q and %q", ConfigurationAPIVersion, ConfigurationKind, c.APIVersion, c.Kind)
+	}
+	return &c, nil
+}
+
+// sections returns the flag values of the configuration by field name and flag set name.
+func (c *Configuration) sections() []configurationSection {
+	return []configurationSection{
+		{"controllers", "KCP Controllers", c.Controllers},
+		{"replication", "KCP Cache Server", c.Replication},
+		{"authorization", "KCP Authorization", c.Authorization},
+		{"virtualWorkspaces", "KCP Virtual Workspaces", c.VirtualWorkspaces},
+		{"loadShedding", "KCP Load Shedding", c.LoadShedding},
+	}
+}
+
+type configurationSection struct {
+	field   string
+	flagSet string
+	values  map[string]interface{}
+}
+
+// ApplyTo sets the flags of the configuration in the given flag sets, skipping flags for
+// which skip returns true. It returns an error for every unknown flag and invalid value.
+func (c *Configuration) ApplyTo(fss cliflag.NamedFlagSets, skip func(name string) bool) []error {
+	var errs []error
+	for _, section := range c.sections() {
+		fs := fss.FlagSets[section.flagSet]
+		if fs == nil {
+			if len(section.values) > 0 {
+				errs = append(errs, fmt.Errorf("%s: flag section %q does not exist", section.field, section.flagSet))
+			}
+			continue
+		}
+		errs = append(errs, applyConfigurationSection(section.field, section.values, fs, skip)...)
+	}
+	return errs
+}
+
+// ApplyConfigFile applies the configuration file given with --config to the flag sets of
+// the options. Flags set on the command line are recorded in Extra.CommandLineFlags and
+// take precedence over the file.
+func (o *Options) ApplyConfigFile(fss cliflag.NamedFlagSets) error {
+	o.Extra.CommandLineFlags = sets.NewString()
+	for _, fs := range fss.FlagSets {
+		fs.Visit(func(f *pflag.Flag) {
+			o.Extra.CommandLineFlags.Insert(f.Name)
+		})
+	}
+
+	if o.Extra.ConfigFile == "" {
+		return nil
+	}
+	c, err := LoadConfiguration(o.Extra.ConfigFile)
+	if err != nil {
+		return err
+	}
+	return utilerrors.NewAggregate(c.ApplyTo(fss, o.Extra.CommandLineFlags.Has))
+}
+
+// ReloadLoadShedding returns the given load shedding options with the reloadable flags of
+// the configuration applied, leaving flags set on the command line alone. Reloadable flags
+// missing in the file keep the values of current.
+func (c *Configuration) ReloadLoadShedding(current LoadShedding, commandLineFlags sets.String) (*LoadShedding, error) {
+	ls := current
+	fs := pflag.NewFlagSet("KCP Load Shedding", pflag.ContinueOnError)
+	ls.AddFlags(fs)
+
+	errs := applyConfigurationSection("loadShedding", c.LoadShedding, fs, func(name string) bool {
+		return !ReloadableFlags.Has(name) || commandLineFlags.Has(name)
+	})
+	if len(errs) == 0 {
+		errs = ls.Validate()
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return &ls, nil
+}
+
+// RestartRequired returns the fields which differ between the two configurations and are not
+// reloadable.
+func (c *Configuration) RestartRequired(other *Configuration) []string {
+	var fields []string
+	otherSections := other.sections()
+	for i, section := range c.sections() {
+		names := sets.StringKeySet(section.values).Union(sets.StringKeySet(otherSections[i].values))
+		for _, name := range names.List() {
+			if ReloadableFlags.Has(name) {
+				continue
+			}
+			if fmt.Sprint(section.values[name]) != fmt.Sprint(otherSections[i].values[name]) {
+				fields = append(fields, section.field+"."+name)
+			}
+		}
+	}
+	return fields
+}
+
+func applyConfigurationSection(field string, values map[string]interface{}, fs *pflag.FlagSet, skip func(name string) bool) []error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		f := fs.Lookup(name)
+		if f == nil {
+			errs = append(errs, fmt.Errorf("%s.%s: unknown flag", field, name))
+			continue
+		}
+		if skip != nil && skip(name) {
+			continue
+		}
+
+		var err error
+		switch v := values[name].(type) {
+		case []interface{}:
+			strs := make([]string, 0, len(v))
+			for _, s := range v {
+				strs = append(strs, configValueString(s))
+			}
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				err = sv.Replace(strs)
+			} else {
+				err = fs.Set(name, strings.Join(strs, ","))
+			}
+		case map[string]interface{}:
+			pairs := make([]string, 0, len(v))
+			for k, s := range v {
+				pairs = append(pairs, k+"="+configValueString(s))
+			}
+			sort.Strings(pairs)
+			err = fs.Set(name, strings.Join(pairs, ","))
+		case nil:
+			err = fmt.Errorf("must not be null")
+		default:
+			err = fs.Set(name, configValueString(v))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", field, name, err))
+		}
+	}
+	return errs
+}
+
+// configValueString formats a value of the configuration file as flag value. Numbers are
+// decoded as float64, but must not be formatted in exponent notation.
+func configValueString(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestParseConfiguration(t *testing.T) {
+	tests := map[string]struct {
+		file    string
+		wantErr string
+	}{
+		"valid": {
+			file: `
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+controllers:
+  apiexport-schema-lint: true
+`,
+		},
+		"unknown section": {
+			file: `
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+controler:
+  apiexport-schema-lint: true
+`,
+			wantErr: `unknown field "controler"`,
+		},
+		"wrong kind": {
+			file: `
+apiVersion: config.kcp.io/v1alpha1
+kind: KubeletConfiguration
+`,
+			wantErr: `expected apiVersion "config.kcp.io/v1alpha1" and kind "KcpConfiguration"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfiguration([]byte(tc.file))
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "kcp.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+controllers:
+  apiexport-schema-lint: true
+  unsupported-run-individual-controllers: [apibinding, apiexport]
+authorization:
+  authorization-always-allow-paths: [/healthz]
+loadShedding:
+  enable-load-shedding: true
+  load-shedding-memory-threshold: 6Gi
+  load-shedding-etcd-latency-threshold: 2s
+`), 0600))
+
+	o := NewOptions(".kcp")
+	fss := o.Flags()
+	require.NoError(t, fss.FlagSet("KCP Load Shedding").Parse([]string{"--load-shedding-etcd-latency-threshold=5s"}))
+	o.Extra.ConfigFile = file
+
+	require.NoError(t, o.ApplyConfigFile(fss))
+	require.True(t, o.Controllers.APIExportSchemaLint)
+	require.Equal(t, []string{"apibinding", "apiexport"}, o.Controllers.IndividuallyEnabled)
+	require.Equal(t, []string{"/healthz"}, o.Authorization.AlwaysAllowPaths)
+	require.True(t, o.LoadShedding.Enabled)
+	require.Equal(t, "6Gi", o.LoadShedding.MemoryThreshold)
+	require.Equal(t, 5*time.Second, o.LoadShedding.EtcdLatencyThreshold, "command line takes precedence")
+	require.Equal(t, sets.NewString("load-shedding-etcd-latency-threshold"), o.Extra.CommandLineFlags)
+}
+
+func TestApplyConfigFileErrors(t *testing.T) {
+	c, err := ParseConfiguration([]byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+controllers:
+  apiexport-schema-lnt: true
+loadShedding:
+  load-shedding-check-interval: often
+`))
+	require.NoError(t, err)
+
+	errs := c.ApplyTo(NewOptions(".kcp").Flags(), nil)
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], "controllers.apiexport-schema-lnt: unknown flag")
+	require.ErrorContains(t, errs[1], "loadShedding.load-shedding-check-interval: ")
+}
+
+func TestReloadLoadShedding(t *testing.T) {
+	initial, err := ParseConfiguration([]byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+loadShedding:
+  enable-load-shedding: true
+  load-shedding-memory-threshold: 6Gi
+`))
+	require.NoError(t, err)
+	changed, err := ParseConfiguration([]byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+loadShedding:
+  enable-load-shedding: false
+  load-shedding-memory-threshold: 8Gi
+  load-shedding-etcd-latency-threshold: 3s
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"loadShedding.enable-load-shedding"}, initial.RestartRequired(changed))
+
+	current := LoadShedding{Enabled: true, MemoryThreshold: "6Gi", EtcdLatencyThreshold: time.Second, CheckInterval: 10 * time.Second}
+	ls, err := changed.ReloadLoadShedding(current, sets.NewString())
+	require.NoError(t, err)
+	require.Equal(t, LoadShedding{Enabled: true, MemoryThreshold: "8Gi", EtcdLatencyThreshold: 3 * time.Second, CheckInterval: 10 * time.Second}, *ls)
+
+	ls, err = changed.ReloadLoadShedding(current, sets.NewString("load-shedding-memory-threshold"))
+	require.NoError(t, err)
+	require.Equal(t, "6Gi", ls.MemoryThreshold, "command line takes precedence")
+
+	invalid, err := ParseConfiguration([]byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+loadShedding:
+  load-shedding-memory-threshold: lots
+`))
+	require.NoError(t, err)
+	_, err = invalid.ReloadLoadShedding(current, sets.NewString())
+	require.Error(t, err)
+}
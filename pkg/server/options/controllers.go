@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -29,20 +31,41 @@ import (
 	"k8s.io/klog/v2"
 	kcmoptions "k8s.io/kubernetes/cmd/kube-controller-manager/app/options"
 
+	"github.com/kcp-dev/kcp/pkg/controllermanager"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportendpointslice"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportusage"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiresource"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/extraannotationsync"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspaceusage"
 	"github.com/kcp-dev/kcp/pkg/reconciler/workload/heartbeat"
 )
 
 type Controllers struct {
-	EnableAll           bool
-	IndividuallyEnabled []string
-	ApiResource         ApiResourceController
-	SyncTargetHeartbeat SyncTargetHeartbeatController
-	SAController        kcmoptions.SAControllerOptions
+	EnableAll                     bool
+	IndividuallyEnabled           []string
+	ExternalGroups                []string
+	APIExportSchemaLint           bool
+	AnnotateTimeToReady           bool
+	QuotaNotificationWebhooks     bool
+	WorkspaceTombstoneDuration    time.Duration
+	WorkspaceMountDNSBaseDomain   string
+	WorkspaceMountDNSNameTemplate string
+	APIExportEndpointSlice        APIExportEndpointSliceController
+	APIExportExtraAnnotationSync  APIExportExtraAnnotationSyncController
+	APIExportUsage                APIExportUsageController
+	ApiResource                   ApiResourceController
+	SyncTargetHeartbeat           SyncTargetHeartbeatController
+	WorkspaceUsage                WorkspaceUsageController
+	SAController                  kcmoptions.SAControllerOptions
 }
 
+type APIExportEndpointSliceController = apiexportendpointslice.Options
+type APIExportExtraAnnotationSyncController = extraannotationsync.Options
+type APIExportUsageController = apiexportusage.Options
 type ApiResourceController = apiresource.Options
 type SyncTargetHeartbeatController = heartbeat.Options
+type WorkspaceUsageController = workspaceusage.Options
 
 var kcmDefaults *kcmoptions.KubeControllerManagerOptions
 
@@ -59,9 +82,15 @@ func NewControllers() *Controllers {
 	return &Controllers{
 		EnableAll: true,
 
-		ApiResource:         *apiresource.DefaultOptions(),
-		SyncTargetHeartbeat: *heartbeat.DefaultOptions(),
-		SAController:        *kcmDefaults.SAController,
+		WorkspaceMountDNSNameTemplate: workspace.DefaultMountDNSNameTemplate,
+
+		APIExportEndpointSlice:       *apiexportendpointslice.DefaultOptions(),
+		APIExportExtraAnnotationSync: *extraannotationsync.DefaultOptions(),
+		APIExportUsage:               *apiexportusage.DefaultOptions(),
+		ApiResource:                  *apiresource.DefaultOptions(),
+		SyncTargetHeartbeat:          *heartbeat.DefaultOptions(),
+		WorkspaceUsage:               *workspaceusage.DefaultOptions(),
+		SAController:                 *kcmDefaults.SAController,
 	}
 }
 
@@ -70,9 +99,21 @@ func (c *Controllers) AddFlags(fs *pflag.FlagSet) {
 
 	fs.StringSliceVar(&c.IndividuallyEnabled, "unsupported-run-individual-controllers", c.IndividuallyEnabled, "Run individual controllers in-process. The controller names can change at any time.")
 	fs.MarkHidden("unsupported-run-individual-controllers") //nolint:errcheck
-
+	fs.StringSliceVar(&c.ExternalGroups, "external-controller-groups", c.ExternalGroups, fmt.Sprintf("Controller groups not to run in-process because kcp-controller-manager runs them, connected through the controller-manager.kubeconfig written to the root directory. Supported groups: %s", strings.Join(controllermanager.Groups(), ", ")))
+
+	fs.BoolVar(&c.APIExportSchemaLint, "apiexport-schema-lint", c.APIExportSchemaLint, "Lint the APIResourceSchemas of APIExports against best practices, reporting violations in the SchemasLinted condition of the APIExport")
+	fs.BoolVar(&c.AnnotateTimeToReady, "annotate-time-to-ready", c.AnnotateTimeToReady, "Annotate Workspaces and APIBindings with the duration from their creation until they became ready")
+	fs.BoolVar(&c.QuotaNotificationWebhooks, "workspacequota-notification-webhooks", c.QuotaNotificationWebhooks, "Call the https webhooks configured by tenants on WorkspaceQuotas when the usage reaches a notification threshold")
+	fs.DurationVar(&c.WorkspaceTombstoneDuration, "workspace-tombstone-duration", c.WorkspaceTombstoneDuration, "Duration for which the name of a deleted workspace cannot be reused, recorded in a WorkspaceTombstone that admins can delete to purge the reservation early. 0 disables the reservation")
+	fs.StringVar(&c.WorkspaceMountDNSBaseDomain, "workspace-mount-dns-base-domain", c.WorkspaceMountDNSBaseDomain, "Base domain of the stable DNS names published in status.mount.dnsName of mounted workspaces. Empty disables DNS names")
+	fs.StringVar(&c.WorkspaceMountDNSNameTemplate, "workspace-mount-dns-name-template", c.WorkspaceMountDNSNameTemplate, "Go template of the DNS names published for mounted workspaces, rendered with .Workspace, .Cluster and .BaseDomain")
+
+	apiexportendpointslice.BindOptions(&c.APIExportEndpointSlice, fs)
+	extraannotationsync.BindOptions(&c.APIExportExtraAnnotationSync, fs)
+	apiexportusage.BindOptions(&c.APIExportUsage, fs)
 	apiresource.BindOptions(&c.ApiResource, fs)
 	heartbeat.BindOptions(&c.SyncTargetHeartbeat, fs)
+	workspaceusage.BindOptions(&c.WorkspaceUsage, fs)
 
 	c.SAController.AddFlags(fs)
 }
@@ -106,12 +147,32 @@ func (c *Controllers) Complete(rootDir string) error {
 func (c *Controllers) Validate() []error {
 	var errs []error
 
+	if c.WorkspaceMountDNSBaseDomain != "" {
+		if err := workspace.ValidateMountDNSName(c.WorkspaceMountDNSBaseDomain, c.WorkspaceMountDNSNameTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("--workspace-mount-dns-base-domain or --workspace-mount-dns-name-template is invalid: %w", err))
+		}
+	}
+	if err := c.APIExportEndpointSlice.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.APIExportExtraAnnotationSync.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.APIExportUsage.Validate(); err != nil {
+		errs = append(errs, err)
+	}
 	if err := c.ApiResource.Validate(); err != nil {
 		errs = append(errs, err)
 	}
 	if err := c.SyncTargetHeartbeat.Validate(); err != nil {
 		errs = append(errs, err)
 	}
+	if err := c.WorkspaceUsage.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := controllermanager.ValidateGroups(c.ExternalGroups); err != nil {
+		errs = append(errs, err)
+	}
 	if saErrs := c.SAController.Validate(); saErrs != nil {
 		errs = append(errs, saErrs...)
 	}
@@ -39,6 +39,7 @@ var (
 		"KCP Controllers",
 		"KCP Home Workspaces",
 		"KCP Cache Server",
+		"KCP Load Shedding",
 		"KCP",
 	}
 
@@ -129,6 +130,7 @@ var (
 		"tracing-config-file", // File with apiserver tracing configuration.
 
 		// KCP flags
+		"config",                           // Path to a KcpConfiguration file of apiVersion config.kcp.io/v1alpha1 with the controllers, replication, authorization, virtual workspaces and load shedding flags by section.
 		"profiler-address",                 // [Address]:port to bind the profiler to
 		"root-directory",                   // Root directory.
 		"shard-base-url",                   // Base URL to this kcp shard. Defaults to external address.
@@ -140,6 +142,24 @@ var (
 		"experimental-bind-free-port",      // Bind to a free port. --secure-bind-port must be 0. Use the admin.kubeconfig to extract the chosen port.
 		"batteries-included",               // A list of batteries included (= default objects that might be unwanted in production, but very helpful in trying out kcp or development).
 		"logical-cluster-admin-kubeconfig", // Kubeconfig holding admin(!) credentials to other shards. Defaults to the loopback client.
+		"bound-crd-partitions",             // Number of system logical clusters the CRDs of APIBindings are partitioned across by the hash of their APIResourceSchema.
+		"shard-usage-heartbeat-interval",   // Interval of reporting the usage of this shard in the status of its Shard. 0 disables the heartbeat.
+		"shard-lease-duration",             // Duration of the lease this shard renews in the status of its Shard. 0 disables the lease.
+		"hash-algorithm",                   // Hash algorithm of newly derived shard hashes, APIExport identities and logical cluster names.
+		"fips",                             // Require the FIPS 140 validated BoringCrypto module and FIPS approved hash algorithms.
+		"committer-batching-window",        // How long status patches of controllers wait to be merged with concurrent status patches of the same object.
+		"committer-concurrency-limit",      // Maximum number of patches of controllers in flight at once.
+
+		"apiexport-custom-subresource-client-cert-file", // Client certificate presented to the custom subresource handlers of APIExports.
+		"apiexport-custom-subresource-client-key-file",  // Key of the client certificate presented to the custom subresource handlers of APIExports.
+
+		"throttling-exemption-signing-key-file", // File holding the key to sign and verify ThrottlingExemptions with.
+
+		"shard-registration-signing-cert-file", // CA certificate to sign the client certificates of registering shards with.
+		"shard-registration-signing-key-file",  // Key of the --shard-registration-signing-cert-file CA.
+		"shard-registration-url",               // Base URL of the root shard to register this shard with.
+		"shard-registration-token",             // Registration token to register this shard with on first start.
+		"shard-registration-ca-file",           // CA bundle to verify the serving certificate of --shard-registration-url with.
 
 		// secure serving flags
 		"bind-address",                     // The IP address on which to listen for the --secure-port port. The associated interface(s) must be reachable by the rest of the cluster, and by CLI/web clients. If blank or an unspecified address (0.0.0.0 or ::), all interfaces will be used.
@@ -171,13 +191,36 @@ var (
 		"home-workspaces-home-creator-groups",    // Groups of users who can have their home workspace created automatically create when first accessing it.
 		"home-workspaces-root-prefix",            // Logical cluster name of the workspace that will contains home workspaces for all workspaces.
 
+		// KCP Load Shedding flags
+		"enable-load-shedding",                 // Shed load when the shard is under memory pressure or etcd is slow: pause low-priority controllers, reject wildcard lists of tenants with 429, and report the degraded mode in the LoadNominal condition of the Shard.
+		"load-shedding-memory-threshold",       // Memory usage of the kcp process (e.g. 6Gi) above which load is shed. Empty disables the memory check.
+		"load-shedding-etcd-latency-threshold", // Latency of the etcd health check above which load is shed. 0 disables the etcd check.
+		"load-shedding-check-interval",         // Interval of checking memory usage and etcd latency for load shedding.
+
 		// KCP Controllers flags
-		"auto-publish-apis",                      // If true, the APIs imported from physical clusters will be published automatically as CRDs
-		"apiresource-controller-threads",         // Number of threads to use for the apiresource controller.
-		"run-controllers",                        // Run the controllers in-process
-		"run-virtual-workspaces",                 // Run the virtual workspaces apiservers in-process
-		"unsupported-run-individual-controllers", // Run individual controllers in-process. The controller names can change at any time.
-		"sync-target-heartbeat-threshold",        // Amount of time to wait for a successful heartbeat before marking the cluster as not ready.
+		"auto-publish-apis",                                   // If true, the APIs imported from physical clusters will be published automatically as CRDs
+		"apiresource-controller-threads",                      // Number of threads to use for the apiresource controller.
+		"run-controllers",                                     // Run the controllers in-process
+		"run-virtual-workspaces",                              // Run the virtual workspaces apiservers in-process
+		"unsupported-run-individual-controllers",              // Run individual controllers in-process. The controller names can change at any time.
+		"external-controller-groups",                          // Controller groups not to run in-process because kcp-controller-manager runs them, connected through the controller-manager.kubeconfig written to the root directory.
+		"sync-target-heartbeat-threshold",                     // Amount of time to wait for a successful heartbeat before marking the cluster as not ready.
+		"apiexport-schema-lint",                               // Lint the APIResourceSchemas of APIExports against best practices, reporting violations in the SchemasLinted condition of the APIExport
+		"annotate-time-to-ready",                              // Annotate Workspaces and APIBindings with the duration from their creation until they became ready
+		"workspacequota-notification-webhooks",                // Call the https webhooks configured by tenants on WorkspaceQuotas when the usage reaches a notification threshold
+		"workspace-tombstone-duration",                        // Duration for which the name of a deleted workspace cannot be reused, recorded in a WorkspaceTombstone that admins can delete to purge the reservation early. 0 disables the reservation
+		"apiexport-endpoint-probe-interval",                   // Interval to probe the virtual workspace URLs published in APIExportEndpointSlices, recording the state of each endpoint. 0 disables probing
+		"apiexport-endpoint-probe-timeout",                    // Timeout of a single probe of a virtual workspace URL published in APIExportEndpointSlices
+		"apiexport-endpoint-dns-base-domain",                  // Base domain of the stable DNS names published for the endpoints of APIExportEndpointSlices. Empty disables DNS names
+		"apiexport-endpoint-dns-name-template",                // Go template of the DNS names published for the endpoints of APIExportEndpointSlices, rendered with .Shard, .Region and .BaseDomain
+		"apiexport-extra-annotation-sync-qps",                 // Maximum number of APIBindings patched per second when syncing extra annotations and labels of APIExports
+		"apiexport-extra-annotation-sync-burst",               // Maximum burst of APIBinding patches when syncing extra annotations and labels of APIExports. APIBindings of a changed APIExport are synced in batches of this size
+		"apiexport-extra-annotation-sync-annotation-prefixes", // Key prefixes of the annotations synced from APIExports to their APIBindings, e.g. to propagate organization specific metadata. Prefixes must end with a slash, and must not be in the kcp.io domain other than the default
+		"apiexport-extra-annotation-sync-label-prefixes",      // Key prefixes of the labels synced from APIExports to their APIBindings. Prefixes must end with a slash, and must not be in the kcp.io domain other than the default
+		"apiexport-usage-metrics",                             // Publish per-APIExport load signals as metrics: consumer requests to bound resources, objects of bound resources and consumers on this shard. These can drive the autoscaling of provider controllers, e.g. through the Prometheus adapter or KEDA. Labels have the cardinality of the bound APIExports
+		"apiexport-usage-metrics-interval",                    // Interval of counting the objects and consumers of APIExports for --apiexport-usage-metrics
+		"workspace-usage-metrics",                             // Publish the usage of every logical cluster on this shard, i.e. objects per resource, requests and approximate storage, as metrics labeled by the workspace path and in the WorkspaceUsage object named "cluster" in the workspace. Labels have the cardinality of the workspaces and their resources
+		"workspace-usage-metrics-interval",                    // Interval of collecting the usage of the logical clusters for --workspace-usage-metrics
 
 		// KCP Cache Server flags
 		"cache-server-kubeconfig-file", // Kubeconfig for the cache server this instance connects to (defaults to loopback configuration).
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type LoadShedding struct {
+	Enabled bool
+
+	MemoryThreshold      string
+	EtcdLatencyThreshold time.Duration
+	CheckInterval        time.Duration
+}
+
+func NewLoadShedding() *LoadShedding {
+	return &LoadShedding{
+		Enabled:              false,
+		MemoryThreshold:      "",
+		EtcdLatencyThreshold: time.Second,
+		CheckInterval:        10 * time.Second,
+	}
+}
+
+func (l *LoadShedding) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&l.Enabled, "enable-load-shedding", l.Enabled, "Shed load when the shard is under memory pressure or etcd is slow: pause low-priority controllers, reject wildcard lists and watches of clients other than kcp itself with 429, and report the degraded mode in the LoadNominal condition of the Shard.")
+	fs.StringVar(&l.MemoryThreshold, "load-shedding-memory-threshold", l.MemoryThreshold, "Memory usage of the kcp process (e.g. 6Gi) above which load is shed. Empty disables the memory check.")
+	fs.DurationVar(&l.EtcdLatencyThreshold, "load-shedding-etcd-latency-threshold", l.EtcdLatencyThreshold, "Latency of the etcd health check above which load is shed. 0 disables the etcd check.")
+	fs.DurationVar(&l.CheckInterval, "load-shedding-check-interval", l.CheckInterval, "Interval of checking memory usage and etcd latency for load shedding.")
+}
+
+// MemoryThresholdBytes returns the memory threshold in bytes, or 0 if none is set.
+func (l *LoadShedding) MemoryThresholdBytes() (uint64, error) {
+	if l.MemoryThreshold == "" {
+		return 0, nil
+	}
+	q, err := resource.ParseQuantity(l.MemoryThreshold)
+	if err != nil {
+		return 0, err
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return uint64(q.Value()), nil
+}
+
+func (l *LoadShedding) Validate() []error {
+	var errs []error
+
+	if l.Enabled {
+		if _, err := l.MemoryThresholdBytes(); err != nil {
+			errs = append(errs, fmt.Errorf("--load-shedding-memory-threshold is invalid: %w", err))
+		}
+		if l.EtcdLatencyThreshold < 0 {
+			errs = append(errs, fmt.Errorf("--load-shedding-etcd-latency-threshold must be >=0 (%s)", l.EtcdLatencyThreshold))
+		}
+		if l.CheckInterval <= 0 {
+			errs = append(errs, fmt.Errorf("--load-shedding-check-interval must be >0 (%s)", l.CheckInterval))
+		}
+	}
+
+	return errs
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
 	genericapiserveroptions "k8s.io/apiserver/pkg/server/options"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
@@ -35,9 +36,14 @@ import (
 	kubeoptions "k8s.io/kubernetes/pkg/kubeapiserver/options"
 
 	kcpadmission "github.com/kcp-dev/kcp/pkg/admission"
+	"github.com/kcp-dev/kcp/pkg/crypto"
 	etcdoptions "github.com/kcp-dev/kcp/pkg/embeddedetcd/options"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+	"github.com/kcp-dev/kcp/pkg/network"
+	"github.com/kcp-dev/kcp/pkg/requesttrace"
 	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
+	"github.com/kcp-dev/kcp/pkg/shardregistration"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
 type Options struct {
@@ -48,6 +54,7 @@ type Options struct {
 	AdminAuthentication AdminAuthentication
 	Virtual             Virtual
 	HomeWorkspaces      HomeWorkspaces
+	LoadShedding        LoadShedding
 	Cache               Cache
 
 	Extra ExtraOptions
@@ -55,6 +62,7 @@ type Options struct {
 
 type ExtraOptions struct {
 	RootDirectory                 string
+	ConfigFile                    string
 	ProfilerAddress               string
 	ShardKubeconfigFile           string
 	RootShardKubeconfigFile       string
@@ -63,10 +71,37 @@ type ExtraOptions struct {
 	ShardName                     string
 	ShardVirtualWorkspaceURL      string
 	DiscoveryPollInterval         time.Duration
+	ShardUsageHeartbeatInterval   time.Duration
+	ShardLeaseDuration            time.Duration
 	ExperimentalBindFreePort      bool
 	LogicalClusterAdminKubeconfig string
+	BoundCRDPartitions            int
+	HashAlgorithm                 string
+	FIPS                          bool
+	CommitterBatchingWindow       time.Duration
+	CommitterConcurrencyLimit     int
+
+	CustomSubresourceClientCertFile string
+	CustomSubresourceClientKeyFile  string
+
+	ThrottlingExemptionSigningKeyFile string
+
+	ShardRegistrationSigningCertFile string
+	ShardRegistrationSigningKeyFile  string
+	ShardRegistrationURL             string
+	ShardRegistrationToken           string
+	ShardRegistrationCAFile          string
 
 	BatteriesIncluded []string
+
+	// CommandLineFlags are the flags set on the command line, which take precedence over
+	// the configuration file.
+	CommandLineFlags sets.String
+	// ShardConfiguration is the spec.configuration of the Shard of this shard applied on start.
+	ShardConfiguration *corev1alpha1.ShardConfiguration
+	// ShardConfigurationOverridden are the values of the reloadable flags before they were
+	// overridden by ShardConfiguration, by flag name.
+	ShardConfigurationOverridden map[string]string
 }
 
 type completedOptions struct {
@@ -77,6 +112,7 @@ type completedOptions struct {
 	AdminAuthentication AdminAuthentication
 	Virtual             Virtual
 	HomeWorkspaces      HomeWorkspaces
+	LoadShedding        LoadShedding
 	Cache               cacheCompleted
 
 	Extra ExtraOptions
@@ -98,18 +134,22 @@ func NewOptions(rootDir string) *Options {
 		AdminAuthentication: *NewAdminAuthentication(rootDir),
 		Virtual:             *NewVirtual(),
 		HomeWorkspaces:      *NewHomeWorkspaces(),
+		LoadShedding:        *NewLoadShedding(),
 		Cache:               *NewCache(rootDir),
 
 		Extra: ExtraOptions{
-			RootDirectory:            rootDir,
-			ProfilerAddress:          "",
-			ShardKubeconfigFile:      "",
-			ShardBaseURL:             "",
-			ShardExternalURL:         "",
-			ShardName:                "root",
-			DiscoveryPollInterval:    60 * time.Second,
-			ExperimentalBindFreePort: false,
-			BatteriesIncluded:        batteries.Defaults.List(),
+			RootDirectory:               rootDir,
+			ProfilerAddress:             "",
+			ShardKubeconfigFile:         "",
+			ShardBaseURL:                "",
+			ShardExternalURL:            "",
+			ShardName:                   "root",
+			DiscoveryPollInterval:       60 * time.Second,
+			ShardUsageHeartbeatInterval: 30 * time.Second,
+			ShardLeaseDuration:          40 * time.Second,
+			ExperimentalBindFreePort:    false,
+			BoundCRDPartitions:          1,
+			BatteriesIncluded:           batteries.Defaults.List(),
 		},
 	}
 
@@ -131,6 +171,7 @@ func NewOptions(rootDir string) *Options {
 	kcpadmission.RegisterAllKcpAdmissionPlugins(o.GenericControlPlane.Admission.Plugins)
 	o.GenericControlPlane.Admission.DisablePlugins = kcpadmission.DefaultOffAdmissionPlugins().List()
 	o.GenericControlPlane.Admission.RecommendedPluginOrder = kcpadmission.AllOrderedPlugins
+	o.GenericControlPlane.Admission.Decorators = append(o.GenericControlPlane.Admission.Decorators, admission.DecoratorFunc(requesttrace.WithAdmissionTracing))
 
 	// turn on the watch cache
 	o.GenericControlPlane.Etcd.EnableWatchCache = true
@@ -164,18 +205,38 @@ func (o *Options) rawFlags() cliflag.NamedFlagSets {
 	o.AdminAuthentication.AddFlags(fss.FlagSet("KCP Authentication"))
 	o.Virtual.AddFlags(fss.FlagSet("KCP Virtual Workspaces"))
 	o.HomeWorkspaces.AddFlags(fss.FlagSet("KCP Home Workspaces"))
+	o.LoadShedding.AddFlags(fss.FlagSet("KCP Load Shedding"))
 	o.Cache.AddFlags(fss.FlagSet("KCP Cache Server"))
 
 	fs := fss.FlagSet("KCP")
 	fs.StringVar(&o.Extra.ProfilerAddress, "profiler-address", o.Extra.ProfilerAddress, "[Address]:port to bind the profiler to")
 	fs.StringVar(&o.Extra.ShardKubeconfigFile, "shard-kubeconfig-file", o.Extra.ShardKubeconfigFile, "Kubeconfig holding admin(!) credentials to peer kcp shards.")
 	fs.StringVar(&o.Extra.RootShardKubeconfigFile, "root-shard-kubeconfig-file", o.Extra.RootShardKubeconfigFile, "Kubeconfig holding admin(!) credentials to the root kcp shard.")
-	fs.StringVar(&o.Extra.ShardBaseURL, "shard-base-url", o.Extra.ShardBaseURL, "Base URL to this kcp shard. Defaults to external address.")
+	fs.StringVar(&o.Extra.ShardBaseURL, "shard-base-url", o.Extra.ShardBaseURL, "Base URL to this kcp shard. IPv6 addresses must be enclosed in brackets, e.g. https://[2001:db8::1]:6443. Defaults to external address.")
 	fs.StringVar(&o.Extra.ShardExternalURL, "shard-external-url", o.Extra.ShardExternalURL, "URL used by outside clients to talk to this kcp shard. Defaults to external address.")
 	fs.StringVar(&o.Extra.ShardName, "shard-name", o.Extra.ShardName, "A name of this kcp shard. Defaults to the \"root\" name.")
 	fs.StringVar(&o.Extra.ShardVirtualWorkspaceURL, "shard-virtual-workspace-url", o.Extra.ShardVirtualWorkspaceURL, "An external URL address of a virtual workspace server associated with this shard. Defaults to shard's base address.")
 	fs.StringVar(&o.Extra.RootDirectory, "root-directory", o.Extra.RootDirectory, "Root directory.")
+	fs.StringVar(&o.Extra.ConfigFile, "config", o.Extra.ConfigFile, fmt.Sprintf("Path to a %s file of apiVersion %s with the controllers, replication, authorization, virtual workspaces and load shedding flags by section. Flags on the command line take precedence. Changes of %s are applied without restart.", ConfigurationKind, ConfigurationAPIVersion, strings.Join(ReloadableFlags.List(), ", ")))
 	fs.StringVar(&o.Extra.LogicalClusterAdminKubeconfig, "logical-cluster-admin-kubeconfig", o.Extra.LogicalClusterAdminKubeconfig, "Kubeconfig holding admin(!) credentials to other shards. Defaults to the loopback client")
+	fs.DurationVar(&o.Extra.ShardUsageHeartbeatInterval, "shard-usage-heartbeat-interval", o.Extra.ShardUsageHeartbeatInterval, "Interval of reporting the usage of this shard, i.e. its logical clusters, etcd database size and requests per second, in the status of its Shard. The workspace scheduler spreads workspaces across shards by their usage. 0 disables the heartbeat.")
+	fs.DurationVar(&o.Extra.ShardLeaseDuration, "shard-lease-duration", o.Extra.ShardLeaseDuration, "Duration of the lease this shard renews in the status of its Shard every quarter of the duration. Shards with an expired lease are not scheduled to and get no APIExport endpoints. 0 disables the lease.")
+	fs.StringVar(&o.Extra.HashAlgorithm, "hash-algorithm", o.Extra.HashAlgorithm, fmt.Sprintf("Hash algorithm of the shard hashes of newly scheduled workspaces, the identities of new APIExports and new logical cluster names, one of %s. The algorithm of existing values is recorded and kept. It must be the same on all shards. Defaults to sha224 for shard hashes and logical cluster names, and sha256 for identities.", strings.Join(hashAlgorithms(), ", ")))
+	fs.BoolVar(&o.Extra.FIPS, "fips", o.Extra.FIPS, "Fail to start unless kcp is built with the FIPS 140 validated BoringCrypto module (make build-fips), and only allow FIPS approved hash algorithms.")
+	fs.DurationVar(&o.Extra.CommitterBatchingWindow, "committer-batching-window", o.Extra.CommitterBatchingWindow, "How long status patches of the APIBinding and Workspace controllers wait to be merged with concurrent status patches of the same object, reducing the status write rate during mass events like shard restarts. 0 disables batching.")
+	fs.IntVar(&o.Extra.CommitterConcurrencyLimit, "committer-concurrency-limit", o.Extra.CommitterConcurrencyLimit, "Maximum number of patches of the APIBinding and Workspace controllers in flight at once. Waiting status patches are merged if --committer-batching-window is set. 0 means no limit.")
+	fs.IntVar(&o.Extra.BoundCRDPartitions, "bound-crd-partitions", o.Extra.BoundCRDPartitions, "Number of system logical clusters the CRDs of APIBindings are partitioned across by the hash of their APIResourceSchema, to spread the load on large shards. It can be increased, but must not be decreased.")
+
+	fs.StringVar(&o.Extra.CustomSubresourceClientCertFile, "apiexport-custom-subresource-client-cert-file", o.Extra.CustomSubresourceClientCertFile, "Client certificate presented to the custom subresource handlers of APIExports.")
+	fs.StringVar(&o.Extra.CustomSubresourceClientKeyFile, "apiexport-custom-subresource-client-key-file", o.Extra.CustomSubresourceClientKeyFile, "Key of the client certificate presented to the custom subresource handlers of APIExports.")
+
+	fs.StringVar(&o.Extra.ThrottlingExemptionSigningKeyFile, "throttling-exemption-signing-key-file", o.Extra.ThrottlingExemptionSigningKeyFile, "File holding the key to sign and verify ThrottlingExemptions with. It must be the same on all shards. Without key, ThrottlingExemptions cannot be created and no workspace is exempt from the per-workspace limits.")
+
+	fs.StringVar(&o.Extra.ShardRegistrationSigningCertFile, "shard-registration-signing-cert-file", o.Extra.ShardRegistrationSigningCertFile, "CA certificate to sign the client certificates of shards registering with a registration token with. All shards must trust it in their --client-ca-file. Only valid on the root shard. Without it, shards cannot register.")
+	fs.StringVar(&o.Extra.ShardRegistrationSigningKeyFile, "shard-registration-signing-key-file", o.Extra.ShardRegistrationSigningKeyFile, "Key of the --shard-registration-signing-cert-file CA.")
+	fs.StringVar(&o.Extra.ShardRegistrationURL, "shard-registration-url", o.Extra.ShardRegistrationURL, "Base URL of the root shard to register this shard with, using --shard-registration-token. The received credentials are written to the root directory and used as --root-shard-kubeconfig-file and, if unset, --logical-cluster-admin-kubeconfig.")
+	fs.StringVar(&o.Extra.ShardRegistrationToken, "shard-registration-token", o.Extra.ShardRegistrationToken, "Registration token of the form [a-z0-9]{6}.[a-z0-9]{16} to register this shard with at --shard-registration-url on first start. The token is consumed by the registration.")
+	fs.StringVar(&o.Extra.ShardRegistrationCAFile, "shard-registration-ca-file", o.Extra.ShardRegistrationCAFile, "CA bundle to verify the serving certificate of --shard-registration-url with.")
 
 	fs.BoolVar(&o.Extra.ExperimentalBindFreePort, "experimental-bind-free-port", o.Extra.ExperimentalBindFreePort, "Bind to a free port. --secure-port must be 0. Use the admin.kubeconfig to extract the chosen port.")
 	fs.MarkHidden("experimental-bind-free-port") //nolint:errcheck
@@ -203,6 +264,29 @@ func (o *CompletedOptions) Validate() []error {
 		}
 	}
 
+	if o.Extra.ShardUsageHeartbeatInterval < 0 {
+		errs = append(errs, fmt.Errorf("--shard-usage-heartbeat-interval must be >=0 (%s)", o.Extra.ShardUsageHeartbeatInterval))
+	}
+	if o.Extra.ShardLeaseDuration != 0 && o.Extra.ShardLeaseDuration < time.Second {
+		errs = append(errs, fmt.Errorf("--shard-lease-duration must be 0 or at least 1s (%s)", o.Extra.ShardLeaseDuration))
+	}
+	if o.Extra.HashAlgorithm != "" {
+		if hasher, err := crypto.HasherFor(o.Extra.HashAlgorithm); err != nil {
+			errs = append(errs, fmt.Errorf("invalid --hash-algorithm: %w", err))
+		} else if o.Extra.FIPS && !hasher.FIPSApproved() {
+			errs = append(errs, fmt.Errorf("--hash-algorithm %s is not FIPS approved, but --fips is set", o.Extra.HashAlgorithm))
+		}
+	}
+	if o.Extra.FIPS && !crypto.FIPSEnabled() {
+		errs = append(errs, fmt.Errorf("--fips requires kcp to be built with GOEXPERIMENT=boringcrypto, e.g. by make build-fips"))
+	}
+	if o.Extra.BoundCRDPartitions < 1 {
+		errs = append(errs, fmt.Errorf("--bound-crd-partitions must be at least 1"))
+	}
+	if (o.Extra.CustomSubresourceClientCertFile == "") != (o.Extra.CustomSubresourceClientKeyFile == "") {
+		errs = append(errs, fmt.Errorf("--apiexport-custom-subresource-client-cert-file and --apiexport-custom-subresource-client-key-file must be set together"))
+	}
+
 	errs = append(errs, o.GenericControlPlane.Validate()...)
 	errs = append(errs, o.Controllers.Validate()...)
 	errs = append(errs, o.EmbeddedEtcd.Validate()...)
@@ -210,6 +294,7 @@ func (o *CompletedOptions) Validate() []error {
 	errs = append(errs, o.AdminAuthentication.Validate()...)
 	errs = append(errs, o.Virtual.Validate()...)
 	errs = append(errs, o.HomeWorkspaces.Validate()...)
+	errs = append(errs, o.LoadShedding.Validate()...)
 	errs = append(errs, o.Cache.Validate()...)
 
 	differential := false
@@ -230,9 +315,54 @@ func (o *CompletedOptions) Validate() []error {
 		}
 	}
 
+	if o.Extra.CommitterBatchingWindow < 0 {
+		errs = append(errs, fmt.Errorf("--committer-batching-window must not be negative"))
+	}
+	if o.Extra.CommitterConcurrencyLimit < 0 {
+		errs = append(errs, fmt.Errorf("--committer-concurrency-limit must not be negative"))
+	}
+
+	if (o.Extra.ShardRegistrationSigningCertFile == "") != (o.Extra.ShardRegistrationSigningKeyFile == "") {
+		errs = append(errs, fmt.Errorf("--shard-registration-signing-cert-file and --shard-registration-signing-key-file must be set together"))
+	}
+	if o.Extra.ShardRegistrationSigningCertFile != "" && o.Extra.ShardName != corev1alpha1.RootShard {
+		errs = append(errs, fmt.Errorf("--shard-registration-signing-cert-file is only valid on the %s shard", corev1alpha1.RootShard))
+	}
+	if o.Extra.ShardRegistrationToken != "" {
+		if _, _, err := shardregistration.ParseToken(o.Extra.ShardRegistrationToken); err != nil {
+			errs = append(errs, fmt.Errorf("invalid --shard-registration-token: %w", err))
+		}
+		if o.Extra.ShardRegistrationURL == "" || o.Extra.ShardRegistrationCAFile == "" {
+			errs = append(errs, fmt.Errorf("--shard-registration-url and --shard-registration-ca-file are required if --shard-registration-token is set"))
+		} else if _, err := network.ParseServerURL(o.Extra.ShardRegistrationURL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid --shard-registration-url: %w", err))
+		}
+		if o.Extra.ShardExternalURL == "" {
+			errs = append(errs, fmt.Errorf("--shard-external-url is required if --shard-registration-token is set"))
+		}
+		if o.Extra.RootShardKubeconfigFile != "" {
+			errs = append(errs, fmt.Errorf("--root-shard-kubeconfig-file and --shard-registration-token are mutually exclusive"))
+		}
+		if o.Extra.ShardName == corev1alpha1.RootShard {
+			errs = append(errs, fmt.Errorf("the %s shard cannot register with a --shard-registration-token", corev1alpha1.RootShard))
+		}
+	}
+
 	if o.Extra.LogicalClusterAdminKubeconfig != "" && o.Extra.ShardExternalURL == "" {
 		errs = append(errs, fmt.Errorf("--shard-external-url is required if --logical-cluster-admin-kubeconfig is set"))
 	}
+	for _, u := range []struct{ flag, value string }{
+		{"--shard-base-url", o.Extra.ShardBaseURL},
+		{"--shard-external-url", o.Extra.ShardExternalURL},
+		{"--shard-virtual-workspace-url", o.Extra.ShardVirtualWorkspaceURL},
+	} {
+		if u.value == "" {
+			continue
+		}
+		if _, err := network.ParseServerURL(u.value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", u.flag, err))
+		}
+	}
 
 	return errs
 }
@@ -288,7 +418,7 @@ func (o *Options) Complete() (*CompletedOptions, error) {
 	}
 
 	if o.Extra.ExperimentalBindFreePort {
-		listener, _, err := genericapiserveroptions.CreateListener("tcp", fmt.Sprintf("%s:0", o.GenericControlPlane.SecureServing.BindAddress), net.ListenConfig{})
+		listener, _, err := genericapiserveroptions.CreateListener("tcp", net.JoinHostPort(o.GenericControlPlane.SecureServing.BindAddress.String(), "0"), net.ListenConfig{})
 		if err != nil {
 			return nil, err
 		}
@@ -366,6 +496,7 @@ func (o *Options) Complete() (*CompletedOptions, error) {
 			AdminAuthentication: o.AdminAuthentication,
 			Virtual:             o.Virtual,
 			HomeWorkspaces:      o.HomeWorkspaces,
+			LoadShedding:        o.LoadShedding,
 			Cache:               cacheCompletedOptions,
 			Extra:               o.Extra,
 		},
@@ -420,3 +551,11 @@ func mkdirRoot(dir string) error {
 	logger.Info("using root directory")
 	return nil
 }
+
+func hashAlgorithms() []string {
+	var algorithms []string
+	for _, hasher := range crypto.Hashers() {
+		algorithms = append(algorithms, hasher.Algorithm())
+	}
+	return algorithms
+}
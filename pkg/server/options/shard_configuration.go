@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// shardConfigurationKCPFlags are the flags of the "KCP" section which spec.configuration of a
+// Shard can set, in addition to the flags of the sections of the configuration file.
+var shardConfigurationKCPFlags = sets.NewString(
+	"committer-batching-window",
+	"committer-concurrency-limit",
+)
+
+// ShardReloadableFlags are the flags of spec.configuration of a Shard which are applied to the
+// running shard. Changes of feature gates and all other flags require a restart.
+var ShardReloadableFlags = ReloadableFlags.Union(shardConfigurationKCPFlags)
+
+// ApplyShardConfiguration applies spec.configuration of the Shard of this shard to the flag sets
+// of the options, on top of the command line and the configuration file. Unknown feature gates,
+// unknown flags and invalid values are skipped and returned as errors.
+func (o *Options) ApplyShardConfiguration(c *corev1alpha1.ShardConfiguration, fss cliflag.NamedFlagSets) []error {
+	o.Extra.ShardConfiguration = c.DeepCopy()
+	o.Extra.ShardConfigurationOverridden = map[string]string{}
+	if c == nil {
+		return nil
+	}
+
+	gates, errs := shardConfigurationFeatureGates(c)
+	for _, gate := range gates {
+		fs := fss.FlagSets["KCP"]
+		if fs == nil || fs.Lookup("feature-gates") == nil {
+			errs = append(errs, fmt.Errorf("featureGates: flag feature-gates does not exist"))
+			break
+		}
+		if err := fs.Set("feature-gates", gate); err != nil {
+			errs = append(errs, fmt.Errorf("featureGates: %w", err))
+		}
+	}
+
+	return append(errs, applyShardConfigurationFlags(c, fss, o.Extra.ShardConfigurationOverridden)...)
+}
+
+// ValidateShardConfiguration returns an error for every unknown feature gate, every flag which
+// cannot be set per shard and every invalid value of the configuration.
+func ValidateShardConfiguration(c *corev1alpha1.ShardConfiguration) []error {
+	if c == nil {
+		return nil
+	}
+	_, errs := shardConfigurationFeatureGates(c)
+	return append(errs, applyShardConfigurationFlags(c, NewOptions("").Flags(), map[string]string{})...)
+}
+
+// ShardConfigurationRestartRequired returns the fields which differ between the two shard
+// configurations and are not reloadable. Either may be nil.
+func ShardConfigurationRestartRequired(started, current *corev1alpha1.ShardConfiguration) []string {
+	var a, b corev1alpha1.ShardConfiguration
+	if started != nil {
+		a = *started
+	}
+	if current != nil {
+		b = *current
+	}
+
+	var fields []string
+	for _, name := range sets.StringKeySet(a.FeatureGates).Union(sets.StringKeySet(b.FeatureGates)).List() {
+		va, oka := a.FeatureGates[name]
+		vb, okb := b.FeatureGates[name]
+		if va != vb || oka != okb {
+			fields = append(fields, "featureGates."+name)
+		}
+	}
+	for _, name := range sets.StringKeySet(a.Flags).Union(sets.StringKeySet(b.Flags)).List() {
+		if ShardReloadableFlags.Has(name) {
+			continue
+		}
+		va, oka := a.Flags[name]
+		vb, okb := b.Flags[name]
+		if va != vb || oka != okb {
+			fields = append(fields, "flags."+name)
+		}
+	}
+	return fields
+}
+
+// ReloadableOptions are the options of a running shard which follow changes of the
+// configuration file and of spec.configuration of its Shard.
+type ReloadableOptions struct {
+	LoadShedding              LoadShedding
+	CommitterBatchingWindow   time.Duration
+	CommitterConcurrencyLimit int
+}
+
+// ReloadableOptions returns the reloadable options the shard started with.
+func (o *CompletedOptions) ReloadableOptions() ReloadableOptions {
+	return ReloadableOptions{
+		LoadShedding:              o.LoadShedding,
+		CommitterBatchingWindow:   o.Extra.CommitterBatchingWindow,
+		CommitterConcurrencyLimit: o.Extra.CommitterConcurrencyLimit,
+	}
+}
+
+// Reload returns the reloadable options of a shard which started with r and the given extra
+// options, for the current configuration file c and spec.configuration sc of its Shard. Either
+// may be nil. spec.configuration takes precedence over the command line, which takes precedence
+// over the configuration file.
+func (r ReloadableOptions) Reload(extra ExtraOptions, c *Configuration, sc *corev1alpha1.ShardConfiguration) (*ReloadableOptions, error) {
+	fs := pflag.NewFlagSet("reloadable", pflag.ContinueOnError)
+	r.LoadShedding.AddFlags(fs)
+	fs.DurationVar(&r.CommitterBatchingWindow, "committer-batching-window", r.CommitterBatchingWindow, "")
+	fs.IntVar(&r.CommitterConcurrencyLimit, "committer-concurrency-limit", r.CommitterConcurrencyLimit, "")
+
+	// undo spec.configuration as applied on start, then layer the current values on top
+	var errs []error
+	for name, value := range extra.ShardConfigurationOverridden {
+		if err := fs.Set(name, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	if c != nil {
+		ls, err := c.ReloadLoadShedding(r.LoadShedding, extra.CommandLineFlags)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			r.LoadShedding = *ls
+		}
+	}
+	if sc != nil {
+		for _, name := range sets.StringKeySet(sc.Flags).Intersection(ShardReloadableFlags).List() {
+			if err := fs.Set(name, sc.Flags[name]); err != nil {
+				errs = append(errs, fmt.Errorf("flags.%s: %w", name, err))
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		errs = r.LoadShedding.Validate()
+		if r.CommitterBatchingWindow < 0 {
+			errs = append(errs, fmt.Errorf("--committer-batching-window must not be negative"))
+		}
+		if r.CommitterConcurrencyLimit < 0 {
+			errs = append(errs, fmt.Errorf("--committer-concurrency-limit must not be negative"))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return &r, nil
+}
+
+// shardConfigurationFeatureGates returns the known feature gates of the configuration as
+// key=value pairs, and an error for every unknown one. Gates hidden from --feature-gates
+// cannot be set either.
+func shardConfigurationFeatureGates(c *corev1alpha1.ShardConfiguration) ([]string, []error) {
+	known := sets.NewString(kcpfeatures.KnownFeatures()...)
+
+	var gates []string
+	var errs []error
+	for _, name := range sets.StringKeySet(c.FeatureGates).List() {
+		if !known.Has(name) {
+			errs = append(errs, fmt.Errorf("featureGates.%s: unknown feature gate", name))
+			continue
+		}
+		gates = append(gates, fmt.Sprintf("%s=%t", name, c.FeatureGates[name]))
+	}
+	return gates, errs
+}
+
+// applyShardConfigurationFlags sets the flags of the configuration in the given flag sets, and
+// records the previous values of the reloadable flags in overridden.
+func applyShardConfigurationFlags(c *corev1alpha1.ShardConfiguration, fss cliflag.NamedFlagSets, overridden map[string]string) []error {
+	var errs []error
+	for _, name := range sets.StringKeySet(c.Flags).List() {
+		fs := shardConfigurationFlagSet(fss, name)
+		if fs == nil {
+			errs = append(errs, fmt.Errorf("flags.%s: unknown flag or not settable per shard", name))
+			continue
+		}
+
+		f := fs.Lookup(name)
+		previous := f.Value.String()
+		var err error
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var values []string
+			if c.Flags[name] != "" {
+				values = strings.Split(c.Flags[name], ",")
+			}
+			err = sv.Replace(values)
+		} else {
+			err = fs.Set(name, c.Flags[name])
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("flags.%s: %w", name, err))
+			continue
+		}
+		if ShardReloadableFlags.Has(name) {
+			overridden[name] = previous
+		}
+	}
+	return errs
+}
+
+// shardConfigurationFlagSet returns the flag set holding the given flag if spec.configuration
+// can set it, or nil.
+func shardConfigurationFlagSet(fss cliflag.NamedFlagSets, name string) *pflag.FlagSet {
+	if shardConfigurationKCPFlags.Has(name) {
+		return fss.FlagSets["KCP"]
+	}
+	for _, section := range (&Configuration{}).sections() {
+		if fs := fss.FlagSets[section.flagSet]; fs != nil && fs.Lookup(name) != nil {
+			return fs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestApplyShardConfiguration(t *testing.T) {
+	o := NewOptions(".kcp")
+	fss := o.Flags()
+	require.NoError(t, fss.FlagSet("KCP Load Shedding").Parse([]string{"--load-shedding-memory-threshold=4Gi"}))
+
+	errs := o.ApplyShardConfiguration(&corev1alpha1.ShardConfiguration{
+		FeatureGates: map[string]bool{"KCPLocationAPI": true, "KCPUnknown": true},
+		Flags: map[string]string{
+			"unsupported-run-individual-controllers": "apibinding,apiexport",
+			"load-shedding-memory-threshold":         "6Gi",
+			"committer-batching-window":              "100ms",
+			"committer-concurrency-limit":            "many",
+			"shard-name":                             "other",
+		},
+	}, fss)
+	require.Len(t, errs, 3)
+	require.EqualError(t, errs[0], "featureGates.KCPUnknown: unknown feature gate")
+	require.ErrorContains(t, errs[1], "flags.committer-concurrency-limit: ")
+	require.EqualError(t, errs[2], "flags.shard-name: unknown flag or not settable per shard")
+
+	require.Equal(t, []string{"apibinding", "apiexport"}, o.Controllers.IndividuallyEnabled)
+	require.Equal(t, "6Gi", o.LoadShedding.MemoryThreshold, "shard configuration takes precedence over the command line")
+	require.Equal(t, 100*time.Millisecond, o.Extra.CommitterBatchingWindow)
+	require.Equal(t, map[string]string{"load-shedding-memory-threshold": "4Gi", "committer-batching-window": "0s"}, o.Extra.ShardConfigurationOverridden)
+	require.Equal(t, "root", o.Extra.ShardName)
+}
+
+func TestShardConfigurationRestartRequired(t *testing.T) {
+	started := &corev1alpha1.ShardConfiguration{
+		FeatureGates: map[string]bool{"KCPLocationAPI": true},
+		Flags:        map[string]string{"apiexport-schema-lint": "true", "committer-batching-window": "100ms"},
+	}
+	require.Empty(t, ShardConfigurationRestartRequired(started, started.DeepCopy()))
+	require.Empty(t, ShardConfigurationRestartRequired(nil, nil))
+	require.Equal(t, []string{"featureGates.KCPLocationAPI", "flags.apiexport-schema-lint"}, ShardConfigurationRestartRequired(started, &corev1alpha1.ShardConfiguration{
+		Flags: map[string]string{"committer-batching-window": "1s"},
+	}))
+}
+
+func TestReloadShardConfiguration(t *testing.T) {
+	started := ReloadableOptions{
+		LoadShedding:            LoadShedding{Enabled: true, MemoryThreshold: "6Gi", EtcdLatencyThreshold: time.Second, CheckInterval: 10 * time.Second},
+		CommitterBatchingWindow: 100 * time.Millisecond,
+	}
+	extra := ExtraOptions{
+		CommandLineFlags:             sets.NewString("load-shedding-etcd-latency-threshold"),
+		ShardConfigurationOverridden: map[string]string{"load-shedding-memory-threshold": "4Gi"},
+	}
+
+	r, err := started.Reload(extra, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "4Gi", r.LoadShedding.MemoryThreshold, "removed shard configuration is undone")
+	require.Equal(t, 100*time.Millisecond, r.CommitterBatchingWindow)
+
+	file, err := ParseConfiguration([]byte(`
+apiVersion: config.kcp.io/v1alpha1
+kind: KcpConfiguration
+loadShedding:
+  load-shedding-memory-threshold: 8Gi
+  load-shedding-etcd-latency-threshold: 3s
+`))
+	require.NoError(t, err)
+	r, err = started.Reload(extra, file, &corev1alpha1.ShardConfiguration{
+		Flags: map[string]string{
+			"load-shedding-etcd-latency-threshold": "2s",
+			"committer-concurrency-limit":          "5",
+			"apiexport-schema-lint":                "true",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "8Gi", r.LoadShedding.MemoryThreshold)
+	require.Equal(t, 2*time.Second, r.LoadShedding.EtcdLatencyThreshold, "shard configuration takes precedence over the command line")
+	require.Equal(t, 5, r.CommitterConcurrencyLimit)
+
+	_, err = started.Reload(extra, nil, &corev1alpha1.ShardConfiguration{
+		Flags: map[string]string{"committer-concurrency-limit": "-1"},
+	})
+	require.Error(t, err)
+}
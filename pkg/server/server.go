@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http"
 	_ "net/http/pprof"
+	"sync"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -39,13 +40,18 @@ import (
 	configrootcompute "github.com/kcp-dev/kcp/config/rootcompute"
 	configshard "github.com/kcp-dev/kcp/config/shard"
 	systemcrds "github.com/kcp-dev/kcp/config/system-crds"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/informer"
 	metadataclient "github.com/kcp-dev/kcp/pkg/metadata"
+	"github.com/kcp-dev/kcp/pkg/schemaconversion"
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+	"github.com/kcp-dev/kcp/sdk/reconciler/committer"
 )
 
 const resyncPeriod = 10 * time.Hour
@@ -57,6 +63,12 @@ type Server struct {
 
 	syncedCh             chan struct{}
 	rootPhase1FinishedCh chan struct{}
+
+	// reloadLock guards the configuration reloaded at runtime and the options applied for it.
+	reloadLock                 sync.Mutex
+	reloadedConfigFile         *kcpserveroptions.Configuration
+	reloadedShardConfiguration *corev1alpha1.ShardConfiguration
+	reloadedOptions            kcpserveroptions.ReloadableOptions
 }
 
 func (s *Server) AddPostStartHook(name string, hook genericapiserver.PostStartHookFunc) error {
@@ -68,6 +80,9 @@ func NewServer(c CompletedConfig) (*Server, error) {
 		CompletedConfig:      c,
 		syncedCh:             make(chan struct{}),
 		rootPhase1FinishedCh: make(chan struct{}),
+
+		reloadedShardConfiguration: c.Options.Extra.ShardConfiguration,
+		reloadedOptions:            c.Options.ReloadableOptions(),
 	}
 
 	var err error
@@ -102,8 +117,8 @@ func NewServer(c CompletedConfig) (*Server, error) {
 		crdGVRSource,
 		indexers.AppendOrDie(
 			cache.Indexers{
-				indexers.BySyncerFinalizerKey:           indexers.IndexBySyncerFinalizerKey,
-				indexers.ByClusterResourceStateLabelKey: indexers.IndexByClusterResourceStateLabelKey,
+				kcpindexers.BySyncerFinalizerKey:           kcpindexers.IndexBySyncerFinalizerKey,
+				kcpindexers.ByClusterResourceStateLabelKey: kcpindexers.IndexByClusterResourceStateLabelKey,
 			},
 		),
 	)
@@ -119,6 +134,38 @@ func (s *Server) Run(ctx context.Context) error {
 	ctx = klog.NewContext(ctx, logger)
 	delegationChainHead := s.MiniAggregator.GenericAPIServer
 
+	committer.SetStatusProvenanceEnabled(kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.StatusProvenance))
+	committer.SetProvenanceShard(s.Options.Extra.ShardName)
+
+	delegationChainHead.Handler.NonGoRestfulMux.Handle(WorkspaceDiscoveryPath, newWorkspaceDiscoveryHandler(
+		func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		s.CompletedConfig.ShardExternalURL,
+	))
+	delegationChainHead.Handler.NonGoRestfulMux.Handle(APIResourceSchemaDiffPath, newAPIResourceSchemaDiffHandler(
+		func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			return s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister().Cluster(clusterName).Get(name)
+		},
+		s.GenericConfig.Authorization.Authorizer,
+	))
+	localSchemaLister := s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister()
+	cacheSchemaLister := s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Lister()
+	delegationChainHead.Handler.NonGoRestfulMux.HandlePrefix(schemaconversion.Path+"/", newAPIResourceSchemaConversionHandler(
+		func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+			sch, err := localSchemaLister.Cluster(clusterName).Get(name)
+			if errors.IsNotFound(err) {
+				// schemas of other shards are replicated by the cache server
+				return cacheSchemaLister.Cluster(clusterName).Get(name)
+			}
+			return sch, err
+		},
+	))
+
+	if s.ShardRegistrationSigner != nil {
+		s.preHandlerChainMux.Handle(ShardRegistrationPath, newShardRegistrationHandler(s.KubeClusterClient, s.KcpClusterClient, s.ShardRegistrationSigner))
+	}
+
 	if err := s.AddPostStartHook("kcp-bootstrap-policy", bootstrappolicy.Policy().EnsureRBACPolicy()); err != nil {
 		return err
 	}
@@ -322,6 +369,38 @@ func (s *Server) Run(ctx context.Context) error {
 		return err
 	}
 
+	if s.Options.LoadShedding.Enabled {
+		if err := s.installLoadSheddingWatchdog(ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Extra.ShardUsageHeartbeatInterval > 0 {
+		if err := s.installShardUsageReporter(ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Extra.ShardLeaseDuration > 0 {
+		if err := s.installShardLeaseRenewer(ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Extra.ConfigFile != "" {
+		if err := s.installConfigFileReloader(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := s.installControllerSwitchboard(ctx); err != nil {
+		return err
+	}
+
+	if err := s.installShardConfigurationReloader(ctx); err != nil {
+		return err
+	}
+
 	// ========================================================================================================
 	// TODO: split apart everything after this line, into their own commands, optional launched in this process
 
@@ -358,6 +437,11 @@ func (s *Server) Run(ctx context.Context) error {
 	if len(enabled) > 0 {
 		logger.WithValues("controllers", enabled).Info("starting controllers individually")
 	}
+	// external controller groups are run by kcp-controller-manager.
+	external := sets.NewString(s.Options.Controllers.ExternalGroups...)
+	if len(external) > 0 {
+		logger.WithValues("controllers", external).Info("not starting external controllers")
+	}
 
 	if s.Options.Controllers.EnableAll || enabled.Has("cluster") {
 		// bootstrap root compute workspace
@@ -439,18 +523,132 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}
 
+	if s.Options.Controllers.EnableAll || enabled.Has("apiexportconsumers") {
+		if err := s.installAPIExportConsumersController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("apiexportconsumersummary") {
+		if err := s.installAPIExportConsumerSummaryController(ctx, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("apiexportmigration") {
+		if err := s.installAPIExportMigrationController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if (s.Options.Controllers.EnableAll || enabled.Has("apiexportdefaultendpointslice")) && !external.Has("apiexportdefaultendpointslice") {
+		if err := s.installAPIExportDefaultEndpointSliceController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.APIExportSchemaLint && (s.Options.Controllers.EnableAll || enabled.Has("apiexportschemalint")) && !external.Has("apiexportschemalint") {
+		if err := s.installAPIExportSchemaLintController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.APIExportUsage.Enabled && (s.Options.Controllers.EnableAll || enabled.Has("apiexportusage")) {
+		if err := s.installAPIExportUsageController(ctx, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if (s.Options.Controllers.EnableAll || enabled.Has("apiresourceschemacompat")) && !external.Has("apiresourceschemacompat") {
+		if err := s.installAPIResourceSchemaCompatController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
 	if s.Options.Controllers.EnableAll || enabled.Has("apiexportendpointslice") {
 		if err := s.installAPIExportEndpointSliceController(ctx, controllerConfig, delegationChainHead); err != nil {
 			return err
 		}
 	}
 
+	if (s.Options.Controllers.EnableAll || enabled.Has("retention")) && !external.Has("retention") {
+		if err := s.installRetentionController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if (s.Options.Controllers.EnableAll || enabled.Has("limitincreaserequest")) && !external.Has("limitincreaserequest") {
+		if err := s.installLimitIncreaseRequestController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("workspacequota") {
+		if err := s.installWorkspaceQuotaController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("workspacetombstone") {
+		if err := s.installWorkspaceTombstoneController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("workspacetyperollout") {
+		if err := s.installWorkspaceTypeRolloutController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("datajob") {
+		if err := s.installDataJobController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.WorkspaceUsage.Enabled && (s.Options.Controllers.EnableAll || enabled.Has("workspaceusage")) {
+		if err := s.installWorkspaceUsageController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if (s.Options.Controllers.EnableAll || enabled.Has("binding-expiry")) && !external.Has("binding-expiry") {
+		if err := s.installBindingExpiryController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("workspace-summary") {
+		if err := s.installWorkspaceSummaryController(ctx, s.LogicalClusterAdminConfig, s.CompletedConfig.ShardExternalURL); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("rbac-propagation") {
+		if err := s.installRBACPropagationController(ctx, s.LogicalClusterAdminConfig, s.CompletedConfig.ShardExternalURL); err != nil {
+			return err
+		}
+	}
+
 	if s.Options.Controllers.EnableAll || enabled.Has("apibinder") {
 		if err := s.installAPIBinderController(ctx, controllerConfig, delegationChainHead); err != nil {
 			return err
 		}
 	}
 
+	if s.Options.Controllers.EnableAll || enabled.Has("apibindingupgrader") {
+		if err := s.installAPIBindingUpgraderController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if s.Options.Controllers.EnableAll || enabled.Has("templateinitializer") {
+		if err := s.installTemplateInitializerController(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
 	if kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.LocationAPI) {
 		if s.Options.Controllers.EnableAll || enabled.Has("scheduling") {
 			if err := s.installWorkloadNamespaceScheduler(ctx, controllerConfig, delegationChainHead); err != nil {
@@ -486,6 +684,16 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}
 
+	if kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.PersistentRateLimiter) {
+		if err := s.installRateLimiterStatePersister(ctx, controllerConfig, delegationChainHead); err != nil {
+			return err
+		}
+	}
+
+	if err := s.installMaintenanceScheduler(ctx, delegationChainHead); err != nil {
+		return err
+	}
+
 	if s.Options.Virtual.Enabled {
 		virtualWorkspacesConfig := rest.CopyConfig(s.GenericConfig.LoopbackClientConfig)
 		virtualWorkspacesConfig = rest.AddUserAgent(virtualWorkspacesConfig, "virtual-workspaces")
@@ -504,6 +712,12 @@ func (s *Server) Run(ctx context.Context) error {
 		return err
 	}
 
+	if len(external) > 0 {
+		if err := s.writeControllerManagerKubeConfig(); err != nil {
+			return err
+		}
+	}
+
 	return delegationChainHead.PrepareRun().Run(ctx.Done())
 }
 
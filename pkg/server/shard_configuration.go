@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
+
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+const (
+	// ShardConfigurationFile is the file in the root directory holding the last seen
+	// spec.configuration of the Shard of this shard. It is applied on start if the Shard cannot
+	// be read from the root shard, e.g. on the root shard itself, which serves its Shard only
+	// after start.
+	ShardConfigurationFile = "shard-configuration.json"
+
+	// shardConfigurationTimeout is the timeout of reading the Shard from the root shard on start.
+	shardConfigurationTimeout = 10 * time.Second
+)
+
+// ApplyShardConfiguration applies spec.configuration of the Shard of this shard to the flag sets
+// of the options, on top of the command line and the configuration file. Invalid feature gates
+// and flags are logged and skipped, such that a broken Shard cannot keep the shard from starting.
+func ApplyShardConfiguration(ctx context.Context, o *kcpserveroptions.Options, fss cliflag.NamedFlagSets) {
+	logger := klog.FromContext(ctx).WithValues("shard", o.Extra.ShardName)
+
+	c, err := readShardConfiguration(ctx, o)
+	if err != nil {
+		logger.Error(err, "failed to read the configuration of the Shard, starting without it")
+	}
+	if c != nil {
+		logger.Info("applying the configuration of the Shard", "featureGates", c.FeatureGates, "flags", c.Flags)
+	}
+	if errs := o.ApplyShardConfiguration(c, fss); len(errs) > 0 {
+		logger.Error(utilerrors.NewAggregate(errs), "ignoring invalid parts of the configuration of the Shard")
+	}
+}
+
+// readShardConfiguration returns spec.configuration of the Shard of this shard from the root
+// shard, falling back to the ShardConfigurationFile of the last run.
+func readShardConfiguration(ctx context.Context, o *kcpserveroptions.Options) (*corev1alpha1.ShardConfiguration, error) {
+	logger := klog.FromContext(ctx)
+
+	if o.Extra.ShardName != corev1alpha1.RootShard && o.Extra.RootShardKubeconfigFile != "" {
+		shard, err := getShardFromRootShard(ctx, o.Extra.RootShardKubeconfigFile, o.Extra.ShardName)
+		switch {
+		case err == nil:
+			return shard.Spec.Configuration, nil
+		case errors.IsNotFound(err):
+			return nil, nil
+		default:
+			logger.Error(err, "failed to get the Shard from the root shard, using the configuration of the last run")
+		}
+	}
+
+	bs, err := os.ReadFile(filepath.Join(o.Extra.RootDirectory, ShardConfigurationFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var c corev1alpha1.ShardConfiguration
+	if err := json.Unmarshal(bs, &c); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ShardConfigurationFile, err)
+	}
+	return &c, nil
+}
+
+func getShardFromRootShard(ctx context.Context, kubeconfigFile, shardName string) (*corev1alpha1.Shard, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigFile}, &clientcmd.ConfigOverrides{CurrentContext: "system:admin"}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig from: %s, for the root shard, err: %w", kubeconfigFile, err)
+	}
+	config.Timeout = shardConfigurationTimeout
+	client, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return client.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Get(ctx, shardName, metav1.GetOptions{})
+}
+
+// writeShardConfiguration persists the configuration for the next start, or removes the file
+// if there is none.
+func writeShardConfiguration(rootDir string, c *corev1alpha1.ShardConfiguration) error {
+	file := filepath.Join(rootDir, ShardConfigurationFile)
+	if c == nil {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	bs, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, bs, 0600)
+}
+
+// installShardConfigurationReloader follows spec.configuration of the Shard of this shard, as
+// seen through the cache server. Changes of reloadable flags are applied to the running shard,
+// all other changes are reported as requiring a restart in the ConfigurationApplied condition.
+// The configuration is persisted in the root directory for the next start.
+func (s *Server) installShardConfigurationReloader(ctx context.Context) error {
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: s.isOwnShard,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { notify() },
+			UpdateFunc: func(_, _ interface{}) { notify() },
+			DeleteFunc: func(interface{}) { notify() },
+		},
+	})
+
+	hookName := "kcp-shard-configuration-reloader"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", hookName, "shard", s.Options.Extra.ShardName)
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		ctx := klog.NewContext(goContext(hookContext), logger)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+					s.reloadShardConfiguration(ctx)
+				}
+			}
+		}()
+
+		return nil
+	})
+}
+
+// reloadShardConfiguration applies the current spec.configuration of the Shard of this shard
+// and records the outcome in its ConfigurationApplied condition.
+func (s *Server) reloadShardConfiguration(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	shard, err := s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Lister().Cluster(core.RootCluster).Get(s.Options.Extra.ShardName)
+	if errors.IsNotFound(err) {
+		return
+	} else if err != nil {
+		logger.Error(err, "failed to get Shard")
+		return
+	}
+
+	c := shard.Spec.Configuration
+	if err := writeShardConfiguration(s.Options.Extra.RootDirectory, c); err != nil {
+		logger.Error(err, "failed to persist the configuration of the Shard")
+	}
+
+	var invalid error
+	if errs := kcpserveroptions.ValidateShardConfiguration(c); len(errs) > 0 {
+		invalid = utilerrors.NewAggregate(errs)
+	} else {
+		s.reloadLock.Lock()
+		previous := s.reloadedShardConfiguration
+		s.reloadedShardConfiguration = c
+		if invalid = s.applyReloadableOptions(ctx); invalid != nil {
+			s.reloadedShardConfiguration = previous
+		}
+		s.reloadLock.Unlock()
+	}
+	if invalid != nil {
+		logger.Error(invalid, "ignoring invalid configuration of the Shard")
+	}
+
+	started := s.Options.Extra.ShardConfiguration
+	restartRequired := kcpserveroptions.ShardConfigurationRestartRequired(started, c)
+	if len(restartRequired) > 0 {
+		logger.Info("configuration of the Shard changed, a restart is required to apply some of the changes", "fields", restartRequired)
+	}
+
+	updated := shard.DeepCopy()
+	setShardConfigurationCondition(updated, started, restartRequired, invalid)
+	if equality.Semantic.DeepEqual(shard.Status.Conditions, updated.Status.Conditions) {
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		shard, err := s.RootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Get(ctx, s.Options.Extra.ShardName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		setShardConfigurationCondition(shard, started, restartRequired, invalid)
+		_, err = s.RootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().UpdateStatus(ctx, shard, metav1.UpdateOptions{})
+		return err
+	})
+	if errors.IsNotFound(err) {
+		logger.V(2).Info("Shard not found, not recording the configuration state")
+	} else if err != nil {
+		logger.Error(err, "failed to record the configuration state in Shard")
+	}
+}
+
+// setShardConfigurationCondition sets the ConfigurationApplied condition of the shard, or
+// removes it if neither the shard started with a configuration nor has one now.
+func setShardConfigurationCondition(shard *corev1alpha1.Shard, started *corev1alpha1.ShardConfiguration, restartRequired []string, invalid error) {
+	switch {
+	case invalid != nil:
+		conditions.MarkFalse(shard, corev1alpha1.ShardConfigurationApplied, corev1alpha1.ShardConfigurationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "%v", invalid)
+	case len(restartRequired) > 0:
+		conditions.MarkFalse(shard, corev1alpha1.ShardConfigurationApplied, corev1alpha1.ShardConfigurationRestartRequiredReason, conditionsv1alpha1.ConditionSeverityWarning, "Restart the shard to apply %s.", strings.Join(restartRequired, ", "))
+	case started == nil && shard.Spec.Configuration == nil:
+		conditions.Delete(shard, corev1alpha1.ShardConfigurationApplied)
+	default:
+		conditions.MarkTrue(shard, corev1alpha1.ShardConfigurationApplied)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/network"
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+	"github.com/kcp-dev/kcp/pkg/shardregistration"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// ShardRegistrationPath is the path of the shard registration endpoint of the root shard. It is
+// served in front of the handler chain, i.e. unauthenticated, as the registration token in the
+// Authorization header is the only credential of a joining shard.
+const ShardRegistrationPath = shardregistration.Path
+
+// newShardRegistrationHandler returns the handler of ShardRegistrationPath. For a valid token,
+// it signs the client certificate of the shard, creates its Shard in the root workspace and
+// deletes the token Secret, i.e. every token registers one shard.
+func newShardRegistrationHandler(kubeClusterClient kcpkubernetesclientset.ClusterInterface, kcpClusterClient kcpclientset.ClusterInterface, signer *shardregistration.Signer) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		logger := klog.FromContext(req.Context()).WithValues("path", ShardRegistrationPath)
+		fail := func(err error) {
+			responsewriters.ErrorNegotiated(err, errorCodecs, schema.GroupVersion{}, w, req)
+		}
+		unauthorized := apierrors.NewUnauthorized("invalid shard registration token")
+
+		if req.Method != http.MethodPost {
+			fail(apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "shard-registration"}, req.Method))
+			return
+		}
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		tokenID, _, err := shardregistration.ParseToken(token)
+		if err != nil {
+			fail(unauthorized)
+			return
+		}
+		logger = logger.WithValues("tokenID", tokenID)
+
+		var registration shardregistration.Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<20)).Decode(&registration); err != nil {
+			fail(apierrors.NewBadRequest(fmt.Sprintf("invalid shard registration request: %v", err)))
+			return
+		}
+		if err := validateShardRegistration(&registration); err != nil {
+			fail(apierrors.NewBadRequest(err.Error()))
+			return
+		}
+		logger = logger.WithValues("shard", registration.ShardName)
+
+		secrets := kubeClusterClient.Cluster(core.RootCluster.Path()).CoreV1().Secrets(shardregistration.SecretNamespace)
+		secret, err := secrets.Get(req.Context(), shardregistration.SecretName(tokenID), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			logger.Info("rejected shard registration with unknown token")
+			fail(unauthorized)
+			return
+		} else if err != nil {
+			fail(apierrors.NewInternalError(err))
+			return
+		}
+		if err := shardregistration.ValidateTokenSecret(secret, token, registration.ShardName, time.Now()); err != nil {
+			logger.Info("rejected shard registration", "reason", err.Error())
+			fail(unauthorized)
+			return
+		}
+
+		cert, err := signer.Sign(registration.CertificateSigningRequest, registration.ShardName, time.Now())
+		if err != nil {
+			fail(apierrors.NewBadRequest(err.Error()))
+			return
+		}
+
+		// creating the Shard fails for existing shards, such that a token cannot take over the
+		// identity of a registered shard, and concurrent uses of one token register one shard only.
+		shard := &corev1alpha1.Shard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        registration.ShardName,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: core.RootCluster.String()},
+				Labels: map[string]string{
+					"name": registration.ShardName,
+				},
+			},
+			Spec: corev1alpha1.ShardSpec{
+				BaseURL:             registration.BaseURL,
+				ExternalURL:         registration.ExternalURL,
+				VirtualWorkspaceURL: registration.VirtualWorkspaceURL,
+			},
+		}
+		if _, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Create(req.Context(), shard, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) {
+			fail(apierrors.NewAlreadyExists(corev1alpha1.Resource("shards"), registration.ShardName))
+			return
+		} else if err != nil {
+			fail(apierrors.NewInternalError(err))
+			return
+		}
+
+		if err := secrets.Delete(req.Context(), secret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			// the token expires anyway
+			logger.Error(err, "failed to delete used shard registration token")
+		}
+
+		logger.Info("registered shard")
+		responsewriters.WriteRawJSON(http.StatusCreated, shardregistration.Response{Certificate: cert}, w)
+	}
+}
+
+// validateShardRegistration validates and defaults the given registration request.
+func validateShardRegistration(registration *shardregistration.Request) error {
+	if registration.ShardName == corev1alpha1.RootShard {
+		return fmt.Errorf("shard %q cannot be registered", corev1alpha1.RootShard)
+	}
+	if errs := validation.IsDNS1123Subdomain(registration.ShardName); len(errs) > 0 {
+		return fmt.Errorf("invalid shard name %q: %s", registration.ShardName, strings.Join(errs, ", "))
+	}
+	if registration.BaseURL == "" {
+		registration.BaseURL = registration.ExternalURL
+	}
+	if registration.VirtualWorkspaceURL == "" {
+		registration.VirtualWorkspaceURL = registration.BaseURL
+	}
+	for _, u := range []struct{ field, value string }{
+		{"externalURL", registration.ExternalURL},
+		{"baseURL", registration.BaseURL},
+		{"virtualWorkspaceURL", registration.VirtualWorkspaceURL},
+	} {
+		if _, err := network.ParseServerURL(u.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", u.field, err)
+		}
+	}
+	return nil
+}
+
+// shardRegistrationKubeconfig is the file in the root directory holding the credentials of a
+// shard registered with a token.
+const shardRegistrationKubeconfig = "shard-registration.kubeconfig"
+
+// registerShard registers the shard with the root shard, unless it has been registered before,
+// and uses the received credentials as root shard and logical cluster admin kubeconfig. As the
+// token is consumed by the registration, later starts reuse the kubeconfig in the root directory.
+func registerShard(ctx context.Context, opts *kcpserveroptions.CompletedOptions) error {
+	logger := klog.FromContext(ctx).WithValues("shard", opts.Extra.ShardName)
+	kubeconfigPath := filepath.Join(opts.Extra.RootDirectory, shardRegistrationKubeconfig)
+
+	if _, err := os.Stat(kubeconfigPath); errors.Is(err, os.ErrNotExist) {
+		logger.Info("registering shard", "url", opts.Extra.ShardRegistrationURL)
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if err := shardregistration.Register(ctx, opts.Extra.ShardRegistrationURL, opts.Extra.ShardRegistrationCAFile, opts.Extra.ShardRegistrationToken, shardregistration.Request{
+			ShardName:           opts.Extra.ShardName,
+			BaseURL:             opts.Extra.ShardBaseURL,
+			ExternalURL:         opts.Extra.ShardExternalURL,
+			VirtualWorkspaceURL: opts.Extra.ShardVirtualWorkspaceURL,
+		}, kubeconfigPath); err != nil {
+			return err
+		}
+		logger.Info("registered shard", "kubeconfig", kubeconfigPath)
+	} else if err != nil {
+		return err
+	} else {
+		logger.Info("shard is registered already, ignoring --shard-registration-token", "kubeconfig", kubeconfigPath)
+	}
+
+	opts.Extra.RootShardKubeconfigFile = kubeconfigPath
+	if opts.Extra.LogicalClusterAdminKubeconfig == "" {
+		opts.Extra.LogicalClusterAdminKubeconfig = kubeconfigPath
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kcp-dev/kcp/pkg/shardregistration"
+)
+
+func TestValidateShardRegistration(t *testing.T) {
+	tests := map[string]struct {
+		registration shardregistration.Request
+		want         shardregistration.Request
+		wantErr      string
+	}{
+		"defaulted URLs": {
+			registration: shardregistration.Request{ShardName: "alpha", ExternalURL: "https://alpha.example.com:6443"},
+			want: shardregistration.Request{
+				ShardName:           "alpha",
+				BaseURL:             "https://alpha.example.com:6443",
+				ExternalURL:         "https://alpha.example.com:6443",
+				VirtualWorkspaceURL: "https://alpha.example.com:6443",
+			},
+		},
+		"virtual workspace URL defaults to the base URL": {
+			registration: shardregistration.Request{ShardName: "alpha", BaseURL: "https://10.0.0.1:6443", ExternalURL: "https://alpha.example.com:6443"},
+			want: shardregistration.Request{
+				ShardName:           "alpha",
+				BaseURL:             "https://10.0.0.1:6443",
+				ExternalURL:         "https://alpha.example.com:6443",
+				VirtualWorkspaceURL: "https://10.0.0.1:6443",
+			},
+		},
+		"root shard": {
+			registration: shardregistration.Request{ShardName: "root", ExternalURL: "https://root.example.com:6443"},
+			wantErr:      `shard "root" cannot be registered`,
+		},
+		"invalid name": {
+			registration: shardregistration.Request{ShardName: "Alpha_1", ExternalURL: "https://alpha.example.com:6443"},
+			wantErr:      `invalid shard name "Alpha_1"`,
+		},
+		"missing external URL": {
+			registration: shardregistration.Request{ShardName: "alpha"},
+			wantErr:      "invalid externalURL",
+		},
+		"unbracketed IPv6 address": {
+			registration: shardregistration.Request{ShardName: "alpha", ExternalURL: "https://2001:db8::1:6443"},
+			wantErr:      "invalid externalURL",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateShardRegistration(&tt.registration)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, tt.registration)
+		})
+	}
+}
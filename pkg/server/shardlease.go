@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// renewShardLease returns a function renewing the lease in the status of the Shard with the given
+// name in the root logical cluster. The lease is patched rather than updated, such that renewals
+// do not conflict with other writers of the status.
+func renewShardLease(rootShardKcpClusterClient kcpclientset.ClusterInterface, shardName string, duration time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		patch, err := json.Marshal(map[string]interface{}{
+			"status": map[string]interface{}{
+				"lease": corev1alpha1.ShardLease{
+					RenewTime:            metav1.Now(),
+					LeaseDurationSeconds: int32(math.Ceil(duration.Seconds())),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = rootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Patch(ctx, shardName, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		if errors.IsNotFound(err) {
+			klog.FromContext(ctx).V(2).Info("Shard not found, not renewing its lease", "shard", shardName)
+			return nil
+		}
+		return err
+	}
+}
+
+func (s *Server) installShardLeaseRenewer(ctx context.Context) error {
+	hookName := "kcp-start-shard-lease-renewer"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", hookName)
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		renew := renewShardLease(s.RootShardKcpClusterClient, s.Options.Extra.ShardName, s.Options.Extra.ShardLeaseDuration)
+		// renew four times per lease duration, such that single failed renewals do not expire the lease
+		go wait.UntilWithContext(goContext(hookContext), func(ctx context.Context) {
+			if err := renew(klog.NewContext(ctx, logger)); err != nil {
+				logger.Error(err, "failed to renew the lease of the shard")
+			}
+		}, s.Options.Extra.ShardLeaseDuration/4)
+
+		return nil
+	})
+}
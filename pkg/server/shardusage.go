@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/shardusage"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
+
+// newEtcdDatabaseSizeProbe returns a function returning the size of the etcd database behind the
+// given transport, as the largest database size of its members. The client is closed when ctx is
+// done.
+func newEtcdDatabaseSizeProbe(ctx context.Context, config storagebackend.TransportConfig) (func(ctx context.Context) (int64, error), error) {
+	tlsInfo := transport.TLSInfo{
+		CertFile:      config.CertFile,
+		KeyFile:       config.KeyFile,
+		TrustedCAFile: config.TrustedCAFile,
+	}
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config.CertFile == "" && config.KeyFile == "" && config.TrustedCAFile == "" {
+		tlsConfig = nil
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   config.ServerList,
+		TLS:         tlsConfig,
+		DialTimeout: 10 * time.Second,
+		Context:     ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		client.Close() //nolint:errcheck
+	}()
+
+	return func(ctx context.Context) (int64, error) {
+		var size int64
+		for _, endpoint := range config.ServerList {
+			status, err := client.Status(ctx, endpoint)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get the status of etcd member %s: %w", endpoint, err)
+			}
+			if status.DbSize > size {
+				size = status.DbSize
+			}
+		}
+		return size, nil
+	}, nil
+}
+
+// updateShardUsage returns a function recording the usage in the status of the Shard with the
+// given name in the root logical cluster.
+func updateShardUsage(rootShardKcpClusterClient kcpclientset.ClusterInterface, shardName string) func(ctx context.Context, usage *corev1alpha1.ShardUsage) error {
+	return func(ctx context.Context, usage *corev1alpha1.ShardUsage) error {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			shard, err := rootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().Get(ctx, shardName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			shard.Status.Usage = usage
+			_, err = rootShardKcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().UpdateStatus(ctx, shard, metav1.UpdateOptions{})
+			return err
+		})
+		if errors.IsNotFound(err) {
+			klog.FromContext(ctx).V(2).Info("Shard not found, not recording usage", "shard", shardName)
+			return nil
+		}
+		return err
+	}
+}
+
+func (s *Server) installShardUsageReporter(ctx context.Context) error {
+	hookName := "kcp-start-shard-usage-reporter"
+	return s.AddPostStartHook(hookName, func(hookContext genericapiserver.PostStartHookContext) error {
+		logger := klog.FromContext(ctx).WithValues("postStartHook", hookName)
+		if err := s.waitForSync(hookContext.StopCh); err != nil {
+			logger.Error(err, "failed to finish post-start-hook")
+			return nil // don't klog.Fatal. This only happens when context is cancelled.
+		}
+
+		// the size of the etcd database is optional, e.g. etcd might not grant the status call
+		etcdDatabaseSize, err := newEtcdDatabaseSizeProbe(goContext(hookContext), s.Options.GenericControlPlane.Etcd.StorageConfig.Transport)
+		if err != nil {
+			logger.Error(err, "failed to create etcd client, not reporting the etcd database size")
+			etcdDatabaseSize = nil
+		}
+
+		logicalClusters := s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Lister()
+		reporter := shardusage.NewReporter(
+			s.Options.Extra.ShardUsageHeartbeatInterval,
+			func() (int, error) {
+				clusters, err := logicalClusters.List(labels.Everything())
+				return len(clusters), err
+			},
+			etcdDatabaseSize,
+			updateShardUsage(s.RootShardKcpClusterClient, s.Options.Extra.ShardName),
+		)
+		go reporter.Start(goContext(hookContext))
+
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// WorkspaceDiscoveryPath is the path of the workspace discovery endpoint of every logical
+// cluster, e.g. /clusters/root:org:ws/.well-known/kcp-workspace. It returns a WorkspaceDiscovery
+// as JSON, and is readable by everybody with access to the workspace.
+const WorkspaceDiscoveryPath = bootstrappolicy.WorkspaceDiscoveryPath
+
+// WorkspaceDiscovery tells how to address the workspace of a logical cluster.
+type WorkspaceDiscovery struct {
+	// Cluster is the name of the logical cluster. It is a DNS-safe hash assigned when the
+	// workspace is scheduled and never changes.
+	Cluster string `json:"cluster"`
+
+	// Path is the canonical path of the workspace. It changes when the workspace or one of
+	// its ancestors is renamed or moved.
+	Path string `json:"path,omitempty"`
+
+	// ClusterURL addresses the workspace by the name of its logical cluster. It stays valid
+	// for the lifetime of the workspace. Prefer it for long-lived references like kubeconfigs.
+	ClusterURL string `json:"clusterURL"`
+
+	// PathURL addresses the workspace by its canonical path. It is human readable, but
+	// changes when Path changes.
+	PathURL string `json:"pathURL,omitempty"`
+}
+
+// newWorkspaceDiscoveryHandler returns the handler of WorkspaceDiscoveryPath. The URLs are
+// based on the external URL of the shard, like spec.URL of workspaces.
+func newWorkspaceDiscoveryHandler(getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error), shardExternalURL func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cluster := request.ClusterFrom(req.Context())
+		if cluster == nil || cluster.Name.Empty() || cluster.Wildcard {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(schema.GroupResource{}, WorkspaceDiscoveryPath),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		logicalCluster, err := getLogicalCluster(cluster.Name)
+		if apierrors.IsNotFound(err) {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(schema.GroupResource{}, WorkspaceDiscoveryPath),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		} else if err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewInternalError(err),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		discovery, err := workspaceDiscoveryOf(logicalCluster, shardExternalURL())
+		if err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewInternalError(err),
+				errorCodecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		responsewriters.WriteRawJSON(http.StatusOK, discovery, w)
+	}
+}
+
+func workspaceDiscoveryOf(logicalCluster *corev1alpha1.LogicalCluster, shardExternalURL string) (*WorkspaceDiscovery, error) {
+	u, err := url.Parse(shardExternalURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard external URL %q: %w", shardExternalURL, err)
+	}
+	urlOf := func(clusterPath logicalcluster.Path) string {
+		u := *u
+		u.Path = path.Join(u.Path, clusterPath.RequestPath())
+		return u.String()
+	}
+
+	clusterName := logicalcluster.From(logicalCluster)
+	discovery := &WorkspaceDiscovery{
+		Cluster:    clusterName.String(),
+		ClusterURL: urlOf(clusterName.Path()),
+	}
+	if clusterPath := logicalcluster.NewPath(logicalCluster.Annotations[core.LogicalClusterPathAnnotationKey]); !clusterPath.Empty() {
+		discovery.Path = clusterPath.String()
+		discovery.PathURL = urlOf(clusterPath)
+	}
+	return discovery, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestWorkspaceDiscoveryOf(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		shardExternalURL string
+		want             *WorkspaceDiscovery
+	}{
+		"with path": {
+			path:             "root:org:ws",
+			shardExternalURL: "https://kcp.example.com",
+			want: &WorkspaceDiscovery{
+				Cluster:    "2x8l3v1b9rgnh8ve",
+				Path:       "root:org:ws",
+				ClusterURL: "https://kcp.example.com/clusters/2x8l3v1b9rgnh8ve",
+				PathURL:    "https://kcp.example.com/clusters/root:org:ws",
+			},
+		},
+		"shard with a path prefix": {
+			path:             "root:org:ws",
+			shardExternalURL: "https://kcp.example.com/prefix",
+			want: &WorkspaceDiscovery{
+				Cluster:    "2x8l3v1b9rgnh8ve",
+				Path:       "root:org:ws",
+				ClusterURL: "https://kcp.example.com/prefix/clusters/2x8l3v1b9rgnh8ve",
+				PathURL:    "https://kcp.example.com/prefix/clusters/root:org:ws",
+			},
+		},
+		"without path": {
+			shardExternalURL: "https://kcp.example.com",
+			want: &WorkspaceDiscovery{
+				Cluster:    "2x8l3v1b9rgnh8ve",
+				ClusterURL: "https://kcp.example.com/clusters/2x8l3v1b9rgnh8ve",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			lc := &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{logicalcluster.AnnotationKey: "2x8l3v1b9rgnh8ve"},
+				},
+			}
+			if tt.path != "" {
+				lc.Annotations[core.LogicalClusterPathAnnotationKey] = tt.path
+			}
+			got, err := workspaceDiscoveryOf(lc, tt.shardExternalURL)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
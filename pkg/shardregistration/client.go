@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardregistration
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+// Path is the path of the shard registration endpoint of the root shard.
+const Path = "/shard-registration"
+
+// KubeconfigContext is the context of the kubeconfig written by Register. It is the context the
+// root shard kubeconfig of a shard is loaded with.
+const KubeconfigContext = "system:admin"
+
+// Request is the body of a POST to the shard registration endpoint. The token is passed as bearer
+// token in the Authorization header.
+type Request struct {
+	// ShardName is the name of the Shard to create in the root workspace.
+	ShardName string `json:"shardName"`
+	// BaseURL, ExternalURL and VirtualWorkspaceURL are the URLs of the Shard. BaseURL defaults
+	// to ExternalURL, VirtualWorkspaceURL to BaseURL.
+	BaseURL             string `json:"baseURL,omitempty"`
+	ExternalURL         string `json:"externalURL"`
+	VirtualWorkspaceURL string `json:"virtualWorkspaceURL,omitempty"`
+	// CertificateSigningRequest is the PEM encoded certificate signing request of the client
+	// certificate of the shard.
+	CertificateSigningRequest []byte `json:"certificateSigningRequest"`
+}
+
+// Response is the body of a successful registration.
+type Response struct {
+	// Certificate is the PEM encoded client certificate of the shard.
+	Certificate []byte `json:"certificate"`
+}
+
+// Register registers a shard with the root shard at rootShardURL, verified with the CA bundle in
+// caFile, using the given registration token. It writes a kubeconfig with the credentials of the
+// shard and the root shard as server to kubeconfigPath.
+func Register(ctx context.Context, rootShardURL, caFile, token string, req Request, kubeconfigPath string) error {
+	caData, err := os.ReadFile(caFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the root shard CA: %w", err)
+	}
+	pool, err := certutil.NewPoolFromBytes(caData)
+	if err != nil {
+		return fmt.Errorf("failed to parse the root shard CA: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: UserName(req.ShardName)},
+	}, key)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate signing request: %w", err)
+	}
+	req.CertificateSigningRequest = pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateRequestBlockType, Bytes: csr})
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	rootShardURL = strings.TrimSuffix(rootShardURL, "/")
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, rootShardURL+Path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Transport: &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to register shard %q: %w", req.ShardName, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to register shard %q: %s: %s", req.ShardName, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var registration Response
+	if err := json.Unmarshal(respBody, &registration); err != nil {
+		return fmt.Errorf("failed to decode the registration of shard %q: %w", req.ShardName, err)
+	}
+
+	keyData, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return err
+	}
+	return clientcmd.WriteToFile(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"root": {
+				Server:                   rootShardURL,
+				CertificateAuthorityData: caData,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			req.ShardName: {
+				ClientCertificateData: registration.Certificate,
+				ClientKeyData:         keyData,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			KubeconfigContext: {
+				Cluster:  "root",
+				AuthInfo: req.ShardName,
+			},
+		},
+		CurrentContext: KubeconfigContext,
+	}, kubeconfigPath)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardregistration
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+// CertificateValidity is how long the client certificates of registered shards are valid.
+const CertificateValidity = 365 * 24 * time.Hour
+
+// UserName returns the user name of the client certificate of the shard with the given name.
+func UserName(shardName string) string {
+	return "system:kcp:shard:" + shardName
+}
+
+// Groups are the groups of the client certificates of registered shards. Shards need the same
+// privileges on the root shard and on their peers as the admin kubeconfigs wired by hand before.
+var Groups = []string{user.SystemPrivilegedGroup}
+
+// Signer signs the certificate signing requests of registering shards with a CA that all shards
+// trust through their --client-ca-file.
+type Signer struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// NewSigner returns a Signer for the given CA.
+func NewSigner(cert *x509.Certificate, key crypto.Signer) (*Signer, error) {
+	if !cert.IsCA {
+		return nil, fmt.Errorf("shard registration signing certificate %q is not a CA", cert.Subject.CommonName)
+	}
+	return &Signer{cert: cert, key: key}, nil
+}
+
+// LoadSigner reads the CA certificate and key to sign shard client certificates with.
+func LoadSigner(certFile, keyFile string) (*Signer, error) {
+	certs, err := certutil.CertsFromFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shard registration signing certificate: %w", err)
+	}
+	key, err := keyutil.PrivateKeyFromFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shard registration signing key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("shard registration signing key %q cannot sign", keyFile)
+	}
+	return NewSigner(certs[0], signer)
+}
+
+// Sign returns the PEM encoded client certificate of the shard with the given name for the given
+// PEM encoded certificate signing request. The subject of the request is ignored; it is always
+// UserName(shardName) with Groups.
+func (s *Signer) Sign(csrPEM []byte, shardName string, now time.Time) ([]byte, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != certutil.CertificateRequestBlockType {
+		return nil, fmt.Errorf("expected a PEM encoded %s", certutil.CertificateRequestBlockType)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate signing request: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid certificate signing request signature: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	notAfter := now.Add(CertificateValidity)
+	if notAfter.After(s.cert.NotAfter) {
+		notAfter = s.cert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   UserName(shardName),
+			Organization: Groups,
+		},
+		NotBefore:             now.Add(-5 * time.Minute), // tolerate clock skew between shards
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.cert, csr.PublicKey, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: der}), nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardregistration
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretType is the type of the Secrets holding shard registration tokens.
+	SecretType corev1.SecretType = "kcp.io/shard-registration-token"
+	// SecretNamespace is the namespace of the token Secrets in the root logical cluster.
+	SecretNamespace = metav1.NamespaceDefault
+	// SecretNamePrefix is the name prefix of the token Secrets. It is followed by the token id.
+	SecretNamePrefix = "shard-registration-token-"
+
+	// TokenIDKey is the Secret data key of the token id.
+	TokenIDKey = "token-id"
+	// TokenSecretKey is the Secret data key of the token secret.
+	TokenSecretKey = "token-secret"
+	// ExpirationKey is the Secret data key of the expiration time of the token, in RFC3339.
+	ExpirationKey = "expiration"
+	// ShardNameKey is the optional Secret data key of the only shard name the token can register.
+	ShardNameKey = "shard-name"
+)
+
+const tokenChars = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// tokenRegexp matches tokens of the form <id>.<secret>, e.g. abcdef.0123456789abcdef.
+var tokenRegexp = regexp.MustCompile(`^([a-z0-9]{6})\.([a-z0-9]{16})$`)
+
+// ParseToken splits a shard registration token into its id and secret.
+func ParseToken(token string) (id, secret string, err error) {
+	parts := tokenRegexp.FindStringSubmatch(token)
+	if parts == nil {
+		return "", "", fmt.Errorf("shard registration token must be of the form [a-z0-9]{6}.[a-z0-9]{16}")
+	}
+	return parts[1], parts[2], nil
+}
+
+// GenerateToken returns a new random shard registration token.
+func GenerateToken() (string, error) {
+	id, err := randomString(6)
+	if err != nil {
+		return "", err
+	}
+	secret, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+	return id + "." + secret, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	for i := range b {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(len(tokenChars))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = tokenChars[j.Int64()]
+	}
+	return string(b), nil
+}
+
+// SecretName returns the name of the Secret of the token with the given id.
+func SecretName(tokenID string) string {
+	return SecretNamePrefix + tokenID
+}
+
+// NewTokenSecret returns the Secret to create in the root logical cluster for the given token,
+// valid until expiration. If shardName is not empty, the token can only register that shard.
+func NewTokenSecret(token string, expiration time.Time, shardName string) (*corev1.Secret, error) {
+	id, secret, err := ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(id),
+			Namespace: SecretNamespace,
+		},
+		Type: SecretType,
+		Data: map[string][]byte{
+			TokenIDKey:     []byte(id),
+			TokenSecretKey: []byte(secret),
+			ExpirationKey:  []byte(expiration.UTC().Format(time.RFC3339)),
+		},
+	}
+	if shardName != "" {
+		s.Data[ShardNameKey] = []byte(shardName)
+	}
+	return s, nil
+}
+
+// ValidateTokenSecret checks that the given token matches the Secret, has not expired at now and
+// is allowed to register the shard with the given name. The returned errors are meant for logging
+// only; clients should not learn why a token was rejected.
+func ValidateTokenSecret(s *corev1.Secret, token, shardName string, now time.Time) error {
+	id, secret, err := ParseToken(token)
+	if err != nil {
+		return err
+	}
+	if s.Type != SecretType {
+		return fmt.Errorf("secret %s has type %q, not %q", s.Name, s.Type, SecretType)
+	}
+	if string(s.Data[TokenIDKey]) != id {
+		return fmt.Errorf("secret %s is not of token %s", s.Name, id)
+	}
+	if subtle.ConstantTimeCompare(s.Data[TokenSecretKey], []byte(secret)) != 1 {
+		return fmt.Errorf("secret of token %s does not match", id)
+	}
+
+	expiration, err := time.Parse(time.RFC3339, string(s.Data[ExpirationKey]))
+	if err != nil {
+		return fmt.Errorf("token %s has an invalid expiration: %w", id, err)
+	}
+	if !now.Before(expiration) {
+		return fmt.Errorf("token %s expired at %s", id, expiration.Format(time.RFC3339))
+	}
+
+	if allowed := string(s.Data[ShardNameKey]); allowed != "" && allowed != shardName {
+		return fmt.Errorf("token %s can only register shard %q, not %q", id, allowed, shardName)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardregistration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToken(t *testing.T) {
+	id, secret, err := ParseToken("abcdef.0123456789abcdef")
+	require.NoError(t, err)
+	require.Equal(t, "abcdef", id)
+	require.Equal(t, "0123456789abcdef", secret)
+
+	for _, token := range []string{"", "abcdef", "abcdef.0123456789abcde", "ABCDEF.0123456789abcdef", "abcdef:0123456789abcdef", "abcdef.0123456789abcdef0"} {
+		_, _, err := ParseToken(token)
+		require.Error(t, err, "token %q", token)
+	}
+
+	token, err := GenerateToken()
+	require.NoError(t, err)
+	_, _, err = ParseToken(token)
+	require.NoError(t, err)
+}
+
+func TestValidateTokenSecret(t *testing.T) {
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	token := "abcdef.0123456789abcdef"
+
+	tests := map[string]struct {
+		token      string
+		expiration time.Time
+		boundShard string
+		shardName  string
+		mutate     func(s map[string][]byte)
+		wantErr    string
+	}{
+		"valid": {
+			token:      token,
+			expiration: now.Add(time.Hour),
+			shardName:  "alpha",
+		},
+		"valid for its shard": {
+			token:      token,
+			expiration: now.Add(time.Hour),
+			boundShard: "alpha",
+			shardName:  "alpha",
+		},
+		"other shard": {
+			token:      token,
+			expiration: now.Add(time.Hour),
+			boundShard: "beta",
+			shardName:  "alpha",
+			wantErr:    `can only register shard "beta"`,
+		},
+		"expired": {
+			token:      token,
+			expiration: now,
+			shardName:  "alpha",
+			wantErr:    "expired",
+		},
+		"wrong secret": {
+			token:      "abcdef.fedcba9876543210",
+			expiration: now.Add(time.Hour),
+			shardName:  "alpha",
+			wantErr:    "does not match",
+		},
+		"wrong id": {
+			token:      "ghijkl.0123456789abcdef",
+			expiration: now.Add(time.Hour),
+			shardName:  "alpha",
+			wantErr:    "is not of token ghijkl",
+		},
+		"missing expiration": {
+			token:      token,
+			expiration: now.Add(time.Hour),
+			shardName:  "alpha",
+			mutate:     func(data map[string][]byte) { delete(data, ExpirationKey) },
+			wantErr:    "invalid expiration",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewTokenSecret(token, tt.expiration, tt.boundShard)
+			require.NoError(t, err)
+			require.Equal(t, "shard-registration-token-abcdef", s.Name)
+			if tt.mutate != nil {
+				tt.mutate(s.Data)
+			}
+
+			err = ValidateTokenSecret(s, tt.token, tt.shardName, now)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shardusage implements the heartbeat of a shard, reporting its utilization (the number of
+// logical clusters, the size of its etcd database and the rate of requests) into the status of its
+// Shard, such that the workspace scheduler can spread workspaces by utilization.
+package shardusage
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+var requests atomic.Int64
+
+// RecordRequest counts a request served by the shard.
+func RecordRequest() {
+	requests.Add(1)
+}
+
+// takeRequests returns the requests recorded since the last call.
+func takeRequests() int64 {
+	return requests.Swap(0)
+}
+
+// Reporter periodically measures the usage of the shard and reports it.
+type Reporter struct {
+	interval time.Duration
+
+	countLogicalClusters func() (int, error)
+	etcdDatabaseSize     func(ctx context.Context) (int64, error)
+	takeRequests         func() int64
+	report               func(ctx context.Context, usage *corev1alpha1.ShardUsage) error
+	now                  func() time.Time
+
+	lastHeartbeat time.Time
+}
+
+// NewReporter returns a reporter. etcdDatabaseSize may be nil if the size of the etcd database
+// cannot be determined. report is called with the usage on every heartbeat.
+func NewReporter(
+	interval time.Duration,
+	countLogicalClusters func() (int, error),
+	etcdDatabaseSize func(ctx context.Context) (int64, error),
+	report func(ctx context.Context, usage *corev1alpha1.ShardUsage) error,
+) *Reporter {
+	return &Reporter{
+		interval: interval,
+
+		countLogicalClusters: countLogicalClusters,
+		etcdDatabaseSize:     etcdDatabaseSize,
+		takeRequests:         takeRequests,
+		report:               report,
+		now:                  time.Now,
+
+		lastHeartbeat: time.Now(),
+	}
+}
+
+// Start runs the heartbeat until ctx is done.
+func (r *Reporter) Start(ctx context.Context) {
+	logger := klog.FromContext(ctx).WithValues("component", "shard-usage-reporter")
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting shard usage reporter")
+	defer logger.Info("Shutting down shard usage reporter")
+
+	wait.UntilWithContext(ctx, r.heartbeat, r.interval)
+}
+
+// heartbeat measures the usage of the shard and reports it.
+func (r *Reporter) heartbeat(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	now := r.now()
+	usage := &corev1alpha1.ShardUsage{
+		LastHeartbeatTime: metav1.NewTime(now),
+	}
+
+	count, err := r.countLogicalClusters()
+	if err != nil {
+		logger.Error(err, "failed to count logical clusters")
+		return
+	}
+	usage.LogicalClusters = int64(count)
+
+	if r.etcdDatabaseSize != nil {
+		if size, err := r.etcdDatabaseSize(ctx); err != nil {
+			logger.Error(err, "failed to get the etcd database size")
+		} else {
+			usage.EtcdDatabaseSize = resource.NewQuantity(size, resource.BinarySI)
+		}
+	}
+
+	requests := r.takeRequests()
+	if elapsed := now.Sub(r.lastHeartbeat).Seconds(); elapsed > 0 {
+		usage.RequestsPerSecond = int64(math.Round(float64(requests) / elapsed))
+	}
+	r.lastHeartbeat = now
+
+	if err := r.report(ctx, usage); err != nil {
+		logger.Error(err, "failed to report shard usage")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardusage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestHeartbeat(t *testing.T) {
+	lastHeartbeat := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := lastHeartbeat.Add(30 * time.Second)
+
+	tests := []struct {
+		name             string
+		lastHeartbeat    time.Time
+		countErr         error
+		etcdDatabaseSize func(ctx context.Context) (int64, error)
+		requests         int64
+		want             *corev1alpha1.ShardUsage
+	}{
+		{
+			name:          "usage with etcd database size",
+			lastHeartbeat: lastHeartbeat,
+			etcdDatabaseSize: func(ctx context.Context) (int64, error) {
+				return 1 << 30, nil
+			},
+			requests: 3000,
+			want: &corev1alpha1.ShardUsage{
+				LogicalClusters:   42,
+				EtcdDatabaseSize:  resource.NewQuantity(1<<30, resource.BinarySI),
+				RequestsPerSecond: 100,
+				LastHeartbeatTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:          "etcd database size unknown",
+			lastHeartbeat: lastHeartbeat,
+			requests:      15,
+			want: &corev1alpha1.ShardUsage{
+				LogicalClusters:   42,
+				RequestsPerSecond: 1,
+				LastHeartbeatTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:          "etcd database size failing",
+			lastHeartbeat: lastHeartbeat,
+			etcdDatabaseSize: func(ctx context.Context) (int64, error) {
+				return 0, errors.New("etcd unavailable")
+			},
+			want: &corev1alpha1.ShardUsage{
+				LogicalClusters:   42,
+				LastHeartbeatTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:          "no time elapsed",
+			lastHeartbeat: now,
+			requests:      10,
+			want: &corev1alpha1.ShardUsage{
+				LogicalClusters:   42,
+				LastHeartbeatTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:          "counting logical clusters failing",
+			lastHeartbeat: lastHeartbeat,
+			countErr:      errors.New("informer not synced"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *corev1alpha1.ShardUsage
+			r := &Reporter{
+				countLogicalClusters: func() (int, error) {
+					return 42, tt.countErr
+				},
+				etcdDatabaseSize: tt.etcdDatabaseSize,
+				takeRequests: func() int64 {
+					return tt.requests
+				},
+				report: func(ctx context.Context, usage *corev1alpha1.ShardUsage) error {
+					got = usage
+					return nil
+				},
+				now:           func() time.Time { return now },
+				lastHeartbeat: tt.lastHeartbeat,
+			}
+
+			r.heartbeat(context.Background())
+			require.Equal(t, tt.want, got)
+			if tt.want != nil {
+				require.Equal(t, now, r.lastHeartbeat)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttling
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+)
+
+// Exemptions decides which logical clusters are exempt from the per-workspace limits, according
+// to the signed ThrottlingExemptions in the root workspace. ThrottlingExemptions are read from
+// the local shard and from the cache server, such that all shards decide the same.
+type Exemptions struct {
+	key []byte
+
+	listLocalExemptions  func() ([]*tenancyv1alpha1.ThrottlingExemption, error)
+	listCachedExemptions func() ([]*tenancyv1alpha1.ThrottlingExemption, error)
+	getLogicalCluster    func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+}
+
+// NewExemptions returns Exemptions signing and verifying ThrottlingExemptions with the given key.
+// Without key, no logical cluster is exempt and ThrottlingExemptions cannot be signed.
+func NewExemptions(
+	key []byte,
+	localExemptionLister tenancyv1alpha1listers.ThrottlingExemptionClusterLister,
+	cachedExemptionLister tenancyv1alpha1listers.ThrottlingExemptionClusterLister,
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister,
+) *Exemptions {
+	return &Exemptions{
+		key: key,
+		listLocalExemptions: func() ([]*tenancyv1alpha1.ThrottlingExemption, error) {
+			return localExemptionLister.Cluster(core.RootCluster).List(labels.Everything())
+		},
+		listCachedExemptions: func() ([]*tenancyv1alpha1.ThrottlingExemption, error) {
+			return cachedExemptionLister.Cluster(core.RootCluster).List(labels.Everything())
+		},
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterLister.Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+	}
+}
+
+// LoadSigningKey reads the key to sign ThrottlingExemptions with from the given file.
+func LoadSigningKey(file string) ([]byte, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read throttling exemption signing key: %w", err)
+	}
+	key := bytes.TrimSpace(bs)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("throttling exemption signing key file %q is empty", file)
+	}
+	return key, nil
+}
+
+// Exempt returns whether the given logical cluster is exempt, i.e. whether its WorkspaceType is
+// referenced by a ThrottlingExemption with a valid signature.
+func (e *Exemptions) Exempt(clusterName logicalcluster.Name) bool {
+	if len(e.key) == 0 {
+		return false
+	}
+
+	logicalCluster, err := e.getLogicalCluster(clusterName)
+	if err != nil {
+		return false
+	}
+	workspaceType, found := logicalCluster.Annotations[tenancyv1beta1.LogicalClusterTypeAnnotationKey]
+	if !found {
+		return false
+	}
+
+	for _, list := range []func() ([]*tenancyv1alpha1.ThrottlingExemption, error){e.listLocalExemptions, e.listCachedExemptions} {
+		exemptions, err := list()
+		if err != nil {
+			continue
+		}
+		for _, exemption := range exemptions {
+			if !e.verify(exemption) {
+				continue
+			}
+			for _, ref := range exemption.Spec.WorkspaceTypes {
+				path := logicalcluster.NewPath(ref.Path)
+				if path.Empty() {
+					path = core.RootCluster.Path()
+				}
+				if path.Join(string(ref.Name)).String() == workspaceType {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Sign returns the signature of the given ThrottlingExemption, which covers its name and spec.
+func (e *Exemptions) Sign(exemption *tenancyv1alpha1.ThrottlingExemption) (string, error) {
+	if len(e.key) == 0 {
+		return "", fmt.Errorf("no throttling exemption signing key configured")
+	}
+	spec, err := json.Marshal(exemption.Spec)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, e.key)
+	mac.Write([]byte(exemption.Name))
+	mac.Write([]byte{0})
+	mac.Write(spec)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (e *Exemptions) verify(exemption *tenancyv1alpha1.ThrottlingExemption) bool {
+	signature, found := exemption.Annotations[tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey]
+	if !found {
+		return false
+	}
+	expected, err := e.Sign(exemption)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+type exemptKeyType int
+
+const exemptKey exemptKeyType = iota
+
+// WithExempt returns a context marking the request as exempt from the per-workspace limits.
+func WithExempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exemptKey, true)
+}
+
+// ExemptFrom returns whether the request of the given context is exempt from the per-workspace limits.
+func ExemptFrom(ctx context.Context) bool {
+	exempt, _ := ctx.Value(exemptKey).(bool)
+	return exempt
+}
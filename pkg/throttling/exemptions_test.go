@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttling
+
+import (
+	"testing"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
+)
+
+func newExemption(cluster, name string, types ...tenancyv1alpha1.WorkspaceTypeReference) *tenancyv1alpha1.ThrottlingExemption {
+	return &tenancyv1alpha1.ThrottlingExemption{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		},
+		Spec: tenancyv1alpha1.ThrottlingExemptionSpec{WorkspaceTypes: types},
+	}
+}
+
+func signed(t *testing.T, key string, exemption *tenancyv1alpha1.ThrottlingExemption) *tenancyv1alpha1.ThrottlingExemption {
+	t.Helper()
+	signature, err := (&Exemptions{key: []byte(key)}).Sign(exemption)
+	require.NoError(t, err)
+	exemption.Annotations[tenancyv1alpha1.ThrottlingExemptionSignatureAnnotationKey] = signature
+	return exemption
+}
+
+func TestExempt(t *testing.T) {
+	automation := tenancyv1alpha1.WorkspaceTypeReference{Path: "root", Name: "automation"}
+
+	tests := map[string]struct {
+		key     string
+		local   []*tenancyv1alpha1.ThrottlingExemption
+		cached  []*tenancyv1alpha1.ThrottlingExemption
+		cluster logicalcluster.Name
+		want    bool
+	}{
+		"signed local exemption": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", automation))},
+			cluster: "automation",
+			want:    true,
+		},
+		"signed replicated exemption": {
+			key:     "secret",
+			cached:  []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", automation))},
+			cluster: "automation",
+			want:    true,
+		},
+		"empty path refers to root": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", tenancyv1alpha1.WorkspaceTypeReference{Name: "automation"}))},
+			cluster: "automation",
+			want:    true,
+		},
+		"unsigned exemption": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{newExemption("root", "system", automation)},
+			cluster: "automation",
+		},
+		"exemption signed with another key": {
+			key:     "secret",
+			cached:  []*tenancyv1alpha1.ThrottlingExemption{signed(t, "forged", newExemption("root", "system", automation))},
+			cluster: "automation",
+		},
+		"exemption changed after signing": {
+			key: "secret",
+			local: []*tenancyv1alpha1.ThrottlingExemption{func() *tenancyv1alpha1.ThrottlingExemption {
+				exemption := signed(t, "secret", newExemption("root", "system", tenancyv1alpha1.WorkspaceTypeReference{Path: "root", Name: "universal"}))
+				exemption.Spec.WorkspaceTypes = []tenancyv1alpha1.WorkspaceTypeReference{automation}
+				return exemption
+			}()},
+			cluster: "automation",
+		},
+		"exemption outside of root": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("tenant", "system", automation))},
+			cluster: "automation",
+		},
+		"other workspace type": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", automation))},
+			cluster: "tenant",
+		},
+		"no key": {
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", automation))},
+			cluster: "automation",
+		},
+		"unknown logical cluster": {
+			key:     "secret",
+			local:   []*tenancyv1alpha1.ThrottlingExemption{signed(t, "secret", newExemption("root", "system", automation))},
+			cluster: "unknown",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			newIndexer := func() cache.Indexer {
+				return cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{kcpcache.ClusterIndexName: kcpcache.ClusterIndexFunc})
+			}
+			localIndexer, cachedIndexer, logicalClusterIndexer := newIndexer(), newIndexer(), newIndexer()
+			for _, exemption := range tt.local {
+				require.NoError(t, localIndexer.Add(exemption))
+			}
+			for _, exemption := range tt.cached {
+				require.NoError(t, cachedIndexer.Add(exemption))
+			}
+			for cluster, typ := range map[string]string{"automation": "root:automation", "tenant": "root:universal"} {
+				require.NoError(t, logicalClusterIndexer.Add(&corev1alpha1.LogicalCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: corev1alpha1.LogicalClusterName,
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey:                   cluster,
+							tenancyv1beta1.LogicalClusterTypeAnnotationKey: typ,
+						},
+					},
+				}))
+			}
+
+			e := NewExemptions([]byte(tt.key),
+				tenancyv1alpha1listers.NewThrottlingExemptionClusterLister(localIndexer),
+				tenancyv1alpha1listers.NewThrottlingExemptionClusterLister(cachedIndexer),
+				corev1alpha1listers.NewLogicalClusterClusterLister(logicalClusterIndexer),
+			)
+			require.Equal(t, tt.want, e.Exempt(tt.cluster))
+		})
+	}
+}
@@ -28,11 +28,13 @@ import (
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
-	apisv1alpha1informers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/indexers"
+	kcpindexers "github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/permissionclaim"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/indexers"
 )
 
 type maximalPermissionAuthorizer struct {
@@ -44,6 +46,7 @@ type maximalPermissionAuthorizer struct {
 // NewMaximalPermissionAuthorizer creates an authorizer that checks the maximal permission policy
 // for the requested resource if the resource is a claimed resource in the requested API export.
 // The check is omitted if the requested resource itself is not associated with an API export.
+// Verbs not granted by the permission claim of the requested resource are denied.
 //
 // If the request is a cluster request the authorizer skips authorization if the request is not for a bound resource.
 // If the request is a wildcard request this check is skipped because no unique API binding can be determined.
@@ -56,7 +59,7 @@ func NewMaximalPermissionAuthorizer(deepSARClient kcpkubernetesclientset.Cluster
 			return apiExportLister.Cluster(logicalcluster.Name(clusterName)).Get(apiExportName)
 		},
 		getAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
-			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportIndexer, indexers.APIExportByIdentity, identityHash)
+			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportIndexer, kcpindexers.APIExportByIdentity, identityHash)
 		},
 		newDeepSARAuthorizer: func(clusterName logicalcluster.Name) (authorizer.Authorizer, error) {
 			return delegated.NewDelegatedAuthorizer(clusterName, deepSARClient)
@@ -82,12 +85,21 @@ func (a *maximalPermissionAuthorizer) Authorize(ctx context.Context, attr author
 		return authorizer.DecisionNoOpinion, "", err
 	}
 
-	claimedIdentityHash, found := getClaimedIdentity(claimingAPIExport, attr)
+	claim, found := getClaim(claimingAPIExport, attr)
 	if !found {
 		// it's a resource in the claiming API export, hence unclaimed
 		return authorizer.DecisionAllow, fmt.Sprintf("unclaimed resource in API export: %q, workspace :%q",
 			claimingAPIExport.Name, logicalcluster.From(claimingAPIExport)), nil
 	}
+	if !claim.AllowsVerb(attr.GetVerb()) {
+		return authorizer.DecisionDeny, fmt.Sprintf("verb %q is not claimed in API export: %q, workspace :%q",
+			attr.GetVerb(), claimingAPIExport.Name, logicalcluster.From(claimingAPIExport)), nil
+	}
+	if !permissionclaim.MaySelect(*claim, attr.GetNamespace(), attr.GetName()) {
+		return authorizer.DecisionDeny, fmt.Sprintf("object %q in namespace %q is not selected by claim in API export: %q, workspace :%q",
+			attr.GetName(), attr.GetNamespace(), claimingAPIExport.Name, logicalcluster.From(claimingAPIExport)), nil
+	}
+	claimedIdentityHash := claim.IdentityHash
 	if claimedIdentityHash == "" {
 		// it's a native k8s resource (secret, configmap, ...), or a system kcp CRD resource (apis.kcp.io)
 		// For neither case a maximum permission policy can exist.
@@ -139,14 +151,14 @@ func (a *maximalPermissionAuthorizer) Authorize(ctx context.Context, attr author
 	return authorizer.DecisionAllow, "all claimed API exports granted access", nil
 }
 
-func getClaimedIdentity(apiExport *apisv1alpha1.APIExport, attr authorizer.Attributes) (string, bool) {
+func getClaim(apiExport *apisv1alpha1.APIExport, attr authorizer.Attributes) (*apisv1alpha1.PermissionClaim, bool) {
 	for i := range apiExport.Spec.PermissionClaims {
 		if apiExport.Spec.PermissionClaims[i].Resource == attr.GetResource() &&
 			apiExport.Spec.PermissionClaims[i].Group == attr.GetAPIGroup() {
-			return apiExport.Spec.PermissionClaims[i].IdentityHash, true
+			return &apiExport.Spec.PermissionClaims[i], true
 		}
 	}
-	return "", false
+	return nil, false
 }
 
 func prefixAttributes(attr authorizer.Attributes) *authorizer.AttributesRecord {
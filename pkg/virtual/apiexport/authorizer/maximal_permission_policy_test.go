@@ -27,8 +27,8 @@ import (
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 func TestMaximalPermissionPolicyAuthorizer(t *testing.T) {
@@ -107,6 +107,135 @@ func TestMaximalPermissionPolicyAuthorizer(t *testing.T) {
 			expectedDecision: authorizer.DecisionAllow,
 			expectedReason:   `unclaimable resource, identity hash not set in claiming API export: "fooExport", workspace :"someWorkspace"`,
 		},
+		{
+			name: "claimed identity with verb not claimed",
+			attr: &authorizer.AttributesRecord{
+				User:     &user.DefaultInfo{},
+				Verb:     "update",
+				APIGroup: "",
+				Resource: "secrets",
+			},
+			apidomainKey: "foo/bar",
+			getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+				return &apisv1alpha1.APIExport{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fooExport",
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "someWorkspace",
+						},
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						PermissionClaims: []apisv1alpha1.PermissionClaim{
+							{
+								GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+								All:           true,
+								Verbs:         []string{"get", "list", "watch"},
+							},
+						},
+					},
+				}, nil
+			},
+
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `verb "update" is not claimed in API export: "fooExport", workspace :"someWorkspace"`,
+		},
+		{
+			name: "claimed identity with verb claimed",
+			attr: &authorizer.AttributesRecord{
+				User:     &user.DefaultInfo{},
+				Verb:     "list",
+				APIGroup: "",
+				Resource: "secrets",
+			},
+			apidomainKey: "foo/bar",
+			getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+				return &apisv1alpha1.APIExport{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fooExport",
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "someWorkspace",
+						},
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						PermissionClaims: []apisv1alpha1.PermissionClaim{
+							{
+								GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"},
+								All:           true,
+								Verbs:         []string{"get", "list", "watch"},
+							},
+						},
+					},
+				}, nil
+			},
+
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   `unclaimable resource, identity hash not set in claiming API export: "fooExport", workspace :"someWorkspace"`,
+		},
+		{
+			name: "claimed identity with object not selected",
+			attr: &authorizer.AttributesRecord{
+				User:      &user.DefaultInfo{},
+				Verb:      "get",
+				APIGroup:  "",
+				Resource:  "configmaps",
+				Namespace: "default",
+				Name:      "other",
+			},
+			apidomainKey: "foo/bar",
+			getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+				return &apisv1alpha1.APIExport{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fooExport",
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "someWorkspace",
+						},
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						PermissionClaims: []apisv1alpha1.PermissionClaim{
+							{
+								GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+								ResourceSelector: []apisv1alpha1.ResourceSelector{{Name: "config-fooExport"}},
+							},
+						},
+					},
+				}, nil
+			},
+
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   `object "other" in namespace "default" is not selected by claim in API export: "fooExport", workspace :"someWorkspace"`,
+		},
+		{
+			name: "claimed identity with list of objects possibly selected",
+			attr: &authorizer.AttributesRecord{
+				User:      &user.DefaultInfo{},
+				Verb:      "list",
+				APIGroup:  "",
+				Resource:  "configmaps",
+				Namespace: "default",
+			},
+			apidomainKey: "foo/bar",
+			getAPIExport: func(clusterName, apiExportName string) (*apisv1alpha1.APIExport, error) {
+				return &apisv1alpha1.APIExport{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fooExport",
+						Annotations: map[string]string{
+							logicalcluster.AnnotationKey: "someWorkspace",
+						},
+					},
+					Spec: apisv1alpha1.APIExportSpec{
+						PermissionClaims: []apisv1alpha1.PermissionClaim{
+							{
+								GroupResource:    apisv1alpha1.GroupResource{Resource: "configmaps"},
+								ResourceSelector: []apisv1alpha1.ResourceSelector{{Name: "config-fooExport"}},
+							},
+						},
+					},
+				}, nil
+			},
+
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   `unclaimable resource, identity hash not set in claiming API export: "fooExport", workspace :"someWorkspace"`,
+		},
 		{
 			name: "claimed identity without api export",
 			attr: &authorizer.AttributesRecord{
@@ -28,23 +28,27 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
 
-	"github.com/kcp-dev/kcp/pkg/apis/apis"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1/permissionclaims"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas"
 	apiexportbuiltin "github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas/builtin"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/apidefinition"
 	dynamiccontext "github.com/kcp-dev/kcp/pkg/virtual/framework/dynamic/context"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1/permissionclaims"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 func (c *APIReconciler) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIExport, apiDomainKey dynamiccontext.APIDomainKey) error {
 	logger := klog.FromContext(ctx)
 	ctx = klog.NewContext(ctx, logger)
 
-	if apiExport == nil || apiExport.Status.IdentityHash == "" {
+	// a retired APIExport has handed over to the target of its migration
+	if apiExport == nil || apiExport.Status.IdentityHash == "" || conditions.IsTrue(apiExport, apisv1alpha1.APIExportRetired) {
 		c.mutex.RLock()
 		_, found := c.apiSets[apiDomainKey]
 		c.mutex.RUnlock()
@@ -272,24 +276,97 @@ func gvrString(gvr schema.GroupVersionResource) string {
 	return fmt.Sprintf("%s.%s.%s", gvr.Resource, gvr.Version, group)
 }
 
+// getSchemasFromAPIExport returns the APIResourceSchemas of the APIExport by resource. Multiple
+// schemas of the same resource are merged into a multi-version schema. Versions that only releases
+// define are added as non-storage versions, newest release first, such that objects of
+// consumers bound to a release are served and validated with the schema they bound.
 func (c *APIReconciler) getSchemasFromAPIExport(ctx context.Context, apiExport *apisv1alpha1.APIExport) (map[schema.GroupResource]*apisv1alpha1.APIResourceSchema, error) {
 	logger := klog.FromContext(ctx)
-	apiResourceSchemas := map[schema.GroupResource]*apisv1alpha1.APIResourceSchema{}
-	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
-		apiExportClusterName := logicalcluster.From(apiExport)
+	apiExportClusterName := logicalcluster.From(apiExport)
+	getSchema := func(schemaName string) (*apisv1alpha1.APIResourceSchema, error) {
 		apiResourceSchema, err := c.apiResourceSchemaLister.Cluster(apiExportClusterName).Get(schemaName)
-		if err != nil && !apierrors.IsNotFound(err) {
-			return nil, err
-		}
 		if apierrors.IsNotFound(err) {
 			logger.WithValues(
 				"schema", schemaName,
 				"exportClusterName", apiExportClusterName,
 				"exportName", apiExport.Name,
 			).V(3).Info("APIResourceSchema for APIExport not found")
+			return nil, nil
+		}
+		return apiResourceSchema, err
+	}
+
+	schemasByResource := map[schema.GroupResource][]*apisv1alpha1.APIResourceSchema{}
+	versionsByResource := map[schema.GroupResource]sets.String{}
+	addSchema := func(apiResourceSchema *apisv1alpha1.APIResourceSchema) {
+		gr := schema.GroupResource{Group: apiResourceSchema.Spec.Group, Resource: apiResourceSchema.Spec.Names.Plural}
+		if versionsByResource[gr] == nil {
+			versionsByResource[gr] = sets.NewString()
+		}
+		for _, version := range apiResourceSchema.Spec.Versions {
+			versionsByResource[gr].Insert(version.Name)
+		}
+		schemasByResource[gr] = append(schemasByResource[gr], apiResourceSchema)
+	}
+
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		apiResourceSchema, err := getSchema(schemaName)
+		if err != nil {
+			return nil, err
+		}
+		if apiResourceSchema != nil {
+			addSchema(apiResourceSchema)
+		}
+	}
+
+	releases := make([]apisv1alpha1.APIExportRelease, len(apiExport.Spec.Releases))
+	copy(releases, apiExport.Spec.Releases)
+	sort.SliceStable(releases, func(i, j int) bool {
+		a, errA := utilversion.ParseSemantic(releases[i].Version)
+		b, errB := utilversion.ParseSemantic(releases[j].Version)
+		return errA == nil && errB == nil && b.LessThan(a)
+	})
+	for _, release := range releases {
+		for _, schemaName := range release.ResourceSchemas {
+			apiResourceSchema, err := getSchema(schemaName)
+			if err != nil {
+				return nil, err
+			}
+			if apiResourceSchema == nil {
+				continue
+			}
+			gr := schema.GroupResource{Group: apiResourceSchema.Spec.Group, Resource: apiResourceSchema.Spec.Names.Plural}
+			if versions, found := versionsByResource[gr]; found {
+				overlapping := false
+				for _, version := range apiResourceSchema.Spec.Versions {
+					overlapping = overlapping || versions.Has(version.Name)
+				}
+				if overlapping {
+					continue
+				}
+
+				// the storage version is owned by latestResourceSchemas or a newer release
+				apiResourceSchema = apiResourceSchema.DeepCopy()
+				for i := range apiResourceSchema.Spec.Versions {
+					apiResourceSchema.Spec.Versions[i].Storage = false
+				}
+			}
+			addSchema(apiResourceSchema)
+		}
+	}
+
+	apiResourceSchemas := map[schema.GroupResource]*apisv1alpha1.APIResourceSchema{}
+	for gr, schemas := range schemasByResource {
+		apiResourceSchema, err := apisv1alpha1.MergeAPIResourceSchemas(schemas, apiExport.Spec.ResourceVersions)
+		if err != nil {
+			logger.WithValues(
+				"resource", gr,
+				"exportClusterName", logicalcluster.From(apiExport),
+				"exportName", apiExport.Name,
+			).Error(err, "invalid versions in APIExport")
 			continue
 		}
-		apiResourceSchemas[schema.GroupResource{Group: apiResourceSchema.Spec.Group, Resource: apiResourceSchema.Spec.Names.Plural}] = apiResourceSchema
+		apiResourceSchemas[gr] = apiResourceSchema
 	}
 
 	return apiResourceSchemas, nil
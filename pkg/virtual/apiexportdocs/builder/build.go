@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/virtual/apiexportdocs"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/handler"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	"github.com/kcp-dev/kcp/sdk/indexers"
+)
+
+func BuildVirtualWorkspace(
+	rootPathPrefix string,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	cachedKcpInformers kcpinformers.SharedInformerFactory,
+) ([]rootapiserver.NamedVirtualWorkspace, error) {
+	if !strings.HasSuffix(rootPathPrefix, "/") {
+		rootPathPrefix += "/"
+	}
+
+	apiExportInformer := cachedKcpInformers.Apis().V1alpha1().APIExports()
+	apiResourceSchemaInformer := cachedKcpInformers.Apis().V1alpha1().APIResourceSchemas()
+
+	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.ByLogicalClusterPathAndName: indexers.IndexByLogicalClusterPathAndName,
+	})
+
+	getAPIExport := func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+		return indexers.ByPathAndName[*apisv1alpha1.APIExport](apisv1alpha1.Resource("apiexports"), apiExportInformer.Informer().GetIndexer(), path, name)
+	}
+	getAPIResourceSchema := func(cluster logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+		return apiResourceSchemaInformer.Lister().Cluster(cluster).Get(name)
+	}
+
+	readyCh := make(chan struct{})
+	vw := &handler.VirtualWorkspace{
+		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, requestContext context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+			if _, _, ok := digestUrl(urlPath, rootPathPrefix); !ok {
+				return false, "", requestContext
+			}
+			return true, strings.TrimSuffix(rootPathPrefix, "/"), requestContext
+		}),
+		Authorizer: authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			// access to the individual APIExports is checked by the handler.
+			if attr.GetUser() == nil || attr.GetUser().GetName() == user.Anonymous {
+				return authorizer.DecisionNoOpinion, "anonymous users cannot read APIExport documentation", nil
+			}
+			if attr.GetVerb() != "get" {
+				return authorizer.DecisionNoOpinion, "only get requests are supported", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		}),
+		ReadyChecker: framework.ReadyFunc(func() error {
+			select {
+			case <-readyCh:
+				return nil
+			default:
+				return fmt.Errorf("%s virtual workspace informers are not synced", apiexportdocs.VirtualWorkspaceName)
+			}
+		}),
+		HandlerFactory: handler.HandlerFactory(func(rootAPIServerConfig genericapiserver.CompletedConfig) (http.Handler, error) {
+			if err := rootAPIServerConfig.AddPostStartHook(apiexportdocs.VirtualWorkspaceName, func(hookContext genericapiserver.PostStartHookContext) error {
+				defer close(readyCh)
+
+				for name, informer := range map[string]cache.SharedIndexInformer{
+					"apiexports":         apiExportInformer.Informer(),
+					"apiresourceschemas": apiResourceSchemaInformer.Informer(),
+				} {
+					if !cache.WaitForNamedCacheSync(name, hookContext.StopCh, informer.HasSynced) {
+						klog.Background().Error(nil, "informer not synced")
+						return nil
+					}
+				}
+
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+			return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				ctx := request.Context()
+				u, ok := genericapirequest.UserFrom(ctx)
+				if !ok {
+					http.Error(writer, "could not determine user for request", http.StatusInternalServerError)
+					return
+				}
+				path, name, ok := digestUrl(request.URL.Path, "/")
+				if !ok {
+					http.NotFound(writer, request)
+					return
+				}
+
+				export, err := getAPIExport(path, name)
+				if apierrors.IsNotFound(err) {
+					http.Error(writer, fmt.Sprintf("APIExport %s|%s not found", path, name), http.StatusNotFound)
+					return
+				} else if err != nil {
+					http.Error(writer, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				// do not disclose the existence of exports the user has no access to.
+				allowed, err := mayReadDocumentation(ctx, kubeClusterClient, u, export)
+				if err != nil {
+					http.Error(writer, fmt.Sprintf("failed to authorize request: %v", err), http.StatusInternalServerError)
+					return
+				}
+				if !allowed {
+					http.Error(writer, fmt.Sprintf("APIExport %s|%s not found", path, name), http.StatusNotFound)
+					return
+				}
+
+				schemas := make([]*apisv1alpha1.APIResourceSchema, 0, len(export.Spec.LatestResourceSchemas))
+				for _, schemaName := range export.Spec.LatestResourceSchemas {
+					schema, err := getAPIResourceSchema(logicalcluster.From(export), schemaName)
+					if err != nil {
+						http.Error(writer, fmt.Sprintf("failed to get APIResourceSchema %s: %v", schemaName, err), http.StatusServiceUnavailable)
+						return
+					}
+					schemas = append(schemas, schema)
+				}
+
+				docs, err := documentationFor(export, schemas)
+				if err != nil {
+					http.Error(writer, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				writer.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(writer).Encode(docs); err != nil {
+					klog.FromContext(ctx).Error(err, "failed to write APIExport documentation")
+				}
+			}), nil
+		}),
+	}
+
+	return []rootapiserver.NamedVirtualWorkspace{
+		{Name: apiexportdocs.VirtualWorkspaceName, VirtualWorkspace: vw},
+	}, nil
+}
+
+// mayReadDocumentation returns whether the user may get or bind the given APIExport.
+func mayReadDocumentation(ctx context.Context, kubeClusterClient kcpkubernetesclientset.ClusterInterface, u user.Info, export *apisv1alpha1.APIExport) (bool, error) {
+	authz, err := delegated.NewDelegatedAuthorizer(logicalcluster.From(export), kubeClusterClient)
+	if err != nil {
+		return false, err
+	}
+	for _, verb := range []string{"get", "bind"} {
+		decision, _, err := authz.Authorize(ctx, authorizer.AttributesRecord{
+			User:            u,
+			Verb:            verb,
+			APIGroup:        apisv1alpha1.SchemeGroupVersion.Group,
+			APIVersion:      apisv1alpha1.SchemeGroupVersion.Version,
+			Resource:        "apiexports",
+			Name:            export.Name,
+			ResourceRequest: true,
+		})
+		if err != nil {
+			return false, err
+		}
+		if decision == authorizer.DecisionAllow {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// digestUrl parses <rootPathPrefix>clusters/<path>/apiexports/<name>.
+func digestUrl(urlPath, rootPathPrefix string) (logicalcluster.Path, string, bool) {
+	if !strings.HasPrefix(urlPath, rootPathPrefix) {
+		return logicalcluster.Path{}, "", false
+	}
+	withoutRootPathPrefix := strings.TrimSuffix(strings.TrimPrefix(urlPath, rootPathPrefix), "/")
+
+	parts := strings.Split(withoutRootPathPrefix, "/")
+	if len(parts) != 4 || parts[0] != "clusters" || parts[2] != "apiexports" || parts[3] == "" {
+		return logicalcluster.Path{}, "", false
+	}
+
+	path := logicalcluster.NewPath(parts[1])
+	if !path.IsValid() {
+		return logicalcluster.Path{}, "", false
+	}
+	return path, parts[3], true
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/apiexportdocs"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+)
+
+// documentationFor renders the documentation of the given APIExport from its APIResourceSchemas.
+func documentationFor(export *apisv1alpha1.APIExport, schemas []*apisv1alpha1.APIResourceSchema) (*apiexportdocs.APIExportDocumentation, error) {
+	path := export.Annotations[core.LogicalClusterPathAnnotationKey]
+	if path == "" {
+		path = logicalcluster.From(export).String()
+	}
+	docs := &apiexportdocs.APIExportDocumentation{
+		Path:      path,
+		Name:      export.Name,
+		Resources: make([]apiexportdocs.ResourceDocumentation, 0, len(schemas)),
+	}
+
+	for _, schema := range schemas {
+		resource := apiexportdocs.ResourceDocumentation{
+			Group:    schema.Spec.Group,
+			Resource: schema.Spec.Names.Plural,
+			Kind:     schema.Spec.Names.Kind,
+			Scope:    string(schema.Spec.Scope),
+			Schema:   schema.Name,
+			Versions: make([]apiexportdocs.VersionDocumentation, 0, len(schema.Spec.Versions)),
+		}
+		for i := range schema.Spec.Versions {
+			v := &schema.Spec.Versions[i]
+			props, err := v.GetSchema()
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema of version %s of APIResourceSchema %s: %w", v.Name, schema.Name, err)
+			}
+			version := apiexportdocs.VersionDocumentation{
+				Name:       v.Name,
+				Served:     v.Served,
+				Deprecated: v.Deprecated,
+			}
+			if v.DeprecationWarning != nil {
+				version.DeprecationWarning = *v.DeprecationWarning
+			}
+			if props != nil {
+				version.Description = props.Description
+				version.Fields = fieldsOf("", props, nil)
+			}
+			resource.Versions = append(resource.Versions, version)
+		}
+		docs.Resources = append(docs.Resources, resource)
+	}
+
+	sort.Slice(docs.Resources, func(i, j int) bool {
+		if docs.Resources[i].Group != docs.Resources[j].Group {
+			return docs.Resources[i].Group < docs.Resources[j].Group
+		}
+		return docs.Resources[i].Resource < docs.Resources[j].Resource
+	})
+
+	return docs, nil
+}
+
+// fieldsOf appends the documentation of the properties of props, and of their properties
+// recursively in depth-first order, to fields. Properties are visited in alphabetical order.
+func fieldsOf(prefix string, props *apiextensionsv1.JSONSchemaProps, fields []apiexportdocs.FieldDocumentation) []apiexportdocs.FieldDocumentation {
+	required := sets.NewString(props.Required...)
+	for _, name := range sets.StringKeySet(props.Properties).List() {
+		if prefix == "" && (name == "apiVersion" || name == "kind" || name == "metadata") {
+			// these are documented by Kubernetes
+			continue
+		}
+		prop := props.Properties[name]
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		fields = append(fields, fieldFor(path, &prop, required.Has(name)))
+		fields = nestedFieldsOf(path, &prop, fields)
+	}
+	return fields
+}
+
+// nestedFieldsOf appends the documentation of the fields nested in props through properties,
+// array items or map values.
+func nestedFieldsOf(path string, props *apiextensionsv1.JSONSchemaProps, fields []apiexportdocs.FieldDocumentation) []apiexportdocs.FieldDocumentation {
+	switch {
+	case len(props.Properties) > 0:
+		return fieldsOf(path, props, fields)
+	case props.Items != nil && props.Items.Schema != nil:
+		return nestedFieldsOf(path+"[]", props.Items.Schema, fields)
+	case props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil:
+		return nestedFieldsOf(path+"[*]", props.AdditionalProperties.Schema, fields)
+	}
+	return fields
+}
+
+func fieldFor(path string, props *apiextensionsv1.JSONSchemaProps, required bool) apiexportdocs.FieldDocumentation {
+	field := apiexportdocs.FieldDocumentation{
+		Path:        path,
+		Type:        typeOf(props),
+		Description: props.Description,
+		Required:    required,
+	}
+	if props.Default != nil {
+		field.Default = json.RawMessage(props.Default.Raw)
+	}
+	if props.Example != nil {
+		field.Example = json.RawMessage(props.Example.Raw)
+	}
+	for _, e := range props.Enum {
+		field.Enum = append(field.Enum, json.RawMessage(e.Raw))
+	}
+	return field
+}
+
+// typeOf returns a human readable type of props, e.g. []string or map[string]object.
+func typeOf(props *apiextensionsv1.JSONSchemaProps) string {
+	switch {
+	case props.XIntOrString:
+		return "int-or-string"
+	case props.Type == "array" && props.Items != nil && props.Items.Schema != nil:
+		return "[]" + typeOf(props.Items.Schema)
+	case props.Type == "object" && props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil:
+		return "map[string]" + typeOf(props.AdditionalProperties.Schema)
+	case props.Type == "" && props.XPreserveUnknownFields != nil && *props.XPreserveUnknownFields:
+		return "any"
+	}
+	return props.Type
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/apiexportdocs"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+)
+
+func TestDocumentationFor(t *testing.T) {
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "widgets",
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey:         "abc",
+				core.LogicalClusterPathAnnotationKey: "root:org:provider",
+			},
+		},
+	}
+
+	schema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1.widgets.example.io"},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: "example.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apisv1alpha1.APIResourceVersion{
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+	}
+	err := schema.Spec.Versions[0].SetSchema(&apiextensionsv1.JSONSchemaProps{
+		Type:        "object",
+		Description: "Widget is a widget.",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"apiVersion": {Type: "string"},
+			"kind":       {Type: "string"},
+			"metadata":   {Type: "object"},
+			"spec": {
+				Type:        "object",
+				Description: "spec is the desired state.",
+				Required:    []string{"size"},
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"size": {
+						Type:        "string",
+						Description: "size of the widget.",
+						Enum:        []apiextensionsv1.JSON{{Raw: []byte(`"small"`)}, {Raw: []byte(`"large"`)}},
+						Default:     &apiextensionsv1.JSON{Raw: []byte(`"small"`)},
+					},
+					"parts": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"name": {Type: "string", Example: &apiextensionsv1.JSON{Raw: []byte(`"bolt"`)}},
+							},
+						}},
+					},
+					"labels": {
+						Type:                 "object",
+						AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	docs, err := documentationFor(export, []*apisv1alpha1.APIResourceSchema{schema})
+	require.NoError(t, err)
+
+	require.Equal(t, &apiexportdocs.APIExportDocumentation{
+		Path: "root:org:provider",
+		Name: "widgets",
+		Resources: []apiexportdocs.ResourceDocumentation{{
+			Group:    "example.io",
+			Resource: "widgets",
+			Kind:     "Widget",
+			Scope:    "Namespaced",
+			Schema:   "v1.widgets.example.io",
+			Versions: []apiexportdocs.VersionDocumentation{{
+				Name:        "v1",
+				Served:      true,
+				Description: "Widget is a widget.",
+				Fields: []apiexportdocs.FieldDocumentation{
+					{Path: "spec", Type: "object", Description: "spec is the desired state."},
+					{Path: "spec.labels", Type: "map[string]string"},
+					{Path: "spec.parts", Type: "[]object"},
+					{Path: "spec.parts[].name", Type: "string", Example: json.RawMessage(`"bolt"`)},
+					{Path: "spec.size", Type: "string", Description: "size of the widget.", Required: true, Default: json.RawMessage(`"small"`), Enum: []json.RawMessage{json.RawMessage(`"small"`), json.RawMessage(`"large"`)}},
+				},
+			}},
+		}},
+	}, docs)
+}
+
+func TestDigestUrl(t *testing.T) {
+	tests := map[string]struct {
+		urlPath  string
+		wantPath string
+		wantName string
+		wantOK   bool
+	}{
+		"path":             {urlPath: "/services/apiexportdocs/clusters/root:org/apiexports/widgets", wantPath: "root:org", wantName: "widgets", wantOK: true},
+		"cluster name":     {urlPath: "/services/apiexportdocs/clusters/abc/apiexports/widgets/", wantPath: "abc", wantName: "widgets", wantOK: true},
+		"other resource":   {urlPath: "/services/apiexportdocs/clusters/root/apibindings/widgets"},
+		"missing name":     {urlPath: "/services/apiexportdocs/clusters/root/apiexports"},
+		"invalid path":     {urlPath: "/services/apiexportdocs/clusters/Root/apiexports/widgets"},
+		"other workspace":  {urlPath: "/services/search/clusters/root/apiexports/widgets"},
+		"trailing segment": {urlPath: "/services/apiexportdocs/clusters/root/apiexports/widgets/foo"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, name, ok := digestUrl(tt.urlPath, "/services/apiexportdocs/")
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantPath, path.String())
+			require.Equal(t, tt.wantName, name)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apiexportdocs and its sub-packages provide the APIExport Docs Virtual Workspace.
+//
+// It serves the schema documentation of the resources of an APIExport, rendered from the
+// descriptions, defaults and examples of its APIResourceSchemas, such that developer portals
+// do not have to regenerate the documentation out-of-band.
+//
+// That is, a request for
+// GET /services/apiexportdocs/clusters/<path>/apiexports/<name>
+// will return the APIExportDocumentation of the APIExport <name> in the workspace <path>.
+// <path> may be the canonical path of the workspace or the name of its logical cluster; the
+// latter is stable across renames. The requesting user must be allowed to get or to bind the
+// APIExport.
+//
+// APIExports and APIResourceSchemas are read from the cache server, such that exports on all
+// shards are found.
+package apiexportdocs
+
+const VirtualWorkspaceName string = "apiexportdocs"
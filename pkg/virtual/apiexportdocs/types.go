@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdocs
+
+import (
+	"encoding/json"
+)
+
+type APIExportDocumentation struct {
+	// path is the canonical path of the logical cluster the APIExport lives in.
+	Path string `json:"path"`
+
+	// name is the name of the APIExport.
+	Name string `json:"name"`
+
+	// resources are the documented resources of the APIExport, sorted by group and resource.
+	Resources []ResourceDocumentation `json:"resources"`
+}
+
+type ResourceDocumentation struct {
+	// group is the API group of the resource.
+	Group string `json:"group"`
+
+	// resource is the plural name of the resource.
+	Resource string `json:"resource"`
+
+	// kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// scope is either "Cluster" or "Namespaced".
+	Scope string `json:"scope"`
+
+	// schema is the name of the APIResourceSchema the documentation is rendered from.
+	Schema string `json:"schema"`
+
+	// versions are the documented versions of the resource, in the order of the APIResourceSchema.
+	Versions []VersionDocumentation `json:"versions"`
+}
+
+type VersionDocumentation struct {
+	// name is the version name, e.g. v1.
+	Name string `json:"name"`
+
+	// served is whether the version is served.
+	Served bool `json:"served"`
+
+	// deprecated is whether the version is deprecated.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// deprecationWarning is the warning returned to clients of a deprecated version.
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+
+	// description is the description of the object.
+	Description string `json:"description,omitempty"`
+
+	// fields are the fields of the object in depth-first order, e.g. spec, spec.replicas.
+	Fields []FieldDocumentation `json:"fields,omitempty"`
+}
+
+type FieldDocumentation struct {
+	// path is the dotted path of the field. Items of arrays are denoted by [], values
+	// of maps by [*], e.g. spec.containers[].ports[].name.
+	Path string `json:"path"`
+
+	// type is the OpenAPI type of the field, e.g. string or []object.
+	Type string `json:"type,omitempty"`
+
+	// description is the description of the field.
+	Description string `json:"description,omitempty"`
+
+	// required is whether the field must be set.
+	Required bool `json:"required,omitempty"`
+
+	// default is the default value of the field.
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// example is the example value of the field.
+	Example json.RawMessage `json:"example,omitempty"`
+
+	// enum are the allowed values of the field.
+	Enum []json.RawMessage `json:"enum,omitempty"`
+}
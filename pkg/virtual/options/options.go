@@ -24,11 +24,13 @@ import (
 
 	"k8s.io/client-go/rest"
 
-	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	apiexportoptions "github.com/kcp-dev/kcp/pkg/virtual/apiexport/options"
+	apiexportdocsoptions "github.com/kcp-dev/kcp/pkg/virtual/apiexportdocs/options"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	initializingworkspacesoptions "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/options"
+	searchoptions "github.com/kcp-dev/kcp/pkg/virtual/search/options"
 	synceroptions "github.com/kcp-dev/kcp/pkg/virtual/syncer/options"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 const virtualWorkspacesFlagPrefix = "virtual-workspaces-"
@@ -37,6 +39,8 @@ type Options struct {
 	Syncer                 *synceroptions.Syncer
 	APIExport              *apiexportoptions.APIExport
 	InitializingWorkspaces *initializingworkspacesoptions.InitializingWorkspaces
+	Search                 *searchoptions.Search
+	APIExportDocs          *apiexportdocsoptions.APIExportDocs
 }
 
 func NewOptions() *Options {
@@ -44,6 +48,8 @@ func NewOptions() *Options {
 		Syncer:                 synceroptions.New(),
 		APIExport:              apiexportoptions.New(),
 		InitializingWorkspaces: initializingworkspacesoptions.New(),
+		Search:                 searchoptions.New(),
+		APIExportDocs:          apiexportdocsoptions.New(),
 	}
 }
 
@@ -53,12 +59,16 @@ func (o *Options) Validate() []error {
 	errs = append(errs, o.Syncer.Validate(virtualWorkspacesFlagPrefix)...)
 	errs = append(errs, o.APIExport.Validate(virtualWorkspacesFlagPrefix)...)
 	errs = append(errs, o.InitializingWorkspaces.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.Search.Validate(virtualWorkspacesFlagPrefix)...)
+	errs = append(errs, o.APIExportDocs.Validate(virtualWorkspacesFlagPrefix)...)
 
 	return errs
 }
 
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	o.InitializingWorkspaces.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.Search.AddFlags(fs, virtualWorkspacesFlagPrefix)
+	o.APIExportDocs.AddFlags(fs, virtualWorkspacesFlagPrefix)
 }
 
 func (o *Options) NewVirtualWorkspaces(
@@ -66,6 +76,7 @@ func (o *Options) NewVirtualWorkspaces(
 	rootPathPrefix string,
 	wildcardKubeInformers kcpkubernetesinformers.SharedInformerFactory,
 	wildcardKcpInformers kcpinformers.SharedInformerFactory,
+	cachedKcpInformers kcpinformers.SharedInformerFactory,
 ) ([]rootapiserver.NamedVirtualWorkspace, error) {
 	syncer, err := o.Syncer.NewVirtualWorkspaces(rootPathPrefix, config, wildcardKcpInformers)
 	if err != nil {
@@ -82,7 +93,17 @@ func (o *Options) NewVirtualWorkspaces(
 		return nil, err
 	}
 
-	all, err := merge(syncer, apiexports, initializingworkspaces)
+	search, err := o.Search.NewVirtualWorkspaces(rootPathPrefix, config, cachedKcpInformers)
+	if err != nil {
+		return nil, err
+	}
+
+	apiexportdocs, err := o.APIExportDocs.NewVirtualWorkspaces(rootPathPrefix, config, cachedKcpInformers)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := merge(syncer, apiexports, initializingworkspaces, search, apiexportdocs)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/authorization/delegated"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/handler"
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// BuildVirtualWorkspace builds the search virtual workspace. Results are served from the informers of the
+// cache server such that workspaces and APIExports of all shards are found. Pages hold at most maxPageSize
+// results and every user can search with the given qps and burst.
+func BuildVirtualWorkspace(
+	rootPathPrefix string,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	cachedKcpInformers kcpinformers.SharedInformerFactory,
+	maxPageSize int,
+	qps float32,
+	burst int,
+) ([]rootapiserver.NamedVirtualWorkspace, error) {
+	if !strings.HasSuffix(rootPathPrefix, "/") {
+		rootPathPrefix += "/"
+	}
+
+	workspaceInformer := cachedKcpInformers.Tenancy().V1beta1().Workspaces()
+	apiExportInformer := cachedKcpInformers.Apis().V1alpha1().APIExports()
+	logicalClusterInformer := cachedKcpInformers.Core().V1alpha1().LogicalClusters()
+
+	s := &searcher{
+		listWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) {
+			return workspaceInformer.Lister().List(labels.Everything())
+		},
+		listAPIExports: func() ([]*apisv1alpha1.APIExport, error) {
+			return apiExportInformer.Lister().List(labels.Everything())
+		},
+		getLogicalCluster: func(cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(cluster).Get(corev1alpha1.LogicalClusterName)
+		},
+		newAuthorizer: func(cluster logicalcluster.Name) (authorizer.Authorizer, error) {
+			return delegated.NewDelegatedAuthorizer(cluster, kubeClusterClient)
+		},
+		decisions: utilcache.NewLRUExpireCache(10000),
+	}
+	limiter := newUserRateLimiter(qps, burst)
+
+	readyCh := make(chan struct{})
+	vw := &handler.VirtualWorkspace{
+		RootPathResolver: framework.RootPathResolverFunc(func(urlPath string, requestContext context.Context) (accepted bool, prefixToStrip string, completedContext context.Context) {
+			cluster, prefix, ok := digestUrl(urlPath, rootPathPrefix)
+			if !ok {
+				return false, "", requestContext
+			}
+
+			completedContext = genericapirequest.WithCluster(requestContext, genericapirequest.Cluster{Name: cluster})
+			return true, prefix, completedContext
+		}),
+		Authorizer: authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			// results are filtered by the permissions of the user, hence every authenticated user may search.
+			if attr.GetUser() == nil || attr.GetUser().GetName() == user.Anonymous {
+				return authorizer.DecisionNoOpinion, "anonymous users cannot search", nil
+			}
+			if attr.GetVerb() != "get" {
+				return authorizer.DecisionNoOpinion, "only get requests are supported", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		}),
+		ReadyChecker: framework.ReadyFunc(func() error {
+			select {
+			case <-readyCh:
+				return nil
+			default:
+				return fmt.Errorf("%s virtual workspace informers are not synced", search.VirtualWorkspaceName)
+			}
+		}),
+		HandlerFactory: handler.HandlerFactory(func(rootAPIServerConfig genericapiserver.CompletedConfig) (http.Handler, error) {
+			if err := rootAPIServerConfig.AddPostStartHook(search.VirtualWorkspaceName, func(hookContext genericapiserver.PostStartHookContext) error {
+				defer close(readyCh)
+
+				for name, informer := range map[string]cache.SharedIndexInformer{
+					"workspaces":      workspaceInformer.Informer(),
+					"apiexports":      apiExportInformer.Informer(),
+					"logicalclusters": logicalClusterInformer.Informer(),
+				} {
+					if !cache.WaitForNamedCacheSync(name, hookContext.StopCh, informer.HasSynced) {
+						klog.Background().Error(nil, "informer not synced")
+						return nil
+					}
+				}
+
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+			return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				ctx := request.Context()
+				u, ok := genericapirequest.UserFrom(ctx)
+				if !ok {
+					http.Error(writer, "could not determine user for request", http.StatusInternalServerError)
+					return
+				}
+				cluster, err := genericapirequest.ClusterNameFrom(ctx)
+				if err != nil {
+					http.Error(writer, fmt.Sprintf("could not determine cluster for request: %v", err), http.StatusInternalServerError)
+					return
+				}
+
+				if !limiter.tryAccept(u.GetName()) {
+					writer.Header().Set("Retry-After", "1")
+					http.Error(writer, "too many search requests, please try again later", http.StatusTooManyRequests)
+					return
+				}
+
+				var result interface{}
+				switch strings.Trim(request.URL.Path, "/") {
+				case "":
+					req, err := parseSearchRequest(request.URL.Query(), maxPageSize)
+					if err != nil {
+						http.Error(writer, err.Error(), http.StatusBadRequest)
+						return
+					}
+					if result, err = s.search(ctx, u, cluster, req); err != nil {
+						http.Error(writer, fmt.Sprintf("search failed: %v", err), http.StatusInternalServerError)
+						return
+					}
+				case treeSubPath:
+					req, err := parseTreeRequest(request.URL.Query())
+					if err != nil {
+						http.Error(writer, err.Error(), http.StatusBadRequest)
+						return
+					}
+					if result, err = s.tree(ctx, u, cluster, req); err != nil {
+						http.Error(writer, fmt.Sprintf("listing workspace tree failed: %v", err), http.StatusInternalServerError)
+						return
+					}
+				default:
+					http.NotFound(writer, request)
+					return
+				}
+
+				writer.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(writer).Encode(result); err != nil {
+					klog.FromContext(ctx).Error(err, "failed to write search results")
+				}
+			}), nil
+		}),
+	}
+
+	return []rootapiserver.NamedVirtualWorkspace{
+		{Name: search.VirtualWorkspaceName, VirtualWorkspace: vw},
+	}, nil
+}
+
+// treeSubPath is the path below a logical cluster that serves its workspace tree.
+const treeSubPath = "tree"
+
+// digestUrl returns the logical cluster of a search or workspace tree request, and the prefix to strip
+// from the URL path. Incoming requests to this virtual workspace look like:
+//
+//	/services/search/clusters/<cluster>
+//	/services/search/clusters/<cluster>/tree
+func digestUrl(urlPath, rootPathPrefix string) (logicalcluster.Name, string, bool) {
+	if !strings.HasPrefix(urlPath, rootPathPrefix) {
+		return "", "", false
+	}
+	withoutRootPathPrefix := strings.TrimPrefix(urlPath, rootPathPrefix)
+
+	if !strings.HasPrefix(withoutRootPathPrefix, "clusters/") {
+		return "", "", false
+	}
+	clusterSegment, subPath, _ := strings.Cut(strings.TrimPrefix(withoutRootPathPrefix, "clusters/"), "/")
+	if clusterSegment == "" {
+		return "", "", false
+	}
+	if subPath = strings.TrimSuffix(subPath, "/"); subPath != "" && subPath != treeSubPath {
+		return "", "", false
+	}
+
+	cluster, ok := logicalcluster.NewPath(clusterSegment).Name()
+	if !ok || !cluster.IsValid() {
+		return "", "", false
+	}
+	return cluster, rootPathPrefix + "clusters/" + clusterSegment, true
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+const (
+	defaultPageSize = 50
+
+	// decisionTTL is how long authorization decisions are reused across search requests of a user.
+	decisionTTL = 10 * time.Second
+)
+
+// searchRequest is a parsed search query.
+type searchRequest struct {
+	// query is the lower-case substring the names of results must contain. Empty matches everything.
+	query string
+	// selector must match the labels of results.
+	selector labels.Selector
+	// kinds are the kinds of objects to search for.
+	kinds sets.String
+	// limit is the maximum number of results to return.
+	limit int
+	// continueKey is the sort key of the last result of the previous page, if any.
+	continueKey string
+
+	// parent is the path results must live in directly, if set. It is used for shell completion.
+	parent logicalcluster.Path
+	// namePrefix is the prefix the names of results must start with.
+	namePrefix string
+}
+
+// parseSearchRequest parses the query parameters of a search request. A limit above maxLimit is capped.
+func parseSearchRequest(values url.Values, maxLimit int) (*searchRequest, error) {
+	req := &searchRequest{
+		query:    strings.ToLower(strings.TrimSpace(values.Get("q"))),
+		selector: labels.Everything(),
+		kinds:    sets.NewString(string(search.WorkspaceResultKind), string(search.APIExportResultKind)),
+		limit:    defaultPageSize,
+	}
+
+	if s := values.Get("labelSelector"); s != "" {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		req.selector = selector
+	}
+
+	if t := values.Get("type"); t != "" {
+		req.kinds = sets.NewString()
+		for _, typ := range strings.Split(t, ",") {
+			switch strings.TrimSpace(typ) {
+			case "workspaces":
+				req.kinds.Insert(string(search.WorkspaceResultKind))
+			case "apiexports":
+				req.kinds.Insert(string(search.APIExportResultKind))
+			default:
+				return nil, fmt.Errorf("invalid type %q, must be one of workspaces, apiexports", typ)
+			}
+		}
+	}
+
+	if p := values.Get("pathPrefix"); p != "" {
+		i := strings.LastIndex(p, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid pathPrefix %q, must be an absolute path followed by a name prefix, e.g. root:org:te", p)
+		}
+		req.parent = logicalcluster.NewPath(p[:i])
+		if !req.parent.IsValid() {
+			return nil, fmt.Errorf("invalid pathPrefix %q, %q is not a valid path", p, p[:i])
+		}
+		req.namePrefix = p[i+1:]
+	}
+
+	if l := values.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid limit %q, must be a positive integer", l)
+		}
+		req.limit = limit
+	}
+	if req.limit > maxLimit {
+		req.limit = maxLimit
+	}
+
+	if c := values.Get("continue"); c != "" {
+		key, err := base64.RawURLEncoding.DecodeString(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid continue token")
+		}
+		req.continueKey = string(key)
+	}
+
+	return req, nil
+}
+
+// searcher finds workspaces and APIExports in a workspace hierarchy. It is backed by the cache server,
+// hence it sees the objects of all shards.
+type searcher struct {
+	listWorkspaces    func() ([]*tenancyv1beta1.Workspace, error)
+	listAPIExports    func() ([]*apisv1alpha1.APIExport, error)
+	getLogicalCluster func(cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	newAuthorizer     func(cluster logicalcluster.Name) (authorizer.Authorizer, error)
+
+	// decisions caches authorization decisions per user for decisionTTL. It is optional.
+	decisions *utilcache.LRUExpireCache
+}
+
+type candidate struct {
+	key     string
+	cluster logicalcluster.Name
+	result  search.SearchResult
+}
+
+// search returns the page of objects in the hierarchy below scope matching the request that
+// the user is allowed to get.
+func (s *searcher) search(ctx context.Context, u user.Info, scope logicalcluster.Name, req *searchRequest) (*search.SearchResultList, error) {
+	pathOf := s.pathCache()
+
+	scopePath, err := pathOf(scope)
+	if err != nil {
+		return nil, err
+	}
+	parent := req.parent
+	if cluster, ok := parent.Name(); ok {
+		// the parent of a completion can be given by logical cluster name, e.g. by clients whose kubeconfig
+		// points to the stable URL of a workspace
+		if parent, err = pathOf(cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	var candidates []candidate
+	if req.kinds.Has(string(search.WorkspaceResultKind)) {
+		workspaces, err := s.listWorkspaces()
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range workspaces {
+			if !req.matches(ws.Name, ws.Labels) {
+				continue
+			}
+			cluster := logicalcluster.From(ws)
+			path, err := pathOf(cluster)
+			if err != nil {
+				return nil, err
+			}
+			if !inScope(path, scopePath) || !inParent(path, parent) {
+				continue
+			}
+			result := search.SearchResult{
+				Kind:     search.WorkspaceResultKind,
+				Resource: tenancyv1beta1.Resource("workspaces"),
+				Path:     path.String(),
+				Name:     ws.Name,
+				Labels:   ws.Labels,
+				Cluster:  ws.Spec.Cluster,
+				URL:      ws.Spec.URL,
+			}
+			if ws.Status.URLs != nil {
+				result.PathURL = ws.Status.URLs.Path
+			}
+			candidates = append(candidates, newCandidate(cluster, result))
+		}
+	}
+	if req.kinds.Has(string(search.APIExportResultKind)) {
+		exports, err := s.listAPIExports()
+		if err != nil {
+			return nil, err
+		}
+		for _, export := range exports {
+			if !req.matches(export.Name, export.Labels) {
+				continue
+			}
+			cluster := logicalcluster.From(export)
+			path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey])
+			if path.Empty() {
+				if path, err = pathOf(cluster); err != nil {
+					return nil, err
+				}
+			}
+			if !inScope(path, scopePath) || !inParent(path, parent) {
+				continue
+			}
+			candidates = append(candidates, newCandidate(cluster, search.SearchResult{
+				Kind:     search.APIExportResultKind,
+				Resource: apisv1alpha1.Resource("apiexports"),
+				Path:     path.String(),
+				Name:     export.Name,
+				Labels:   export.Labels,
+			}))
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].key < candidates[j].key
+	})
+
+	// authorization is expensive, hence only check as many candidates as needed to fill the page,
+	// and authorize them in batches per logical cluster and resource.
+	batch := &authorizationBatch{searcher: s, user: u, authorizers: map[logicalcluster.Name]authorizer.Authorizer{}, listable: map[string]bool{}}
+	list := &search.SearchResultList{Items: []search.SearchResult{}}
+	for i, c := range candidates {
+		if c.key <= req.continueKey {
+			continue
+		}
+		if len(list.Items) == req.limit {
+			list.Continue = base64.RawURLEncoding.EncodeToString([]byte(candidates[i-1].key))
+			break
+		}
+
+		allowed, err := batch.allowed(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+		list.Items = append(list.Items, c.result)
+	}
+
+	return list, nil
+}
+
+// pathCache returns a function that resolves the canonical path of logical clusters. Results are
+// cached by the function, hence it should be used for one request only.
+func (s *searcher) pathCache() func(cluster logicalcluster.Name) (logicalcluster.Path, error) {
+	paths := map[logicalcluster.Name]logicalcluster.Path{}
+	return func(cluster logicalcluster.Name) (logicalcluster.Path, error) {
+		if path, found := paths[cluster]; found {
+			return path, nil
+		}
+		path := cluster.Path()
+		lc, err := s.getLogicalCluster(cluster)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return logicalcluster.Path{}, err
+		}
+		if err == nil {
+			if p, found := lc.Annotations[core.LogicalClusterPathAnnotationKey]; found {
+				path = logicalcluster.NewPath(p)
+			}
+		}
+		paths[cluster] = path
+		return path, nil
+	}
+}
+
+func (req *searchRequest) matches(name string, objLabels map[string]string) bool {
+	return strings.HasPrefix(name, req.namePrefix) && strings.Contains(strings.ToLower(name), req.query) && req.selector.Matches(labels.Set(objLabels))
+}
+
+// inParent returns true if path is the parent of a pathPrefix query, or if there is none.
+func inParent(path, parent logicalcluster.Path) bool {
+	return parent.Empty() || path == parent
+}
+
+func newCandidate(cluster logicalcluster.Name, result search.SearchResult) candidate {
+	return candidate{
+		key:     strings.Join([]string{string(result.Kind), result.Path, result.Name}, "/"),
+		cluster: cluster,
+		result:  result,
+	}
+}
+
+// inScope returns true if path is scope or one of its descendants.
+func inScope(path, scope logicalcluster.Path) bool {
+	return path == scope || strings.HasPrefix(path.String(), scope.String()+":")
+}
+
+// authorizationBatch authorizes the candidates of one search request. A user who may list a resource in
+// a logical cluster may see all of its candidates there, hence only one SubjectAccessReview per logical
+// cluster and resource is needed in the common case. Only if listing is denied, the candidates are
+// authorized individually. Decisions are shared between requests of the same user for a short time.
+type authorizationBatch struct {
+	searcher    *searcher
+	user        user.Info
+	authorizers map[logicalcluster.Name]authorizer.Authorizer
+	listable    map[string]bool
+}
+
+func (b *authorizationBatch) allowed(ctx context.Context, c candidate) (bool, error) {
+	listKey := strings.Join([]string{c.cluster.String(), c.result.Resource.String()}, "/")
+	listable, found := b.listable[listKey]
+	if !found {
+		var err error
+		if listable, err = b.authorize(ctx, c.cluster, "list", c.result.Resource.Group, c.result.Resource.Resource, ""); err != nil {
+			return false, err
+		}
+		b.listable[listKey] = listable
+	}
+	if listable {
+		return true, nil
+	}
+	return b.authorize(ctx, c.cluster, "get", c.result.Resource.Group, c.result.Resource.Resource, c.result.Name)
+}
+
+func (b *authorizationBatch) authorize(ctx context.Context, cluster logicalcluster.Name, verb, group, resource, name string) (bool, error) {
+	cacheKey := strings.Join([]string{b.user.GetName(), b.user.GetUID(), strings.Join(b.user.GetGroups(), ","), cluster.String(), verb, group, resource, name}, "|")
+	if b.searcher.decisions != nil {
+		if allowed, found := b.searcher.decisions.Get(cacheKey); found {
+			return allowed.(bool), nil
+		}
+	}
+
+	authz, found := b.authorizers[cluster]
+	if !found {
+		var err error
+		if authz, err = b.searcher.newAuthorizer(cluster); err != nil {
+			return false, err
+		}
+		b.authorizers[cluster] = authz
+	}
+
+	attr := authorizer.AttributesRecord{
+		User:            b.user,
+		Verb:            verb,
+		APIGroup:        group,
+		APIVersion:      "*",
+		Resource:        resource,
+		Name:            name,
+		ResourceRequest: true,
+	}
+	decision, _, err := authz.Authorize(ctx, attr)
+	if err != nil {
+		klog.FromContext(ctx).V(4).Info("failed to authorize search result", "cluster", cluster, "verb", verb, "resource", resource, "name", name, "err", err)
+		return false, nil
+	}
+	allowed := decision == authorizer.DecisionAllow
+	if b.searcher.decisions != nil {
+		b.searcher.decisions.Add(cacheKey, allowed, decisionTTL)
+	}
+	return allowed, nil
+}
+
+// userRateLimiter limits the rate of searches per user.
+type userRateLimiter struct {
+	lock     sync.Mutex
+	limiters *utilcache.LRUExpireCache
+	qps      float32
+	burst    int
+}
+
+func newUserRateLimiter(qps float32, burst int) *userRateLimiter {
+	return &userRateLimiter{
+		limiters: utilcache.NewLRUExpireCache(10000),
+		qps:      qps,
+		burst:    burst,
+	}
+}
+
+// tryAccept returns true if the user has not exceeded its rate of searches.
+func (l *userRateLimiter) tryAccept(username string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, found := l.limiters.Get(username)
+	if !found {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(l.qps, l.burst)
+	}
+	// refresh expiry such that active users keep their bucket
+	l.limiters.Add(username, limiter, 10*time.Minute)
+
+	return limiter.(flowcontrol.RateLimiter).TryAccept()
+}
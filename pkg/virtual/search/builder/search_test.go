@@ -0,0 +1,339 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestParseSearchRequest(t *testing.T) {
+	tests := map[string]struct {
+		query     string
+		wantQuery string
+		wantKinds []string
+		wantLimit int
+		wantErr   bool
+	}{
+		"defaults": {
+			wantKinds: []string{"APIExport", "Workspace"},
+			wantLimit: defaultPageSize,
+		},
+		"query is lower-cased": {
+			query:     "q=%20Foo",
+			wantQuery: "foo",
+			wantKinds: []string{"APIExport", "Workspace"},
+			wantLimit: defaultPageSize,
+		},
+		"types": {
+			query:     "type=workspaces",
+			wantKinds: []string{"Workspace"},
+			wantLimit: defaultPageSize,
+		},
+		"limit is capped": {
+			query:     "limit=1000",
+			wantKinds: []string{"APIExport", "Workspace"},
+			wantLimit: 100,
+		},
+		"invalid type":          {query: "type=pods", wantErr: true},
+		"invalid limit":         {query: "limit=-1", wantErr: true},
+		"invalid labelSelector": {query: "labelSelector=a%20b", wantErr: true},
+		"invalid continue":      {query: "continue=%25%25", wantErr: true},
+		"relative pathPrefix":   {query: "pathPrefix=team", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			req, err := parseSearchRequest(values, 100)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, req.query)
+			require.Equal(t, tt.wantKinds, req.kinds.List())
+			require.Equal(t, tt.wantLimit, req.limit)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	// root
+	// └── org (cluster "orgcluster")
+	//     ├── team-a (cluster "teamacluster", has APIExport "widgets")
+	//     └── team-b (cluster "teambcluster", has APIExport "gadgets")
+	// other (cluster "othercluster", has APIExport "widgets")
+	logicalClusters := map[logicalcluster.Name]string{
+		"root":         "root",
+		"orgcluster":   "root:org",
+		"teamacluster": "root:org:team-a",
+		"teambcluster": "root:org:team-b",
+		"othercluster": "other",
+	}
+	workspaces := []*tenancyv1beta1.Workspace{
+		newWorkspace("root", "org", "orgcluster", nil),
+		newWorkspace("orgcluster", "team-a", "teamacluster", map[string]string{"team": "a"}),
+		newWorkspace("orgcluster", "team-b", "teambcluster", map[string]string{"team": "b"}),
+	}
+	exports := []*apisv1alpha1.APIExport{
+		newAPIExport("teamacluster", "root:org:team-a", "widgets"),
+		newAPIExport("teambcluster", "", "gadgets"),
+		newAPIExport("othercluster", "other", "widgets"),
+	}
+
+	s := &searcher{
+		listWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) { return workspaces, nil },
+		listAPIExports: func() ([]*apisv1alpha1.APIExport, error) { return exports, nil },
+		getLogicalCluster: func(cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			path, found := logicalClusters[cluster]
+			if !found {
+				return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+			}
+			return &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: path},
+				},
+			}, nil
+		},
+		newAuthorizer: func(cluster logicalcluster.Name) (authorizer.Authorizer, error) {
+			return authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+				// the user cannot list workspaces in org and cannot see team-b
+				if cluster == "orgcluster" && (attr.GetVerb() == "list" || attr.GetName() == "team-b") || cluster == "teambcluster" {
+					return authorizer.DecisionNoOpinion, "", nil
+				}
+				return authorizer.DecisionAllow, "", nil
+			}), nil
+		},
+	}
+
+	type result struct {
+		kind search.SearchResultKind
+		path string
+		name string
+	}
+	tests := map[string]struct {
+		scope logicalcluster.Name
+		query string
+		want  []result
+	}{
+		"everything below root": {
+			scope: "root",
+			want: []result{
+				{search.APIExportResultKind, "root:org:team-a", "widgets"},
+				{search.WorkspaceResultKind, "root", "org"},
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"everything below org": {
+			scope: "orgcluster",
+			want: []result{
+				{search.APIExportResultKind, "root:org:team-a", "widgets"},
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"name substring": {
+			scope: "root",
+			query: "q=TEAM",
+			want: []result{
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"label selector": {
+			scope: "root",
+			query: "labelSelector=team",
+			want: []result{
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"only apiexports": {
+			scope: "root",
+			query: "type=apiexports&q=widget",
+			want: []result{
+				{search.APIExportResultKind, "root:org:team-a", "widgets"},
+			},
+		},
+		"workspace path completion": {
+			scope: "root",
+			query: "type=workspaces&pathPrefix=root:org:te",
+			want: []result{
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"workspace path completion only completes the last segment": {
+			scope: "root",
+			query: "type=workspaces&pathPrefix=root:o",
+			want: []result{
+				{search.WorkspaceResultKind, "root", "org"},
+			},
+		},
+		"workspace path completion with the logical cluster name of the parent": {
+			scope: "root",
+			query: "type=workspaces&pathPrefix=orgcluster:te",
+			want: []result{
+				{search.WorkspaceResultKind, "root:org", "team-a"},
+			},
+		},
+		"apiexport completion": {
+			scope: "root",
+			query: "type=apiexports&pathPrefix=root:org:team-a:",
+			want: []result{
+				{search.APIExportResultKind, "root:org:team-a", "widgets"},
+			},
+		},
+		"unknown scope": {
+			scope: "unknown",
+			want:  []result{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			req, err := parseSearchRequest(values, 100)
+			require.NoError(t, err)
+
+			list, err := s.search(context.Background(), &user.DefaultInfo{Name: "user"}, tt.scope, req)
+			require.NoError(t, err)
+			require.Empty(t, list.Continue)
+
+			got := []result{}
+			for _, item := range list.Items {
+				got = append(got, result{item.Kind, item.Path, item.Name})
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		var got []string
+		values := url.Values{"limit": []string{"1"}}
+		for i := 0; i < 10; i++ {
+			req, err := parseSearchRequest(values, 100)
+			require.NoError(t, err)
+			list, err := s.search(context.Background(), &user.DefaultInfo{Name: "user"}, "root", req)
+			require.NoError(t, err)
+			for _, item := range list.Items {
+				got = append(got, item.Name)
+			}
+			if list.Continue == "" {
+				break
+			}
+			values.Set("continue", list.Continue)
+		}
+		require.Equal(t, []string{"widgets", "org", "team-a"}, got)
+	})
+
+	t.Run("authorization is batched and cached", func(t *testing.T) {
+		var calls int
+		s := *s
+		s.decisions = utilcache.NewLRUExpireCache(100)
+		s.newAuthorizer = func(cluster logicalcluster.Name) (authorizer.Authorizer, error) {
+			return authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+				calls++
+				return authorizer.DecisionAllow, "", nil
+			}), nil
+		}
+
+		req, err := parseSearchRequest(url.Values{}, 100)
+		require.NoError(t, err)
+		list, err := s.search(context.Background(), &user.DefaultInfo{Name: "user"}, "root", req)
+		require.NoError(t, err)
+		require.Len(t, list.Items, 5)
+		require.Equal(t, 4, calls, "expected one authorization per logical cluster and resource")
+
+		_, err = s.search(context.Background(), &user.DefaultInfo{Name: "user"}, "root", req)
+		require.NoError(t, err)
+		require.Equal(t, 4, calls, "expected cached decisions to be reused")
+	})
+}
+
+func TestDigestUrl(t *testing.T) {
+	tests := map[string]struct {
+		urlPath     string
+		wantCluster logicalcluster.Name
+		wantPrefix  string
+		wantOK      bool
+	}{
+		"cluster":             {urlPath: "/services/search/clusters/root", wantCluster: "root", wantPrefix: "/services/search/clusters/root", wantOK: true},
+		"trailing slash":      {urlPath: "/services/search/clusters/root/", wantCluster: "root", wantPrefix: "/services/search/clusters/root", wantOK: true},
+		"tree":                {urlPath: "/services/search/clusters/root/tree", wantCluster: "root", wantPrefix: "/services/search/clusters/root", wantOK: true},
+		"tree trailing slash": {urlPath: "/services/search/clusters/root/tree/", wantCluster: "root", wantPrefix: "/services/search/clusters/root", wantOK: true},
+		"other service":       {urlPath: "/services/apiexport/clusters/root"},
+		"no cluster":          {urlPath: "/services/search/clusters/"},
+		"path":                {urlPath: "/services/search/clusters/root:org"},
+		"wildcard":            {urlPath: "/services/search/clusters/*"},
+		"resource request":    {urlPath: "/services/search/clusters/root/api/v1/pods"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster, prefix, ok := digestUrl(tt.urlPath, "/services/search/")
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantCluster, cluster)
+			require.Equal(t, tt.wantPrefix, prefix)
+		})
+	}
+}
+
+func TestUserRateLimiter(t *testing.T) {
+	l := newUserRateLimiter(0.0001, 2)
+	require.True(t, l.tryAccept("alice"))
+	require.True(t, l.tryAccept("alice"))
+	require.False(t, l.tryAccept("alice"))
+	require.True(t, l.tryAccept("bob"), "users have separate limits")
+}
+
+func newWorkspace(cluster logicalcluster.Name, name, workspaceCluster string, labels map[string]string) *tenancyv1beta1.Workspace {
+	return &tenancyv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster.String()},
+		},
+		Spec: tenancyv1beta1.WorkspaceSpec{Cluster: workspaceCluster},
+	}
+}
+
+func newAPIExport(cluster logicalcluster.Name, path, name string) *apisv1alpha1.APIExport {
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster.String()},
+		},
+	}
+	if path != "" {
+		export.Annotations[core.LogicalClusterPathAnnotationKey] = path
+	}
+	return export
+}
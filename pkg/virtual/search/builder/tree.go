@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+// treeRequest is a parsed workspace tree query.
+type treeRequest struct {
+	// depth is the number of levels of workspaces to return. 0 means unlimited.
+	depth int
+	// selector must match the labels of the workspaces in the tree, or of one of their descendants.
+	selector labels.Selector
+}
+
+// parseTreeRequest parses the query parameters of a workspace tree request.
+func parseTreeRequest(values url.Values) (*treeRequest, error) {
+	req := &treeRequest{
+		selector: labels.Everything(),
+	}
+
+	if s := values.Get("labelSelector"); s != "" {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		req.selector = selector
+	}
+
+	if d := values.Get("depth"); d != "" {
+		depth, err := strconv.Atoi(d)
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("invalid depth %q, must be a non-negative integer", d)
+		}
+		req.depth = depth
+	}
+
+	return req, nil
+}
+
+// tree returns the workspaces in the hierarchy below scope that the user is allowed to get. Workspaces the
+// user is not allowed to get are omitted together with their descendants. With a label selector, only
+// matching workspaces and their ancestors are returned.
+func (s *searcher) tree(ctx context.Context, u user.Info, scope logicalcluster.Name, req *treeRequest) (*search.WorkspaceTree, error) {
+	scopePath, err := s.pathCache()(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.listWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	children := map[logicalcluster.Name][]*tenancyv1beta1.Workspace{}
+	for _, ws := range workspaces {
+		parent := logicalcluster.From(ws)
+		children[parent] = append(children[parent], ws)
+	}
+	for _, wss := range children {
+		sort.Slice(wss, func(i, j int) bool {
+			return wss[i].Name < wss[j].Name
+		})
+	}
+
+	b := &treeBuilder{
+		req:      req,
+		children: children,
+		visited:  map[logicalcluster.Name]bool{scope: true},
+		batch:    &authorizationBatch{searcher: s, user: u, authorizers: map[logicalcluster.Name]authorizer.Authorizer{}, listable: map[string]bool{}},
+	}
+	nodes, err := b.build(ctx, scope, scopePath, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &search.WorkspaceTree{
+		Path:     scopePath.String(),
+		Cluster:  scope.String(),
+		Children: nodes,
+	}, nil
+}
+
+type treeBuilder struct {
+	req      *treeRequest
+	children map[logicalcluster.Name][]*tenancyv1beta1.Workspace
+	// visited protects against cycles in case of inconsistent data in the cache.
+	visited map[logicalcluster.Name]bool
+	batch   *authorizationBatch
+}
+
+// build returns the nodes of the workspaces in cluster, which is at the given path and depth.
+func (b *treeBuilder) build(ctx context.Context, cluster logicalcluster.Name, path logicalcluster.Path, depth int) ([]search.WorkspaceTreeNode, error) {
+	nodes := []search.WorkspaceTreeNode{}
+	for _, ws := range b.children[cluster] {
+		allowed, err := b.batch.allowed(ctx, candidate{
+			cluster: cluster,
+			result:  search.SearchResult{Resource: tenancyv1beta1.Resource("workspaces"), Name: ws.Name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+
+		node := search.WorkspaceTreeNode{
+			Name:    ws.Name,
+			Path:    path.Join(ws.Name).String(),
+			Labels:  ws.Labels,
+			Type:    string(ws.Spec.Type.Name),
+			Phase:   string(ws.Status.Phase),
+			Cluster: ws.Spec.Cluster,
+			URL:     ws.Spec.URL,
+		}
+		if child := logicalcluster.Name(ws.Spec.Cluster); child != "" && !b.visited[child] && len(b.children[child]) > 0 {
+			if b.req.depth > 0 && depth >= b.req.depth {
+				node.Truncated = true
+			} else {
+				b.visited[child] = true
+				if node.Children, err = b.build(ctx, child, path.Join(ws.Name), depth+1); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if len(node.Children) == 0 && !b.req.selector.Matches(labels.Set(ws.Labels)) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+)
+
+func TestParseTreeRequest(t *testing.T) {
+	tests := map[string]struct {
+		query     string
+		wantDepth int
+		wantErr   bool
+	}{
+		"defaults":              {},
+		"depth":                 {query: "depth=2", wantDepth: 2},
+		"negative depth":        {query: "depth=-1", wantErr: true},
+		"invalid depth":         {query: "depth=foo", wantErr: true},
+		"invalid labelSelector": {query: "labelSelector=a%20b", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			req, err := parseTreeRequest(values)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDepth, req.depth)
+		})
+	}
+}
+
+func TestTree(t *testing.T) {
+	// root
+	// ├── org (cluster "orgcluster")
+	// │   ├── team-a (cluster "teamacluster")
+	// │   │   └── sub (cluster "subcluster")
+	// │   └── team-b (cluster "teambcluster", not visible to the user)
+	// │       └── hidden (cluster "hiddencluster")
+	// └── other (cluster "othercluster")
+	logicalClusters := map[logicalcluster.Name]string{
+		"root":       "root",
+		"orgcluster": "root:org",
+	}
+	workspaces := []*tenancyv1beta1.Workspace{
+		newWorkspace("root", "other", "othercluster", nil),
+		newWorkspace("root", "org", "orgcluster", nil),
+		newWorkspace("orgcluster", "team-b", "teambcluster", map[string]string{"team": "b"}),
+		newWorkspace("orgcluster", "team-a", "teamacluster", map[string]string{"team": "a"}),
+		newWorkspace("teamacluster", "sub", "subcluster", nil),
+		newWorkspace("teambcluster", "hidden", "hiddencluster", map[string]string{"team": "a"}),
+	}
+
+	s := &searcher{
+		listWorkspaces: func() ([]*tenancyv1beta1.Workspace, error) { return workspaces, nil },
+		getLogicalCluster: func(cluster logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			path, found := logicalClusters[cluster]
+			if !found {
+				return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), corev1alpha1.LogicalClusterName)
+			}
+			return &corev1alpha1.LogicalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        corev1alpha1.LogicalClusterName,
+					Annotations: map[string]string{core.LogicalClusterPathAnnotationKey: path},
+				},
+			}, nil
+		},
+		newAuthorizer: func(cluster logicalcluster.Name) (authorizer.Authorizer, error) {
+			return authorizer.AuthorizerFunc(func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+				// the user cannot list workspaces in org and cannot see team-b
+				if cluster == "orgcluster" && (attr.GetVerb() == "list" || attr.GetName() == "team-b") {
+					return authorizer.DecisionNoOpinion, "", nil
+				}
+				return authorizer.DecisionAllow, "", nil
+			}), nil
+		},
+	}
+
+	tests := map[string]struct {
+		scope    logicalcluster.Name
+		query    string
+		wantPath string
+		want     []string
+	}{
+		"everything below root": {
+			scope:    "root",
+			wantPath: "root",
+			want:     []string{"root:org", "root:org:team-a", "root:org:team-a:sub", "root:other"},
+		},
+		"everything below org": {
+			scope:    "orgcluster",
+			wantPath: "root:org",
+			want:     []string{"root:org:team-a", "root:org:team-a:sub"},
+		},
+		"depth": {
+			scope:    "root",
+			query:    "depth=2",
+			wantPath: "root",
+			want:     []string{"root:org", "root:org:team-a (truncated)", "root:other"},
+		},
+		"label selector keeps ancestors": {
+			scope:    "root",
+			query:    "labelSelector=team=a",
+			wantPath: "root",
+			want:     []string{"root:org", "root:org:team-a"},
+		},
+		"label selector without matches": {
+			scope:    "root",
+			query:    "labelSelector=team=c",
+			wantPath: "root",
+			want:     []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			req, err := parseTreeRequest(values)
+			require.NoError(t, err)
+
+			tree, err := s.tree(context.Background(), &user.DefaultInfo{Name: "user"}, tt.scope, req)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPath, tree.Path)
+			require.Equal(t, tt.scope.String(), tree.Cluster)
+			require.Equal(t, tt.want, flattenTree(tree.Children))
+		})
+	}
+}
+
+// flattenTree returns the paths of the nodes in depth-first order.
+func flattenTree(nodes []search.WorkspaceTreeNode) []string {
+	paths := []string{}
+	for _, node := range nodes {
+		path := node.Path
+		if node.Truncated {
+			path += " (truncated)"
+		}
+		paths = append(paths, path)
+		paths = append(paths, flattenTree(node.Children)...)
+	}
+	return paths
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package search and its sub-packages provide the Search Virtual Workspace.
+//
+// It allows UIs to find workspaces and APIExports by name substring and labels across the
+// workspace hierarchy below a given logical cluster, without crawling it client-side.
+//
+// That is, a request for
+// GET /services/search/clusters/<cluster>?q=<substring>&labelSelector=<selector>&type=workspaces&limit=<n>&continue=<token>
+// will return a SearchResultList with the matching objects in <cluster> and all of its descendants,
+// sorted by kind, path and name. Only objects the requesting user is allowed to get are returned.
+// If more results are available, the list carries a continue token for the next page.
+//
+// For shell completion, pathPrefix=<path>:<name prefix> restricts the results to the objects directly
+// in <path> whose names start with <name prefix>, e.g. pathPrefix=root:org:te finds root:org:team-a.
+// <path> may also be the name of a logical cluster. As paths are unique across shards, completion
+// requests are usually scoped to the root cluster.
+//
+// The workspace tree below <cluster> is returned in one call by
+// GET /services/search/clusters/<cluster>/tree?depth=<n>&labelSelector=<selector>
+// as a WorkspaceTree, instead of clients listing the workspaces of every level. depth limits the number
+// of levels returned, nodes with omitted children are marked as truncated. With a label selector, only
+// the matching workspaces and their ancestors are returned. Workspaces the requesting user is not allowed
+// to get are omitted together with their descendants.
+//
+// Workspaces, LogicalClusters and APIExports are read from the cache server, such that objects on all
+// shards are found.
+package search
+
+const VirtualWorkspaceName string = "search"
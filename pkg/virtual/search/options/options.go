@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"path"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/spf13/pflag"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
+	"github.com/kcp-dev/kcp/pkg/virtual/search"
+	"github.com/kcp-dev/kcp/pkg/virtual/search/builder"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+type Search struct {
+	// MaxPageSize is the maximum number of results returned per request.
+	MaxPageSize int
+	// QPS is the number of search requests per second allowed per user.
+	QPS float32
+	// Burst is the number of search requests a user can issue at once.
+	Burst int
+}
+
+func New() *Search {
+	return &Search{
+		MaxPageSize: 500,
+		QPS:         5,
+		Burst:       10,
+	}
+}
+
+func (o *Search) AddFlags(flags *pflag.FlagSet, prefix string) {
+	if o == nil {
+		return
+	}
+
+	flags.IntVar(&o.MaxPageSize, prefix+"search-max-page-size", o.MaxPageSize, "The maximum number of results returned per request by the search virtual workspace.")
+	flags.Float32Var(&o.QPS, prefix+"search-qps", o.QPS, "The number of requests per second every user can issue against the search virtual workspace.")
+	flags.IntVar(&o.Burst, prefix+"search-burst", o.Burst, "The number of requests every user can issue at once against the search virtual workspace.")
+}
+
+func (o *Search) Validate(flagPrefix string) []error {
+	if o == nil {
+		return nil
+	}
+	errs := []error{}
+
+	if o.MaxPageSize <= 0 {
+		errs = append(errs, fmt.Errorf("--%ssearch-max-page-size must be positive", flagPrefix))
+	}
+	if o.QPS <= 0 {
+		errs = append(errs, fmt.Errorf("--%ssearch-qps must be positive", flagPrefix))
+	}
+	if o.Burst <= 0 {
+		errs = append(errs, fmt.Errorf("--%ssearch-burst must be positive", flagPrefix))
+	}
+
+	return errs
+}
+
+func (o *Search) NewVirtualWorkspaces(
+	rootPathPrefix string,
+	config *rest.Config,
+	cachedKcpInformers kcpinformers.SharedInformerFactory,
+) (workspaces []rootapiserver.NamedVirtualWorkspace, err error) {
+	config = rest.AddUserAgent(rest.CopyConfig(config), "search-virtual-workspace")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildVirtualWorkspace(path.Join(rootPathPrefix, search.VirtualWorkspaceName), kubeClusterClient, cachedKcpInformers, o.MaxPageSize, o.QPS, o.Burst)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SearchResultKind is the kind of object found by a search.
+type SearchResultKind string
+
+const (
+	// WorkspaceResultKind is the kind of workspace results.
+	WorkspaceResultKind SearchResultKind = "Workspace"
+	// APIExportResultKind is the kind of APIExport results.
+	APIExportResultKind SearchResultKind = "APIExport"
+)
+
+// SearchResult is a single object found by a search.
+type SearchResult struct {
+	// kind is the kind of the found object.
+	Kind SearchResultKind `json:"kind"`
+
+	// resource is the group and resource of the found object.
+	Resource schema.GroupResource `json:"resource"`
+
+	// path is the canonical path of the logical cluster the object lives in.
+	Path string `json:"path"`
+
+	// name is the name of the found object.
+	Name string `json:"name"`
+
+	// labels are the labels of the found object.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// cluster is the name of the logical cluster a workspace result is backed by.
+	Cluster string `json:"cluster,omitempty"`
+
+	// url is the URL a workspace result is served at, based on the name of its logical
+	// cluster. It stays valid for the lifetime of the workspace.
+	URL string `json:"url,omitempty"`
+
+	// pathURL is the URL a workspace result is served at, based on its path. Unlike url,
+	// it changes when the workspace or one of its ancestors is renamed or moved.
+	PathURL string `json:"pathURL,omitempty"`
+}
+
+// SearchResultList is one page of results of a search.
+type SearchResultList struct {
+	// items are the results, sorted by kind, path and name.
+	Items []SearchResult `json:"items"`
+
+	// continue is set if more results are available. Pass it as the continue query parameter
+	// of the next request to retrieve the next page.
+	Continue string `json:"continue,omitempty"`
+}
+
+// WorkspaceTree is the hierarchy of workspaces below a logical cluster.
+type WorkspaceTree struct {
+	// path is the canonical path of the logical cluster the tree is rooted at.
+	Path string `json:"path"`
+
+	// cluster is the name of the logical cluster the tree is rooted at.
+	Cluster string `json:"cluster"`
+
+	// children are the workspaces directly in the logical cluster, sorted by name.
+	Children []WorkspaceTreeNode `json:"children"`
+}
+
+// WorkspaceTreeNode is a workspace in a WorkspaceTree.
+type WorkspaceTreeNode struct {
+	// name is the name of the workspace.
+	Name string `json:"name"`
+
+	// path is the canonical path of the workspace, i.e. the path of its parent joined with its name.
+	Path string `json:"path"`
+
+	// labels are the labels of the workspace.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// type is the name of the WorkspaceType of the workspace.
+	Type string `json:"type,omitempty"`
+
+	// phase is the phase of the workspace.
+	Phase string `json:"phase,omitempty"`
+
+	// cluster is the name of the logical cluster the workspace is backed by.
+	Cluster string `json:"cluster,omitempty"`
+
+	// url is the URL the workspace is served at, based on the name of its logical cluster.
+	URL string `json:"url,omitempty"`
+
+	// children are the workspaces directly in this workspace, sorted by name.
+	Children []WorkspaceTreeNode `json:"children,omitempty"`
+
+	// truncated is true if the workspace has children that are omitted because the requested
+	// depth is reached.
+	Truncated bool `json:"truncated,omitempty"`
+}
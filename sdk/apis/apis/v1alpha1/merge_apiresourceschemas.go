@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MergeAPIResourceSchemas merges the APIResourceSchemas of one resource, as referenced by an
+// APIExport, into a multi-version APIResourceSchema. The schemas must agree on names and scope,
+// and must define disjoint versions. The served and storage flags of the versions are taken
+// from the schemas, unless overridden by the given resource versions of the same resource.
+//
+// A single schema that is not changed by overrides is returned as is. Otherwise, the result
+// is a copy of the first schema with the versions of all of them, and with a UID derived from
+// the UIDs of the schemas and the version flags. Equal inputs hence result in equal UIDs.
+func MergeAPIResourceSchemas(schemas []*APIResourceSchema, overrides []ExportedResourceVersion) (*APIResourceSchema, error) {
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("no APIResourceSchemas to merge")
+	}
+
+	first := schemas[0]
+	gr := GroupResource{Group: first.Spec.Group, Resource: first.Spec.Names.Plural}
+	resource := schema.GroupResource{Group: gr.Group, Resource: gr.Resource}
+
+	definedBy := map[string]string{}
+	var versions []APIResourceVersion
+	for _, s := range schemas {
+		if s.Spec.Group != gr.Group || s.Spec.Names.Plural != gr.Resource {
+			return nil, fmt.Errorf("APIResourceSchema %s is not for resource %s", s.Name, resource)
+		}
+		if !reflect.DeepEqual(s.Spec.Names, first.Spec.Names) || s.Spec.Scope != first.Spec.Scope {
+			return nil, fmt.Errorf("APIResourceSchemas %s and %s for resource %s differ in names or scope", first.Name, s.Name, resource)
+		}
+		for _, version := range s.Spec.Versions {
+			if other, found := definedBy[version.Name]; found {
+				return nil, fmt.Errorf("version %q of resource %s is defined by both APIResourceSchemas %s and %s", version.Name, resource, other, s.Name)
+			}
+			definedBy[version.Name] = s.Name
+			versions = append(versions, *version.DeepCopy())
+		}
+	}
+
+	storageOverridden := false
+	byVersion := map[string]ExportedResourceVersion{}
+	for _, override := range overrides {
+		if override.GroupResource != gr {
+			continue
+		}
+		if _, found := definedBy[override.Version]; !found {
+			return nil, fmt.Errorf("version %q of resource %s is not defined by any APIResourceSchema", override.Version, resource)
+		}
+		if override.Storage && storageOverridden {
+			return nil, fmt.Errorf("more than one version of resource %s is marked as storage", resource)
+		}
+		storageOverridden = storageOverridden || override.Storage
+		byVersion[override.Version] = override
+	}
+
+	changed := len(schemas) > 1
+	storageVersions := 0
+	for i := range versions {
+		served, storage := versions[i].Served, versions[i].Storage
+		if override, found := byVersion[versions[i].Name]; found {
+			served, storage = override.Served, override.Storage
+		} else if storageOverridden {
+			storage = false
+		}
+		if served != versions[i].Served || storage != versions[i].Storage {
+			changed = true
+		}
+		versions[i].Served, versions[i].Storage = served, storage
+		if storage {
+			storageVersions++
+		}
+	}
+	if storageVersions != 1 {
+		return nil, fmt.Errorf("resource %s must have exactly one storage version, found %d", resource, storageVersions)
+	}
+
+	if !changed {
+		return first, nil
+	}
+
+	merged := first.DeepCopy()
+	merged.Spec.Versions = versions
+	merged.UID = mergedUID(schemas, versions)
+
+	return merged, nil
+}
+
+// mergedUID derives a UUID formatted UID from the UIDs of the given schemas and
+// the flags of the merged versions.
+func mergedUID(schemas []*APIResourceSchema, versions []APIResourceVersion) types.UID {
+	h := sha256.New()
+	for _, s := range schemas {
+		h.Write([]byte(s.UID))
+		h.Write([]byte{0})
+	}
+	for _, version := range versions {
+		h.Write([]byte(version.Name + "/" + strconv.FormatBool(version.Served) + "/" + strconv.FormatBool(version.Storage)))
+		h.Write([]byte{0})
+	}
+	sum := h.Sum(nil)
+	return types.UID(fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16]))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMergeAPIResourceSchemas(t *testing.T) {
+	newSchema := func(name string, uid types.UID, versions ...APIResourceVersion) *APIResourceSchema {
+		return &APIResourceSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: uid},
+			Spec: APIResourceSchemaSpec{
+				Group:    "example.com",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets", Singular: "widget", Kind: "Widget", ListKind: "WidgetList"},
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Versions: versions,
+			},
+		}
+	}
+	version := func(name string, served, storage bool) APIResourceVersion {
+		return APIResourceVersion{Name: name, Served: served, Storage: storage}
+	}
+	override := func(version string, served, storage bool) ExportedResourceVersion {
+		return ExportedResourceVersion{
+			GroupResource: GroupResource{Group: "example.com", Resource: "widgets"},
+			Version:       version,
+			Served:        served,
+			Storage:       storage,
+		}
+	}
+	type flags struct {
+		served, storage bool
+	}
+
+	v1alpha1 := newSchema("today.widgets.example.com", "uid-1", version("v1alpha1", true, true))
+	v1 := newSchema("tomorrow.widgets.example.com", "uid-2", version("v1", true, false))
+
+	tests := map[string]struct {
+		schemas       []*APIResourceSchema
+		overrides     []ExportedResourceVersion
+		wantUnchanged bool
+		wantVersions  map[string]flags
+		wantErr       string
+	}{
+		"single schema is returned as is": {
+			schemas:       []*APIResourceSchema{v1alpha1},
+			wantUnchanged: true,
+		},
+		"override not changing anything": {
+			schemas:       []*APIResourceSchema{v1alpha1},
+			overrides:     []ExportedResourceVersion{override("v1alpha1", true, true)},
+			wantUnchanged: true,
+		},
+		"overrides of other resources are ignored": {
+			schemas: []*APIResourceSchema{v1alpha1},
+			overrides: []ExportedResourceVersion{{
+				GroupResource: GroupResource{Group: "example.com", Resource: "gadgets"},
+				Version:       "v1",
+				Served:        true,
+				Storage:       true,
+			}},
+			wantUnchanged: true,
+		},
+		"two schemas are merged": {
+			schemas:      []*APIResourceSchema{v1alpha1, v1},
+			wantVersions: map[string]flags{"v1alpha1": {true, true}, "v1": {true, false}},
+		},
+		"storage is moved by override": {
+			schemas:      []*APIResourceSchema{v1alpha1, v1},
+			overrides:    []ExportedResourceVersion{override("v1", true, true)},
+			wantVersions: map[string]flags{"v1alpha1": {true, false}, "v1": {true, true}},
+		},
+		"version is no longer served": {
+			schemas:      []*APIResourceSchema{v1alpha1, v1},
+			overrides:    []ExportedResourceVersion{override("v1alpha1", false, false), override("v1", true, true)},
+			wantVersions: map[string]flags{"v1alpha1": {false, false}, "v1": {true, true}},
+		},
+		"no storage version left": {
+			schemas:   []*APIResourceSchema{v1alpha1, v1},
+			overrides: []ExportedResourceVersion{override("v1alpha1", true, false)},
+			wantErr:   "must have exactly one storage version, found 0",
+		},
+		"two storage versions": {
+			schemas: []*APIResourceSchema{v1alpha1, newSchema("tomorrow.widgets.example.com", "uid-2", version("v1", true, true))},
+			wantErr: "must have exactly one storage version, found 2",
+		},
+		"two storage overrides": {
+			schemas:   []*APIResourceSchema{v1alpha1, v1},
+			overrides: []ExportedResourceVersion{override("v1alpha1", true, true), override("v1", true, true)},
+			wantErr:   "more than one version of resource widgets.example.com is marked as storage",
+		},
+		"override of unknown version": {
+			schemas:   []*APIResourceSchema{v1alpha1},
+			overrides: []ExportedResourceVersion{override("v2", true, true)},
+			wantErr:   `version "v2" of resource widgets.example.com is not defined by any APIResourceSchema`,
+		},
+		"version defined twice": {
+			schemas: []*APIResourceSchema{v1alpha1, newSchema("other.widgets.example.com", "uid-3", version("v1alpha1", true, false))},
+			wantErr: `version "v1alpha1" of resource widgets.example.com is defined by both APIResourceSchemas today.widgets.example.com and other.widgets.example.com`,
+		},
+		"different scope": {
+			schemas: []*APIResourceSchema{v1alpha1, func() *APIResourceSchema {
+				s := v1.DeepCopy()
+				s.Spec.Scope = apiextensionsv1.ClusterScoped
+				return s
+			}()},
+			wantErr: "differ in names or scope",
+		},
+		"different resource": {
+			schemas: []*APIResourceSchema{v1alpha1, func() *APIResourceSchema {
+				s := v1.DeepCopy()
+				s.Spec.Names.Plural = "gadgets"
+				return s
+			}()},
+			wantErr: "APIResourceSchema tomorrow.widgets.example.com is not for resource widgets.example.com",
+		},
+		"no schemas": {
+			wantErr: "no APIResourceSchemas to merge",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MergeAPIResourceSchemas(tt.schemas, tt.overrides)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantUnchanged {
+				require.Same(t, tt.schemas[0], got)
+				return
+			}
+
+			require.Equal(t, tt.schemas[0].Name, got.Name)
+			require.NotEqual(t, tt.schemas[0].UID, got.UID)
+			gotVersions := map[string]flags{}
+			for _, v := range got.Spec.Versions {
+				gotVersions[v.Name] = flags{v.Served, v.Storage}
+			}
+			require.Equal(t, tt.wantVersions, gotVersions)
+
+			// merging is deterministic, and the inputs are not mutated
+			again, err := MergeAPIResourceSchemas(tt.schemas, tt.overrides)
+			require.NoError(t, err)
+			require.Equal(t, got.UID, again.UID)
+			require.Len(t, tt.schemas[0].Spec.Versions, 1)
+		})
+	}
+
+	// different flags result in different UIDs
+	a, err := MergeAPIResourceSchemas([]*APIResourceSchema{v1alpha1, v1}, nil)
+	require.NoError(t, err)
+	b, err := MergeAPIResourceSchemas([]*APIResourceSchema{v1alpha1, v1}, []ExportedResourceVersion{override("v1", true, true)})
+	require.NoError(t, err)
+	require.NotEqual(t, a.UID, b.UID)
+}
@@ -19,7 +19,7 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 const (
@@ -67,11 +67,27 @@ func (in *APIBinding) SetConditions(conditions conditionsv1alpha1.Conditions) {
 type APIBindingSpec struct {
 	// reference uniquely identifies an API to bind to.
 	//
+	// The reference can be changed to point to a different APIExport. The resources
+	// bound so far are only switched over to the new APIExport if it serves all of them
+	// under the same identity and with all stored versions. Otherwise, the APIBinding
+	// keeps serving the previously bound resources and reports a MigrationRequired reason
+	// on the BindingUpToDate condition.
+	//
 	// +required
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="APIExport reference must not be changed"
 	Reference BindingReference `json:"reference"`
 
+	// release is the version of a release of the referenced APIExport, e.g. v1.2.0. The
+	// APIResourceSchemas of the release are bound instead of latestResourceSchemas.
+	//
+	// When the release is removed from the APIExport, the APIBinding moves on to the next
+	// newer release, or to latestResourceSchemas if there is none, and reports the
+	// APIExportReleaseRemoved reason on the BindingUpToDate condition.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^v(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`
+	Release string `json:"release,omitempty"`
+
 	// permissionClaims records decisions about permission claims requested by the API service provider.
 	// Individual claims can be accepted or rejected. If accepted, the API service provider gets the
 	// requested access to the specified resources in this workspace. Access is granted per
@@ -79,6 +95,27 @@ type APIBindingSpec struct {
 	//
 	// +optional
 	PermissionClaims []AcceptablePermissionClaim `json:"permissionClaims,omitempty"`
+
+	// excludedResources are resources of the referenced APIExport that are not bound, e.g.
+	// because they conflict with local CRDs. No CRD is created for them, and they are not
+	// considered in conflict checking. Excluding a bound resource stops serving it in this
+	// workspace, but keeps the stored objects.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	// +listMapKey=resource
+	ExcludedResources []GroupResource `json:"excludedResources,omitempty"`
+}
+
+// ExcludesResource returns true if the APIBinding excludes the given resource.
+func (s APIBindingSpec) ExcludesResource(gr GroupResource) bool {
+	for _, excluded := range s.ExcludedResources {
+		if excluded == gr {
+			return true
+		}
+	}
+	return false
 }
 
 // AcceptablePermissionClaim is a PermissionClaim that records if the user accepts or rejects it.
@@ -117,8 +154,7 @@ type ExportBindingReference struct {
 	// If the path is unset, the logical cluster of the APIBinding is used.
 	//
 	// +optional
-	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
-	Path string `json:"path,omitempty"`
+	Path LogicalClusterPath `json:"path,omitempty"`
 
 	// name is the name of the APIExport that describes the API.
 	//
@@ -171,6 +207,105 @@ type APIBindingStatus struct {
 	// the binding to grant.
 	// +optional
 	ExportPermissionClaims []PermissionClaim `json:"exportPermissionClaims,omitempty"`
+
+	// boundAPIExport records the APIExport the resources in boundResources were bound from.
+	// It is used to detect a change of spec.reference to a different APIExport.
+	//
+	// +optional
+	BoundAPIExport *BoundAPIExport `json:"boundAPIExport,omitempty"`
+
+	// resources records the binding state of each resource of the referenced APIExport, i.e.
+	// which APIResourceSchema is served, which one is pending, and why it is not served yet.
+	// Automation can wait on specific resources instead of parsing the BindingUpToDate condition.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	// +listMapKey=resource
+	Resources []APIBindingResourceStatus `json:"resources,omitempty"`
+
+	// extraCRDAnnotations are the extra annotations of the APIBinding that the APIExport selected
+	// to be set on the CRDs of the bound resources (see apis.kcp.io/extra-crd-keys). They are
+	// published here for tooling in the consumer workspace, which cannot read the bound CRDs.
+	//
+	// +optional
+	ExtraCRDAnnotations map[string]string `json:"extraCRDAnnotations,omitempty"`
+}
+
+// ResourceBindingState is the binding state of a resource of an APIBinding.
+type ResourceBindingState string
+
+const (
+	// ResourceBindingStateBound means that the latest APIResourceSchema of the resource is served.
+	ResourceBindingStateBound ResourceBindingState = "Bound"
+	// ResourceBindingStatePending means that the pending APIResourceSchema of the resource will be
+	// served without further action.
+	ResourceBindingStatePending ResourceBindingState = "Pending"
+	// ResourceBindingStateBlocked means that the pending APIResourceSchema of the resource cannot be
+	// served without action.
+	ResourceBindingStateBlocked ResourceBindingState = "Blocked"
+)
+
+// APIBindingResourceStatus is the binding state of a resource of the referenced APIExport.
+type APIBindingResourceStatus struct {
+	// group is the group of the resource. Empty string for the core API group.
+	//
+	// +required
+	Group string `json:"group"`
+
+	// resource is the resource name.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+
+	// state is the binding state of the resource:
+	// - Bound: the latest APIResourceSchema of the resource is served.
+	// - Pending: the pending APIResourceSchema will be served without further action.
+	// - Blocked: the pending APIResourceSchema cannot be served without action, see reason.
+	//
+	// +required
+	// +kubebuilder:validation:Enum=Bound;Pending;Blocked
+	State ResourceBindingState `json:"state"`
+
+	// boundSchema is the name of the APIResourceSchema currently served for the resource, if any.
+	//
+	// +optional
+	BoundSchema string `json:"boundSchema,omitempty"`
+
+	// pendingSchema is the name of the APIResourceSchema the resource is being bound or updated to.
+	// It is empty if the resource is bound with the latest APIResourceSchema.
+	//
+	// +optional
+	PendingSchema string `json:"pendingSchema,omitempty"`
+
+	// reason is a machine-readable, CamelCase reason why the pending APIResourceSchema is not
+	// served yet. The reasons of the BindingUpToDate condition are used.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// message is a human-readable message explaining the reason.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BoundAPIExport identifies the APIExport an APIBinding is bound to by logical cluster and name.
+type BoundAPIExport struct {
+	// cluster is the logical cluster name of the bound APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Cluster LogicalClusterName `json:"cluster"`
+
+	// name is the name of the bound APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // These are valid conditions of APIBinding.
@@ -183,6 +318,9 @@ const (
 	APIExportInvalidReferenceReason = "APIExportInvalidReference"
 	// APIExportNotFoundReason is a reason for the APIExportValid condition that the referenced APIExport is not found.
 	APIExportNotFoundReason = "APIExportNotFound"
+	// APIExportReleaseNotFoundReason is a reason for the APIExportValid condition that the referenced release
+	// of the APIExport is not found.
+	APIExportReleaseNotFoundReason = "APIExportReleaseNotFound"
 
 	// APIResourceSchemaInvalidReason is a reason for the InitialBindingCompleted and BindingUpToDate conditions when one of generated CRD is invalid.
 	APIResourceSchemaInvalidReason = "APIResourceSchemaInvalid"
@@ -205,6 +343,22 @@ const (
 	// has a naming conflict with other APIs.
 	NamingConflictsReason = "NamingConflicts"
 
+	// MigrationRequiredReason is a reason for the BindingUpToDate condition that spec.reference points to an APIExport
+	// that cannot take over the currently bound resources without data loss, e.g. because it serves them under a
+	// different identity or does not serve all stored versions anymore.
+	MigrationRequiredReason = "MigrationRequired"
+
+	// SchemaIncompatibleReason is a reason for the BindingUpToDate condition that the APIExport moved a bound resource
+	// to an APIResourceSchema that is incompatible with the currently bound one, e.g. because it removes fields,
+	// changes field types or stops serving versions. The update can be forced with the
+	// AnnotationForceIncompatibleSchemaUpdateKey annotation.
+	SchemaIncompatibleReason = "SchemaIncompatible"
+
+	// APIExportReleaseRemovedReason is a reason for the BindingUpToDate condition that the release referenced
+	// by spec.release was removed from the APIExport, and the next newer release or the latest schemas are
+	// bound instead.
+	APIExportReleaseRemovedReason = "APIExportReleaseRemoved"
+
 	// BindingResourceDeleteSuccess is a condition for APIBinding that indicates the resources relating this binding are deleted
 	// successfully when the APIBinding is deleting
 	BindingResourceDeleteSuccess conditionsv1alpha1.ConditionType = "BindingResourceDeleteSuccess"
@@ -219,6 +373,39 @@ const (
 	// PermissionClaimsApplied is a condition for APIBinding that indicates that all the accepted permission claims
 	// have been applied.
 	PermissionClaimsApplied conditionsv1alpha1.ConditionType = "PermissionClaimsApplied"
+
+	// APIExportNotDeprecated is a condition for APIBinding that indicates that the referenced APIExport is not
+	// deprecated. It is only added once the APIExport gets deprecated.
+	APIExportNotDeprecated conditionsv1alpha1.ConditionType = "APIExportNotDeprecated"
+
+	// APIExportDeprecatedReason is a reason for the APIExportNotDeprecated condition that the referenced APIExport
+	// is deprecated.
+	APIExportDeprecatedReason = "APIExportDeprecated"
+)
+
+// These are annotations for APIBindings
+const (
+	// AnnotationForceIncompatibleSchemaUpdateKey is the annotation key on an APIBinding that, if set to "true", makes
+	// the APIBinding switch to updated APIResourceSchemas even if they are incompatible with the bound ones.
+	AnnotationForceIncompatibleSchemaUpdateKey = "apis.kcp.io/force-incompatible-schema-update"
+
+	// AnnotationAPIExportDeprecationKey is the annotation key on an APIBinding holding the deprecation warning of
+	// the referenced APIExport. It is kept in sync with the APIExport and removed when it is not deprecated anymore.
+	// It is informational only, request warnings are taken from the APIExport itself.
+	AnnotationAPIExportDeprecationKey = "apis.kcp.io/apiexport-deprecation"
+
+	// AnnotationConsumerOwnedExtraKeysKey is the annotation key on an APIBinding holding the comma separated keys
+	// of the extra annotations that were seeded from the referenced APIExport as defaults, and which are owned by
+	// the consumer since then. It is maintained by the system.
+	AnnotationConsumerOwnedExtraKeysKey = "apis.kcp.io/consumer-owned-extra-keys"
+
+	// AnnotationMigratedFromKey is the annotation key on an APIBinding holding the cluster and name of the
+	// APIExport it was repointed from by spec.migration of that APIExport, in the format "<cluster>:<name>".
+	AnnotationMigratedFromKey = "apis.kcp.io/migrated-from"
+
+	// AnnotationTimeToReadyKey is the annotation key on an APIBinding holding the duration from its creation
+	// until the initial binding completed, e.g. "3s". It is only set if kcp runs with --annotate-time-to-ready.
+	AnnotationTimeToReadyKey = "apis.kcp.io/time-to-ready"
 )
 
 // These are annotations for bound CRDs
@@ -21,7 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	apitest "github.com/kcp-dev/kcp/pkg/apis/test"
+	apitest "github.com/kcp-dev/kcp/sdk/apis/test"
 )
 
 // TestAPIBindingPermissionClaimCELValidation will validate the permission claims for an otherwise valid APIBinding.
@@ -60,7 +60,6 @@ func TestAPIBindingPermissionClaimCELValidation(t *testing.T) {
 					"name": "CHANGE",
 				},
 			},
-			wantErrs: []string{"openAPIV3Schema.properties.spec.properties.reference: Invalid value: \"object\": APIExport reference must not be changed"},
 		},
 		{
 			name: "change path",
@@ -76,19 +75,20 @@ func TestAPIBindingPermissionClaimCELValidation(t *testing.T) {
 					"name": "bar",
 				},
 			},
-			wantErrs: []string{"openAPIV3Schema.properties.spec.properties.reference: Invalid value: \"object\": APIExport reference must not be changed"},
 		},
 	}
 
-	validators := apitest.FieldValidatorsFromFile(t, "../../../../config/crds/apis.kcp.io_apibindings.yaml")
+	// the reference can be changed to rebind, hence it is validated as part of the whole object
+	validators := apitest.VersionValidatorsFromFile(t, "../../../../config/crds/apis.kcp.io_apibindings.yaml")
 
 	for _, tc := range testCases {
-		pth := "openAPIV3Schema.properties.spec.properties.reference"
-		validator, found := validators["v1alpha1"][pth]
-		require.True(t, found, "failed to find validator for %s", pth)
+		validator, found := validators["v1alpha1"]
+		require.True(t, found, "failed to find validator for v1alpha1")
 
 		t.Run(tc.name, func(t *testing.T) {
-			errs := validator(tc.current, tc.old)
+			current := map[string]interface{}{"spec": map[string]interface{}{"reference": tc.current}}
+			old := map[string]interface{}{"spec": map[string]interface{}{"reference": tc.old}}
+			errs := validator(current, old)
 			t.Log(errs)
 
 			if got := len(errs); got != len(tc.wantErrs) {
@@ -0,0 +1,812 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// These are valid conditions of APIExport.
+const (
+	APIExportIdentityValid conditionsv1alpha1.ConditionType = "IdentityValid"
+
+	IdentityVerificationFailedReason = "IdentityVerificationFailed"
+	IdentityGenerationFailedReason   = "IdentityGenerationFailed"
+
+	APIExportVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
+
+	ErrorGeneratingURLsReason = "ErrorGeneratingURLs"
+
+	// APIExportMigrated is a condition for APIExport with spec.migration that reflects whether all
+	// APIBindings have been repointed to the target APIExport.
+	APIExportMigrated conditionsv1alpha1.ConditionType = "Migrated"
+
+	// MigrationTargetInvalidReason is a reason for the Migrated condition that the target APIExport
+	// does not exist or has a different identity.
+	MigrationTargetInvalidReason = "MigrationTargetInvalid"
+	// MigrationInProgressReason is a reason for the Migrated condition that APIBindings are still
+	// referencing this APIExport.
+	MigrationInProgressReason = "MigrationInProgress"
+
+	// APIExportRetired is a condition for APIExport with spec.migration that reflects whether the
+	// APIExport has stopped serving its virtual workspace after all APIBindings were migrated.
+	APIExportRetired conditionsv1alpha1.ConditionType = "Retired"
+
+	// DualPublishingReason is a reason for the Retired condition that the APIExport keeps serving
+	// its virtual workspace next to the target until spec.migration.retireAfter has passed.
+	DualPublishingReason = "DualPublishing"
+
+	// APIExportSchemasLinted is a condition for APIExport that reflects whether the latest
+	// APIResourceSchemas follow best practices. It is only set if the schema linter is enabled.
+	APIExportSchemasLinted conditionsv1alpha1.ConditionType = "SchemasLinted"
+
+	// SchemaLintViolationsReason is a reason for the SchemasLinted condition that at least one
+	// APIResourceSchema violates a best-practice rule.
+	SchemaLintViolationsReason = "SchemaLintViolations"
+	// SchemaLintFailedReason is a reason for the SchemasLinted condition that at least one
+	// APIResourceSchema could not be linted, e.g. because it does not exist.
+	SchemaLintFailedReason = "SchemaLintFailed"
+
+	// APIExportDefaultEndpointSliceReady is a condition for APIExport that reflects whether the default
+	// APIExportEndpointSlice exists. It is only set if the AnnotationAPIExportDefaultEndpointSliceKey
+	// annotation is "true".
+	APIExportDefaultEndpointSliceReady conditionsv1alpha1.ConditionType = "DefaultEndpointSliceReady"
+
+	// DefaultEndpointSliceNameConflictReason is a reason for the DefaultEndpointSliceReady condition that an
+	// APIExportEndpointSlice with the name of the APIExport exists, but references another APIExport.
+	DefaultEndpointSliceNameConflictReason = "NameConflict"
+	// DefaultEndpointSliceCreationFailedReason is a reason for the DefaultEndpointSliceReady condition that
+	// the default APIExportEndpointSlice could not be created.
+	DefaultEndpointSliceCreationFailedReason = "CreationFailed"
+)
+
+// These are for APIExport identity.
+const (
+	// SecretKeyAPIExportIdentity is the key in an identity secret for the identity of an APIExport.
+	SecretKeyAPIExportIdentity = "key"
+)
+
+// APIExport registers an API and implementation to allow consumption by others
+// through APIBindings.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type APIExport struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state.
+	//
+	// +optional
+	Spec APIExportSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state.
+	//
+	// +optional
+	Status APIExportStatus `json:"status,omitempty"`
+}
+
+const (
+	// AnnotationAPIExportExtraKeyPrefix is the prefix of an annotation set on an APIExport to
+	// provide extra info that will be made available to all APIBindings bound to this APIExport.
+	// Any annotation with this prefix will be continuously synced to all the APIBindings bound to
+	// this APIExport. If the annotation is removed from the APIExport, it will also be removed from
+	// all APIBindings bound to this APIExport. Further prefixes can be configured on the server with
+	// --apiexport-extra-annotation-sync-annotation-prefixes.
+	AnnotationAPIExportExtraKeyPrefix = "extra.apis.kcp.io/"
+
+	// AnnotationAPIExportExtraDefaultOnlyKeysKey is the annotation key on an APIExport holding comma separated
+	// keys of extra annotations (with the AnnotationAPIExportExtraKeyPrefix prefix) that are only defaults:
+	// they are set on APIBindings once, and afterwards owned by the consumer, i.e. changes and removals on
+	// the APIBinding are not reverted. All other extra annotations are enforced.
+	AnnotationAPIExportExtraDefaultOnlyKeysKey = "apis.kcp.io/extra-default-only-keys"
+
+	// AnnotationAPIExportExtraCRDKeysKey is the annotation key on an APIExport holding comma separated keys of
+	// extra annotations (with the AnnotationAPIExportExtraKeyPrefix prefix) that are also set on the CRDs served
+	// for the bound resources in consumer workspaces. The annotation is mirrored to the APIBindings bound to this
+	// APIExport, which publish the selected annotations in status.extraCRDAnnotations.
+	AnnotationAPIExportExtraCRDKeysKey = "apis.kcp.io/extra-crd-keys"
+
+	// AnnotationAPIExportForceIncompatibleSchemasKey is the annotation key on an APIExport that, if set to "true",
+	// allows spec.latestResourceSchemas to move a resource to an APIResourceSchema that is not backward compatible
+	// with the one it replaces. Without it, such updates are rejected.
+	AnnotationAPIExportForceIncompatibleSchemasKey = "apis.kcp.io/force-incompatible-schemas"
+
+	// AnnotationAPIExportDefaultEndpointSliceKey is the annotation key on an APIExport that, if set to "true",
+	// makes kcp maintain an APIExportEndpointSlice with the name of the APIExport next to it. The slice is owned
+	// by the APIExport: it is recreated when deleted, and deleted with the APIExport or when the annotation is
+	// removed. An existing slice of the same name referencing the APIExport is used as is.
+	AnnotationAPIExportDefaultEndpointSliceKey = "apis.kcp.io/default-endpoint-slice"
+
+	// LabelAPIExportExtraKeyPrefix is the prefix of a label set on an APIExport to be made available
+	// to all APIBindings bound to this APIExport, e.g. to select them or to apply policies to them.
+	// Like annotations with the AnnotationAPIExportExtraKeyPrefix prefix, any label with this prefix
+	// will be continuously synced to all the APIBindings bound to this APIExport, and removed from
+	// them when it is removed from the APIExport. Further prefixes can be configured on the server with
+	// --apiexport-extra-annotation-sync-label-prefixes.
+	LabelAPIExportExtraKeyPrefix = "extra-label.apis.kcp.io/"
+)
+
+func (in *APIExport) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *APIExport) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+const (
+	// MaximalPermissionPolicyRBACUserGroupPrefix is the prefix for the user and group names
+	// when verifying the APIExport.spec.maximalPermissionPolicy.
+	MaximalPermissionPolicyRBACUserGroupPrefix = "apis.kcp.io:binding:"
+)
+
+// APIExportSpec defines the desired state of APIExport.
+type APIExportSpec struct {
+	// latestResourceSchemas records the latest APIResourceSchemas that are exposed
+	// with this APIExport.
+	//
+	// The schemas can be changed in the life-cycle of the APIExport. These changes
+	// have no effect on existing APIBindings, but only on newly bound ones.
+	//
+	// For updating existing APIBindings, use an APIDeployment keeping bound
+	// workspaces up-to-date.
+	//
+	// Multiple APIResourceSchemas can be listed for the same resource, each contributing
+	// different versions of it. They must agree on names and scope. The versions are merged
+	// into one multi-version API, with the served and storage flags taken from the schemas
+	// unless overridden in resourceVersions.
+	//
+	// +optional
+	// +listType=set
+	LatestResourceSchemas []string `json:"latestResourceSchemas,omitempty"`
+
+	// resourceVersions overrides the served and storage flags of versions of the exported
+	// resources. Versions not listed here keep the flags of their APIResourceSchema. If a
+	// version of a resource is marked as storage here, all other versions of that resource
+	// are not storage.
+	//
+	// APIResourceSchemas are immutable. With resourceVersions, a new version can be added
+	// and served first, and later be promoted to storage without publishing new schemas.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	// +listMapKey=resource
+	// +listMapKey=version
+	ResourceVersions []ExportedResourceVersion `json:"resourceVersions,omitempty"`
+
+	// releases are named snapshots of APIResourceSchemas under a semantic version. APIBindings
+	// referencing a release bind its APIResourceSchemas instead of latestResourceSchemas, such
+	// that consumers get a reproducible API independent of the evolution of the APIExport.
+	//
+	// Releases are immutable, but can be removed. APIBindings of a removed release move on to
+	// the next newer release, or to latestResourceSchemas. The served and storage flags of the
+	// versions of the resources are taken from the APIResourceSchemas, resourceVersions is not applied.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=version
+	Releases []APIExportRelease `json:"releases,omitempty"`
+
+	// identity points to a secret that contains the API identity in the 'key' file.
+	// The API identity determines an unique etcd prefix for objects stored via this
+	// APIExport.
+	//
+	// Different APIExport in a workspace can share a common identity, or have different
+	// ones. The identity (the secret) can also be transferred to another workspace
+	// when the APIExport is moved.
+	//
+	// The identity is a secret of the API provider. The APIBindings referencing this APIExport
+	// will store a derived, non-sensitive value of this identity.
+	//
+	// The identity of an APIExport cannot be changed. A derived, non-sensitive value of
+	// the identity key is stored in the APIExport status and this value is immutable.
+	//
+	// The identity is defaulted. A secret with the name of the APIExport is automatically
+	// created.
+	//
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+
+	// TODO: before beta we should re-evaluate this field name
+
+	// maximalPermissionPolicy will allow for a service provider to set an upper bound on what is allowed
+	// for a consumer of this API. If the policy is not set, no upper bound is applied,
+	// i.e the consuming users can do whatever the user workspace allows the user to do.
+	//
+	// The policy consists of RBAC (Cluster)Roles and (Cluster)Bindings. A request of a user in
+	// a workspace that binds to this APIExport via an APIBinding is additionally checked against
+	// these rules, with the user name and the groups prefixed with `apis.kcp.io:binding:`.
+	//
+	// For example: assume a user `adam` with groups `system:authenticated` and `a-team` binds to
+	// this APIExport in another workspace root:org:ws. Then a request in that workspace
+	// against a resource of this APIExport is authorized as every other request in that workspace,
+	// but in addition the RBAC policy here in the APIExport workspace has to grant access to the
+	// user `apis.kcp.io:binding:adam` with the groups `apis.kcp.io:binding:system:authenticated`
+	// and `apis.kcp.io:binding:a-team`.
+	//
+	// +optional
+	MaximalPermissionPolicy *MaximalPermissionPolicy `json:"maximalPermissionPolicy,omitempty"`
+
+	// permissionClaims make resources available in APIExport's virtual workspace that are not part
+	// of the actual APIExport resources.
+	//
+	// PermissionClaims are optional and should be the least access necessary to complete the functions
+	// that the service provider needs. Access is asked for on a GroupResource + identity basis.
+	//
+	// PermissionClaims must be accepted by the user's explicit acknowledgement. Hence, when claims
+	// change, the respecting objects are not visible immediately.
+	//
+	// PermissionClaims overlapping with the APIExport resources are ignored.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	// +listMapKey=resource
+	PermissionClaims []PermissionClaim `json:"permissionClaims,omitempty"`
+
+	// deprecation marks the APIExport as deprecated. Consumers are informed through a
+	// condition and an annotation on their APIBindings, and through warnings on requests
+	// against the bound resources.
+	//
+	// +optional
+	Deprecation *APIExportDeprecation `json:"deprecation,omitempty"`
+
+	// migration moves the consumers of this APIExport to another APIExport with the same
+	// identity, e.g. when the API provider moves to a different workspace. While migrating,
+	// both APIExports serve the API, the APIBindings on all shards are repointed to the target
+	// in batches, and no new APIBindings to this APIExport are admitted. Once all APIBindings
+	// are migrated, this APIExport keeps serving for spec.migration.retireAfter and is retired
+	// afterwards.
+	//
+	// +optional
+	Migration *APIExportMigration `json:"migration,omitempty"`
+
+	// customSubresourceHandler serves the custom subresources declared in the exported
+	// APIResourceSchemas. Requests of consumers are forwarded to
+	// `<url>/clusters/<consumer logical cluster>/<request path>`, with the user in the
+	// X-Remote-User, X-Remote-Group and X-Remote-Extra-* headers.
+	//
+	// +optional
+	CustomSubresourceHandler *CustomSubresourceHandler `json:"customSubresourceHandler,omitempty"`
+
+	// categories are kubectl categories added to all resources of this APIExport when they are
+	// bound, in addition to the categories of the APIResourceSchemas. API providers sharing a
+	// category name let consumers list the resources of all their APIs with
+	// `kubectl get <category>`.
+	//
+	// The categories are applied when the APIResourceSchema of a resource is bound for the
+	// first time. Later changes do not affect resources already bound.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// CustomSubresourceHandler describes the endpoint of the API provider serving custom subresources.
+type CustomSubresourceHandler struct {
+	// url is the https URL of the handler, without query and fragment.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://[^?#]+$`
+	URL string `json:"url"`
+
+	// caBundle is a PEM encoded CA bundle used to verify the serving certificate of the handler.
+	// If unset, the system trust roots are used.
+	//
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// APIExportRelease is a named snapshot of the APIResourceSchemas of an APIExport.
+type APIExportRelease struct {
+	// version is the semantic version of the release, e.g. v1.2.0.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^v(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`
+	Version string `json:"version"`
+
+	// resourceSchemas are the APIResourceSchemas of the release, in the same format as
+	// latestResourceSchemas.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	ResourceSchemas []string `json:"resourceSchemas"`
+}
+
+// APIExportMigration describes the migration of the consumers of an APIExport to another APIExport.
+type APIExportMigration struct {
+	// target is the APIExport the consumers are moved to. It must have the same identity as
+	// this APIExport. If the path is unset, the logical cluster of this APIExport is used.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Target ExportBindingReference `json:"target"`
+
+	// batchSize is the maximal number of APIBindings repointed to the target at a time.
+	//
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// retireAfter is the time this APIExport keeps serving its virtual workspace after all
+	// APIBindings have been migrated, for the API provider to switch its controllers to the
+	// target. Afterwards the virtual workspace of this APIExport stops serving.
+	//
+	// APIBindings are only repointed if the owner of their workspace is allowed to bind
+	// the target.
+	//
+	// +optional
+	// +kubebuilder:default="24h"
+	RetireAfter *metav1.Duration `json:"retireAfter,omitempty"`
+}
+
+// APIExportDeprecation describes the deprecation of an APIExport.
+type APIExportDeprecation struct {
+	// deprecated marks the APIExport as deprecated.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Deprecated bool `json:"deprecated"`
+
+	// message is shown to consumers of the APIExport, e.g. to point to a replacement.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// sunsetTime is the time after which the APIExport is expected to be removed. From then on,
+	// no new APIBindings to the APIExport can be created.
+	//
+	// +optional
+	SunsetTime *metav1.Time `json:"sunsetTime,omitempty"`
+}
+
+// DeprecationWarning returns the warning shown to consumers of a deprecated APIExport,
+// or an empty string if the APIExport is not deprecated.
+func (in *APIExport) DeprecationWarning() string {
+	if in.Spec.Deprecation == nil || !in.Spec.Deprecation.Deprecated {
+		return ""
+	}
+
+	warning := fmt.Sprintf("APIExport %s is deprecated", in.Name)
+	if in.Spec.Deprecation.SunsetTime != nil {
+		warning += fmt.Sprintf(" and will be removed after %s", in.Spec.Deprecation.SunsetTime.UTC().Format(time.RFC3339))
+	}
+	if in.Spec.Deprecation.Message != "" {
+		warning += ": " + in.Spec.Deprecation.Message
+	}
+	return warning
+}
+
+// IsSunset returns true if the APIExport is deprecated and its sunset time has passed.
+func (in *APIExport) IsSunset(now time.Time) bool {
+	if in.Spec.Deprecation == nil || !in.Spec.Deprecation.Deprecated || in.Spec.Deprecation.SunsetTime == nil {
+		return false
+	}
+	return !now.Before(in.Spec.Deprecation.SunsetTime.Time)
+}
+
+// Identity defines the identity of an APIExport, i.e. determines the etcd prefix
+// data of this APIExport are stored under.
+type Identity struct {
+	// secretRef is a reference to a secret that contains the API identity in the 'key' file.
+	//
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+}
+
+// MaximalPermissionPolicy is a wrapper type around the multiple options that would be allowed.
+type MaximalPermissionPolicy struct {
+	// local is the policy that is defined in same workspace as the API Export.
+	// +optional
+	Local *LocalAPIExportPolicy `json:"local,omitempty"`
+}
+
+// LocalAPIExportPolicy is a maximal permission policy
+// that checks RBAC in the workspace of the API Export.
+//
+// In order to avoid conflicts the user and group name will be prefixed
+// with "apis.kcp.io:binding:".
+type LocalAPIExportPolicy struct{}
+
+const (
+	APIExportPermissionClaimLabelPrefix = "claimed.internal.apis.kcp.io/"
+)
+
+// PermissionClaim identifies an object by GR and identity hash.
+// Its purpose is to determine the added permissions that a service provider may
+// request and that a consumer may accept and allow the service provider access to.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.all) && self.all) != (has(self.resourceSelector) && size(self.resourceSelector) > 0)",message="either \"all\" or \"resourceSelector\" must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.group) || self.group != \"core.kcp.io\" || self.resource != \"logicalclusters\" || (has(self.identityHash) && self.identityHash != \"\")",message="logicalclusters cannot be claimed"
+type PermissionClaim struct {
+	GroupResource `json:","`
+
+	// all claims all resources for the given group/resource.
+	// This is mutually exclusive with resourceSelector.
+	// +optional
+	All bool `json:"all,omitempty"`
+
+	// resourceSelector is a list of claimed resource selectors.
+	//
+	// +optional
+	ResourceSelector []ResourceSelector `json:"resourceSelector,omitempty"`
+
+	// verbs restricts the claim to the given verbs, e.g. get, list and watch for
+	// read-only access. If empty, all verbs are claimed.
+	//
+	// +optional
+	// +listType=set
+	Verbs []string `json:"verbs,omitempty"`
+
+	// This is the identity for a given APIExport that the APIResourceSchema belongs to.
+	// The hash can be found on APIExport and APIResourceSchema's status.
+	// It will be empty for core types.
+	// Note that one must look this up for a particular KCP instance.
+	// +optional
+	IdentityHash string `json:"identityHash,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) || has(self.name) || has(self.namespaceSelector)",message="at least one field must be set"
+type ResourceSelector struct {
+	// name of an object within a claimed group/resource.
+	// It matches the metadata.name field of the underlying object.
+	// If namespace is unset, all objects matching that name will be claimed.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern="^([a-z0-9][-a-z0-9_.]*)?[a-z0-9]$"
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name,omitempty"`
+
+	// namespace containing the named object. Matches metadata.namespace field.
+	// If "name" is unset, all objects from the namespace are being claimed.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
+
+	// namespaceSelector selects the namespaces of claimed objects by their labels.
+	// Objects in namespaces not matching the selector are not claimed.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	//
+	// WARNING: If adding new fields, add them to the XValidation check!
+	//
+}
+
+func (p PermissionClaim) String() string {
+	// core resources have no group or identity hash
+	if p.Group == "" {
+		return p.Resource
+	}
+	if p.IdentityHash == "" {
+		return fmt.Sprintf("%s.%s", p.Resource, p.Group)
+	}
+	return fmt.Sprintf("%s.%s:%s", p.Resource, p.Group, p.IdentityHash)
+}
+
+// Equal returns true if both claims are for the same resource and verbs. The order of the verbs does not matter.
+func (p PermissionClaim) Equal(claim PermissionClaim) bool {
+	if !sets.NewString(p.Verbs...).Equal(sets.NewString(claim.Verbs...)) {
+		return false
+	}
+	return p.Group == claim.Group &&
+		p.Resource == claim.Resource &&
+		p.IdentityHash == claim.IdentityHash
+}
+
+// AllowsVerb returns true if the claim grants the given verb.
+func (p PermissionClaim) AllowsVerb(verb string) bool {
+	if len(p.Verbs) == 0 {
+		return true
+	}
+	for _, v := range p.Verbs {
+		if v == verb || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceSchemasForRelease returns the APIResourceSchemas and the version overrides to bind for the given
+// release, or latestResourceSchemas and resourceVersions if the release is empty. It returns false if
+// the release does not exist.
+func (s APIExportSpec) ResourceSchemasForRelease(release string) ([]string, []ExportedResourceVersion, bool) {
+	if release == "" {
+		return s.LatestResourceSchemas, s.ResourceVersions, true
+	}
+	for _, r := range s.Releases {
+		if r.Version == release {
+			return r.ResourceSchemas, nil, true
+		}
+	}
+	return nil, nil, false
+}
+
+// SuccessorRelease returns the oldest release newer than the given one, or an empty string for
+// latestResourceSchemas if there is none. The given release does not have to exist.
+func (s APIExportSpec) SuccessorRelease(release string) string {
+	removed, err := utilversion.ParseSemantic(release)
+	if err != nil {
+		return ""
+	}
+
+	var successor *utilversion.Version
+	successorName := ""
+	for _, r := range s.Releases {
+		v, err := utilversion.ParseSemantic(r.Version)
+		if err != nil || !removed.LessThan(v) {
+			continue
+		}
+		if successor == nil || v.LessThan(successor) {
+			successor, successorName = v, r.Version
+		}
+	}
+	return successorName
+}
+
+// NewestReleaseMatching returns the newest release matching the given constraint, a space-separated
+// list of comparisons like ">=v1.2.0 <v2.0.0" that all have to hold. It returns an empty string if
+// no release matches, and an error if the constraint is invalid.
+func (s APIExportSpec) NewestReleaseMatching(constraint string) (string, error) {
+	var newest *utilversion.Version
+	newestName := ""
+	for _, r := range s.Releases {
+		v, err := utilversion.ParseSemantic(r.Version)
+		if err != nil {
+			continue
+		}
+		matches, err := MatchesReleaseConstraint(v, constraint)
+		if err != nil {
+			return "", err
+		}
+		if matches && (newest == nil || newest.LessThan(v)) {
+			newest, newestName = v, r.Version
+		}
+	}
+	return newestName, nil
+}
+
+// MatchesReleaseConstraint returns whether the version satisfies all comparisons of the constraint.
+func MatchesReleaseConstraint(v *utilversion.Version, constraint string) (bool, error) {
+	comparisons := strings.Fields(constraint)
+	if len(comparisons) == 0 {
+		return false, fmt.Errorf("empty release constraint")
+	}
+	for _, c := range comparisons {
+		op := strings.TrimRight(c, "v0123456789.-+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+		other, err := utilversion.ParseSemantic(strings.TrimPrefix(c, op))
+		if err != nil {
+			return false, fmt.Errorf("invalid release constraint %q: %w", c, err)
+		}
+		cmp := 0
+		if v.LessThan(other) {
+			cmp = -1
+		} else if other.LessThan(v) {
+			cmp = 1
+		}
+		var ok bool
+		switch op {
+		case "", "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		default:
+			return false, fmt.Errorf("invalid operator %q in release constraint %q", op, c)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GroupResource identifies a resource.
+type GroupResource struct {
+	// group is the name of an API group.
+	// For core groups this is the empty string '""'.
+	//
+	// +kubebuilder:validation:Pattern=`^(|[a-z0-9]([-a-z0-9]*[a-z0-9](\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*)?)$`
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// resource is the name of the resource.
+	// Note: it is worth noting that you can not ask for permissions for resource provided by a CRD
+	// not provided by an api export.
+	// +kubebuilder:validation:Pattern=`^[a-z][-a-z0-9]*[a-z0-9]$`
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+}
+
+// ExportedResourceVersion sets the served and storage flags of a version of an exported resource.
+type ExportedResourceVersion struct {
+	GroupResource `json:","`
+
+	// version is the name of the version, e.g. "v1".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// served is a flag enabling/disabling this version from being served via REST APIs.
+	//
+	// +required
+	// +kubebuilder:default=true
+	Served bool `json:"served"`
+
+	// storage indicates this version should be used when persisting objects to storage.
+	// At most one version of a resource can be marked as storage.
+	//
+	// +optional
+	Storage bool `json:"storage,omitempty"`
+}
+
+// APIExportStatus defines the observed state of APIExport.
+//
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.identityHashAlgorithm) || has(self.identityHashAlgorithm)",message="identityHashAlgorithm cannot be unset"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.identityHashAlgorithm) || !has(oldSelf.identityHash) || !has(self.identityHashAlgorithm)",message="identityHashAlgorithm can only be set together with the first identityHash"
+type APIExportStatus struct {
+	// identityHash is the hash of the API identity key of this APIExport. This value
+	// is immutable as soon as it is set.
+	//
+	// +optional
+	IdentityHash string `json:"identityHash,omitempty"`
+
+	// identityHashAlgorithm is the hash algorithm of identityHash, e.g. sha256. It is recorded
+	// together with the first identityHash and is immutable. If it is empty, the identity is
+	// hashed with sha256.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="identityHashAlgorithm is immutable"
+	IdentityHashAlgorithm string `json:"identityHashAlgorithm,omitempty"`
+
+	// conditions is a list of conditions that apply to the APIExport.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// virtualWorkspaces contains all APIExport virtual workspace URLs.
+	//
+	// Deprecated: use APIExportEndpointSlice.status.endpoints instead
+	//
+	// +optional
+	VirtualWorkspaces []VirtualWorkspace `json:"virtualWorkspaces,omitempty"`
+
+	// consumers summarizes the APIBindings referencing this APIExport on all shards. The
+	// shards publish their APIBindings with a delay, hence the numbers lag behind a bit.
+	//
+	// +optional
+	Consumers *APIExportConsumers `json:"consumers,omitempty"`
+
+	// migration is the progress of spec.migration on all shards.
+	//
+	// +optional
+	Migration *APIExportMigrationStatus `json:"migration,omitempty"`
+}
+
+// APIExportMigrationStatus is the progress of the migration of the consumers of an APIExport.
+type APIExportMigrationStatus struct {
+	// migratedAPIBindings is the number of APIBindings currently referencing the target
+	// APIExport that were repointed from this APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	MigratedAPIBindings int32 `json:"migratedAPIBindings"`
+
+	// remainingAPIBindings is the number of APIBindings still referencing this APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	RemainingAPIBindings int32 `json:"remainingAPIBindings"`
+
+	// completionTime is the time all APIBindings were found to be migrated. The APIExport
+	// is retired spec.migration.retireAfter later.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// APIExportConsumers summarizes the APIBindings referencing an APIExport.
+type APIExportConsumers struct {
+	// apiBindings is the number of APIBindings referencing this APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	APIBindings int32 `json:"apiBindings"`
+
+	// readyAPIBindings is the number of APIBindings referencing this APIExport that are Ready.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	ReadyAPIBindings int32 `json:"readyAPIBindings"`
+
+	// boundSchemas is the distribution of the APIResourceSchemas bound by the APIBindings,
+	// i.e. how far a schema rollout has propagated.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	BoundSchemas []BoundSchemaCount `json:"boundSchemas,omitempty"`
+}
+
+// BoundSchemaCount is the number of APIBindings binding an APIResourceSchema.
+type BoundSchemaCount struct {
+	// name is the name of the APIResourceSchema.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// apiBindings is the number of APIBindings binding the APIResourceSchema.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	APIBindings int32 `json:"apiBindings"`
+}
+
+type VirtualWorkspace struct {
+	// url is an APIExport virtual workspace URL.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:format:URL
+	// +required
+	URL string `json:"url"`
+}
+
+// APIExportList is a list of APIExport resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type APIExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []APIExport `json:"items"`
+}
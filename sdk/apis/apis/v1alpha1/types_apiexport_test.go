@@ -21,7 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	apitest "github.com/kcp-dev/kcp/pkg/apis/test"
+	apitest "github.com/kcp-dev/kcp/sdk/apis/test"
 )
 
 func TestAPIExportPermissionClaimCELValidation(t *testing.T) {
@@ -212,6 +212,14 @@ func TestResourceSelectorCELValidation(t *testing.T) {
 				"namespace": "bar",
 			},
 		},
+		{
+			name: "namespaceSelector is set",
+			current: map[string]interface{}{
+				"namespaceSelector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"foo": "bar"},
+				},
+			},
+		},
 	}
 
 	validators := apitest.FieldValidatorsFromFile(t, "../../../../config/crds/apis.kcp.io_apiexports.yaml")
@@ -314,3 +322,113 @@ func TestAPIExportPermissionClaimPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionClaimAllowsVerb(t *testing.T) {
+	testCases := []struct {
+		name  string
+		verbs []string
+		verb  string
+		want  bool
+	}{
+		{name: "no verbs", verb: "delete", want: true},
+		{name: "claimed verb", verbs: []string{"get", "list", "watch"}, verb: "list", want: true},
+		{name: "unclaimed verb", verbs: []string{"get", "list", "watch"}, verb: "update", want: false},
+		{name: "wildcard", verbs: []string{"*"}, verb: "update", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claim := PermissionClaim{GroupResource: GroupResource{Resource: "secrets"}, All: true, Verbs: tc.verbs}
+			require.Equal(t, tc.want, claim.AllowsVerb(tc.verb))
+		})
+	}
+}
+
+func TestPermissionClaimEqual(t *testing.T) {
+	testCases := []struct {
+		name  string
+		verbs []string
+		other []string
+		want  bool
+	}{
+		{name: "no verbs", want: true},
+		{name: "same verbs", verbs: []string{"get", "list"}, other: []string{"get", "list"}, want: true},
+		{name: "different order", verbs: []string{"get", "list"}, other: []string{"list", "get"}, want: true},
+		{name: "different verbs", verbs: []string{"get", "list"}, other: []string{"get", "watch"}, want: false},
+		{name: "subset", verbs: []string{"get", "list"}, other: []string{"get"}, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claim := PermissionClaim{GroupResource: GroupResource{Resource: "secrets"}, All: true, Verbs: tc.verbs}
+			other := PermissionClaim{GroupResource: GroupResource{Resource: "secrets"}, All: true, Verbs: tc.other}
+			require.Equal(t, tc.want, claim.Equal(other))
+		})
+	}
+}
+
+func TestAPIExportSpecSuccessorRelease(t *testing.T) {
+	spec := APIExportSpec{Releases: []APIExportRelease{
+		{Version: "v1.10.0"},
+		{Version: "v1.2.0"},
+		{Version: "v2.0.0-rc.1"},
+		{Version: "v2.0.0"},
+	}}
+
+	testCases := []struct {
+		name    string
+		release string
+		want    string
+	}{
+		{name: "removed release in between", release: "v1.3.0", want: "v1.10.0"},
+		{name: "numeric ordering", release: "v1.2.0", want: "v1.10.0"},
+		{name: "pre-release before release", release: "v1.10.0", want: "v2.0.0-rc.1"},
+		{name: "newest release", release: "v2.0.0", want: ""},
+		{name: "invalid release", release: "latest", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, spec.SuccessorRelease(tc.release))
+		})
+	}
+}
+
+func TestAPIExportSpecNewestReleaseMatching(t *testing.T) {
+	spec := APIExportSpec{Releases: []APIExportRelease{
+		{Version: "v1.10.0"},
+		{Version: "v1.2.0"},
+		{Version: "v2.0.0-rc.1"},
+		{Version: "v2.0.0"},
+	}}
+
+	testCases := []struct {
+		name       string
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{name: "range", constraint: ">=v1.2.0 <v2.0.0-rc.1", want: "v1.10.0"},
+		{name: "exact version", constraint: "v1.2.0", want: "v1.2.0"},
+		{name: "exact version with operator", constraint: "=v1.2.0", want: "v1.2.0"},
+		{name: "lower bound only", constraint: ">v1.2.0", want: "v2.0.0"},
+		{name: "upper bound includes pre-release", constraint: "<v2.0.0", want: "v2.0.0-rc.1"},
+		{name: "inclusive upper bound", constraint: "<=v1.10.0", want: "v1.10.0"},
+		{name: "no match", constraint: ">v2.0.0", want: ""},
+		{name: "invalid version", constraint: ">=latest", wantErr: true},
+		{name: "invalid operator", constraint: "~v1.2.0", wantErr: true},
+		{name: "empty", constraint: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := spec.NewestReleaseMatching(tc.constraint)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APIExportConsumerSummary summarizes the APIBindings of one shard that reference the
+// APIExports of one logical cluster. It lives in the logical cluster of the APIExports,
+// is named after the shard and is written by that shard.
+//
+// APIExportConsumerSummaries only exist in the cache server. They let the shard of an
+// APIExport learn about consumers on other shards without replicating the APIBindings.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Shard",type="string",JSONPath=".spec.shard"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type APIExportConsumerSummary struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state.
+	//
+	// +optional
+	Spec APIExportConsumerSummarySpec `json:"spec,omitempty"`
+}
+
+// APIExportConsumerSummarySpec holds the consumers of the APIExports of a logical cluster on a shard.
+type APIExportConsumerSummarySpec struct {
+	// shard is the name of the shard the summarized APIBindings live on.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Shard string `json:"shard"`
+
+	// apiExports lists the consumers per APIExport. APIExports without APIBindings on
+	// the shard are omitted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	APIExports []APIExportShardConsumers `json:"apiExports,omitempty"`
+}
+
+// APIExportShardConsumers are the consumers of an APIExport on a single shard.
+type APIExportShardConsumers struct {
+	// name is the name of the APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	APIExportConsumers `json:",inline"`
+
+	// migratedAPIBindings is the number of APIBindings on the shard which spec.migration
+	// of the APIExport has repointed to its target.
+	//
+	// +optional
+	MigratedAPIBindings int32 `json:"migratedAPIBindings,omitempty"`
+}
+
+// APIExportConsumerSummaryList is a list of APIExportConsumerSummary resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type APIExportConsumerSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []APIExportConsumerSummary `json:"items"`
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp,path=apiexportendpointslices,singular=apiexportendpointslice
+// +kubebuilder:printcolumn:name="Export",type="string",JSONPath=".spec.apiexport.workspace.exportName"
+// +kubebuilder:printcolumn:name="Partition",type="string",JSONPath=".spec.partition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// APIExportEndpointSlice is a sink for the endpoints of an APIExport. These endpoints can be filtered by a Partition.
+// They get consumed by the managers to start controllers and informers for the respective APIExport services.
+type APIExportEndpointSlice struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec holds the desired state:
+	// - the targetted APIExport
+	// - an optional partition for filtering
+	Spec APIExportEndpointSliceSpec `json:"spec,omitempty"`
+
+	// status communicates the observed state:
+	// the filtered list of endpoints for the APIExport service.
+	// +optional
+	Status APIExportEndpointSliceStatus `json:"status,omitempty"`
+}
+
+// APIExportEndpointSliceSpec defines the desired state of the APIExportEndpointSlice.
+type APIExportEndpointSliceSpec struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="APIExport reference must not be changed"
+
+	// export points to the API export.
+	APIExport ExportBindingReference `json:"export"`
+
+	// +optional
+
+	// partition (optional) points to a partition in the same workspace that is used for filtering
+	// the endpoints of the APIExport part of the slice. Only shards matching the selector of the
+	// partition get an endpoint.
+	Partition string `json:"partition,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=Retain
+
+	// orphanPolicy decides what happens to the slice once the referenced APIExport has been
+	// gone for longer than orphanGracePeriod. With Retain, the slice is kept and marked with
+	// the Orphaned condition. With Delete, the slice is deleted.
+	OrphanPolicy APIExportEndpointSliceOrphanPolicy `json:"orphanPolicy,omitempty"`
+
+	// +optional
+	// +kubebuilder:default="1h"
+
+	// orphanGracePeriod is the time the referenced APIExport must be gone before orphanPolicy
+	// applies. It defaults to one hour, such that recreating an APIExport does not lose its slices.
+	OrphanGracePeriod *metav1.Duration `json:"orphanGracePeriod,omitempty"`
+}
+
+// APIExportEndpointSliceOrphanPolicy decides what happens to an APIExportEndpointSlice whose
+// APIExport has been deleted.
+//
+// +kubebuilder:validation:Enum=Retain;Delete
+type APIExportEndpointSliceOrphanPolicy string
+
+const (
+	// APIExportEndpointSliceOrphanPolicyRetain keeps orphaned slices and marks them with the
+	// Orphaned condition.
+	APIExportEndpointSliceOrphanPolicyRetain APIExportEndpointSliceOrphanPolicy = "Retain"
+
+	// APIExportEndpointSliceOrphanPolicyDelete deletes orphaned slices.
+	APIExportEndpointSliceOrphanPolicyDelete APIExportEndpointSliceOrphanPolicy = "Delete"
+)
+
+// APIExportEndpointSliceStatus defines the observed state of APIExportEndpointSlice.
+type APIExportEndpointSliceStatus struct {
+	// +optional
+
+	// conditions is a list of conditions that apply to the APIExportEndpointSlice.
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// +optional
+
+	// endpoints contains one endpoint of the APIExport service per shard, sorted by shard name.
+	// Shards sharing a virtual workspace URL are represented by the first of them, such that
+	// every URL is listed once.
+	APIExportEndpoints []APIExportEndpoint `json:"endpoints,omitempty"`
+}
+
+// APIExportEndpoint contains the endpoint information of an APIExport service for a specific shard.
+type APIExportEndpoint struct {
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:format:URL
+	// +required
+
+	// url is an APIExport virtual workspace URL. Clients only knowing about URLs can keep
+	// using this field.
+	URL string `json:"url"`
+
+	// +optional
+
+	// id is a stable identifier of the endpoint. It is the UID of the shard, i.e. it does not
+	// change when the URL of the shard changes, but when the shard is recreated.
+	ID string `json:"id,omitempty"`
+
+	// +optional
+
+	// shard is the name of the shard serving this endpoint.
+	Shard string `json:"shard,omitempty"`
+
+	// +optional
+
+	// region is the value of the topology.kcp.io/region label of the shard, if set.
+	Region string `json:"region,omitempty"`
+
+	// +optional
+
+	// dnsName is a stable DNS name of the endpoint, rendered from the shard name and the base
+	// domain configured for kcp. It is only set if configured. External load balancers and
+	// service meshes can target the endpoint by this name instead of parsing the url.
+	DNSName string `json:"dnsName,omitempty"`
+
+	// +optional
+
+	// servingSince is the time the endpoint was added to the slice.
+	ServingSince *metav1.Time `json:"servingSince,omitempty"`
+
+	// +optional
+
+	// state is the result of the last health probe of the endpoint. It is only set if
+	// endpoint probing is enabled. Consumers can skip Unready endpoints.
+	State APIExportEndpointState `json:"state,omitempty"`
+
+	// +optional
+
+	// lastProbeTime is the time of the health probe that first observed the current state
+	// of the endpoint. Later probes observing the same state do not update it.
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// APIExportEndpointState is the probed health of an APIExportEndpoint.
+//
+// +kubebuilder:validation:Enum=Ready;Unready
+type APIExportEndpointState string
+
+const (
+	// APIExportEndpointStateReady means that the virtual workspace server of the endpoint
+	// accepted connections when last probed.
+	APIExportEndpointStateReady APIExportEndpointState = "Ready"
+
+	// APIExportEndpointStateUnready means that the virtual workspace server of the endpoint
+	// could not be reached when last probed.
+	APIExportEndpointStateUnready APIExportEndpointState = "Unready"
+)
+
+func (in *APIExportEndpointSlice) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *APIExportEndpointSlice) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// These are valid conditions of APIExportEndpointSlice in addition to
+// APIExportValid and related reasons defined with the APIBinding type.
+const (
+	APIExportEndpointSliceURLsReady conditionsv1alpha1.ConditionType = "EndpointURLsReady"
+
+	// PartitionValid is a condition for APIExportEndpointSlice that reflects the validity of the referenced Partition.
+	PartitionValid conditionsv1alpha1.ConditionType = "PartitionValid"
+
+	// PartitionInvalidReferenceReason is a reason for the PartitionValid condition of APIExportEndpointSlice that the
+	// Partition reference is invalid.
+	PartitionInvalidReferenceReason = "PartitionInvalidReference"
+
+	// APIExportEndpointSliceOrphaned is a condition for APIExportEndpointSlice that is true when the
+	// referenced APIExport has been gone for longer than the orphan grace period of the slice.
+	APIExportEndpointSliceOrphaned conditionsv1alpha1.ConditionType = "Orphaned"
+
+	// APIExportDeletedReason is a reason for the Orphaned condition of APIExportEndpointSlice that the
+	// referenced APIExport has been deleted.
+	APIExportDeletedReason = "APIExportDeleted"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// APIExportEndpointSliceList is a list of APIExportEndpointSlice resources
+type APIExportEndpointSliceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []APIExportEndpointSlice `json:"items"`
+}
@@ -22,6 +22,8 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 // APIResourceSchema describes a resource, identified by (group, version, resource, schema).
@@ -33,6 +35,7 @@ import (
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories=kcp
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type APIResourceSchema struct {
@@ -44,6 +47,48 @@ type APIResourceSchema struct {
 	//
 	// +optional
 	Spec APIResourceSchemaSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state.
+	//
+	// +optional
+	Status APIResourceSchemaStatus `json:"status,omitempty"`
+}
+
+const (
+	// AnnotationAPIResourceSchemaPredecessorKey is the annotation key on an APIResourceSchema holding the name of
+	// the APIResourceSchema in the same workspace it evolves from. The schema is checked for backward compatibility
+	// with its predecessor, and the result is published in the CompatibleWithPredecessor condition.
+	AnnotationAPIResourceSchemaPredecessorKey = "apis.kcp.io/predecessor"
+
+	// AnnotationAPIResourceSchemaTemplatesKey is the annotation key on an APIResourceSchema holding a comma-separated
+	// list of templates whose printer columns and categories are injected into the schema on creation, e.g.
+	// "standard,all" for the Ready and Age columns and the "all" category.
+	AnnotationAPIResourceSchemaTemplatesKey = "apis.kcp.io/templates"
+)
+
+// These are valid conditions of APIResourceSchema.
+const (
+	// CompatibleWithPredecessor is a condition for APIResourceSchema that reflects whether the schema is backward
+	// compatible with the predecessor referenced by the AnnotationAPIResourceSchemaPredecessorKey annotation, i.e.
+	// it serves the same resource, keeps serving all served versions, and neither removes fields, changes field
+	// types, nor requires new fields without default.
+	CompatibleWithPredecessor conditionsv1alpha1.ConditionType = "CompatibleWithPredecessor"
+
+	// PredecessorNotFoundReason is a reason for the CompatibleWithPredecessor condition that the predecessor
+	// APIResourceSchema does not exist.
+	PredecessorNotFoundReason = "PredecessorNotFound"
+
+	// IncompatibleChangesReason is a reason for the CompatibleWithPredecessor condition that the schema is not
+	// backward compatible with its predecessor.
+	IncompatibleChangesReason = "IncompatibleChanges"
+)
+
+func (in *APIResourceSchema) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *APIResourceSchema) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
 }
 
 // APIResourceSchemaSpec defines the desired state of APIResourceSchema.
@@ -75,6 +120,63 @@ type APIResourceSchemaSpec struct {
 	// +listMapKey=name
 	// +kubebuilder:validation:MinItems=1
 	Versions []APIResourceVersion `json:"versions"`
+
+	// conversions declares how custom resources are converted between versions. Bound resources
+	// of a schema with conversions are converted by kcp, without a conversion webhook hosted by
+	// the provider. Between versions without a conversion, only the apiVersion is changed.
+	//
+	// +optional
+	// +listType=atomic
+	Conversions []APIVersionConversion `json:"conversions,omitempty"`
+}
+
+// APIVersionConversion declares the conversion of custom resources from one version to another.
+type APIVersionConversion struct {
+	// from is the version the custom resources are converted from.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// to is the version the custom resources are converted to.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+	// rules are applied to the custom resources converted. Fields not mentioned in any rule
+	// are kept unchanged.
+	//
+	// +optional
+	// +listType=atomic
+	Rules []APIConversionRule `json:"rules,omitempty"`
+}
+
+// APIConversionRule moves a field of a custom resource and optionally transforms its value.
+type APIConversionRule struct {
+	// field is the JSONPath of the source field, e.g. ".spec.firstName". Only dot-separated
+	// field names are supported, and metadata, apiVersion and kind cannot be converted.
+	// Rules of fields missing in a custom resource are skipped.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Field string `json:"field"`
+	// destination is the JSONPath the value is written to, in the same syntax as field. It
+	// defaults to field. If it differs, the source field is removed.
+	//
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// transformation is a CEL expression computing the written value from the value of the
+	// source field, which is available as "self", e.g. "self.split(' ')[0]".
+	//
+	// +optional
+	Transformation string `json:"transformation,omitempty"`
+}
+
+// APIResourceSchemaStatus defines the observed state of APIResourceSchema.
+type APIResourceSchemaStatus struct {
+	// conditions is a list of conditions that apply to the APIResourceSchema.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
 }
 
 // APIResourceVersion describes one API version of a resource.
@@ -110,7 +212,10 @@ type APIResourceVersion struct {
 	// +optional
 	DeprecationWarning *string `json:"deprecationWarning,omitempty"`
 	// schema describes the structural schema used for validation, pruning, and defaulting
-	// of this version of the custom resource.
+	// of this version of the custom resource. CEL validation rules (x-kubernetes-validations)
+	// are compiled and checked against the cost budget on creation, and enforced for all
+	// bound resources. Defaults must be pruned and valid against the schema, like in CRDs,
+	// and are applied to all bound resources.
 	//
 	// +required
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -120,6 +225,15 @@ type APIResourceVersion struct {
 	//
 	// +optional
 	Subresources apiextensionsv1.CustomResourceSubresources `json:"subresources,omitempty"`
+	// customSubresources declares subresources in addition to status and scale, e.g. approve or bind.
+	// Requests of consumers to them are authorized like requests to other subresources, and then
+	// forwarded to spec.customSubresourceHandler of the APIExport. Custom subresources are not
+	// published in discovery.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	CustomSubresources []CustomSubresource `json:"customSubresources,omitempty"`
 	// additionalPrinterColumns specifies additional columns returned in Table output.
 	// See https://kubernetes.io/docs/reference/using-api/api-concepts/#receiving-resources-as-tables for details.
 	// If no columns are specified, a single column displaying the age of the custom resource is used.
@@ -130,6 +244,19 @@ type APIResourceVersion struct {
 	AdditionalPrinterColumns []apiextensionsv1.CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
 }
 
+// CustomSubresource is a subresource served by the provider of an APIExport.
+type CustomSubresource struct {
+	// name is the name of the subresource, served under `.../<resource>/<object name>/<name>`.
+	// It must be a DNS label, and must not be status or scale.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+}
+
 // APIResourceSchemaList is a list of APIResourceSchema resources
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+)
+
+// LogicalClusterPath is a reference to a logical cluster by its path, e.g. root:org:ws. The
+// name of a logical cluster is a valid path as well.
+//
+// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+type LogicalClusterPath string
+
+// NewLogicalClusterPath returns the reference to the given logical cluster path.
+func NewLogicalClusterPath(path logicalcluster.Path) LogicalClusterPath {
+	return LogicalClusterPath(path.String())
+}
+
+// ParseLogicalClusterPath converts an untyped path, e.g. taken from user input or from code predating
+// the typed references, into a normalized LogicalClusterPath. It fails if the normalized path is invalid.
+func ParseLogicalClusterPath(path string) (LogicalClusterPath, error) {
+	p := LogicalClusterPath(path).Normalize()
+	if !p.IsValid() {
+		return "", fmt.Errorf("invalid logical cluster path %q", path)
+	}
+	return p, nil
+}
+
+// String returns the untyped path of the reference.
+func (p LogicalClusterPath) String() string {
+	return string(p)
+}
+
+// Path returns the logical cluster path of the reference.
+func (p LogicalClusterPath) Path() logicalcluster.Path {
+	return logicalcluster.NewPath(string(p))
+}
+
+// Empty returns true if the reference is unset.
+func (p LogicalClusterPath) Empty() bool {
+	return p == ""
+}
+
+// IsValid returns true if the reference is a well-formed logical cluster path.
+func (p LogicalClusterPath) IsValid() bool {
+	return p.Path() != logicalcluster.Wildcard && p.Path().IsValid()
+}
+
+// Normalize returns the reference in its canonical form, i.e. lower-case and without
+// surrounding whitespace or leading and trailing colons. A normalized reference is not
+// necessarily valid.
+func (p LogicalClusterPath) Normalize() LogicalClusterPath {
+	return LogicalClusterPath(strings.Trim(strings.ToLower(strings.TrimSpace(string(p))), ":"))
+}
+
+// LogicalClusterName is a reference to a logical cluster by its name. Other than a
+// LogicalClusterPath, it never consists of multiple segments.
+type LogicalClusterName string
+
+// NewLogicalClusterName returns the reference to the given logical cluster name.
+func NewLogicalClusterName(name logicalcluster.Name) LogicalClusterName {
+	return LogicalClusterName(name.String())
+}
+
+// ParseLogicalClusterName converts an untyped name, e.g. taken from user input or from code predating
+// the typed references, into a LogicalClusterName. It fails if the name is invalid.
+func ParseLogicalClusterName(name string) (LogicalClusterName, error) {
+	n := LogicalClusterName(strings.TrimSpace(name))
+	if !n.IsValid() {
+		return "", fmt.Errorf("invalid logical cluster name %q", name)
+	}
+	return n, nil
+}
+
+// String returns the untyped name of the reference.
+func (n LogicalClusterName) String() string {
+	return string(n)
+}
+
+// AsPath returns the reference as a path, which is valid wherever a LogicalClusterPath is expected.
+func (n LogicalClusterName) AsPath() LogicalClusterPath {
+	return LogicalClusterPath(n)
+}
+
+// Name returns the logical cluster name of the reference.
+func (n LogicalClusterName) Name() logicalcluster.Name {
+	return logicalcluster.Name(n)
+}
+
+// Empty returns true if the reference is unset.
+func (n LogicalClusterName) Empty() bool {
+	return n == ""
+}
+
+// IsValid returns true if the reference is a well-formed logical cluster name.
+func (n LogicalClusterName) IsValid() bool {
+	return n.Name().IsValid()
+}
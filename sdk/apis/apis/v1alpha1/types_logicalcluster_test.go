@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicalClusterPath(t *testing.T) {
+	tests := []struct {
+		path           LogicalClusterPath
+		wantNormalized LogicalClusterPath
+		wantValid      bool
+	}{
+		{path: "root", wantNormalized: "root", wantValid: true},
+		{path: "root:org:ws", wantNormalized: "root:org:ws", wantValid: true},
+		{path: "2x7jzj8rpjz5sbnx", wantNormalized: "2x7jzj8rpjz5sbnx", wantValid: true},
+		{path: "Root:Org", wantNormalized: "root:org"},
+		{path: "root:org:", wantNormalized: "root:org"},
+		{path: " :root: ", wantNormalized: "root"},
+		{path: "root::org", wantNormalized: "root::org"},
+		{path: "root:org_1", wantNormalized: "root:org_1"},
+		{path: "*", wantNormalized: "*"},
+		{path: "", wantNormalized: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.path), func(t *testing.T) {
+			require.Equal(t, tt.wantValid, tt.path.IsValid(), "IsValid")
+			require.Equal(t, tt.wantNormalized, tt.path.Normalize(), "Normalize")
+		})
+	}
+}
+
+func TestParseLogicalClusterPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    LogicalClusterPath
+		wantErr bool
+	}{
+		{path: "root:org", want: "root:org"},
+		{path: " Root:Org: ", want: "root:org"},
+		{path: "root::org", wantErr: true},
+		{path: "*", wantErr: true},
+		{path: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ParseLogicalClusterPath(tt.path)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLogicalClusterName(t *testing.T) {
+	got, err := ParseLogicalClusterName(" 2x7jzj8rpjz5sbnx ")
+	require.NoError(t, err)
+	require.Equal(t, LogicalClusterName("2x7jzj8rpjz5sbnx"), got)
+	require.Equal(t, LogicalClusterPath("2x7jzj8rpjz5sbnx"), got.AsPath())
+
+	_, err = ParseLogicalClusterName("root:org")
+	require.Error(t, err)
+}
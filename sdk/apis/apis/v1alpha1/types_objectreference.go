@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ObjectReference is a reference to an object in the same or in another workspace. It is
+// meant to be embedded by APIs that point to objects across workspaces, such that they all
+// share the same path semantics. Use pkg/objectreference to validate and resolve it.
+type ObjectReference struct {
+	// path is a logical cluster path where the object lives.
+	// If the path is unset, the logical cluster of the referencing object is used.
+	//
+	// +optional
+	Path LogicalClusterPath `json:"path,omitempty"`
+
+	// group is the API group of the object. The empty group is the core group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is the API version of the object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// resource is the lower-case plural resource name of the object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+
+	// namespace is the namespace of the object. It is empty for cluster-scoped objects.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// GroupVersionResource returns the resource of the referenced object.
+func (r ObjectReference) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// ClusterPath returns the path of the logical cluster of the referenced object, falling back to
+// the given logical cluster of the referencing object if the path is unset.
+func (r ObjectReference) ClusterPath(from logicalcluster.Name) logicalcluster.Path {
+	if r.Path.Empty() {
+		return from.Path()
+	}
+	return r.Path.Path()
+}
+
+// String returns a human-readable form of the reference, e.g. root:org|configmaps.v1/default/foo.
+func (r ObjectReference) String() string {
+	s := r.Resource + "." + r.Version
+	if r.Group != "" {
+		s += "." + r.Group
+	}
+	s += "/"
+	if r.Namespace != "" {
+		s += r.Namespace + "/"
+	}
+	s += r.Name
+	if r.Path.Empty() {
+		return s
+	}
+	return r.Path.String() + "|" + s
+}
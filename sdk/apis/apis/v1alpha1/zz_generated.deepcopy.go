@@ -24,9 +24,10 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -90,6 +91,22 @@ func (in *APIBindingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIBindingResourceStatus) DeepCopyInto(out *APIBindingResourceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIBindingResourceStatus.
+func (in *APIBindingResourceStatus) DeepCopy() *APIBindingResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIBindingResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIBindingSpec) DeepCopyInto(out *APIBindingSpec) {
 	*out = *in
@@ -101,6 +118,11 @@ func (in *APIBindingSpec) DeepCopyInto(out *APIBindingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExcludedResources != nil {
+		in, out := &in.ExcludedResources, &out.ExcludedResources
+		*out = make([]GroupResource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -145,6 +167,23 @@ func (in *APIBindingStatus) DeepCopyInto(out *APIBindingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BoundAPIExport != nil {
+		in, out := &in.BoundAPIExport, &out.BoundAPIExport
+		*out = new(BoundAPIExport)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]APIBindingResourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraCRDAnnotations != nil {
+		in, out := &in.ExtraCRDAnnotations, &out.ExtraCRDAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -158,6 +197,22 @@ func (in *APIBindingStatus) DeepCopy() *APIBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConversionRule) DeepCopyInto(out *APIConversionRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConversionRule.
+func (in *APIConversionRule) DeepCopy() *APIConversionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConversionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIExport) DeepCopyInto(out *APIExport) {
 	*out = *in
@@ -186,9 +241,141 @@ func (in *APIExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportConsumerSummary) DeepCopyInto(out *APIExportConsumerSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportConsumerSummary.
+func (in *APIExportConsumerSummary) DeepCopy() *APIExportConsumerSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportConsumerSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIExportConsumerSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportConsumerSummaryList) DeepCopyInto(out *APIExportConsumerSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIExportConsumerSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportConsumerSummaryList.
+func (in *APIExportConsumerSummaryList) DeepCopy() *APIExportConsumerSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportConsumerSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIExportConsumerSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportConsumerSummarySpec) DeepCopyInto(out *APIExportConsumerSummarySpec) {
+	*out = *in
+	if in.APIExports != nil {
+		in, out := &in.APIExports, &out.APIExports
+		*out = make([]APIExportShardConsumers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportConsumerSummarySpec.
+func (in *APIExportConsumerSummarySpec) DeepCopy() *APIExportConsumerSummarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportConsumerSummarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportConsumers) DeepCopyInto(out *APIExportConsumers) {
+	*out = *in
+	if in.BoundSchemas != nil {
+		in, out := &in.BoundSchemas, &out.BoundSchemas
+		*out = make([]BoundSchemaCount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportConsumers.
+func (in *APIExportConsumers) DeepCopy() *APIExportConsumers {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportConsumers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportDeprecation) DeepCopyInto(out *APIExportDeprecation) {
+	*out = *in
+	if in.SunsetTime != nil {
+		in, out := &in.SunsetTime, &out.SunsetTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportDeprecation.
+func (in *APIExportDeprecation) DeepCopy() *APIExportDeprecation {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportDeprecation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIExportEndpoint) DeepCopyInto(out *APIExportEndpoint) {
 	*out = *in
+	if in.ServingSince != nil {
+		in, out := &in.ServingSince, &out.ServingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -207,7 +394,7 @@ func (in *APIExportEndpointSlice) DeepCopyInto(out *APIExportEndpointSlice) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -267,6 +454,11 @@ func (in *APIExportEndpointSliceList) DeepCopyObject() runtime.Object {
 func (in *APIExportEndpointSliceSpec) DeepCopyInto(out *APIExportEndpointSliceSpec) {
 	*out = *in
 	out.APIExport = in.APIExport
+	if in.OrphanGracePeriod != nil {
+		in, out := &in.OrphanGracePeriod, &out.OrphanGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -293,7 +485,9 @@ func (in *APIExportEndpointSliceStatus) DeepCopyInto(out *APIExportEndpointSlice
 	if in.APIExportEndpoints != nil {
 		in, out := &in.APIExportEndpoints, &out.APIExportEndpoints
 		*out = make([]APIExportEndpoint, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -341,6 +535,86 @@ func (in *APIExportList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportMigration) DeepCopyInto(out *APIExportMigration) {
+	*out = *in
+	out.Target = in.Target
+	if in.RetireAfter != nil {
+		in, out := &in.RetireAfter, &out.RetireAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportMigration.
+func (in *APIExportMigration) DeepCopy() *APIExportMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportMigrationStatus) DeepCopyInto(out *APIExportMigrationStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportMigrationStatus.
+func (in *APIExportMigrationStatus) DeepCopy() *APIExportMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportRelease) DeepCopyInto(out *APIExportRelease) {
+	*out = *in
+	if in.ResourceSchemas != nil {
+		in, out := &in.ResourceSchemas, &out.ResourceSchemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportRelease.
+func (in *APIExportRelease) DeepCopy() *APIExportRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIExportShardConsumers) DeepCopyInto(out *APIExportShardConsumers) {
+	*out = *in
+	in.APIExportConsumers.DeepCopyInto(&out.APIExportConsumers)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIExportShardConsumers.
+func (in *APIExportShardConsumers) DeepCopy() *APIExportShardConsumers {
+	if in == nil {
+		return nil
+	}
+	out := new(APIExportShardConsumers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIExportSpec) DeepCopyInto(out *APIExportSpec) {
 	*out = *in
@@ -349,6 +623,18 @@ func (in *APIExportSpec) DeepCopyInto(out *APIExportSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceVersions != nil {
+		in, out := &in.ResourceVersions, &out.ResourceVersions
+		*out = make([]ExportedResourceVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Releases != nil {
+		in, out := &in.Releases, &out.Releases
+		*out = make([]APIExportRelease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Identity != nil {
 		in, out := &in.Identity, &out.Identity
 		*out = new(Identity)
@@ -366,6 +652,26 @@ func (in *APIExportSpec) DeepCopyInto(out *APIExportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deprecation != nil {
+		in, out := &in.Deprecation, &out.Deprecation
+		*out = new(APIExportDeprecation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(APIExportMigration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomSubresourceHandler != nil {
+		in, out := &in.CustomSubresourceHandler, &out.CustomSubresourceHandler
+		*out = new(CustomSubresourceHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -394,6 +700,16 @@ func (in *APIExportStatus) DeepCopyInto(out *APIExportStatus) {
 		*out = make([]VirtualWorkspace, len(*in))
 		copy(*out, *in)
 	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = new(APIExportConsumers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(APIExportMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -413,6 +729,7 @@ func (in *APIResourceSchema) DeepCopyInto(out *APIResourceSchema) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -478,6 +795,13 @@ func (in *APIResourceSchemaSpec) DeepCopyInto(out *APIResourceSchemaSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conversions != nil {
+		in, out := &in.Conversions, &out.Conversions
+		*out = make([]APIVersionConversion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -491,6 +815,29 @@ func (in *APIResourceSchemaSpec) DeepCopy() *APIResourceSchemaSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIResourceSchemaStatus) DeepCopyInto(out *APIResourceSchemaStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(conditionsv1alpha1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIResourceSchemaStatus.
+func (in *APIResourceSchemaStatus) DeepCopy() *APIResourceSchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIResourceSchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIResourceVersion) DeepCopyInto(out *APIResourceVersion) {
 	*out = *in
@@ -506,6 +853,11 @@ func (in *APIResourceVersion) DeepCopyInto(out *APIResourceVersion) {
 		*out = make([]v1.CustomResourceColumnDefinition, len(*in))
 		copy(*out, *in)
 	}
+	if in.CustomSubresources != nil {
+		in, out := &in.CustomSubresources, &out.CustomSubresources
+		*out = make([]CustomSubresource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -519,6 +871,27 @@ func (in *APIResourceVersion) DeepCopy() *APIResourceVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIVersionConversion) DeepCopyInto(out *APIVersionConversion) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]APIConversionRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIVersionConversion.
+func (in *APIVersionConversion) DeepCopy() *APIVersionConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(APIVersionConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceptablePermissionClaim) DeepCopyInto(out *AcceptablePermissionClaim) {
 	*out = *in
@@ -557,6 +930,22 @@ func (in *BindingReference) DeepCopy() *BindingReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BoundAPIExport) DeepCopyInto(out *BoundAPIExport) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoundAPIExport.
+func (in *BoundAPIExport) DeepCopy() *BoundAPIExport {
+	if in == nil {
+		return nil
+	}
+	out := new(BoundAPIExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BoundAPIResource) DeepCopyInto(out *BoundAPIResource) {
 	*out = *in
@@ -595,6 +984,59 @@ func (in *BoundAPIResourceSchema) DeepCopy() *BoundAPIResourceSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BoundSchemaCount) DeepCopyInto(out *BoundSchemaCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoundSchemaCount.
+func (in *BoundSchemaCount) DeepCopy() *BoundSchemaCount {
+	if in == nil {
+		return nil
+	}
+	out := new(BoundSchemaCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSubresource) DeepCopyInto(out *CustomSubresource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSubresource.
+func (in *CustomSubresource) DeepCopy() *CustomSubresource {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSubresource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSubresourceHandler) DeepCopyInto(out *CustomSubresourceHandler) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSubresourceHandler.
+func (in *CustomSubresourceHandler) DeepCopy() *CustomSubresourceHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSubresourceHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExportBindingReference) DeepCopyInto(out *ExportBindingReference) {
 	*out = *in
@@ -611,6 +1053,23 @@ func (in *ExportBindingReference) DeepCopy() *ExportBindingReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportedResourceVersion) DeepCopyInto(out *ExportedResourceVersion) {
+	*out = *in
+	out.GroupResource = in.GroupResource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportedResourceVersion.
+func (in *ExportedResourceVersion) DeepCopy() *ExportedResourceVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportedResourceVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupResource) DeepCopyInto(out *GroupResource) {
 	*out = *in
@@ -685,6 +1144,22 @@ func (in *MaximalPermissionPolicy) DeepCopy() *MaximalPermissionPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PermissionClaim) DeepCopyInto(out *PermissionClaim) {
 	*out = *in
@@ -692,6 +1167,13 @@ func (in *PermissionClaim) DeepCopyInto(out *PermissionClaim) {
 	if in.ResourceSelector != nil {
 		in, out := &in.ResourceSelector, &out.ResourceSelector
 		*out = make([]ResourceSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -710,6 +1192,11 @@ func (in *PermissionClaim) DeepCopy() *PermissionClaim {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
 	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
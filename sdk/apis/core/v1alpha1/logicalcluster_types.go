@@ -20,8 +20,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
 )
 
 // LogicalCluster describes the current logical cluster. It is used to authorize
@@ -55,6 +55,18 @@ const (
 	// LogicalClusterFinalizer attached to the owner of thw LogicalCluster resource (usually a Workspace) so that we can control
 	// deletion of LogicalCluster resources
 	LogicalClusterFinalizer = "core.kcp.io/logicalcluster"
+
+	// LogicalClusterArchivedAnnotationKey marks a logical cluster as archived. Archived logical
+	// clusters are read-only until the annotation is removed. It is maintained by the workspace
+	// controller from spec.state of the owning Workspace.
+	LogicalClusterArchivedAnnotationKey = "core.kcp.io/archived"
+
+	// LogicalClusterRecoveryAnnotationKey puts a logical cluster into recovery mode. Its value is
+	// the reason given by the operator. A logical cluster in recovery mode is read-only for
+	// everybody but members of system:masters, whose requests skip admission, including webhooks,
+	// such that corrupted finalizers and owner references can be repaired. Only members of
+	// system:masters can set or remove the annotation.
+	LogicalClusterRecoveryAnnotationKey = "core.kcp.io/recovery"
 )
 
 // LogicalClusterPhaseType is the type of the current phase of the logical cluster.
@@ -0,0 +1,306 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// RootShard holds a name of the root shard.
+var RootShard = "root"
+
+// ShardRegionLabelKey is the label on a Shard holding the region it runs in.
+const ShardRegionLabelKey = "topology.kcp.io/region"
+
+const (
+	// ShardLoadNominal is false when the shard sheds load because of memory pressure or etcd latency,
+	// i.e. low-priority controllers are paused and expensive requests are rejected.
+	ShardLoadNominal v1alpha1.ConditionType = "LoadNominal"
+
+	// ShardMemoryPressureReason is the reason of a false ShardLoadNominal condition caused by memory usage.
+	ShardMemoryPressureReason = "MemoryPressure"
+	// ShardEtcdLatencyReason is the reason of a false ShardLoadNominal condition caused by etcd latency.
+	ShardEtcdLatencyReason = "EtcdLatency"
+
+	// ShardLive is false when the shard did not renew its lease in status.lease in time, e.g. because
+	// it is down or cannot reach the root shard. The workspace scheduler and the APIExportEndpointSlice
+	// controller avoid shards which are not live. Shards without lease have no ShardLive condition.
+	ShardLive v1alpha1.ConditionType = "Live"
+
+	// ShardLeaseExpiredReason is the reason of a false ShardLive condition.
+	ShardLeaseExpiredReason = "LeaseExpired"
+
+	// ShardConfigurationApplied is true when the shard runs with its spec.configuration. The shard
+	// sets it itself. Shards without configuration have no ShardConfigurationApplied condition.
+	ShardConfigurationApplied v1alpha1.ConditionType = "ConfigurationApplied"
+
+	// ShardConfigurationRestartRequiredReason is the reason of a false ShardConfigurationApplied
+	// condition when parts of spec.configuration changed which are only applied on start.
+	ShardConfigurationRestartRequiredReason = "RestartRequired"
+	// ShardConfigurationInvalidReason is the reason of a false ShardConfigurationApplied condition
+	// when spec.configuration has unknown or invalid feature gates or flags.
+	ShardConfigurationInvalidReason = "InvalidConfiguration"
+
+	// ShardControllersApplied is false when spec.controllers overrides controllers which do not run
+	// in the shard process, e.g. because of a typo in the name or because they run in
+	// kcp-controller-manager. The shard sets it itself. Shards without overrides have no
+	// ShardControllersApplied condition.
+	ShardControllersApplied v1alpha1.ConditionType = "ControllersApplied"
+
+	// ShardUnknownControllersReason is the reason of a false ShardControllersApplied condition.
+	ShardUnknownControllersReason = "UnknownControllers"
+)
+
+// Shard describes a kcp instance on which a number of logical clusters will live
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.baseURL`,description="Type URL to directly connect to the shard"
+// +kubebuilder:printcolumn:name="External URL",type=string,JSONPath=`.spec.externalURL`,description="The URL exposed in logical clusters created on that shard"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Shard struct {
+	v1.TypeMeta `json:",inline"`
+	// +optional
+	v1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ShardSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status ShardStatus `json:"status,omitempty"`
+}
+
+func (in *Shard) SetConditions(c v1alpha1.Conditions) {
+	in.Status.Conditions = c
+}
+
+func (in *Shard) GetConditions() v1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+var _ conditions.Getter = &Shard{}
+var _ conditions.Setter = &Shard{}
+
+// ShardSpec holds the desired state of the Shard.
+type ShardSpec struct {
+	// baseURL is the address of the KCP shard for direct connections, e.g. by some
+	// front-proxy doing the fan-out to the shards.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:MinLength=1
+	BaseURL string `json:"baseURL"`
+
+	// externalURL is the externally visible address presented to users in Workspace URLs.
+	// Changing this will break all existing logical clusters on that shard, i.e. existing
+	// kubeconfigs of clients will be invalid. Hence, when changing this value, the old
+	// URL used by clients must keep working.
+	//
+	// The external address will not be unique if a front-proxy does a fan-out to
+	// shards, but all logical cluster clients will talk to the front-proxy. In that case,
+	// put the address of the front-proxy here.
+	//
+	// Note that movement of shards is only possible (in the future) between shards
+	// that share a common external URL.
+	//
+	// This will be defaulted to the value of the baseURL.
+	//
+	// +optional
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:MinLength=1
+	ExternalURL string `json:"externalURL,omitempty"`
+
+	// virtualWorkspaceURL is the address of the virtual workspace apiserver associated with this shard.
+	// It can be a direct address, an address of a front-proxy or even an address of an LB.
+	// As of today this address is assigned to APIExports.
+	//
+	// This will be defaulted to the value of the baseURL.
+	//
+	// +optional
+	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:MinLength=1
+	VirtualWorkspaceURL string `json:"virtualWorkspaceURL,omitempty"`
+
+	// cordoned stops the scheduling of new workspaces to the shard. Existing workspaces are not
+	// affected.
+	//
+	// +optional
+	Cordoned bool `json:"cordoned,omitempty"`
+
+	// draining stops the scheduling of new workspaces to the shard like cordoned, and marks the
+	// workspaces on it with the ShardDraining condition. Workspaces cannot be moved between shards
+	// yet, so the condition tells their owners that the shard is about to go down for maintenance.
+	//
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
+	// controllers overrides embedded controllers of the shard at runtime, e.g. to stop a
+	// misbehaving controller without restarting the shard. Controllers which are not listed
+	// run as configured on the command line. Overrides of controllers which the shard does not
+	// run are reported in the ControllersApplied condition.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Controllers []ShardControllerOverride `json:"controllers,omitempty"`
+
+	// configuration overrides feature gates and flags of the shard, e.g. to canary them on a subset
+	// of shards. It takes precedence over the command line and the configuration file of the shard.
+	// Reloadable flags are applied at runtime, everything else when the shard starts.
+	//
+	// +optional
+	Configuration *ShardConfiguration `json:"configuration,omitempty"`
+}
+
+// ShardConfiguration overrides the configuration of a shard.
+type ShardConfiguration struct {
+	// featureGates enables or disables feature gates of the shard by name, e.g.
+	// KCPStatusProvenance: true. Changes are applied when the shard restarts.
+	//
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// flags sets flags of the shard by name without leading dashes, e.g.
+	// load-shedding-memory-threshold: 6Gi. Only the flags of the sections of the configuration
+	// file and the committer flags can be set. The load shedding thresholds and the committer
+	// flags are applied at runtime, other changes when the shard restarts. Lists are comma
+	// separated.
+	//
+	// +optional
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// ShardControllerMaxWorkers is the maximal number of workers of an embedded controller
+// which can be configured at runtime.
+const ShardControllerMaxWorkers = 16
+
+// ShardControllerOverride overrides an embedded controller of a shard at runtime.
+type ShardControllerOverride struct {
+	// name is the name of the controller, e.g. kcp-apiexport-extra-annotation-sync.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// disabled stops the controller from processing its queue. Queued work is processed
+	// when the controller is enabled again.
+	//
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// workers is the number of concurrent workers of the controller. It defaults to the
+	// number of workers the controller is started with.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	Workers *int32 `json:"workers,omitempty"`
+}
+
+// ShardCapacityWorkspaces is the resource in the capacity of a Shard limiting the number of
+// workspaces scheduled to it. Shards without it have unlimited capacity. The limit is enforced by
+// the workspace scheduler on a best-effort basis.
+const ShardCapacityWorkspaces corev1.ResourceName = "workspaces"
+
+// ShardCapacityEtcdDatabaseSize is the resource in the capacity of a Shard giving the etcd
+// database size the shard is meant to run at, e.g. below the etcd quota. It is not enforced, but
+// the workspace scheduler prefers shards further away from it.
+const ShardCapacityEtcdDatabaseSize corev1.ResourceName = "etcd-database-size"
+
+// ShardCapacityRequestsPerSecond is the resource in the capacity of a Shard giving the rate of
+// requests the shard is meant to serve. It is not enforced, but the workspace scheduler prefers
+// shards further away from it.
+const ShardCapacityRequestsPerSecond corev1.ResourceName = "requests-per-second"
+
+// ShardStatus communicates the observed state of the Shard.
+type ShardStatus struct {
+	// Set of integer resources that logical clusters can be scheduled into
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// Current processing state of the Shard.
+	// +optional
+	Conditions v1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// usage is the utilization of the shard, reported by the shard itself in a regular heartbeat.
+	// The workspace scheduler spreads workspaces across shards by their usage relative to their
+	// capacity.
+	//
+	// +optional
+	Usage *ShardUsage `json:"usage,omitempty"`
+
+	// lease is renewed by the shard itself in a regular heartbeat. The shard is considered down
+	// when the lease expires.
+	//
+	// +optional
+	Lease *ShardLease `json:"lease,omitempty"`
+}
+
+// ShardLease is the liveness heartbeat of a shard.
+type ShardLease struct {
+	// renewTime is the time the shard renewed the lease last.
+	RenewTime v1.Time `json:"renewTime"`
+
+	// leaseDurationSeconds is the duration after renewTime the lease expires unless it is renewed.
+	//
+	// +kubebuilder:validation:Minimum=1
+	LeaseDurationSeconds int32 `json:"leaseDurationSeconds"`
+}
+
+// ShardUsage is the utilization of a shard at its last heartbeat.
+type ShardUsage struct {
+	// logicalClusters is the number of logical clusters on the shard.
+	//
+	// +kubebuilder:validation:Minimum=0
+	LogicalClusters int64 `json:"logicalClusters"`
+
+	// etcdDatabaseSize is the size of the etcd database of the shard. It is not set if the size
+	// could not be determined.
+	//
+	// +optional
+	EtcdDatabaseSize *resource.Quantity `json:"etcdDatabaseSize,omitempty"`
+
+	// requestsPerSecond is the average rate of requests served by the shard since the previous
+	// heartbeat.
+	//
+	// +kubebuilder:validation:Minimum=0
+	RequestsPerSecond int64 `json:"requestsPerSecond"`
+
+	// lastHeartbeatTime is the time the shard reported this usage.
+	LastHeartbeatTime v1.Time `json:"lastHeartbeatTime"`
+}
+
+// ShardList is a list of shard instances
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ShardList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata"`
+
+	Items []Shard `json:"items"`
+}
@@ -25,7 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
-	conditionsv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/apis/conditions/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -164,7 +164,7 @@ func (in *Shard) DeepCopyInto(out *Shard) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -187,6 +187,74 @@ func (in *Shard) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardConfiguration) DeepCopyInto(out *ShardConfiguration) {
+	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardConfiguration.
+func (in *ShardConfiguration) DeepCopy() *ShardConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardControllerOverride) DeepCopyInto(out *ShardControllerOverride) {
+	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardControllerOverride.
+func (in *ShardControllerOverride) DeepCopy() *ShardControllerOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardControllerOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardLease) DeepCopyInto(out *ShardLease) {
+	*out = *in
+	in.RenewTime.DeepCopyInto(&out.RenewTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardLease.
+func (in *ShardLease) DeepCopy() *ShardLease {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardLease)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardList) DeepCopyInto(out *ShardList) {
 	*out = *in
@@ -223,6 +291,18 @@ func (in *ShardList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardSpec) DeepCopyInto(out *ShardSpec) {
 	*out = *in
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]ShardControllerOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(ShardConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -253,6 +333,16 @@ func (in *ShardStatus) DeepCopyInto(out *ShardStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ShardUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lease != nil {
+		in, out := &in.Lease, &out.Lease
+		*out = new(ShardLease)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -265,3 +355,25 @@ func (in *ShardStatus) DeepCopy() *ShardStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardUsage) DeepCopyInto(out *ShardUsage) {
+	*out = *in
+	if in.EtcdDatabaseSize != nil {
+		in, out := &in.EtcdDatabaseSize, &out.EtcdDatabaseSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardUsage.
+func (in *ShardUsage) DeepCopy() *ShardUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardUsage)
+	in.DeepCopyInto(out)
+	return out
+}
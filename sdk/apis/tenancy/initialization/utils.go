@@ -25,8 +25,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation"
 
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 )
 
 func InitializerPresent(initializer corev1alpha1.LogicalClusterInitializer, initializers []corev1alpha1.LogicalClusterInitializer) bool {
@@ -21,7 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
-	"github.com/kcp-dev/kcp/pkg/apis/tenancy"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -47,6 +47,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&WorkspaceType{},
 		&WorkspaceTypeList{},
+		&LimitIncreaseRequest{},
+		&LimitIncreaseRequestList{},
+		&RetentionPolicy{},
+		&RetentionPolicyList{},
+		&WorkspaceQuota{},
+		&WorkspaceQuotaList{},
+		&WorkspaceTemplate{},
+		&WorkspaceTemplateList{},
+		&WorkspaceTombstone{},
+		&WorkspaceTombstoneList{},
+		&WorkspaceUsage{},
+		&WorkspaceUsageList{},
+		&DataJob{},
+		&DataJobList{},
+		&ThrottlingExemption{},
+		&ThrottlingExemptionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
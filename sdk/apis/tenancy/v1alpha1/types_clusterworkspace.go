@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// WorkspaceTypeReference is a globally unique, fully qualified reference to a workspace type.
+type WorkspaceTypeReference struct {
+	// name is the name of the WorkspaceType
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Name WorkspaceTypeName `json:"name"`
+
+	// path is an absolute reference to the workspace that owns this type, e.g. root:org:ws.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Path string `json:"path,omitempty"`
+}
+
+// WorkspaceTypeName is a name of a WorkspaceType
+//
+// +kubebuilder:validation:Pattern=`^[a-z]([a-z0-9-]{0,61}[a-z0-9])?`
+type WorkspaceTypeName string
+
+func (r WorkspaceTypeReference) String() string {
+	if r.Path == "" {
+		return string(r.Name)
+	}
+	return fmt.Sprintf("%s:%s", r.Path, r.Name)
+}
+
+const ExperimentalWorkspaceOwnerAnnotationKey string = "experimental.tenancy.kcp.io/owner"
+
+const (
+	// WorkspaceTrashRetentionAnnotationKey opts a workspace into soft deletion. Its value is a Go duration,
+	// e.g. "72h", during which a deleted workspace is kept in the trash, together with its logical cluster
+	// and all of its content, before it is finally deleted.
+	WorkspaceTrashRetentionAnnotationKey = "tenancy.kcp.io/trash-retention"
+
+	// WorkspaceRestoreAnnotationKey requests the restore of a workspace that is in the trash. It can be
+	// set to any value while the workspace is terminating. The restored workspace keeps its name, type
+	// and logical cluster, but is a new object with a new UID.
+	WorkspaceRestoreAnnotationKey = "tenancy.kcp.io/restore"
+
+	// WorkspaceTrashFinalizer is held by workspaces with a trash retention until the retention has
+	// passed after deletion, or until the workspace is restored.
+	WorkspaceTrashFinalizer = "tenancy.kcp.io/trash"
+
+	// WorkspaceTimeToReadyAnnotationKey holds the duration from the creation of a workspace until it
+	// became ready, e.g. "12s". It is only set if kcp runs with --annotate-time-to-ready.
+	WorkspaceTimeToReadyAnnotationKey = "tenancy.kcp.io/time-to-ready"
+
+	// WorkspaceOwnershipTransferAnnotationKey records the last ownership transfer of a workspace
+	// as JSON with the previous owner, the new owner and the user who transferred it. It is set by
+	// admission when spec.owner changes.
+	WorkspaceOwnershipTransferAnnotationKey = "tenancy.kcp.io/ownership-transfer"
+
+	// WorkspaceDryRunSchedulingAnnotationKey is set by admission on the response of a dry-run create
+	// of a workspace. It holds as JSON the shard the workspace would be scheduled to and the
+	// initializers that would run, or the reason why the workspace is unschedulable. It is never
+	// persisted.
+	WorkspaceDryRunSchedulingAnnotationKey = "tenancy.kcp.io/dry-run-scheduling"
+)
+
+// These are valid conditions of workspace.
+const (
+	// WorkspaceScheduled represents status of the scheduling process for this workspace.
+	WorkspaceScheduled conditionsv1alpha1.ConditionType = "WorkspaceScheduled"
+	// WorkspaceReasonUnschedulable reason in WorkspaceScheduled WorkspaceCondition means that the scheduler
+	// can't schedule the workspace right now, for example due to insufficient resources in the cluster.
+	WorkspaceReasonUnschedulable = "Unschedulable"
+	// WorkspaceReasonShardsAtCapacity reason in WorkspaceScheduled WorkspaceCondition means that all
+	// shards the workspace can be scheduled to are at capacity, or their free capacity is reserved
+	// for waiting workspaces of higher priority.
+	WorkspaceReasonShardsAtCapacity = "ShardsAtCapacity"
+	// WorkspaceReasonReasonUnknown reason in WorkspaceScheduled means that scheduler has failed for
+	// some unexpected reason.
+	WorkspaceReasonReasonUnknown = "Unknown"
+
+	// WorkspaceContentDeleted represents the status that all resources in the workspace are deleted.
+	WorkspaceContentDeleted conditionsv1alpha1.ConditionType = "WorkspaceContentDeleted"
+
+	// WorkspaceInitialized represents the status that initialization has finished.
+	WorkspaceInitialized conditionsv1alpha1.ConditionType = "WorkspaceInitialized"
+	// WorkspaceInitializedInitializerExists reason in WorkspaceInitialized condition means that there is at least
+	// one initializer still left.
+	WorkspaceInitializedInitializerExists = "InitializerExists"
+	// WorkspaceInitializedWorkspaceDisappeared reason in WorkspaceInitialized condition means that the LogicalCluster
+	// object has disappeared.
+	WorkspaceInitializedWorkspaceDisappeared = "WorkspaceDisappeared"
+	// WorkspaceInitializedInitializerTimedOut reason in WorkspaceInitialized condition means that an initializer
+	// with failure policy Retry exceeded its timeout, and is waited for with backoff.
+	WorkspaceInitializedInitializerTimedOut = "InitializerTimedOut"
+	// WorkspaceInitializedInitializerFailed reason in WorkspaceInitialized condition means that an initializer
+	// exceeded its timeout with failure policy Fail, or exhausted its retries. The initialization is stopped.
+	WorkspaceInitializedInitializerFailed = "InitializerFailed"
+
+	// WorkspaceShardDegraded is true when the shard hosting the workspace is degraded, e.g. sheds load
+	// because of memory pressure or etcd latency. The reason and message are taken from the shard. The
+	// condition is removed once the shard recovers.
+	WorkspaceShardDegraded conditionsv1alpha1.ConditionType = "ShardDegraded"
+
+	// WorkspaceShardDraining is true when the shard hosting the workspace is draining, i.e. it is
+	// about to go down for maintenance. The condition is removed once the shard stops draining.
+	WorkspaceShardDraining conditionsv1alpha1.ConditionType = "ShardDraining"
+
+	// WorkspaceArchived is true when the logical cluster of a workspace with spec.state Archived
+	// has been made read-only. It is removed once the workspace is active again.
+	WorkspaceArchived conditionsv1alpha1.ConditionType = "Archived"
+
+	// WorkspaceTrashed is true when a deleted workspace is kept in the trash and can still be restored
+	// through the WorkspaceRestoreAnnotationKey annotation.
+	WorkspaceTrashed conditionsv1alpha1.ConditionType = "Trashed"
+	// WorkspaceInTrashReason reason in the Trashed condition means that the trash retention of the
+	// workspace has not passed yet.
+	WorkspaceInTrashReason = "InTrash"
+
+	// WorkspaceOwnershipTransferred is true when the ownership of a workspace has been transferred
+	// through spec.owner and the new owner has been propagated to the logical cluster, where the
+	// workspace-admin ClusterRoleBinding follows it. The message records the previous owner and who
+	// transferred it.
+	WorkspaceOwnershipTransferred conditionsv1alpha1.ConditionType = "OwnershipTransferred"
+	// WorkspaceTransferredReason reason in the OwnershipTransferred condition means that the
+	// transfer is complete.
+	WorkspaceTransferredReason = "Transferred"
+
+	// WorkspaceExpiring is true when a workspace with spec.ttl is inactive and will expire within
+	// its warning period. It is removed once the workspace is active again.
+	WorkspaceExpiring conditionsv1alpha1.ConditionType = "Expiring"
+	// WorkspaceInactiveReason reason in the Expiring condition means that the workspace has not
+	// been used for almost its ttl.
+	WorkspaceInactiveReason = "Inactive"
+
+	// WorkspaceMountReady is true when the object referenced by spec.mount publishes a URL and is
+	// in phase Ready. Only then the front-proxy forwards requests to the mount.
+	WorkspaceMountReady conditionsv1alpha1.ConditionType = "MountReady"
+	// WorkspaceMountNotFoundReason reason in the MountReady condition means that the object
+	// referenced by spec.mount does not exist.
+	WorkspaceMountNotFoundReason = "MountNotFound"
+	// WorkspaceMountInvalidReason reason in the MountReady condition means that spec.mount or the
+	// status of the referenced object is invalid, e.g. has no URL.
+	WorkspaceMountInvalidReason = "MountInvalid"
+	// WorkspaceMountNotReadyReason reason in the MountReady condition means that the object
+	// referenced by spec.mount is not in phase Ready.
+	WorkspaceMountNotReadyReason = "MountNotReady"
+
+	// WorkspaceAPIBindingsInitialized represents the status of the initial APIBindings for the workspace.
+	WorkspaceAPIBindingsInitialized conditionsv1alpha1.ConditionType = "APIBindingsInitialized"
+	// WorkspaceInitializedWaitingOnAPIBindings is a reason for the APIBindingsInitialized condition that indicates
+	// at least one APIBinding is not ready.
+	WorkspaceInitializedWaitingOnAPIBindings = "WaitingOnAPIBindings"
+	// WorkspaceInitializedWorkspaceTypeInvalid is a reason for the APIBindingsInitialized
+	// condition that indicates something is invalid with the WorkspaceType (e.g. a cycle trying
+	// to resolve all the transitive types).
+	WorkspaceInitializedWorkspaceTypeInvalid = "WorkspaceTypesInvalid"
+	// WorkspaceInitializedAPIBindingErrors is a reason for the APIBindingsInitialized condition that indicates there
+	// were errors trying to initialize APIBindings for the workspace.
+	WorkspaceInitializedAPIBindingErrors = "APIBindingErrors"
+)
@@ -0,0 +1,251 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DataJob rewrites all objects of a resource in the logical clusters of the shard it is stored on,
+// e.g. to re-encrypt them with the current encryption key, to migrate them to the current storage
+// version or to relabel them in bulk. It is the common execution engine of such bulk rewrites: the
+// objects are processed in batches, logical cluster by logical cluster in the order of their names,
+// with a checkpoint recorded in the status after every batch, such that the job resumes where it
+// stopped after restarts of the shard. Writes are throttled by spec.throttle.
+//
+// DataJobs can only be created and changed by kcp admins.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Operation",type="string",JSONPath=".spec.operation",description="The operation applied to the objects"
+// +kubebuilder:printcolumn:name="Resource",type="string",JSONPath=".spec.resource.resource",description="The resource of the objects"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the job"
+// +kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=".status.completedLogicalClusters",description="The number of completed logical clusters"
+// +kubebuilder:printcolumn:name="ETA",type="string",JSONPath=".status.estimatedCompletionTime",description="The estimated completion time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DataJob struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec DataJobSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status DataJobStatus `json:"status,omitempty"`
+}
+
+// DataJobOperation is the operation a DataJob applies to every object.
+type DataJobOperation string
+
+const (
+	// DataJobRewrite writes every object back unchanged. The storage layer persists objects
+	// encrypted with a key other than the current write key or encoded in another storage version
+	// again, and skips all others.
+	DataJobRewrite DataJobOperation = "Rewrite"
+	// DataJobRelabel sets and removes labels of every object as configured in spec.relabel.
+	DataJobRelabel DataJobOperation = "Relabel"
+)
+
+// DataJobSpec describes the objects to process and how.
+type DataJobSpec struct {
+	// operation is the operation applied to every object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Rewrite;Relabel
+	Operation DataJobOperation `json:"operation"`
+
+	// resource is the resource whose objects are processed. Logical clusters not serving the
+	// resource are skipped.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Resource DataJobResource `json:"resource"`
+
+	// relabel configures the Relabel operation.
+	//
+	// +optional
+	Relabel *DataJobRelabel `json:"relabel,omitempty"`
+
+	// logicalClusters restricts the job to the given logical clusters of the shard. By default,
+	// all logical clusters of the shard are processed, including those created while the job runs.
+	//
+	// +optional
+	LogicalClusters []string `json:"logicalClusters,omitempty"`
+
+	// throttle limits the load the job puts on the shard.
+	//
+	// +optional
+	Throttle DataJobThrottle `json:"throttle,omitempty"`
+
+	// suspend stops the job at its last checkpoint until it is unset again.
+	//
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// DataJobResource identifies a resource.
+type DataJobResource struct {
+	// group is the API group of the resource, empty for the core group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is the API version the objects are read and written with.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// resource is the plural name of the resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+}
+
+// DataJobRelabel describes the labels changed by the Relabel operation.
+type DataJobRelabel struct {
+	// set are the labels set on every object, overriding existing values.
+	//
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+
+	// remove are the keys of the labels removed from every object.
+	//
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// DataJobThrottle limits the rate of a DataJob.
+type DataJobThrottle struct {
+	// writesPerSecond is the maximum average number of objects written per second.
+	//
+	// +optional
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=1
+	WritesPerSecond int32 `json:"writesPerSecond,omitempty"`
+
+	// batchSize is the number of objects listed at once. A checkpoint is recorded after every batch.
+	//
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	BatchSize int32 `json:"batchSize,omitempty"`
+}
+
+// DataJobPhase is the phase of a DataJob.
+type DataJobPhase string
+
+const (
+	// DataJobRunning means the job processes objects.
+	DataJobRunning DataJobPhase = "Running"
+	// DataJobSuspended means the job is stopped at its checkpoint because of spec.suspend.
+	DataJobSuspended DataJobPhase = "Suspended"
+	// DataJobSucceeded means all objects have been processed.
+	DataJobSucceeded DataJobPhase = "Succeeded"
+	// DataJobFailed means the job is invalid, or it completed but some objects could not be written.
+	DataJobFailed DataJobPhase = "Failed"
+)
+
+// DataJobStatus communicates the progress of a DataJob.
+type DataJobStatus struct {
+	// phase is the phase of the job.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Running;Suspended;Succeeded;Failed
+	Phase DataJobPhase `json:"phase,omitempty"`
+
+	// checkpoint is the position the job resumes at. All logical clusters with names before the
+	// checkpoint's logical cluster are completed.
+	//
+	// +optional
+	Checkpoint *DataJobCheckpoint `json:"checkpoint,omitempty"`
+
+	// logicalClusters is the number of logical clusters covered by the job.
+	//
+	// +optional
+	LogicalClusters int32 `json:"logicalClusters,omitempty"`
+
+	// completedLogicalClusters is the number of logical clusters whose objects have all been processed.
+	//
+	// +optional
+	CompletedLogicalClusters int32 `json:"completedLogicalClusters,omitempty"`
+
+	// processedObjects is the number of objects processed so far.
+	//
+	// +optional
+	ProcessedObjects int64 `json:"processedObjects,omitempty"`
+
+	// failedObjects is the number of objects that could not be written.
+	//
+	// +optional
+	FailedObjects int64 `json:"failedObjects,omitempty"`
+
+	// message describes why the job failed, or the last object that could not be written.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// startTime is the time the job started processing objects.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// estimatedCompletionTime is extrapolated from the share of completed logical clusters.
+	//
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// completionTime is the time the job succeeded or failed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// DataJobCheckpoint is a position within the objects of a DataJob.
+type DataJobCheckpoint struct {
+	// logicalCluster is the logical cluster being processed.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	LogicalCluster string `json:"logicalCluster"`
+
+	// continue is the continue token of the next batch of objects in the logical cluster, empty to
+	// start with its first batch.
+	//
+	// +optional
+	Continue string `json:"continue,omitempty"`
+}
+
+// DataJobList is a list of data jobs.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DataJob `json:"items"`
+}
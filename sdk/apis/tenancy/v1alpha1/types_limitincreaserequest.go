@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// LimitIncreaseRequest is a request of a tenant to increase the hard limits of a ResourceQuota
+// in their workspace. It is decided by the admins of the parent workspace: setting spec.decision
+// requires the "approve" verb on limitincreaserequests in the parent workspace, for the name of
+// the workspace. Approved requests are applied to the ResourceQuota by a controller.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Quota",type="string",JSONPath=".spec.resourceQuota.name",description="The ResourceQuota whose limits are to be increased"
+// +kubebuilder:printcolumn:name="Decision",type="string",JSONPath=".spec.decision",description="The decision of the parent workspace admins"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type LimitIncreaseRequest struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Spec LimitIncreaseRequestSpec `json:"spec"`
+
+	// +optional
+	Status LimitIncreaseRequestStatus `json:"status,omitempty"`
+}
+
+// LimitIncreaseRequestSpec holds the requested limits and the decision about them.
+//
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.decision) || self == oldSelf",message="spec is immutable once decided"
+type LimitIncreaseRequestSpec struct {
+	// resourceQuota references the ResourceQuota in this workspace whose hard limits are to be increased.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	ResourceQuota LimitIncreaseRequestQuotaReference `json:"resourceQuota"`
+
+	// hard is the requested set of hard limits. Limits of the ResourceQuota that are not
+	// listed are left unchanged.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	Hard corev1.ResourceList `json:"hard"`
+
+	// justification explains to the admins of the parent workspace why the limits are needed.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Justification string `json:"justification"`
+
+	// decision is set by the admins of the parent workspace. It cannot be changed once set.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Approved;Denied
+	Decision LimitIncreaseRequestDecision `json:"decision,omitempty"`
+}
+
+// LimitIncreaseRequestQuotaReference identifies a ResourceQuota.
+type LimitIncreaseRequestQuotaReference struct {
+	// namespace is the namespace of the ResourceQuota.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// name is the name of the ResourceQuota.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// LimitIncreaseRequestDecision is the decision about a LimitIncreaseRequest.
+type LimitIncreaseRequestDecision string
+
+const (
+	// LimitIncreaseRequestApproved means the requested limits are applied.
+	LimitIncreaseRequestApproved LimitIncreaseRequestDecision = "Approved"
+	// LimitIncreaseRequestDenied means the requested limits are not applied.
+	LimitIncreaseRequestDenied LimitIncreaseRequestDecision = "Denied"
+)
+
+// LimitIncreaseRequestPhase is the phase of a LimitIncreaseRequest.
+//
+// +kubebuilder:validation:Enum=Pending;Applied;Denied
+type LimitIncreaseRequestPhase string
+
+const (
+	// LimitIncreaseRequestPhasePending means the request waits for a decision, or for being applied.
+	LimitIncreaseRequestPhasePending LimitIncreaseRequestPhase = "Pending"
+	// LimitIncreaseRequestPhaseApplied means the requested limits have been applied to the ResourceQuota.
+	LimitIncreaseRequestPhaseApplied LimitIncreaseRequestPhase = "Applied"
+	// LimitIncreaseRequestPhaseDenied means the request has been denied.
+	LimitIncreaseRequestPhaseDenied LimitIncreaseRequestPhase = "Denied"
+)
+
+// LimitIncreaseRequestStatus communicates the observed state of the LimitIncreaseRequest.
+type LimitIncreaseRequestStatus struct {
+	// phase is the current phase of the request.
+	//
+	// +optional
+	Phase LimitIncreaseRequestPhase `json:"phase,omitempty"`
+
+	// appliedTime is the time the requested limits were applied to the ResourceQuota.
+	//
+	// +optional
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
+
+	// conditions is a list of conditions that apply to the LimitIncreaseRequest.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+func (in *LimitIncreaseRequest) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *LimitIncreaseRequest) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// These are valid conditions of LimitIncreaseRequest.
+const (
+	// LimitIncreaseRequestApplied represents whether the requested limits have been applied to the ResourceQuota.
+	LimitIncreaseRequestApplied conditionsv1alpha1.ConditionType = "Applied"
+
+	// LimitIncreaseRequestWaitingForDecisionReason is a reason for the Applied condition that the request
+	// has not been decided yet.
+	LimitIncreaseRequestWaitingForDecisionReason = "WaitingForDecision"
+	// LimitIncreaseRequestDeniedReason is a reason for the Applied condition that the request has been denied.
+	LimitIncreaseRequestDeniedReason = "Denied"
+	// LimitIncreaseRequestQuotaNotFoundReason is a reason for the Applied condition that the referenced
+	// ResourceQuota does not exist.
+	LimitIncreaseRequestQuotaNotFoundReason = "QuotaNotFound"
+	// LimitIncreaseRequestUpdateFailedReason is a reason for the Applied condition that the ResourceQuota
+	// could not be updated.
+	LimitIncreaseRequestUpdateFailedReason = "UpdateFailed"
+)
+
+// LimitIncreaseRequestList is a list of limit increase requests.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type LimitIncreaseRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []LimitIncreaseRequest `json:"items"`
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// RetentionPolicy defines when objects of a resource in this workspace are deleted, either
+// because they exceed a maximum age, or because only a number of most recent objects is kept.
+// Policies are executed periodically by a janitor controller, with the permissions of the user
+// who last changed the policy.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Resource",type="string",JSONPath=".spec.resource.resource",description="The resource the policy applies to"
+// +kubebuilder:printcolumn:name="DryRun",type="boolean",JSONPath=".spec.dryRun",description="Whether objects are only reported instead of deleted"
+// +kubebuilder:printcolumn:name="Last Run",type="date",JSONPath=".status.lastRunTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RetentionPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Spec RetentionPolicySpec `json:"spec"`
+
+	// +optional
+	Status RetentionPolicyStatus `json:"status,omitempty"`
+}
+
+// RetentionPolicyOwnerAnnotationKey is the annotation key recording the user who last changed a
+// RetentionPolicy, as JSON-encoded authentication.k8s.io/v1 UserInfo. It is set by admission. The
+// janitor only lists and deletes objects this user is allowed to list and delete.
+const RetentionPolicyOwnerAnnotationKey = "tenancy.kcp.io/retention-policy-owner"
+
+// RetentionPolicySpec defines which objects are subject to the policy and when they are deleted.
+// If both maxAge and keepLatest are set, objects are deleted when either of them applies.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.maxAge) || has(self.keepLatest)",message="at least one of maxAge or keepLatest must be set"
+type RetentionPolicySpec struct {
+	// resource is the resource whose objects are subject to this policy.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Resource RetentionPolicyResource `json:"resource"`
+
+	// selector restricts the policy to objects matching the label selector.
+	// If unset, all objects of the resource are subject to the policy.
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// maxAge is the age, measured from creation, after which objects are deleted.
+	//
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// keepLatest is the number of most recently created objects that are kept per
+	// namespace. All older objects are deleted.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KeepLatest *int32 `json:"keepLatest,omitempty"`
+
+	// dryRun prevents objects from being deleted if set. Instead, the objects that
+	// would have been deleted are reported in status.dryRunCandidates.
+	//
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// RetentionPolicyResource identifies a resource by group, version and resource name.
+type RetentionPolicyResource struct {
+	// group is the API group of the resource. Empty string for the core API group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is the API version used to list and delete objects.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// resource is the lower-case plural name of the resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+}
+
+// RetentionPolicyStatus communicates the observed state of the RetentionPolicy.
+type RetentionPolicyStatus struct {
+	// lastRunTime is the time the policy was last executed.
+	//
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// lastDeletedCount is the number of objects deleted during the last run, or in
+	// dry-run mode the number of objects that would have been deleted.
+	//
+	// +optional
+	LastDeletedCount int32 `json:"lastDeletedCount,omitempty"`
+
+	// dryRunCandidates lists the objects, in the format namespace/name or name for
+	// cluster-scoped objects, that would have been deleted during the last run in
+	// dry-run mode. The list is truncated to 100 entries.
+	//
+	// +optional
+	DryRunCandidates []string `json:"dryRunCandidates,omitempty"`
+
+	// conditions is a list of conditions that apply to the RetentionPolicy.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+}
+
+func (in *RetentionPolicy) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *RetentionPolicy) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// These are valid conditions of RetentionPolicy.
+const (
+	// RetentionPolicyApplied represents status of the last execution of the policy.
+	RetentionPolicyApplied conditionsv1alpha1.ConditionType = "Applied"
+
+	// RetentionPolicyInvalidReason is a reason for the Applied condition that the policy spec is invalid.
+	RetentionPolicyInvalidReason = "Invalid"
+	// RetentionPolicyListFailedReason is a reason for the Applied condition that the objects of the resource
+	// could not be listed, e.g. because the resource does not exist in the workspace.
+	RetentionPolicyListFailedReason = "ListFailed"
+	// RetentionPolicyDeletionFailedReason is a reason for the Applied condition that some of the expired
+	// objects could not be deleted.
+	RetentionPolicyDeletionFailedReason = "DeletionFailed"
+	// RetentionPolicyForbiddenReason is a reason for the Applied condition that the user who last changed
+	// the policy is not allowed to list the objects of the resource, or to delete some of the expired objects.
+	RetentionPolicyForbiddenReason = "Forbidden"
+)
+
+// RetentionPolicyList is a list of retention policies.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RetentionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RetentionPolicy `json:"items"`
+}
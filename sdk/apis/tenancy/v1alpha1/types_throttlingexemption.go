@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ThrottlingExemption exempts the workspaces of the referenced WorkspaceTypes from the
+// per-workspace limits, i.e. from WorkspaceQuotas and ResourceQuotas. It is meant for system
+// automation tenants, e.g. the workspaces of platform-internal controllers, which must not be
+// throttled like tenants.
+//
+// ThrottlingExemptions are only honored in the root workspace, and only if they carry a valid
+// signature in the tenancy.kcp.io/throttling-exemption-signature annotation. The signature is
+// set on creation and update by the shard serving the root workspace, using the key of
+// --throttling-exemption-signing-key-file, which must be the same on all shards. Replicas
+// forged in the cache server are hence ignored.
+//
+// Note that anybody allowed to use an exempt WorkspaceType can create exempt workspaces.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ThrottlingExemption struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ThrottlingExemptionSpec `json:"spec,omitempty"`
+}
+
+// ThrottlingExemptionSignatureAnnotationKey is the annotation holding the signature of a
+// ThrottlingExemption. It is set by admission, user-provided values are overwritten.
+const ThrottlingExemptionSignatureAnnotationKey = "tenancy.kcp.io/throttling-exemption-signature"
+
+// ThrottlingExemptionSpec holds the exempt WorkspaceTypes.
+type ThrottlingExemptionSpec struct {
+	// workspaceTypes are the WorkspaceTypes whose workspaces are exempt. Only workspaces of
+	// exactly these types are exempt, not those of types extending them. An empty path refers
+	// to the root workspace.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	WorkspaceTypes []WorkspaceTypeReference `json:"workspaceTypes"`
+}
+
+// ThrottlingExemptionList is a list of throttling exemptions.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ThrottlingExemptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ThrottlingExemption `json:"items"`
+}
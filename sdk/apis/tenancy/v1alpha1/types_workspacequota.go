@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// WorkspaceQuota limits the number of child workspaces, APIBindings and total objects in the
+// logical cluster it lives in. Creations exceeding any of the hard limits are rejected by admission.
+// The spec is owned by the admins of the parent workspace: changing it requires the "manage" verb
+// on workspacequotas in the parent workspace, for the name of the workspace.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WorkspaceQuota struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Spec WorkspaceQuotaSpec `json:"spec"`
+
+	// +optional
+	Status WorkspaceQuotaStatus `json:"status,omitempty"`
+}
+
+// These are the resources limited by a WorkspaceQuota.
+const (
+	// WorkspaceQuotaWorkspaces is the number of child workspaces.
+	WorkspaceQuotaWorkspaces corev1.ResourceName = "workspaces"
+	// WorkspaceQuotaAPIBindings is the number of APIBindings.
+	WorkspaceQuotaAPIBindings corev1.ResourceName = "apibindings"
+	// WorkspaceQuotaObjects is the total number of objects of all resources, excluding events.
+	WorkspaceQuotaObjects corev1.ResourceName = "objects"
+)
+
+// WorkspaceQuotaSpec holds the hard limits of a WorkspaceQuota.
+type WorkspaceQuotaSpec struct {
+	// hard is the set of hard limits, keyed by "workspaces", "apibindings" or "objects".
+	// Resources that are not listed are not limited.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['workspaces', 'apibindings', 'objects'])",message="only workspaces, apibindings and objects can be limited"
+	Hard corev1.ResourceList `json:"hard"`
+}
+
+// WorkspaceQuotaStatus communicates the observed usage of the logical cluster.
+type WorkspaceQuotaStatus struct {
+	// used is the current usage of the resources listed in spec.hard.
+	//
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+
+	// conditions is a list of conditions that apply to the WorkspaceQuota.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// thresholds are the highest notification thresholds the usage of the resources has
+	// reached. The tenant is notified when the usage reaches a higher threshold, and again
+	// after it has dropped below a threshold and reaches it another time.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=resource
+	Thresholds []WorkspaceQuotaThreshold `json:"thresholds,omitempty"`
+}
+
+// WorkspaceQuotaThreshold is a notification threshold reached by the usage of a resource.
+type WorkspaceQuotaThreshold struct {
+	// resource is the name of the resource in spec.hard.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Resource corev1.ResourceName `json:"resource"`
+
+	// percent is the threshold in percent of the hard limit.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Percent int32 `json:"percent"`
+}
+
+func (in *WorkspaceQuota) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *WorkspaceQuota) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+const (
+	// WorkspaceQuotaNotificationThresholdsAnnotationKey configures the thresholds, in percent of the
+	// hard limits, at which the tenant is notified about the usage of a WorkspaceQuota, as a comma
+	// separated list, e.g. "50,100". It defaults to DefaultWorkspaceQuotaNotificationThresholds. The
+	// annotation is owned by the tenant, i.e. it does not require the "manage" verb.
+	WorkspaceQuotaNotificationThresholdsAnnotationKey = "tenancy.kcp.io/quota-notification-thresholds"
+
+	// WorkspaceQuotaNotificationWebhookAnnotationKey configures an https URL which is called with a
+	// JSON POST request when the usage of a WorkspaceQuota reaches a notification threshold, in
+	// addition to the event created in the workspace. Webhooks are only called if enabled for the
+	// kcp server. The annotation is owned by the tenant, i.e. it does not require the "manage" verb.
+	WorkspaceQuotaNotificationWebhookAnnotationKey = "tenancy.kcp.io/quota-notification-webhook"
+)
+
+// DefaultWorkspaceQuotaNotificationThresholds are the notification thresholds of WorkspaceQuotas
+// without the WorkspaceQuotaNotificationThresholdsAnnotationKey annotation.
+var DefaultWorkspaceQuotaNotificationThresholds = []int32{80, 90, 100}
+
+// These are valid conditions of WorkspaceQuota.
+const (
+	// WorkspaceQuotaWithinLimits represents whether the usage is within the hard limits. Usage can
+	// exceed the limits when they are lowered below the current usage.
+	WorkspaceQuotaWithinLimits conditionsv1alpha1.ConditionType = "WithinLimits"
+
+	// WorkspaceQuotaExceededReason is a reason for the WithinLimits condition that at least one
+	// resource is used beyond its hard limit.
+	WorkspaceQuotaExceededReason = "Exceeded"
+)
+
+// WorkspaceQuotaList is a list of workspace quotas.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceQuota `json:"items"`
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// WorkspaceTemplate declares a set of objects that are created inside of new workspaces
+// during initialization. It is referenced by WorkspaceTypes through spec.template, and
+// applied to every workspace of such a type (or of a type extending it) before the
+// workspace becomes ready.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WorkspaceTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkspaceTemplateSpec `json:"spec,omitempty"`
+}
+
+// WorkspaceTemplateSpec holds the objects of a WorkspaceTemplate.
+type WorkspaceTemplateSpec struct {
+	// objects are the objects to create in new workspaces, e.g. APIBindings, namespaces,
+	// RBAC or configmaps. They are created in the given order, hence namespaces must be
+	// listed before the objects living in them. Objects that already exist are left untouched.
+	//
+	// Every object must have apiVersion, kind and metadata.name set.
+	//
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +listType=atomic
+	Objects []runtime.RawExtension `json:"objects,omitempty"`
+}
+
+// WorkspaceTemplateReference is a reference to a WorkspaceTemplate.
+type WorkspaceTemplateReference struct {
+	// path is an absolute reference to the workspace that owns the WorkspaceTemplate,
+	// e.g. root:org. If it is empty, the workspace of the referencing WorkspaceType is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Path string `json:"path,omitempty"`
+
+	// name is the name of the WorkspaceTemplate.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// WorkspaceTemplateInitializer is a special-case initializer that creates the objects of the
+// WorkspaceTemplates referenced by the WorkspaceType of a workspace.
+const WorkspaceTemplateInitializer corev1alpha1.LogicalClusterInitializer = "system:template"
+
+// These are valid conditions of LogicalClusters initialized from WorkspaceTemplates.
+const (
+	// WorkspaceTemplateApplied represents the status of creating the objects of the
+	// WorkspaceTemplates in the workspace.
+	WorkspaceTemplateApplied conditionsv1alpha1.ConditionType = "TemplateApplied"
+
+	// WorkspaceTemplateNotFoundReason is a reason for the TemplateApplied condition that a
+	// referenced WorkspaceTemplate does not exist (yet).
+	WorkspaceTemplateNotFoundReason = "TemplateNotFound"
+	// WorkspaceTemplateInvalidObjectReason is a reason for the TemplateApplied condition that an
+	// object of a WorkspaceTemplate cannot be decoded or its resource is not served in the workspace.
+	WorkspaceTemplateInvalidObjectReason = "InvalidObject"
+	// WorkspaceTemplateCreateErrorReason is a reason for the TemplateApplied condition that
+	// creating objects failed.
+	WorkspaceTemplateCreateErrorReason = "CreateError"
+)
+
+// WorkspaceTemplateList is a list of workspace templates.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceTemplate `json:"items"`
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceTombstone reserves the name of a deleted workspace in its parent workspace. While
+// it exists, no workspace of the same name, and hence with the same URL, can be created, such
+// that clients and references to the deleted workspace cannot be taken over by a new one.
+//
+// WorkspaceTombstones are created by kcp when a workspace is finally deleted, i.e. not while
+// it is in the trash, if --workspace-tombstone-duration is positive. They are named like the
+// deleted workspace and removed when they expire. Deleting a WorkspaceTombstone purges it early
+// and frees the name. Creation and updates are reserved to kcp.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster",description="The logical cluster of the deleted workspace"
+// +kubebuilder:printcolumn:name="Expires",type="string",JSONPath=".spec.expiresAt",description="Time the name can be reused"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WorkspaceTombstone struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkspaceTombstoneSpec `json:"spec,omitempty"`
+}
+
+// WorkspaceTombstoneSpec describes the deleted workspace and the end of the reservation.
+type WorkspaceTombstoneSpec struct {
+	// cluster is the logical cluster the deleted workspace pointed to.
+	//
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// uid is the UID of the deleted workspace.
+	//
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// expiresAt is the time after which the name can be reused and the tombstone is removed.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// WorkspaceTombstoneList is a list of workspace tombstones.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceTombstoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceTombstone `json:"items"`
+}
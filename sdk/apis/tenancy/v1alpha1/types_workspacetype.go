@@ -0,0 +1,605 @@
+/*
+Copyright 2021 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// WorkspaceTypeReservedNames defines the set of names that may not be
+// used on user-supplied WorkspaceTypes.
+// TODO(hasheddan): tie this definition of reserved names to the patches used to
+// apply the same restrictions to the OpenAPISchema.
+func WorkspaceTypeReservedNames() []string {
+	return []string{
+		"any",
+		"system",
+	}
+}
+
+// WorkspaceType specifies behaviour of workspaces of this type.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+type WorkspaceType struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkspaceTypeSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status WorkspaceTypeStatus `json:"status,omitempty"`
+}
+
+type WorkspaceTypeSpec struct {
+	// initializer determines if this WorkspaceType has an associated initializing
+	// controller. These controllers are used to add functionality to a ClusterWorkspace;
+	// all controllers must finish their work before the ClusterWorkspace becomes ready
+	// for use.
+	//
+	// One initializing controller is supported per WorkspaceType; the identifier
+	// for this initializer will be a colon-delimited string using the workspace in which
+	// the WorkspaceType is defined, and the type's name. For example, if a
+	// WorkspaceType `example` is created in the `root:org` workspace, the implicit
+	// initializer name is `root:org:Example`.
+	//
+	// +optional
+	Initializer bool `json:"initializer,omitempty"`
+
+	// extend is a list of other WorkspaceTypes whose initializers and limitAllowedChildren
+	// and limitAllowedParents this WorkspaceType is inheriting. By (transitively) extending
+	// another WorkspaceType, this WorkspaceType will be considered as that
+	// other type in evaluation of limitAllowedChildren and limitAllowedParents constraints.
+	//
+	// A dependency cycle stop this WorkspaceType from being admitted as the type
+	// of a ClusterWorkspace.
+	//
+	// A non-existing dependency stop this WorkspaceType from being admitted as the type
+	// of a ClusterWorkspace.
+	//
+	// +optional
+	Extend WorkspaceTypeExtension `json:"extend,omitempty"`
+
+	// additionalWorkspaceLabels are a set of labels that will be added to a
+	// ClusterWorkspace on creation.
+	//
+	// +optional
+	AdditionalWorkspaceLabels map[string]string `json:"additionalWorkspaceLabels,omitempty"`
+
+	// defaultChildWorkspaceType is the WorkspaceType that will be used
+	// by default if another, nested ClusterWorkspace is created in a workspace
+	// of this type. When this field is unset, the user must specify a type when
+	// creating nested workspaces. Extending another WorkspaceType does
+	// not inherit its defaultChildWorkspaceType.
+	//
+	// +optional
+	DefaultChildWorkspaceType *WorkspaceTypeReference `json:"defaultChildWorkspaceType,omitempty"`
+
+	// limitAllowedChildren specifies constraints for sub-workspaces created in workspaces
+	// of this type. These are in addition to child constraints of types this one extends.
+	//
+	// +optional
+	LimitAllowedChildren *WorkspaceTypeSelector `json:"limitAllowedChildren,omitempty"`
+
+	// limitAllowedParents specifies constraints for the parent workspace that workspaces
+	// of this type are created in. These are in addition to parent constraints of types this one
+	// extends.
+	//
+	// +optional
+	LimitAllowedParents *WorkspaceTypeSelector `json:"limitAllowedParents,omitempty"`
+
+	// defaultAPIBindings are the APIs to bind during initialization of workspaces created from this type.
+	// The APIBinding names will be generated dynamically.
+	//
+	// +optional
+	DefaultAPIBindings []DefaultAPIBinding `json:"defaultAPIBindings,omitempty"`
+
+	// acceptedPermissionClaimPolicies are permission claims of APIExports that are accepted
+	// automatically when an APIBinding to the APIExport is created in workspaces of this type.
+	// Claims the APIBinding already accepts or rejects are left untouched.
+	//
+	// +optional
+	AcceptedPermissionClaimPolicies []AcceptedPermissionClaimPolicy `json:"acceptedPermissionClaimPolicies,omitempty"`
+
+	// shardSelector restricts the shards workspaces of this type are scheduled to, by the
+	// labels of the shards. It is combined with the shard selectors of the types this one
+	// extends and with spec.location.selector of the workspace: a shard must match all of them.
+	//
+	// +optional
+	ShardSelector *metav1.LabelSelector `json:"shardSelector,omitempty"`
+
+	// template references a WorkspaceTemplate whose objects are created in workspaces
+	// of this type during initialization. Templates of the types this one extends are
+	// applied first.
+	//
+	// +optional
+	Template *WorkspaceTemplateReference `json:"template,omitempty"`
+
+	// initializerPolicies bound the time the initializers of workspaces of this type may take,
+	// including the initializers inherited from the types this one extends. Initializers without
+	// policy block the initialization of the workspace until they are done.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=initializer
+	InitializerPolicies []InitializerPolicy `json:"initializerPolicies,omitempty"`
+
+	// priority is the default and maximal priority of workspaces of this type in scheduling
+	// when shards are at capacity. Workspaces with higher priority are scheduled first.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority,omitempty"`
+
+	// namingPolicy restricts the names of workspaces of this type. The naming policies of the
+	// types this one extends apply as well.
+	//
+	// +optional
+	NamingPolicy *WorkspaceNamingPolicy `json:"namingPolicy,omitempty"`
+
+	// rollout stages changes of defaultAPIBindings, i.e. the APIBindings created by initialization
+	// and the releases existing APIBindings are upgraded to, in waves of workspaces. Without rollout,
+	// changes apply to all workspaces of this type at once.
+	//
+	// +optional
+	Rollout *WorkspaceTypeRolloutPolicy `json:"rollout,omitempty"`
+}
+
+// WorkspaceTypeRolloutPolicy configures the waves in which changes of a WorkspaceType reach its workspaces.
+type WorkspaceTypeRolloutPolicy struct {
+	// waves are started one after the other, every waveInterval. Workspaces in a started wave get the
+	// new revision of the WorkspaceType, all others keep the stable revision. Once the last wave has
+	// run for waveInterval, the rollout completes and the new revision becomes the stable one for all
+	// workspaces.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Waves []WorkspaceTypeRolloutWave `json:"waves"`
+
+	// waveInterval is the time between the start of two waves.
+	//
+	// +optional
+	// +kubebuilder:default="1h"
+	WaveInterval metav1.Duration `json:"waveInterval,omitempty"`
+
+	// paused stops the rollout from starting further waves. Workspaces in started waves keep the new
+	// revision.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// WorkspaceTypeRolloutWave selects the workspaces updated in a wave, by percentage or by label.
+// A workspace is in the wave if it matches either.
+type WorkspaceTypeRolloutWave struct {
+	// percentage is the share of the workspaces of this type updated once this wave has started,
+	// including the ones of earlier waves. Workspaces are picked by a hash of their logical cluster
+	// name, such that the same workspaces come first in every rollout.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage,omitempty"`
+
+	// selector selects the workspaces updated in this wave by the labels of their LogicalCluster,
+	// e.g. canary workspaces.
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// includes returns whether the workspace is in one of the first started waves.
+func (p *WorkspaceTypeRolloutPolicy) includes(started int32, clusterName string, lbls map[string]string) bool {
+	h := fnv.New32a()
+	h.Write([]byte(clusterName)) //nolint:errcheck
+	bucket := int32(h.Sum32() % 100)
+
+	for i := 0; i < int(started) && i < len(p.Waves); i++ {
+		wave := p.Waves[i]
+		if bucket < wave.Percentage {
+			return true
+		}
+		if wave.Selector == nil {
+			continue
+		}
+		if selector, err := metav1.LabelSelectorAsSelector(wave.Selector); err == nil && selector.Matches(labels.Set(lbls)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkspaceNamingPolicy restricts the names of workspaces. A name must satisfy all rules that are set.
+type WorkspaceNamingPolicy struct {
+	// pattern is a regular expression in RE2 syntax the whole name must match, e.g. "^[a-z]+-[0-9]+$".
+	//
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// prefixes are the prefixes one of which the name must start with, e.g. the team prefixes
+	// of an organization.
+	//
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// deniedWords are words the name must not contain, compared case-insensitively.
+	//
+	// +optional
+	DeniedWords []string `json:"deniedWords,omitempty"`
+
+	// uniquenessScope is the scope in which the name must be unique. With "Parent", names
+	// are unique among the sibling workspaces only, like the names of all objects. With "Type",
+	// the name must also be unique among all workspaces of the same type. The latter is checked
+	// against the workspaces known to the shard of the parent workspace.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Parent;Type
+	// +kubebuilder:default=Parent
+	UniquenessScope WorkspaceNameUniquenessScope `json:"uniquenessScope,omitempty"`
+}
+
+// WorkspaceNameUniquenessScope is the scope in which workspace names must be unique.
+type WorkspaceNameUniquenessScope string
+
+const (
+	// WorkspaceNameUniquenessScopeParent requires names to be unique among sibling workspaces.
+	WorkspaceNameUniquenessScopeParent WorkspaceNameUniquenessScope = "Parent"
+	// WorkspaceNameUniquenessScopeType requires names to be unique among workspaces of the same type.
+	WorkspaceNameUniquenessScopeType WorkspaceNameUniquenessScope = "Type"
+)
+
+// InitializerPolicy configures the timeout and the failure policy of an initializer.
+type InitializerPolicy struct {
+	// initializer is the name of the initializer, e.g. "system:apibindings" or "root:org:example".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Initializer corev1alpha1.LogicalClusterInitializer `json:"initializer"`
+
+	// timeout is the time the initializer may take, counted from the creation of the logical
+	// cluster of the workspace.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Timeout metav1.Duration `json:"timeout"`
+
+	// failurePolicy determines what happens when the initializer times out. With "Fail", the
+	// workspace stays in phase Initializing with the WorkspaceInitialized condition marking the
+	// initializer as failed. With "Ignore", the initializer is removed and the initialization
+	// continues without it. With "Retry", the initializer gets maxRetries more attempts, each
+	// with twice the timeout of the previous one, before it fails.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Fail;Ignore;Retry
+	// +kubebuilder:default=Fail
+	FailurePolicy InitializerFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// maxRetries is the number of additional attempts with failurePolicy "Retry".
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+}
+
+// InitializerFailurePolicy determines what happens when an initializer times out.
+type InitializerFailurePolicy string
+
+const (
+	// InitializerFailurePolicyFail stops the initialization of the workspace.
+	InitializerFailurePolicyFail InitializerFailurePolicy = "Fail"
+	// InitializerFailurePolicyIgnore removes the initializer and continues the initialization.
+	InitializerFailurePolicyIgnore InitializerFailurePolicy = "Ignore"
+	// InitializerFailurePolicyRetry waits for the initializer with exponential backoff before failing.
+	InitializerFailurePolicyRetry InitializerFailurePolicy = "Retry"
+)
+
+// DefaultInitializerMaxRetries is the number of additional attempts of initializers with failure policy
+// Retry and unset maxRetries.
+const DefaultInitializerMaxRetries = 3
+
+// DefaultAPIBinding is an APIExport bound during initialization of workspaces of a type.
+type DefaultAPIBinding struct {
+	APIExportReference `json:",inline"`
+
+	// releaseConstraint restricts the releases of the APIExport to bind, as a space-separated list
+	// of comparisons with semantic versions that all have to hold, e.g. ">=v1.2.0 <v2.0.0". The
+	// operators =, >, >=, < and <= are supported, a version without operator means =. The newest
+	// matching release is bound and recorded in spec.release of the APIBinding.
+	//
+	// If unset, the APIBinding binds the latestResourceSchemas of the APIExport.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(=|>|>=|<|<=)?v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?( +(=|>|>=|<|<=)?v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?)*$`
+	ReleaseConstraint string `json:"releaseConstraint,omitempty"`
+
+	// upgradePolicy determines whether existing APIBindings move on to newer releases of the
+	// APIExport matching releaseConstraint. With "Pinned", the release bound during initialization
+	// is kept. With "Automatic", the APIBindings of all workspaces of this type are bumped to the
+	// newest matching release when the APIExport publishes one or releaseConstraint is changed.
+	// APIBindings are never moved to an older release.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Pinned;Automatic
+	UpgradePolicy DefaultAPIBindingUpgradePolicy `json:"upgradePolicy,omitempty"`
+}
+
+// DefaultAPIBindingUpgradePolicy determines how APIBindings created for a default APIBinding are upgraded.
+type DefaultAPIBindingUpgradePolicy string
+
+const (
+	// DefaultAPIBindingUpgradePinned keeps the release bound during initialization. This is the default.
+	DefaultAPIBindingUpgradePinned DefaultAPIBindingUpgradePolicy = "Pinned"
+	// DefaultAPIBindingUpgradeAutomatic bumps the APIBindings to the newest release matching the constraint.
+	DefaultAPIBindingUpgradeAutomatic DefaultAPIBindingUpgradePolicy = "Automatic"
+)
+
+// AcceptedPermissionClaimPolicy declares the permission claims of an APIExport that are accepted automatically.
+type AcceptedPermissionClaimPolicy struct {
+	// export references the APIExport whose permission claims are accepted.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Export APIExportReference `json:"export"`
+
+	// claims are the claimed resources whose permission claims are accepted. Claims of the
+	// APIExport not listed here have to be accepted by the user.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Claims []ClaimedResource `json:"claims"`
+}
+
+// ClaimedResource identifies the resource of a permission claim.
+type ClaimedResource struct {
+	// group is the API group of the claimed resource. It is empty for the core group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// resource is the name of the claimed resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+
+	// identityHash is the identity of the APIExport providing the claimed resource. It is
+	// empty for built-in resources.
+	//
+	// +optional
+	IdentityHash string `json:"identityHash,omitempty"`
+}
+
+// APIExportReference provides the fields necessary to resolve an APIExport.
+type APIExportReference struct {
+	// path is the fully-qualified path to the workspace containing the APIExport. If it is
+	// empty, the current workspace is assumed.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(:[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Path string `json:"path,omitempty"`
+
+	// export is the name of the APIExport.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kube:validation:MinLength=1
+	Export string `json:"export"`
+}
+
+// WorkspaceTypeSelector describes a set of types.
+type WorkspaceTypeSelector struct {
+	// none means that no type matches.
+	//
+	// +kuberbuilders:Enum=true
+	None bool `json:"none,omitempty"`
+
+	// types is a list of WorkspaceTypes that match. A workspace type extending
+	// another workspace type automatically is considered as that extended type as well
+	// (even transitively).
+	//
+	// An empty list matches all types.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	Types []WorkspaceTypeReference `json:"types,omitempty"`
+}
+
+// WorkspaceTypeExtension defines how other WorkspaceTypes are
+// composed together to add functionality to the owning WorkspaceType.
+type WorkspaceTypeExtension struct {
+	// with are WorkspaceTypes whose initializers are added to the list
+	// for the owning type, and for whom the owning type becomes an alias, as long
+	// as all of their required types are not mentioned in without.
+	//
+	// +optional
+	With []WorkspaceTypeReference `json:"with,omitempty"`
+}
+
+// These are valid conditions of WorkspaceType.
+const (
+	WorkspaceTypeVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
+	ErrorGeneratingURLsReason                                               = "ErrorGeneratingURLs"
+)
+
+// WorkspaceTypeStatus defines the observed state of WorkspaceType.
+type WorkspaceTypeStatus struct {
+	// conditions is a list of conditions that apply to the APIExport.
+	//
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// virtualWorkspaces contains all APIExport virtual workspace URLs.
+	// +optional
+	VirtualWorkspaces []VirtualWorkspace `json:"virtualWorkspaces,omitempty"`
+
+	// rollout is the state of the staged rollout configured in spec.rollout.
+	//
+	// +optional
+	Rollout *WorkspaceTypeRolloutStatus `json:"rollout,omitempty"`
+}
+
+// WorkspaceTypeRolloutPhase is the phase of the rollout of a WorkspaceType revision.
+type WorkspaceTypeRolloutPhase string
+
+const (
+	// WorkspaceTypeRolloutProgressing means waves of the revision are being started.
+	WorkspaceTypeRolloutProgressing WorkspaceTypeRolloutPhase = "Progressing"
+	// WorkspaceTypeRolloutPaused means no further waves are started until spec.rollout.paused is unset.
+	WorkspaceTypeRolloutPaused WorkspaceTypeRolloutPhase = "Paused"
+	// WorkspaceTypeRolloutComplete means the revision is the stable revision of all workspaces.
+	WorkspaceTypeRolloutComplete WorkspaceTypeRolloutPhase = "Complete"
+)
+
+// WorkspaceTypeRolloutStatus communicates the progress of a staged rollout.
+type WorkspaceTypeRolloutStatus struct {
+	// revision is the revision of spec.defaultAPIBindings being rolled out.
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// stableRevision is the revision of the workspaces not in a started wave.
+	//
+	// +optional
+	StableRevision string `json:"stableRevision,omitempty"`
+
+	// stableDefaultAPIBindings are the default APIBindings of the stable revision.
+	//
+	// +optional
+	StableDefaultAPIBindings []DefaultAPIBinding `json:"stableDefaultAPIBindings,omitempty"`
+
+	// startedWaves is the number of waves of spec.rollout.waves started for revision.
+	//
+	// +optional
+	StartedWaves int32 `json:"startedWaves,omitempty"`
+
+	// lastTransitionTime is the time the last wave was started or the rollout completed.
+	//
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// phase is the phase of the rollout.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Progressing;Paused;Complete
+	Phase WorkspaceTypeRolloutPhase `json:"phase,omitempty"`
+}
+
+type VirtualWorkspace struct {
+	// url is a WorkspaceType initialization virtual workspace URL.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:format:URL
+	// +required
+	URL string `json:"url"`
+}
+
+// DefaultAPIBindingsRevision returns the revision of spec.defaultAPIBindings, a hash of their content.
+func (in *WorkspaceType) DefaultAPIBindingsRevision() string {
+	bs, err := json.Marshal(in.Spec.DefaultAPIBindings)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bs))[:10]
+}
+
+// DefaultAPIBindingsFor returns the default APIBindings of the revision the workspace with the given
+// logical cluster name and LogicalCluster labels is at. During a staged rollout, these are the ones of
+// spec.defaultAPIBindings for workspaces in a started wave, and the stable ones for all others. A
+// revision not yet observed by the rollout controller is not rolled out to any workspace.
+func (in *WorkspaceType) DefaultAPIBindingsFor(clusterName string, lbls map[string]string) []DefaultAPIBinding {
+	status := in.Status.Rollout
+	if in.Spec.Rollout == nil || status == nil {
+		return in.Spec.DefaultAPIBindings
+	}
+	if status.Revision != in.DefaultAPIBindingsRevision() {
+		return status.StableDefaultAPIBindings
+	}
+	if status.Phase == WorkspaceTypeRolloutComplete || in.Spec.Rollout.includes(status.StartedWaves, clusterName, lbls) {
+		return in.Spec.DefaultAPIBindings
+	}
+	return status.StableDefaultAPIBindings
+}
+
+func (in *WorkspaceType) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+func (in *WorkspaceType) SetConditions(conditions conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// WorkspaceTypeList is a list of workspace types
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceType `json:"items"`
+}
+
+// WorkspaceAPIBindingsInitializer is a special-case initializer that waits for APIBindings defined
+// on a WorkspaceType to be created.
+const WorkspaceAPIBindingsInitializer corev1alpha1.LogicalClusterInitializer = "system:apibindings"
+
+const (
+	// WorkspacePhaseLabel holds the Workspace.Status.Phase value, and is enforced to match
+	// by a mutating admission webhook.
+	WorkspacePhaseLabel = "tenancy.kcp.io/phase"
+	// WorkspaceInitializerLabelPrefix is the prefix for labels which match Workspace.Status.Initializers,
+	// and the set of labels with this prefix is enforced to match the set of initializers by a mutating admission
+	// webhook.
+	WorkspaceInitializerLabelPrefix = "initializer.internal.kcp.io/"
+)
+
+const (
+	// RootWorkspaceTypeName is a reference to the root logical cluster, which has no workspace type
+	RootWorkspaceTypeName = WorkspaceTypeName("root")
+)
+
+// ObjectName converts the proper name of a type that users interact with to the
+// metadata.name of the WorkspaceType object.
+func ObjectName(typeName WorkspaceTypeName) string {
+	return string(typeName)
+}
+
+// TypeName converts the metadata.name of a WorkspaceType to the proper
+// name of a type, as users interact with it.
+func TypeName(objectName string) WorkspaceTypeName {
+	return WorkspaceTypeName(objectName)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultAPIBindingsFor(t *testing.T) {
+	stable := []DefaultAPIBinding{{APIExportReference: APIExportReference{Export: "widgets"}, ReleaseConstraint: "<v2.0.0"}}
+	current := []DefaultAPIBinding{{APIExportReference: APIExportReference{Export: "widgets"}, ReleaseConstraint: "<v3.0.0"}}
+	revision := (&WorkspaceType{Spec: WorkspaceTypeSpec{DefaultAPIBindings: current}}).DefaultAPIBindingsRevision()
+
+	policy := &WorkspaceTypeRolloutPolicy{Waves: []WorkspaceTypeRolloutWave{
+		{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}},
+		{Percentage: 100},
+	}}
+
+	tests := map[string]struct {
+		policy *WorkspaceTypeRolloutPolicy
+		status *WorkspaceTypeRolloutStatus
+		labels map[string]string
+		want   []DefaultAPIBinding
+	}{
+		"without rollout": {
+			want: current,
+		},
+		"rollout not yet observed": {
+			policy: policy,
+			want:   current,
+		},
+		"revision not yet observed": {
+			policy: policy,
+			status: &WorkspaceTypeRolloutStatus{Revision: "old", StableDefaultAPIBindings: stable, StartedWaves: 2, Phase: WorkspaceTypeRolloutComplete},
+			labels: map[string]string{"canary": "true"},
+			want:   stable,
+		},
+		"in a started wave by label": {
+			policy: policy,
+			status: &WorkspaceTypeRolloutStatus{Revision: revision, StableDefaultAPIBindings: stable, StartedWaves: 1, Phase: WorkspaceTypeRolloutProgressing},
+			labels: map[string]string{"canary": "true"},
+			want:   current,
+		},
+		"not in a started wave": {
+			policy: policy,
+			status: &WorkspaceTypeRolloutStatus{Revision: revision, StableDefaultAPIBindings: stable, StartedWaves: 1, Phase: WorkspaceTypeRolloutProgressing},
+			want:   stable,
+		},
+		"in a started wave by percentage": {
+			policy: policy,
+			status: &WorkspaceTypeRolloutStatus{Revision: revision, StableDefaultAPIBindings: stable, StartedWaves: 2, Phase: WorkspaceTypeRolloutPaused},
+			want:   current,
+		},
+		"rollout complete": {
+			policy: policy,
+			status: &WorkspaceTypeRolloutStatus{Revision: revision, StableDefaultAPIBindings: current, Phase: WorkspaceTypeRolloutComplete},
+			want:   current,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wt := &WorkspaceType{
+				Spec:   WorkspaceTypeSpec{DefaultAPIBindings: current, Rollout: tt.policy},
+				Status: WorkspaceTypeStatus{Rollout: tt.status},
+			}
+			require.Equal(t, tt.want, wt.DefaultAPIBindingsFor("2bxu7gdk0wvnsfaz", tt.labels))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceUsageName is the name of the WorkspaceUsage in every logical cluster.
+const WorkspaceUsageName = "cluster"
+
+// WorkspaceUsage reports the usage of the logical cluster it lives in, e.g. for chargeback. There
+// is one WorkspaceUsage per logical cluster, named "cluster". It is maintained by kcp periodically
+// if --workspace-usage-metrics is enabled, and cannot be changed by anybody else. The same usage is
+// exposed as metrics labeled by the workspace path.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster,categories=kcp
+// +kubebuilder:printcolumn:name="Path",type="string",JSONPath=".status.path",description="The path of the workspace"
+// +kubebuilder:printcolumn:name="Requests",type="integer",JSONPath=".status.requests",description="The number of requests to the workspace"
+// +kubebuilder:printcolumn:name="Storage",type="integer",JSONPath=".status.storageBytes",description="The approximate size of the objects in the workspace in bytes"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WorkspaceUsage struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Status WorkspaceUsageStatus `json:"status,omitempty"`
+}
+
+// WorkspaceUsageStatus communicates the observed usage of the logical cluster.
+type WorkspaceUsageStatus struct {
+	// path is the canonical path of the workspace, e.g. root:org:team.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// objects is the number of objects per resource, sorted by group, version and resource.
+	// Every resource is listed once, with one of its served versions.
+	//
+	// +optional
+	Objects []WorkspaceResourceUsage `json:"objects,omitempty"`
+
+	// requests is the number of requests to the logical cluster served by its shard since the
+	// WorkspaceUsage was created.
+	//
+	// +optional
+	Requests int64 `json:"requests,omitempty"`
+
+	// storageBytes is the approximate size of all objects in the logical cluster, as the size of
+	// their JSON encoding.
+	//
+	// +optional
+	StorageBytes int64 `json:"storageBytes,omitempty"`
+
+	// lastUpdateTime is the time the usage was last changed.
+	//
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// WorkspaceResourceUsage is the number of objects of a resource.
+type WorkspaceResourceUsage struct {
+	// group is the API group of the resource, empty for the core group.
+	//
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is the API version of the resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+
+	// resource is the plural name of the resource.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// count is the number of objects.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Count int64 `json:"count"`
+}
+
+// WorkspaceUsageList is a list of workspace usages.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkspaceUsage `json:"items"`
+}
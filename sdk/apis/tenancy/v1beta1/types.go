@@ -0,0 +1,416 @@
+/*
+Copyright 2021 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+)
+
+// LogicalClusterTypeAnnotationKey is the annotation key used to indicate
+// the type of the workspace on the corresponding LogicalCluster object. Its format is "root:ws:name".
+const LogicalClusterTypeAnnotationKey = "internal.tenancy.kcp.io/type"
+
+// Workspace defines a generic Kubernetes-cluster-like endpoint, with standard Kubernetes
+// discovery APIs, OpenAPI and resource API endpoints.
+//
+// A workspace can be backed by different concrete types of workspace implementation,
+// depending on access pattern. All workspace implementations share the characteristic
+// that the URL that serves a given workspace can be used with standard Kubernetes
+// API machinery and client libraries and command line tools.
+//
+// +crd
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=kcp,shortName=ws
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type.name`,description="Type of the workspace"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.metadata.labels['tenancy\.kcp\.dev/phase']`,description="The current phase (e.g. Scheduling, Initializing, Ready, Deleting)"
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.URL`,description="URL to access the workspace"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Workspace struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceSpec `json:"spec"`
+
+	// +optional
+	Status WorkspaceStatus `json:"status,omitempty"`
+}
+
+// WorkspaceSpec holds the desired state of the Workspace.
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.URL) || has(self.URL)",message="URL cannot be unset"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.cluster) || has(self.cluster)",message="cluster cannot be unset"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.mount) == has(self.mount)",message="mount is immutable"
+type WorkspaceSpec struct {
+	// type defines properties of the workspace both on creation (e.g. initial
+	// resources and initially installed APIs) and during runtime (e.g. permissions).
+	// If no type is provided, the default type for the workspace in which this workspace
+	// is nesting will be used.
+	//
+	// The type is a reference to a WorkspaceType in the listed workspace, but
+	// lower-cased. The WorkspaceType existence is validated at admission during
+	// creation. The type is immutable after creation. The use of a type is gated via
+	// the RBAC workspacetypes/use resource permission.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.name == oldSelf.name",message="name is immutable"
+	// +kubebuilder:validation:XValidation:rule="has(oldSelf.path) == has(self.path)",message="path is immutable"
+	// +kubebuilder:validation:XValidation:rule="!has(oldSelf.path) || !has(self.path) || self.path == oldSelf.path",message="path is immutable"
+	Type v1alpha1.WorkspaceTypeReference `json:"type,omitempty"`
+
+	// location constraints where this workspace can be scheduled to.
+	//
+	// If the no location is specified, an arbitrary location is chosen.
+	//
+	// +optional
+	Location *WorkspaceLocation `json:"shard,omitempty"`
+
+	// cluster is the name of the logical cluster this workspace is stored under.
+	//
+	// Set by the system.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster is immutable"
+	Cluster string `json:"cluster,omitempty"`
+
+	// URL is the address under which the Kubernetes-cluster-like endpoint
+	// can be found. This URL can be used to access the workspace with standard Kubernetes
+	// client libraries and command line tools.
+	//
+	// The URL addresses the workspace by the name of its logical cluster. It stays valid
+	// for the lifetime of the workspace, independently of renames and moves of the workspace
+	// or its ancestors. Prefer it for long-lived references like kubeconfigs.
+	//
+	// Set by the system.
+	//
+	// +kubebuilder:format:uri
+	URL string `json:"URL,omitempty"`
+
+	// state is the desired state of the workspace. An Archived workspace is read-only:
+	// its logical cluster rejects all writes, and with them controllers stop reconciling
+	// inside of it, until the workspace is set back to Active.
+	//
+	// +optional
+	// +kubebuilder:default=Active
+	State WorkspaceState `json:"state,omitempty"`
+
+	// ttl makes the workspace expire after a period of inactivity, e.g. for CI sandboxes or
+	// demos. Activity is tracked in status.summary.lastActivityTime, and the time of expiry
+	// is published in status.expiryTime.
+	//
+	// +optional
+	TTL *WorkspaceTTL `json:"ttl,omitempty"`
+
+	// owner is the user owning the workspace. The owner is bound to the cluster-admin role
+	// inside of the workspace through the workspace-admin ClusterRoleBinding.
+	//
+	// It defaults to the user creating the workspace. Changing it transfers the ownership
+	// of the workspace, which is allowed for the current owner and for users with the
+	// "transfer" verb on the workspace.
+	//
+	// +optional
+	Owner *WorkspaceOwner `json:"owner,omitempty"`
+
+	// priority of the workspace in scheduling when shards are at capacity. Workspaces
+	// with higher priority are scheduled first, while workspaces with lower priority
+	// are kept unschedulable until capacity is free.
+	//
+	// It defaults to the priority of the workspace type and must not exceed it. The
+	// priority is immutable.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// mount makes an external endpoint serve the workspace path instead of kcp, e.g. a
+	// cluster proxy in front of a Kubernetes cluster. The front-proxy forwards requests
+	// under the workspace path to the URL of the mount while its MountReady condition
+	// is true.
+	//
+	// The mount is immutable.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="mount is immutable"
+	Mount *Mount `json:"mount,omitempty"`
+}
+
+// Mount references the object providing the endpoint of a mounted workspace.
+type Mount struct {
+	// reference is the object in the workspace of the Workspace object, e.g. a ClusterProxy.
+	// It must publish the URL of the endpoint in status.URL and its readiness in
+	// status.phase, which is Ready once the endpoint can serve requests.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reference ObjectReference `json:"reference"`
+}
+
+// ObjectReference references an object by API version, kind and name.
+type ObjectReference struct {
+	// apiVersion is the API group and version of the object, e.g. "proxy.example.com/v1alpha1".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// kind is the kind of the object, e.g. "ClusterProxy".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// name is the name of the object.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// namespace is the namespace of the object, if it is namespaced.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// MountPhaseType is the phase published in status.phase by the object referenced by a mount.
+type MountPhaseType string
+
+const (
+	// MountPhaseReady means that the endpoint of the mount serves requests.
+	MountPhaseReady MountPhaseType = "Ready"
+	// MountPhaseConnecting means that the endpoint of the mount is being set up or reconnected.
+	MountPhaseConnecting MountPhaseType = "Connecting"
+	// MountPhaseUnknown means that the state of the endpoint of the mount is unknown.
+	MountPhaseUnknown MountPhaseType = "Unknown"
+)
+
+// WorkspaceOwner is the user owning a workspace.
+type WorkspaceOwner struct {
+	// username is the name of the user owning the workspace.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+}
+
+// WorkspaceTTL configures the expiry of an inactive workspace.
+type WorkspaceTTL struct {
+	// inactivity is the time without activity after which the workspace expires, e.g. "72h".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Inactivity metav1.Duration `json:"inactivity"`
+
+	// action is what happens to the workspace when it expires. A Deleted workspace goes
+	// through the trash if it has a trash retention. An Archived workspace stays read-only
+	// until spec.state is set back to Active, which counts as activity.
+	//
+	// +optional
+	// +kubebuilder:default=Delete
+	Action WorkspaceTTLAction `json:"action,omitempty"`
+
+	// warningPeriod is the time before the expiry at which the Expiring condition is set and
+	// a warning event is emitted.
+	//
+	// +optional
+	// +kubebuilder:default="1h"
+	WarningPeriod *metav1.Duration `json:"warningPeriod,omitempty"`
+}
+
+// WorkspaceTTLAction is what happens to an expired workspace.
+//
+// +kubebuilder:validation:Enum=Delete;Archive
+type WorkspaceTTLAction string
+
+const (
+	// WorkspaceTTLActionDelete deletes an expired workspace.
+	WorkspaceTTLActionDelete WorkspaceTTLAction = "Delete"
+	// WorkspaceTTLActionArchive sets spec.state of an expired workspace to Archived.
+	WorkspaceTTLActionArchive WorkspaceTTLAction = "Archive"
+)
+
+// DefaultWorkspaceTTLWarningPeriod is the warning period of a TTL without warningPeriod.
+const DefaultWorkspaceTTLWarningPeriod = time.Hour
+
+// WorkspaceState is the desired state of a workspace.
+//
+// +kubebuilder:validation:Enum=Active;Archived
+type WorkspaceState string
+
+const (
+	// WorkspaceStateActive is the default state of a workspace.
+	WorkspaceStateActive WorkspaceState = "Active"
+	// WorkspaceStateArchived freezes the content of a workspace.
+	WorkspaceStateArchived WorkspaceState = "Archived"
+)
+
+type WorkspaceLocation struct {
+
+	// selector is a label selector that filters workspace scheduling targets.
+	//
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// WorkspaceStatus communicates the observed state of the Workspace.
+type WorkspaceStatus struct {
+	// Phase of the workspace (Scheduling, Initializing, Ready).
+	//
+	// +kubebuilder:default=Scheduling
+	Phase corev1alpha1.LogicalClusterPhaseType `json:"phase,omitempty"`
+
+	// Current processing state of the Workspace.
+	// +optional
+	Conditions conditionsv1alpha1.Conditions `json:"conditions,omitempty"`
+
+	// initializers must be cleared by a controller before the workspace is ready
+	// and can be used.
+	//
+	// +optional
+	Initializers []corev1alpha1.LogicalClusterInitializer `json:"initializers,omitempty"`
+
+	// summary is a rollup of the content of the workspace, meant to be displayed by user
+	// interfaces. It is updated asynchronously and may lag behind the actual content.
+	//
+	// +optional
+	Summary *WorkspaceSummary `json:"summary,omitempty"`
+
+	// urls are the URLs the workspace is served at, once it is scheduled.
+	//
+	// +optional
+	URLs *WorkspaceURLs `json:"urls,omitempty"`
+
+	// scheduling records the decision of the workspace scheduler.
+	//
+	// +optional
+	Scheduling *WorkspaceScheduling `json:"scheduling,omitempty"`
+
+	// expiryTime is the time the workspace expires at if it stays inactive. It is only set
+	// for ready workspaces with spec.ttl.
+	//
+	// +optional
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+
+	// mount is the observed state of spec.mount.
+	//
+	// +optional
+	Mount *WorkspaceMountStatus `json:"mount,omitempty"`
+}
+
+// WorkspaceMountStatus is the observed state of the mount of a workspace.
+type WorkspaceMountStatus struct {
+	// URL is the address of the endpoint of the mount, as published by the referenced object.
+	//
+	// +optional
+	URL string `json:"URL,omitempty"`
+
+	// dnsName is the stable DNS name of the mount, rendered from the logical cluster of the
+	// workspace and its name if the workspace controller is configured with a base domain for
+	// mounts. It does not change when the URL of the mount changes.
+	//
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+}
+
+// WorkspaceScheduling records the decision of the workspace scheduler.
+type WorkspaceScheduling struct {
+	// shard is the name of the shard the workspace is scheduled to.
+	//
+	// +optional
+	Shard string `json:"shard,omitempty"`
+
+	// selector is the shard label selector the decision was made with. It combines
+	// spec.location.selector with the shard selectors of the workspace type and the
+	// types it extends. It is empty if any shard was eligible.
+	//
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// message explains the decision, e.g. why no shard could be chosen.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WorkspaceURLs are the URLs a workspace is served at.
+type WorkspaceURLs struct {
+	// path is the URL addressing the workspace by its canonical path, e.g.
+	// https://kcp.example.com/clusters/root:org:ws. It is human readable, but unlike
+	// spec.URL it changes when the workspace or one of its ancestors is renamed or moved.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// WorkspaceSummary is a rollup of the content of a workspace.
+type WorkspaceSummary struct {
+	// childWorkspaces is the number of workspaces directly inside of the workspace.
+	ChildWorkspaces int32 `json:"childWorkspaces"`
+
+	// apiBindings is the number of APIBindings in the workspace.
+	APIBindings int32 `json:"apiBindings"`
+
+	// apis is the number of resources bound by the APIBindings in the workspace.
+	APIs int32 `json:"apis"`
+
+	// lastActivityTime is the last time the workspace, its child workspaces, APIBindings
+	// or resource quotas have been changed by a user. Changes by kcp itself, e.g. of the
+	// status, are not considered activity.
+	//
+	// +optional
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+
+	// quotaHard is the sum of the hard limits of all resource quotas in the workspace.
+	//
+	// +optional
+	QuotaHard corev1.ResourceList `json:"quotaHard,omitempty"`
+
+	// quotaUsed is the sum of the observed usage of all resource quotas in the workspace.
+	//
+	// +optional
+	QuotaUsed corev1.ResourceList `json:"quotaUsed,omitempty"`
+}
+
+func (in *Workspace) SetConditions(c conditionsv1alpha1.Conditions) {
+	in.Status.Conditions = c
+}
+
+func (in *Workspace) GetConditions() conditionsv1alpha1.Conditions {
+	return in.Status.Conditions
+}
+
+// WorkspaceList is a list of Workspaces
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Workspace `json:"items"`
+}
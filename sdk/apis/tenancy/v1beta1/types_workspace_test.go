@@ -23,7 +23,7 @@ import (
 
 	"sigs.k8s.io/yaml"
 
-	apitest "github.com/kcp-dev/kcp/pkg/apis/test"
+	apitest "github.com/kcp-dev/kcp/sdk/apis/test"
 )
 
 func TestWorkspaceCELValidation(t *testing.T) {
@@ -29,13 +29,14 @@ import (
 
 	"k8s.io/client-go/rest"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 type ApisV1alpha1ClusterInterface interface {
 	ApisV1alpha1ClusterScoper
 	APIBindingsClusterGetter
 	APIExportsClusterGetter
+	APIExportConsumerSummariesClusterGetter
 	APIExportEndpointSlicesClusterGetter
 	APIResourceSchemasClusterGetter
 }
@@ -63,6 +64,10 @@ func (c *ApisV1alpha1ClusterClient) APIExports() APIExportClusterInterface {
 	return &aPIExportsClusterInterface{clientCache: c.clientCache}
 }
 
+func (c *ApisV1alpha1ClusterClient) APIExportConsumerSummaries() APIExportConsumerSummaryClusterInterface {
+	return &aPIExportConsumerSummariesClusterInterface{clientCache: c.clientCache}
+}
+
 func (c *ApisV1alpha1ClusterClient) APIExportEndpointSlices() APIExportEndpointSliceClusterInterface {
 	return &aPIExportEndpointSlicesClusterInterface{clientCache: c.clientCache}
 }
@@ -34,8 +34,8 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/testing"
 
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	apisv1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 var aPIResourceSchemasResource = schema.GroupVersionResource{Group: "apis.kcp.io", Version: "v1alpha1", Resource: "apiresourceschemas"}
@@ -108,6 +108,14 @@ func (c *aPIResourceSchemasClient) UpdateStatus(ctx context.Context, aPIResource
 	return obj.(*apisv1alpha1.APIResourceSchema), err
 }
 
+func (c *aPIResourceSchemasClient) UpdateStatus(ctx context.Context, aPIResourceSchema *apisv1alpha1.APIResourceSchema, opts metav1.UpdateOptions) (*apisv1alpha1.APIResourceSchema, error) {
+	obj, err := c.Fake.Invokes(kcptesting.NewRootUpdateSubresourceAction(aPIResourceSchemasResource, c.ClusterPath, "status", aPIResourceSchema), &apisv1alpha1.APIResourceSchema{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*apisv1alpha1.APIResourceSchema), err
+}
+
 func (c *aPIResourceSchemasClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	_, err := c.Fake.Invokes(kcptesting.NewRootDeleteActionWithOptions(aPIResourceSchemasResource, c.ClusterPath, name, opts), &apisv1alpha1.APIResourceSchema{})
 	return err
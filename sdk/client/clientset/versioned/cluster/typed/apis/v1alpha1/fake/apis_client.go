@@ -27,8 +27,8 @@ import (
 	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
 	"k8s.io/client-go/rest"
 
-	kcpapisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/typed/apis/v1alpha1"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
+	kcpapisv1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/typed/apis/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 var _ kcpapisv1alpha1.ApisV1alpha1ClusterInterface = (*ApisV1alpha1ClusterClient)(nil)
@@ -52,6 +52,10 @@ func (c *ApisV1alpha1ClusterClient) APIExports() kcpapisv1alpha1.APIExportCluste
 	return &aPIExportsClusterClient{Fake: c.Fake}
 }
 
+func (c *ApisV1alpha1ClusterClient) APIExportConsumerSummaries() kcpapisv1alpha1.APIExportConsumerSummaryClusterInterface {
+	return &aPIExportConsumerSummariesClusterClient{Fake: c.Fake}
+}
+
 func (c *ApisV1alpha1ClusterClient) APIExportEndpointSlices() kcpapisv1alpha1.APIExportEndpointSliceClusterInterface {
 	return &aPIExportEndpointSlicesClusterClient{Fake: c.Fake}
 }
@@ -80,6 +84,10 @@ func (c *ApisV1alpha1Client) APIExports() apisv1alpha1.APIExportInterface {
 	return &aPIExportsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
 
+func (c *ApisV1alpha1Client) APIExportConsumerSummaries() apisv1alpha1.APIExportConsumerSummaryInterface {
+	return &aPIExportConsumerSummariesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
 func (c *ApisV1alpha1Client) APIExportEndpointSlices() apisv1alpha1.APIExportEndpointSliceInterface {
 	return &aPIExportEndpointSlicesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
@@ -29,12 +29,20 @@ import (
 
 	"k8s.io/client-go/rest"
 
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
 )
 
 type TenancyV1alpha1ClusterInterface interface {
 	TenancyV1alpha1ClusterScoper
+	DataJobsClusterGetter
+	LimitIncreaseRequestsClusterGetter
+	RetentionPoliciesClusterGetter
+	ThrottlingExemptionsClusterGetter
+	WorkspaceQuotasClusterGetter
+	WorkspaceTemplatesClusterGetter
+	WorkspaceTombstonesClusterGetter
 	WorkspaceTypesClusterGetter
+	WorkspaceUsagesClusterGetter
 }
 
 type TenancyV1alpha1ClusterScoper interface {
@@ -52,10 +60,42 @@ func (c *TenancyV1alpha1ClusterClient) Cluster(clusterPath logicalcluster.Path)
 	return c.clientCache.ClusterOrDie(clusterPath)
 }
 
+func (c *TenancyV1alpha1ClusterClient) DataJobs() DataJobClusterInterface {
+	return &dataJobsClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) LimitIncreaseRequests() LimitIncreaseRequestClusterInterface {
+	return &limitIncreaseRequestsClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) RetentionPolicies() RetentionPolicyClusterInterface {
+	return &retentionPoliciesClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) ThrottlingExemptions() ThrottlingExemptionClusterInterface {
+	return &throttlingExemptionsClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) WorkspaceQuotas() WorkspaceQuotaClusterInterface {
+	return &workspaceQuotasClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) WorkspaceTemplates() WorkspaceTemplateClusterInterface {
+	return &workspaceTemplatesClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *TenancyV1alpha1ClusterClient) WorkspaceTombstones() WorkspaceTombstoneClusterInterface {
+	return &workspaceTombstonesClusterInterface{clientCache: c.clientCache}
+}
+
 func (c *TenancyV1alpha1ClusterClient) WorkspaceTypes() WorkspaceTypeClusterInterface {
 	return &workspaceTypesClusterInterface{clientCache: c.clientCache}
 }
 
+func (c *TenancyV1alpha1ClusterClient) WorkspaceUsages() WorkspaceUsageClusterInterface {
+	return &workspaceUsagesClusterInterface{clientCache: c.clientCache}
+}
+
 // NewForConfig creates a new TenancyV1alpha1ClusterClient for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
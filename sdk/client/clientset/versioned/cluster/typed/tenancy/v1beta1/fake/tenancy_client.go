@@ -27,8 +27,8 @@ import (
 	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
 	"k8s.io/client-go/rest"
 
-	kcptenancyv1beta1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster/typed/tenancy/v1beta1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1beta1"
+	kcptenancyv1beta1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/typed/tenancy/v1beta1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1beta1"
 )
 
 var _ kcptenancyv1beta1.TenancyV1beta1ClusterInterface = (*TenancyV1beta1ClusterClient)(nil)
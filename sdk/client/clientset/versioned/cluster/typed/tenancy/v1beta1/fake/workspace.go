@@ -34,8 +34,8 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/testing"
 
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	tenancyv1beta1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1beta1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	tenancyv1beta1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1beta1"
 )
 
 var workspacesResource = schema.GroupVersionResource{Group: "tenancy.kcp.io", Version: "v1beta1", Resource: "workspaces"}
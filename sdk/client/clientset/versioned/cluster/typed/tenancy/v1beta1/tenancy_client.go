@@ -29,7 +29,7 @@ import (
 
 	"k8s.io/client-go/rest"
 
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1beta1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1beta1"
 )
 
 type TenancyV1beta1ClusterInterface interface {
@@ -27,8 +27,8 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	rest "k8s.io/client-go/rest"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	scheme "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	scheme "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/scheme"
 )
 
 // APIResourceSchemasGetter has a method to return a APIResourceSchemaInterface.
@@ -41,6 +41,7 @@ type APIResourceSchemasGetter interface {
 type APIResourceSchemaInterface interface {
 	Create(ctx context.Context, aPIResourceSchema *v1alpha1.APIResourceSchema, opts v1.CreateOptions) (*v1alpha1.APIResourceSchema, error)
 	Update(ctx context.Context, aPIResourceSchema *v1alpha1.APIResourceSchema, opts v1.UpdateOptions) (*v1alpha1.APIResourceSchema, error)
+	UpdateStatus(ctx context.Context, aPIResourceSchema *v1alpha1.APIResourceSchema, opts v1.UpdateOptions) (*v1alpha1.APIResourceSchema, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.APIResourceSchema, error)
@@ -129,6 +130,21 @@ func (c *aPIResourceSchemas) Update(ctx context.Context, aPIResourceSchema *v1al
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *aPIResourceSchemas) UpdateStatus(ctx context.Context, aPIResourceSchema *v1alpha1.APIResourceSchema, opts v1.UpdateOptions) (result *v1alpha1.APIResourceSchema, err error) {
+	result = &v1alpha1.APIResourceSchema{}
+	err = c.client.Put().
+		Resource("apiresourceschemas").
+		Name(aPIResourceSchema.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(aPIResourceSchema).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the aPIResourceSchema and deletes it. Returns an error if one occurs.
 func (c *aPIResourceSchemas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -23,14 +23,15 @@ import (
 
 	rest "k8s.io/client-go/rest"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/client/clientset/versioned/scheme"
 )
 
 type ApisV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	APIBindingsGetter
 	APIExportsGetter
+	APIExportConsumerSummariesGetter
 	APIExportEndpointSlicesGetter
 	APIResourceSchemasGetter
 }
@@ -48,6 +49,10 @@ func (c *ApisV1alpha1Client) APIExports() APIExportInterface {
 	return newAPIExports(c)
 }
 
+func (c *ApisV1alpha1Client) APIExportConsumerSummaries() APIExportConsumerSummaryInterface {
+	return newAPIExportConsumerSummaries(c)
+}
+
 func (c *ApisV1alpha1Client) APIExportEndpointSlices() APIExportEndpointSliceInterface {
 	return newAPIExportEndpointSlices(c)
 }
@@ -28,7 +28,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
 // FakeAPIResourceSchemas implements APIResourceSchemaInterface
@@ -97,6 +97,17 @@ func (c *FakeAPIResourceSchemas) Update(ctx context.Context, aPIResourceSchema *
 	return obj.(*v1alpha1.APIResourceSchema), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAPIResourceSchemas) UpdateStatus(ctx context.Context, aPIResourceSchema *v1alpha1.APIResourceSchema, opts v1.UpdateOptions) (*v1alpha1.APIResourceSchema, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(apiresourceschemasResource, "status", aPIResourceSchema), &v1alpha1.APIResourceSchema{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.APIResourceSchema), err
+}
+
 // Delete takes name of the aPIResourceSchema and deletes it. Returns an error if one occurs.
 func (c *FakeAPIResourceSchemas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
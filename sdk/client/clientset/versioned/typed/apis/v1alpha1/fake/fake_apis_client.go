@@ -22,7 +22,7 @@ import (
 	rest "k8s.io/client-go/rest"
 	testing "k8s.io/client-go/testing"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/apis/v1alpha1"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 type FakeApisV1alpha1 struct {
@@ -37,6 +37,10 @@ func (c *FakeApisV1alpha1) APIExports() v1alpha1.APIExportInterface {
 	return &FakeAPIExports{c}
 }
 
+func (c *FakeApisV1alpha1) APIExportConsumerSummaries() v1alpha1.APIExportConsumerSummaryInterface {
+	return &FakeAPIExportConsumerSummaries{c}
+}
+
 func (c *FakeApisV1alpha1) APIExportEndpointSlices() v1alpha1.APIExportEndpointSliceInterface {
 	return &FakeAPIExportEndpointSlices{c}
 }
@@ -22,6 +22,8 @@ type APIBindingExpansion interface{}
 
 type APIExportExpansion interface{}
 
+type APIExportConsumerSummaryExpansion interface{}
+
 type APIExportEndpointSliceExpansion interface{}
 
 type APIResourceSchemaExpansion interface{}
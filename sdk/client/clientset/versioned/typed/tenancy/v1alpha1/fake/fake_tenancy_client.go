@@ -22,17 +22,49 @@ import (
 	rest "k8s.io/client-go/rest"
 	testing "k8s.io/client-go/testing"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/tenancy/v1alpha1"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
 )
 
 type FakeTenancyV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTenancyV1alpha1) DataJobs() v1alpha1.DataJobInterface {
+	return &FakeDataJobs{c}
+}
+
+func (c *FakeTenancyV1alpha1) LimitIncreaseRequests() v1alpha1.LimitIncreaseRequestInterface {
+	return &FakeLimitIncreaseRequests{c}
+}
+
+func (c *FakeTenancyV1alpha1) RetentionPolicies() v1alpha1.RetentionPolicyInterface {
+	return &FakeRetentionPolicies{c}
+}
+
+func (c *FakeTenancyV1alpha1) ThrottlingExemptions() v1alpha1.ThrottlingExemptionInterface {
+	return &FakeThrottlingExemptions{c}
+}
+
+func (c *FakeTenancyV1alpha1) WorkspaceQuotas() v1alpha1.WorkspaceQuotaInterface {
+	return &FakeWorkspaceQuotas{c}
+}
+
+func (c *FakeTenancyV1alpha1) WorkspaceTemplates() v1alpha1.WorkspaceTemplateInterface {
+	return &FakeWorkspaceTemplates{c}
+}
+
+func (c *FakeTenancyV1alpha1) WorkspaceTombstones() v1alpha1.WorkspaceTombstoneInterface {
+	return &FakeWorkspaceTombstones{c}
+}
+
 func (c *FakeTenancyV1alpha1) WorkspaceTypes() v1alpha1.WorkspaceTypeInterface {
 	return &FakeWorkspaceTypes{c}
 }
 
+func (c *FakeTenancyV1alpha1) WorkspaceUsages() v1alpha1.WorkspaceUsageInterface {
+	return &FakeWorkspaceUsages{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeTenancyV1alpha1) RESTClient() rest.Interface {
@@ -18,4 +18,20 @@ limitations under the License.
 
 package v1alpha1
 
+type DataJobExpansion interface{}
+
+type LimitIncreaseRequestExpansion interface{}
+
+type RetentionPolicyExpansion interface{}
+
+type ThrottlingExemptionExpansion interface{}
+
+type WorkspaceQuotaExpansion interface{}
+
+type WorkspaceTemplateExpansion interface{}
+
+type WorkspaceTombstoneExpansion interface{}
+
 type WorkspaceTypeExpansion interface{}
+
+type WorkspaceUsageExpansion interface{}
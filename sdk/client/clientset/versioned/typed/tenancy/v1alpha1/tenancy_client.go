@@ -23,13 +23,21 @@ import (
 
 	rest "k8s.io/client-go/rest"
 
-	v1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/client/clientset/versioned/scheme"
+	v1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/client/clientset/versioned/scheme"
 )
 
 type TenancyV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	DataJobsGetter
+	LimitIncreaseRequestsGetter
+	RetentionPoliciesGetter
+	ThrottlingExemptionsGetter
+	WorkspaceQuotasGetter
+	WorkspaceTemplatesGetter
+	WorkspaceTombstonesGetter
 	WorkspaceTypesGetter
+	WorkspaceUsagesGetter
 }
 
 // TenancyV1alpha1Client is used to interact with features provided by the tenancy.kcp.io group.
@@ -37,10 +45,42 @@ type TenancyV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TenancyV1alpha1Client) DataJobs() DataJobInterface {
+	return newDataJobs(c)
+}
+
+func (c *TenancyV1alpha1Client) LimitIncreaseRequests() LimitIncreaseRequestInterface {
+	return newLimitIncreaseRequests(c)
+}
+
+func (c *TenancyV1alpha1Client) RetentionPolicies() RetentionPolicyInterface {
+	return newRetentionPolicies(c)
+}
+
+func (c *TenancyV1alpha1Client) ThrottlingExemptions() ThrottlingExemptionInterface {
+	return newThrottlingExemptions(c)
+}
+
+func (c *TenancyV1alpha1Client) WorkspaceQuotas() WorkspaceQuotaInterface {
+	return newWorkspaceQuotas(c)
+}
+
+func (c *TenancyV1alpha1Client) WorkspaceTemplates() WorkspaceTemplateInterface {
+	return newWorkspaceTemplates(c)
+}
+
+func (c *TenancyV1alpha1Client) WorkspaceTombstones() WorkspaceTombstoneInterface {
+	return newWorkspaceTombstones(c)
+}
+
 func (c *TenancyV1alpha1Client) WorkspaceTypes() WorkspaceTypeInterface {
 	return newWorkspaceTypes(c)
 }
 
+func (c *TenancyV1alpha1Client) WorkspaceUsages() WorkspaceUsageInterface {
+	return newWorkspaceUsages(c)
+}
+
 // NewForConfig creates a new TenancyV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
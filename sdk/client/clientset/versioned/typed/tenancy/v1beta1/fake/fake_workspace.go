@@ -28,7 +28,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
 
-	v1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
+	v1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
 )
 
 // FakeWorkspaces implements WorkspaceInterface
@@ -22,7 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"github.com/kcp-dev/kcp/pkg/client/informers/externalversions/internalinterfaces"
+	"github.com/kcp-dev/kcp/sdk/client/informers/externalversions/internalinterfaces"
 )
 
 type ClusterInterface interface {
@@ -30,6 +30,8 @@ type ClusterInterface interface {
 	APIBindings() APIBindingClusterInformer
 	// APIExports returns a APIExportClusterInformer
 	APIExports() APIExportClusterInformer
+	// APIExportConsumerSummaries returns a APIExportConsumerSummaryClusterInformer
+	APIExportConsumerSummaries() APIExportConsumerSummaryClusterInformer
 	// APIExportEndpointSlices returns a APIExportEndpointSliceClusterInformer
 	APIExportEndpointSlices() APIExportEndpointSliceClusterInformer
 	// APIResourceSchemas returns a APIResourceSchemaClusterInformer
@@ -56,6 +58,11 @@ func (v *version) APIExports() APIExportClusterInformer {
 	return &aPIExportClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// APIExportConsumerSummaries returns a APIExportConsumerSummaryClusterInformer
+func (v *version) APIExportConsumerSummaries() APIExportConsumerSummaryClusterInformer {
+	return &aPIExportConsumerSummaryClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // APIExportEndpointSlices returns a APIExportEndpointSliceClusterInformer
 func (v *version) APIExportEndpointSlices() APIExportEndpointSliceClusterInformer {
 	return &aPIExportEndpointSliceClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -71,6 +78,8 @@ type Interface interface {
 	APIBindings() APIBindingInformer
 	// APIExports returns a APIExportInformer
 	APIExports() APIExportInformer
+	// APIExportConsumerSummaries returns a APIExportConsumerSummaryInformer
+	APIExportConsumerSummaries() APIExportConsumerSummaryInformer
 	// APIExportEndpointSlices returns a APIExportEndpointSliceInformer
 	APIExportEndpointSlices() APIExportEndpointSliceInformer
 	// APIResourceSchemas returns a APIResourceSchemaInformer
@@ -98,6 +107,11 @@ func (v *scopedVersion) APIExports() APIExportInformer {
 	return &aPIExportScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// APIExportConsumerSummaries returns a APIExportConsumerSummaryInformer
+func (v *scopedVersion) APIExportConsumerSummaries() APIExportConsumerSummaryInformer {
+	return &aPIExportConsumerSummaryScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // APIExportEndpointSlices returns a APIExportEndpointSliceInformer
 func (v *scopedVersion) APIExportEndpointSlices() APIExportEndpointSliceInformer {
 	return &aPIExportEndpointSliceScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
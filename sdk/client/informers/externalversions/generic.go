@@ -30,14 +30,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 
-	apiresourcev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apiresource/v1alpha1"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	corev1alpha1 "github.com/kcp-dev/kcp/pkg/apis/core/v1alpha1"
-	schedulingv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/scheduling/v1alpha1"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	tenancyv1beta1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1beta1"
-	topologyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/topology/v1alpha1"
-	workloadv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
+	apiresourcev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apiresource/v1alpha1"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	schedulingv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/scheduling/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	tenancyv1beta1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1beta1"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	workloadv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1"
 )
 
 type GenericClusterInformer interface {
@@ -103,6 +103,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIBindings().Informer()}, nil
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexports"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIExports().Informer()}, nil
+	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexportconsumersummaries"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIExportConsumerSummaries().Informer()}, nil
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexportendpointslices"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Apis().V1alpha1().APIExportEndpointSlices().Informer()}, nil
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiresourceschemas"):
@@ -118,8 +120,27 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case schedulingv1alpha1.SchemeGroupVersion.WithResource("placements"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Scheduling().V1alpha1().Placements().Informer()}, nil
 	// Group=tenancy.kcp.io, Version=V1alpha1
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("datajobs"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().DataJobs().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("limitincreaserequests"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().LimitIncreaseRequests().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("retentionpolicies"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().RetentionPolicies().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().ThrottlingExemptions().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("throttlingexemptions"):
+		informer := f.Tenancy().V1alpha1().ThrottlingExemptions().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacequotas"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceQuotas().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetemplates"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceTemplates().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetombstones"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceTombstones().Informer()}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceTypes().Informer()}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaceusages"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1alpha1().WorkspaceUsages().Informer()}, nil
 	// Group=tenancy.kcp.io, Version=V1beta1
 	case tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Tenancy().V1beta1().Workspaces().Informer()}, nil
@@ -154,6 +175,8 @@ func (f *sharedScopedInformerFactory) ForResource(resource schema.GroupVersionRe
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexports"):
 		informer := f.Apis().V1alpha1().APIExports().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexportconsumersummaries"):
+		informer := f.Apis().V1alpha1().APIExportConsumerSummaries().Informer()
 	case apisv1alpha1.SchemeGroupVersion.WithResource("apiexportendpointslices"):
 		informer := f.Apis().V1alpha1().APIExportEndpointSlices().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
@@ -175,9 +198,30 @@ func (f *sharedScopedInformerFactory) ForResource(resource schema.GroupVersionRe
 		informer := f.Scheduling().V1alpha1().Placements().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	// Group=tenancy.kcp.io, Version=V1alpha1
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("datajobs"):
+		informer := f.Tenancy().V1alpha1().DataJobs().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("limitincreaserequests"):
+		informer := f.Tenancy().V1alpha1().LimitIncreaseRequests().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("retentionpolicies"):
+		informer := f.Tenancy().V1alpha1().RetentionPolicies().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacequotas"):
+		informer := f.Tenancy().V1alpha1().WorkspaceQuotas().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetemplates"):
+		informer := f.Tenancy().V1alpha1().WorkspaceTemplates().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetombstones"):
+		informer := f.Tenancy().V1alpha1().WorkspaceTombstones().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspacetypes"):
 		informer := f.Tenancy().V1alpha1().WorkspaceTypes().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case tenancyv1alpha1.SchemeGroupVersion.WithResource("workspaceusages"):
+		informer := f.Tenancy().V1alpha1().WorkspaceUsages().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	// Group=tenancy.kcp.io, Version=V1beta1
 	case tenancyv1beta1.SchemeGroupVersion.WithResource("workspaces"):
 		informer := f.Tenancy().V1beta1().Workspaces().Informer()
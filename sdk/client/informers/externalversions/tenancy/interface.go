@@ -22,9 +22,9 @@ limitations under the License.
 package tenancy
 
 import (
-	"github.com/kcp-dev/kcp/pkg/client/informers/externalversions/internalinterfaces"
-	"github.com/kcp-dev/kcp/pkg/client/informers/externalversions/tenancy/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/client/informers/externalversions/tenancy/v1beta1"
+	"github.com/kcp-dev/kcp/sdk/client/informers/externalversions/internalinterfaces"
+	"github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/client/informers/externalversions/tenancy/v1beta1"
 )
 
 type ClusterInterface interface {
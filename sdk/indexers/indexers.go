@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexers
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+)
+
+const (
+	// ByLogicalClusterPath indexes by logical cluster path, if the annotation exists.
+	ByLogicalClusterPath = "ByLogicalClusterPath"
+	// ByLogicalClusterPathAndName indexes by logical cluster path and object name, if the annotation exists.
+	ByLogicalClusterPathAndName = "ByLogicalClusterPathAndName"
+)
+
+// IndexByLogicalClusterPath indexes by logical cluster path, if the annotation exists.
+func IndexByLogicalClusterPath(obj interface{}) ([]string, error) {
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be a metav1.Object, but is %T", obj)
+	}
+	if path, found := metaObj.GetAnnotations()[core.LogicalClusterPathAnnotationKey]; found {
+		return []string{
+			logicalcluster.NewPath(path).String(),
+			logicalcluster.From(metaObj).String(),
+		}, nil
+	}
+
+	return []string{logicalcluster.From(metaObj).String()}, nil
+}
+
+// IndexByLogicalClusterPathAndName indexes by logical cluster path and object name, if the annotation exists.
+func IndexByLogicalClusterPathAndName(obj interface{}) ([]string, error) {
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return []string{}, fmt.Errorf("obj is supposed to be a metav1.Object, but is %T", obj)
+	}
+	if path, found := metaObj.GetAnnotations()[core.LogicalClusterPathAnnotationKey]; found {
+		return []string{
+			logicalcluster.NewPath(path).Join(metaObj.GetName()).String(),
+			logicalcluster.From(metaObj).Path().Join(metaObj.GetName()).String(),
+		}, nil
+	}
+
+	return []string{logicalcluster.From(metaObj).String()}, nil
+}
+
+// ByIndex returns all instances of T that match indexValue in indexName in indexer.
+func ByIndex[T runtime.Object](indexer cache.Indexer, indexName, indexValue string) ([]T, error) {
+	list, err := indexer.ByIndex(indexName, indexValue)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]T, 0, len(list))
+	for _, o := range list {
+		ret = append(ret, o.(T))
+	}
+
+	return ret, nil
+}
+
+// ByPathAndName returns the instance of T from the indexer with the matching path and name. Path may be a canonical path
+// or a cluster name. Note: this depends on the presence of the optional "kcp.io/path" annotation.
+func ByPathAndName[T runtime.Object](groupResource schema.GroupResource, indexer cache.Indexer, path logicalcluster.Path, name string) (ret T, err error) {
+	objs, err := indexer.ByIndex(ByLogicalClusterPathAndName, path.Join(name).String())
+	if err != nil {
+		return ret, err
+	}
+	if len(objs) == 0 {
+		return ret, apierrors.NewNotFound(groupResource, path.Join(name).String())
+	}
+	if len(objs) > 1 {
+		return ret, fmt.Errorf("multiple %s found for %s", groupResource, path.Join(name).String())
+	}
+	return objs[0].(T), nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+)
+
+// Metrics receives the events of the committers of a Batcher, by the type of the committed
+// resource. The sdk does not depend on a metrics library; kcp implements Metrics with its registry.
+// The coalesce rate is Coalesced / (Patched + Coalesced).
+type Metrics interface {
+	// Patched is called for every patch sent.
+	Patched(resource string)
+	// Coalesced is called for every commit merged into the patch of another commit.
+	Coalesced(resource string)
+	// Throttled is called for every patch waiting for the concurrency limit.
+	Throttled(resource string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Patched(string)   {}
+func (noopMetrics) Coalesced(string) {}
+func (noopMetrics) Throttled(string) {}
+
+// Batcher batches and limits the patches of the committers created with WithBatcher. Its
+// settings can be changed while the committers are running. Batching and the concurrency
+// limit are both disabled initially.
+type Batcher struct {
+	lock    sync.RWMutex
+	window  time.Duration
+	limiter chan struct{}
+	metrics Metrics
+
+	batchesLock sync.Mutex
+	batches     map[batchKey]*batch
+}
+
+// NewBatcher returns a Batcher reporting to the given metrics, which may be nil.
+func NewBatcher(metrics Metrics) *Batcher {
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+	return &Batcher{
+		metrics: metrics,
+		batches: map[batchKey]*batch{},
+	}
+}
+
+// SetWindow sets how long a status patch waits for other status patches to the same object
+// to be merged into it, e.g. from other workers or controllers during mass events like shard
+// restarts. The commits of a batch share the result of the merged patch. Only patches based on
+// the same resourceVersion which change different top-level status fields are merged. Status
+// patches with different field managers, e.g. with status provenance, are not merged. 0
+// disables batching.
+func (b *Batcher) SetWindow(window time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.window = window
+}
+
+// SetConcurrencyLimit limits the number of patches in flight across the committers of the
+// batcher. Batches stay open for more commits while waiting for the limit. 0 means no limit.
+func (b *Batcher) SetConcurrencyLimit(limit int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if limit <= 0 {
+		b.limiter = nil
+		return
+	}
+	b.limiter = make(chan struct{}, limit)
+}
+
+func (b *Batcher) config() (time.Duration, chan struct{}, Metrics) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.window, b.limiter, b.metrics
+}
+
+// batchKey identifies the status patches that can be merged.
+type batchKey struct {
+	focusType       string
+	cluster         logicalcluster.Name
+	namespace       string
+	name            string
+	resourceVersion string
+	fieldManager    string
+}
+
+// batch is the pending status patch of one or more commits.
+type batch struct {
+	patch []byte
+	done  chan struct{}
+	err   error
+}
+
+// commit sends the patch through the concurrency limiter, merged with concurrent status patches
+// to the same object if batching is enabled. A nil Batcher sends the patch right away.
+func (b *Batcher) commit(ctx context.Context, key batchKey, patchBytes []byte, subresources []string, patch patchFunc) error {
+	if b == nil {
+		return patch(patchBytes, subresources)
+	}
+
+	window, sem, m := b.config()
+	resource := strings.TrimPrefix(key.focusType, "*")
+	if window <= 0 || len(subresources) == 0 {
+		return limited(ctx, sem, m, resource, func() error {
+			return patch(patchBytes, subresources)
+		})
+	}
+
+	b.batchesLock.Lock()
+	if pending, ok := b.batches[key]; ok {
+		if merged, ok := mergeStatusPatches(pending.patch, patchBytes); ok {
+			pending.patch = merged
+			b.batchesLock.Unlock()
+			m.Coalesced(resource)
+			select {
+			case <-pending.done:
+				return pending.err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		// not mergeable, send on its own
+		b.batchesLock.Unlock()
+		return limited(ctx, sem, m, resource, func() error {
+			return patch(patchBytes, subresources)
+		})
+	}
+	pending := &batch{patch: patchBytes, done: make(chan struct{})}
+	b.batches[key] = pending
+	b.batchesLock.Unlock()
+
+	defer close(pending.done)
+	closeBatch := func() []byte {
+		b.batchesLock.Lock()
+		defer b.batchesLock.Unlock()
+		if b.batches[key] == pending {
+			delete(b.batches, key)
+		}
+		return pending.patch
+	}
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		closeBatch()
+		pending.err = ctx.Err()
+		return pending.err
+	}
+
+	pending.err = limited(ctx, sem, m, resource, func() error {
+		return patch(closeBatch(), subresources)
+	})
+	closeBatch() // in case the limiter gave up
+	return pending.err
+}
+
+// mergeStatusPatches merges two status patches if they have the same preconditions and change
+// different top-level status fields. Other patches cannot be merged without losing writes:
+// only one of two different resourceVersion preconditions would survive, turning a conflict
+// into a success, and a JSON merge patch replaces arrays like status.conditions as a whole.
+func mergeStatusPatches(a, b []byte) ([]byte, bool) {
+	var patchA, patchB map[string]json.RawMessage
+	if err := json.Unmarshal(a, &patchA); err != nil {
+		return nil, false
+	}
+	if err := json.Unmarshal(b, &patchB); err != nil {
+		return nil, false
+	}
+	if len(patchA) != len(patchB) {
+		return nil, false
+	}
+	for k, v := range patchA {
+		if k == "status" {
+			continue
+		}
+		if other, found := patchB[k]; !found || !bytes.Equal(v, other) {
+			return nil, false
+		}
+	}
+
+	var statusA, statusB map[string]json.RawMessage
+	if err := json.Unmarshal(patchA["status"], &statusA); err != nil || statusA == nil {
+		return nil, false
+	}
+	if err := json.Unmarshal(patchB["status"], &statusB); err != nil || statusB == nil {
+		return nil, false
+	}
+	for k, v := range statusB {
+		if _, found := statusA[k]; found {
+			return nil, false
+		}
+		statusA[k] = v
+	}
+
+	status, err := json.Marshal(statusA)
+	if err != nil {
+		return nil, false
+	}
+	patchA["status"] = status
+	merged, err := json.Marshal(patchA)
+	if err != nil {
+		return nil, false
+	}
+	return merged, true
+}
+
+// limited calls fn when the concurrency limiter allows.
+func limited(ctx context.Context, sem chan struct{}, m Metrics, resource string, fn func() error) error {
+	if sem != nil {
+		select {
+		case sem <- struct{}{}:
+		default:
+			m.Throttled(resource)
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		defer func() { <-sem }()
+	}
+	m.Patched(resource)
+	return fn()
+}
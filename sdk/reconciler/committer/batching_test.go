@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	lock                          sync.Mutex
+	patched, coalesced, throttled int
+}
+
+func (m *testMetrics) Patched(string)   { m.lock.Lock(); m.patched++; m.lock.Unlock() }
+func (m *testMetrics) Coalesced(string) { m.lock.Lock(); m.coalesced++; m.lock.Unlock() }
+func (m *testMetrics) Throttled(string) { m.lock.Lock(); m.throttled++; m.lock.Unlock() }
+
+func waitForBatch(t *testing.T, b *Batcher, key batchKey) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		b.batchesLock.Lock()
+		defer b.batchesLock.Unlock()
+		_, ok := b.batches[key]
+		return ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestBatching(t *testing.T) {
+	m := &testMetrics{}
+	b := NewBatcher(m)
+	b.SetWindow(100 * time.Millisecond)
+
+	ctx := context.Background()
+	key := batchKey{focusType: "*v1alpha1.APIBinding", cluster: "root", name: "foo", resourceVersion: "1"}
+	status := []string{"status"}
+
+	var lock sync.Mutex
+	var patches []string
+	patchErr := errors.New("conflict")
+	patch := func(patchBytes []byte, subresources []string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		patches = append(patches, string(patchBytes))
+		return patchErr
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = b.commit(ctx, key, []byte(`{"metadata":{"resourceVersion":"1"},"status":{"a":1}}`), status, patch)
+	}()
+	waitForBatch(t, b, key)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[1] = b.commit(ctx, key, []byte(`{"metadata":{"resourceVersion":"1"},"status":{"b":2}}`), status, patch)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		// changes a field of the batch already, hence sent on its own to keep both writes
+		errs[2] = b.commit(ctx, key, []byte(`{"metadata":{"resourceVersion":"1"},"status":{"a":3}}`), status, patch)
+	}()
+
+	// spec patches and patches of other objects are not merged
+	require.ErrorIs(t, b.commit(ctx, key, []byte(`{"spec":{"c":4}}`), nil, patch), patchErr)
+	other := key
+	other.fieldManager = "kcp-provenance/kcp-apibinding/alpha/1"
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.ErrorIs(t, b.commit(ctx, other, []byte(`{"status":{"d":5}}`), status, patch), patchErr)
+	}()
+
+	wg.Wait()
+	for _, err := range errs {
+		require.ErrorIs(t, err, patchErr, "all commits must get the error of their patch")
+	}
+	require.ElementsMatch(t, []string{
+		`{"spec":{"c":4}}`,
+		`{"metadata":{"resourceVersion":"1"},"status":{"a":1,"b":2}}`,
+		`{"metadata":{"resourceVersion":"1"},"status":{"a":3}}`,
+		`{"status":{"d":5}}`,
+	}, patches)
+	require.Equal(t, 4, m.patched)
+	require.Equal(t, 1, m.coalesced)
+}
+
+func TestMergeStatusPatches(t *testing.T) {
+	tests := map[string]struct {
+		a, b      string
+		want      string
+		wantMerge bool
+	}{
+		"disjoint fields": {
+			a:         `{"metadata":{"resourceVersion":"1","uid":"u"},"status":{"phase":"Ready"}}`,
+			b:         `{"metadata":{"resourceVersion":"1","uid":"u"},"status":{"conditions":[{"type":"Ready"}]}}`,
+			want:      `{"metadata":{"resourceVersion":"1","uid":"u"},"status":{"conditions":[{"type":"Ready"}],"phase":"Ready"}}`,
+			wantMerge: true,
+		},
+		"same array": {
+			a: `{"metadata":{"resourceVersion":"1"},"status":{"conditions":[{"type":"A"}]}}`,
+			b: `{"metadata":{"resourceVersion":"1"},"status":{"conditions":[{"type":"B"}]}}`,
+		},
+		"nested fields of the same field": {
+			a: `{"metadata":{"resourceVersion":"1"},"status":{"summary":{"a":1}}}`,
+			b: `{"metadata":{"resourceVersion":"1"},"status":{"summary":{"b":2}}}`,
+		},
+		"different resourceVersions": {
+			a: `{"metadata":{"resourceVersion":"1"},"status":{"a":1}}`,
+			b: `{"metadata":{"resourceVersion":"2"},"status":{"b":2}}`,
+		},
+		"precondition missing in one": {
+			a: `{"metadata":{"resourceVersion":"1"},"status":{"a":1}}`,
+			b: `{"status":{"b":2}}`,
+		},
+		"status removed": {
+			a: `{"status":{"a":1}}`,
+			b: `{"status":null}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, merged := mergeStatusPatches([]byte(tt.a), []byte(tt.b))
+			require.Equal(t, tt.wantMerge, merged)
+			if tt.wantMerge {
+				require.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	m := &testMetrics{}
+	b := NewBatcher(m)
+	b.SetConcurrencyLimit(2)
+
+	var lock sync.Mutex
+	var inFlight, maxInFlight int
+	patch := func([]byte, []string) error {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, b.commit(context.Background(), batchKey{name: "foo"}, []byte(`{"spec":{}}`), nil, patch))
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 2, maxInFlight)
+	require.Equal(t, 6, m.patched)
+	require.Greater(t, m.throttled, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.SetConcurrencyLimit(1)
+	b.limiter <- struct{}{}
+	require.ErrorIs(t, b.commit(ctx, batchKey{name: "foo"}, []byte(`{"spec":{}}`), nil, patch), context.Canceled)
+}
+
+func TestNilBatcher(t *testing.T) {
+	var b *Batcher
+	called := false
+	require.NoError(t, b.commit(context.Background(), batchKey{name: "foo"}, []byte(`{"status":{}}`), []string{"status"}, func([]byte, []string) error {
+		called = true
+		return nil
+	}))
+	require.True(t, called)
+}
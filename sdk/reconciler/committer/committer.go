@@ -52,13 +52,37 @@ type Patcher[R runtime.Object] interface {
 // CommitFunc is an alias to clean up type declarations.
 type CommitFunc[Sp any, St any] func(context.Context, *Resource[Sp, St], *Resource[Sp, St]) error
 
+// Option configures a committer.
+type Option func(*committerOptions)
+
+type committerOptions struct {
+	batcher *Batcher
+}
+
+// WithBatcher makes the committer send its patches through the given batcher, which merges
+// concurrent status patches and limits the patches in flight of all committers using it.
+func WithBatcher(batcher *Batcher) Option {
+	return func(o *committerOptions) {
+		o.batcher = batcher
+	}
+}
+
+func newCommitterOptions(opts []Option) committerOptions {
+	var o committerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // NewCommitter returns a function that can patch instances of R based on meta,
 // spec or status changes using a cluster-aware patcher.
-func NewCommitter[R runtime.Object, P Patcher[R], Sp any, St any](patcher ClusterPatcher[R, P]) CommitFunc[Sp, St] {
+func NewCommitter[R runtime.Object, P Patcher[R], Sp any, St any](patcher ClusterPatcher[R, P], opts ...Option) CommitFunc[Sp, St] {
 	r := new(R)
 	focusType := fmt.Sprintf("%T", *r)
+	o := newCommitterOptions(opts)
 	return func(ctx context.Context, old, obj *Resource[Sp, St]) error {
-		return withPatchAndSubResources(ctx, focusType, old, obj,
+		return withPatchAndSubResources(ctx, o.batcher, focusType, old, obj, "",
 			func(patchBytes []byte, subresources []string) error {
 				clusterName := logicalcluster.From(old)
 				_, err := patcher.Cluster(clusterName.Path()).Patch(ctx, obj.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, subresources...)
@@ -69,11 +93,12 @@ func NewCommitter[R runtime.Object, P Patcher[R], Sp any, St any](patcher Cluste
 
 // NewCommitterScoped returns a function that can patch instances of R based on
 // meta, spec or status changes using a patcher scoped to a specific cluster.
-func NewCommitterScoped[R runtime.Object, P Patcher[R], Sp any, St any](patcher Patcher[R]) CommitFunc[Sp, St] {
+func NewCommitterScoped[R runtime.Object, P Patcher[R], Sp any, St any](patcher Patcher[R], opts ...Option) CommitFunc[Sp, St] {
 	r := new(R)
 	focusType := fmt.Sprintf("%T", *r)
+	o := newCommitterOptions(opts)
 	return func(ctx context.Context, old, obj *Resource[Sp, St]) error {
-		return withPatchAndSubResources(ctx, focusType, old, obj,
+		return withPatchAndSubResources(ctx, o.batcher, focusType, old, obj, "",
 			func(patchBytes []byte, subresources []string) error {
 				_, err := patcher.Patch(ctx, obj.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, subresources...)
 				return err
@@ -83,7 +108,9 @@ func NewCommitterScoped[R runtime.Object, P Patcher[R], Sp any, St any](patcher
 
 type patchFunc func([]byte, []string) error
 
-func withPatchAndSubResources[Sp any, St any](ctx context.Context, focusType string, old, obj *Resource[Sp, St], patch patchFunc) error {
+// withPatchAndSubResources patches the changes from old to obj. Status patches of the same
+// object with the same field manager may be merged by the batcher, see Batcher.SetWindow.
+func withPatchAndSubResources[Sp any, St any](ctx context.Context, batcher *Batcher, focusType string, old, obj *Resource[Sp, St], fieldManager string, patch patchFunc) error {
 	logger := klog.FromContext(ctx)
 	patchBytes, subresources, err := generatePatchAndSubResources(old, obj)
 	if err != nil {
@@ -95,7 +122,15 @@ func withPatchAndSubResources[Sp any, St any](ctx context.Context, focusType str
 	}
 
 	logger.V(2).Info(fmt.Sprintf("patching %s", focusType), "patch", string(patchBytes))
-	if err := patch(patchBytes, subresources); err != nil {
+	key := batchKey{
+		focusType:       focusType,
+		cluster:         logicalcluster.From(old),
+		namespace:       old.Namespace,
+		name:            old.Name,
+		resourceVersion: old.ResourceVersion,
+		fieldManager:    fieldManager,
+	}
+	if err := batcher.commit(ctx, key, patchBytes, subresources, patch); err != nil {
 		return fmt.Errorf("failed to patch %s %s: %w", focusType, old.Name, err)
 	}
 	return nil
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StatusProvenanceFieldManagerPrefix is the prefix of the field manager of status changes made
+// with status provenance. The field manager records the controller, shard and instance that made
+// the change, and the managed fields entry records the time. Hence, the provenance is written
+// atomically with the status change. It is only used if enabled with SetStatusProvenanceEnabled,
+// which kcp does according to the KCPStatusProvenance feature gate.
+const StatusProvenanceFieldManagerPrefix = "kcp-provenance/"
+
+// Provenance describes the controller instance that made a status change.
+type Provenance struct {
+	Controller string
+	Shard      string
+	Instance   string
+	Time       metav1.Time
+}
+
+var (
+	provenanceLock     sync.RWMutex
+	provenanceEnabled  bool
+	provenanceShard    string
+	provenanceInstance = defaultInstance()
+)
+
+func defaultInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// SetProvenanceShard sets the shard name recorded in the status provenance of this process.
+func SetProvenanceShard(shard string) {
+	provenanceLock.Lock()
+	defer provenanceLock.Unlock()
+	provenanceShard = shard
+}
+
+// SetStatusProvenanceEnabled enables or disables recording the status provenance in this process.
+func SetStatusProvenanceEnabled(enabled bool) {
+	provenanceLock.Lock()
+	defer provenanceLock.Unlock()
+	provenanceEnabled = enabled
+}
+
+// NewCommitterWithProvenance is like NewCommitter, but additionally records the provenance of
+// status changes in the field manager of the status patch if status provenance is enabled. Use
+// StatusProvenanceFrom to read it.
+func NewCommitterWithProvenance[R runtime.Object, P Patcher[R], Sp any, St any](patcher ClusterPatcher[R, P], controllerName string, opts ...Option) CommitFunc[Sp, St] {
+	r := new(R)
+	focusType := fmt.Sprintf("%T", *r)
+	o := newCommitterOptions(opts)
+	return func(ctx context.Context, old, obj *Resource[Sp, St]) error {
+		fieldManager := provenanceFieldManager(controllerName)
+		return withPatchAndSubResources(ctx, o.batcher, focusType, old, obj, fieldManager,
+			func(patchBytes []byte, subresources []string) error {
+				var opts metav1.PatchOptions
+				if len(subresources) > 0 {
+					opts.FieldManager = fieldManager
+				}
+				clusterName := logicalcluster.From(old)
+				_, err := patcher.Cluster(clusterName.Path()).Patch(ctx, obj.Name, types.MergePatchType, patchBytes, opts, subresources...)
+				return err
+			})
+	}
+}
+
+// provenanceFieldManager returns the field manager recording the provenance, or an empty string
+// if status provenance is disabled.
+func provenanceFieldManager(controllerName string) string {
+	provenanceLock.RLock()
+	defer provenanceLock.RUnlock()
+	if !provenanceEnabled {
+		return ""
+	}
+	return StatusProvenanceFieldManagerPrefix + strings.Join([]string{controllerName, provenanceShard, provenanceInstance}, "/")
+}
+
+// StatusProvenanceFrom returns the provenance of the most recent status change of obj that was
+// recorded with status provenance.
+func StatusProvenanceFrom(obj metav1.Object) (*Provenance, bool) {
+	var latest *Provenance
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "status" || entry.Time == nil || !strings.HasPrefix(entry.Manager, StatusProvenanceFieldManagerPrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(entry.Manager, StatusProvenanceFieldManagerPrefix), "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if latest == nil || latest.Time.Before(entry.Time) {
+			latest = &Provenance{Controller: parts[0], Shard: parts[1], Instance: parts[2], Time: *entry.Time}
+		}
+	}
+	return latest, latest != nil
+}
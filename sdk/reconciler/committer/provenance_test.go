@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatusProvenanceFrom(t *testing.T) {
+	SetStatusProvenanceEnabled(true)
+	defer SetStatusProvenanceEnabled(false)
+	SetProvenanceShard("alpha")
+	defer SetProvenanceShard("")
+
+	earlier := metav1.NewTime(time.Date(2022, 11, 1, 11, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2022, 11, 1, 13, 0, 0, 0, time.UTC))
+
+	obj := &metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{
+		{Manager: StatusProvenanceFieldManagerPrefix + "kcp-apibinding/beta/other-1", Subresource: "status", Time: &earlier},
+		{Manager: provenanceFieldManager("kcp-apibinding"), Subresource: "status", Time: &now},
+		{Manager: "kubectl", Subresource: "status", Time: &later},
+		{Manager: StatusProvenanceFieldManagerPrefix + "kcp-apibinding/beta/other-1", Time: &later},
+	}}
+
+	got, ok := StatusProvenanceFrom(obj)
+	require.True(t, ok)
+	require.Equal(t, &Provenance{
+		Controller: "kcp-apibinding",
+		Shard:      "alpha",
+		Instance:   provenanceInstance,
+		Time:       now,
+	}, got)
+
+	_, ok = StatusProvenanceFrom(&metav1.ObjectMeta{})
+	require.False(t, ok)
+}
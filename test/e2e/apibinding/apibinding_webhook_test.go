@@ -41,8 +41,8 @@ import (
 	"k8s.io/client-go/restmapper"
 
 	"github.com/kcp-dev/kcp/config/helpers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	webhookserver "github.com/kcp-dev/kcp/test/e2e/fixtures/webhook"
 	"github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest/v1alpha1"
 	wildwestclientset "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/client/clientset/versioned/cluster"
@@ -102,7 +102,7 @@ func TestAPIBindingMutatingWebhook(t *testing.T) {
 		Spec: apisv1alpha1.APIBindingSpec{
 			Reference: apisv1alpha1.BindingReference{
 				Export: &apisv1alpha1.ExportBindingReference{
-					Path: sourceClusterName.Path().String(),
+					Path: apisv1alpha1.NewLogicalClusterPath(sourceClusterName.Path()),
 					Name: cowboysAPIExport.Name,
 				},
 			},
@@ -252,7 +252,7 @@ func TestAPIBindingValidatingWebhook(t *testing.T) {
 		Spec: apisv1alpha1.APIBindingSpec{
 			Reference: apisv1alpha1.BindingReference{
 				Export: &apisv1alpha1.ExportBindingReference{
-					Path: sourceClusterName.Path().String(),
+					Path: apisv1alpha1.NewLogicalClusterPath(sourceClusterName.Path()),
 					Name: cowboysAPIExport.Name,
 				},
 			},
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakecache provides an in-process test double of the cache server. It allows to test
+// the local-vs-global lookup paths of controllers deterministically, without a multi-shard
+// deployment: objects are replicated explicitly on behalf of a shard, with a controllable
+// replication lag and injectable failures, and the global informers of the controllers under
+// test are served from the fake.
+package fakecache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kcptesting "github.com/kcp-dev/client-go/third_party/k8s.io/client-go/testing"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+	clientscheme "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/scheme"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// replication is a pending write of an object to the cache server.
+type replication struct {
+	shard   string
+	obj     runtime.Object
+	deleted bool
+	due     time.Time
+}
+
+// Server is an in-process fake of the cache server. Objects only become visible through its
+// client and informers once they are replicated, i.e. after the replication lag has passed on
+// the fake clock and no failure has been injected for the replication attempt.
+type Server struct {
+	client    *kcpfakeclient.ClusterClientset
+	informers kcpinformers.SharedInformerFactory
+	clock     *clocktesting.FakeClock
+
+	lock        sync.Mutex
+	lag         time.Duration
+	pending     []replication
+	failures    []error
+	unavailable error
+}
+
+// NewServer returns a fake cache server holding the given objects, which are considered
+// replicated already. Objects are expected to carry the logical cluster and shard annotations.
+func NewServer(objects ...runtime.Object) *Server {
+	s := &Server{
+		client: kcpfakeclient.NewSimpleClientset(objects...),
+		clock:  clocktesting.NewFakeClock(time.Now()),
+	}
+	s.informers = kcpinformers.NewSharedInformerFactory(s.client, 0)
+
+	s.client.PrependReactor("*", "*", func(action kcptesting.Action) (bool, runtime.Object, error) {
+		if err := s.unavailableErr(); err != nil {
+			return true, nil, err
+		}
+		return false, nil, nil
+	})
+	s.client.PrependWatchReactor("*", func(action kcptesting.Action) (bool, watch.Interface, error) {
+		if err := s.unavailableErr(); err != nil {
+			return true, nil, err
+		}
+		return false, nil, nil
+	})
+
+	return s
+}
+
+// Client returns the client of the cache server, i.e. a client returning replicated objects only.
+func (s *Server) Client() *kcpfakeclient.ClusterClientset {
+	return s.client
+}
+
+// InformerFactory returns the informer factory to get the global informers of controllers from.
+func (s *Server) InformerFactory() kcpinformers.SharedInformerFactory {
+	return s.informers
+}
+
+// Start starts the informers requested so far and waits for them to sync.
+func (s *Server) Start(ctx context.Context) error {
+	s.informers.Start(ctx.Done())
+	for typ, synced := range s.informers.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync cache informer for %v", typ)
+		}
+	}
+	return nil
+}
+
+// SetReplicationLag sets the time it takes for objects to be replicated from now on.
+func (s *Server) SetReplicationLag(lag time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lag = lag
+}
+
+// FailReplications makes the next replication attempts fail, one per given error. Failed
+// replications stay pending and are retried on the next Step or Flush.
+func (s *Server) FailReplications(errs ...error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failures = append(s.failures, errs...)
+}
+
+// SetUnavailable makes all requests to the cache server fail with the given error, including
+// the list and watch requests of informers, until it is called with nil.
+func (s *Server) SetUnavailable(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.unavailable = err
+}
+
+func (s *Server) unavailableErr() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.unavailable
+}
+
+// Replicate schedules the replication of the given object on behalf of the given shard. The
+// object is visible after the current replication lag has passed.
+func (s *Server) Replicate(shard string, obj runtime.Object) {
+	s.schedule(shard, obj, false)
+}
+
+// ReplicateDeletion schedules the deletion of the given object on behalf of the given shard.
+func (s *Server) ReplicateDeletion(shard string, obj runtime.Object) {
+	s.schedule(shard, obj, true)
+}
+
+func (s *Server) schedule(shard string, obj runtime.Object, deleted bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending = append(s.pending, replication{
+		shard:   shard,
+		obj:     obj.DeepCopyObject(),
+		deleted: deleted,
+		due:     s.clock.Now().Add(s.lag),
+	})
+}
+
+// Pending returns the number of replications that have not been applied yet.
+func (s *Server) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.pending)
+}
+
+// Step advances the fake clock by the given duration and applies the replications due by then,
+// in the order they were scheduled. It returns the errors of failed replication attempts.
+func (s *Server) Step(d time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clock.Step(d)
+	return s.apply(s.clock.Now())
+}
+
+// Flush applies all pending replications regardless of the replication lag. It returns the
+// errors of failed replication attempts.
+func (s *Server) Flush() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var latest time.Time
+	for _, r := range s.pending {
+		if r.due.After(latest) {
+			latest = r.due
+		}
+	}
+	return s.apply(latest)
+}
+
+func (s *Server) apply(now time.Time) error {
+	var errs []error
+	var remaining []replication
+	for _, r := range s.pending {
+		if r.due.After(now) {
+			remaining = append(remaining, r)
+			continue
+		}
+		if len(s.failures) > 0 {
+			errs = append(errs, s.failures[0])
+			s.failures = s.failures[1:]
+			remaining = append(remaining, r)
+			continue
+		}
+		if err := s.write(r); err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, r)
+		}
+	}
+	s.pending = remaining
+	return utilerrors.NewAggregate(errs)
+}
+
+// write stores or deletes a replicated object, annotated with its shard like the replication
+// controller does.
+func (s *Server) write(r replication) error {
+	obj, err := meta.Accessor(r.obj)
+	if err != nil {
+		return err
+	}
+	gvr, err := resourceFor(r.obj)
+	if err != nil {
+		return err
+	}
+	tracker := s.client.Tracker().Cluster(logicalcluster.From(obj).Path())
+
+	if r.deleted {
+		if err := tracker.Delete(gvr, obj.GetNamespace(), obj.GetName()); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[genericrequest.AnnotationKey] = r.shard
+	obj.SetAnnotations(annotations)
+
+	if err := tracker.Create(gvr, r.obj, obj.GetNamespace()); apierrors.IsAlreadyExists(err) {
+		return tracker.Update(gvr, r.obj, obj.GetNamespace())
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceFor(obj runtime.Object) (schema.GroupVersionResource, error) {
+	gvks, _, err := clientscheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvks[0])
+	return gvr, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakecache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func newAPIExport(cluster, name string) *apisv1alpha1.APIExport {
+	return &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{logicalcluster.AnnotationKey: cluster},
+		},
+	}
+}
+
+func TestReplicationLag(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := NewServer()
+	lister := s.InformerFactory().Apis().V1alpha1().APIExports().Lister()
+	require.NoError(t, s.Start(ctx))
+
+	s.SetReplicationLag(time.Minute)
+	s.Replicate("alpha", newAPIExport("root-org", "export"))
+
+	require.NoError(t, s.Step(30*time.Second))
+	require.Equal(t, 1, s.Pending())
+	_, err := s.Client().Cluster(logicalcluster.NewPath("root-org")).ApisV1alpha1().APIExports().Get(ctx, "export", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "object must not be replicated before the lag has passed")
+
+	require.NoError(t, s.Step(30*time.Second))
+	require.Equal(t, 0, s.Pending())
+	require.Eventually(t, func() bool {
+		export, err := lister.Cluster("root-org").Get("export")
+		return err == nil && export.Annotations[genericrequest.AnnotationKey] == "alpha"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "replicated object must be visible in the informer with its shard")
+
+	s.ReplicateDeletion("alpha", newAPIExport("root-org", "export"))
+	require.NoError(t, s.Flush())
+	require.Eventually(t, func() bool {
+		_, err := lister.Cluster("root-org").Get("export")
+		return apierrors.IsNotFound(err)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "deleted object must disappear from the informer")
+}
+
+func TestFailureInjection(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	s.FailReplications(errors.New("boom"))
+	s.Replicate("alpha", newAPIExport("root-org", "export"))
+
+	require.EqualError(t, s.Flush(), "boom")
+	require.Equal(t, 1, s.Pending(), "failed replication must be retried")
+
+	require.NoError(t, s.Flush())
+	require.Equal(t, 0, s.Pending())
+
+	s.SetUnavailable(apierrors.NewServiceUnavailable("cache server is down"))
+	_, err := s.Client().Cluster(logicalcluster.NewPath("root-org")).ApisV1alpha1().APIExports().Get(ctx, "export", metav1.GetOptions{})
+	require.True(t, apierrors.IsServiceUnavailable(err))
+
+	s.SetUnavailable(nil)
+	_, err = s.Client().Cluster(logicalcluster.NewPath("root-org")).ApisV1alpha1().APIExports().Get(ctx, "export", metav1.GetOptions{})
+	require.NoError(t, err)
+}
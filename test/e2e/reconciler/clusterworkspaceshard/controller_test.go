@@ -23,11 +23,14 @@ import (
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/stretchr/testify/require"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
-	corev1alpha1client "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/typed/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/core/v1alpha1"
 	"github.com/kcp-dev/kcp/test/e2e/framework"
 )
 
@@ -46,7 +49,46 @@ func TestWorkspaceShardController(t *testing.T) {
 		destructive bool
 		work        func(ctx context.Context, t *testing.T, server runningServer)
 	}{
-		// nothing for now
+		{
+			name: "a shard with IPv6 URLs is accepted and its URLs are defaulted",
+			work: func(ctx context.Context, t *testing.T, server runningServer) {
+				t.Helper()
+
+				t.Logf("Create a cordoned shard with an IPv6 base URL, such that no workspace is scheduled to it")
+				shard, err := server.rootShardClient.Create(ctx, &corev1alpha1.Shard{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "ipv6-"},
+					Spec: corev1alpha1.ShardSpec{
+						BaseURL:  "https://[2001:db8::1]:6443",
+						Cordoned: true,
+					},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create shard")
+				t.Cleanup(func() {
+					ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+					defer cancel()
+					err := server.rootShardClient.Delete(ctx, shard.Name, metav1.DeleteOptions{})
+					require.NoError(t, err, "failed to delete shard")
+				})
+
+				require.Equal(t, "https://[2001:db8::1]:6443", shard.Spec.ExternalURL)
+				require.Equal(t, "https://[2001:db8::1]:6443", shard.Spec.VirtualWorkspaceURL)
+			},
+		},
+		{
+			name: "a shard with an IPv6 URL without brackets is rejected",
+			work: func(ctx context.Context, t *testing.T, server runningServer) {
+				t.Helper()
+
+				_, err := server.rootShardClient.Create(ctx, &corev1alpha1.Shard{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "ipv6-"},
+					Spec: corev1alpha1.ShardSpec{
+						BaseURL:  "https://2001:db8::1:6443",
+						Cordoned: true,
+					},
+				}, metav1.CreateOptions{})
+				require.ErrorContains(t, err, "must be enclosed in brackets")
+			},
+		},
 	}
 
 	sharedServer := framework.SharedKcpServer(t)
@@ -28,10 +28,10 @@ import (
 	"k8s.io/client-go/restmapper"
 
 	"github.com/kcp-dev/kcp/config/helpers"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	"github.com/kcp-dev/kcp/test/e2e/framework"
 )
 
@@ -96,15 +96,15 @@ func TestWorkspaceTypesAPIBindingInitialization(t *testing.T) {
 			Name: "parent1",
 		},
 		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
-			DefaultAPIBindings: []tenancyv1alpha1.APIExportReference{
-				{
+			DefaultAPIBindings: []tenancyv1alpha1.DefaultAPIBinding{
+				{APIExportReference: tenancyv1alpha1.APIExportReference{
 					Path:   cowboysProviderPath.String(),
 					Export: cowboysAPIExport.Name,
-				},
-				{
+				}},
+				{APIExportReference: tenancyv1alpha1.APIExportReference{
 					Path:   "root",
 					Export: "scheduling.kcp.io",
-				},
+				}},
 			},
 		},
 	}
@@ -114,11 +114,11 @@ func TestWorkspaceTypesAPIBindingInitialization(t *testing.T) {
 			Name: "parent2",
 		},
 		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
-			DefaultAPIBindings: []tenancyv1alpha1.APIExportReference{
-				{
+			DefaultAPIBindings: []tenancyv1alpha1.DefaultAPIBinding{
+				{APIExportReference: tenancyv1alpha1.APIExportReference{
 					Path:   "root",
 					Export: "workload.kcp.io",
-				},
+				}},
 			},
 		},
 	}
@@ -128,11 +128,11 @@ func TestWorkspaceTypesAPIBindingInitialization(t *testing.T) {
 			Name: "test",
 		},
 		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
-			DefaultAPIBindings: []tenancyv1alpha1.APIExportReference{
-				{
+			DefaultAPIBindings: []tenancyv1alpha1.DefaultAPIBinding{
+				{APIExportReference: tenancyv1alpha1.APIExportReference{
 					Path:   "root",
 					Export: "shards.core.kcp.io",
-				},
+				}},
 			},
 			Extend: tenancyv1alpha1.WorkspaceTypeExtension{
 				With: []tenancyv1alpha1.WorkspaceTypeReference{
@@ -53,13 +53,13 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kcp-dev/kcp/config/helpers"
-	"github.com/kcp-dev/kcp/pkg/apis/apis"
-	apisv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/apis/v1alpha1"
-	"github.com/kcp-dev/kcp/pkg/apis/core"
-	"github.com/kcp-dev/kcp/pkg/apis/third_party/conditions/util/conditions"
-	kcpclientset "github.com/kcp-dev/kcp/pkg/client/clientset/versioned/cluster"
 	apiexportbuiltin "github.com/kcp-dev/kcp/pkg/virtual/apiexport/schemas/builtin"
 	"github.com/kcp-dev/kcp/pkg/virtual/framework/internalapis"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
 	"github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest"
 	wildwestv1alpha1 "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest/v1alpha1"
@@ -376,7 +376,7 @@ func TestAPIExportAPIBindingsAccess(t *testing.T) {
 		var binding apisv1alpha1.APIBinding
 		err := yaml.Unmarshal(bs, &binding)
 		require.NoError(t, err, "error unmarshaling binding")
-		binding.Spec.Reference.Export.Path = clusterName1.Path().String()
+		binding.Spec.Reference.Export.Path = apisv1alpha1.NewLogicalClusterPath(clusterName1.Path())
 		out, err := yaml.Marshal(&binding)
 		require.NoError(t, err, "error marshaling binding")
 		return out, nil
@@ -1033,7 +1033,7 @@ func bindConsumerToProvider(ctx context.Context, t *testing.T, consumerWorkspace
 		Spec: apisv1alpha1.APIBindingSpec{
 			Reference: apisv1alpha1.BindingReference{
 				Export: &apisv1alpha1.ExportBindingReference{
-					Path: providerClusterName.Path().String(),
+					Path: apisv1alpha1.NewLogicalClusterPath(providerClusterName.Path()),
 					Name: "today-cowboys",
 				},
 			},
@@ -26,9 +26,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
-	"github.com/kcp-dev/kcp/pkg/apis/workload/v1alpha1"
 	syncercontext "github.com/kcp-dev/kcp/pkg/virtual/syncer/context"
 	"github.com/kcp-dev/kcp/pkg/virtual/syncer/transformations"
+	"github.com/kcp-dev/kcp/sdk/apis/workload/v1alpha1"
 )
 
 // FilteredSyncerViewsChanged returns true if the syncer view fields changed between old and new